@@ -126,6 +126,10 @@ const defaultServerConfig: AdminConfig = {
         AllowedUntrustedInternalConnections: '',
         EnableMultifactorAuthentication: false,
         EnforceMultifactorAuthentication: false,
+        MultifactorAuthenticationMethods: ['totp'],
+        EnforcedMultifactorMethod: '',
+        EnableMfaRecoveryCodes: true,
+        EnablePasswordlessLogin: false,
         EnableUserAccessTokens: false,
         AllowCorsFrom: '',
         CorsExposedHeaders: '',
@@ -150,12 +154,15 @@ const defaultServerConfig: AdminConfig = {
         EnableCustomEmoji: true,
         EnableEmojiPicker: true,
         PostEditTimeLimit: -1,
+        PostEditHistoryLimit: 0,
         TimeBetweenUserTypingUpdatesMilliseconds: 5000,
         EnablePostSearch: true,
         EnableFileSearch: true,
         MinimumHashtagLength: 3,
         EnableUserTypingMessages: true,
         EnableChannelViewedMessages: true,
+        EnableReadReceipts: false,
+        ReadReceiptsMaxChannelMembers: 0,
         EnableUserStatuses: true,
         ExperimentalEnableAuthenticationTransfer: true,
         ClusterLogTimeoutMilliseconds: 2000,
@@ -244,6 +251,8 @@ const defaultServerConfig: AdminConfig = {
         AtRestEncryptKey: '',
         QueryTimeout: 30,
         DisableDatabaseSearch: false,
+        DatabaseSearchRanking: 'recency',
+        TextSearchConfigs: {},
         MigrationsStatementTimeoutSeconds: 100000,
         ReplicaLagSettings: [],
         ReplicaMonitorIntervalSeconds: 5,
@@ -319,6 +328,25 @@ const defaultServerConfig: AdminConfig = {
         AmazonS3Trace: false,
         AmazonS3RequestTimeoutMilliseconds: 30000,
         AmazonS3UploadPartSizeBytes: 5242880,
+        AzureStorageAccount: '',
+        AzureAccessKey: '',
+        AzureContainer: '',
+        AzurePathPrefix: '',
+        AzureEndpoint: '',
+        AzureRequestTimeoutMilliseconds: 30000,
+        AzurePresignExpiresSeconds: 21600,
+        GCSBucket: '',
+        GCSPathPrefix: '',
+        GCSCredentialsJSON: '',
+        GCSEndpoint: '',
+        GCSRequestTimeoutMilliseconds: 30000,
+        GCSPresignExpiresSeconds: 21600,
+        EnableEncryptionAtRest: false,
+        EncryptionAtRestMasterKey: '',
+        EncryptionAtRestRetiredKeys: [],
+        EnableAntivirusScan: false,
+        AntivirusScanURL: 'tcp://localhost:3310',
+        AntivirusScanTimeoutSeconds: 60,
         DedicatedExportStore: false,
         ExportDriverName: 'local',
         ExportDirectory: './data/',
@@ -369,6 +397,14 @@ const defaultServerConfig: AdminConfig = {
         LoginButtonColor: '#0000',
         LoginButtonBorderColor: '#2389D7',
         LoginButtonTextColor: '#2389D7',
+        EnableReplyByEmail: false,
+        ReplyByEmailAddress: '',
+        InboundEmailProtocol: 'smtp',
+        InboundEmailListenAddress: '127.0.0.1:10025',
+        InboundEmailMaxMessageSize: 26214400,
+        InboundEmailTrustedAuthServIds: [],
+        InboundEmailTrustedSources: [],
+        EnableWebPushNotifications: false,
     },
     RateLimitSettings: {
         Enable: false,
@@ -762,4 +798,13 @@ const defaultServerConfig: AdminConfig = {
         DisableSharedChannelsStatusSync: false,
         MaxPostsPerSync: 50,
     },
+    ScimSettings: {
+        Enable: false,
+        BearerToken: '',
+        UserAuthService: 'saml',
+    },
+    DataLossPreventionSettings: {
+        Enable: false,
+        Rules: [],
+    },
 };
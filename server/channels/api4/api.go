@@ -111,6 +111,24 @@ type Routes struct {
 
 	Reactions *mux.Router // 'api/v4/reactions'
 
+	Polls *mux.Router // 'api/v4/polls'
+	Poll  *mux.Router // 'api/v4/polls/{poll_id:[A-Za-z0-9]+}'
+
+	Reminders *mux.Router // 'api/v4/reminders'
+	Reminder  *mux.Router // 'api/v4/reminders/{reminder_id:[A-Za-z0-9]+}'
+
+	SavedSearches *mux.Router // 'api/v4/saved_searches'
+	SavedSearch   *mux.Router // 'api/v4/saved_searches/{saved_search_id:[A-Za-z0-9]+}'
+
+	LegalHolds *mux.Router // 'api/v4/legal_holds'
+	LegalHold  *mux.Router // 'api/v4/legal_holds/{legal_hold_id:[A-Za-z0-9]+}'
+
+	Scim       *mux.Router // 'api/v4/scim/v2'
+	ScimUsers  *mux.Router // 'api/v4/scim/v2/Users'
+	ScimUser   *mux.Router // 'api/v4/scim/v2/Users/{user_id:[A-Za-z0-9]+}'
+	ScimGroups *mux.Router // 'api/v4/scim/v2/Groups'
+	ScimGroup  *mux.Router // 'api/v4/scim/v2/Groups/{group_id:[A-Za-z0-9]+}'
+
 	Roles   *mux.Router // 'api/v4/roles'
 	Schemes *mux.Router // 'api/v4/schemes'
 
@@ -241,6 +259,19 @@ func Init(srv *app.Server) (*API, error) {
 	api.BaseRoutes.License = api.BaseRoutes.APIRoot.PathPrefix("/license").Subrouter()
 	api.BaseRoutes.Public = api.BaseRoutes.APIRoot.PathPrefix("/public").Subrouter()
 	api.BaseRoutes.Reactions = api.BaseRoutes.APIRoot.PathPrefix("/reactions").Subrouter()
+	api.BaseRoutes.Polls = api.BaseRoutes.APIRoot.PathPrefix("/polls").Subrouter()
+	api.BaseRoutes.Poll = api.BaseRoutes.Polls.PathPrefix("/{poll_id:[A-Za-z0-9]+}").Subrouter()
+	api.BaseRoutes.Reminders = api.BaseRoutes.APIRoot.PathPrefix("/reminders").Subrouter()
+	api.BaseRoutes.Reminder = api.BaseRoutes.Reminders.PathPrefix("/{reminder_id:[A-Za-z0-9]+}").Subrouter()
+	api.BaseRoutes.SavedSearches = api.BaseRoutes.APIRoot.PathPrefix("/saved_searches").Subrouter()
+	api.BaseRoutes.SavedSearch = api.BaseRoutes.SavedSearches.PathPrefix("/{saved_search_id:[A-Za-z0-9]+}").Subrouter()
+	api.BaseRoutes.LegalHolds = api.BaseRoutes.APIRoot.PathPrefix("/legal_holds").Subrouter()
+	api.BaseRoutes.LegalHold = api.BaseRoutes.LegalHolds.PathPrefix("/{legal_hold_id:[A-Za-z0-9]+}").Subrouter()
+	api.BaseRoutes.Scim = api.BaseRoutes.APIRoot.PathPrefix("/scim/v2").Subrouter()
+	api.BaseRoutes.ScimUsers = api.BaseRoutes.Scim.PathPrefix("/Users").Subrouter()
+	api.BaseRoutes.ScimUser = api.BaseRoutes.ScimUsers.PathPrefix("/{user_id:[A-Za-z0-9]+}").Subrouter()
+	api.BaseRoutes.ScimGroups = api.BaseRoutes.Scim.PathPrefix("/Groups").Subrouter()
+	api.BaseRoutes.ScimGroup = api.BaseRoutes.ScimGroups.PathPrefix("/{group_id:[A-Za-z0-9]+}").Subrouter()
 	api.BaseRoutes.Jobs = api.BaseRoutes.APIRoot.PathPrefix("/jobs").Subrouter()
 	api.BaseRoutes.Elasticsearch = api.BaseRoutes.APIRoot.PathPrefix("/elasticsearch").Subrouter()
 	api.BaseRoutes.Bleve = api.BaseRoutes.APIRoot.PathPrefix("/bleve").Subrouter()
@@ -331,6 +362,14 @@ func Init(srv *app.Server) (*API, error) {
 	api.InitUsage()
 	api.InitHostedCustomer()
 	api.InitDrafts()
+	api.InitScheduledPost()
+	api.InitPoll()
+	api.InitReminder()
+	api.InitSavedSearch()
+	api.InitLegalHold()
+	api.InitWebAuthn()
+	api.InitWebPush()
+	api.InitScim()
 	api.InitIPFiltering()
 	api.InitChannelBookmarks()
 	api.InitReports()
@@ -27,6 +27,8 @@ func (api *API) InitCommand() {
 	api.BaseRoutes.Team.Handle("/commands/autocomplete", api.APISessionRequired(listAutocompleteCommands)).Methods(http.MethodGet)
 	api.BaseRoutes.Team.Handle("/commands/autocomplete_suggestions", api.APISessionRequired(listCommandAutocompleteSuggestions)).Methods(http.MethodGet)
 	api.BaseRoutes.Command.Handle("/regen_token", api.APISessionRequired(regenCommandToken)).Methods(http.MethodPut)
+	api.BaseRoutes.Command.Handle("/signing_secret/rotate", api.APISessionRequired(rotateCommandSigningSecret)).Methods(http.MethodPost)
+	api.BaseRoutes.Command.Handle("/signing_secret", api.APISessionRequired(removeCommandSigningSecret)).Methods(http.MethodDelete)
 }
 
 func createCommand(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -484,3 +486,54 @@ func regenCommandToken(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	w.Write([]byte(model.MapToJSON(resp)))
 }
+
+func rotateCommandSigningSecret(c *Context, w http.ResponseWriter, r *http.Request) {
+	updateCommandSigningSecret(c, w, "rotateCommandSigningSecret", c.App.RotateCommandSigningSecret)
+}
+
+func removeCommandSigningSecret(c *Context, w http.ResponseWriter, r *http.Request) {
+	updateCommandSigningSecret(c, w, "removeCommandSigningSecret", c.App.RemoveCommandSigningSecret)
+}
+
+func updateCommandSigningSecret(c *Context, w http.ResponseWriter, event string, update func(*model.Command) (*model.Command, *model.AppError)) {
+	c.RequireCommandId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(event, audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "command_id", c.Params.CommandId)
+
+	cmd, err := c.App.GetCommand(c.Params.CommandId)
+	if err != nil {
+		c.SetCommandNotFoundError()
+		return
+	}
+	auditRec.AddEventPriorState(cmd)
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), cmd.TeamId, model.PermissionManageSlashCommands) {
+		// here we return Not_found instead of a permissions error so we don't leak the existence of
+		// a command to someone without permissions for the team it belongs to.
+		c.SetCommandNotFoundError()
+		return
+	}
+
+	if c.AppContext.Session().UserId != cmd.CreatorId && !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), cmd.TeamId, model.PermissionManageOthersSlashCommands) {
+		c.SetPermissionError(model.PermissionManageOthersSlashCommands)
+		return
+	}
+
+	rcmd, err := update(cmd)
+	if err != nil {
+		c.Err = err
+		return
+	}
+	auditRec.AddEventResultState(rcmd)
+	auditRec.AddEventObjectType("command")
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(rcmd); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
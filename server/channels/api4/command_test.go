@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -625,6 +626,52 @@ func TestRegenToken(t *testing.T) {
 	require.Empty(t, token, "should not return the token")
 }
 
+func TestRotateCommandSigningSecret(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCommands = true })
+
+	newCmd := &model.Command{
+		CreatorId: th.BasicUser.Id,
+		TeamId:    th.BasicTeam.Id,
+		URL:       "http://nowhere.com",
+		Method:    model.CommandMethodPost,
+		Trigger:   "trigger"}
+
+	createdCmd, _, err := th.SystemAdminClient.CreateCommand(context.Background(), newCmd)
+	require.NoError(t, err)
+	require.Empty(t, createdCmd.SigningSecret)
+
+	rotatedCmd, _, err := th.SystemAdminClient.RotateCommandSigningSecret(context.Background(), createdCmd.Id)
+	require.NoError(t, err)
+	require.NotEmpty(t, rotatedCmd.SigningSecret)
+	require.Zero(t, rotatedCmd.PreviousSigningSecretExpireAt)
+
+	rotatedAgainCmd, _, err := th.SystemAdminClient.RotateCommandSigningSecret(context.Background(), createdCmd.Id)
+	require.NoError(t, err)
+	require.NotEqual(t, rotatedCmd.SigningSecret, rotatedAgainCmd.SigningSecret)
+	require.Greater(t, rotatedAgainCmd.PreviousSigningSecretExpireAt, model.GetMillis())
+
+	fetchedCmd, appErr := th.App.GetCommand(createdCmd.Id)
+	require.Nil(t, appErr)
+	require.Equal(t, []string{rotatedAgainCmd.SigningSecret, rotatedCmd.SigningSecret}, fetchedCmd.SigningSecrets())
+
+	_, resp, err := client.RotateCommandSigningSecret(context.Background(), createdCmd.Id)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+
+	_, resp, err = client.RemoveCommandSigningSecret(context.Background(), createdCmd.Id)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+
+	removedCmd, _, err := th.SystemAdminClient.RemoveCommandSigningSecret(context.Background(), createdCmd.Id)
+	require.NoError(t, err)
+	require.Empty(t, removedCmd.SigningSecret)
+	require.Zero(t, removedCmd.PreviousSigningSecretExpireAt)
+}
+
 func TestExecuteInvalidCommand(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -817,6 +864,59 @@ func TestExecutePostCommand(t *testing.T) {
 	require.Equal(t, expectedCommandResponse, commandResponse)
 }
 
+func TestExecuteSignedCommand(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+	channel := th.BasicChannel
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableCommands = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "127.0.0.0/8"
+	})
+
+	var signingSecret atomic.Value
+	signingSecret.Store("")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := model.VerifyIntegrationRequest(r, signingSecret.Load().(string), model.IntegrationSignatureDefaultTolerance); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(&model.CommandResponse{Text: "signed " + r.FormValue("text")}); err != nil {
+			th.TestLogger.Warn("Error while writing response", mlog.Err(err))
+		}
+	}))
+	defer ts.Close()
+
+	for _, method := range []string{model.CommandMethodPost, model.CommandMethodGet} {
+		t.Run(method, func(t *testing.T) {
+			cmd, appErr := th.App.CreateCommand(&model.Command{
+				CreatorId: th.BasicUser.Id,
+				TeamId:    th.BasicTeam.Id,
+				URL:       ts.URL + "?existing=param",
+				Method:    method,
+				Trigger:   "signed" + strings.ToLower(method),
+			})
+			require.Nil(t, appErr)
+
+			_, resp, err := client.ExecuteCommand(context.Background(), channel.Id, "/"+cmd.Trigger+" hello")
+			require.Error(t, err, "unsigned requests should be rejected")
+			CheckInternalErrorStatus(t, resp)
+
+			cmd, appErr = th.App.RotateCommandSigningSecret(cmd)
+			require.Nil(t, appErr)
+			signingSecret.Store(cmd.SigningSecret)
+
+			commandResponse, _, err := client.ExecuteCommand(context.Background(), channel.Id, "/"+cmd.Trigger+" hello")
+			require.NoError(t, err)
+			require.Equal(t, "signed hello", commandResponse.Text)
+		})
+	}
+}
+
 func TestExecuteCommandAgainstChannelOnAnotherTeam(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
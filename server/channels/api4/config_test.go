@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -867,3 +868,142 @@ func TestMigrateConfig(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestConfigHistory(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+	client := th.Client
+	versionID := model.NewId()
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp, err := client.GetConfigHistory(context.Background(), 0, 10)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.GetConfigVersionDiff(context.Background(), versionID, "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.RollbackConfig(context.Background(), versionID)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		_, resp, err := client.GetConfigVersionDiff(context.Background(), versionID, "invalid")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		// The test servers keep their configuration in memory, without history.
+		_, resp, err = client.GetConfigHistory(context.Background(), 0, 10)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+
+		_, resp, err = client.GetConfigVersionDiff(context.Background(), versionID, "")
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+
+		_, resp, err = client.RollbackConfig(context.Background(), versionID)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	}, "as system admin and local mode")
+
+	t.Run("rollback as restricted system admin", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RestrictSystemAdmin = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RestrictSystemAdmin = false })
+
+		_, resp, err := th.SystemAdminClient.RollbackConfig(context.Background(), versionID)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestConfigChangeAuthor(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	session := &model.Session{
+		Id:     model.NewId(),
+		UserId: model.NewId(),
+	}
+	c := &Context{}
+	c.AppContext = th.Context.WithSession(session)
+
+	t.Run("api", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPut, "/api/v4/config", nil)
+
+		author := configChangeAuthor(c, r)
+		assert.Equal(t, &model.ConfigChangeAuthor{
+			UserId:    session.UserId,
+			SessionId: session.Id,
+			Source:    model.ConfigChangeSourceAPI,
+		}, author)
+	})
+
+	t.Run("system console", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPut, "/api/v4/config", nil)
+		r.Header.Set(model.HeaderRequestedWith, model.HeaderRequestedWithXML)
+
+		author := configChangeAuthor(c, r)
+		assert.Equal(t, model.ConfigChangeSourceSystemConsole, author.Source)
+		assert.Equal(t, session.UserId, author.UserId)
+	})
+
+	t.Run("mmctl user agent", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPut, "/api/v4/config", nil)
+		r.Header.Set("User-Agent", "mmctl/10.0.0 (linux)")
+
+		author := configChangeAuthor(c, r)
+		assert.Equal(t, model.ConfigChangeSourceAPI, author.Source)
+		assert.Equal(t, session.Id, author.SessionId)
+	})
+
+	t.Run("mmctl in local mode", func(t *testing.T) {
+		localContext := &Context{}
+		localContext.AppContext = th.Context.WithSession(&model.Session{Local: true})
+		r := httptest.NewRequest(http.MethodPut, "/api/v4/config", nil)
+
+		author := configChangeAuthor(localContext, r)
+		assert.Equal(t, &model.ConfigChangeAuthor{Source: model.ConfigChangeSourceMmctl}, author)
+	})
+}
+
+func TestPrepareConfigUpdate(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	session, appErr := th.App.CreateSession(th.Context, &model.Session{UserId: th.SystemAdminUser.Id, Roles: model.SystemAdminRoleId + " " + model.SystemUserRoleId})
+	require.Nil(t, appErr)
+	c := &Context{}
+	c.App = th.App
+	c.AppContext = th.Context.WithSession(session)
+	c.Logger = th.App.Srv().Log()
+
+	appCfg := th.App.Config()
+
+	t.Run("keeps the settings that cannot be changed through the API", func(t *testing.T) {
+		c.Err = nil
+		cfg := appCfg.Clone()
+		*cfg.PluginSettings.EnableUploads = !*appCfg.PluginSettings.EnableUploads
+		cfg.PluginSettings.SignaturePublicKeyFiles = []string{"rogue.key"}
+		*cfg.TeamSettings.SiteName = "Rolled back"
+
+		cfg = prepareConfigUpdate(c, "rollbackConfig", cfg)
+		require.Nil(t, c.Err)
+		assert.Equal(t, *appCfg.PluginSettings.EnableUploads, *cfg.PluginSettings.EnableUploads)
+		assert.Equal(t, appCfg.PluginSettings.SignaturePublicKeyFiles, cfg.PluginSettings.SignaturePublicKeyFiles)
+		assert.Equal(t, "Rolled back", *cfg.TeamSettings.SiteName)
+	})
+
+	t.Run("refuses to clear the site URL", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.SiteURL = "http://localhost:8065" })
+
+		c.Err = nil
+		cfg := th.App.Config().Clone()
+		*cfg.ServiceSettings.SiteURL = ""
+
+		assert.Nil(t, prepareConfigUpdate(c, "rollbackConfig", cfg))
+		require.NotNil(t, c.Err)
+		assert.Equal(t, "api.config.update_config.clear_siteurl.app_error", c.Err.Id)
+	})
+}
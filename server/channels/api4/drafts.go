@@ -36,18 +36,7 @@ func upsertDraft(c *Context, w http.ResponseWriter, r *http.Request) {
 	draft.UserId = c.AppContext.Session().UserId
 	connectionID := r.Header.Get(model.ConnectionId)
 
-	hasPermission := false
-
-	if c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), draft.ChannelId, model.PermissionCreatePost) {
-		hasPermission = true
-	} else if channel, err := c.App.GetChannel(c.AppContext, draft.ChannelId); err == nil {
-		// Temporary permission check method until advanced permissions, please do not copy
-		if channel.Type == model.ChannelTypeOpen && c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), channel.TeamId, model.PermissionCreatePostPublic) {
-			hasPermission = true
-		}
-	}
-
-	if !hasPermission {
+	if !hasPermissionToCreatePost(c, draft.ChannelId) {
 		c.SetPermissionError(model.PermissionCreatePost)
 		return
 	}
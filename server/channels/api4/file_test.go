@@ -4,13 +4,16 @@
 package api4
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"crypto/rand"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net"
 	"net/http"
 	"net/textproto"
 	"net/url"
@@ -806,6 +809,132 @@ func TestGetFile(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestGetFileQuarantined(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+	channel := th.BasicChannel
+
+	if *th.App.Config().FileSettings.DriverName == "" {
+		t.Skip("skipping because no file driver is enabled")
+	}
+
+	// A clamd stand-in reporting every stream containing "infected" as such.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				if _, err := reader.ReadString(0); err != nil {
+					return
+				}
+				var data []byte
+				for {
+					var size uint32
+					if err := binary.Read(reader, binary.BigEndian, &size); err != nil || size == 0 {
+						break
+					}
+					chunk := make([]byte, size)
+					if _, err := io.ReadFull(reader, chunk); err != nil {
+						return
+					}
+					data = append(data, chunk...)
+				}
+				reply := "stream: OK\x00"
+				if bytes.Contains(data, []byte("infected")) {
+					reply = "stream: Test.Signature FOUND\x00"
+				}
+				conn.Write([]byte(reply))
+			}()
+		}
+	}()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.FileSettings.EnableAntivirusScan = true
+		*cfg.FileSettings.AntivirusScanURL = "tcp://" + listener.Addr().String()
+	})
+
+	waitForScanStatus := func(t *testing.T, fileID, status string) {
+		t.Helper()
+		require.Eventually(t, func() bool {
+			info, _, err := th.SystemAdminClient.GetFileInfo(context.Background(), fileID)
+			return err == nil && info.ScanStatus == status
+		}, 10*time.Second, 100*time.Millisecond)
+	}
+
+	t.Run("clean file", func(t *testing.T) {
+		fileResp, _, err := client.UploadFile(context.Background(), []byte("clean data"), channel.Id, "clean.txt")
+		require.NoError(t, err)
+		fileID := fileResp.FileInfos[0].Id
+
+		waitForScanStatus(t, fileID, model.FileScanStatusClean)
+
+		data, _, err := client.GetFile(context.Background(), fileID)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("clean data"), data)
+	})
+
+	t.Run("infected file", func(t *testing.T) {
+		fileResp, _, err := client.UploadFile(context.Background(), []byte("infected data"), channel.Id, "infected.txt")
+		require.NoError(t, err)
+		fileID := fileResp.FileInfos[0].Id
+
+		waitForScanStatus(t, fileID, model.FileScanStatusInfected)
+
+		_, resp, err := client.GetFile(context.Background(), fileID)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.GetFile(context.Background(), fileID)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		systemBot, appErr := th.App.GetSystemBot(th.Context)
+		require.Nil(t, appErr)
+		dm, appErr := th.App.GetOrCreateDirectChannel(th.Context, systemBot.UserId, th.SystemAdminUser.Id)
+		require.Nil(t, appErr)
+		countNotifications := func() int {
+			posts, appErr := th.App.GetPosts(dm.Id, 0, 100)
+			require.Nil(t, appErr)
+			return len(posts.Order)
+		}
+		require.Eventually(t, func() bool { return countNotifications() > 0 }, 10*time.Second, 100*time.Millisecond)
+		notifications := countNotifications()
+
+		// Rescanning a file already known to be infected doesn't notify the
+		// admins again.
+		info, appErr := th.App.GetFileInfo(th.Context, fileID)
+		require.Nil(t, appErr)
+		status, appErr := th.App.ScanFile(th.Context, info)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.FileScanStatusInfected, status)
+		assert.Equal(t, notifications, countNotifications())
+	})
+
+	t.Run("unreachable scanner", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.FileSettings.AntivirusScanURL = "unix://" + filepath.Join(t.TempDir(), "missing.sock")
+		})
+
+		fileResp, _, err := client.UploadFile(context.Background(), []byte("clean data"), channel.Id, "clean.txt")
+		require.NoError(t, err)
+		fileID := fileResp.FileInfos[0].Id
+
+		waitForScanStatus(t, fileID, model.FileScanStatusFailed)
+
+		_, resp, err := client.GetFile(context.Background(), fileID)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestGetFileHeaders(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
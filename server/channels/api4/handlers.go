@@ -109,6 +109,29 @@ func (api *API) RemoteClusterTokenRequired(h handlerFunc, opts ...APIHandlerOpti
 	return handler
 }
 
+// ScimTokenRequired provides a handler for identity providers provisioning
+// users and groups through the /scim/v2 endpoints.
+func (api *API) ScimTokenRequired(h handlerFunc, opts ...APIHandlerOption) http.Handler {
+	handler := &web.Handler{
+		Srv:              api.srv,
+		HandleFunc:       h,
+		HandlerName:      web.GetHandlerName(h),
+		RequireSession:   false,
+		RequireCloudKey:  false,
+		RequireScimToken: true,
+		TrustRequester:   false,
+		RequireMfa:       false,
+		IsStatic:         false,
+		IsLocal:          false,
+	}
+	setHandlerOpts(handler, opts...)
+
+	if *api.srv.Config().ServiceSettings.WebserverMode == "gzip" {
+		return gzhttp.GzipHandler(handler)
+	}
+	return handler
+}
+
 // APISessionRequiredMfa provides a handler for API endpoints which require a logged-in user session  but when accessed,
 // if MFA is enabled, the MFA process is not yet complete, and therefore the requirement to have completed the MFA
 // authentication must be waived.
@@ -4,6 +4,7 @@
 package api4
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"path"
@@ -110,6 +111,48 @@ func TestListImports(t *testing.T) {
 	}, "change import directory")
 }
 
+func TestGetImportValidationReport(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	job := &model.Job{
+		Id:     model.NewId(),
+		Type:   model.JobTypeImportProcess,
+		Status: model.JobStatusSuccess,
+		Data:   map[string]string{"dry_run": "true", "is_downloadable": "true"},
+	}
+	_, err := th.App.Srv().Store().Job().Save(job)
+	require.NoError(t, err)
+	defer th.App.Srv().Store().Job().Delete(job.Id)
+
+	report := model.NewImportValidationReport()
+	report.JobId = job.Id
+	report.TotalLines = 2
+	report.AddIssue("post", &model.ImportValidationIssue{Line: 2, ErrorId: "app.import.validate.unknown_user.error", Message: "Reference to unknown user someone."})
+	_, appErr := th.App.WriteFile(bytes.NewReader(model.ToJSON(report)), model.ImportValidationReportPath(job.Id))
+	require.Nil(t, appErr)
+	defer th.App.RemoveFile(model.ImportValidationReportPath(job.Id))
+
+	t.Run("no permissions", func(t *testing.T) {
+		_, resp, err := th.Client.GetImportValidationReport(context.Background(), job.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, c *model.Client4) {
+		fetched, _, err := c.GetImportValidationReport(context.Background(), job.Id)
+		require.NoError(t, err)
+		require.Equal(t, 2, fetched.TotalLines)
+		require.Equal(t, report.Issues, fetched.Issues)
+	}, "report")
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, c *model.Client4) {
+		_, resp, err := c.GetImportValidationReport(context.Background(), model.NewId())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	}, "unknown job")
+}
+
 func TestImportInLocalMode(t *testing.T) {
 	th := SetupWithServerOptions(t, []app.Option{app.RunEssentialJobs})
 	defer th.TearDown()
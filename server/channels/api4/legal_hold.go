@@ -0,0 +1,207 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+)
+
+func (api *API) InitLegalHold() {
+	api.BaseRoutes.LegalHolds.Handle("", api.APISessionRequired(getLegalHolds)).Methods(http.MethodGet)
+	api.BaseRoutes.LegalHolds.Handle("", api.APISessionRequired(createLegalHold)).Methods(http.MethodPost)
+	api.BaseRoutes.LegalHold.Handle("", api.APISessionRequired(getLegalHold)).Methods(http.MethodGet)
+	api.BaseRoutes.LegalHold.Handle("", api.APISessionRequired(releaseLegalHold)).Methods(http.MethodDelete)
+	api.BaseRoutes.LegalHold.Handle("/patch", api.APISessionRequired(patchLegalHold)).Methods(http.MethodPut)
+	api.BaseRoutes.LegalHold.Handle("/export", api.APISessionRequired(exportLegalHold)).Methods(http.MethodPost)
+}
+
+func getLegalHolds(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadComplianceDataRetentionPolicy) {
+		c.SetPermissionError(model.PermissionSysconsoleReadComplianceDataRetentionPolicy)
+		return
+	}
+
+	includeReleased, _ := strconv.ParseBool(r.URL.Query().Get("include_released"))
+	holds, appErr := c.App.GetLegalHolds(c.Params.Page, c.Params.PerPage, includeReleased)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(holds); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getLegalHold(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireLegalHoldId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadComplianceDataRetentionPolicy) {
+		c.SetPermissionError(model.PermissionSysconsoleReadComplianceDataRetentionPolicy)
+		return
+	}
+
+	hold, appErr := c.App.GetLegalHold(c.Params.LegalHoldId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(hold); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func createLegalHold(c *Context, w http.ResponseWriter, r *http.Request) {
+	var hold model.LegalHold
+	if jsonErr := json.NewDecoder(r.Body).Decode(&hold); jsonErr != nil {
+		c.SetInvalidParamWithErr("legal_hold", jsonErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createLegalHold", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameterAuditable(auditRec, "legal_hold", &hold)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteComplianceDataRetentionPolicy) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteComplianceDataRetentionPolicy)
+		return
+	}
+
+	hold.Id = ""
+	savedHold, appErr := c.App.CreateLegalHold(c.AppContext, &hold)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(savedHold)
+	auditRec.AddEventObjectType("legal_hold")
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(savedHold); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func patchLegalHold(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireLegalHoldId()
+	if c.Err != nil {
+		return
+	}
+
+	var patch model.LegalHoldPatch
+	if jsonErr := json.NewDecoder(r.Body).Decode(&patch); jsonErr != nil {
+		c.SetInvalidParamWithErr("legal_hold", jsonErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("patchLegalHold", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "legal_hold_id", c.Params.LegalHoldId)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteComplianceDataRetentionPolicy) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteComplianceDataRetentionPolicy)
+		return
+	}
+
+	hold, appErr := c.App.GetLegalHold(c.Params.LegalHoldId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	auditRec.AddEventPriorState(hold)
+
+	updatedHold, appErr := c.App.PatchLegalHold(c.AppContext, c.Params.LegalHoldId, &patch)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(updatedHold)
+	auditRec.AddEventObjectType("legal_hold")
+
+	if err := json.NewEncoder(w).Encode(updatedHold); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func releaseLegalHold(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireLegalHoldId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("releaseLegalHold", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "legal_hold_id", c.Params.LegalHoldId)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteComplianceDataRetentionPolicy) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteComplianceDataRetentionPolicy)
+		return
+	}
+
+	hold, appErr := c.App.ReleaseLegalHold(c.Params.LegalHoldId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(hold)
+	auditRec.AddEventObjectType("legal_hold")
+
+	if err := json.NewEncoder(w).Encode(hold); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func exportLegalHold(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireLegalHoldId()
+	if c.Err != nil {
+		return
+	}
+
+	var exportRequest model.LegalHoldExportRequest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&exportRequest); jsonErr != nil {
+		c.SetInvalidParamWithErr("export_type", jsonErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("exportLegalHold", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "legal_hold_id", c.Params.LegalHoldId)
+	audit.AddEventParameter(auditRec, "export_type", exportRequest.ExportType)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionCreateComplianceExportJob) {
+		c.SetPermissionError(model.PermissionCreateComplianceExportJob)
+		return
+	}
+
+	job, appErr := c.App.CreateLegalHoldExportJob(c.AppContext, c.Params.LegalHoldId, exportRequest.ExportType)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(job)
+	auditRec.AddEventObjectType("job")
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestLegalHolds(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableAPIChannelDeletion = true
+		*cfg.ServiceSettings.EnableAPIUserDeletion = true
+	})
+
+	heldChannel := th.CreatePublicChannel()
+	hold := &model.LegalHold{
+		Name:       "Smith v. Example",
+		UserIds:    []string{th.BasicUser2.Id},
+		ChannelIds: []string{heldChannel.Id},
+	}
+
+	t.Run("create requires permission", func(t *testing.T) {
+		_, resp, err := th.Client.CreateLegalHold(context.Background(), hold)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("create with an unknown user", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.CreateLegalHold(context.Background(), &model.LegalHold{
+			Name:    "Unknown",
+			UserIds: []string{model.NewId()},
+		})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	savedHold, resp, err := th.SystemAdminClient.CreateLegalHold(context.Background(), hold)
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+	require.NotEmpty(t, savedHold.Id)
+
+	t.Run("get and list", func(t *testing.T) {
+		fetched, resp, err := th.SystemAdminClient.GetLegalHold(context.Background(), savedHold.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		assert.Equal(t, savedHold, fetched)
+
+		holds, _, err := th.SystemAdminClient.GetLegalHolds(context.Background(), 0, 60, false)
+		require.NoError(t, err)
+		require.Len(t, holds, 1)
+		assert.Equal(t, savedHold.Id, holds[0].Id)
+
+		_, resp, err = th.Client.GetLegalHolds(context.Background(), 0, 60, false)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("held content cannot be permanently deleted", func(t *testing.T) {
+		resp, err := th.SystemAdminClient.PermanentDeleteUser(context.Background(), th.BasicUser2.Id)
+		require.Error(t, err)
+		checkHTTPStatus(t, resp, http.StatusConflict)
+
+		resp, err = th.SystemAdminClient.PermanentDeleteChannel(context.Background(), heldChannel.Id)
+		require.Error(t, err)
+		checkHTTPStatus(t, resp, http.StatusConflict)
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		name := "Renamed"
+		patched, resp, err := th.SystemAdminClient.PatchLegalHold(context.Background(), savedHold.Id, &model.LegalHoldPatch{Name: &name})
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		assert.Equal(t, name, patched.Name)
+		assert.Equal(t, savedHold.UserIds, patched.UserIds)
+	})
+
+	t.Run("export with an invalid type", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.ExportLegalHold(context.Background(), savedHold.Id, "pdf")
+		require.Error(t, err)
+		require.NotNil(t, resp)
+		require.Contains(t, []int{http.StatusBadRequest, http.StatusNotImplemented}, resp.StatusCode)
+	})
+
+	t.Run("release", func(t *testing.T) {
+		released, resp, err := th.SystemAdminClient.ReleaseLegalHold(context.Background(), savedHold.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		assert.True(t, released.IsReleased())
+
+		_, resp, err = th.SystemAdminClient.ReleaseLegalHold(context.Background(), savedHold.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+
+		holds, _, err := th.SystemAdminClient.GetLegalHolds(context.Background(), 0, 60, false)
+		require.NoError(t, err)
+		assert.Empty(t, holds)
+
+		resp, err = th.SystemAdminClient.PermanentDeleteChannel(context.Background(), heldChannel.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+	})
+}
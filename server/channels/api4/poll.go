@@ -0,0 +1,160 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+)
+
+func (api *API) InitPoll() {
+	api.BaseRoutes.Polls.Handle("", api.APISessionRequired(createPoll)).Methods(http.MethodPost)
+	api.BaseRoutes.Poll.Handle("", api.APISessionRequired(getPoll)).Methods(http.MethodGet)
+	api.BaseRoutes.Poll.Handle("/votes", api.APISessionRequired(votePoll)).Methods(http.MethodPost)
+	api.BaseRoutes.Poll.Handle("/close", api.APISessionRequired(closePoll)).Methods(http.MethodPost)
+}
+
+func createPoll(c *Context, w http.ResponseWriter, r *http.Request) {
+	var pollRequest model.CreatePollRequest
+	if err := json.NewDecoder(r.Body).Decode(&pollRequest); err != nil {
+		c.SetInvalidParamWithErr("poll", err)
+		return
+	}
+
+	if !model.IsValidId(pollRequest.ChannelId) {
+		c.SetInvalidParam("channel_id")
+		return
+	}
+
+	poll := pollRequest.ToPoll()
+	poll.UserId = c.AppContext.Session().UserId
+
+	auditRec := c.MakeAuditRecord("createPoll", audit.Fail)
+	defer c.LogAuditRecWithLevel(auditRec, app.LevelContent)
+	audit.AddEventParameterAuditable(auditRec, "poll", poll)
+	audit.AddEventParameter(auditRec, "root_id", pollRequest.RootId)
+
+	if !hasPermissionToCreatePost(c, poll.ChannelId) {
+		c.SetPermissionError(model.PermissionCreatePost)
+		return
+	}
+
+	createdPoll, appErr := c.App.CreatePoll(c.AppContext, poll, pollRequest.RootId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(createdPoll)
+	auditRec.AddEventObjectType("poll")
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(createdPoll); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getPoll(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePollId()
+	if c.Err != nil {
+		return
+	}
+
+	poll, appErr := c.App.GetPoll(c.Params.PollId, c.AppContext.Session().UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), poll.ChannelId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(poll); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func votePoll(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePollId()
+	if c.Err != nil {
+		return
+	}
+
+	var voteRequest model.PollVoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&voteRequest); err != nil {
+		c.SetInvalidParamWithErr("vote", err)
+		return
+	}
+
+	poll, appErr := c.App.GetPoll(c.Params.PollId, "")
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if !hasPermissionToCreatePost(c, poll.ChannelId) {
+		c.SetPermissionError(model.PermissionCreatePost)
+		return
+	}
+
+	poll, appErr = c.App.VotePoll(c.AppContext, c.Params.PollId, c.AppContext.Session().UserId, voteRequest.OptionIds)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(poll); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func closePoll(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePollId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("closePoll", audit.Fail)
+	defer c.LogAuditRecWithLevel(auditRec, app.LevelContent)
+	audit.AddEventParameter(auditRec, "poll_id", c.Params.PollId)
+
+	poll, appErr := c.App.GetPoll(c.Params.PollId, "")
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	auditRec.AddEventPriorState(poll)
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), poll.ChannelId, model.PermissionReadChannelContent) {
+		c.SetPermissionError(model.PermissionReadChannelContent)
+		return
+	}
+
+	if poll.UserId != c.AppContext.Session().UserId && !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), poll.ChannelId, model.PermissionDeleteOthersPosts) {
+		c.SetPermissionError(model.PermissionDeleteOthersPosts)
+		return
+	}
+
+	poll, appErr = c.App.ClosePoll(c.AppContext, c.Params.PollId, c.AppContext.Session().UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(poll)
+	auditRec.AddEventObjectType("poll")
+
+	if err := json.NewEncoder(w).Encode(poll); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
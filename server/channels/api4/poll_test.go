@@ -0,0 +1,177 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestCreatePoll(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	client := th.Client
+
+	t.Run("create poll", func(t *testing.T) {
+		poll, resp, err := client.CreatePoll(context.Background(), &model.CreatePollRequest{
+			ChannelId:      th.BasicChannel.Id,
+			Question:       "Where should we eat?",
+			Options:        []string{"Pizza", "Sushi"},
+			MultipleChoice: true,
+		})
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, th.BasicUser.Id, poll.UserId)
+		assert.True(t, poll.MultipleChoice)
+		require.Len(t, poll.Options, 2)
+
+		post, _, err := client.GetPost(context.Background(), poll.PostId, "")
+		require.NoError(t, err)
+		assert.Equal(t, model.PostTypePoll, post.Type)
+		require.NotNil(t, post.Metadata.Poll)
+		assert.Equal(t, poll.Id, post.Metadata.Poll.Id)
+	})
+
+	t.Run("invalid poll", func(t *testing.T) {
+		_, resp, err := client.CreatePoll(context.Background(), &model.CreatePollRequest{
+			ChannelId: th.BasicChannel.Id,
+			Question:  "Where should we eat?",
+			Options:   []string{"Pizza"},
+		})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("channel the user is not a member of", func(t *testing.T) {
+		privateChannel := th.CreateChannelWithClient(th.SystemAdminClient, model.ChannelTypePrivate)
+		_, resp, err := client.CreatePoll(context.Background(), &model.CreatePollRequest{
+			ChannelId: privateChannel.Id,
+			Question:  "Where should we eat?",
+			Options:   []string{"Pizza", "Sushi"},
+		})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestVotePoll(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	poll, _, err := th.Client.CreatePoll(context.Background(), &model.CreatePollRequest{
+		ChannelId: th.BasicChannel.Id,
+		Question:  "Where should we eat?",
+		Options:   []string{"Pizza", "Sushi"},
+		Anonymous: true,
+	})
+	require.NoError(t, err)
+
+	t.Run("vote", func(t *testing.T) {
+		voted, resp, err := th.Client.VotePoll(context.Background(), poll.Id, []string{poll.Options[0].Id})
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		assert.Equal(t, 1, voted.Results[0].Votes)
+		assert.Empty(t, voted.Results[0].UserIds)
+		assert.Equal(t, []string{poll.Options[0].Id}, voted.MyVotes)
+
+		fetched, _, err := th.Client.GetPoll(context.Background(), poll.Id)
+		require.NoError(t, err)
+		assert.Equal(t, 1, fetched.TotalVoters)
+	})
+
+	t.Run("too many options for a single choice poll", func(t *testing.T) {
+		_, resp, err := th.Client.VotePoll(context.Background(), poll.Id, []string{poll.Options[0].Id, poll.Options[1].Id})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("user without access to the channel", func(t *testing.T) {
+		privateChannel := th.CreateChannelWithClient(th.SystemAdminClient, model.ChannelTypePrivate)
+		th.AddUserToChannel(th.BasicUser, privateChannel)
+		privatePoll, _, err := th.Client.CreatePoll(context.Background(), &model.CreatePollRequest{
+			ChannelId: privateChannel.Id,
+			Question:  "Lunch?",
+			Options:   []string{"Yes", "No"},
+		})
+		require.NoError(t, err)
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp, err := th.Client.VotePoll(context.Background(), privatePoll.Id, []string{privatePoll.Options[0].Id})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.GetPoll(context.Background(), privatePoll.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("user without permission to post in the channel", func(t *testing.T) {
+		privatePoll, _, err := th.Client.CreatePoll(context.Background(), &model.CreatePollRequest{
+			ChannelId: th.BasicPrivateChannel.Id,
+			Question:  "Lunch?",
+			Options:   []string{"Yes", "No"},
+		})
+		require.NoError(t, err)
+
+		th.RemovePermissionFromRole(model.PermissionCreatePost.Id, model.ChannelUserRoleId)
+		defer th.AddPermissionToRole(model.PermissionCreatePost.Id, model.ChannelUserRoleId)
+
+		_, resp, err := th.Client.VotePoll(context.Background(), privatePoll.Id, []string{privatePoll.Options[0].Id})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestClosePoll(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	poll, _, err := th.Client.CreatePoll(context.Background(), &model.CreatePollRequest{
+		ChannelId: th.BasicChannel.Id,
+		Question:  "Where should we eat?",
+		Options:   []string{"Pizza", "Sushi"},
+	})
+	require.NoError(t, err)
+
+	t.Run("only the creator can close the poll", func(t *testing.T) {
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp, err := th.Client.ClosePoll(context.Background(), poll.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("close poll", func(t *testing.T) {
+		closed, resp, err := th.Client.ClosePoll(context.Background(), poll.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		assert.True(t, closed.IsClosed())
+
+		_, resp, err = th.Client.VotePoll(context.Background(), poll.Id, []string{poll.Options[0].Id})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("admins can close any poll", func(t *testing.T) {
+		other, _, err := th.Client.CreatePoll(context.Background(), &model.CreatePollRequest{
+			ChannelId: th.BasicChannel.Id,
+			Question:  "Lunch?",
+			Options:   []string{"Yes", "No"},
+		})
+		require.NoError(t, err)
+
+		_, resp, err := th.SystemAdminClient.ClosePoll(context.Background(), other.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+	})
+}
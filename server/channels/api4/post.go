@@ -24,6 +24,8 @@ func (api *API) InitPost() {
 	api.BaseRoutes.Posts.Handle("/ids", api.APISessionRequired(getPostsByIds)).Methods(http.MethodPost)
 	api.BaseRoutes.Posts.Handle("/ephemeral", api.APISessionRequired(createEphemeralPost)).Methods(http.MethodPost)
 	api.BaseRoutes.Post.Handle("/edit_history", api.APISessionRequired(getEditHistoryForPost)).Methods(http.MethodGet)
+	api.BaseRoutes.Post.Handle("/edit_history/revisions", api.APISessionRequired(getPostEditHistory)).Methods(http.MethodGet)
+	api.BaseRoutes.Post.Handle("/read_receipts", api.APISessionRequired(getPostReadReceipts)).Methods(http.MethodGet)
 	api.BaseRoutes.Post.Handle("/thread", api.APISessionRequired(getPostThread)).Methods(http.MethodGet)
 	api.BaseRoutes.Post.Handle("/info", api.APISessionRequired(getPostInfo)).Methods(http.MethodGet)
 	api.BaseRoutes.Post.Handle("/files/info", api.APISessionRequired(getFileInfosForPost)).Methods(http.MethodGet)
@@ -48,6 +50,23 @@ func (api *API) InitPost() {
 	api.BaseRoutes.Post.Handle("/move", api.APISessionRequired(moveThread)).Methods(http.MethodPost)
 }
 
+// hasPermissionToCreatePost checks whether the session can post in the given
+// channel, either through the channel permission or, for public channels,
+// through the team permission.
+func hasPermissionToCreatePost(c *Context, channelID string) bool {
+	if c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), channelID, model.PermissionCreatePost) {
+		return true
+	}
+
+	channel, err := c.App.GetChannel(c.AppContext, channelID)
+	if err != nil {
+		return false
+	}
+
+	// Temporary permission check method until advanced permissions, please do not copy
+	return channel.Type == model.ChannelTypeOpen && c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), channel.TeamId, model.PermissionCreatePostPublic)
+}
+
 func createPost(c *Context, w http.ResponseWriter, r *http.Request) {
 	var post model.Post
 	if jsonErr := json.NewDecoder(r.Body).Decode(&post); jsonErr != nil {
@@ -63,17 +82,7 @@ func createPost(c *Context, w http.ResponseWriter, r *http.Request) {
 	defer c.LogAuditRecWithLevel(auditRec, app.LevelContent)
 	audit.AddEventParameterAuditable(auditRec, "post", &post)
 
-	hasPermission := false
-	if c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), post.ChannelId, model.PermissionCreatePost) {
-		hasPermission = true
-	} else if channel, err := c.App.GetChannel(c.AppContext, post.ChannelId); err == nil {
-		// Temporary permission check method until advanced permissions, please do not copy
-		if channel.Type == model.ChannelTypeOpen && c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), channel.TeamId, model.PermissionCreatePostPublic) {
-			hasPermission = true
-		}
-	}
-
-	if !hasPermission {
+	if !hasPermissionToCreatePost(c, post.ChannelId) {
 		c.SetPermissionError(model.PermissionCreatePost)
 		return
 	}
@@ -587,35 +596,93 @@ func getPostsByIds(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// canReadEditHistoryForPost returns whether the session may see the previous
+// versions of the post, which only its author and compliance officers can.
+func canReadEditHistoryForPost(c *Context) bool {
+	if c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionReadComplianceExportJob) {
+		return true
+	}
+
+	originalPost, err := c.App.GetSinglePost(c.AppContext, c.Params.PostId, false)
+	if err != nil {
+		return false
+	}
+
+	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), originalPost.ChannelId, model.PermissionEditPost) {
+		return false
+	}
+
+	return c.AppContext.Session().UserId == originalPost.UserId
+}
+
 func getEditHistoryForPost(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequirePostId()
 	if c.Err != nil {
 		return
 	}
 
-	originalPost, err := c.App.GetSinglePost(c.AppContext, c.Params.PostId, false)
-	if err != nil {
+	if !canReadEditHistoryForPost(c) {
 		c.SetPermissionError(model.PermissionEditPost)
 		return
 	}
 
-	if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), originalPost.ChannelId, model.PermissionEditPost) {
-		c.SetPermissionError(model.PermissionEditPost)
+	postsList, err := c.App.GetEditHistoryForPost(c.Params.PostId)
+	if err != nil {
+		c.Err = err
 		return
 	}
 
-	if c.AppContext.Session().UserId != originalPost.UserId {
+	if err := json.NewEncoder(w).Encode(postsList); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getPostEditHistory(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	if !canReadEditHistoryForPost(c) {
 		c.SetPermissionError(model.PermissionEditPost)
 		return
 	}
 
-	postsList, err := c.App.GetEditHistoryForPost(c.Params.PostId)
+	history, err := c.App.GetPostEditHistory(c.AppContext, c.Params.PostId)
 	if err != nil {
 		c.Err = err
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(postsList); err != nil {
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getPostReadReceipts(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePostId()
+	if c.Err != nil {
+		return
+	}
+
+	post, err := c.App.GetPostIfAuthorized(c.AppContext, c.Params.PostId, c.AppContext.Session(), false)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if post.UserId != c.AppContext.Session().UserId {
+		c.Err = model.NewAppError("getPostReadReceipts", "api.post.read_receipts.not_author.app_error", nil, "", http.StatusForbidden)
+		return
+	}
+
+	receipts, err := c.App.GetPostReadReceipts(c.AppContext, post)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(receipts); err != nil {
 		c.Logger.Warn("Error while writing response", mlog.Err(err))
 	}
 }
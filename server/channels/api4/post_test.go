@@ -3884,6 +3884,74 @@ func TestGetEditHistoryForPost(t *testing.T) {
 		require.Error(t, err)
 		CheckForbiddenStatus(t, resp)
 	})
+
+	t.Run("system admin", func(t *testing.T) {
+		history, resp, err := th.SystemAdminClient.GetEditHistoryForPost(context.Background(), rpost.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		require.Len(t, history, 2)
+	})
+}
+
+func TestGetPostEditHistory(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	rpost, appErr := th.App.CreatePost(th.Context, &model.Post{
+		ChannelId: th.BasicChannel.Id,
+		Message:   "the quick fox",
+		UserId:    th.BasicUser.Id,
+	}, th.BasicChannel, model.CreatePostFlags{SetOnline: true})
+	require.Nil(t, appErr)
+
+	t.Run("unedited post", func(t *testing.T) {
+		history, resp, err := client.GetPostEditHistory(context.Background(), rpost.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		require.Equal(t, rpost.Id, history.PostId)
+		require.Empty(t, history.Revisions)
+	})
+
+	time.Sleep(1 * time.Millisecond)
+
+	_, resp, err := client.PatchPost(context.Background(), rpost.Id, &model.PostPatch{
+		Message: model.NewPointer("the slow fox"),
+	})
+	require.NoError(t, err)
+	CheckOKStatus(t, resp)
+
+	t.Run("revisions with diffs", func(t *testing.T) {
+		history, resp, err := client.GetPostEditHistory(context.Background(), rpost.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+
+		require.Len(t, history.Revisions, 1)
+		revision := history.Revisions[0]
+		require.Equal(t, "the quick fox", revision.Post.Message)
+		require.Equal(t, []model.PostDiffSegment{
+			{Op: model.PostDiffEqual, Text: "the "},
+			{Op: model.PostDiffDelete, Text: "quick"},
+			{Op: model.PostDiffInsert, Text: "slow"},
+			{Op: model.PostDiffEqual, Text: " fox"},
+		}, revision.MessageDiff)
+		require.Empty(t, revision.AddedFileIds)
+		require.Empty(t, revision.RemovedFileIds)
+	})
+
+	t.Run("system admin", func(t *testing.T) {
+		history, resp, err := th.SystemAdminClient.GetPostEditHistory(context.Background(), rpost.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		require.Len(t, history.Revisions, 1)
+	})
+
+	t.Run("different user", func(t *testing.T) {
+		th.LoginBasic2()
+		_, resp, err := client.GetPostEditHistory(context.Background(), rpost.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
 }
 
 func TestCreatePostNotificationsWithCRT(t *testing.T) {
@@ -4505,3 +4573,43 @@ func TestUnacknowledgePost(t *testing.T) {
 	require.Error(t, err)
 	CheckUnauthorizedStatus(t, resp)
 }
+
+func TestGetPostReadReceipts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableReadReceipts = true
+	})
+
+	dm := th.CreateDmChannel(th.BasicUser2)
+	post := th.CreatePostWithClient(client, dm)
+
+	time.Sleep(1 * time.Millisecond)
+	_, _, err := th.SystemAdminClient.ViewChannel(context.Background(), th.BasicUser2.Id, &model.ChannelView{ChannelId: dm.Id})
+	require.NoError(t, err)
+
+	t.Run("author", func(t *testing.T) {
+		receipts, resp, err := client.GetPostReadReceipts(context.Background(), post.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		require.Equal(t, post.Id, receipts.PostId)
+		require.Equal(t, []string{th.BasicUser2.Id}, receipts.ReadBy)
+	})
+
+	t.Run("other member", func(t *testing.T) {
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp, err := client.GetPostReadReceipts(context.Background(), post.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("channel without read receipts", func(t *testing.T) {
+		_, resp, err := client.GetPostReadReceipts(context.Background(), th.BasicPost.Id)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+}
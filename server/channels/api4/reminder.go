@@ -0,0 +1,107 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+)
+
+func (api *API) InitReminder() {
+	api.BaseRoutes.User.Handle("/reminders", api.APISessionRequired(getUserReminders)).Methods(http.MethodGet)
+	api.BaseRoutes.Reminder.Handle("", api.APISessionRequired(deleteReminder)).Methods(http.MethodDelete)
+	api.BaseRoutes.Reminder.Handle("/snooze", api.APISessionRequired(snoozeReminder)).Methods(http.MethodPost)
+}
+
+func getUserReminders(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	reminders, appErr := c.App.GetRemindersForUser(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(reminders); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteReminder(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireReminderId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteReminder", audit.Fail)
+	defer c.LogAuditRecWithLevel(auditRec, app.LevelContent)
+	audit.AddEventParameter(auditRec, "reminder_id", c.Params.ReminderId)
+
+	reminder, appErr := c.App.GetReminder(c.Params.ReminderId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	auditRec.AddEventPriorState(reminder)
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), reminder.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	if _, appErr = c.App.DeleteReminder(c.AppContext, reminder.UserId, reminder.Id); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventObjectType("reminder")
+
+	ReturnStatusOK(w)
+}
+
+func snoozeReminder(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireReminderId()
+	if c.Err != nil {
+		return
+	}
+
+	var snoozeRequest model.SnoozeReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&snoozeRequest); err != nil {
+		c.SetInvalidParamWithErr("snooze", err)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("snoozeReminder", audit.Fail)
+	defer c.LogAuditRecWithLevel(auditRec, app.LevelContent)
+	audit.AddEventParameter(auditRec, "reminder_id", c.Params.ReminderId)
+	audit.AddEventParameter(auditRec, "snooze_until", snoozeRequest.SnoozeUntil)
+
+	reminder, appErr := c.App.SnoozeReminder(c.AppContext, c.AppContext.Session().UserId, c.Params.ReminderId, snoozeRequest.SnoozeUntil)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(reminder)
+	auditRec.AddEventObjectType("reminder")
+
+	if err := json.NewEncoder(w).Encode(reminder); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
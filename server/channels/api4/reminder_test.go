@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestReminders(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	reminder, appErr := th.App.CreateReminder(th.Context, &model.Reminder{
+		UserId:     th.BasicUser.Id,
+		TargetType: model.ReminderTargetUser,
+		TargetId:   th.BasicUser2.Id,
+		Message:    "Stretch",
+		TriggerAt:  model.GetMillis() + 60*1000,
+	})
+	require.Nil(t, appErr)
+
+	t.Run("get reminders of the user", func(t *testing.T) {
+		reminders, resp, err := th.Client.GetUserReminders(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		require.Len(t, reminders, 1)
+		assert.Equal(t, reminder.Id, reminders[0].Id)
+	})
+
+	t.Run("get reminders of another user", func(t *testing.T) {
+		_, resp, err := th.Client.GetUserReminders(context.Background(), th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		reminders, _, err := th.SystemAdminClient.GetUserReminders(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+	})
+
+	t.Run("snooze as the target user", func(t *testing.T) {
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		snoozeUntil := model.GetMillis() + 60*60*1000
+		snoozed, resp, err := th.Client.SnoozeReminder(context.Background(), reminder.Id, snoozeUntil)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		assert.Equal(t, snoozeUntil, snoozed.TriggerAt)
+
+		_, resp, err = th.Client.SnoozeReminder(context.Background(), reminder.Id, model.GetMillis()-1000)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		th.LoginBasic2()
+		resp, err := th.Client.DeleteReminder(context.Background(), reminder.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		th.LoginBasic()
+		resp, err = th.Client.DeleteReminder(context.Background(), reminder.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+
+		resp, err = th.Client.DeleteReminder(context.Background(), reminder.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+)
+
+func (api *API) InitSavedSearch() {
+	api.BaseRoutes.SavedSearches.Handle("", api.APISessionRequired(createSavedSearch)).Methods(http.MethodPost)
+	api.BaseRoutes.User.Handle("/saved_searches", api.APISessionRequired(getUserSavedSearches)).Methods(http.MethodGet)
+	api.BaseRoutes.SavedSearch.Handle("", api.APISessionRequired(getSavedSearch)).Methods(http.MethodGet)
+	api.BaseRoutes.SavedSearch.Handle("/patch", api.APISessionRequired(patchSavedSearch)).Methods(http.MethodPut)
+	api.BaseRoutes.SavedSearch.Handle("", api.APISessionRequired(deleteSavedSearch)).Methods(http.MethodDelete)
+	api.BaseRoutes.SavedSearch.Handle("/search", api.APISessionRequired(runSavedSearch)).Methods(http.MethodPost)
+}
+
+func createSavedSearch(c *Context, w http.ResponseWriter, r *http.Request) {
+	var savedSearch model.SavedSearch
+	if err := json.NewDecoder(r.Body).Decode(&savedSearch); err != nil {
+		c.SetInvalidParamWithErr("saved_search", err)
+		return
+	}
+	savedSearch.UserId = c.AppContext.Session().UserId
+
+	auditRec := c.MakeAuditRecord("createSavedSearch", audit.Fail)
+	defer c.LogAuditRecWithLevel(auditRec, app.LevelContent)
+	audit.AddEventParameterAuditable(auditRec, "saved_search", &savedSearch)
+
+	saved, appErr := c.App.CreateSavedSearch(c.AppContext, &savedSearch)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(saved)
+	auditRec.AddEventObjectType("saved_search")
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(saved); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getUserSavedSearches(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	savedSearches, appErr := c.App.GetSavedSearchesForUser(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(savedSearches); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getSavedSearch(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireSavedSearchId()
+	if c.Err != nil {
+		return
+	}
+
+	savedSearch, appErr := c.App.GetSavedSearch(c.Params.SavedSearchId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), savedSearch.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(savedSearch); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func patchSavedSearch(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireSavedSearchId()
+	if c.Err != nil {
+		return
+	}
+
+	var patch model.SavedSearchPatch
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		c.SetInvalidParamWithErr("saved_search", err)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("patchSavedSearch", audit.Fail)
+	defer c.LogAuditRecWithLevel(auditRec, app.LevelContent)
+	audit.AddEventParameter(auditRec, "saved_search_id", c.Params.SavedSearchId)
+	audit.AddEventParameterAuditable(auditRec, "patch", &patch)
+
+	savedSearch, appErr := c.App.PatchSavedSearch(c.AppContext, c.AppContext.Session().UserId, c.Params.SavedSearchId, &patch)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(savedSearch)
+	auditRec.AddEventObjectType("saved_search")
+
+	if err := json.NewEncoder(w).Encode(savedSearch); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteSavedSearch(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireSavedSearchId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteSavedSearch", audit.Fail)
+	defer c.LogAuditRecWithLevel(auditRec, app.LevelContent)
+	audit.AddEventParameter(auditRec, "saved_search_id", c.Params.SavedSearchId)
+
+	savedSearch, appErr := c.App.DeleteSavedSearch(c.AppContext, c.AppContext.Session().UserId, c.Params.SavedSearchId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventPriorState(savedSearch)
+	auditRec.AddEventObjectType("saved_search")
+
+	ReturnStatusOK(w)
+}
+
+func runSavedSearch(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireSavedSearchId()
+	if c.Err != nil {
+		return
+	}
+
+	savedSearch, appErr := c.App.GetSavedSearch(c.Params.SavedSearchId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	// Saved searches run as their user, so only that user can run them.
+	if savedSearch.UserId != c.AppContext.Session().UserId {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	results, appErr := c.App.RunSavedSearch(c.AppContext, savedSearch, c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	clientPostList := c.App.PreparePostListForClient(c.AppContext, results.PostList)
+	clientPostList, appErr = c.App.SanitizePostListMetadataForUser(c.AppContext, clientPostList, c.AppContext.Session().UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	results = model.MakePostSearchResults(clientPostList, results.Matches)
+
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	if err := results.EncodeJSON(w); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSavedSearches(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post := th.CreateMessagePost("the deploy failed with an error")
+
+	var savedSearch *model.SavedSearch
+	t.Run("create", func(t *testing.T) {
+		var resp *model.Response
+		var err error
+		savedSearch, resp, err = th.Client.CreateSavedSearch(context.Background(), &model.SavedSearch{
+			UserId: th.BasicUser2.Id,
+			TeamId: th.BasicTeam.Id,
+			Name:   "Deploy errors",
+			Terms:  "deploy error",
+		})
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, th.BasicUser.Id, savedSearch.UserId)
+
+		_, resp, err = th.Client.CreateSavedSearch(context.Background(), &model.SavedSearch{
+			Name:  "Invalid",
+			Terms: "(deploy",
+		})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("get saved searches of the user", func(t *testing.T) {
+		savedSearches, resp, err := th.Client.GetUserSavedSearches(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		require.Len(t, savedSearches, 1)
+		assert.Equal(t, savedSearch.Id, savedSearches[0].Id)
+
+		_, resp, err = th.Client.GetUserSavedSearches(context.Background(), th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		fetched, _, err := th.SystemAdminClient.GetSavedSearch(context.Background(), savedSearch.Id)
+		require.NoError(t, err)
+		assert.Equal(t, savedSearch.Id, fetched.Id)
+	})
+
+	t.Run("run", func(t *testing.T) {
+		list, resp, err := th.Client.RunSavedSearch(context.Background(), savedSearch.Id, 0, 20)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		assert.Contains(t, list.Order, post.Id)
+
+		th.LoginBasic2()
+		defer th.LoginBasic()
+		_, resp, err = th.Client.RunSavedSearch(context.Background(), savedSearch.Id, 0, 20)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		patched, resp, err := th.Client.PatchSavedSearch(context.Background(), savedSearch.Id, &model.SavedSearchPatch{
+			Name:  model.NewPointer("Deploy failures"),
+			Alert: model.NewPointer(model.SavedSearchAlertDirectMessage),
+		})
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		assert.Equal(t, "Deploy failures", patched.Name)
+		assert.Equal(t, model.SavedSearchAlertDirectMessage, patched.Alert)
+		assert.NotZero(t, patched.LastCheckedAt)
+
+		_, resp, err = th.Client.PatchSavedSearch(context.Background(), savedSearch.Id, &model.SavedSearchPatch{
+			Alert: model.NewPointer("email"),
+		})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		th.LoginBasic2()
+		resp, err := th.Client.DeleteSavedSearch(context.Background(), savedSearch.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		th.LoginBasic()
+		resp, err = th.Client.DeleteSavedSearch(context.Background(), savedSearch.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+
+		_, resp, err = th.Client.GetSavedSearch(context.Background(), savedSearch.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
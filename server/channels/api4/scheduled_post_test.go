@@ -0,0 +1,164 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestCreateScheduledPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	client := th.Client
+
+	scheduledPost := &model.ScheduledPost{
+		ChannelId:   th.BasicChannel.Id,
+		Message:     "scheduled message",
+		ScheduledAt: model.GetMillis() + 60000,
+	}
+
+	t.Run("create scheduled post", func(t *testing.T) {
+		created, resp, err := client.CreateScheduledPost(context.Background(), scheduledPost)
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.NotEmpty(t, created.Id)
+		assert.Equal(t, th.BasicUser.Id, created.UserId)
+		assert.Equal(t, scheduledPost.Message, created.Message)
+		assert.Equal(t, scheduledPost.ScheduledAt, created.ScheduledAt)
+	})
+
+	t.Run("scheduled time in the past", func(t *testing.T) {
+		_, resp, err := client.CreateScheduledPost(context.Background(), &model.ScheduledPost{
+			ChannelId:   th.BasicChannel.Id,
+			Message:     "scheduled message",
+			ScheduledAt: model.GetMillis() - 60000,
+		})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("channel the user is not a member of", func(t *testing.T) {
+		privateChannel := th.CreateChannelWithClient(th.SystemAdminClient, model.ChannelTypePrivate)
+		_, resp, err := client.CreateScheduledPost(context.Background(), &model.ScheduledPost{
+			ChannelId:   privateChannel.Id,
+			Message:     "scheduled message",
+			ScheduledAt: model.GetMillis() + 60000,
+		})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("feature disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ScheduledPosts = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ScheduledPosts = true })
+
+		_, resp, err := client.CreateScheduledPost(context.Background(), scheduledPost)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+}
+
+func TestGetUserScheduledPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	client := th.Client
+
+	created, _, err := client.CreateScheduledPost(context.Background(), &model.ScheduledPost{
+		ChannelId:   th.BasicChannel.Id,
+		Message:     "scheduled message",
+		ScheduledAt: model.GetMillis() + 60000,
+	})
+	require.NoError(t, err)
+
+	scheduledPosts, _, err := client.GetUserScheduledPosts(context.Background(), th.BasicTeam.Id)
+	require.NoError(t, err)
+	require.Len(t, scheduledPosts, 1)
+	assert.Equal(t, created.Id, scheduledPosts[0].Id)
+
+	scheduledPosts, _, err = th.SystemAdminClient.GetUserScheduledPosts(context.Background(), th.BasicTeam.Id)
+	require.NoError(t, err)
+	assert.Empty(t, scheduledPosts)
+
+	_, resp, err := client.GetUserScheduledPosts(context.Background(), model.NewId())
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+}
+
+func TestUpdateScheduledPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	client := th.Client
+
+	created, _, err := client.CreateScheduledPost(context.Background(), &model.ScheduledPost{
+		ChannelId:   th.BasicChannel.Id,
+		Message:     "scheduled message",
+		ScheduledAt: model.GetMillis() + 60000,
+	})
+	require.NoError(t, err)
+
+	t.Run("update scheduled post", func(t *testing.T) {
+		created.Message = "updated message"
+		created.ScheduledAt += 60000
+		updated, _, err := client.UpdateScheduledPost(context.Background(), created)
+		require.NoError(t, err)
+		assert.Equal(t, "updated message", updated.Message)
+		assert.Equal(t, created.ScheduledAt, updated.ScheduledAt)
+	})
+
+	t.Run("another user cannot update the scheduled post", func(t *testing.T) {
+		th.LoginBasic2()
+		defer th.LoginBasic()
+
+		_, resp, err := th.Client.UpdateScheduledPost(context.Background(), created)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("unknown scheduled post", func(t *testing.T) {
+		_, resp, err := client.UpdateScheduledPost(context.Background(), &model.ScheduledPost{
+			Id:          model.NewId(),
+			Message:     "updated message",
+			ScheduledAt: model.GetMillis() + 60000,
+		})
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
+
+func TestDeleteScheduledPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	client := th.Client
+
+	created, _, err := client.CreateScheduledPost(context.Background(), &model.ScheduledPost{
+		ChannelId:   th.BasicChannel.Id,
+		Message:     "scheduled message",
+		ScheduledAt: model.GetMillis() + 60000,
+	})
+	require.NoError(t, err)
+
+	th.LoginBasic2()
+	_, resp, err := th.Client.DeleteScheduledPost(context.Background(), created.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+	th.LoginBasic()
+
+	deleted, _, err := client.DeleteScheduledPost(context.Background(), created.Id)
+	require.NoError(t, err)
+	assert.Equal(t, created.Id, deleted.Id)
+
+	_, resp, err = client.DeleteScheduledPost(context.Background(), created.Id)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+}
@@ -0,0 +1,357 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+)
+
+func (api *API) InitScim() {
+	api.BaseRoutes.Scim.Handle("/ServiceProviderConfig", api.ScimTokenRequired(getScimServiceProviderConfig)).Methods(http.MethodGet)
+	api.BaseRoutes.Scim.Handle("/ResourceTypes", api.ScimTokenRequired(getScimResourceTypes)).Methods(http.MethodGet)
+
+	api.BaseRoutes.ScimUsers.Handle("", api.ScimTokenRequired(getScimUsers)).Methods(http.MethodGet)
+	api.BaseRoutes.ScimUsers.Handle("", api.ScimTokenRequired(createScimUser)).Methods(http.MethodPost)
+	api.BaseRoutes.ScimUser.Handle("", api.ScimTokenRequired(getScimUser)).Methods(http.MethodGet)
+	api.BaseRoutes.ScimUser.Handle("", api.ScimTokenRequired(updateScimUser)).Methods(http.MethodPut)
+	api.BaseRoutes.ScimUser.Handle("", api.ScimTokenRequired(patchScimUser)).Methods(http.MethodPatch)
+	api.BaseRoutes.ScimUser.Handle("", api.ScimTokenRequired(deleteScimUser)).Methods(http.MethodDelete)
+
+	api.BaseRoutes.ScimGroups.Handle("", api.ScimTokenRequired(getScimGroups)).Methods(http.MethodGet)
+	api.BaseRoutes.ScimGroups.Handle("", api.ScimTokenRequired(createScimGroup)).Methods(http.MethodPost)
+	api.BaseRoutes.ScimGroup.Handle("", api.ScimTokenRequired(getScimGroup)).Methods(http.MethodGet)
+	api.BaseRoutes.ScimGroup.Handle("", api.ScimTokenRequired(updateScimGroup)).Methods(http.MethodPut)
+	api.BaseRoutes.ScimGroup.Handle("", api.ScimTokenRequired(patchScimGroup)).Methods(http.MethodPatch)
+	api.BaseRoutes.ScimGroup.Handle("", api.ScimTokenRequired(deleteScimGroup)).Methods(http.MethodDelete)
+}
+
+// writeScimError writes an error in the format of RFC 7644 section 3.12,
+// which identity providers expect instead of the usual API errors.
+func writeScimError(c *Context, w http.ResponseWriter, appErr *model.AppError) {
+	c.LogErrorByCode(appErr)
+
+	detail := appErr.DetailedError
+	if model.ScimErrorType(appErr) == "" {
+		appErr.Translate(c.AppContext.T)
+		detail = appErr.Message
+	}
+
+	w.Header().Set("Content-Type", model.ScimContentType)
+	w.WriteHeader(appErr.StatusCode)
+	if err := json.NewEncoder(w).Encode(model.NewScimError(appErr.StatusCode, model.ScimErrorType(appErr), detail)); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func writeScimResponse(c *Context, w http.ResponseWriter, status int, resource any) {
+	w.Header().Set("Content-Type", model.ScimContentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resource); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// scimResourceID returns the id of the requested resource. Unknown ids are
+// reported as missing resources, as expected by identity providers.
+func scimResourceID(c *Context, w http.ResponseWriter, id string) (string, bool) {
+	if !model.IsValidId(id) {
+		writeScimError(c, w, model.NewAppError("scimResourceID", "api.context.invalid_url_param.app_error", map[string]any{"Name": "id"}, "", http.StatusNotFound))
+		return "", false
+	}
+	return id, true
+}
+
+// scimListParams returns the filter, 1-based start index and page size of a
+// SCIM list request.
+func scimListParams(r *http.Request) (string, int, int) {
+	query := r.URL.Query()
+
+	startIndex, err := strconv.Atoi(query.Get("startIndex"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+
+	count, err := strconv.Atoi(query.Get("count"))
+	if err != nil {
+		count = model.ScimDefaultCount
+	}
+	count = max(0, min(count, model.ScimMaxCount))
+
+	return query.Get("filter"), startIndex, count
+}
+
+func scimExcludeMembers(r *http.Request) bool {
+	for _, attr := range strings.Split(r.URL.Query().Get("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attr), "members") {
+			return true
+		}
+	}
+	return false
+}
+
+func getScimServiceProviderConfig(c *Context, w http.ResponseWriter, r *http.Request) {
+	writeScimResponse(c, w, http.StatusOK, model.NewScimServiceProviderConfig(c.App.GetSiteURL()+model.APIURLSuffix+"/scim/v2/ServiceProviderConfig"))
+}
+
+func getScimResourceTypes(c *Context, w http.ResponseWriter, r *http.Request) {
+	resourceTypes := model.NewScimResourceTypes(c.App.GetSiteURL() + model.APIURLSuffix + "/scim/v2")
+	writeScimResponse(c, w, http.StatusOK, &model.ScimListResponse{
+		Schemas:      []string{model.ScimSchemaListResponse},
+		TotalResults: len(resourceTypes),
+		StartIndex:   1,
+		ItemsPerPage: len(resourceTypes),
+		Resources:    resourceTypes,
+	})
+}
+
+func getScimUsers(c *Context, w http.ResponseWriter, r *http.Request) {
+	filter, startIndex, count := scimListParams(r)
+	list, appErr := c.App.GetScimUsers(c.AppContext, filter, startIndex, count)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, list)
+}
+
+func getScimUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID, ok := scimResourceID(c, w, c.Params.UserId)
+	if !ok {
+		return
+	}
+
+	user, appErr := c.App.GetScimUser(userID)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, user)
+}
+
+func createScimUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	var scimUser model.ScimUser
+	if err := json.NewDecoder(r.Body).Decode(&scimUser); err != nil {
+		writeScimError(c, w, model.NewScimAppError("createScimUser", model.ScimErrorTypeInvalidSyntax, err.Error()))
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createScimUser", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "user_name", scimUser.UserName)
+	audit.AddEventParameter(auditRec, "external_id", scimUser.ExternalId)
+
+	user, appErr := c.App.CreateScimUser(c.AppContext, &scimUser)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventObjectType("user")
+	auditRec.AddMeta("user_id", user.Id)
+
+	writeScimResponse(c, w, http.StatusCreated, user)
+}
+
+func updateScimUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID, ok := scimResourceID(c, w, c.Params.UserId)
+	if !ok {
+		return
+	}
+
+	var scimUser model.ScimUser
+	if err := json.NewDecoder(r.Body).Decode(&scimUser); err != nil {
+		writeScimError(c, w, model.NewScimAppError("updateScimUser", model.ScimErrorTypeInvalidSyntax, err.Error()))
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updateScimUser", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "user_id", userID)
+
+	user, appErr := c.App.UpdateScimUser(c.AppContext, userID, &scimUser)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec.Success()
+	writeScimResponse(c, w, http.StatusOK, user)
+}
+
+func patchScimUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID, ok := scimResourceID(c, w, c.Params.UserId)
+	if !ok {
+		return
+	}
+
+	var patch model.ScimPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeScimError(c, w, model.NewScimAppError("patchScimUser", model.ScimErrorTypeInvalidSyntax, err.Error()))
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("patchScimUser", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "user_id", userID)
+
+	user, appErr := c.App.PatchScimUser(c.AppContext, userID, patch.Operations)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec.Success()
+	writeScimResponse(c, w, http.StatusOK, user)
+}
+
+func deleteScimUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID, ok := scimResourceID(c, w, c.Params.UserId)
+	if !ok {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteScimUser", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "user_id", userID)
+
+	if appErr := c.App.DeleteScimUser(c.AppContext, userID); appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec.Success()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func getScimGroups(c *Context, w http.ResponseWriter, r *http.Request) {
+	filter, startIndex, count := scimListParams(r)
+	list, appErr := c.App.GetScimGroups(filter, startIndex, count, scimExcludeMembers(r))
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, list)
+}
+
+func getScimGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	groupID, ok := scimResourceID(c, w, c.Params.GroupId)
+	if !ok {
+		return
+	}
+
+	group, appErr := c.App.GetScimGroup(groupID, scimExcludeMembers(r))
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, group)
+}
+
+func createScimGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	var scimGroup model.ScimGroup
+	if err := json.NewDecoder(r.Body).Decode(&scimGroup); err != nil {
+		writeScimError(c, w, model.NewScimAppError("createScimGroup", model.ScimErrorTypeInvalidSyntax, err.Error()))
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createScimGroup", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "display_name", scimGroup.DisplayName)
+	audit.AddEventParameter(auditRec, "external_id", scimGroup.ExternalId)
+
+	group, appErr := c.App.CreateScimGroup(c.AppContext, &scimGroup)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventObjectType("group")
+	auditRec.AddMeta("group_id", group.Id)
+
+	writeScimResponse(c, w, http.StatusCreated, group)
+}
+
+func updateScimGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	groupID, ok := scimResourceID(c, w, c.Params.GroupId)
+	if !ok {
+		return
+	}
+
+	var scimGroup model.ScimGroup
+	if err := json.NewDecoder(r.Body).Decode(&scimGroup); err != nil {
+		writeScimError(c, w, model.NewScimAppError("updateScimGroup", model.ScimErrorTypeInvalidSyntax, err.Error()))
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("updateScimGroup", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "group_id", groupID)
+
+	group, appErr := c.App.UpdateScimGroup(c.AppContext, groupID, &scimGroup)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec.Success()
+	writeScimResponse(c, w, http.StatusOK, group)
+}
+
+func patchScimGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	groupID, ok := scimResourceID(c, w, c.Params.GroupId)
+	if !ok {
+		return
+	}
+
+	var patch model.ScimPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeScimError(c, w, model.NewScimAppError("patchScimGroup", model.ScimErrorTypeInvalidSyntax, err.Error()))
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("patchScimGroup", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "group_id", groupID)
+
+	group, appErr := c.App.PatchScimGroup(c.AppContext, groupID, patch.Operations)
+	if appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec.Success()
+	writeScimResponse(c, w, http.StatusOK, group)
+}
+
+func deleteScimGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	groupID, ok := scimResourceID(c, w, c.Params.GroupId)
+	if !ok {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteScimGroup", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "group_id", groupID)
+
+	if appErr := c.App.DeleteScimGroup(c.AppContext, groupID); appErr != nil {
+		writeScimError(c, w, appErr)
+		return
+	}
+
+	auditRec.Success()
+	w.WriteHeader(http.StatusNoContent)
+}
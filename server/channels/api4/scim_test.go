@@ -0,0 +1,230 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func doScimRequest(t *testing.T, client *model.Client4, method, path string, body any, result any) *http.Response {
+	t.Helper()
+
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+
+	resp, _ := client.DoAPIRequestBytes(context.Background(), method, client.APIURL+"/scim/v2"+path, data, "")
+	require.NotNil(t, resp)
+	if result != nil && resp.StatusCode < 300 {
+		defer resp.Body.Close()
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(result))
+	}
+	return resp
+}
+
+func TestScim(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.Srv().SetLicense(model.NewTestLicense("ldap"))
+
+	bearerToken := model.NewId() + model.NewId()
+	scimClient := model.NewAPIv4Client(th.Client.URL)
+	scimClient.SetToken(bearerToken)
+
+	t.Run("disabled", func(t *testing.T) {
+		resp := doScimRequest(t, scimClient, http.MethodGet, "/ServiceProviderConfig", nil, nil)
+		assert.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ScimSettings.Enable = true
+		*cfg.ScimSettings.BearerToken = bearerToken
+	})
+
+	t.Run("requires the bearer token", func(t *testing.T) {
+		wrongClient := model.NewAPIv4Client(th.Client.URL)
+		wrongClient.SetToken(model.NewId())
+		resp := doScimRequest(t, wrongClient, http.MethodGet, "/ServiceProviderConfig", nil, nil)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		resp = doScimRequest(t, th.SystemAdminClient, http.MethodGet, "/ServiceProviderConfig", nil, nil)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("service provider config", func(t *testing.T) {
+		var config model.ScimServiceProviderConfig
+		resp := doScimRequest(t, scimClient, http.MethodGet, "/ServiceProviderConfig", nil, &config)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, model.ScimContentType, resp.Header.Get("Content-Type"))
+		assert.True(t, config.Patch.Supported)
+		assert.True(t, config.Filter.Supported)
+	})
+
+	var scimUser model.ScimUser
+	t.Run("create user", func(t *testing.T) {
+		newUser := &model.ScimUser{
+			Schemas:    []string{model.ScimSchemaUser},
+			ExternalId: "00u1" + model.NewId(),
+			UserName:   "jane.doe@example.com",
+			Name:       &model.ScimName{GivenName: "Jane", FamilyName: "Doe"},
+			Emails:     []model.ScimMultiValue{{Value: "Jane.Doe@example.com", Type: "work", Primary: true}},
+			Active:     model.NewPointer(true),
+		}
+		resp := doScimRequest(t, scimClient, http.MethodPost, "/Users", newUser, &scimUser)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.NotEmpty(t, scimUser.Id)
+		assert.Equal(t, newUser.ExternalId, scimUser.ExternalId)
+		assert.Equal(t, "jane.doe-example.com", scimUser.UserName)
+		assert.Equal(t, "jane.doe@example.com", scimUser.PrimaryEmail())
+
+		user, appErr := th.App.GetUser(scimUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.UserAuthServiceSaml, user.AuthService)
+		assert.Equal(t, "Jane", user.FirstName)
+
+		resp = doScimRequest(t, scimClient, http.MethodPost, "/Users", newUser, nil)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("filter users", func(t *testing.T) {
+		var list model.ScimListResponse
+		resp := doScimRequest(t, scimClient, http.MethodGet, "/Users?filter="+url.QueryEscape(`userName eq "jane.doe@example.com"`), nil, &list)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, list.TotalResults)
+
+		resp = doScimRequest(t, scimClient, http.MethodGet, "/Users?filter="+url.QueryEscape(`userName eq "nobody"`), nil, &list)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 0, list.TotalResults)
+
+		resp = doScimRequest(t, scimClient, http.MethodGet, "/Users?filter="+url.QueryEscape(`name.familyName eq "Doe" and active eq true`), nil, &list)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, list.TotalResults)
+
+		resp = doScimRequest(t, scimClient, http.MethodGet, "/Users?filter="+url.QueryEscape(`userName eq`), nil, nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("patch user", func(t *testing.T) {
+		patch := &model.ScimPatchRequest{
+			Schemas: []string{model.ScimSchemaPatchOp},
+			Operations: []model.ScimPatchOperation{
+				{Op: "replace", Path: "name.familyName", Value: json.RawMessage(`"Smith"`)},
+				{Op: "replace", Path: "active", Value: json.RawMessage(`false`)},
+			},
+		}
+		var patched model.ScimUser
+		resp := doScimRequest(t, scimClient, http.MethodPatch, "/Users/"+scimUser.Id, patch, &patched)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.False(t, patched.IsActive())
+
+		user, appErr := th.App.GetUser(scimUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, "Smith", user.LastName)
+		assert.NotZero(t, user.DeleteAt)
+
+		resp = doScimRequest(t, scimClient, http.MethodPatch, "/Users/"+model.NewId(), patch, nil)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("list users", func(t *testing.T) {
+		var list model.ScimListResponse
+		resp := doScimRequest(t, scimClient, http.MethodGet, "/Users?startIndex=2&count=1", nil, &list)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Greater(t, list.TotalResults, 2)
+		assert.Equal(t, 2, list.StartIndex)
+		assert.Equal(t, 1, list.ItemsPerPage)
+		assert.Len(t, list.Resources, 1)
+	})
+
+	t.Run("users of other authentication services are not modified", func(t *testing.T) {
+		update := &model.ScimUser{
+			Schemas:  []string{model.ScimSchemaUser},
+			UserName: th.SystemAdminUser.Username,
+			Emails:   []model.ScimMultiValue{{Value: th.SystemAdminUser.Email, Primary: true}},
+			Active:   model.NewPointer(true),
+		}
+		resp := doScimRequest(t, scimClient, http.MethodPut, "/Users/"+th.SystemAdminUser.Id, update, nil)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		resp = doScimRequest(t, scimClient, http.MethodDelete, "/Users/"+th.SystemAdminUser.Id, nil, nil)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		user, appErr := th.App.GetUser(th.SystemAdminUser.Id)
+		require.Nil(t, appErr)
+		assert.Empty(t, user.AuthService)
+		assert.Zero(t, user.DeleteAt)
+	})
+
+	var scimGroup model.ScimGroup
+	t.Run("create group", func(t *testing.T) {
+		newGroup := &model.ScimGroup{
+			Schemas:     []string{model.ScimSchemaGroup},
+			ExternalId:  model.NewId(),
+			DisplayName: "Engineering",
+			Members:     []model.ScimMultiValue{{Value: scimUser.Id}, {Value: th.BasicUser.Id}},
+		}
+		resp := doScimRequest(t, scimClient, http.MethodPost, "/Groups", newGroup, &scimGroup)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Len(t, scimGroup.Members, 2)
+
+		group, appErr := th.App.GetGroup(scimGroup.Id, nil, nil)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.GroupSourceScim, group.Source)
+
+		newGroup.ExternalId = model.NewId()
+		newGroup.Members = []model.ScimMultiValue{{Value: model.NewId()}}
+		resp = doScimRequest(t, scimClient, http.MethodPost, "/Groups", newGroup, nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("patch group", func(t *testing.T) {
+		patch := &model.ScimPatchRequest{
+			Schemas: []string{model.ScimSchemaPatchOp},
+			Operations: []model.ScimPatchOperation{
+				{Op: "remove", Path: `members[value eq "` + th.BasicUser.Id + `"]`},
+			},
+		}
+		var patched model.ScimGroup
+		resp := doScimRequest(t, scimClient, http.MethodPatch, "/Groups/"+scimGroup.Id, patch, &patched)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Len(t, patched.Members, 1)
+		assert.Equal(t, scimUser.Id, patched.Members[0].Value)
+
+		members, appErr := th.App.GetGroupMemberUsers(scimGroup.Id)
+		require.Nil(t, appErr)
+		require.Len(t, members, 1)
+		assert.Equal(t, scimUser.Id, members[0].Id)
+	})
+
+	t.Run("list groups without members", func(t *testing.T) {
+		var list model.ScimListResponse
+		resp := doScimRequest(t, scimClient, http.MethodGet, "/Groups?excludedAttributes=members&filter="+url.QueryEscape(`displayName eq "engineering"`), nil, &list)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, list.TotalResults)
+	})
+
+	t.Run("delete group and user", func(t *testing.T) {
+		resp := doScimRequest(t, scimClient, http.MethodDelete, "/Groups/"+scimGroup.Id, nil, nil)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		resp = doScimRequest(t, scimClient, http.MethodGet, "/Groups/"+scimGroup.Id, nil, nil)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp = doScimRequest(t, scimClient, http.MethodDelete, "/Users/"+scimUser.Id, nil, nil)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
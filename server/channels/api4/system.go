@@ -616,6 +616,12 @@ func testS3(c *Context, w http.ResponseWriter, r *http.Request) {
 	if *cfg.FileSettings.AmazonS3SecretAccessKey == model.FakeSetting {
 		cfg.FileSettings.AmazonS3SecretAccessKey = c.App.Config().FileSettings.AmazonS3SecretAccessKey
 	}
+	if *cfg.FileSettings.AzureAccessKey == model.FakeSetting {
+		cfg.FileSettings.AzureAccessKey = c.App.Config().FileSettings.AzureAccessKey
+	}
+	if *cfg.FileSettings.GCSCredentialsJSON == model.FakeSetting {
+		cfg.FileSettings.GCSCredentialsJSON = c.App.Config().FileSettings.GCSCredentialsJSON
+	}
 
 	appErr = c.App.TestFileStoreConnectionWithConfig(&cfg.FileSettings)
 	if appErr != nil {
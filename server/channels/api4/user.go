@@ -1939,6 +1939,12 @@ func login(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 	auditRec.AddEventResultState(user)
 
+	completeLogin(c, w, r, user, deviceId, auditRec)
+}
+
+// completeLogin creates the session of an authenticated user, and writes the
+// user to the response.
+func completeLogin(c *Context, w http.ResponseWriter, r *http.Request, user *model.User, deviceId string, auditRec *audit.Record) {
 	if user.IsGuest() {
 		if c.App.Channels().License() == nil {
 			c.Err = model.NewAppError("login", "api.user.login.guest_accounts.license.error", nil, "", http.StatusUnauthorized)
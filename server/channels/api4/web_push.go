@@ -0,0 +1,69 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+)
+
+func (api *API) InitWebPush() {
+	api.BaseRoutes.Users.Handle("/sessions/web_push/public_key", api.APISessionRequired(getWebPushPublicKey)).Methods(http.MethodGet)
+	api.BaseRoutes.Users.Handle("/sessions/web_push", api.APISessionRequired(attachWebPushSubscription)).Methods(http.MethodPut)
+	api.BaseRoutes.Users.Handle("/sessions/web_push", api.APISessionRequired(detachWebPushSubscription)).Methods(http.MethodDelete)
+}
+
+func getWebPushPublicKey(c *Context, w http.ResponseWriter, r *http.Request) {
+	publicKey, appErr := c.App.GetWebPushPublicKey()
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(publicKey); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func attachWebPushSubscription(c *Context, w http.ResponseWriter, r *http.Request) {
+	var req model.WebPushSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.SetInvalidParamWithErr("subscription", err)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("attachWebPushSubscription", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	subscription, appErr := c.App.SaveWebPushSubscription(c.AppContext, c.AppContext.Session(), &req)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("subscription_id", subscription.Id)
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(subscription); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func detachWebPushSubscription(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord("detachWebPushSubscription", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	if appErr := c.App.DeleteWebPushSubscription(c.AppContext, c.AppContext.Session().Id); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestWebPushSubscription(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	req := &model.WebPushSubscriptionRequest{Endpoint: "https://push.example.com/send/abc"}
+	req.Keys.P256dh = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	req.Keys.Auth = "BTBZMqHH6r4Tts7J_aSIgg"
+
+	t.Run("disabled", func(t *testing.T) {
+		_, resp, err := th.Client.GetWebPushPublicKey(context.Background())
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+
+		_, resp, err = th.Client.AttachWebPushSubscription(context.Background(), req)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.EnableWebPushNotifications = true
+	})
+
+	t.Run("public key", func(t *testing.T) {
+		publicKey, _, err := th.Client.GetWebPushPublicKey(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, publicKey.PublicKey, 87)
+	})
+
+	t.Run("attach and detach", func(t *testing.T) {
+		subscription, resp, err := th.Client.AttachWebPushSubscription(context.Background(), req)
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, th.BasicUser.Id, subscription.UserId)
+		assert.Equal(t, req.Endpoint, subscription.Endpoint)
+
+		subscriptions, err := th.App.Srv().Store().WebPushSubscription().GetForUser(th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, subscriptions, 1)
+
+		_, err = th.Client.DetachWebPushSubscription(context.Background())
+		require.NoError(t, err)
+
+		subscriptions, err = th.App.Srv().Store().WebPushSubscription().GetForUser(th.BasicUser.Id)
+		require.NoError(t, err)
+		assert.Empty(t, subscriptions)
+	})
+
+	t.Run("invalid subscription", func(t *testing.T) {
+		invalid := *req
+		invalid.Endpoint = "http://localhost:8065/internal"
+		_, resp, err := th.Client.AttachWebPushSubscription(context.Background(), &invalid)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("logged out", func(t *testing.T) {
+		client := th.CreateClient()
+		_, resp, err := client.AttachWebPushSubscription(context.Background(), req)
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
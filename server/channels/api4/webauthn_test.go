@@ -0,0 +1,298 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgryski/dgoogauth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestBeginWebAuthnRegistration(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.SiteURL = "https://chat.example.com"
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+		cfg.ServiceSettings.MultifactorAuthenticationMethods = []string{model.MfaMethodTotp}
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		_, resp, err := th.Client.BeginWebAuthnRegistration(context.Background(), th.BasicUser.Id, false)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.ServiceSettings.MultifactorAuthenticationMethods = []string{model.MfaMethodTotp, model.MfaMethodWebAuthn}
+	})
+
+	t.Run("second factor", func(t *testing.T) {
+		options, _, err := th.Client.BeginWebAuthnRegistration(context.Background(), th.BasicUser.Id, false)
+		require.NoError(t, err)
+		assert.Equal(t, "chat.example.com", options.PublicKey.Rp.Id)
+		assert.Equal(t, th.BasicUser.Username, options.PublicKey.User.Name)
+		assert.NotEmpty(t, options.PublicKey.Challenge)
+		assert.NotEmpty(t, options.PublicKey.PubKeyCredParams)
+		assert.Equal(t, "discouraged", options.PublicKey.AuthenticatorSelection.ResidentKey)
+	})
+
+	t.Run("passwordless disabled", func(t *testing.T) {
+		_, resp, err := th.Client.BeginWebAuthnRegistration(context.Background(), th.BasicUser.Id, true)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	t.Run("passwordless", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnablePasswordlessLogin = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnablePasswordlessLogin = false })
+
+		options, _, err := th.Client.BeginWebAuthnRegistration(context.Background(), th.BasicUser.Id, true)
+		require.NoError(t, err)
+		assert.Equal(t, "required", options.PublicKey.AuthenticatorSelection.ResidentKey)
+		assert.Equal(t, model.WebAuthnUserVerificationRequired, options.PublicKey.AuthenticatorSelection.UserVerification)
+	})
+
+	t.Run("other users", func(t *testing.T) {
+		_, resp, err := th.Client.BeginWebAuthnRegistration(context.Background(), th.BasicUser2.Id, false)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.BeginWebAuthnRegistration(context.Background(), th.BasicUser.Id, false)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestFinishWebAuthnRegistration(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.SiteURL = "https://chat.example.com"
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+		cfg.ServiceSettings.MultifactorAuthenticationMethods = []string{model.MfaMethodWebAuthn}
+	})
+
+	t.Run("invalid credential", func(t *testing.T) {
+		_, resp, err := th.Client.FinishWebAuthnRegistration(context.Background(), th.BasicUser.Id, &model.WebAuthnRegistration{Name: "Key"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("unknown challenge", func(t *testing.T) {
+		_, resp, err := th.Client.FinishWebAuthnRegistration(context.Background(), th.BasicUser.Id, &model.WebAuthnRegistration{
+			Name: "Key",
+			Credential: &model.WebAuthnAttestation{
+				Id:   "AQID",
+				Type: "public-key",
+				Response: model.WebAuthnAttestationResponse{
+					// {"type":"webauthn.create","challenge":"AQID","origin":"https://chat.example.com"}
+					ClientDataJSON:    "eyJ0eXBlIjoid2ViYXV0aG4uY3JlYXRlIiwiY2hhbGxlbmdlIjoiQVFJRCIsIm9yaWdpbiI6Imh0dHBzOi8vY2hhdC5leGFtcGxlLmNvbSJ9",
+					AttestationObject: "oA",
+				},
+			},
+		})
+		require.Error(t, err)
+		CheckErrorID(t, err, "app.webauthn.invalid_challenge.app_error")
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
+func TestWebAuthnCredentials(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	credential, err := th.App.Srv().Store().WebAuthnCredential().Save(&model.WebAuthnCredential{
+		UserId:       th.BasicUser.Id,
+		Name:         "Security key",
+		CredentialId: "AQIDBA",
+		PublicKey:    []byte{1},
+	})
+	require.NoError(t, err)
+	require.NoError(t, th.App.Srv().Store().User().UpdateMfaActive(th.BasicUser.Id, true))
+
+	t.Run("get", func(t *testing.T) {
+		credentials, _, err := th.Client.GetWebAuthnCredentials(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+		assert.Equal(t, credential.Id, credentials[0].Id)
+		assert.Equal(t, "Security key", credentials[0].Name)
+
+		_, resp, err := th.Client.GetWebAuthnCredentials(context.Background(), th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("delete another user's credential", func(t *testing.T) {
+		resp, err := th.Client.DeleteWebAuthnCredential(context.Background(), th.BasicUser2.Id, credential.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = th.SystemAdminClient.DeleteWebAuthnCredential(context.Background(), th.BasicUser2.Id, credential.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("deleting the last second factor deactivates mfa", func(t *testing.T) {
+		_, err := th.App.Srv().Store().WebAuthnCredential().Save(&model.WebAuthnCredential{
+			UserId:       th.BasicUser.Id,
+			Name:         "Passkey",
+			CredentialId: "BQYHCA",
+			PublicKey:    []byte{1},
+			Passwordless: true,
+		})
+		require.NoError(t, err)
+
+		_, err = th.Client.DeleteWebAuthnCredential(context.Background(), th.BasicUser.Id, credential.Id)
+		require.NoError(t, err)
+
+		user, appErr := th.App.GetUser(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.False(t, user.MfaActive)
+	})
+}
+
+func TestBeginWebAuthnLogin(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.SiteURL = "https://chat.example.com"
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+	})
+
+	_, err := th.App.Srv().Store().WebAuthnCredential().Save(&model.WebAuthnCredential{
+		UserId:       th.BasicUser.Id,
+		CredentialId: "AQIDBA",
+		PublicKey:    []byte{1},
+	})
+	require.NoError(t, err)
+
+	th.Client.Logout(context.Background())
+
+	t.Run("second factor", func(t *testing.T) {
+		options, _, err := th.Client.GetWebAuthnLoginOptions(context.Background(), th.BasicUser.Email)
+		require.NoError(t, err)
+		require.Len(t, options.PublicKey.AllowCredentials, 1)
+		assert.Equal(t, "AQIDBA", options.PublicKey.AllowCredentials[0].Id)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		options, _, err := th.Client.GetWebAuthnLoginOptions(context.Background(), "unknown@example.com")
+		require.NoError(t, err)
+		assert.Empty(t, options.PublicKey.AllowCredentials)
+		assert.NotEmpty(t, options.PublicKey.Challenge)
+	})
+
+	t.Run("passwordless disabled", func(t *testing.T) {
+		_, resp, err := th.Client.GetWebAuthnLoginOptions(context.Background(), "")
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+
+		_, resp, err = th.Client.LoginWithWebAuthn(context.Background(), &model.WebAuthnAssertion{}, "")
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	t.Run("passwordless", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnablePasswordlessLogin = true })
+
+		options, _, err := th.Client.GetWebAuthnLoginOptions(context.Background(), "")
+		require.NoError(t, err)
+		assert.Empty(t, options.PublicKey.AllowCredentials)
+		assert.Equal(t, model.WebAuthnUserVerificationRequired, options.PublicKey.UserVerification)
+
+		_, resp, err := th.Client.LoginWithWebAuthn(context.Background(), &model.WebAuthnAssertion{Type: "public-key"}, "")
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
+
+func TestMfaRecoveryCodes(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+	})
+
+	t.Run("mfa not active", func(t *testing.T) {
+		_, resp, err := th.Client.GenerateMfaRecoveryCodes(context.Background(), th.BasicUser.Id)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	secret, appErr := th.App.GenerateMfaSecret(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	require.NoError(t, th.Server.Store().User().UpdateMfaActive(th.BasicUser.Id, true))
+
+	t.Run("other users", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GenerateMfaRecoveryCodes(context.Background(), th.BasicUser.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	codes, _, err := th.Client.GenerateMfaRecoveryCodes(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Len(t, codes.Codes, 10)
+
+	status, _, err := th.Client.GetMfaRecoveryCodesStatus(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), status.Remaining)
+
+	t.Run("login with a recovery code", func(t *testing.T) {
+		user, _, err := th.Client.LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, codes.Codes[0])
+		require.NoError(t, err)
+		assert.Equal(t, th.BasicUser.Id, user.Id)
+
+		status, _, err := th.Client.GetMfaRecoveryCodesStatus(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		assert.Equal(t, int64(9), status.Remaining)
+	})
+
+	t.Run("recovery codes are single use", func(t *testing.T) {
+		_, _, err := th.Client.LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, codes.Codes[0])
+		CheckErrorID(t, err, "api.user.check_user_mfa.bad_code.app_error")
+	})
+
+	t.Run("totp still works", func(t *testing.T) {
+		code := dgoogauth.ComputeCode(secret.Secret, time.Now().UTC().Unix()/30)
+		_, _, err := th.Client.LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, fmt.Sprintf("%06d", code))
+		require.NoError(t, err)
+	})
+
+	t.Run("disabled methods are refused", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.ServiceSettings.MultifactorAuthenticationMethods = []string{model.MfaMethodWebAuthn}
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.ServiceSettings.MultifactorAuthenticationMethods = []string{model.MfaMethodTotp}
+		})
+
+		code := dgoogauth.ComputeCode(secret.Secret, time.Now().UTC().Unix()/30)
+		_, _, err := th.Client.LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, fmt.Sprintf("%06d", code))
+		CheckErrorID(t, err, "api.user.check_user_mfa.method_disabled.app_error")
+
+		_, _, err = th.Client.LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, codes.Codes[1])
+		CheckErrorID(t, err, "api.user.check_user_mfa.method_disabled.app_error")
+	})
+
+	t.Run("deactivating mfa deletes the recovery codes", func(t *testing.T) {
+		appErr := th.App.DeactivateMfa(th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		status, _, err := th.Client.GetMfaRecoveryCodesStatus(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), status.Remaining)
+	})
+}
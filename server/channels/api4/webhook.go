@@ -25,6 +25,10 @@ func (api *API) InitWebhook() {
 	api.BaseRoutes.OutgoingHook.Handle("", api.APISessionRequired(updateOutgoingHook)).Methods(http.MethodPut)
 	api.BaseRoutes.OutgoingHook.Handle("", api.APISessionRequired(deleteOutgoingHook)).Methods(http.MethodDelete)
 	api.BaseRoutes.OutgoingHook.Handle("/regen_token", api.APISessionRequired(regenOutgoingHookToken)).Methods(http.MethodPost)
+	api.BaseRoutes.OutgoingHook.Handle("/signing_secret/rotate", api.APISessionRequired(rotateOutgoingHookSigningSecret)).Methods(http.MethodPost)
+	api.BaseRoutes.OutgoingHook.Handle("/signing_secret", api.APISessionRequired(removeOutgoingHookSigningSecret)).Methods(http.MethodDelete)
+	api.BaseRoutes.OutgoingHook.Handle("/deliveries", api.APISessionRequired(getOutgoingHookDeliveries)).Methods(http.MethodGet)
+	api.BaseRoutes.OutgoingHook.Handle("/deliveries/{delivery_id:[A-Za-z0-9]+}/replay", api.APISessionRequired(replayOutgoingHookDelivery)).Methods(http.MethodPost)
 }
 
 func createIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -622,6 +626,78 @@ func regenOutgoingHookToken(c *Context, w http.ResponseWriter, r *http.Request)
 	}
 }
 
+func rotateOutgoingHookSigningSecret(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId()
+	if c.Err != nil {
+		return
+	}
+
+	hook, err := c.App.GetOutgoingWebhook(c.Params.HookId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("rotateOutgoingHookSigningSecret", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "hook_id", hook.Id)
+	auditRec.AddEventPriorState(hook)
+
+	if !checkOutgoingHookPermissions(c, hook) {
+		return
+	}
+
+	rhook, err := c.App.RotateOutgoingWebhookSigningSecret(hook)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.AddEventResultState(rhook)
+	auditRec.AddEventObjectType("outgoing_webhook")
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(rhook); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func removeOutgoingHookSigningSecret(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId()
+	if c.Err != nil {
+		return
+	}
+
+	hook, err := c.App.GetOutgoingWebhook(c.Params.HookId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("removeOutgoingHookSigningSecret", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "hook_id", hook.Id)
+	auditRec.AddEventPriorState(hook)
+
+	if !checkOutgoingHookPermissions(c, hook) {
+		return
+	}
+
+	rhook, err := c.App.RemoveOutgoingWebhookSigningSecret(hook)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.AddEventResultState(rhook)
+	auditRec.AddEventObjectType("outgoing_webhook")
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(rhook); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func deleteOutgoingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireHookId()
 	if c.Err != nil {
@@ -665,3 +741,106 @@ func deleteOutgoingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	ReturnStatusOK(w)
 }
+
+// checkOutgoingHookPermissions verifies that the session can manage the given
+// outgoing webhook.
+func checkOutgoingHookPermissions(c *Context, hook *model.OutgoingWebhook) bool {
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), hook.TeamId, model.PermissionManageOutgoingWebhooks) {
+		c.SetPermissionError(model.PermissionManageOutgoingWebhooks)
+		return false
+	}
+
+	if c.AppContext.Session().UserId != hook.CreatorId && !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), hook.TeamId, model.PermissionManageOthersOutgoingWebhooks) {
+		c.SetPermissionError(model.PermissionManageOthersOutgoingWebhooks)
+		return false
+	}
+
+	return true
+}
+
+func getOutgoingHookDeliveries(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId()
+	if c.Err != nil {
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", model.OutgoingWebhookDeliveryStatusPending, model.OutgoingWebhookDeliveryStatusSuccess, model.OutgoingWebhookDeliveryStatusFailed:
+	default:
+		c.SetInvalidURLParam("status")
+		return
+	}
+
+	hook, appErr := c.App.GetOutgoingWebhook(c.Params.HookId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if !checkOutgoingHookPermissions(c, hook) {
+		return
+	}
+
+	deliveries, appErr := c.App.GetOutgoingWebhookDeliveries(hook.Id, model.OutgoingWebhookDeliveryGetOptions{
+		Status:  status,
+		Page:    c.Params.Page,
+		PerPage: c.Params.PerPage,
+	})
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(deliveries); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func replayOutgoingHookDelivery(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId().RequireDeliveryId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("replayOutgoingHookDelivery", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "hook_id", c.Params.HookId)
+	audit.AddEventParameter(auditRec, "delivery_id", c.Params.DeliveryId)
+
+	hook, appErr := c.App.GetOutgoingWebhook(c.Params.HookId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if !checkOutgoingHookPermissions(c, hook) {
+		return
+	}
+
+	delivery, appErr := c.App.GetOutgoingWebhookDelivery(c.Params.DeliveryId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if delivery.HookId != hook.Id {
+		c.Err = model.NewAppError("replayOutgoingHookDelivery", "app.outgoing_webhook_delivery.get.app_error", nil, "", http.StatusNotFound)
+		return
+	}
+	auditRec.AddEventPriorState(delivery)
+
+	delivery, appErr = c.App.ReplayOutgoingWebhookDelivery(c.AppContext, delivery)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(delivery)
+	auditRec.AddEventObjectType("outgoing_webhook_delivery")
+
+	if err := json.NewEncoder(w).Encode(delivery); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
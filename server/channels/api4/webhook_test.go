@@ -1048,6 +1048,59 @@ func TestRegenOutgoingHookToken(t *testing.T) {
 	CheckNotImplementedStatus(t, resp)
 }
 
+func TestRotateOutgoingHookSigningSecret(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = true })
+
+	hook := &model.OutgoingWebhook{ChannelId: th.BasicChannel.Id, TeamId: th.BasicChannel.TeamId, CallbackURLs: []string{"http://nowhere.com"}}
+	rhook, _, err := th.SystemAdminClient.CreateOutgoingWebhook(context.Background(), hook)
+	require.NoError(t, err)
+	require.Empty(t, rhook.SigningSecret)
+
+	rotatedHook, _, err := th.SystemAdminClient.RotateOutgoingWebhookSigningSecret(context.Background(), rhook.Id)
+	require.NoError(t, err)
+	require.NotEmpty(t, rotatedHook.SigningSecret)
+
+	rotatedAgainHook, _, err := th.SystemAdminClient.RotateOutgoingWebhookSigningSecret(context.Background(), rhook.Id)
+	require.NoError(t, err)
+	require.NotEqual(t, rotatedHook.SigningSecret, rotatedAgainHook.SigningSecret)
+	require.Greater(t, rotatedAgainHook.PreviousSigningSecretExpireAt, model.GetMillis())
+
+	t.Run("updating the hook keeps the signing secrets", func(t *testing.T) {
+		rotatedAgainHook.DisplayName = "signed"
+		rotatedAgainHook.SigningSecret = ""
+		_, _, err = th.SystemAdminClient.UpdateOutgoingWebhook(context.Background(), rotatedAgainHook)
+		require.NoError(t, err)
+
+		fetchedHook, appErr := th.App.GetOutgoingWebhook(rhook.Id)
+		require.Nil(t, appErr)
+		require.Equal(t, "signed", fetchedHook.DisplayName)
+		require.Len(t, fetchedHook.SigningSecrets(), 2)
+		require.Equal(t, rotatedHook.SigningSecret, fetchedHook.PreviousSigningSecret)
+	})
+
+	_, resp, err := client.RotateOutgoingWebhookSigningSecret(context.Background(), rhook.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp, err = client.RemoveOutgoingWebhookSigningSecret(context.Background(), rhook.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	removedHook, _, err := th.SystemAdminClient.RemoveOutgoingWebhookSigningSecret(context.Background(), rhook.Id)
+	require.NoError(t, err)
+	require.Empty(t, removedHook.SigningSecret)
+	require.Zero(t, removedHook.PreviousSigningSecretExpireAt)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = false })
+	_, resp, err = th.SystemAdminClient.RotateOutgoingWebhookSigningSecret(context.Background(), rhook.Id)
+	require.Error(t, err)
+	CheckNotImplementedStatus(t, resp)
+}
+
 func TestUpdateOutgoingHook(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -1331,3 +1384,88 @@ func TestDeleteOutgoingHook(t *testing.T) {
 		CheckForbiddenStatus(t, resp)
 	})
 }
+
+func TestGetOutgoingHookDeliveries(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = true })
+
+	hook := &model.OutgoingWebhook{ChannelId: th.BasicChannel.Id, TeamId: th.BasicChannel.TeamId, CallbackURLs: []string{"http://nowhere.com"}}
+	rhook, _, err := th.SystemAdminClient.CreateOutgoingWebhook(context.Background(), hook)
+	require.NoError(t, err)
+
+	failed, err := th.App.Srv().Store().OutgoingWebhookDelivery().Save(&model.OutgoingWebhookDelivery{
+		HookId:      rhook.Id,
+		ChannelId:   th.BasicChannel.Id,
+		PostId:      th.BasicPost.Id,
+		CallbackURL: "http://nowhere.com",
+		Status:      model.OutgoingWebhookDeliveryStatusFailed,
+		Payload:     "token=secret",
+	})
+	require.NoError(t, err)
+	_, err = th.App.Srv().Store().OutgoingWebhookDelivery().Save(&model.OutgoingWebhookDelivery{
+		HookId:      rhook.Id,
+		ChannelId:   th.BasicChannel.Id,
+		PostId:      th.BasicPost.Id,
+		CallbackURL: "http://nowhere.com",
+		Status:      model.OutgoingWebhookDeliveryStatusSuccess,
+	})
+	require.NoError(t, err)
+
+	deliveries, _, err := th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), rhook.Id, "", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	for _, delivery := range deliveries {
+		assert.Empty(t, delivery.Payload, "the payload should not be exposed")
+	}
+
+	deliveries, _, err = th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), rhook.Id, model.OutgoingWebhookDeliveryStatusFailed, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, failed.Id, deliveries[0].Id)
+
+	_, resp, err := th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), rhook.Id, "unknown", 0, 10)
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp, err = th.Client.GetOutgoingWebhookDeliveries(context.Background(), rhook.Id, "", 0, 10)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+}
+
+func TestReplayOutgoingHookDelivery(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = true })
+
+	hook := &model.OutgoingWebhook{ChannelId: th.BasicChannel.Id, TeamId: th.BasicChannel.TeamId, CallbackURLs: []string{"http://nowhere.com"}}
+	rhook, _, err := th.SystemAdminClient.CreateOutgoingWebhook(context.Background(), hook)
+	require.NoError(t, err)
+
+	otherHook := &model.OutgoingWebhook{ChannelId: th.BasicChannel.Id, TeamId: th.BasicChannel.TeamId, CallbackURLs: []string{"http://nowhere.com"}, TriggerWords: []string{"other"}}
+	rOtherHook, _, err := th.SystemAdminClient.CreateOutgoingWebhook(context.Background(), otherHook)
+	require.NoError(t, err)
+
+	delivery, err := th.App.Srv().Store().OutgoingWebhookDelivery().Save(&model.OutgoingWebhookDelivery{
+		HookId:      rhook.Id,
+		ChannelId:   th.BasicChannel.Id,
+		PostId:      th.BasicPost.Id,
+		CallbackURL: "http://nowhere.com",
+		Status:      model.OutgoingWebhookDeliveryStatusSuccess,
+	})
+	require.NoError(t, err)
+
+	_, resp, err := th.Client.ReplayOutgoingWebhookDelivery(context.Background(), rhook.Id, delivery.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, resp, err = th.SystemAdminClient.ReplayOutgoingWebhookDelivery(context.Background(), rOtherHook.Id, delivery.Id)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+
+	_, resp, err = th.SystemAdminClient.ReplayOutgoingWebhookDelivery(context.Background(), rhook.Id, delivery.Id)
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/services/antivirus"
+)
+
+// markFileForScan quarantines the file until it is scanned when antivirus
+// scanning is enabled. It must be called before the FileInfo is saved.
+func (a *App) markFileForScan(info *model.FileInfo) {
+	if *a.Config().FileSettings.EnableAntivirusScan {
+		info.ScanStatus = model.FileScanStatusPending
+	}
+}
+
+// CheckFileNotQuarantined returns an error if the contents of the file can't be
+// served because the antivirus scan hasn't reported it clean.
+func CheckFileNotQuarantined(info *model.FileInfo) *model.AppError {
+	if info.IsQuarantined() {
+		return model.NewAppError("CheckFileNotQuarantined", "app.file.quarantined.app_error", map[string]any{"ScanStatus": info.ScanStatus}, "file_id="+info.Id, http.StatusForbidden)
+	}
+	return nil
+}
+
+// scanFileInBackground scans a file previously marked for scanning once it has
+// been saved.
+func (a *App) scanFileInBackground(rctx request.CTX, info *model.FileInfo) {
+	if info.ScanStatus != model.FileScanStatusPending {
+		return
+	}
+
+	infoCopy := *info
+	a.Srv().Go(func() {
+		if _, appErr := a.ScanFile(rctx, &infoCopy); appErr != nil {
+			rctx.Logger().Error("Failed to scan file", mlog.String("file_info_id", infoCopy.Id), mlog.Err(appErr))
+		}
+	})
+}
+
+// ScanFile streams the contents of the file to the configured antivirus
+// scanner and stores the verdict on the FileInfo. Files the scanner could not
+// process are marked as failed and stay quarantined. Infected files are
+// reported to the system admins.
+func (a *App) ScanFile(rctx request.CTX, info *model.FileInfo) (string, *model.AppError) {
+	settings := a.Config().FileSettings
+	scanner, err := antivirus.NewScanner(antivirus.ScanSettings{
+		URL:     *settings.AntivirusScanURL,
+		Timeout: time.Duration(*settings.AntivirusScanTimeoutSeconds) * time.Second,
+	})
+	if err != nil {
+		return "", model.NewAppError("ScanFile", "app.file.antivirus.scanner.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	status := model.FileScanStatusClean
+	result, err := a.scanFileContents(rctx, scanner, info)
+	if err != nil {
+		rctx.Logger().Warn("Antivirus scan failed, the file stays quarantined", mlog.String("file_info_id", info.Id), mlog.Err(err))
+		status = model.FileScanStatusFailed
+	} else if !result.Clean {
+		status = model.FileScanStatusInfected
+	}
+
+	if err := a.Srv().Store().FileInfo().UpdateScanStatus(info.Id, status); err != nil {
+		return "", model.NewAppError("ScanFile", "app.file_info.update_scan_status.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	previousStatus := info.ScanStatus
+	info.ScanStatus = status
+
+	reloadFileInfo, err := a.Srv().Store().FileInfo().GetFromMaster(info.Id)
+	if err != nil {
+		rctx.Logger().Warn("Failed to invalidate the fileInfo cache.", mlog.Err(err), mlog.String("file_info_id", info.Id))
+	} else if reloadFileInfo.PostId != "" {
+		a.Srv().Store().FileInfo().InvalidateFileInfosForPostCache(reloadFileInfo.PostId, false)
+	}
+
+	// Rescans of a file already known to be infected don't notify the admins
+	// again.
+	if status == model.FileScanStatusInfected && previousStatus != model.FileScanStatusInfected {
+		rctx.Logger().Warn("Antivirus scan found an infected file",
+			mlog.String("file_info_id", info.Id),
+			mlog.String("creator_id", info.CreatorId),
+			mlog.String("signature", result.Signature),
+		)
+		a.notifyAdminsOfInfectedFile(rctx, info, result.Signature)
+	}
+
+	return status, nil
+}
+
+func (a *App) scanFileContents(rctx request.CTX, scanner antivirus.Scanner, info *model.FileInfo) (*antivirus.Result, error) {
+	file, appErr := a.FileReader(info.Path)
+	if appErr != nil {
+		return nil, appErr
+	}
+	defer file.Close()
+
+	return scanner.Scan(rctx.Context(), file)
+}
+
+// notifyAdminsOfInfectedFile sends a direct message from the system bot to
+// every system admin.
+func (a *App) notifyAdminsOfInfectedFile(rctx request.CTX, info *model.FileInfo, signature string) {
+	systemBot, appErr := a.GetSystemBot(rctx)
+	if appErr != nil {
+		rctx.Logger().Warn("Failed to get the system bot", mlog.Err(appErr))
+		return
+	}
+
+	uploader := info.CreatorId
+	if user, appErr := a.GetUser(info.CreatorId); appErr == nil {
+		uploader = "@" + user.Username
+	}
+
+	userOptions := &model.UserGetOptions{
+		Page:     0,
+		PerPage:  100,
+		Role:     model.SystemAdminRoleId,
+		Inactive: false,
+	}
+	for {
+		sysAdmins, appErr := a.GetUsersFromProfiles(userOptions)
+		if appErr != nil {
+			rctx.Logger().Warn("Failed to get system admins", mlog.Err(appErr))
+			return
+		}
+
+		for _, sysAdmin := range sysAdmins {
+			channel, appErr := a.GetOrCreateDirectChannel(rctx, systemBot.UserId, sysAdmin.Id)
+			if appErr != nil {
+				rctx.Logger().Warn("Error getting direct channel", mlog.Err(appErr))
+				continue
+			}
+
+			T := i18n.GetUserTranslations(sysAdmin.Locale)
+			post := &model.Post{
+				UserId:    systemBot.UserId,
+				ChannelId: channel.Id,
+				Message: T("app.file.antivirus.infected_file_notification", map[string]any{
+					"Filename":  info.Name,
+					"FileId":    info.Id,
+					"Uploader":  uploader,
+					"Signature": signature,
+				}),
+				Type: model.PostTypeSystemGeneric,
+			}
+			if _, appErr := a.CreatePost(rctx, post, channel, model.CreatePostFlags{SetOnline: true}); appErr != nil {
+				rctx.Logger().Warn("Error creating post", mlog.Err(appErr))
+			}
+		}
+
+		if len(sysAdmins) < userOptions.PerPage {
+			return
+		}
+		userOptions.Page++
+	}
+}
@@ -44,6 +44,9 @@ type AppIface interface {
 	ListAutocompleteCommands(teamID string, T i18n.TranslateFunc) ([]*model.Command, *model.AppError)
 	// @openTracingParams teamID, skipSlackParsing
 	CreateCommandPost(c request.CTX, post *model.Post, teamID string, response *model.CommandResponse, skipSlackParsing bool) (*model.Post, *model.AppError)
+	// AcceptReplyByEmailRecipient checks an inbound email is sent to a reply
+	// address.
+	AcceptReplyByEmailRecipient(address string) error
 	// AddChannelMember adds a user to a channel. It is a wrapper over AddUserToChannel.
 	AddChannelMember(c request.CTX, userID string, channel *model.Channel, opts ChannelMemberOpts) (*model.ChannelMember, *model.AppError)
 	// AddCursorIdsForPostList adds NextPostId and PrevPostId as cursor to the PostList.
@@ -54,6 +57,18 @@ type AppIface interface {
 	AddPublicKey(name string, key io.Reader) *model.AppError
 	// AddUserToChannel adds a user to a given channel.
 	AddUserToChannel(c request.CTX, user *model.User, channel *model.Channel, skipTeamMemberIntegrityCheck bool) (*model.ChannelMember, *model.AppError)
+	// AuthenticateUserWithWebAuthn authenticates an email user with a
+	// passwordless credential. The user verification performed by the
+	// authenticator stands in for both the password and the second factor.
+	AuthenticateUserWithWebAuthn(c request.CTX, assertion *model.WebAuthnAssertion) (*model.User, *model.AppError)
+	// BeginWebAuthnLogin returns the options the client passes to
+	// navigator.credentials.get to log in. With a login ID, the assertion is a
+	// second factor sent as the MFA token of the login request. Without one, it
+	// logs the user in without a password through LoginWithWebAuthn.
+	BeginWebAuthnLogin(c request.CTX, loginID string) (*model.WebAuthnRequestOptions, *model.AppError)
+	// BeginWebAuthnRegistration returns the options the client passes to
+	// navigator.credentials.create to register a credential for the user.
+	BeginWebAuthnRegistration(c request.CTX, userID string, passwordless bool) (*model.WebAuthnCreationOptions, *model.AppError)
 	// Caller must close the first return value
 	ExportFileReader(path string) (filestore.ReadCloseSeeker, *model.AppError)
 	// Caller must close the first return value
@@ -75,6 +90,8 @@ type AppIface interface {
 	// overriding attributes set by the user's login provider; otherwise, the name of the offending
 	// field is returned.
 	CheckProviderAttributes(c request.CTX, user *model.User, patch *model.UserPatch) string
+	// ClosePoll stops a poll from accepting votes.
+	ClosePoll(c request.CTX, pollID, userID string) (*model.Poll, *model.AppError)
 	// CommandsForTeam returns all the plugin commands for the given team.
 	CommandsForTeam(teamID string) []*model.Command
 	// ComputeLastAccessibleFileTime updates cache with CreateAt time of the last accessible file as per the cloud plan's limit.
@@ -102,6 +119,21 @@ type AppIface interface {
 	// CreateGuest creates a guest and sets several fields of the returned User struct to
 	// their zero values.
 	CreateGuest(c request.CTX, user *model.User) (*model.User, *model.AppError)
+	// CreateLegalHold saves a legal hold after checking that its custodians and
+	// channels exist. Archived channels and deactivated users can be held.
+	CreateLegalHold(rctx request.CTX, hold *model.LegalHold) (*model.LegalHold, *model.AppError)
+	// CreateLegalHoldExportJob starts a job exporting the content held by a legal
+	// hold in one of the compliance export formats.
+	CreateLegalHoldExportJob(rctx request.CTX, holdID string, exportType string) (*model.Job, *model.AppError)
+	// CreatePoll creates a poll along with the post that holds it. The poll must
+	// have its ChannelId and UserId set.
+	CreatePoll(c request.CTX, poll *model.Poll, rootID string) (*model.Poll, *model.AppError)
+	// CreateReminder saves a reminder after checking that its creator is allowed
+	// to send messages to its target.
+	CreateReminder(rctx request.CTX, reminder *model.Reminder) (*model.Reminder, *model.AppError)
+	// CreateSavedSearch saves a search of the user, after checking that the user
+	// is a member of the team the search is restricted to.
+	CreateSavedSearch(rctx request.CTX, savedSearch *model.SavedSearch) (*model.SavedSearch, *model.AppError)
 	// CreateUser creates a user and sets several fields of the returned User struct to
 	// their zero values.
 	CreateUser(c request.CTX, user *model.User) (*model.User, *model.AppError)
@@ -127,6 +159,19 @@ type AppIface interface {
 	DeletePersistentNotification(c request.CTX, post *model.Post) *model.AppError
 	// DeletePublicKey will delete plugin public key from the config.
 	DeletePublicKey(name string) *model.AppError
+	// DeleteReminder deletes a reminder created by userID.
+	DeleteReminder(rctx request.CTX, userID, reminderID string) (*model.Reminder, *model.AppError)
+	// DeleteSavedSearch deletes a saved search of userID.
+	DeleteSavedSearch(rctx request.CTX, userID, savedSearchID string) (*model.SavedSearch, *model.AppError)
+	// DeleteScimUser deactivates a user deprovisioned by the identity provider.
+	// The user is kept so that their content and memberships are preserved.
+	DeleteScimUser(rctx request.CTX, userID string) *model.AppError
+	// DeleteWebAuthnCredential deletes a credential of the user. Deleting the
+	// last second factor of a user deactivates MFA.
+	DeleteWebAuthnCredential(c request.CTX, userID, credentialID string) *model.AppError
+	// DeleteWebPushSubscription stops sending push notifications to the browser
+	// of the session.
+	DeleteWebPushSubscription(c request.CTX, sessionID string) *model.AppError
 	// DemoteUserToGuest Convert user's roles and all his membership's roles from
 	// regular user roles to guest roles.
 	DemoteUserToGuest(c request.CTX, user *model.User) *model.AppError
@@ -164,6 +209,13 @@ type AppIface interface {
 	// FilterNonGroupTeamMembers returns the subset of the given user IDs of the users who are not members of groups
 	// associated to the team excluding bots.
 	FilterNonGroupTeamMembers(userIDs []string, team *model.Team) ([]string, error)
+	// FinishWebAuthnRegistration verifies the credential created by the
+	// authenticator and saves it. The first second factor credential of a user
+	// activates MFA, while passwordless credentials leave it unchanged.
+	FinishWebAuthnRegistration(c request.CTX, userID string, registration *model.WebAuthnRegistration) (*model.WebAuthnCredential, *model.AppError)
+	// GenerateMfaRecoveryCodes replaces the recovery codes of the user with new
+	// ones. They are only returned once.
+	GenerateMfaRecoveryCodes(userID string) (*model.MfaRecoveryCodes, *model.AppError)
 	// GetAllLdapGroupsPage retrieves all LDAP groups under the configured base DN using the default or configured group
 	// filter.
 	GetAllLdapGroupsPage(rctx request.CTX, page int, perPage int, opts model.LdapGroupSearchOpts) ([]*model.Group, int, *model.AppError)
@@ -179,6 +231,16 @@ type AppIface interface {
 	GetClusterPluginStatuses() (model.PluginStatuses, *model.AppError)
 	// GetConfigFile proxies access to the given configuration file to the underlying config store.
 	GetConfigFile(name string) ([]byte, error)
+	// GetConfigHistory returns a page of the versions of the configuration kept in the
+	// database, newest first.
+	GetConfigHistory(page, perPage int) ([]*model.ConfigVersion, *model.AppError)
+	// GetConfigVersion returns a version of the configuration kept in the database, along
+	// with its value. The value does not include environment overrides.
+	GetConfigVersion(versionID string) (*model.ConfigVersion, *model.Config, *model.AppError)
+	// GetConfigVersionDiff returns the settings changed from the compareTo version of the
+	// configuration to the given one, or from the version that preceded it when compareTo
+	// is empty. Sensitive values are masked.
+	GetConfigVersionDiff(versionID, compareTo string) (*model.ConfigVersionDiff, *model.AppError)
 	// GetEmojiStaticURL returns a relative static URL for system default emojis,
 	// and the API route for custom ones. Errors if not found or if custom and deleted.
 	GetEmojiStaticURL(c request.CTX, emojiName string) (string, *model.AppError)
@@ -191,6 +253,9 @@ type AppIface interface {
 	GetFilteredUsersStats(options *model.UserCountOptions) (*model.UsersStats, *model.AppError)
 	// GetGroupsByTeam returns the paged list and the total count of group associated to the given team.
 	GetGroupsByTeam(teamID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, int, *model.AppError)
+	// GetImportValidationReport returns the report written by a dry-run of an
+	// import job.
+	GetImportValidationReport(c request.CTX, jobID string) (*model.ImportValidationReport, *model.AppError)
 	// GetKnownUsers returns the list of user ids of users with any direct
 	// relationship with a user. That means any user sharing any channel, including
 	// direct and group channels.
@@ -202,6 +267,9 @@ type AppIface interface {
 	GetLastAccessibleFileTime() (int64, *model.AppError)
 	// GetLastAccessiblePostTime returns CreateAt time(from cache) of the last accessible post as per the cloud limit
 	GetLastAccessiblePostTime() (int64, *model.AppError)
+	// GetLastTriggeredReminder returns the reminder most recently delivered to the
+	// given user.
+	GetLastTriggeredReminder(userID string) (*model.Reminder, *model.AppError)
 	// GetLdapGroup retrieves a single LDAP group by the given LDAP group id.
 	GetLdapGroup(rctx request.CTX, ldapGroupID string) (*model.Group, *model.AppError)
 	// GetMarketplacePlugins returns a list of plugins from the marketplace-server,
@@ -217,6 +285,18 @@ type AppIface interface {
 	// To get the plugins environment when the plugins are disabled, manually acquire the plugins
 	// lock instead.
 	GetPluginsEnvironment() *plugin.Environment
+	// GetPoll returns a poll with its results and the votes of the given user.
+	GetPoll(pollID, userID string) (*model.Poll, *model.AppError)
+	// GetPollForPost returns the poll held by a post with its results and the
+	// votes of the given user.
+	GetPollForPost(postID, userID string) (*model.Poll, *model.AppError)
+	// GetPostEditHistory returns the previous versions of the post, newest first,
+	// each with the changes made by the edit which replaced it.
+	GetPostEditHistory(rctx request.CTX, postID string) (*model.PostEditHistory, *model.AppError)
+	// GetPostReadReceipts returns the members of the post's channel who have viewed
+	// it since the post was created. A member's last viewed time is all that is
+	// needed, so no state is stored per post.
+	GetPostReadReceipts(rctx request.CTX, post *model.Post) (*model.PostReadReceipts, *model.AppError)
 	// GetPostsByIds response bool value indicates, if the post is inaccessible due to cloud plan's limit.
 	GetPostsByIds(postIDs []string) ([]*model.Post, int64, *model.AppError)
 	// GetPostsUsage returns the total posts count rounded down to the most
@@ -228,10 +308,25 @@ type AppIface interface {
 	GetProfileImagePath(user *model.User) (string, *model.AppError)
 	// GetPublicKey will return the actual public key saved in the `name` file.
 	GetPublicKey(name string) ([]byte, *model.AppError)
+	// GetRemindersForUser returns the reminders created by the given user.
+	GetRemindersForUser(userID string) ([]*model.Reminder, *model.AppError)
+	// GetReplyByEmailAddress returns the address the user can reply to, to
+	// respond in the thread of the post. It returns an empty string if replying
+	// by email is disabled.
+	GetReplyByEmailAddress(userID string, post *model.Post) string
 	// GetSanitizedConfig gets the configuration for a system admin without any secrets.
 	GetSanitizedConfig() *model.Config
+	// GetSavedSearchesForUser returns the saved searches of the given user,
+	// ordered by name.
+	GetSavedSearchesForUser(userID string) ([]*model.SavedSearch, *model.AppError)
 	// GetSchemeRolesForChannel Checks if a channel or its team has an override scheme for channel roles and returns the scheme roles or default channel roles.
 	GetSchemeRolesForChannel(c request.CTX, channelID string) (guestRoleName string, userRoleName string, adminRoleName string, err *model.AppError)
+	// GetScimGroups returns the page of SCIM groups matching the given filter,
+	// starting at the 1-based startIndex.
+	GetScimGroups(filter string, startIndex, count int, excludeMembers bool) (*model.ScimListResponse, *model.AppError)
+	// GetScimUsers returns the page of users matching the given SCIM filter,
+	// starting at the 1-based startIndex.
+	GetScimUsers(rctx request.CTX, filter string, startIndex, count int) (*model.ScimListResponse, *model.AppError)
 	// GetSessionLengthInMillis returns the session length, in milliseconds,
 	// based on the type of session (Mobile, SSO, Web/LDAP).
 	GetSessionLengthInMillis(session *model.Session) int64
@@ -247,6 +342,9 @@ type AppIface interface {
 	GetTotalUsersStats(viewRestrictions *model.ViewUsersRestrictions) (*model.UsersStats, *model.AppError)
 	// GetUserStatusesByIds used by apiV4
 	GetUserStatusesByIds(userIDs []string) ([]*model.Status, *model.AppError)
+	// GetWebPushPublicKey returns the VAPID public key browsers must subscribe
+	// with.
+	GetWebPushPublicKey() (*model.WebPushPublicKey, *model.AppError)
 	// HasRemote returns whether a given channelID is present in the channel remotes or not.
 	HasRemote(channelID string, remoteID string) (bool, error)
 	// HubRegister registers a connection to a hub.
@@ -294,6 +392,15 @@ type AppIface interface {
 	PatchBot(rctx request.CTX, botUserId string, botPatch *model.BotPatch) (*model.Bot, *model.AppError)
 	// PatchChannelModerationsForChannel Updates a channels scheme roles based on a given ChannelModerationPatch, if the permissions match the higher scoped role the scheme is deleted.
 	PatchChannelModerationsForChannel(c request.CTX, channel *model.Channel, channelModerationsPatch []*model.ChannelModerationPatch) ([]*model.ChannelModeration, *model.AppError)
+	// PatchLegalHold updates an active legal hold. Content which is no longer
+	// covered by the hold becomes subject to data retention again.
+	PatchLegalHold(rctx request.CTX, holdID string, patch *model.LegalHoldPatch) (*model.LegalHold, *model.AppError)
+	// PatchSavedSearch changes a saved search of userID.
+	PatchSavedSearch(rctx request.CTX, userID, savedSearchID string, patch *model.SavedSearchPatch) (*model.SavedSearch, *model.AppError)
+	// PatchScimGroup applies the operations of a SCIM PATCH request to a group.
+	PatchScimGroup(rctx request.CTX, groupID string, operations []model.ScimPatchOperation) (*model.ScimGroup, *model.AppError)
+	// PatchScimUser applies the operations of a SCIM PATCH request to a user.
+	PatchScimUser(rctx request.CTX, userID string, operations []model.ScimPatchOperation) (*model.ScimUser, *model.AppError)
 	// Perform an HTTP POST request to an integration's action endpoint.
 	// Caller must consume and close returned http.Response as necessary.
 	// For internal requests, requests are routed directly to a plugin ServerHTTP hook
@@ -303,27 +410,82 @@ type AppIface interface {
 	// PopulateWebConnConfig checks if the connection id already exists in the hub,
 	// and if so, accordingly populates the other fields of the webconn.
 	PopulateWebConnConfig(s *model.Session, cfg *platform.WebConnConfig, seqVal string) (*platform.WebConnConfig, error)
+	// ProcessOutgoingWebhookDeliveries retries the pending deliveries that are
+	// due and deletes the delivery history older than the configured retention.
+	ProcessOutgoingWebhookDeliveries(rctx request.CTX) error
+	// ProcessReminders delivers every reminder that is due and deletes the ones
+	// completed long enough ago.
+	ProcessReminders(rctx request.CTX) error
+	// ProcessSavedSearchAlerts checks the saved searches with alerts for new
+	// matches when post searches run on the database.
+	ProcessSavedSearchAlerts(rctx request.CTX) error
+	// ProcessScheduledPosts publishes every scheduled post that is due. Posts
+	// that can no longer be published are marked as processed with an error code
+	// so that their author can see why they were not sent.
+	ProcessScheduledPosts(rctx request.CTX) error
 	// PromoteGuestToUser Convert user's roles and all his membership's roles from
 	// guest roles to regular user roles.
 	PromoteGuestToUser(c request.CTX, user *model.User, requestorId string) *model.AppError
+	// PublishWithContext publishes the event like Publish, propagating the trace
+	// context of the request to the other cluster nodes.
+	PublishWithContext(rctx request.CTX, message *model.WebSocketEvent)
 	// ReattachPlugin allows the server to bind to an existing plugin instance launched elsewhere.
 	ReattachPlugin(manifest *model.Manifest, pluginReattachConfig *model.PluginReattachConfig) *model.AppError
+	// ReceiveReplyByEmail posts the reply to a notification email in the thread
+	// of the post it was sent for. The reply is posted as the user the email was
+	// sent to, provided the email comes from their address: both the envelope
+	// sender and the From header must be that address, and one of the trusted
+	// MTAs must have authenticated it with DKIM or SPF.
+	ReceiveReplyByEmail(rctx request.CTX, sender, recipient string, data []byte) error
+	// ReleaseLegalHold stops a legal hold from preserving its content, which
+	// becomes subject to data retention again. The hold itself is kept so that
+	// its content can still be exported until it gets deleted.
+	ReleaseLegalHold(holdID string) (*model.LegalHold, *model.AppError)
+	// RemoveCommandSigningSecret stops the signing of the requests of the command.
+	RemoveCommandSigningSecret(cmd *model.Command) (*model.Command, *model.AppError)
+	// RemoveOutgoingWebhookSigningSecret stops the signing of the requests of the hook.
+	RemoveOutgoingWebhookSigningSecret(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError)
 	// Removes a listener function by the unique ID returned when AddConfigListener was called
 	RemoveConfigListener(id string)
 	// RenameChannel is used to rename the channel Name and the DisplayName fields
 	RenameChannel(c request.CTX, channel *model.Channel, newChannelName string, newDisplayName string) (*model.Channel, *model.AppError)
 	// RenameTeam is used to rename the team Name and the DisplayName fields
 	RenameTeam(team *model.Team, newTeamName string, newDisplayName string) (*model.Team, *model.AppError)
+	// ReplayOutgoingWebhookDelivery immediately attempts a failed delivery again.
+	// If the attempt fails, the delivery is retried with the configured backoff
+	// as if it was a new delivery.
+	ReplayOutgoingWebhookDelivery(c request.CTX, delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, *model.AppError)
 	// ResolvePersistentNotification stops the persistent notifications, if a loggedInUserID(except the post owner) reacts, reply or ack on the post.
 	// Post-owner can only delete the original post to stop the notifications.
 	ResolvePersistentNotification(c request.CTX, post *model.Post, loggedInUserID string) *model.AppError
 	// RevokeSessionsFromAllUsers will go through all the sessions active
 	// in the server and revoke them
 	RevokeSessionsFromAllUsers() *model.AppError
+	// RotateCommandSigningSecret generates a new signing secret for the command,
+	// enabling the signing of its requests if needed. The previous secret keeps
+	// being used for the configured grace period.
+	RotateCommandSigningSecret(cmd *model.Command) (*model.Command, *model.AppError)
+	// RotateOutgoingWebhookSigningSecret generates a new signing secret for the
+	// hook, enabling the signing of its requests if needed. The previous secret
+	// keeps being used for the configured grace period.
+	RotateOutgoingWebhookSigningSecret(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError)
+	// RunSavedSearch searches the posts matching a saved search, as its user.
+	RunSavedSearch(rctx request.CTX, savedSearch *model.SavedSearch, page, perPage int) (*model.PostSearchResults, *model.AppError)
 	// SanitizedConfig sanitizes a given configuration for a system admin without any secrets.
 	SanitizedConfig(cfg *model.Config)
 	// SaveConfig replaces the active configuration, optionally notifying cluster peers.
 	SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool) (*model.Config, *model.Config, *model.AppError)
+	// SaveConfigWithAuthor replaces the active configuration, optionally notifying cluster peers,
+	// and records the author of the change in the configuration history.
+	SaveConfigWithAuthor(newCfg *model.Config, sendConfigChangeClusterMessage bool, author *model.ConfigChangeAuthor) (*model.Config, *model.Config, *model.AppError)
+	// SaveWebPushSubscription sets the Web Push subscription of the browser of
+	// the session, replacing any previous one.
+	SaveWebPushSubscription(c request.CTX, session *model.Session, subscriptionRequest *model.WebPushSubscriptionRequest) (*model.WebPushSubscription, *model.AppError)
+	// ScanFile streams the contents of the file to the configured antivirus
+	// scanner and stores the verdict on the FileInfo. Files the scanner could not
+	// process are marked as failed and stay quarantined. Infected files are
+	// reported to the system admins.
+	ScanFile(rctx request.CTX, info *model.FileInfo) (string, *model.AppError)
 	// SearchAllChannels returns a list of channels, the total count of the results of the search (if the paginate search option is true), and an error.
 	SearchAllChannels(c request.CTX, term string, opts model.ChannelSearchOpts) (model.ChannelListWithTeamData, int64, *model.AppError)
 	// SearchAllTeams returns a team list and the total count of the results
@@ -349,6 +511,9 @@ type AppIface interface {
 	// status to away if needed. Used by the WS to set status to away if an 'online' device disconnects
 	// while an 'away' device is still connected
 	SetStatusLastActivityAt(userID string, activityAt int64)
+	// SnoozeReminder moves the next delivery of a reminder to snoozeUntil. Both
+	// the creator of the reminder and the user it is delivered to can snooze it.
+	SnoozeReminder(rctx request.CTX, userID, reminderID string, snoozeUntil int64) (*model.Reminder, *model.AppError)
 	// SyncLdap starts an LDAP sync job.
 	// If includeRemovedMembers is true, then members who left or were removed from a team/channel will
 	// be re-added; otherwise, they will not be re-added.
@@ -398,6 +563,15 @@ type AppIface interface {
 	UpdateDNDStatusOfUsers()
 	// UpdateProductNotices is called periodically from a scheduled worker to fetch new notices and update the cache
 	UpdateProductNotices() *model.AppError
+	// UpdateScheduledPost updates the content and delivery time of a scheduled
+	// post owned by userID. Already processed scheduled posts cannot be updated.
+	UpdateScheduledPost(rctx request.CTX, userID string, scheduledPost *model.ScheduledPost, connectionID string) (*model.ScheduledPost, *model.AppError)
+	// UpdateScimGroup replaces the display name, external id and members of a
+	// group with the ones of the given SCIM resource.
+	UpdateScimGroup(rctx request.CTX, groupID string, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError)
+	// UpdateScimUser replaces the attributes of a user with the ones of the
+	// given SCIM resource.
+	UpdateScimUser(rctx request.CTX, userID string, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError)
 	// UpdateSharedChannelCursor updates the cursor for the specified channelID and remoteID.
 	// This can be used to manually set the point of last sync, either forward to skip older posts,
 	// or backward to re-sync history.
@@ -419,13 +593,24 @@ type AppIface interface {
 	// upload, returning a rejection error. In this case FileInfo would have
 	// contained the last "good" FileInfo before the execution of that plugin.
 	UploadFileX(c request.CTX, channelID, name string, input io.Reader, opts ...func(*UploadFileTask)) (*model.FileInfo, *model.AppError)
+	// UserHasMfaMethod returns true if the user has set up the given second
+	// factor.
+	UserHasMfaMethod(user *model.User, method string) (bool, *model.AppError)
 	// UserIsInAdminRoleGroup returns true at least one of the user's groups are configured to set the members as
 	// admins in the given syncable.
 	UserIsInAdminRoleGroup(userID, syncableID string, syncableType model.GroupSyncableType) (bool, *model.AppError)
+	// ValidateBulkImport checks a bulk import without writing anything. On top of
+	// validating each line, it resolves the teams, channels, users and schemes
+	// the lines refer to and checks the attachments are present. Unlike a dry-run
+	// of BulkImport, it doesn't stop at the first problem but reports them all.
+	ValidateBulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, importPath string) (*model.ImportValidationReport, *model.AppError)
 	// ValidateUserPermissionsOnChannels filters channelIds based on whether userId is authorized to manage channel members. Unauthorized channels are removed from the returned list.
 	ValidateUserPermissionsOnChannels(c request.CTX, userId string, channelIds []string) []string
 	// VerifyPlugin checks that the given signature corresponds to the given plugin and matches a trusted certificate.
 	VerifyPlugin(plugin, signature io.ReadSeeker) *model.AppError
+	// VotePoll replaces the votes of a user on a poll. An empty list of options
+	// retracts the votes of the user.
+	VotePoll(c request.CTX, pollID, userID string, optionIDs []string) (*model.Poll, *model.AppError)
 	// validateMoveOrCopy performs validation on a provided post list to determine
 	// if all permissions are in place to allow the for the posts to be moved or
 	// copied.
@@ -474,6 +659,7 @@ type AppIface interface {
 	BuildSamlMetadataObject(idpMetadata []byte) (*model.SamlMetadataResponse, *model.AppError)
 	BulkExport(ctx request.CTX, writer io.Writer, outPath string, job *model.Job, opts model.BulkExportOpts) *model.AppError
 	BulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun bool, workers int) (*model.AppError, int)
+	BulkImportWithOpts(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, opts model.BulkImportOpts) (*model.AppError, int)
 	BulkImportWithPath(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun, extractContent bool, workers int, importPath string) (*model.AppError, int)
 	CanNotifyAdmin(rctx request.CTX, trial bool) bool
 	CancelJob(c request.CTX, jobId string) *model.AppError
@@ -542,6 +728,8 @@ type AppIface interface {
 	CreateRole(role *model.Role) (*model.Role, *model.AppError)
 	CreateSamlRelayToken(extra string) (*model.Token, *model.AppError)
 	CreateScheme(scheme *model.Scheme) (*model.Scheme, *model.AppError)
+	CreateScimGroup(rctx request.CTX, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError)
+	CreateScimUser(rctx request.CTX, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError)
 	CreateSession(c request.CTX, session *model.Session) (*model.Session, *model.AppError)
 	CreateSidebarCategory(c request.CTX, userID, teamID string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, *model.AppError)
 	CreateTeam(c request.CTX, team *model.Team) (*model.Team, *model.AppError)
@@ -585,7 +773,9 @@ type AppIface interface {
 	DeleteReactionForPost(c request.CTX, reaction *model.Reaction) *model.AppError
 	DeleteRemoteCluster(remoteClusterId string) (bool, *model.AppError)
 	DeleteRetentionPolicy(policyID string) *model.AppError
+	DeleteScheduledPost(rctx request.CTX, userID, scheduledPostID, connectionID string) (*model.ScheduledPost, *model.AppError)
 	DeleteScheme(schemeId string) (*model.Scheme, *model.AppError)
+	DeleteScimGroup(rctx request.CTX, groupID string) *model.AppError
 	DeleteSharedChannelRemote(id string) (bool, error)
 	DeleteSidebarCategory(c request.CTX, userID, teamID, categoryId string) *model.AppError
 	DeleteToken(token *model.Token) *model.AppError
@@ -733,10 +923,13 @@ type AppIface interface {
 	GetJobsByTypesPage(c request.CTX, jobType []string, page int, perPage int) ([]*model.Job, *model.AppError)
 	GetLatestTermsOfService() (*model.TermsOfService, *model.AppError)
 	GetLatestVersion(rctx request.CTX, latestVersionUrl string) (*model.GithubReleaseInfo, *model.AppError)
+	GetLegalHold(holdID string) (*model.LegalHold, *model.AppError)
+	GetLegalHolds(page, perPage int, includeReleased bool) ([]*model.LegalHold, *model.AppError)
 	GetLogs(rctx request.CTX, page, perPage int) ([]string, *model.AppError)
 	GetLogsSkipSend(rctx request.CTX, page, perPage int, logFilter *model.LogFilter) ([]string, *model.AppError)
 	GetMemberCountsByGroup(rctx request.CTX, channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, *model.AppError)
 	GetMessageForNotification(post *model.Post, teamName, siteUrl string, translateFunc i18n.TranslateFunc) string
+	GetMfaRecoveryCodesStatus(userID string) (*model.MfaRecoveryCodesStatus, *model.AppError)
 	GetMultipleEmojiByName(c request.CTX, names []string) ([]*model.Emoji, *model.AppError)
 	GetNewUsersForTeamPage(rctx request.CTX, teamID string, page, perPage int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
 	GetNextPostIdFromPostList(postList *model.PostList, collapsedThreads bool) string
@@ -756,6 +949,8 @@ type AppIface interface {
 	GetOpenGraphMetadata(requestURL string) ([]byte, error)
 	GetOrCreateDirectChannel(c request.CTX, userID, otherUserID string, channelOptions ...model.ChannelOption) (*model.Channel, *model.AppError)
 	GetOutgoingWebhook(hookID string) (*model.OutgoingWebhook, *model.AppError)
+	GetOutgoingWebhookDeliveries(hookID string, opts model.OutgoingWebhookDeliveryGetOptions) ([]*model.OutgoingWebhookDelivery, *model.AppError)
+	GetOutgoingWebhookDelivery(deliveryID string) (*model.OutgoingWebhookDelivery, *model.AppError)
 	GetOutgoingWebhooksForChannelPageByUser(channelID string, userID string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
 	GetOutgoingWebhooksForTeamPage(teamID string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
 	GetOutgoingWebhooksForTeamPageByUser(teamID string, userID string, page, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
@@ -793,6 +988,7 @@ type AppIface interface {
 	GetReactionsForPost(postID string) ([]*model.Reaction, *model.AppError)
 	GetRecentlyActiveUsersForTeam(rctx request.CTX, teamID string) (map[string]*model.User, *model.AppError)
 	GetRecentlyActiveUsersForTeamPage(rctx request.CTX, teamID string, page, perPage int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
+	GetReminder(reminderID string) (*model.Reminder, *model.AppError)
 	GetRemoteCluster(remoteClusterId string, includeDeleted bool) (*model.RemoteCluster, *model.AppError)
 	GetRemoteClusterForUser(remoteID string, userID string) (*model.RemoteCluster, *model.AppError)
 	GetRemoteClusterService() (remotecluster.RemoteClusterServiceIFace, *model.AppError)
@@ -808,11 +1004,15 @@ type AppIface interface {
 	GetSamlMetadata(c request.CTX) (string, *model.AppError)
 	GetSamlMetadataFromIdp(idpMetadataURL string) (*model.SamlMetadataResponse, *model.AppError)
 	GetSanitizeOptions(asAdmin bool) map[string]bool
+	GetSavedSearch(savedSearchID string) (*model.SavedSearch, *model.AppError)
+	GetScheduledPost(scheduledPostID string) (*model.ScheduledPost, *model.AppError)
 	GetScheme(id string) (*model.Scheme, *model.AppError)
 	GetSchemeByName(name string) (*model.Scheme, *model.AppError)
 	GetSchemeRolesForTeam(teamID string) (string, string, string, *model.AppError)
 	GetSchemes(scope string, offset int, limit int) ([]*model.Scheme, *model.AppError)
 	GetSchemesPage(scope string, page int, perPage int) ([]*model.Scheme, *model.AppError)
+	GetScimGroup(groupID string, excludeMembers bool) (*model.ScimGroup, *model.AppError)
+	GetScimUser(userID string) (*model.ScimUser, *model.AppError)
 	GetServerLimits() (*model.ServerLimits, *model.AppError)
 	GetSession(token string) (*model.Session, *model.AppError)
 	GetSessionById(c request.CTX, sessionID string) (*model.Session, *model.AppError)
@@ -871,6 +1071,7 @@ type AppIface interface {
 	GetUserByUsername(username string) (*model.User, *model.AppError)
 	GetUserCountForReport(filter *model.UserReportOptions) (*int64, *model.AppError)
 	GetUserForLogin(c request.CTX, id, loginId string) (*model.User, *model.AppError)
+	GetUserTeamScheduledPosts(rctx request.CTX, userID, teamID string) ([]*model.ScheduledPost, *model.AppError)
 	GetUserTermsOfService(userID string) (*model.UserTermsOfService, *model.AppError)
 	GetUsers(userIDs []string) ([]*model.User, *model.AppError)
 	GetUsersByGroupChannelIds(c request.CTX, channelIDs []string, asAdmin bool) (map[string][]*model.User, *model.AppError)
@@ -902,6 +1103,7 @@ type AppIface interface {
 	GetUsersWithoutTeamPage(options *model.UserGetOptions, asAdmin bool) ([]*model.User, *model.AppError)
 	GetVerifyEmailToken(token string) (*model.Token, *model.AppError)
 	GetViewUsersRestrictions(c request.CTX, userID string) (*model.ViewUsersRestrictions, *model.AppError)
+	GetWebAuthnCredentials(userID string) ([]*model.WebAuthnCredential, *model.AppError)
 	HTTPService() httpservice.HTTPService
 	HandleCommandResponse(c request.CTX, command *model.Command, args *model.CommandArgs, response *model.CommandResponse, builtIn bool) (*model.CommandResponse, *model.AppError)
 	HandleCommandResponsePost(c request.CTX, command *model.Command, args *model.CommandArgs, response *model.CommandResponse, builtIn bool) (*model.Post, *model.AppError)
@@ -941,6 +1143,7 @@ type AppIface interface {
 	IsPhase2MigrationCompleted() *model.AppError
 	IsPluginActive(pluginName string) (bool, error)
 	IsPostPriorityEnabled() bool
+	IsScheduledPostsEnabled() bool
 	IsUserSignUpAllowed() *model.AppError
 	JoinChannel(c request.CTX, channel *model.Channel, userID string) *model.AppError
 	JoinDefaultChannels(c request.CTX, teamID string, user *model.User, shouldBeAdmin bool, userRequestorId string) *model.AppError
@@ -1068,6 +1271,7 @@ type AppIface interface {
 	SaveComplianceReport(rctx request.CTX, job *model.Compliance) (*model.Compliance, *model.AppError)
 	SaveReactionForPost(c request.CTX, reaction *model.Reaction) (*model.Reaction, *model.AppError)
 	SaveReportChunk(format string, prefix string, count int, reportData []model.ReportableObject) *model.AppError
+	SaveScheduledPost(rctx request.CTX, scheduledPost *model.ScheduledPost, connectionID string) (*model.ScheduledPost, *model.AppError)
 	SaveSharedChannelRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error)
 	SaveUserTermsOfService(userID, termsOfServiceId string, accepted bool) *model.AppError
 	SchemesIterator(scope string, batchSize int) func() []*model.Scheme
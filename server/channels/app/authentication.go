@@ -221,6 +221,37 @@ func (a *App) CheckUserMfa(rctx request.CTX, user *model.User, token string) *mo
 		return model.NewAppError("CheckUserMfa", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
+	switch {
+	case isWebAuthnMfaToken(token):
+		if !a.isMfaMethodAllowed(model.MfaMethodWebAuthn) {
+			return model.NewAppError("checkUserMfa", "api.user.check_user_mfa.method_disabled.app_error", nil, "", http.StatusUnauthorized)
+		}
+		return a.checkWebAuthnMfa(user, token)
+	case *a.Config().ServiceSettings.EnableMfaRecoveryCodes && mfa.IsRecoveryCode(token):
+		// Recovery codes stand in for the second factors of the user, so they
+		// are only accepted while one of those methods is allowed.
+		allowed, appErr := a.userHasAllowedMfaMethod(user)
+		if appErr != nil {
+			return appErr
+		}
+		if !allowed {
+			return model.NewAppError("checkUserMfa", "api.user.check_user_mfa.method_disabled.app_error", nil, "", http.StatusUnauthorized)
+		}
+		ok, err := mfa.NewRecoveryCodes(a.Srv().Store().MfaRecoveryCode()).Use(user.Id, token)
+		if err != nil {
+			return model.NewAppError("CheckUserMfa", "app.mfa_recovery_code.use.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		if !ok {
+			return model.NewAppError("checkUserMfa", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized)
+		}
+		return nil
+	case user.MfaSecret == "":
+		// The user only has WebAuthn credentials.
+		return model.NewAppError("checkUserMfa", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized)
+	case !a.isMfaMethodAllowed(model.MfaMethodTotp):
+		return model.NewAppError("checkUserMfa", "api.user.check_user_mfa.method_disabled.app_error", nil, "", http.StatusUnauthorized)
+	}
+
 	ok, err := mfa.New(a.Srv().Store().User()).ValidateToken(user, token)
 	if err != nil {
 		return model.NewAppError("CheckUserMfa", "mfa.validate_token.authenticate.app_error", nil, "", http.StatusBadRequest).Wrap(err)
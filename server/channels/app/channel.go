@@ -108,7 +108,7 @@ func (a *App) JoinDefaultChannels(c request.CTX, teamID string, user *model.User
 		message := model.NewWebSocketEvent(model.WebsocketEventUserAdded, "", channel.Id, "", nil, "")
 		message.Add("user_id", user.Id)
 		message.Add("team_id", channel.TeamId)
-		a.Publish(message)
+		a.PublishWithContext(c, message)
 	}
 
 	if nErr != nil {
@@ -191,7 +191,7 @@ func (a *App) CreateChannelWithUser(c request.CTX, channel *model.Channel, userI
 	message := model.NewWebSocketEvent(model.WebsocketEventChannelCreated, "", "", userID, nil, "")
 	message.Add("channel_id", channel.Id)
 	message.Add("team_id", channel.TeamId)
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	return rchannel, nil
 }
@@ -387,7 +387,7 @@ func (a *App) handleCreationEvent(c request.CTX, userID, otherUserID string, cha
 	message := model.NewWebSocketEvent(model.WebsocketEventDirectAdded, "", channel.Id, "", nil, "")
 	message.Add("creator_id", userID)
 	message.Add("teammate_id", otherUserID)
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 }
 
 func (a *App) createDirectChannel(c request.CTX, userID string, otherUserID string, channelOptions ...model.ChannelOption) (*model.Channel, *model.AppError) {
@@ -508,7 +508,7 @@ func (a *App) CreateGroupChannel(c request.CTX, userIDs []string, creatorId stri
 
 		message := model.NewWebSocketEvent(model.WebsocketEventGroupAdded, "", channel.Id, userID, nil, "")
 		message.Add("teammate_ids", jsonIDs)
-		a.Publish(message)
+		a.PublishWithContext(c, message)
 	}
 
 	return channel, nil
@@ -658,7 +658,7 @@ func (a *App) UpdateChannel(c request.CTX, channel *model.Channel) (*model.Chann
 		return nil, model.NewAppError("UpdateChannel", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
 	}
 	messageWs.Add("channel", string(channelJSON))
-	a.Publish(messageWs)
+	a.PublishWithContext(c, messageWs)
 
 	return channel, nil
 }
@@ -725,7 +725,7 @@ func (a *App) UpdateChannelPrivacy(c request.CTX, oldChannel *model.Channel, use
 
 	messageWs := model.NewWebSocketEvent(model.WebsocketEventChannelConverted, channel.TeamId, "", "", nil, "")
 	messageWs.Add("channel_id", channel.Id)
-	a.Publish(messageWs)
+	a.PublishWithContext(c, messageWs)
 
 	return channel, nil
 }
@@ -785,7 +785,7 @@ func (a *App) RestoreChannel(c request.CTX, channel *model.Channel, userID strin
 		message = model.NewWebSocketEvent(model.WebsocketEventChannelRestored, "", channel.Id, "", nil, "")
 	}
 	message.Add("channel_id", channel.Id)
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	var user *model.User
 	if userID != "" {
@@ -1023,7 +1023,7 @@ func (a *App) PatchChannelModerationsForChannel(c request.CTX, channel *model.Ch
 		}
 
 		message := model.NewWebSocketEvent(model.WebsocketEventChannelSchemeUpdated, "", channel.Id, "", nil, "")
-		a.Publish(message)
+		a.PublishWithContext(c, message)
 		c.Logger().Info("Permission scheme created.", mlog.String("channel_id", channel.Id), mlog.String("channel_name", channel.Name))
 	} else {
 		scheme, err = a.GetScheme(*channel.SchemeId)
@@ -1081,7 +1081,7 @@ func (a *App) PatchChannelModerationsForChannel(c request.CTX, channel *model.Ch
 		}
 
 		message := model.NewWebSocketEvent(model.WebsocketEventChannelSchemeUpdated, "", channel.Id, "", nil, "")
-		a.Publish(message)
+		a.PublishWithContext(c, message)
 
 		memberRole = higherScopedMemberRole
 		guestRole = higherScopedGuestRole
@@ -1106,7 +1106,7 @@ func (a *App) PatchChannelModerationsForChannel(c request.CTX, channel *model.Ch
 			return jsonErr
 		}
 		evt.Add("channelMember", string(memberJSON))
-		a.Publish(evt)
+		a.PublishWithContext(c, evt)
 
 		return nil
 	})
@@ -1397,7 +1397,7 @@ func (a *App) updateChannelMember(c request.CTX, member *model.ChannelMember) (*
 		return nil, model.NewAppError("updateChannelMember", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
 	}
 	evt.Add("channelMember", string(memberJSON))
-	a.Publish(evt)
+	a.PublishWithContext(c, evt)
 
 	return member, nil
 }
@@ -1524,7 +1524,7 @@ func (a *App) DeleteChannel(c request.CTX, channel *model.Channel, userID string
 	}
 	message.Add("channel_id", channel.Id)
 	message.Add("delete_at", deleteAt)
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	return nil
 }
@@ -1623,12 +1623,12 @@ func (a *App) AddUserToChannel(c request.CTX, user *model.User, channel *model.C
 	message := model.NewWebSocketEvent(model.WebsocketEventUserAdded, "", channel.Id, "", map[string]bool{user.Id: true}, "")
 	message.Add("user_id", user.Id)
 	message.Add("team_id", channel.TeamId)
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	userMessage := model.NewWebSocketEvent(model.WebsocketEventUserAdded, "", channel.Id, user.Id, nil, "")
 	userMessage.Add("user_id", user.Id)
 	userMessage.Add("team_id", channel.TeamId)
-	a.Publish(userMessage)
+	a.PublishWithContext(c, userMessage)
 
 	return newMember, nil
 }
@@ -2607,13 +2607,13 @@ func (a *App) removeUserFromChannel(c request.CTX, userIDToRemove string, remove
 	message := model.NewWebSocketEvent(model.WebsocketEventUserRemoved, "", channel.Id, "", nil, "")
 	message.Add("user_id", userIDToRemove)
 	message.Add("remover_id", removerUserId)
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	// because the removed user no longer belongs to the channel we need to send a separate websocket event
 	userMsg := model.NewWebSocketEvent(model.WebsocketEventUserRemoved, "", "", userIDToRemove, nil, "")
 	userMsg.Add("channel_id", channel.Id)
 	userMsg.Add("remover_id", removerUserId)
-	a.Publish(userMsg)
+	a.PublishWithContext(c, userMsg)
 
 	return nil
 }
@@ -2867,7 +2867,7 @@ func (a *App) markChannelAsUnreadFromPostCRTUnsupported(c request.CTX, postID st
 			}
 			message := model.NewWebSocketEvent(model.WebsocketEventThreadUpdated, channel.TeamId, "", userID, nil, "")
 			message.Add("thread", string(payload))
-			a.Publish(message)
+			a.PublishWithContext(c, message)
 		}
 	}
 
@@ -2889,7 +2889,7 @@ func (a *App) sendWebSocketPostUnreadEvent(c request.CTX, channelUnread *model.C
 	message.Add("urgent_mention_count", channelUnread.UrgentMentionCount)
 	message.Add("last_viewed_at", channelUnread.LastViewedAt)
 	message.Add("post_id", postID)
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 }
 
 func (a *App) AutocompleteChannels(c request.CTX, userID, term string) (model.ChannelListWithTeamData, *model.AppError) {
@@ -3058,7 +3058,7 @@ func (a *App) MarkChannelsAsViewed(c request.CTX, channelIDs []string, userID st
 		}
 	}
 
-	_, err = a.Srv().Store().Channel().UpdateLastViewedAt(channelsToView, userID)
+	lastViewedAt, err := a.Srv().Store().Channel().UpdateLastViewedAt(channelsToView, userID)
 	if err != nil {
 		var invErr *store.ErrInvalidInput
 		switch {
@@ -3069,10 +3069,16 @@ func (a *App) MarkChannelsAsViewed(c request.CTX, channelIDs []string, userID st
 		}
 	}
 
+	if *a.Config().ServiceSettings.EnableReadReceipts {
+		a.Srv().Go(func() {
+			a.publishPostsRead(request.EmptyContext(a.Log()), userID, lastViewedAt)
+		})
+	}
+
 	if *a.Config().ServiceSettings.EnableChannelViewedMessages {
 		message := model.NewWebSocketEvent(model.WebsocketEventMultipleChannelsViewed, "", "", userID, nil, "")
 		message.Add("channel_times", times)
-		a.Publish(message)
+		a.PublishWithContext(c, message)
 	}
 
 	for _, channelID := range channelsToClearPushNotifications {
@@ -3084,7 +3090,7 @@ func (a *App) MarkChannelsAsViewed(c request.CTX, channelIDs []string, userID st
 		for _, channelID := range channelsToView {
 			message := model.NewWebSocketEvent(model.WebsocketEventThreadReadChanged, "", channelID, userID, nil, "")
 			message.Add("timestamp", timestamp)
-			a.Publish(message)
+			a.PublishWithContext(c, message)
 		}
 	}
 
@@ -3114,6 +3120,10 @@ func (a *App) ViewChannel(c request.CTX, view *model.ChannelView, userID string,
 }
 
 func (a *App) PermanentDeleteChannel(c request.CTX, channel *model.Channel) *model.AppError {
+	if appErr := a.checkChannelNotHeld("PermanentDeleteChannel", channel.Id); appErr != nil {
+		return appErr
+	}
+
 	if err := a.Srv().Store().Post().PermanentDeleteByChannel(c, channel.Id); err != nil {
 		return model.NewAppError("PermanentDeleteChannel", "app.post.permanent_delete_by_channel.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
@@ -3150,7 +3160,7 @@ func (a *App) PermanentDeleteChannel(c request.CTX, channel *model.Channel) *mod
 	}
 	message.Add("channel_id", channel.Id)
 	message.Add("delete_at", deleteAt)
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	return nil
 }
@@ -3420,7 +3430,7 @@ func (a *App) setChannelsMuted(c request.CTX, channelIDs []string, userID string
 		}
 
 		evt.Add("channelMember", string(memberJSON))
-		a.Publish(evt)
+		a.PublishWithContext(c, evt)
 	}
 
 	return updated, nil
@@ -3526,7 +3536,7 @@ func (a *App) ClearChannelMembersCache(c request.CTX, channelID string) error {
 			return jsonErr
 		}
 		message.Add("channelMember", string(memberJSON))
-		a.Publish(message)
+		a.PublishWithContext(c, message)
 		return nil
 	}
 	if err := a.forEachChannelMember(c, channelID, clearSessionCache); err != nil {
@@ -45,7 +45,7 @@ func (a *App) CreateChannelBookmark(c request.CTX, newBookmark *model.ChannelBoo
 		return nil, model.NewAppError("CreateChannelBookmark", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
 	}
 	message.Add("bookmark", string(bookmarkJSON))
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 	return bookmark, nil
 }
 
@@ -99,7 +99,7 @@ func (a *App) UpdateChannelBookmark(c request.CTX, updateBookmark *model.Channel
 		return nil, model.NewAppError("UpdateChannelBookmark", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
 	}
 	message.Add("bookmarks", string(bookmarkJSON))
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	return response, nil
 }
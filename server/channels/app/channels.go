@@ -4,6 +4,7 @@
 package app
 
 import (
+	"crypto/ecdsa"
 	"runtime"
 	"strings"
 	"sync"
@@ -17,6 +18,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/app/imaging"
 	"github.com/mattermost/mattermost/server/v8/config"
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/platform/services/dlp"
 	"github.com/mattermost/mattermost/server/v8/platform/services/imageproxy"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 )
@@ -37,6 +39,7 @@ type Channels struct {
 	exportFilestore filestore.FileBackend
 
 	postActionCookieSecret []byte
+	webPushVAPIDKey        *ecdsa.PrivateKey
 
 	pluginCommandsLock            sync.RWMutex
 	pluginCommands                []*PluginCommand
@@ -78,16 +81,26 @@ type Channels struct {
 	postReminderTask *model.ScheduledTask
 
 	loginAttemptsMut sync.Mutex
+
+	dlpEngineMut sync.RWMutex
+	dlpEngine    *dlp.Engine
+
+	// savedSearchAlertsPending maps the saved searches to check for alerts
+	// to the creation time of their most recent new post.
+	savedSearchAlertsMut     sync.Mutex
+	savedSearchAlertsPending map[string]int64
+	savedSearchAlertsTask    *model.ScheduledTask
 }
 
 func NewChannels(s *Server) (*Channels, error) {
 	ch := &Channels{
-		srv:             s,
-		imageProxy:      imageproxy.MakeImageProxy(s.platform, s.httpService, s.Log()),
-		uploadLockMap:   map[string]bool{},
-		filestore:       s.FileBackend(),
-		exportFilestore: s.ExportFileBackend(),
-		cfgSvc:          s.Platform(),
+		srv:                      s,
+		imageProxy:               imageproxy.MakeImageProxy(s.platform, s.httpService, s.Log()),
+		uploadLockMap:            map[string]bool{},
+		filestore:                s.FileBackend(),
+		exportFilestore:          s.ExportFileBackend(),
+		cfgSvc:                   s.Platform(),
+		savedSearchAlertsPending: map[string]int64{},
 	}
 
 	// We are passing a partially filled Channels struct so that the enterprise
@@ -143,6 +156,11 @@ func NewChannels(s *Server) (*Channels, error) {
 		return nil, errors.Wrap(imgErr, "failed to create image encoder")
 	}
 
+	ch.configureDLPEngine()
+	ch.AddConfigListener(func(_, _ *model.Config) {
+		ch.configureDLPEngine()
+	})
+
 	// Setup routes.
 	pluginsRoute := ch.srv.Router.PathPrefix("/plugins/{plugin_id:[A-Za-z0-9\\_\\-\\.]+}").Subrouter()
 	pluginsRoute.HandleFunc("", ch.ServePluginRequest)
@@ -194,6 +212,10 @@ func (ch *Channels) Start() error {
 		return errors.Wrapf(err, "unable to ensure PostAction cookie secret")
 	}
 
+	if err := ch.ensureWebPushVAPIDKey(); err != nil {
+		return errors.Wrapf(err, "unable to ensure Web Push VAPID key")
+	}
+
 	return nil
 }
 
@@ -206,6 +228,8 @@ func (ch *Channels) Stop() error {
 	}
 	ch.dndTaskMut.Unlock()
 
+	cancelTask(&ch.savedSearchAlertsMut, &ch.savedSearchAlertsTask)
+
 	return nil
 }
 
@@ -4,11 +4,13 @@
 package app
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/plugin"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 func (s *Server) clusterInstallPluginHandler(msg *model.ClusterMessage) {
@@ -48,7 +50,9 @@ func (s *Server) clusterPluginEventHandler(msg *model.ClusterMessage) {
 		return
 	}
 
-	hooks.OnPluginClusterEvent(&plugin.Context{}, model.PluginClusterEvent{
+	hooks.OnPluginClusterEvent(&plugin.Context{
+		TraceContext: tracing.InjectContext(tracing.ClusterMessageContext(context.Background(), msg)),
+	}, model.PluginClusterEvent{
 		Id:   eventID,
 		Data: msg.Data,
 	})
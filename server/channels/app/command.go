@@ -533,6 +533,9 @@ func (a *App) DoCommandRequest(rctx request.CTX, cmd *model.Command, p url.Value
 
 	if cmd.Method == model.CommandMethodPost {
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		model.SignIntegrationRequest(req.Header, []byte(p.Encode()), cmd.SigningSecrets())
+	} else {
+		model.SignIntegrationRequest(req.Header, []byte(req.URL.RawQuery), cmd.SigningSecrets())
 	}
 
 	resp, err := a.Srv().outgoingWebhookClient.Do(req)
@@ -732,6 +735,9 @@ func (a *App) UpdateCommand(oldCmd, updatedCmd *model.Command) (*model.Command,
 	updatedCmd.CreatorId = oldCmd.CreatorId
 	updatedCmd.PluginId = oldCmd.PluginId
 	updatedCmd.TeamId = oldCmd.TeamId
+	updatedCmd.SigningSecret = oldCmd.SigningSecret
+	updatedCmd.PreviousSigningSecret = oldCmd.PreviousSigningSecret
+	updatedCmd.PreviousSigningSecretExpireAt = oldCmd.PreviousSigningSecretExpireAt
 
 	command, err := a.Srv().Store().Command().Update(updatedCmd)
 	if err != nil {
@@ -794,6 +800,48 @@ func (a *App) RegenCommandToken(cmd *model.Command) (*model.Command, *model.AppE
 	return command, nil
 }
 
+// RotateCommandSigningSecret generates a new signing secret for the command,
+// enabling the signing of its requests if needed. The previous secret keeps
+// being used for the configured grace period.
+func (a *App) RotateCommandSigningSecret(cmd *model.Command) (*model.Command, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableCommands {
+		return nil, model.NewAppError("RotateCommandSigningSecret", "api.command.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	cmd.RotateSigningSecret(a.integrationSigningSecretGracePeriod())
+
+	return a.updateCommandSigningSecret("RotateCommandSigningSecret", cmd)
+}
+
+// RemoveCommandSigningSecret stops the signing of the requests of the command.
+func (a *App) RemoveCommandSigningSecret(cmd *model.Command) (*model.Command, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableCommands {
+		return nil, model.NewAppError("RemoveCommandSigningSecret", "api.command.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	cmd.RemoveSigningSecret()
+
+	return a.updateCommandSigningSecret("RemoveCommandSigningSecret", cmd)
+}
+
+func (a *App) updateCommandSigningSecret(where string, cmd *model.Command) (*model.Command, *model.AppError) {
+	command, err := a.Srv().Store().Command().Update(cmd)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("SqlCommandStore.Update", "store.sql_command.update.missing.app_error", map[string]any{"command_id": cmd.Id}, "", http.StatusNotFound).Wrap(err)
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError(where, "app.command.update_signing_secret.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return command, nil
+}
+
 func (a *App) DeleteCommand(commandID string) *model.AppError {
 	if !*a.Config().ServiceSettings.EnableCommands {
 		return model.NewAppError("DeleteCommand", "api.command.disabled.app_error", nil, "", http.StatusNotImplemented)
@@ -0,0 +1,102 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/config"
+)
+
+func configHistoryAppError(where string, err error) *model.AppError {
+	switch {
+	case errors.Is(err, config.ErrConfigHistoryNotSupported):
+		return model.NewAppError(where, "app.config.history.not_supported.app_error", nil, "", http.StatusNotImplemented).Wrap(err)
+	case errors.Is(err, config.ErrConfigVersionNotFound):
+		return model.NewAppError(where, "app.config.history.version_not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+	default:
+		return model.NewAppError(where, "app.config.history.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+}
+
+// SaveConfigWithAuthor replaces the active configuration, optionally notifying cluster peers,
+// and records the author of the change in the configuration history.
+func (a *App) SaveConfigWithAuthor(newCfg *model.Config, sendConfigChangeClusterMessage bool, author *model.ConfigChangeAuthor) (*model.Config, *model.Config, *model.AppError) {
+	return a.Srv().platform.SaveConfigWithAuthor(newCfg, sendConfigChangeClusterMessage, author)
+}
+
+// GetConfigHistory returns a page of the versions of the configuration kept in the
+// database, newest first.
+func (a *App) GetConfigHistory(page, perPage int) ([]*model.ConfigVersion, *model.AppError) {
+	versions, err := a.Srv().platform.GetConfigHistory(page, perPage)
+	if err != nil {
+		return nil, configHistoryAppError("GetConfigHistory", err)
+	}
+
+	return versions, nil
+}
+
+// GetConfigVersionDiff returns the settings changed from the compareTo version of the
+// configuration to the given one, or from the version that preceded it when compareTo
+// is empty. Sensitive values are masked.
+func (a *App) GetConfigVersionDiff(versionID, compareTo string) (*model.ConfigVersionDiff, *model.AppError) {
+	actual, actualCfg, err := a.Srv().platform.GetConfigVersion(versionID)
+	if err != nil {
+		return nil, configHistoryAppError("GetConfigVersionDiff", err)
+	}
+
+	if compareTo == "" {
+		compareTo, err = a.Srv().platform.GetPreviousConfigVersionId(versionID)
+		if errors.Is(err, config.ErrConfigVersionNotFound) {
+			return nil, model.NewAppError("GetConfigVersionDiff", "app.config.history.no_previous_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		} else if err != nil {
+			return nil, configHistoryAppError("GetConfigVersionDiff", err)
+		}
+	}
+
+	base, baseCfg, err := a.Srv().platform.GetConfigVersion(compareTo)
+	if err != nil {
+		return nil, configHistoryAppError("GetConfigVersionDiff", err)
+	}
+
+	// Older versions may miss the settings added since, which would
+	// otherwise all show up as changes.
+	baseCfg.SetDefaults()
+	actualCfg.SetDefaults()
+
+	diffs, err := config.Diff(baseCfg, actualCfg)
+	if err != nil {
+		return nil, model.NewAppError("GetConfigVersionDiff", "app.config.history.diff.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	changes := make([]*model.ConfigVersionChange, 0, len(diffs))
+	for _, d := range diffs.Sanitize() {
+		changes = append(changes, &model.ConfigVersionChange{
+			Path:      d.Path,
+			BaseVal:   d.BaseVal,
+			ActualVal: d.ActualVal,
+		})
+	}
+
+	return &model.ConfigVersionDiff{
+		Base:    base,
+		Actual:  actual,
+		Changes: changes,
+	}, nil
+}
+
+// GetConfigVersion returns a version of the configuration kept in the database, along
+// with its value. The value does not include environment overrides.
+func (a *App) GetConfigVersion(versionID string) (*model.ConfigVersion, *model.Config, *model.AppError) {
+	version, cfg, err := a.Srv().platform.GetConfigVersion(versionID)
+	if err != nil {
+		return nil, nil, configHistoryAppError("GetConfigVersion", err)
+	}
+
+	cfg.SetDefaults()
+	return version, cfg, nil
+}
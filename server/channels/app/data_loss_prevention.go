@@ -0,0 +1,268 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+	"github.com/mattermost/mattermost/server/v8/platform/services/dlp"
+)
+
+// dlpHit is a violation of a data loss prevention rule found in the message
+// of a post, or in the content of one of its files when FileId is set.
+type dlpHit struct {
+	*dlp.Violation
+	FileId string
+}
+
+// blocks returns true if the hit prevents the post from being saved. Files
+// can't be redacted, so they are blocked instead.
+func (h *dlpHit) blocks() bool {
+	return h.Rule.Action == model.DLPActionBlock || (h.FileId != "" && h.Rule.Action == model.DLPActionRedact)
+}
+
+// configureDLPEngine compiles the data loss prevention rules of the current
+// configuration.
+func (ch *Channels) configureDLPEngine() {
+	settings := ch.cfgSvc.Config().DataLossPreventionSettings
+
+	var engine *dlp.Engine
+	if *settings.Enable {
+		var err error
+		engine, err = dlp.NewEngine(settings.Rules)
+		if err != nil {
+			ch.srv.Log().Error("Failed to set up the data loss prevention rules", mlog.Err(err))
+		}
+	}
+
+	ch.dlpEngineMut.Lock()
+	ch.dlpEngine = engine
+	ch.dlpEngineMut.Unlock()
+}
+
+func (a *App) dlpEngine() *dlp.Engine {
+	a.ch.dlpEngineMut.RLock()
+	defer a.ch.dlpEngineMut.RUnlock()
+	return a.ch.dlpEngine
+}
+
+// The content of the files not extracted yet is extracted while the post is
+// being created, so it is bounded to keep the request responsive.
+const (
+	dlpMaxExtractionFileSize = 10 * 1024 * 1024 // 10MB
+	dlpExtractionTimeout     = 5 * time.Second
+)
+
+// applyDLPRulesToPost evaluates the data loss prevention rules against the
+// message, the attachments and the files of the post before it is saved. It
+// returns an error if a rule blocks the post, and redacts the message and the
+// attachments in place. The returned hits must be passed to handleDLPHits
+// once the post is saved.
+func (a *App) applyDLPRulesToPost(c request.CTX, post *model.Post, channel *model.Channel) ([]*dlpHit, *model.AppError) {
+	engine := a.dlpEngine()
+	if engine == nil || post.IsSystemMessage() {
+		return nil, nil
+	}
+
+	var hits []*dlpHit
+	var redacted bool
+	scanText := func(text string) string {
+		var redactions []*dlp.Violation
+		for _, violation := range engine.Scan(text, channel.TeamId, channel.Id) {
+			hits = append(hits, &dlpHit{Violation: violation})
+			if violation.Rule.Action == model.DLPActionRedact {
+				redactions = append(redactions, violation)
+				redacted = true
+			}
+		}
+		return dlp.Redact(text, redactions)
+	}
+
+	message := scanText(post.Message)
+
+	attachments := post.Attachments()
+	for _, attachment := range attachments {
+		for _, text := range []*string{&attachment.Fallback, &attachment.Pretext, &attachment.AuthorName, &attachment.Title, &attachment.Text, &attachment.Footer} {
+			*text = scanText(*text)
+		}
+		for _, field := range attachment.Fields {
+			field.Title = scanText(field.Title)
+			if value, ok := field.Value.(string); ok {
+				field.Value = scanText(value)
+			}
+		}
+	}
+
+	if len(post.FileIds) > 0 {
+		fileInfos, err := a.Srv().Store().FileInfo().GetByIds(post.FileIds)
+		if err != nil {
+			return nil, model.NewAppError("applyDLPRulesToPost", "app.file_info.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, fileInfo := range fileInfos {
+			content := fileInfo.Content
+			if content == "" && !fileInfo.IsImage() {
+				// The content is extracted in the background after the
+				// upload, which may not have completed yet.
+				if content, err = a.extractFileContentForDLP(c, fileInfo); err != nil {
+					c.Logger().Warn("Failed to extract file content for the data loss prevention rules", mlog.String("file_info_id", fileInfo.Id), mlog.Err(err))
+				}
+			}
+
+			for _, violation := range engine.Scan(content, channel.TeamId, channel.Id) {
+				hits = append(hits, &dlpHit{Violation: violation, FileId: fileInfo.Id})
+			}
+		}
+	}
+
+	for _, hit := range hits {
+		if hit.blocks() {
+			a.auditDLPHits(c, post, channel, hits, true)
+			return nil, model.NewAppError("applyDLPRulesToPost", "app.dlp.post_blocked.app_error", map[string]any{"RuleName": hit.Rule.Name}, "", http.StatusBadRequest)
+		}
+	}
+
+	if redacted {
+		post.Message = message
+		if len(attachments) > 0 {
+			post.AddProp("attachments", attachments)
+		}
+	}
+
+	return hits, nil
+}
+
+// extractFileContentForDLP extracts the content of a file, giving up on files
+// too large or too slow to extract while a post is being created.
+func (a *App) extractFileContentForDLP(c request.CTX, fileInfo *model.FileInfo) (string, error) {
+	if fileInfo.Size > dlpMaxExtractionFileSize {
+		return "", fmt.Errorf("file is larger than %d bytes", dlpMaxExtractionFileSize)
+	}
+
+	type result struct {
+		content string
+		err     error
+	}
+	done := make(chan result, 1)
+	go func() {
+		content, err := a.extractFileContent(c, fileInfo)
+		done <- result{content, err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.content, res.err
+	case <-time.After(dlpExtractionTimeout):
+		return "", fmt.Errorf("extraction did not complete within %s", dlpExtractionTimeout)
+	}
+}
+
+// handleDLPHits records the hits of the data loss prevention rules in the
+// audit log, warns the author and notifies the compliance channels.
+func (a *App) handleDLPHits(c request.CTX, post *model.Post, channel *model.Channel, hits []*dlpHit) {
+	if len(hits) == 0 {
+		return
+	}
+
+	a.auditDLPHits(c, post, channel, hits, false)
+
+	user, appErr := a.GetUser(post.UserId)
+	if appErr != nil {
+		c.Logger().Warn("Failed to get the author of the post", mlog.String("post_id", post.Id), mlog.Err(appErr))
+		return
+	}
+
+	var warnedRules []string
+	notifiedRules := map[string]bool{}
+	for _, hit := range hits {
+		switch hit.Rule.Action {
+		case model.DLPActionWarn:
+			warnedRules = append(warnedRules, hit.Rule.Name)
+		case model.DLPActionNotify:
+			if !notifiedRules[hit.Rule.Name] {
+				notifiedRules[hit.Rule.Name] = true
+				a.notifyDLPComplianceChannel(c, post, channel, user, hit.Rule)
+			}
+		}
+	}
+
+	if len(warnedRules) > 0 {
+		T := i18n.GetUserTranslations(user.Locale)
+		a.SendEphemeralPost(c, user.Id, &model.Post{
+			UserId:    user.Id,
+			ChannelId: post.ChannelId,
+			RootId:    post.RootId,
+			Message:   T("app.dlp.warn_author.message", map[string]any{"RuleNames": strings.Join(warnedRules, ", ")}),
+		})
+	}
+}
+
+func (a *App) notifyDLPComplianceChannel(c request.CTX, post *model.Post, channel *model.Channel, user *model.User, rule *model.DLPRule) {
+	complianceChannel, appErr := a.GetChannel(c, rule.ComplianceChannelId)
+	if appErr != nil {
+		c.Logger().Warn("Failed to get the compliance channel of a data loss prevention rule", mlog.String("rule", rule.Name), mlog.Err(appErr))
+		return
+	}
+
+	systemBot, appErr := a.GetSystemBot(c)
+	if appErr != nil {
+		c.Logger().Warn("Failed to get the system bot", mlog.Err(appErr))
+		return
+	}
+
+	notification := &model.Post{
+		UserId:    systemBot.UserId,
+		ChannelId: complianceChannel.Id,
+		Message: i18n.T("app.dlp.compliance_notification.message", map[string]any{
+			"RuleName":    rule.Name,
+			"Username":    user.Username,
+			"ChannelName": channel.DisplayName,
+			"Link":        fmt.Sprintf("%s/_redirect/pl/%s", a.GetSiteURL(), post.Id),
+		}),
+		// As a system message, the notification isn't evaluated against the
+		// rules itself.
+		Type: model.PostTypeSystemGeneric,
+	}
+	if _, appErr := a.CreatePost(c, notification, complianceChannel, model.CreatePostFlags{SetOnline: true}); appErr != nil {
+		c.Logger().Warn("Failed to notify the compliance channel of a data loss prevention rule", mlog.String("rule", rule.Name), mlog.Err(appErr))
+	}
+}
+
+// auditDLPHits records every hit in the audit log. The matched text is left
+// out of the records as it is the sensitive data the rules protect.
+func (a *App) auditDLPHits(c request.CTX, post *model.Post, channel *model.Channel, hits []*dlpHit, blocked bool) {
+	for _, hit := range hits {
+		rec := a.MakeAuditRecord(c, "dataLossPreventionRuleMatched", audit.Success)
+		rec.Actor.UserId = post.UserId
+		rec.Actor.SessionId = c.Session().Id
+		rec.Actor.IpAddress = c.IPAddress()
+		rec.Actor.XForwardedFor = c.XForwardedFor()
+		audit.AddEventParameter(rec, "rule", hit.Rule.Name)
+		audit.AddEventParameter(rec, "action", hit.Rule.Action)
+		audit.AddEventParameter(rec, "blocked", blocked)
+		audit.AddEventParameter(rec, "matches", len(hit.Matches))
+		audit.AddEventParameter(rec, "user_id", post.UserId)
+		// Blocked new posts are never saved, so they have no id.
+		if post.Id != "" {
+			audit.AddEventParameter(rec, "post_id", post.Id)
+		}
+		audit.AddEventParameter(rec, "channel_id", channel.Id)
+		audit.AddEventParameter(rec, "team_id", channel.TeamId)
+		if hit.FileId != "" {
+			audit.AddEventParameter(rec, "file_id", hit.FileId)
+		}
+		if len(post.FileIds) > 0 {
+			audit.AddEventParameter(rec, "file_ids", []string(post.FileIds))
+		}
+		a.LogAuditRec(c, rec, nil)
+	}
+}
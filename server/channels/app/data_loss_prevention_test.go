@@ -0,0 +1,112 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestApplyDLPRulesToPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.DataLossPreventionSettings.Enable = true
+		cfg.DataLossPreventionSettings.Rules = []*model.DLPRule{
+			{Name: "Credit cards", Preset: model.DLPPresetCreditCard, Action: model.DLPActionBlock},
+			{Name: "Tokens", Pattern: `tok_[a-z0-9]{8}`, Action: model.DLPActionRedact},
+			{Name: "Code names", Keywords: []string{"falcon"}, Action: model.DLPActionWarn},
+			{Name: "Other team", Keywords: []string{"secret"}, TeamIds: []string{model.NewId()}, Action: model.DLPActionBlock},
+		}
+	})
+
+	createPost := func(message string) (*model.Post, *model.AppError) {
+		return th.App.CreatePost(th.Context, &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			UserId:    th.BasicUser.Id,
+			Message:   message,
+		}, th.BasicChannel, model.CreatePostFlags{SetOnline: true})
+	}
+
+	t.Run("blocks the post", func(t *testing.T) {
+		_, appErr := createPost("my card is 4111 1111 1111 1111")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.dlp.post_blocked.app_error", appErr.Id)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("ignores numbers failing the Luhn check", func(t *testing.T) {
+		_, appErr := createPost("order 4111 1111 1111 1112")
+		require.Nil(t, appErr)
+	})
+
+	t.Run("redacts the message", func(t *testing.T) {
+		post, appErr := createPost("use tok_abcd1234 to log in")
+		require.Nil(t, appErr)
+		assert.Equal(t, "use ************ to log in", post.Message)
+	})
+
+	t.Run("redacts edits", func(t *testing.T) {
+		post, appErr := createPost("no token yet")
+		require.Nil(t, appErr)
+
+		post.Message = "now tok_abcd1234"
+		updated, appErr := th.App.UpdatePost(th.Context, post, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, "now ************", updated.Message)
+	})
+
+	t.Run("scans the attachments", func(t *testing.T) {
+		post := &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			UserId:    th.BasicUser.Id,
+		}
+		model.ParseSlackAttachment(post, []*model.SlackAttachment{{
+			Text:   "use tok_abcd1234 to log in",
+			Fields: []*model.SlackAttachmentField{{Title: "Token", Value: "tok_abcd1234"}},
+		}})
+
+		created, appErr := th.App.CreatePost(th.Context, post, th.BasicChannel, model.CreatePostFlags{SetOnline: true})
+		require.Nil(t, appErr)
+		attachments := created.Attachments()
+		require.Len(t, attachments, 1)
+		assert.Equal(t, "use ************ to log in", attachments[0].Text)
+		assert.Equal(t, "************", attachments[0].Fields[0].Value)
+
+		blocked := &model.Post{
+			ChannelId: th.BasicChannel.Id,
+			UserId:    th.BasicUser.Id,
+		}
+		model.ParseSlackAttachment(blocked, []*model.SlackAttachment{{Pretext: "my card is 4111 1111 1111 1111"}})
+		_, appErr = th.App.CreatePost(th.Context, blocked, th.BasicChannel, model.CreatePostFlags{SetOnline: true})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.dlp.post_blocked.app_error", appErr.Id)
+	})
+
+	t.Run("warns without altering the post", func(t *testing.T) {
+		post, appErr := createPost("project falcon is on track")
+		require.Nil(t, appErr)
+		assert.Equal(t, "project falcon is on track", post.Message)
+	})
+
+	t.Run("ignores rules scoped to other teams", func(t *testing.T) {
+		_, appErr := createPost("a secret")
+		require.Nil(t, appErr)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.DataLossPreventionSettings.Enable = false
+		})
+
+		_, appErr := createPost("my card is 4111 1111 1111 1111")
+		require.Nil(t, appErr)
+	})
+}
@@ -78,7 +78,7 @@ func (a *App) UpsertDraft(c request.CTX, draft *model.Draft, connectionID string
 		c.Logger().Warn("Failed to encode draft to JSON", mlog.Err(jsonErr))
 	}
 	message.Add("draft", string(draftJSON))
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	return dt, nil
 }
@@ -155,7 +155,7 @@ func (a *App) DeleteDraft(rctx request.CTX, draft *model.Draft, connectionID str
 
 	message := model.NewWebSocketEvent(model.WebsocketEventDraftDeleted, "", draft.ChannelId, draft.UserId, nil, connectionID)
 	message.Add("draft", string(draftJSON))
-	a.Publish(message)
+	a.PublishWithContext(rctx, message)
 
 	return nil
 }
@@ -0,0 +1,307 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/platform/services/inboundmail"
+)
+
+const (
+	// replyByEmailMacSize is the size of the MAC binding a reply address to
+	// its recipient. Guessing it also requires spoofing the recipient.
+	replyByEmailMacSize = 10
+	// replyByEmailTokenLength is the length of the sub-address of reply
+	// addresses: the ID of the post followed by the hex encoded MAC.
+	replyByEmailTokenLength = 26 + 2*replyByEmailMacSize
+	// replyByEmailMaxAttachments matches the number of files a post can have.
+	replyByEmailMaxAttachments = 10
+
+	inboundEmailTimeout = 5 * time.Minute
+)
+
+var (
+	errReplyByEmailUnknownRecipient = &inboundmail.Error{Code: 550, Message: "No such recipient"}
+	errReplyByEmailRejected         = &inboundmail.Error{Code: 550, Message: "The reply could not be posted"}
+	errReplyByEmailUnauthenticated  = &inboundmail.Error{Code: 550, Message: "The sender could not be authenticated"}
+)
+
+// replyByEmailMac binds the reply address of a post to the user it is sent
+// to, so that replies can't be posted to the thread by anyone else.
+func (a *App) replyByEmailMac(postID, userID string) []byte {
+	mac := hmac.New(sha256.New, a.PostActionCookieSecret())
+	mac.Write([]byte("reply_by_email:" + postID + ":" + userID))
+	return mac.Sum(nil)[:replyByEmailMacSize]
+}
+
+// GetReplyByEmailAddress returns the address the user can reply to, to
+// respond in the thread of the post. It returns an empty string if replying
+// by email is disabled.
+func (a *App) GetReplyByEmailAddress(userID string, post *model.Post) string {
+	settings := a.Config().EmailSettings
+	if !*settings.EnableReplyByEmail || post.Id == "" {
+		return ""
+	}
+
+	local, domain, found := strings.Cut(*settings.ReplyByEmailAddress, "@")
+	if !found {
+		return ""
+	}
+
+	return local + "+" + post.Id + hex.EncodeToString(a.replyByEmailMac(post.Id, userID)) + "@" + domain
+}
+
+// parseReplyByEmailAddress returns the post ID and the MAC of a reply
+// address.
+func (a *App) parseReplyByEmailAddress(address string) (string, []byte, bool) {
+	local, domain, found := strings.Cut(strings.ToLower(*a.Config().EmailSettings.ReplyByEmailAddress), "@")
+	if !found {
+		return "", nil, false
+	}
+
+	recipientLocal, recipientDomain, found := strings.Cut(strings.ToLower(address), "@")
+	if !found || recipientDomain != domain {
+		return "", nil, false
+	}
+
+	token, found := strings.CutPrefix(recipientLocal, local+"+")
+	if !found || len(token) != replyByEmailTokenLength {
+		return "", nil, false
+	}
+
+	postID := token[:26]
+	mac, err := hex.DecodeString(token[26:])
+	if !model.IsValidId(postID) || err != nil {
+		return "", nil, false
+	}
+
+	return postID, mac, true
+}
+
+// AcceptReplyByEmailRecipient checks an inbound email is sent to a reply
+// address.
+func (a *App) AcceptReplyByEmailRecipient(address string) error {
+	if _, _, ok := a.parseReplyByEmailAddress(address); !ok {
+		return errReplyByEmailUnknownRecipient
+	}
+	return nil
+}
+
+// ReceiveReplyByEmail posts the reply to a notification email in the thread
+// of the post it was sent for. The reply is posted as the user the email was
+// sent to, provided the email comes from their address: both the envelope
+// sender and the From header must be that address, and one of the trusted
+// MTAs must have authenticated it with DKIM or SPF.
+func (a *App) ReceiveReplyByEmail(rctx request.CTX, sender, recipient string, data []byte) error {
+	if !*a.Config().EmailSettings.EnableReplyByEmail {
+		return errReplyByEmailUnknownRecipient
+	}
+
+	postID, mac, ok := a.parseReplyByEmailAddress(recipient)
+	if !ok {
+		return errReplyByEmailUnknownRecipient
+	}
+
+	msg, err := inboundmail.ParseMessage(data)
+	if err != nil {
+		rctx.Logger().Debug("Failed to parse a reply by email", mlog.Err(err))
+		return &inboundmail.Error{Code: 554, Message: "The message could not be parsed"}
+	}
+
+	if !strings.EqualFold(sender, msg.From) {
+		rctx.Logger().Info("Rejected a reply by email whose envelope sender is not its author", mlog.String("post_id", postID))
+		return errReplyByEmailUnauthenticated
+	}
+
+	if msg.AuthenticationFailed() || !msg.Authenticated(a.Config().EmailSettings.InboundEmailTrustedAuthServIds) {
+		rctx.Logger().Info("Rejected a reply by email whose sender failed authentication", mlog.String("post_id", postID))
+		return errReplyByEmailUnauthenticated
+	}
+
+	user, appErr := a.GetUserByEmail(msg.From)
+	if appErr != nil {
+		if appErr.StatusCode == 404 {
+			return errReplyByEmailRejected
+		}
+		return appErr
+	}
+	if !hmac.Equal(mac, a.replyByEmailMac(postID, user.Id)) {
+		rctx.Logger().Info("Rejected a reply by email sent from another address", mlog.String("post_id", postID), mlog.String("user_id", user.Id))
+		return errReplyByEmailRejected
+	}
+	if user.DeleteAt != 0 || user.IsBot || user.IsRemote() {
+		return errReplyByEmailRejected
+	}
+
+	rctx = rctx.WithLogger(rctx.Logger().With(mlog.String("user_id", user.Id), mlog.String("post_id", postID)))
+
+	post, appErr := a.GetSinglePost(rctx, postID, false)
+	if appErr != nil {
+		return rejectReplyByEmailOnAppError(appErr)
+	}
+	channel, appErr := a.GetChannel(rctx, post.ChannelId)
+	if appErr != nil {
+		return rejectReplyByEmailOnAppError(appErr)
+	}
+	if channel.DeleteAt != 0 || !a.HasPermissionToChannel(rctx, user.Id, channel.Id, model.PermissionCreatePost) {
+		return errReplyByEmailRejected
+	}
+
+	message := inboundmail.StripReply(msg.Text)
+	if message == "" && len(msg.Attachments) == 0 {
+		return &inboundmail.Error{Code: 550, Message: "The reply is empty"}
+	}
+
+	fileIDs, err := a.uploadReplyByEmailAttachments(rctx, user, channel, msg.Attachments)
+	if err != nil {
+		return err
+	}
+
+	rootID := post.RootId
+	if rootID == "" {
+		rootID = post.Id
+	}
+
+	reply := &model.Post{
+		UserId:    user.Id,
+		ChannelId: channel.Id,
+		RootId:    rootID,
+		Message:   message,
+		FileIds:   fileIDs,
+	}
+	if _, appErr := a.CreatePost(rctx, reply, channel, model.CreatePostFlags{TriggerWebhooks: true}); appErr != nil {
+		rctx.Logger().Info("Failed to post a reply by email", mlog.Err(appErr))
+		return rejectReplyByEmailOnAppError(appErr)
+	}
+
+	return nil
+}
+
+func (a *App) uploadReplyByEmailAttachments(rctx request.CTX, user *model.User, channel *model.Channel, attachments []*inboundmail.Attachment) ([]string, error) {
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+
+	if !*a.Config().FileSettings.EnableFileAttachments || !a.HasPermissionToChannel(rctx, user.Id, channel.Id, model.PermissionUploadFile) {
+		return nil, &inboundmail.Error{Code: 550, Message: "Attachments are not allowed"}
+	}
+	if len(attachments) > replyByEmailMaxAttachments {
+		return nil, &inboundmail.Error{Code: 552, Message: "Too many attachments"}
+	}
+
+	fileIDs := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		if int64(len(attachment.Data)) > *a.Config().FileSettings.MaxFileSize {
+			return nil, &inboundmail.Error{Code: 552, Message: "Attachment too large"}
+		}
+
+		name := attachment.Name
+		if name == "" || name == "." || name == "/" {
+			name = "attachment"
+		}
+
+		info, appErr := a.UploadFileForUserAndTeam(rctx, attachment.Data, channel.Id, name, user.Id, channel.TeamId)
+		if appErr != nil {
+			rctx.Logger().Info("Failed to upload an attachment of a reply by email", mlog.Err(appErr))
+			return nil, rejectReplyByEmailOnAppError(appErr)
+		}
+		fileIDs = append(fileIDs, info.Id)
+	}
+
+	return fileIDs, nil
+}
+
+// rejectReplyByEmailOnAppError bounces the reply on client errors, and
+// defers it on server errors so that the MTA tries again later.
+func rejectReplyByEmailOnAppError(appErr *model.AppError) error {
+	if appErr.StatusCode >= 400 && appErr.StatusCode < 500 {
+		return errReplyByEmailRejected
+	}
+	return appErr
+}
+
+// startInboundEmailServer starts receiving replies to notification emails,
+// if enabled.
+func (s *Server) startInboundEmailServer() error {
+	s.inboundEmailMutex.Lock()
+	defer s.inboundEmailMutex.Unlock()
+
+	settings := s.platform.Config().EmailSettings
+	if !*settings.EnableReplyByEmail {
+		return nil
+	}
+
+	// Replies are only trusted when relayed by the MTAs authenticating them.
+	if len(settings.InboundEmailTrustedSources) == 0 {
+		return inboundmail.ErrNoTrustedSources
+	}
+	trustedSources, err := inboundmail.ParseTrustedSources(settings.InboundEmailTrustedSources)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", *settings.InboundEmailListenAddress)
+	if err != nil {
+		return err
+	}
+
+	appInstance := New(ServerConnector(s.Channels()))
+	server := &inboundmail.Server{
+		Hostname:        utils.GetHostnameFromSiteURL(*s.platform.Config().ServiceSettings.SiteURL),
+		Protocol:        *settings.InboundEmailProtocol,
+		MaxMessageSize:  *settings.InboundEmailMaxMessageSize,
+		Timeout:         inboundEmailTimeout,
+		TrustedSources:  trustedSources,
+		AcceptRecipient: appInstance.AcceptReplyByEmailRecipient,
+		Deliver: func(from, to string, data []byte) error {
+			rctx := request.EmptyContext(s.Log())
+			return appInstance.ReceiveReplyByEmail(rctx, from, to, data)
+		},
+		Logger: s.Log(),
+	}
+	s.inboundEmailServer = server
+
+	s.Log().Info("Starting the inbound email server", mlog.String("address", listener.Addr().String()), mlog.String("protocol", *settings.InboundEmailProtocol))
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, inboundmail.ErrServerClosed) {
+			s.Log().Error("The inbound email server stopped", mlog.Err(err))
+		}
+	}()
+
+	return nil
+}
+
+func (s *Server) stopInboundEmailServer() {
+	s.inboundEmailMutex.Lock()
+	defer s.inboundEmailMutex.Unlock()
+
+	if s.inboundEmailServer == nil {
+		return
+	}
+	if err := s.inboundEmailServer.Close(); err != nil {
+		s.Log().Warn("Failed to stop the inbound email server", mlog.Err(err))
+	}
+	s.inboundEmailServer = nil
+}
+
+func inboundEmailSettingsChanged(oldCfg, newCfg *model.Config) bool {
+	oldSettings, newSettings := oldCfg.EmailSettings, newCfg.EmailSettings
+	return *oldSettings.EnableReplyByEmail != *newSettings.EnableReplyByEmail ||
+		*oldSettings.InboundEmailProtocol != *newSettings.InboundEmailProtocol ||
+		*oldSettings.InboundEmailListenAddress != *newSettings.InboundEmailListenAddress ||
+		*oldSettings.InboundEmailMaxMessageSize != *newSettings.InboundEmailMaxMessageSize ||
+		!slices.Equal(oldSettings.InboundEmailTrustedSources, newSettings.InboundEmailTrustedSources)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/services/inboundmail"
+)
+
+func TestGetReplyByEmailAddress(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	post := th.BasicPost
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Empty(t, th.App.GetReplyByEmailAddress(th.BasicUser.Id, post))
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.EnableReplyByEmail = true
+		*cfg.EmailSettings.ReplyByEmailAddress = "Reply@chat.example.com"
+		cfg.EmailSettings.InboundEmailTrustedAuthServIds = []string{"mx.chat.example.com"}
+		cfg.EmailSettings.InboundEmailTrustedSources = []string{"127.0.0.1"}
+	})
+
+	address := th.App.GetReplyByEmailAddress(th.BasicUser.Id, post)
+	require.True(t, strings.HasPrefix(address, "Reply+"+post.Id), address)
+	require.True(t, strings.HasSuffix(address, "@chat.example.com"), address)
+	assert.NotEqual(t, address, th.App.GetReplyByEmailAddress(th.BasicUser2.Id, post))
+
+	postID, mac, ok := th.App.parseReplyByEmailAddress(address)
+	require.True(t, ok)
+	assert.Equal(t, post.Id, postID)
+	assert.Equal(t, th.App.replyByEmailMac(post.Id, th.BasicUser.Id), mac)
+
+	assert.NoError(t, th.App.AcceptReplyByEmailRecipient(strings.ToUpper(address)))
+	for _, recipient := range []string{
+		"reply@chat.example.com",
+		"reply+" + post.Id + "@chat.example.com",
+		strings.Replace(address, "chat.example.com", "example.com", 1),
+		"other" + address,
+	} {
+		assert.Error(t, th.App.AcceptReplyByEmailRecipient(recipient), recipient)
+	}
+}
+
+func TestReceiveReplyByEmail(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.EnableReplyByEmail = true
+		*cfg.EmailSettings.ReplyByEmailAddress = "reply@chat.example.com"
+		cfg.EmailSettings.InboundEmailTrustedAuthServIds = []string{"mx.chat.example.com"}
+		cfg.EmailSettings.InboundEmailTrustedSources = []string{"127.0.0.1"}
+	})
+
+	post := th.BasicPost
+	address := th.App.GetReplyByEmailAddress(th.BasicUser.Id, post)
+
+	authenticatedMessage := func(from, results, body string) []byte {
+		return []byte(fmt.Sprintf("Authentication-Results: %s\nFrom: %s\nTo: %s\nSubject: Re: Notification\n\n%s", results, from, address, body))
+	}
+	message := func(from, body string) []byte {
+		_, domain, _ := strings.Cut(from, "@")
+		return authenticatedMessage(from, "mx.chat.example.com; dkim=pass header.d="+domain, body)
+	}
+
+	t.Run("posts the reply in the thread", func(t *testing.T) {
+		err := th.App.ReceiveReplyByEmail(th.Context, th.BasicUser.Email, address, message(th.BasicUser.Email, "Sounds good!\n\nOn Mon, Jan 2, 2006, Mattermost wrote:\n> Lunch?\n"))
+		require.NoError(t, err)
+
+		thread, appErr := th.App.GetPostThread(post.Id, model.GetPostsOptions{}, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		var found bool
+		for _, reply := range thread.Posts {
+			if reply.RootId == post.Id && reply.UserId == th.BasicUser.Id && reply.Message == "Sounds good!" {
+				found = true
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("rejects replies from another sender", func(t *testing.T) {
+		err := th.App.ReceiveReplyByEmail(th.Context, th.BasicUser2.Email, address, message(th.BasicUser2.Email, "Sounds good!"))
+		var mailErr *inboundmail.Error
+		require.ErrorAs(t, err, &mailErr)
+		assert.Equal(t, 550, mailErr.Code)
+	})
+
+	t.Run("rejects replies from another envelope sender", func(t *testing.T) {
+		err := th.App.ReceiveReplyByEmail(th.Context, "attacker@example.org", address, message(th.BasicUser.Email, "Sounds good!"))
+		assert.Equal(t, errReplyByEmailUnauthenticated, err)
+	})
+
+	t.Run("rejects replies not authenticated by a trusted server", func(t *testing.T) {
+		_, domain, _ := strings.Cut(th.BasicUser.Email, "@")
+		for _, results := range []string{
+			"mx.example.org; dkim=pass header.d=" + domain,
+			"mx.chat.example.com; dkim=none",
+			"mx.chat.example.com; dkim=pass header.d=example.org",
+		} {
+			err := th.App.ReceiveReplyByEmail(th.Context, th.BasicUser.Email, address, authenticatedMessage(th.BasicUser.Email, results, "Sounds good!"))
+			assert.Equal(t, errReplyByEmailUnauthenticated, err, results)
+		}
+	})
+
+	t.Run("rejects empty replies", func(t *testing.T) {
+		err := th.App.ReceiveReplyByEmail(th.Context, th.BasicUser.Email, address, message(th.BasicUser.Email, "> Lunch?\n"))
+		var mailErr *inboundmail.Error
+		require.ErrorAs(t, err, &mailErr)
+		assert.Equal(t, 550, mailErr.Code)
+	})
+}
@@ -109,6 +109,22 @@ var exportablePreferences = map[imports.ComparablePreference]string{
 }
 
 func (a *App) BulkExport(ctx request.CTX, writer io.Writer, outPath string, job *model.Job, opts model.BulkExportOpts) *model.AppError {
+	if job != nil && job.Data == nil {
+		job.Data = make(model.StringMap)
+	}
+
+	if job != nil && opts.CreateArchive {
+		return a.resumableBulkExport(ctx, writer, outPath, job, opts)
+	}
+
+	manifest := &model.BulkExportManifest{
+		Since:      opts.Since,
+		ExportedAt: model.GetMillis(),
+	}
+	if job != nil {
+		manifest.JobId = job.Id
+	}
+
 	var zipWr *zip.Writer
 	if opts.CreateArchive {
 		var err error
@@ -121,76 +137,140 @@ func (a *App) BulkExport(ctx request.CTX, writer io.Writer, outPath string, job
 		}
 	}
 
-	if job != nil && job.Data == nil {
-		job.Data = make(model.StringMap)
-	}
-
 	ctx.Logger().Info("Bulk export: exporting version")
 	if err := a.exportVersion(writer); err != nil {
 		return err
 	}
 
-	if opts.IncludeRolesAndSchemes {
-		if err := a.exportRolesAndSchemes(ctx, job, writer); err != nil {
-			return err
-		}
-	}
-
-	ctx.Logger().Info("Bulk export: exporting teams")
-	teamNames, err := a.exportAllTeams(ctx, job, writer)
+	files, err := a.exportStages(ctx, job, writer, outPath, opts, nil)
 	if err != nil {
 		return err
 	}
 
-	ctx.Logger().Info("Bulk export: exporting channels")
-	if err = a.exportAllChannels(ctx, job, writer, teamNames, opts.IncludeArchivedChannels); err != nil {
+	if err := a.exportFiles(ctx, job, outPath, zipWr, opts, files); err != nil {
 		return err
 	}
 
-	ctx.Logger().Info("Bulk export: exporting users")
-	profilePictures, err := a.exportAllUsers(ctx, job, writer, opts.IncludeArchivedChannels, opts.IncludeProfilePictures)
-	if err != nil {
-		return err
+	if zipWr != nil {
+		return a.exportManifest(zipWr, manifest)
 	}
 
-	ctx.Logger().Info("Bulk export: exporting posts")
-	attachments, err := a.exportAllPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels)
-	if err != nil {
-		return err
+	return nil
+}
+
+// exportedFiles holds the paths of the files referenced by the exported lines.
+type exportedFiles struct {
+	attachments     []imports.AttachmentImportData
+	profilePictures []string
+	emojiPaths      []string
+}
+
+// exportStages writes the lines of every export stage not already completed
+// according to cursor, which may be nil to run all of them.
+func (a *App) exportStages(ctx request.CTX, job *model.Job, writer io.Writer, outPath string, opts model.BulkExportOpts, cursor *exportCursor) (*exportedFiles, *model.AppError) {
+	files := &exportedFiles{}
+
+	if opts.IncludeRolesAndSchemes && !cursor.skip(exportStageRolesAndSchemes) {
+		if err := a.exportRolesAndSchemes(ctx, job, writer, opts.Since); err != nil {
+			return nil, err
+		}
+		if err := cursor.finish(exportStageRolesAndSchemes); err != nil {
+			return nil, err
+		}
 	}
 
-	ctx.Logger().Info("Bulk export: exporting emoji")
-	emojiPaths, err := a.exportCustomEmoji(ctx, job, writer, outPath, "exported_emoji", !opts.CreateArchive)
-	if err != nil {
-		return err
+	if !cursor.skip(exportStageTeams) {
+		ctx.Logger().Info("Bulk export: exporting teams")
+		if err := a.exportAllTeams(ctx, job, writer, opts.Since, cursor); err != nil {
+			return nil, err
+		}
+		if err := cursor.finish(exportStageTeams); err != nil {
+			return nil, err
+		}
 	}
 
-	ctx.Logger().Info("Bulk export: exporting direct channels")
-	if err = a.exportAllDirectChannels(ctx, job, writer, opts.IncludeArchivedChannels); err != nil {
-		return err
+	if !cursor.skip(exportStageChannels) {
+		ctx.Logger().Info("Bulk export: exporting channels")
+		if err := a.exportAllChannels(ctx, job, writer, opts.IncludeArchivedChannels, opts.Since, cursor); err != nil {
+			return nil, err
+		}
+		if err := cursor.finish(exportStageChannels); err != nil {
+			return nil, err
+		}
 	}
 
-	ctx.Logger().Info("Bulk export: exporting direct posts")
-	directAttachments, err := a.exportAllDirectPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels)
-	if err != nil {
-		return err
+	if !cursor.skip(exportStageUsers) {
+		ctx.Logger().Info("Bulk export: exporting users")
+		profilePictures, err := a.exportAllUsers(ctx, job, writer, opts.IncludeArchivedChannels, opts.IncludeProfilePictures, opts.Since, cursor)
+		if err != nil {
+			return nil, err
+		}
+		files.profilePictures = profilePictures
+		if err := cursor.finish(exportStageUsers); err != nil {
+			return nil, err
+		}
 	}
 
-	if opts.IncludeAttachments {
-		ctx.Logger().Info("Bulk export: exporting file attachments")
-		if err = a.exportAttachments(ctx, attachments, outPath, zipWr); err != nil {
-			return err
+	if !cursor.skip(exportStagePosts) {
+		ctx.Logger().Info("Bulk export: exporting posts")
+		attachments, err := a.exportAllPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels, opts.Since, cursor)
+		if err != nil {
+			return nil, err
+		}
+		files.attachments = append(files.attachments, attachments...)
+		if err := cursor.finish(exportStagePosts); err != nil {
+			return nil, err
+		}
+	}
+
+	if !cursor.skip(exportStageEmoji) {
+		ctx.Logger().Info("Bulk export: exporting emoji")
+		emojiPaths, err := a.exportCustomEmoji(ctx, job, writer, outPath, "exported_emoji", !opts.CreateArchive, opts.Since)
+		if err != nil {
+			return nil, err
+		}
+		files.emojiPaths = emojiPaths
+		if err := cursor.finish(exportStageEmoji); err != nil {
+			return nil, err
 		}
+	}
+
+	if !cursor.skip(exportStageDirectChannels) {
+		ctx.Logger().Info("Bulk export: exporting direct channels")
+		if err := a.exportAllDirectChannels(ctx, job, writer, opts.IncludeArchivedChannels, opts.Since, cursor); err != nil {
+			return nil, err
+		}
+		if err := cursor.finish(exportStageDirectChannels); err != nil {
+			return nil, err
+		}
+	}
+
+	if !cursor.skip(exportStageDirectPosts) {
+		ctx.Logger().Info("Bulk export: exporting direct posts")
+		directAttachments, err := a.exportAllDirectPosts(ctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels, opts.Since, cursor)
+		if err != nil {
+			return nil, err
+		}
+		files.attachments = append(files.attachments, directAttachments...)
+		if err := cursor.finish(exportStageDirectPosts); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
 
-		ctx.Logger().Info("Bulk export: exporting direct file attachments")
-		if err = a.exportAttachments(ctx, directAttachments, outPath, zipWr); err != nil {
+func (a *App) exportFiles(ctx request.CTX, job *model.Job, outPath string, zipWr *zip.Writer, opts model.BulkExportOpts, files *exportedFiles) *model.AppError {
+	if opts.IncludeAttachments {
+		ctx.Logger().Info("Bulk export: exporting file attachments")
+		if err := a.exportAttachments(ctx, files.attachments, outPath, zipWr); err != nil {
 			return err
 		}
 
 		totalExportedEmojis := 0
-		emojisLen := len(emojiPaths)
+		emojisLen := len(files.emojiPaths)
 		ctx.Logger().Info("Bulk export: exporting custom emojis")
-		for _, emojiPath := range emojiPaths {
+		for _, emojiPath := range files.emojiPaths {
 			if err := a.exportFile(outPath, emojiPath, zipWr); err != nil {
 				return err
 			}
@@ -200,17 +280,30 @@ func (a *App) BulkExport(ctx request.CTX, writer io.Writer, outPath string, job
 			}
 		}
 
-		updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "attachments_exported", len(attachments)+len(directAttachments)+len(emojiPaths))
+		updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "attachments_exported", len(files.attachments)+len(files.emojiPaths))
 	}
 
 	if opts.IncludeProfilePictures {
 		ctx.Logger().Info("Bulk export: exporting profile pictures")
-		for _, profilePicture := range profilePictures {
+		for _, profilePicture := range files.profilePictures {
 			if err := a.exportFile(outPath, profilePicture, zipWr); err != nil {
 				ctx.Logger().Warn("Unable to export profile picture", mlog.String("profile_picture", profilePicture), mlog.Err(err))
 			}
 		}
-		updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "profile_pictures_exported", len(profilePictures))
+		updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "profile_pictures_exported", len(files.profilePictures))
+	}
+
+	return nil
+}
+
+func (a *App) exportManifest(zipWr *zip.Writer, manifest *model.BulkExportManifest) *model.AppError {
+	wr, err := zipWr.Create(model.BulkExportManifestName)
+	if err != nil {
+		return model.NewAppError("BulkExport", "app.export.zip_create.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := json.NewEncoder(wr).Encode(manifest); err != nil {
+		return model.NewAppError("BulkExport", "app.export.export_manifest.json_marshal.error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
 	return nil
@@ -251,6 +344,7 @@ func (a *App) exportVersion(writer io.Writer) *model.AppError {
 		Generator: "mattermost-server",
 		Version:   fmt.Sprintf("%s (%s, enterprise: %s)", model.CurrentVersion, model.BuildHash, model.BuildEnterpriseReady),
 		Created:   time.Now().Format(time.RFC3339Nano),
+		SiteURL:   *a.Config().ServiceSettings.SiteURL,
 	}
 
 	versionLine := &imports.LineImportData{
@@ -262,7 +356,7 @@ func (a *App) exportVersion(writer io.Writer) *model.AppError {
 	return a.exportWriteLine(writer, versionLine)
 }
 
-func (a *App) exportRolesAndSchemes(ctx request.CTX, job *model.Job, writer io.Writer) *model.AppError {
+func (a *App) exportRolesAndSchemes(ctx request.CTX, job *model.Job, writer io.Writer, since int64) *model.AppError {
 	// We export schemes first since they'll already include their attached roles
 	// which we map to avoid exporting them twice later in exportRoles.
 	schemeRolesMap := make(map[string]bool)
@@ -273,28 +367,28 @@ func (a *App) exportRolesAndSchemes(ctx request.CTX, job *model.Job, writer io.W
 	}
 
 	ctx.Logger().Info("Bulk export: exporting team schemes")
-	if err := a.exportSchemes(ctx, job, writer, model.SchemeScopeTeam, schemeRolesMap, roles); err != nil {
+	if err := a.exportSchemes(ctx, job, writer, model.SchemeScopeTeam, schemeRolesMap, roles, since); err != nil {
 		return err
 	}
 
 	ctx.Logger().Info("Bulk export: exporting channel schemes")
-	if err := a.exportSchemes(ctx, job, writer, model.SchemeScopeChannel, schemeRolesMap, roles); err != nil {
+	if err := a.exportSchemes(ctx, job, writer, model.SchemeScopeChannel, schemeRolesMap, roles, since); err != nil {
 		return err
 	}
 
 	ctx.Logger().Info("Bulk export: exporting roles")
-	if err := a.exportRoles(ctx, job, writer, schemeRolesMap, roles); err != nil {
+	if err := a.exportRoles(ctx, job, writer, schemeRolesMap, roles, since); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (a *App) exportRoles(ctx request.CTX, job *model.Job, writer io.Writer, schemeRoles map[string]bool, allRoles []*model.Role) *model.AppError {
+func (a *App) exportRoles(ctx request.CTX, job *model.Job, writer io.Writer, schemeRoles map[string]bool, allRoles []*model.Role, since int64) *model.AppError {
 	var cnt int
 	for _, role := range allRoles {
 		// We skip any roles that will be included as part of custom schemes.
-		if !schemeRoles[role.Name] {
+		if !schemeRoles[role.Name] && role.UpdateAt >= since {
 			if err := a.exportWriteLine(writer, ImportLineFromRole(role)); err != nil {
 				return err
 			}
@@ -307,7 +401,7 @@ func (a *App) exportRoles(ctx request.CTX, job *model.Job, writer io.Writer, sch
 	return nil
 }
 
-func (a *App) exportSchemes(ctx request.CTX, job *model.Job, writer io.Writer, scope string, schemeRolesMap map[string]bool, allRoles []*model.Role) *model.AppError {
+func (a *App) exportSchemes(ctx request.CTX, job *model.Job, writer io.Writer, scope string, schemeRolesMap map[string]bool, allRoles []*model.Role, since int64) *model.AppError {
 	rolesMap := make(map[string]*model.Role, len(allRoles))
 	for _, role := range allRoles {
 		rolesMap[role.Name] = role
@@ -347,6 +441,10 @@ func (a *App) exportSchemes(ctx request.CTX, job *model.Job, writer io.Writer, s
 				schemeRolesMap[scheme.DefaultChannelGuestRole] = true
 			}
 
+			if scheme.UpdateAt < since {
+				continue
+			}
+
 			if err := a.exportWriteLine(writer, ImportLineFromScheme(scheme, rolesMap)); err != nil {
 				return err
 			}
@@ -362,14 +460,13 @@ func (a *App) exportSchemes(ctx request.CTX, job *model.Job, writer io.Writer, s
 	}
 }
 
-func (a *App) exportAllTeams(ctx request.CTX, job *model.Job, writer io.Writer) (map[string]bool, *model.AppError) {
-	afterId := strings.Repeat("0", 26)
-	teamNames := make(map[string]bool)
+func (a *App) exportAllTeams(ctx request.CTX, job *model.Job, writer io.Writer, since int64, cursor *exportCursor) *model.AppError {
+	afterId := cursor.start(exportStageTeams)
 	cnt := 0
 	for {
 		teams, err := a.Srv().Store().Team().GetAllForExportAfter(1000, afterId)
 		if err != nil {
-			return nil, model.NewAppError("exportAllTeams", "app.team.get_all.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			return model.NewAppError("exportAllTeams", "app.team.get_all.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		}
 
 		if len(teams) == 0 {
@@ -385,20 +482,59 @@ func (a *App) exportAllTeams(ctx request.CTX, job *model.Job, writer io.Writer)
 			if team.DeleteAt != 0 {
 				continue
 			}
-			teamNames[team.Name] = true
+
+			// Skip unchanged.
+			if team.UpdateAt < since {
+				continue
+			}
 
 			teamLine := ImportLineFromTeam(team)
 			if err := a.exportWriteLine(writer, teamLine); err != nil {
-				return nil, err
+				return err
 			}
 		}
+
+		if err := cursor.save(exportStageTeams, afterId); err != nil {
+			return err
+		}
 	}
 
-	return teamNames, nil
+	return nil
 }
 
-func (a *App) exportAllChannels(ctx request.CTX, job *model.Job, writer io.Writer, teamNames map[string]bool, withArchived bool) *model.AppError {
+// exportTeamNames returns the names of all the teams that are not deleted,
+// including the ones left out of an incremental export.
+func (a *App) exportTeamNames() (map[string]bool, *model.AppError) {
 	afterId := strings.Repeat("0", 26)
+	teamNames := make(map[string]bool)
+	for {
+		teams, err := a.Srv().Store().Team().GetAllForExportAfter(1000, afterId)
+		if err != nil {
+			return nil, model.NewAppError("exportTeamNames", "app.team.get_all.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(teams) == 0 {
+			break
+		}
+
+		for _, team := range teams {
+			afterId = team.Id
+			if team.DeleteAt == 0 {
+				teamNames[team.Name] = true
+			}
+		}
+	}
+
+	return teamNames, nil
+}
+
+func (a *App) exportAllChannels(ctx request.CTX, job *model.Job, writer io.Writer, withArchived bool, since int64, cursor *exportCursor) *model.AppError {
+	teamNames, appErr := a.exportTeamNames()
+	if appErr != nil {
+		return appErr
+	}
+
+	afterId := cursor.start(exportStageChannels)
 	cnt := 0
 	for {
 		channels, err := a.Srv().Store().Channel().GetAllChannelsForExportAfter(1000, afterId)
@@ -425,18 +561,27 @@ func (a *App) exportAllChannels(ctx request.CTX, job *model.Job, writer io.Write
 				continue
 			}
 
+			// Skip unchanged.
+			if channel.UpdateAt < since {
+				continue
+			}
+
 			channelLine := ImportLineFromChannel(channel)
 			if err := a.exportWriteLine(writer, channelLine); err != nil {
 				return err
 			}
 		}
+
+		if err := cursor.save(exportStageChannels, afterId); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func (a *App) exportAllUsers(ctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels, includeProfilePictures bool) ([]string, *model.AppError) {
-	afterId := strings.Repeat("0", 26)
+func (a *App) exportAllUsers(ctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels, includeProfilePictures bool, since int64, cursor *exportCursor) ([]string, *model.AppError) {
+	afterId := cursor.start(exportStageUsers)
 	cnt := 0
 	profilePictures := []string{}
 	for {
@@ -455,6 +600,17 @@ func (a *App) exportAllUsers(ctx request.CTX, job *model.Job, writer io.Writer,
 		for _, user := range users {
 			afterId = user.Id
 
+			// Skip unchanged.
+			if user.UpdateAt < since {
+				changed, err := a.userMembershipsChangedSince(user.Id, since)
+				if err != nil {
+					return profilePictures, err
+				}
+				if !changed {
+					continue
+				}
+			}
+
 			// Gathering here the exportable preferences to pass them on to ImportLineFromUser
 			exportedPrefs := make(map[string]*string)
 			allPrefs, err := a.GetPreferencesForUser(ctx, user.Id)
@@ -526,11 +682,43 @@ func (a *App) exportAllUsers(ctx request.CTX, job *model.Job, writer io.Writer,
 				return profilePictures, err
 			}
 		}
+
+		if err := cursor.save(exportStageUsers, afterId); err != nil {
+			return profilePictures, err
+		}
 	}
 
 	return profilePictures, nil
 }
 
+// userMembershipsChangedSince reports whether the user joined a team, or
+// had any of their channel memberships updated, at or after since.
+func (a *App) userMembershipsChangedSince(userID string, since int64) (bool, *model.AppError) {
+	members, err := a.Srv().Store().Team().GetTeamMembersForExport(userID)
+	if err != nil {
+		return false, model.NewAppError("userMembershipsChangedSince", "app.team.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, member := range members {
+		if member.CreateAt >= since {
+			return true, nil
+		}
+
+		channelMembers, err := a.Srv().Store().Channel().GetChannelMembersForExport(userID, member.TeamId, true)
+		if err != nil {
+			return false, model.NewAppError("userMembershipsChangedSince", "app.channel.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, channelMember := range channelMembers {
+			if channelMember.LastUpdateAt >= since {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
 func (a *App) buildUserTeamAndChannelMemberships(c request.CTX, userID string, includeArchivedChannels bool) (*[]imports.UserTeamImportData, *model.AppError) {
 	var memberships []imports.UserTeamImportData
 
@@ -607,9 +795,9 @@ func (a *App) buildUserNotifyProps(notifyProps model.StringMap) *imports.UserNot
 	}
 }
 
-func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments bool, includeArchivedChannels bool) ([]imports.AttachmentImportData, *model.AppError) {
+func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments bool, includeArchivedChannels bool, since int64, cursor *exportCursor) ([]imports.AttachmentImportData, *model.AppError) {
 	var attachments []imports.AttachmentImportData
-	afterId := strings.Repeat("0", 26)
+	afterId := cursor.start(exportStagePosts)
 	var postProcessCount uint64
 	logCheckpoint := time.Now()
 
@@ -620,7 +808,7 @@ func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer,
 			logCheckpoint = time.Now()
 		}
 
-		posts, nErr := a.Srv().Store().Post().GetParentsForExportAfter(1000, afterId, includeArchivedChannels)
+		posts, nErr := a.Srv().Store().Post().GetParentsForExportAfter(1000, afterId, includeArchivedChannels, since)
 		if nErr != nil {
 			return nil, model.NewAppError("exportAllPosts", "app.post.get_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 		}
@@ -669,6 +857,11 @@ func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer,
 				}
 			}
 
+			postLine.Post.Poll, err = a.buildPostPoll(ctx, &post.Post)
+			if err != nil {
+				return nil, err
+			}
+
 			if len(post.FileIds) > 0 {
 				postAttachments, err := a.buildPostAttachments(post.Id)
 				if err != nil {
@@ -685,6 +878,10 @@ func (a *App) exportAllPosts(ctx request.CTX, job *model.Job, writer io.Writer,
 				return nil, err
 			}
 		}
+
+		if err := cursor.save(exportStagePosts, afterId); err != nil {
+			return nil, err
+		}
 	}
 }
 
@@ -706,6 +903,11 @@ func (a *App) buildPostReplies(ctx request.CTX, postID string, withAttachments b
 				return nil, nil, appErr
 			}
 		}
+		var appErr *model.AppError
+		replyImportObject.Poll, appErr = a.buildPostPoll(ctx, &reply.Post)
+		if appErr != nil {
+			return nil, nil, appErr
+		}
 		if len(reply.FileIds) > 0 {
 			postAttachments, appErr := a.buildPostAttachments(reply.Id)
 			if appErr != nil {
@@ -762,6 +964,68 @@ func (a *App) BuildPostReactions(ctx request.CTX, postID string) (*[]ReactionImp
 	return &reactionsOfPost, nil
 }
 
+// buildPostPoll returns the poll held by a post along with its votes, or nil
+// if the post is not a poll.
+func (a *App) buildPostPoll(ctx request.CTX, post *model.Post) (*imports.PollImportData, *model.AppError) {
+	if post.Type != model.PostTypePoll {
+		return nil, nil
+	}
+
+	poll, nErr := a.Srv().Store().Poll().GetForPost(post.Id)
+	if nErr != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(nErr, &nfErr) {
+			ctx.Logger().Info("Skipping poll of post since the entity doesn't exist anymore", mlog.String("post_id", post.Id))
+			return nil, nil
+		}
+		return nil, model.NewAppError("buildPostPoll", "app.poll.get.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+	}
+
+	pollLine := ImportPollFromPoll(poll)
+	// Votes can only be exported along with the username of the voter, which
+	// anonymous polls must not reveal.
+	if poll.Anonymous {
+		return pollLine, nil
+	}
+
+	votes, nErr := a.Srv().Store().Poll().GetVotes(poll.Id)
+	if nErr != nil {
+		return nil, model.NewAppError("buildPostPoll", "app.poll.get_votes.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+	}
+
+	usernames := map[string]string{}
+	pollVotes := []imports.PollVoteImportData{}
+	for _, vote := range votes {
+		option := poll.GetOption(vote.OptionId)
+		if option == nil {
+			continue
+		}
+
+		username, ok := usernames[vote.UserId]
+		if !ok {
+			user, err := a.Srv().Store().User().Get(context.Background(), vote.UserId)
+			if err != nil {
+				var nfErr *store.ErrNotFound
+				if errors.As(err, &nfErr) { // the user that voted might've been deleted by now
+					ctx.Logger().Info("Skipping poll votes by user since the entity doesn't exist anymore", mlog.String("user_id", vote.UserId))
+					continue
+				}
+				return nil, model.NewAppError("buildPostPoll", "app.user.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			username = user.Username
+			usernames[vote.UserId] = username
+		}
+
+		pollVotes = append(pollVotes, imports.PollVoteImportData{
+			User:   model.NewPointer(username),
+			Option: model.NewPointer(option.Text),
+		})
+	}
+	pollLine.Votes = &pollVotes
+
+	return pollLine, nil
+}
+
 func (a *App) buildPostAttachments(postID string) ([]imports.AttachmentImportData, *model.AppError) {
 	infos, nErr := a.Srv().Store().FileInfo().GetForPost(postID, false, false, false)
 	if nErr != nil {
@@ -770,13 +1034,13 @@ func (a *App) buildPostAttachments(postID string) ([]imports.AttachmentImportDat
 
 	attachments := make([]imports.AttachmentImportData, 0, len(infos))
 	for _, info := range infos {
-		attachments = append(attachments, imports.AttachmentImportData{Path: &info.Path})
+		attachments = append(attachments, imports.AttachmentImportData{Path: &info.Path, Id: &info.Id})
 	}
 
 	return attachments, nil
 }
 
-func (a *App) exportCustomEmoji(c request.CTX, job *model.Job, writer io.Writer, outPath, exportDir string, exportFiles bool) ([]string, *model.AppError) {
+func (a *App) exportCustomEmoji(c request.CTX, job *model.Job, writer io.Writer, outPath, exportDir string, exportFiles bool, since int64) ([]string, *model.AppError) {
 	var emojiPaths []string
 	pageNumber := 0
 	cnt := 0
@@ -804,6 +1068,11 @@ func (a *App) exportCustomEmoji(c request.CTX, job *model.Job, writer io.Writer,
 		}
 
 		for _, emoji := range customEmojiList {
+			// Skip unchanged.
+			if emoji.UpdateAt < since {
+				continue
+			}
+
 			emojiImagePath := filepath.Join(emojiPath, emoji.Id, "image")
 			filePath := filepath.Join(exportDir, emoji.Id, "image")
 			if exportFiles {
@@ -860,8 +1129,8 @@ func (a *App) copyEmojiImages(emojiId string, emojiImagePath string, pathToDir s
 	return nil
 }
 
-func (a *App) exportAllDirectChannels(ctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels bool) *model.AppError {
-	afterId := strings.Repeat("0", 26)
+func (a *App) exportAllDirectChannels(ctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels bool, since int64, cursor *exportCursor) *model.AppError {
+	afterId := cursor.start(exportStageDirectChannels)
 	cnt := 0
 	for {
 		channels, err := a.Srv().Store().Channel().GetAllDirectChannelsForExportAfter(1000, afterId, includeArchivedChannels)
@@ -888,6 +1157,11 @@ func (a *App) exportAllDirectChannels(ctx request.CTX, job *model.Job, writer io
 				continue
 			}
 
+			// Skip unchanged.
+			if channel.UpdateAt < since {
+				continue
+			}
+
 			favoritedBy, err := a.buildFavoritedByList(channel.Id)
 			if err != nil {
 				return err
@@ -903,6 +1177,10 @@ func (a *App) exportAllDirectChannels(ctx request.CTX, job *model.Job, writer io
 				return err
 			}
 		}
+
+		if err := cursor.save(exportStageDirectChannels, afterId); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -980,9 +1258,9 @@ func (a *App) buildShownByList(channel *model.DirectChannelForExport) ([]string,
 	return shownBy, nil
 }
 
-func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments, includeArchivedChannels bool) ([]imports.AttachmentImportData, *model.AppError) {
+func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Writer, withAttachments, includeArchivedChannels bool, since int64, cursor *exportCursor) ([]imports.AttachmentImportData, *model.AppError) {
 	var attachments []imports.AttachmentImportData
-	afterId := strings.Repeat("0", 26)
+	afterId := cursor.start(exportStageDirectPosts)
 	var postProcessCount uint64
 	logCheckpoint := time.Now()
 
@@ -993,7 +1271,7 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 			logCheckpoint = time.Now()
 		}
 
-		posts, err := a.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, afterId, includeArchivedChannels)
+		posts, err := a.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, afterId, includeArchivedChannels, since)
 		if err != nil {
 			return nil, model.NewAppError("exportAllDirectPosts", "app.post.get_direct_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		}
@@ -1043,6 +1321,11 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 				postLine.DirectPost.Attachments = &postAttachments
 			}
 
+			postLine.DirectPost.Poll, err = a.buildPostPoll(ctx, &post.Post)
+			if err != nil {
+				return nil, err
+			}
+
 			followers, err := a.buildThreadFollowers(ctx, post.Id)
 			if err != nil {
 				return nil, err
@@ -1056,6 +1339,10 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 				return nil, err
 			}
 		}
+
+		if err := cursor.save(exportStageDirectPosts, afterId); err != nil {
+			return nil, err
+		}
 	}
 	return attachments, nil
 }
@@ -1107,9 +1394,14 @@ func (a *App) ListExports() ([]string, *model.AppError) {
 		return nil, appErr
 	}
 
-	results := make([]string, len(exports))
+	results := make([]string, 0, len(exports))
 	for i := range exports {
-		results[i] = filepath.Base(exports[i])
+		name := filepath.Base(exports[i])
+		// Skip the lines staged by unfinished export jobs.
+		if strings.HasSuffix(name, bulkExportStagingSuffix) {
+			continue
+		}
+		results = append(results, name)
 	}
 
 	return results, nil
@@ -0,0 +1,337 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+const (
+	// bulkExportSegmentSize is the amount of exported lines buffered in
+	// memory before they are stored and the export progress is checkpointed.
+	bulkExportSegmentSize = 32 * 1024 * 1024
+
+	// bulkExportStagingSuffix names the directory, next to the export file,
+	// where an export job stages its lines until the archive is assembled.
+	bulkExportStagingSuffix = "_staging"
+)
+
+// The stages of a bulk export, in the order they run.
+const (
+	exportStageRolesAndSchemes = "roles_and_schemes"
+	exportStageTeams           = "teams"
+	exportStageChannels        = "channels"
+	exportStageUsers           = "users"
+	exportStagePosts           = "posts"
+	exportStageEmoji           = "emoji"
+	exportStageDirectChannels  = "direct_channels"
+	exportStageDirectPosts     = "direct_posts"
+	exportStageDone            = "done"
+)
+
+var exportStages = []string{
+	exportStageRolesAndSchemes,
+	exportStageTeams,
+	exportStageChannels,
+	exportStageUsers,
+	exportStagePosts,
+	exportStageEmoji,
+	exportStageDirectChannels,
+	exportStageDirectPosts,
+	exportStageDone,
+}
+
+// Job data keys used to checkpoint the progress of an export job.
+const (
+	exportJobDataStartedAt = "export_started_at"
+	exportJobDataStage     = "checkpoint_stage"
+	exportJobDataAfterId   = "checkpoint_after_id"
+	exportJobDataSegments  = "checkpoint_segments"
+)
+
+// exportCursor tracks the progress of a bulk export through its stages. It
+// lets the stages skip the work already done by a previous run of the same
+// job, and checkpoints their progress as they go. A nil cursor runs every
+// stage from the start.
+type exportCursor struct {
+	stage      string
+	afterId    string
+	checkpoint func(stage, afterId string) *model.AppError
+}
+
+// skip reports whether stage was completed by a previous run.
+func (c *exportCursor) skip(stage string) bool {
+	if c == nil {
+		return false
+	}
+	return slices.Index(exportStages, stage) < slices.Index(exportStages, c.stage)
+}
+
+// start returns the id after which stage has to resume.
+func (c *exportCursor) start(stage string) string {
+	if c != nil && c.stage == stage && c.afterId != "" {
+		return c.afterId
+	}
+	return strings.Repeat("0", 26)
+}
+
+// save records that stage exported everything up to afterId.
+func (c *exportCursor) save(stage, afterId string) *model.AppError {
+	if c == nil {
+		return nil
+	}
+	return c.checkpoint(stage, afterId)
+}
+
+// finish records that stage has been completed.
+func (c *exportCursor) finish(stage string) *model.AppError {
+	next := exportStages[slices.Index(exportStages, stage)+1]
+	return c.save(next, "")
+}
+
+// exportStaging buffers the exported lines and stores them as numbered
+// segments in the export file store, so that they survive a restart of the
+// job.
+type exportStaging struct {
+	a        *App
+	logger   mlog.LoggerIFace
+	job      *model.Job
+	dir      string
+	buf      bytes.Buffer
+	segments int
+}
+
+func (s *exportStaging) Write(p []byte) (int, error) {
+	return s.buf.Write(p)
+}
+
+func (s *exportStaging) segmentPath(n int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("segment_%06d.jsonl", n))
+}
+
+func (s *exportStaging) flush() *model.AppError {
+	if s.buf.Len() == 0 {
+		return nil
+	}
+
+	if _, err := s.a.WriteExportFile(&s.buf, s.segmentPath(s.segments)); err != nil {
+		return err
+	}
+	s.segments++
+	s.buf.Reset()
+
+	return nil
+}
+
+// checkpoint stores the buffered lines and records the position of the
+// export in the job's data. Lines of an unfinished stage are only stored once
+// enough of them are buffered, since the position can't be recorded before
+// the lines preceding it are safely stored.
+func (s *exportStaging) checkpoint(stage, afterId string) *model.AppError {
+	if afterId != "" && s.buf.Len() < bulkExportSegmentSize {
+		return nil
+	}
+
+	if err := s.flush(); err != nil {
+		return err
+	}
+
+	s.job.Data[exportJobDataStage] = stage
+	s.job.Data[exportJobDataAfterId] = afterId
+	s.job.Data[exportJobDataSegments] = strconv.Itoa(s.segments)
+	if _, err := s.a.Srv().Store().Job().UpdateOptimistically(s.job, model.JobStatusInProgress); err != nil {
+		s.logger.Warn("Failed to checkpoint export job", mlog.String("stage", stage), mlog.Err(err))
+	}
+
+	return nil
+}
+
+func (s *exportStaging) remove() {
+	if err := s.a.ExportFileBackend().RemoveDirectory(s.dir); err != nil {
+		s.logger.Warn("Failed to remove the staged export lines", mlog.String("staging_dir", s.dir), mlog.Err(err))
+	}
+}
+
+// resumableBulkExport runs the bulk export of a job. The exported lines are
+// staged in the export file store and the progress is checkpointed in the
+// job's data, so a job that is run again after failing or being interrupted
+// resumes where it stopped. The archive is only assembled once every stage
+// is done.
+func (a *App) resumableBulkExport(ctx request.CTX, writer io.Writer, outPath string, job *model.Job, opts model.BulkExportOpts) *model.AppError {
+	staging := &exportStaging{
+		a:      a,
+		logger: ctx.Logger(),
+		job:    job,
+		dir:    filepath.Join(outPath, job.Id+bulkExportStagingSuffix),
+	}
+	staging.segments, _ = strconv.Atoi(job.Data[exportJobDataSegments])
+
+	cursor := &exportCursor{
+		stage:      job.Data[exportJobDataStage],
+		afterId:    job.Data[exportJobDataAfterId],
+		checkpoint: staging.checkpoint,
+	}
+
+	// The start of the first run bounds what the export contains, so it
+	// must not move when the job resumes.
+	startedAt, err := strconv.ParseInt(job.Data[exportJobDataStartedAt], 10, 64)
+	if err != nil || cursor.stage == "" {
+		startedAt = model.GetMillis()
+		cursor.stage = exportStages[0]
+		cursor.afterId = ""
+		staging.segments = 0
+		job.Data[exportJobDataStartedAt] = strconv.FormatInt(startedAt, 10)
+		if err := cursor.save(cursor.stage, ""); err != nil {
+			return err
+		}
+	} else {
+		ctx.Logger().Info("Bulk export: resuming export", mlog.String("stage", cursor.stage), mlog.String("after_id", cursor.afterId), mlog.Int("segments", staging.segments))
+	}
+
+	if cursor.stage != exportStageDone {
+		// The files referenced by the staged lines are gathered back from
+		// them when assembling the archive, so there is no need to keep
+		// track of the attachments here.
+		stageOpts := opts
+		stageOpts.IncludeAttachments = false
+		if _, err := a.exportStages(ctx, job, staging, outPath, stageOpts, cursor); err != nil {
+			return err
+		}
+	}
+
+	manifest := &model.BulkExportManifest{
+		JobId:      job.Id,
+		Since:      opts.Since,
+		ExportedAt: startedAt,
+	}
+
+	if err := a.assembleBulkExport(ctx, job, writer, outPath, opts, staging, manifest); err != nil {
+		return err
+	}
+
+	staging.remove()
+
+	return nil
+}
+
+// assembleBulkExport writes the export archive out of the staged lines.
+func (a *App) assembleBulkExport(ctx request.CTX, job *model.Job, writer io.Writer, outPath string, opts model.BulkExportOpts, staging *exportStaging, manifest *model.BulkExportManifest) *model.AppError {
+	zipWr := zip.NewWriter(writer)
+	jsonlWr, err := zipWr.Create("import.jsonl")
+	if err != nil {
+		return model.NewAppError("BulkExport", "app.export.zip_create.error",
+			nil, "err="+err.Error(), http.StatusInternalServerError)
+	}
+
+	ctx.Logger().Info("Bulk export: exporting version")
+	if err := a.exportVersion(jsonlWr); err != nil {
+		return err
+	}
+
+	ctx.Logger().Info("Bulk export: assembling staged lines", mlog.Int("segments", staging.segments))
+	files := &exportedFiles{}
+	for i := range staging.segments {
+		if err := a.copyExportSegment(staging.segmentPath(i), jsonlWr, opts, files); err != nil {
+			return err
+		}
+	}
+
+	if err := a.exportFiles(ctx, job, outPath, zipWr, opts, files); err != nil {
+		return err
+	}
+
+	if err := a.exportManifest(zipWr, manifest); err != nil {
+		return err
+	}
+
+	if err := zipWr.Close(); err != nil {
+		return model.NewAppError("BulkExport", "app.export.zip_close.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// copyExportSegment copies a staged segment into writer, gathering the files
+// its lines reference along the way.
+func (a *App) copyExportSegment(segmentPath string, writer io.Writer, opts model.BulkExportOpts, files *exportedFiles) *model.AppError {
+	rd, appErr := a.ExportFileReader(segmentPath)
+	if appErr != nil {
+		return appErr
+	}
+	defer rd.Close()
+
+	gather := opts.IncludeAttachments || opts.IncludeProfilePictures
+	br := bufio.NewReader(rd)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			if _, wErr := writer.Write(line); wErr != nil {
+				return model.NewAppError("BulkExport", "app.export.export_write_line.io_writer.error", nil, "", http.StatusBadRequest).Wrap(wErr)
+			}
+			if gather {
+				if gErr := files.gather(line, opts); gErr != nil {
+					return model.NewAppError("BulkExport", "app.export.export_segment.json_unmarshal.error", nil, "", http.StatusInternalServerError).Wrap(gErr)
+				}
+			}
+		}
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return model.NewAppError("BulkExport", "app.export.export_segment.read.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+}
+
+// gather records the files referenced by an exported line.
+func (f *exportedFiles) gather(line []byte, opts model.BulkExportOpts) error {
+	var data imports.LineImportData
+	if err := json.Unmarshal(line, &data); err != nil {
+		return err
+	}
+
+	addAttachments := func(attachments *[]imports.AttachmentImportData, replies *[]imports.ReplyImportData) {
+		if attachments != nil {
+			f.attachments = append(f.attachments, *attachments...)
+		}
+		if replies != nil {
+			for _, reply := range *replies {
+				if reply.Attachments != nil {
+					f.attachments = append(f.attachments, *reply.Attachments...)
+				}
+			}
+		}
+	}
+
+	switch {
+	case data.User != nil && opts.IncludeProfilePictures:
+		if data.User.ProfileImage != nil {
+			f.profilePictures = append(f.profilePictures, *data.User.ProfileImage)
+		}
+	case data.Post != nil && opts.IncludeAttachments:
+		addAttachments(data.Post.Attachments, data.Post.Replies)
+	case data.DirectPost != nil && opts.IncludeAttachments:
+		addAttachments(data.DirectPost.Attachments, data.DirectPost.Replies)
+	case data.Emoji != nil && opts.IncludeAttachments:
+		if data.Emoji.Image != nil {
+			f.emojiPaths = append(f.emojiPaths, *data.Emoji.Image)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+)
+
+func readExportArchive(t *testing.T, b *bytes.Buffer) ([]imports.LineImportData, *model.BulkExportManifest) {
+	t.Helper()
+
+	zipRd, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	require.NoError(t, err)
+
+	jsonlFile, err := zipRd.Open("import.jsonl")
+	require.NoError(t, err)
+	defer jsonlFile.Close()
+
+	var lines []imports.LineImportData
+	scanner := bufio.NewScanner(jsonlFile)
+	for scanner.Scan() {
+		var line imports.LineImportData
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	require.NoError(t, scanner.Err())
+
+	manifestFile, err := zipRd.Open(model.BulkExportManifestName)
+	require.NoError(t, err)
+	defer manifestFile.Close()
+
+	var manifest model.BulkExportManifest
+	require.NoError(t, json.NewDecoder(manifestFile).Decode(&manifest))
+
+	return lines, &manifest
+}
+
+func TestResumableBulkExport(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	saveJob := func(data model.StringMap) *model.Job {
+		job, err := th.App.Srv().Store().Job().Save(&model.Job{
+			Id:     model.NewId(),
+			Type:   model.JobTypeExportProcess,
+			Status: model.JobStatusInProgress,
+			Data:   data,
+		})
+		require.NoError(t, err)
+		return job
+	}
+
+	t.Run("export from the start", func(t *testing.T) {
+		job := saveJob(model.StringMap{})
+
+		var b bytes.Buffer
+		appErr := th.App.BulkExport(th.Context, &b, "export", job, model.BulkExportOpts{CreateArchive: true})
+		require.Nil(t, appErr)
+
+		lines, manifest := readExportArchive(t, &b)
+		require.NotEmpty(t, lines)
+		assert.Equal(t, "version", lines[0].Type)
+
+		var teams, posts []string
+		for _, line := range lines {
+			switch line.Type {
+			case "team":
+				teams = append(teams, *line.Team.Name)
+			case "post":
+				posts = append(posts, *line.Post.Message)
+			}
+		}
+		assert.Contains(t, teams, th.BasicTeam.Name)
+		assert.Contains(t, posts, th.BasicPost.Message)
+
+		assert.Equal(t, job.Id, manifest.JobId)
+		assert.Equal(t, job.Data[exportJobDataStartedAt], strconv.FormatInt(manifest.ExportedAt, 10))
+		assert.Equal(t, exportStageDone, job.Data[exportJobDataStage])
+
+		// The staged lines are removed once the archive is assembled.
+		stagingDir := filepath.Join("export", job.Id+bulkExportStagingSuffix)
+		exists, appErr := th.App.ExportFileExists(filepath.Join(stagingDir, "segment_000000.jsonl"))
+		require.Nil(t, appErr)
+		assert.False(t, exists)
+	})
+
+	t.Run("resume from a checkpoint", func(t *testing.T) {
+		job := saveJob(model.StringMap{
+			exportJobDataStartedAt: "1234",
+			exportJobDataStage:     exportStagePosts,
+			exportJobDataSegments:  "1",
+		})
+
+		// Lines staged by the previous run of the job.
+		stagingDir := filepath.Join("export", job.Id+bulkExportStagingSuffix)
+		staged := `{"type":"team","team":{"name":"staged-team","display_name":"Staged","type":"O"}}` + "\n"
+		_, appErr := th.App.WriteExportFile(strings.NewReader(staged), filepath.Join(stagingDir, "segment_000000.jsonl"))
+		require.Nil(t, appErr)
+
+		var b bytes.Buffer
+		appErr = th.App.BulkExport(th.Context, &b, "export", job, model.BulkExportOpts{CreateArchive: true})
+		require.Nil(t, appErr)
+
+		lines, manifest := readExportArchive(t, &b)
+
+		var teams, posts []string
+		for _, line := range lines {
+			switch line.Type {
+			case "team":
+				teams = append(teams, *line.Team.Name)
+			case "user", "channel":
+				assert.Fail(t, "stages completed by the previous run should be skipped", line.Type)
+			case "post":
+				posts = append(posts, *line.Post.Message)
+			}
+		}
+		assert.Equal(t, []string{"staged-team"}, teams)
+		assert.Contains(t, posts, th.BasicPost.Message)
+
+		// The export keeps the bounds set by its first run.
+		assert.Equal(t, int64(1234), manifest.ExportedAt)
+	})
+}
@@ -249,6 +249,7 @@ func ImportLineForPost(post *model.PostForExport) *imports.LineImportData {
 	return &imports.LineImportData{
 		Type: "post",
 		Post: &imports.PostImportData{
+			Id:        &post.Id,
 			Team:      &post.TeamName,
 			Channel:   &post.ChannelName,
 			User:      &post.Username,
@@ -272,6 +273,7 @@ func ImportLineForDirectPost(post *model.DirectPostForExport) *imports.LineImpor
 	return &imports.LineImportData{
 		Type: "direct_post",
 		DirectPost: &imports.DirectPostImportData{
+			Id:             &post.Id,
 			ChannelMembers: &channelMembers,
 			User:           &post.User,
 			Type:           &post.Type,
@@ -288,6 +290,7 @@ func ImportLineForDirectPost(post *model.DirectPostForExport) *imports.LineImpor
 func ImportReplyFromPost(post *model.ReplyForExport) *imports.ReplyImportData {
 	f := []string(post.FlaggedBy)
 	return &imports.ReplyImportData{
+		Id:        &post.Id,
 		User:      &post.Username,
 		Type:      &post.Type,
 		Message:   &post.Message,
@@ -306,6 +309,21 @@ func ImportReactionFromPost(user *model.User, reaction *model.Reaction) *imports
 	}
 }
 
+func ImportPollFromPoll(poll *model.Poll) *imports.PollImportData {
+	options := make([]string, 0, len(poll.Options))
+	for _, option := range poll.Options {
+		options = append(options, option.Text)
+	}
+
+	return &imports.PollImportData{
+		Question:       &poll.Question,
+		Options:        &options,
+		MultipleChoice: &poll.MultipleChoice,
+		Anonymous:      &poll.Anonymous,
+		CloseAt:        &poll.CloseAt,
+	}
+}
+
 func ImportLineFromEmoji(emoji *model.Emoji, filePath string) *imports.LineImportData {
 	return &imports.LineImportData{
 		Type: "emoji",
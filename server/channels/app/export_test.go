@@ -179,7 +179,7 @@ func TestExportCustomEmoji(t *testing.T) {
 	outPath, err := filepath.Abs(filePath)
 	require.NoError(t, err)
 
-	_, appErr := th.App.exportCustomEmoji(th.Context, nil, fileWriter, outPath, dirNameToExportEmoji, false)
+	_, appErr := th.App.exportCustomEmoji(th.Context, nil, fileWriter, outPath, dirNameToExportEmoji, false, 0)
 	require.Nil(t, appErr, "should not have failed")
 }
 
@@ -470,7 +470,7 @@ func TestExportDMandGMPost(t *testing.T) {
 	}
 	th1.App.CreatePost(th1.Context, p4, gmChannel, model.CreatePostFlags{SetOnline: true})
 
-	posts, err := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 4, len(posts))
 
@@ -483,7 +483,7 @@ func TestExportDMandGMPost(t *testing.T) {
 	th2 := Setup(t)
 	defer th2.TearDown()
 
-	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 0, len(posts))
 
@@ -492,7 +492,7 @@ func TestExportDMandGMPost(t *testing.T) {
 	assert.Nil(t, appErr)
 	assert.Equal(t, 0, i)
 
-	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, err)
 
 	// Adding some determinism so its possible to assert on slice index
@@ -543,7 +543,7 @@ func TestExportPostWithProps(t *testing.T) {
 	}
 	th1.App.CreatePost(th1.Context, p2, gmChannel, model.CreatePostFlags{SetOnline: true})
 
-	posts, err := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, err)
 	assert.Len(t, posts, 2)
 	require.NotEmpty(t, posts[0].Props)
@@ -558,7 +558,7 @@ func TestExportPostWithProps(t *testing.T) {
 	th2 := Setup(t)
 	defer th2.TearDown()
 
-	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, err)
 	assert.Len(t, posts, 0)
 
@@ -567,7 +567,7 @@ func TestExportPostWithProps(t *testing.T) {
 	assert.Nil(t, appErr)
 	assert.Equal(t, 0, i)
 
-	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, err)
 
 	// Adding some determinism so its possible to assert on slice index
@@ -625,7 +625,7 @@ func TestExportDMPostWithSelf(t *testing.T) {
 	err := th1.App.BulkExport(th1.Context, &b, "somePath", nil, model.BulkExportOpts{})
 	require.Nil(t, err)
 
-	posts, nErr := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, nErr := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, nErr)
 	assert.Equal(t, 1, len(posts))
 
@@ -634,7 +634,7 @@ func TestExportDMPostWithSelf(t *testing.T) {
 	th2 := Setup(t)
 	defer th2.TearDown()
 
-	posts, nErr = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, nErr = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, nErr)
 	assert.Equal(t, 0, len(posts))
 
@@ -643,7 +643,7 @@ func TestExportDMPostWithSelf(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 0, i)
 
-	posts, nErr = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, nErr = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false, 0)
 	require.NoError(t, nErr)
 	assert.Equal(t, 1, len(posts))
 	assert.Equal(t, 1, len((*posts[0].ChannelMembers)))
@@ -1351,3 +1351,51 @@ func TestExportSchemes(t *testing.T) {
 		require.Equal(t, customTeamGuestRole.BuiltIn, importedTeamGuestRole.BuiltIn)
 	})
 }
+
+func TestExportSince(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	oldPost := th.CreatePost(th.BasicChannel)
+
+	time.Sleep(time.Millisecond)
+	since := model.GetMillis()
+	time.Sleep(time.Millisecond)
+
+	channel := th.CreateChannel(th.Context, th.BasicTeam)
+	post := th.CreatePost(channel)
+	th.CreatePostReply(th.BasicPost)
+
+	var b bytes.Buffer
+	appErr := th.App.BulkExport(th.Context, &b, "somePath", nil, model.BulkExportOpts{Since: since})
+	require.Nil(t, appErr)
+
+	var channels, users, posts []string
+	scanner := bufio.NewScanner(&b)
+	for scanner.Scan() {
+		var line imports.LineImportData
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		switch line.Type {
+		case "team":
+			assert.Fail(t, "unchanged team should not be exported", *line.Team.Name)
+		case "channel":
+			channels = append(channels, *line.Channel.Name)
+		case "user":
+			users = append(users, *line.User.Username)
+		case "post":
+			posts = append(posts, *line.Post.Message)
+		}
+	}
+	require.NoError(t, scanner.Err())
+
+	assert.Equal(t, []string{channel.Name}, channels)
+
+	// The user joined the new channel, which the other user didn't.
+	assert.Contains(t, users, th.BasicUser.Username)
+	assert.NotContains(t, users, th.BasicUser2.Username)
+
+	// The thread with a new reply is exported again along with the new post.
+	assert.Contains(t, posts, post.Message)
+	assert.Contains(t, posts, th.BasicPost.Message)
+	assert.NotContains(t, posts, oldPost.Message)
+}
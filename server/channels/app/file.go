@@ -73,9 +73,9 @@ func (a *App) CheckMandatoryS3Fields(settings *model.FileSettings) *model.AppErr
 
 func connectionTestErrorToAppError(connTestErr error) *model.AppError {
 	switch err := connTestErr.(type) {
-	case *filestore.S3FileBackendAuthError:
+	case *filestore.S3FileBackendAuthError, *filestore.AzureFileBackendAuthError, *filestore.GCSFileBackendAuthError:
 		return model.NewAppError("TestConnection", "api.file.test_connection_s3_auth.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
-	case *filestore.S3FileBackendNoBucketError:
+	case *filestore.S3FileBackendNoBucketError, *filestore.AzureFileBackendNoContainerError, *filestore.GCSFileBackendNoBucketError:
 		return model.NewAppError("TestConnection", "api.file.test_connection_s3_bucket_does_not_exist.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	default:
 		return model.NewAppError("TestConnection", "api.file.test_connection.app_error", nil, "", http.StatusInternalServerError).Wrap(connTestErr)
@@ -627,6 +627,12 @@ func UploadFileSetClientId(clientId string) func(t *UploadFileTask) {
 	}
 }
 
+func UploadFileSetId(id string) func(t *UploadFileTask) {
+	return func(t *UploadFileTask) {
+		t.Id = id
+	}
+}
+
 func UploadFileSetRaw() func(t *UploadFileTask) {
 	return func(t *UploadFileTask) {
 		t.Raw = true
@@ -661,6 +667,10 @@ type UploadFileTask struct {
 	// An optional, client-assigned Id field.
 	ClientId string
 
+	// An optional Id to save the file with instead of a new one. This is
+	// used by the bulk import process to keep the ids of imported files.
+	Id string
+
 	// If Raw, do not execute special processing for images, just upload
 	// the file.  Plugins are still invoked.
 	Raw bool
@@ -710,7 +720,10 @@ func (t *UploadFileTask) init(a *App) {
 	}
 
 	t.fileinfo = model.NewInfo(filepath.Base(t.Name))
-	t.fileinfo.Id = model.NewId()
+	t.fileinfo.Id = t.Id
+	if t.fileinfo.Id == "" {
+		t.fileinfo.Id = model.NewId()
+	}
 	t.fileinfo.CreatorId = t.UserId
 	t.fileinfo.CreateAt = t.Timestamp.UnixNano() / int64(time.Millisecond)
 	t.fileinfo.Path = t.pathPrefix() + t.Name
@@ -804,6 +817,7 @@ func (a *App) UploadFileX(c request.CTX, channelID, name string, input io.Reader
 		t.postprocessImage(file)
 	}
 
+	a.markFileForScan(t.fileinfo)
 	if _, err := t.saveToDatabase(c, t.fileinfo); err != nil {
 		var appErr *model.AppError
 		switch {
@@ -813,6 +827,7 @@ func (a *App) UploadFileX(c request.CTX, channelID, name string, input io.Reader
 			return nil, model.NewAppError("UploadFileX", "app.file_info.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		}
 	}
+	a.scanFileInBackground(c, t.fileinfo)
 
 	if *a.Config().FileSettings.ExtractContent && t.ExtractContent {
 		infoCopy := *t.fileinfo
@@ -1064,6 +1079,7 @@ func (a *App) DoUploadFileExpectModification(c request.CTX, now time.Time, rawTe
 		return nil, data, err
 	}
 
+	a.markFileForScan(info)
 	if _, err := a.Srv().Store().FileInfo().Save(c, info); err != nil {
 		var appErr *model.AppError
 		switch {
@@ -1073,6 +1089,7 @@ func (a *App) DoUploadFileExpectModification(c request.CTX, now time.Time, rawTe
 			return nil, data, model.NewAppError("DoUploadFileExpectModification", "app.file_info.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		}
 	}
+	a.scanFileInBackground(c, info)
 
 	// The extra boolean extractContent is used to turn off extraction
 	// during the import process. It is unnecessary overhead during the import,
@@ -1310,6 +1327,9 @@ func (a *App) GetFile(rctx request.CTX, fileID string) ([]byte, *model.AppError)
 	if err != nil {
 		return nil, err
 	}
+	if err := CheckFileNotQuarantined(info); err != nil {
+		return nil, err
+	}
 
 	data, err := a.ReadFile(info.Path)
 	if err != nil {
@@ -1352,6 +1372,9 @@ func (a *App) CopyFileInfos(rctx request.CTX, userID string, fileIDs []string) (
 				return nil, model.NewAppError("CopyFileInfos", "app.file_info.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 			}
 		}
+		// A copy of a file which is still waiting for its scan must be
+		// scanned on its own, the verdict of the original isn't copied over.
+		a.scanFileInBackground(rctx, fileInfo)
 
 		newFileIds = append(newFileIds, fileInfo.Id)
 	}
@@ -1462,21 +1485,11 @@ func (a *App) ExtractContentFromFileInfo(rctx request.CTX, fileInfo *model.FileI
 		return nil
 	}
 
-	file, aerr := a.FileReader(fileInfo.Path)
-	if aerr != nil {
-		return errors.Wrap(aerr, "failed to open file for extract file content")
-	}
-	defer file.Close()
-	text, err := docextractor.Extract(rctx.Logger(), fileInfo.Name, file, docextractor.ExtractSettings{
-		ArchiveRecursion: *a.Config().FileSettings.ArchiveRecursion,
-	})
+	text, err := a.extractFileContent(rctx, fileInfo)
 	if err != nil {
-		return errors.Wrap(err, "failed to extract file content")
+		return err
 	}
 	if text != "" {
-		if len(text) > maxContentExtractionSize {
-			text = text[0:maxContentExtractionSize]
-		}
 		if storeErr := a.Srv().Store().FileInfo().SetContent(rctx, fileInfo.Id, text); storeErr != nil {
 			return errors.Wrap(storeErr, "failed to save the extracted file content")
 		}
@@ -1490,6 +1503,26 @@ func (a *App) ExtractContentFromFileInfo(rctx request.CTX, fileInfo *model.FileI
 	return nil
 }
 
+// extractFileContent returns the text of the file, truncated to the size
+// stored in the database.
+func (a *App) extractFileContent(rctx request.CTX, fileInfo *model.FileInfo) (string, error) {
+	file, aerr := a.FileReader(fileInfo.Path)
+	if aerr != nil {
+		return "", errors.Wrap(aerr, "failed to open file for extract file content")
+	}
+	defer file.Close()
+	text, err := docextractor.Extract(rctx.Logger(), fileInfo.Name, file, docextractor.ExtractSettings{
+		ArchiveRecursion: *a.Config().FileSettings.ArchiveRecursion,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to extract file content")
+	}
+	if len(text) > maxContentExtractionSize {
+		text = text[0:maxContentExtractionSize]
+	}
+	return text, nil
+}
+
 // GetLastAccessibleFileTime returns CreateAt time(from cache) of the last accessible post as per the cloud limit
 func (a *App) GetLastAccessibleFileTime() (int64, *model.AppError) {
 	license := a.Srv().License()
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"image"
 	"io"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -379,6 +380,33 @@ func TestCopyFileInfos(t *testing.T) {
 	assert.Equal(t, info2.PostId, "", "should be empty string")
 }
 
+func TestGetFileQuarantined(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	info, appErr := th.App.DoUploadFile(th.Context, time.Now(), model.NewId(), model.NewId(), model.NewId(), "test", []byte("abcd"), true)
+	require.Nil(t, appErr)
+	defer func() {
+		th.App.Srv().Store().FileInfo().PermanentDelete(th.Context, info.Id)
+		th.App.RemoveFile(info.Path)
+	}()
+
+	for _, status := range []string{model.FileScanStatusPending, model.FileScanStatusInfected, model.FileScanStatusFailed} {
+		require.NoError(t, th.App.Srv().Store().FileInfo().UpdateScanStatus(info.Id, status))
+
+		data, appErr := th.App.GetFile(th.Context, info.Id)
+		require.NotNil(t, appErr, status)
+		assert.Equal(t, "app.file.quarantined.app_error", appErr.Id)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+		assert.Nil(t, data)
+	}
+
+	require.NoError(t, th.App.Srv().Store().FileInfo().UpdateScanStatus(info.Id, model.FileScanStatusClean))
+	data, appErr := th.App.GetFile(th.Context, info.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, []byte("abcd"), data)
+}
+
 func TestGenerateThumbnailImage(t *testing.T) {
 	t.Run("test generating thumbnail image", func(t *testing.T) {
 		// given
@@ -172,18 +172,37 @@ func (a *App) bulkImportWorker(c request.CTX, dryRun, extractContent bool, wg *s
 }
 
 func (a *App) BulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun bool, workers int) (*model.AppError, int) {
-	return a.bulkImport(c, jsonlReader, attachmentsReader, dryRun, true, workers, "")
+	return a.bulkImport(c, jsonlReader, attachmentsReader, model.BulkImportOpts{
+		DryRun:         dryRun,
+		ExtractContent: true,
+		Workers:        workers,
+	})
 }
 
 func (a *App) BulkImportWithPath(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun, extractContent bool, workers int, importPath string) (*model.AppError, int) {
-	return a.bulkImport(c, jsonlReader, attachmentsReader, dryRun, extractContent, workers, importPath)
+	return a.bulkImport(c, jsonlReader, attachmentsReader, model.BulkImportOpts{
+		DryRun:         dryRun,
+		ExtractContent: extractContent,
+		Workers:        workers,
+		ImportPath:     importPath,
+	})
+}
+
+func (a *App) BulkImportWithOpts(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, opts model.BulkImportOpts) (*model.AppError, int) {
+	return a.bulkImport(c, jsonlReader, attachmentsReader, opts)
 }
 
 // bulkImport will extract attachments from attachmentsReader if it is
 // not nil. If it is nil, it will look for attachments on the
 // filesystem in the locations specified by the JSONL file according
 // to the older behavior
-func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun, extractContent bool, workers int, importPath string) (*model.AppError, int) {
+func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, opts model.BulkImportOpts) (*model.AppError, int) {
+	if !model.IsValidImportIdMode(opts.IdMode) {
+		return model.NewAppError("BulkImport", "app.import.bulk_import.id_mode.error", map[string]any{"IdMode": opts.IdMode}, "", http.StatusBadRequest), 0
+	}
+
+	dryRun, extractContent, workers := opts.DryRun, opts.ExtractContent, opts.Workers
+
 	scanner := bufio.NewScanner(jsonlReader)
 	buf := make([]byte, 0, 64*1024)
 	scanner.Buffer(buf, maxScanTokenSize)
@@ -198,6 +217,7 @@ func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader
 	var linesChan chan imports.LineImportWorkerData
 	lastLineType := ""
 
+	var idMapper *importIdMapper
 	var attachedFiles map[string]*zip.File
 	if attachmentsReader != nil {
 		attachedFiles = make(map[string]*zip.File, len(attachmentsReader.File))
@@ -217,10 +237,14 @@ func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader
 			return model.NewAppError("BulkImport", "app.import.bulk_import.json_decode.error", nil, "", http.StatusBadRequest).Wrap(err), lineNumber
 		}
 
-		if err := processAttachments(c, &line, importPath, attachedFiles); err != nil {
+		if err := processAttachments(c, &line, opts.ImportPath, attachedFiles); err != nil {
 			c.Logger().Warn("Error while processing import attachments. Objects might be broken.", mlog.Err(err))
 		}
 
+		if idMapper != nil {
+			idMapper.mapLine(c, &line)
+		}
+
 		if lineNumber == 1 {
 			importDataFileVersion, appErr := processImportDataFileVersionLine(line)
 			if appErr != nil {
@@ -230,6 +254,11 @@ func (a *App) bulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader
 			if importDataFileVersion != 1 {
 				return model.NewAppError("BulkImport", "app.import.bulk_import.unsupported_version.error", nil, "", http.StatusBadRequest), lineNumber
 			}
+
+			if opts.IdMode != "" {
+				idMapper = a.newImportIdMapper(c, opts.IdMode, line.Info)
+			}
+
 			lastLineType = line.Type
 			continue
 		}
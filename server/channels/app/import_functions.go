@@ -1244,6 +1244,79 @@ func (a *App) importReaction(data *imports.ReactionImportData, post *model.Post)
 	return nil
 }
 
+func (a *App) importPoll(data *imports.PollImportData, post *model.Post) *model.AppError {
+	// Re-importing a post keeps its poll and only replaces the votes.
+	poll, nErr := a.Srv().Store().Poll().GetForPost(post.Id)
+	var nfErr *store.ErrNotFound
+	if nErr != nil && !errors.As(nErr, &nfErr) {
+		return model.NewAppError("importPoll", "app.poll.get.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+	}
+
+	if poll == nil {
+		poll = &model.Poll{
+			CreateAt:  post.CreateAt,
+			PostId:    post.Id,
+			ChannelId: post.ChannelId,
+			UserId:    post.UserId,
+			Question:  *data.Question,
+		}
+		for _, text := range *data.Options {
+			poll.Options = append(poll.Options, &model.PollOption{Text: text})
+		}
+		if data.MultipleChoice != nil {
+			poll.MultipleChoice = *data.MultipleChoice
+		}
+		if data.Anonymous != nil {
+			poll.Anonymous = *data.Anonymous
+		}
+		if data.CloseAt != nil {
+			poll.CloseAt = *data.CloseAt
+		}
+
+		if poll, nErr = a.Srv().Store().Poll().Save(poll); nErr != nil {
+			var appErr *model.AppError
+			switch {
+			case errors.As(nErr, &appErr):
+				return appErr
+			default:
+				return model.NewAppError("importPoll", "app.poll.save.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+			}
+		}
+	}
+
+	if data.Votes == nil {
+		return nil
+	}
+
+	usernames := []string{}
+	optionIDsByUsername := map[string][]string{}
+	for _, vote := range *data.Votes {
+		option := poll.GetOptionByText(*vote.Option)
+		if option == nil {
+			continue
+		}
+
+		username := strings.ToLower(*vote.User)
+		if _, ok := optionIDsByUsername[username]; !ok {
+			usernames = append(usernames, username)
+		}
+		optionIDsByUsername[username] = append(optionIDsByUsername[username], option.Id)
+	}
+
+	users, appErr := a.getUsersByUsernames(usernames)
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, username := range usernames {
+		if nErr := a.Srv().Store().Poll().SaveVotes(poll.Id, users[username].Id, optionIDsByUsername[username]); nErr != nil {
+			return model.NewAppError("importPoll", "app.poll.vote.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		}
+	}
+
+	return nil
+}
+
 func (a *App) importReplies(rctx request.CTX, data []imports.ReplyImportData, post *model.Post, teamID string, extractContent bool) *model.AppError {
 	var err *model.AppError
 	usernames := []string{}
@@ -1263,6 +1336,17 @@ func (a *App) importReplies(rctx request.CTX, data []imports.ReplyImportData, po
 		return err
 	}
 
+	importIDs := make([]string, 0, len(data))
+	for _, replyData := range data {
+		if replyData.ImportId != "" {
+			importIDs = append(importIDs, replyData.ImportId)
+		}
+	}
+	repliesByImportID, err := a.getPostsByImportIds(importIDs)
+	if err != nil {
+		return err
+	}
+
 	var (
 		postsWithData         = []postAndData{}
 		postsForCreateList    = []*model.Post{}
@@ -1274,16 +1358,22 @@ func (a *App) importReplies(rctx request.CTX, data []imports.ReplyImportData, po
 		user := users[strings.ToLower(*replyData.User)]
 
 		// Check if this post already exists.
-		replies, nErr := a.Srv().Store().Post().GetPostsCreatedAt(post.ChannelId, *replyData.CreateAt)
-		if nErr != nil {
-			return model.NewAppError("importReplies", "app.post.get_posts_created_at.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		reply, err := getImportedPost(repliesByImportID, replyData.ImportId, post.ChannelId, post.Id)
+		if err != nil {
+			return err
 		}
 
-		var reply *model.Post
-		for _, r := range replies {
-			if r.Message == *replyData.Message && r.RootId == post.Id {
-				reply = r
-				break
+		if reply == nil {
+			replies, nErr := a.Srv().Store().Post().GetPostsCreatedAt(post.ChannelId, *replyData.CreateAt)
+			if nErr != nil {
+				return model.NewAppError("importReplies", "app.post.get_posts_created_at.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+			}
+
+			for _, r := range replies {
+				if r.Message == *replyData.Message && r.RootId == post.Id {
+					reply = r
+					break
+				}
 			}
 		}
 
@@ -1321,6 +1411,7 @@ func (a *App) importReplies(rctx request.CTX, data []imports.ReplyImportData, po
 		}
 
 		if reply.Id == "" {
+			reply.Id = replyData.ImportId
 			postsForCreateList = append(postsForCreateList, reply)
 		} else {
 			postsForOverwriteList = append(postsForOverwriteList, reply)
@@ -1329,7 +1420,7 @@ func (a *App) importReplies(rctx request.CTX, data []imports.ReplyImportData, po
 	}
 
 	if len(postsForCreateList) > 0 {
-		if _, _, err := a.Srv().Store().Post().SaveMultiple(postsForCreateList); err != nil {
+		if _, _, err := a.Srv().Store().Post().SaveMultipleWithIds(postsForCreateList); err != nil {
 			var appErr *model.AppError
 			var invErr *store.ErrInvalidInput
 			switch {
@@ -1347,9 +1438,25 @@ func (a *App) importReplies(rctx request.CTX, data []imports.ReplyImportData, po
 		return model.NewAppError("importReplies", "app.post.overwrite.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 	}
 
+	var idMappings []*model.ImportIdMapping
+	for _, postWithData := range postsWithData {
+		if postWithData.replyData.Id != nil && postWithData.replyData.ImportId != "" {
+			idMappings = append(idMappings, &model.ImportIdMapping{ObjectType: model.ImportIdMappingObjectTypePost, OldId: *postWithData.replyData.Id, NewId: postWithData.post.Id})
+		}
+	}
+	if err := a.saveImportIdMappings(idMappings); err != nil {
+		return err
+	}
+
 	for _, postWithData := range postsWithData {
 		a.updateFileInfoWithPostId(rctx, postWithData.post)
 
+		if postWithData.replyData.Poll != nil {
+			if err := a.importPoll(postWithData.replyData.Poll, postWithData.post); err != nil {
+				return err
+			}
+		}
+
 		if postWithData.replyData.FlaggedBy != nil {
 			var preferences model.Preferences
 
@@ -1524,6 +1631,7 @@ func (a *App) importAttachment(rctx request.CTX, data *imports.AttachmentImportD
 		UploadFileSetTimestamp(timestamp),
 		UploadFileSetContentLength(fileSize),
 		UploadFileSetExtractContent(extractContent),
+		UploadFileSetId(data.ImportId),
 	)
 	if appErr != nil {
 		rctx.Logger().Error("Failed to upload file", mlog.Err(appErr), mlog.String("file_name", name))
@@ -1613,6 +1721,58 @@ func getPostStrID(post *model.Post) string {
 	return fmt.Sprintf("%d%s%s", post.CreateAt, post.ChannelId, post.Message)
 }
 
+// getPostsByImportIds returns the posts that already exist with the given
+// import IDs, keyed by ID.
+func (a *App) getPostsByImportIds(importIDs []string) (map[string]*model.Post, *model.AppError) {
+	postsByID := make(map[string]*model.Post, len(importIDs))
+	if len(importIDs) == 0 {
+		return postsByID, nil
+	}
+
+	posts, err := a.Srv().Store().Post().GetPostsByIds(importIDs)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return postsByID, nil
+		}
+		return nil, model.NewAppError("BulkImport", "app.post.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, post := range posts {
+		postsByID[post.Id] = post
+	}
+	return postsByID, nil
+}
+
+// getImportedPost returns the post previously imported with importID, if
+// any. It fails when the ID is taken by a post of another channel or
+// thread, which happens when importing in the ImportIdModePreserve mode
+// an archive whose IDs are already in use.
+func getImportedPost(postsByImportID map[string]*model.Post, importID, channelID, rootID string) (*model.Post, *model.AppError) {
+	post, ok := postsByImportID[importID]
+	if importID == "" || !ok {
+		return nil, nil
+	}
+
+	if post.ChannelId != channelID || post.RootId != rootID {
+		return nil, model.NewAppError("BulkImport", "app.import.import_post.id_conflict.error", map[string]any{"PostId": importID}, "", http.StatusBadRequest)
+	}
+
+	return post, nil
+}
+
+func (a *App) saveImportIdMappings(mappings []*model.ImportIdMapping) *model.AppError {
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	if err := a.Srv().Store().ImportIdMapping().SaveMultiple(mappings); err != nil {
+		return model.NewAppError("BulkImport", "app.import.save_id_mappings.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
 // importMultiplePostLines will return an error and the line that
 // caused it whenever possible
 func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImportWorkerData, dryRun, extractContent bool) (int, *model.AppError) {
@@ -1662,6 +1822,17 @@ func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImpo
 		return 0, err
 	}
 
+	importIDs := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line.Post.ImportId != "" {
+			importIDs = append(importIDs, line.Post.ImportId)
+		}
+	}
+	postsByImportID, err := a.getPostsByImportIds(importIDs)
+	if err != nil {
+		return 0, err
+	}
+
 	var (
 		postsWithData                = []postAndData{}
 		postsForCreateList           = []*model.Post{}
@@ -1678,16 +1849,22 @@ func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImpo
 		user := users[strings.ToLower(*line.Post.User)]
 
 		// Check if this post already exists.
-		posts, nErr := a.Srv().Store().Post().GetPostsCreatedAt(channel.Id, *line.Post.CreateAt)
-		if nErr != nil {
-			return line.LineNumber, model.NewAppError("importMultiplePostLines", "app.post.get_posts_created_at.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		post, appErr := getImportedPost(postsByImportID, line.Post.ImportId, channel.Id, "")
+		if appErr != nil {
+			return line.LineNumber, appErr
 		}
 
-		var post *model.Post
-		for _, p := range posts {
-			if p.Message == *line.Post.Message {
-				post = p
-				break
+		if post == nil {
+			posts, nErr := a.Srv().Store().Post().GetPostsCreatedAt(channel.Id, *line.Post.CreateAt)
+			if nErr != nil {
+				return line.LineNumber, model.NewAppError("importMultiplePostLines", "app.post.get_posts_created_at.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+			}
+
+			for _, p := range posts {
+				if p.Message == *line.Post.Message {
+					post = p
+					break
+				}
 			}
 		}
 
@@ -1738,6 +1915,7 @@ func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImpo
 		}
 
 		if post.Id == "" {
+			post.Id = line.Post.ImportId
 			postsForCreateList = append(postsForCreateList, post)
 			postsForCreateMap[getPostStrID(post)] = line.LineNumber
 		} else {
@@ -1749,7 +1927,7 @@ func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImpo
 	}
 
 	if len(postsForCreateList) > 0 {
-		_, idx, nErr := a.Srv().Store().Post().SaveMultiple(postsForCreateList)
+		_, idx, nErr := a.Srv().Store().Post().SaveMultipleWithIds(postsForCreateList)
 		if nErr != nil {
 			var appErr *model.AppError
 			var invErr *store.ErrInvalidInput
@@ -1822,6 +2000,16 @@ func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImpo
 		return 0, model.NewAppError("importMultiplePostLines", "app.post.save.thread_membership.app_error", nil, "", http.StatusInternalServerError).Wrap(sErr)
 	}
 
+	var idMappings []*model.ImportIdMapping
+	for _, postWithData := range postsWithData {
+		if postWithData.postData.Id != nil && postWithData.postData.ImportId != "" {
+			idMappings = append(idMappings, &model.ImportIdMapping{ObjectType: model.ImportIdMappingObjectTypePost, OldId: *postWithData.postData.Id, NewId: postWithData.post.Id})
+		}
+	}
+	if err := a.saveImportIdMappings(idMappings); err != nil {
+		return 0, err
+	}
+
 	for _, postWithData := range postsWithData {
 		postWithData := postWithData
 		if postWithData.postData.FlaggedBy != nil {
@@ -1854,6 +2042,12 @@ func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImpo
 			}
 		}
 
+		if postWithData.postData.Poll != nil {
+			if err := a.importPoll(postWithData.postData.Poll, postWithData.post); err != nil {
+				return postWithData.lineNumber, err
+			}
+		}
+
 		if postWithData.postData.Replies != nil && len(*postWithData.postData.Replies) > 0 {
 			err := a.importReplies(rctx, *postWithData.postData.Replies, postWithData.post, postWithData.team.Id, extractContent)
 			if err != nil {
@@ -1871,6 +2065,7 @@ func (a *App) uploadAttachments(rctx request.CTX, attachments *[]imports.Attachm
 		return nil
 	}
 	fileIDs := make(map[string]bool)
+	var idMappings []*model.ImportIdMapping
 	for _, attachment := range *attachments {
 		attachment := attachment
 		fileInfo, err := a.importAttachment(rctx, &attachment, post, teamID, extractContent)
@@ -1887,7 +2082,16 @@ func (a *App) uploadAttachments(rctx request.CTX, attachments *[]imports.Attachm
 			continue
 		}
 		fileIDs[fileInfo.Id] = true
+
+		if attachment.Id != nil && attachment.ImportId != "" {
+			idMappings = append(idMappings, &model.ImportIdMapping{ObjectType: model.ImportIdMappingObjectTypeFile, OldId: *attachment.Id, NewId: fileInfo.Id})
+		}
+	}
+
+	if err := a.saveImportIdMappings(idMappings); err != nil {
+		rctx.Logger().Warn("failed to save the import id mappings of attachments", mlog.String("post_id", post.Id), mlog.Err(err))
 	}
+
 	return fileIDs
 }
 
@@ -2158,6 +2362,17 @@ func (a *App) importMultipleDirectPostLines(rctx request.CTX, lines []imports.Li
 		return 0, err
 	}
 
+	importIDs := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line.DirectPost.ImportId != "" {
+			importIDs = append(importIDs, line.DirectPost.ImportId)
+		}
+	}
+	postsByImportID, err := a.getPostsByImportIds(importIDs)
+	if err != nil {
+		return 0, err
+	}
+
 	var (
 		postsWithData                = []postAndData{}
 		postsForCreateList           = []*model.Post{}
@@ -2195,16 +2410,22 @@ func (a *App) importMultipleDirectPostLines(rctx request.CTX, lines []imports.Li
 		user := users[strings.ToLower(*line.DirectPost.User)]
 
 		// Check if this post already exists.
-		posts, nErr := a.Srv().Store().Post().GetPostsCreatedAt(channel.Id, *line.DirectPost.CreateAt)
-		if nErr != nil {
-			return line.LineNumber, model.NewAppError("BulkImport", "app.post.get_posts_created_at.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		post, err := getImportedPost(postsByImportID, line.DirectPost.ImportId, channel.Id, "")
+		if err != nil {
+			return line.LineNumber, err
 		}
 
-		var post *model.Post
-		for _, p := range posts {
-			if p.Message == *line.DirectPost.Message {
-				post = p
-				break
+		if post == nil {
+			posts, nErr := a.Srv().Store().Post().GetPostsCreatedAt(channel.Id, *line.DirectPost.CreateAt)
+			if nErr != nil {
+				return line.LineNumber, model.NewAppError("BulkImport", "app.post.get_posts_created_at.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+			}
+
+			for _, p := range posts {
+				if p.Message == *line.DirectPost.Message {
+					post = p
+					break
+				}
 			}
 		}
 
@@ -2255,6 +2476,7 @@ func (a *App) importMultipleDirectPostLines(rctx request.CTX, lines []imports.Li
 		}
 
 		if post.Id == "" {
+			post.Id = line.DirectPost.ImportId
 			postsForCreateList = append(postsForCreateList, post)
 			postsForCreateMap[getPostStrID(post)] = line.LineNumber
 		} else {
@@ -2265,7 +2487,7 @@ func (a *App) importMultipleDirectPostLines(rctx request.CTX, lines []imports.Li
 	}
 
 	if len(postsForCreateList) > 0 {
-		if _, idx, err := a.Srv().Store().Post().SaveMultiple(postsForCreateList); err != nil {
+		if _, idx, err := a.Srv().Store().Post().SaveMultipleWithIds(postsForCreateList); err != nil {
 			var appErr *model.AppError
 			var invErr *store.ErrInvalidInput
 			var retErr *model.AppError
@@ -2327,6 +2549,16 @@ func (a *App) importMultipleDirectPostLines(rctx request.CTX, lines []imports.Li
 		return 0, model.NewAppError("importMultiplePostLines", "app.post.save.thread_membership.app_error", nil, "", http.StatusInternalServerError).Wrap(sErr)
 	}
 
+	var idMappings []*model.ImportIdMapping
+	for _, postWithData := range postsWithData {
+		if postWithData.directPostData.Id != nil && postWithData.directPostData.ImportId != "" {
+			idMappings = append(idMappings, &model.ImportIdMapping{ObjectType: model.ImportIdMappingObjectTypePost, OldId: *postWithData.directPostData.Id, NewId: postWithData.post.Id})
+		}
+	}
+	if err := a.saveImportIdMappings(idMappings); err != nil {
+		return 0, err
+	}
+
 	for _, postWithData := range postsWithData {
 		if postWithData.directPostData.FlaggedBy != nil {
 			var preferences model.Preferences
@@ -2358,6 +2590,12 @@ func (a *App) importMultipleDirectPostLines(rctx request.CTX, lines []imports.Li
 			}
 		}
 
+		if postWithData.directPostData.Poll != nil {
+			if err := a.importPoll(postWithData.directPostData.Poll, postWithData.post); err != nil {
+				return postWithData.lineNumber, err
+			}
+		}
+
 		if postWithData.directPostData.Replies != nil {
 			if err := a.importReplies(rctx, *postWithData.directPostData.Replies, postWithData.post, "noteam", extractContent); err != nil {
 				return postWithData.lineNumber, err
@@ -0,0 +1,152 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+	"github.com/mattermost/mattermost/server/v8/platform/services/sharedchannel"
+)
+
+// importIdMapper sets the IDs the posts and files of a bulk import are saved
+// with, according to the ID mode of the import. It also rewrites the
+// permalinks to the server the data was exported from, so that they point to
+// the imported posts.
+type importIdMapper struct {
+	a    *App
+	mode string
+
+	// sourceSiteURL is the site URL of the server the data was exported from.
+	sourceSiteURL *url.URL
+	siteURL       *url.URL
+}
+
+func (a *App) newImportIdMapper(c request.CTX, mode string, info *imports.VersionInfoImportData) *importIdMapper {
+	m := &importIdMapper{a: a, mode: mode}
+
+	if info != nil && info.SiteURL != "" {
+		if sourceURL, err := url.Parse(info.SiteURL); err == nil && sourceURL.Host != "" {
+			m.sourceSiteURL = sourceURL
+		}
+	}
+	if siteURL := *a.Config().ServiceSettings.SiteURL; siteURL != "" {
+		if parsed, err := url.Parse(siteURL); err == nil {
+			m.siteURL = parsed
+		}
+	}
+
+	if m.sourceSiteURL == nil || m.siteURL == nil {
+		c.Logger().Warn("The permalinks of the imported posts won't be rewritten, the site URL of the exporting server or of this server is unknown")
+	}
+
+	return m
+}
+
+// importId returns the ID to import an object with, given the ID it was
+// exported with.
+func (m *importIdMapper) importId(id *string) string {
+	if id == nil || !model.IsValidId(*id) {
+		return ""
+	}
+
+	if m.mode == model.ImportIdModeRemap {
+		return model.RemapImportId(*id)
+	}
+	return *id
+}
+
+func (m *importIdMapper) mapLine(c request.CTX, line *imports.LineImportData) {
+	switch {
+	case line.Type == "post" && line.Post != nil:
+		line.Post.ImportId = m.importId(line.Post.Id)
+		m.rewritePermalinks(c, line.Post.Message)
+		m.mapAttachments(line.Post.Attachments)
+		m.mapReplies(c, line.Post.Replies)
+	case line.Type == "direct_post" && line.DirectPost != nil:
+		line.DirectPost.ImportId = m.importId(line.DirectPost.Id)
+		m.rewritePermalinks(c, line.DirectPost.Message)
+		m.mapAttachments(line.DirectPost.Attachments)
+		m.mapReplies(c, line.DirectPost.Replies)
+	}
+}
+
+func (m *importIdMapper) mapReplies(c request.CTX, replies *[]imports.ReplyImportData) {
+	if replies == nil {
+		return
+	}
+
+	for i := range *replies {
+		reply := &(*replies)[i]
+		reply.ImportId = m.importId(reply.Id)
+		m.rewritePermalinks(c, reply.Message)
+		m.mapAttachments(reply.Attachments)
+	}
+}
+
+func (m *importIdMapper) mapAttachments(attachments *[]imports.AttachmentImportData) {
+	if attachments == nil {
+		return
+	}
+
+	for i := range *attachments {
+		attachment := &(*attachments)[i]
+		attachment.ImportId = m.importId(attachment.Id)
+	}
+}
+
+// rewritePermalinks makes the permalinks to posts of the exporting server
+// point to the posts they were imported as. Posts imported by a previous
+// import are looked up in the import ID mappings.
+func (m *importIdMapper) rewritePermalinks(c request.CTX, message *string) {
+	if message == nil || m.sourceSiteURL == nil || m.siteURL == nil {
+		return
+	}
+
+	var linkedIDs []string
+	sharedchannel.RewritePermalinks(*message, func(permalink, postID string) string {
+		if m.isSourcePermalink(permalink) {
+			linkedIDs = append(linkedIDs, postID)
+		}
+		return permalink
+	})
+	if len(linkedIDs) == 0 {
+		return
+	}
+
+	importedIDs, err := m.a.Srv().Store().ImportIdMapping().GetNewIds(model.ImportIdMappingObjectTypePost, linkedIDs)
+	if err != nil {
+		c.Logger().Warn("Failed to get the import id mappings of linked posts", mlog.Err(err))
+		importedIDs = map[string]string{}
+	}
+
+	*message = sharedchannel.RewritePermalinks(*message, func(permalink, postID string) string {
+		if !m.isSourcePermalink(permalink) {
+			return permalink
+		}
+
+		newID, ok := importedIDs[postID]
+		if !ok {
+			if newID = m.importId(&postID); newID == "" {
+				return permalink
+			}
+		}
+
+		link, err := sharedchannel.RelocatePermalink(permalink, m.sourceSiteURL, m.siteURL, "", newID)
+		if err != nil {
+			return permalink
+		}
+		return link
+	})
+}
+
+func (m *importIdMapper) isSourcePermalink(permalink string) bool {
+	parsed, err := url.Parse(permalink)
+	return err == nil && strings.EqualFold(parsed.Host, m.sourceSiteURL.Host) &&
+		strings.HasPrefix(parsed.Path, strings.TrimSuffix(m.sourceSiteURL.Path, "/")+"/")
+}
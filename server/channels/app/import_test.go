@@ -231,6 +231,100 @@ func TestImportBulkImport(t *testing.T) {
 	})
 }
 
+func TestImportBulkImportWithIdMode(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.SiteURL = "https://local.example.com" })
+
+	importData := func(teamName string, postID, linkingPostID, replyID string) string {
+		channelName := model.NewId()
+		username := model.NewUsername()
+		return `{"type": "version", "version": 1, "info": {"generator": "test", "site_url": "https://source.example.com"}}
+{"type": "team", "team": {"type": "O", "display_name": "lskmw2d7a5ao7ppwqh5ljchvr4", "name": "` + teamName + `"}}
+{"type": "channel", "channel": {"type": "O", "display_name": "xr6m6udffngark2uekvr3hoeny", "team": "` + teamName + `", "name": "` + channelName + `"}}
+{"type": "user", "user": {"username": "` + username + `", "email": "` + username + `@example.com", "teams": [{"name": "` + teamName + `", "channels": [{"name": "` + channelName + `"}]}]}}
+{"type": "post", "post": {"id": "` + postID + `", "team": "` + teamName + `", "channel": "` + channelName + `", "user": "` + username + `", "message": "Hello World", "create_at": 123456789012}}
+{"type": "post", "post": {"id": "` + linkingPostID + `", "team": "` + teamName + `", "channel": "` + channelName + `", "user": "` + username + `", "message": "See https://source.example.com/old-team/pl/` + postID + ` and https://other.example.com/old-team/pl/` + postID + `", "create_at": 123456789013, "replies": [{"id": "` + replyID + `", "user": "` + username + `", "message": "Reply", "create_at": 123456789014}]}}`
+	}
+
+	t.Run("preserve", func(t *testing.T) {
+		postID, linkingPostID, replyID := model.NewId(), model.NewId(), model.NewId()
+		data := importData(model.NewRandomTeamName(), postID, linkingPostID, replyID)
+
+		appErr, line := th.App.BulkImportWithOpts(th.Context, strings.NewReader(data), nil, model.BulkImportOpts{Workers: 2, IdMode: model.ImportIdModePreserve})
+		require.Nil(t, appErr)
+		require.Equal(t, 0, line)
+
+		post, appErr := th.App.GetSinglePost(th.Context, postID, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, "Hello World", post.Message)
+
+		linkingPost, appErr := th.App.GetSinglePost(th.Context, linkingPostID, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, "See https://local.example.com/old-team/pl/"+postID+" and https://other.example.com/old-team/pl/"+postID, linkingPost.Message)
+
+		reply, appErr := th.App.GetSinglePost(th.Context, replyID, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, linkingPostID, reply.RootId)
+
+		mappings, err := th.App.Srv().Store().ImportIdMapping().GetNewIds(model.ImportIdMappingObjectTypePost, []string{postID, linkingPostID, replyID})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{postID: postID, linkingPostID: linkingPostID, replyID: replyID}, mappings)
+
+		// Importing the same data again doesn't duplicate the posts.
+		appErr, _ = th.App.BulkImportWithOpts(th.Context, strings.NewReader(data), nil, model.BulkImportOpts{Workers: 2, IdMode: model.ImportIdModePreserve})
+		require.Nil(t, appErr)
+
+		posts, appErr := th.App.GetPosts(post.ChannelId, 0, 10)
+		require.Nil(t, appErr)
+		assert.Len(t, posts.Order, 3)
+	})
+
+	t.Run("remap", func(t *testing.T) {
+		postID, linkingPostID, replyID := model.NewId(), model.NewId(), model.NewId()
+		data := importData(model.NewRandomTeamName(), postID, linkingPostID, replyID)
+
+		appErr, line := th.App.BulkImportWithOpts(th.Context, strings.NewReader(data), nil, model.BulkImportOpts{Workers: 2, IdMode: model.ImportIdModeRemap})
+		require.Nil(t, appErr)
+		require.Equal(t, 0, line)
+
+		_, appErr = th.App.GetSinglePost(th.Context, postID, false)
+		require.NotNil(t, appErr)
+
+		newPostID := model.RemapImportId(postID)
+		post, appErr := th.App.GetSinglePost(th.Context, newPostID, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, "Hello World", post.Message)
+
+		linkingPost, appErr := th.App.GetSinglePost(th.Context, model.RemapImportId(linkingPostID), false)
+		require.Nil(t, appErr)
+		assert.Equal(t, "See https://local.example.com/old-team/pl/"+newPostID+" and https://other.example.com/old-team/pl/"+postID, linkingPost.Message)
+
+		mappings, err := th.App.Srv().Store().ImportIdMapping().GetNewIds(model.ImportIdMappingObjectTypePost, []string{postID, replyID})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{postID: newPostID, replyID: model.RemapImportId(replyID)}, mappings)
+	})
+
+	t.Run("conflicting id", func(t *testing.T) {
+		data := importData(model.NewRandomTeamName(), th.BasicPost.Id, model.NewId(), model.NewId())
+
+		appErr, line := th.App.BulkImportWithOpts(th.Context, strings.NewReader(data), nil, model.BulkImportOpts{Workers: 2, IdMode: model.ImportIdModePreserve})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.import.import_post.id_conflict.error", appErr.Id)
+		assert.Equal(t, 5, line)
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		data := importData(model.NewRandomTeamName(), model.NewId(), model.NewId(), model.NewId())
+
+		appErr, line := th.App.BulkImportWithOpts(th.Context, strings.NewReader(data), nil, model.BulkImportOpts{Workers: 2, IdMode: "renumber"})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.import.bulk_import.id_mode.error", appErr.Id)
+		assert.Equal(t, 0, line)
+	})
+}
+
 func TestImportProcessImportDataFileVersionLine(t *testing.T) {
 	data := imports.LineImportData{
 		Type:    "version",
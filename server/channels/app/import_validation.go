@@ -0,0 +1,455 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// importValidationUnknownLineType groups the issues of the lines whose type
+// can't be determined.
+const importValidationUnknownLineType = "unknown"
+
+// importValidator resolves the references of the lines of a bulk import,
+// either to objects defined by earlier lines or to existing ones.
+type importValidator struct {
+	a             *App
+	c             request.CTX
+	report        *model.ImportValidationReport
+	attachedFiles map[string]*zip.File
+	maxPostSize   int
+
+	// The references resolved so far, by name, and whether they were found.
+	teams    map[string]bool
+	channels map[string]bool
+	users    map[string]bool
+	schemes  map[string]bool
+	// teamIDs holds the IDs of the referenced teams that exist on the server.
+	teamIDs map[string]string
+}
+
+// ValidateBulkImport checks a bulk import without writing anything. On top of
+// validating each line, it resolves the teams, channels, users and schemes
+// the lines refer to and checks the attachments are present. Unlike a dry-run
+// of BulkImport, it doesn't stop at the first problem but reports them all.
+func (a *App) ValidateBulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, importPath string) (*model.ImportValidationReport, *model.AppError) {
+	v := &importValidator{
+		a:           a,
+		c:           c,
+		report:      model.NewImportValidationReport(),
+		maxPostSize: a.MaxPostSize(),
+		teams:       map[string]bool{},
+		teamIDs:     map[string]string{},
+		channels:    map[string]bool{},
+		users:       map[string]bool{},
+		schemes:     map[string]bool{},
+	}
+	if attachmentsReader != nil {
+		v.attachedFiles = make(map[string]*zip.File, len(attachmentsReader.File))
+		for _, fi := range attachmentsReader.File {
+			v.attachedFiles[fi.Name] = fi
+		}
+	}
+
+	scanner := bufio.NewScanner(jsonlReader)
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, maxScanTokenSize)
+
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		if lineNumber%statusUpdateAfterLines == 0 {
+			c.Logger().Info("Validation progress", mlog.Int("processed_lines", lineNumber))
+		}
+
+		var line imports.LineImportData
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			v.addIssue(importValidationUnknownLineType, lineNumber, "", model.NewAppError("BulkImport", "app.import.bulk_import.json_decode.error", nil, "", http.StatusBadRequest).Wrap(err))
+			continue
+		}
+
+		lineType := line.Type
+		if lineType == "" {
+			lineType = importValidationUnknownLineType
+		}
+		v.report.LineCounts[lineType]++
+
+		if lineNumber == 1 {
+			if version, appErr := processImportDataFileVersionLine(line); appErr != nil {
+				v.addIssue(lineType, lineNumber, "", appErr)
+			} else if version != 1 {
+				v.addIssue(lineType, lineNumber, "version", model.NewAppError("BulkImport", "app.import.bulk_import.unsupported_version.error", nil, "", http.StatusBadRequest))
+			}
+
+			if line.Type == "version" {
+				continue
+			}
+		}
+
+		// Missing attachments are reported when validating the line.
+		_ = processAttachments(c, &line, importPath, v.attachedFiles)
+
+		v.validateLine(lineType, lineNumber, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, model.NewAppError("BulkImport", "app.import.bulk_import.file_scan.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	v.report.TotalLines = lineNumber
+	return v.report, nil
+}
+
+func (v *importValidator) addIssue(lineType string, lineNumber int, field string, appErr *model.AppError) {
+	appErr.Translate(i18n.T)
+	v.report.AddIssue(lineType, &model.ImportValidationIssue{
+		Line:    lineNumber,
+		Field:   field,
+		ErrorId: appErr.Id,
+		Message: appErr.Message,
+	})
+}
+
+func (v *importValidator) validateLine(lineType string, lineNumber int, line imports.LineImportData) {
+	addIssue := func(field string, appErr *model.AppError) {
+		v.addIssue(lineType, lineNumber, field, appErr)
+	}
+	nullLine := func() {
+		addIssue("", model.NewAppError("BulkImport", fmt.Sprintf("app.import.import_line.null_%s.error", line.Type), nil, "", http.StatusBadRequest))
+	}
+
+	switch line.Type {
+	case "role":
+		if line.Role == nil {
+			nullLine()
+			return
+		}
+		if appErr := imports.ValidateRoleImportData(line.Role); appErr != nil {
+			addIssue("role", appErr)
+		}
+	case "scheme":
+		if line.Scheme == nil {
+			nullLine()
+			return
+		}
+		if appErr := imports.ValidateSchemeImportData(line.Scheme); appErr != nil {
+			addIssue("scheme", appErr)
+			return
+		}
+		v.schemes[*line.Scheme.Name] = true
+	case "team":
+		if line.Team == nil {
+			nullLine()
+			return
+		}
+		if appErr := imports.ValidateTeamImportData(line.Team); appErr != nil {
+			addIssue("team", appErr)
+			return
+		}
+		v.checkScheme(addIssue, "team.scheme", line.Team.Scheme)
+		v.teams[strings.ToLower(*line.Team.Name)] = true
+	case "channel":
+		if line.Channel == nil {
+			nullLine()
+			return
+		}
+		if appErr := imports.ValidateChannelImportData(line.Channel); appErr != nil {
+			addIssue("channel", appErr)
+			return
+		}
+		v.checkScheme(addIssue, "channel.scheme", line.Channel.Scheme)
+		if v.checkTeam(addIssue, "channel.team", line.Channel.Team) {
+			v.channels[channelKey(*line.Channel.Team, *line.Channel.Name)] = true
+		}
+	case "user":
+		if line.User == nil {
+			nullLine()
+			return
+		}
+		if appErr := imports.ValidateUserImportData(line.User); appErr != nil {
+			addIssue("user", appErr)
+			return
+		}
+		if line.User.ProfileImage != nil {
+			v.checkAttachment(addIssue, "user.profile_image", *line.User.ProfileImage, line.User.ProfileImageData)
+		}
+		if line.User.Teams != nil {
+			for i, team := range *line.User.Teams {
+				if !v.checkTeam(addIssue, fmt.Sprintf("user.teams[%d].name", i), team.Name) || team.Channels == nil {
+					continue
+				}
+				for j, channel := range *team.Channels {
+					v.checkChannel(addIssue, fmt.Sprintf("user.teams[%d].channels[%d].name", i, j), team.Name, channel.Name)
+				}
+			}
+		}
+		v.users[strings.ToLower(*line.User.Username)] = true
+	case "post":
+		if line.Post == nil {
+			nullLine()
+			return
+		}
+		if appErr := imports.ValidatePostImportData(line.Post, v.maxPostSize); appErr != nil {
+			addIssue("post", appErr)
+			return
+		}
+		if v.checkTeam(addIssue, "post.team", line.Post.Team) {
+			v.checkChannel(addIssue, "post.channel", line.Post.Team, line.Post.Channel)
+		}
+		v.checkUser(addIssue, "post.user", line.Post.User)
+		v.checkPostReferences(addIssue, "post", line.Post.FlaggedBy, line.Post.Reactions, line.Post.Attachments, line.Post.Poll, line.Post.ThreadFollowers)
+		v.checkReplies(addIssue, "post", line.Post.Replies)
+	case "direct_channel":
+		if line.DirectChannel == nil {
+			nullLine()
+			return
+		}
+		if appErr := imports.ValidateDirectChannelImportData(line.DirectChannel); appErr != nil {
+			addIssue("direct_channel", appErr)
+			return
+		}
+		v.checkUsers(addIssue, "direct_channel.members", line.DirectChannel.Members)
+		for i, participant := range line.DirectChannel.Participants {
+			if participant != nil {
+				v.checkUser(addIssue, fmt.Sprintf("direct_channel.participants[%d].username", i), participant.Username)
+			}
+		}
+		v.checkUsers(addIssue, "direct_channel.favorited_by", line.DirectChannel.FavoritedBy)
+		v.checkUsers(addIssue, "direct_channel.shown_by", line.DirectChannel.ShownBy)
+	case "direct_post":
+		if line.DirectPost == nil {
+			nullLine()
+			return
+		}
+		if appErr := imports.ValidateDirectPostImportData(line.DirectPost, v.maxPostSize); appErr != nil {
+			addIssue("direct_post", appErr)
+			return
+		}
+		v.checkUsers(addIssue, "direct_post.channel_members", line.DirectPost.ChannelMembers)
+		v.checkUser(addIssue, "direct_post.user", line.DirectPost.User)
+		v.checkPostReferences(addIssue, "direct_post", line.DirectPost.FlaggedBy, line.DirectPost.Reactions, line.DirectPost.Attachments, line.DirectPost.Poll, line.DirectPost.ThreadFollowers)
+		v.checkReplies(addIssue, "direct_post", line.DirectPost.Replies)
+	case "emoji":
+		if line.Emoji == nil {
+			nullLine()
+			return
+		}
+		if appErr := imports.ValidateEmojiImportData(line.Emoji); appErr != nil {
+			addIssue("emoji", appErr)
+			return
+		}
+		v.checkAttachment(addIssue, "emoji.image", *line.Emoji.Image, line.Emoji.Data)
+	default:
+		addIssue("type", model.NewAppError("BulkImport", "app.import.import_line.unknown_line_type.error", map[string]any{"Type": line.Type}, "", http.StatusBadRequest))
+	}
+}
+
+func (v *importValidator) checkReplies(addIssue func(string, *model.AppError), field string, replies *[]imports.ReplyImportData) {
+	if replies == nil {
+		return
+	}
+
+	for i, reply := range *replies {
+		replyField := fmt.Sprintf("%s.replies[%d]", field, i)
+		v.checkUser(addIssue, replyField+".user", reply.User)
+		v.checkPostReferences(addIssue, replyField, reply.FlaggedBy, reply.Reactions, reply.Attachments, reply.Poll, nil)
+	}
+}
+
+func (v *importValidator) checkPostReferences(addIssue func(string, *model.AppError), field string, flaggedBy *[]string, reactions *[]imports.ReactionImportData, attachments *[]imports.AttachmentImportData, poll *imports.PollImportData, followers *[]imports.ThreadFollowerImportData) {
+	v.checkUsers(addIssue, field+".flagged_by", flaggedBy)
+
+	if reactions != nil {
+		for i, reaction := range *reactions {
+			v.checkUser(addIssue, fmt.Sprintf("%s.reactions[%d].user", field, i), reaction.User)
+		}
+	}
+
+	if poll != nil && poll.Votes != nil {
+		for i, vote := range *poll.Votes {
+			v.checkUser(addIssue, fmt.Sprintf("%s.poll.votes[%d].user", field, i), vote.User)
+		}
+	}
+
+	if followers != nil {
+		for i, follower := range *followers {
+			v.checkUser(addIssue, fmt.Sprintf("%s.thread_followers[%d].user", field, i), follower.User)
+		}
+	}
+
+	if attachments != nil {
+		for i, attachment := range *attachments {
+			if attachment.Path != nil {
+				v.checkAttachment(addIssue, fmt.Sprintf("%s.attachments[%d].path", field, i), *attachment.Path, attachment.Data)
+			}
+		}
+	}
+}
+
+// checkTeam reports an issue if the team isn't defined by an earlier line
+// and doesn't exist, and returns whether it was found.
+func (v *importValidator) checkTeam(addIssue func(string, *model.AppError), field string, name *string) bool {
+	if name == nil {
+		return false
+	}
+
+	if _, ok := v.lookupTeam(*name); !ok {
+		addIssue(field, model.NewAppError("BulkImport", "app.import.validate.unknown_team.error", map[string]any{"Team": *name}, "", http.StatusBadRequest))
+		return false
+	}
+
+	return true
+}
+
+// lookupTeam returns whether the team is known and, if it exists on the
+// server, its ID.
+func (v *importValidator) lookupTeam(name string) (string, bool) {
+	name = strings.ToLower(name)
+	if found, ok := v.teams[name]; ok {
+		return v.teamIDs[name], found
+	}
+
+	team, err := v.a.Srv().Store().Team().GetByName(name)
+	v.logLookupError(err)
+	v.teams[name] = err == nil
+	if err != nil {
+		return "", false
+	}
+
+	v.teamIDs[name] = team.Id
+	return team.Id, true
+}
+
+func (v *importValidator) checkChannel(addIssue func(string, *model.AppError), field string, teamName, name *string) {
+	if teamName == nil || name == nil {
+		return
+	}
+
+	key := channelKey(*teamName, *name)
+	found, ok := v.channels[key]
+	if !ok {
+		if teamID, _ := v.lookupTeam(*teamName); teamID != "" {
+			_, err := v.a.Srv().Store().Channel().GetByName(teamID, strings.ToLower(*name), true)
+			v.logLookupError(err)
+			found = err == nil
+		}
+		v.channels[key] = found
+	}
+
+	if !found {
+		addIssue(field, model.NewAppError("BulkImport", "app.import.validate.unknown_channel.error", map[string]any{"Team": *teamName, "Channel": *name}, "", http.StatusBadRequest))
+	}
+}
+
+func (v *importValidator) checkUsers(addIssue func(string, *model.AppError), field string, usernames *[]string) {
+	if usernames == nil {
+		return
+	}
+
+	for i := range *usernames {
+		v.checkUser(addIssue, fmt.Sprintf("%s[%d]", field, i), &(*usernames)[i])
+	}
+}
+
+func (v *importValidator) checkUser(addIssue func(string, *model.AppError), field string, username *string) {
+	if username == nil {
+		return
+	}
+
+	key := strings.ToLower(*username)
+	found, ok := v.users[key]
+	if !ok {
+		_, err := v.a.Srv().Store().User().GetByUsername(key)
+		v.logLookupError(err)
+		found = err == nil
+		v.users[key] = found
+	}
+
+	if !found {
+		addIssue(field, model.NewAppError("BulkImport", "app.import.validate.unknown_user.error", map[string]any{"Username": *username}, "", http.StatusBadRequest))
+	}
+}
+
+func (v *importValidator) checkScheme(addIssue func(string, *model.AppError), field string, name *string) {
+	if name == nil {
+		return
+	}
+
+	found, ok := v.schemes[*name]
+	if !ok {
+		_, err := v.a.Srv().Store().Scheme().GetByName(*name)
+		v.logLookupError(err)
+		found = err == nil
+		v.schemes[*name] = found
+	}
+
+	if !found {
+		addIssue(field, model.NewAppError("BulkImport", "app.import.validate.unknown_scheme.error", map[string]any{"Scheme": *name}, "", http.StatusBadRequest))
+	}
+}
+
+// checkAttachment reports an issue if the file isn't in the import archive
+// or, when importing from the file system, doesn't exist.
+func (v *importValidator) checkAttachment(addIssue func(string, *model.AppError), field, path string, data *zip.File) {
+	found := data != nil
+	if v.attachedFiles == nil {
+		_, err := os.Stat(path)
+		found = err == nil
+	}
+
+	if !found {
+		addIssue(field, model.NewAppError("BulkImport", "app.import.validate.missing_attachment.error", map[string]any{"Path": path}, "", http.StatusBadRequest))
+	}
+}
+
+func (v *importValidator) logLookupError(err error) {
+	var nfErr *store.ErrNotFound
+	if err != nil && !errors.As(err, &nfErr) {
+		v.c.Logger().Warn("Failed to look up an object referenced by the import", mlog.Err(err))
+	}
+}
+
+func channelKey(teamName, channelName string) string {
+	return strings.ToLower(teamName) + "/" + strings.ToLower(channelName)
+}
+
+// GetImportValidationReport returns the report written by a dry-run of an
+// import job.
+func (a *App) GetImportValidationReport(c request.CTX, jobID string) (*model.ImportValidationReport, *model.AppError) {
+	job, appErr := a.GetJob(c, jobID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if job.Type != model.JobTypeImportProcess || job.Data["dry_run"] != "true" || job.Data["is_downloadable"] != "true" {
+		return nil, model.NewAppError("GetImportValidationReport", "app.import.get_validation_report.not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	data, appErr := a.ReadFile(model.ImportValidationReportPath(job.Id))
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var report model.ImportValidationReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, model.NewAppError("GetImportValidationReport", "app.import.get_validation_report.decode.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return &report, nil
+}
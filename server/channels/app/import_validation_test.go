@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestValidateBulkImport(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	_, err := zipWriter.Create("data/present.png")
+	require.NoError(t, err)
+	require.NoError(t, zipWriter.Close())
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	teamName := model.NewRandomTeamName()
+	channelName := model.NewId()
+	username := model.NewUsername()
+	data := `{"type": "version", "version": 1}
+{"type": "team", "team": {"type": "O", "display_name": "New team", "name": "` + teamName + `"}}
+{"type": "channel", "channel": {"type": "O", "display_name": "New channel", "team": "` + teamName + `", "name": "` + channelName + `"}}
+{"type": "channel", "channel": {"type": "O", "display_name": "Orphan channel", "team": "unknown-team", "name": "orphan"}}
+{"type": "user", "user": {"username": "` + username + `", "email": "` + username + `@example.com", "teams": [{"name": "` + teamName + `", "channels": [{"name": "` + channelName + `"}, {"name": "missing-channel"}]}, {"name": "` + th.BasicTeam.Name + `", "channels": [{"name": "` + th.BasicChannel.Name + `"}]}]}}
+{"type": "post", "post": {"team": "` + teamName + `", "channel": "` + channelName + `", "user": "` + username + `", "message": "Hello", "create_at": 123456789012, "attachments": [{"path": "present.png"}, {"path": "missing.png"}]}}
+{"type": "post", "post": {"team": "` + th.BasicTeam.Name + `", "channel": "` + th.BasicChannel.Name + `", "user": "` + th.BasicUser.Username + `", "message": "Hello", "create_at": 123456789013, "replies": [{"user": "nobody", "message": "Reply", "create_at": 123456789014}]}}
+{"type": "post", "post": {"team": "` + teamName + `", "channel": "` + channelName + `", "user": "` + username + `", "message": "No create_at"}}
+{"type": "post", "post": {"team": "` + teamName + `"
+{"type": "direct_post", "direct_post": {"channel_members": ["` + username + `", "` + th.BasicUser.Username + `"], "user": "someone-else", "message": "Hi", "create_at": 123456789015}}`
+
+	report, appErr := th.App.ValidateBulkImport(th.Context, strings.NewReader(data), zipReader, "data")
+	require.Nil(t, appErr)
+
+	assert.Equal(t, 10, report.TotalLines)
+	assert.Equal(t, 2, report.LineCounts["channel"])
+	assert.Equal(t, 3, report.LineCounts["post"])
+	assert.False(t, report.Truncated)
+
+	issueIDs := func(lineType string) []string {
+		var ids []string
+		for _, issue := range report.Issues[lineType] {
+			ids = append(ids, issue.ErrorId)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"app.import.validate.unknown_team.error"}, issueIDs("channel"))
+	assert.Equal(t, 4, report.Issues["channel"][0].Line)
+	assert.Equal(t, "channel.team", report.Issues["channel"][0].Field)
+
+	require.Len(t, report.Issues["user"], 1)
+	assert.Equal(t, "user.teams[0].channels[1].name", report.Issues["user"][0].Field)
+	assert.Equal(t, "app.import.validate.unknown_channel.error", report.Issues["user"][0].ErrorId)
+
+	assert.Equal(t, []string{
+		"app.import.validate.missing_attachment.error",
+		"app.import.validate.unknown_user.error",
+		"app.import.validate_post_import_data.create_at_missing.error",
+	}, issueIDs("post"))
+	assert.Equal(t, "post.attachments[1].path", report.Issues["post"][0].Field)
+	assert.Equal(t, "post.replies[0].user", report.Issues["post"][1].Field)
+
+	assert.Equal(t, []string{"app.import.bulk_import.json_decode.error"}, issueIDs(importValidationUnknownLineType))
+	assert.Equal(t, 9, report.Issues[importValidationUnknownLineType][0].Line)
+
+	require.Len(t, report.Issues["direct_post"], 1)
+	assert.Equal(t, "direct_post.user", report.Issues["direct_post"][0].Field)
+
+	assert.Equal(t, 7, report.IssueCount)
+
+	t.Run("invalid version line", func(t *testing.T) {
+		report, appErr := th.App.ValidateBulkImport(th.Context, strings.NewReader(`{"type": "version", "version": 2}`), nil, "")
+		require.Nil(t, appErr)
+		require.Len(t, report.Issues["version"], 1)
+		assert.Equal(t, "app.import.bulk_import.unsupported_version.error", report.Issues["version"][0].ErrorId)
+	})
+}
+
+func TestGetImportValidationReport(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	job := &model.Job{
+		Id:     model.NewId(),
+		Type:   model.JobTypeImportProcess,
+		Status: model.JobStatusSuccess,
+		Data:   map[string]string{"dry_run": "true"},
+	}
+	_, err := th.App.Srv().Store().Job().Save(job)
+	require.NoError(t, err)
+	defer th.App.Srv().Store().Job().Delete(job.Id)
+
+	_, appErr := th.App.GetImportValidationReport(th.Context, job.Id)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.import.get_validation_report.not_found.app_error", appErr.Id)
+
+	job.Data["is_downloadable"] = "true"
+	_, err = th.App.Srv().Store().Job().UpdateOptimistically(job, model.JobStatusSuccess)
+	require.NoError(t, err)
+
+	report := model.NewImportValidationReport()
+	report.JobId = job.Id
+	report.AddIssue("post", &model.ImportValidationIssue{Line: 2, ErrorId: "app.import.validate.unknown_user.error"})
+	_, appErr = th.App.WriteFile(bytes.NewReader(model.ToJSON(report)), model.ImportValidationReportPath(job.Id))
+	require.Nil(t, appErr)
+	defer th.App.RemoveFile(model.ImportValidationReportPath(job.Id))
+
+	fetched, appErr := th.App.GetImportValidationReport(th.Context, job.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, report.IssueCount, fetched.IssueCount)
+	assert.Equal(t, report.Issues, fetched.Issues)
+}
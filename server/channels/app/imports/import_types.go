@@ -31,6 +31,7 @@ type VersionInfoImportData struct {
 	Generator  string          `json:"generator"`
 	Version    string          `json:"version"`
 	Created    string          `json:"created"`
+	SiteURL    string          `json:"site_url,omitempty"`
 	Additional json.RawMessage `json:"additional,omitempty"`
 }
 
@@ -166,7 +167,29 @@ type ReactionImportData struct {
 	EmojiName *string `json:"emoji_name"`
 }
 
+// PollImportData is the poll held by a post of type "poll". Options and votes
+// refer to each other by the text of the options.
+type PollImportData struct {
+	Question       *string               `json:"question"`
+	Options        *[]string             `json:"options"`
+	MultipleChoice *bool                 `json:"multiple_choice,omitempty"`
+	Anonymous      *bool                 `json:"anonymous,omitempty"`
+	CloseAt        *int64                `json:"close_at,omitempty"`
+	Votes          *[]PollVoteImportData `json:"votes,omitempty"`
+}
+
+type PollVoteImportData struct {
+	User   *string `json:"user"`
+	Option *string `json:"option"`
+}
+
 type ReplyImportData struct {
+	// Id is the ID the reply had on the server it was exported from.
+	Id *string `json:"id,omitempty"`
+	// ImportId is the ID to import the reply with, set from Id according
+	// to the ID mode of the import. The reply gets a new ID when empty.
+	ImportId string `json:"-"`
+
 	User *string `json:"user"`
 
 	Type     *string `json:"type"`
@@ -178,9 +201,16 @@ type ReplyImportData struct {
 	Reactions   *[]ReactionImportData   `json:"reactions,omitempty"`
 	Attachments *[]AttachmentImportData `json:"attachments,omitempty"`
 	IsPinned    *bool                   `json:"is_pinned,omitempty"`
+	Poll        *PollImportData         `json:"poll,omitempty"`
 }
 
 type PostImportData struct {
+	// Id is the ID the post had on the server it was exported from.
+	Id *string `json:"id,omitempty"`
+	// ImportId is the ID to import the post with, set from Id according
+	// to the ID mode of the import. The post gets a new ID when empty.
+	ImportId string `json:"-"`
+
 	Team    *string `json:"team"`
 	Channel *string `json:"channel"`
 	User    *string `json:"user"`
@@ -196,6 +226,7 @@ type PostImportData struct {
 	Replies     *[]ReplyImportData      `json:"replies,omitempty"`
 	Attachments *[]AttachmentImportData `json:"attachments,omitempty"`
 	IsPinned    *bool                   `json:"is_pinned,omitempty"`
+	Poll        *PollImportData         `json:"poll,omitempty"`
 
 	ThreadFollowers *[]ThreadFollowerImportData `json:"thread_followers,omitempty"`
 }
@@ -210,6 +241,12 @@ type DirectChannelImportData struct {
 }
 
 type DirectPostImportData struct {
+	// Id is the ID the post had on the server it was exported from.
+	Id *string `json:"id,omitempty"`
+	// ImportId is the ID to import the post with, set from Id according
+	// to the ID mode of the import. The post gets a new ID when empty.
+	ImportId string `json:"-"`
+
 	ChannelMembers *[]string `json:"channel_members"`
 	User           *string   `json:"user"`
 
@@ -224,6 +261,7 @@ type DirectPostImportData struct {
 	Replies     *[]ReplyImportData      `json:"replies"`
 	Attachments *[]AttachmentImportData `json:"attachments"`
 	IsPinned    *bool                   `json:"is_pinned,omitempty"`
+	Poll        *PollImportData         `json:"poll,omitempty"`
 
 	ThreadFollowers *[]ThreadFollowerImportData `json:"thread_followers,omitempty"`
 }
@@ -262,6 +300,11 @@ type LineImportWorkerError struct {
 type AttachmentImportData struct {
 	Path *string   `json:"path"`
 	Data *zip.File `json:"-"`
+	// Id is the ID the file had on the server it was exported from.
+	Id *string `json:"id,omitempty"`
+	// ImportId is the ID to import the file with, set from Id according
+	// to the ID mode of the import. The file gets a new ID when empty.
+	ImportId string `json:"-"`
 }
 
 type ComparablePreference struct {
@@ -421,7 +421,59 @@ func ValidateReactionImportData(data *ReactionImportData, parentCreateAt int64)
 	return nil
 }
 
+func ValidatePollImportData(data *PollImportData, postType *string) *model.AppError {
+	if postType == nil || *postType != model.PostTypePoll {
+		return model.NewAppError("BulkImport", "app.import.validate_poll_import_data.post_type.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Question == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_poll_import_data.question_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Options == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_poll_import_data.options_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	poll := &model.Poll{Question: *data.Question}
+	for _, text := range *data.Options {
+		poll.Options = append(poll.Options, &model.PollOption{Text: text})
+	}
+	if appErr := poll.IsValidContent(); appErr != nil {
+		return model.NewAppError("BulkImport", "app.import.validate_poll_import_data.invalid.error", nil, "", http.StatusBadRequest).Wrap(appErr)
+	}
+
+	if data.CloseAt != nil && *data.CloseAt < 0 {
+		return model.NewAppError("BulkImport", "app.import.validate_poll_import_data.close_at_negative.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Votes != nil {
+		multipleChoice := data.MultipleChoice != nil && *data.MultipleChoice
+		voters := make(map[string]bool, len(*data.Votes))
+		for _, vote := range *data.Votes {
+			if vote.User == nil {
+				return model.NewAppError("BulkImport", "app.import.validate_poll_import_data.vote_user_missing.error", nil, "", http.StatusBadRequest)
+			}
+
+			if vote.Option == nil || poll.GetOptionByText(*vote.Option) == nil {
+				return model.NewAppError("BulkImport", "app.import.validate_poll_import_data.vote_option_unknown.error", nil, "", http.StatusBadRequest)
+			}
+
+			voter := strings.ToLower(*vote.User)
+			if voters[voter] && !multipleChoice {
+				return model.NewAppError("BulkImport", "app.import.validate_poll_import_data.vote_single_choice.error", map[string]any{"Username": *vote.User}, "", http.StatusBadRequest)
+			}
+			voters[voter] = true
+		}
+	}
+
+	return nil
+}
+
 func ValidateReplyImportData(data *ReplyImportData, parentCreateAt int64, maxPostSize int) *model.AppError {
+	if data.Id != nil && !model.IsValidId(*data.Id) {
+		return model.NewAppError("BulkImport", "app.import.validate_reply_import_data.id_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
 	if data.User == nil {
 		return model.NewAppError("BulkImport", "app.import.validate_reply_import_data.user_missing.error", nil, "", http.StatusBadRequest)
 	}
@@ -440,10 +492,20 @@ func ValidateReplyImportData(data *ReplyImportData, parentCreateAt int64, maxPos
 		mlog.Warn("Reply CreateAt is before parent post CreateAt", mlog.Int("reply_create_at", *data.CreateAt), mlog.Int("parent_create_at", parentCreateAt))
 	}
 
+	if data.Poll != nil {
+		if appErr := ValidatePollImportData(data.Poll, data.Type); appErr != nil {
+			return appErr
+		}
+	}
+
 	return nil
 }
 
 func ValidatePostImportData(data *PostImportData, maxPostSize int) *model.AppError {
+	if data.Id != nil && !model.IsValidId(*data.Id) {
+		return model.NewAppError("BulkImport", "app.import.validate_post_import_data.id_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
 	if data.Team == nil {
 		return model.NewAppError("BulkImport", "app.import.validate_post_import_data.team_missing.error", nil, "", http.StatusBadRequest)
 	}
@@ -486,6 +548,12 @@ func ValidatePostImportData(data *PostImportData, maxPostSize int) *model.AppErr
 		return model.NewAppError("BulkImport", "app.import.validate_post_import_data.props_too_large.error", nil, "", http.StatusBadRequest)
 	}
 
+	if data.Poll != nil {
+		if appErr := ValidatePollImportData(data.Poll, data.Type); appErr != nil {
+			return appErr
+		}
+	}
+
 	return nil
 }
 
@@ -541,6 +609,10 @@ func ValidateDirectChannelImportData(data *DirectChannelImportData) *model.AppEr
 }
 
 func ValidateDirectPostImportData(data *DirectPostImportData, maxPostSize int) *model.AppError {
+	if data.Id != nil && !model.IsValidId(*data.Id) {
+		return model.NewAppError("BulkImport", "app.import.validate_direct_post_import_data.id_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
 	if data.ChannelMembers == nil {
 		return model.NewAppError("BulkImport", "app.import.validate_direct_post_import_data.channel_members_required.error", nil, "", http.StatusBadRequest)
 	}
@@ -598,6 +670,12 @@ func ValidateDirectPostImportData(data *DirectPostImportData, maxPostSize int) *
 		}
 	}
 
+	if data.Poll != nil {
+		if appErr := ValidatePollImportData(data.Poll, data.Type); appErr != nil {
+			return appErr
+		}
+	}
+
 	return nil
 }
 
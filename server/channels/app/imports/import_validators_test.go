@@ -830,6 +830,66 @@ func TestImportValidateReactionImportData(t *testing.T) {
 	require.NotNil(t, err, "Should have failed due parent with newer create-at value.")
 }
 
+func TestImportValidatePollImportData(t *testing.T) {
+	validPoll := func() *PollImportData {
+		return &PollImportData{
+			Question: model.NewPointer("Where should we eat?"),
+			Options:  &[]string{"Pizza", "Sushi"},
+			Votes: &[]PollVoteImportData{
+				{User: model.NewPointer("user1"), Option: model.NewPointer("Pizza")},
+				{User: model.NewPointer("user2"), Option: model.NewPointer("sushi")},
+			},
+		}
+	}
+	postType := model.NewPointer(model.PostTypePoll)
+
+	// Test with valid properties.
+	err := ValidatePollImportData(validPoll(), postType)
+	require.Nil(t, err, "Validation failed but should have been valid.")
+
+	// Test with a post that is not a poll.
+	err = ValidatePollImportData(validPoll(), model.NewPointer(model.PostTypeDefault))
+	require.NotNil(t, err, "Should have failed due to the post type.")
+	err = ValidatePollImportData(validPoll(), nil)
+	require.NotNil(t, err, "Should have failed due to the post type.")
+
+	// Test with missing required properties.
+	data := validPoll()
+	data.Question = nil
+	err = ValidatePollImportData(data, postType)
+	require.NotNil(t, err, "Should have failed due to missing required property.")
+
+	data = validPoll()
+	data.Options = nil
+	err = ValidatePollImportData(data, postType)
+	require.NotNil(t, err, "Should have failed due to missing required property.")
+
+	// Test with invalid properties.
+	data = validPoll()
+	data.Options = &[]string{"Pizza"}
+	err = ValidatePollImportData(data, postType)
+	require.NotNil(t, err, "Should have failed due to not enough options.")
+
+	data = validPoll()
+	data.CloseAt = model.NewPointer(int64(-1))
+	err = ValidatePollImportData(data, postType)
+	require.NotNil(t, err, "Should have failed due to negative close_at.")
+
+	data = validPoll()
+	(*data.Votes)[0].Option = model.NewPointer("Burgers")
+	err = ValidatePollImportData(data, postType)
+	require.NotNil(t, err, "Should have failed due to a vote for an unknown option.")
+
+	data = validPoll()
+	(*data.Votes)[1].User = model.NewPointer("USER1")
+	err = ValidatePollImportData(data, postType)
+	require.NotNil(t, err, "Should have failed due to several votes on a single choice poll.")
+
+	data.MultipleChoice = model.NewPointer(true)
+	err = ValidatePollImportData(data, postType)
+	require.Nil(t, err, "Validation failed but should have been valid.")
+}
+
 func TestImportValidateReplyImportData(t *testing.T) {
 	// Test with minimum required valid properties.
 	parentCreateAt := model.GetMillis() - 100
@@ -881,6 +941,21 @@ func TestImportValidateReplyImportData(t *testing.T) {
 	}
 	err = ValidateReplyImportData(&data, parentCreateAt, maxPostSize)
 	require.NotNil(t, err, "Should have failed due to 0 create-at value.")
+
+	// Test with an exported ID.
+	data = ReplyImportData{
+		Id:       model.NewPointer(model.NewId()),
+		User:     model.NewPointer("username"),
+		Message:  model.NewPointer("message"),
+		CreateAt: model.NewPointer(model.GetMillis()),
+	}
+	err = ValidateReplyImportData(&data, parentCreateAt, maxPostSize)
+	require.Nil(t, err, "Validation failed but should have been valid.")
+
+	data.Id = model.NewPointer("invalid")
+	err = ValidateReplyImportData(&data, parentCreateAt, maxPostSize)
+	require.NotNil(t, err, "Should have failed due to invalid id.")
+	assert.Equal(t, "app.import.validate_reply_import_data.id_invalid.error", err.Id)
 }
 
 func TestImportValidatePostImportData(t *testing.T) {
@@ -898,6 +973,24 @@ func TestImportValidatePostImportData(t *testing.T) {
 		require.Nil(t, err, "Validation failed but should have been valid.")
 	})
 
+	t.Run("Test with an exported ID", func(t *testing.T) {
+		data := PostImportData{
+			Id:       model.NewPointer(model.NewId()),
+			Team:     model.NewPointer("teamname"),
+			Channel:  model.NewPointer("channelname"),
+			User:     model.NewPointer("username"),
+			Message:  model.NewPointer("message"),
+			CreateAt: model.NewPointer(model.GetMillis()),
+		}
+		err := ValidatePostImportData(&data, maxPostSize)
+		require.Nil(t, err, "Validation failed but should have been valid.")
+
+		data.Id = model.NewPointer("invalid")
+		err = ValidatePostImportData(&data, maxPostSize)
+		require.NotNil(t, err, "Should have failed due to invalid id.")
+		assert.Equal(t, "app.import.validate_post_import_data.id_invalid.error", err.Id)
+	})
+
 	t.Run("Test with missing required properties", func(t *testing.T) {
 		data := PostImportData{
 			Channel:  model.NewPointer("channelname"),
@@ -87,7 +87,7 @@ func (a *App) SessionHasPermissionToCreateJob(session model.Session, job *model.
 		return a.SessionHasPermissionTo(session, model.PermissionCreatePostBleveIndexesJob), model.PermissionCreatePostBleveIndexesJob
 	case model.JobTypeDataRetention:
 		return a.SessionHasPermissionTo(session, model.PermissionCreateDataRetentionJob), model.PermissionCreateDataRetentionJob
-	case model.JobTypeMessageExport:
+	case model.JobTypeMessageExport, model.JobTypeLegalHoldExport:
 		return a.SessionHasPermissionTo(session, model.PermissionCreateComplianceExportJob), model.PermissionCreateComplianceExportJob
 	case model.JobTypeElasticsearchPostIndexing:
 		return a.SessionHasPermissionTo(session, model.PermissionCreateElasticsearchPostIndexingJob), model.PermissionCreateElasticsearchPostIndexingJob
@@ -106,7 +106,9 @@ func (a *App) SessionHasPermissionToCreateJob(session model.Session, job *model.
 		model.JobTypeExportProcess,
 		model.JobTypeExportDelete,
 		model.JobTypeCloud,
-		model.JobTypeExtractContent:
+		model.JobTypeExtractContent,
+		model.JobTypeFileReencryption,
+		model.JobTypeAntivirusRescan:
 		return a.SessionHasPermissionTo(session, model.PermissionManageJobs), model.PermissionManageJobs
 	}
 
@@ -121,7 +123,7 @@ func (a *App) SessionHasPermissionToManageJob(session model.Session, job *model.
 		permission = model.PermissionManagePostBleveIndexesJob
 	case model.JobTypeDataRetention:
 		permission = model.PermissionManageDataRetentionJob
-	case model.JobTypeMessageExport:
+	case model.JobTypeMessageExport, model.JobTypeLegalHoldExport:
 		permission = model.PermissionManageComplianceExportJob
 	case model.JobTypeElasticsearchPostIndexing:
 		permission = model.PermissionManageElasticsearchPostIndexingJob
@@ -140,7 +142,9 @@ func (a *App) SessionHasPermissionToManageJob(session model.Session, job *model.
 		model.JobTypeExportProcess,
 		model.JobTypeExportDelete,
 		model.JobTypeCloud,
-		model.JobTypeExtractContent:
+		model.JobTypeExtractContent,
+		model.JobTypeFileReencryption,
+		model.JobTypeAntivirusRescan:
 		permission = model.PermissionManageJobs
 	}
 
@@ -155,7 +159,7 @@ func (a *App) SessionHasPermissionToReadJob(session model.Session, jobType strin
 	switch jobType {
 	case model.JobTypeDataRetention:
 		return a.SessionHasPermissionTo(session, model.PermissionReadDataRetentionJob), model.PermissionReadDataRetentionJob
-	case model.JobTypeMessageExport:
+	case model.JobTypeMessageExport, model.JobTypeLegalHoldExport:
 		return a.SessionHasPermissionTo(session, model.PermissionReadComplianceExportJob), model.PermissionReadComplianceExportJob
 	case model.JobTypeElasticsearchPostIndexing:
 		return a.SessionHasPermissionTo(session, model.PermissionReadElasticsearchPostIndexingJob), model.PermissionReadElasticsearchPostIndexingJob
@@ -176,7 +180,13 @@ func (a *App) SessionHasPermissionToReadJob(session model.Session, jobType strin
 		model.JobTypeExportDelete,
 		model.JobTypeCloud,
 		model.JobTypeMobileSessionMetadata,
-		model.JobTypeExtractContent:
+		model.JobTypeScheduledPosts,
+		model.JobTypeOutgoingWebhookDeliveries,
+		model.JobTypeReminders,
+		model.JobTypeSavedSearchAlerts,
+		model.JobTypeExtractContent,
+		model.JobTypeFileReencryption,
+		model.JobTypeAntivirusRescan:
 		return a.SessionHasPermissionTo(session, model.PermissionReadJobs), model.PermissionReadJobs
 	}
 
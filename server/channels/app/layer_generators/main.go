@@ -247,11 +247,11 @@ func generateLayer(name, templateFile string) ([]byte, error) {
 		},
 		"shouldTrace": func(params map[string]bool, param string) string {
 			if _, ok := params[param]; ok {
-				return fmt.Sprintf(`span.SetTag("%s", %s)`, param, param)
+				return fmt.Sprintf(`tracing.SetAttribute(span, "%s", %s)`, param, param)
 			}
 			for pName := range params {
 				if strings.HasPrefix(pName, param+".") {
-					return fmt.Sprintf(`span.SetTag("%s", %s)`, pName, pName)
+					return fmt.Sprintf(`tracing.SetAttribute(span, "%s", %s)`, pName, pName)
 				}
 			}
 			return ""
@@ -0,0 +1,188 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// CreateLegalHold saves a legal hold after checking that its custodians and
+// channels exist. Archived channels and deactivated users can be held.
+func (a *App) CreateLegalHold(rctx request.CTX, hold *model.LegalHold) (*model.LegalHold, *model.AppError) {
+	if appErr := a.checkLegalHoldMembers(rctx, hold); appErr != nil {
+		return nil, appErr
+	}
+
+	savedHold, err := a.Srv().Store().LegalHold().Save(hold)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreateLegalHold", "app.legal_hold.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return savedHold, nil
+}
+
+func (a *App) GetLegalHold(holdID string) (*model.LegalHold, *model.AppError) {
+	hold, err := a.Srv().Store().LegalHold().Get(holdID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetLegalHold", "app.legal_hold.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetLegalHold", "app.legal_hold.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return hold, nil
+}
+
+func (a *App) GetLegalHolds(page, perPage int, includeReleased bool) ([]*model.LegalHold, *model.AppError) {
+	holds, err := a.Srv().Store().LegalHold().GetAll(page*perPage, perPage, includeReleased)
+	if err != nil {
+		return nil, model.NewAppError("GetLegalHolds", "app.legal_hold.get_all.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return holds, nil
+}
+
+// PatchLegalHold updates an active legal hold. Content which is no longer
+// covered by the hold becomes subject to data retention again.
+func (a *App) PatchLegalHold(rctx request.CTX, holdID string, patch *model.LegalHoldPatch) (*model.LegalHold, *model.AppError) {
+	hold, appErr := a.GetLegalHold(holdID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if hold.IsReleased() {
+		return nil, model.NewAppError("PatchLegalHold", "app.legal_hold.patch.released.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	hold.Patch(patch)
+	if appErr = a.checkLegalHoldMembers(rctx, hold); appErr != nil {
+		return nil, appErr
+	}
+
+	updatedHold, err := a.Srv().Store().LegalHold().Update(hold)
+	if err != nil {
+		var appErr *model.AppError
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("PatchLegalHold", "app.legal_hold.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("PatchLegalHold", "app.legal_hold.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return updatedHold, nil
+}
+
+// ReleaseLegalHold stops a legal hold from preserving its content, which
+// becomes subject to data retention again. The hold itself is kept so that
+// its content can still be exported until it gets deleted.
+func (a *App) ReleaseLegalHold(holdID string) (*model.LegalHold, *model.AppError) {
+	if err := a.Srv().Store().LegalHold().Release(holdID, model.GetMillis()); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("ReleaseLegalHold", "app.legal_hold.release.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("ReleaseLegalHold", "app.legal_hold.release.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return a.GetLegalHold(holdID)
+}
+
+// CreateLegalHoldExportJob starts a job exporting the content held by a legal
+// hold in one of the compliance export formats.
+func (a *App) CreateLegalHoldExportJob(rctx request.CTX, holdID string, exportType string) (*model.Job, *model.AppError) {
+	if a.MessageExport() == nil {
+		return nil, model.NewAppError("CreateLegalHoldExportJob", "app.legal_hold.export.not_available.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if !slices.Contains([]string{
+		model.ComplianceExportTypeCsv,
+		model.ComplianceExportTypeActiance,
+		model.ComplianceExportTypeGlobalrelay,
+		model.ComplianceExportTypeGlobalrelayZip,
+	}, exportType) {
+		return nil, model.NewAppError("CreateLegalHoldExportJob", "app.legal_hold.export.export_type.app_error", map[string]any{"ExportType": exportType}, "", http.StatusBadRequest)
+	}
+
+	if _, appErr := a.GetLegalHold(holdID); appErr != nil {
+		return nil, appErr
+	}
+
+	return a.Srv().Jobs.CreateJob(rctx, model.JobTypeLegalHoldExport, map[string]string{
+		model.LegalHoldExportJobDataHoldId:     holdID,
+		model.LegalHoldExportJobDataExportType: exportType,
+	})
+}
+
+func (a *App) checkLegalHoldMembers(rctx request.CTX, hold *model.LegalHold) *model.AppError {
+	if len(hold.UserIds) > 0 {
+		users, err := a.Srv().Store().User().GetProfileByIds(rctx.Context(), hold.UserIds, nil, false)
+		if err != nil {
+			return model.NewAppError("checkLegalHoldMembers", "app.user.get_profiles.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		if len(users) != len(model.RemoveDuplicateStrings(hold.UserIds)) {
+			return model.NewAppError("checkLegalHoldMembers", "app.legal_hold.user_not_found.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	if len(hold.ChannelIds) > 0 {
+		channels, err := a.Srv().Store().Channel().GetChannelsByIds(hold.ChannelIds, true)
+		if err != nil {
+			return model.NewAppError("checkLegalHoldMembers", "app.channel.get_channels_by_ids.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		if len(channels) != len(model.RemoveDuplicateStrings(hold.ChannelIds)) {
+			return model.NewAppError("checkLegalHoldMembers", "app.legal_hold.channel_not_found.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// checkUserNotHeld returns an error if deleting the user would delete content
+// preserved by a legal hold.
+func (a *App) checkUserNotHeld(where, userID string) *model.AppError {
+	held, err := a.Srv().Store().LegalHold().IsUserHeld(userID)
+	if err != nil {
+		return model.NewAppError(where, "app.legal_hold.check.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if held {
+		return model.NewAppError(where, "app.legal_hold.user_held.app_error", nil, "user_id="+userID, http.StatusConflict)
+	}
+
+	return nil
+}
+
+// checkChannelNotHeld returns an error if deleting the channel would delete
+// content preserved by a legal hold.
+func (a *App) checkChannelNotHeld(where, channelID string) *model.AppError {
+	held, err := a.Srv().Store().LegalHold().IsChannelHeld(channelID)
+	if err != nil {
+		return model.NewAppError(where, "app.legal_hold.check.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if held {
+		return model.NewAppError(where, "app.legal_hold.channel_held.app_error", nil, "channel_id="+channelID, http.StatusConflict)
+	}
+
+	return nil
+}
@@ -496,7 +496,7 @@ func (a *App) SendNotifications(c request.CTX, post *model.Post, team *model.Tea
 		}
 	}
 
-	if a.canSendPushNotifications() {
+	if a.canSendWebPushNotifications() || a.canSendPushNotifications() {
 		a.NotificationsLog().Trace("Begin sending push notifications",
 			mlog.String("type", model.NotificationTypePush),
 			mlog.String("sender_id", sender.Id),
@@ -847,7 +847,7 @@ func (a *App) SendNotifications(c request.CTX, post *model.Post, team *model.Tea
 					message.Add("previous_unread_mentions", previousUnreadMentions)
 					message.Add("previous_unread_replies", previousUnreadReplies)
 
-					a.Publish(message)
+					a.PublishWithContext(c, message)
 				}
 			}
 		}
@@ -1019,7 +1019,7 @@ func (a *App) RemoveNotifications(c request.CTX, post *model.Post, channel *mode
 				message.Add("previous_unread_mentions", previousUnreadMentions)
 				message.Add("previous_unread_replies", previousUnreadReplies)
 
-				a.Publish(message)
+				a.PublishWithContext(c, message)
 			}
 		}
 	}
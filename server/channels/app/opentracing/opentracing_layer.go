@@ -35,9 +35,6 @@ import (
 	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
 	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
-	"github.com/opentracing/opentracing-go/ext"
-
-	spanlog "github.com/opentracing/opentracing-go/log"
 )
 
 type OpenTracingAppLayer struct {
@@ -66,6 +63,27 @@ type OpenTracingAppLayer struct {
 	ctx context.Context
 }
 
+func (a *OpenTracingAppLayer) AcceptReplyByEmailRecipient(address string) error {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.AcceptReplyByEmailRecipient")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0 := a.app.AcceptReplyByEmailRecipient(address)
+
+	if resultVar0 != nil {
+		tracing.RecordError(span, resultVar0)
+	}
+
+	return resultVar0
+}
+
 func (a *OpenTracingAppLayer) ActivateMfa(userID string, token string) *model.AppError {
 	origCtx := a.ctx
 	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.ActivateMfa")
@@ -77,12 +95,11 @@ func (a *OpenTracingAppLayer) ActivateMfa(userID string, token string) *model.Ap
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ActivateMfa(userID, token)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -99,7 +116,7 @@ func (a *OpenTracingAppLayer) ActiveSearchBackend() string {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ActiveSearchBackend()
 
 	return resultVar0
@@ -116,12 +133,11 @@ func (a *OpenTracingAppLayer) AddChannelMember(c request.CTX, userID string, cha
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.AddChannelMember(c, userID, channel, opts)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -138,12 +154,11 @@ func (a *OpenTracingAppLayer) AddChannelsToRetentionPolicy(policyID string, chan
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.AddChannelsToRetentionPolicy(policyID, channelIDs)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -160,7 +175,7 @@ func (a *OpenTracingAppLayer) AddConfigListener(listener func(*model.Config, *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.AddConfigListener(listener)
 
 	return resultVar0
@@ -177,7 +192,7 @@ func (a *OpenTracingAppLayer) AddCursorIdsForPostList(originalList *model.PostLi
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.AddCursorIdsForPostList(originalList, afterPost, beforePost, since, page, perPage, collapsedThreads)
 }
 
@@ -192,12 +207,11 @@ func (a *OpenTracingAppLayer) AddDirectChannels(c request.CTX, teamID string, us
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.AddDirectChannels(c, teamID, user)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -214,12 +228,11 @@ func (a *OpenTracingAppLayer) AddLdapPrivateCertificate(fileData *multipart.File
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.AddLdapPrivateCertificate(fileData)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -236,12 +249,11 @@ func (a *OpenTracingAppLayer) AddLdapPublicCertificate(fileData *multipart.FileH
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.AddLdapPublicCertificate(fileData)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -258,7 +270,7 @@ func (a *OpenTracingAppLayer) AddLicenseListener(listener func(oldLicense, newLi
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.AddLicenseListener(listener)
 
 	return resultVar0
@@ -275,12 +287,11 @@ func (a *OpenTracingAppLayer) AddPublicKey(name string, key io.Reader) *model.Ap
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.AddPublicKey(name, key)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -297,12 +308,11 @@ func (a *OpenTracingAppLayer) AddRemoteCluster(rc *model.RemoteCluster) (*model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.AddRemoteCluster(rc)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -319,12 +329,11 @@ func (a *OpenTracingAppLayer) AddSamlIdpCertificate(fileData *multipart.FileHead
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.AddSamlIdpCertificate(fileData)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -341,12 +350,11 @@ func (a *OpenTracingAppLayer) AddSamlPrivateCertificate(fileData *multipart.File
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.AddSamlPrivateCertificate(fileData)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -363,12 +371,11 @@ func (a *OpenTracingAppLayer) AddSamlPublicCertificate(fileData *multipart.FileH
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.AddSamlPublicCertificate(fileData)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -385,7 +392,7 @@ func (a *OpenTracingAppLayer) AddSessionToCache(session *model.Session) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.AddSessionToCache(session)
 }
 
@@ -400,12 +407,11 @@ func (a *OpenTracingAppLayer) AddTeamMember(c request.CTX, teamID string, userID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.AddTeamMember(c, teamID, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -422,12 +428,11 @@ func (a *OpenTracingAppLayer) AddTeamMemberByInviteId(c request.CTX, inviteId st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.AddTeamMemberByInviteId(c, inviteId, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -444,12 +449,11 @@ func (a *OpenTracingAppLayer) AddTeamMemberByToken(c request.CTX, userID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.AddTeamMemberByToken(c, userID, tokenID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -466,12 +470,11 @@ func (a *OpenTracingAppLayer) AddTeamMembers(c request.CTX, teamID string, userI
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.AddTeamMembers(c, teamID, userIDs, userRequestorId, graceful)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -488,12 +491,11 @@ func (a *OpenTracingAppLayer) AddTeamsToRetentionPolicy(policyID string, teamIDs
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.AddTeamsToRetentionPolicy(policyID, teamIDs)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -510,12 +512,11 @@ func (a *OpenTracingAppLayer) AddUserToChannel(c request.CTX, user *model.User,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.AddUserToChannel(c, user, channel, skipTeamMemberIntegrityCheck)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -532,12 +533,11 @@ func (a *OpenTracingAppLayer) AddUserToTeam(c request.CTX, teamID string, userID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.AddUserToTeam(c, teamID, userID, userRequestorId)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -554,12 +554,11 @@ func (a *OpenTracingAppLayer) AddUserToTeamByInviteId(c request.CTX, inviteId st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.AddUserToTeamByInviteId(c, inviteId, userID)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -576,12 +575,11 @@ func (a *OpenTracingAppLayer) AddUserToTeamByTeamId(c request.CTX, teamID string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.AddUserToTeamByTeamId(c, teamID, user)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -598,12 +596,11 @@ func (a *OpenTracingAppLayer) AddUserToTeamByToken(c request.CTX, userID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.AddUserToTeamByToken(c, userID, tokenID)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -620,12 +617,11 @@ func (a *OpenTracingAppLayer) AdjustImage(file io.Reader) (*bytes.Buffer, *model
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.AdjustImage(file)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -642,12 +638,11 @@ func (a *OpenTracingAppLayer) AdjustInProductLimits(limits *model.ProductLimits,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.AdjustInProductLimits(limits, subscription)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -664,12 +659,11 @@ func (a *OpenTracingAppLayer) AdjustTeamsFromProductLimits(teamLimits *model.Tea
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.AdjustTeamsFromProductLimits(teamLimits)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -686,12 +680,11 @@ func (a *OpenTracingAppLayer) AllowOAuthAppAccessToUser(c request.CTX, userID st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.AllowOAuthAppAccessToUser(c, userID, authRequest)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -708,12 +701,11 @@ func (a *OpenTracingAppLayer) AppendFile(fr io.Reader, path string) (int64, *mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.AppendFile(fr, path)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -730,7 +722,7 @@ func (a *OpenTracingAppLayer) AsymmetricSigningKey() *ecdsa.PrivateKey {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.AsymmetricSigningKey()
 
 	return resultVar0
@@ -747,7 +739,7 @@ func (a *OpenTracingAppLayer) AttachCloudSessionCookie(c request.CTX, w http.Res
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.AttachCloudSessionCookie(c, w, r)
 }
 
@@ -762,12 +754,11 @@ func (a *OpenTracingAppLayer) AttachDeviceId(sessionID string, deviceID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.AttachDeviceId(sessionID, deviceID, expiresAt)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -784,7 +775,7 @@ func (a *OpenTracingAppLayer) AttachSessionCookies(c request.CTX, w http.Respons
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.AttachSessionCookies(c, w, r)
 }
 
@@ -799,12 +790,32 @@ func (a *OpenTracingAppLayer) AuthenticateUserForLogin(c request.CTX, id string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.AuthenticateUserForLogin(c, id, loginId, password, mfaToken, cwsToken, ldapOnly)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) AuthenticateUserWithWebAuthn(c request.CTX, assertion *model.WebAuthnAssertion) (*model.User, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.AuthenticateUserWithWebAuthn")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.AuthenticateUserWithWebAuthn(c, assertion)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -821,12 +832,11 @@ func (a *OpenTracingAppLayer) AuthorizeOAuthUser(c request.CTX, w http.ResponseW
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2, resultVar3, resultVar4 := a.app.AuthorizeOAuthUser(c, w, r, service, code, state, redirectURI)
 
 	if resultVar4 != nil {
-		span.LogFields(spanlog.Error(resultVar4))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar4)
 	}
 
 	return resultVar0, resultVar1, resultVar2, resultVar3, resultVar4
@@ -843,12 +853,11 @@ func (a *OpenTracingAppLayer) AutocompleteChannels(c request.CTX, userID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.AutocompleteChannels(c, userID, term)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -865,12 +874,11 @@ func (a *OpenTracingAppLayer) AutocompleteChannelsForSearch(c request.CTX, teamI
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.AutocompleteChannelsForSearch(c, teamID, userID, term)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -887,12 +895,11 @@ func (a *OpenTracingAppLayer) AutocompleteChannelsForTeam(c request.CTX, teamID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.AutocompleteChannelsForTeam(c, teamID, userID, term)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -909,12 +916,11 @@ func (a *OpenTracingAppLayer) AutocompleteUsersInChannel(rctx request.CTX, teamI
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.AutocompleteUsersInChannel(rctx, teamID, channelID, term, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -931,12 +937,53 @@ func (a *OpenTracingAppLayer) AutocompleteUsersInTeam(rctx request.CTX, teamID s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.AutocompleteUsersInTeam(rctx, teamID, term, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) BeginWebAuthnLogin(c request.CTX, loginID string) (*model.WebAuthnRequestOptions, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.BeginWebAuthnLogin")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.BeginWebAuthnLogin(c, loginID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) BeginWebAuthnRegistration(c request.CTX, userID string, passwordless bool) (*model.WebAuthnCreationOptions, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.BeginWebAuthnRegistration")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.BeginWebAuthnRegistration(c, userID, passwordless)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -953,12 +1000,11 @@ func (a *OpenTracingAppLayer) BuildPostReactions(ctx request.CTX, postID string)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.BuildPostReactions(ctx, postID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -975,12 +1021,11 @@ func (a *OpenTracingAppLayer) BuildPushNotificationMessage(c request.CTX, conten
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.BuildPushNotificationMessage(c, contentsConfig, post, user, channel, channelName, senderName, explicitMention, channelWideMention, replyToThreadType)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -997,12 +1042,11 @@ func (a *OpenTracingAppLayer) BuildSamlMetadataObject(idpMetadata []byte) (*mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.BuildSamlMetadataObject(idpMetadata)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -1019,12 +1063,11 @@ func (a *OpenTracingAppLayer) BulkExport(ctx request.CTX, writer io.Writer, outP
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.BulkExport(ctx, writer, outPath, job, opts)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1041,12 +1084,32 @@ func (a *OpenTracingAppLayer) BulkImport(c request.CTX, jsonlReader io.Reader, a
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.BulkImport(c, jsonlReader, attachmentsReader, dryRun, workers)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) BulkImportWithOpts(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, opts model.BulkImportOpts) (*model.AppError, int) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.BulkImportWithOpts")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.BulkImportWithOpts(c, jsonlReader, attachmentsReader, opts)
+
+	if resultVar0 != nil {
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0, resultVar1
@@ -1063,12 +1126,11 @@ func (a *OpenTracingAppLayer) BulkImportWithPath(c request.CTX, jsonlReader io.R
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.BulkImportWithPath(c, jsonlReader, attachmentsReader, dryRun, extractContent, workers, importPath)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0, resultVar1
@@ -1085,7 +1147,7 @@ func (a *OpenTracingAppLayer) CanNotifyAdmin(rctx request.CTX, trial bool) bool
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CanNotifyAdmin(rctx, trial)
 
 	return resultVar0
@@ -1102,12 +1164,11 @@ func (a *OpenTracingAppLayer) CancelJob(c request.CTX, jobId string) *model.AppE
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CancelJob(c, jobId)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1124,12 +1185,11 @@ func (a *OpenTracingAppLayer) ChannelMembersMinusGroupMembers(channelID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -1146,12 +1206,11 @@ func (a *OpenTracingAppLayer) ChannelMembersToAdd(since int64, channelID *string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ChannelMembersToAdd(since, channelID, includeRemovedMembers)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -1168,12 +1227,11 @@ func (a *OpenTracingAppLayer) ChannelMembersToRemove(teamID *string) ([]*model.C
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ChannelMembersToRemove(teamID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -1190,7 +1248,7 @@ func (a *OpenTracingAppLayer) Channels() *app.Channels {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.Channels()
 
 	return resultVar0
@@ -1207,12 +1265,11 @@ func (a *OpenTracingAppLayer) CheckCanInviteToSharedChannel(channelId string) er
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CheckCanInviteToSharedChannel(channelId)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1229,7 +1286,7 @@ func (a *OpenTracingAppLayer) CheckForClientSideCert(r *http.Request) (string, s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.CheckForClientSideCert(r)
 
 	return resultVar0, resultVar1, resultVar2
@@ -1246,7 +1303,7 @@ func (a *OpenTracingAppLayer) CheckIntegrity() <-chan model.IntegrityCheckResult
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CheckIntegrity()
 
 	return resultVar0
@@ -1263,12 +1320,11 @@ func (a *OpenTracingAppLayer) CheckMandatoryS3Fields(settings *model.FileSetting
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CheckMandatoryS3Fields(settings)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1285,12 +1341,11 @@ func (a *OpenTracingAppLayer) CheckPasswordAndAllCriteria(rctx request.CTX, user
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CheckPasswordAndAllCriteria(rctx, userID, password, mfaToken)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1307,7 +1362,7 @@ func (a *OpenTracingAppLayer) CheckPostReminders(rctx request.CTX) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.CheckPostReminders(rctx)
 }
 
@@ -1322,7 +1377,7 @@ func (a *OpenTracingAppLayer) CheckProviderAttributes(c request.CTX, user *model
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CheckProviderAttributes(c, user, patch)
 
 	return resultVar0
@@ -1339,12 +1394,11 @@ func (a *OpenTracingAppLayer) CheckRolesExist(roleNames []string) *model.AppErro
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CheckRolesExist(roleNames)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1361,12 +1415,11 @@ func (a *OpenTracingAppLayer) CheckUserAllAuthenticationCriteria(rctx request.CT
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CheckUserAllAuthenticationCriteria(rctx, user, mfaToken)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1383,12 +1436,11 @@ func (a *OpenTracingAppLayer) CheckUserMfa(rctx request.CTX, user *model.User, t
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CheckUserMfa(rctx, user, token)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1405,12 +1457,11 @@ func (a *OpenTracingAppLayer) CheckUserPostflightAuthenticationCriteria(rctx req
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CheckUserPostflightAuthenticationCriteria(rctx, user)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1427,12 +1478,11 @@ func (a *OpenTracingAppLayer) CheckUserPreflightAuthenticationCriteria(rctx requ
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CheckUserPreflightAuthenticationCriteria(rctx, user, mfaToken)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1449,7 +1499,7 @@ func (a *OpenTracingAppLayer) CheckWebConn(userID string, connectionID string) *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CheckWebConn(userID, connectionID)
 
 	return resultVar0
@@ -1466,12 +1516,11 @@ func (a *OpenTracingAppLayer) CleanUpAfterPostDeletion(c request.CTX, post *mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CleanUpAfterPostDeletion(c, post, deleteByID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1488,12 +1537,11 @@ func (a *OpenTracingAppLayer) CleanupReportChunks(format string, prefix string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CleanupReportChunks(format, prefix, numberOfChunks)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1510,12 +1558,11 @@ func (a *OpenTracingAppLayer) ClearChannelMembersCache(c request.CTX, channelID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ClearChannelMembersCache(c, channelID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1532,7 +1579,7 @@ func (a *OpenTracingAppLayer) ClearLatestVersionCache(rctx request.CTX) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.ClearLatestVersionCache(rctx)
 }
 
@@ -1547,7 +1594,7 @@ func (a *OpenTracingAppLayer) ClearSessionCacheForAllUsers() {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.ClearSessionCacheForAllUsers()
 }
 
@@ -1562,7 +1609,7 @@ func (a *OpenTracingAppLayer) ClearSessionCacheForAllUsersSkipClusterSend() {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.ClearSessionCacheForAllUsersSkipClusterSend()
 }
 
@@ -1577,7 +1624,7 @@ func (a *OpenTracingAppLayer) ClearSessionCacheForUser(userID string) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.ClearSessionCacheForUser(userID)
 }
 
@@ -1592,7 +1639,7 @@ func (a *OpenTracingAppLayer) ClearSessionCacheForUserSkipClusterSend(userID str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.ClearSessionCacheForUserSkipClusterSend(userID)
 }
 
@@ -1607,12 +1654,11 @@ func (a *OpenTracingAppLayer) ClearTeamMembersCache(teamID string) error {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ClearTeamMembersCache(teamID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1629,7 +1675,7 @@ func (a *OpenTracingAppLayer) ClientConfig() map[string]string {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ClientConfig()
 
 	return resultVar0
@@ -1646,12 +1692,33 @@ func (a *OpenTracingAppLayer) ClientConfigHash() string {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ClientConfigHash()
 
 	return resultVar0
 }
 
+func (a *OpenTracingAppLayer) ClosePoll(c request.CTX, pollID string, userID string) (*model.Poll, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.ClosePoll")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.ClosePoll(c, pollID, userID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
 func (a *OpenTracingAppLayer) Cloud() einterfaces.CloudInterface {
 	origCtx := a.ctx
 	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.Cloud")
@@ -1663,7 +1730,7 @@ func (a *OpenTracingAppLayer) Cloud() einterfaces.CloudInterface {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.Cloud()
 
 	return resultVar0
@@ -1680,7 +1747,7 @@ func (a *OpenTracingAppLayer) CommandsForTeam(teamID string) []*model.Command {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CommandsForTeam(teamID)
 
 	return resultVar0
@@ -1697,12 +1764,11 @@ func (a *OpenTracingAppLayer) CompareAndDeletePluginKey(rctx request.CTX, plugin
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CompareAndDeletePluginKey(rctx, pluginID, key, oldValue)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -1719,12 +1785,11 @@ func (a *OpenTracingAppLayer) CompareAndSetPluginKey(pluginID string, key string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CompareAndSetPluginKey(pluginID, key, oldValue, newValue)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -1741,12 +1806,11 @@ func (a *OpenTracingAppLayer) CompileReportChunks(format string, prefix string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CompileReportChunks(format, prefix, numberOfChunks, headers)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1763,12 +1827,11 @@ func (a *OpenTracingAppLayer) CompleteOAuth(c request.CTX, service string, body
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CompleteOAuth(c, service, body, teamID, props, tokenUser)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -1785,12 +1848,11 @@ func (a *OpenTracingAppLayer) CompleteOnboarding(c request.CTX, request *model.C
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CompleteOnboarding(c, request)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1807,12 +1869,11 @@ func (a *OpenTracingAppLayer) CompleteSwitchWithOAuth(c request.CTX, service str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CompleteSwitchWithOAuth(c, service, userData, email, tokenUser)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -1829,12 +1890,11 @@ func (a *OpenTracingAppLayer) ComputeLastAccessibleFileTime() error {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ComputeLastAccessibleFileTime()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1851,12 +1911,11 @@ func (a *OpenTracingAppLayer) ComputeLastAccessiblePostTime() error {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ComputeLastAccessiblePostTime()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -1873,7 +1932,7 @@ func (a *OpenTracingAppLayer) Config() *model.Config {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.Config()
 
 	return resultVar0
@@ -1890,12 +1949,11 @@ func (a *OpenTracingAppLayer) ConvertBotToUser(c request.CTX, bot *model.Bot, us
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ConvertBotToUser(c, bot, userPatch, sysadmin)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -1912,12 +1970,11 @@ func (a *OpenTracingAppLayer) ConvertGroupMessageToChannel(c request.CTX, conver
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ConvertGroupMessageToChannel(c, convertedByUserId, gmConversionRequest)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -1934,12 +1991,11 @@ func (a *OpenTracingAppLayer) ConvertUserToBot(rctx request.CTX, user *model.Use
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ConvertUserToBot(rctx, user)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -1956,12 +2012,11 @@ func (a *OpenTracingAppLayer) CopyFileInfos(rctx request.CTX, userID string, fil
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CopyFileInfos(rctx, userID, fileIDs)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -1978,12 +2033,11 @@ func (a *OpenTracingAppLayer) CopyWranglerPostlist(c request.CTX, wpl *model.Wra
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CopyWranglerPostlist(c, wpl, targetChannel)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2000,7 +2054,7 @@ func (a *OpenTracingAppLayer) CountNotification(notificationType model.Notificat
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.CountNotification(notificationType, platform)
 }
 
@@ -2015,7 +2069,7 @@ func (a *OpenTracingAppLayer) CountNotificationAck(notificationType model.Notifi
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.CountNotificationAck(notificationType, platform)
 }
 
@@ -2030,7 +2084,7 @@ func (a *OpenTracingAppLayer) CountNotificationReason(notificationStatus model.N
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.CountNotificationReason(notificationStatus, notificationType, notificationReason, platform)
 }
 
@@ -2045,12 +2099,11 @@ func (a *OpenTracingAppLayer) CreateBot(rctx request.CTX, bot *model.Bot) (*mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateBot(rctx, bot)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2067,12 +2120,11 @@ func (a *OpenTracingAppLayer) CreateChannel(c request.CTX, channel *model.Channe
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateChannel(c, channel, addMember)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2089,12 +2141,11 @@ func (a *OpenTracingAppLayer) CreateChannelBookmark(c request.CTX, newBookmark *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateChannelBookmark(c, newBookmark, connectionId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2111,12 +2162,11 @@ func (a *OpenTracingAppLayer) CreateChannelScheme(c request.CTX, channel *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateChannelScheme(c, channel)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2133,12 +2183,11 @@ func (a *OpenTracingAppLayer) CreateChannelWithUser(c request.CTX, channel *mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateChannelWithUser(c, channel, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2155,12 +2204,11 @@ func (a *OpenTracingAppLayer) CreateCommand(cmd *model.Command) (*model.Command,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateCommand(cmd)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2177,16 +2225,15 @@ func (a *OpenTracingAppLayer) CreateCommandPost(c request.CTX, post *model.Post,
 		a.ctx = origCtx
 	}()
 
-	span.SetTag("teamID", teamID)
+	tracing.SetAttribute(span, "teamID", teamID)
 
-	span.SetTag("skipSlackParsing", skipSlackParsing)
+	tracing.SetAttribute(span, "skipSlackParsing", skipSlackParsing)
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateCommandPost(c, post, teamID, response, skipSlackParsing)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2203,12 +2250,11 @@ func (a *OpenTracingAppLayer) CreateCommandWebhook(commandID string, args *model
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateCommandWebhook(commandID, args)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2225,12 +2271,11 @@ func (a *OpenTracingAppLayer) CreateDefaultMemberships(rctx request.CTX, params
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CreateDefaultMemberships(rctx, params)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -2247,12 +2292,11 @@ func (a *OpenTracingAppLayer) CreateEmoji(c request.CTX, sessionUserId string, e
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateEmoji(c, sessionUserId, emoji, multiPartImageData)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2269,12 +2313,11 @@ func (a *OpenTracingAppLayer) CreateGroup(group *model.Group) (*model.Group, *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateGroup(group)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2291,12 +2334,11 @@ func (a *OpenTracingAppLayer) CreateGroupChannel(c request.CTX, userIDs []string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateGroupChannel(c, userIDs, creatorId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2313,12 +2355,11 @@ func (a *OpenTracingAppLayer) CreateGroupWithUserIds(group *model.GroupWithUserI
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateGroupWithUserIds(group)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2335,12 +2376,11 @@ func (a *OpenTracingAppLayer) CreateGuest(c request.CTX, user *model.User) (*mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateGuest(c, user)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2357,12 +2397,11 @@ func (a *OpenTracingAppLayer) CreateIncomingWebhookForChannel(creatorId string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateIncomingWebhookForChannel(creatorId, channel, hook)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2379,12 +2418,53 @@ func (a *OpenTracingAppLayer) CreateJob(c request.CTX, job *model.Job) (*model.J
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateJob(c, job)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) CreateLegalHold(rctx request.CTX, hold *model.LegalHold) (*model.LegalHold, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.CreateLegalHold")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.CreateLegalHold(rctx, hold)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) CreateLegalHoldExportJob(rctx request.CTX, holdID string, exportType string) (*model.Job, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.CreateLegalHoldExportJob")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.CreateLegalHoldExportJob(rctx, holdID, exportType)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2401,12 +2481,11 @@ func (a *OpenTracingAppLayer) CreateOAuthApp(app *model.OAuthApp) (*model.OAuthA
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateOAuthApp(app)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2423,12 +2502,11 @@ func (a *OpenTracingAppLayer) CreateOAuthStateToken(extra string) (*model.Token,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateOAuthStateToken(extra)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2445,12 +2523,11 @@ func (a *OpenTracingAppLayer) CreateOAuthUser(c request.CTX, service string, use
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateOAuthUser(c, service, userData, teamID, tokenUser)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2467,12 +2544,11 @@ func (a *OpenTracingAppLayer) CreateOutgoingWebhook(hook *model.OutgoingWebhook)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateOutgoingWebhook(hook)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2489,12 +2565,32 @@ func (a *OpenTracingAppLayer) CreatePasswordRecoveryToken(rctx request.CTX, user
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreatePasswordRecoveryToken(rctx, userID, email)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) CreatePoll(c request.CTX, poll *model.Poll, rootID string) (*model.Poll, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.CreatePoll")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.CreatePoll(c, poll, rootID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2511,12 +2607,11 @@ func (a *OpenTracingAppLayer) CreatePost(c request.CTX, post *model.Post, channe
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreatePost(c, post, channel, flags)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2533,12 +2628,11 @@ func (a *OpenTracingAppLayer) CreatePostAsUser(c request.CTX, post *model.Post,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreatePostAsUser(c, post, currentSessionId, setOnline)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2555,12 +2649,32 @@ func (a *OpenTracingAppLayer) CreatePostMissingChannel(c request.CTX, post *mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreatePostMissingChannel(c, post, triggerWebhooks, setOnline)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) CreateReminder(rctx request.CTX, reminder *model.Reminder) (*model.Reminder, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.CreateReminder")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.CreateReminder(rctx, reminder)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2577,12 +2691,11 @@ func (a *OpenTracingAppLayer) CreateRemoteClusterInvite(remoteId string, siteURL
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateRemoteClusterInvite(remoteId, siteURL, token, password)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2599,12 +2712,11 @@ func (a *OpenTracingAppLayer) CreateRetentionPolicy(policy *model.RetentionPolic
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateRetentionPolicy(policy)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2621,12 +2733,11 @@ func (a *OpenTracingAppLayer) CreateRole(role *model.Role) (*model.Role, *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateRole(role)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2643,12 +2754,32 @@ func (a *OpenTracingAppLayer) CreateSamlRelayToken(extra string) (*model.Token,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateSamlRelayToken(extra)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) CreateSavedSearch(rctx request.CTX, savedSearch *model.SavedSearch) (*model.SavedSearch, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.CreateSavedSearch")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.CreateSavedSearch(rctx, savedSearch)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2665,12 +2796,53 @@ func (a *OpenTracingAppLayer) CreateScheme(scheme *model.Scheme) (*model.Scheme,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateScheme(scheme)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) CreateScimGroup(rctx request.CTX, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.CreateScimGroup")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.CreateScimGroup(rctx, scimGroup)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) CreateScimUser(rctx request.CTX, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.CreateScimUser")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.CreateScimUser(rctx, scimUser)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2687,12 +2859,11 @@ func (a *OpenTracingAppLayer) CreateSession(c request.CTX, session *model.Sessio
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateSession(c, session)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2709,12 +2880,11 @@ func (a *OpenTracingAppLayer) CreateSidebarCategory(c request.CTX, userID string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateSidebarCategory(c, userID, teamID, newCategory)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2731,12 +2901,11 @@ func (a *OpenTracingAppLayer) CreateTeam(c request.CTX, team *model.Team) (*mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateTeam(c, team)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2753,12 +2922,11 @@ func (a *OpenTracingAppLayer) CreateTeamWithUser(c request.CTX, team *model.Team
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateTeamWithUser(c, team, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2775,12 +2943,11 @@ func (a *OpenTracingAppLayer) CreateTermsOfService(text string, userID string) (
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateTermsOfService(text, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2797,12 +2964,11 @@ func (a *OpenTracingAppLayer) CreateUploadSession(c request.CTX, us *model.Uploa
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateUploadSession(c, us)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2819,12 +2985,11 @@ func (a *OpenTracingAppLayer) CreateUser(c request.CTX, user *model.User) (*mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateUser(c, user)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2841,12 +3006,11 @@ func (a *OpenTracingAppLayer) CreateUserAccessToken(rctx request.CTX, token *mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateUserAccessToken(rctx, token)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2863,12 +3027,11 @@ func (a *OpenTracingAppLayer) CreateUserAsAdmin(c request.CTX, user *model.User,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateUserAsAdmin(c, user, redirect)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2885,12 +3048,11 @@ func (a *OpenTracingAppLayer) CreateUserFromSignup(c request.CTX, user *model.Us
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateUserFromSignup(c, user, redirect)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2907,12 +3069,11 @@ func (a *OpenTracingAppLayer) CreateUserWithInviteId(c request.CTX, user *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateUserWithInviteId(c, user, inviteId, redirect)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2929,12 +3090,11 @@ func (a *OpenTracingAppLayer) CreateUserWithToken(c request.CTX, user *model.Use
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateUserWithToken(c, user, token)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2951,12 +3111,11 @@ func (a *OpenTracingAppLayer) CreateWebhookPost(c request.CTX, userID string, ch
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.CreateWebhookPost(c, userID, channel, text, overrideUsername, overrideIconURL, overrideIconEmoji, props, postType, postRootId, priority)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -2973,12 +3132,11 @@ func (a *OpenTracingAppLayer) CreateZipFileAndAddFiles(fileBackend filestore.Fil
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.CreateZipFileAndAddFiles(fileBackend, fileDatas, zipFileName, directory)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -2995,12 +3153,11 @@ func (a *OpenTracingAppLayer) DBHealthCheckDelete() error {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DBHealthCheckDelete()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3017,12 +3174,11 @@ func (a *OpenTracingAppLayer) DBHealthCheckWrite() error {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DBHealthCheckWrite()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3039,12 +3195,11 @@ func (a *OpenTracingAppLayer) DeactivateGuests(c request.CTX) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeactivateGuests(c)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3061,12 +3216,11 @@ func (a *OpenTracingAppLayer) DeactivateMfa(userID string) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeactivateMfa(userID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3083,12 +3237,11 @@ func (a *OpenTracingAppLayer) DeauthorizeOAuthAppForUser(c request.CTX, userID s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeauthorizeOAuthAppForUser(c, userID, appID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3105,12 +3258,11 @@ func (a *OpenTracingAppLayer) DecryptRemoteClusterInvite(inviteCode string, pass
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.DecryptRemoteClusterInvite(inviteCode, password)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -3127,7 +3279,7 @@ func (a *OpenTracingAppLayer) DefaultChannelNames(c request.CTX) []string {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DefaultChannelNames(c)
 
 	return resultVar0
@@ -3144,12 +3296,11 @@ func (a *OpenTracingAppLayer) DeleteAcknowledgementForPost(c request.CTX, postID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeleteAcknowledgementForPost(c, postID, userID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3166,12 +3317,11 @@ func (a *OpenTracingAppLayer) DeleteAllExpiredPluginKeys() *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeleteAllExpiredPluginKeys()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3188,12 +3338,11 @@ func (a *OpenTracingAppLayer) DeleteAllKeysForPlugin(pluginID string) *model.App
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeleteAllKeysForPlugin(pluginID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3210,12 +3359,11 @@ func (a *OpenTracingAppLayer) DeleteBrandImage(rctx request.CTX) *model.AppError
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeleteBrandImage(rctx)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3232,12 +3380,11 @@ func (a *OpenTracingAppLayer) DeleteChannel(c request.CTX, channel *model.Channe
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeleteChannel(c, channel, userID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3254,12 +3401,11 @@ func (a *OpenTracingAppLayer) DeleteChannelBookmark(bookmarkId string, connectio
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.DeleteChannelBookmark(bookmarkId, connectionId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -3276,12 +3422,11 @@ func (a *OpenTracingAppLayer) DeleteChannelScheme(c request.CTX, channel *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.DeleteChannelScheme(c, channel)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -3298,12 +3443,11 @@ func (a *OpenTracingAppLayer) DeleteCommand(commandID string) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeleteCommand(commandID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3320,12 +3464,11 @@ func (a *OpenTracingAppLayer) DeleteDraft(rctx request.CTX, draft *model.Draft,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeleteDraft(rctx, draft, connectionID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3342,12 +3485,11 @@ func (a *OpenTracingAppLayer) DeleteEmoji(c request.CTX, emoji *model.Emoji) *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeleteEmoji(c, emoji)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3364,7 +3506,7 @@ func (a *OpenTracingAppLayer) DeleteEphemeralPost(rctx request.CTX, userID strin
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.DeleteEphemeralPost(rctx, userID, postID)
 }
 
@@ -3379,12 +3521,11 @@ func (a *OpenTracingAppLayer) DeleteExport(name string) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeleteExport(name)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3401,12 +3542,11 @@ func (a *OpenTracingAppLayer) DeleteGroup(groupID string) (*model.Group, *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.DeleteGroup(groupID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -3423,12 +3563,11 @@ func (a *OpenTracingAppLayer) DeleteGroupConstrainedMemberships(rctx request.CTX
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeleteGroupConstrainedMemberships(rctx)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3445,12 +3584,11 @@ func (a *OpenTracingAppLayer) DeleteGroupMember(groupID string, userID string) (
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.DeleteGroupMember(groupID, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -3467,12 +3605,11 @@ func (a *OpenTracingAppLayer) DeleteGroupMembers(groupID string, userIDs []strin
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.DeleteGroupMembers(groupID, userIDs)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -3489,12 +3626,11 @@ func (a *OpenTracingAppLayer) DeleteGroupSyncable(groupID string, syncableID str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.DeleteGroupSyncable(groupID, syncableID, syncableType)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -3511,12 +3647,11 @@ func (a *OpenTracingAppLayer) DeleteIncomingWebhook(hookID string) *model.AppErr
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeleteIncomingWebhook(hookID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3533,12 +3668,11 @@ func (a *OpenTracingAppLayer) DeleteOAuthApp(rctx request.CTX, appID string) *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeleteOAuthApp(rctx, appID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3555,12 +3689,11 @@ func (a *OpenTracingAppLayer) DeleteOutgoingWebhook(hookID string) *model.AppErr
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeleteOutgoingWebhook(hookID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3577,12 +3710,11 @@ func (a *OpenTracingAppLayer) DeletePersistentNotification(c request.CTX, post *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeletePersistentNotification(c, post)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3599,12 +3731,11 @@ func (a *OpenTracingAppLayer) DeletePluginKey(pluginID string, key string) *mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeletePluginKey(pluginID, key)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3621,12 +3752,11 @@ func (a *OpenTracingAppLayer) DeletePost(rctx request.CTX, postID string, delete
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.DeletePost(rctx, postID, deleteByID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -3643,12 +3773,11 @@ func (a *OpenTracingAppLayer) DeletePreferences(c request.CTX, userID string, pr
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeletePreferences(c, userID, preferences)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3665,12 +3794,11 @@ func (a *OpenTracingAppLayer) DeletePublicKey(name string) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeletePublicKey(name)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3687,17 +3815,37 @@ func (a *OpenTracingAppLayer) DeleteReactionForPost(c request.CTX, reaction *mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeleteReactionForPost(c, reaction)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
 }
 
+func (a *OpenTracingAppLayer) DeleteReminder(rctx request.CTX, userID string, reminderID string) (*model.Reminder, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DeleteReminder")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.DeleteReminder(rctx, userID, reminderID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
 func (a *OpenTracingAppLayer) DeleteRemoteCluster(remoteClusterId string) (bool, *model.AppError) {
 	origCtx := a.ctx
 	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DeleteRemoteCluster")
@@ -3709,12 +3857,11 @@ func (a *OpenTracingAppLayer) DeleteRemoteCluster(remoteClusterId string) (bool,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.DeleteRemoteCluster(remoteClusterId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -3731,20 +3878,19 @@ func (a *OpenTracingAppLayer) DeleteRetentionPolicy(policyID string) *model.AppE
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DeleteRetentionPolicy(policyID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
 }
 
-func (a *OpenTracingAppLayer) DeleteScheme(schemeId string) (*model.Scheme, *model.AppError) {
+func (a *OpenTracingAppLayer) DeleteSavedSearch(rctx request.CTX, userID string, savedSearchID string) (*model.SavedSearch, *model.AppError) {
 	origCtx := a.ctx
-	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DeleteScheme")
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DeleteSavedSearch")
 
 	a.ctx = newCtx
 	a.app.Srv().Store().SetContext(newCtx)
@@ -3753,20 +3899,19 @@ func (a *OpenTracingAppLayer) DeleteScheme(schemeId string) (*model.Scheme, *mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
-	resultVar0, resultVar1 := a.app.DeleteScheme(schemeId)
+	defer span.End()
+	resultVar0, resultVar1 := a.app.DeleteSavedSearch(rctx, userID, savedSearchID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
 }
 
-func (a *OpenTracingAppLayer) DeleteSharedChannelRemote(id string) (bool, error) {
+func (a *OpenTracingAppLayer) DeleteScheduledPost(rctx request.CTX, userID string, scheduledPostID string, connectionID string) (*model.ScheduledPost, *model.AppError) {
 	origCtx := a.ctx
-	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DeleteSharedChannelRemote")
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DeleteScheduledPost")
 
 	a.ctx = newCtx
 	a.app.Srv().Store().SetContext(newCtx)
@@ -3775,20 +3920,19 @@ func (a *OpenTracingAppLayer) DeleteSharedChannelRemote(id string) (bool, error)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
-	resultVar0, resultVar1 := a.app.DeleteSharedChannelRemote(id)
+	defer span.End()
+	resultVar0, resultVar1 := a.app.DeleteScheduledPost(rctx, userID, scheduledPostID, connectionID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
 }
 
-func (a *OpenTracingAppLayer) DeleteSidebarCategory(c request.CTX, userID string, teamID string, categoryId string) *model.AppError {
+func (a *OpenTracingAppLayer) DeleteScheme(schemeId string) (*model.Scheme, *model.AppError) {
 	origCtx := a.ctx
-	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DeleteSidebarCategory")
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DeleteScheme")
 
 	a.ctx = newCtx
 	a.app.Srv().Store().SetContext(newCtx)
@@ -3797,20 +3941,19 @@ func (a *OpenTracingAppLayer) DeleteSidebarCategory(c request.CTX, userID string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
-	resultVar0 := a.app.DeleteSidebarCategory(c, userID, teamID, categoryId)
+	defer span.End()
+	resultVar0, resultVar1 := a.app.DeleteScheme(schemeId)
 
-	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
-	return resultVar0
+	return resultVar0, resultVar1
 }
 
-func (a *OpenTracingAppLayer) DeleteToken(token *model.Token) *model.AppError {
+func (a *OpenTracingAppLayer) DeleteScimGroup(rctx request.CTX, groupID string) *model.AppError {
 	origCtx := a.ctx
-	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DeleteToken")
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DeleteScimGroup")
 
 	a.ctx = newCtx
 	a.app.Srv().Store().SetContext(newCtx)
@@ -3819,20 +3962,19 @@ func (a *OpenTracingAppLayer) DeleteToken(token *model.Token) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
-	resultVar0 := a.app.DeleteToken(token)
+	defer span.End()
+	resultVar0 := a.app.DeleteScimGroup(rctx, groupID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
 }
 
-func (a *OpenTracingAppLayer) DemoteUserToGuest(c request.CTX, user *model.User) *model.AppError {
+func (a *OpenTracingAppLayer) DeleteScimUser(rctx request.CTX, userID string) *model.AppError {
 	origCtx := a.ctx
-	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DemoteUserToGuest")
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DeleteScimUser")
 
 	a.ctx = newCtx
 	a.app.Srv().Store().SetContext(newCtx)
@@ -3841,20 +3983,19 @@ func (a *OpenTracingAppLayer) DemoteUserToGuest(c request.CTX, user *model.User)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
-	resultVar0 := a.app.DemoteUserToGuest(c, user)
+	defer span.End()
+	resultVar0 := a.app.DeleteScimUser(rctx, userID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
 }
 
-func (a *OpenTracingAppLayer) DetachPlugin(pluginId string) *model.AppError {
+func (a *OpenTracingAppLayer) DeleteSharedChannelRemote(id string) (bool, error) {
 	origCtx := a.ctx
-	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DetachPlugin")
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DeleteSharedChannelRemote")
 
 	a.ctx = newCtx
 	a.app.Srv().Store().SetContext(newCtx)
@@ -3863,15 +4004,140 @@ func (a *OpenTracingAppLayer) DetachPlugin(pluginId string) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
-	resultVar0 := a.app.DetachPlugin(pluginId)
+	defer span.End()
+	resultVar0, resultVar1 := a.app.DeleteSharedChannelRemote(id)
 
-	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
-	return resultVar0
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) DeleteSidebarCategory(c request.CTX, userID string, teamID string, categoryId string) *model.AppError {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DeleteSidebarCategory")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0 := a.app.DeleteSidebarCategory(c, userID, teamID, categoryId)
+
+	if resultVar0 != nil {
+		tracing.RecordError(span, resultVar0)
+	}
+
+	return resultVar0
+}
+
+func (a *OpenTracingAppLayer) DeleteToken(token *model.Token) *model.AppError {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DeleteToken")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0 := a.app.DeleteToken(token)
+
+	if resultVar0 != nil {
+		tracing.RecordError(span, resultVar0)
+	}
+
+	return resultVar0
+}
+
+func (a *OpenTracingAppLayer) DeleteWebAuthnCredential(c request.CTX, userID string, credentialID string) *model.AppError {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DeleteWebAuthnCredential")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0 := a.app.DeleteWebAuthnCredential(c, userID, credentialID)
+
+	if resultVar0 != nil {
+		tracing.RecordError(span, resultVar0)
+	}
+
+	return resultVar0
+}
+
+func (a *OpenTracingAppLayer) DeleteWebPushSubscription(c request.CTX, sessionID string) *model.AppError {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DeleteWebPushSubscription")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0 := a.app.DeleteWebPushSubscription(c, sessionID)
+
+	if resultVar0 != nil {
+		tracing.RecordError(span, resultVar0)
+	}
+
+	return resultVar0
+}
+
+func (a *OpenTracingAppLayer) DemoteUserToGuest(c request.CTX, user *model.User) *model.AppError {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DemoteUserToGuest")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0 := a.app.DemoteUserToGuest(c, user)
+
+	if resultVar0 != nil {
+		tracing.RecordError(span, resultVar0)
+	}
+
+	return resultVar0
+}
+
+func (a *OpenTracingAppLayer) DetachPlugin(pluginId string) *model.AppError {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.DetachPlugin")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0 := a.app.DetachPlugin(pluginId)
+
+	if resultVar0 != nil {
+		tracing.RecordError(span, resultVar0)
+	}
+
+	return resultVar0
 }
 
 func (a *OpenTracingAppLayer) DisableAutoResponder(rctx request.CTX, userID string, asAdmin bool) *model.AppError {
@@ -3885,12 +4151,11 @@ func (a *OpenTracingAppLayer) DisableAutoResponder(rctx request.CTX, userID stri
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DisableAutoResponder(rctx, userID, asAdmin)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3907,12 +4172,11 @@ func (a *OpenTracingAppLayer) DisablePlugin(id string) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DisablePlugin(id)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3929,12 +4193,11 @@ func (a *OpenTracingAppLayer) DisableUserAccessToken(c request.CTX, token *model
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DisableUserAccessToken(c, token)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3951,12 +4214,11 @@ func (a *OpenTracingAppLayer) DoActionRequest(c request.CTX, rawURL string, body
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.DoActionRequest(c, rawURL, body)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -3973,12 +4235,11 @@ func (a *OpenTracingAppLayer) DoAdvancedPermissionsMigration() error {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DoAdvancedPermissionsMigration()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -3995,7 +4256,7 @@ func (a *OpenTracingAppLayer) DoAppMigrations() {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.DoAppMigrations()
 }
 
@@ -4010,12 +4271,11 @@ func (a *OpenTracingAppLayer) DoCheckForAdminNotifications(trial bool) *model.Ap
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DoCheckForAdminNotifications(trial)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -4032,12 +4292,11 @@ func (a *OpenTracingAppLayer) DoCommandRequest(rctx request.CTX, cmd *model.Comm
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.DoCommandRequest(rctx, cmd, p)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -4054,7 +4313,7 @@ func (a *OpenTracingAppLayer) DoEmojisPermissionsMigration() {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.DoEmojisPermissionsMigration()
 }
 
@@ -4069,7 +4328,7 @@ func (a *OpenTracingAppLayer) DoGuestRolesCreationMigration() {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.DoGuestRolesCreationMigration()
 }
 
@@ -4084,12 +4343,11 @@ func (a *OpenTracingAppLayer) DoLocalRequest(c request.CTX, rawURL string, body
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.DoLocalRequest(c, rawURL, body)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4106,12 +4364,11 @@ func (a *OpenTracingAppLayer) DoLogin(c request.CTX, w http.ResponseWriter, r *h
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.DoLogin(c, w, r, user, deviceID, isMobile, isOAuthUser, isSaml)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4128,12 +4385,11 @@ func (a *OpenTracingAppLayer) DoPermissionsMigrations() error {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DoPermissionsMigrations()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -4150,12 +4406,11 @@ func (a *OpenTracingAppLayer) DoPostActionWithCookie(c request.CTX, postID strin
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.DoPostActionWithCookie(c, postID, actionId, userID, selectedOption, cookie)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4172,12 +4427,11 @@ func (a *OpenTracingAppLayer) DoSystemConsoleRolesCreationMigration() error {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DoSystemConsoleRolesCreationMigration()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -4194,12 +4448,11 @@ func (a *OpenTracingAppLayer) DoUploadFile(c request.CTX, now time.Time, rawTeam
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.DoUploadFile(c, now, rawTeamId, rawChannelId, rawUserId, rawFilename, data, extractContent)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4216,12 +4469,11 @@ func (a *OpenTracingAppLayer) DoUploadFileExpectModification(c request.CTX, now
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.DoUploadFileExpectModification(c, now, rawTeamId, rawChannelId, rawUserId, rawFilename, data, extractContent)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -4238,12 +4490,11 @@ func (a *OpenTracingAppLayer) DoubleCheckPassword(rctx request.CTX, user *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.DoubleCheckPassword(rctx, user, password)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -4260,12 +4511,11 @@ func (a *OpenTracingAppLayer) DownloadFromURL(downloadURL string) ([]byte, error
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.DownloadFromURL(downloadURL)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4282,12 +4532,11 @@ func (a *OpenTracingAppLayer) EnablePlugin(id string) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.EnablePlugin(id)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -4304,12 +4553,11 @@ func (a *OpenTracingAppLayer) EnableUserAccessToken(c request.CTX, token *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.EnableUserAccessToken(c, token)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -4326,12 +4574,11 @@ func (a *OpenTracingAppLayer) EnsureBot(rctx request.CTX, pluginID string, bot *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.EnsureBot(rctx, pluginID, bot)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4348,7 +4595,7 @@ func (a *OpenTracingAppLayer) EnvironmentConfig(filter func(reflect.StructField)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.EnvironmentConfig(filter)
 
 	return resultVar0
@@ -4365,14 +4612,13 @@ func (a *OpenTracingAppLayer) ExecuteCommand(c request.CTX, args *model.CommandA
 		a.ctx = origCtx
 	}()
 
-	span.SetTag("args", args)
+	tracing.SetAttribute(span, "args", args)
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ExecuteCommand(c, args)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4389,7 +4635,7 @@ func (a *OpenTracingAppLayer) ExportFileBackend() filestore.FileBackend {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ExportFileBackend()
 
 	return resultVar0
@@ -4406,12 +4652,11 @@ func (a *OpenTracingAppLayer) ExportFileExists(path string) (bool, *model.AppErr
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ExportFileExists(path)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4428,12 +4673,11 @@ func (a *OpenTracingAppLayer) ExportFileModTime(path string) (time.Time, *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ExportFileModTime(path)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4450,12 +4694,11 @@ func (a *OpenTracingAppLayer) ExportFileReader(path string) (filestore.ReadClose
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ExportFileReader(path)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4472,12 +4715,11 @@ func (a *OpenTracingAppLayer) ExportPermissions(w io.Writer) error {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ExportPermissions(w)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -4494,7 +4736,7 @@ func (a *OpenTracingAppLayer) ExtendSessionExpiryIfNeeded(rctx request.CTX, sess
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ExtendSessionExpiryIfNeeded(rctx, session)
 
 	return resultVar0
@@ -4511,12 +4753,11 @@ func (a *OpenTracingAppLayer) ExtractContentFromFileInfo(rctx request.CTX, fileI
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ExtractContentFromFileInfo(rctx, fileInfo)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -4533,12 +4774,11 @@ func (a *OpenTracingAppLayer) FetchSamlMetadataFromIdp(url string) ([]byte, *mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.FetchSamlMetadataFromIdp(url)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4555,7 +4795,7 @@ func (a *OpenTracingAppLayer) FileBackend() filestore.FileBackend {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.FileBackend()
 
 	return resultVar0
@@ -4572,12 +4812,11 @@ func (a *OpenTracingAppLayer) FileExists(path string) (bool, *model.AppError) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.FileExists(path)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4594,12 +4833,11 @@ func (a *OpenTracingAppLayer) FileModTime(path string) (time.Time, *model.AppErr
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.FileModTime(path)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4616,12 +4854,11 @@ func (a *OpenTracingAppLayer) FileReader(path string) (filestore.ReadCloseSeeker
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.FileReader(path)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4638,12 +4875,11 @@ func (a *OpenTracingAppLayer) FileSize(path string) (int64, *model.AppError) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.FileSize(path)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4660,12 +4896,11 @@ func (a *OpenTracingAppLayer) FillInChannelProps(c request.CTX, channel *model.C
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.FillInChannelProps(c, channel)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -4682,12 +4917,11 @@ func (a *OpenTracingAppLayer) FillInChannelsProps(c request.CTX, channelList mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.FillInChannelsProps(c, channelList)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -4704,12 +4938,11 @@ func (a *OpenTracingAppLayer) FillInPostProps(c request.CTX, post *model.Post, c
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.FillInPostProps(c, post, channel)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -4726,12 +4959,11 @@ func (a *OpenTracingAppLayer) FilterNonGroupChannelMembers(userIDs []string, cha
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.FilterNonGroupChannelMembers(userIDs, channel)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4748,12 +4980,11 @@ func (a *OpenTracingAppLayer) FilterNonGroupTeamMembers(userIDs []string, team *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.FilterNonGroupTeamMembers(userIDs, team)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4770,12 +5001,11 @@ func (a *OpenTracingAppLayer) FilterUsersByVisible(c request.CTX, viewer *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.FilterUsersByVisible(c, viewer, otherUsers)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4792,7 +5022,7 @@ func (a *OpenTracingAppLayer) FindTeamByName(name string) bool {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.FindTeamByName(name)
 
 	return resultVar0
@@ -4809,10 +5039,31 @@ func (a *OpenTracingAppLayer) FinishSendAdminNotifyPost(rctx request.CTX, trial
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.FinishSendAdminNotifyPost(rctx, trial, now, pluginBasedData)
 }
 
+func (a *OpenTracingAppLayer) FinishWebAuthnRegistration(c request.CTX, userID string, registration *model.WebAuthnRegistration) (*model.WebAuthnCredential, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.FinishWebAuthnRegistration")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.FinishWebAuthnRegistration(c, userID, registration)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
 func (a *OpenTracingAppLayer) GenerateAndSaveDesktopToken(createAt int64, user *model.User) (*string, *model.AppError) {
 	origCtx := a.ctx
 	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GenerateAndSaveDesktopToken")
@@ -4824,12 +5075,32 @@ func (a *OpenTracingAppLayer) GenerateAndSaveDesktopToken(createAt int64, user *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GenerateAndSaveDesktopToken(createAt, user)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GenerateMfaRecoveryCodes(userID string) (*model.MfaRecoveryCodes, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GenerateMfaRecoveryCodes")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GenerateMfaRecoveryCodes(userID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4846,12 +5117,11 @@ func (a *OpenTracingAppLayer) GenerateMfaSecret(userID string) (*model.MfaSecret
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GenerateMfaSecret(userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4868,12 +5138,11 @@ func (a *OpenTracingAppLayer) GeneratePresignURLForExport(name string) (*model.P
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GeneratePresignURLForExport(name)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4890,7 +5159,7 @@ func (a *OpenTracingAppLayer) GeneratePublicLink(siteURL string, info *model.Fil
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GeneratePublicLink(siteURL, info)
 
 	return resultVar0
@@ -4907,7 +5176,7 @@ func (a *OpenTracingAppLayer) GenerateSupportPacket(c request.CTX, options *mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GenerateSupportPacket(c, options)
 
 	return resultVar0
@@ -4924,12 +5193,11 @@ func (a *OpenTracingAppLayer) GetAcknowledgementsForPost(postID string) ([]*mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAcknowledgementsForPost(postID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4946,12 +5214,11 @@ func (a *OpenTracingAppLayer) GetAcknowledgementsForPostList(postList *model.Pos
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAcknowledgementsForPostList(postList)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4968,12 +5235,11 @@ func (a *OpenTracingAppLayer) GetActivePluginManifests() ([]*model.Manifest, *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetActivePluginManifests()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -4990,12 +5256,11 @@ func (a *OpenTracingAppLayer) GetAllChannels(c request.CTX, page int, perPage in
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAllChannels(c, page, perPage, opts)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5012,12 +5277,11 @@ func (a *OpenTracingAppLayer) GetAllChannelsCount(c request.CTX, opts model.Chan
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAllChannelsCount(c, opts)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5034,12 +5298,11 @@ func (a *OpenTracingAppLayer) GetAllLdapGroupsPage(rctx request.CTX, page int, p
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.GetAllLdapGroupsPage(rctx, page, perPage, opts)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -5056,12 +5319,11 @@ func (a *OpenTracingAppLayer) GetAllPrivateTeams() ([]*model.Team, *model.AppErr
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAllPrivateTeams()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5078,12 +5340,11 @@ func (a *OpenTracingAppLayer) GetAllPublicTeams() ([]*model.Team, *model.AppErro
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAllPublicTeams()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5100,12 +5361,11 @@ func (a *OpenTracingAppLayer) GetAllRemoteClusters(page int, perPage int, filter
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAllRemoteClusters(page, perPage, filter)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5122,12 +5382,11 @@ func (a *OpenTracingAppLayer) GetAllRoles() ([]*model.Role, *model.AppError) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAllRoles()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5144,12 +5403,11 @@ func (a *OpenTracingAppLayer) GetAllTeams() ([]*model.Team, *model.AppError) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAllTeams()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5166,12 +5424,11 @@ func (a *OpenTracingAppLayer) GetAllTeamsPage(offset int, limit int, opts *model
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAllTeamsPage(offset, limit, opts)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5188,12 +5445,11 @@ func (a *OpenTracingAppLayer) GetAllTeamsPageWithCount(offset int, limit int, op
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAllTeamsPageWithCount(offset, limit, opts)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5210,12 +5466,11 @@ func (a *OpenTracingAppLayer) GetAnalytics(rctx request.CTX, name string, teamID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAnalytics(rctx, name, teamID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5232,12 +5487,11 @@ func (a *OpenTracingAppLayer) GetAnalyticsForSupportPacket(rctx request.CTX) (mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAnalyticsForSupportPacket(rctx)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5254,12 +5508,11 @@ func (a *OpenTracingAppLayer) GetAppliedSchemaMigrations() ([]model.AppliedMigra
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAppliedSchemaMigrations()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5276,12 +5529,11 @@ func (a *OpenTracingAppLayer) GetAudits(rctx request.CTX, userID string, limit i
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAudits(rctx, userID, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5298,12 +5550,11 @@ func (a *OpenTracingAppLayer) GetAuditsPage(rctx request.CTX, userID string, pag
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAuditsPage(rctx, userID, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5320,12 +5571,11 @@ func (a *OpenTracingAppLayer) GetAuthorizationCode(c request.CTX, w http.Respons
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAuthorizationCode(c, w, r, service, props, loginHint)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5342,12 +5592,11 @@ func (a *OpenTracingAppLayer) GetAuthorizedAppsForUser(userID string, page int,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetAuthorizedAppsForUser(userID, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5364,12 +5613,11 @@ func (a *OpenTracingAppLayer) GetBookmark(bookmarkId string, includeDeleted bool
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetBookmark(bookmarkId, includeDeleted)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5386,12 +5634,11 @@ func (a *OpenTracingAppLayer) GetBot(rctx request.CTX, botUserId string, include
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetBot(rctx, botUserId, includeDeleted)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5408,12 +5655,11 @@ func (a *OpenTracingAppLayer) GetBots(rctx request.CTX, options *model.BotGetOpt
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetBots(rctx, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5430,12 +5676,11 @@ func (a *OpenTracingAppLayer) GetBrandImage(rctx request.CTX) ([]byte, *model.Ap
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetBrandImage(rctx)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5452,12 +5697,11 @@ func (a *OpenTracingAppLayer) GetBulkReactionsForPosts(postIDs []string) (map[st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetBulkReactionsForPosts(postIDs)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5474,12 +5718,11 @@ func (a *OpenTracingAppLayer) GetChannel(c request.CTX, channelID string) (*mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannel(c, channelID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5496,12 +5739,11 @@ func (a *OpenTracingAppLayer) GetChannelBookmarks(channelId string, since int64)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelBookmarks(channelId, since)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5518,12 +5760,11 @@ func (a *OpenTracingAppLayer) GetChannelByName(c request.CTX, channelName string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelByName(c, channelName, teamID, includeDeleted)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5540,12 +5781,11 @@ func (a *OpenTracingAppLayer) GetChannelByNameForTeamName(c request.CTX, channel
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelByNameForTeamName(c, channelName, teamName, includeDeleted)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5562,12 +5802,11 @@ func (a *OpenTracingAppLayer) GetChannelCounts(c request.CTX, teamID string, use
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelCounts(c, teamID, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5584,12 +5823,11 @@ func (a *OpenTracingAppLayer) GetChannelFileCount(c request.CTX, channelID strin
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelFileCount(c, channelID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5606,12 +5844,11 @@ func (a *OpenTracingAppLayer) GetChannelGroupUsers(channelID string) ([]*model.U
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelGroupUsers(channelID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5628,12 +5865,11 @@ func (a *OpenTracingAppLayer) GetChannelGuestCount(c request.CTX, channelID stri
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelGuestCount(c, channelID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5650,12 +5886,11 @@ func (a *OpenTracingAppLayer) GetChannelMember(c request.CTX, channelID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelMember(c, channelID, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5672,12 +5907,11 @@ func (a *OpenTracingAppLayer) GetChannelMemberCount(c request.CTX, channelID str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelMemberCount(c, channelID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5694,12 +5928,11 @@ func (a *OpenTracingAppLayer) GetChannelMembersByIds(c request.CTX, channelID st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelMembersByIds(c, channelID, userIDs)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5716,12 +5949,11 @@ func (a *OpenTracingAppLayer) GetChannelMembersForUser(c request.CTX, teamID str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelMembersForUser(c, teamID, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5738,12 +5970,11 @@ func (a *OpenTracingAppLayer) GetChannelMembersForUserWithPagination(c request.C
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelMembersForUserWithPagination(c, userID, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5760,12 +5991,11 @@ func (a *OpenTracingAppLayer) GetChannelMembersPage(c request.CTX, channelID str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelMembersPage(c, channelID, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5782,12 +6012,11 @@ func (a *OpenTracingAppLayer) GetChannelMembersTimezones(c request.CTX, channelI
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelMembersTimezones(c, channelID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5804,12 +6033,11 @@ func (a *OpenTracingAppLayer) GetChannelMembersWithTeamDataForUserWithPagination
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelMembersWithTeamDataForUserWithPagination(c, userID, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5826,12 +6054,11 @@ func (a *OpenTracingAppLayer) GetChannelModerationsForChannel(c request.CTX, cha
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelModerationsForChannel(c, channel)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5848,12 +6075,11 @@ func (a *OpenTracingAppLayer) GetChannelPinnedPostCount(c request.CTX, channelID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelPinnedPostCount(c, channelID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5870,12 +6096,11 @@ func (a *OpenTracingAppLayer) GetChannelPoliciesForUser(userID string, offset in
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelPoliciesForUser(userID, offset, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5892,12 +6117,11 @@ func (a *OpenTracingAppLayer) GetChannelUnread(c request.CTX, channelID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelUnread(c, channelID, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5914,12 +6138,11 @@ func (a *OpenTracingAppLayer) GetChannels(c request.CTX, channelIDs []string) ([
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannels(c, channelIDs)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5936,12 +6159,11 @@ func (a *OpenTracingAppLayer) GetChannelsByNames(c request.CTX, channelNames []s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelsByNames(c, channelNames, teamID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5958,12 +6180,11 @@ func (a *OpenTracingAppLayer) GetChannelsForRetentionPolicy(policyID string, off
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelsForRetentionPolicy(policyID, offset, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -5980,12 +6201,11 @@ func (a *OpenTracingAppLayer) GetChannelsForScheme(scheme *model.Scheme, offset
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelsForScheme(scheme, offset, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6002,12 +6222,11 @@ func (a *OpenTracingAppLayer) GetChannelsForSchemePage(scheme *model.Scheme, pag
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelsForSchemePage(scheme, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6024,12 +6243,11 @@ func (a *OpenTracingAppLayer) GetChannelsForTeamForUser(c request.CTX, teamID st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelsForTeamForUser(c, teamID, userID, opts)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6046,12 +6264,11 @@ func (a *OpenTracingAppLayer) GetChannelsForUser(c request.CTX, userID string, i
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelsForUser(c, userID, includeDeleted, lastDeleteAt, pageSize, fromChannelID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6068,12 +6285,11 @@ func (a *OpenTracingAppLayer) GetChannelsMemberCount(c request.CTX, channelIDs [
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelsMemberCount(c, channelIDs)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6090,12 +6306,11 @@ func (a *OpenTracingAppLayer) GetChannelsUserNotIn(c request.CTX, teamID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetChannelsUserNotIn(c, teamID, userID, offset, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6112,12 +6327,11 @@ func (a *OpenTracingAppLayer) GetCloudSession(token string) (*model.Session, *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetCloudSession(token)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6134,7 +6348,7 @@ func (a *OpenTracingAppLayer) GetClusterId() string {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetClusterId()
 
 	return resultVar0
@@ -6151,12 +6365,11 @@ func (a *OpenTracingAppLayer) GetClusterPluginStatuses() (model.PluginStatuses,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetClusterPluginStatuses()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6173,7 +6386,7 @@ func (a *OpenTracingAppLayer) GetClusterStatus(rctx request.CTX) []*model.Cluste
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetClusterStatus(rctx)
 
 	return resultVar0
@@ -6190,12 +6403,11 @@ func (a *OpenTracingAppLayer) GetCommand(commandID string) (*model.Command, *mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetCommand(commandID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6212,12 +6424,11 @@ func (a *OpenTracingAppLayer) GetCommonTeamIDsForTwoUsers(userID string, otherUs
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetCommonTeamIDsForTwoUsers(userID, otherUserID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6234,12 +6445,11 @@ func (a *OpenTracingAppLayer) GetComplianceFile(job *model.Compliance) ([]byte,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetComplianceFile(job)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6256,12 +6466,11 @@ func (a *OpenTracingAppLayer) GetComplianceReport(reportId string) (*model.Compl
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetComplianceReport(reportId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6278,12 +6487,11 @@ func (a *OpenTracingAppLayer) GetComplianceReports(page int, perPage int) (model
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetComplianceReports(page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6300,12 +6508,74 @@ func (a *OpenTracingAppLayer) GetConfigFile(name string) ([]byte, error) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetConfigFile(name)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetConfigHistory(page int, perPage int) ([]*model.ConfigVersion, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetConfigHistory")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetConfigHistory(page, perPage)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetConfigVersion(versionID string) (*model.ConfigVersion, *model.Config, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetConfigVersion")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1, resultVar2 := a.app.GetConfigVersion(versionID)
+
+	if resultVar2 != nil {
+		tracing.RecordError(span, resultVar2)
+	}
+
+	return resultVar0, resultVar1, resultVar2
+}
+
+func (a *OpenTracingAppLayer) GetConfigVersionDiff(versionID string, compareTo string) (*model.ConfigVersionDiff, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetConfigVersionDiff")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetConfigVersionDiff(versionID, compareTo)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6322,7 +6592,7 @@ func (a *OpenTracingAppLayer) GetCookieDomain() string {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetCookieDomain()
 
 	return resultVar0
@@ -6339,12 +6609,11 @@ func (a *OpenTracingAppLayer) GetCustomStatus(userID string) (*model.CustomStatu
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetCustomStatus(userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6361,12 +6630,11 @@ func (a *OpenTracingAppLayer) GetDefaultProfileImage(user *model.User) ([]byte,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetDefaultProfileImage(user)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6383,12 +6651,11 @@ func (a *OpenTracingAppLayer) GetDeletedChannels(c request.CTX, teamID string, o
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetDeletedChannels(c, teamID, offset, limit, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6405,12 +6672,11 @@ func (a *OpenTracingAppLayer) GetDraft(userID string, channelID string, rootID s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetDraft(userID, channelID, rootID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6427,12 +6693,11 @@ func (a *OpenTracingAppLayer) GetDraftsForUser(rctx request.CTX, userID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetDraftsForUser(rctx, userID, teamID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6449,12 +6714,11 @@ func (a *OpenTracingAppLayer) GetEditHistoryForPost(postID string) ([]*model.Pos
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetEditHistoryForPost(postID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6471,12 +6735,11 @@ func (a *OpenTracingAppLayer) GetEmoji(c request.CTX, emojiId string) (*model.Em
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetEmoji(c, emojiId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6493,12 +6756,11 @@ func (a *OpenTracingAppLayer) GetEmojiByName(c request.CTX, emojiName string) (*
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetEmojiByName(c, emojiName)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6515,12 +6777,11 @@ func (a *OpenTracingAppLayer) GetEmojiImage(c request.CTX, emojiId string) ([]by
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.GetEmojiImage(c, emojiId)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -6537,12 +6798,11 @@ func (a *OpenTracingAppLayer) GetEmojiList(c request.CTX, page int, perPage int,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetEmojiList(c, page, perPage, sort)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6559,12 +6819,11 @@ func (a *OpenTracingAppLayer) GetEmojiStaticURL(c request.CTX, emojiName string)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetEmojiStaticURL(c, emojiName)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6581,7 +6840,7 @@ func (a *OpenTracingAppLayer) GetEnvironmentConfig(filter func(reflect.StructFie
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetEnvironmentConfig(filter)
 
 	return resultVar0
@@ -6598,12 +6857,11 @@ func (a *OpenTracingAppLayer) GetFile(rctx request.CTX, fileID string) ([]byte,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetFile(rctx, fileID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6620,12 +6878,11 @@ func (a *OpenTracingAppLayer) GetFileInfo(rctx request.CTX, fileID string) (*mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetFileInfo(rctx, fileID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6642,12 +6899,11 @@ func (a *OpenTracingAppLayer) GetFileInfos(rctx request.CTX, page int, perPage i
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetFileInfos(rctx, page, perPage, opt)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6664,12 +6920,11 @@ func (a *OpenTracingAppLayer) GetFileInfosForPost(rctx request.CTX, postID strin
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.GetFileInfosForPost(rctx, postID, fromMaster, includeDeleted)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -6686,12 +6941,11 @@ func (a *OpenTracingAppLayer) GetFileInfosForPostWithMigration(rctx request.CTX,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetFileInfosForPostWithMigration(rctx, postID, includeDeleted)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6708,12 +6962,11 @@ func (a *OpenTracingAppLayer) GetFilteredUsersStats(options *model.UserCountOpti
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetFilteredUsersStats(options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6730,12 +6983,11 @@ func (a *OpenTracingAppLayer) GetFlaggedPosts(userID string, offset int, limit i
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetFlaggedPosts(userID, offset, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6752,12 +7004,11 @@ func (a *OpenTracingAppLayer) GetFlaggedPostsForChannel(userID string, channelID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetFlaggedPostsForChannel(userID, channelID, offset, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6774,12 +7025,11 @@ func (a *OpenTracingAppLayer) GetFlaggedPostsForTeam(userID string, teamID strin
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetFlaggedPostsForTeam(userID, teamID, offset, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6796,12 +7046,11 @@ func (a *OpenTracingAppLayer) GetGlobalRetentionPolicy() (*model.GlobalRetention
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetGlobalRetentionPolicy()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6818,12 +7067,11 @@ func (a *OpenTracingAppLayer) GetGroup(id string, opts *model.GetGroupOpts, view
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetGroup(id, opts, viewRestrictions)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6840,12 +7088,11 @@ func (a *OpenTracingAppLayer) GetGroupByName(name string, opts model.GroupSearch
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetGroupByName(name, opts)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6862,12 +7109,11 @@ func (a *OpenTracingAppLayer) GetGroupByRemoteID(remoteID string, groupSource mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetGroupByRemoteID(remoteID, groupSource)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6884,12 +7130,11 @@ func (a *OpenTracingAppLayer) GetGroupChannel(c request.CTX, userIDs []string) (
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetGroupChannel(c, userIDs)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6906,12 +7151,11 @@ func (a *OpenTracingAppLayer) GetGroupMemberCount(groupID string, viewRestrictio
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetGroupMemberCount(groupID, viewRestrictions)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6928,12 +7172,11 @@ func (a *OpenTracingAppLayer) GetGroupMemberUsers(groupID string) ([]*model.User
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetGroupMemberUsers(groupID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -6950,12 +7193,11 @@ func (a *OpenTracingAppLayer) GetGroupMemberUsersPage(groupID string, page int,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.GetGroupMemberUsersPage(groupID, page, perPage, viewRestrictions)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -6972,12 +7214,11 @@ func (a *OpenTracingAppLayer) GetGroupMemberUsersSortedPage(groupID string, page
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.GetGroupMemberUsersSortedPage(groupID, page, perPage, viewRestrictions, teammateNameDisplay)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -6994,12 +7235,11 @@ func (a *OpenTracingAppLayer) GetGroupMessageMembersCommonTeams(c request.CTX, c
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetGroupMessageMembersCommonTeams(c, channelID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7016,12 +7256,11 @@ func (a *OpenTracingAppLayer) GetGroupSyncable(groupID string, syncableID string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetGroupSyncable(groupID, syncableID, syncableType)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7038,12 +7277,11 @@ func (a *OpenTracingAppLayer) GetGroupSyncables(groupID string, syncableType mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetGroupSyncables(groupID, syncableType)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7060,12 +7298,11 @@ func (a *OpenTracingAppLayer) GetGroups(page int, perPage int, opts model.GroupS
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetGroups(page, perPage, opts, viewRestrictions)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7082,12 +7319,11 @@ func (a *OpenTracingAppLayer) GetGroupsAssociatedToChannelsByTeam(teamID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetGroupsAssociatedToChannelsByTeam(teamID, opts)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7104,12 +7340,11 @@ func (a *OpenTracingAppLayer) GetGroupsByChannel(channelID string, opts model.Gr
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.GetGroupsByChannel(channelID, opts)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -7126,12 +7361,11 @@ func (a *OpenTracingAppLayer) GetGroupsByIDs(groupIDs []string) ([]*model.Group,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetGroupsByIDs(groupIDs)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7148,12 +7382,11 @@ func (a *OpenTracingAppLayer) GetGroupsBySource(groupSource model.GroupSource) (
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetGroupsBySource(groupSource)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7170,12 +7403,11 @@ func (a *OpenTracingAppLayer) GetGroupsByTeam(teamID string, opts model.GroupSea
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.GetGroupsByTeam(teamID, opts)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -7192,12 +7424,11 @@ func (a *OpenTracingAppLayer) GetGroupsByUserId(userID string) ([]*model.Group,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetGroupsByUserId(userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7214,12 +7445,33 @@ func (a *OpenTracingAppLayer) GetHubForUserId(userID string) *platform.Hub {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetHubForUserId(userID)
 
 	return resultVar0
 }
 
+func (a *OpenTracingAppLayer) GetImportValidationReport(c request.CTX, jobID string) (*model.ImportValidationReport, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetImportValidationReport")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetImportValidationReport(c, jobID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
 func (a *OpenTracingAppLayer) GetIncomingWebhook(hookID string) (*model.IncomingWebhook, *model.AppError) {
 	origCtx := a.ctx
 	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetIncomingWebhook")
@@ -7231,12 +7483,11 @@ func (a *OpenTracingAppLayer) GetIncomingWebhook(hookID string) (*model.Incoming
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetIncomingWebhook(hookID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7253,12 +7504,11 @@ func (a *OpenTracingAppLayer) GetIncomingWebhooksCount(teamID string, userID str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetIncomingWebhooksCount(teamID, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7275,12 +7525,11 @@ func (a *OpenTracingAppLayer) GetIncomingWebhooksForTeamPage(teamID string, page
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetIncomingWebhooksForTeamPage(teamID, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7297,12 +7546,11 @@ func (a *OpenTracingAppLayer) GetIncomingWebhooksForTeamPageByUser(teamID string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetIncomingWebhooksForTeamPageByUser(teamID, userID, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7319,12 +7567,11 @@ func (a *OpenTracingAppLayer) GetIncomingWebhooksPage(page int, perPage int) ([]
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetIncomingWebhooksPage(page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7341,12 +7588,11 @@ func (a *OpenTracingAppLayer) GetIncomingWebhooksPageByUser(userID string, page
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetIncomingWebhooksPageByUser(userID, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7363,12 +7609,11 @@ func (a *OpenTracingAppLayer) GetJob(c request.CTX, id string) (*model.Job, *mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetJob(c, id)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7385,12 +7630,11 @@ func (a *OpenTracingAppLayer) GetJobsByType(c request.CTX, jobType string, offse
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetJobsByType(c, jobType, offset, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7407,12 +7651,11 @@ func (a *OpenTracingAppLayer) GetJobsByTypeAndStatus(c request.CTX, jobTypes []s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetJobsByTypeAndStatus(c, jobTypes, status, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7429,12 +7672,11 @@ func (a *OpenTracingAppLayer) GetJobsByTypePage(c request.CTX, jobType string, p
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetJobsByTypePage(c, jobType, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7451,12 +7693,11 @@ func (a *OpenTracingAppLayer) GetJobsByTypes(c request.CTX, jobTypes []string, o
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetJobsByTypes(c, jobTypes, offset, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7473,12 +7714,11 @@ func (a *OpenTracingAppLayer) GetJobsByTypesPage(c request.CTX, jobType []string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetJobsByTypesPage(c, jobType, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7495,12 +7735,11 @@ func (a *OpenTracingAppLayer) GetKnownUsers(userID string) ([]string, *model.App
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetKnownUsers(userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7517,12 +7756,11 @@ func (a *OpenTracingAppLayer) GetLRUSessions(c request.CTX, userID string, limit
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetLRUSessions(c, userID, limit, offset)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7539,12 +7777,11 @@ func (a *OpenTracingAppLayer) GetLastAccessibleFileTime() (int64, *model.AppErro
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetLastAccessibleFileTime()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7561,12 +7798,32 @@ func (a *OpenTracingAppLayer) GetLastAccessiblePostTime() (int64, *model.AppErro
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetLastAccessiblePostTime()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetLastTriggeredReminder(userID string) (*model.Reminder, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetLastTriggeredReminder")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetLastTriggeredReminder(userID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7583,12 +7840,11 @@ func (a *OpenTracingAppLayer) GetLatestTermsOfService() (*model.TermsOfService,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetLatestTermsOfService()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7605,12 +7861,11 @@ func (a *OpenTracingAppLayer) GetLatestVersion(rctx request.CTX, latestVersionUr
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetLatestVersion(rctx, latestVersionUrl)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7627,12 +7882,53 @@ func (a *OpenTracingAppLayer) GetLdapGroup(rctx request.CTX, ldapGroupID string)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetLdapGroup(rctx, ldapGroupID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetLegalHold(holdID string) (*model.LegalHold, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetLegalHold")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetLegalHold(holdID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetLegalHolds(page int, perPage int, includeReleased bool) ([]*model.LegalHold, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetLegalHolds")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetLegalHolds(page, perPage, includeReleased)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7649,12 +7945,11 @@ func (a *OpenTracingAppLayer) GetLogs(rctx request.CTX, page int, perPage int) (
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetLogs(rctx, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7671,12 +7966,11 @@ func (a *OpenTracingAppLayer) GetLogsSkipSend(rctx request.CTX, page int, perPag
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetLogsSkipSend(rctx, page, perPage, logFilter)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7693,12 +7987,11 @@ func (a *OpenTracingAppLayer) GetMarketplacePlugins(rctx request.CTX, filter *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetMarketplacePlugins(rctx, filter)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7715,12 +8008,11 @@ func (a *OpenTracingAppLayer) GetMemberCountsByGroup(rctx request.CTX, channelID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetMemberCountsByGroup(rctx, channelID, includeTimezones)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7737,15 +8029,15 @@ func (a *OpenTracingAppLayer) GetMessageForNotification(post *model.Post, teamNa
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetMessageForNotification(post, teamName, siteUrl, translateFunc)
 
 	return resultVar0
 }
 
-func (a *OpenTracingAppLayer) GetMultipleEmojiByName(c request.CTX, names []string) ([]*model.Emoji, *model.AppError) {
+func (a *OpenTracingAppLayer) GetMfaRecoveryCodesStatus(userID string) (*model.MfaRecoveryCodesStatus, *model.AppError) {
 	origCtx := a.ctx
-	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetMultipleEmojiByName")
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetMfaRecoveryCodesStatus")
 
 	a.ctx = newCtx
 	a.app.Srv().Store().SetContext(newCtx)
@@ -7754,12 +8046,32 @@ func (a *OpenTracingAppLayer) GetMultipleEmojiByName(c request.CTX, names []stri
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
-	resultVar0, resultVar1 := a.app.GetMultipleEmojiByName(c, names)
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetMfaRecoveryCodesStatus(userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetMultipleEmojiByName(c request.CTX, names []string) ([]*model.Emoji, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetMultipleEmojiByName")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetMultipleEmojiByName(c, names)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7776,12 +8088,11 @@ func (a *OpenTracingAppLayer) GetNewUsersForTeamPage(rctx request.CTX, teamID st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetNewUsersForTeamPage(rctx, teamID, page, perPage, asAdmin, viewRestrictions)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7798,7 +8109,7 @@ func (a *OpenTracingAppLayer) GetNextPostIdFromPostList(postList *model.PostList
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetNextPostIdFromPostList(postList, collapsedThreads)
 
 	return resultVar0
@@ -7815,7 +8126,7 @@ func (a *OpenTracingAppLayer) GetNotificationNameFormat(user *model.User) string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetNotificationNameFormat(user)
 
 	return resultVar0
@@ -7832,12 +8143,11 @@ func (a *OpenTracingAppLayer) GetNumberOfChannelsOnTeam(c request.CTX, teamID st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetNumberOfChannelsOnTeam(c, teamID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7854,12 +8164,11 @@ func (a *OpenTracingAppLayer) GetOAuthAccessTokenForCodeFlow(c request.CTX, clie
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOAuthAccessTokenForCodeFlow(c, clientId, grantType, redirectURI, code, secret, refreshToken)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7876,12 +8185,11 @@ func (a *OpenTracingAppLayer) GetOAuthAccessTokenForImplicitFlow(c request.CTX,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOAuthAccessTokenForImplicitFlow(c, userID, authRequest)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7898,12 +8206,11 @@ func (a *OpenTracingAppLayer) GetOAuthApp(appID string) (*model.OAuthApp, *model
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOAuthApp(appID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7920,12 +8227,11 @@ func (a *OpenTracingAppLayer) GetOAuthApps(page int, perPage int) ([]*model.OAut
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOAuthApps(page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7942,12 +8248,11 @@ func (a *OpenTracingAppLayer) GetOAuthAppsByCreator(userID string, page int, per
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOAuthAppsByCreator(userID, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7964,12 +8269,11 @@ func (a *OpenTracingAppLayer) GetOAuthCodeRedirect(userID string, authRequest *m
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOAuthCodeRedirect(userID, authRequest)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -7986,12 +8290,11 @@ func (a *OpenTracingAppLayer) GetOAuthImplicitRedirect(c request.CTX, userID str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOAuthImplicitRedirect(c, userID, authRequest)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8008,12 +8311,11 @@ func (a *OpenTracingAppLayer) GetOAuthLoginEndpoint(c request.CTX, w http.Respon
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOAuthLoginEndpoint(c, w, r, service, teamID, action, redirectTo, loginHint, isMobile, desktopToken)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8030,12 +8332,11 @@ func (a *OpenTracingAppLayer) GetOAuthSignupEndpoint(c request.CTX, w http.Respo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOAuthSignupEndpoint(c, w, r, service, teamID, desktopToken)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8052,12 +8353,11 @@ func (a *OpenTracingAppLayer) GetOAuthStateToken(token string) (*model.Token, *m
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOAuthStateToken(token)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8074,12 +8374,11 @@ func (a *OpenTracingAppLayer) GetOnboarding() (*model.System, *model.AppError) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOnboarding()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8096,12 +8395,11 @@ func (a *OpenTracingAppLayer) GetOpenGraphMetadata(requestURL string) ([]byte, e
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOpenGraphMetadata(requestURL)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8118,12 +8416,11 @@ func (a *OpenTracingAppLayer) GetOrCreateDirectChannel(c request.CTX, userID str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOrCreateDirectChannel(c, userID, otherUserID, channelOptions...)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8140,12 +8437,53 @@ func (a *OpenTracingAppLayer) GetOutgoingWebhook(hookID string) (*model.Outgoing
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOutgoingWebhook(hookID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetOutgoingWebhookDeliveries(hookID string, opts model.OutgoingWebhookDeliveryGetOptions) ([]*model.OutgoingWebhookDelivery, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetOutgoingWebhookDeliveries")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetOutgoingWebhookDeliveries(hookID, opts)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetOutgoingWebhookDelivery(deliveryID string) (*model.OutgoingWebhookDelivery, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetOutgoingWebhookDelivery")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetOutgoingWebhookDelivery(deliveryID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8162,12 +8500,11 @@ func (a *OpenTracingAppLayer) GetOutgoingWebhooksForChannelPageByUser(channelID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOutgoingWebhooksForChannelPageByUser(channelID, userID, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8184,12 +8521,11 @@ func (a *OpenTracingAppLayer) GetOutgoingWebhooksForTeamPage(teamID string, page
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOutgoingWebhooksForTeamPage(teamID, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8206,12 +8542,11 @@ func (a *OpenTracingAppLayer) GetOutgoingWebhooksForTeamPageByUser(teamID string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOutgoingWebhooksForTeamPageByUser(teamID, userID, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8228,12 +8563,11 @@ func (a *OpenTracingAppLayer) GetOutgoingWebhooksPage(page int, perPage int) ([]
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOutgoingWebhooksPage(page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8250,12 +8584,11 @@ func (a *OpenTracingAppLayer) GetOutgoingWebhooksPageByUser(userID string, page
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetOutgoingWebhooksPageByUser(userID, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8272,12 +8605,11 @@ func (a *OpenTracingAppLayer) GetPasswordRecoveryToken(token string) (*model.Tok
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPasswordRecoveryToken(token)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8294,12 +8626,11 @@ func (a *OpenTracingAppLayer) GetPermalinkPost(c request.CTX, postID string, use
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPermalinkPost(c, postID, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8316,12 +8647,11 @@ func (a *OpenTracingAppLayer) GetPinnedPosts(c request.CTX, channelID string) (*
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPinnedPosts(c, channelID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8338,12 +8668,11 @@ func (a *OpenTracingAppLayer) GetPluginKey(pluginID string, key string) ([]byte,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPluginKey(pluginID, key)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8360,12 +8689,11 @@ func (a *OpenTracingAppLayer) GetPluginStatus(id string) (*model.PluginStatus, *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPluginStatus(id)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8382,12 +8710,11 @@ func (a *OpenTracingAppLayer) GetPluginStatuses() (model.PluginStatuses, *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPluginStatuses()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8404,12 +8731,11 @@ func (a *OpenTracingAppLayer) GetPlugins() (*model.PluginsResponse, *model.AppEr
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPlugins()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8426,12 +8752,54 @@ func (a *OpenTracingAppLayer) GetPluginsEnvironment() *plugin.Environment {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetPluginsEnvironment()
 
 	return resultVar0
 }
 
+func (a *OpenTracingAppLayer) GetPoll(pollID string, userID string) (*model.Poll, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetPoll")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetPoll(pollID, userID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetPollForPost(postID string, userID string) (*model.Poll, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetPollForPost")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetPollForPost(postID, userID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
 func (a *OpenTracingAppLayer) GetPostAfterTime(channelID string, time int64, collapsedThreads bool) (*model.Post, *model.AppError) {
 	origCtx := a.ctx
 	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetPostAfterTime")
@@ -8443,12 +8811,32 @@ func (a *OpenTracingAppLayer) GetPostAfterTime(channelID string, time int64, col
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPostAfterTime(channelID, time, collapsedThreads)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetPostEditHistory(rctx request.CTX, postID string) (*model.PostEditHistory, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetPostEditHistory")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetPostEditHistory(rctx, postID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8465,12 +8853,11 @@ func (a *OpenTracingAppLayer) GetPostIdAfterTime(channelID string, time int64, c
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPostIdAfterTime(channelID, time, collapsedThreads)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8487,12 +8874,11 @@ func (a *OpenTracingAppLayer) GetPostIdBeforeTime(channelID string, time int64,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPostIdBeforeTime(channelID, time, collapsedThreads)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8509,12 +8895,11 @@ func (a *OpenTracingAppLayer) GetPostIfAuthorized(c request.CTX, postID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPostIfAuthorized(c, postID, session, includeDeleted)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8531,12 +8916,32 @@ func (a *OpenTracingAppLayer) GetPostInfo(c request.CTX, postID string) (*model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPostInfo(c, postID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetPostReadReceipts(rctx request.CTX, post *model.Post) (*model.PostReadReceipts, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetPostReadReceipts")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetPostReadReceipts(rctx, post)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8553,12 +8958,11 @@ func (a *OpenTracingAppLayer) GetPostThread(postID string, opts model.GetPostsOp
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPostThread(postID, opts, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8575,12 +8979,11 @@ func (a *OpenTracingAppLayer) GetPosts(channelID string, offset int, limit int)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPosts(channelID, offset, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8597,12 +9000,11 @@ func (a *OpenTracingAppLayer) GetPostsAfterPost(options model.GetPostsOptions) (
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPostsAfterPost(options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8619,12 +9021,11 @@ func (a *OpenTracingAppLayer) GetPostsAroundPost(before bool, options model.GetP
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPostsAroundPost(before, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8641,12 +9042,11 @@ func (a *OpenTracingAppLayer) GetPostsBeforePost(options model.GetPostsOptions)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPostsBeforePost(options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8663,12 +9063,11 @@ func (a *OpenTracingAppLayer) GetPostsByIds(postIDs []string) ([]*model.Post, in
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.GetPostsByIds(postIDs)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -8685,7 +9084,7 @@ func (a *OpenTracingAppLayer) GetPostsEtag(channelID string, collapsedThreads bo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetPostsEtag(channelID, collapsedThreads)
 
 	return resultVar0
@@ -8702,12 +9101,11 @@ func (a *OpenTracingAppLayer) GetPostsForChannelAroundLastUnread(c request.CTX,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPostsForChannelAroundLastUnread(c, channelID, userID, limitBefore, limitAfter, skipFetchThreads, collapsedThreads, collapsedThreadsExtended)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8724,12 +9122,11 @@ func (a *OpenTracingAppLayer) GetPostsPage(options model.GetPostsOptions) (*mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPostsPage(options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8746,12 +9143,11 @@ func (a *OpenTracingAppLayer) GetPostsSince(options model.GetPostsSinceOptions)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPostsSince(options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8768,12 +9164,11 @@ func (a *OpenTracingAppLayer) GetPostsUsage() (int64, *model.AppError) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPostsUsage()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8790,12 +9185,11 @@ func (a *OpenTracingAppLayer) GetPreferenceByCategoryAndNameForUser(c request.CT
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPreferenceByCategoryAndNameForUser(c, userID, category, preferenceName)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8812,12 +9206,11 @@ func (a *OpenTracingAppLayer) GetPreferenceByCategoryForUser(c request.CTX, user
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPreferenceByCategoryForUser(c, userID, category)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8834,12 +9227,11 @@ func (a *OpenTracingAppLayer) GetPreferencesForUser(c request.CTX, userID string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPreferencesForUser(c, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8856,7 +9248,7 @@ func (a *OpenTracingAppLayer) GetPrevPostIdFromPostList(postList *model.PostList
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetPrevPostIdFromPostList(postList, collapsedThreads)
 
 	return resultVar0
@@ -8873,12 +9265,11 @@ func (a *OpenTracingAppLayer) GetPriorityForPost(postId string) (*model.PostPrio
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPriorityForPost(postId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8895,12 +9286,11 @@ func (a *OpenTracingAppLayer) GetPriorityForPostList(list *model.PostList) (map[
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPriorityForPostList(list)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8917,12 +9307,11 @@ func (a *OpenTracingAppLayer) GetPrivateChannelsForTeam(c request.CTX, teamID st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPrivateChannelsForTeam(c, teamID, offset, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8939,12 +9328,11 @@ func (a *OpenTracingAppLayer) GetProductNotices(c request.CTX, userID string, te
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetProductNotices(c, userID, teamID, client, clientVersion, locale)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -8961,12 +9349,11 @@ func (a *OpenTracingAppLayer) GetProfileImage(user *model.User) ([]byte, bool, *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.GetProfileImage(user)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -8983,12 +9370,11 @@ func (a *OpenTracingAppLayer) GetProfileImagePath(user *model.User) (string, *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetProfileImagePath(user)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9005,12 +9391,11 @@ func (a *OpenTracingAppLayer) GetPublicChannelsByIdsForTeam(c request.CTX, teamI
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPublicChannelsByIdsForTeam(c, teamID, channelIDs)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9027,12 +9412,11 @@ func (a *OpenTracingAppLayer) GetPublicChannelsForTeam(c request.CTX, teamID str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPublicChannelsForTeam(c, teamID, offset, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9049,12 +9433,11 @@ func (a *OpenTracingAppLayer) GetPublicKey(name string) ([]byte, *model.AppError
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetPublicKey(name)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9071,12 +9454,11 @@ func (a *OpenTracingAppLayer) GetReactionsForPost(postID string) ([]*model.React
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetReactionsForPost(postID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9093,12 +9475,11 @@ func (a *OpenTracingAppLayer) GetRecentlyActiveUsersForTeam(rctx request.CTX, te
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetRecentlyActiveUsersForTeam(rctx, teamID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9115,12 +9496,53 @@ func (a *OpenTracingAppLayer) GetRecentlyActiveUsersForTeamPage(rctx request.CTX
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetRecentlyActiveUsersForTeamPage(rctx, teamID, page, perPage, asAdmin, viewRestrictions)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetReminder(reminderID string) (*model.Reminder, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetReminder")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetReminder(reminderID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetRemindersForUser(userID string) ([]*model.Reminder, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetRemindersForUser")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetRemindersForUser(userID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9137,12 +9559,11 @@ func (a *OpenTracingAppLayer) GetRemoteCluster(remoteClusterId string, includeDe
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetRemoteCluster(remoteClusterId, includeDeleted)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9159,12 +9580,11 @@ func (a *OpenTracingAppLayer) GetRemoteClusterForUser(remoteID string, userID st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetRemoteClusterForUser(remoteID, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9181,12 +9601,11 @@ func (a *OpenTracingAppLayer) GetRemoteClusterService() (remotecluster.RemoteClu
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetRemoteClusterService()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9203,17 +9622,33 @@ func (a *OpenTracingAppLayer) GetRemoteClusterSession(token string, remoteId str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetRemoteClusterSession(token, remoteId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
 }
 
+func (a *OpenTracingAppLayer) GetReplyByEmailAddress(userID string, post *model.Post) string {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetReplyByEmailAddress")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0 := a.app.GetReplyByEmailAddress(userID, post)
+
+	return resultVar0
+}
+
 func (a *OpenTracingAppLayer) GetRetentionPolicies(offset int, limit int) (*model.RetentionPolicyWithTeamAndChannelCountsList, *model.AppError) {
 	origCtx := a.ctx
 	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetRetentionPolicies")
@@ -9225,12 +9660,11 @@ func (a *OpenTracingAppLayer) GetRetentionPolicies(offset int, limit int) (*mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetRetentionPolicies(offset, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9247,12 +9681,11 @@ func (a *OpenTracingAppLayer) GetRetentionPoliciesCount() (int64, *model.AppErro
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetRetentionPoliciesCount()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9269,12 +9702,11 @@ func (a *OpenTracingAppLayer) GetRetentionPolicy(policyID string) (*model.Retent
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetRetentionPolicy(policyID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9291,12 +9723,11 @@ func (a *OpenTracingAppLayer) GetRole(id string) (*model.Role, *model.AppError)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetRole(id)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9313,12 +9744,11 @@ func (a *OpenTracingAppLayer) GetRoleByName(ctx context.Context, name string) (*
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetRoleByName(ctx, name)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9335,12 +9765,11 @@ func (a *OpenTracingAppLayer) GetRolesByNames(names []string) ([]*model.Role, *m
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetRolesByNames(names)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9357,7 +9786,7 @@ func (a *OpenTracingAppLayer) GetSamlCertificateStatus() *model.SamlCertificateS
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetSamlCertificateStatus()
 
 	return resultVar0
@@ -9374,12 +9803,11 @@ func (a *OpenTracingAppLayer) GetSamlEmailToken(token string) (*model.Token, *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSamlEmailToken(token)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9396,12 +9824,11 @@ func (a *OpenTracingAppLayer) GetSamlMetadata(c request.CTX) (string, *model.App
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSamlMetadata(c)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9418,12 +9845,11 @@ func (a *OpenTracingAppLayer) GetSamlMetadataFromIdp(idpMetadataURL string) (*mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSamlMetadataFromIdp(idpMetadataURL)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9440,7 +9866,7 @@ func (a *OpenTracingAppLayer) GetSanitizeOptions(asAdmin bool) map[string]bool {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetSanitizeOptions(asAdmin)
 
 	return resultVar0
@@ -9457,12 +9883,75 @@ func (a *OpenTracingAppLayer) GetSanitizedConfig() *model.Config {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetSanitizedConfig()
 
 	return resultVar0
 }
 
+func (a *OpenTracingAppLayer) GetSavedSearch(savedSearchID string) (*model.SavedSearch, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetSavedSearch")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetSavedSearch(savedSearchID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetSavedSearchesForUser(userID string) ([]*model.SavedSearch, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetSavedSearchesForUser")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetSavedSearchesForUser(userID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetScheduledPost(scheduledPostID string) (*model.ScheduledPost, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetScheduledPost")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetScheduledPost(scheduledPostID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
 func (a *OpenTracingAppLayer) GetScheme(id string) (*model.Scheme, *model.AppError) {
 	origCtx := a.ctx
 	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetScheme")
@@ -9474,12 +9963,11 @@ func (a *OpenTracingAppLayer) GetScheme(id string) (*model.Scheme, *model.AppErr
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetScheme(id)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9496,12 +9984,11 @@ func (a *OpenTracingAppLayer) GetSchemeByName(name string) (*model.Scheme, *mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSchemeByName(name)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9518,12 +10005,11 @@ func (a *OpenTracingAppLayer) GetSchemeRolesForChannel(c request.CTX, channelID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2, resultVar3 := a.app.GetSchemeRolesForChannel(c, channelID)
 
 	if resultVar3 != nil {
-		span.LogFields(spanlog.Error(resultVar3))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar3)
 	}
 
 	return resultVar0, resultVar1, resultVar2, resultVar3
@@ -9540,20 +10026,103 @@ func (a *OpenTracingAppLayer) GetSchemeRolesForTeam(teamID string) (string, stri
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2, resultVar3 := a.app.GetSchemeRolesForTeam(teamID)
 
 	if resultVar3 != nil {
-		span.LogFields(spanlog.Error(resultVar3))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar3)
+	}
+
+	return resultVar0, resultVar1, resultVar2, resultVar3
+}
+
+func (a *OpenTracingAppLayer) GetSchemes(scope string, offset int, limit int) ([]*model.Scheme, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetSchemes")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetSchemes(scope, offset, limit)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetSchemesPage(scope string, page int, perPage int) ([]*model.Scheme, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetSchemesPage")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetSchemesPage(scope, page, perPage)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetScimGroup(groupID string, excludeMembers bool) (*model.ScimGroup, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetScimGroup")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetScimGroup(groupID, excludeMembers)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetScimGroups(filter string, startIndex int, count int, excludeMembers bool) (*model.ScimListResponse, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetScimGroups")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetScimGroups(filter, startIndex, count, excludeMembers)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
-	return resultVar0, resultVar1, resultVar2, resultVar3
+	return resultVar0, resultVar1
 }
 
-func (a *OpenTracingAppLayer) GetSchemes(scope string, offset int, limit int) ([]*model.Scheme, *model.AppError) {
+func (a *OpenTracingAppLayer) GetScimUser(userID string) (*model.ScimUser, *model.AppError) {
 	origCtx := a.ctx
-	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetSchemes")
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetScimUser")
 
 	a.ctx = newCtx
 	a.app.Srv().Store().SetContext(newCtx)
@@ -9562,20 +10131,19 @@ func (a *OpenTracingAppLayer) GetSchemes(scope string, offset int, limit int) ([
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
-	resultVar0, resultVar1 := a.app.GetSchemes(scope, offset, limit)
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetScimUser(userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
 }
 
-func (a *OpenTracingAppLayer) GetSchemesPage(scope string, page int, perPage int) ([]*model.Scheme, *model.AppError) {
+func (a *OpenTracingAppLayer) GetScimUsers(rctx request.CTX, filter string, startIndex int, count int) (*model.ScimListResponse, *model.AppError) {
 	origCtx := a.ctx
-	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetSchemesPage")
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetScimUsers")
 
 	a.ctx = newCtx
 	a.app.Srv().Store().SetContext(newCtx)
@@ -9584,12 +10152,11 @@ func (a *OpenTracingAppLayer) GetSchemesPage(scope string, page int, perPage int
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
-	resultVar0, resultVar1 := a.app.GetSchemesPage(scope, page, perPage)
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetScimUsers(rctx, filter, startIndex, count)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9606,12 +10173,11 @@ func (a *OpenTracingAppLayer) GetServerLimits() (*model.ServerLimits, *model.App
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetServerLimits()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9628,12 +10194,11 @@ func (a *OpenTracingAppLayer) GetSession(token string) (*model.Session, *model.A
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSession(token)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9650,12 +10215,11 @@ func (a *OpenTracingAppLayer) GetSessionById(c request.CTX, sessionID string) (*
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSessionById(c, sessionID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9672,7 +10236,7 @@ func (a *OpenTracingAppLayer) GetSessionLengthInMillis(session *model.Session) i
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetSessionLengthInMillis(session)
 
 	return resultVar0
@@ -9689,12 +10253,11 @@ func (a *OpenTracingAppLayer) GetSessions(c request.CTX, userID string) ([]*mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSessions(c, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9711,12 +10274,11 @@ func (a *OpenTracingAppLayer) GetSharedChannel(channelID string) (*model.SharedC
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSharedChannel(channelID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9733,12 +10295,11 @@ func (a *OpenTracingAppLayer) GetSharedChannelRemote(id string) (*model.SharedCh
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSharedChannelRemote(id)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9755,12 +10316,11 @@ func (a *OpenTracingAppLayer) GetSharedChannelRemoteByIds(channelID string, remo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSharedChannelRemoteByIds(channelID, remoteID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9777,12 +10337,11 @@ func (a *OpenTracingAppLayer) GetSharedChannelRemotes(page int, perPage int, opt
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSharedChannelRemotes(page, perPage, opts)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9799,12 +10358,11 @@ func (a *OpenTracingAppLayer) GetSharedChannelRemotesStatus(channelID string) ([
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSharedChannelRemotesStatus(channelID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9821,12 +10379,11 @@ func (a *OpenTracingAppLayer) GetSharedChannels(page int, perPage int, opts mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSharedChannels(page, perPage, opts)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9843,12 +10400,11 @@ func (a *OpenTracingAppLayer) GetSharedChannelsCount(opts model.SharedChannelFil
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSharedChannelsCount(opts)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9865,12 +10421,11 @@ func (a *OpenTracingAppLayer) GetSidebarCategories(c request.CTX, userID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSidebarCategories(c, userID, opts)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9887,12 +10442,11 @@ func (a *OpenTracingAppLayer) GetSidebarCategoriesForTeamForUser(c request.CTX,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSidebarCategoriesForTeamForUser(c, userID, teamID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9909,12 +10463,11 @@ func (a *OpenTracingAppLayer) GetSidebarCategory(c request.CTX, categoryId strin
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSidebarCategory(c, categoryId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9931,12 +10484,11 @@ func (a *OpenTracingAppLayer) GetSidebarCategoryOrder(c request.CTX, userID stri
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSidebarCategoryOrder(c, userID, teamID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9953,12 +10505,11 @@ func (a *OpenTracingAppLayer) GetSinglePost(rctx request.CTX, postID string, inc
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSinglePost(rctx, postID, includeDeleted)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -9975,7 +10526,7 @@ func (a *OpenTracingAppLayer) GetSiteURL() string {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetSiteURL()
 
 	return resultVar0
@@ -9992,12 +10543,11 @@ func (a *OpenTracingAppLayer) GetStatus(userID string) (*model.Status, *model.Ap
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetStatus(userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10014,7 +10564,7 @@ func (a *OpenTracingAppLayer) GetStatusFromCache(userID string) *model.Status {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetStatusFromCache(userID)
 
 	return resultVar0
@@ -10031,12 +10581,11 @@ func (a *OpenTracingAppLayer) GetStorageUsage() (int64, *model.AppError) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetStorageUsage()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10053,7 +10602,7 @@ func (a *OpenTracingAppLayer) GetSuggestions(c request.CTX, commandArgs *model.C
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetSuggestions(c, commandArgs, commands, roleID)
 
 	return resultVar0
@@ -10070,12 +10619,11 @@ func (a *OpenTracingAppLayer) GetSystemBot(rctx request.CTX) (*model.Bot, *model
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetSystemBot(rctx)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10092,12 +10640,11 @@ func (a *OpenTracingAppLayer) GetTeam(teamID string) (*model.Team, *model.AppErr
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeam(teamID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10114,12 +10661,11 @@ func (a *OpenTracingAppLayer) GetTeamByInviteId(inviteId string) (*model.Team, *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamByInviteId(inviteId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10136,12 +10682,11 @@ func (a *OpenTracingAppLayer) GetTeamByName(name string) (*model.Team, *model.Ap
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamByName(name)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10158,12 +10703,11 @@ func (a *OpenTracingAppLayer) GetTeamGroupUsers(teamID string) ([]*model.User, *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamGroupUsers(teamID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10180,12 +10724,11 @@ func (a *OpenTracingAppLayer) GetTeamIcon(team *model.Team) ([]byte, *model.AppE
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamIcon(team)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10202,12 +10745,11 @@ func (a *OpenTracingAppLayer) GetTeamIdFromQuery(rctx request.CTX, query url.Val
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamIdFromQuery(rctx, query)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10224,12 +10766,11 @@ func (a *OpenTracingAppLayer) GetTeamMember(rctx request.CTX, teamID string, use
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamMember(rctx, teamID, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10246,12 +10787,11 @@ func (a *OpenTracingAppLayer) GetTeamMembers(teamID string, offset int, limit in
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamMembers(teamID, offset, limit, teamMembersGetOptions)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10268,12 +10808,11 @@ func (a *OpenTracingAppLayer) GetTeamMembersByIds(teamID string, userIDs []strin
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamMembersByIds(teamID, userIDs, restrictions)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10290,12 +10829,11 @@ func (a *OpenTracingAppLayer) GetTeamMembersForUser(c request.CTX, userID string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamMembersForUser(c, userID, excludeTeamID, includeDeleted)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10312,12 +10850,11 @@ func (a *OpenTracingAppLayer) GetTeamMembersForUserWithPagination(userID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamMembersForUserWithPagination(userID, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10334,12 +10871,11 @@ func (a *OpenTracingAppLayer) GetTeamPoliciesForUser(userID string, offset int,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamPoliciesForUser(userID, offset, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10356,12 +10892,11 @@ func (a *OpenTracingAppLayer) GetTeamSchemeChannelRoles(c request.CTX, teamID st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2, resultVar3 := a.app.GetTeamSchemeChannelRoles(c, teamID)
 
 	if resultVar3 != nil {
-		span.LogFields(spanlog.Error(resultVar3))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar3)
 	}
 
 	return resultVar0, resultVar1, resultVar2, resultVar3
@@ -10378,12 +10913,11 @@ func (a *OpenTracingAppLayer) GetTeamStats(teamID string, restrictions *model.Vi
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamStats(teamID, restrictions)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10400,12 +10934,11 @@ func (a *OpenTracingAppLayer) GetTeamUnread(teamID string, userID string) (*mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamUnread(teamID, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10422,12 +10955,11 @@ func (a *OpenTracingAppLayer) GetTeams(teamIDs []string) ([]*model.Team, *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeams(teamIDs)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10444,12 +10976,11 @@ func (a *OpenTracingAppLayer) GetTeamsForRetentionPolicy(policyID string, offset
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamsForRetentionPolicy(policyID, offset, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10466,12 +10997,11 @@ func (a *OpenTracingAppLayer) GetTeamsForScheme(scheme *model.Scheme, offset int
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamsForScheme(scheme, offset, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10488,12 +11018,11 @@ func (a *OpenTracingAppLayer) GetTeamsForSchemePage(scheme *model.Scheme, page i
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamsForSchemePage(scheme, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10510,12 +11039,11 @@ func (a *OpenTracingAppLayer) GetTeamsForUser(userID string) ([]*model.Team, *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamsForUser(userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10532,12 +11060,11 @@ func (a *OpenTracingAppLayer) GetTeamsUnreadForUser(excludeTeamId string, userID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamsUnreadForUser(excludeTeamId, userID, includeCollapsedThreads)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10554,12 +11081,11 @@ func (a *OpenTracingAppLayer) GetTeamsUsage() (*model.TeamsUsage, *model.AppErro
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTeamsUsage()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10576,12 +11102,11 @@ func (a *OpenTracingAppLayer) GetTermsOfService(id string) (*model.TermsOfServic
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTermsOfService(id)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10598,12 +11123,11 @@ func (a *OpenTracingAppLayer) GetThreadForUser(threadMembership *model.ThreadMem
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetThreadForUser(threadMembership, extended)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10620,12 +11144,11 @@ func (a *OpenTracingAppLayer) GetThreadMembershipForUser(userId string, threadId
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetThreadMembershipForUser(userId, threadId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10642,12 +11165,11 @@ func (a *OpenTracingAppLayer) GetThreadMembershipsForUser(userID string, teamID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetThreadMembershipsForUser(userID, teamID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10664,12 +11186,11 @@ func (a *OpenTracingAppLayer) GetThreadsForUser(userID string, teamID string, op
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetThreadsForUser(userID, teamID, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10686,12 +11207,11 @@ func (a *OpenTracingAppLayer) GetTokenById(token string) (*model.Token, *model.A
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTokenById(token)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10708,12 +11228,11 @@ func (a *OpenTracingAppLayer) GetTotalUsersStats(viewRestrictions *model.ViewUse
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetTotalUsersStats(viewRestrictions)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10730,12 +11249,11 @@ func (a *OpenTracingAppLayer) GetUploadSession(c request.CTX, uploadId string) (
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUploadSession(c, uploadId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10752,12 +11270,11 @@ func (a *OpenTracingAppLayer) GetUploadSessionsForUser(userID string) ([]*model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUploadSessionsForUser(userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10774,12 +11291,11 @@ func (a *OpenTracingAppLayer) GetUser(userID string) (*model.User, *model.AppErr
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUser(userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10796,12 +11312,11 @@ func (a *OpenTracingAppLayer) GetUserAccessToken(tokenID string, sanitize bool)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUserAccessToken(tokenID, sanitize)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10818,12 +11333,11 @@ func (a *OpenTracingAppLayer) GetUserAccessTokens(page int, perPage int) ([]*mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUserAccessTokens(page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10840,12 +11354,11 @@ func (a *OpenTracingAppLayer) GetUserAccessTokensForUser(userID string, page int
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUserAccessTokensForUser(userID, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10862,12 +11375,11 @@ func (a *OpenTracingAppLayer) GetUserByAuth(authData *string, authService string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUserByAuth(authData, authService)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10884,12 +11396,11 @@ func (a *OpenTracingAppLayer) GetUserByEmail(email string) (*model.User, *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUserByEmail(email)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10906,12 +11417,11 @@ func (a *OpenTracingAppLayer) GetUserByRemoteID(remoteID string) (*model.User, *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUserByRemoteID(remoteID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10928,12 +11438,11 @@ func (a *OpenTracingAppLayer) GetUserByUsername(username string) (*model.User, *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUserByUsername(username)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10950,12 +11459,11 @@ func (a *OpenTracingAppLayer) GetUserCountForReport(filter *model.UserReportOpti
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUserCountForReport(filter)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10972,12 +11480,11 @@ func (a *OpenTracingAppLayer) GetUserForLogin(c request.CTX, id string, loginId
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUserForLogin(c, id, loginId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -10994,12 +11501,32 @@ func (a *OpenTracingAppLayer) GetUserStatusesByIds(userIDs []string) ([]*model.S
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUserStatusesByIds(userIDs)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetUserTeamScheduledPosts(rctx request.CTX, userID string, teamID string) ([]*model.ScheduledPost, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetUserTeamScheduledPosts")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetUserTeamScheduledPosts(rctx, userID, teamID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11016,12 +11543,11 @@ func (a *OpenTracingAppLayer) GetUserTermsOfService(userID string) (*model.UserT
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUserTermsOfService(userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11038,12 +11564,11 @@ func (a *OpenTracingAppLayer) GetUsers(userIDs []string) ([]*model.User, *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsers(userIDs)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11060,12 +11585,11 @@ func (a *OpenTracingAppLayer) GetUsersByGroupChannelIds(c request.CTX, channelID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersByGroupChannelIds(c, channelIDs, asAdmin)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11082,12 +11606,11 @@ func (a *OpenTracingAppLayer) GetUsersByIds(userIDs []string, options *store.Use
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersByIds(userIDs, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11104,12 +11627,11 @@ func (a *OpenTracingAppLayer) GetUsersByUsernames(usernames []string, asAdmin bo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersByUsernames(usernames, asAdmin, viewRestrictions)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11126,7 +11648,7 @@ func (a *OpenTracingAppLayer) GetUsersEtag(restrictionsHash string) string {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetUsersEtag(restrictionsHash)
 
 	return resultVar0
@@ -11143,12 +11665,11 @@ func (a *OpenTracingAppLayer) GetUsersForReporting(filter *model.UserReportOptio
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersForReporting(filter)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11165,12 +11686,11 @@ func (a *OpenTracingAppLayer) GetUsersFromProfiles(options *model.UserGetOptions
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersFromProfiles(options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11187,12 +11707,11 @@ func (a *OpenTracingAppLayer) GetUsersInChannel(options *model.UserGetOptions) (
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersInChannel(options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11209,12 +11728,11 @@ func (a *OpenTracingAppLayer) GetUsersInChannelByAdmin(options *model.UserGetOpt
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersInChannelByAdmin(options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11231,12 +11749,11 @@ func (a *OpenTracingAppLayer) GetUsersInChannelByStatus(options *model.UserGetOp
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersInChannelByStatus(options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11253,12 +11770,11 @@ func (a *OpenTracingAppLayer) GetUsersInChannelMap(options *model.UserGetOptions
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersInChannelMap(options, asAdmin)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11275,12 +11791,11 @@ func (a *OpenTracingAppLayer) GetUsersInChannelPage(options *model.UserGetOption
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersInChannelPage(options, asAdmin)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11297,12 +11812,11 @@ func (a *OpenTracingAppLayer) GetUsersInChannelPageByAdmin(options *model.UserGe
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersInChannelPageByAdmin(options, asAdmin)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11319,12 +11833,11 @@ func (a *OpenTracingAppLayer) GetUsersInChannelPageByStatus(options *model.UserG
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersInChannelPageByStatus(options, asAdmin)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11341,12 +11854,11 @@ func (a *OpenTracingAppLayer) GetUsersInTeam(options *model.UserGetOptions) ([]*
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersInTeam(options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11363,7 +11875,7 @@ func (a *OpenTracingAppLayer) GetUsersInTeamEtag(teamID string, restrictionsHash
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetUsersInTeamEtag(teamID, restrictionsHash)
 
 	return resultVar0
@@ -11380,12 +11892,11 @@ func (a *OpenTracingAppLayer) GetUsersInTeamPage(options *model.UserGetOptions,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersInTeamPage(options, asAdmin)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11402,12 +11913,11 @@ func (a *OpenTracingAppLayer) GetUsersNotInChannel(teamID string, channelID stri
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersNotInChannel(teamID, channelID, groupConstrained, offset, limit, viewRestrictions)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11424,12 +11934,11 @@ func (a *OpenTracingAppLayer) GetUsersNotInChannelMap(teamID string, channelID s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersNotInChannelMap(teamID, channelID, groupConstrained, offset, limit, asAdmin, viewRestrictions)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11446,12 +11955,11 @@ func (a *OpenTracingAppLayer) GetUsersNotInChannelPage(teamID string, channelID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersNotInChannelPage(teamID, channelID, groupConstrained, page, perPage, asAdmin, viewRestrictions)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11468,12 +11976,11 @@ func (a *OpenTracingAppLayer) GetUsersNotInGroupPage(groupID string, page int, p
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersNotInGroupPage(groupID, page, perPage, viewRestrictions)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11490,12 +11997,11 @@ func (a *OpenTracingAppLayer) GetUsersNotInTeam(teamID string, groupConstrained
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersNotInTeam(teamID, groupConstrained, offset, limit, viewRestrictions)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11512,7 +12018,7 @@ func (a *OpenTracingAppLayer) GetUsersNotInTeamEtag(teamID string, restrictionsH
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.GetUsersNotInTeamEtag(teamID, restrictionsHash)
 
 	return resultVar0
@@ -11529,12 +12035,11 @@ func (a *OpenTracingAppLayer) GetUsersNotInTeamPage(teamID string, groupConstrai
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersNotInTeamPage(teamID, groupConstrained, page, perPage, asAdmin, viewRestrictions)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11551,12 +12056,11 @@ func (a *OpenTracingAppLayer) GetUsersPage(options *model.UserGetOptions, asAdmi
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersPage(options, asAdmin)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11573,12 +12077,11 @@ func (a *OpenTracingAppLayer) GetUsersWithInvalidEmails(page int, perPage int) (
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersWithInvalidEmails(page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11595,12 +12098,11 @@ func (a *OpenTracingAppLayer) GetUsersWithoutTeam(options *model.UserGetOptions)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersWithoutTeam(options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11617,12 +12119,11 @@ func (a *OpenTracingAppLayer) GetUsersWithoutTeamPage(options *model.UserGetOpti
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetUsersWithoutTeamPage(options, asAdmin)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11639,12 +12140,11 @@ func (a *OpenTracingAppLayer) GetVerifyEmailToken(token string) (*model.Token, *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetVerifyEmailToken(token)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11661,12 +12161,53 @@ func (a *OpenTracingAppLayer) GetViewUsersRestrictions(c request.CTX, userID str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.GetViewUsersRestrictions(c, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetWebAuthnCredentials(userID string) ([]*model.WebAuthnCredential, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetWebAuthnCredentials")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetWebAuthnCredentials(userID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) GetWebPushPublicKey() (*model.WebPushPublicKey, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.GetWebPushPublicKey")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.GetWebPushPublicKey()
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11683,12 +12224,11 @@ func (a *OpenTracingAppLayer) HandleCommandResponse(c request.CTX, command *mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.HandleCommandResponse(c, command, args, response, builtIn)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11705,12 +12245,11 @@ func (a *OpenTracingAppLayer) HandleCommandResponsePost(c request.CTX, command *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.HandleCommandResponsePost(c, command, args, response, builtIn)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11727,12 +12266,11 @@ func (a *OpenTracingAppLayer) HandleCommandWebhook(c request.CTX, hookID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.HandleCommandWebhook(c, hookID, response)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -11749,7 +12287,7 @@ func (a *OpenTracingAppLayer) HandleImages(rctx request.CTX, previewPathList []s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.HandleImages(rctx, previewPathList, thumbnailPathList, fileData)
 }
 
@@ -11764,12 +12302,11 @@ func (a *OpenTracingAppLayer) HandleIncomingWebhook(c request.CTX, hookID string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.HandleIncomingWebhook(c, hookID, req)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -11786,7 +12323,7 @@ func (a *OpenTracingAppLayer) HandleMessageExportConfig(cfg *model.Config, appCf
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.HandleMessageExportConfig(cfg, appCfg)
 }
 
@@ -11801,7 +12338,7 @@ func (a *OpenTracingAppLayer) HasPermissionTo(askingUserId string, permission *m
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.HasPermissionTo(askingUserId, permission)
 
 	return resultVar0
@@ -11818,7 +12355,7 @@ func (a *OpenTracingAppLayer) HasPermissionToChannel(c request.CTX, askingUserId
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.HasPermissionToChannel(c, askingUserId, channelID, permission)
 
 	return resultVar0
@@ -11835,7 +12372,7 @@ func (a *OpenTracingAppLayer) HasPermissionToChannelByPost(c request.CTX, asking
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.HasPermissionToChannelByPost(c, askingUserId, postID, permission)
 
 	return resultVar0
@@ -11852,7 +12389,7 @@ func (a *OpenTracingAppLayer) HasPermissionToChannelMemberCount(c request.CTX, u
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.HasPermissionToChannelMemberCount(c, userID, channel)
 
 	return resultVar0
@@ -11869,7 +12406,7 @@ func (a *OpenTracingAppLayer) HasPermissionToReadChannel(c request.CTX, userID s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.HasPermissionToReadChannel(c, userID, channel)
 
 	return resultVar0
@@ -11886,7 +12423,7 @@ func (a *OpenTracingAppLayer) HasPermissionToTeam(c request.CTX, askingUserId st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.HasPermissionToTeam(c, askingUserId, teamID, permission)
 
 	return resultVar0
@@ -11903,7 +12440,7 @@ func (a *OpenTracingAppLayer) HasPermissionToUser(askingUserId string, userID st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.HasPermissionToUser(askingUserId, userID)
 
 	return resultVar0
@@ -11920,12 +12457,11 @@ func (a *OpenTracingAppLayer) HasRemote(channelID string, remoteID string) (bool
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.HasRemote(channelID, remoteID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11942,12 +12478,11 @@ func (a *OpenTracingAppLayer) HasSharedChannel(channelID string) (bool, error) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.HasSharedChannel(channelID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -11964,7 +12499,7 @@ func (a *OpenTracingAppLayer) HubRegister(webConn *platform.WebConn) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.HubRegister(webConn)
 }
 
@@ -11979,7 +12514,7 @@ func (a *OpenTracingAppLayer) HubUnregister(webConn *platform.WebConn) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.HubUnregister(webConn)
 }
 
@@ -11994,7 +12529,7 @@ func (a *OpenTracingAppLayer) IPFiltering() einterfaces.IPFilteringInterface {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.IPFiltering()
 
 	return resultVar0
@@ -12011,7 +12546,7 @@ func (a *OpenTracingAppLayer) ImageProxyAdder() func(string) string {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ImageProxyAdder()
 
 	return resultVar0
@@ -12028,7 +12563,7 @@ func (a *OpenTracingAppLayer) ImageProxyRemover() (f func(string) string) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ImageProxyRemover()
 
 	return resultVar0
@@ -12045,12 +12580,11 @@ func (a *OpenTracingAppLayer) ImportPermissions(jsonl io.Reader) error {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ImportPermissions(jsonl)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -12067,7 +12601,7 @@ func (a *OpenTracingAppLayer) InitPlugins(c request.CTX, pluginDir string, webap
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.InitPlugins(c, pluginDir, webappPluginDir)
 }
 
@@ -12082,12 +12616,11 @@ func (a *OpenTracingAppLayer) InstallPlugin(pluginFile io.ReadSeeker, replace bo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.InstallPlugin(pluginFile, replace)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12104,12 +12637,11 @@ func (a *OpenTracingAppLayer) InvalidateAllEmailInvites(c request.CTX) *model.Ap
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.InvalidateAllEmailInvites(c)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -12126,12 +12658,11 @@ func (a *OpenTracingAppLayer) InvalidateAllResendInviteEmailJobs(c request.CTX)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.InvalidateAllResendInviteEmailJobs(c)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -12148,7 +12679,7 @@ func (a *OpenTracingAppLayer) InvalidateCacheForUser(userID string) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.InvalidateCacheForUser(userID)
 }
 
@@ -12163,12 +12694,11 @@ func (a *OpenTracingAppLayer) InvalidatePasswordRecoveryTokensForUser(userID str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.InvalidatePasswordRecoveryTokensForUser(userID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -12185,12 +12715,11 @@ func (a *OpenTracingAppLayer) InviteGuestsToChannels(rctx request.CTX, teamID st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.InviteGuestsToChannels(rctx, teamID, guestsInvite, senderId)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -12207,12 +12736,11 @@ func (a *OpenTracingAppLayer) InviteGuestsToChannelsGracefully(rctx request.CTX,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.InviteGuestsToChannelsGracefully(rctx, teamID, guestsInvite, senderId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12229,12 +12757,11 @@ func (a *OpenTracingAppLayer) InviteNewUsersToTeam(rctx request.CTX, emailList [
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.InviteNewUsersToTeam(rctx, emailList, teamID, senderId)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -12251,12 +12778,11 @@ func (a *OpenTracingAppLayer) InviteNewUsersToTeamGracefully(rctx request.CTX, m
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.InviteNewUsersToTeamGracefully(rctx, memberInvite, teamID, senderId, reminderInterval)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12273,12 +12799,11 @@ func (a *OpenTracingAppLayer) InviteRemoteToChannel(channelID string, remoteID s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.InviteRemoteToChannel(channelID, remoteID, userID, shareIfNotShared)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -12295,7 +12820,7 @@ func (a *OpenTracingAppLayer) IsCRTEnabledForUser(c request.CTX, userID string)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.IsCRTEnabledForUser(c, userID)
 
 	return resultVar0
@@ -12312,7 +12837,7 @@ func (a *OpenTracingAppLayer) IsConfigReadOnly() bool {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.IsConfigReadOnly()
 
 	return resultVar0
@@ -12329,7 +12854,7 @@ func (a *OpenTracingAppLayer) IsFirstUserAccount() bool {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.IsFirstUserAccount()
 
 	return resultVar0
@@ -12346,7 +12871,7 @@ func (a *OpenTracingAppLayer) IsLeader() bool {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.IsLeader()
 
 	return resultVar0
@@ -12363,12 +12888,11 @@ func (a *OpenTracingAppLayer) IsPasswordValid(rctx request.CTX, password string)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.IsPasswordValid(rctx, password)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -12385,7 +12909,7 @@ func (a *OpenTracingAppLayer) IsPersistentNotificationsEnabled() bool {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.IsPersistentNotificationsEnabled()
 
 	return resultVar0
@@ -12402,12 +12926,11 @@ func (a *OpenTracingAppLayer) IsPhase2MigrationCompleted() *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.IsPhase2MigrationCompleted()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -12424,12 +12947,11 @@ func (a *OpenTracingAppLayer) IsPluginActive(pluginName string) (bool, error) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.IsPluginActive(pluginName)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12446,12 +12968,29 @@ func (a *OpenTracingAppLayer) IsPostPriorityEnabled() bool {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.IsPostPriorityEnabled()
 
 	return resultVar0
 }
 
+func (a *OpenTracingAppLayer) IsScheduledPostsEnabled() bool {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.IsScheduledPostsEnabled")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0 := a.app.IsScheduledPostsEnabled()
+
+	return resultVar0
+}
+
 func (a *OpenTracingAppLayer) IsUserSignUpAllowed() *model.AppError {
 	origCtx := a.ctx
 	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.IsUserSignUpAllowed")
@@ -12463,12 +13002,11 @@ func (a *OpenTracingAppLayer) IsUserSignUpAllowed() *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.IsUserSignUpAllowed()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -12485,12 +13023,11 @@ func (a *OpenTracingAppLayer) JoinChannel(c request.CTX, channel *model.Channel,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.JoinChannel(c, channel, userID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -12507,12 +13044,11 @@ func (a *OpenTracingAppLayer) JoinDefaultChannels(c request.CTX, teamID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.JoinDefaultChannels(c, teamID, user, shouldBeAdmin, userRequestorId)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -12529,12 +13065,11 @@ func (a *OpenTracingAppLayer) JoinUserToTeam(c request.CTX, team *model.Team, us
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.JoinUserToTeam(c, team, user, userRequestorId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12551,12 +13086,11 @@ func (a *OpenTracingAppLayer) LeaveChannel(c request.CTX, channelID string, user
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.LeaveChannel(c, channelID, userID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -12573,12 +13107,11 @@ func (a *OpenTracingAppLayer) LeaveTeam(c request.CTX, team *model.Team, user *m
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.LeaveTeam(c, team, user, requestorId)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -12595,7 +13128,7 @@ func (a *OpenTracingAppLayer) License() *model.License {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.License()
 
 	return resultVar0
@@ -12612,7 +13145,7 @@ func (a *OpenTracingAppLayer) LimitedClientConfig() map[string]string {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.LimitedClientConfig()
 
 	return resultVar0
@@ -12629,12 +13162,11 @@ func (a *OpenTracingAppLayer) ListAllCommands(teamID string, T i18n.TranslateFun
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ListAllCommands(teamID, T)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12651,14 +13183,13 @@ func (a *OpenTracingAppLayer) ListAutocompleteCommands(teamID string, T i18n.Tra
 		a.ctx = origCtx
 	}()
 
-	span.SetTag("teamID", teamID)
+	tracing.SetAttribute(span, "teamID", teamID)
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ListAutocompleteCommands(teamID, T)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12675,12 +13206,11 @@ func (a *OpenTracingAppLayer) ListDirectory(path string) ([]string, *model.AppEr
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ListDirectory(path)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12697,12 +13227,11 @@ func (a *OpenTracingAppLayer) ListDirectoryRecursively(path string) ([]string, *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ListDirectoryRecursively(path)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12719,12 +13248,11 @@ func (a *OpenTracingAppLayer) ListExportDirectory(path string) ([]string, *model
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ListExportDirectory(path)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12741,12 +13269,11 @@ func (a *OpenTracingAppLayer) ListExports() ([]string, *model.AppError) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ListExports()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12763,12 +13290,11 @@ func (a *OpenTracingAppLayer) ListImports() ([]string, *model.AppError) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ListImports()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12785,12 +13311,11 @@ func (a *OpenTracingAppLayer) ListPluginKeys(pluginID string, page int, perPage
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ListPluginKeys(pluginID, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12807,12 +13332,11 @@ func (a *OpenTracingAppLayer) ListTeamCommands(teamID string) ([]*model.Command,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ListTeamCommands(teamID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12829,7 +13353,7 @@ func (a *OpenTracingAppLayer) LogAuditRec(rctx request.CTX, rec *audit.Record, e
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.LogAuditRec(rctx, rec, err)
 }
 
@@ -12844,7 +13368,7 @@ func (a *OpenTracingAppLayer) LogAuditRecWithLevel(rctx request.CTX, rec *audit.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.LogAuditRecWithLevel(rctx, rec, level, err)
 }
 
@@ -12859,12 +13383,11 @@ func (a *OpenTracingAppLayer) LoginByOAuth(c request.CTX, service string, userDa
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.LoginByOAuth(c, service, userData, teamID, tokenUser)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12881,7 +13404,7 @@ func (a *OpenTracingAppLayer) MakeAuditRecord(rctx request.CTX, event string, in
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.MakeAuditRecord(rctx, event, initialStatus)
 
 	return resultVar0
@@ -12898,12 +13421,11 @@ func (a *OpenTracingAppLayer) MarkChannelAsUnreadFromPost(c request.CTX, postID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.MarkChannelAsUnreadFromPost(c, postID, userID, collapsedThreadsSupported)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12920,12 +13442,11 @@ func (a *OpenTracingAppLayer) MarkChannelsAsViewed(c request.CTX, channelIDs []s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.MarkChannelsAsViewed(c, channelIDs, userID, currentSessionId, collapsedThreadsSupported, isCRTEnabled)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -12942,7 +13463,7 @@ func (a *OpenTracingAppLayer) MaxPostSize() int {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.MaxPostSize()
 
 	return resultVar0
@@ -12959,7 +13480,7 @@ func (a *OpenTracingAppLayer) MentionsToPublicChannels(c request.CTX, message st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.MentionsToPublicChannels(c, message, teamID)
 
 	return resultVar0
@@ -12976,7 +13497,7 @@ func (a *OpenTracingAppLayer) MentionsToTeamMembers(c request.CTX, message strin
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.MentionsToTeamMembers(c, message, teamID)
 
 	return resultVar0
@@ -12993,7 +13514,7 @@ func (a *OpenTracingAppLayer) MigrateFilenamesToFileInfos(rctx request.CTX, post
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.MigrateFilenamesToFileInfos(rctx, post)
 
 	return resultVar0
@@ -13010,12 +13531,11 @@ func (a *OpenTracingAppLayer) MigrateIdLDAP(c request.CTX, toAttribute string) *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.MigrateIdLDAP(c, toAttribute)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13032,12 +13552,11 @@ func (a *OpenTracingAppLayer) MoveChannel(c request.CTX, team *model.Team, chann
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.MoveChannel(c, team, channel, user)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13054,12 +13573,11 @@ func (a *OpenTracingAppLayer) MoveCommand(team *model.Team, command *model.Comma
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.MoveCommand(team, command)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13076,12 +13594,11 @@ func (a *OpenTracingAppLayer) MoveFile(oldPath string, newPath string) *model.Ap
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.MoveFile(oldPath, newPath)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13098,12 +13615,11 @@ func (a *OpenTracingAppLayer) MoveThread(c request.CTX, postID string, sourceCha
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.MoveThread(c, postID, sourceChannelID, channelID, user)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13120,7 +13636,7 @@ func (a *OpenTracingAppLayer) NewPluginAPI(c request.CTX, manifest *model.Manife
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.NewPluginAPI(c, manifest)
 
 	return resultVar0
@@ -13137,7 +13653,7 @@ func (a *OpenTracingAppLayer) NotifySelfHostedSignupProgress(progress string, us
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.NotifySelfHostedSignupProgress(progress, userId)
 }
 
@@ -13152,12 +13668,11 @@ func (a *OpenTracingAppLayer) NotifySessionsExpired() error {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.NotifySessionsExpired()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13174,7 +13689,7 @@ func (a *OpenTracingAppLayer) NotifySharedChannelUserUpdate(user *model.User) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.NotifySharedChannelUserUpdate(user)
 }
 
@@ -13189,12 +13704,11 @@ func (a *OpenTracingAppLayer) OnSharedChannelsAttachmentSyncMsg(fi *model.FileIn
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.OnSharedChannelsAttachmentSyncMsg(fi, post, rc)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13211,7 +13725,7 @@ func (a *OpenTracingAppLayer) OnSharedChannelsPing(rc *model.RemoteCluster) bool
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.OnSharedChannelsPing(rc)
 
 	return resultVar0
@@ -13228,12 +13742,11 @@ func (a *OpenTracingAppLayer) OnSharedChannelsProfileImageSyncMsg(user *model.Us
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.OnSharedChannelsProfileImageSyncMsg(user, rc)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13250,12 +13763,11 @@ func (a *OpenTracingAppLayer) OnSharedChannelsSyncMsg(msg *model.SyncMsg, rc *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.OnSharedChannelsSyncMsg(msg, rc)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -13272,12 +13784,11 @@ func (a *OpenTracingAppLayer) OpenInteractiveDialog(c request.CTX, request model
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.OpenInteractiveDialog(c, request)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13294,7 +13805,7 @@ func (a *OpenTracingAppLayer) OriginChecker() func(*http.Request) bool {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.OriginChecker()
 
 	return resultVar0
@@ -13311,7 +13822,7 @@ func (a *OpenTracingAppLayer) OutgoingOAuthConnections() einterfaces.OutgoingOAu
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.OutgoingOAuthConnections()
 
 	return resultVar0
@@ -13328,7 +13839,7 @@ func (a *OpenTracingAppLayer) OverrideIconURLIfEmoji(c request.CTX, post *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.OverrideIconURLIfEmoji(c, post)
 }
 
@@ -13343,12 +13854,11 @@ func (a *OpenTracingAppLayer) PatchBot(rctx request.CTX, botUserId string, botPa
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.PatchBot(rctx, botUserId, botPatch)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -13365,12 +13875,11 @@ func (a *OpenTracingAppLayer) PatchChannel(c request.CTX, channel *model.Channel
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.PatchChannel(c, channel, patch, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -13387,12 +13896,11 @@ func (a *OpenTracingAppLayer) PatchChannelMembersNotifyProps(c request.CTX, memb
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.PatchChannelMembersNotifyProps(c, members, notifyProps)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -13409,12 +13917,32 @@ func (a *OpenTracingAppLayer) PatchChannelModerationsForChannel(c request.CTX, c
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.PatchChannelModerationsForChannel(c, channel, channelModerationsPatch)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) PatchLegalHold(rctx request.CTX, holdID string, patch *model.LegalHoldPatch) (*model.LegalHold, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.PatchLegalHold")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.PatchLegalHold(rctx, holdID, patch)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -13431,12 +13959,11 @@ func (a *OpenTracingAppLayer) PatchPost(c request.CTX, postID string, patch *mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.PatchPost(c, postID, patch)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -13453,12 +13980,11 @@ func (a *OpenTracingAppLayer) PatchRemoteCluster(rcId string, patch *model.Remot
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.PatchRemoteCluster(rcId, patch)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -13475,12 +14001,11 @@ func (a *OpenTracingAppLayer) PatchRetentionPolicy(patch *model.RetentionPolicyW
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.PatchRetentionPolicy(patch)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -13497,12 +14022,32 @@ func (a *OpenTracingAppLayer) PatchRole(role *model.Role, patch *model.RolePatch
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.PatchRole(role, patch)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) PatchSavedSearch(rctx request.CTX, userID string, savedSearchID string, patch *model.SavedSearchPatch) (*model.SavedSearch, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.PatchSavedSearch")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.PatchSavedSearch(rctx, userID, savedSearchID, patch)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -13519,12 +14064,53 @@ func (a *OpenTracingAppLayer) PatchScheme(scheme *model.Scheme, patch *model.Sch
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.PatchScheme(scheme, patch)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) PatchScimGroup(rctx request.CTX, groupID string, operations []model.ScimPatchOperation) (*model.ScimGroup, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.PatchScimGroup")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.PatchScimGroup(rctx, groupID, operations)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) PatchScimUser(rctx request.CTX, userID string, operations []model.ScimPatchOperation) (*model.ScimUser, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.PatchScimUser")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.PatchScimUser(rctx, userID, operations)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -13541,12 +14127,11 @@ func (a *OpenTracingAppLayer) PatchTeam(teamID string, patch *model.TeamPatch) (
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.PatchTeam(teamID, patch)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -13563,12 +14148,11 @@ func (a *OpenTracingAppLayer) PatchUser(c request.CTX, userID string, patch *mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.PatchUser(c, userID, patch, asAdmin)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -13585,12 +14169,11 @@ func (a *OpenTracingAppLayer) PermanentDeleteAllUsers(c request.CTX) *model.AppE
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PermanentDeleteAllUsers(c)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13607,12 +14190,11 @@ func (a *OpenTracingAppLayer) PermanentDeleteBot(rctx request.CTX, botUserId str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PermanentDeleteBot(rctx, botUserId)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13629,12 +14211,11 @@ func (a *OpenTracingAppLayer) PermanentDeleteChannel(c request.CTX, channel *mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PermanentDeleteChannel(c, channel)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13651,12 +14232,11 @@ func (a *OpenTracingAppLayer) PermanentDeleteFilesByPost(rctx request.CTX, postI
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PermanentDeleteFilesByPost(rctx, postID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13673,12 +14253,11 @@ func (a *OpenTracingAppLayer) PermanentDeletePost(rctx request.CTX, postID strin
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PermanentDeletePost(rctx, postID, deleteByID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13695,12 +14274,11 @@ func (a *OpenTracingAppLayer) PermanentDeleteTeam(c request.CTX, team *model.Tea
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PermanentDeleteTeam(c, team)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13717,12 +14295,11 @@ func (a *OpenTracingAppLayer) PermanentDeleteTeamId(c request.CTX, teamID string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PermanentDeleteTeamId(c, teamID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13739,12 +14316,11 @@ func (a *OpenTracingAppLayer) PermanentDeleteUser(rctx request.CTX, user *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PermanentDeleteUser(rctx, user)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13761,12 +14337,11 @@ func (a *OpenTracingAppLayer) PopulateWebConnConfig(s *model.Session, cfg *platf
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.PopulateWebConnConfig(s, cfg, seqVal)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -13783,7 +14358,7 @@ func (a *OpenTracingAppLayer) PostActionCookieSecret() []byte {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PostActionCookieSecret()
 
 	return resultVar0
@@ -13800,12 +14375,11 @@ func (a *OpenTracingAppLayer) PostAddToChannelMessage(c request.CTX, user *model
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PostAddToChannelMessage(c, user, addedUser, channel, postRootId)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13822,7 +14396,7 @@ func (a *OpenTracingAppLayer) PostPatchWithProxyRemovedFromImageURLs(patch *mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PostPatchWithProxyRemovedFromImageURLs(patch)
 
 	return resultVar0
@@ -13839,12 +14413,11 @@ func (a *OpenTracingAppLayer) PostUpdateChannelDisplayNameMessage(c request.CTX,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PostUpdateChannelDisplayNameMessage(c, userID, channel, oldChannelDisplayName, newChannelDisplayName)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13861,12 +14434,11 @@ func (a *OpenTracingAppLayer) PostUpdateChannelHeaderMessage(c request.CTX, user
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PostUpdateChannelHeaderMessage(c, userID, channel, oldChannelHeader, newChannelHeader)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13883,12 +14455,11 @@ func (a *OpenTracingAppLayer) PostUpdateChannelPurposeMessage(c request.CTX, use
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PostUpdateChannelPurposeMessage(c, userID, channel, oldChannelPurpose, newChannelPurpose)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -13905,7 +14476,7 @@ func (a *OpenTracingAppLayer) PostWithProxyAddedToImageURLs(post *model.Post) *m
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PostWithProxyAddedToImageURLs(post)
 
 	return resultVar0
@@ -13922,7 +14493,7 @@ func (a *OpenTracingAppLayer) PostWithProxyRemovedFromImageURLs(post *model.Post
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PostWithProxyRemovedFromImageURLs(post)
 
 	return resultVar0
@@ -13939,7 +14510,7 @@ func (a *OpenTracingAppLayer) PreparePostForClient(c request.CTX, originalPost *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PreparePostForClient(c, originalPost, isNewPost, isEditPost, includePriority)
 
 	return resultVar0
@@ -13956,7 +14527,7 @@ func (a *OpenTracingAppLayer) PreparePostForClientWithEmbedsAndImages(c request.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PreparePostForClientWithEmbedsAndImages(c, originalPost, isNewPost, isEditPost, includePriority)
 
 	return resultVar0
@@ -13973,12 +14544,96 @@ func (a *OpenTracingAppLayer) PreparePostListForClient(c request.CTX, originalLi
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PreparePostListForClient(c, originalList)
 
 	return resultVar0
 }
 
+func (a *OpenTracingAppLayer) ProcessOutgoingWebhookDeliveries(rctx request.CTX) error {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.ProcessOutgoingWebhookDeliveries")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0 := a.app.ProcessOutgoingWebhookDeliveries(rctx)
+
+	if resultVar0 != nil {
+		tracing.RecordError(span, resultVar0)
+	}
+
+	return resultVar0
+}
+
+func (a *OpenTracingAppLayer) ProcessReminders(rctx request.CTX) error {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.ProcessReminders")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0 := a.app.ProcessReminders(rctx)
+
+	if resultVar0 != nil {
+		tracing.RecordError(span, resultVar0)
+	}
+
+	return resultVar0
+}
+
+func (a *OpenTracingAppLayer) ProcessSavedSearchAlerts(rctx request.CTX) error {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.ProcessSavedSearchAlerts")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0 := a.app.ProcessSavedSearchAlerts(rctx)
+
+	if resultVar0 != nil {
+		tracing.RecordError(span, resultVar0)
+	}
+
+	return resultVar0
+}
+
+func (a *OpenTracingAppLayer) ProcessScheduledPosts(rctx request.CTX) error {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.ProcessScheduledPosts")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0 := a.app.ProcessScheduledPosts(rctx)
+
+	if resultVar0 != nil {
+		tracing.RecordError(span, resultVar0)
+	}
+
+	return resultVar0
+}
+
 func (a *OpenTracingAppLayer) ProcessSlackAttachments(attachments []*model.SlackAttachment) []*model.SlackAttachment {
 	origCtx := a.ctx
 	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.ProcessSlackAttachments")
@@ -13990,7 +14645,7 @@ func (a *OpenTracingAppLayer) ProcessSlackAttachments(attachments []*model.Slack
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ProcessSlackAttachments(attachments)
 
 	return resultVar0
@@ -14007,7 +14662,7 @@ func (a *OpenTracingAppLayer) ProcessSlackText(text string) string {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ProcessSlackText(text)
 
 	return resultVar0
@@ -14024,12 +14679,11 @@ func (a *OpenTracingAppLayer) PromoteGuestToUser(c request.CTX, user *model.User
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PromoteGuestToUser(c, user, requestorId)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14046,7 +14700,7 @@ func (a *OpenTracingAppLayer) Publish(message *model.WebSocketEvent) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.Publish(message)
 }
 
@@ -14061,17 +14715,31 @@ func (a *OpenTracingAppLayer) PublishUserTyping(userID string, channelID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PublishUserTyping(userID, channelID, parentId)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
 }
 
+func (a *OpenTracingAppLayer) PublishWithContext(rctx request.CTX, message *model.WebSocketEvent) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.PublishWithContext")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	a.app.PublishWithContext(rctx, message)
+}
+
 func (a *OpenTracingAppLayer) PurgeBleveIndexes(c request.CTX) *model.AppError {
 	origCtx := a.ctx
 	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.PurgeBleveIndexes")
@@ -14083,12 +14751,11 @@ func (a *OpenTracingAppLayer) PurgeBleveIndexes(c request.CTX) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PurgeBleveIndexes(c)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14105,12 +14772,11 @@ func (a *OpenTracingAppLayer) PurgeElasticsearchIndexes(c request.CTX, indexes [
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.PurgeElasticsearchIndexes(c, indexes)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14127,12 +14793,11 @@ func (a *OpenTracingAppLayer) QueryLogs(rctx request.CTX, page int, perPage int,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.QueryLogs(rctx, page, perPage, logFilter)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -14149,12 +14814,11 @@ func (a *OpenTracingAppLayer) ReadFile(path string) ([]byte, *model.AppError) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ReadFile(path)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -14171,12 +14835,32 @@ func (a *OpenTracingAppLayer) ReattachPlugin(manifest *model.Manifest, pluginRea
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ReattachPlugin(manifest, pluginReattachConfig)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
+	}
+
+	return resultVar0
+}
+
+func (a *OpenTracingAppLayer) ReceiveReplyByEmail(rctx request.CTX, sender string, recipient string, data []byte) error {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.ReceiveReplyByEmail")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0 := a.app.ReceiveReplyByEmail(rctx, sender, recipient, data)
+
+	if resultVar0 != nil {
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14193,7 +14877,7 @@ func (a *OpenTracingAppLayer) RecycleDatabaseConnection(rctx request.CTX) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.RecycleDatabaseConnection(rctx)
 }
 
@@ -14208,12 +14892,11 @@ func (a *OpenTracingAppLayer) RegenCommandToken(cmd *model.Command) (*model.Comm
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.RegenCommandToken(cmd)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -14230,12 +14913,11 @@ func (a *OpenTracingAppLayer) RegenOutgoingWebhookToken(hook *model.OutgoingWebh
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.RegenOutgoingWebhookToken(hook)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -14252,12 +14934,11 @@ func (a *OpenTracingAppLayer) RegenerateOAuthAppSecret(app *model.OAuthApp) (*mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.RegenerateOAuthAppSecret(app)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -14274,12 +14955,11 @@ func (a *OpenTracingAppLayer) RegenerateTeamInviteId(teamID string) (*model.Team
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.RegenerateTeamInviteId(teamID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -14296,12 +14976,11 @@ func (a *OpenTracingAppLayer) RegisterPerformanceReport(rctx request.CTX, report
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RegisterPerformanceReport(rctx, report)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14318,12 +14997,11 @@ func (a *OpenTracingAppLayer) RegisterPluginCommand(pluginID string, command *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RegisterPluginCommand(pluginID, command)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14340,12 +15018,32 @@ func (a *OpenTracingAppLayer) RegisterPluginForSharedChannels(rctx request.CTX,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.RegisterPluginForSharedChannels(rctx, opts)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) ReleaseLegalHold(holdID string) (*model.LegalHold, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.ReleaseLegalHold")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.ReleaseLegalHold(holdID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -14362,12 +15060,11 @@ func (a *OpenTracingAppLayer) ReloadConfig() error {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ReloadConfig()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14384,12 +15081,11 @@ func (a *OpenTracingAppLayer) RemoveAllDeactivatedMembersFromChannel(c request.C
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveAllDeactivatedMembersFromChannel(c, channel)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14406,17 +15102,37 @@ func (a *OpenTracingAppLayer) RemoveChannelsFromRetentionPolicy(policyID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveChannelsFromRetentionPolicy(policyID, channelIDs)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
 }
 
+func (a *OpenTracingAppLayer) RemoveCommandSigningSecret(cmd *model.Command) (*model.Command, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.RemoveCommandSigningSecret")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.RemoveCommandSigningSecret(cmd)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
 func (a *OpenTracingAppLayer) RemoveConfigListener(id string) {
 	origCtx := a.ctx
 	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.RemoveConfigListener")
@@ -14428,7 +15144,7 @@ func (a *OpenTracingAppLayer) RemoveConfigListener(id string) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.RemoveConfigListener(id)
 }
 
@@ -14443,12 +15159,11 @@ func (a *OpenTracingAppLayer) RemoveCustomStatus(c request.CTX, userID string) *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveCustomStatus(c, userID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14465,12 +15180,11 @@ func (a *OpenTracingAppLayer) RemoveDirectory(path string) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveDirectory(path)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14487,12 +15201,11 @@ func (a *OpenTracingAppLayer) RemoveExportFile(path string) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveExportFile(path)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14509,12 +15222,11 @@ func (a *OpenTracingAppLayer) RemoveFile(path string) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveFile(path)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14531,7 +15243,7 @@ func (a *OpenTracingAppLayer) RemoveFileFromFileStore(rctx request.CTX, path str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.RemoveFileFromFileStore(rctx, path)
 }
 
@@ -14546,7 +15258,7 @@ func (a *OpenTracingAppLayer) RemoveFilesFromFileStore(rctx request.CTX, fileInf
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.RemoveFilesFromFileStore(rctx, fileInfos)
 }
 
@@ -14561,12 +15273,11 @@ func (a *OpenTracingAppLayer) RemoveLdapPrivateCertificate() *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveLdapPrivateCertificate()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14583,12 +15294,11 @@ func (a *OpenTracingAppLayer) RemoveLdapPublicCertificate() *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveLdapPublicCertificate()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14605,17 +15315,37 @@ func (a *OpenTracingAppLayer) RemoveNotifications(c request.CTX, post *model.Pos
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveNotifications(c, post, channel)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
 }
 
+func (a *OpenTracingAppLayer) RemoveOutgoingWebhookSigningSecret(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.RemoveOutgoingWebhookSigningSecret")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.RemoveOutgoingWebhookSigningSecret(hook)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
 func (a *OpenTracingAppLayer) RemoveRecentCustomStatus(c request.CTX, userID string, status *model.CustomStatus) *model.AppError {
 	origCtx := a.ctx
 	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.RemoveRecentCustomStatus")
@@ -14627,12 +15357,11 @@ func (a *OpenTracingAppLayer) RemoveRecentCustomStatus(c request.CTX, userID str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveRecentCustomStatus(c, userID, status)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14649,12 +15378,11 @@ func (a *OpenTracingAppLayer) RemoveSamlIdpCertificate() *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveSamlIdpCertificate()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14671,12 +15399,11 @@ func (a *OpenTracingAppLayer) RemoveSamlPrivateCertificate() *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveSamlPrivateCertificate()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14693,12 +15420,11 @@ func (a *OpenTracingAppLayer) RemoveSamlPublicCertificate() *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveSamlPublicCertificate()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14715,12 +15441,11 @@ func (a *OpenTracingAppLayer) RemoveTeamIcon(teamID string) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveTeamIcon(teamID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14737,12 +15462,11 @@ func (a *OpenTracingAppLayer) RemoveTeamsFromRetentionPolicy(policyID string, te
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveTeamsFromRetentionPolicy(policyID, teamIDs)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14759,12 +15483,11 @@ func (a *OpenTracingAppLayer) RemoveUserFromChannel(c request.CTX, userIDToRemov
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveUserFromChannel(c, userIDToRemove, removerUserId, channel)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14781,12 +15504,11 @@ func (a *OpenTracingAppLayer) RemoveUserFromTeam(c request.CTX, teamID string, u
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveUserFromTeam(c, teamID, userID, requestorId)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14803,12 +15525,11 @@ func (a *OpenTracingAppLayer) RemoveUsersFromChannelNotMemberOfTeam(c request.CT
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RemoveUsersFromChannelNotMemberOfTeam(c, remover, channel, team)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14825,12 +15546,11 @@ func (a *OpenTracingAppLayer) RenameChannel(c request.CTX, channel *model.Channe
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.RenameChannel(c, channel, newChannelName, newDisplayName)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -14847,12 +15567,32 @@ func (a *OpenTracingAppLayer) RenameTeam(team *model.Team, newTeamName string, n
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.RenameTeam(team, newTeamName, newDisplayName)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) ReplayOutgoingWebhookDelivery(c request.CTX, delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.ReplayOutgoingWebhookDelivery")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.ReplayOutgoingWebhookDelivery(c, delivery)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -14869,12 +15609,11 @@ func (a *OpenTracingAppLayer) ResetPasswordFromToken(c request.CTX, userSupplied
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ResetPasswordFromToken(c, userSuppliedTokenString, newPassword)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14891,12 +15630,11 @@ func (a *OpenTracingAppLayer) ResetPermissionsSystem() *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ResetPermissionsSystem()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14913,12 +15651,11 @@ func (a *OpenTracingAppLayer) ResetSamlAuthDataToEmail(includeDeleted bool, dryR
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ResetSamlAuthDataToEmail(includeDeleted, dryRun, userIDs)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -14935,12 +15672,11 @@ func (a *OpenTracingAppLayer) ResolvePersistentNotification(c request.CTX, post
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ResolvePersistentNotification(c, post, loggedInUserID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -14957,12 +15693,11 @@ func (a *OpenTracingAppLayer) RestoreChannel(c request.CTX, channel *model.Chann
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.RestoreChannel(c, channel, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -14979,12 +15714,11 @@ func (a *OpenTracingAppLayer) RestoreGroup(groupID string) (*model.Group, *model
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.RestoreGroup(groupID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15001,12 +15735,11 @@ func (a *OpenTracingAppLayer) RestoreTeam(teamID string) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RestoreTeam(teamID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -15023,12 +15756,11 @@ func (a *OpenTracingAppLayer) RestrictUsersGetByPermissions(c request.CTX, userI
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.RestrictUsersGetByPermissions(c, userID, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15045,12 +15777,11 @@ func (a *OpenTracingAppLayer) RestrictUsersSearchByPermissions(c request.CTX, us
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.RestrictUsersSearchByPermissions(c, userID, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15067,12 +15798,11 @@ func (a *OpenTracingAppLayer) RevokeAccessToken(c request.CTX, token string) *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RevokeAccessToken(c, token)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -15089,12 +15819,11 @@ func (a *OpenTracingAppLayer) RevokeAllSessions(c request.CTX, userID string) *m
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RevokeAllSessions(c, userID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -15111,12 +15840,11 @@ func (a *OpenTracingAppLayer) RevokeSession(c request.CTX, session *model.Sessio
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RevokeSession(c, session)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -15133,12 +15861,11 @@ func (a *OpenTracingAppLayer) RevokeSessionById(c request.CTX, sessionID string)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RevokeSessionById(c, sessionID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -15155,12 +15882,11 @@ func (a *OpenTracingAppLayer) RevokeSessionsForDeviceId(c request.CTX, userID st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RevokeSessionsForDeviceId(c, userID, deviceID, currentSessionId)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -15177,12 +15903,11 @@ func (a *OpenTracingAppLayer) RevokeSessionsFromAllUsers() *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RevokeSessionsFromAllUsers()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -15199,12 +15924,11 @@ func (a *OpenTracingAppLayer) RevokeUserAccessToken(c request.CTX, token *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RevokeUserAccessToken(c, token)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -15221,12 +15945,75 @@ func (a *OpenTracingAppLayer) RolesGrantPermission(roleNames []string, permissio
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.RolesGrantPermission(roleNames, permissionId)
 
 	return resultVar0
 }
 
+func (a *OpenTracingAppLayer) RotateCommandSigningSecret(cmd *model.Command) (*model.Command, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.RotateCommandSigningSecret")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.RotateCommandSigningSecret(cmd)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) RotateOutgoingWebhookSigningSecret(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.RotateOutgoingWebhookSigningSecret")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.RotateOutgoingWebhookSigningSecret(hook)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) RunSavedSearch(rctx request.CTX, savedSearch *model.SavedSearch, page int, perPage int) (*model.PostSearchResults, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.RunSavedSearch")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.RunSavedSearch(rctx, savedSearch, page, perPage)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
 func (a *OpenTracingAppLayer) SanitizePostListMetadataForUser(c request.CTX, postList *model.PostList, userID string) (*model.PostList, *model.AppError) {
 	origCtx := a.ctx
 	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.SanitizePostListMetadataForUser")
@@ -15238,12 +16025,11 @@ func (a *OpenTracingAppLayer) SanitizePostListMetadataForUser(c request.CTX, pos
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SanitizePostListMetadataForUser(c, postList, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15260,12 +16046,11 @@ func (a *OpenTracingAppLayer) SanitizePostMetadataForUser(c request.CTX, post *m
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SanitizePostMetadataForUser(c, post, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15282,7 +16067,7 @@ func (a *OpenTracingAppLayer) SanitizeProfile(user *model.User, asAdmin bool) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.SanitizeProfile(user, asAdmin)
 }
 
@@ -15297,7 +16082,7 @@ func (a *OpenTracingAppLayer) SanitizeTeam(session model.Session, team *model.Te
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SanitizeTeam(session, team)
 
 	return resultVar0
@@ -15314,7 +16099,7 @@ func (a *OpenTracingAppLayer) SanitizeTeams(session model.Session, teams []*mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SanitizeTeams(session, teams)
 
 	return resultVar0
@@ -15331,7 +16116,7 @@ func (a *OpenTracingAppLayer) SanitizedConfig(cfg *model.Config) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.SanitizedConfig(cfg)
 }
 
@@ -15346,12 +16131,11 @@ func (a *OpenTracingAppLayer) SaveAcknowledgementForPost(c request.CTX, postID s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SaveAcknowledgementForPost(c, postID, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15368,12 +16152,11 @@ func (a *OpenTracingAppLayer) SaveAdminNotification(userId string, notifyData *m
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SaveAdminNotification(userId, notifyData)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -15390,12 +16173,11 @@ func (a *OpenTracingAppLayer) SaveAdminNotifyData(data *model.NotifyAdminData) (
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SaveAdminNotifyData(data)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15412,7 +16194,7 @@ func (a *OpenTracingAppLayer) SaveAndBroadcastStatus(status *model.Status) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.SaveAndBroadcastStatus(status)
 }
 
@@ -15427,12 +16209,11 @@ func (a *OpenTracingAppLayer) SaveBrandImage(rctx request.CTX, imageData *multip
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SaveBrandImage(rctx, imageData)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -15449,12 +16230,11 @@ func (a *OpenTracingAppLayer) SaveComplianceReport(rctx request.CTX, job *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SaveComplianceReport(rctx, job)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15471,12 +16251,32 @@ func (a *OpenTracingAppLayer) SaveConfig(newCfg *model.Config, sendConfigChangeC
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.SaveConfig(newCfg, sendConfigChangeClusterMessage)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
+	}
+
+	return resultVar0, resultVar1, resultVar2
+}
+
+func (a *OpenTracingAppLayer) SaveConfigWithAuthor(newCfg *model.Config, sendConfigChangeClusterMessage bool, author *model.ConfigChangeAuthor) (*model.Config, *model.Config, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.SaveConfigWithAuthor")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1, resultVar2 := a.app.SaveConfigWithAuthor(newCfg, sendConfigChangeClusterMessage, author)
+
+	if resultVar2 != nil {
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -15493,12 +16293,11 @@ func (a *OpenTracingAppLayer) SaveReactionForPost(c request.CTX, reaction *model
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SaveReactionForPost(c, reaction)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15515,17 +16314,37 @@ func (a *OpenTracingAppLayer) SaveReportChunk(format string, prefix string, coun
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SaveReportChunk(format, prefix, count, reportData)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
 }
 
+func (a *OpenTracingAppLayer) SaveScheduledPost(rctx request.CTX, scheduledPost *model.ScheduledPost, connectionID string) (*model.ScheduledPost, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.SaveScheduledPost")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.SaveScheduledPost(rctx, scheduledPost, connectionID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
 func (a *OpenTracingAppLayer) SaveSharedChannelRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
 	origCtx := a.ctx
 	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.SaveSharedChannelRemote")
@@ -15537,12 +16356,11 @@ func (a *OpenTracingAppLayer) SaveSharedChannelRemote(remote *model.SharedChanne
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SaveSharedChannelRemote(remote)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15559,17 +16377,58 @@ func (a *OpenTracingAppLayer) SaveUserTermsOfService(userID string, termsOfServi
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SaveUserTermsOfService(userID, termsOfServiceId, accepted)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
 }
 
+func (a *OpenTracingAppLayer) SaveWebPushSubscription(c request.CTX, session *model.Session, subscriptionRequest *model.WebPushSubscriptionRequest) (*model.WebPushSubscription, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.SaveWebPushSubscription")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.SaveWebPushSubscription(c, session, subscriptionRequest)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) ScanFile(rctx request.CTX, info *model.FileInfo) (string, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.ScanFile")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.ScanFile(rctx, info)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
 func (a *OpenTracingAppLayer) SchemesIterator(scope string, batchSize int) func() []*model.Scheme {
 	origCtx := a.ctx
 	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.SchemesIterator")
@@ -15581,7 +16440,7 @@ func (a *OpenTracingAppLayer) SchemesIterator(scope string, batchSize int) func(
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SchemesIterator(scope, batchSize)
 
 	return resultVar0
@@ -15598,12 +16457,11 @@ func (a *OpenTracingAppLayer) SearchAllChannels(c request.CTX, term string, opts
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.SearchAllChannels(c, term, opts)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -15620,12 +16478,11 @@ func (a *OpenTracingAppLayer) SearchAllTeams(searchOpts *model.TeamSearch) ([]*m
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.SearchAllTeams(searchOpts)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -15642,12 +16499,11 @@ func (a *OpenTracingAppLayer) SearchArchivedChannels(c request.CTX, teamID strin
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchArchivedChannels(c, teamID, term, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15664,12 +16520,11 @@ func (a *OpenTracingAppLayer) SearchChannels(c request.CTX, teamID string, term
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchChannels(c, teamID, term)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15686,12 +16541,11 @@ func (a *OpenTracingAppLayer) SearchChannelsForUser(c request.CTX, userID string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchChannelsForUser(c, userID, teamID, term)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15708,12 +16562,11 @@ func (a *OpenTracingAppLayer) SearchChannelsUserNotIn(c request.CTX, teamID stri
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchChannelsUserNotIn(c, teamID, userID, term)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15730,12 +16583,11 @@ func (a *OpenTracingAppLayer) SearchEmoji(c request.CTX, name string, prefixOnly
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchEmoji(c, name, prefixOnly, limit)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15752,7 +16604,7 @@ func (a *OpenTracingAppLayer) SearchEngine() *searchengine.Broker {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SearchEngine()
 
 	return resultVar0
@@ -15769,12 +16621,11 @@ func (a *OpenTracingAppLayer) SearchFilesInTeamForUser(c request.CTX, terms stri
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchFilesInTeamForUser(c, terms, userId, teamId, isOrSearch, includeDeletedChannels, timeZoneOffset, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15791,12 +16642,11 @@ func (a *OpenTracingAppLayer) SearchGroupChannels(c request.CTX, userID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchGroupChannels(c, userID, term)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15813,12 +16663,11 @@ func (a *OpenTracingAppLayer) SearchPostsForUser(c request.CTX, terms string, us
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchPostsForUser(c, terms, userID, teamID, isOrSearch, includeDeletedChannels, timeZoneOffset, page, perPage)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15835,12 +16684,11 @@ func (a *OpenTracingAppLayer) SearchPostsInTeam(teamID string, paramsList []*mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchPostsInTeam(teamID, paramsList)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15857,12 +16705,11 @@ func (a *OpenTracingAppLayer) SearchPrivateTeams(searchOpts *model.TeamSearch) (
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchPrivateTeams(searchOpts)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15879,12 +16726,11 @@ func (a *OpenTracingAppLayer) SearchPublicTeams(searchOpts *model.TeamSearch) ([
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchPublicTeams(searchOpts)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15901,12 +16747,11 @@ func (a *OpenTracingAppLayer) SearchUserAccessTokens(term string) ([]*model.User
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchUserAccessTokens(term)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15923,12 +16768,11 @@ func (a *OpenTracingAppLayer) SearchUsers(rctx request.CTX, props *model.UserSea
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchUsers(rctx, props, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15945,12 +16789,11 @@ func (a *OpenTracingAppLayer) SearchUsersInChannel(channelID string, term string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchUsersInChannel(channelID, term, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15967,12 +16810,11 @@ func (a *OpenTracingAppLayer) SearchUsersInGroup(groupID string, term string, op
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchUsersInGroup(groupID, term, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -15989,12 +16831,11 @@ func (a *OpenTracingAppLayer) SearchUsersInTeam(rctx request.CTX, teamID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchUsersInTeam(rctx, teamID, term, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -16011,12 +16852,11 @@ func (a *OpenTracingAppLayer) SearchUsersNotInChannel(teamID string, channelID s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchUsersNotInChannel(teamID, channelID, term, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -16033,12 +16873,11 @@ func (a *OpenTracingAppLayer) SearchUsersNotInGroup(groupID string, term string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchUsersNotInGroup(groupID, term, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -16055,12 +16894,11 @@ func (a *OpenTracingAppLayer) SearchUsersNotInTeam(notInTeamId string, term stri
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchUsersNotInTeam(notInTeamId, term, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -16077,12 +16915,11 @@ func (a *OpenTracingAppLayer) SearchUsersWithoutTeam(term string, options *model
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SearchUsersWithoutTeam(term, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -16099,12 +16936,11 @@ func (a *OpenTracingAppLayer) SendAckToPushProxy(ack *model.PushNotificationAck)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SendAckToPushProxy(ack)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16121,12 +16957,11 @@ func (a *OpenTracingAppLayer) SendAutoResponse(rctx request.CTX, channel *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SendAutoResponse(rctx, channel, receiver, post)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -16143,12 +16978,11 @@ func (a *OpenTracingAppLayer) SendAutoResponseIfNecessary(rctx request.CTX, chan
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SendAutoResponseIfNecessary(rctx, channel, sender, post)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -16165,12 +16999,11 @@ func (a *OpenTracingAppLayer) SendEmailVerification(user *model.User, newEmail s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SendEmailVerification(user, newEmail, redirect)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16187,7 +17020,7 @@ func (a *OpenTracingAppLayer) SendEphemeralPost(c request.CTX, userID string, po
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SendEphemeralPost(c, userID, post)
 
 	return resultVar0
@@ -16204,12 +17037,11 @@ func (a *OpenTracingAppLayer) SendIPFiltersChangedEmail(c request.CTX, userID st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SendIPFiltersChangedEmail(c, userID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16226,12 +17058,11 @@ func (a *OpenTracingAppLayer) SendNotifications(c request.CTX, post *model.Post,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SendNotifications(c, post, team, channel, sender, parentPostList, setOnline)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -16248,12 +17079,11 @@ func (a *OpenTracingAppLayer) SendNotifyAdminPosts(c request.CTX, workspaceName
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SendNotifyAdminPosts(c, workspaceName, currentSKU, trial)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16270,12 +17100,11 @@ func (a *OpenTracingAppLayer) SendPasswordReset(rctx request.CTX, email string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SendPasswordReset(rctx, email, siteURL)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -16292,12 +17121,11 @@ func (a *OpenTracingAppLayer) SendPersistentNotifications() error {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SendPersistentNotifications()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16314,12 +17142,11 @@ func (a *OpenTracingAppLayer) SendReportToUser(rctx request.CTX, job *model.Job,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SendReportToUser(rctx, job, format)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16336,12 +17163,11 @@ func (a *OpenTracingAppLayer) SendSubscriptionHistoryEvent(userID string) (*mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SendSubscriptionHistoryEvent(userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -16358,7 +17184,7 @@ func (a *OpenTracingAppLayer) SendTestPushNotification(deviceID string) string {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SendTestPushNotification(deviceID)
 
 	return resultVar0
@@ -16375,7 +17201,7 @@ func (a *OpenTracingAppLayer) ServeInterPluginRequest(w http.ResponseWriter, r *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.ServeInterPluginRequest(w, r, sourcePluginId, destinationPluginId)
 }
 
@@ -16390,7 +17216,7 @@ func (a *OpenTracingAppLayer) SessionHasPermissionTo(session model.Session, perm
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SessionHasPermissionTo(session, permission)
 
 	return resultVar0
@@ -16407,7 +17233,7 @@ func (a *OpenTracingAppLayer) SessionHasPermissionToAny(session model.Session, p
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SessionHasPermissionToAny(session, permissions)
 
 	return resultVar0
@@ -16424,7 +17250,7 @@ func (a *OpenTracingAppLayer) SessionHasPermissionToCategory(c request.CTX, sess
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SessionHasPermissionToCategory(c, session, userID, teamID, categoryId)
 
 	return resultVar0
@@ -16441,7 +17267,7 @@ func (a *OpenTracingAppLayer) SessionHasPermissionToChannel(c request.CTX, sessi
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SessionHasPermissionToChannel(c, session, channelID, permission)
 
 	return resultVar0
@@ -16458,7 +17284,7 @@ func (a *OpenTracingAppLayer) SessionHasPermissionToChannelByPost(session model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SessionHasPermissionToChannelByPost(session, postID, permission)
 
 	return resultVar0
@@ -16475,7 +17301,7 @@ func (a *OpenTracingAppLayer) SessionHasPermissionToChannels(c request.CTX, sess
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SessionHasPermissionToChannels(c, session, channelIDs, permission)
 
 	return resultVar0
@@ -16492,7 +17318,7 @@ func (a *OpenTracingAppLayer) SessionHasPermissionToCreateJob(session model.Sess
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SessionHasPermissionToCreateJob(session, job)
 
 	return resultVar0, resultVar1
@@ -16509,7 +17335,7 @@ func (a *OpenTracingAppLayer) SessionHasPermissionToGroup(session model.Session,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SessionHasPermissionToGroup(session, groupID, permission)
 
 	return resultVar0
@@ -16526,12 +17352,11 @@ func (a *OpenTracingAppLayer) SessionHasPermissionToManageBot(rctx request.CTX,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SessionHasPermissionToManageBot(rctx, session, botUserId)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16548,7 +17373,7 @@ func (a *OpenTracingAppLayer) SessionHasPermissionToManageJob(session model.Sess
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SessionHasPermissionToManageJob(session, job)
 
 	return resultVar0, resultVar1
@@ -16565,7 +17390,7 @@ func (a *OpenTracingAppLayer) SessionHasPermissionToReadChannel(c request.CTX, s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SessionHasPermissionToReadChannel(c, session, channel)
 
 	return resultVar0
@@ -16582,7 +17407,7 @@ func (a *OpenTracingAppLayer) SessionHasPermissionToReadJob(session model.Sessio
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SessionHasPermissionToReadJob(session, jobType)
 
 	return resultVar0, resultVar1
@@ -16599,7 +17424,7 @@ func (a *OpenTracingAppLayer) SessionHasPermissionToTeam(session model.Session,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SessionHasPermissionToTeam(session, teamID, permission)
 
 	return resultVar0
@@ -16616,7 +17441,7 @@ func (a *OpenTracingAppLayer) SessionHasPermissionToTeams(c request.CTX, session
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SessionHasPermissionToTeams(c, session, teamIDs, permission)
 
 	return resultVar0
@@ -16633,7 +17458,7 @@ func (a *OpenTracingAppLayer) SessionHasPermissionToUser(session model.Session,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SessionHasPermissionToUser(session, userID)
 
 	return resultVar0
@@ -16650,7 +17475,7 @@ func (a *OpenTracingAppLayer) SessionHasPermissionToUserOrBot(rctx request.CTX,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SessionHasPermissionToUserOrBot(rctx, session, userID)
 
 	return resultVar0
@@ -16667,7 +17492,7 @@ func (a *OpenTracingAppLayer) SessionIsRegistered(session model.Session) bool {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SessionIsRegistered(session)
 
 	return resultVar0
@@ -16684,12 +17509,11 @@ func (a *OpenTracingAppLayer) SetActiveChannel(c request.CTX, userID string, cha
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetActiveChannel(c, userID, channelID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16706,7 +17530,7 @@ func (a *OpenTracingAppLayer) SetAutoResponderStatus(rctx request.CTX, user *mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.SetAutoResponderStatus(rctx, user, oldNotifyProps)
 }
 
@@ -16721,7 +17545,7 @@ func (a *OpenTracingAppLayer) SetChannels(ch *app.Channels) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.SetChannels(ch)
 }
 
@@ -16736,12 +17560,11 @@ func (a *OpenTracingAppLayer) SetCustomStatus(c request.CTX, userID string, cs *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetCustomStatus(c, userID, cs)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16758,12 +17581,11 @@ func (a *OpenTracingAppLayer) SetDefaultProfileImage(c request.CTX, user *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetDefaultProfileImage(c, user)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16780,12 +17602,11 @@ func (a *OpenTracingAppLayer) SetExtraSessionProps(session *model.Session, newPr
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetExtraSessionProps(session, newProps)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16802,12 +17623,11 @@ func (a *OpenTracingAppLayer) SetFileSearchableContent(rctx request.CTX, fileID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetFileSearchableContent(rctx, fileID, data)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16824,12 +17644,11 @@ func (a *OpenTracingAppLayer) SetPhase2PermissionsMigrationStatus(isComplete boo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetPhase2PermissionsMigrationStatus(isComplete)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16846,12 +17665,11 @@ func (a *OpenTracingAppLayer) SetPluginKey(pluginID string, key string, value []
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetPluginKey(pluginID, key, value)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16868,12 +17686,11 @@ func (a *OpenTracingAppLayer) SetPluginKeyWithExpiry(pluginID string, key string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetPluginKeyWithExpiry(pluginID, key, value, expireInSeconds)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16890,12 +17707,11 @@ func (a *OpenTracingAppLayer) SetPluginKeyWithOptions(pluginID string, key strin
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SetPluginKeyWithOptions(pluginID, key, value, options)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -16912,12 +17728,11 @@ func (a *OpenTracingAppLayer) SetPostReminder(rctx request.CTX, postID string, u
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetPostReminder(rctx, postID, userID, targetTime)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16934,12 +17749,11 @@ func (a *OpenTracingAppLayer) SetProfileImage(c request.CTX, userID string, imag
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetProfileImage(c, userID, imageData)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16956,12 +17770,11 @@ func (a *OpenTracingAppLayer) SetProfileImageFromFile(c request.CTX, userID stri
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetProfileImageFromFile(c, userID, file)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -16978,12 +17791,11 @@ func (a *OpenTracingAppLayer) SetProfileImageFromMultiPartFile(c request.CTX, us
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetProfileImageFromMultiPartFile(c, userID, file)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17000,12 +17812,11 @@ func (a *OpenTracingAppLayer) SetRemoteClusterLastPingAt(remoteClusterId string)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetRemoteClusterLastPingAt(remoteClusterId)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17022,12 +17833,11 @@ func (a *OpenTracingAppLayer) SetSamlIdpCertificateFromMetadata(data []byte) *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetSamlIdpCertificateFromMetadata(data)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17044,7 +17854,7 @@ func (a *OpenTracingAppLayer) SetSearchEngine(se *searchengine.Broker) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.SetSearchEngine(se)
 }
 
@@ -17059,7 +17869,7 @@ func (a *OpenTracingAppLayer) SetSessionExpireInHours(session *model.Session, ho
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.SetSessionExpireInHours(session, hours)
 }
 
@@ -17074,7 +17884,7 @@ func (a *OpenTracingAppLayer) SetStatusAwayIfNeeded(userID string, manual bool)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.SetStatusAwayIfNeeded(userID, manual)
 }
 
@@ -17089,7 +17899,7 @@ func (a *OpenTracingAppLayer) SetStatusDoNotDisturb(userID string) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.SetStatusDoNotDisturb(userID)
 }
 
@@ -17104,7 +17914,7 @@ func (a *OpenTracingAppLayer) SetStatusDoNotDisturbTimed(userId string, endtime
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.SetStatusDoNotDisturbTimed(userId, endtime)
 }
 
@@ -17119,7 +17929,7 @@ func (a *OpenTracingAppLayer) SetStatusLastActivityAt(userID string, activityAt
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.SetStatusLastActivityAt(userID, activityAt)
 }
 
@@ -17134,7 +17944,7 @@ func (a *OpenTracingAppLayer) SetStatusOffline(userID string, manual bool) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.SetStatusOffline(userID, manual)
 }
 
@@ -17149,7 +17959,7 @@ func (a *OpenTracingAppLayer) SetStatusOnline(userID string, manual bool) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.SetStatusOnline(userID, manual)
 }
 
@@ -17164,7 +17974,7 @@ func (a *OpenTracingAppLayer) SetStatusOutOfOffice(userID string) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.SetStatusOutOfOffice(userID)
 }
 
@@ -17179,12 +17989,11 @@ func (a *OpenTracingAppLayer) SetTeamIcon(teamID string, imageData *multipart.Fi
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetTeamIcon(teamID, imageData)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17201,12 +18010,11 @@ func (a *OpenTracingAppLayer) SetTeamIconFromFile(team *model.Team, file io.Read
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetTeamIconFromFile(team, file)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17223,12 +18031,11 @@ func (a *OpenTracingAppLayer) SetTeamIconFromMultiPartFile(teamID string, file m
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SetTeamIconFromMultiPartFile(teamID, file)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17245,12 +18052,11 @@ func (a *OpenTracingAppLayer) ShareChannel(c request.CTX, sc *model.SharedChanne
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ShareChannel(c, sc)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -17267,7 +18073,7 @@ func (a *OpenTracingAppLayer) ShouldSendPushNotification(user *model.User, chann
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ShouldSendPushNotification(user, channelNotifyProps, wasMentioned, status, post, isGM)
 
 	return resultVar0
@@ -17284,12 +18090,32 @@ func (a *OpenTracingAppLayer) SlackImport(c request.CTX, fileData multipart.File
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SlackImport(c, fileData, fileSize, teamID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) SnoozeReminder(rctx request.CTX, userID string, reminderID string, snoozeUntil int64) (*model.Reminder, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.SnoozeReminder")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.SnoozeReminder(rctx, userID, reminderID, snoozeUntil)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -17306,12 +18132,11 @@ func (a *OpenTracingAppLayer) SoftDeleteTeam(teamID string) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SoftDeleteTeam(teamID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17328,12 +18153,11 @@ func (a *OpenTracingAppLayer) StartUsersBatchExport(rctx request.CTX, ro *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.StartUsersBatchExport(rctx, ro, startAt, endAt)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17350,12 +18174,11 @@ func (a *OpenTracingAppLayer) SubmitInteractiveDialog(c request.CTX, request mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SubmitInteractiveDialog(c, request)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -17372,12 +18195,11 @@ func (a *OpenTracingAppLayer) SwitchEmailToLdap(c request.CTX, email string, pas
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SwitchEmailToLdap(c, email, password, code, ldapLoginId, ldapPassword)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -17394,12 +18216,11 @@ func (a *OpenTracingAppLayer) SwitchEmailToOAuth(c request.CTX, w http.ResponseW
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SwitchEmailToOAuth(c, w, r, email, password, code, service)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -17416,12 +18237,11 @@ func (a *OpenTracingAppLayer) SwitchLdapToEmail(c request.CTX, ldapPassword stri
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SwitchLdapToEmail(c, ldapPassword, code, email, newPassword)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -17438,12 +18258,11 @@ func (a *OpenTracingAppLayer) SwitchOAuthToEmail(c request.CTX, email string, pa
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.SwitchOAuthToEmail(c, email, password, requesterId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -17460,7 +18279,7 @@ func (a *OpenTracingAppLayer) SyncLdap(c request.CTX, includeRemovedMembers bool
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.SyncLdap(c, includeRemovedMembers)
 }
 
@@ -17475,12 +18294,11 @@ func (a *OpenTracingAppLayer) SyncPlugins() *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SyncPlugins()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17497,7 +18315,7 @@ func (a *OpenTracingAppLayer) SyncRolesAndMembership(rctx request.CTX, syncableI
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.SyncRolesAndMembership(rctx, syncableID, syncableType, includeRemovedMembers)
 }
 
@@ -17512,12 +18330,11 @@ func (a *OpenTracingAppLayer) SyncSharedChannel(channelID string) error {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SyncSharedChannel(channelID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17534,12 +18351,11 @@ func (a *OpenTracingAppLayer) SyncSyncableRoles(rctx request.CTX, syncableID str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.SyncSyncableRoles(rctx, syncableID, syncableType)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17556,12 +18372,11 @@ func (a *OpenTracingAppLayer) TeamMembersMinusGroupMembers(teamID string, groupI
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1, resultVar2 := a.app.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
 
 	if resultVar2 != nil {
-		span.LogFields(spanlog.Error(resultVar2))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar2)
 	}
 
 	return resultVar0, resultVar1, resultVar2
@@ -17578,12 +18393,11 @@ func (a *OpenTracingAppLayer) TeamMembersToAdd(since int64, teamID *string, incl
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.TeamMembersToAdd(since, teamID, includeRemovedMembers)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -17600,12 +18414,11 @@ func (a *OpenTracingAppLayer) TeamMembersToRemove(teamID *string) ([]*model.Team
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.TeamMembersToRemove(teamID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -17622,7 +18435,7 @@ func (a *OpenTracingAppLayer) TelemetryId() string {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.TelemetryId()
 
 	return resultVar0
@@ -17639,12 +18452,11 @@ func (a *OpenTracingAppLayer) TestElasticsearch(rctx request.CTX, cfg *model.Con
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.TestElasticsearch(rctx, cfg)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17661,12 +18473,11 @@ func (a *OpenTracingAppLayer) TestEmail(rctx request.CTX, userID string, cfg *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.TestEmail(rctx, userID, cfg)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17683,12 +18494,11 @@ func (a *OpenTracingAppLayer) TestFileStoreConnection() *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.TestFileStoreConnection()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17705,12 +18515,11 @@ func (a *OpenTracingAppLayer) TestFileStoreConnectionWithConfig(cfg *model.FileS
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.TestFileStoreConnectionWithConfig(cfg)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17727,12 +18536,11 @@ func (a *OpenTracingAppLayer) TestLdap(rctx request.CTX) *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.TestLdap(rctx)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17749,12 +18557,11 @@ func (a *OpenTracingAppLayer) TestSiteURL(rctx request.CTX, siteURL string) *mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.TestSiteURL(rctx, siteURL)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17771,12 +18578,11 @@ func (a *OpenTracingAppLayer) ToggleMuteChannel(c request.CTX, channelID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ToggleMuteChannel(c, channelID, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -17793,7 +18599,7 @@ func (a *OpenTracingAppLayer) TotalWebsocketConnections() int {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.TotalWebsocketConnections()
 
 	return resultVar0
@@ -17810,7 +18616,7 @@ func (a *OpenTracingAppLayer) TriggerWebhook(c request.CTX, payload *model.Outgo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.TriggerWebhook(c, payload, hook, post, channel)
 }
 
@@ -17825,12 +18631,11 @@ func (a *OpenTracingAppLayer) UninviteRemoteFromChannel(channelID string, remote
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UninviteRemoteFromChannel(channelID, remoteID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17847,7 +18652,7 @@ func (a *OpenTracingAppLayer) UnregisterPluginCommand(pluginID string, teamID st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.UnregisterPluginCommand(pluginID, teamID, trigger)
 }
 
@@ -17862,12 +18667,11 @@ func (a *OpenTracingAppLayer) UnregisterPluginForSharedChannels(pluginID string)
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UnregisterPluginForSharedChannels(pluginID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -17884,12 +18688,11 @@ func (a *OpenTracingAppLayer) UnshareChannel(channelID string) (bool, error) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UnshareChannel(channelID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -17906,12 +18709,11 @@ func (a *OpenTracingAppLayer) UpdateActive(c request.CTX, user *model.User, acti
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateActive(c, user, active)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -17928,12 +18730,11 @@ func (a *OpenTracingAppLayer) UpdateBotActive(rctx request.CTX, botUserId string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateBotActive(rctx, botUserId, active)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -17950,12 +18751,11 @@ func (a *OpenTracingAppLayer) UpdateBotOwner(rctx request.CTX, botUserId string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateBotOwner(rctx, botUserId, newOwnerId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -17972,12 +18772,11 @@ func (a *OpenTracingAppLayer) UpdateChannel(c request.CTX, channel *model.Channe
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateChannel(c, channel)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -17994,12 +18793,11 @@ func (a *OpenTracingAppLayer) UpdateChannelBookmark(c request.CTX, updateBookmar
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateChannelBookmark(c, updateBookmark, connectionId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18016,12 +18814,11 @@ func (a *OpenTracingAppLayer) UpdateChannelBookmarkSortOrder(bookmarkId string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateChannelBookmarkSortOrder(bookmarkId, channelId, newIndex, connectionId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18038,12 +18835,11 @@ func (a *OpenTracingAppLayer) UpdateChannelMemberNotifyProps(c request.CTX, data
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateChannelMemberNotifyProps(c, data, channelID, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18060,12 +18856,11 @@ func (a *OpenTracingAppLayer) UpdateChannelMemberRoles(c request.CTX, channelID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateChannelMemberRoles(c, channelID, userID, newRoles)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18082,12 +18877,11 @@ func (a *OpenTracingAppLayer) UpdateChannelMemberSchemeRoles(c request.CTX, chan
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateChannelMemberSchemeRoles(c, channelID, userID, isSchemeGuest, isSchemeUser, isSchemeAdmin)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18104,12 +18898,11 @@ func (a *OpenTracingAppLayer) UpdateChannelPrivacy(c request.CTX, oldChannel *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateChannelPrivacy(c, oldChannel, user)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18126,12 +18919,11 @@ func (a *OpenTracingAppLayer) UpdateChannelScheme(c request.CTX, channel *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateChannelScheme(c, channel)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18148,12 +18940,11 @@ func (a *OpenTracingAppLayer) UpdateCommand(oldCmd *model.Command, updatedCmd *m
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateCommand(oldCmd, updatedCmd)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18170,7 +18961,7 @@ func (a *OpenTracingAppLayer) UpdateConfig(f func(*model.Config)) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.UpdateConfig(f)
 }
 
@@ -18185,7 +18976,7 @@ func (a *OpenTracingAppLayer) UpdateDNDStatusOfUsers() {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.UpdateDNDStatusOfUsers()
 }
 
@@ -18200,12 +18991,11 @@ func (a *OpenTracingAppLayer) UpdateDefaultProfileImage(c request.CTX, user *mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateDefaultProfileImage(c, user)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18222,7 +19012,7 @@ func (a *OpenTracingAppLayer) UpdateEphemeralPost(c request.CTX, userID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateEphemeralPost(c, userID, post)
 
 	return resultVar0
@@ -18239,12 +19029,11 @@ func (a *OpenTracingAppLayer) UpdateExpiredDNDStatuses() ([]*model.Status, error
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateExpiredDNDStatuses()
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18261,12 +19050,11 @@ func (a *OpenTracingAppLayer) UpdateGroup(group *model.Group) (*model.Group, *mo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateGroup(group)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18283,12 +19071,11 @@ func (a *OpenTracingAppLayer) UpdateGroupSyncable(groupSyncable *model.GroupSync
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateGroupSyncable(groupSyncable)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18305,12 +19092,11 @@ func (a *OpenTracingAppLayer) UpdateHashedPassword(user *model.User, newHashedPa
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateHashedPassword(user, newHashedPassword)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18327,12 +19113,11 @@ func (a *OpenTracingAppLayer) UpdateHashedPasswordByUserId(userID string, newHas
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateHashedPasswordByUserId(userID, newHashedPassword)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18349,12 +19134,11 @@ func (a *OpenTracingAppLayer) UpdateIncomingWebhook(oldHook *model.IncomingWebho
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateIncomingWebhook(oldHook, updatedHook)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18371,12 +19155,11 @@ func (a *OpenTracingAppLayer) UpdateJobStatus(c request.CTX, job *model.Job, new
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateJobStatus(c, job, newStatus)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18393,12 +19176,11 @@ func (a *OpenTracingAppLayer) UpdateMfa(c request.CTX, activate bool, userID str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateMfa(c, activate, userID, token)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18415,7 +19197,7 @@ func (a *OpenTracingAppLayer) UpdateMobileAppBadge(userID string) {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.UpdateMobileAppBadge(userID)
 }
 
@@ -18430,12 +19212,11 @@ func (a *OpenTracingAppLayer) UpdateOAuthApp(oldApp *model.OAuthApp, updatedApp
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateOAuthApp(oldApp, updatedApp)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18452,12 +19233,11 @@ func (a *OpenTracingAppLayer) UpdateOAuthUserAttrs(c request.CTX, userData io.Re
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateOAuthUserAttrs(c, userData, user, provider, service, tokenUser)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18474,12 +19254,11 @@ func (a *OpenTracingAppLayer) UpdateOutgoingWebhook(c request.CTX, oldHook *mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateOutgoingWebhook(c, oldHook, updatedHook)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18496,12 +19275,11 @@ func (a *OpenTracingAppLayer) UpdatePassword(rctx request.CTX, user *model.User,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdatePassword(rctx, user, newPassword)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18518,12 +19296,11 @@ func (a *OpenTracingAppLayer) UpdatePasswordAsUser(c request.CTX, userID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdatePasswordAsUser(c, userID, currentPassword, newPassword)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18540,12 +19317,11 @@ func (a *OpenTracingAppLayer) UpdatePasswordByUserIdSendEmail(c request.CTX, use
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdatePasswordByUserIdSendEmail(c, userID, newPassword, method)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18562,12 +19338,11 @@ func (a *OpenTracingAppLayer) UpdatePasswordSendEmail(c request.CTX, user *model
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdatePasswordSendEmail(c, user, newPassword, method)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18584,12 +19359,11 @@ func (a *OpenTracingAppLayer) UpdatePost(c request.CTX, receivedUpdatedPost *mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdatePost(c, receivedUpdatedPost, safeUpdate)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18606,12 +19380,11 @@ func (a *OpenTracingAppLayer) UpdatePreferences(c request.CTX, userID string, pr
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdatePreferences(c, userID, preferences)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18628,12 +19401,11 @@ func (a *OpenTracingAppLayer) UpdateProductNotices() *model.AppError {
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateProductNotices()
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18650,12 +19422,11 @@ func (a *OpenTracingAppLayer) UpdateRemoteCluster(rc *model.RemoteCluster) (*mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateRemoteCluster(rc)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18672,12 +19443,11 @@ func (a *OpenTracingAppLayer) UpdateRemoteClusterTopics(remoteClusterId string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateRemoteClusterTopics(remoteClusterId, topics)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18694,12 +19464,32 @@ func (a *OpenTracingAppLayer) UpdateRole(role *model.Role) (*model.Role, *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateRole(role)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) UpdateScheduledPost(rctx request.CTX, userID string, scheduledPost *model.ScheduledPost, connectionID string) (*model.ScheduledPost, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.UpdateScheduledPost")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.UpdateScheduledPost(rctx, userID, scheduledPost, connectionID)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18716,12 +19506,53 @@ func (a *OpenTracingAppLayer) UpdateScheme(scheme *model.Scheme) (*model.Scheme,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateScheme(scheme)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) UpdateScimGroup(rctx request.CTX, groupID string, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.UpdateScimGroup")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.UpdateScimGroup(rctx, groupID, scimGroup)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) UpdateScimUser(rctx request.CTX, userID string, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.UpdateScimUser")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.UpdateScimUser(rctx, userID, scimUser)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18738,12 +19569,11 @@ func (a *OpenTracingAppLayer) UpdateSharedChannel(sc *model.SharedChannel) (*mod
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateSharedChannel(sc)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18760,12 +19590,11 @@ func (a *OpenTracingAppLayer) UpdateSharedChannelCursor(channelID string, remote
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateSharedChannelCursor(channelID, remoteID, cursor)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18782,12 +19611,11 @@ func (a *OpenTracingAppLayer) UpdateSharedChannelRemoteCursor(id string, cursor
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateSharedChannelRemoteCursor(id, cursor)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18804,12 +19632,11 @@ func (a *OpenTracingAppLayer) UpdateSidebarCategories(c request.CTX, userID stri
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateSidebarCategories(c, userID, teamID, categories)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18826,12 +19653,11 @@ func (a *OpenTracingAppLayer) UpdateSidebarCategoryOrder(c request.CTX, userID s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateSidebarCategoryOrder(c, userID, teamID, categoryOrder)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18848,12 +19674,11 @@ func (a *OpenTracingAppLayer) UpdateTeam(team *model.Team) (*model.Team, *model.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateTeam(team)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18870,12 +19695,11 @@ func (a *OpenTracingAppLayer) UpdateTeamMemberRoles(c request.CTX, teamID string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateTeamMemberRoles(c, teamID, userID, newRoles)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18892,12 +19716,11 @@ func (a *OpenTracingAppLayer) UpdateTeamMemberSchemeRoles(c request.CTX, teamID
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateTeamMemberSchemeRoles(c, teamID, userID, isSchemeGuest, isSchemeUser, isSchemeAdmin)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18914,12 +19737,11 @@ func (a *OpenTracingAppLayer) UpdateTeamPrivacy(teamID string, teamType string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateTeamPrivacy(teamID, teamType, allowOpenInvite)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18936,12 +19758,11 @@ func (a *OpenTracingAppLayer) UpdateTeamScheme(team *model.Team) (*model.Team, *
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateTeamScheme(team)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -18958,12 +19779,11 @@ func (a *OpenTracingAppLayer) UpdateThreadFollowForUser(userID string, teamID st
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateThreadFollowForUser(userID, teamID, threadID, state)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -18980,12 +19800,11 @@ func (a *OpenTracingAppLayer) UpdateThreadFollowForUserFromChannelAdd(c request.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateThreadFollowForUserFromChannelAdd(c, userID, teamID, threadID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -19002,12 +19821,11 @@ func (a *OpenTracingAppLayer) UpdateThreadReadForUser(c request.CTX, currentSess
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateThreadReadForUser(c, currentSessionId, userID, teamID, threadID, timestamp)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19024,12 +19842,11 @@ func (a *OpenTracingAppLayer) UpdateThreadReadForUserByPost(c request.CTX, curre
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateThreadReadForUserByPost(c, currentSessionId, userID, teamID, threadID, postID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19046,12 +19863,11 @@ func (a *OpenTracingAppLayer) UpdateThreadsReadForUser(userID string, teamID str
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateThreadsReadForUser(userID, teamID)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -19068,12 +19884,11 @@ func (a *OpenTracingAppLayer) UpdateUser(c request.CTX, user *model.User, sendNo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateUser(c, user, sendNotifications)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19090,12 +19905,11 @@ func (a *OpenTracingAppLayer) UpdateUserActive(c request.CTX, userID string, act
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateUserActive(c, userID, active)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -19112,12 +19926,11 @@ func (a *OpenTracingAppLayer) UpdateUserAsUser(c request.CTX, user *model.User,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateUserAsUser(c, user, asAdmin)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19134,12 +19947,11 @@ func (a *OpenTracingAppLayer) UpdateUserAuth(c request.CTX, userID string, userA
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateUserAuth(c, userID, userAuth)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19156,12 +19968,11 @@ func (a *OpenTracingAppLayer) UpdateUserRoles(c request.CTX, userID string, newR
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateUserRoles(c, userID, newRoles, sendWebSocketEvent)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19178,12 +19989,11 @@ func (a *OpenTracingAppLayer) UpdateUserRolesWithUser(c request.CTX, user *model
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpdateUserRolesWithUser(c, user, newRoles, sendWebSocketEvent)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19200,12 +20010,11 @@ func (a *OpenTracingAppLayer) UpdateViewedProductNotices(userID string, noticeId
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UpdateViewedProductNotices(userID, noticeIds)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -19222,7 +20031,7 @@ func (a *OpenTracingAppLayer) UpdateViewedProductNoticesForNewUser(userID string
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.UpdateViewedProductNoticesForNewUser(userID)
 }
 
@@ -19237,7 +20046,7 @@ func (a *OpenTracingAppLayer) UpdateWebConnUserActivity(session model.Session, a
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	a.app.UpdateWebConnUserActivity(session, activityAt)
 }
 
@@ -19252,12 +20061,11 @@ func (a *OpenTracingAppLayer) UploadData(c request.CTX, us *model.UploadSession,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UploadData(c, us, rd)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19274,12 +20082,11 @@ func (a *OpenTracingAppLayer) UploadFile(c request.CTX, data []byte, channelID s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UploadFile(c, data, channelID, filename)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19296,12 +20103,11 @@ func (a *OpenTracingAppLayer) UploadFileForUserAndTeam(c request.CTX, data []byt
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UploadFileForUserAndTeam(c, data, channelID, filename, rawUserId, rawTeamId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19318,12 +20124,11 @@ func (a *OpenTracingAppLayer) UploadFileX(c request.CTX, channelID string, name
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UploadFileX(c, channelID, name, input, opts...)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19340,12 +20145,11 @@ func (a *OpenTracingAppLayer) UpsertDraft(c request.CTX, draft *model.Draft, con
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpsertDraft(c, draft, connectionID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19362,12 +20166,11 @@ func (a *OpenTracingAppLayer) UpsertGroupMember(groupID string, userID string) (
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpsertGroupMember(groupID, userID)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19384,12 +20187,11 @@ func (a *OpenTracingAppLayer) UpsertGroupMembers(groupID string, userIDs []strin
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpsertGroupMembers(groupID, userIDs)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19406,12 +20208,11 @@ func (a *OpenTracingAppLayer) UpsertGroupSyncable(groupSyncable *model.GroupSync
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UpsertGroupSyncable(groupSyncable)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19428,7 +20229,7 @@ func (a *OpenTracingAppLayer) UserAlreadyNotifiedOnRequiredFeature(user string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UserAlreadyNotifiedOnRequiredFeature(user, feature)
 
 	return resultVar0
@@ -19445,12 +20246,32 @@ func (a *OpenTracingAppLayer) UserCanSeeOtherUser(c request.CTX, userID string,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UserCanSeeOtherUser(c, userID, otherUserId)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) UserHasMfaMethod(user *model.User, method string) (bool, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.UserHasMfaMethod")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.UserHasMfaMethod(user, method)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19467,7 +20288,7 @@ func (a *OpenTracingAppLayer) UserIsFirstAdmin(rctx request.CTX, user *model.Use
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.UserIsFirstAdmin(rctx, user)
 
 	return resultVar0
@@ -19484,12 +20305,32 @@ func (a *OpenTracingAppLayer) UserIsInAdminRoleGroup(userID string, syncableID s
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.UserIsInAdminRoleGroup(userID, syncableID, syncableType)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) ValidateBulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, importPath string) (*model.ImportValidationReport, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.ValidateBulkImport")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.ValidateBulkImport(c, jsonlReader, attachmentsReader, importPath)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19506,12 +20347,11 @@ func (a *OpenTracingAppLayer) ValidateDesktopToken(token string, expiryTime int6
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ValidateDesktopToken(token, expiryTime)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19528,12 +20368,11 @@ func (a *OpenTracingAppLayer) ValidateMoveOrCopy(c request.CTX, wpl *model.Wrang
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ValidateMoveOrCopy(c, wpl, originalChannel, targetChannel, user)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -19550,7 +20389,7 @@ func (a *OpenTracingAppLayer) ValidateUserPermissionsOnChannels(c request.CTX, u
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.ValidateUserPermissionsOnChannels(c, userId, channelIds)
 
 	return resultVar0
@@ -19567,12 +20406,11 @@ func (a *OpenTracingAppLayer) VerifyEmailFromToken(c request.CTX, userSuppliedTo
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.VerifyEmailFromToken(c, userSuppliedTokenString)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -19589,12 +20427,11 @@ func (a *OpenTracingAppLayer) VerifyPlugin(plugin io.ReadSeeker, signature io.Re
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.VerifyPlugin(plugin, signature)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -19611,12 +20448,11 @@ func (a *OpenTracingAppLayer) VerifyUserEmail(userID string, email string) *mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0 := a.app.VerifyUserEmail(userID, email)
 
 	if resultVar0 != nil {
-		span.LogFields(spanlog.Error(resultVar0))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar0)
 	}
 
 	return resultVar0
@@ -19633,12 +20469,32 @@ func (a *OpenTracingAppLayer) ViewChannel(c request.CTX, view *model.ChannelView
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.ViewChannel(c, view, userID, currentSessionId, collapsedThreadsSupported)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
+	}
+
+	return resultVar0, resultVar1
+}
+
+func (a *OpenTracingAppLayer) VotePoll(c request.CTX, pollID string, userID string, optionIDs []string) (*model.Poll, *model.AppError) {
+	origCtx := a.ctx
+	span, newCtx := tracing.StartSpanWithParentByContext(a.ctx, "app.VotePoll")
+
+	a.ctx = newCtx
+	a.app.Srv().Store().SetContext(newCtx)
+	defer func() {
+		a.app.Srv().Store().SetContext(origCtx)
+		a.ctx = origCtx
+	}()
+
+	defer span.End()
+	resultVar0, resultVar1 := a.app.VotePoll(c, pollID, userID, optionIDs)
+
+	if resultVar1 != nil {
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19655,12 +20511,11 @@ func (a *OpenTracingAppLayer) WriteExportFile(fr io.Reader, path string) (int64,
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.WriteExportFile(fr, path)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19677,12 +20532,11 @@ func (a *OpenTracingAppLayer) WriteExportFileContext(ctx context.Context, fr io.
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.WriteExportFileContext(ctx, fr, path)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19699,12 +20553,11 @@ func (a *OpenTracingAppLayer) WriteFile(fr io.Reader, path string) (int64, *mode
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.WriteFile(fr, path)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
@@ -19721,12 +20574,11 @@ func (a *OpenTracingAppLayer) WriteFileContext(ctx context.Context, fr io.Reader
 		a.ctx = origCtx
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	resultVar0, resultVar1 := a.app.WriteFileContext(ctx, fr, path)
 
 	if resultVar1 != nil {
-		span.LogFields(spanlog.Error(resultVar1))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, resultVar1)
 	}
 
 	return resultVar0, resultVar1
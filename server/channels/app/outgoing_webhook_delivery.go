@@ -0,0 +1,277 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const (
+	outgoingWebhookDeliveriesBatchSize       = 100
+	outgoingWebhookDeliveriesConcurrency     = 10
+	outgoingWebhookDeliveriesDeleteBatchSize = 1000
+)
+
+// deliverOutgoingWebhook makes one delivery attempt, records its outcome in
+// the delivery history and, if the receiver accepted it, creates the post
+// sent back in the response. Failed attempts are scheduled for a retry until
+// the configured number of attempts is exhausted.
+func (a *App) deliverOutgoingWebhook(c request.CTX, hook *model.OutgoingWebhook, channel *model.Channel, delivery *model.OutgoingWebhookDelivery) {
+	logger := c.Logger().With(mlog.String("hook_id", hook.Id))
+
+	var statusCode int
+	var respBody []byte
+	start := time.Now()
+
+	accessToken, err := a.getOutgoingWebhookAccessToken(c, delivery.CallbackURL)
+	if err == nil {
+		statusCode, respBody, err = a.sendOutgoingWebhookRequest(delivery.CallbackURL, []byte(delivery.Payload), delivery.ContentType, accessToken, hook.SigningSecrets())
+	}
+	latency := time.Since(start)
+
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			logger.Error("Outgoing Webhook POST timed out. Consider increasing ServiceSettings.OutgoingIntegrationRequestsTimeout.", mlog.Err(err))
+		} else {
+			logger.Error("Outgoing Webhook POST failed", mlog.Err(err))
+		}
+	} else if statusCode < 200 || statusCode >= 300 {
+		logger.Error("Outgoing Webhook POST failed", mlog.Int("status_code", statusCode))
+	}
+
+	a.recordOutgoingWebhookAttempt(delivery, statusCode, respBody, latency, err)
+	a.saveOutgoingWebhookDelivery(c, delivery)
+
+	if delivery.Status != model.OutgoingWebhookDeliveryStatusSuccess {
+		return
+	}
+
+	webhookResp, err := decodeOutgoingWebhookResponse(respBody)
+	if err != nil {
+		logger.Error("Outgoing Webhook POST failed", mlog.Err(err))
+		return
+	}
+
+	a.handleOutgoingWebhookResponse(c, hook, channel, delivery.PostId, webhookResp)
+}
+
+// getOutgoingWebhookAccessToken retrieves an access token from an outgoing
+// OAuth connection, if one exists for the given URL.
+func (a *App) getOutgoingWebhookAccessToken(c request.CTX, url string) (*model.OutgoingOAuthConnectionToken, error) {
+	if a.Config().ServiceSettings.EnableOutgoingOAuthConnections == nil || !*a.Config().ServiceSettings.EnableOutgoingOAuthConnections || a.OutgoingOAuthConnections() == nil {
+		return nil, nil
+	}
+
+	connection, err := a.OutgoingOAuthConnections().GetConnectionForAudience(c, url)
+	if err != nil {
+		c.Logger().Error("Failed to find an outgoing oauth connection for the webhook", mlog.Err(err))
+		return nil, err
+	}
+
+	if connection == nil {
+		return nil, nil
+	}
+
+	accessToken, err := a.OutgoingOAuthConnections().RetrieveTokenForConnection(c, connection)
+	if err != nil {
+		c.Logger().Error("Failed to retrieve token for outgoing oauth connection", mlog.Err(err))
+		return nil, err
+	}
+
+	return accessToken, nil
+}
+
+// recordOutgoingWebhookAttempt updates the delivery with the outcome of an
+// attempt and decides whether it succeeded, has to be retried, or failed.
+func (a *App) recordOutgoingWebhookAttempt(delivery *model.OutgoingWebhookDelivery, statusCode int, respBody []byte, latency time.Duration, attemptErr error) {
+	now := model.GetMillis()
+
+	delivery.Attempts++
+	delivery.LastAttemptAt = now
+	delivery.StatusCode = statusCode
+	delivery.Latency = latency.Milliseconds()
+	delivery.Response = sanitizeOutgoingWebhookResponse(respBody)
+	delivery.Error = ""
+	if attemptErr != nil {
+		delivery.Error = attemptErr.Error()
+	}
+
+	settings := a.Config().ServiceSettings
+	switch {
+	case attemptErr == nil && delivery.IsSuccessStatusCode():
+		delivery.Status = model.OutgoingWebhookDeliveryStatusSuccess
+		delivery.NextAttemptAt = 0
+	case delivery.Attempts >= *settings.OutgoingWebhookMaxDeliveryAttempts:
+		delivery.Status = model.OutgoingWebhookDeliveryStatusFailed
+		delivery.NextAttemptAt = 0
+	default:
+		delivery.Status = model.OutgoingWebhookDeliveryStatusPending
+		delivery.NextAttemptAt = now + model.OutgoingWebhookRetryBackoff(delivery.Attempts, *settings.OutgoingWebhookRetryBackoffSeconds, *settings.OutgoingWebhookMaxBackoffSeconds)
+	}
+}
+
+// sanitizeOutgoingWebhookResponse makes the response of the receiver safe to
+// store: the database rejects invalid UTF-8 and NUL characters.
+func sanitizeOutgoingWebhookResponse(respBody []byte) string {
+	response := strings.ToValidUTF8(string(respBody), "")
+	return strings.ReplaceAll(response, "\x00", "")
+}
+
+func (a *App) saveOutgoingWebhookDelivery(c request.CTX, delivery *model.OutgoingWebhookDelivery) {
+	var err error
+	if delivery.Id == "" {
+		_, err = a.Srv().Store().OutgoingWebhookDelivery().Save(delivery)
+	} else {
+		err = a.Srv().Store().OutgoingWebhookDelivery().Update(delivery)
+	}
+
+	if err != nil {
+		c.Logger().Warn("Failed to save outgoing webhook delivery", mlog.String("hook_id", delivery.HookId), mlog.Err(err))
+	}
+}
+
+func (a *App) GetOutgoingWebhookDelivery(deliveryID string) (*model.OutgoingWebhookDelivery, *model.AppError) {
+	delivery, err := a.Srv().Store().OutgoingWebhookDelivery().Get(deliveryID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetOutgoingWebhookDelivery", "app.outgoing_webhook_delivery.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetOutgoingWebhookDelivery", "app.outgoing_webhook_delivery.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return delivery, nil
+}
+
+func (a *App) GetOutgoingWebhookDeliveries(hookID string, opts model.OutgoingWebhookDeliveryGetOptions) ([]*model.OutgoingWebhookDelivery, *model.AppError) {
+	deliveries, err := a.Srv().Store().OutgoingWebhookDelivery().GetForHook(hookID, opts)
+	if err != nil {
+		return nil, model.NewAppError("GetOutgoingWebhookDeliveries", "app.outgoing_webhook_delivery.get_for_hook.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return deliveries, nil
+}
+
+// ReplayOutgoingWebhookDelivery immediately attempts a failed delivery again.
+// If the attempt fails, the delivery is retried with the configured backoff
+// as if it was a new delivery.
+func (a *App) ReplayOutgoingWebhookDelivery(c request.CTX, delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("ReplayOutgoingWebhookDelivery", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if delivery.Status != model.OutgoingWebhookDeliveryStatusFailed {
+		return nil, model.NewAppError("ReplayOutgoingWebhookDelivery", "app.outgoing_webhook_delivery.replay.not_failed.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	hook, appErr := a.GetOutgoingWebhook(delivery.HookId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	channel, appErr := a.GetChannel(c, delivery.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	delivery.Attempts = 0
+	a.deliverOutgoingWebhook(c, hook, channel, delivery)
+
+	return delivery, nil
+}
+
+// ProcessOutgoingWebhookDeliveries retries the pending deliveries that are
+// due and deletes the delivery history older than the configured retention.
+func (a *App) ProcessOutgoingWebhookDeliveries(rctx request.CTX) error {
+	if *a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		if err := a.retryOutgoingWebhookDeliveries(rctx); err != nil {
+			return err
+		}
+	}
+
+	endTime := model.GetMillis() - int64(*a.Config().ServiceSettings.OutgoingWebhookHistoryDays)*model.DayInMilliseconds
+	for {
+		deleted, err := a.Srv().Store().OutgoingWebhookDelivery().PermanentDeleteBatch(endTime, outgoingWebhookDeliveriesDeleteBatchSize)
+		if err != nil {
+			return err
+		}
+		if deleted < outgoingWebhookDeliveriesDeleteBatchSize {
+			return nil
+		}
+	}
+}
+
+func (a *App) retryOutgoingWebhookDeliveries(rctx request.CTX) error {
+	now := model.GetMillis()
+	attempted := make(map[string]bool)
+
+	for {
+		deliveries, err := a.Srv().Store().OutgoingWebhookDelivery().GetPending(now, outgoingWebhookDeliveriesBatchSize)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, outgoingWebhookDeliveriesConcurrency)
+		progress := false
+		for _, delivery := range deliveries {
+			// A delivery that could not be updated would be returned again.
+			if attempted[delivery.Id] {
+				continue
+			}
+			attempted[delivery.Id] = true
+			progress = true
+
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				a.retryOutgoingWebhookDelivery(rctx, delivery)
+			}()
+		}
+		wg.Wait()
+
+		if !progress || len(deliveries) < outgoingWebhookDeliveriesBatchSize {
+			return nil
+		}
+	}
+}
+
+func (a *App) retryOutgoingWebhookDelivery(rctx request.CTX, delivery *model.OutgoingWebhookDelivery) {
+	hook, err := a.Srv().Store().Webhook().GetOutgoing(delivery.HookId)
+	if err != nil || hook.DeleteAt != 0 {
+		a.failOutgoingWebhookDelivery(rctx, delivery, "the outgoing webhook no longer exists")
+		return
+	}
+
+	channel, err := a.Srv().Store().Channel().Get(delivery.ChannelId, true)
+	if err != nil {
+		a.failOutgoingWebhookDelivery(rctx, delivery, "the channel no longer exists")
+		return
+	}
+
+	a.deliverOutgoingWebhook(rctx, hook, channel, delivery)
+}
+
+func (a *App) failOutgoingWebhookDelivery(rctx request.CTX, delivery *model.OutgoingWebhookDelivery, reason string) {
+	delivery.Status = model.OutgoingWebhookDeliveryStatusFailed
+	delivery.NextAttemptAt = 0
+	delivery.Error = reason
+	a.saveOutgoingWebhookDelivery(rctx, delivery)
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestOutgoingWebhookDeliveries(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+		*cfg.ServiceSettings.OutgoingWebhookMaxDeliveryAttempts = 2
+	})
+
+	var statusCode atomic.Int32
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(int(statusCode.Load()))
+		w.Write([]byte("receiver response"))
+	}))
+	defer ts.Close()
+
+	hook, appErr := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+		ChannelId:    th.BasicChannel.Id,
+		TeamId:       th.BasicTeam.Id,
+		CallbackURLs: []string{ts.URL},
+		CreatorId:    th.BasicUser.Id,
+		TriggerWords: []string{"trigger"},
+		ContentType:  "application/json",
+	})
+	require.Nil(t, appErr)
+
+	trigger := func(t *testing.T) *model.OutgoingWebhookDelivery {
+		t.Helper()
+		payload := &model.OutgoingWebhookPayload{
+			Token:     hook.Token,
+			TeamId:    hook.TeamId,
+			ChannelId: th.BasicChannel.Id,
+			PostId:    th.BasicPost.Id,
+			Text:      "trigger",
+		}
+		th.App.TriggerWebhook(th.Context, payload, hook, th.BasicPost, th.BasicChannel)
+
+		deliveries, appErr := th.App.GetOutgoingWebhookDeliveries(hook.Id, model.OutgoingWebhookDeliveryGetOptions{PerPage: 1})
+		require.Nil(t, appErr)
+		require.Len(t, deliveries, 1)
+		return deliveries[0]
+	}
+
+	// makeDue moves the next attempt of a pending delivery to the past.
+	makeDue := func(t *testing.T, delivery *model.OutgoingWebhookDelivery) {
+		t.Helper()
+		delivery.NextAttemptAt = model.GetMillis() - 1000
+		require.NoError(t, th.App.Srv().Store().OutgoingWebhookDelivery().Update(delivery))
+	}
+
+	t.Run("successful deliveries are recorded", func(t *testing.T) {
+		statusCode.Store(http.StatusOK)
+
+		delivery := trigger(t)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusSuccess, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, http.StatusOK, delivery.StatusCode)
+		assert.Equal(t, "receiver response", delivery.Response)
+		assert.Equal(t, th.BasicPost.Id, delivery.PostId)
+	})
+
+	t.Run("failed deliveries are retried until they run out of attempts", func(t *testing.T) {
+		statusCode.Store(http.StatusServiceUnavailable)
+
+		delivery := trigger(t)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusPending, delivery.Status)
+		assert.Equal(t, http.StatusServiceUnavailable, delivery.StatusCode)
+		assert.Greater(t, delivery.NextAttemptAt, delivery.LastAttemptAt)
+
+		// The retry is not due yet.
+		before := requests.Load()
+		require.NoError(t, th.App.ProcessOutgoingWebhookDeliveries(th.Context))
+		assert.Equal(t, before, requests.Load())
+
+		makeDue(t, delivery)
+		require.NoError(t, th.App.ProcessOutgoingWebhookDeliveries(th.Context))
+		assert.Equal(t, before+1, requests.Load())
+
+		delivery, appErr := th.App.GetOutgoingWebhookDelivery(delivery.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusFailed, delivery.Status)
+		assert.Equal(t, 2, delivery.Attempts)
+
+		failed, appErr := th.App.GetOutgoingWebhookDeliveries(hook.Id, model.OutgoingWebhookDeliveryGetOptions{Status: model.OutgoingWebhookDeliveryStatusFailed, PerPage: 10})
+		require.Nil(t, appErr)
+		require.Len(t, failed, 1)
+		assert.Equal(t, delivery.Id, failed[0].Id)
+
+		t.Run("failed deliveries can be replayed", func(t *testing.T) {
+			statusCode.Store(http.StatusOK)
+
+			replayed, appErr := th.App.ReplayOutgoingWebhookDelivery(th.Context, delivery)
+			require.Nil(t, appErr)
+			assert.Equal(t, model.OutgoingWebhookDeliveryStatusSuccess, replayed.Status)
+			assert.Equal(t, 1, replayed.Attempts)
+
+			_, appErr = th.App.ReplayOutgoingWebhookDelivery(th.Context, replayed)
+			require.NotNil(t, appErr)
+			assert.Equal(t, "app.outgoing_webhook_delivery.replay.not_failed.app_error", appErr.Id)
+		})
+	})
+
+	t.Run("pending deliveries of deleted hooks fail", func(t *testing.T) {
+		statusCode.Store(http.StatusServiceUnavailable)
+
+		otherHook, appErr := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+			ChannelId:    th.BasicChannel.Id,
+			TeamId:       th.BasicTeam.Id,
+			CallbackURLs: []string{ts.URL},
+			CreatorId:    th.BasicUser.Id,
+			TriggerWords: []string{"other"},
+		})
+		require.Nil(t, appErr)
+
+		delivery, err := th.App.Srv().Store().OutgoingWebhookDelivery().Save(&model.OutgoingWebhookDelivery{
+			HookId:        otherHook.Id,
+			ChannelId:     th.BasicChannel.Id,
+			PostId:        th.BasicPost.Id,
+			CallbackURL:   ts.URL,
+			NextAttemptAt: model.GetMillis() - 1000,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, th.App.Srv().Store().Webhook().DeleteOutgoing(otherHook.Id, model.GetMillis()))
+		require.NoError(t, th.App.ProcessOutgoingWebhookDeliveries(th.Context))
+
+		delivery, appErr = th.App.GetOutgoingWebhookDelivery(delivery.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusFailed, delivery.Status)
+	})
+}
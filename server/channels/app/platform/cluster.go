@@ -4,6 +4,7 @@
 package platform
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 func (ps *PlatformService) Cluster() einterfaces.ClusterInterface {
@@ -172,6 +174,12 @@ func (ps *PlatformService) InvokeClusterLeaderChangedListeners() {
 }
 
 func (ps *PlatformService) Publish(message *model.WebSocketEvent) {
+	ps.PublishWithContext(context.Background(), message)
+}
+
+// PublishWithContext publishes the event like Publish, propagating the trace
+// context of ctx to the other cluster nodes.
+func (ps *PlatformService) PublishWithContext(ctx context.Context, message *model.WebSocketEvent) {
 	if ps.metricsIFace != nil {
 		ps.metricsIFace.IncrementWebsocketEvent(message.EventType())
 	}
@@ -198,6 +206,7 @@ func (ps *PlatformService) Publish(message *model.WebSocketEvent) {
 			cm.SendType = model.ClusterSendReliable
 		}
 
+		tracing.InjectClusterMessage(ctx, cm)
 		ps.clusterIFace.SendClusterMessage(cm)
 	}
 }
@@ -11,22 +11,41 @@ import (
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 func (ps *PlatformService) RegisterClusterHandlers() {
-	ps.clusterIFace.RegisterClusterMessageHandler(model.ClusterEventPublish, ps.ClusterPublishHandler)
-	ps.clusterIFace.RegisterClusterMessageHandler(model.ClusterEventUpdateStatus, ps.ClusterUpdateStatusHandler)
-	ps.clusterIFace.RegisterClusterMessageHandler(model.ClusterEventInvalidateAllCaches, ps.ClusterInvalidateAllCachesHandler)
-	ps.clusterIFace.RegisterClusterMessageHandler(model.ClusterEventInvalidateWebConnCacheForUser, ps.clusterInvalidateWebConnSessionCacheForUserHandler)
-	ps.clusterIFace.RegisterClusterMessageHandler(model.ClusterEventBusyStateChanged, ps.clusterBusyStateChgHandler)
-	ps.clusterIFace.RegisterClusterMessageHandler(model.ClusterEventClearSessionCacheForUser, ps.clusterClearSessionCacheForUserHandler)
-	ps.clusterIFace.RegisterClusterMessageHandler(model.ClusterEventClearSessionCacheForAllUsers, ps.clusterClearSessionCacheForAllUsersHandler)
+	ps.registerClusterMessageHandler(model.ClusterEventPublish, ps.ClusterPublishHandler)
+	ps.registerClusterMessageHandler(model.ClusterEventUpdateStatus, ps.ClusterUpdateStatusHandler)
+	ps.registerClusterMessageHandler(model.ClusterEventInvalidateAllCaches, ps.ClusterInvalidateAllCachesHandler)
+	ps.registerClusterMessageHandler(model.ClusterEventInvalidateWebConnCacheForUser, ps.clusterInvalidateWebConnSessionCacheForUserHandler)
+	ps.registerClusterMessageHandler(model.ClusterEventBusyStateChanged, ps.clusterBusyStateChgHandler)
+	ps.registerClusterMessageHandler(model.ClusterEventClearSessionCacheForUser, ps.clusterClearSessionCacheForUserHandler)
+	ps.registerClusterMessageHandler(model.ClusterEventClearSessionCacheForAllUsers, ps.clusterClearSessionCacheForAllUsersHandler)
 
 	for e, h := range ps.additionalClusterHandlers {
-		ps.clusterIFace.RegisterClusterMessageHandler(e, h)
+		ps.registerClusterMessageHandler(e, h)
 	}
 }
 
+// registerClusterMessageHandler registers the handler with the cluster, tracing the
+// handling of each message as part of the trace of the node which sent it. The
+// handler receives the message with the trace context of the handling span, see
+// tracing.ClusterMessageContext.
+func (ps *PlatformService) registerClusterMessageHandler(ev model.ClusterEvent, h einterfaces.ClusterMessageHandler) {
+	ps.clusterIFace.RegisterClusterMessageHandler(ev, func(msg *model.ClusterMessage) {
+		if !*ps.Config().ServiceSettings.EnableOpenTracing {
+			h(msg)
+			return
+		}
+
+		span, ctx := tracing.StartClusterMessageSpan(msg)
+		defer span.End()
+		tracing.InjectClusterMessage(ctx, msg)
+		h(msg)
+	})
+}
+
 func (ps *PlatformService) RegisterClusterMessageHandler(ev model.ClusterEvent, h einterfaces.ClusterMessageHandler) {
 	ps.additionalClusterHandlers[ev] = h
 }
@@ -200,6 +200,11 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 	// Depends on step 3 (s.SearchEngine must be non-nil)
 	ps.initEnterprise()
 
+	// In a cluster, Bleve uses the indexes of the leader node.
+	bleveEngine.SetCluster(ps, ps.Log())
+	ps.RegisterClusterMessageHandler(model.ClusterEventBleveRequest, bleveEngine.HandleClusterRequest)
+	ps.RegisterClusterMessageHandler(model.ClusterEventBleveResponse, bleveEngine.HandleClusterResponse)
+
 	// Step 5: Init Metrics
 	if metricsInterfaceFn != nil && ps.metricsIFace == nil { // if the metrics interface is set by options, do not override it
 		ps.metricsIFace = metricsInterfaceFn(ps, *ps.configStore.Get().SqlSettings.DriverName, *ps.configStore.Get().SqlSettings.DataSource)
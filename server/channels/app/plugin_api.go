@@ -19,6 +19,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 type PluginAPI struct {
@@ -1236,6 +1237,7 @@ func (api *PluginAPI) PublishPluginClusterEvent(ev model.PluginClusterEvent,
 		},
 		Data: ev.Data,
 	}
+	tracing.InjectClusterMessage(api.ctx.Context(), msg)
 
 	// If TargetId is empty we broadcast to all other cluster nodes.
 	if opts.TargetId == "" {
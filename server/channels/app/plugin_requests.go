@@ -12,11 +12,14 @@ import (
 	"strings"
 
 	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/plugin"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 func (ch *Channels) ServePluginRequest(w http.ResponseWriter, r *http.Request) {
@@ -70,8 +73,9 @@ func (a *App) ServeInterPluginRequest(w http.ResponseWriter, r *http.Request, so
 	}
 
 	context := &plugin.Context{
-		RequestId: model.NewId(),
-		UserAgent: r.UserAgent(),
+		RequestId:    model.NewId(),
+		UserAgent:    r.UserAgent(),
+		TraceContext: tracing.InjectContext(tracing.ExtractHTTPHeaders(r.Context(), r.Header)),
 	}
 
 	r.Header.Set("Mattermost-Plugin-ID", sourcePluginId)
@@ -225,5 +229,21 @@ func (ch *Channels) servePluginRequest(w http.ResponseWriter, r *http.Request, h
 	r.URL.RawQuery = newQuery.Encode()
 	r.URL.Path = strings.TrimPrefix(r.URL.Path, path.Join(subpath, "plugins", params["plugin_id"]))
 
+	if *ch.cfgSvc.Config().ServiceSettings.EnableOpenTracing {
+		span, ctx := tracing.StartHTTPServerSpan(r.Context(), r.Header, "plugin:ServeHTTP")
+		span.SetAttributes(
+			semconv.HTTPRequestMethodKey.String(r.Method),
+			semconv.URLPath(r.URL.Path),
+			attribute.String("plugin_id", params["plugin_id"]),
+			attribute.String("request_id", context.RequestId),
+		)
+		defer span.End()
+
+		// The plugin receives the trace context both in the request headers and in the plugin context.
+		tracing.InjectHTTPHeaders(ctx, r.Header)
+		context.TraceContext = tracing.InjectContext(ctx)
+		r = r.WithContext(ctx)
+	}
+
 	handler(context, w, r)
 }
@@ -0,0 +1,212 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// CreatePoll creates a poll along with the post that holds it. The poll must
+// have its ChannelId and UserId set.
+func (a *App) CreatePoll(c request.CTX, poll *model.Poll, rootID string) (*model.Poll, *model.AppError) {
+	if appErr := poll.IsValidContent(); appErr != nil {
+		return nil, appErr
+	}
+
+	if poll.CloseAt != 0 && poll.CloseAt <= model.GetMillis() {
+		return nil, model.NewAppError("CreatePoll", "app.poll.create.close_at_in_past.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	// The poll is attached to the post before it is created so that the
+	// posted event already carries it.
+	poll.PreSave()
+	poll.SetResults(nil)
+	post := poll.ToPost(rootID)
+	post.Metadata = &model.PostMetadata{Poll: poll}
+
+	rpost, appErr := a.CreatePostAsUser(c, post, c.Session().Id, true)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	poll.PostId = rpost.Id
+	savedPoll, err := a.Srv().Store().Poll().Save(poll)
+	if err != nil {
+		if _, delErr := a.DeletePost(c, rpost.Id, poll.UserId); delErr != nil {
+			c.Logger().Warn("Failed to delete the post of a poll that could not be saved", mlog.String("post_id", rpost.Id), mlog.Err(delErr))
+		}
+
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreatePoll", "app.poll.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	savedPoll.SetResults(nil)
+	savedPoll.MyVotes = []string{}
+
+	return savedPoll, nil
+}
+
+// GetPoll returns a poll with its results and the votes of the given user.
+func (a *App) GetPoll(pollID, userID string) (*model.Poll, *model.AppError) {
+	poll, err := a.Srv().Store().Poll().Get(pollID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetPoll", "app.poll.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetPoll", "app.poll.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	if appErr := a.setPollResults(poll, userID); appErr != nil {
+		return nil, appErr
+	}
+
+	return poll, nil
+}
+
+// GetPollForPost returns the poll held by a post with its results and the
+// votes of the given user.
+func (a *App) GetPollForPost(postID, userID string) (*model.Poll, *model.AppError) {
+	poll, err := a.Srv().Store().Poll().GetForPost(postID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetPollForPost", "app.poll.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetPollForPost", "app.poll.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	if appErr := a.setPollResults(poll, userID); appErr != nil {
+		return nil, appErr
+	}
+
+	return poll, nil
+}
+
+func (a *App) setPollResults(poll *model.Poll, userID string) *model.AppError {
+	votes, err := a.Srv().Store().Poll().GetVotes(poll.Id)
+	if err != nil {
+		return model.NewAppError("setPollResults", "app.poll.get_votes.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	poll.SetResults(votes)
+	if userID != "" {
+		poll.SetMyVotes(votes, userID)
+	}
+
+	return nil
+}
+
+// VotePoll replaces the votes of a user on a poll. An empty list of options
+// retracts the votes of the user.
+func (a *App) VotePoll(c request.CTX, pollID, userID string, optionIDs []string) (*model.Poll, *model.AppError) {
+	poll, appErr := a.GetPoll(pollID, "")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if poll.IsClosed() {
+		return nil, model.NewAppError("VotePoll", "app.poll.vote.closed.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if appErr = poll.IsValidVote(optionIDs); appErr != nil {
+		return nil, appErr
+	}
+
+	channel, appErr := a.GetChannel(c, poll.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if channel.DeleteAt > 0 {
+		return nil, model.NewAppError("VotePoll", "app.poll.vote.archived_channel.app_error", nil, "", http.StatusForbidden)
+	}
+
+	if err := a.Srv().Store().Poll().SaveVotes(poll.Id, userID, optionIDs); err != nil {
+		return nil, model.NewAppError("VotePoll", "app.poll.vote.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if appErr = a.setPollResults(poll, userID); appErr != nil {
+		return nil, appErr
+	}
+
+	a.sendPollUpdatedEvent(c, poll)
+
+	return poll, nil
+}
+
+// ClosePoll stops a poll from accepting votes.
+func (a *App) ClosePoll(c request.CTX, pollID, userID string) (*model.Poll, *model.AppError) {
+	poll, appErr := a.GetPoll(pollID, userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if poll.IsClosed() {
+		return poll, nil
+	}
+
+	poll.CloseAt = model.GetMillis()
+	if err := a.Srv().Store().Poll().Update(poll); err != nil {
+		var nfErr *store.ErrNotFound
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("ClosePoll", "app.poll.update.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("ClosePoll", "app.poll.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	a.sendPollUpdatedEvent(c, poll)
+
+	return poll, nil
+}
+
+func (a *App) deletePollForPost(c request.CTX, post *model.Post) {
+	if post.Type != model.PostTypePoll {
+		return
+	}
+
+	if err := a.Srv().Store().Poll().DeleteForPost(post.Id, model.GetMillis()); err != nil {
+		c.Logger().Warn("Failed to delete the poll of a post", mlog.String("post_id", post.Id), mlog.Err(err))
+	}
+}
+
+// sendPollUpdatedEvent publishes the results of a poll to the members of its
+// channel. The votes of the user who triggered the update are left out since
+// the event is broadcast.
+func (a *App) sendPollUpdatedEvent(c request.CTX, poll *model.Poll) {
+	pollCopy := poll.Clone()
+	pollCopy.MyVotes = nil
+
+	pollJSON, err := json.Marshal(pollCopy)
+	if err != nil {
+		c.Logger().Warn("Failed to encode poll to JSON", mlog.Err(err))
+		return
+	}
+
+	message := model.NewWebSocketEvent(model.WebsocketEventPollUpdated, "", poll.ChannelId, "", nil, "")
+	message.Add("poll", string(pollJSON))
+	message.Add("post_id", poll.PostId)
+	a.PublishWithContext(c, message)
+}
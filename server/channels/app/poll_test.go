@@ -0,0 +1,174 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func createTestPoll(t *testing.T, th *TestHelper, multipleChoice bool) *model.Poll {
+	poll, appErr := th.App.CreatePoll(th.Context, &model.Poll{
+		ChannelId:      th.BasicChannel.Id,
+		UserId:         th.BasicUser.Id,
+		Question:       "Where should we eat?",
+		Options:        model.PollOptions{{Text: "Pizza"}, {Text: "Sushi"}},
+		MultipleChoice: multipleChoice,
+	}, "")
+	require.Nil(t, appErr)
+
+	return poll
+}
+
+func TestCreatePoll(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("creates the poll and its post", func(t *testing.T) {
+		poll := createTestPoll(t, th, false)
+		require.NotEmpty(t, poll.Id)
+		require.Len(t, poll.Results, 2)
+
+		post, appErr := th.App.GetSinglePost(th.Context, poll.PostId, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.PostTypePoll, post.Type)
+		assert.Equal(t, poll.Question, post.Message)
+
+		post = th.App.PreparePostForClient(th.Context, post, false, false, false)
+		require.NotNil(t, post.Metadata.Poll)
+		assert.Equal(t, poll.Id, post.Metadata.Poll.Id)
+	})
+
+	t.Run("rejects a closing time in the past", func(t *testing.T) {
+		_, appErr := th.App.CreatePoll(th.Context, &model.Poll{
+			ChannelId: th.BasicChannel.Id,
+			UserId:    th.BasicUser.Id,
+			Question:  "Lunch?",
+			Options:   model.PollOptions{{Text: "Yes"}, {Text: "No"}},
+			CloseAt:   model.GetMillis() - 1000,
+		}, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.poll.create.close_at_in_past.app_error", appErr.Id)
+	})
+
+	t.Run("rejects invalid polls", func(t *testing.T) {
+		_, appErr := th.App.CreatePoll(th.Context, &model.Poll{
+			ChannelId: th.BasicChannel.Id,
+			UserId:    th.BasicUser.Id,
+			Question:  "Lunch?",
+			Options:   model.PollOptions{{Text: "Yes"}},
+		}, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.poll.is_valid.options.app_error", appErr.Id)
+	})
+}
+
+func TestVotePoll(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("single choice", func(t *testing.T) {
+		poll := createTestPoll(t, th, false)
+
+		voted, appErr := th.App.VotePoll(th.Context, poll.Id, th.BasicUser.Id, []string{poll.Options[0].Id})
+		require.Nil(t, appErr)
+		assert.Equal(t, 1, voted.Results[0].Votes)
+		assert.Equal(t, []string{poll.Options[0].Id}, voted.MyVotes)
+
+		voted, appErr = th.App.VotePoll(th.Context, poll.Id, th.BasicUser.Id, []string{poll.Options[1].Id})
+		require.Nil(t, appErr)
+		assert.Equal(t, 0, voted.Results[0].Votes)
+		assert.Equal(t, 1, voted.Results[1].Votes)
+		assert.Equal(t, 1, voted.TotalVoters)
+
+		_, appErr = th.App.VotePoll(th.Context, poll.Id, th.BasicUser.Id, []string{poll.Options[0].Id, poll.Options[1].Id})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.poll.is_valid_vote.single_choice.app_error", appErr.Id)
+	})
+
+	t.Run("multiple choice", func(t *testing.T) {
+		poll := createTestPoll(t, th, true)
+
+		_, appErr := th.App.VotePoll(th.Context, poll.Id, th.BasicUser.Id, []string{poll.Options[0].Id, poll.Options[1].Id})
+		require.Nil(t, appErr)
+
+		voted, appErr := th.App.VotePoll(th.Context, poll.Id, th.BasicUser2.Id, []string{poll.Options[0].Id})
+		require.Nil(t, appErr)
+		assert.Equal(t, 2, voted.Results[0].Votes)
+		assert.ElementsMatch(t, []string{th.BasicUser.Id, th.BasicUser2.Id}, voted.Results[0].UserIds)
+		assert.Equal(t, 2, voted.TotalVoters)
+	})
+
+	t.Run("closed poll", func(t *testing.T) {
+		poll := createTestPoll(t, th, false)
+
+		closed, appErr := th.App.ClosePoll(th.Context, poll.Id, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.True(t, closed.IsClosed())
+
+		_, appErr = th.App.VotePoll(th.Context, poll.Id, th.BasicUser.Id, []string{poll.Options[0].Id})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.poll.vote.closed.app_error", appErr.Id)
+	})
+}
+
+func TestDeletePollPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	poll := createTestPoll(t, th, false)
+
+	_, appErr := th.App.DeletePost(th.Context, poll.PostId, th.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	_, appErr = th.App.GetPoll(poll.Id, th.BasicUser.Id)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.poll.get.app_error", appErr.Id)
+}
+
+func TestBuildPostPoll(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("exports the votes with the voters", func(t *testing.T) {
+		poll := createTestPoll(t, th, false)
+		_, appErr := th.App.VotePoll(th.Context, poll.Id, th.BasicUser.Id, []string{poll.Options[1].Id})
+		require.Nil(t, appErr)
+
+		post, appErr := th.App.GetSinglePost(th.Context, poll.PostId, false)
+		require.Nil(t, appErr)
+
+		pollLine, appErr := th.App.buildPostPoll(th.Context, post)
+		require.Nil(t, appErr)
+		require.NotNil(t, pollLine.Votes)
+		require.Len(t, *pollLine.Votes, 1)
+		assert.Equal(t, th.BasicUser.Username, *(*pollLine.Votes)[0].User)
+		assert.Equal(t, poll.Options[1].Text, *(*pollLine.Votes)[0].Option)
+	})
+
+	t.Run("leaves out the votes of anonymous polls", func(t *testing.T) {
+		poll, appErr := th.App.CreatePoll(th.Context, &model.Poll{
+			ChannelId: th.BasicChannel.Id,
+			UserId:    th.BasicUser.Id,
+			Question:  "Lunch?",
+			Options:   model.PollOptions{{Text: "Yes"}, {Text: "No"}},
+			Anonymous: true,
+		}, "")
+		require.Nil(t, appErr)
+		_, appErr = th.App.VotePoll(th.Context, poll.Id, th.BasicUser.Id, []string{poll.Options[0].Id})
+		require.Nil(t, appErr)
+
+		post, appErr := th.App.GetSinglePost(th.Context, poll.PostId, false)
+		require.Nil(t, appErr)
+
+		pollLine, appErr := th.App.buildPostPoll(th.Context, post)
+		require.Nil(t, appErr)
+		assert.True(t, *pollLine.Anonymous)
+		assert.Nil(t, pollLine.Votes)
+	})
+}
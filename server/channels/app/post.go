@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -315,6 +316,11 @@ func (a *App) CreatePost(c request.CTX, post *model.Post, channel *model.Channel
 		return nil, rejectionError
 	}
 
+	dlpHits, err := a.applyDLPRulesToPost(c, post, channel)
+	if err != nil {
+		return nil, err
+	}
+
 	// Pre-fill the CreateAt field for link previews to get the correct timestamp.
 	if post.CreateAt == 0 {
 		post.CreateAt = model.GetMillis()
@@ -405,11 +411,20 @@ func (a *App) CreatePost(c request.CTX, post *model.Post, channel *model.Channel
 		c.Logger().Warn("Failed to handle post events", mlog.Err(err))
 	}
 
+	if a.savedSearchAlertsForPost(rpost) {
+		alertPost := rpost.Clone()
+		a.Srv().Go(func() {
+			a.queueSavedSearchAlertsForPost(request.EmptyContext(a.Log()), alertPost, channel)
+		})
+	}
+
 	// Send any ephemeral posts after the post is created to ensure it shows up after the latest post created
 	if ephemeralPost != nil {
 		a.SendEphemeralPost(c, post.UserId, ephemeralPost)
 	}
 
+	a.handleDLPHits(c, rpost, channel, dlpHits)
+
 	rpost, err = a.SanitizePostMetadataForUser(c, rpost, c.Session().UserId)
 	if err != nil {
 		return nil, err
@@ -589,7 +604,7 @@ func (a *App) SendEphemeralPost(c request.CTX, userID string, post *model.Post)
 		c.Logger().Warn("Failed to encode post to JSON", mlog.Err(jsonErr))
 	}
 	message.Add("post", postJSON)
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	return post
 }
@@ -623,7 +638,7 @@ func (a *App) UpdateEphemeralPost(c request.CTX, userID string, post *model.Post
 		c.Logger().Warn("Failed to encode post to JSON", mlog.Err(jsonErr))
 	}
 	message.Add("post", postJSON)
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	return post
 }
@@ -643,7 +658,7 @@ func (a *App) DeleteEphemeralPost(rctx request.CTX, userID, postID string) {
 		rctx.Logger().Warn("Failed to encode post to JSON", mlog.Err(jsonErr))
 	}
 	message.Add("post", postJSON)
-	a.Publish(message)
+	a.PublishWithContext(rctx, message)
 }
 
 func (a *App) UpdatePost(c request.CTX, receivedUpdatedPost *model.Post, safeUpdate bool) (*model.Post, *model.AppError) {
@@ -730,6 +745,11 @@ func (a *App) UpdatePost(c request.CTX, receivedUpdatedPost *model.Post, safeUpd
 	// the last known good.
 	newPost.Metadata = oldPost.Metadata
 
+	dlpHits, appErr := a.applyDLPRulesToPost(c, newPost, channel)
+	if appErr != nil {
+		return nil, appErr
+	}
+
 	rpost, nErr := a.Srv().Store().Post().Update(c, newPost, oldPost)
 	if nErr != nil {
 		switch {
@@ -740,6 +760,13 @@ func (a *App) UpdatePost(c request.CTX, receivedUpdatedPost *model.Post, safeUpd
 		}
 	}
 
+	if a.editHistoryPruningEnabled() {
+		prunedPost := rpost.Clone()
+		a.Srv().Go(func() {
+			a.pruneEditHistory(request.EmptyContext(a.Log()), prunedPost)
+		})
+	}
+
 	pluginOldPost := oldPost.ForPlugin()
 	pluginNewPost := newPost.ForPlugin()
 	a.Srv().Go(func() {
@@ -770,6 +797,8 @@ func (a *App) UpdatePost(c request.CTX, receivedUpdatedPost *model.Post, safeUpd
 
 	a.invalidateCacheForChannelPosts(rpost.ChannelId)
 
+	a.handleDLPHits(c, rpost, channel, dlpHits)
+
 	userID := c.Session().UserId
 	sanitizedPost, appErr := a.SanitizePostMetadataForUser(c, rpost, userID)
 	if appErr != nil {
@@ -804,7 +833,7 @@ func (a *App) publishWebsocketEventForPost(rctx request.CTX, post *model.Post, m
 		return appErr
 	}
 
-	a.Publish(message)
+	a.PublishWithContext(rctx, message)
 	return nil
 }
 
@@ -1546,6 +1575,81 @@ func (a *App) convertUserNameToUserIds(c request.CTX, usernames []string) []stri
 	return usernames
 }
 
+// resolveSearchChannelPatterns converts the regular expressions of the in:
+// filters of the params into the IDs of the matching channels of the user.
+// It returns false when the params are limited to channels matching patterns
+// and there are none.
+func (a *App) resolveSearchChannelPatterns(params *model.SearchParams, userID, teamID string, includeDeleted bool) (bool, *model.AppError) {
+	if len(params.InChannelPatterns) == 0 && len(params.ExcludedChannelPatterns) == 0 {
+		return true, nil
+	}
+
+	channels, err := a.Srv().Store().Channel().GetChannelsByUser(userID, includeDeleted, 0, -1, "")
+	if err != nil {
+		return false, model.NewAppError("resolveSearchChannelPatterns", "app.channel.get_channels.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	matchingChannels := func(patterns []string) ([]string, *model.AppError) {
+		channelIDs := []string{}
+		for _, pattern := range patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, model.NewAppError("resolveSearchChannelPatterns", "model.search_query.invalid_channel_pattern.app_error", map[string]any{"Pattern": pattern}, "", http.StatusBadRequest).Wrap(err)
+			}
+			for _, channel := range channels {
+				if teamID != "" && channel.TeamId != teamID && channel.TeamId != "" {
+					continue
+				}
+				if re.MatchString(channel.Name) {
+					channelIDs = append(channelIDs, channel.Id)
+				}
+			}
+		}
+		return channelIDs, nil
+	}
+
+	included, appErr := matchingChannels(params.InChannelPatterns)
+	if appErr != nil {
+		return false, appErr
+	}
+	excluded, appErr := matchingChannels(params.ExcludedChannelPatterns)
+	if appErr != nil {
+		return false, appErr
+	}
+
+	if len(params.InChannelPatterns) > 0 && len(included) == 0 {
+		return false, nil
+	}
+
+	params.InChannels = append(params.InChannels, included...)
+	params.ExcludedChannels = append(params.ExcludedChannels, excluded...)
+	params.InChannelPatterns = nil
+	params.ExcludedChannelPatterns = nil
+	return true, nil
+}
+
+// resolveSearchMentions replaces @me in the mentions: filters of the params
+// with the username of the user searching.
+func (a *App) resolveSearchMentions(params *model.SearchParams, userID string) *model.AppError {
+	if !slices.Contains(params.MentionedUsers, model.SearchMentionsMe) && !slices.Contains(params.ExcludedMentionedUsers, model.SearchMentionsMe) {
+		return nil
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, usernames := range [][]string{params.MentionedUsers, params.ExcludedMentionedUsers} {
+		for i, username := range usernames {
+			if username == model.SearchMentionsMe {
+				usernames[i] = user.Username
+			}
+		}
+	}
+	return nil
+}
+
 // GetLastAccessiblePostTime returns CreateAt time(from cache) of the last accessible post as per the cloud limit
 func (a *App) GetLastAccessiblePostTime() (int64, *model.AppError) {
 	license := a.Srv().License()
@@ -1654,14 +1758,26 @@ func (a *App) SearchPostsInTeam(teamID string, paramsList []*model.SearchParams)
 }
 
 func (a *App) SearchPostsForUser(c request.CTX, terms string, userID string, teamID string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page, perPage int) (*model.PostSearchResults, *model.AppError) {
-	var postSearchResults *model.PostSearchResults
-	paramsList := model.ParseSearchParams(strings.TrimSpace(terms), timeZoneOffset)
-	includeDeleted := includeDeletedChannels && *a.Config().TeamSettings.ExperimentalViewArchivedChannels
-
 	if !*a.Config().ServiceSettings.EnablePostSearch {
 		return nil, model.NewAppError("SearchPostsForUser", "store.sql_post.search.disabled", nil, fmt.Sprintf("teamId=%v userId=%v", teamID, userID), http.StatusNotImplemented)
 	}
 
+	query, appErr := model.ParseSearchQuery(strings.TrimSpace(terms))
+	if appErr != nil {
+		return nil, appErr
+	}
+	paramsList, appErr := query.SearchParams(timeZoneOffset)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.searchPostsForUserWithParams(c, paramsList, userID, teamID, isOrSearch, includeDeletedChannels, page, perPage)
+}
+
+// searchPostsForUserWithParams resolves the channels and users named by the
+// parsed search params of a user before running the search.
+func (a *App) searchPostsForUserWithParams(c request.CTX, paramsList []*model.SearchParams, userID string, teamID string, isOrSearch bool, includeDeletedChannels bool, page, perPage int) (*model.PostSearchResults, *model.AppError) {
+	includeDeleted := includeDeletedChannels && *a.Config().TeamSettings.ExperimentalViewArchivedChannels
 	finalParamsList := []*model.SearchParams{}
 
 	for _, params := range paramsList {
@@ -1680,6 +1796,17 @@ func (a *App) SearchPostsForUser(c request.CTX, terms string, userID string, tea
 			params.FromUsers = a.convertUserNameToUserIds(c, params.FromUsers)
 			params.ExcludedUsers = a.convertUserNameToUserIds(c, params.ExcludedUsers)
 
+			if matched, appErr := a.resolveSearchChannelPatterns(params, userID, teamID, includeDeleted); appErr != nil {
+				return nil, appErr
+			} else if !matched {
+				// No channel matches the patterns, so no post can match the params
+				continue
+			}
+
+			if appErr := a.resolveSearchMentions(params, userID); appErr != nil {
+				return nil, appErr
+			}
+
 			finalParamsList = append(finalParamsList, params)
 		}
 	}
@@ -2173,7 +2300,7 @@ func (a *App) SetPostReminder(rctx request.CTX, postID, userID string, targetTim
 		rctx.Logger().Warn("Failed to encode post to JSON", mlog.Err(jsonErr))
 	}
 	message.Add("post", postJSON)
-	a.Publish(message)
+	a.PublishWithContext(rctx, message)
 
 	return nil
 }
@@ -2671,18 +2798,20 @@ func (a *App) CleanUpAfterPostDeletion(c request.CTX, post *model.Post, deleteBy
 	userMessage := model.NewWebSocketEvent(model.WebsocketEventPostDeleted, "", post.ChannelId, "", nil, "")
 	userMessage.Add("post", string(postJSON))
 	userMessage.GetBroadcast().ContainsSanitizedData = true
-	a.Publish(userMessage)
+	a.PublishWithContext(c, userMessage)
 
 	adminMessage := model.NewWebSocketEvent(model.WebsocketEventPostDeleted, "", post.ChannelId, "", nil, "")
 	adminMessage.Add("post", string(postJSON))
 	adminMessage.Add("delete_by", deleteByID)
 	adminMessage.GetBroadcast().ContainsSensitiveData = true
-	a.Publish(adminMessage)
+	a.PublishWithContext(c, adminMessage)
 
 	a.Srv().Go(func() {
 		a.deleteFlaggedPosts(c, post.Id)
 	})
 
+	a.deletePollForPost(c, post)
+
 	pluginPost := post.ForPlugin()
 	pluginContext := pluginContext(c)
 	a.Srv().Go(func() {
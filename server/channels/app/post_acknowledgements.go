@@ -139,5 +139,5 @@ func (a *App) sendAcknowledgementEvent(rctx request.CTX, event model.WebsocketEv
 		rctx.Logger().Warn("Failed to encode acknowledgement to JSON", mlog.Err(err))
 	}
 	message.Add("acknowledgement", string(acknowledgementJSON))
-	a.Publish(message)
+	a.PublishWithContext(rctx, message)
 }
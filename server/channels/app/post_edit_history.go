@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// GetPostEditHistory returns the previous versions of the post, newest first,
+// each with the changes made by the edit which replaced it.
+func (a *App) GetPostEditHistory(rctx request.CTX, postID string) (*model.PostEditHistory, *model.AppError) {
+	post, err := a.Srv().Store().Post().GetSingle(rctx, postID, true)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetPostEditHistory", "app.post.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetPostEditHistory", "app.post.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	history := &model.PostEditHistory{
+		PostId:    post.Id,
+		Revisions: []*model.PostRevision{},
+	}
+
+	revisions, err := a.Srv().Store().Post().GetEditHistoryForPost(post.Id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return history, nil
+		default:
+			return nil, model.NewAppError("GetPostEditHistory", "app.post.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	next := post
+	for _, revision := range revisions {
+		history.Revisions = append(history.Revisions, model.NewPostRevision(revision, next))
+		next = revision
+	}
+
+	return history, nil
+}
+
+// editHistoryPruningEnabled reports whether the previous versions of edited
+// posts are limited. Nothing is deleted while the content may still have to be
+// exported.
+func (a *App) editHistoryPruningEnabled() bool {
+	return *a.Config().ServiceSettings.PostEditHistoryLimit > 0 && !*a.Config().MessageExportSettings.EnableExport
+}
+
+// pruneEditHistory deletes the oldest previous versions of the post beyond the
+// configured limit, unless the post is preserved by a legal hold. Callers check
+// editHistoryPruningEnabled first.
+func (a *App) pruneEditHistory(rctx request.CTX, post *model.Post) {
+	limit := *a.Config().ServiceSettings.PostEditHistoryLimit
+
+	if held, err := a.Srv().Store().LegalHold().IsUserHeld(post.UserId); err != nil || held {
+		return
+	}
+	if held, err := a.Srv().Store().LegalHold().IsChannelHeld(post.ChannelId); err != nil || held {
+		return
+	}
+
+	if _, err := a.Srv().Store().Post().PermanentDeleteEditHistoryForPost(post.Id, limit); err != nil {
+		rctx.Logger().Warn("Failed to prune the edit history of the post", mlog.String("post_id", post.Id), mlog.Err(err))
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestGetPostEditHistory(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	fileInfo, err := th.App.Srv().Store().FileInfo().Save(th.Context, &model.FileInfo{
+		CreatorId: th.BasicUser.Id,
+		Path:      "path.txt",
+		Name:      "path.txt",
+	})
+	require.NoError(t, err)
+
+	post := th.CreatePost(th.BasicChannel)
+
+	time.Sleep(time.Millisecond)
+	edited := post.Clone()
+	edited.Message = "edited message"
+	edited.FileIds = model.StringArray{fileInfo.Id}
+	_, appErr := th.App.UpdatePost(th.Context, edited, false)
+	require.Nil(t, appErr)
+
+	history, appErr := th.App.GetPostEditHistory(th.Context, post.Id)
+	require.Nil(t, appErr)
+	require.Len(t, history.Revisions, 1)
+
+	revision := history.Revisions[0]
+	assert.Equal(t, post.Message, revision.Post.Message)
+	assert.Equal(t, []string{fileInfo.Id}, revision.AddedFileIds)
+	assert.Empty(t, revision.RemovedFileIds)
+	assert.Equal(t, model.PostDiffInsert, revision.MessageDiff[len(revision.MessageDiff)-1].Op)
+
+	t.Run("missing post", func(t *testing.T) {
+		_, appErr := th.App.GetPostEditHistory(th.Context, model.NewId())
+		require.NotNil(t, appErr)
+	})
+}
+
+func TestPruneEditHistory(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	editPost := func(post *model.Post, times int) {
+		for i := 0; i < times; i++ {
+			time.Sleep(time.Millisecond)
+			edited := post.Clone()
+			edited.Message = fmt.Sprintf("edit %d", i)
+			_, appErr := th.App.UpdatePost(th.Context, edited, false)
+			require.Nil(t, appErr)
+		}
+	}
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.PostEditHistoryLimit = 2
+	})
+
+	t.Run("keeps the configured number of revisions", func(t *testing.T) {
+		post := th.CreatePost(th.BasicChannel)
+		editPost(post, 4)
+
+		// The history is pruned in the background.
+		require.EventuallyWithT(t, func(c *assert.CollectT) {
+			revisions, appErr := th.App.GetEditHistoryForPost(post.Id)
+			require.Nil(c, appErr)
+			require.Len(c, revisions, 2)
+			assert.Equal(c, "edit 2", revisions[0].Message)
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+
+	t.Run("keeps all revisions while exporting messages", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.MessageExportSettings.EnableExport = true
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.MessageExportSettings.EnableExport = false
+		})
+
+		post := th.CreatePost(th.BasicChannel)
+		editPost(post, 4)
+
+		revisions, appErr := th.App.GetEditHistoryForPost(post.Id)
+		require.Nil(t, appErr)
+		require.Len(t, revisions, 4)
+	})
+}
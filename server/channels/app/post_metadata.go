@@ -143,6 +143,16 @@ func (a *App) PreparePostForClient(c request.CTX, originalPost *model.Post, isNe
 		post.Metadata.Files = fileInfos
 	}
 
+	// Poll and its results. While the post of a poll is being created, the
+	// poll is not saved yet and the one attached to the post is kept.
+	if post.Type == model.PostTypePoll {
+		if poll, err := a.GetPollForPost(post.Id, c.Session().UserId); err == nil {
+			post.Metadata.Poll = poll
+		} else if post.Metadata.Poll == nil || err.StatusCode != http.StatusNotFound {
+			c.Logger().Warn("Failed to get the poll for a post", mlog.String("post_id", post.Id), mlog.Err(err))
+		}
+	}
+
 	if includePriority && a.IsPostPriorityEnabled() && post.RootId == "" {
 		// Post's Priority if any
 		if priority, err := a.GetPriorityForPost(post.Id); err != nil {
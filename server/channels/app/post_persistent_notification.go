@@ -297,7 +297,7 @@ func (a *App) sendPersistentNotifications(post *model.Post, channel *model.Chann
 		return errors.Errorf("mentioned users: %d are more than allowed users: %d", len(mentionedUsersList), *a.Config().TeamSettings.MaxNotificationsPerChannel)
 	}
 
-	if a.canSendPushNotifications() {
+	if a.canSendWebPushNotifications() || a.canSendPushNotifications() {
 		for _, userID := range mentionedUsersList {
 			user := profileMap[userID]
 			if user == nil {
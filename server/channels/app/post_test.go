@@ -1888,6 +1888,62 @@ func TestSearchPostsForUser(t *testing.T) {
 			assert.Equal(t, v, resultsWithoutPrefix.Posts[k], "post at %s was different", k)
 		}
 	})
+
+	t.Run("should search with the advanced syntax", func(t *testing.T) {
+		th, posts := setup(t, false)
+		defer th.TearDown()
+
+		results, err := th.App.SearchPostsForUser(th.Context, searchTerm+" -is:pinned", th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, perPage)
+		require.Nil(t, err)
+		assert.Len(t, results.Order, len(posts))
+
+		results, err = th.App.SearchPostsForUser(th.Context, searchTerm+" (is:pinned OR mentions:@me)", th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, perPage)
+		require.Nil(t, err)
+		assert.Empty(t, results.Order)
+	})
+
+	t.Run("should limit the search to the channels matching a pattern", func(t *testing.T) {
+		th, posts := setup(t, false)
+		defer th.TearDown()
+
+		results, err := th.App.SearchPostsForUser(th.Context, fmt.Sprintf("in:/^%s$/ %s", th.BasicChannel.Name, searchTerm), th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, perPage)
+		require.Nil(t, err)
+		assert.Len(t, results.Order, len(posts))
+
+		results, err = th.App.SearchPostsForUser(th.Context, "in:/^no-such-channel$/ "+searchTerm, th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, perPage)
+		require.Nil(t, err)
+		assert.Empty(t, results.Order)
+	})
+
+	t.Run("should return an error for invalid searches", func(t *testing.T) {
+		th, _ := setup(t, false)
+		defer th.TearDown()
+
+		_, err := th.App.SearchPostsForUser(th.Context, "("+searchTerm, th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, perPage)
+		require.NotNil(t, err)
+		assert.Equal(t, "model.search_query.unbalanced_parentheses.app_error", err.Id)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+
+	t.Run("should search the database when the search engine doesn't support the filters", func(t *testing.T) {
+		th, posts := setup(t, true)
+		defer th.TearDown()
+
+		es := &mocks.SearchEngineInterface{}
+		es.On("Start").Return(nil).Maybe()
+		es.On("IsActive").Return(true)
+		es.On("IsSearchEnabled").Return(true)
+		es.On("GetName").Return("elasticsearch")
+		th.App.Srv().Platform().SearchEngine.ElasticsearchEngine = es
+		defer func() {
+			th.App.Srv().Platform().SearchEngine.ElasticsearchEngine = nil
+		}()
+
+		results, err := th.App.SearchPostsForUser(th.Context, searchTerm+" -is:pinned", th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, perPage)
+		require.Nil(t, err)
+		assert.Len(t, results.Order, len(posts))
+		es.AssertNotCalled(t, "SearchPosts", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 func TestCountMentionsFromPost(t *testing.T) {
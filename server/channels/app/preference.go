@@ -65,7 +65,7 @@ func (a *App) UpdatePreferences(c request.CTX, userID string, preferences model.
 
 	message := model.NewWebSocketEvent(model.WebsocketEventSidebarCategoryUpdated, "", "", userID, nil, "")
 	// TODO this needs to be updated to include information on which categories changed
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	message = model.NewWebSocketEvent(model.WebsocketEventPreferencesChanged, "", "", userID, nil, "")
 	prefsJSON, jsonErr := json.Marshal(preferences)
@@ -73,7 +73,7 @@ func (a *App) UpdatePreferences(c request.CTX, userID string, preferences model.
 		return model.NewAppError("UpdatePreferences", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
 	}
 	message.Add("preferences", string(prefsJSON))
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	pluginContext := pluginContext(c)
 	a.Srv().Go(func() {
@@ -107,7 +107,7 @@ func (a *App) DeletePreferences(c request.CTX, userID string, preferences model.
 
 	message := model.NewWebSocketEvent(model.WebsocketEventSidebarCategoryUpdated, "", "", userID, nil, "")
 	// TODO this needs to be updated to include information on which categories changed
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	message = model.NewWebSocketEvent(model.WebsocketEventPreferencesDeleted, "", "", userID, nil, "")
 	prefsJSON, jsonErr := json.Marshal(preferences)
@@ -115,7 +115,7 @@ func (a *App) DeletePreferences(c request.CTX, userID string, preferences model.
 		return model.NewAppError("DeletePreferences", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
 	}
 	message.Add("preferences", string(prefsJSON))
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	return nil
 }
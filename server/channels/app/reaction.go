@@ -174,5 +174,5 @@ func (a *App) sendReactionEvent(rctx request.CTX, event model.WebsocketEventType
 		rctx.Logger().Warn("Failed to encode reaction to JSON", mlog.Err(err))
 	}
 	message.Add("reaction", string(reactionJSON))
-	a.Publish(message)
+	a.PublishWithContext(rctx, message)
 }
@@ -0,0 +1,150 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// readReceiptsEnabledForChannel reports whether read receipts are shown in the
+// channel. They are always available in direct and group messages, and in
+// other channels when they have no more members than the configured limit.
+func (a *App) readReceiptsEnabledForChannel(channel *model.Channel) (bool, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableReadReceipts {
+		return false, nil
+	}
+
+	if channel.IsGroupOrDirect() {
+		return true, nil
+	}
+
+	limit := *a.Config().ServiceSettings.ReadReceiptsMaxChannelMembers
+	if limit <= 0 {
+		return false, nil
+	}
+
+	count, err := a.Srv().Store().Channel().GetMemberCount(channel.Id, true)
+	if err != nil {
+		return false, model.NewAppError("readReceiptsEnabledForChannel", "app.channel.get_member_count.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return count <= int64(limit), nil
+}
+
+// sendsReadReceipts reports whether the user lets other members see when they
+// have read a post. Users send receipts unless they have turned them off.
+func (a *App) sendsReadReceipts(userID string) bool {
+	preference, err := a.Srv().Store().Preference().Get(userID, model.PreferenceCategoryAdvancedSettings, model.PreferenceNameSendReadReceipts)
+	if err != nil {
+		return true
+	}
+	return preference.Value != "false"
+}
+
+// getUsersNotSendingReadReceipts returns the ids of the given users who have
+// turned off read receipts.
+func (a *App) getUsersNotSendingReadReceipts(userIDs []string) (map[string]bool, *model.AppError) {
+	preferences, err := a.Srv().Store().Preference().GetCategoryAndNameForUsers(userIDs, model.PreferenceCategoryAdvancedSettings, model.PreferenceNameSendReadReceipts)
+	if err != nil {
+		return nil, model.NewAppError("getUsersNotSendingReadReceipts", "app.preference.get_category.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	notSending := map[string]bool{}
+	for _, preference := range preferences {
+		if preference.Value == "false" {
+			notSending[preference.UserId] = true
+		}
+	}
+	return notSending, nil
+}
+
+// GetPostReadReceipts returns the members of the post's channel who have viewed
+// it since the post was created. A member's last viewed time is all that is
+// needed, so no state is stored per post.
+func (a *App) GetPostReadReceipts(rctx request.CTX, post *model.Post) (*model.PostReadReceipts, *model.AppError) {
+	channel, appErr := a.GetChannel(rctx, post.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	enabled, appErr := a.readReceiptsEnabledForChannel(channel)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !enabled {
+		return nil, model.NewAppError("GetPostReadReceipts", "app.post.read_receipts.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	limit := model.ChannelGroupMaxUsers
+	if !channel.IsGroupOrDirect() {
+		limit = *a.Config().ServiceSettings.ReadReceiptsMaxChannelMembers
+	}
+
+	members, err := a.Srv().Store().Channel().GetMembers(channel.Id, 0, limit)
+	if err != nil {
+		return nil, model.NewAppError("GetPostReadReceipts", "app.channel.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	userIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.UserId)
+	}
+	notSending, appErr := a.getUsersNotSendingReadReceipts(userIDs)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	receipts := &model.PostReadReceipts{
+		PostId:    post.Id,
+		ChannelId: channel.Id,
+		ReadBy:    []string{},
+	}
+	for _, member := range members {
+		if member.UserId == post.UserId || member.LastViewedAt < post.CreateAt || notSending[member.UserId] {
+			continue
+		}
+		receipts.ReadBy = append(receipts.ReadBy, member.UserId)
+	}
+
+	return receipts, nil
+}
+
+// publishPostsRead tells the other members of each channel which has read
+// receipts enabled that the user has read its posts up to the given time.
+func (a *App) publishPostsRead(rctx request.CTX, userID string, lastViewedAt map[string]int64) {
+	if !*a.Config().ServiceSettings.EnableReadReceipts || len(lastViewedAt) == 0 {
+		return
+	}
+
+	if !a.sendsReadReceipts(userID) {
+		return
+	}
+
+	for channelID, viewedAt := range lastViewedAt {
+		channel, appErr := a.GetChannel(rctx, channelID)
+		if appErr != nil {
+			rctx.Logger().Warn("Failed to get the channel for read receipts", mlog.String("channel_id", channelID), mlog.Err(appErr))
+			continue
+		}
+
+		enabled, appErr := a.readReceiptsEnabledForChannel(channel)
+		if appErr != nil {
+			rctx.Logger().Warn("Failed to check whether read receipts are enabled", mlog.String("channel_id", channelID), mlog.Err(appErr))
+			continue
+		}
+		if !enabled {
+			continue
+		}
+
+		message := model.NewWebSocketEvent(model.WebsocketEventPostsRead, "", channelID, "", map[string]bool{userID: true}, "")
+		message.Add("channel_id", channelID)
+		message.Add("user_id", userID)
+		message.Add("last_viewed_at", viewedAt)
+		a.PublishWithContext(rctx, message)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestGetPostReadReceipts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableReadReceipts = true
+		*cfg.ServiceSettings.ReadReceiptsMaxChannelMembers = 0
+	})
+
+	dm := th.CreateDmChannel(th.BasicUser2)
+	post := th.CreatePost(dm)
+
+	t.Run("unread post", func(t *testing.T) {
+		receipts, appErr := th.App.GetPostReadReceipts(th.Context, post)
+		require.Nil(t, appErr)
+		assert.Equal(t, post.Id, receipts.PostId)
+		assert.Empty(t, receipts.ReadBy)
+	})
+
+	time.Sleep(time.Millisecond)
+	_, appErr := th.App.MarkChannelsAsViewed(th.Context, []string{dm.Id}, th.BasicUser2.Id, "", false, false)
+	require.Nil(t, appErr)
+
+	t.Run("read post", func(t *testing.T) {
+		receipts, appErr := th.App.GetPostReadReceipts(th.Context, post)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{th.BasicUser2.Id}, receipts.ReadBy)
+	})
+
+	t.Run("reader opted out", func(t *testing.T) {
+		appErr := th.App.UpdatePreferences(th.Context, th.BasicUser2.Id, model.Preferences{{
+			UserId:   th.BasicUser2.Id,
+			Category: model.PreferenceCategoryAdvancedSettings,
+			Name:     model.PreferenceNameSendReadReceipts,
+			Value:    "false",
+		}})
+		require.Nil(t, appErr)
+		defer func() {
+			appErr := th.App.DeletePreferences(th.Context, th.BasicUser2.Id, model.Preferences{{
+				UserId:   th.BasicUser2.Id,
+				Category: model.PreferenceCategoryAdvancedSettings,
+				Name:     model.PreferenceNameSendReadReceipts,
+			}})
+			require.Nil(t, appErr)
+		}()
+
+		receipts, appErr := th.App.GetPostReadReceipts(th.Context, post)
+		require.Nil(t, appErr)
+		assert.Empty(t, receipts.ReadBy)
+	})
+
+	t.Run("channel over the member limit", func(t *testing.T) {
+		_, appErr := th.App.GetPostReadReceipts(th.Context, th.CreatePost(th.BasicChannel))
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotImplemented, appErr.StatusCode)
+	})
+
+	t.Run("channel within the member limit", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.ReadReceiptsMaxChannelMembers = 10
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.ReadReceiptsMaxChannelMembers = 0
+		})
+
+		_, appErr := th.App.GetPostReadReceipts(th.Context, th.CreatePost(th.BasicChannel))
+		require.Nil(t, appErr)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.EnableReadReceipts = false
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.EnableReadReceipts = true
+		})
+
+		_, appErr := th.App.GetPostReadReceipts(th.Context, post)
+		require.NotNil(t, appErr)
+	})
+}
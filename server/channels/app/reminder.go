@@ -0,0 +1,286 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const (
+	remindersProcessingBatchSize = 500
+
+	// completedRemindersRetention is how long delivered reminders are kept
+	// so that they can still be snoozed.
+	completedRemindersRetention = 7 * 24 * time.Hour
+)
+
+// CreateReminder saves a reminder after checking that its creator is allowed
+// to send messages to its target.
+func (a *App) CreateReminder(rctx request.CTX, reminder *model.Reminder) (*model.Reminder, *model.AppError) {
+	if reminder.TriggerAt <= model.GetMillis() {
+		return nil, model.NewAppError("CreateReminder", "app.reminder.create.trigger_at_in_past.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	switch reminder.TargetType {
+	case model.ReminderTargetUser:
+		target, appErr := a.GetUser(reminder.TargetId)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		if target.DeleteAt != 0 || target.IsBot {
+			return nil, model.NewAppError("CreateReminder", "app.reminder.create.target_user.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		canSee, appErr := a.UserCanSeeOtherUser(rctx, reminder.UserId, target.Id)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if !canSee {
+			return nil, model.NewAppError("CreateReminder", "app.reminder.create.target_user.app_error", nil, "", http.StatusForbidden)
+		}
+	case model.ReminderTargetChannel:
+		channel, appErr := a.GetChannel(rctx, reminder.TargetId)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		if channel.DeleteAt != 0 {
+			return nil, model.NewAppError("CreateReminder", "app.reminder.create.channel_archived.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		if !a.hasPermissionToCreatePostInChannel(rctx, reminder.UserId, channel) {
+			return nil, model.NewAppError("CreateReminder", "app.reminder.create.target_channel.app_error", nil, "", http.StatusForbidden)
+		}
+	}
+
+	savedReminder, err := a.Srv().Store().Reminder().Save(reminder)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreateReminder", "app.reminder.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return savedReminder, nil
+}
+
+func (a *App) GetReminder(reminderID string) (*model.Reminder, *model.AppError) {
+	reminder, err := a.Srv().Store().Reminder().Get(reminderID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetReminder", "app.reminder.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetReminder", "app.reminder.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return reminder, nil
+}
+
+// GetRemindersForUser returns the reminders created by the given user.
+func (a *App) GetRemindersForUser(userID string) ([]*model.Reminder, *model.AppError) {
+	reminders, err := a.Srv().Store().Reminder().GetForUser(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetRemindersForUser", "app.reminder.get_for_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return reminders, nil
+}
+
+// GetLastTriggeredReminder returns the reminder most recently delivered to the
+// given user.
+func (a *App) GetLastTriggeredReminder(userID string) (*model.Reminder, *model.AppError) {
+	reminder, err := a.Srv().Store().Reminder().GetLastTriggeredForUser(userID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetLastTriggeredReminder", "app.reminder.get_last_triggered.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetLastTriggeredReminder", "app.reminder.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return reminder, nil
+}
+
+// DeleteReminder deletes a reminder created by userID.
+func (a *App) DeleteReminder(rctx request.CTX, userID, reminderID string) (*model.Reminder, *model.AppError) {
+	reminder, appErr := a.GetReminder(reminderID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if reminder.UserId != userID {
+		return nil, model.NewAppError("DeleteReminder", "app.reminder.delete.permissions.app_error", nil, "", http.StatusForbidden)
+	}
+
+	if err := a.Srv().Store().Reminder().Delete(reminderID); err != nil {
+		return nil, model.NewAppError("DeleteReminder", "app.reminder.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return reminder, nil
+}
+
+// SnoozeReminder moves the next delivery of a reminder to snoozeUntil. Both
+// the creator of the reminder and the user it is delivered to can snooze it.
+func (a *App) SnoozeReminder(rctx request.CTX, userID, reminderID string, snoozeUntil int64) (*model.Reminder, *model.AppError) {
+	reminder, appErr := a.GetReminder(reminderID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	isTarget := reminder.TargetType == model.ReminderTargetUser && reminder.TargetId == userID
+	if reminder.UserId != userID && !isTarget {
+		return nil, model.NewAppError("SnoozeReminder", "app.reminder.snooze.permissions.app_error", nil, "", http.StatusForbidden)
+	}
+
+	if snoozeUntil <= model.GetMillis() {
+		return nil, model.NewAppError("SnoozeReminder", "app.reminder.snooze.in_past.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	reminder.TriggerAt = snoozeUntil
+	if err := a.Srv().Store().Reminder().Update(reminder); err != nil {
+		var appErr *model.AppError
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("SnoozeReminder", "app.reminder.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("SnoozeReminder", "app.reminder.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return reminder, nil
+}
+
+// ProcessReminders delivers every reminder that is due and deletes the ones
+// completed long enough ago.
+func (a *App) ProcessReminders(rctx request.CTX) error {
+	systemBot, appErr := a.GetSystemBot(rctx)
+	if appErr != nil {
+		return appErr
+	}
+
+	now := time.Now()
+	for {
+		reminders, err := a.Srv().Store().Reminder().GetDue(now.UnixMilli(), remindersProcessingBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, reminder := range reminders {
+			// The reminder is rescheduled before being delivered so that a
+			// failure cannot deliver it over and over.
+			reminder.MarkTriggered(now)
+			if err := a.Srv().Store().Reminder().Update(reminder); err != nil {
+				return err
+			}
+
+			if appErr := a.deliverReminder(rctx, systemBot, reminder); appErr != nil {
+				rctx.Logger().Warn("Failed to deliver reminder", mlog.String("reminder_id", reminder.Id), mlog.Err(appErr))
+			}
+		}
+
+		if len(reminders) < remindersProcessingBatchSize {
+			break
+		}
+	}
+
+	return a.Srv().Store().Reminder().DeleteCompletedBefore(now.Add(-completedRemindersRetention).UnixMilli())
+}
+
+// deliverReminder posts a reminder as the system bot, either in the direct
+// channel of the target user or in the target channel. Reminders whose
+// creator can no longer reach the target are skipped.
+func (a *App) deliverReminder(rctx request.CTX, systemBot *model.Bot, reminder *model.Reminder) *model.AppError {
+	logger := rctx.Logger().With(
+		mlog.String("reminder_id", reminder.Id),
+		mlog.String("user_id", reminder.UserId),
+		mlog.String("target_id", reminder.TargetId),
+	)
+
+	creator, err := a.Srv().Store().User().Get(context.Background(), reminder.UserId)
+	if err != nil {
+		return model.NewAppError("deliverReminder", "app.user.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if creator.DeleteAt != 0 {
+		logger.Debug("Skipping reminder of a deactivated user")
+		return nil
+	}
+
+	var channel *model.Channel
+	var message string
+	switch reminder.TargetType {
+	case model.ReminderTargetUser:
+		target := creator
+		if reminder.TargetId != creator.Id {
+			target, err = a.Srv().Store().User().Get(context.Background(), reminder.TargetId)
+			if err != nil {
+				return model.NewAppError("deliverReminder", "app.user.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			if target.DeleteAt != 0 {
+				logger.Debug("Skipping reminder for a deactivated user")
+				return nil
+			}
+		}
+
+		var appErr *model.AppError
+		channel, appErr = a.GetOrCreateDirectChannel(rctx, target.Id, systemBot.UserId)
+		if appErr != nil {
+			return appErr
+		}
+
+		T := i18n.GetUserTranslations(target.Locale)
+		if target.Id == creator.Id {
+			message = T("app.reminder.delivery.self", map[string]any{"Message": reminder.Message})
+		} else {
+			message = T("app.reminder.delivery.user", map[string]any{"Username": creator.Username, "Message": reminder.Message})
+		}
+	case model.ReminderTargetChannel:
+		var appErr *model.AppError
+		channel, appErr = a.GetChannel(rctx, reminder.TargetId)
+		if appErr != nil {
+			return appErr
+		}
+
+		if channel.DeleteAt != 0 || !a.hasPermissionToCreatePostInChannel(rctx, creator.Id, channel) {
+			logger.Debug("Skipping reminder for a channel the user can no longer post to")
+			return nil
+		}
+
+		T := i18n.GetUserTranslations(creator.Locale)
+		message = T("app.reminder.delivery.channel", map[string]any{"Username": creator.Username, "Message": reminder.Message})
+	}
+
+	post := &model.Post{
+		ChannelId: channel.Id,
+		UserId:    systemBot.UserId,
+		Message:   message,
+	}
+	post.AddProp(model.PostPropsReminderId, reminder.Id)
+
+	if _, appErr := a.CreatePost(rctx, post, channel, model.CreatePostFlags{}); appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestCreateReminder(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("rejects a time in the past", func(t *testing.T) {
+		_, appErr := th.App.CreateReminder(th.Context, &model.Reminder{
+			UserId:     th.BasicUser.Id,
+			TargetType: model.ReminderTargetUser,
+			TargetId:   th.BasicUser.Id,
+			Message:    "Stretch",
+			TriggerAt:  model.GetMillis() - 1000,
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.reminder.create.trigger_at_in_past.app_error", appErr.Id)
+	})
+
+	t.Run("rejects bots as target", func(t *testing.T) {
+		bot := th.CreateBot()
+		_, appErr := th.App.CreateReminder(th.Context, &model.Reminder{
+			UserId:     th.BasicUser.Id,
+			TargetType: model.ReminderTargetUser,
+			TargetId:   bot.UserId,
+			Message:    "Stretch",
+			TriggerAt:  model.GetMillis() + 60*1000,
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.reminder.create.target_user.app_error", appErr.Id)
+	})
+
+	t.Run("rejects channels the user cannot post to", func(t *testing.T) {
+		privateChannel := th.CreatePrivateChannel(th.Context, th.BasicTeam)
+		_, appErr := th.App.CreateReminder(th.Context, &model.Reminder{
+			UserId:     th.BasicUser2.Id,
+			TargetType: model.ReminderTargetChannel,
+			TargetId:   privateChannel.Id,
+			Message:    "Stretch",
+			TriggerAt:  model.GetMillis() + 60*1000,
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.reminder.create.target_channel.app_error", appErr.Id)
+	})
+}
+
+func TestProcessReminders(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	systemBot, appErr := th.App.GetSystemBot(th.Context)
+	require.Nil(t, appErr)
+
+	saveDueReminder := func(t *testing.T, reminder *model.Reminder) *model.Reminder {
+		t.Helper()
+		reminder.TriggerAt = model.GetMillis() + 60*1000
+		reminder, appErr := th.App.CreateReminder(th.Context, reminder)
+		require.Nil(t, appErr)
+
+		// Make the reminder due without waiting for it.
+		reminder.TriggerAt = model.GetMillis() - 1000
+		require.NoError(t, th.App.Srv().Store().Reminder().Update(reminder))
+		return reminder
+	}
+
+	t.Run("delivers a reminder in the direct channel with the system bot", func(t *testing.T) {
+		reminder := saveDueReminder(t, &model.Reminder{
+			UserId:     th.BasicUser.Id,
+			TargetType: model.ReminderTargetUser,
+			TargetId:   th.BasicUser2.Id,
+			Message:    "Review the release notes",
+		})
+
+		require.NoError(t, th.App.ProcessReminders(th.Context))
+
+		channel, appErr := th.App.GetOrCreateDirectChannel(th.Context, th.BasicUser2.Id, systemBot.UserId)
+		require.Nil(t, appErr)
+		posts, appErr := th.App.GetPosts(channel.Id, 0, 1)
+		require.Nil(t, appErr)
+		require.Len(t, posts.Order, 1)
+		post := posts.Posts[posts.Order[0]]
+		assert.Equal(t, systemBot.UserId, post.UserId)
+		assert.Contains(t, post.Message, "Review the release notes")
+		assert.Equal(t, reminder.Id, post.GetProp(model.PostPropsReminderId))
+
+		delivered, appErr := th.App.GetReminder(reminder.Id)
+		require.Nil(t, appErr)
+		assert.True(t, delivered.IsCompleted())
+		assert.NotZero(t, delivered.LastTriggeredAt)
+	})
+
+	t.Run("reschedules recurring reminders", func(t *testing.T) {
+		reminder := saveDueReminder(t, &model.Reminder{
+			UserId:     th.BasicUser.Id,
+			TargetType: model.ReminderTargetChannel,
+			TargetId:   th.BasicChannel.Id,
+			Message:    "Fill in the standup notes",
+			Recurrence: &model.ReminderRecurrence{Frequency: model.ReminderFrequencyDaily, Hour: 9, TimeZone: "UTC"},
+		})
+
+		require.NoError(t, th.App.ProcessReminders(th.Context))
+
+		posts, appErr := th.App.GetPosts(th.BasicChannel.Id, 0, 1)
+		require.Nil(t, appErr)
+		require.Len(t, posts.Order, 1)
+		assert.Contains(t, posts.Posts[posts.Order[0]].Message, "Fill in the standup notes")
+
+		delivered, appErr := th.App.GetReminder(reminder.Id)
+		require.Nil(t, appErr)
+		assert.False(t, delivered.IsCompleted())
+		assert.Greater(t, delivered.TriggerAt, model.GetMillis())
+		assert.Equal(t, 9, time.UnixMilli(delivered.TriggerAt).UTC().Hour())
+	})
+}
+
+func TestSnoozeAndDeleteReminder(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	reminder, appErr := th.App.CreateReminder(th.Context, &model.Reminder{
+		UserId:     th.BasicUser.Id,
+		TargetType: model.ReminderTargetUser,
+		TargetId:   th.BasicUser2.Id,
+		Message:    "Stretch",
+		TriggerAt:  model.GetMillis() + 60*1000,
+	})
+	require.Nil(t, appErr)
+
+	t.Run("the target user can snooze the reminder", func(t *testing.T) {
+		snoozeUntil := model.GetMillis() + 60*60*1000
+		snoozed, appErr := th.App.SnoozeReminder(th.Context, th.BasicUser2.Id, reminder.Id, snoozeUntil)
+		require.Nil(t, appErr)
+		assert.Equal(t, snoozeUntil, snoozed.TriggerAt)
+
+		_, appErr = th.App.SnoozeReminder(th.Context, th.BasicUser2.Id, reminder.Id, model.GetMillis()-1000)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.reminder.snooze.in_past.app_error", appErr.Id)
+	})
+
+	t.Run("only the creator can delete the reminder", func(t *testing.T) {
+		_, appErr := th.App.DeleteReminder(th.Context, th.BasicUser2.Id, reminder.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.reminder.delete.permissions.app_error", appErr.Id)
+
+		_, appErr = th.App.DeleteReminder(th.Context, th.BasicUser.Id, reminder.Id)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetReminder(reminder.Id)
+		require.NotNil(t, appErr)
+	})
+}
@@ -0,0 +1,454 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const (
+	savedSearchAlertsBatchSize = 100
+
+	// savedSearchAlertsDelay is how long alerts are gathered after a post
+	// gets created before being checked, so that they are delivered as a
+	// digest.
+	savedSearchAlertsDelay = 10 * time.Second
+
+	// savedSearchAlertsIndexingDelay leaves time to the search engines and
+	// the database replicas to catch up with the new posts before they are
+	// searched.
+	savedSearchAlertsIndexingDelay = 5 * time.Second
+
+	// savedSearchAlertsMaxLookback bounds the posts checked when the alerts of
+	// a saved search were not checked for a long time.
+	savedSearchAlertsMaxLookback = 24 * time.Hour
+
+	savedSearchAlertsPerPage     = 100
+	savedSearchAlertsMaxMatches  = 100
+	savedSearchAlertsDigestPosts = 10
+)
+
+// CreateSavedSearch saves a search of the user, after checking that the user
+// is a member of the team the search is restricted to.
+func (a *App) CreateSavedSearch(rctx request.CTX, savedSearch *model.SavedSearch) (*model.SavedSearch, *model.AppError) {
+	if savedSearch.TeamId != "" {
+		if _, err := a.Srv().Store().Team().GetMember(rctx, savedSearch.TeamId, savedSearch.UserId); err != nil {
+			return nil, model.NewAppError("CreateSavedSearch", "app.saved_search.create.team_member.app_error", nil, "", http.StatusForbidden).Wrap(err)
+		}
+	}
+
+	savedSearches, appErr := a.GetSavedSearchesForUser(savedSearch.UserId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if len(savedSearches) >= model.SavedSearchMaxPerUser {
+		return nil, model.NewAppError("CreateSavedSearch", "app.saved_search.create.limit.app_error", map[string]any{"Limit": model.SavedSearchMaxPerUser}, "", http.StatusBadRequest)
+	}
+
+	saved, err := a.Srv().Store().SavedSearch().Save(savedSearch)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("CreateSavedSearch", "app.saved_search.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return saved, nil
+}
+
+func (a *App) GetSavedSearch(savedSearchID string) (*model.SavedSearch, *model.AppError) {
+	savedSearch, err := a.Srv().Store().SavedSearch().Get(savedSearchID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetSavedSearch", "app.saved_search.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetSavedSearch", "app.saved_search.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return savedSearch, nil
+}
+
+// GetSavedSearchesForUser returns the saved searches of the given user,
+// ordered by name.
+func (a *App) GetSavedSearchesForUser(userID string) ([]*model.SavedSearch, *model.AppError) {
+	savedSearches, err := a.Srv().Store().SavedSearch().GetForUser(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetSavedSearchesForUser", "app.saved_search.get_for_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return savedSearches, nil
+}
+
+// PatchSavedSearch changes a saved search of userID.
+func (a *App) PatchSavedSearch(rctx request.CTX, userID, savedSearchID string, patch *model.SavedSearchPatch) (*model.SavedSearch, *model.AppError) {
+	savedSearch, appErr := a.GetSavedSearch(savedSearchID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if savedSearch.UserId != userID {
+		return nil, model.NewAppError("PatchSavedSearch", "app.saved_search.permissions.app_error", nil, "", http.StatusForbidden)
+	}
+
+	savedSearch.Patch(patch)
+	updated, err := a.Srv().Store().SavedSearch().Update(savedSearch)
+	if err != nil {
+		var appErr *model.AppError
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("PatchSavedSearch", "app.saved_search.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("PatchSavedSearch", "app.saved_search.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return updated, nil
+}
+
+// DeleteSavedSearch deletes a saved search of userID.
+func (a *App) DeleteSavedSearch(rctx request.CTX, userID, savedSearchID string) (*model.SavedSearch, *model.AppError) {
+	savedSearch, appErr := a.GetSavedSearch(savedSearchID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if savedSearch.UserId != userID {
+		return nil, model.NewAppError("DeleteSavedSearch", "app.saved_search.permissions.app_error", nil, "", http.StatusForbidden)
+	}
+
+	if err := a.Srv().Store().SavedSearch().Delete(savedSearchID); err != nil {
+		return nil, model.NewAppError("DeleteSavedSearch", "app.saved_search.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return savedSearch, nil
+}
+
+// RunSavedSearch searches the posts matching a saved search, as its user.
+func (a *App) RunSavedSearch(rctx request.CTX, savedSearch *model.SavedSearch, page, perPage int) (*model.PostSearchResults, *model.AppError) {
+	return a.SearchPostsForUser(rctx, savedSearch.Terms, savedSearch.UserId, savedSearch.TeamId, savedSearch.IsOrSearch, false, savedSearch.TimeZoneOffset, page, perPage)
+}
+
+// savedSearchAlertsOnPostCreation reports whether post searches run on a
+// search engine, in which case the alerts of saved searches are checked as
+// posts get created rather than by the saved search alerts job.
+func (a *App) savedSearchAlertsOnPostCreation() bool {
+	for _, engine := range a.SearchEngine().GetActiveEngines() {
+		if engine.IsSearchEnabled() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ProcessSavedSearchAlerts checks the saved searches with alerts for new
+// matches when post searches run on the database.
+func (a *App) ProcessSavedSearchAlerts(rctx request.CTX) error {
+	if a.savedSearchAlertsOnPostCreation() {
+		return nil
+	}
+
+	upTo := model.GetMillis() - savedSearchAlertsIndexingDelay.Milliseconds()
+	afterID := ""
+	for {
+		savedSearches, err := a.Srv().Store().SavedSearch().GetWithAlerts(afterID, savedSearchAlertsBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, savedSearch := range savedSearches {
+			if appErr := a.checkSavedSearchAlerts(rctx, savedSearch, upTo); appErr != nil {
+				rctx.Logger().Warn("Failed to check the alerts of a saved search", mlog.String("saved_search_id", savedSearch.Id), mlog.Err(appErr))
+			}
+		}
+
+		if len(savedSearches) < savedSearchAlertsBatchSize {
+			return nil
+		}
+		afterID = savedSearches[len(savedSearches)-1].Id
+	}
+}
+
+// savedSearchAlertsForPost reports whether a new post may trigger the alerts
+// of saved searches when it gets created.
+func (a *App) savedSearchAlertsForPost(post *model.Post) bool {
+	if !*a.Config().ServiceSettings.EnablePostSearch || post.IsSystemMessage() || post.GetProp(model.PostPropsSavedSearchId) != nil {
+		return false
+	}
+
+	return a.savedSearchAlertsOnPostCreation()
+}
+
+// queueSavedSearchAlertsForPost schedules a check of the saved searches with
+// alerts of the members of the channel of a new post. Callers check
+// savedSearchAlertsForPost first.
+func (a *App) queueSavedSearchAlertsForPost(rctx request.CTX, post *model.Post, channel *model.Channel) {
+	savedSearches, err := a.Srv().Store().SavedSearch().GetWithAlertsForChannel(channel.Id)
+	if err != nil {
+		rctx.Logger().Warn("Failed to get the saved searches with alerts of a channel", mlog.String("channel_id", channel.Id), mlog.Err(err))
+		return
+	}
+
+	a.ch.savedSearchAlertsMut.Lock()
+	defer a.ch.savedSearchAlertsMut.Unlock()
+
+	for _, savedSearch := range savedSearches {
+		if savedSearch.UserId == post.UserId {
+			continue
+		}
+		if savedSearch.TeamId != "" && channel.TeamId != "" && savedSearch.TeamId != channel.TeamId {
+			continue
+		}
+
+		if post.CreateAt > a.ch.savedSearchAlertsPending[savedSearch.Id] {
+			a.ch.savedSearchAlertsPending[savedSearch.Id] = post.CreateAt
+		}
+	}
+
+	if len(a.ch.savedSearchAlertsPending) > 0 && a.ch.savedSearchAlertsTask == nil {
+		a.ch.savedSearchAlertsTask = model.CreateTask("Check saved search alerts", func() {
+			a.checkPendingSavedSearchAlerts(request.EmptyContext(a.Log()))
+		}, savedSearchAlertsDelay)
+	}
+}
+
+// checkPendingSavedSearchAlerts checks the saved searches queued by new posts
+// that had time to be indexed, and queues the check of the others again.
+func (a *App) checkPendingSavedSearchAlerts(rctx request.CTX) {
+	upTo := model.GetMillis() - savedSearchAlertsIndexingDelay.Milliseconds()
+
+	savedSearchIDs := []string{}
+	withMut(&a.ch.savedSearchAlertsMut, func() {
+		for savedSearchID, lastPostAt := range a.ch.savedSearchAlertsPending {
+			if lastPostAt <= upTo {
+				savedSearchIDs = append(savedSearchIDs, savedSearchID)
+				delete(a.ch.savedSearchAlertsPending, savedSearchID)
+			}
+		}
+
+		a.ch.savedSearchAlertsTask = nil
+		if len(a.ch.savedSearchAlertsPending) > 0 {
+			a.ch.savedSearchAlertsTask = model.CreateTask("Check saved search alerts", func() {
+				a.checkPendingSavedSearchAlerts(request.EmptyContext(a.Log()))
+			}, savedSearchAlertsDelay)
+		}
+	})
+
+	for _, savedSearchID := range savedSearchIDs {
+		savedSearch, err := a.Srv().Store().SavedSearch().Get(savedSearchID)
+		if err != nil {
+			var nfErr *store.ErrNotFound
+			if !errors.As(err, &nfErr) {
+				rctx.Logger().Warn("Failed to get a saved search", mlog.String("saved_search_id", savedSearchID), mlog.Err(err))
+			}
+			continue
+		}
+
+		if appErr := a.checkSavedSearchAlerts(rctx, savedSearch, upTo); appErr != nil {
+			rctx.Logger().Warn("Failed to check the alerts of a saved search", mlog.String("saved_search_id", savedSearchID), mlog.Err(appErr))
+		}
+	}
+}
+
+// checkSavedSearchAlerts delivers the posts matching a saved search that were
+// created since its alerts were last checked and up to the given time.
+func (a *App) checkSavedSearchAlerts(rctx request.CTX, savedSearch *model.SavedSearch, upTo int64) *model.AppError {
+	if !savedSearch.HasAlert() || savedSearch.LastCheckedAt >= upTo {
+		return nil
+	}
+
+	since := max(savedSearch.LastCheckedAt, upTo-savedSearchAlertsMaxLookback.Milliseconds())
+	posts, appErr := a.getSavedSearchMatches(rctx, savedSearch, since, upTo)
+	if appErr != nil {
+		return appErr
+	}
+
+	// The alerts are moved forward before being delivered so that the same
+	// matches cannot be delivered twice by concurrent checks.
+	updated, err := a.Srv().Store().SavedSearch().UpdateLastCheckedAt(savedSearch.Id, savedSearch.LastCheckedAt, upTo)
+	if err != nil {
+		return model.NewAppError("checkSavedSearchAlerts", "app.saved_search.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if !updated || len(posts) == 0 {
+		return nil
+	}
+
+	return a.deliverSavedSearchAlert(rctx, savedSearch, posts)
+}
+
+// getSavedSearchMatches returns the posts matching a saved search created in
+// (since, upTo], the oldest first. The posts of the user of the search and the
+// digests of saved searches are left out.
+func (a *App) getSavedSearchMatches(rctx request.CTX, savedSearch *model.SavedSearch, since, upTo int64) ([]*model.Post, *model.AppError) {
+	// The search is restricted to the days of the new posts. The posts are
+	// then filtered by time since search dates have a one day granularity.
+	loc := time.FixedZone("", savedSearch.TimeZoneOffset)
+	afterDate := time.UnixMilli(since).In(loc).AddDate(0, 0, -1).Format("2006-01-02")
+	afterDateMillis := (&model.SearchParams{AfterDate: afterDate, TimeZoneOffset: savedSearch.TimeZoneOffset}).GetAfterDateMillis()
+
+	matches := map[string]*model.Post{}
+	for page := 0; len(matches) < savedSearchAlertsMaxMatches; page++ {
+		// Searching resolves the channel and user names of the params in
+		// place, so every page needs fresh params.
+		paramsList, appErr := savedSearch.SearchParams()
+		if appErr != nil {
+			return nil, appErr
+		}
+		for _, params := range paramsList {
+			if params.AfterDate == "" || params.GetAfterDateMillis() < afterDateMillis {
+				params.AfterDate = afterDate
+			}
+		}
+
+		results, appErr := a.searchPostsForUserWithParams(rctx, paramsList, savedSearch.UserId, savedSearch.TeamId, savedSearch.IsOrSearch, false, page, savedSearchAlertsPerPage)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		for _, post := range results.Posts {
+			if post.CreateAt <= since || post.CreateAt > upTo || post.DeleteAt != 0 {
+				continue
+			}
+			if post.UserId == savedSearch.UserId || post.GetProp(model.PostPropsSavedSearchId) != nil {
+				continue
+			}
+			matches[post.Id] = post
+		}
+
+		if len(results.Order) < savedSearchAlertsPerPage {
+			break
+		}
+	}
+
+	posts := make([]*model.Post, 0, len(matches))
+	for _, post := range matches {
+		posts = append(posts, post)
+	}
+	sort.Slice(posts, func(i, j int) bool {
+		if posts[i].CreateAt == posts[j].CreateAt {
+			return posts[i].Id < posts[j].Id
+		}
+		return posts[i].CreateAt < posts[j].CreateAt
+	})
+	if len(posts) > savedSearchAlertsMaxMatches {
+		posts = posts[:savedSearchAlertsMaxMatches]
+	}
+
+	return posts, nil
+}
+
+// deliverSavedSearchAlert sends the new matches of a saved search to its user,
+// leaving out the posts of the channels the user is no longer a member of.
+func (a *App) deliverSavedSearchAlert(rctx request.CTX, savedSearch *model.SavedSearch, posts []*model.Post) *model.AppError {
+	user, err := a.Srv().Store().User().Get(context.Background(), savedSearch.UserId)
+	if err != nil {
+		return model.NewAppError("deliverSavedSearchAlert", "app.user.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if user.DeleteAt != 0 {
+		return nil
+	}
+
+	isMember := map[string]bool{}
+	postIDs := []string{}
+	for _, post := range posts {
+		member, ok := isMember[post.ChannelId]
+		if !ok {
+			_, err := a.Srv().Store().Channel().GetMember(context.Background(), post.ChannelId, user.Id)
+			if err != nil {
+				var nfErr *store.ErrNotFound
+				if !errors.As(err, &nfErr) {
+					return model.NewAppError("deliverSavedSearchAlert", "app.channel.get_member.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+				}
+			}
+			member = err == nil
+			isMember[post.ChannelId] = member
+		}
+
+		if member {
+			postIDs = append(postIDs, post.Id)
+		}
+	}
+
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	switch savedSearch.Alert {
+	case model.SavedSearchAlertDirectMessage:
+		return a.postSavedSearchDigest(rctx, savedSearch, user, postIDs)
+	case model.SavedSearchAlertWebsocket:
+		matchesJSON, err := json.Marshal(&model.SavedSearchMatches{
+			SavedSearchId: savedSearch.Id,
+			Name:          savedSearch.Name,
+			PostIds:       postIDs,
+		})
+		if err != nil {
+			return model.NewAppError("deliverSavedSearchAlert", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		message := model.NewWebSocketEvent(model.WebsocketEventSavedSearchMatched, "", "", user.Id, nil, "")
+		message.Add("matches", string(matchesJSON))
+		a.PublishWithContext(rctx, message)
+	}
+
+	return nil
+}
+
+// postSavedSearchDigest posts the links to the new matches of a saved search
+// in the direct channel of its user with the system bot.
+func (a *App) postSavedSearchDigest(rctx request.CTX, savedSearch *model.SavedSearch, user *model.User, postIDs []string) *model.AppError {
+	systemBot, appErr := a.GetSystemBot(rctx)
+	if appErr != nil {
+		return appErr
+	}
+
+	channel, appErr := a.GetOrCreateDirectChannel(rctx, user.Id, systemBot.UserId)
+	if appErr != nil {
+		return appErr
+	}
+
+	T := i18n.GetUserTranslations(user.Locale)
+	lines := []string{T("app.saved_search.alert.digest", map[string]any{"Name": savedSearch.Name, "Count": len(postIDs)})}
+	for _, postID := range postIDs[:min(len(postIDs), savedSearchAlertsDigestPosts)] {
+		lines = append(lines, fmt.Sprintf("- %s/_redirect/pl/%s", a.GetSiteURL(), postID))
+	}
+	if len(postIDs) > savedSearchAlertsDigestPosts {
+		lines = append(lines, T("app.saved_search.alert.digest_more", map[string]any{"Count": len(postIDs) - savedSearchAlertsDigestPosts}))
+	}
+
+	post := &model.Post{
+		ChannelId: channel.Id,
+		UserId:    systemBot.UserId,
+		Message:   strings.Join(lines, "\n"),
+	}
+	post.AddProp(model.PostPropsSavedSearchId, savedSearch.Id)
+
+	if _, appErr := a.CreatePost(rctx, post, channel, model.CreatePostFlags{}); appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
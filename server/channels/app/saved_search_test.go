@@ -0,0 +1,186 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSavedSearches(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("rejects teams the user is not a member of", func(t *testing.T) {
+		_, appErr := th.App.CreateSavedSearch(th.Context, &model.SavedSearch{
+			UserId: th.BasicUser.Id,
+			TeamId: model.NewId(),
+			Name:   "Deploy errors",
+			Terms:  "error",
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.saved_search.create.team_member.app_error", appErr.Id)
+	})
+
+	t.Run("runs a saved search as its user", func(t *testing.T) {
+		post := th.CreateMessagePost(th.BasicChannel, "the deploy failed with an error")
+
+		savedSearch, appErr := th.App.CreateSavedSearch(th.Context, &model.SavedSearch{
+			UserId: th.BasicUser.Id,
+			TeamId: th.BasicTeam.Id,
+			Name:   "Deploy errors",
+			Terms:  "deploy error",
+		})
+		require.Nil(t, appErr)
+
+		results, appErr := th.App.RunSavedSearch(th.Context, savedSearch, 0, 20)
+		require.Nil(t, appErr)
+		assert.Contains(t, results.Order, post.Id)
+	})
+
+	t.Run("only the user of a saved search can change it", func(t *testing.T) {
+		savedSearch, appErr := th.App.CreateSavedSearch(th.Context, &model.SavedSearch{
+			UserId: th.BasicUser.Id,
+			Name:   "Warnings",
+			Terms:  "warning",
+		})
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.PatchSavedSearch(th.Context, th.BasicUser2.Id, savedSearch.Id, &model.SavedSearchPatch{Name: model.NewPointer("Mine")})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.saved_search.permissions.app_error", appErr.Id)
+
+		_, appErr = th.App.DeleteSavedSearch(th.Context, th.BasicUser2.Id, savedSearch.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.saved_search.permissions.app_error", appErr.Id)
+
+		patched, appErr := th.App.PatchSavedSearch(th.Context, th.BasicUser.Id, savedSearch.Id, &model.SavedSearchPatch{Alert: model.NewPointer(model.SavedSearchAlertWebsocket)})
+		require.Nil(t, appErr)
+		assert.True(t, patched.HasAlert())
+
+		_, appErr = th.App.DeleteSavedSearch(th.Context, th.BasicUser.Id, savedSearch.Id)
+		require.Nil(t, appErr)
+	})
+}
+
+func TestProcessSavedSearchAlerts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	systemBot, appErr := th.App.GetSystemBot(th.Context)
+	require.Nil(t, appErr)
+
+	createSavedSearchWithAlert := func(t *testing.T, terms, alert string) *model.SavedSearch {
+		t.Helper()
+		savedSearch, appErr := th.App.CreateSavedSearch(th.Context, &model.SavedSearch{
+			UserId: th.BasicUser.Id,
+			Name:   terms,
+			Terms:  terms,
+			Alert:  alert,
+		})
+		require.Nil(t, appErr)
+
+		// Check the alerts of the posts created by the test helpers in the
+		// past.
+		lastCheckedAt := model.GetMillis() - time.Minute.Milliseconds()
+		updated, err := th.App.Srv().Store().SavedSearch().UpdateLastCheckedAt(savedSearch.Id, savedSearch.LastCheckedAt, lastCheckedAt)
+		require.NoError(t, err)
+		require.True(t, updated)
+		savedSearch.LastCheckedAt = lastCheckedAt
+		return savedSearch
+	}
+
+	createPost := func(t *testing.T, user *model.User, channel *model.Channel, message string) *model.Post {
+		t.Helper()
+		post, appErr := th.App.CreatePost(th.Context, &model.Post{
+			UserId:    user.Id,
+			ChannelId: channel.Id,
+			Message:   message,
+			CreateAt:  model.GetMillis() - 10000,
+		}, channel, model.CreatePostFlags{})
+		require.Nil(t, appErr)
+		return post
+	}
+
+	dmChannel, appErr := th.App.GetOrCreateDirectChannel(th.Context, th.BasicUser.Id, systemBot.UserId)
+	require.Nil(t, appErr)
+
+	t.Run("delivers new matches as a digest in the direct channel with the system bot", func(t *testing.T) {
+		savedSearch := createSavedSearchWithAlert(t, "rollback", model.SavedSearchAlertDirectMessage)
+		match := createPost(t, th.BasicUser2, th.BasicChannel, "starting the rollback of prod")
+		createPost(t, th.BasicUser, th.BasicChannel, "my own rollback")
+		createPost(t, th.BasicUser2, th.BasicChannel, "unrelated message")
+
+		require.NoError(t, th.App.ProcessSavedSearchAlerts(th.Context))
+
+		posts, appErr := th.App.GetPosts(dmChannel.Id, 0, 1)
+		require.Nil(t, appErr)
+		require.Len(t, posts.Order, 1)
+		digest := posts.Posts[posts.Order[0]]
+		assert.Equal(t, systemBot.UserId, digest.UserId)
+		assert.Equal(t, savedSearch.Id, digest.GetProp(model.PostPropsSavedSearchId))
+		assert.Contains(t, digest.Message, match.Id)
+		assert.Contains(t, digest.Message, "1 new post(s)")
+
+		checked, appErr := th.App.GetSavedSearch(savedSearch.Id)
+		require.Nil(t, appErr)
+		assert.Greater(t, checked.LastCheckedAt, savedSearch.LastCheckedAt)
+
+		// The matches are only delivered once.
+		require.NoError(t, th.App.ProcessSavedSearchAlerts(th.Context))
+		posts, appErr = th.App.GetPosts(dmChannel.Id, 0, 2)
+		require.Nil(t, appErr)
+		assert.Equal(t, digest.Id, posts.Order[0])
+
+		_, appErr = th.App.DeleteSavedSearch(th.Context, th.BasicUser.Id, savedSearch.Id)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("delivers new matches as a websocket event", func(t *testing.T) {
+		savedSearch := createSavedSearchWithAlert(t, "outage", model.SavedSearchAlertWebsocket)
+		match := createPost(t, th.BasicUser2, th.BasicChannel, "the outage is over")
+
+		messages, closeWS := connectFakeWebSocket(t, th, th.BasicUser.Id, "", []model.WebsocketEventType{model.WebsocketEventSavedSearchMatched})
+		defer closeWS()
+
+		require.NoError(t, th.App.ProcessSavedSearchAlerts(th.Context))
+
+		select {
+		case event := <-messages:
+			var matches model.SavedSearchMatches
+			require.NoError(t, json.Unmarshal([]byte(event.GetData()["matches"].(string)), &matches))
+			assert.Equal(t, savedSearch.Id, matches.SavedSearchId)
+			assert.Equal(t, []string{match.Id}, matches.PostIds)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "timed out waiting for the saved search event")
+		}
+
+		_, appErr = th.App.DeleteSavedSearch(th.Context, th.BasicUser.Id, savedSearch.Id)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("leaves out the channels the user left before the delivery", func(t *testing.T) {
+		savedSearch := createSavedSearchWithAlert(t, "incident", model.SavedSearchAlertDirectMessage)
+		channel := th.CreatePrivateChannel(th.Context, th.BasicTeam)
+		th.AddUserToChannel(th.BasicUser2, channel)
+		post := createPost(t, th.BasicUser2, channel, "incident in the private channel")
+
+		require.Nil(t, th.App.RemoveUserFromChannel(th.Context, th.BasicUser.Id, th.BasicUser.Id, channel))
+
+		lastPosts, appErr := th.App.GetPosts(dmChannel.Id, 0, 1)
+		require.Nil(t, appErr)
+
+		require.Nil(t, th.App.deliverSavedSearchAlert(th.Context, savedSearch, []*model.Post{post}))
+
+		posts, appErr := th.App.GetPosts(dmChannel.Id, 0, 1)
+		require.Nil(t, appErr)
+		assert.Equal(t, lastPosts.Order, posts.Order)
+	})
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const scheduledPostsProcessingBatchSize = 500
+
+func (a *App) IsScheduledPostsEnabled() bool {
+	return *a.Config().ServiceSettings.ScheduledPosts
+}
+
+func (a *App) SaveScheduledPost(rctx request.CTX, scheduledPost *model.ScheduledPost, connectionID string) (*model.ScheduledPost, *model.AppError) {
+	if !a.IsScheduledPostsEnabled() {
+		return nil, model.NewAppError("SaveScheduledPost", "app.scheduled_post.feature_disabled", nil, "", http.StatusNotImplemented)
+	}
+
+	if scheduledPost.ScheduledAt <= model.GetMillis() {
+		return nil, model.NewAppError("SaveScheduledPost", "app.scheduled_post.save.scheduled_at_in_past.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if appErr := a.validateScheduledPostChannel(scheduledPost.ChannelId); appErr != nil {
+		return nil, appErr
+	}
+
+	savedScheduledPost, err := a.Srv().Store().ScheduledPost().Save(scheduledPost)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("SaveScheduledPost", "app.scheduled_post.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	a.publishScheduledPostEvent(rctx, model.WebsocketEventScheduledPostCreated, savedScheduledPost, connectionID)
+
+	return savedScheduledPost, nil
+}
+
+func (a *App) GetScheduledPost(scheduledPostID string) (*model.ScheduledPost, *model.AppError) {
+	scheduledPost, err := a.Srv().Store().ScheduledPost().Get(scheduledPostID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetScheduledPost", "app.scheduled_post.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetScheduledPost", "app.scheduled_post.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return scheduledPost, nil
+}
+
+func (a *App) GetUserTeamScheduledPosts(rctx request.CTX, userID, teamID string) ([]*model.ScheduledPost, *model.AppError) {
+	if !a.IsScheduledPostsEnabled() {
+		return nil, model.NewAppError("GetUserTeamScheduledPosts", "app.scheduled_post.feature_disabled", nil, "", http.StatusNotImplemented)
+	}
+
+	scheduledPosts, err := a.Srv().Store().ScheduledPost().GetScheduledPostsForUser(userID, teamID)
+	if err != nil {
+		return nil, model.NewAppError("GetUserTeamScheduledPosts", "app.scheduled_post.get_for_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return scheduledPosts, nil
+}
+
+// UpdateScheduledPost updates the content and delivery time of a scheduled
+// post owned by userID. Already processed scheduled posts cannot be updated.
+func (a *App) UpdateScheduledPost(rctx request.CTX, userID string, scheduledPost *model.ScheduledPost, connectionID string) (*model.ScheduledPost, *model.AppError) {
+	if !a.IsScheduledPostsEnabled() {
+		return nil, model.NewAppError("UpdateScheduledPost", "app.scheduled_post.feature_disabled", nil, "", http.StatusNotImplemented)
+	}
+
+	existing, appErr := a.GetScheduledPost(scheduledPost.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if existing.UserId != userID {
+		return nil, model.NewAppError("UpdateScheduledPost", "app.scheduled_post.update.permissions.app_error", nil, "", http.StatusForbidden)
+	}
+
+	if existing.IsProcessed() {
+		return nil, model.NewAppError("UpdateScheduledPost", "app.scheduled_post.update.already_processed.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if scheduledPost.ScheduledAt <= model.GetMillis() {
+		return nil, model.NewAppError("UpdateScheduledPost", "app.scheduled_post.save.scheduled_at_in_past.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	// Only the content and the delivery time can be changed.
+	existing.Message = scheduledPost.Message
+	existing.SetProps(scheduledPost.GetProps())
+	existing.FileIds = scheduledPost.FileIds
+	existing.Priority = scheduledPost.Priority
+	existing.ScheduledAt = scheduledPost.ScheduledAt
+
+	if err := a.Srv().Store().ScheduledPost().Update(existing); err != nil {
+		var appErr *model.AppError
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("UpdateScheduledPost", "app.scheduled_post.update.already_processed.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("UpdateScheduledPost", "app.scheduled_post.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	a.publishScheduledPostEvent(rctx, model.WebsocketEventScheduledPostUpdated, existing, connectionID)
+
+	return existing, nil
+}
+
+func (a *App) DeleteScheduledPost(rctx request.CTX, userID, scheduledPostID, connectionID string) (*model.ScheduledPost, *model.AppError) {
+	if !a.IsScheduledPostsEnabled() {
+		return nil, model.NewAppError("DeleteScheduledPost", "app.scheduled_post.feature_disabled", nil, "", http.StatusNotImplemented)
+	}
+
+	scheduledPost, appErr := a.GetScheduledPost(scheduledPostID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if scheduledPost.UserId != userID {
+		return nil, model.NewAppError("DeleteScheduledPost", "app.scheduled_post.delete.permissions.app_error", nil, "", http.StatusForbidden)
+	}
+
+	if err := a.Srv().Store().ScheduledPost().Delete(scheduledPostID); err != nil {
+		return nil, model.NewAppError("DeleteScheduledPost", "app.scheduled_post.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.publishScheduledPostEvent(rctx, model.WebsocketEventScheduledPostDeleted, scheduledPost, connectionID)
+
+	return scheduledPost, nil
+}
+
+// ProcessScheduledPosts publishes every scheduled post that is due. Posts
+// that can no longer be published are marked as processed with an error code
+// so that their author can see why they were not sent.
+func (a *App) ProcessScheduledPosts(rctx request.CTX) error {
+	if !a.IsScheduledPostsEnabled() {
+		return nil
+	}
+
+	opts := model.GetScheduledPostsForProcessingOptions{
+		ScheduledBefore: model.GetMillis(),
+		PerPage:         scheduledPostsProcessingBatchSize,
+	}
+
+	for {
+		scheduledPosts, err := a.Srv().Store().ScheduledPost().GetPendingScheduledPosts(opts)
+		if err != nil {
+			return err
+		}
+
+		for _, scheduledPost := range scheduledPosts {
+			a.processScheduledPost(rctx, scheduledPost)
+		}
+
+		if len(scheduledPosts) < opts.PerPage {
+			return nil
+		}
+
+		last := scheduledPosts[len(scheduledPosts)-1]
+		opts.AfterTime = last.ScheduledAt
+		opts.AfterId = last.Id
+	}
+}
+
+func (a *App) processScheduledPost(rctx request.CTX, scheduledPost *model.ScheduledPost) {
+	logger := rctx.Logger().With(
+		mlog.String("scheduled_post_id", scheduledPost.Id),
+		mlog.String("user_id", scheduledPost.UserId),
+		mlog.String("channel_id", scheduledPost.ChannelId),
+	)
+
+	postID, errorCode := a.publishScheduledPost(rctx, scheduledPost)
+	if errorCode != "" {
+		logger.Info("Could not publish scheduled post", mlog.String("error_code", errorCode))
+	}
+
+	processedAt := model.GetMillis()
+	if err := a.Srv().Store().ScheduledPost().MarkAsProcessed(scheduledPost.Id, postID, errorCode, processedAt); err != nil {
+		logger.Error("Failed to mark scheduled post as processed", mlog.Err(err))
+		return
+	}
+
+	scheduledPost.ProcessedAt = processedAt
+	scheduledPost.UpdateAt = processedAt
+	scheduledPost.PostId = postID
+	scheduledPost.ErrorCode = errorCode
+	a.publishScheduledPostEvent(rctx, model.WebsocketEventScheduledPostUpdated, scheduledPost, "")
+}
+
+// publishScheduledPost re-checks that the author can still post to the
+// channel and creates the post. It returns either the ID of the created
+// post or the error code describing why it could not be created.
+func (a *App) publishScheduledPost(rctx request.CTX, scheduledPost *model.ScheduledPost) (string, string) {
+	user, err := a.Srv().Store().User().Get(context.Background(), scheduledPost.UserId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return "", model.ScheduledPostErrorCodeUserDoesNotExist
+		}
+		rctx.Logger().Warn("Failed to get author of scheduled post", mlog.String("scheduled_post_id", scheduledPost.Id), mlog.Err(err))
+		return "", model.ScheduledPostErrorCodeUnknown
+	}
+
+	if user.DeleteAt != 0 {
+		return "", model.ScheduledPostErrorCodeUserDeleted
+	}
+
+	channel, err := a.Srv().Store().Channel().Get(scheduledPost.ChannelId, true)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return "", model.ScheduledPostErrorCodeChannelNotFound
+		}
+		rctx.Logger().Warn("Failed to get channel of scheduled post", mlog.String("scheduled_post_id", scheduledPost.Id), mlog.Err(err))
+		return "", model.ScheduledPostErrorCodeUnknown
+	}
+
+	if channel.DeleteAt != 0 {
+		return "", model.ScheduledPostErrorCodeChannelArchived
+	}
+
+	if !a.hasPermissionToCreatePostInChannel(rctx, user.Id, channel) {
+		return "", model.ScheduledPostErrorCodeNoChannelPermission
+	}
+
+	if scheduledPost.RootId != "" {
+		rootPost, appErr := a.GetSinglePost(rctx, scheduledPost.RootId, false)
+		if appErr != nil || rootPost.ChannelId != channel.Id {
+			return "", model.ScheduledPostErrorCodeThreadDeleted
+		}
+	}
+
+	post := scheduledPost.ToPost()
+	if post.GetPriority() != nil && !a.IsPostPriorityEnabled() {
+		post.Metadata.Priority = nil
+	}
+
+	createdPost, appErr := a.CreatePost(rctx, post, channel, model.CreatePostFlags{TriggerWebhooks: true, SetOnline: false})
+	if appErr != nil {
+		rctx.Logger().Warn("Failed to create post from scheduled post", mlog.String("scheduled_post_id", scheduledPost.Id), mlog.Err(appErr))
+		return "", model.ScheduledPostErrorCodeUnknown
+	}
+
+	return createdPost.Id, ""
+}
+
+func (a *App) hasPermissionToCreatePostInChannel(rctx request.CTX, userID string, channel *model.Channel) bool {
+	if a.HasPermissionToChannel(rctx, userID, channel.Id, model.PermissionCreatePost) {
+		return true
+	}
+
+	return channel.Type == model.ChannelTypeOpen && a.HasPermissionToTeam(rctx, userID, channel.TeamId, model.PermissionCreatePostPublic)
+}
+
+func (a *App) validateScheduledPostChannel(channelID string) *model.AppError {
+	channel, err := a.Srv().Store().Channel().Get(channelID, true)
+	if err != nil {
+		return model.NewAppError("SaveScheduledPost", "api.context.invalid_param.app_error", map[string]any{"Name": "scheduled_post.channel_id"}, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	if channel.DeleteAt != 0 {
+		return model.NewAppError("SaveScheduledPost", "app.scheduled_post.save.channel_archived.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (a *App) publishScheduledPostEvent(rctx request.CTX, eventType model.WebsocketEventType, scheduledPost *model.ScheduledPost, connectionID string) {
+	message := model.NewWebSocketEvent(eventType, "", "", scheduledPost.UserId, nil, connectionID)
+	scheduledPostJSON, err := json.Marshal(scheduledPost)
+	if err != nil {
+		rctx.Logger().Warn("Failed to encode scheduled post to JSON", mlog.Err(err))
+		return
+	}
+	message.Add("scheduled_post", string(scheduledPostJSON))
+	a.PublishWithContext(rctx, message)
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSaveScheduledPost(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("save scheduled post", func(t *testing.T) {
+		scheduledPost, appErr := th.App.SaveScheduledPost(th.Context, &model.ScheduledPost{
+			UserId:      th.BasicUser.Id,
+			ChannelId:   th.BasicChannel.Id,
+			Message:     "scheduled message",
+			ScheduledAt: model.GetMillis() + 60000,
+		}, "")
+		require.Nil(t, appErr)
+		assert.NotEmpty(t, scheduledPost.Id)
+	})
+
+	t.Run("archived channel", func(t *testing.T) {
+		channel := th.CreateChannel(th.Context, th.BasicTeam)
+		appErr := th.App.DeleteChannel(th.Context, channel, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.SaveScheduledPost(th.Context, &model.ScheduledPost{
+			UserId:      th.BasicUser.Id,
+			ChannelId:   channel.Id,
+			Message:     "scheduled message",
+			ScheduledAt: model.GetMillis() + 60000,
+		}, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.scheduled_post.save.channel_archived.app_error", appErr.Id)
+	})
+
+	t.Run("feature disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ScheduledPosts = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.ScheduledPosts = true })
+
+		_, appErr := th.App.SaveScheduledPost(th.Context, &model.ScheduledPost{
+			UserId:      th.BasicUser.Id,
+			ChannelId:   th.BasicChannel.Id,
+			Message:     "scheduled message",
+			ScheduledAt: model.GetMillis() + 60000,
+		}, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.scheduled_post.feature_disabled", appErr.Id)
+	})
+}
+
+func TestProcessScheduledPosts(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	// Scheduled posts are saved directly through the store so that they are
+	// already due when the job runs.
+	saveDueScheduledPost := func(t *testing.T, channelID string) *model.ScheduledPost {
+		t.Helper()
+		scheduledPost, err := th.App.Srv().Store().ScheduledPost().Save(&model.ScheduledPost{
+			UserId:      th.BasicUser.Id,
+			ChannelId:   channelID,
+			Message:     "scheduled message " + model.NewId(),
+			ScheduledAt: model.GetMillis() - 1000,
+		})
+		require.NoError(t, err)
+		return scheduledPost
+	}
+
+	t.Run("publishes due scheduled posts", func(t *testing.T) {
+		scheduledPost := saveDueScheduledPost(t, th.BasicChannel.Id)
+
+		require.NoError(t, th.App.ProcessScheduledPosts(th.Context))
+
+		processed, appErr := th.App.GetScheduledPost(scheduledPost.Id)
+		require.Nil(t, appErr)
+		require.True(t, processed.IsProcessed())
+		require.Empty(t, processed.ErrorCode)
+		require.NotEmpty(t, processed.PostId)
+
+		post, appErr := th.App.GetSinglePost(th.Context, processed.PostId, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, scheduledPost.Message, post.Message)
+		assert.Equal(t, th.BasicUser.Id, post.UserId)
+	})
+
+	t.Run("does not publish to archived channels", func(t *testing.T) {
+		channel := th.CreateChannel(th.Context, th.BasicTeam)
+		scheduledPost := saveDueScheduledPost(t, channel.Id)
+		appErr := th.App.DeleteChannel(th.Context, channel, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		require.NoError(t, th.App.ProcessScheduledPosts(th.Context))
+
+		processed, appErr := th.App.GetScheduledPost(scheduledPost.Id)
+		require.Nil(t, appErr)
+		require.True(t, processed.IsProcessed())
+		assert.Empty(t, processed.PostId)
+		assert.Equal(t, model.ScheduledPostErrorCodeChannelArchived, processed.ErrorCode)
+	})
+
+	t.Run("does not publish to channels the user left", func(t *testing.T) {
+		channel := th.CreatePrivateChannel(th.Context, th.BasicTeam)
+		scheduledPost := saveDueScheduledPost(t, channel.Id)
+		appErr := th.App.LeaveChannel(th.Context, channel.Id, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		require.NoError(t, th.App.ProcessScheduledPosts(th.Context))
+
+		processed, appErr := th.App.GetScheduledPost(scheduledPost.Id)
+		require.Nil(t, appErr)
+		require.True(t, processed.IsProcessed())
+		assert.Equal(t, model.ScheduledPostErrorCodeNoChannelPermission, processed.ErrorCode)
+	})
+}
@@ -0,0 +1,691 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const scimUsersPageSize = 1000
+
+var scimUniquenessErrorIds = map[string]bool{
+	"app.user.save.email_exists.app_error":             true,
+	"app.user.save.username_exists.app_error":          true,
+	"app.user.save.existing.app_error":                 true,
+	"app.user.update_auth_data.email_exists.app_error": true,
+	"app.group.username_conflict":                      true,
+	"app.group.uniqueness_error":                       true,
+}
+
+// scimError converts the uniqueness errors of the user and group operations
+// into SCIM uniqueness errors, which identity providers handle as conflicts.
+func scimError(where string, appErr *model.AppError) *model.AppError {
+	if scimUniquenessErrorIds[appErr.Id] {
+		return model.NewScimAppError(where, model.ScimErrorTypeUniqueness, "a resource with the same unique attributes already exists").Wrap(appErr)
+	}
+	return appErr
+}
+
+func (a *App) scimBaseURL() string {
+	return a.GetSiteURL() + model.APIURLSuffix + "/scim/v2"
+}
+
+func scimListResponse(resources []any, startIndex, count int) *model.ScimListResponse {
+	if startIndex < 1 {
+		startIndex = 1
+	}
+
+	page := []any{}
+	if startIndex <= len(resources) {
+		end := min(startIndex-1+count, len(resources))
+		page = resources[startIndex-1 : end]
+	}
+
+	return scimPageResponse(page, startIndex, len(resources))
+}
+
+// scimPageResponse returns a list response for a page of resources which was
+// already read from the given 1-based startIndex out of total resources.
+func scimPageResponse(page []any, startIndex, total int) *model.ScimListResponse {
+	return &model.ScimListResponse{
+		Schemas:      []string{model.ScimSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(page),
+		Resources:    page,
+	}
+}
+
+func (a *App) scimUserFromUser(user *model.User) *model.ScimUser {
+	displayName := user.GetFullName()
+	if displayName == "" {
+		displayName = user.Username
+	}
+
+	scimUser := &model.ScimUser{
+		Schemas:     []string{model.ScimSchemaUser},
+		Id:          user.Id,
+		UserName:    user.Username,
+		DisplayName: displayName,
+		NickName:    user.Nickname,
+		Emails:      []model.ScimMultiValue{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      model.NewPointer(user.DeleteAt == 0),
+		Meta: &model.ScimMeta{
+			ResourceType: model.ScimResourceTypeUser,
+			Created:      model.ScimTime(user.CreateAt),
+			LastModified: model.ScimTime(user.UpdateAt),
+			Location:     a.scimBaseURL() + "/Users/" + user.Id,
+		},
+	}
+
+	if user.AuthService == *a.Config().ScimSettings.UserAuthService && user.AuthData != nil {
+		scimUser.ExternalId = *user.AuthData
+	}
+
+	if user.FirstName != "" || user.LastName != "" {
+		scimUser.Name = &model.ScimName{
+			Formatted:  user.GetFullName(),
+			GivenName:  user.FirstName,
+			FamilyName: user.LastName,
+		}
+	}
+
+	return scimUser
+}
+
+// scimAuthData returns the authentication data linking a provisioned user
+// to its account in the identity provider.
+func scimAuthData(scimUser *model.ScimUser) string {
+	if scimUser.ExternalId != "" {
+		return scimUser.ExternalId
+	}
+	return scimUser.UserName
+}
+
+func isValidScimUser(where string, scimUser *model.ScimUser) *model.AppError {
+	if scimUser.UserName == "" {
+		return model.NewScimAppError(where, model.ScimErrorTypeInvalidValue, "userName is required")
+	}
+	if scimUser.PrimaryEmail() == "" {
+		return model.NewScimAppError(where, model.ScimErrorTypeInvalidValue, "an email is required")
+	}
+	return nil
+}
+
+// getScimUser returns the user with the given id, unless it is a bot or a
+// remote user which cannot be provisioned through SCIM.
+func (a *App) getScimUser(userID string) (*model.User, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if user.IsBot || user.IsRemote() {
+		return nil, model.NewAppError("getScimUser", MissingAccountError, nil, "", http.StatusNotFound)
+	}
+
+	return user, nil
+}
+
+// getProvisionedScimUser returns the user with the given id if it is linked
+// to the identity provider, since SCIM must not take over the local accounts
+// or the ones of other authentication services.
+func (a *App) getProvisionedScimUser(where, userID string) (*model.User, *model.AppError) {
+	user, appErr := a.getScimUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if user.AuthService != *a.Config().ScimSettings.UserAuthService {
+		return nil, model.NewScimAppError(where, model.ScimErrorTypeUniqueness, "the user is not provisioned through SCIM")
+	}
+
+	return user, nil
+}
+
+func (a *App) GetScimUser(userID string) (*model.ScimUser, *model.AppError) {
+	user, appErr := a.getScimUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.scimUserFromUser(user), nil
+}
+
+// GetScimUsers returns the page of users matching the given SCIM filter,
+// starting at the 1-based startIndex.
+func (a *App) GetScimUsers(rctx request.CTX, filter string, startIndex, count int) (*model.ScimListResponse, *model.AppError) {
+	var parsedFilter model.ScimFilter
+	if filter != "" {
+		var appErr *model.AppError
+		parsedFilter, appErr = model.ParseScimFilter(filter)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		// Identity providers look users up by userName, externalId or email
+		// before provisioning them, which is answered without listing every user.
+		if attr, value, ok := model.ScimFilterEquality(parsedFilter); ok {
+			var user *model.User
+			switch attr {
+			case "username":
+				user, appErr = a.GetUserByUsername(model.CleanUsername(rctx.Logger(), value))
+			case "externalid":
+				user, appErr = a.GetUserByAuth(&value, *a.Config().ScimSettings.UserAuthService)
+			case "emails", "emails.value":
+				user, appErr = a.GetUserByEmail(value)
+			default:
+				ok = false
+			}
+
+			if ok {
+				// Unknown users result in an empty list. GetUserByAuth reports
+				// them with MissingAuthAccountError and an internal error status.
+				resources := []any{}
+				if appErr == nil && !user.IsBot && !user.IsRemote() {
+					resources = append(resources, a.scimUserFromUser(user))
+				} else if appErr != nil && appErr.StatusCode == http.StatusInternalServerError && appErr.Id != MissingAuthAccountError {
+					return nil, appErr
+				}
+				return scimListResponse(resources, startIndex, count), nil
+			}
+		}
+	}
+
+	if parsedFilter == nil {
+		return a.getScimUsersPage(startIndex, count)
+	}
+
+	resources := []any{}
+	for offset := 0; ; offset += scimUsersPageSize {
+		users, err := a.Srv().Store().User().GetScimProfiles(offset, scimUsersPageSize)
+		if err != nil {
+			return nil, model.NewAppError("GetScimUsers", "app.user.get_profiles.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, user := range users {
+			scimUser := a.scimUserFromUser(user)
+			if model.ScimFilterMatches(parsedFilter, scimUser) {
+				resources = append(resources, scimUser)
+			}
+		}
+
+		if len(users) < scimUsersPageSize {
+			break
+		}
+	}
+
+	return scimListResponse(resources, startIndex, count), nil
+}
+
+// getScimUsersPage returns the page of all the users starting at the 1-based
+// startIndex, reading only that page from the database.
+func (a *App) getScimUsersPage(startIndex, count int) (*model.ScimListResponse, *model.AppError) {
+	if startIndex < 1 {
+		startIndex = 1
+	}
+
+	total, err := a.Srv().Store().User().Count(model.UserCountOptions{IncludeDeleted: true})
+	if err != nil {
+		return nil, model.NewAppError("getScimUsersPage", "app.user.get_total_users_count.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	page := []any{}
+	if count > 0 && int64(startIndex) <= total {
+		users, err := a.Srv().Store().User().GetScimProfiles(startIndex-1, count)
+		if err != nil {
+			return nil, model.NewAppError("getScimUsersPage", "app.user.get_profiles.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, user := range users {
+			page = append(page, a.scimUserFromUser(user))
+		}
+	}
+
+	return scimPageResponse(page, startIndex, int(total)), nil
+}
+
+func (a *App) CreateScimUser(rctx request.CTX, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError) {
+	if appErr := isValidScimUser("CreateScimUser", scimUser); appErr != nil {
+		return nil, appErr
+	}
+
+	authService := *a.Config().ScimSettings.UserAuthService
+	authData := scimAuthData(scimUser)
+	if _, appErr := a.GetUserByAuth(&authData, authService); appErr == nil {
+		return nil, model.NewScimAppError("CreateScimUser", model.ScimErrorTypeUniqueness, "a user with the same externalId already exists")
+	}
+
+	user := &model.User{
+		Username:      model.CleanUsername(rctx.Logger(), scimUser.UserName),
+		Email:         strings.ToLower(scimUser.PrimaryEmail()),
+		Nickname:      scimUser.NickName,
+		AuthService:   authService,
+		AuthData:      &authData,
+		EmailVerified: true,
+	}
+	if scimUser.Name != nil {
+		user.FirstName = scimUser.Name.GivenName
+		user.LastName = scimUser.Name.FamilyName
+	}
+
+	ruser, appErr := a.CreateUser(rctx, user)
+	if appErr != nil {
+		return nil, scimError("CreateScimUser", appErr)
+	}
+
+	if !scimUser.IsActive() {
+		ruser, appErr = a.UpdateActive(rctx, ruser, false)
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return a.scimUserFromUser(ruser), nil
+}
+
+// UpdateScimUser replaces the attributes of a user with the ones of the
+// given SCIM resource.
+func (a *App) UpdateScimUser(rctx request.CTX, userID string, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError) {
+	user, appErr := a.getProvisionedScimUser("UpdateScimUser", userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.updateScimUser(rctx, user, scimUser)
+}
+
+// PatchScimUser applies the operations of a SCIM PATCH request to a user.
+func (a *App) PatchScimUser(rctx request.CTX, userID string, operations []model.ScimPatchOperation) (*model.ScimUser, *model.AppError) {
+	user, appErr := a.getProvisionedScimUser("PatchScimUser", userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	patched, appErr := a.scimUserFromUser(user).ApplyPatch(operations)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.updateScimUser(rctx, user, patched)
+}
+
+func (a *App) updateScimUser(rctx request.CTX, user *model.User, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError) {
+	if appErr := isValidScimUser("updateScimUser", scimUser); appErr != nil {
+		return nil, appErr
+	}
+
+	authService := *a.Config().ScimSettings.UserAuthService
+	authData := scimAuthData(scimUser)
+	email := strings.ToLower(scimUser.PrimaryEmail())
+
+	// The email and the authentication data of SSO users are only updated
+	// through their authentication data, as on login.
+	if user.AuthData == nil || *user.AuthData != authData || user.Email != email {
+		if _, err := a.Srv().Store().User().UpdateAuthData(user.Id, authService, &authData, email, false); err != nil {
+			var invErr *store.ErrInvalidInput
+			switch {
+			case errors.As(err, &invErr):
+				return nil, model.NewScimAppError("updateScimUser", model.ScimErrorTypeUniqueness, "a user with the same email or externalId already exists")
+			default:
+				return nil, model.NewAppError("updateScimUser", "app.user.update_auth_data.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+		}
+		a.InvalidateCacheForUser(user.Id)
+
+		var appErr *model.AppError
+		user, appErr = a.GetUser(user.Id)
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	patch := &model.UserPatch{
+		Username: model.NewPointer(model.CleanUsername(rctx.Logger(), scimUser.UserName)),
+		Nickname: model.NewPointer(scimUser.NickName),
+	}
+	if scimUser.Name != nil {
+		patch.FirstName = model.NewPointer(scimUser.Name.GivenName)
+		patch.LastName = model.NewPointer(scimUser.Name.FamilyName)
+	} else {
+		patch.FirstName = model.NewPointer("")
+		patch.LastName = model.NewPointer("")
+	}
+
+	if *patch.Username != user.Username || *patch.Nickname != user.Nickname || *patch.FirstName != user.FirstName || *patch.LastName != user.LastName {
+		user.Patch(patch)
+
+		var appErr *model.AppError
+		user, appErr = a.UpdateUser(rctx, user, false)
+		if appErr != nil {
+			return nil, scimError("updateScimUser", appErr)
+		}
+	}
+
+	if active := scimUser.IsActive(); active != (user.DeleteAt == 0) {
+		var appErr *model.AppError
+		user, appErr = a.UpdateActive(rctx, user, active)
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return a.scimUserFromUser(user), nil
+}
+
+// DeleteScimUser deactivates a user deprovisioned by the identity provider.
+// The user is kept so that their content and memberships are preserved.
+func (a *App) DeleteScimUser(rctx request.CTX, userID string) *model.AppError {
+	user, appErr := a.getProvisionedScimUser("DeleteScimUser", userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if user.DeleteAt != 0 {
+		return nil
+	}
+
+	_, appErr = a.UpdateActive(rctx, user, false)
+	return appErr
+}
+
+func (a *App) scimGroupFromGroup(group *model.Group, members []*model.User) *model.ScimGroup {
+	scimGroup := &model.ScimGroup{
+		Schemas:     []string{model.ScimSchemaGroup},
+		Id:          group.Id,
+		DisplayName: group.DisplayName,
+		Meta: &model.ScimMeta{
+			ResourceType: model.ScimResourceTypeGroup,
+			Created:      model.ScimTime(group.CreateAt),
+			LastModified: model.ScimTime(group.UpdateAt),
+			Location:     a.scimBaseURL() + "/Groups/" + group.Id,
+		},
+	}
+
+	if group.RemoteId != nil {
+		scimGroup.ExternalId = *group.RemoteId
+	}
+
+	for _, member := range members {
+		scimGroup.Members = append(scimGroup.Members, model.ScimMultiValue{
+			Value:   member.Id,
+			Display: member.Username,
+			Ref:     a.scimBaseURL() + "/Users/" + member.Id,
+		})
+	}
+
+	return scimGroup
+}
+
+func (a *App) getScimGroup(groupID string) (*model.Group, *model.AppError) {
+	group, appErr := a.GetGroup(groupID, nil, nil)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if group.Source != model.GroupSourceScim || group.DeleteAt != 0 {
+		return nil, model.NewAppError("getScimGroup", "app.group.no_rows", nil, "", http.StatusNotFound)
+	}
+
+	return group, nil
+}
+
+func (a *App) getScimGroupResource(group *model.Group, excludeMembers bool) (*model.ScimGroup, *model.AppError) {
+	if excludeMembers {
+		return a.scimGroupFromGroup(group, nil), nil
+	}
+
+	members, appErr := a.GetGroupMemberUsers(group.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.scimGroupFromGroup(group, members), nil
+}
+
+func (a *App) GetScimGroup(groupID string, excludeMembers bool) (*model.ScimGroup, *model.AppError) {
+	group, appErr := a.getScimGroup(groupID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.getScimGroupResource(group, excludeMembers)
+}
+
+// GetScimGroups returns the page of SCIM groups matching the given filter,
+// starting at the 1-based startIndex.
+func (a *App) GetScimGroups(filter string, startIndex, count int, excludeMembers bool) (*model.ScimListResponse, *model.AppError) {
+	var parsedFilter model.ScimFilter
+	if filter != "" {
+		var appErr *model.AppError
+		parsedFilter, appErr = model.ParseScimFilter(filter)
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	groups, appErr := a.GetGroupsBySource(model.GroupSourceScim)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	resources := []any{}
+	for _, group := range groups {
+		scimGroup, appErr := a.getScimGroupResource(group, excludeMembers)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		if parsedFilter == nil || model.ScimFilterMatches(parsedFilter, scimGroup) {
+			resources = append(resources, scimGroup)
+		}
+	}
+
+	return scimListResponse(resources, startIndex, count), nil
+}
+
+func (a *App) CreateScimGroup(rctx request.CTX, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError) {
+	if scimGroup.DisplayName == "" {
+		return nil, model.NewScimAppError("CreateScimGroup", model.ScimErrorTypeInvalidValue, "displayName is required")
+	}
+
+	group := &model.Group{
+		DisplayName: scimGroup.DisplayName,
+		Source:      model.GroupSourceScim,
+	}
+	if scimGroup.ExternalId != "" {
+		if _, appErr := a.GetGroupByRemoteID(scimGroup.ExternalId, model.GroupSourceScim); appErr == nil {
+			return nil, model.NewScimAppError("CreateScimGroup", model.ScimErrorTypeUniqueness, "a group with the same externalId already exists")
+		}
+		group.RemoteId = model.NewPointer(scimGroup.ExternalId)
+	}
+
+	memberIDs, appErr := a.getScimGroupMemberIDs(scimGroup)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	since := model.GetMillis()
+	group, appErr = a.CreateGroup(group)
+	if appErr != nil {
+		return nil, scimError("CreateScimGroup", appErr)
+	}
+
+	if len(memberIDs) > 0 {
+		if _, appErr := a.UpsertGroupMembers(group.Id, memberIDs); appErr != nil {
+			return nil, appErr
+		}
+		a.syncScimGroupMemberships(rctx, since, false)
+	}
+
+	return a.getScimGroupResource(group, false)
+}
+
+// UpdateScimGroup replaces the display name, external id and members of a
+// group with the ones of the given SCIM resource.
+func (a *App) UpdateScimGroup(rctx request.CTX, groupID string, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError) {
+	group, appErr := a.getScimGroup(groupID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.updateScimGroup(rctx, group, scimGroup)
+}
+
+// PatchScimGroup applies the operations of a SCIM PATCH request to a group.
+func (a *App) PatchScimGroup(rctx request.CTX, groupID string, operations []model.ScimPatchOperation) (*model.ScimGroup, *model.AppError) {
+	group, appErr := a.getScimGroup(groupID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	current, appErr := a.getScimGroupResource(group, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	patched, appErr := current.ApplyPatch(operations)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.updateScimGroup(rctx, group, patched)
+}
+
+func (a *App) updateScimGroup(rctx request.CTX, group *model.Group, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError) {
+	if scimGroup.DisplayName == "" {
+		return nil, model.NewScimAppError("updateScimGroup", model.ScimErrorTypeInvalidValue, "displayName is required")
+	}
+
+	var remoteID *string
+	if scimGroup.ExternalId != "" {
+		remoteID = model.NewPointer(scimGroup.ExternalId)
+	}
+
+	if group.DisplayName != scimGroup.DisplayName || group.GetRemoteId() != scimGroup.ExternalId {
+		group.DisplayName = scimGroup.DisplayName
+		group.RemoteId = remoteID
+
+		var appErr *model.AppError
+		group, appErr = a.UpdateGroup(group)
+		if appErr != nil {
+			return nil, scimError("updateScimGroup", appErr)
+		}
+	}
+
+	memberIDs, appErr := a.getScimGroupMemberIDs(scimGroup)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	members, appErr := a.GetGroupMemberUsers(group.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	current := make(map[string]bool, len(members))
+	for _, member := range members {
+		current[member.Id] = true
+	}
+
+	var added, removed []string
+	for _, memberID := range memberIDs {
+		if !current[memberID] {
+			added = append(added, memberID)
+		}
+		delete(current, memberID)
+	}
+	for memberID := range current {
+		removed = append(removed, memberID)
+	}
+
+	since := model.GetMillis()
+	if len(added) > 0 {
+		if _, appErr := a.UpsertGroupMembers(group.Id, added); appErr != nil {
+			return nil, appErr
+		}
+	}
+	if len(removed) > 0 {
+		if _, appErr := a.DeleteGroupMembers(group.Id, removed); appErr != nil {
+			return nil, appErr
+		}
+	}
+	if len(added) > 0 || len(removed) > 0 {
+		a.syncScimGroupMemberships(rctx, since, len(removed) > 0)
+	}
+
+	return a.getScimGroupResource(group, false)
+}
+
+func (a *App) DeleteScimGroup(rctx request.CTX, groupID string) *model.AppError {
+	if _, appErr := a.getScimGroup(groupID); appErr != nil {
+		return appErr
+	}
+
+	if _, appErr := a.DeleteGroup(groupID); appErr != nil {
+		return appErr
+	}
+
+	a.syncScimGroupMemberships(rctx, model.GetMillis(), true)
+
+	return nil
+}
+
+// getScimGroupMemberIDs returns the ids of the members of a SCIM group,
+// checking that each of them is a user which can be provisioned.
+func (a *App) getScimGroupMemberIDs(scimGroup *model.ScimGroup) ([]string, *model.AppError) {
+	memberIDs := []string{}
+	seen := map[string]bool{}
+	for _, member := range scimGroup.Members {
+		if member.Value == "" || seen[member.Value] {
+			continue
+		}
+		seen[member.Value] = true
+		memberIDs = append(memberIDs, member.Value)
+	}
+
+	if len(memberIDs) == 0 {
+		return memberIDs, nil
+	}
+
+	users, appErr := a.GetUsers(memberIDs)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	found := 0
+	for _, user := range users {
+		if !user.IsBot && !user.IsRemote() {
+			found++
+		}
+	}
+	if found != len(memberIDs) {
+		return nil, model.NewScimAppError("getScimGroupMemberIDs", model.ScimErrorTypeInvalidValue, "members must reference existing users")
+	}
+
+	return memberIDs, nil
+}
+
+// syncScimGroupMemberships adds and removes the team and channel memberships
+// of the users whose SCIM group memberships changed since the given time.
+func (a *App) syncScimGroupMemberships(rctx request.CTX, since int64, removedMembers bool) {
+	a.Srv().Go(func() {
+		if err := a.CreateDefaultMemberships(rctx, model.CreateDefaultMembershipParams{Since: since}); err != nil {
+			rctx.Logger().Warn("Error creating default memberships for SCIM groups", mlog.Err(err))
+		}
+
+		if removedMembers {
+			if err := a.DeleteGroupConstrainedMemberships(rctx); err != nil {
+				rctx.Logger().Warn("Error deleting group constrained memberships for SCIM groups", mlog.Err(err))
+			}
+		}
+	})
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/audit"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/active_users"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/antivirus_rescan"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/cleanup_desktop_tokens"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_dms_preferences_migration"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_empty_drafts_migration"
@@ -49,26 +50,33 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_process"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_users_to_csv"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/extract_content"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/file_reencryption"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/hosted_purchase_screening"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/import_delete"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/import_process"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/last_accessible_file"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/last_accessible_post"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/legal_hold_export"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/migrations"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/mobile_session_metadata"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/notify_admin"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/outgoing_webhook_deliveries"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/plugins"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/post_persistent_notifications"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/product_notices"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/refresh_post_stats"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/reminders"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/resend_invitation_email"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/s3_path_migration"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/saved_search_alerts"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/scheduled_posts"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 	"github.com/mattermost/mattermost/server/v8/config"
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
 	"github.com/mattermost/mattermost/server/v8/platform/services/awsmeter"
 	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+	"github.com/mattermost/mattermost/server/v8/platform/services/inboundmail"
 	"github.com/mattermost/mattermost/server/v8/platform/services/remotecluster"
 	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine/bleveengine"
 	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine/bleveengine/indexer"
@@ -106,6 +114,9 @@ type Server struct {
 
 	localModeServer *http.Server
 
+	inboundEmailServer *inboundmail.Server
+	inboundEmailMutex  sync.Mutex
+
 	didFinishListen chan struct{}
 
 	EmailService email.ServiceInterface
@@ -113,6 +124,7 @@ type Server struct {
 	httpService            httpservice.HTTPService
 	PushNotificationsHub   PushNotificationsHub
 	pushNotificationClient *http.Client // TODO: move this to it's own package
+	webPushClient          *http.Client
 	outgoingWebhookClient  *http.Client
 
 	runEssentialJobs bool
@@ -298,6 +310,8 @@ func NewServer(options ...Option) (*Server, error) {
 	}
 
 	s.pushNotificationClient = s.httpService.MakeClient(true)
+	// The push services of browsers are chosen by the clients.
+	s.webPushClient = s.httpService.MakeClient(false)
 	s.outgoingWebhookClient = s.httpService.MakeClient(false)
 
 	if err2 := utils.TranslationsPreInit(); err2 != nil {
@@ -376,6 +390,7 @@ func NewServer(options ...Option) (*Server, error) {
 			s.Jobs.HandleClusterLeaderChange(s.IsLeader())
 		}
 		s.platform.SetupFeatureFlags()
+		s.rebuildBleveIndexesOnLeader()
 	})
 
 	// If configured with a subpath, redirect 404s at the root back into the subpath.
@@ -494,6 +509,17 @@ func NewServer(options ...Option) (*Server, error) {
 		}
 	})
 
+	// Restart the inbound email server to apply its settings.
+	s.platform.AddConfigListener(func(oldCfg, newCfg *model.Config) {
+		if !inboundEmailSettingsChanged(oldCfg, newCfg) {
+			return
+		}
+		s.stopInboundEmailServer()
+		if err := s.startInboundEmailServer(); err != nil {
+			s.Log().Error("Error starting the inbound email server", mlog.Err(err))
+		}
+	})
+
 	app.initElasticsearchChannelIndexCheck()
 
 	return s, nil
@@ -699,6 +725,7 @@ func (s *Server) Shutdown() {
 
 	s.StopHTTPServer()
 	s.stopLocalModeServer()
+	s.stopInboundEmailServer()
 	// Push notification hub needs to be shutdown after HTTP server
 	// to prevent stray requests from generating a push notification after it's shut down.
 	s.StopPushNotificationsHubWorkers()
@@ -872,8 +899,11 @@ func (s *Server) Start() error {
 
 	err := s.FileBackend().TestConnection()
 	if err != nil {
-		if _, ok := err.(*filestore.S3FileBackendNoBucketError); ok {
-			err = s.FileBackend().(*filestore.S3FileBackend).MakeBucket()
+		switch err.(type) {
+		case *filestore.S3FileBackendNoBucketError, *filestore.AzureFileBackendNoContainerError, *filestore.GCSFileBackendNoBucketError:
+			if bucketMaker, ok := s.FileBackend().(interface{ MakeBucket() error }); ok {
+				err = bucketMaker.MakeBucket()
+			}
 		}
 		if err != nil {
 			mlog.Error("Problem with file storage settings", mlog.Err(err))
@@ -1088,6 +1118,10 @@ func (s *Server) Start() error {
 		mlog.Error("Error starting inter-cluster services", mlog.Err(err))
 	}
 
+	if err := s.startInboundEmailServer(); err != nil {
+		mlog.Error("Error starting the inbound email server", mlog.Err(err))
+	}
+
 	return nil
 }
 
@@ -1271,6 +1305,10 @@ func doSessionCleanup(s *Server) {
 	if err != nil {
 		mlog.Warn("Error while cleaning up sessions", mlog.Err(err))
 	}
+
+	if _, err := s.Store().WebPushSubscription().DeleteOrphaned(); err != nil {
+		mlog.Warn("Error while cleaning up web push subscriptions", mlog.Err(err))
+	}
 }
 
 func doJobsCleanup(s *Server) {
@@ -1584,6 +1622,24 @@ func (s *Server) initJobs() {
 		nil,
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeFileReencryption,
+		file_reencryption.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeAntivirusRescan,
+		antivirus_rescan.MakeWorker(s.Jobs, New(ServerConnector(s.Channels())), s.Store()),
+		nil,
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeLegalHoldExport,
+		legal_hold_export.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
 	s.Jobs.RegisterJobType(
 		model.JobTypeLastAccessiblePost,
 		last_accessible_post.MakeWorker(s.Jobs, s.License(), New(ServerConnector(s.Channels()))),
@@ -1649,6 +1705,30 @@ func (s *Server) initJobs() {
 		delete_dms_preferences_migration.MakeWorker(s.Jobs, s.Store(), New(ServerConnector(s.Channels()))),
 		nil)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeScheduledPosts,
+		scheduled_posts.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		scheduled_posts.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeOutgoingWebhookDeliveries,
+		outgoing_webhook_deliveries.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		outgoing_webhook_deliveries.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeReminders,
+		reminders.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		reminders.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeSavedSearchAlerts,
+		saved_search_alerts.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		saved_search_alerts.MakeScheduler(s.Jobs),
+	)
+
 	s.platform.Jobs = s.Jobs
 }
 
@@ -1832,6 +1912,20 @@ func (s *Server) Platform() *platform.PlatformService {
 	return s.platform
 }
 
+// rebuildBleveIndexesOnLeader starts a Bleve indexing job when this node is the
+// cluster leader. The other nodes use the leader's indexes, which may be
+// missing the changes made while another node was leading.
+func (s *Server) rebuildBleveIndexesOnLeader() {
+	cfg := s.platform.Config()
+	if !*cfg.ClusterSettings.Enable || !*cfg.BleveSettings.EnableIndexing || !s.IsLeader() || s.Jobs == nil {
+		return
+	}
+
+	if _, appErr := s.Jobs.CreateJobOnce(request.EmptyContext(s.Log()), model.JobTypeBlevePostIndexing, nil); appErr != nil {
+		s.Log().Warn("Failed to create a Bleve indexing job for the new cluster leader", mlog.Err(appErr))
+	}
+}
+
 func (s *Server) Log() *mlog.Logger {
 	return s.platform.Logger()
 }
@@ -275,6 +275,12 @@ func (a *App) RevokeSession(c request.CTX, session *model.Session) *model.AppErr
 		}
 	}
 
+	// Subscriptions of sessions removed otherwise are cleaned up with the
+	// sessions.
+	if err := a.Srv().Store().WebPushSubscription().DeleteForSession(session.Id); err != nil {
+		c.Logger().Warn("Failed to delete the web push subscription of the session", mlog.String("session_id", session.Id), mlog.Err(err))
+	}
+
 	return nil
 }
 
@@ -0,0 +1,110 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+)
+
+type PollProvider struct {
+}
+
+const (
+	CmdPoll = "poll"
+)
+
+func init() {
+	app.RegisterCommandProvider(&PollProvider{})
+}
+
+func (*PollProvider) GetTrigger() string {
+	return CmdPoll
+}
+
+func (*PollProvider) GetCommand(a *app.App, T i18n.TranslateFunc) *model.Command {
+	return &model.Command{
+		Trigger:          CmdPoll,
+		AutoComplete:     true,
+		AutoCompleteDesc: T("api.command_poll.desc"),
+		AutoCompleteHint: T("api.command_poll.hint"),
+		DisplayName:      T("api.command_poll.name"),
+	}
+}
+
+// DoCommand creates a poll from a command of the form
+// `/poll "Question" "Option 1" "Option 2" [--multi] [--anonymous] [--close 2h]`.
+func (*PollProvider) DoCommand(a *app.App, c request.CTX, args *model.CommandArgs, message string) *model.CommandResponse {
+	poll, errID := parsePollCommand(message)
+	if errID != "" {
+		return &model.CommandResponse{
+			Text:         args.T(errID, map[string]any{"Min": model.PollMinOptions, "Max": model.PollMaxOptions}),
+			ResponseType: model.CommandResponseTypeEphemeral,
+		}
+	}
+
+	if !a.HasPermissionToChannel(c, args.UserId, args.ChannelId, model.PermissionCreatePost) {
+		return &model.CommandResponse{Text: args.T("api.command_poll.permission.app_error"), ResponseType: model.CommandResponseTypeEphemeral}
+	}
+
+	poll.ChannelId = args.ChannelId
+	poll.UserId = args.UserId
+
+	if _, appErr := a.CreatePoll(c, poll, args.RootId); appErr != nil {
+		appErr.Translate(args.T)
+		return &model.CommandResponse{Text: appErr.Message, ResponseType: model.CommandResponseTypeEphemeral}
+	}
+
+	// The poll is posted by CreatePoll, so no response post is needed.
+	return &model.CommandResponse{}
+}
+
+// parsePollCommand returns the poll described by the arguments of the
+// command, or the id of the error to report.
+func parsePollCommand(message string) (*model.Poll, string) {
+	poll := &model.Poll{}
+	var texts []string
+
+	args := splitQuotedArgs(message)
+	for i := 0; i < len(args); i++ {
+		switch strings.ToLower(args[i]) {
+		case "--multi", "--multiple":
+			poll.MultipleChoice = true
+		case "--anonymous":
+			poll.Anonymous = true
+		case "--close":
+			if i == len(args)-1 {
+				return nil, "api.command_poll.close.app_error"
+			}
+			i++
+			duration, err := time.ParseDuration(args[i])
+			if err != nil || duration <= 0 {
+				return nil, "api.command_poll.close.app_error"
+			}
+			poll.CloseAt = model.GetMillisForTime(time.Now().Add(duration))
+		default:
+			texts = append(texts, args[i])
+		}
+	}
+
+	if len(texts) == 0 {
+		return nil, "api.command_poll.usage.app_error"
+	}
+
+	poll.Question = texts[0]
+	for _, text := range texts[1:] {
+		poll.Options = append(poll.Options, &model.PollOption{Text: text})
+	}
+
+	if len(poll.Options) < model.PollMinOptions || len(poll.Options) > model.PollMaxOptions {
+		return nil, "api.command_poll.options.app_error"
+	}
+
+	return poll, ""
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestParsePollCommand(t *testing.T) {
+	t.Run("question and options", func(t *testing.T) {
+		poll, errID := parsePollCommand(`"Where should we eat?" Pizza "Fried chicken"`)
+		require.Empty(t, errID)
+		assert.Equal(t, "Where should we eat?", poll.Question)
+		require.Len(t, poll.Options, 2)
+		assert.Equal(t, "Pizza", poll.Options[0].Text)
+		assert.Equal(t, "Fried chicken", poll.Options[1].Text)
+		assert.False(t, poll.MultipleChoice)
+		assert.False(t, poll.Anonymous)
+		assert.Zero(t, poll.CloseAt)
+	})
+
+	t.Run("flags", func(t *testing.T) {
+		before := model.GetMillis()
+		poll, errID := parsePollCommand(`"Lunch?" Yes No --multi --anonymous --close 2h`)
+		require.Empty(t, errID)
+		assert.True(t, poll.MultipleChoice)
+		assert.True(t, poll.Anonymous)
+		assert.GreaterOrEqual(t, poll.CloseAt, before+2*60*60*1000)
+		assert.Len(t, poll.Options, 2)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for message, expectedErrID := range map[string]string{
+			``:                            "api.command_poll.usage.app_error",
+			`"Lunch?"`:                    "api.command_poll.options.app_error",
+			`"Lunch?" Yes`:                "api.command_poll.options.app_error",
+			`"Lunch?" Yes No --close`:     "api.command_poll.close.app_error",
+			`"Lunch?" Yes No --close 2`:   "api.command_poll.close.app_error",
+			`"Lunch?" Yes No --close -1h`: "api.command_poll.close.app_error",
+		} {
+			_, errID := parsePollCommand(message)
+			assert.Equal(t, expectedErrID, errID, message)
+		}
+	})
+}
+
+func TestPollProviderDoCommand(t *testing.T) {
+	th := setup(t).initBasic()
+	defer th.tearDown()
+
+	pp := PollProvider{}
+
+	args := &model.CommandArgs{
+		T:         func(s string, args ...any) string { return s },
+		ChannelId: th.BasicChannel.Id,
+		UserId:    th.BasicUser.Id,
+	}
+
+	t.Run("creates a poll", func(t *testing.T) {
+		resp := pp.DoCommand(th.App, th.Context, args, `"Where should we eat?" Pizza Sushi --multi`)
+		assert.Empty(t, resp.Text)
+
+		posts, appErr := th.App.GetPosts(th.BasicChannel.Id, 0, 1)
+		require.Nil(t, appErr)
+		require.Len(t, posts.Order, 1)
+		post := posts.Posts[posts.Order[0]]
+		assert.Equal(t, model.PostTypePoll, post.Type)
+
+		poll, appErr := th.App.GetPollForPost(post.Id, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, "Where should we eat?", poll.Question)
+		assert.True(t, poll.MultipleChoice)
+	})
+
+	t.Run("reports invalid commands", func(t *testing.T) {
+		resp := pp.DoCommand(th.App, th.Context, args, `"Where should we eat?"`)
+		assert.Equal(t, model.CommandResponseTypeEphemeral, resp.ResponseType)
+		assert.Equal(t, "api.command_poll.options.app_error", resp.Text)
+	})
+
+	t.Run("requires permission to post", func(t *testing.T) {
+		privateChannel := th.createPrivateChannel(th.BasicTeam)
+		resp := pp.DoCommand(th.App, th.Context, &model.CommandArgs{
+			T:         args.T,
+			ChannelId: privateChannel.Id,
+			UserId:    th.BasicUser2.Id,
+		}, `"Lunch?" Yes No`)
+		assert.Equal(t, "api.command_poll.permission.app_error", resp.Text)
+	})
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+)
+
+type RemindProvider struct {
+}
+
+const (
+	CmdRemind = "remind"
+
+	defaultSnoozeDuration = 10 * time.Minute
+)
+
+func init() {
+	app.RegisterCommandProvider(&RemindProvider{})
+}
+
+func (*RemindProvider) GetTrigger() string {
+	return CmdRemind
+}
+
+func (*RemindProvider) GetCommand(a *app.App, T i18n.TranslateFunc) *model.Command {
+	return &model.Command{
+		Trigger:          CmdRemind,
+		AutoComplete:     true,
+		AutoCompleteDesc: T("api.command_remind.desc"),
+		AutoCompleteHint: T("api.command_remind.hint"),
+		DisplayName:      T("api.command_remind.name"),
+	}
+}
+
+// DoCommand handles `/remind me|@user|~channel <what> <when>`, as well as
+// `/remind list` and `/remind snooze [duration]`.
+func (*RemindProvider) DoCommand(a *app.App, c request.CTX, args *model.CommandArgs, message string) *model.CommandResponse {
+	user, appErr := a.GetUser(args.UserId)
+	if appErr != nil {
+		return remindErrorResponse(args, appErr)
+	}
+
+	loc, err := time.LoadLocation(user.GetPreferredTimezone())
+	if err != nil {
+		loc = time.UTC
+	}
+	now := time.Now().In(loc)
+
+	target, text, _ := strings.Cut(strings.TrimSpace(message), " ")
+	switch strings.ToLower(target) {
+	case "", "help":
+		return &model.CommandResponse{Text: args.T("api.command_remind.usage"), ResponseType: model.CommandResponseTypeEphemeral}
+	case "list":
+		return listReminders(a, c, args, loc)
+	case "snooze":
+		return snoozeReminder(a, c, args, text, now)
+	}
+
+	reminder := &model.Reminder{UserId: args.UserId}
+	switch {
+	case strings.ToLower(target) == "me" || strings.ToLower(target) == "@me":
+		reminder.TargetType = model.ReminderTargetUser
+		reminder.TargetId = args.UserId
+	case strings.HasPrefix(target, "@"):
+		targetUser, appErr := a.GetUserByUsername(strings.TrimPrefix(target, "@"))
+		if appErr != nil {
+			return &model.CommandResponse{Text: args.T("api.command_remind.target.app_error"), ResponseType: model.CommandResponseTypeEphemeral}
+		}
+		reminder.TargetType = model.ReminderTargetUser
+		reminder.TargetId = targetUser.Id
+	case strings.HasPrefix(target, "~"):
+		channel, appErr := a.GetChannelByName(c, strings.TrimPrefix(target, "~"), args.TeamId, false)
+		if appErr != nil {
+			return &model.CommandResponse{Text: args.T("api.command_remind.target.app_error"), ResponseType: model.CommandResponseTypeEphemeral}
+		}
+		reminder.TargetType = model.ReminderTargetChannel
+		reminder.TargetId = channel.Id
+	default:
+		return &model.CommandResponse{Text: args.T("api.command_remind.target.app_error"), ResponseType: model.CommandResponseTypeEphemeral}
+	}
+
+	what, schedule, ok := parseReminderText(text, now)
+	if !ok || what == "" {
+		return &model.CommandResponse{Text: args.T("api.command_remind.parse_time.app_error"), ResponseType: model.CommandResponseTypeEphemeral}
+	}
+
+	reminder.Message = what
+	reminder.TriggerAt = schedule.at.UnixMilli()
+	reminder.Recurrence = schedule.recurrence
+
+	reminder, appErr = a.CreateReminder(c, reminder)
+	if appErr != nil {
+		return remindErrorResponse(args, appErr)
+	}
+
+	return &model.CommandResponse{
+		Text: args.T("api.command_remind.created", map[string]any{
+			"Target":  describeReminderTarget(a, c, args, reminder),
+			"Message": reminder.Message,
+			"When":    describeReminderTime(args.T, reminder, loc),
+		}),
+		ResponseType: model.CommandResponseTypeEphemeral,
+	}
+}
+
+func listReminders(a *app.App, c request.CTX, args *model.CommandArgs, loc *time.Location) *model.CommandResponse {
+	reminders, appErr := a.GetRemindersForUser(args.UserId)
+	if appErr != nil {
+		return remindErrorResponse(args, appErr)
+	}
+
+	if len(reminders) == 0 {
+		return &model.CommandResponse{Text: args.T("api.command_remind.list.empty"), ResponseType: model.CommandResponseTypeEphemeral}
+	}
+
+	lines := []string{args.T("api.command_remind.list.title")}
+	for _, reminder := range reminders {
+		lines = append(lines, args.T("api.command_remind.list.item", map[string]any{
+			"Target":  describeReminderTarget(a, c, args, reminder),
+			"Message": reminder.Message,
+			"When":    describeReminderTime(args.T, reminder, loc),
+		}))
+	}
+
+	return &model.CommandResponse{Text: strings.Join(lines, "\n"), ResponseType: model.CommandResponseTypeEphemeral}
+}
+
+// snoozeReminder snoozes the reminder most recently delivered to the user,
+// for a duration ("10m", "1 hour") or until a time ("tomorrow at 9").
+func snoozeReminder(a *app.App, c request.CTX, args *model.CommandArgs, text string, now time.Time) *model.CommandResponse {
+	until := now.Add(defaultSnoozeDuration)
+	if text = strings.TrimSpace(text); text != "" {
+		tokens := strings.Fields(strings.ToLower(strings.TrimPrefix(text, "for ")))
+		if days, duration, ok := parseReminderDuration(tokens); ok {
+			until = now.AddDate(0, 0, days).Add(duration)
+		} else if schedule, ok := parseReminderWhen(strings.TrimPrefix(text, "until "), now); ok && schedule.recurrence == nil {
+			until = schedule.at
+		} else {
+			return &model.CommandResponse{Text: args.T("api.command_remind.snooze.parse.app_error"), ResponseType: model.CommandResponseTypeEphemeral}
+		}
+	}
+
+	reminder, appErr := a.GetLastTriggeredReminder(args.UserId)
+	if appErr != nil {
+		return remindErrorResponse(args, appErr)
+	}
+
+	reminder, appErr = a.SnoozeReminder(c, args.UserId, reminder.Id, until.UnixMilli())
+	if appErr != nil {
+		return remindErrorResponse(args, appErr)
+	}
+
+	return &model.CommandResponse{
+		Text: args.T("api.command_remind.created", map[string]any{
+			"Target":  describeReminderTarget(a, c, args, reminder),
+			"Message": reminder.Message,
+			"When":    describeReminderTime(args.T, reminder, now.Location()),
+		}),
+		ResponseType: model.CommandResponseTypeEphemeral,
+	}
+}
+
+func remindErrorResponse(args *model.CommandArgs, appErr *model.AppError) *model.CommandResponse {
+	appErr.Translate(args.T)
+	return &model.CommandResponse{Text: appErr.Message, ResponseType: model.CommandResponseTypeEphemeral}
+}
+
+func describeReminderTarget(a *app.App, c request.CTX, args *model.CommandArgs, reminder *model.Reminder) string {
+	switch reminder.TargetType {
+	case model.ReminderTargetUser:
+		if reminder.TargetId == args.UserId {
+			return args.T("api.command_remind.target.you")
+		}
+		if user, appErr := a.GetUser(reminder.TargetId); appErr == nil {
+			return "@" + user.Username
+		}
+	case model.ReminderTargetChannel:
+		if channel, appErr := a.GetChannel(c, reminder.TargetId); appErr == nil {
+			return "~" + channel.Name
+		}
+	}
+
+	return reminder.TargetId
+}
+
+// describeReminderTime describes when the reminder is delivered next, in the
+// time zone of the user.
+func describeReminderTime(T i18n.TranslateFunc, reminder *model.Reminder, loc *time.Location) string {
+	if reminder.IsCompleted() {
+		return T("api.command_remind.when.completed")
+	}
+
+	next := time.UnixMilli(reminder.TriggerAt).In(loc)
+	once := T("api.command_remind.when.once", map[string]any{
+		"Date": next.Format("Mon, Jan 2 2006"),
+		"Time": next.Format("3:04 PM MST"),
+	})
+
+	recurrence := reminder.Recurrence
+	if recurrence == nil {
+		return once
+	}
+
+	clock := time.Date(2000, time.January, 1, recurrence.Hour, recurrence.Minute, 0, 0, time.UTC).Format("3:04 PM")
+	var every string
+	switch recurrence.Frequency {
+	case model.ReminderFrequencyDaily:
+		every = T("api.command_remind.when.daily", map[string]any{"Time": clock})
+	case model.ReminderFrequencyWeekdays:
+		every = T("api.command_remind.when.weekdays", map[string]any{"Time": clock})
+	case model.ReminderFrequencyWeekly:
+		days := make([]string, 0, len(recurrence.Weekdays))
+		for _, weekday := range recurrence.Weekdays {
+			days = append(days, weekday.String())
+		}
+		every = T("api.command_remind.when.weekly", map[string]any{"Days": strings.Join(days, ", "), "Time": clock})
+	case model.ReminderFrequencyMonthly:
+		every = T("api.command_remind.when.monthly", map[string]any{"Day": recurrence.DayOfMonth, "Time": clock})
+	}
+
+	return T("api.command_remind.when.recurring", map[string]any{"Every": every, "Next": once})
+}
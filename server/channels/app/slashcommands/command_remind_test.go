@@ -0,0 +1,112 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestRemindProviderDoCommand(t *testing.T) {
+	th := setup(t).initBasic()
+	defer th.tearDown()
+
+	rp := RemindProvider{}
+
+	args := &model.CommandArgs{
+		T:         func(s string, args ...any) string { return s },
+		ChannelId: th.BasicChannel.Id,
+		TeamId:    th.BasicTeam.Id,
+		UserId:    th.BasicUser.Id,
+	}
+
+	t.Run("reminds the user", func(t *testing.T) {
+		resp := rp.DoCommand(th.App, th.Context, args, "me to water the plants in 2 hours")
+		assert.Equal(t, "api.command_remind.created", resp.Text)
+
+		reminders, appErr := th.App.GetRemindersForUser(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		require.Len(t, reminders, 1)
+		assert.Equal(t, "water the plants", reminders[0].Message)
+		assert.Equal(t, model.ReminderTargetUser, reminders[0].TargetType)
+		assert.Equal(t, th.BasicUser.Id, reminders[0].TargetId)
+		assert.Nil(t, reminders[0].Recurrence)
+	})
+
+	t.Run("reminds another user", func(t *testing.T) {
+		resp := rp.DoCommand(th.App, th.Context, args, "@"+th.BasicUser2.Username+" to review the PR tomorrow at 10am")
+		assert.Equal(t, "api.command_remind.created", resp.Text)
+	})
+
+	t.Run("reminds a channel on a recurring schedule", func(t *testing.T) {
+		resp := rp.DoCommand(th.App, th.Context, args, "~"+th.BasicChannel.Name+" to fill in the standup notes every weekday at 9")
+		assert.Equal(t, "api.command_remind.created", resp.Text)
+
+		reminders, appErr := th.App.GetRemindersForUser(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		var found *model.Reminder
+		for _, reminder := range reminders {
+			if reminder.TargetType == model.ReminderTargetChannel {
+				found = reminder
+			}
+		}
+		require.NotNil(t, found)
+		assert.Equal(t, th.BasicChannel.Id, found.TargetId)
+		require.NotNil(t, found.Recurrence)
+		assert.Equal(t, model.ReminderFrequencyWeekdays, found.Recurrence.Frequency)
+	})
+
+	t.Run("lists reminders", func(t *testing.T) {
+		resp := rp.DoCommand(th.App, th.Context, args, "list")
+		assert.Contains(t, resp.Text, "api.command_remind.list.title")
+		assert.Contains(t, resp.Text, "api.command_remind.list.item")
+	})
+
+	t.Run("reports unknown targets and times", func(t *testing.T) {
+		resp := rp.DoCommand(th.App, th.Context, args, "@nobody-"+model.NewId()+" to do it tomorrow")
+		assert.Equal(t, "api.command_remind.target.app_error", resp.Text)
+
+		resp = rp.DoCommand(th.App, th.Context, args, "me to do it eventually")
+		assert.Equal(t, "api.command_remind.parse_time.app_error", resp.Text)
+	})
+
+	t.Run("requires permission to post to the channel", func(t *testing.T) {
+		privateChannel := th.createPrivateChannel(th.BasicTeam)
+		resp := rp.DoCommand(th.App, th.Context, &model.CommandArgs{
+			T:         args.T,
+			ChannelId: th.BasicChannel.Id,
+			TeamId:    th.BasicTeam.Id,
+			UserId:    th.BasicUser2.Id,
+		}, "~"+privateChannel.Name+" to do it tomorrow")
+		assert.Equal(t, "app.reminder.create.target_channel.app_error", resp.Text)
+	})
+
+	t.Run("snoozes the last delivered reminder", func(t *testing.T) {
+		resp := rp.DoCommand(th.App, th.Context, args, "snooze")
+		assert.Equal(t, "app.reminder.get_last_triggered.not_found.app_error", resp.Text)
+
+		reminder, appErr := th.App.CreateReminder(th.Context, &model.Reminder{
+			UserId:     th.BasicUser.Id,
+			TargetType: model.ReminderTargetUser,
+			TargetId:   th.BasicUser.Id,
+			Message:    "Stretch",
+			TriggerAt:  model.GetMillis() + 1000,
+		})
+		require.Nil(t, appErr)
+		reminder.TriggerAt = 0
+		reminder.LastTriggeredAt = model.GetMillis()
+		require.NoError(t, th.App.Srv().Store().Reminder().Update(reminder))
+
+		resp = rp.DoCommand(th.App, th.Context, args, "snooze 15 minutes")
+		assert.Equal(t, "api.command_remind.created", resp.Text)
+
+		snoozed, appErr := th.App.GetReminder(reminder.Id)
+		require.Nil(t, appErr)
+		assert.Greater(t, snoozed.TriggerAt, model.GetMillis()+14*60*1000)
+	})
+}
@@ -0,0 +1,529 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// defaultReminderHour is the time of day used for reminders set on a day
+// without a time, such as "tomorrow" or "every monday".
+const defaultReminderHour = 9
+
+var (
+	clockRegexp   = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm|a\.m\.|p\.m\.)?$`)
+	isoDateRegexp = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`)
+	ordinalRegexp = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?$`)
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+var monthNames = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
+
+// reminderSchedule is the result of parsing when a reminder is due.
+type reminderSchedule struct {
+	at         time.Time
+	recurrence *model.ReminderRecurrence
+}
+
+// parseReminderText splits the text of a /remind command, without its
+// target, into what to be reminded of and when. The time is either at the end
+// ("to call Bob tomorrow at 5pm") or at the start ("tomorrow at 5pm to call
+// Bob"), and the message may be quoted.
+func parseReminderText(text string, now time.Time) (string, *reminderSchedule, bool) {
+	text = strings.TrimSpace(text)
+
+	if strings.HasPrefix(text, `"`) || strings.HasPrefix(text, "“") {
+		args := splitQuotedArgs(text)
+		if len(args) < 2 {
+			return "", nil, false
+		}
+		schedule, ok := parseReminderWhen(strings.Join(args[1:], " "), now)
+		if !ok {
+			return "", nil, false
+		}
+		return args[0], schedule, true
+	}
+
+	words := strings.Fields(text)
+
+	// The longest suffix that parses as a time wins, so that the message can
+	// contain words such as "in" or "at".
+	for i := 1; i < len(words); i++ {
+		if schedule, ok := parseReminderWhen(strings.Join(words[i:], " "), now); ok {
+			return cleanReminderMessage(strings.Join(words[:i], " ")), schedule, true
+		}
+	}
+
+	for i := len(words) - 1; i > 0; i-- {
+		if schedule, ok := parseReminderWhen(strings.Join(words[:i], " "), now); ok {
+			return cleanReminderMessage(strings.Join(words[i:], " ")), schedule, true
+		}
+	}
+
+	return "", nil, false
+}
+
+func cleanReminderMessage(message string) string {
+	lower := strings.ToLower(message)
+	for _, prefix := range []string{"to ", "that ", "about "} {
+		if strings.HasPrefix(lower, prefix) {
+			message = message[len(prefix):]
+			break
+		}
+	}
+
+	return strings.Trim(strings.TrimSpace(message), `"“”`)
+}
+
+// parseReminderWhen parses a time relative to now, such as "in 2 hours",
+// "tomorrow at 9am", "on friday", "at noon", "on 2024-12-25" or
+// "every weekday at 9". The returned time is always after now.
+func parseReminderWhen(when string, now time.Time) (*reminderSchedule, bool) {
+	tokens := strings.Fields(strings.ToLower(strings.TrimRight(strings.TrimSpace(when), ".!")))
+	if len(tokens) == 0 {
+		return nil, false
+	}
+
+	switch tokens[0] {
+	case "in":
+		days, duration, ok := parseReminderDuration(tokens[1:])
+		if !ok {
+			return nil, false
+		}
+		return &reminderSchedule{at: now.AddDate(0, 0, days).Add(duration)}, true
+	case "every":
+		recurrence, ok := parseReminderRecurrence(tokens[1:], now)
+		if !ok {
+			return nil, false
+		}
+		return &reminderSchedule{at: recurrence.Next(now), recurrence: recurrence}, true
+	}
+
+	at, ok := parseReminderDateTime(tokens, now)
+	if !ok {
+		return nil, false
+	}
+
+	return &reminderSchedule{at: at}, true
+}
+
+// parseReminderDuration parses durations such as "5 minutes", "an hour",
+// "2 hours and 30 minutes" or "1h30m". Days and weeks are returned
+// separately so that they can be added as calendar days.
+func parseReminderDuration(tokens []string) (int, time.Duration, bool) {
+	if len(tokens) == 0 {
+		return 0, 0, false
+	}
+
+	if len(tokens) == 1 {
+		if duration, err := time.ParseDuration(tokens[0]); err == nil && duration > 0 {
+			return 0, duration, true
+		}
+	}
+
+	days := 0
+	var duration time.Duration
+	for i := 0; i < len(tokens); {
+		if tokens[i] == "and" && i > 0 {
+			i++
+			continue
+		}
+
+		amount, unit := 0, ""
+		if n, err := strconv.Atoi(tokens[i]); err == nil && n > 0 && i+1 < len(tokens) {
+			amount, unit = n, tokens[i+1]
+			i += 2
+		} else if (tokens[i] == "a" || tokens[i] == "an") && i+1 < len(tokens) {
+			amount, unit = 1, tokens[i+1]
+			i += 2
+		} else if n, suffix, ok := splitAmountAndUnit(tokens[i]); ok {
+			amount, unit = n, suffix
+			i++
+		} else {
+			return 0, 0, false
+		}
+
+		switch strings.TrimSuffix(strings.TrimRight(unit, ","), "s") {
+		case "m", "min", "minute":
+			duration += time.Duration(amount) * time.Minute
+		case "h", "hr", "hour":
+			duration += time.Duration(amount) * time.Hour
+		case "d", "day":
+			days += amount
+		case "w", "wk", "week":
+			days += 7 * amount
+		default:
+			return 0, 0, false
+		}
+	}
+
+	return days, duration, days > 0 || duration > 0
+}
+
+// splitAmountAndUnit splits tokens such as "5m" or "2days".
+func splitAmountAndUnit(token string) (int, string, bool) {
+	i := strings.IndexFunc(token, func(r rune) bool { return r < '0' || r > '9' })
+	if i <= 0 {
+		return 0, "", false
+	}
+
+	n, err := strconv.Atoi(token[:i])
+	if err != nil || n <= 0 {
+		return 0, "", false
+	}
+
+	return n, token[i:], true
+}
+
+// parseReminderRecurrence parses what follows "every", such as "day",
+// "weekday at 9", "monday and thursday at 10:30am" or "month on the 15th".
+func parseReminderRecurrence(tokens []string, now time.Time) (*model.ReminderRecurrence, bool) {
+	recurrence := &model.ReminderRecurrence{
+		Hour:     defaultReminderHour,
+		TimeZone: now.Location().String(),
+	}
+
+	i := 0
+	switch {
+	case len(tokens) == 0:
+		return nil, false
+	case tokens[0] == "day" || tokens[0] == "morning":
+		recurrence.Frequency = model.ReminderFrequencyDaily
+		i++
+	case tokens[0] == "weekday" || tokens[0] == "weekdays":
+		recurrence.Frequency = model.ReminderFrequencyWeekdays
+		i++
+	case tokens[0] == "week":
+		recurrence.Frequency = model.ReminderFrequencyWeekly
+		recurrence.Weekdays = []time.Weekday{now.Weekday()}
+		i++
+	case tokens[0] == "month":
+		recurrence.Frequency = model.ReminderFrequencyMonthly
+		recurrence.DayOfMonth = now.Day()
+		i++
+		if i < len(tokens) && tokens[i] == "on" {
+			i++
+			if i < len(tokens) && tokens[i] == "the" {
+				i++
+			}
+			if i == len(tokens) {
+				return nil, false
+			}
+			day, ok := parseDayOfMonth(tokens[i])
+			if !ok {
+				return nil, false
+			}
+			recurrence.DayOfMonth = day
+			i++
+		}
+	default:
+		recurrence.Frequency = model.ReminderFrequencyWeekly
+		for ; i < len(tokens) && tokens[i] != "at"; i++ {
+			for _, name := range strings.Split(tokens[i], ",") {
+				if name == "" || name == "and" {
+					continue
+				}
+				weekday, ok := weekdayNames[strings.TrimSuffix(name, "s")]
+				if !ok {
+					weekday, ok = weekdayNames[name]
+				}
+				if !ok {
+					return nil, false
+				}
+				recurrence.Weekdays = append(recurrence.Weekdays, weekday)
+			}
+		}
+		if len(recurrence.Weekdays) == 0 {
+			return nil, false
+		}
+	}
+
+	if i < len(tokens) {
+		if tokens[i] != "at" {
+			return nil, false
+		}
+		hour, minute, n, ok := parseClock(tokens[i+1:], true)
+		if !ok || i+1+n != len(tokens) {
+			return nil, false
+		}
+		recurrence.Hour, recurrence.Minute = hour, minute
+	}
+
+	return recurrence, recurrence.IsValid()
+}
+
+func parseDayOfMonth(token string) (int, bool) {
+	match := ordinalRegexp.FindStringSubmatch(token)
+	if match == nil {
+		return 0, false
+	}
+
+	day, _ := strconv.Atoi(match[1])
+	return day, day >= 1 && day <= 31
+}
+
+type dayRoll int
+
+const (
+	rollNone dayRoll = iota
+	rollWeek
+	rollYear
+)
+
+// parseReminderDateTime parses a day, a time of day, or both, in any order:
+// "tomorrow", "at 3pm", "friday at noon", "at 9:30 on 2024-12-25".
+func parseReminderDateTime(tokens []string, now time.Time) (time.Time, bool) {
+	var day time.Time
+	var roll dayRoll
+	hasDay, hasTime := false, false
+	hour, minute := defaultReminderHour, 0
+
+	for i := 0; i < len(tokens); {
+		if tokens[i] == "at" {
+			h, m, n, ok := parseClock(tokens[i+1:], true)
+			if !ok || hasTime {
+				return time.Time{}, false
+			}
+			hour, minute, hasTime = h, m, true
+			i += 1 + n
+			continue
+		}
+
+		if d, r, defaultHour, n, ok := parseDay(tokens[i:], now); ok && !hasDay {
+			day, roll, hasDay = d, r, true
+			if !hasTime {
+				hour = defaultHour
+			}
+			i += n
+			continue
+		}
+
+		if h, m, n, ok := parseClock(tokens[i:], false); ok && !hasTime {
+			hour, minute, hasTime = h, m, true
+			i += n
+			continue
+		}
+
+		return time.Time{}, false
+	}
+
+	if !hasDay && !hasTime {
+		return time.Time{}, false
+	}
+
+	if !hasDay {
+		day = now
+		roll = rollNone
+	}
+
+	at := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, now.Location())
+	if !at.After(now) {
+		switch {
+		case !hasDay:
+			at = time.Date(day.Year(), day.Month(), day.Day()+1, hour, minute, 0, 0, now.Location())
+		case roll == rollWeek:
+			at = time.Date(day.Year(), day.Month(), day.Day()+7, hour, minute, 0, 0, now.Location())
+		case roll == rollYear:
+			at = time.Date(day.Year()+1, day.Month(), day.Day(), hour, minute, 0, 0, now.Location())
+		default:
+			return time.Time{}, false
+		}
+	}
+
+	return at, true
+}
+
+// parseDay parses the day at the start of the tokens and returns it along
+// with how to move it forward if it is in the past, the default hour for
+// that day and the number of tokens consumed.
+func parseDay(tokens []string, now time.Time) (time.Time, dayRoll, int, int, bool) {
+	if len(tokens) == 0 {
+		return time.Time{}, rollNone, 0, 0, false
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	token := strings.TrimRight(tokens[0], ",")
+
+	switch token {
+	case "today":
+		return today, rollNone, defaultReminderHour, 1, true
+	case "tonight":
+		return today, rollNone, 20, 1, true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), rollNone, defaultReminderHour, 1, true
+	case "on":
+		day, roll, defaultHour, n, ok := parseDay(tokens[1:], now)
+		if !ok || tokens[1] == "on" {
+			return time.Time{}, rollNone, 0, 0, false
+		}
+		return day, roll, defaultHour, n + 1, true
+	case "next":
+		if len(tokens) < 2 {
+			return time.Time{}, rollNone, 0, 0, false
+		}
+		switch weekday, ok := weekdayNames[strings.TrimRight(tokens[1], ",")]; {
+		case ok:
+			days := (int(weekday)-int(now.Weekday())+6)%7 + 1
+			return today.AddDate(0, 0, days), rollNone, defaultReminderHour, 2, true
+		case strings.TrimRight(tokens[1], ",") == "week":
+			return today.AddDate(0, 0, 7), rollNone, defaultReminderHour, 2, true
+		}
+		return time.Time{}, rollNone, 0, 0, false
+	}
+
+	if weekday, ok := weekdayNames[token]; ok {
+		days := (int(weekday) - int(now.Weekday()) + 7) % 7
+		return today.AddDate(0, 0, days), rollWeek, defaultReminderHour, 1, true
+	}
+
+	if match := isoDateRegexp.FindStringSubmatch(token); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		month, _ := strconv.Atoi(match[2])
+		day, _ := strconv.Atoi(match[3])
+		date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, now.Location())
+		if date.Month() != time.Month(month) || date.Day() != day {
+			return time.Time{}, rollNone, 0, 0, false
+		}
+		return date, rollNone, defaultReminderHour, 1, true
+	}
+
+	// "december 25", "dec 25th 2024", "25 december" or "the 25th of december".
+	n := 0
+	if token == "the" {
+		n = 1
+	}
+	if len(tokens) < n+2 {
+		return time.Time{}, rollNone, 0, 0, false
+	}
+
+	first, second := strings.TrimRight(tokens[n], ","), strings.TrimRight(tokens[n+1], ",")
+	month, monthFirst := monthNames[first]
+	dayToken := second
+	n += 2
+	if !monthFirst {
+		var ok bool
+		if second == "of" && len(tokens) > n {
+			second = strings.TrimRight(tokens[n], ",")
+			n++
+		}
+		if month, ok = monthNames[second]; !ok {
+			return time.Time{}, rollNone, 0, 0, false
+		}
+		dayToken = first
+	}
+
+	day, ok := parseDayOfMonth(dayToken)
+	if !ok {
+		return time.Time{}, rollNone, 0, 0, false
+	}
+
+	year, roll := now.Year(), rollYear
+	if len(tokens) > n {
+		if y, err := strconv.Atoi(tokens[n]); err == nil && y >= now.Year() && y < now.Year()+100 {
+			year, roll = y, rollNone
+			n++
+		}
+	}
+
+	date := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	if date.Day() != day {
+		return time.Time{}, rollNone, 0, 0, false
+	}
+
+	return date, roll, defaultReminderHour, n, true
+}
+
+// parseClock parses the time of day at the start of the tokens: "noon",
+// "3pm", "3:30 pm" or "15:30". A bare hour such as "9" is only accepted
+// when allowBareHour is set, that is after "at".
+func parseClock(tokens []string, allowBareHour bool) (int, int, int, bool) {
+	if len(tokens) == 0 {
+		return 0, 0, 0, false
+	}
+
+	switch tokens[0] {
+	case "noon":
+		return 12, 0, 1, true
+	case "midnight":
+		return 0, 0, 1, true
+	}
+
+	match := clockRegexp.FindStringSubmatch(tokens[0])
+	if match == nil {
+		return 0, 0, 0, false
+	}
+
+	n := 1
+	meridiem := match[3]
+	if meridiem == "" && len(tokens) > 1 {
+		switch tokens[1] {
+		case "am", "pm", "a.m.", "p.m.":
+			meridiem = tokens[1]
+			n++
+		}
+	}
+
+	if meridiem == "" && match[2] == "" && !allowBareHour {
+		return 0, 0, 0, false
+	}
+
+	hour, _ := strconv.Atoi(match[1])
+	minute := 0
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+	if minute > 59 {
+		return 0, 0, 0, false
+	}
+
+	switch strings.ReplaceAll(meridiem, ".", "") {
+	case "am":
+		if hour < 1 || hour > 12 {
+			return 0, 0, 0, false
+		}
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, 0, false
+		}
+		if hour != 12 {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return 0, 0, 0, false
+		}
+	}
+
+	return hour, minute, n, true
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slashcommands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestParseReminderWhen(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Wednesday 2024-03-06 10:30 in New York.
+	now := time.Date(2024, time.March, 6, 10, 30, 0, 0, loc)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2024, month, day, hour, minute, 0, 0, loc)
+	}
+
+	for when, expected := range map[string]time.Time{
+		"in 5 minutes":                  now.Add(5 * time.Minute),
+		"in 5 mins":                     now.Add(5 * time.Minute),
+		"in an hour":                    now.Add(time.Hour),
+		"in 1h30m":                      now.Add(90 * time.Minute),
+		"in 2 hours and 15 minutes":     now.Add(2*time.Hour + 15*time.Minute),
+		"in 2 days":                     at(time.March, 8, 10, 30),
+		"in 1 week":                     at(time.March, 13, 10, 30),
+		"at 3pm":                        at(time.March, 6, 15, 0),
+		"at 3:45 pm":                    at(time.March, 6, 15, 45),
+		"at 15:45":                      at(time.March, 6, 15, 45),
+		"at 9":                          at(time.March, 7, 9, 0),
+		"at noon":                       at(time.March, 6, 12, 0),
+		"at midnight":                   at(time.March, 7, 0, 0),
+		"12am":                          at(time.March, 7, 0, 0),
+		"tomorrow":                      at(time.March, 7, 9, 0),
+		"tomorrow at 5pm":               at(time.March, 7, 17, 0),
+		"at 5pm tomorrow":               at(time.March, 7, 17, 0),
+		"tonight":                       at(time.March, 6, 20, 0),
+		"today at 11am":                 at(time.March, 6, 11, 0),
+		"friday":                        at(time.March, 8, 9, 0),
+		"on friday at 4:30pm":           at(time.March, 8, 16, 30),
+		"wednesday at 9am":              at(time.March, 13, 9, 0),
+		"wednesday at 11am":             at(time.March, 6, 11, 0),
+		"next wednesday":                at(time.March, 13, 9, 0),
+		"next week":                     at(time.March, 13, 9, 0),
+		"on 2024-03-20":                 at(time.March, 20, 9, 0),
+		"on 2024-03-20 at 14:00":        at(time.March, 20, 14, 0),
+		"on march 20th":                 at(time.March, 20, 9, 0),
+		"march 1":                       time.Date(2025, time.March, 1, 9, 0, 0, 0, loc),
+		"on the 1st of april at 8am":    at(time.April, 1, 8, 0),
+		"20 march 2024":                 at(time.March, 20, 9, 0),
+		"every day at 9am":              at(time.March, 7, 9, 0),
+		"every weekday":                 at(time.March, 7, 9, 0),
+		"every monday and friday at 10": at(time.March, 8, 10, 0),
+		"every tue, thu at 11:00":       at(time.March, 7, 11, 0),
+		"every mondays":                 at(time.March, 11, 9, 0),
+		"every week":                    at(time.March, 13, 9, 0),
+		"every month on the 15th":       at(time.March, 15, 9, 0),
+		"every month at noon":           at(time.March, 6, 12, 0),
+	} {
+		t.Run(when, func(t *testing.T) {
+			schedule, ok := parseReminderWhen(when, now)
+			require.True(t, ok)
+			assert.True(t, expected.Equal(schedule.at), "expected %s, got %s", expected, schedule.at)
+		})
+	}
+
+	for _, when := range []string{
+		"",
+		"in",
+		"in 5",
+		"in 5 parsecs",
+		"in -5 minutes",
+		"at",
+		"at 25:00",
+		"at 13pm",
+		"at 3:75pm",
+		"today at 9am",
+		"on 2024-02-30",
+		"on 2023-01-01",
+		"every",
+		"every fortnight",
+		"every month on the 32nd",
+		"every day at dawn",
+		"tomorrow tomorrow",
+		"the report",
+		"5",
+	} {
+		t.Run("invalid "+when, func(t *testing.T) {
+			_, ok := parseReminderWhen(when, now)
+			assert.False(t, ok)
+		})
+	}
+
+	t.Run("recurrence", func(t *testing.T) {
+		schedule, ok := parseReminderWhen("every monday and friday at 10:15am", now)
+		require.True(t, ok)
+		require.NotNil(t, schedule.recurrence)
+		assert.Equal(t, &model.ReminderRecurrence{
+			Frequency: model.ReminderFrequencyWeekly,
+			Weekdays:  []time.Weekday{time.Monday, time.Friday},
+			Hour:      10,
+			Minute:    15,
+			TimeZone:  "America/New_York",
+		}, schedule.recurrence)
+
+		schedule, ok = parseReminderWhen("tomorrow", now)
+		require.True(t, ok)
+		assert.Nil(t, schedule.recurrence)
+	})
+}
+
+func TestParseReminderText(t *testing.T) {
+	now := time.Date(2024, time.March, 6, 10, 30, 0, 0, time.UTC)
+
+	for text, expected := range map[string]struct {
+		message string
+		at      time.Time
+	}{
+		"to call Bob tomorrow at 5pm":          {"call Bob", time.Date(2024, time.March, 7, 17, 0, 0, 0, time.UTC)},
+		"to check in on the build at 3pm":      {"check in on the build", time.Date(2024, time.March, 6, 15, 0, 0, 0, time.UTC)},
+		"submit the report in 2 days":          {"submit the report", time.Date(2024, time.March, 8, 10, 30, 0, 0, time.UTC)},
+		"tomorrow at 9am to water the plants":  {"water the plants", time.Date(2024, time.March, 7, 9, 0, 0, 0, time.UTC)},
+		`"Stand-up in 5 minutes" at 9:55`:      {"Stand-up in 5 minutes", time.Date(2024, time.March, 7, 9, 55, 0, 0, time.UTC)},
+		"about the release every weekday at 9": {"the release", time.Date(2024, time.March, 7, 9, 0, 0, 0, time.UTC)},
+	} {
+		t.Run(text, func(t *testing.T) {
+			message, schedule, ok := parseReminderText(text, now)
+			require.True(t, ok)
+			assert.Equal(t, expected.message, message)
+			assert.True(t, expected.at.Equal(schedule.at), "expected %s, got %s", expected.at, schedule.at)
+		})
+	}
+
+	for _, text := range []string{
+		"",
+		"tomorrow",
+		"to call Bob",
+		`"call Bob"`,
+		`"call Bob" whenever`,
+	} {
+		t.Run("invalid "+text, func(t *testing.T) {
+			_, _, ok := parseReminderText(text, now)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestParseReminderDuration(t *testing.T) {
+	for input, expected := range map[string]struct {
+		days     int
+		duration time.Duration
+	}{
+		"10m":               {0, 10 * time.Minute},
+		"10 minutes":        {0, 10 * time.Minute},
+		"1 hour":            {0, time.Hour},
+		"an hour":           {0, time.Hour},
+		"2d":                {2, 0},
+		"1 day and 2 hours": {1, 2 * time.Hour},
+		"2 weeks":           {14, 0},
+	} {
+		t.Run(input, func(t *testing.T) {
+			days, duration, ok := parseReminderDuration(splitQuotedArgs(input))
+			require.True(t, ok)
+			assert.Equal(t, expected.days, days)
+			assert.Equal(t, expected.duration, duration)
+		})
+	}
+
+	for _, input := range []string{"", "soon", "10", "and 10 minutes", "0m", "10 lightyears"} {
+		t.Run("invalid "+input, func(t *testing.T) {
+			_, _, ok := parseReminderDuration(splitQuotedArgs(input))
+			assert.False(t, ok)
+		})
+	}
+}
@@ -38,3 +38,25 @@ func TestParseNamedArgs(t *testing.T) {
 		assert.Equal(t, tt.m, m, tt.name)
 	}
 }
+
+func TestSplitQuotedArgs(t *testing.T) {
+	data := []struct {
+		name string
+		s    string
+		args []string
+	}{
+		{"empty", "", nil},
+		{"spaces only", "   ", nil},
+		{"unquoted", "one two  three", []string{"one", "two", "three"}},
+		{"quoted", `"one two" three`, []string{"one two", "three"}},
+		{"smart quotes", "\u201cone two\u201d three", []string{"one two", "three"}},
+		{"empty quotes", `"" one`, []string{"", "one"}},
+		{"embedded apostrophe", `"Don't stop" now`, []string{"Don't stop", "now"}},
+		{"unterminated quote", `one "two three`, []string{"one", "two three"}},
+		{"flags", `"Lunch?" "Yes" "No" --multi --close 2h`, []string{"Lunch?", "Yes", "No", "--multi", "--close", "2h"}},
+	}
+
+	for _, tt := range data {
+		assert.Equal(t, tt.args, splitQuotedArgs(tt.s), tt.name)
+	}
+}
@@ -812,7 +812,7 @@ func (a *App) JoinUserToTeam(c request.CTX, team *model.Team, user *model.User,
 	message := model.NewWebSocketEvent(model.WebsocketEventAddedToTeam, "", "", user.Id, nil, "")
 	message.Add("team_id", team.Id)
 	message.Add("user_id", user.Id)
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	return teamMember, nil
 }
@@ -1037,7 +1037,7 @@ func (a *App) AddTeamMember(c request.CTX, teamID, userID string) (*model.TeamMe
 	message := model.NewWebSocketEvent(model.WebsocketEventAddedToTeam, "", "", userID, nil, "")
 	message.Add("team_id", teamID)
 	message.Add("user_id", userID)
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	return teamMember, nil
 }
@@ -1066,7 +1066,7 @@ func (a *App) AddTeamMembers(c request.CTX, teamID string, userIDs []string, use
 		message := model.NewWebSocketEvent(model.WebsocketEventAddedToTeam, "", "", userID, nil, "")
 		message.Add("team_id", teamID)
 		message.Add("user_id", userID)
-		a.Publish(message)
+		a.PublishWithContext(c, message)
 	}
 
 	return membersWithErrors, nil
@@ -1732,6 +1732,21 @@ func (a *App) PermanentDeleteTeamId(c request.CTX, teamID string) *model.AppErro
 }
 
 func (a *App) PermanentDeleteTeam(c request.CTX, team *model.Team) *model.AppError {
+	channels, err := a.Srv().Store().Channel().GetTeamChannels(team.Id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			return model.NewAppError("PermanentDeleteTeam", "app.channel.get_channels.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	// Channels preserved by a legal hold would outlive their team.
+	for _, ch := range channels {
+		if appErr := a.checkChannelNotHeld("PermanentDeleteTeam", ch.Id); appErr != nil {
+			return appErr
+		}
+	}
+
 	team.DeleteAt = model.GetMillis()
 	if _, err := a.Srv().Store().Team().Update(team); err != nil {
 		var invErr *store.ErrInvalidInput
@@ -1746,15 +1761,8 @@ func (a *App) PermanentDeleteTeam(c request.CTX, team *model.Team) *model.AppErr
 		}
 	}
 
-	if channels, err := a.Srv().Store().Channel().GetTeamChannels(team.Id); err != nil {
-		var nfErr *store.ErrNotFound
-		if !errors.As(err, &nfErr) {
-			return model.NewAppError("PermanentDeleteTeam", "app.channel.get_channels.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
-		}
-	} else {
-		for _, ch := range channels {
-			a.PermanentDeleteChannel(c, ch)
-		}
+	for _, ch := range channels {
+		a.PermanentDeleteChannel(c, ch)
 	}
 
 	if err := a.Srv().Store().Team().RemoveAllMembersByTeam(team.Id); err != nil {
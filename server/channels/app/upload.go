@@ -306,6 +306,7 @@ func (a *App) UploadData(c request.CTX, us *model.UploadSession, rd io.Reader) (
 		}
 	}
 
+	a.markFileForScan(info)
 	var storeErr error
 	if info, storeErr = a.Srv().Store().FileInfo().Save(c, info); storeErr != nil {
 		var appErr *model.AppError
@@ -316,6 +317,7 @@ func (a *App) UploadData(c request.CTX, us *model.UploadSession, rd io.Reader) (
 			return nil, model.NewAppError("uploadData", "app.upload.upload_data.save.app_error", nil, "", http.StatusInternalServerError).Wrap(storeErr)
 		}
 	}
+	a.scanFileInBackground(c, info)
 
 	if *a.Config().FileSettings.ExtractContent {
 		infoCopy := *info
@@ -12,6 +12,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -309,7 +310,7 @@ func (a *App) createUserOrGuest(c request.CTX, user *model.User, guest bool) (*m
 	// This message goes to everyone, so the teamID, channelID and userID are irrelevant
 	message := model.NewWebSocketEvent(model.WebsocketEventNewUser, "", "", "", nil, "")
 	message.Add("user_id", ruser.Id)
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	pluginContext := pluginContext(c)
 	a.Srv().Go(func() {
@@ -737,6 +738,10 @@ func (a *App) GenerateMfaSecret(userID string) (*model.MfaSecret, *model.AppErro
 		return nil, model.NewAppError("GenerateMfaSecret", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
+	if !slices.Contains(a.Config().ServiceSettings.MultifactorAuthenticationMethods, model.MfaMethodTotp) {
+		return nil, model.NewAppError("GenerateMfaSecret", "mfa.method_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
 	mfaSecret, err := a.ch.srv.userService.GenerateMfaSecret(user)
 	if err != nil {
 		return nil, model.NewAppError("GenerateMfaSecret", "mfa.generate_qr_code.create_code.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -759,6 +764,10 @@ func (a *App) ActivateMfa(userID, token string) *model.AppError {
 		return model.NewAppError("ActivateMfa", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
+	if !slices.Contains(a.Config().ServiceSettings.MultifactorAuthenticationMethods, model.MfaMethodTotp) {
+		return model.NewAppError("ActivateMfa", "mfa.method_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
 	if err := a.ch.srv.userService.ActivateMfa(user, token); err != nil {
 		switch {
 		case errors.Is(err, mfa.InvalidToken):
@@ -784,6 +793,14 @@ func (a *App) DeactivateMfa(userID string) *model.AppError {
 		return model.NewAppError("DeactivateMfa", "mfa.deactivate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	// Deactivating MFA resets all the second factors of the user.
+	if err := a.Srv().Store().WebAuthnCredential().DeleteForUser(userID); err != nil {
+		return model.NewAppError("DeactivateMfa", "mfa.deactivate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if err := a.Srv().Store().MfaRecoveryCode().DeleteForUser(userID); err != nil {
+		return model.NewAppError("DeactivateMfa", "mfa.deactivate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
 	// Make sure old MFA status is not cached locally or in cluster nodes.
 	a.InvalidateCacheForUser(userID)
 
@@ -854,7 +871,7 @@ func (a *App) SetDefaultProfileImage(c request.CTX, user *model.User) *model.App
 
 	message := model.NewWebSocketEvent(model.WebsocketEventUserUpdated, "", "", "", nil, "")
 	message.Add("user", updatedUser)
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	return nil
 }
@@ -1091,7 +1108,7 @@ func (a *App) DeactivateGuests(c request.CTX) *model.AppError {
 	a.Srv().Store().User().ClearCaches()
 
 	message := model.NewWebSocketEvent(model.WebsocketEventGuestsDeactivated, "", "", "", nil, "")
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 
 	return nil
 }
@@ -1753,7 +1770,7 @@ func (a *App) UpdateUserRolesWithUser(c request.CTX, user *model.User, newRoles
 		message := model.NewWebSocketEvent(model.WebsocketEventUserRoleUpdated, "", "", user.Id, nil, "")
 		message.Add("user_id", user.Id)
 		message.Add("roles", newRoles)
-		a.Publish(message)
+		a.PublishWithContext(c, message)
 	}
 
 	return ruser, nil
@@ -1765,6 +1782,10 @@ func (a *App) PermanentDeleteUser(rctx request.CTX, user *model.User) *model.App
 		rctx.Logger().Warn("You are deleting a user that is a system administrator.  You may need to set another account as the system administrator using the command line tools.", mlog.String("user_email", user.Email))
 	}
 
+	if appErr := a.checkUserNotHeld("PermanentDeleteUser", user.Id); appErr != nil {
+		return appErr
+	}
+
 	if _, err := a.UpdateActive(rctx, user, false); err != nil {
 		return err
 	}
@@ -1777,6 +1798,18 @@ func (a *App) PermanentDeleteUser(rctx request.CTX, user *model.User) *model.App
 		return model.NewAppError("PermanentDeleteUser", "app.user_access_token.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if err := a.Srv().Store().WebAuthnCredential().DeleteForUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.webauthn.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().MfaRecoveryCode().DeleteForUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.mfa_recovery_code.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().WebPushSubscription().DeleteForUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.web_push.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
 	if err := a.Srv().Store().OAuth().PermanentDeleteAuthDataByUser(user.Id); err != nil {
 		return model.NewAppError("PermanentDeleteUser", "app.oauth.permanent_delete_auth_data_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
@@ -1813,6 +1846,22 @@ func (a *App) PermanentDeleteUser(rctx request.CTX, user *model.User) *model.App
 		return model.NewAppError("PermanentDeleteUser", "app.reaction.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if err := a.Srv().Store().ScheduledPost().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.scheduled_post.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().Poll().PermanentDeleteVotesByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.poll.permanent_delete_votes_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().Reminder().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.reminder.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().SavedSearch().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.saved_search.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
 	if err := a.Srv().Store().Bot().PermanentDelete(user.Id); err != nil {
 		var invErr *store.ErrInvalidInput
 		switch {
@@ -2434,7 +2483,7 @@ func (a *App) PromoteGuestToUser(c request.CTX, user *model.User, requestorId st
 				return model.NewAppError("PromoteGuestToUser", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
 			}
 			evt.Add("channelMember", string(memberJSON))
-			a.Publish(evt)
+			a.PublishWithContext(c, evt)
 		}
 	}
 
@@ -2479,7 +2528,7 @@ func (a *App) DemoteUserToGuest(c request.CTX, user *model.User) *model.AppError
 				return model.NewAppError("DemoteUserToGuest", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
 			}
 			evt.Add("channelMember", string(memberJSON))
-			a.Publish(evt)
+			a.PublishWithContext(c, evt)
 		}
 	}
 
@@ -2514,7 +2563,7 @@ func (a *App) invalidateUserCacheAndPublish(rctx request.CTX, userID string) {
 
 	message := model.NewWebSocketEvent(model.WebsocketEventUserUpdated, "", "", "", nil, "")
 	message.Add("user", user)
-	a.Publish(message)
+	a.PublishWithContext(rctx, message)
 }
 
 // GetKnownUsers returns the list of user ids of users with any direct
@@ -2783,7 +2832,7 @@ func (a *App) UpdateThreadFollowForUserFromChannelAdd(c request.CTX, userID, tea
 	message.Add("previous_unread_replies", int64(0))
 	message.Add("previous_unread_mentions", int64(0))
 
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 	return nil
 }
 
@@ -2855,7 +2904,7 @@ func (a *App) UpdateThreadReadForUser(c request.CTX, currentSessionId, userID, t
 	message.Add("previous_unread_mentions", previousUnreadMentions)
 	message.Add("previous_unread_replies", previousUnreadReplies)
 	message.Add("channel_id", post.ChannelId)
-	a.Publish(message)
+	a.PublishWithContext(c, message)
 	return thread, nil
 }
 
@@ -5,6 +5,7 @@ package app
 
 import (
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/app/platform"
 )
 
@@ -30,6 +31,12 @@ func (a *App) Publish(message *model.WebSocketEvent) {
 	a.Srv().Platform().Publish(message)
 }
 
+// PublishWithContext publishes the event like Publish, propagating the trace
+// context of the request to the other cluster nodes.
+func (a *App) PublishWithContext(rctx request.CTX, message *model.WebSocketEvent) {
+	a.Srv().Platform().PublishWithContext(rctx.Context(), message)
+}
+
 func (ch *Channels) Publish(message *model.WebSocketEvent) {
 	ch.srv.Platform().Publish(message)
 }
@@ -0,0 +1,319 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/webpush"
+)
+
+// webPushTTL is how long push services keep the notifications of browsers
+// which are offline.
+const webPushTTL = 24 * time.Hour
+
+// ensureWebPushVAPIDKey ensures that the key identifying the server to the
+// push services of browsers exists. The key is shared by the servers of a
+// cluster since browsers subscribe with it.
+func (ch *Channels) ensureWebPushVAPIDKey() error {
+	if ch.webPushVAPIDKey != nil {
+		return nil
+	}
+
+	var key *model.SystemAsymmetricSigningKey
+
+	value, err := ch.srv.Store().System().GetByName(model.SystemWebPushVAPIDKeyKey)
+	if err == nil {
+		if err := json.Unmarshal([]byte(value.Value), &key); err != nil {
+			return err
+		}
+	}
+
+	// If we don't already have a key, try to generate one.
+	if key == nil {
+		newECDSAKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return err
+		}
+		newKey := &model.SystemAsymmetricSigningKey{
+			ECDSAKey: &model.SystemECDSAKey{
+				Curve: "P-256",
+				X:     newECDSAKey.X,
+				Y:     newECDSAKey.Y,
+				D:     newECDSAKey.D,
+			},
+		}
+		system := &model.System{
+			Name: model.SystemWebPushVAPIDKeyKey,
+		}
+		v, err := json.Marshal(newKey)
+		if err != nil {
+			return err
+		}
+		system.Value = string(v)
+		// If we were able to save the key, use it, otherwise log the error.
+		if err = ch.srv.Store().System().Save(system); err != nil {
+			mlog.Warn("Failed to save WebPushVAPIDKey", mlog.Err(err))
+		} else {
+			key = newKey
+		}
+	}
+
+	// If we weren't able to save a new key above, another server must have beat us to it. Get the
+	// key from the database, and if that fails, error out.
+	if key == nil {
+		value, err := ch.srv.Store().System().GetByName(model.SystemWebPushVAPIDKeyKey)
+		if err != nil {
+			return err
+		}
+
+		if err := json.Unmarshal([]byte(value.Value), &key); err != nil {
+			return err
+		}
+	}
+
+	if key.ECDSAKey == nil || key.ECDSAKey.Curve != "P-256" {
+		return errors.New("invalid WebPushVAPIDKey")
+	}
+	ch.webPushVAPIDKey = &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     key.ECDSAKey.X,
+			Y:     key.ECDSAKey.Y,
+		},
+		D: key.ECDSAKey.D,
+	}
+	return nil
+}
+
+func (a *App) canSendWebPushNotifications() bool {
+	return *a.Config().EmailSettings.EnableWebPushNotifications && a.ch.webPushVAPIDKey != nil
+}
+
+// GetWebPushPublicKey returns the VAPID public key browsers must subscribe
+// with.
+func (a *App) GetWebPushPublicKey() (*model.WebPushPublicKey, *model.AppError) {
+	if !a.canSendWebPushNotifications() {
+		return nil, model.NewAppError("GetWebPushPublicKey", "app.web_push.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	publicKey, err := webpush.EncodePublicKey(&a.ch.webPushVAPIDKey.PublicKey)
+	if err != nil {
+		return nil, model.NewAppError("GetWebPushPublicKey", "app.web_push.public_key.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return &model.WebPushPublicKey{PublicKey: publicKey}, nil
+}
+
+// SaveWebPushSubscription sets the Web Push subscription of the browser of
+// the session, replacing any previous one.
+func (a *App) SaveWebPushSubscription(c request.CTX, session *model.Session, subscriptionRequest *model.WebPushSubscriptionRequest) (*model.WebPushSubscription, *model.AppError) {
+	if !a.canSendWebPushNotifications() {
+		return nil, model.NewAppError("SaveWebPushSubscription", "app.web_push.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	subscription, err := a.Srv().Store().WebPushSubscription().Save(&model.WebPushSubscription{
+		UserId:    session.UserId,
+		SessionId: session.Id,
+		Endpoint:  subscriptionRequest.Endpoint,
+		P256dh:    subscriptionRequest.Keys.P256dh,
+		Auth:      subscriptionRequest.Keys.Auth,
+	})
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("SaveWebPushSubscription", "app.web_push.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return subscription, nil
+}
+
+// DeleteWebPushSubscription stops sending push notifications to the browser
+// of the session.
+func (a *App) DeleteWebPushSubscription(c request.CTX, sessionID string) *model.AppError {
+	if err := a.Srv().Store().WebPushSubscription().DeleteForSession(sessionID); err != nil {
+		return model.NewAppError("DeleteWebPushSubscription", "app.web_push.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// webPushSubject is the contact push services may use to reach the
+// administrator of the server.
+func (a *App) webPushSubject() string {
+	if feedbackEmail := a.Config().EmailSettings.FeedbackEmail; feedbackEmail != nil && *feedbackEmail != "" {
+		return "mailto:" + *feedbackEmail
+	}
+	if siteURL := a.GetSiteURL(); strings.HasPrefix(siteURL, "https://") {
+		return siteURL
+	}
+	return ""
+}
+
+// webPushPayload encodes the notification for the service worker, shortening
+// its message to fit in a push message.
+func webPushPayload(msg *model.PushNotification) ([]byte, error) {
+	payload, err := json.Marshal(msg)
+	if err != nil || len(payload) <= webpush.MaxPayloadSize {
+		return payload, err
+	}
+
+	// JSON escaping makes the length of the encoded message hard to predict,
+	// so look for the longest prefix of the message which fits.
+	message := msg.Message
+	low, high := 0, len(message)
+	for low < high {
+		mid := (low + high + 1) / 2
+		msg.Message = truncateWebPushMessage(message, mid)
+		if payload, err = json.Marshal(msg); err != nil {
+			return nil, err
+		}
+		if len(payload) <= webpush.MaxPayloadSize {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+
+	msg.Message = truncateWebPushMessage(message, low)
+	if payload, err = json.Marshal(msg); err != nil {
+		return nil, err
+	}
+	if len(payload) > webpush.MaxPayloadSize {
+		return nil, webpush.ErrPayloadTooLarge
+	}
+
+	return payload, nil
+}
+
+func truncateWebPushMessage(message string, length int) string {
+	if length >= len(message) {
+		return message
+	}
+	for length > 0 && !utf8.RuneStart(message[length]) {
+		length--
+	}
+	if length == 0 {
+		return ""
+	}
+	return message[:length] + "…"
+}
+
+// sendWebPushNotifications sends a push notification to the browsers the user
+// subscribed with. Only messages are sent, since browsers require every push
+// message to show a notification.
+func (a *App) sendWebPushNotifications(rctx request.CTX, msg *model.PushNotification, userID string, skipSessionId string) {
+	if msg.Type != model.PushTypeMessage {
+		return
+	}
+
+	subscriptions, err := a.Srv().Store().WebPushSubscription().GetForUser(userID)
+	if err != nil {
+		a.CountNotificationReason(model.NotificationStatusError, model.NotificationTypePush, model.NotificationReasonFetchError, model.PushNotifyWeb)
+		a.NotificationsLog().Error("Failed to get web push subscriptions",
+			mlog.String("type", model.NotificationTypePush),
+			mlog.String("status", model.NotificationStatusError),
+			mlog.String("reason", model.NotificationReasonFetchError),
+			mlog.String("user_id", userID),
+			mlog.Err(err),
+		)
+		return
+	}
+
+	sender := &webpush.Sender{
+		Client:  a.Srv().webPushClient,
+		Key:     a.ch.webPushVAPIDKey,
+		Subject: a.webPushSubject(),
+	}
+
+	for _, subscription := range subscriptions {
+		if skipSessionId != "" && skipSessionId == subscription.SessionId {
+			continue
+		}
+
+		tmpMessage := msg.DeepCopy()
+		tmpMessage.Platform = model.PushNotifyWeb
+		tmpMessage.AckId = model.NewId()
+		tmpMessage.ServerId = a.TelemetryId()
+
+		err := a.sendWebPushNotification(sender, subscription, tmpMessage)
+		if err != nil {
+			reason := model.NotificationReasonWebPushSendError
+			if errors.Is(err, webpush.ErrSubscriptionGone) {
+				reason = model.NotificationReasonWebPushSubscriptionGone
+				if err := a.Srv().Store().WebPushSubscription().Delete(subscription.Id); err != nil {
+					rctx.Logger().Warn("Failed to delete web push subscription", mlog.String("subscription_id", subscription.Id), mlog.Err(err))
+				}
+			}
+			a.CountNotificationReason(model.NotificationStatusError, model.NotificationTypePush, reason, model.PushNotifyWeb)
+			a.NotificationsLog().Error("Failed to send web push notification",
+				mlog.String("type", model.NotificationTypePush),
+				mlog.String("status", model.NotificationStatusNotSent),
+				mlog.String("reason", reason),
+				mlog.String("ack_id", tmpMessage.AckId),
+				mlog.String("push_type", tmpMessage.Type),
+				mlog.String("user_id", userID),
+				mlog.String("session_id", subscription.SessionId),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		a.NotificationsLog().Trace("Notification sent to web push service",
+			mlog.String("type", model.NotificationTypePush),
+			mlog.String("ack_id", tmpMessage.AckId),
+			mlog.String("push_type", tmpMessage.Type),
+			mlog.String("user_id", userID),
+			mlog.String("session_id", subscription.SessionId),
+			mlog.String("status", model.PushSendSuccess),
+		)
+
+		if a.Metrics() != nil {
+			a.Metrics().IncrementPostSentPush()
+		}
+		a.CountNotification(model.NotificationTypePush, model.PushNotifyWeb)
+	}
+}
+
+func (a *App) sendWebPushNotification(sender *webpush.Sender, subscription *model.WebPushSubscription, msg *model.PushNotification) error {
+	payload, err := webPushPayload(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode the notification: %w", err)
+	}
+
+	p256dh, err := webpush.DecodeKey(subscription.P256dh)
+	if err != nil {
+		return fmt.Errorf("invalid subscription key: %w", err)
+	}
+	auth, err := webpush.DecodeKey(subscription.Auth)
+	if err != nil {
+		return fmt.Errorf("invalid subscription secret: %w", err)
+	}
+
+	return sender.Send(context.Background(), &webpush.Subscription{
+		Endpoint: subscription.Endpoint,
+		P256dh:   p256dh,
+		Auth:     auth,
+	}, payload, webpush.Options{
+		TTL:     webPushTTL,
+		Urgency: webpush.UrgencyHigh,
+	})
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/webpush"
+)
+
+func TestWebPushPayload(t *testing.T) {
+	t.Run("short message", func(t *testing.T) {
+		msg := &model.PushNotification{Type: model.PushTypeMessage, Message: "Hello"}
+		payload, err := webPushPayload(msg)
+		require.NoError(t, err)
+
+		var decoded model.PushNotification
+		require.NoError(t, json.Unmarshal(payload, &decoded))
+		assert.Equal(t, "Hello", decoded.Message)
+	})
+
+	t.Run("long message is shortened", func(t *testing.T) {
+		msg := &model.PushNotification{Type: model.PushTypeMessage, Message: strings.Repeat("é<", 3000)}
+		payload, err := webPushPayload(msg)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(payload), webpush.MaxPayloadSize)
+
+		var decoded model.PushNotification
+		require.NoError(t, json.Unmarshal(payload, &decoded))
+		assert.True(t, strings.HasSuffix(decoded.Message, "…"))
+		assert.True(t, utf8.ValidString(decoded.Message))
+	})
+}
+
+func TestWebPushSubscription(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	browserKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	var mutex sync.Mutex
+	var received []*http.Request
+	status := http.StatusCreated
+	pushService := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		received = append(received, r)
+		w.WriteHeader(status)
+	}))
+	defer pushService.Close()
+	th.App.Srv().webPushClient = pushService.Client()
+
+	session, appErr := th.App.CreateSession(th.Context, &model.Session{UserId: th.BasicUser.Id})
+	require.Nil(t, appErr)
+
+	req := &model.WebPushSubscriptionRequest{Endpoint: pushService.URL + "/push/abc"}
+	req.Keys.P256dh = base64.RawURLEncoding.EncodeToString(browserKey.PublicKey().Bytes())
+	req.Keys.Auth = base64.RawURLEncoding.EncodeToString(make([]byte, 16))
+
+	t.Run("disabled", func(t *testing.T) {
+		_, appErr := th.App.SaveWebPushSubscription(th.Context, session, req)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotImplemented, appErr.StatusCode)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.EnableWebPushNotifications = true
+	})
+
+	publicKey, appErr := th.App.GetWebPushPublicKey()
+	require.Nil(t, appErr)
+	assert.NotEmpty(t, publicKey.PublicKey)
+
+	subscription, appErr := th.App.SaveWebPushSubscription(th.Context, session, req)
+	require.Nil(t, appErr)
+	assert.Equal(t, session.Id, subscription.SessionId)
+
+	msg := &model.PushNotification{Type: model.PushTypeMessage, Message: "Hello", ChannelId: th.BasicChannel.Id}
+
+	t.Run("sends messages only", func(t *testing.T) {
+		th.App.sendWebPushNotifications(th.Context, &model.PushNotification{Type: model.PushTypeClear}, th.BasicUser.Id, "")
+		th.App.sendWebPushNotifications(th.Context, msg, th.BasicUser.Id, "")
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		require.Len(t, received, 1)
+		assert.Equal(t, "aes128gcm", received[0].Header.Get("Content-Encoding"))
+		assert.True(t, strings.HasPrefix(received[0].Header.Get("Authorization"), "vapid t="))
+		assert.Contains(t, received[0].Header.Get("Authorization"), "k="+publicKey.PublicKey)
+	})
+
+	t.Run("skips the session", func(t *testing.T) {
+		th.App.sendWebPushNotifications(th.Context, msg, th.BasicUser.Id, session.Id)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		require.Len(t, received, 1)
+	})
+
+	t.Run("deletes subscriptions which are gone", func(t *testing.T) {
+		mutex.Lock()
+		status = http.StatusGone
+		mutex.Unlock()
+
+		th.App.sendWebPushNotifications(th.Context, msg, th.BasicUser.Id, "")
+
+		subscriptions, err := th.App.Srv().Store().WebPushSubscription().GetForUser(th.BasicUser.Id)
+		require.NoError(t, err)
+		assert.Empty(t, subscriptions)
+	})
+
+	t.Run("revoking the session deletes its subscription", func(t *testing.T) {
+		_, appErr := th.App.SaveWebPushSubscription(th.Context, session, req)
+		require.Nil(t, appErr)
+
+		require.Nil(t, th.App.RevokeSession(th.Context, session))
+
+		subscriptions, err := th.App.Srv().Store().WebPushSubscription().GetForUser(th.BasicUser.Id)
+		require.NoError(t, err)
+		assert.Empty(t, subscriptions)
+	})
+}
@@ -0,0 +1,602 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mfa"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/webauthn"
+)
+
+const (
+	TokenTypeWebAuthnChallenge = "webauthn_challenge"
+
+	webAuthnCeremonyRegistration = "registration"
+	webAuthnCeremonyLogin        = "login"
+	webAuthnCredentialType       = "public-key"
+)
+
+// webAuthnChallenge is stored in the Extra of the token holding a challenge
+// until the ceremony completes.
+type webAuthnChallenge struct {
+	// UserId is empty for passwordless logins, where the user is only known
+	// once the credential is verified.
+	UserId       string `json:"user_id"`
+	Ceremony     string `json:"ceremony"`
+	Passwordless bool   `json:"passwordless"`
+}
+
+// webAuthnRelyingParty scopes the credentials to the host of the site URL.
+func (a *App) webAuthnRelyingParty() (*webauthn.RelyingParty, *model.AppError) {
+	siteURL, err := url.Parse(a.GetSiteURL())
+	if err != nil || siteURL.Hostname() == "" {
+		return nil, model.NewAppError("webAuthnRelyingParty", "app.webauthn.site_url.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	return &webauthn.RelyingParty{
+		ID:     siteURL.Hostname(),
+		Origin: siteURL.Scheme + "://" + siteURL.Host,
+	}, nil
+}
+
+func (a *App) isWebAuthnMfaAllowed() bool {
+	return *a.Config().ServiceSettings.EnableMultifactorAuthentication && a.isMfaMethodAllowed(model.MfaMethodWebAuthn)
+}
+
+// isMfaMethodAllowed returns true if the policy lets users authenticate with
+// the given second factor.
+func (a *App) isMfaMethodAllowed(method string) bool {
+	return slices.Contains(a.Config().ServiceSettings.MultifactorAuthenticationMethods, method)
+}
+
+// userHasAllowedMfaMethod returns true if the user has set up a second factor
+// the policy still allows.
+func (a *App) userHasAllowedMfaMethod(user *model.User) (bool, *model.AppError) {
+	for _, method := range a.Config().ServiceSettings.MultifactorAuthenticationMethods {
+		hasMethod, appErr := a.UserHasMfaMethod(user, method)
+		if appErr != nil {
+			return false, appErr
+		}
+		if hasMethod {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkWebAuthnRegistrationAllowed checks the policy allows the user to
+// register a credential. Like TOTP, second factors are limited to email and
+// LDAP users, while passwordless credentials replace the password of email
+// users.
+func (a *App) checkWebAuthnRegistrationAllowed(user *model.User, passwordless bool) *model.AppError {
+	if passwordless {
+		if !*a.Config().ServiceSettings.EnablePasswordlessLogin {
+			return model.NewAppError("checkWebAuthnRegistrationAllowed", "app.webauthn.passwordless_disabled.app_error", nil, "", http.StatusNotImplemented)
+		}
+		if user.AuthService != "" {
+			return model.NewAppError("checkWebAuthnRegistrationAllowed", "app.webauthn.passwordless_email_only.app_error", nil, "", http.StatusBadRequest)
+		}
+		return nil
+	}
+
+	if !a.isWebAuthnMfaAllowed() {
+		return model.NewAppError("checkWebAuthnRegistrationAllowed", "app.webauthn.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+	if user.AuthService != "" && user.AuthService != model.UserAuthServiceLdap {
+		return model.NewAppError("checkWebAuthnRegistrationAllowed", "api.user.activate_mfa.email_and_ldap_only.app_error", nil, "", http.StatusBadRequest)
+	}
+	return nil
+}
+
+// newWebAuthnChallenge stores a random challenge for a ceremony, and returns
+// it base64url encoded.
+func (a *App) newWebAuthnChallenge(challenge *webAuthnChallenge) (string, *model.AppError) {
+	extra, err := json.Marshal(challenge)
+	if err != nil {
+		return "", model.NewAppError("newWebAuthnChallenge", "app.webauthn.challenge.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	token := model.NewToken(TokenTypeWebAuthnChallenge, string(extra))
+	if err := a.Srv().Store().Token().Save(token); err != nil {
+		return "", model.NewAppError("newWebAuthnChallenge", "app.webauthn.challenge.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return webauthn.EncodeBase64URL([]byte(token.Token)), nil
+}
+
+// consumeWebAuthnChallenge looks up the challenge signed by the client and
+// deletes it, so that it can only be used once.
+func (a *App) consumeWebAuthnChallenge(clientDataJSON []byte, ceremony string) ([]byte, *webAuthnChallenge, *model.AppError) {
+	invalidErr := model.NewAppError("consumeWebAuthnChallenge", "app.webauthn.invalid_challenge.app_error", nil, "", http.StatusBadRequest)
+
+	clientData, err := webauthn.ParseClientData(clientDataJSON)
+	if err != nil {
+		return nil, nil, invalidErr.Wrap(err)
+	}
+	challenge, err := webauthn.DecodeBase64URL(clientData.Challenge)
+	if err != nil || len(challenge) != model.TokenSize {
+		return nil, nil, invalidErr
+	}
+
+	token, err := a.Srv().Store().Token().GetByToken(string(challenge))
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, nil, invalidErr
+		}
+		return nil, nil, model.NewAppError("consumeWebAuthnChallenge", "app.webauthn.challenge.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if token.Type != TokenTypeWebAuthnChallenge {
+		return nil, nil, invalidErr
+	}
+
+	if err := a.Srv().Store().Token().Delete(token.Token); err != nil {
+		return nil, nil, model.NewAppError("consumeWebAuthnChallenge", "app.webauthn.challenge.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if model.GetMillis()-token.CreateAt > model.WebAuthnCeremonyTimeout {
+		return nil, nil, model.NewAppError("consumeWebAuthnChallenge", "app.webauthn.expired_challenge.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	var data webAuthnChallenge
+	if err := json.Unmarshal([]byte(token.Extra), &data); err != nil || data.Ceremony != ceremony {
+		return nil, nil, invalidErr
+	}
+
+	return challenge, &data, nil
+}
+
+func webAuthnCredentialDescriptors(credentials []*model.WebAuthnCredential) []model.WebAuthnCredentialDescriptor {
+	descriptors := make([]model.WebAuthnCredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		descriptors = append(descriptors, model.WebAuthnCredentialDescriptor{Type: webAuthnCredentialType, Id: credential.CredentialId})
+	}
+	return descriptors
+}
+
+// BeginWebAuthnRegistration returns the options the client passes to
+// navigator.credentials.create to register a credential for the user.
+func (a *App) BeginWebAuthnRegistration(c request.CTX, userID string, passwordless bool) (*model.WebAuthnCreationOptions, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr = a.checkWebAuthnRegistrationAllowed(user, passwordless); appErr != nil {
+		return nil, appErr
+	}
+
+	rp, appErr := a.webAuthnRelyingParty()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	credentials, appErr := a.GetWebAuthnCredentials(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	challenge, appErr := a.newWebAuthnChallenge(&webAuthnChallenge{
+		UserId:       userID,
+		Ceremony:     webAuthnCeremonyRegistration,
+		Passwordless: passwordless,
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	selection := model.WebAuthnAuthenticatorSelection{
+		ResidentKey:      "discouraged",
+		UserVerification: model.WebAuthnUserVerificationPreferred,
+	}
+	if passwordless {
+		selection = model.WebAuthnAuthenticatorSelection{
+			ResidentKey:      "required",
+			UserVerification: model.WebAuthnUserVerificationRequired,
+		}
+	}
+
+	params := make([]model.WebAuthnCredentialParameter, 0, len(webauthn.SupportedAlgorithms))
+	for _, algorithm := range webauthn.SupportedAlgorithms {
+		params = append(params, model.WebAuthnCredentialParameter{Type: webAuthnCredentialType, Alg: algorithm})
+	}
+
+	return &model.WebAuthnCreationOptions{
+		PublicKey: model.WebAuthnPublicKeyCreationOptions{
+			Challenge: challenge,
+			Rp: model.WebAuthnRelyingPartyEntity{
+				Id:   rp.ID,
+				Name: *a.Config().TeamSettings.SiteName,
+			},
+			User: model.WebAuthnUserEntity{
+				Id:          webauthn.EncodeBase64URL([]byte(user.Id)),
+				Name:        user.Username,
+				DisplayName: user.GetDisplayName(model.ShowFullName),
+			},
+			PubKeyCredParams:       params,
+			Timeout:                model.WebAuthnCeremonyTimeout,
+			ExcludeCredentials:     webAuthnCredentialDescriptors(credentials),
+			AuthenticatorSelection: selection,
+			Attestation:            "none",
+		},
+	}, nil
+}
+
+// FinishWebAuthnRegistration verifies the credential created by the
+// authenticator and saves it. The first second factor credential of a user
+// activates MFA, while passwordless credentials leave it unchanged.
+func (a *App) FinishWebAuthnRegistration(c request.CTX, userID string, registration *model.WebAuthnRegistration) (*model.WebAuthnCredential, *model.AppError) {
+	invalidErr := model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.invalid_credential.app_error", nil, "", http.StatusBadRequest)
+	attestation := registration.Credential
+	if attestation == nil || attestation.Type != webAuthnCredentialType {
+		return nil, invalidErr
+	}
+	clientDataJSON, err := webauthn.DecodeBase64URL(attestation.Response.ClientDataJSON)
+	if err != nil {
+		return nil, invalidErr.Wrap(err)
+	}
+	attestationObject, err := webauthn.DecodeBase64URL(attestation.Response.AttestationObject)
+	if err != nil {
+		return nil, invalidErr.Wrap(err)
+	}
+
+	challenge, data, appErr := a.consumeWebAuthnChallenge(clientDataJSON, webAuthnCeremonyRegistration)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if data.UserId != userID {
+		return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.invalid_challenge.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr = a.checkWebAuthnRegistrationAllowed(user, data.Passwordless); appErr != nil {
+		return nil, appErr
+	}
+
+	rp, appErr := a.webAuthnRelyingParty()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	verified, err := rp.VerifyRegistration(challenge, clientDataJSON, attestationObject, data.Passwordless)
+	if err != nil {
+		return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.verify.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	credential, err := a.Srv().Store().WebAuthnCredential().Save(&model.WebAuthnCredential{
+		UserId:       userID,
+		Name:         strings.TrimSpace(registration.Name),
+		CredentialId: webauthn.EncodeBase64URL(verified.ID),
+		PublicKey:    verified.PublicKey,
+		SignCount:    int64(verified.SignCount),
+		Passwordless: data.Passwordless,
+	})
+	if err != nil {
+		var appErr *model.AppError
+		var conflictErr *store.ErrConflict
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &conflictErr):
+			return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.credential_exists.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	if !data.Passwordless && !user.MfaActive {
+		if err := a.Srv().Store().User().UpdateMfaActive(userID, true); err != nil {
+			return nil, model.NewAppError("FinishWebAuthnRegistration", "mfa.activate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		// Make sure old MFA status is not cached locally or in cluster nodes.
+		a.InvalidateCacheForUser(userID)
+		a.sendMfaChangeEmail(c, user, true)
+	}
+
+	return credential, nil
+}
+
+func (a *App) GetWebAuthnCredentials(userID string) ([]*model.WebAuthnCredential, *model.AppError) {
+	credentials, err := a.Srv().Store().WebAuthnCredential().GetForUser(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetWebAuthnCredentials", "app.webauthn.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return credentials, nil
+}
+
+// getWebAuthnMfaCredentials returns the credentials the user registered as a
+// second factor, leaving out the passwordless ones.
+func (a *App) getWebAuthnMfaCredentials(userID string) ([]*model.WebAuthnCredential, *model.AppError) {
+	credentials, appErr := a.GetWebAuthnCredentials(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	mfaCredentials := []*model.WebAuthnCredential{}
+	for _, credential := range credentials {
+		if !credential.Passwordless {
+			mfaCredentials = append(mfaCredentials, credential)
+		}
+	}
+	return mfaCredentials, nil
+}
+
+// DeleteWebAuthnCredential deletes a credential of the user. Deleting the
+// last second factor of a user deactivates MFA.
+func (a *App) DeleteWebAuthnCredential(c request.CTX, userID, credentialID string) *model.AppError {
+	credential, err := a.Srv().Store().WebAuthnCredential().Get(credentialID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return model.NewAppError("DeleteWebAuthnCredential", "app.webauthn.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		return model.NewAppError("DeleteWebAuthnCredential", "app.webauthn.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if credential.UserId != userID {
+		return model.NewAppError("DeleteWebAuthnCredential", "app.webauthn.get.not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	if err := a.Srv().Store().WebAuthnCredential().Delete(credentialID); err != nil {
+		return model.NewAppError("DeleteWebAuthnCredential", "app.webauthn.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return appErr
+	}
+	hasWebAuthn, appErr := a.UserHasMfaMethod(user, model.MfaMethodWebAuthn)
+	if appErr != nil {
+		return appErr
+	}
+	if user.MfaActive && user.MfaSecret == "" && !hasWebAuthn {
+		if appErr := a.DeactivateMfa(userID); appErr != nil {
+			return appErr
+		}
+		a.sendMfaChangeEmail(c, user, false)
+	}
+
+	return nil
+}
+
+// UserHasMfaMethod returns true if the user has set up the given second
+// factor.
+func (a *App) UserHasMfaMethod(user *model.User, method string) (bool, *model.AppError) {
+	switch method {
+	case model.MfaMethodTotp:
+		return user.MfaActive && user.MfaSecret != "", nil
+	case model.MfaMethodWebAuthn:
+		credentials, appErr := a.getWebAuthnMfaCredentials(user.Id)
+		if appErr != nil {
+			return false, appErr
+		}
+		return len(credentials) > 0, nil
+	}
+	return false, nil
+}
+
+// BeginWebAuthnLogin returns the options the client passes to
+// navigator.credentials.get to log in. With a login ID, the assertion is a
+// second factor sent as the MFA token of the login request. Without one, it
+// logs the user in without a password through LoginWithWebAuthn.
+func (a *App) BeginWebAuthnLogin(c request.CTX, loginID string) (*model.WebAuthnRequestOptions, *model.AppError) {
+	rp, appErr := a.webAuthnRelyingParty()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	data := &webAuthnChallenge{Ceremony: webAuthnCeremonyLogin}
+	userVerification := model.WebAuthnUserVerificationPreferred
+	allowCredentials := []model.WebAuthnCredentialDescriptor{}
+
+	if loginID == "" {
+		if !*a.Config().ServiceSettings.EnablePasswordlessLogin {
+			return nil, model.NewAppError("BeginWebAuthnLogin", "app.webauthn.passwordless_disabled.app_error", nil, "", http.StatusNotImplemented)
+		}
+		data.Passwordless = true
+		userVerification = model.WebAuthnUserVerificationRequired
+	} else {
+		if !*a.Config().ServiceSettings.EnableMultifactorAuthentication {
+			return nil, model.NewAppError("BeginWebAuthnLogin", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
+		}
+		// Unknown users get options without credentials rather than an error,
+		// so that the endpoint can't be used to find out which users exist.
+		if user, appErr := a.GetUserForLogin(c, "", loginID); appErr == nil {
+			credentials, appErr := a.getWebAuthnMfaCredentials(user.Id)
+			if appErr != nil {
+				return nil, appErr
+			}
+			data.UserId = user.Id
+			allowCredentials = webAuthnCredentialDescriptors(credentials)
+		}
+	}
+
+	challenge, appErr := a.newWebAuthnChallenge(data)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &model.WebAuthnRequestOptions{
+		PublicKey: model.WebAuthnPublicKeyRequestOptions{
+			Challenge:        challenge,
+			Timeout:          model.WebAuthnCeremonyTimeout,
+			RpId:             rp.ID,
+			AllowCredentials: allowCredentials,
+			UserVerification: userVerification,
+		},
+	}, nil
+}
+
+// verifyWebAuthnAssertion verifies the assertion of a login ceremony and
+// returns the credential it was signed with.
+func (a *App) verifyWebAuthnAssertion(assertion *model.WebAuthnAssertion, passwordless bool) (*model.WebAuthnCredential, *model.AppError) {
+	invalidErr := model.NewAppError("verifyWebAuthnAssertion", "app.webauthn.invalid_credential.app_error", nil, "", http.StatusUnauthorized)
+	if assertion == nil || assertion.Type != webAuthnCredentialType {
+		return nil, invalidErr
+	}
+
+	credentialID, err := webauthn.DecodeBase64URL(assertion.Id)
+	if err != nil {
+		return nil, invalidErr.Wrap(err)
+	}
+	clientDataJSON, err := webauthn.DecodeBase64URL(assertion.Response.ClientDataJSON)
+	if err != nil {
+		return nil, invalidErr.Wrap(err)
+	}
+	authenticatorData, err := webauthn.DecodeBase64URL(assertion.Response.AuthenticatorData)
+	if err != nil {
+		return nil, invalidErr.Wrap(err)
+	}
+	signature, err := webauthn.DecodeBase64URL(assertion.Response.Signature)
+	if err != nil {
+		return nil, invalidErr.Wrap(err)
+	}
+
+	challenge, data, appErr := a.consumeWebAuthnChallenge(clientDataJSON, webAuthnCeremonyLogin)
+	if appErr != nil {
+		appErr.StatusCode = http.StatusUnauthorized
+		return nil, appErr
+	}
+
+	credential, err := a.Srv().Store().WebAuthnCredential().GetByCredentialId(webauthn.EncodeBase64URL(credentialID))
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, invalidErr.Wrap(err)
+		}
+		return nil, model.NewAppError("verifyWebAuthnAssertion", "app.webauthn.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if data.Passwordless != passwordless || (passwordless && !credential.Passwordless) || (data.UserId != "" && data.UserId != credential.UserId) {
+		return nil, invalidErr
+	}
+
+	rp, appErr := a.webAuthnRelyingParty()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	verified, err := rp.VerifyAssertion(challenge, credential.PublicKey, uint32(credential.SignCount), clientDataJSON, authenticatorData, signature, passwordless)
+	if err != nil {
+		if errors.Is(err, webauthn.ErrCredentialCloned) {
+			a.Log().Warn("A WebAuthn credential may have been cloned", mlog.String("user_id", credential.UserId), mlog.String("webauthn_credential_id", credential.Id))
+		}
+		return nil, invalidErr.Wrap(err)
+	}
+
+	if err := a.Srv().Store().WebAuthnCredential().UpdateSignCount(credential.Id, int64(verified.SignCount), model.GetMillis()); err != nil {
+		return nil, model.NewAppError("verifyWebAuthnAssertion", "app.webauthn.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return credential, nil
+}
+
+// isWebAuthnMfaToken returns true if the MFA token of a login request is a
+// WebAuthn assertion rather than a TOTP token or a recovery code.
+func isWebAuthnMfaToken(token string) bool {
+	return strings.HasPrefix(strings.TrimSpace(token), "{")
+}
+
+func (a *App) checkWebAuthnMfa(user *model.User, token string) *model.AppError {
+	var assertion model.WebAuthnAssertion
+	if err := json.Unmarshal([]byte(token), &assertion); err != nil {
+		return model.NewAppError("checkWebAuthnMfa", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized).Wrap(err)
+	}
+
+	credential, appErr := a.verifyWebAuthnAssertion(&assertion, false)
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusInternalServerError {
+			return appErr
+		}
+		return model.NewAppError("checkWebAuthnMfa", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized).Wrap(appErr)
+	}
+	if credential.UserId != user.Id || credential.Passwordless {
+		return model.NewAppError("checkWebAuthnMfa", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized)
+	}
+
+	return nil
+}
+
+// AuthenticateUserWithWebAuthn authenticates an email user with a
+// passwordless credential. The user verification performed by the
+// authenticator stands in for both the password and the second factor.
+func (a *App) AuthenticateUserWithWebAuthn(c request.CTX, assertion *model.WebAuthnAssertion) (*model.User, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnablePasswordlessLogin {
+		return nil, model.NewAppError("AuthenticateUserWithWebAuthn", "app.webauthn.passwordless_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	credential, appErr := a.verifyWebAuthnAssertion(assertion, true)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	user, appErr := a.GetUser(credential.UserId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if user.AuthService != "" {
+		return nil, model.NewAppError("AuthenticateUserWithWebAuthn", "app.webauthn.passwordless_email_only.app_error", nil, "", http.StatusUnauthorized)
+	}
+
+	if appErr := a.CheckUserAllAuthenticationCriteria(c, user, ""); appErr != nil {
+		return nil, appErr
+	}
+
+	return user, nil
+}
+
+func (a *App) sendMfaChangeEmail(c request.CTX, user *model.User, activated bool) {
+	a.Srv().Go(func() {
+		if err := a.Srv().EmailService.SendMfaChangeEmail(user.Email, activated, user.Locale, a.GetSiteURL()); err != nil {
+			c.Logger().Error("Failed to send mfa change email", mlog.Err(err))
+		}
+	})
+}
+
+// GenerateMfaRecoveryCodes replaces the recovery codes of the user with new
+// ones. They are only returned once.
+func (a *App) GenerateMfaRecoveryCodes(userID string) (*model.MfaRecoveryCodes, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableMultifactorAuthentication {
+		return nil, model.NewAppError("GenerateMfaRecoveryCodes", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+	if !*a.Config().ServiceSettings.EnableMfaRecoveryCodes {
+		return nil, model.NewAppError("GenerateMfaRecoveryCodes", "app.mfa_recovery_code.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !user.MfaActive {
+		return nil, model.NewAppError("GenerateMfaRecoveryCodes", "app.mfa_recovery_code.mfa_inactive.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	codes, err := mfa.NewRecoveryCodes(a.Srv().Store().MfaRecoveryCode()).Generate(userID)
+	if err != nil {
+		return nil, model.NewAppError("GenerateMfaRecoveryCodes", "app.mfa_recovery_code.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return &model.MfaRecoveryCodes{Codes: codes}, nil
+}
+
+func (a *App) GetMfaRecoveryCodesStatus(userID string) (*model.MfaRecoveryCodesStatus, *model.AppError) {
+	remaining, err := a.Srv().Store().MfaRecoveryCode().CountUnused(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetMfaRecoveryCodesStatus", "app.mfa_recovery_code.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return &model.MfaRecoveryCodesStatus{Remaining: remaining}, nil
+}
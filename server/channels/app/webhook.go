@@ -95,111 +95,108 @@ func (a *App) handleWebhookEvents(c request.CTX, post *model.Post, team *model.T
 }
 
 func (a *App) TriggerWebhook(c request.CTX, payload *model.OutgoingWebhookPayload, hook *model.OutgoingWebhook, post *model.Post, channel *model.Channel) {
-	var jsonBytes []byte
-	var err error
+	var body string
 
 	contentType := "application/x-www-form-urlencoded"
 	if hook.ContentType == "application/json" {
 		contentType = "application/json"
-		jsonBytes, err = json.Marshal(payload)
+		jsonBytes, err := json.Marshal(payload)
 		if err != nil {
 			c.Logger().Warn("Failed to encode to JSON", mlog.Err(err))
 			return
 		}
+		body = string(jsonBytes)
+	} else {
+		body = payload.ToFormValues()
 	}
 
 	var wg sync.WaitGroup
 
-	for i := range hook.CallbackURLs {
-		var body io.Reader
-		if hook.ContentType == "application/json" {
-			body = bytes.NewReader(jsonBytes)
-		} else {
-			body = strings.NewReader(payload.ToFormValues())
+	for _, url := range hook.CallbackURLs {
+		delivery := &model.OutgoingWebhookDelivery{
+			HookId:      hook.Id,
+			ChannelId:   channel.Id,
+			PostId:      post.Id,
+			CallbackURL: url,
+			ContentType: contentType,
+			Payload:     body,
 		}
-		wg.Add(1)
-
-		// Get the callback URL by index to properly capture it for the go func
-		url := hook.CallbackURLs[i]
 
+		wg.Add(1)
 		go func() {
 			defer wg.Done()
+			a.deliverOutgoingWebhook(c, hook, channel, delivery)
+		}()
+	}
+	wg.Wait()
+}
 
-			var accessToken *model.OutgoingOAuthConnectionToken
-
-			// Retrieve an access token from a connection if one exists to use for the webhook request
-			if a.Config().ServiceSettings.EnableOutgoingOAuthConnections != nil && *a.Config().ServiceSettings.EnableOutgoingOAuthConnections && a.OutgoingOAuthConnections() != nil {
-				connection, err := a.OutgoingOAuthConnections().GetConnectionForAudience(c, url)
-				if err != nil {
-					c.Logger().Error("Failed to find an outgoing oauth connection for the webhook", mlog.Err(err))
-					return
-				}
+// handleOutgoingWebhookResponse creates the post sent back by the receiver of
+// an outgoing webhook, if any.
+func (a *App) handleOutgoingWebhookResponse(c request.CTX, hook *model.OutgoingWebhook, channel *model.Channel, postID string, webhookResp *model.OutgoingWebhookResponse) {
+	if webhookResp == nil || (webhookResp.Text == nil && len(webhookResp.Attachments) == 0) {
+		return
+	}
 
-				if connection != nil {
-					accessToken, err = a.OutgoingOAuthConnections().RetrieveTokenForConnection(c, connection)
-					if err != nil {
-						c.Logger().Error("Failed to retrieve token for outgoing oauth connection", mlog.Err(err))
-						return
-					}
-				}
-			}
+	postRootId := ""
+	if webhookResp.ResponseType == model.OutgoingHookResponseTypeComment {
+		postRootId = postID
+	}
+	if len(webhookResp.Props) == 0 {
+		webhookResp.Props = make(model.StringInterface)
+	}
+	webhookResp.Props["webhook_display_name"] = hook.DisplayName
 
-			webhookResp, err := a.doOutgoingWebhookRequest(url, body, contentType, accessToken)
-			if err != nil {
-				if errors.Is(err, context.DeadlineExceeded) {
-					c.Logger().Error("Outgoing Webhook POST timed out. Consider increasing ServiceSettings.OutgoingIntegrationRequestsTimeout.", mlog.Err(err))
-				} else {
-					c.Logger().Error("Outgoing Webhook POST failed", mlog.Err(err))
-				}
-				return
-			}
+	text := ""
+	if webhookResp.Text != nil {
+		text = a.ProcessSlackText(*webhookResp.Text)
+	}
+	webhookResp.Attachments = a.ProcessSlackAttachments(webhookResp.Attachments)
+	// attachments is in here for slack compatibility
+	if len(webhookResp.Attachments) > 0 {
+		webhookResp.Props["attachments"] = webhookResp.Attachments
+	}
+	if *a.Config().ServiceSettings.EnablePostUsernameOverride && hook.Username != "" && webhookResp.Username == "" {
+		webhookResp.Username = hook.Username
+	}
 
-			if webhookResp != nil && (webhookResp.Text != nil || len(webhookResp.Attachments) > 0) {
-				postRootId := ""
-				if webhookResp.ResponseType == model.OutgoingHookResponseTypeComment {
-					postRootId = post.Id
-				}
-				if len(webhookResp.Props) == 0 {
-					webhookResp.Props = make(model.StringInterface)
-				}
-				webhookResp.Props["webhook_display_name"] = hook.DisplayName
+	if *a.Config().ServiceSettings.EnablePostIconOverride && hook.IconURL != "" && webhookResp.IconURL == "" {
+		webhookResp.IconURL = hook.IconURL
+	}
+	if _, err := a.CreateWebhookPost(c, hook.CreatorId, channel, text, webhookResp.Username, webhookResp.IconURL, "", webhookResp.Props, webhookResp.Type, postRootId, webhookResp.Priority); err != nil {
+		c.Logger().Error("Failed to create response post.", mlog.Err(err))
+	}
+}
 
-				text := ""
-				if webhookResp.Text != nil {
-					text = a.ProcessSlackText(*webhookResp.Text)
-				}
-				webhookResp.Attachments = a.ProcessSlackAttachments(webhookResp.Attachments)
-				// attachments is in here for slack compatibility
-				if len(webhookResp.Attachments) > 0 {
-					webhookResp.Props["attachments"] = webhookResp.Attachments
-				}
-				if *a.Config().ServiceSettings.EnablePostUsernameOverride && hook.Username != "" && webhookResp.Username == "" {
-					webhookResp.Username = hook.Username
-				}
+func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType string, accessToken *model.OutgoingOAuthConnectionToken) (*model.OutgoingWebhookResponse, error) {
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
 
-				if *a.Config().ServiceSettings.EnablePostIconOverride && hook.IconURL != "" && webhookResp.IconURL == "" {
-					webhookResp.IconURL = hook.IconURL
-				}
-				if _, err := a.CreateWebhookPost(c, hook.CreatorId, channel, text, webhookResp.Username, webhookResp.IconURL, "", webhookResp.Props, webhookResp.Type, postRootId, webhookResp.Priority); err != nil {
-					c.Logger().Error("Failed to create response post.", mlog.Err(err))
-				}
-			}
-		}()
+	_, respBody, err := a.sendOutgoingWebhookRequest(url, content, contentType, accessToken, nil)
+	if err != nil {
+		return nil, err
 	}
-	wg.Wait()
+
+	return decodeOutgoingWebhookResponse(respBody)
 }
 
-func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType string, accessToken *model.OutgoingOAuthConnectionToken) (*model.OutgoingWebhookResponse, error) {
+// sendOutgoingWebhookRequest posts the body to the given URL, signed with the
+// given secrets, and returns the status code and the body of the response,
+// read up to MaxIntegrationResponseSize.
+func (a *App) sendOutgoingWebhookRequest(url string, body []byte, contentType string, accessToken *model.OutgoingOAuthConnectionToken, signingSecrets []string) (int, []byte, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*a.Config().ServiceSettings.OutgoingIntegrationRequestsTimeout)*time.Second)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, "POST", url, body)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
 	if err != nil {
-		return nil, err
+		return 0, nil, err
 	}
 
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
+	model.SignIntegrationRequest(req.Header, body, signingSecrets)
 
 	if accessToken != nil {
 		req.Header.Add("Authorization", accessToken.AsHeaderValue())
@@ -207,13 +204,22 @@ func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType s
 
 	resp, err := a.Srv().outgoingWebhookClient.Do(req)
 	if err != nil {
-		return nil, err
+		return 0, nil, err
 	}
 
 	defer resp.Body.Close()
 
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, MaxIntegrationResponseSize))
+	if err != nil {
+		return resp.StatusCode, nil, err
+	}
+
+	return resp.StatusCode, respBody, nil
+}
+
+func decodeOutgoingWebhookResponse(body []byte) (*model.OutgoingWebhookResponse, error) {
 	var hookResp model.OutgoingWebhookResponse
-	if jsonErr := json.NewDecoder(io.LimitReader(resp.Body, MaxIntegrationResponseSize)).Decode(&hookResp); jsonErr != nil {
+	if jsonErr := json.NewDecoder(bytes.NewReader(body)).Decode(&hookResp); jsonErr != nil {
 		if jsonErr == io.EOF {
 			return nil, nil
 		}
@@ -618,6 +624,9 @@ func (a *App) UpdateOutgoingWebhook(c request.CTX, oldHook, updatedHook *model.O
 	updatedHook.CreateAt = oldHook.CreateAt
 	updatedHook.DeleteAt = oldHook.DeleteAt
 	updatedHook.TeamId = oldHook.TeamId
+	updatedHook.SigningSecret = oldHook.SigningSecret
+	updatedHook.PreviousSigningSecret = oldHook.PreviousSigningSecret
+	updatedHook.PreviousSigningSecretExpireAt = oldHook.PreviousSigningSecretExpireAt
 	updatedHook.UpdateAt = model.GetMillis()
 
 	webhook, err := a.Srv().Store().Webhook().UpdateOutgoing(updatedHook)
@@ -703,6 +712,10 @@ func (a *App) DeleteOutgoingWebhook(hookID string) *model.AppError {
 		return model.NewAppError("DeleteOutgoingWebhook", "app.webhooks.delete_outgoing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if err := a.Srv().Store().OutgoingWebhookDelivery().PermanentDeleteByHook(hookID); err != nil {
+		a.Log().Warn("Failed to delete the delivery history of the outgoing webhook", mlog.String("hook_id", hookID), mlog.Err(err))
+	}
+
 	return nil
 }
 
@@ -721,6 +734,44 @@ func (a *App) RegenOutgoingWebhookToken(hook *model.OutgoingWebhook) (*model.Out
 	return webhook, nil
 }
 
+// RotateOutgoingWebhookSigningSecret generates a new signing secret for the
+// hook, enabling the signing of its requests if needed. The previous secret
+// keeps being used for the configured grace period.
+func (a *App) RotateOutgoingWebhookSigningSecret(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("RotateOutgoingWebhookSigningSecret", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	hook.RotateSigningSecret(a.integrationSigningSecretGracePeriod())
+
+	webhook, err := a.Srv().Store().Webhook().UpdateOutgoing(hook)
+	if err != nil {
+		return nil, model.NewAppError("RotateOutgoingWebhookSigningSecret", "app.webhooks.update_outgoing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return webhook, nil
+}
+
+// RemoveOutgoingWebhookSigningSecret stops the signing of the requests of the hook.
+func (a *App) RemoveOutgoingWebhookSigningSecret(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("RemoveOutgoingWebhookSigningSecret", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	hook.RemoveSigningSecret()
+
+	webhook, err := a.Srv().Store().Webhook().UpdateOutgoing(hook)
+	if err != nil {
+		return nil, model.NewAppError("RemoveOutgoingWebhookSigningSecret", "app.webhooks.update_outgoing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return webhook, nil
+}
+
+func (a *App) integrationSigningSecretGracePeriod() time.Duration {
+	return time.Duration(*a.Config().ServiceSettings.IntegrationSigningSecretGraceHours) * time.Hour
+}
+
 func (a *App) HandleIncomingWebhook(c request.CTX, hookID string, req *model.IncomingWebhookRequest) *model.AppError {
 	if !*a.Config().ServiceSettings.EnableIncomingWebhooks {
 		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
@@ -912,6 +912,91 @@ func (r InfiniteReader) Read(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
+func TestTriggerSignedOutgoingWebhook(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	type signedRequest struct {
+		header http.Header
+		body   []byte
+	}
+	requests := make(chan signedRequest, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests <- signedRequest{header: r.Header, body: body}
+	}))
+	defer ts.Close()
+
+	hook, appErr := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+		ChannelId:    th.BasicChannel.Id,
+		TeamId:       th.BasicTeam.Id,
+		CallbackURLs: []string{ts.URL},
+		CreatorId:    th.BasicUser.Id,
+		TriggerWords: []string{"trigger"},
+	})
+	require.Nil(t, appErr)
+
+	trigger := func() signedRequest {
+		payload := &model.OutgoingWebhookPayload{
+			Token:     hook.Token,
+			TeamId:    hook.TeamId,
+			ChannelId: th.BasicChannel.Id,
+			PostId:    th.BasicPost.Id,
+			Text:      "trigger",
+		}
+		th.App.TriggerWebhook(th.Context, payload, hook, th.BasicPost, th.BasicChannel)
+
+		select {
+		case request := <-requests:
+			return request
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "the webhook was not called")
+			return signedRequest{}
+		}
+	}
+
+	t.Run("requests are not signed without a signing secret", func(t *testing.T) {
+		request := trigger()
+		assert.Empty(t, request.header.Get(model.HeaderIntegrationSignature))
+		assert.Empty(t, request.header.Get(model.HeaderIntegrationTimestamp))
+	})
+
+	hook, appErr = th.App.RotateOutgoingWebhookSigningSecret(hook)
+	require.Nil(t, appErr)
+	oldSecret := hook.SigningSecret
+
+	t.Run("requests are signed with the signing secret", func(t *testing.T) {
+		request := trigger()
+		require.NoError(t, model.VerifyIntegrationSignature(oldSecret, request.header, request.body, model.IntegrationSignatureDefaultTolerance))
+		require.ErrorIs(t, model.VerifyIntegrationSignature(model.NewIntegrationSigningSecret(), request.header, request.body, model.IntegrationSignatureDefaultTolerance), model.ErrIntegrationSignatureMismatch)
+	})
+
+	hook, appErr = th.App.RotateOutgoingWebhookSigningSecret(hook)
+	require.Nil(t, appErr)
+
+	t.Run("the previous secret is valid during the grace period", func(t *testing.T) {
+		request := trigger()
+		require.NoError(t, model.VerifyIntegrationSignature(hook.SigningSecret, request.header, request.body, model.IntegrationSignatureDefaultTolerance))
+		require.NoError(t, model.VerifyIntegrationSignature(oldSecret, request.header, request.body, model.IntegrationSignatureDefaultTolerance))
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.IntegrationSigningSecretGraceHours = 0 })
+	hook, appErr = th.App.RotateOutgoingWebhookSigningSecret(hook)
+	require.Nil(t, appErr)
+
+	t.Run("the previous secret is not kept without a grace period", func(t *testing.T) {
+		request := trigger()
+		require.NoError(t, model.VerifyIntegrationSignature(hook.SigningSecret, request.header, request.body, model.IntegrationSignatureDefaultTolerance))
+		assert.Len(t, strings.Split(request.header.Get(model.HeaderIntegrationSignature), ","), 1)
+	})
+}
+
 func TestDoOutgoingWebhookRequest(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
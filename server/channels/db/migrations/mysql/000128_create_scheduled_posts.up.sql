@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS ScheduledPosts (
+    Id varchar(26) NOT NULL,
+    CreateAt bigint(20) NOT NULL,
+    UpdateAt bigint(20) NOT NULL,
+    UserId varchar(26) NOT NULL,
+    ChannelId varchar(26) NOT NULL,
+    RootId varchar(26) NOT NULL DEFAULT '',
+    Message text,
+    Props text,
+    FileIds text,
+    Priority text,
+    ScheduledAt bigint(20) NOT NULL,
+    ProcessedAt bigint(20) NOT NULL DEFAULT 0,
+    PostId varchar(26) NOT NULL DEFAULT '',
+    ErrorCode varchar(200) NOT NULL DEFAULT '',
+    PRIMARY KEY (Id),
+    KEY idx_scheduledposts_userid_channelid (UserId, ChannelId),
+    KEY idx_scheduledposts_processedat_scheduledat (ProcessedAt, ScheduledAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS OutgoingWebhookDeliveries (
+    Id varchar(26) NOT NULL,
+    CreateAt bigint(20) NOT NULL,
+    UpdateAt bigint(20) NOT NULL,
+    HookId varchar(26) NOT NULL,
+    ChannelId varchar(26) NOT NULL,
+    PostId varchar(26) NOT NULL,
+    CallbackURL text NOT NULL,
+    ContentType varchar(128) NOT NULL DEFAULT '',
+    Payload mediumtext,
+    Status varchar(32) NOT NULL,
+    Attempts int(11) NOT NULL DEFAULT 0,
+    NextAttemptAt bigint(20) NOT NULL DEFAULT 0,
+    LastAttemptAt bigint(20) NOT NULL DEFAULT 0,
+    StatusCode int(11) NOT NULL DEFAULT 0,
+    Latency bigint(20) NOT NULL DEFAULT 0,
+    Response text,
+    Error text,
+    PRIMARY KEY (Id),
+    KEY idx_outgoingwebhookdeliveries_hookid_createat (HookId, CreateAt),
+    KEY idx_outgoingwebhookdeliveries_status_nextattemptat (Status, NextAttemptAt),
+    KEY idx_outgoingwebhookdeliveries_updateat (UpdateAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,29 @@
+SET @preparedStatement = (SELECT IF(
+    NOT EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'OutgoingWebhooks'
+        AND table_schema = DATABASE()
+        AND column_name = 'SigningSecret'
+    ),
+    'ALTER TABLE OutgoingWebhooks ADD COLUMN SigningSecret varchar(128) DEFAULT "", ADD COLUMN PreviousSigningSecret varchar(128) DEFAULT "", ADD COLUMN PreviousSigningSecretExpireAt bigint DEFAULT 0;',
+    'SELECT 1;'
+));
+
+PREPARE addColumnIfNotExists FROM @preparedStatement;
+EXECUTE addColumnIfNotExists;
+DEALLOCATE PREPARE addColumnIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+    NOT EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'Commands'
+        AND table_schema = DATABASE()
+        AND column_name = 'SigningSecret'
+    ),
+    'ALTER TABLE Commands ADD COLUMN SigningSecret varchar(128) DEFAULT "", ADD COLUMN PreviousSigningSecret varchar(128) DEFAULT "", ADD COLUMN PreviousSigningSecretExpireAt bigint DEFAULT 0;',
+    'SELECT 1;'
+));
+
+PREPARE addColumnIfNotExists FROM @preparedStatement;
+EXECUTE addColumnIfNotExists;
+DEALLOCATE PREPARE addColumnIfNotExists;
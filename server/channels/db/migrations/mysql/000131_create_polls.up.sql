@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS Polls (
+    Id varchar(26) NOT NULL,
+    CreateAt bigint(20) NOT NULL,
+    UpdateAt bigint(20) NOT NULL,
+    DeleteAt bigint(20) NOT NULL DEFAULT 0,
+    PostId varchar(26) NOT NULL,
+    ChannelId varchar(26) NOT NULL,
+    UserId varchar(26) NOT NULL,
+    Question text NOT NULL,
+    Options text NOT NULL,
+    MultipleChoice tinyint(1) NOT NULL DEFAULT 0,
+    Anonymous tinyint(1) NOT NULL DEFAULT 0,
+    CloseAt bigint(20) NOT NULL DEFAULT 0,
+    PRIMARY KEY (Id),
+    UNIQUE KEY idx_polls_postid (PostId),
+    KEY idx_polls_channelid (ChannelId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS PollVotes (
+    PollId varchar(26) NOT NULL,
+    UserId varchar(26) NOT NULL,
+    OptionId varchar(26) NOT NULL,
+    CreateAt bigint(20) NOT NULL,
+    PRIMARY KEY (PollId, UserId, OptionId),
+    KEY idx_pollvotes_userid (UserId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
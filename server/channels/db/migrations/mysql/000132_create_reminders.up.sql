@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS Reminders (
+    Id varchar(26) NOT NULL,
+    CreateAt bigint(20) NOT NULL,
+    UpdateAt bigint(20) NOT NULL,
+    UserId varchar(26) NOT NULL,
+    TargetType varchar(32) NOT NULL,
+    TargetId varchar(26) NOT NULL,
+    Message text NOT NULL,
+    TriggerAt bigint(20) NOT NULL DEFAULT 0,
+    LastTriggeredAt bigint(20) NOT NULL DEFAULT 0,
+    Recurrence text,
+    PRIMARY KEY (Id),
+    KEY idx_reminders_userid (UserId),
+    KEY idx_reminders_targetid (TargetId),
+    KEY idx_reminders_triggerat (TriggerAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS ImportIdMappings (
+    ObjectType varchar(32) NOT NULL,
+    OldId varchar(26) NOT NULL,
+    NewId varchar(26) NOT NULL,
+    CreateAt bigint(20) NOT NULL,
+    PRIMARY KEY (ObjectType, OldId),
+    KEY idx_importidmappings_newid (NewId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS LegalHoldChannels;
+DROP TABLE IF EXISTS LegalHoldUsers;
+DROP TABLE IF EXISTS LegalHolds;
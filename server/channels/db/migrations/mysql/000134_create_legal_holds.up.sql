@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS LegalHolds (
+    Id varchar(26) NOT NULL,
+    Name varchar(64) NOT NULL,
+    Description text NOT NULL,
+    StartsAt bigint(20) NOT NULL DEFAULT 0,
+    EndsAt bigint(20) NOT NULL DEFAULT 0,
+    CreateAt bigint(20) NOT NULL,
+    UpdateAt bigint(20) NOT NULL,
+    DeleteAt bigint(20) NOT NULL DEFAULT 0,
+    PRIMARY KEY (Id),
+    KEY idx_legalholds_deleteat (DeleteAt)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS LegalHoldUsers (
+    LegalHoldId varchar(26) NOT NULL,
+    UserId varchar(26) NOT NULL,
+    PRIMARY KEY (LegalHoldId, UserId),
+    KEY idx_legalholdusers_userid (UserId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS LegalHoldChannels (
+    LegalHoldId varchar(26) NOT NULL,
+    ChannelId varchar(26) NOT NULL,
+    PRIMARY KEY (LegalHoldId, ChannelId),
+    KEY idx_legalholdchannels_channelid (ChannelId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS MfaRecoveryCodes (
+    UserId varchar(26) NOT NULL,
+    CodeHash varchar(64) NOT NULL,
+    CreateAt bigint(20) NOT NULL,
+    UsedAt bigint(20) NOT NULL DEFAULT 0,
+    PRIMARY KEY (UserId, CodeHash)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS WebPushSubscriptions (
+    Id varchar(26) NOT NULL,
+    UserId varchar(26) NOT NULL,
+    SessionId varchar(26) NOT NULL,
+    Endpoint varchar(1024) NOT NULL,
+    P256dh varchar(128) NOT NULL,
+    Auth varchar(64) NOT NULL,
+    CreateAt bigint(20) NOT NULL,
+    PRIMARY KEY (Id),
+    UNIQUE KEY idx_webpushsubscriptions_sessionid (SessionId),
+    KEY idx_webpushsubscriptions_userid (UserId)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS SavedSearches (
+    Id varchar(26) NOT NULL,
+    CreateAt bigint(20) NOT NULL,
+    UpdateAt bigint(20) NOT NULL,
+    UserId varchar(26) NOT NULL,
+    TeamId varchar(26) NOT NULL DEFAULT '',
+    Name varchar(64) NOT NULL,
+    Terms text NOT NULL,
+    IsOrSearch tinyint(1) NOT NULL DEFAULT 0,
+    TimeZoneOffset int NOT NULL DEFAULT 0,
+    Alert varchar(32) NOT NULL DEFAULT '',
+    LastCheckedAt bigint(20) NOT NULL DEFAULT 0,
+    PRIMARY KEY (Id),
+    KEY idx_savedsearches_userid (UserId),
+    KEY idx_savedsearches_alert (Alert)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
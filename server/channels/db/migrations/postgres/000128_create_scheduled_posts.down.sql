@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_scheduledposts_userid_channelid;
+DROP INDEX IF EXISTS idx_scheduledposts_processedat_scheduledat;
+
+DROP TABLE IF EXISTS scheduledposts;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS scheduledposts (
+    id varchar(26) PRIMARY KEY,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    userid varchar(26) NOT NULL,
+    channelid varchar(26) NOT NULL,
+    rootid varchar(26) NOT NULL DEFAULT '',
+    message varchar(65535),
+    props varchar(8000),
+    fileids varchar(300),
+    priority text,
+    scheduledat bigint NOT NULL,
+    processedat bigint NOT NULL DEFAULT 0,
+    postid varchar(26) NOT NULL DEFAULT '',
+    errorcode varchar(200) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduledposts_userid_channelid ON scheduledposts (userid, channelid);
+CREATE INDEX IF NOT EXISTS idx_scheduledposts_processedat_scheduledat ON scheduledposts (processedat, scheduledat);
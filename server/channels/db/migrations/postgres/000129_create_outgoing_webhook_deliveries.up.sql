@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS outgoingwebhookdeliveries (
+    id varchar(26) PRIMARY KEY,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    hookid varchar(26) NOT NULL,
+    channelid varchar(26) NOT NULL,
+    postid varchar(26) NOT NULL,
+    callbackurl varchar(1024) NOT NULL,
+    contenttype varchar(128) NOT NULL DEFAULT '',
+    payload text,
+    status varchar(32) NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    nextattemptat bigint NOT NULL DEFAULT 0,
+    lastattemptat bigint NOT NULL DEFAULT 0,
+    statuscode integer NOT NULL DEFAULT 0,
+    latency bigint NOT NULL DEFAULT 0,
+    response varchar(1024) NOT NULL DEFAULT '',
+    error varchar(1024) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_outgoingwebhookdeliveries_hookid_createat ON outgoingwebhookdeliveries (hookid, createat);
+CREATE INDEX IF NOT EXISTS idx_outgoingwebhookdeliveries_status_nextattemptat ON outgoingwebhookdeliveries (status, nextattemptat);
+CREATE INDEX IF NOT EXISTS idx_outgoingwebhookdeliveries_updateat ON outgoingwebhookdeliveries (updateat);
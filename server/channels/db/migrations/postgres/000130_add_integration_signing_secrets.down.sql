@@ -0,0 +1,7 @@
+ALTER TABLE outgoingwebhooks DROP COLUMN IF EXISTS signingsecret;
+ALTER TABLE outgoingwebhooks DROP COLUMN IF EXISTS previoussigningsecret;
+ALTER TABLE outgoingwebhooks DROP COLUMN IF EXISTS previoussigningsecretexpireat;
+
+ALTER TABLE commands DROP COLUMN IF EXISTS signingsecret;
+ALTER TABLE commands DROP COLUMN IF EXISTS previoussigningsecret;
+ALTER TABLE commands DROP COLUMN IF EXISTS previoussigningsecretexpireat;
@@ -0,0 +1,7 @@
+ALTER TABLE outgoingwebhooks ADD COLUMN IF NOT EXISTS signingsecret varchar(128) DEFAULT '';
+ALTER TABLE outgoingwebhooks ADD COLUMN IF NOT EXISTS previoussigningsecret varchar(128) DEFAULT '';
+ALTER TABLE outgoingwebhooks ADD COLUMN IF NOT EXISTS previoussigningsecretexpireat bigint DEFAULT 0;
+
+ALTER TABLE commands ADD COLUMN IF NOT EXISTS signingsecret varchar(128) DEFAULT '';
+ALTER TABLE commands ADD COLUMN IF NOT EXISTS previoussigningsecret varchar(128) DEFAULT '';
+ALTER TABLE commands ADD COLUMN IF NOT EXISTS previoussigningsecretexpireat bigint DEFAULT 0;
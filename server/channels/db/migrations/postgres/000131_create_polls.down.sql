@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_pollvotes_userid;
+DROP TABLE IF EXISTS pollvotes;
+
+DROP INDEX IF EXISTS idx_polls_postid;
+DROP INDEX IF EXISTS idx_polls_channelid;
+DROP TABLE IF EXISTS polls;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS polls (
+    id varchar(26) PRIMARY KEY,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    deleteat bigint NOT NULL DEFAULT 0,
+    postid varchar(26) NOT NULL,
+    channelid varchar(26) NOT NULL,
+    userid varchar(26) NOT NULL,
+    question varchar(4096) NOT NULL,
+    options text NOT NULL,
+    multiplechoice boolean NOT NULL DEFAULT false,
+    anonymous boolean NOT NULL DEFAULT false,
+    closeat bigint NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_polls_postid ON polls (postid);
+CREATE INDEX IF NOT EXISTS idx_polls_channelid ON polls (channelid);
+
+CREATE TABLE IF NOT EXISTS pollvotes (
+    pollid varchar(26) NOT NULL,
+    userid varchar(26) NOT NULL,
+    optionid varchar(26) NOT NULL,
+    createat bigint NOT NULL,
+    PRIMARY KEY (pollid, userid, optionid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pollvotes_userid ON pollvotes (userid);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_reminders_userid;
+DROP INDEX IF EXISTS idx_reminders_targetid;
+DROP INDEX IF EXISTS idx_reminders_triggerat;
+DROP TABLE IF EXISTS reminders;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS reminders (
+    id varchar(26) PRIMARY KEY,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    userid varchar(26) NOT NULL,
+    targettype varchar(32) NOT NULL,
+    targetid varchar(26) NOT NULL,
+    message varchar(4096) NOT NULL,
+    triggerat bigint NOT NULL DEFAULT 0,
+    lasttriggeredat bigint NOT NULL DEFAULT 0,
+    recurrence text
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminders_userid ON reminders (userid);
+CREATE INDEX IF NOT EXISTS idx_reminders_targetid ON reminders (targetid);
+CREATE INDEX IF NOT EXISTS idx_reminders_triggerat ON reminders (triggerat);
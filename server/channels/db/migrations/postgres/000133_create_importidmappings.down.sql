@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_importidmappings_newid;
+DROP TABLE IF EXISTS importidmappings;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS importidmappings (
+    objecttype varchar(32) NOT NULL,
+    oldid varchar(26) NOT NULL,
+    newid varchar(26) NOT NULL,
+    createat bigint NOT NULL,
+    PRIMARY KEY (objecttype, oldid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_importidmappings_newid ON importidmappings (newid);
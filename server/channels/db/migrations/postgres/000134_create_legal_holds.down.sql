@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_legalholdchannels_channelid;
+DROP TABLE IF EXISTS legalholdchannels;
+DROP INDEX IF EXISTS idx_legalholdusers_userid;
+DROP TABLE IF EXISTS legalholdusers;
+DROP INDEX IF EXISTS idx_legalholds_deleteat;
+DROP TABLE IF EXISTS legalholds;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS legalholds (
+    id varchar(26) PRIMARY KEY,
+    name varchar(64) NOT NULL,
+    description varchar(1024) NOT NULL DEFAULT '',
+    startsat bigint NOT NULL DEFAULT 0,
+    endsat bigint NOT NULL DEFAULT 0,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    deleteat bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_legalholds_deleteat ON legalholds (deleteat);
+
+CREATE TABLE IF NOT EXISTS legalholdusers (
+    legalholdid varchar(26) NOT NULL,
+    userid varchar(26) NOT NULL,
+    PRIMARY KEY (legalholdid, userid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_legalholdusers_userid ON legalholdusers (userid);
+
+CREATE TABLE IF NOT EXISTS legalholdchannels (
+    legalholdid varchar(26) NOT NULL,
+    channelid varchar(26) NOT NULL,
+    PRIMARY KEY (legalholdid, channelid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_legalholdchannels_channelid ON legalholdchannels (channelid);
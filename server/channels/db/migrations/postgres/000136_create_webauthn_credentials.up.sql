@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS webauthncredentials (
+    id varchar(26) PRIMARY KEY,
+    userid varchar(26) NOT NULL,
+    name varchar(64) NOT NULL DEFAULT '',
+    credentialid varchar(512) NOT NULL,
+    publickey bytea NOT NULL,
+    signcount bigint NOT NULL DEFAULT 0,
+    passwordless boolean NOT NULL DEFAULT false,
+    createat bigint NOT NULL,
+    lastusedat bigint NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webauthncredentials_credentialid ON webauthncredentials (credentialid);
+CREATE INDEX IF NOT EXISTS idx_webauthncredentials_userid ON webauthncredentials (userid);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS mfarecoverycodes (
+    userid varchar(26) NOT NULL,
+    codehash varchar(64) NOT NULL,
+    createat bigint NOT NULL,
+    usedat bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (userid, codehash)
+);
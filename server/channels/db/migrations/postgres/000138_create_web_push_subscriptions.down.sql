@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_webpushsubscriptions_userid;
+DROP INDEX IF EXISTS idx_webpushsubscriptions_sessionid;
+DROP TABLE IF EXISTS webpushsubscriptions;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS webpushsubscriptions (
+    id varchar(26) PRIMARY KEY,
+    userid varchar(26) NOT NULL,
+    sessionid varchar(26) NOT NULL,
+    endpoint varchar(1024) NOT NULL,
+    p256dh varchar(128) NOT NULL,
+    auth varchar(64) NOT NULL,
+    createat bigint NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webpushsubscriptions_sessionid ON webpushsubscriptions (sessionid);
+CREATE INDEX IF NOT EXISTS idx_webpushsubscriptions_userid ON webpushsubscriptions (userid);
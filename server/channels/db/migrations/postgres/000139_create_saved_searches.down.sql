@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_savedsearches_userid;
+DROP INDEX IF EXISTS idx_savedsearches_alert;
+DROP TABLE IF EXISTS savedsearches;
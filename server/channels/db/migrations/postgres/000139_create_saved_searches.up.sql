@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS savedsearches (
+    id varchar(26) PRIMARY KEY,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    userid varchar(26) NOT NULL,
+    teamid varchar(26) NOT NULL DEFAULT '',
+    name varchar(64) NOT NULL,
+    terms varchar(1024) NOT NULL,
+    isorsearch boolean NOT NULL DEFAULT false,
+    timezoneoffset integer NOT NULL DEFAULT 0,
+    alert varchar(32) NOT NULL DEFAULT '',
+    lastcheckedat bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_savedsearches_userid ON savedsearches (userid);
+CREATE INDEX IF NOT EXISTS idx_savedsearches_alert ON savedsearches (alert);
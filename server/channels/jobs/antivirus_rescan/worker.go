@@ -0,0 +1,104 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package antivirus_rescan
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const (
+	batchSize = 1000
+
+	// OnlyQuarantinedKey is the job data key restricting the rescan to the
+	// files which are not known to be clean.
+	OnlyQuarantinedKey = "only_quarantined"
+)
+
+type AppIface interface {
+	ScanFile(rctx request.CTX, info *model.FileInfo) (string, *model.AppError)
+}
+
+// MakeWorker creates a worker that sends the files of the file store to the
+// antivirus scanner again, e.g. after the signatures were updated or to scan
+// the files uploaded before scanning was enabled.
+func MakeWorker(jobServer *jobs.JobServer, app AppIface, store store.Store) *jobs.SimpleWorker {
+	const workerName = "AntivirusRescan"
+
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.FileSettings.EnableAntivirusScan
+	}
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		onlyQuarantined, _ := strconv.ParseBool(job.Data[OnlyQuarantinedKey])
+
+		total, err := store.FileInfo().CountAll()
+		if err != nil {
+			return err
+		}
+
+		counts := map[string]int{}
+		var nProcessed, nErrs int
+		var startTime int64
+		var startFileID string
+		for {
+			files, err := store.FileInfo().GetFilesBatchForIndexing(startTime, startFileID, false, batchSize)
+			if err != nil {
+				return err
+			}
+			if len(files) == 0 {
+				break
+			}
+
+			for _, file := range files {
+				nProcessed++
+				if onlyQuarantined && file.ScanStatus == model.FileScanStatusClean {
+					continue
+				}
+
+				status, appErr := app.ScanFile(request.EmptyContext(logger), &file.FileInfo)
+				if appErr != nil {
+					logger.Warn("Failed to scan file", mlog.String("file_info_id", file.Id), mlog.Err(appErr))
+					nErrs++
+					continue
+				}
+				counts[status]++
+			}
+
+			lastFile := files[len(files)-1]
+			startTime = lastFile.CreateAt
+			startFileID = lastFile.Id
+
+			job.Data["processed"] = strconv.Itoa(nProcessed)
+			if total > 0 {
+				if appErr := jobServer.SetJobProgress(job, min(int64(nProcessed)*100/total, 100)); appErr != nil {
+					logger.Error("Worker: Failed to update job progress", mlog.Err(appErr))
+				}
+			}
+		}
+
+		job.Data["processed"] = strconv.Itoa(nProcessed)
+		job.Data["clean"] = strconv.Itoa(counts[model.FileScanStatusClean])
+		job.Data["infected"] = strconv.Itoa(counts[model.FileScanStatusInfected])
+		job.Data["failed"] = strconv.Itoa(counts[model.FileScanStatusFailed])
+		job.Data["errors"] = strconv.Itoa(nErrs)
+		if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+			logger.Error("Worker: Failed to update job data", mlog.Err(appErr))
+		}
+
+		if nErrs > 0 {
+			return errors.New("some files could not be rescanned")
+		}
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
+	return worker
+}
@@ -6,7 +6,9 @@ package export_process
 import (
 	"context"
 	"io"
+	"net/http"
 	"path/filepath"
+	"strconv"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/configservice"
@@ -53,6 +55,16 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 			opts.IncludeRolesAndSchemes = true
 		}
 
+		// An incremental export only includes what changed since the given
+		// time, usually the one recorded in the manifest of a previous export.
+		if since := job.Data["since"]; since != "" {
+			var err error
+			opts.Since, err = strconv.ParseInt(since, 10, 64)
+			if err != nil || opts.Since < 0 {
+				return model.NewAppError("ExportProcessWorker", "export_process.worker.do_job.since", map[string]any{"Since": since}, "", http.StatusBadRequest)
+			}
+		}
+
 		outPath := *app.Config().ExportSettings.Directory
 		exportFilename := job.Id + "_export.zip"
 
@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package file_reencryption
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const progressUpdateInterval = 100
+
+type AppIface interface {
+	FileBackend() filestore.FileBackend
+}
+
+// MakeWorker creates a worker that makes sure every file of the file store is
+// encrypted with the current encryption at rest master key. Files written
+// before encryption was enabled get encrypted, and the data keys of files
+// encrypted with a retired master key are wrapped again.
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
+	const workerName = "FileReencryption"
+
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.FileSettings.EnableEncryptionAtRest
+	}
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		backend, ok := app.FileBackend().(*filestore.EncryptedFileBackend)
+		if !ok {
+			return errors.New("encryption at rest is not enabled for the file store")
+		}
+
+		paths, err := backend.ListDirectoryRecursively("")
+		if err != nil {
+			return err
+		}
+
+		var nProcessed, nReencrypted, nErrs int
+		for i, path := range paths {
+			rewritten, err := backend.Reencrypt(path)
+			if err != nil {
+				logger.Warn("Failed to re-encrypt file", mlog.String("path", path), mlog.Err(err))
+				nErrs++
+			} else if rewritten {
+				nReencrypted++
+			}
+			nProcessed++
+
+			if nProcessed%progressUpdateInterval == 0 {
+				job.Data["processed"] = strconv.Itoa(nProcessed)
+				if appErr := jobServer.SetJobProgress(job, int64((i+1)*100/len(paths))); appErr != nil {
+					logger.Error("Worker: Failed to update job progress", mlog.Err(appErr))
+				}
+			}
+		}
+
+		job.Data["processed"] = strconv.Itoa(nProcessed)
+		job.Data["reencrypted"] = strconv.Itoa(nReencrypted)
+		job.Data["errors"] = strconv.Itoa(nErrs)
+		if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+			logger.Error("Worker: Failed to update job data", mlog.Err(appErr))
+		}
+
+		if nErrs > 0 {
+			return errors.New("some files could not be re-encrypted")
+		}
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
+	return worker
+}
@@ -5,6 +5,8 @@ package import_process
 
 import (
 	"archive/zip"
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -29,7 +31,9 @@ type AppIface interface {
 	FileExists(path string) (bool, *model.AppError)
 	FileSize(path string) (int64, *model.AppError)
 	FileReader(path string) (filestore.ReadCloseSeeker, *model.AppError)
-	BulkImportWithPath(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun, extractContent bool, workers int, importPath string) (*model.AppError, int)
+	WriteFile(fr io.Reader, path string) (int64, *model.AppError)
+	BulkImportWithOpts(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, opts model.BulkImportOpts) (*model.AppError, int)
+	ValidateBulkImport(c request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, importPath string) (*model.ImportValidationReport, *model.AppError)
 	Log() *mlog.Logger
 }
 
@@ -123,9 +127,44 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 			return model.NewAppError("ImportProcessWorker", "import_process.worker.do_job.missing_jsonl", nil, "jsonFile was nil", http.StatusBadRequest)
 		}
 
-		extractContent := job.Data["extract_content"] == "true"
+		// A dry-run only validates the import and writes a report of the
+		// problems found, the import file is kept for the actual import.
+		if job.Data["dry_run"] == "true" {
+			report, appErr := app.ValidateBulkImport(appContext, jsonFile, importZipReader, model.ExportDataDir)
+			if appErr != nil {
+				return appErr
+			}
+			report.JobId = job.Id
+			report.ImportFile = importFileName
+
+			reportJSON, err := json.Marshal(report)
+			if err != nil {
+				return model.NewAppError("ImportProcessWorker", "import_process.worker.do_job.write_report", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			if _, appErr := app.WriteFile(bytes.NewReader(reportJSON), model.ImportValidationReportPath(job.Id)); appErr != nil {
+				return appErr
+			}
+
+			job.Data["is_downloadable"] = "true"
+			job.Data["issue_count"] = strconv.Itoa(report.IssueCount)
+			if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+				return appErr
+			}
+			return nil
+		}
+
+		idMode := job.Data["id_mode"]
+		if !model.IsValidImportIdMode(idMode) {
+			return model.NewAppError("ImportProcessWorker", "import_process.worker.do_job.id_mode", map[string]any{"IdMode": idMode}, "", http.StatusBadRequest)
+		}
+
 		// do the actual import.
-		appErr, lineNumber := app.BulkImportWithPath(appContext, jsonFile, importZipReader, false, extractContent, runtime.NumCPU(), model.ExportDataDir)
+		appErr, lineNumber := app.BulkImportWithOpts(appContext, jsonFile, importZipReader, model.BulkImportOpts{
+			ExtractContent: job.Data["extract_content"] == "true",
+			Workers:        runtime.NumCPU(),
+			ImportPath:     model.ExportDataDir,
+			IdMode:         idMode,
+		})
 		if appErr != nil {
 			job.Data["line_number"] = strconv.Itoa(lineNumber)
 			return appErr
@@ -0,0 +1,57 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package legal_hold_export
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+)
+
+type AppIface interface {
+	MessageExport() einterfaces.MessageExportInterface
+}
+
+// MakeWorker creates a worker that exports the posts held by a legal hold in
+// one of the compliance export formats.
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
+	const workerName = "LegalHoldExport"
+
+	isEnabled := func(cfg *model.Config) bool { return true }
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		messageExport := app.MessageExport()
+		if messageExport == nil {
+			return errors.New("message export is not available")
+		}
+
+		holdID := job.Data[model.LegalHoldExportJobDataHoldId]
+		exportType := job.Data[model.LegalHoldExportJobDataExportType]
+		if !model.IsValidId(holdID) || exportType == "" {
+			return errors.New("invalid legal hold export job data")
+		}
+
+		logger = logger.With(mlog.String("legal_hold_id", holdID), mlog.String("export_type", exportType))
+		exportDir, count, appErr := messageExport.RunLegalHoldExport(request.EmptyContext(logger), holdID, exportType)
+		if appErr != nil {
+			return appErr
+		}
+
+		job.Data[model.LegalHoldExportJobDataExportDir] = exportDir
+		job.Data[model.LegalHoldExportJobDataPostCount] = strconv.FormatInt(count, 10)
+		if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+			logger.Error("Worker: Failed to update job data", mlog.Err(appErr))
+		}
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
+	return worker
+}
@@ -13,8 +13,6 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
-	"github.com/opentracing/opentracing-go/ext"
-	spanlog "github.com/opentracing/opentracing-go/log"
 )
 
 type OpenTracingLayer struct {
@@ -33,13 +31,18 @@ type OpenTracingLayer struct {
 	EmojiStore                      store.EmojiStore
 	FileInfoStore                   store.FileInfoStore
 	GroupStore                      store.GroupStore
+	ImportIdMappingStore            store.ImportIdMappingStore
 	JobStore                        store.JobStore
+	LegalHoldStore                  store.LegalHoldStore
 	LicenseStore                    store.LicenseStore
 	LinkMetadataStore               store.LinkMetadataStore
+	MfaRecoveryCodeStore            store.MfaRecoveryCodeStore
 	NotifyAdminStore                store.NotifyAdminStore
 	OAuthStore                      store.OAuthStore
 	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
+	OutgoingWebhookDeliveryStore    store.OutgoingWebhookDeliveryStore
 	PluginStore                     store.PluginStore
+	PollStore                       store.PollStore
 	PostStore                       store.PostStore
 	PostAcknowledgementStore        store.PostAcknowledgementStore
 	PostPersistentNotificationStore store.PostPersistentNotificationStore
@@ -47,9 +50,12 @@ type OpenTracingLayer struct {
 	PreferenceStore                 store.PreferenceStore
 	ProductNoticesStore             store.ProductNoticesStore
 	ReactionStore                   store.ReactionStore
+	ReminderStore                   store.ReminderStore
 	RemoteClusterStore              store.RemoteClusterStore
 	RetentionPolicyStore            store.RetentionPolicyStore
 	RoleStore                       store.RoleStore
+	SavedSearchStore                store.SavedSearchStore
+	ScheduledPostStore              store.ScheduledPostStore
 	SchemeStore                     store.SchemeStore
 	SessionStore                    store.SessionStore
 	SharedChannelStore              store.SharedChannelStore
@@ -63,6 +69,8 @@ type OpenTracingLayer struct {
 	UserStore                       store.UserStore
 	UserAccessTokenStore            store.UserAccessTokenStore
 	UserTermsOfServiceStore         store.UserTermsOfServiceStore
+	WebAuthnCredentialStore         store.WebAuthnCredentialStore
+	WebPushSubscriptionStore        store.WebPushSubscriptionStore
 	WebhookStore                    store.WebhookStore
 }
 
@@ -122,10 +130,18 @@ func (s *OpenTracingLayer) Group() store.GroupStore {
 	return s.GroupStore
 }
 
+func (s *OpenTracingLayer) ImportIdMapping() store.ImportIdMappingStore {
+	return s.ImportIdMappingStore
+}
+
 func (s *OpenTracingLayer) Job() store.JobStore {
 	return s.JobStore
 }
 
+func (s *OpenTracingLayer) LegalHold() store.LegalHoldStore {
+	return s.LegalHoldStore
+}
+
 func (s *OpenTracingLayer) License() store.LicenseStore {
 	return s.LicenseStore
 }
@@ -134,6 +150,10 @@ func (s *OpenTracingLayer) LinkMetadata() store.LinkMetadataStore {
 	return s.LinkMetadataStore
 }
 
+func (s *OpenTracingLayer) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	return s.MfaRecoveryCodeStore
+}
+
 func (s *OpenTracingLayer) NotifyAdmin() store.NotifyAdminStore {
 	return s.NotifyAdminStore
 }
@@ -146,10 +166,18 @@ func (s *OpenTracingLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnecti
 	return s.OutgoingOAuthConnectionStore
 }
 
+func (s *OpenTracingLayer) OutgoingWebhookDelivery() store.OutgoingWebhookDeliveryStore {
+	return s.OutgoingWebhookDeliveryStore
+}
+
 func (s *OpenTracingLayer) Plugin() store.PluginStore {
 	return s.PluginStore
 }
 
+func (s *OpenTracingLayer) Poll() store.PollStore {
+	return s.PollStore
+}
+
 func (s *OpenTracingLayer) Post() store.PostStore {
 	return s.PostStore
 }
@@ -178,6 +206,10 @@ func (s *OpenTracingLayer) Reaction() store.ReactionStore {
 	return s.ReactionStore
 }
 
+func (s *OpenTracingLayer) Reminder() store.ReminderStore {
+	return s.ReminderStore
+}
+
 func (s *OpenTracingLayer) RemoteCluster() store.RemoteClusterStore {
 	return s.RemoteClusterStore
 }
@@ -190,6 +222,14 @@ func (s *OpenTracingLayer) Role() store.RoleStore {
 	return s.RoleStore
 }
 
+func (s *OpenTracingLayer) SavedSearch() store.SavedSearchStore {
+	return s.SavedSearchStore
+}
+
+func (s *OpenTracingLayer) ScheduledPost() store.ScheduledPostStore {
+	return s.ScheduledPostStore
+}
+
 func (s *OpenTracingLayer) Scheme() store.SchemeStore {
 	return s.SchemeStore
 }
@@ -242,6 +282,14 @@ func (s *OpenTracingLayer) UserTermsOfService() store.UserTermsOfServiceStore {
 	return s.UserTermsOfServiceStore
 }
 
+func (s *OpenTracingLayer) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return s.WebAuthnCredentialStore
+}
+
+func (s *OpenTracingLayer) WebPushSubscription() store.WebPushSubscriptionStore {
+	return s.WebPushSubscriptionStore
+}
+
 func (s *OpenTracingLayer) Webhook() store.WebhookStore {
 	return s.WebhookStore
 }
@@ -316,11 +364,21 @@ type OpenTracingLayerGroupStore struct {
 	Root *OpenTracingLayer
 }
 
+type OpenTracingLayerImportIdMappingStore struct {
+	store.ImportIdMappingStore
+	Root *OpenTracingLayer
+}
+
 type OpenTracingLayerJobStore struct {
 	store.JobStore
 	Root *OpenTracingLayer
 }
 
+type OpenTracingLayerLegalHoldStore struct {
+	store.LegalHoldStore
+	Root *OpenTracingLayer
+}
+
 type OpenTracingLayerLicenseStore struct {
 	store.LicenseStore
 	Root *OpenTracingLayer
@@ -331,6 +389,11 @@ type OpenTracingLayerLinkMetadataStore struct {
 	Root *OpenTracingLayer
 }
 
+type OpenTracingLayerMfaRecoveryCodeStore struct {
+	store.MfaRecoveryCodeStore
+	Root *OpenTracingLayer
+}
+
 type OpenTracingLayerNotifyAdminStore struct {
 	store.NotifyAdminStore
 	Root *OpenTracingLayer
@@ -346,11 +409,21 @@ type OpenTracingLayerOutgoingOAuthConnectionStore struct {
 	Root *OpenTracingLayer
 }
 
+type OpenTracingLayerOutgoingWebhookDeliveryStore struct {
+	store.OutgoingWebhookDeliveryStore
+	Root *OpenTracingLayer
+}
+
 type OpenTracingLayerPluginStore struct {
 	store.PluginStore
 	Root *OpenTracingLayer
 }
 
+type OpenTracingLayerPollStore struct {
+	store.PollStore
+	Root *OpenTracingLayer
+}
+
 type OpenTracingLayerPostStore struct {
 	store.PostStore
 	Root *OpenTracingLayer
@@ -386,6 +459,11 @@ type OpenTracingLayerReactionStore struct {
 	Root *OpenTracingLayer
 }
 
+type OpenTracingLayerReminderStore struct {
+	store.ReminderStore
+	Root *OpenTracingLayer
+}
+
 type OpenTracingLayerRemoteClusterStore struct {
 	store.RemoteClusterStore
 	Root *OpenTracingLayer
@@ -401,6 +479,16 @@ type OpenTracingLayerRoleStore struct {
 	Root *OpenTracingLayer
 }
 
+type OpenTracingLayerSavedSearchStore struct {
+	store.SavedSearchStore
+	Root *OpenTracingLayer
+}
+
+type OpenTracingLayerScheduledPostStore struct {
+	store.ScheduledPostStore
+	Root *OpenTracingLayer
+}
+
 type OpenTracingLayerSchemeStore struct {
 	store.SchemeStore
 	Root *OpenTracingLayer
@@ -466,6 +554,16 @@ type OpenTracingLayerUserTermsOfServiceStore struct {
 	Root *OpenTracingLayer
 }
 
+type OpenTracingLayerWebAuthnCredentialStore struct {
+	store.WebAuthnCredentialStore
+	Root *OpenTracingLayer
+}
+
+type OpenTracingLayerWebPushSubscriptionStore struct {
+	store.WebPushSubscriptionStore
+	Root *OpenTracingLayer
+}
+
 type OpenTracingLayerWebhookStore struct {
 	store.WebhookStore
 	Root *OpenTracingLayer
@@ -479,11 +577,10 @@ func (s *OpenTracingLayerAuditStore) Get(userID string, offset int, limit int) (
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.AuditStore.Get(userID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -497,11 +594,10 @@ func (s *OpenTracingLayerAuditStore) PermanentDeleteByUser(userID string) error
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.AuditStore.PermanentDeleteByUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -515,11 +611,10 @@ func (s *OpenTracingLayerAuditStore) Save(audit *model.Audit) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.AuditStore.Save(audit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -533,11 +628,10 @@ func (s *OpenTracingLayerBotStore) Get(userID string, includeDeleted bool) (*mod
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.BotStore.Get(userID, includeDeleted)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -551,11 +645,10 @@ func (s *OpenTracingLayerBotStore) GetAll(options *model.BotGetOptions) ([]*mode
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.BotStore.GetAll(options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -569,11 +662,10 @@ func (s *OpenTracingLayerBotStore) PermanentDelete(userID string) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.BotStore.PermanentDelete(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -587,11 +679,10 @@ func (s *OpenTracingLayerBotStore) Save(bot *model.Bot) (*model.Bot, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.BotStore.Save(bot)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -605,11 +696,10 @@ func (s *OpenTracingLayerBotStore) Update(bot *model.Bot) (*model.Bot, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.BotStore.Update(bot)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -623,11 +713,10 @@ func (s *OpenTracingLayerChannelStore) AnalyticsDeletedTypeCount(teamID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.AnalyticsDeletedTypeCount(teamID, channelType)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -641,11 +730,10 @@ func (s *OpenTracingLayerChannelStore) AnalyticsTypeCount(teamID string, channel
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.AnalyticsTypeCount(teamID, channelType)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -659,11 +747,10 @@ func (s *OpenTracingLayerChannelStore) Autocomplete(rctx request.CTX, userID str
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.Autocomplete(rctx, userID, term, includeDeleted, isGuest)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -677,11 +764,10 @@ func (s *OpenTracingLayerChannelStore) AutocompleteInTeam(rctx request.CTX, team
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.AutocompleteInTeam(rctx, teamID, userID, term, includeDeleted, isGuest)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -695,11 +781,10 @@ func (s *OpenTracingLayerChannelStore) AutocompleteInTeamForSearch(teamID string
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.AutocompleteInTeamForSearch(teamID, userID, term, includeDeleted)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -713,11 +798,10 @@ func (s *OpenTracingLayerChannelStore) ClearAllCustomRoleAssignments() error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.ClearAllCustomRoleAssignments()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -731,7 +815,7 @@ func (s *OpenTracingLayerChannelStore) ClearCaches() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.ChannelStore.ClearCaches()
 
 }
@@ -744,7 +828,7 @@ func (s *OpenTracingLayerChannelStore) ClearMembersForUserCache() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.ChannelStore.ClearMembersForUserCache()
 
 }
@@ -757,11 +841,10 @@ func (s *OpenTracingLayerChannelStore) ClearSidebarOnTeamLeave(userID string, te
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.ClearSidebarOnTeamLeave(userID, teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -775,11 +858,10 @@ func (s *OpenTracingLayerChannelStore) CountPostsAfter(channelID string, timesta
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, resultVar1, err := s.ChannelStore.CountPostsAfter(channelID, timestamp, excludedUserID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, resultVar1, err
@@ -793,11 +875,10 @@ func (s *OpenTracingLayerChannelStore) CountUrgentPostsAfter(channelID string, t
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.CountUrgentPostsAfter(channelID, timestamp, excludedUserID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -811,11 +892,10 @@ func (s *OpenTracingLayerChannelStore) CreateDirectChannel(ctx request.CTX, user
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.CreateDirectChannel(ctx, userID, otherUserID, channelOptions...)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -829,11 +909,10 @@ func (s *OpenTracingLayerChannelStore) CreateInitialSidebarCategories(c request.
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.CreateInitialSidebarCategories(c, userID, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -847,11 +926,10 @@ func (s *OpenTracingLayerChannelStore) CreateSidebarCategory(userID string, team
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.CreateSidebarCategory(userID, teamID, newCategory)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -865,11 +943,10 @@ func (s *OpenTracingLayerChannelStore) Delete(channelID string, timestamp int64)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.Delete(channelID, timestamp)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -883,11 +960,10 @@ func (s *OpenTracingLayerChannelStore) DeleteAllSidebarChannelForChannel(channel
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.DeleteAllSidebarChannelForChannel(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -901,11 +977,10 @@ func (s *OpenTracingLayerChannelStore) DeleteSidebarCategory(categoryID string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.DeleteSidebarCategory(categoryID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -919,11 +994,10 @@ func (s *OpenTracingLayerChannelStore) DeleteSidebarChannelsByPreferences(prefer
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.DeleteSidebarChannelsByPreferences(preferences)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -937,11 +1011,10 @@ func (s *OpenTracingLayerChannelStore) Get(id string, allowFromCache bool) (*mod
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.Get(id, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -955,11 +1028,10 @@ func (s *OpenTracingLayerChannelStore) GetAll(teamID string) ([]*model.Channel,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetAll(teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -973,11 +1045,10 @@ func (s *OpenTracingLayerChannelStore) GetAllChannelMemberIdsByChannelId(id stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetAllChannelMemberIdsByChannelId(id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -991,11 +1062,10 @@ func (s *OpenTracingLayerChannelStore) GetAllChannelMembersForUser(ctx request.C
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetAllChannelMembersForUser(ctx, userID, allowFromCache, includeDeleted)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1009,11 +1079,10 @@ func (s *OpenTracingLayerChannelStore) GetAllChannelMembersNotifyPropsForChannel
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetAllChannelMembersNotifyPropsForChannel(channelID, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1027,11 +1096,10 @@ func (s *OpenTracingLayerChannelStore) GetAllChannels(page int, perPage int, opt
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetAllChannels(page, perPage, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1045,11 +1113,10 @@ func (s *OpenTracingLayerChannelStore) GetAllChannelsCount(opts store.ChannelSea
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetAllChannelsCount(opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1063,11 +1130,10 @@ func (s *OpenTracingLayerChannelStore) GetAllChannelsForExportAfter(limit int, a
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetAllChannelsForExportAfter(limit, afterID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1081,11 +1147,10 @@ func (s *OpenTracingLayerChannelStore) GetAllDirectChannelsForExportAfter(limit
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetAllDirectChannelsForExportAfter(limit, afterID, includeArchivedChannels)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1099,11 +1164,10 @@ func (s *OpenTracingLayerChannelStore) GetByName(teamID string, name string, all
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetByName(teamID, name, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1117,11 +1181,10 @@ func (s *OpenTracingLayerChannelStore) GetByNameIncludeDeleted(teamID string, na
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetByNameIncludeDeleted(teamID, name, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1135,11 +1198,10 @@ func (s *OpenTracingLayerChannelStore) GetByNames(teamID string, names []string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetByNames(teamID, names, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1153,11 +1215,10 @@ func (s *OpenTracingLayerChannelStore) GetByNamesIncludeDeleted(teamID string, n
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetByNamesIncludeDeleted(teamID, names, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1171,11 +1232,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelCounts(teamID string, userID st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetChannelCounts(teamID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1189,11 +1249,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelMembersForExport(userID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetChannelMembersForExport(userID, teamID, includeArchivedChannel)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1207,11 +1266,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelMembersTimezones(channelID stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetChannelMembersTimezones(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1225,11 +1283,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelUnread(channelID string, userID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetChannelUnread(channelID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1243,11 +1300,10 @@ func (s *OpenTracingLayerChannelStore) GetChannels(teamID string, userID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetChannels(teamID, userID, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1261,11 +1317,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelsBatchForIndexing(startTime int
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetChannelsBatchForIndexing(startTime, startChannelID, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1279,11 +1334,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelsByIds(channelIds []string, inc
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetChannelsByIds(channelIds, includeDeleted)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1297,11 +1351,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelsByScheme(schemeID string, offs
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetChannelsByScheme(schemeID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1315,11 +1368,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelsByUser(userID string, includeD
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetChannelsByUser(userID, includeDeleted, lastDeleteAt, pageSize, fromChannelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1333,11 +1385,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelsMemberCount(channelIDs []strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetChannelsMemberCount(channelIDs)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1351,11 +1402,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelsWithTeamDataByIds(channelIds [
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetChannelsWithTeamDataByIds(channelIds, includeDeleted)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1369,11 +1419,10 @@ func (s *OpenTracingLayerChannelStore) GetChannelsWithUnreadsAndWithMentions(ctx
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, resultVar1, resultVar2, err := s.ChannelStore.GetChannelsWithUnreadsAndWithMentions(ctx, channelIDs, userID, userNotifyProps)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, resultVar1, resultVar2, err
@@ -1387,11 +1436,10 @@ func (s *OpenTracingLayerChannelStore) GetDeleted(teamID string, offset int, lim
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetDeleted(teamID, offset, limit, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1405,11 +1453,10 @@ func (s *OpenTracingLayerChannelStore) GetDeletedByName(teamID string, name stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetDeletedByName(teamID, name)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1423,11 +1470,10 @@ func (s *OpenTracingLayerChannelStore) GetFileCount(channelID string) (int64, er
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetFileCount(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1441,11 +1487,10 @@ func (s *OpenTracingLayerChannelStore) GetForPost(postID string) (*model.Channel
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetForPost(postID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1459,11 +1504,10 @@ func (s *OpenTracingLayerChannelStore) GetGuestCount(channelID string, allowFrom
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetGuestCount(channelID, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1477,11 +1521,10 @@ func (s *OpenTracingLayerChannelStore) GetMany(ids []string, allowFromCache bool
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetMany(ids, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1495,11 +1538,10 @@ func (s *OpenTracingLayerChannelStore) GetMember(ctx context.Context, channelID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetMember(ctx, channelID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1513,11 +1555,10 @@ func (s *OpenTracingLayerChannelStore) GetMemberCount(channelID string, allowFro
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetMemberCount(channelID, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1531,7 +1572,7 @@ func (s *OpenTracingLayerChannelStore) GetMemberCountFromCache(channelID string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result := s.ChannelStore.GetMemberCountFromCache(channelID)
 	return result
 }
@@ -1544,11 +1585,10 @@ func (s *OpenTracingLayerChannelStore) GetMemberCountsByGroup(ctx context.Contex
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetMemberCountsByGroup(ctx, channelID, includeTimezones)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1562,11 +1602,10 @@ func (s *OpenTracingLayerChannelStore) GetMemberForPost(postID string, userID st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetMemberForPost(postID, userID, includeArchivedChannels)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1580,11 +1619,10 @@ func (s *OpenTracingLayerChannelStore) GetMemberLastViewedAt(ctx context.Context
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetMemberLastViewedAt(ctx, channelID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1598,11 +1636,10 @@ func (s *OpenTracingLayerChannelStore) GetMembers(channelID string, offset int,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetMembers(channelID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1616,11 +1653,10 @@ func (s *OpenTracingLayerChannelStore) GetMembersByChannelIds(channelIds []strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetMembersByChannelIds(channelIds, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1634,11 +1670,10 @@ func (s *OpenTracingLayerChannelStore) GetMembersByIds(channelID string, userIds
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetMembersByIds(channelID, userIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1652,11 +1687,10 @@ func (s *OpenTracingLayerChannelStore) GetMembersForUser(teamID string, userID s
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetMembersForUser(teamID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1670,11 +1704,10 @@ func (s *OpenTracingLayerChannelStore) GetMembersForUserWithPagination(userID st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetMembersForUserWithPagination(userID, page, perPage)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1688,11 +1721,10 @@ func (s *OpenTracingLayerChannelStore) GetMembersInfoByChannelIds(channelIDs []s
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetMembersInfoByChannelIds(channelIDs)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1706,11 +1738,10 @@ func (s *OpenTracingLayerChannelStore) GetMoreChannels(teamID string, userID str
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetMoreChannels(teamID, userID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1724,11 +1755,10 @@ func (s *OpenTracingLayerChannelStore) GetPinnedPostCount(channelID string, allo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetPinnedPostCount(channelID, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1742,11 +1772,10 @@ func (s *OpenTracingLayerChannelStore) GetPinnedPosts(channelID string) (*model.
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetPinnedPosts(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1760,11 +1789,10 @@ func (s *OpenTracingLayerChannelStore) GetPrivateChannelsForTeam(teamID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetPrivateChannelsForTeam(teamID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1778,11 +1806,10 @@ func (s *OpenTracingLayerChannelStore) GetPublicChannelsByIdsForTeam(teamID stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetPublicChannelsByIdsForTeam(teamID, channelIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1796,11 +1823,10 @@ func (s *OpenTracingLayerChannelStore) GetPublicChannelsForTeam(teamID string, o
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetPublicChannelsForTeam(teamID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1814,11 +1840,10 @@ func (s *OpenTracingLayerChannelStore) GetSidebarCategories(userID string, opts
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetSidebarCategories(userID, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1832,11 +1857,10 @@ func (s *OpenTracingLayerChannelStore) GetSidebarCategoriesForTeamForUser(userID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetSidebarCategoriesForTeamForUser(userID, teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1850,11 +1874,10 @@ func (s *OpenTracingLayerChannelStore) GetSidebarCategory(categoryID string) (*m
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetSidebarCategory(categoryID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1868,11 +1891,10 @@ func (s *OpenTracingLayerChannelStore) GetSidebarCategoryOrder(userID string, te
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetSidebarCategoryOrder(userID, teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1886,11 +1908,10 @@ func (s *OpenTracingLayerChannelStore) GetTeamChannels(teamID string) (model.Cha
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetTeamChannels(teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1904,11 +1925,10 @@ func (s *OpenTracingLayerChannelStore) GetTeamForChannel(channelID string) (*mod
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetTeamForChannel(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1922,11 +1942,10 @@ func (s *OpenTracingLayerChannelStore) GetTeamMembersForChannel(channelID string
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GetTeamMembersForChannel(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1940,11 +1959,10 @@ func (s *OpenTracingLayerChannelStore) GroupSyncedChannelCount() (int64, error)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.GroupSyncedChannelCount()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -1958,11 +1976,10 @@ func (s *OpenTracingLayerChannelStore) IncrementMentionCount(channelID string, u
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.IncrementMentionCount(channelID, userIDs, isRoot, isUrgent)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -1976,7 +1993,7 @@ func (s *OpenTracingLayerChannelStore) InvalidateAllChannelMembersForUser(userID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.ChannelStore.InvalidateAllChannelMembersForUser(userID)
 
 }
@@ -1989,7 +2006,7 @@ func (s *OpenTracingLayerChannelStore) InvalidateCacheForChannelMembersNotifyPro
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.ChannelStore.InvalidateCacheForChannelMembersNotifyProps(channelID)
 
 }
@@ -2002,7 +2019,7 @@ func (s *OpenTracingLayerChannelStore) InvalidateChannel(id string) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.ChannelStore.InvalidateChannel(id)
 
 }
@@ -2015,7 +2032,7 @@ func (s *OpenTracingLayerChannelStore) InvalidateChannelByName(teamID string, na
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.ChannelStore.InvalidateChannelByName(teamID, name)
 
 }
@@ -2028,7 +2045,7 @@ func (s *OpenTracingLayerChannelStore) InvalidateGuestCount(channelID string) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.ChannelStore.InvalidateGuestCount(channelID)
 
 }
@@ -2041,7 +2058,7 @@ func (s *OpenTracingLayerChannelStore) InvalidateMemberCount(channelID string) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.ChannelStore.InvalidateMemberCount(channelID)
 
 }
@@ -2054,7 +2071,7 @@ func (s *OpenTracingLayerChannelStore) InvalidatePinnedPostCount(channelID strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.ChannelStore.InvalidatePinnedPostCount(channelID)
 
 }
@@ -2067,11 +2084,10 @@ func (s *OpenTracingLayerChannelStore) IsChannelReadOnlyScheme(schemeID string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.IsChannelReadOnlyScheme(schemeID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2085,11 +2101,10 @@ func (s *OpenTracingLayerChannelStore) IsReadOnlyChannel(channelID string) (bool
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.IsReadOnlyChannel(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2103,11 +2118,10 @@ func (s *OpenTracingLayerChannelStore) MigrateChannelMembers(fromChannelID strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.MigrateChannelMembers(fromChannelID, fromUserID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2121,11 +2135,10 @@ func (s *OpenTracingLayerChannelStore) PatchMultipleMembersNotifyProps(members [
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.PatchMultipleMembersNotifyProps(members, notifyProps)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2139,11 +2152,10 @@ func (s *OpenTracingLayerChannelStore) PermanentDelete(ctx request.CTX, channelI
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.PermanentDelete(ctx, channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2157,11 +2169,10 @@ func (s *OpenTracingLayerChannelStore) PermanentDeleteByTeam(teamID string) erro
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.PermanentDeleteByTeam(teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2175,11 +2186,10 @@ func (s *OpenTracingLayerChannelStore) PermanentDeleteMembersByChannel(ctx reque
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.PermanentDeleteMembersByChannel(ctx, channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2193,11 +2203,10 @@ func (s *OpenTracingLayerChannelStore) PermanentDeleteMembersByUser(ctx request.
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.PermanentDeleteMembersByUser(ctx, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2211,11 +2220,10 @@ func (s *OpenTracingLayerChannelStore) RemoveAllDeactivatedMembers(ctx request.C
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.RemoveAllDeactivatedMembers(ctx, channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2229,11 +2237,10 @@ func (s *OpenTracingLayerChannelStore) RemoveMember(ctx request.CTX, channelID s
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.RemoveMember(ctx, channelID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2247,11 +2254,10 @@ func (s *OpenTracingLayerChannelStore) RemoveMembers(ctx request.CTX, channelID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.RemoveMembers(ctx, channelID, userIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2265,11 +2271,10 @@ func (s *OpenTracingLayerChannelStore) ResetAllChannelSchemes() error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.ResetAllChannelSchemes()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2283,11 +2288,10 @@ func (s *OpenTracingLayerChannelStore) Restore(channelID string, timestamp int64
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.Restore(channelID, timestamp)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2301,11 +2305,10 @@ func (s *OpenTracingLayerChannelStore) Save(rctx request.CTX, channel *model.Cha
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.Save(rctx, channel, maxChannelsPerTeam)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2319,11 +2322,10 @@ func (s *OpenTracingLayerChannelStore) SaveDirectChannel(ctx request.CTX, channe
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.SaveDirectChannel(ctx, channel, member1, member2)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2337,11 +2339,10 @@ func (s *OpenTracingLayerChannelStore) SaveMember(rctx request.CTX, member *mode
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.SaveMember(rctx, member)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2355,11 +2356,10 @@ func (s *OpenTracingLayerChannelStore) SaveMultipleMembers(members []*model.Chan
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.SaveMultipleMembers(members)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2373,11 +2373,10 @@ func (s *OpenTracingLayerChannelStore) SearchAllChannels(term string, opts store
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, resultVar1, err := s.ChannelStore.SearchAllChannels(term, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, resultVar1, err
@@ -2391,11 +2390,10 @@ func (s *OpenTracingLayerChannelStore) SearchArchivedInTeam(teamID string, term
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.SearchArchivedInTeam(teamID, term, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2409,11 +2407,10 @@ func (s *OpenTracingLayerChannelStore) SearchForUserInTeam(userID string, teamID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.SearchForUserInTeam(userID, teamID, term, includeDeleted)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2427,11 +2424,10 @@ func (s *OpenTracingLayerChannelStore) SearchGroupChannels(userID string, term s
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.SearchGroupChannels(userID, term)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2445,11 +2441,10 @@ func (s *OpenTracingLayerChannelStore) SearchInTeam(teamID string, term string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.SearchInTeam(teamID, term, includeDeleted)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2463,11 +2458,10 @@ func (s *OpenTracingLayerChannelStore) SearchMore(userID string, teamID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.SearchMore(userID, teamID, term)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2481,11 +2475,10 @@ func (s *OpenTracingLayerChannelStore) SetDeleteAt(channelID string, deleteAt in
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.SetDeleteAt(channelID, deleteAt, updateAt)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2499,11 +2492,10 @@ func (s *OpenTracingLayerChannelStore) SetShared(channelID string, shared bool)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.SetShared(channelID, shared)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2517,11 +2509,10 @@ func (s *OpenTracingLayerChannelStore) Update(ctx request.CTX, channel *model.Ch
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.Update(ctx, channel)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2535,11 +2526,10 @@ func (s *OpenTracingLayerChannelStore) UpdateLastViewedAt(channelIds []string, u
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.UpdateLastViewedAt(channelIds, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2553,11 +2543,10 @@ func (s *OpenTracingLayerChannelStore) UpdateLastViewedAtPost(unreadPost *model.
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.UpdateLastViewedAtPost(unreadPost, userID, mentionCount, mentionCountRoot, urgentMentionCount, setUnreadCountRoot)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2571,11 +2560,10 @@ func (s *OpenTracingLayerChannelStore) UpdateMember(ctx request.CTX, member *mod
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.UpdateMember(ctx, member)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2589,11 +2577,10 @@ func (s *OpenTracingLayerChannelStore) UpdateMemberNotifyProps(channelID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.UpdateMemberNotifyProps(channelID, userID, props)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2607,11 +2594,10 @@ func (s *OpenTracingLayerChannelStore) UpdateMembersRole(channelID string, userI
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.UpdateMembersRole(channelID, userIDs)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2625,11 +2611,10 @@ func (s *OpenTracingLayerChannelStore) UpdateMultipleMembers(members []*model.Ch
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.UpdateMultipleMembers(members)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2643,11 +2628,10 @@ func (s *OpenTracingLayerChannelStore) UpdateSidebarCategories(userID string, te
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, resultVar1, err := s.ChannelStore.UpdateSidebarCategories(userID, teamID, categories)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, resultVar1, err
@@ -2661,11 +2645,10 @@ func (s *OpenTracingLayerChannelStore) UpdateSidebarCategoryOrder(userID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.UpdateSidebarCategoryOrder(userID, teamID, categoryOrder)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2679,11 +2662,10 @@ func (s *OpenTracingLayerChannelStore) UpdateSidebarChannelCategoryOnMove(channe
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.UpdateSidebarChannelCategoryOnMove(channel, newTeamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2697,11 +2679,10 @@ func (s *OpenTracingLayerChannelStore) UpdateSidebarChannelsByPreferences(prefer
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelStore.UpdateSidebarChannelsByPreferences(preferences)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2715,11 +2696,10 @@ func (s *OpenTracingLayerChannelStore) UserBelongsToChannels(userID string, chan
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelStore.UserBelongsToChannels(userID, channelIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2733,11 +2713,10 @@ func (s *OpenTracingLayerChannelBookmarkStore) Delete(bookmarkID string, deleteF
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelBookmarkStore.Delete(bookmarkID, deleteFile)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2751,11 +2730,10 @@ func (s *OpenTracingLayerChannelBookmarkStore) ErrorIfBookmarkFileInfoAlreadyAtt
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelBookmarkStore.ErrorIfBookmarkFileInfoAlreadyAttached(fileID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2769,11 +2747,10 @@ func (s *OpenTracingLayerChannelBookmarkStore) Get(Id string, includeDeleted boo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelBookmarkStore.Get(Id, includeDeleted)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2787,11 +2764,10 @@ func (s *OpenTracingLayerChannelBookmarkStore) GetBookmarksForChannelSince(chann
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelBookmarkStore.GetBookmarksForChannelSince(channelID, since)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2805,11 +2781,10 @@ func (s *OpenTracingLayerChannelBookmarkStore) Save(bookmark *model.ChannelBookm
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelBookmarkStore.Save(bookmark, increaseSortOrder)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2823,11 +2798,10 @@ func (s *OpenTracingLayerChannelBookmarkStore) Update(bookmark *model.ChannelBoo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelBookmarkStore.Update(bookmark)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2841,11 +2815,10 @@ func (s *OpenTracingLayerChannelBookmarkStore) UpdateSortOrder(bookmarkID string
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelBookmarkStore.UpdateSortOrder(bookmarkID, channelID, newIndex)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2859,11 +2832,10 @@ func (s *OpenTracingLayerChannelMemberHistoryStore) DeleteOrphanedRows(limit int
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelMemberHistoryStore.DeleteOrphanedRows(limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2877,11 +2849,10 @@ func (s *OpenTracingLayerChannelMemberHistoryStore) GetChannelsLeftSince(userID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelMemberHistoryStore.GetChannelsLeftSince(userID, since)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2895,11 +2866,10 @@ func (s *OpenTracingLayerChannelMemberHistoryStore) GetUsersInChannelDuring(star
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2913,11 +2883,10 @@ func (s *OpenTracingLayerChannelMemberHistoryStore) LogJoinEvent(userID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelMemberHistoryStore.LogJoinEvent(userID, channelID, joinTime)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2931,11 +2900,10 @@ func (s *OpenTracingLayerChannelMemberHistoryStore) LogLeaveEvent(userID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ChannelMemberHistoryStore.LogLeaveEvent(userID, channelID, leaveTime)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -2949,11 +2917,10 @@ func (s *OpenTracingLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -2967,11 +2934,10 @@ func (s *OpenTracingLayerChannelMemberHistoryStore) PermanentDeleteBatchForReten
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, resultVar1, err := s.ChannelMemberHistoryStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, resultVar1, err
@@ -2985,11 +2951,10 @@ func (s *OpenTracingLayerClusterDiscoveryStore) Cleanup() error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ClusterDiscoveryStore.Cleanup()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3003,11 +2968,10 @@ func (s *OpenTracingLayerClusterDiscoveryStore) Delete(discovery *model.ClusterD
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ClusterDiscoveryStore.Delete(discovery)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3021,11 +2985,10 @@ func (s *OpenTracingLayerClusterDiscoveryStore) Exists(discovery *model.ClusterD
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ClusterDiscoveryStore.Exists(discovery)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3039,11 +3002,10 @@ func (s *OpenTracingLayerClusterDiscoveryStore) GetAll(discoveryType string, clu
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3057,11 +3019,10 @@ func (s *OpenTracingLayerClusterDiscoveryStore) Save(discovery *model.ClusterDis
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ClusterDiscoveryStore.Save(discovery)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3075,11 +3036,10 @@ func (s *OpenTracingLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.C
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ClusterDiscoveryStore.SetLastPingAt(discovery)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3093,11 +3053,10 @@ func (s *OpenTracingLayerCommandStore) AnalyticsCommandCount(teamID string) (int
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.CommandStore.AnalyticsCommandCount(teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3111,11 +3070,10 @@ func (s *OpenTracingLayerCommandStore) Delete(commandID string, timestamp int64)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.CommandStore.Delete(commandID, timestamp)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3129,11 +3087,10 @@ func (s *OpenTracingLayerCommandStore) Get(id string) (*model.Command, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.CommandStore.Get(id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3147,11 +3104,10 @@ func (s *OpenTracingLayerCommandStore) GetByTeam(teamID string) ([]*model.Comman
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.CommandStore.GetByTeam(teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3165,11 +3121,10 @@ func (s *OpenTracingLayerCommandStore) GetByTrigger(teamID string, trigger strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.CommandStore.GetByTrigger(teamID, trigger)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3183,11 +3138,10 @@ func (s *OpenTracingLayerCommandStore) PermanentDeleteByTeam(teamID string) erro
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.CommandStore.PermanentDeleteByTeam(teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3201,11 +3155,10 @@ func (s *OpenTracingLayerCommandStore) PermanentDeleteByUser(userID string) erro
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.CommandStore.PermanentDeleteByUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3219,11 +3172,10 @@ func (s *OpenTracingLayerCommandStore) Save(webhook *model.Command) (*model.Comm
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.CommandStore.Save(webhook)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3237,11 +3189,10 @@ func (s *OpenTracingLayerCommandStore) Update(hook *model.Command) (*model.Comma
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.CommandStore.Update(hook)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3255,7 +3206,7 @@ func (s *OpenTracingLayerCommandWebhookStore) Cleanup() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.CommandWebhookStore.Cleanup()
 
 }
@@ -3268,11 +3219,10 @@ func (s *OpenTracingLayerCommandWebhookStore) Get(id string) (*model.CommandWebh
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.CommandWebhookStore.Get(id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3286,11 +3236,10 @@ func (s *OpenTracingLayerCommandWebhookStore) Save(webhook *model.CommandWebhook
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.CommandWebhookStore.Save(webhook)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3304,11 +3253,10 @@ func (s *OpenTracingLayerCommandWebhookStore) TryUse(id string, limit int) error
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.CommandWebhookStore.TryUse(id, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3322,11 +3270,10 @@ func (s *OpenTracingLayerComplianceStore) ComplianceExport(compliance *model.Com
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, resultVar1, err := s.ComplianceStore.ComplianceExport(compliance, cursor, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, resultVar1, err
@@ -3340,11 +3287,10 @@ func (s *OpenTracingLayerComplianceStore) Get(id string) (*model.Compliance, err
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ComplianceStore.Get(id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3358,16 +3304,32 @@ func (s *OpenTracingLayerComplianceStore) GetAll(offset int, limit int) (model.C
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ComplianceStore.GetAll(offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
+func (s *OpenTracingLayerComplianceStore) LegalHoldMessageExport(c request.CTX, legalHoldID string, cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ComplianceStore.LegalHoldMessageExport")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, resultVar1, err := s.ComplianceStore.LegalHoldMessageExport(c, legalHoldID, cursor, limit)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, resultVar1, err
+}
+
 func (s *OpenTracingLayerComplianceStore) MessageExport(c request.CTX, cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ComplianceStore.MessageExport")
@@ -3376,11 +3338,10 @@ func (s *OpenTracingLayerComplianceStore) MessageExport(c request.CTX, cursor mo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, resultVar1, err := s.ComplianceStore.MessageExport(c, cursor, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, resultVar1, err
@@ -3394,11 +3355,10 @@ func (s *OpenTracingLayerComplianceStore) Save(compliance *model.Compliance) (*m
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ComplianceStore.Save(compliance)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3412,11 +3372,10 @@ func (s *OpenTracingLayerComplianceStore) Update(compliance *model.Compliance) (
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ComplianceStore.Update(compliance)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3430,11 +3389,10 @@ func (s *OpenTracingLayerDesktopTokensStore) Delete(token string) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.DesktopTokensStore.Delete(token)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3448,11 +3406,10 @@ func (s *OpenTracingLayerDesktopTokensStore) DeleteByUserId(userID string) error
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.DesktopTokensStore.DeleteByUserId(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3466,11 +3423,10 @@ func (s *OpenTracingLayerDesktopTokensStore) DeleteOlderThan(minCreatedAt int64)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.DesktopTokensStore.DeleteOlderThan(minCreatedAt)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3484,11 +3440,10 @@ func (s *OpenTracingLayerDesktopTokensStore) GetUserId(token string, minCreatedA
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.DesktopTokensStore.GetUserId(token, minCreatedAt)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3502,11 +3457,10 @@ func (s *OpenTracingLayerDesktopTokensStore) Insert(token string, createAt int64
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.DesktopTokensStore.Insert(token, createAt, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3520,11 +3474,10 @@ func (s *OpenTracingLayerDraftStore) Delete(userID string, channelID string, roo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.DraftStore.Delete(userID, channelID, rootID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3538,11 +3491,10 @@ func (s *OpenTracingLayerDraftStore) DeleteDraftsAssociatedWithPost(channelID st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.DraftStore.DeleteDraftsAssociatedWithPost(channelID, rootID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3556,11 +3508,10 @@ func (s *OpenTracingLayerDraftStore) DeleteEmptyDraftsByCreateAtAndUserId(create
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.DraftStore.DeleteEmptyDraftsByCreateAtAndUserId(createAt, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3574,11 +3525,10 @@ func (s *OpenTracingLayerDraftStore) DeleteOrphanDraftsByCreateAtAndUserId(creat
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.DraftStore.DeleteOrphanDraftsByCreateAtAndUserId(createAt, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3592,11 +3542,10 @@ func (s *OpenTracingLayerDraftStore) Get(userID string, channelID string, rootID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.DraftStore.Get(userID, channelID, rootID, includeDeleted)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3610,11 +3559,10 @@ func (s *OpenTracingLayerDraftStore) GetDraftsForUser(userID string, teamID stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.DraftStore.GetDraftsForUser(userID, teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3628,11 +3576,10 @@ func (s *OpenTracingLayerDraftStore) GetLastCreateAtAndUserIdValuesForEmptyDraft
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, resultVar1, err := s.DraftStore.GetLastCreateAtAndUserIdValuesForEmptyDraftsMigration(createAt, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, resultVar1, err
@@ -3646,11 +3593,10 @@ func (s *OpenTracingLayerDraftStore) Upsert(d *model.Draft) (*model.Draft, error
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.DraftStore.Upsert(d)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3664,11 +3610,10 @@ func (s *OpenTracingLayerEmojiStore) Delete(emoji *model.Emoji, timestamp int64)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.EmojiStore.Delete(emoji, timestamp)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3682,11 +3627,10 @@ func (s *OpenTracingLayerEmojiStore) Get(c request.CTX, id string, allowFromCach
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.EmojiStore.Get(c, id, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3700,11 +3644,10 @@ func (s *OpenTracingLayerEmojiStore) GetByName(c request.CTX, name string, allow
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.EmojiStore.GetByName(c, name, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3718,11 +3661,10 @@ func (s *OpenTracingLayerEmojiStore) GetList(offset int, limit int, sort string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.EmojiStore.GetList(offset, limit, sort)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3736,11 +3678,10 @@ func (s *OpenTracingLayerEmojiStore) GetMultipleByName(c request.CTX, names []st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.EmojiStore.GetMultipleByName(c, names)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3754,11 +3695,10 @@ func (s *OpenTracingLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, err
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.EmojiStore.Save(emoji)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3772,11 +3712,10 @@ func (s *OpenTracingLayerEmojiStore) Search(name string, prefixOnly bool, limit
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.EmojiStore.Search(name, prefixOnly, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3790,11 +3729,10 @@ func (s *OpenTracingLayerFileInfoStore) AttachToPost(c request.CTX, fileID strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.FileInfoStore.AttachToPost(c, fileID, postID, channelID, creatorID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -3808,7 +3746,7 @@ func (s *OpenTracingLayerFileInfoStore) ClearCaches() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.FileInfoStore.ClearCaches()
 
 }
@@ -3821,11 +3759,10 @@ func (s *OpenTracingLayerFileInfoStore) CountAll() (int64, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.CountAll()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3839,11 +3776,10 @@ func (s *OpenTracingLayerFileInfoStore) DeleteForPost(c request.CTX, postID stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.DeleteForPost(c, postID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3857,11 +3793,10 @@ func (s *OpenTracingLayerFileInfoStore) Get(id string) (*model.FileInfo, error)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.Get(id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3875,11 +3810,10 @@ func (s *OpenTracingLayerFileInfoStore) GetByIds(ids []string) ([]*model.FileInf
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.GetByIds(ids)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3893,11 +3827,10 @@ func (s *OpenTracingLayerFileInfoStore) GetByPath(path string) (*model.FileInfo,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.GetByPath(path)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3911,11 +3844,10 @@ func (s *OpenTracingLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.GetFilesBatchForIndexing(startTime, startFileID, includeDeleted, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3929,11 +3861,10 @@ func (s *OpenTracingLayerFileInfoStore) GetForPost(postID string, readFromMaster
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.GetForPost(postID, readFromMaster, includeDeleted, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3947,11 +3878,10 @@ func (s *OpenTracingLayerFileInfoStore) GetForUser(userID string) ([]*model.File
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.GetForUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3965,11 +3895,10 @@ func (s *OpenTracingLayerFileInfoStore) GetFromMaster(id string) (*model.FileInf
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.GetFromMaster(id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -3983,11 +3912,10 @@ func (s *OpenTracingLayerFileInfoStore) GetStorageUsage(allowFromCache bool, inc
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.GetStorageUsage(allowFromCache, includeDeleted)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4001,11 +3929,10 @@ func (s *OpenTracingLayerFileInfoStore) GetUptoNSizeFileTime(n int64) (int64, er
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.GetUptoNSizeFileTime(n)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4019,11 +3946,10 @@ func (s *OpenTracingLayerFileInfoStore) GetWithOptions(page int, perPage int, op
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.GetWithOptions(page, perPage, opt)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4037,7 +3963,7 @@ func (s *OpenTracingLayerFileInfoStore) InvalidateFileInfosForPostCache(postID s
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.FileInfoStore.InvalidateFileInfosForPostCache(postID, deleted)
 
 }
@@ -4050,11 +3976,10 @@ func (s *OpenTracingLayerFileInfoStore) PermanentDelete(c request.CTX, fileID st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.FileInfoStore.PermanentDelete(c, fileID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -4068,11 +3993,10 @@ func (s *OpenTracingLayerFileInfoStore) PermanentDeleteBatch(ctx request.CTX, en
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.PermanentDeleteBatch(ctx, endTime, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4086,11 +4010,10 @@ func (s *OpenTracingLayerFileInfoStore) PermanentDeleteByUser(ctx request.CTX, u
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.PermanentDeleteByUser(ctx, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4104,11 +4027,10 @@ func (s *OpenTracingLayerFileInfoStore) PermanentDeleteForPost(rctx request.CTX,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.FileInfoStore.PermanentDeleteForPost(rctx, postID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -4122,11 +4044,10 @@ func (s *OpenTracingLayerFileInfoStore) Save(ctx request.CTX, info *model.FileIn
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.Save(ctx, info)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4140,11 +4061,10 @@ func (s *OpenTracingLayerFileInfoStore) Search(ctx request.CTX, paramsList []*mo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.Search(ctx, paramsList, userID, teamID, page, perPage)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4158,11 +4078,27 @@ func (s *OpenTracingLayerFileInfoStore) SetContent(ctx request.CTX, fileID strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.FileInfoStore.SetContent(ctx, fileID, content)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerFileInfoStore) UpdateScanStatus(fileID string, scanStatus string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "FileInfoStore.UpdateScanStatus")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.FileInfoStore.UpdateScanStatus(fileID, scanStatus)
+	if err != nil {
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -4176,11 +4112,10 @@ func (s *OpenTracingLayerFileInfoStore) Upsert(rctx request.CTX, info *model.Fil
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.FileInfoStore.Upsert(rctx, info)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4194,11 +4129,10 @@ func (s *OpenTracingLayerGroupStore) AdminRoleGroupsForSyncableMember(userID str
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.AdminRoleGroupsForSyncableMember(userID, syncableID, syncableType)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4212,11 +4146,10 @@ func (s *OpenTracingLayerGroupStore) ChannelMembersMinusGroupMembers(channelID s
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4230,11 +4163,10 @@ func (s *OpenTracingLayerGroupStore) ChannelMembersToAdd(since int64, channelID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.ChannelMembersToAdd(since, channelID, includeRemovedMembers)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4248,11 +4180,10 @@ func (s *OpenTracingLayerGroupStore) ChannelMembersToRemove(channelID *string) (
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.ChannelMembersToRemove(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4266,11 +4197,10 @@ func (s *OpenTracingLayerGroupStore) CountChannelMembersMinusGroupMembers(channe
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4284,11 +4214,10 @@ func (s *OpenTracingLayerGroupStore) CountGroupsByChannel(channelID string, opts
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.CountGroupsByChannel(channelID, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4302,11 +4231,10 @@ func (s *OpenTracingLayerGroupStore) CountGroupsByTeam(teamID string, opts model
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.CountGroupsByTeam(teamID, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4320,11 +4248,10 @@ func (s *OpenTracingLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.CountTeamMembersMinusGroupMembers(teamID, groupIDs)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4338,11 +4265,10 @@ func (s *OpenTracingLayerGroupStore) Create(group *model.Group) (*model.Group, e
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.Create(group)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4356,11 +4282,10 @@ func (s *OpenTracingLayerGroupStore) CreateGroupSyncable(groupSyncable *model.Gr
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.CreateGroupSyncable(groupSyncable)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4374,11 +4299,10 @@ func (s *OpenTracingLayerGroupStore) CreateWithUserIds(group *model.GroupWithUse
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.CreateWithUserIds(group)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4392,11 +4316,10 @@ func (s *OpenTracingLayerGroupStore) Delete(groupID string) (*model.Group, error
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.Delete(groupID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4410,11 +4333,10 @@ func (s *OpenTracingLayerGroupStore) DeleteGroupSyncable(groupID string, syncabl
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4428,11 +4350,10 @@ func (s *OpenTracingLayerGroupStore) DeleteMember(groupID string, userID string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.DeleteMember(groupID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4446,11 +4367,10 @@ func (s *OpenTracingLayerGroupStore) DeleteMembers(groupID string, userIDs []str
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.DeleteMembers(groupID, userIDs)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4464,11 +4384,10 @@ func (s *OpenTracingLayerGroupStore) DistinctGroupMemberCount() (int64, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.DistinctGroupMemberCount()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4482,11 +4401,10 @@ func (s *OpenTracingLayerGroupStore) DistinctGroupMemberCountForSource(source mo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.DistinctGroupMemberCountForSource(source)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4500,11 +4418,10 @@ func (s *OpenTracingLayerGroupStore) Get(groupID string) (*model.Group, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.Get(groupID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4518,11 +4435,10 @@ func (s *OpenTracingLayerGroupStore) GetAllBySource(groupSource model.GroupSourc
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetAllBySource(groupSource)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4536,11 +4452,10 @@ func (s *OpenTracingLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetAllGroupSyncablesByGroupId(groupID, syncableType)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4554,11 +4469,10 @@ func (s *OpenTracingLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetByIDs(groupIDs)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4572,11 +4486,10 @@ func (s *OpenTracingLayerGroupStore) GetByName(name string, opts model.GroupSear
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetByName(name, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4590,11 +4503,10 @@ func (s *OpenTracingLayerGroupStore) GetByRemoteID(remoteID string, groupSource
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetByRemoteID(remoteID, groupSource)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4608,11 +4520,10 @@ func (s *OpenTracingLayerGroupStore) GetByUser(userID string) ([]*model.Group, e
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetByUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4626,11 +4537,10 @@ func (s *OpenTracingLayerGroupStore) GetGroupSyncable(groupID string, syncableID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetGroupSyncable(groupID, syncableID, syncableType)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4644,11 +4554,10 @@ func (s *OpenTracingLayerGroupStore) GetGroups(page int, perPage int, opts model
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetGroups(page, perPage, opts, viewRestrictions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4662,11 +4571,10 @@ func (s *OpenTracingLayerGroupStore) GetGroupsAssociatedToChannelsByTeam(teamID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetGroupsAssociatedToChannelsByTeam(teamID, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4680,11 +4588,10 @@ func (s *OpenTracingLayerGroupStore) GetGroupsByChannel(channelID string, opts m
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetGroupsByChannel(channelID, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4698,11 +4605,10 @@ func (s *OpenTracingLayerGroupStore) GetGroupsByTeam(teamID string, opts model.G
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetGroupsByTeam(teamID, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4716,11 +4622,10 @@ func (s *OpenTracingLayerGroupStore) GetMember(groupID string, userID string) (*
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetMember(groupID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4734,11 +4639,10 @@ func (s *OpenTracingLayerGroupStore) GetMemberCount(groupID string) (int64, erro
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetMemberCount(groupID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4752,11 +4656,10 @@ func (s *OpenTracingLayerGroupStore) GetMemberCountWithRestrictions(groupID stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetMemberCountWithRestrictions(groupID, viewRestrictions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4770,11 +4673,10 @@ func (s *OpenTracingLayerGroupStore) GetMemberUsers(groupID string) ([]*model.Us
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetMemberUsers(groupID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4788,11 +4690,10 @@ func (s *OpenTracingLayerGroupStore) GetMemberUsersInTeam(groupID string, teamID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetMemberUsersInTeam(groupID, teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4806,11 +4707,10 @@ func (s *OpenTracingLayerGroupStore) GetMemberUsersNotInChannel(groupID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetMemberUsersNotInChannel(groupID, channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4824,11 +4724,10 @@ func (s *OpenTracingLayerGroupStore) GetMemberUsersPage(groupID string, page int
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetMemberUsersPage(groupID, page, perPage, viewRestrictions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4842,11 +4741,10 @@ func (s *OpenTracingLayerGroupStore) GetMemberUsersSortedPage(groupID string, pa
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetMemberUsersSortedPage(groupID, page, perPage, viewRestrictions, teammateNameDisplay)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4860,11 +4758,10 @@ func (s *OpenTracingLayerGroupStore) GetNonMemberUsersPage(groupID string, page
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GetNonMemberUsersPage(groupID, page, perPage, viewRestrictions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4878,11 +4775,10 @@ func (s *OpenTracingLayerGroupStore) GroupChannelCount() (int64, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GroupChannelCount()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4896,11 +4792,10 @@ func (s *OpenTracingLayerGroupStore) GroupCount() (int64, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GroupCount()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4914,11 +4809,10 @@ func (s *OpenTracingLayerGroupStore) GroupCountBySource(source model.GroupSource
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GroupCountBySource(source)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4932,11 +4826,10 @@ func (s *OpenTracingLayerGroupStore) GroupCountWithAllowReference() (int64, erro
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GroupCountWithAllowReference()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4950,11 +4843,10 @@ func (s *OpenTracingLayerGroupStore) GroupMemberCount() (int64, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GroupMemberCount()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4968,11 +4860,10 @@ func (s *OpenTracingLayerGroupStore) GroupTeamCount() (int64, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.GroupTeamCount()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -4986,11 +4877,10 @@ func (s *OpenTracingLayerGroupStore) PermanentDeleteMembersByUser(userID string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.GroupStore.PermanentDeleteMembersByUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -5004,11 +4894,10 @@ func (s *OpenTracingLayerGroupStore) PermittedSyncableAdmins(syncableID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.PermittedSyncableAdmins(syncableID, syncableType)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5022,11 +4911,10 @@ func (s *OpenTracingLayerGroupStore) Restore(groupID string) (*model.Group, erro
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.Restore(groupID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5040,11 +4928,10 @@ func (s *OpenTracingLayerGroupStore) TeamMembersMinusGroupMembers(teamID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5058,11 +4945,10 @@ func (s *OpenTracingLayerGroupStore) TeamMembersToAdd(since int64, teamID *strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.TeamMembersToAdd(since, teamID, includeRemovedMembers)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5076,11 +4962,10 @@ func (s *OpenTracingLayerGroupStore) TeamMembersToRemove(teamID *string) ([]*mod
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.TeamMembersToRemove(teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5094,11 +4979,10 @@ func (s *OpenTracingLayerGroupStore) Update(group *model.Group) (*model.Group, e
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.Update(group)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5112,11 +4996,10 @@ func (s *OpenTracingLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.Gr
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.UpdateGroupSyncable(groupSyncable)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5130,11 +5013,10 @@ func (s *OpenTracingLayerGroupStore) UpsertMember(groupID string, userID string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.UpsertMember(groupID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5148,16 +5030,49 @@ func (s *OpenTracingLayerGroupStore) UpsertMembers(groupID string, userIDs []str
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.GroupStore.UpsertMembers(groupID, userIDs)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerImportIdMappingStore) GetNewIds(objectType string, oldIDs []string) (map[string]string, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ImportIdMappingStore.GetNewIds")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.ImportIdMappingStore.GetNewIds(objectType, oldIDs)
+	if err != nil {
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
+func (s *OpenTracingLayerImportIdMappingStore) SaveMultiple(mappings []*model.ImportIdMapping) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ImportIdMappingStore.SaveMultiple")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.ImportIdMappingStore.SaveMultiple(mappings)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
 func (s *OpenTracingLayerJobStore) Cleanup(expiryTime int64, batchSize int) error {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "JobStore.Cleanup")
@@ -5166,11 +5081,10 @@ func (s *OpenTracingLayerJobStore) Cleanup(expiryTime int64, batchSize int) erro
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.JobStore.Cleanup(expiryTime, batchSize)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -5184,11 +5098,10 @@ func (s *OpenTracingLayerJobStore) Delete(id string) (string, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.JobStore.Delete(id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5202,11 +5115,10 @@ func (s *OpenTracingLayerJobStore) Get(c request.CTX, id string) (*model.Job, er
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.JobStore.Get(c, id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5220,11 +5132,10 @@ func (s *OpenTracingLayerJobStore) GetAllByStatus(c request.CTX, status string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.JobStore.GetAllByStatus(c, status)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5238,11 +5149,10 @@ func (s *OpenTracingLayerJobStore) GetAllByType(c request.CTX, jobType string) (
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.JobStore.GetAllByType(c, jobType)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5256,11 +5166,10 @@ func (s *OpenTracingLayerJobStore) GetAllByTypeAndStatus(c request.CTX, jobType
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.JobStore.GetAllByTypeAndStatus(c, jobType, status)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5274,11 +5183,10 @@ func (s *OpenTracingLayerJobStore) GetAllByTypeAndStatusPage(c request.CTX, jobT
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.JobStore.GetAllByTypeAndStatusPage(c, jobType, status, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5292,11 +5200,10 @@ func (s *OpenTracingLayerJobStore) GetAllByTypePage(c request.CTX, jobType strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.JobStore.GetAllByTypePage(c, jobType, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5310,11 +5217,10 @@ func (s *OpenTracingLayerJobStore) GetAllByTypesPage(c request.CTX, jobTypes []s
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.JobStore.GetAllByTypesPage(c, jobTypes, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5328,11 +5234,10 @@ func (s *OpenTracingLayerJobStore) GetCountByStatusAndType(status string, jobTyp
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.JobStore.GetCountByStatusAndType(status, jobType)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5346,11 +5251,10 @@ func (s *OpenTracingLayerJobStore) GetNewestJobByStatusAndType(status string, jo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5364,11 +5268,10 @@ func (s *OpenTracingLayerJobStore) GetNewestJobByStatusesAndType(statuses []stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.JobStore.GetNewestJobByStatusesAndType(statuses, jobType)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5382,11 +5285,10 @@ func (s *OpenTracingLayerJobStore) Save(job *model.Job) (*model.Job, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.JobStore.Save(job)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5400,11 +5302,10 @@ func (s *OpenTracingLayerJobStore) SaveOnce(job *model.Job) (*model.Job, error)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.JobStore.SaveOnce(job)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5418,11 +5319,10 @@ func (s *OpenTracingLayerJobStore) UpdateOptimistically(job *model.Job, currentS
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.JobStore.UpdateOptimistically(job, currentStatus)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5436,11 +5336,10 @@ func (s *OpenTracingLayerJobStore) UpdateStatus(id string, status string) (*mode
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.JobStore.UpdateStatus(id, status)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5454,11 +5353,129 @@ func (s *OpenTracingLayerJobStore) UpdateStatusOptimistically(id string, current
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerLegalHoldStore) Get(holdID string) (*model.LegalHold, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "LegalHoldStore.Get")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.LegalHoldStore.Get(holdID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerLegalHoldStore) GetAll(offset int, limit int, includeReleased bool) ([]*model.LegalHold, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "LegalHoldStore.GetAll")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.LegalHoldStore.GetAll(offset, limit, includeReleased)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerLegalHoldStore) IsChannelHeld(channelID string) (bool, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "LegalHoldStore.IsChannelHeld")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.LegalHoldStore.IsChannelHeld(channelID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerLegalHoldStore) IsUserHeld(userID string) (bool, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "LegalHoldStore.IsUserHeld")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.LegalHoldStore.IsUserHeld(userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerLegalHoldStore) Release(holdID string, releaseAt int64) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "LegalHoldStore.Release")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.LegalHoldStore.Release(holdID, releaseAt)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerLegalHoldStore) Save(hold *model.LegalHold) (*model.LegalHold, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "LegalHoldStore.Save")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.LegalHoldStore.Save(hold)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerLegalHoldStore) Update(hold *model.LegalHold) (*model.LegalHold, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "LegalHoldStore.Update")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.LegalHoldStore.Update(hold)
+	if err != nil {
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5472,11 +5489,10 @@ func (s *OpenTracingLayerLicenseStore) Get(c request.CTX, id string) (*model.Lic
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.LicenseStore.Get(c, id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5490,11 +5506,10 @@ func (s *OpenTracingLayerLicenseStore) GetAll() ([]*model.LicenseRecord, error)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.LicenseStore.GetAll()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5508,11 +5523,10 @@ func (s *OpenTracingLayerLicenseStore) Save(license *model.LicenseRecord) error
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.LicenseStore.Save(license)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -5526,11 +5540,10 @@ func (s *OpenTracingLayerLinkMetadataStore) Get(url string, timestamp int64) (*m
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.LinkMetadataStore.Get(url, timestamp)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5544,11 +5557,78 @@ func (s *OpenTracingLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadat
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.LinkMetadataStore.Save(linkMetadata)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerMfaRecoveryCodeStore) CountUnused(userID string) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "MfaRecoveryCodeStore.CountUnused")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.MfaRecoveryCodeStore.CountUnused(userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerMfaRecoveryCodeStore) DeleteForUser(userID string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "MfaRecoveryCodeStore.DeleteForUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.MfaRecoveryCodeStore.DeleteForUser(userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerMfaRecoveryCodeStore) SaveForUser(userID string, codeHashes []string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "MfaRecoveryCodeStore.SaveForUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.MfaRecoveryCodeStore.SaveForUser(userID, codeHashes)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerMfaRecoveryCodeStore) Use(userID string, codeHash string) (bool, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "MfaRecoveryCodeStore.Use")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.MfaRecoveryCodeStore.Use(userID, codeHash)
+	if err != nil {
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5562,11 +5642,10 @@ func (s *OpenTracingLayerNotifyAdminStore) DeleteBefore(trial bool, now int64) e
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.NotifyAdminStore.DeleteBefore(trial, now)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -5580,11 +5659,10 @@ func (s *OpenTracingLayerNotifyAdminStore) Get(trial bool) ([]*model.NotifyAdmin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.NotifyAdminStore.Get(trial)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5598,11 +5676,10 @@ func (s *OpenTracingLayerNotifyAdminStore) GetDataByUserIdAndFeature(userID stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.NotifyAdminStore.GetDataByUserIdAndFeature(userID, feature)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5616,11 +5693,10 @@ func (s *OpenTracingLayerNotifyAdminStore) Save(data *model.NotifyAdminData) (*m
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.NotifyAdminStore.Save(data)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5634,11 +5710,10 @@ func (s *OpenTracingLayerNotifyAdminStore) Update(userID string, requiredPlan st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.NotifyAdminStore.Update(userID, requiredPlan, requiredFeature, now)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -5652,11 +5727,10 @@ func (s *OpenTracingLayerOAuthStore) DeleteApp(id string) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.OAuthStore.DeleteApp(id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -5670,11 +5744,10 @@ func (s *OpenTracingLayerOAuthStore) GetAccessData(token string) (*model.AccessD
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OAuthStore.GetAccessData(token)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5688,11 +5761,10 @@ func (s *OpenTracingLayerOAuthStore) GetAccessDataByRefreshToken(token string) (
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OAuthStore.GetAccessDataByRefreshToken(token)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5706,11 +5778,10 @@ func (s *OpenTracingLayerOAuthStore) GetAccessDataByUserForApp(userID string, cl
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OAuthStore.GetAccessDataByUserForApp(userID, clientID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5724,11 +5795,10 @@ func (s *OpenTracingLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OAuthStore.GetApp(id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5742,11 +5812,10 @@ func (s *OpenTracingLayerOAuthStore) GetAppByUser(userID string, offset int, lim
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OAuthStore.GetAppByUser(userID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5760,11 +5829,10 @@ func (s *OpenTracingLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OA
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OAuthStore.GetApps(offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5778,11 +5846,10 @@ func (s *OpenTracingLayerOAuthStore) GetAuthData(code string) (*model.AuthData,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OAuthStore.GetAuthData(code)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5796,11 +5863,10 @@ func (s *OpenTracingLayerOAuthStore) GetAuthorizedApps(userID string, offset int
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OAuthStore.GetAuthorizedApps(userID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5814,11 +5880,10 @@ func (s *OpenTracingLayerOAuthStore) GetPreviousAccessData(userID string, client
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OAuthStore.GetPreviousAccessData(userID, clientID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5832,11 +5897,10 @@ func (s *OpenTracingLayerOAuthStore) PermanentDeleteAuthDataByUser(userID string
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.OAuthStore.PermanentDeleteAuthDataByUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -5850,11 +5914,10 @@ func (s *OpenTracingLayerOAuthStore) RemoveAccessData(token string) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.OAuthStore.RemoveAccessData(token)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -5868,11 +5931,10 @@ func (s *OpenTracingLayerOAuthStore) RemoveAllAccessData() error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.OAuthStore.RemoveAllAccessData()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -5886,11 +5948,10 @@ func (s *OpenTracingLayerOAuthStore) RemoveAuthData(code string) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.OAuthStore.RemoveAuthData(code)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -5904,11 +5965,10 @@ func (s *OpenTracingLayerOAuthStore) RemoveAuthDataByClientId(clientID string, u
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.OAuthStore.RemoveAuthDataByClientId(clientID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -5922,11 +5982,10 @@ func (s *OpenTracingLayerOAuthStore) RemoveAuthDataByUserId(userID string) error
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.OAuthStore.RemoveAuthDataByUserId(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -5940,11 +5999,10 @@ func (s *OpenTracingLayerOAuthStore) SaveAccessData(accessData *model.AccessData
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OAuthStore.SaveAccessData(accessData)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5958,11 +6016,10 @@ func (s *OpenTracingLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthA
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OAuthStore.SaveApp(app)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5976,11 +6033,10 @@ func (s *OpenTracingLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*mo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OAuthStore.SaveAuthData(authData)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -5994,11 +6050,10 @@ func (s *OpenTracingLayerOAuthStore) UpdateAccessData(accessData *model.AccessDa
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OAuthStore.UpdateAccessData(accessData)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6012,11 +6067,10 @@ func (s *OpenTracingLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAut
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OAuthStore.UpdateApp(app)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6030,11 +6084,10 @@ func (s *OpenTracingLayerOutgoingOAuthConnectionStore) DeleteConnection(c reques
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.OutgoingOAuthConnectionStore.DeleteConnection(c, id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -6048,11 +6101,10 @@ func (s *OpenTracingLayerOutgoingOAuthConnectionStore) GetConnection(c request.C
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OutgoingOAuthConnectionStore.GetConnection(c, id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6066,11 +6118,10 @@ func (s *OpenTracingLayerOutgoingOAuthConnectionStore) GetConnections(c request.
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OutgoingOAuthConnectionStore.GetConnections(c, filters)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6084,11 +6135,10 @@ func (s *OpenTracingLayerOutgoingOAuthConnectionStore) SaveConnection(c request.
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OutgoingOAuthConnectionStore.SaveConnection(c, conn)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6102,140 +6152,251 @@ func (s *OpenTracingLayerOutgoingOAuthConnectionStore) UpdateConnection(c reques
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.OutgoingOAuthConnectionStore.UpdateConnection(c, conn)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+func (s *OpenTracingLayerOutgoingWebhookDeliveryStore) Get(deliveryID string) (*model.OutgoingWebhookDelivery, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PluginStore.CompareAndDelete")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OutgoingWebhookDeliveryStore.Get")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+	defer span.End()
+	result, err := s.OutgoingWebhookDeliveryStore.Get(deliveryID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+func (s *OpenTracingLayerOutgoingWebhookDeliveryStore) GetForHook(hookID string, opts model.OutgoingWebhookDeliveryGetOptions) ([]*model.OutgoingWebhookDelivery, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PluginStore.CompareAndSet")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OutgoingWebhookDeliveryStore.GetForHook")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
+	defer span.End()
+	result, err := s.OutgoingWebhookDeliveryStore.GetForHook(hookID, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerPluginStore) Delete(pluginID string, key string) error {
+func (s *OpenTracingLayerOutgoingWebhookDeliveryStore) GetPending(nextAttemptBefore int64, limit int) ([]*model.OutgoingWebhookDelivery, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PluginStore.Delete")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OutgoingWebhookDeliveryStore.GetPending")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	err := s.PluginStore.Delete(pluginID, key)
+	defer span.End()
+	result, err := s.OutgoingWebhookDeliveryStore.GetPending(nextAttemptBefore, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
-	return err
+	return result, err
 }
 
-func (s *OpenTracingLayerPluginStore) DeleteAllExpired() error {
+func (s *OpenTracingLayerOutgoingWebhookDeliveryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PluginStore.DeleteAllExpired")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OutgoingWebhookDeliveryStore.PermanentDeleteBatch")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	err := s.PluginStore.DeleteAllExpired()
+	defer span.End()
+	result, err := s.OutgoingWebhookDeliveryStore.PermanentDeleteBatch(endTime, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
-	return err
+	return result, err
 }
 
-func (s *OpenTracingLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
+func (s *OpenTracingLayerOutgoingWebhookDeliveryStore) PermanentDeleteByHook(hookID string) error {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PluginStore.DeleteAllForPlugin")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OutgoingWebhookDeliveryStore.PermanentDeleteByHook")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	err := s.PluginStore.DeleteAllForPlugin(PluginID)
+	defer span.End()
+	err := s.OutgoingWebhookDeliveryStore.PermanentDeleteByHook(hookID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
 }
 
-func (s *OpenTracingLayerPluginStore) Get(pluginID string, key string) (*model.PluginKeyValue, error) {
+func (s *OpenTracingLayerOutgoingWebhookDeliveryStore) Save(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PluginStore.Get")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OutgoingWebhookDeliveryStore.Save")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.PluginStore.Get(pluginID, key)
+	defer span.End()
+	result, err := s.OutgoingWebhookDeliveryStore.Save(delivery)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
+func (s *OpenTracingLayerOutgoingWebhookDeliveryStore) Update(delivery *model.OutgoingWebhookDelivery) error {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PluginStore.List")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "OutgoingWebhookDeliveryStore.Update")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.PluginStore.List(pluginID, page, perPage)
+	defer span.End()
+	err := s.OutgoingWebhookDeliveryStore.Update(delivery)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
-	return result, err
+	return err
+}
+
+func (s *OpenTracingLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PluginStore.CompareAndDelete")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PluginStore.CompareAndSet")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPluginStore) Delete(pluginID string, key string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PluginStore.Delete")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.PluginStore.Delete(pluginID, key)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerPluginStore) DeleteAllExpired() error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PluginStore.DeleteAllExpired")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.PluginStore.DeleteAllExpired()
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PluginStore.DeleteAllForPlugin")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.PluginStore.DeleteAllForPlugin(PluginID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerPluginStore) Get(pluginID string, key string) (*model.PluginKeyValue, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PluginStore.Get")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.PluginStore.Get(pluginID, key)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PluginStore.List")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.PluginStore.List(pluginID, page, perPage)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
 }
 
 func (s *OpenTracingLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
@@ -6246,11 +6407,10 @@ func (s *OpenTracingLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PluginStore.SaveOrUpdate(keyVal)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6264,16 +6424,185 @@ func (s *OpenTracingLayerPluginStore) SetWithOptions(pluginID string, key string
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PluginStore.SetWithOptions(pluginID, key, value, options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPollStore) DeleteForPost(postID string, deleteAt int64) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PollStore.DeleteForPost")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.PollStore.DeleteForPost(postID, deleteAt)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerPollStore) Get(pollID string) (*model.Poll, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PollStore.Get")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.PollStore.Get(pollID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPollStore) GetForPost(postID string) (*model.Poll, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PollStore.GetForPost")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.PollStore.GetForPost(postID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPollStore) GetForPosts(postIDs []string) ([]*model.Poll, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PollStore.GetForPosts")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.PollStore.GetForPosts(postIDs)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPollStore) GetVotes(pollID string) ([]*model.PollVote, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PollStore.GetVotes")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.PollStore.GetVotes(pollID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPollStore) GetVotesForPolls(pollIDs []string) ([]*model.PollVote, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PollStore.GetVotesForPolls")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.PollStore.GetVotesForPolls(pollIDs)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerPollStore) PermanentDeleteVotesByUser(userID string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PollStore.PermanentDeleteVotesByUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.PollStore.PermanentDeleteVotesByUser(userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerPollStore) Save(poll *model.Poll) (*model.Poll, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PollStore.Save")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.PollStore.Save(poll)
+	if err != nil {
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
+func (s *OpenTracingLayerPollStore) SaveVotes(pollID string, userID string, optionIDs []string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PollStore.SaveVotes")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.PollStore.SaveVotes(pollID, userID, optionIDs)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerPollStore) Update(poll *model.Poll) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PollStore.Update")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.PollStore.Update(poll)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
 func (s *OpenTracingLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int64, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.AnalyticsPostCount")
@@ -6282,11 +6611,10 @@ func (s *OpenTracingLayerPostStore) AnalyticsPostCount(options *model.PostCountO
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.AnalyticsPostCount(options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6300,11 +6628,10 @@ func (s *OpenTracingLayerPostStore) AnalyticsPostCountsByDay(options *model.Anal
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.AnalyticsPostCountsByDay(options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6318,11 +6645,10 @@ func (s *OpenTracingLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID str
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6336,7 +6662,7 @@ func (s *OpenTracingLayerPostStore) ClearCaches() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.PostStore.ClearCaches()
 
 }
@@ -6349,11 +6675,10 @@ func (s *OpenTracingLayerPostStore) Delete(rctx request.CTX, postID string, time
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.PostStore.Delete(rctx, postID, timestamp, deleteByID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -6367,17 +6692,16 @@ func (s *OpenTracingLayerPostStore) Get(ctx context.Context, id string, opts mod
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.Get(ctx, id, opts, userID, sanitizeOptions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+func (s *OpenTracingLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool, since int64) ([]*model.DirectPostForExport, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetDirectPostParentsForExportAfter")
 	s.Root.Store.SetContext(newCtx)
@@ -6385,11 +6709,10 @@ func (s *OpenTracingLayerPostStore) GetDirectPostParentsForExportAfter(limit int
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, includeArchivedChannels)
+	defer span.End()
+	result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, includeArchivedChannels, since)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6403,11 +6726,10 @@ func (s *OpenTracingLayerPostStore) GetEditHistoryForPost(postID string) ([]*mod
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetEditHistoryForPost(postID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6421,7 +6743,7 @@ func (s *OpenTracingLayerPostStore) GetEtag(channelID string, allowFromCache boo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result := s.PostStore.GetEtag(channelID, allowFromCache, collapsedThreads)
 	return result
 }
@@ -6434,11 +6756,10 @@ func (s *OpenTracingLayerPostStore) GetFlaggedPosts(userID string, offset int, l
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetFlaggedPosts(userID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6452,11 +6773,10 @@ func (s *OpenTracingLayerPostStore) GetFlaggedPostsForChannel(userID string, cha
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetFlaggedPostsForChannel(userID, channelID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6470,19 +6790,18 @@ func (s *OpenTracingLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	span.SetTag("userID", userID)
+	tracing.SetAttribute(span, "userID", userID)
 
-	span.SetTag("teamID", teamID)
+	tracing.SetAttribute(span, "teamID", teamID)
 
-	span.SetTag("offset", offset)
+	tracing.SetAttribute(span, "offset", offset)
 
-	span.SetTag("limit", limit)
+	tracing.SetAttribute(span, "limit", limit)
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetFlaggedPostsForTeam(userID, teamID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6496,7 +6815,7 @@ func (s *OpenTracingLayerPostStore) GetMaxPostSize() int {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result := s.PostStore.GetMaxPostSize()
 	return result
 }
@@ -6509,11 +6828,10 @@ func (s *OpenTracingLayerPostStore) GetNthRecentPostTime(n int64) (int64, error)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetNthRecentPostTime(n)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6527,11 +6845,10 @@ func (s *OpenTracingLayerPostStore) GetOldest() (*model.Post, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetOldest()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6545,17 +6862,16 @@ func (s *OpenTracingLayerPostStore) GetOldestEntityCreationTime() (int64, error)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetOldestEntityCreationTime()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerPostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error) {
+func (s *OpenTracingLayerPostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool, since int64) ([]*model.PostForExport, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.GetParentsForExportAfter")
 	s.Root.Store.SetContext(newCtx)
@@ -6563,11 +6879,10 @@ func (s *OpenTracingLayerPostStore) GetParentsForExportAfter(limit int, afterID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.PostStore.GetParentsForExportAfter(limit, afterID, includeArchivedChannels)
+	defer span.End()
+	result, err := s.PostStore.GetParentsForExportAfter(limit, afterID, includeArchivedChannels, since)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6581,11 +6896,10 @@ func (s *OpenTracingLayerPostStore) GetPostAfterTime(channelID string, timestamp
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetPostAfterTime(channelID, timestamp, collapsedThreads)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6599,11 +6913,10 @@ func (s *OpenTracingLayerPostStore) GetPostIdAfterTime(channelID string, timesta
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetPostIdAfterTime(channelID, timestamp, collapsedThreads)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6617,11 +6930,10 @@ func (s *OpenTracingLayerPostStore) GetPostIdBeforeTime(channelID string, timest
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetPostIdBeforeTime(channelID, timestamp, collapsedThreads)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6635,11 +6947,10 @@ func (s *OpenTracingLayerPostStore) GetPostReminderMetadata(postID string) (*sto
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetPostReminderMetadata(postID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6653,11 +6964,10 @@ func (s *OpenTracingLayerPostStore) GetPostReminders(now int64) ([]*model.PostRe
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetPostReminders(now)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6671,11 +6981,10 @@ func (s *OpenTracingLayerPostStore) GetPosts(options model.GetPostsOptions, allo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetPosts(options, allowFromCache, sanitizeOptions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6689,11 +6998,10 @@ func (s *OpenTracingLayerPostStore) GetPostsAfter(options model.GetPostsOptions,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetPostsAfter(options, sanitizeOptions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6707,11 +7015,10 @@ func (s *OpenTracingLayerPostStore) GetPostsBatchForIndexing(startTime int64, st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetPostsBatchForIndexing(startTime, startPostID, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6725,11 +7032,10 @@ func (s *OpenTracingLayerPostStore) GetPostsBefore(options model.GetPostsOptions
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetPostsBefore(options, sanitizeOptions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6743,11 +7049,10 @@ func (s *OpenTracingLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Po
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetPostsByIds(postIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6761,11 +7066,10 @@ func (s *OpenTracingLayerPostStore) GetPostsByThread(threadID string, since int6
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetPostsByThread(threadID, since)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6779,11 +7083,10 @@ func (s *OpenTracingLayerPostStore) GetPostsCreatedAt(channelID string, timestam
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetPostsCreatedAt(channelID, timestamp)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6797,11 +7100,10 @@ func (s *OpenTracingLayerPostStore) GetPostsSince(options model.GetPostsSinceOpt
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetPostsSince(options, allowFromCache, sanitizeOptions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6815,11 +7117,10 @@ func (s *OpenTracingLayerPostStore) GetPostsSinceForSync(options model.GetPostsS
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, resultVar1, err := s.PostStore.GetPostsSinceForSync(options, cursor, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, resultVar1, err
@@ -6833,11 +7134,10 @@ func (s *OpenTracingLayerPostStore) GetRepliesForExport(parentID string) ([]*mod
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetRepliesForExport(parentID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6851,11 +7151,10 @@ func (s *OpenTracingLayerPostStore) GetSingle(rctx request.CTX, id string, inclD
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.GetSingle(rctx, id, inclDeleted)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6869,11 +7168,10 @@ func (s *OpenTracingLayerPostStore) HasAutoResponsePostByUserSince(options model
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.HasAutoResponsePostByUserSince(options, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6887,7 +7185,7 @@ func (s *OpenTracingLayerPostStore) InvalidateLastPostTimeCache(channelID string
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.PostStore.InvalidateLastPostTimeCache(channelID)
 
 }
@@ -6900,11 +7198,10 @@ func (s *OpenTracingLayerPostStore) Overwrite(rctx request.CTX, post *model.Post
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.Overwrite(rctx, post)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6918,11 +7215,10 @@ func (s *OpenTracingLayerPostStore) OverwriteMultiple(posts []*model.Post) ([]*m
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, resultVar1, err := s.PostStore.OverwriteMultiple(posts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, resultVar1, err
@@ -6936,11 +7232,10 @@ func (s *OpenTracingLayerPostStore) PermanentDelete(rctx request.CTX, postID str
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.PostStore.PermanentDelete(rctx, postID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -6954,11 +7249,10 @@ func (s *OpenTracingLayerPostStore) PermanentDeleteBatch(endTime int64, limit in
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.PermanentDeleteBatch(endTime, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -6972,11 +7266,10 @@ func (s *OpenTracingLayerPostStore) PermanentDeleteBatchForRetentionPolicies(now
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, resultVar1, err := s.PostStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, resultVar1, err
@@ -6990,11 +7283,10 @@ func (s *OpenTracingLayerPostStore) PermanentDeleteByChannel(rctx request.CTX, c
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.PostStore.PermanentDeleteByChannel(rctx, channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7008,16 +7300,32 @@ func (s *OpenTracingLayerPostStore) PermanentDeleteByUser(rctx request.CTX, user
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.PostStore.PermanentDeleteByUser(rctx, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
 }
 
+func (s *OpenTracingLayerPostStore) PermanentDeleteEditHistoryForPost(postID string, keep int) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.PermanentDeleteEditHistoryForPost")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.PostStore.PermanentDeleteEditHistoryForPost(postID, keep)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
 func (s *OpenTracingLayerPostStore) Save(rctx request.CTX, post *model.Post) (*model.Post, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.Save")
@@ -7026,11 +7334,10 @@ func (s *OpenTracingLayerPostStore) Save(rctx request.CTX, post *model.Post) (*m
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.Save(rctx, post)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7044,11 +7351,27 @@ func (s *OpenTracingLayerPostStore) SaveMultiple(posts []*model.Post) ([]*model.
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, resultVar1, err := s.PostStore.SaveMultiple(posts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
+	}
+
+	return result, resultVar1, err
+}
+
+func (s *OpenTracingLayerPostStore) SaveMultipleWithIds(posts []*model.Post) ([]*model.Post, int, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PostStore.SaveMultipleWithIds")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, resultVar1, err := s.PostStore.SaveMultipleWithIds(posts)
+	if err != nil {
+		tracing.RecordError(span, err)
 	}
 
 	return result, resultVar1, err
@@ -7062,11 +7385,10 @@ func (s *OpenTracingLayerPostStore) Search(teamID string, userID string, params
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.Search(teamID, userID, params)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7080,11 +7402,10 @@ func (s *OpenTracingLayerPostStore) SearchPostsForUser(rctx request.CTX, paramsL
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.SearchPostsForUser(rctx, paramsList, userID, teamID, page, perPage)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7098,11 +7419,10 @@ func (s *OpenTracingLayerPostStore) SetPostReminder(reminder *model.PostReminder
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.PostStore.SetPostReminder(reminder)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7116,11 +7436,10 @@ func (s *OpenTracingLayerPostStore) Update(rctx request.CTX, newPost *model.Post
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostStore.Update(rctx, newPost, oldPost)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7134,11 +7453,10 @@ func (s *OpenTracingLayerPostAcknowledgementStore) Delete(acknowledgement *model
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.PostAcknowledgementStore.Delete(acknowledgement)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7152,11 +7470,10 @@ func (s *OpenTracingLayerPostAcknowledgementStore) Get(postID string, userID str
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostAcknowledgementStore.Get(postID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7170,11 +7487,10 @@ func (s *OpenTracingLayerPostAcknowledgementStore) GetForPost(postID string) ([]
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostAcknowledgementStore.GetForPost(postID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7188,11 +7504,10 @@ func (s *OpenTracingLayerPostAcknowledgementStore) GetForPosts(postIds []string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostAcknowledgementStore.GetForPosts(postIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7206,11 +7521,10 @@ func (s *OpenTracingLayerPostAcknowledgementStore) Save(postID string, userID st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostAcknowledgementStore.Save(postID, userID, acknowledgedAt)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7224,11 +7538,10 @@ func (s *OpenTracingLayerPostPersistentNotificationStore) Delete(postIds []strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.PostPersistentNotificationStore.Delete(postIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7242,11 +7555,10 @@ func (s *OpenTracingLayerPostPersistentNotificationStore) DeleteByChannel(channe
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.PostPersistentNotificationStore.DeleteByChannel(channelIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7260,11 +7572,10 @@ func (s *OpenTracingLayerPostPersistentNotificationStore) DeleteByTeam(teamIds [
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.PostPersistentNotificationStore.DeleteByTeam(teamIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7278,11 +7589,10 @@ func (s *OpenTracingLayerPostPersistentNotificationStore) DeleteExpired(maxSentC
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.PostPersistentNotificationStore.DeleteExpired(maxSentCount)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7296,11 +7606,10 @@ func (s *OpenTracingLayerPostPersistentNotificationStore) Get(params model.GetPe
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostPersistentNotificationStore.Get(params)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7314,11 +7623,10 @@ func (s *OpenTracingLayerPostPersistentNotificationStore) GetSingle(postID strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostPersistentNotificationStore.GetSingle(postID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7332,11 +7640,10 @@ func (s *OpenTracingLayerPostPersistentNotificationStore) UpdateLastActivity(pos
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.PostPersistentNotificationStore.UpdateLastActivity(postIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7350,11 +7657,10 @@ func (s *OpenTracingLayerPostPriorityStore) GetForPost(postID string) (*model.Po
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostPriorityStore.GetForPost(postID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7368,11 +7674,10 @@ func (s *OpenTracingLayerPostPriorityStore) GetForPosts(ids []string) ([]*model.
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PostPriorityStore.GetForPosts(ids)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7386,11 +7691,10 @@ func (s *OpenTracingLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7404,11 +7708,10 @@ func (s *OpenTracingLayerPreferenceStore) Delete(userID string, category string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.PreferenceStore.Delete(userID, category, name)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7422,11 +7725,10 @@ func (s *OpenTracingLayerPreferenceStore) DeleteCategory(userID string, category
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.PreferenceStore.DeleteCategory(userID, category)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7440,11 +7742,10 @@ func (s *OpenTracingLayerPreferenceStore) DeleteCategoryAndName(category string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.PreferenceStore.DeleteCategoryAndName(category, name)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7458,11 +7759,10 @@ func (s *OpenTracingLayerPreferenceStore) DeleteInvalidVisibleDmsGms() (int64, e
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PreferenceStore.DeleteInvalidVisibleDmsGms()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7476,11 +7776,10 @@ func (s *OpenTracingLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PreferenceStore.DeleteOrphanedRows(limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7494,11 +7793,10 @@ func (s *OpenTracingLayerPreferenceStore) Get(userID string, category string, na
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PreferenceStore.Get(userID, category, name)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7512,11 +7810,10 @@ func (s *OpenTracingLayerPreferenceStore) GetAll(userID string) (model.Preferenc
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PreferenceStore.GetAll(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7530,11 +7827,10 @@ func (s *OpenTracingLayerPreferenceStore) GetCategory(userID string, category st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PreferenceStore.GetCategory(userID, category)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7548,35 +7844,50 @@ func (s *OpenTracingLayerPreferenceStore) GetCategoryAndName(category string, na
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.PreferenceStore.GetCategoryAndName(category, nane)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
+func (s *OpenTracingLayerPreferenceStore) GetCategoryAndNameForUsers(userIDs []string, category string, name string) (model.Preferences, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PreferenceStore.PermanentDeleteByUser")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PreferenceStore.GetCategoryAndNameForUsers")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	err := s.PreferenceStore.PermanentDeleteByUser(userID)
+	defer span.End()
+	result, err := s.PreferenceStore.GetCategoryAndNameForUsers(userIDs, category, name)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
-	return err
+	return result, err
 }
 
-func (s *OpenTracingLayerPreferenceStore) Save(preferences model.Preferences) error {
+func (s *OpenTracingLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PreferenceStore.PermanentDeleteByUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.PreferenceStore.PermanentDeleteByUser(userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerPreferenceStore) Save(preferences model.Preferences) error {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "PreferenceStore.Save")
 	s.Root.Store.SetContext(newCtx)
@@ -7584,11 +7895,10 @@ func (s *OpenTracingLayerPreferenceStore) Save(preferences model.Preferences) er
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.PreferenceStore.Save(preferences)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7602,11 +7912,10 @@ func (s *OpenTracingLayerProductNoticesStore) Clear(notices []string) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ProductNoticesStore.Clear(notices)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7620,11 +7929,10 @@ func (s *OpenTracingLayerProductNoticesStore) ClearOldNotices(currentNotices mod
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7638,11 +7946,10 @@ func (s *OpenTracingLayerProductNoticesStore) GetViews(userID string) ([]model.P
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ProductNoticesStore.GetViews(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7656,11 +7963,10 @@ func (s *OpenTracingLayerProductNoticesStore) View(userID string, notices []stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ProductNoticesStore.View(userID, notices)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7674,11 +7980,10 @@ func (s *OpenTracingLayerReactionStore) BulkGetForPosts(postIds []string) ([]*mo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ReactionStore.BulkGetForPosts(postIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7692,11 +7997,10 @@ func (s *OpenTracingLayerReactionStore) Delete(reaction *model.Reaction) (*model
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ReactionStore.Delete(reaction)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7710,11 +8014,10 @@ func (s *OpenTracingLayerReactionStore) DeleteAllWithEmojiName(emojiName string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7728,11 +8031,10 @@ func (s *OpenTracingLayerReactionStore) DeleteOrphanedRowsByIds(r *model.Retenti
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ReactionStore.DeleteOrphanedRowsByIds(r)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7746,11 +8048,10 @@ func (s *OpenTracingLayerReactionStore) ExistsOnPost(postID string, emojiName st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ReactionStore.ExistsOnPost(postID, emojiName)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7764,11 +8065,10 @@ func (s *OpenTracingLayerReactionStore) GetForPost(postID string, allowFromCache
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ReactionStore.GetForPost(postID, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7782,11 +8082,10 @@ func (s *OpenTracingLayerReactionStore) GetForPostSince(postID string, since int
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ReactionStore.GetForPostSince(postID, since, excludeRemoteID, inclDeleted)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7800,11 +8099,10 @@ func (s *OpenTracingLayerReactionStore) GetSingle(userID string, postID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ReactionStore.GetSingle(userID, postID, remoteID, emojiName)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7818,11 +8116,10 @@ func (s *OpenTracingLayerReactionStore) GetUniqueCountForPost(postID string) (in
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ReactionStore.GetUniqueCountForPost(postID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7836,11 +8133,10 @@ func (s *OpenTracingLayerReactionStore) PermanentDeleteBatch(endTime int64, limi
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7854,11 +8150,10 @@ func (s *OpenTracingLayerReactionStore) PermanentDeleteByUser(userID string) err
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ReactionStore.PermanentDeleteByUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7872,16 +8167,168 @@ func (s *OpenTracingLayerReactionStore) Save(reaction *model.Reaction) (*model.R
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ReactionStore.Save(reaction)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
+func (s *OpenTracingLayerReminderStore) Delete(reminderID string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ReminderStore.Delete")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.ReminderStore.Delete(reminderID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerReminderStore) DeleteCompletedBefore(before int64) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ReminderStore.DeleteCompletedBefore")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.ReminderStore.DeleteCompletedBefore(before)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerReminderStore) Get(reminderID string) (*model.Reminder, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ReminderStore.Get")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.ReminderStore.Get(reminderID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerReminderStore) GetDue(before int64, limit int) ([]*model.Reminder, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ReminderStore.GetDue")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.ReminderStore.GetDue(before, limit)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerReminderStore) GetForUser(userID string) ([]*model.Reminder, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ReminderStore.GetForUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.ReminderStore.GetForUser(userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerReminderStore) GetLastTriggeredForUser(userID string) (*model.Reminder, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ReminderStore.GetLastTriggeredForUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.ReminderStore.GetLastTriggeredForUser(userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerReminderStore) PermanentDeleteByUser(userID string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ReminderStore.PermanentDeleteByUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.ReminderStore.PermanentDeleteByUser(userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerReminderStore) Save(reminder *model.Reminder) (*model.Reminder, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ReminderStore.Save")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.ReminderStore.Save(reminder)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerReminderStore) Update(reminder *model.Reminder) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ReminderStore.Update")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.ReminderStore.Update(reminder)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
 func (s *OpenTracingLayerRemoteClusterStore) Delete(remoteClusterID string) (bool, error) {
 	origCtx := s.Root.Store.Context()
 	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RemoteClusterStore.Delete")
@@ -7890,11 +8337,10 @@ func (s *OpenTracingLayerRemoteClusterStore) Delete(remoteClusterID string) (boo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.RemoteClusterStore.Delete(remoteClusterID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7908,11 +8354,10 @@ func (s *OpenTracingLayerRemoteClusterStore) Get(remoteClusterID string, include
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.RemoteClusterStore.Get(remoteClusterID, includeDeleted)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7926,11 +8371,10 @@ func (s *OpenTracingLayerRemoteClusterStore) GetAll(offset int, limit int, filte
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.RemoteClusterStore.GetAll(offset, limit, filter)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7944,11 +8388,10 @@ func (s *OpenTracingLayerRemoteClusterStore) GetByPluginID(pluginID string) (*mo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.RemoteClusterStore.GetByPluginID(pluginID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7962,11 +8405,10 @@ func (s *OpenTracingLayerRemoteClusterStore) Save(rc *model.RemoteCluster) (*mod
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.RemoteClusterStore.Save(rc)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -7980,11 +8422,10 @@ func (s *OpenTracingLayerRemoteClusterStore) SetLastPingAt(remoteClusterID strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.RemoteClusterStore.SetLastPingAt(remoteClusterID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -7998,11 +8439,10 @@ func (s *OpenTracingLayerRemoteClusterStore) Update(rc *model.RemoteCluster) (*m
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.RemoteClusterStore.Update(rc)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8016,11 +8456,10 @@ func (s *OpenTracingLayerRemoteClusterStore) UpdateTopics(remoteClusterID string
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.RemoteClusterStore.UpdateTopics(remoteClusterID, topics)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8034,11 +8473,10 @@ func (s *OpenTracingLayerRetentionPolicyStore) AddChannels(policyID string, chan
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.RetentionPolicyStore.AddChannels(policyID, channelIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -8052,11 +8490,10 @@ func (s *OpenTracingLayerRetentionPolicyStore) AddTeams(policyID string, teamIds
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.RetentionPolicyStore.AddTeams(policyID, teamIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -8070,11 +8507,10 @@ func (s *OpenTracingLayerRetentionPolicyStore) Delete(id string) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.RetentionPolicyStore.Delete(id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -8088,11 +8524,10 @@ func (s *OpenTracingLayerRetentionPolicyStore) DeleteOrphanedRows(limit int) (in
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.RetentionPolicyStore.DeleteOrphanedRows(limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8106,11 +8541,10 @@ func (s *OpenTracingLayerRetentionPolicyStore) Get(id string) (*model.RetentionP
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.RetentionPolicyStore.Get(id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8124,11 +8558,10 @@ func (s *OpenTracingLayerRetentionPolicyStore) GetAll(offset int, limit int) ([]
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.RetentionPolicyStore.GetAll(offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8142,11 +8575,10 @@ func (s *OpenTracingLayerRetentionPolicyStore) GetChannelPoliciesCountForUser(us
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.RetentionPolicyStore.GetChannelPoliciesCountForUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8160,11 +8592,10 @@ func (s *OpenTracingLayerRetentionPolicyStore) GetChannelPoliciesForUser(userID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.RetentionPolicyStore.GetChannelPoliciesForUser(userID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8178,11 +8609,10 @@ func (s *OpenTracingLayerRetentionPolicyStore) GetChannels(policyID string, offs
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.RetentionPolicyStore.GetChannels(policyID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8196,374 +8626,642 @@ func (s *OpenTracingLayerRetentionPolicyStore) GetChannelsCount(policyID string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RetentionPolicyStore.GetChannelsCount(policyID)
+	defer span.End()
+	result, err := s.RetentionPolicyStore.GetChannelsCount(policyID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerRetentionPolicyStore) GetCount() (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.GetCount")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.RetentionPolicyStore.GetCount()
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerRetentionPolicyStore) GetIdsForDeletionByTableName(tableName string, limit int) ([]*model.RetentionIdsForDeletion, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.GetIdsForDeletionByTableName")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.RetentionPolicyStore.GetIdsForDeletionByTableName(tableName, limit)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerRetentionPolicyStore) GetTeamPoliciesCountForUser(userID string) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.GetTeamPoliciesCountForUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.RetentionPolicyStore.GetTeamPoliciesCountForUser(userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerRetentionPolicyStore) GetTeamPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForTeam, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.GetTeamPoliciesForUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.RetentionPolicyStore.GetTeamPoliciesForUser(userID, offset, limit)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerRetentionPolicyStore) GetTeams(policyID string, offset int, limit int) ([]*model.Team, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.GetTeams")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.RetentionPolicyStore.GetTeams(policyID, offset, limit)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerRetentionPolicyStore) GetTeamsCount(policyID string) (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.GetTeamsCount")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.RetentionPolicyStore.GetTeamsCount(policyID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerRetentionPolicyStore) Patch(patch *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.Patch")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.RetentionPolicyStore.Patch(patch)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerRetentionPolicyStore) RemoveChannels(policyID string, channelIds []string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.RemoveChannels")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.RetentionPolicyStore.RemoveChannels(policyID, channelIds)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerRetentionPolicyStore) RemoveTeams(policyID string, teamIds []string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.RemoveTeams")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.RetentionPolicyStore.RemoveTeams(policyID, teamIds)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerRetentionPolicyStore) Save(policy *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.Save")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.RetentionPolicyStore.Save(policy)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.AllChannelSchemeRoles")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.RoleStore.AllChannelSchemeRoles()
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.ChannelHigherScopedPermissions")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.RoleStore.ChannelHigherScopedPermissions(roleNames)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.ChannelRolesUnderTeamRole")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.RoleStore.ChannelRolesUnderTeamRole(roleName)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerRoleStore) Delete(roleID string) (*model.Role, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.Delete")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.RoleStore.Delete(roleID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerRoleStore) Get(roleID string) (*model.Role, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.Get")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.RoleStore.Get(roleID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerRoleStore) GetAll() ([]*model.Role, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.GetAll")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.RoleStore.GetAll()
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerRoleStore) GetByName(ctx context.Context, name string) (*model.Role, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.GetByName")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.RoleStore.GetByName(ctx, name)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerRetentionPolicyStore) GetCount() (int64, error) {
+func (s *OpenTracingLayerRoleStore) GetByNames(names []string) ([]*model.Role, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.GetCount")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.GetByNames")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RetentionPolicyStore.GetCount()
+	defer span.End()
+	result, err := s.RoleStore.GetByNames(names)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerRetentionPolicyStore) GetIdsForDeletionByTableName(tableName string, limit int) ([]*model.RetentionIdsForDeletion, error) {
+func (s *OpenTracingLayerRoleStore) PermanentDeleteAll() error {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.GetIdsForDeletionByTableName")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.PermanentDeleteAll")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RetentionPolicyStore.GetIdsForDeletionByTableName(tableName, limit)
+	defer span.End()
+	err := s.RoleStore.PermanentDeleteAll()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
-	return result, err
+	return err
 }
 
-func (s *OpenTracingLayerRetentionPolicyStore) GetTeamPoliciesCountForUser(userID string) (int64, error) {
+func (s *OpenTracingLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.GetTeamPoliciesCountForUser")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.Save")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RetentionPolicyStore.GetTeamPoliciesCountForUser(userID)
+	defer span.End()
+	result, err := s.RoleStore.Save(role)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerRetentionPolicyStore) GetTeamPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForTeam, error) {
+func (s *OpenTracingLayerSavedSearchStore) Delete(savedSearchID string) error {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.GetTeamPoliciesForUser")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SavedSearchStore.Delete")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RetentionPolicyStore.GetTeamPoliciesForUser(userID, offset, limit)
+	defer span.End()
+	err := s.SavedSearchStore.Delete(savedSearchID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
-	return result, err
+	return err
 }
 
-func (s *OpenTracingLayerRetentionPolicyStore) GetTeams(policyID string, offset int, limit int) ([]*model.Team, error) {
+func (s *OpenTracingLayerSavedSearchStore) Get(savedSearchID string) (*model.SavedSearch, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.GetTeams")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SavedSearchStore.Get")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RetentionPolicyStore.GetTeams(policyID, offset, limit)
+	defer span.End()
+	result, err := s.SavedSearchStore.Get(savedSearchID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerRetentionPolicyStore) GetTeamsCount(policyID string) (int64, error) {
+func (s *OpenTracingLayerSavedSearchStore) GetForUser(userID string) ([]*model.SavedSearch, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.GetTeamsCount")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SavedSearchStore.GetForUser")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RetentionPolicyStore.GetTeamsCount(policyID)
+	defer span.End()
+	result, err := s.SavedSearchStore.GetForUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerRetentionPolicyStore) Patch(patch *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+func (s *OpenTracingLayerSavedSearchStore) GetWithAlerts(afterID string, limit int) ([]*model.SavedSearch, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.Patch")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SavedSearchStore.GetWithAlerts")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RetentionPolicyStore.Patch(patch)
+	defer span.End()
+	result, err := s.SavedSearchStore.GetWithAlerts(afterID, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerRetentionPolicyStore) RemoveChannels(policyID string, channelIds []string) error {
+func (s *OpenTracingLayerSavedSearchStore) GetWithAlertsForChannel(channelID string) ([]*model.SavedSearch, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.RemoveChannels")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SavedSearchStore.GetWithAlertsForChannel")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	err := s.RetentionPolicyStore.RemoveChannels(policyID, channelIds)
+	defer span.End()
+	result, err := s.SavedSearchStore.GetWithAlertsForChannel(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
-	return err
+	return result, err
 }
 
-func (s *OpenTracingLayerRetentionPolicyStore) RemoveTeams(policyID string, teamIds []string) error {
+func (s *OpenTracingLayerSavedSearchStore) PermanentDeleteByUser(userID string) error {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.RemoveTeams")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SavedSearchStore.PermanentDeleteByUser")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	err := s.RetentionPolicyStore.RemoveTeams(policyID, teamIds)
+	defer span.End()
+	err := s.SavedSearchStore.PermanentDeleteByUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
 }
 
-func (s *OpenTracingLayerRetentionPolicyStore) Save(policy *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+func (s *OpenTracingLayerSavedSearchStore) Save(savedSearch *model.SavedSearch) (*model.SavedSearch, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RetentionPolicyStore.Save")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SavedSearchStore.Save")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RetentionPolicyStore.Save(policy)
+	defer span.End()
+	result, err := s.SavedSearchStore.Save(savedSearch)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
+func (s *OpenTracingLayerSavedSearchStore) Update(savedSearch *model.SavedSearch) (*model.SavedSearch, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.AllChannelSchemeRoles")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SavedSearchStore.Update")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RoleStore.AllChannelSchemeRoles()
+	defer span.End()
+	result, err := s.SavedSearchStore.Update(savedSearch)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
+func (s *OpenTracingLayerSavedSearchStore) UpdateLastCheckedAt(savedSearchID string, previous int64, lastCheckedAt int64) (bool, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.ChannelHigherScopedPermissions")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "SavedSearchStore.UpdateLastCheckedAt")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RoleStore.ChannelHigherScopedPermissions(roleNames)
+	defer span.End()
+	result, err := s.SavedSearchStore.UpdateLastCheckedAt(savedSearchID, previous, lastCheckedAt)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
+func (s *OpenTracingLayerScheduledPostStore) Delete(scheduledPostID string) error {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.ChannelRolesUnderTeamRole")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ScheduledPostStore.Delete")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RoleStore.ChannelRolesUnderTeamRole(roleName)
+	defer span.End()
+	err := s.ScheduledPostStore.Delete(scheduledPostID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
-	return result, err
+	return err
 }
 
-func (s *OpenTracingLayerRoleStore) Delete(roleID string) (*model.Role, error) {
+func (s *OpenTracingLayerScheduledPostStore) Get(scheduledPostID string) (*model.ScheduledPost, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.Delete")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ScheduledPostStore.Get")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RoleStore.Delete(roleID)
+	defer span.End()
+	result, err := s.ScheduledPostStore.Get(scheduledPostID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerRoleStore) Get(roleID string) (*model.Role, error) {
+func (s *OpenTracingLayerScheduledPostStore) GetPendingScheduledPosts(opts model.GetScheduledPostsForProcessingOptions) ([]*model.ScheduledPost, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.Get")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ScheduledPostStore.GetPendingScheduledPosts")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RoleStore.Get(roleID)
+	defer span.End()
+	result, err := s.ScheduledPostStore.GetPendingScheduledPosts(opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerRoleStore) GetAll() ([]*model.Role, error) {
+func (s *OpenTracingLayerScheduledPostStore) GetScheduledPostsForUser(userID string, teamID string) ([]*model.ScheduledPost, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.GetAll")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ScheduledPostStore.GetScheduledPostsForUser")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RoleStore.GetAll()
+	defer span.End()
+	result, err := s.ScheduledPostStore.GetScheduledPostsForUser(userID, teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
 }
 
-func (s *OpenTracingLayerRoleStore) GetByName(ctx context.Context, name string) (*model.Role, error) {
+func (s *OpenTracingLayerScheduledPostStore) MarkAsProcessed(scheduledPostID string, postID string, errorCode string, processedAt int64) error {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.GetByName")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ScheduledPostStore.MarkAsProcessed")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RoleStore.GetByName(ctx, name)
+	defer span.End()
+	err := s.ScheduledPostStore.MarkAsProcessed(scheduledPostID, postID, errorCode, processedAt)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
-	return result, err
+	return err
 }
 
-func (s *OpenTracingLayerRoleStore) GetByNames(names []string) ([]*model.Role, error) {
+func (s *OpenTracingLayerScheduledPostStore) PermanentDeleteByUser(userID string) error {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.GetByNames")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ScheduledPostStore.PermanentDeleteByUser")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RoleStore.GetByNames(names)
+	defer span.End()
+	err := s.ScheduledPostStore.PermanentDeleteByUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
-	return result, err
+	return err
 }
 
-func (s *OpenTracingLayerRoleStore) PermanentDeleteAll() error {
+func (s *OpenTracingLayerScheduledPostStore) Save(scheduledPost *model.ScheduledPost) (*model.ScheduledPost, error) {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.PermanentDeleteAll")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ScheduledPostStore.Save")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	err := s.RoleStore.PermanentDeleteAll()
+	defer span.End()
+	result, err := s.ScheduledPostStore.Save(scheduledPost)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
-	return err
+	return result, err
 }
 
-func (s *OpenTracingLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
+func (s *OpenTracingLayerScheduledPostStore) Update(scheduledPost *model.ScheduledPost) error {
 	origCtx := s.Root.Store.Context()
-	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "RoleStore.Save")
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "ScheduledPostStore.Update")
 	s.Root.Store.SetContext(newCtx)
 	defer func() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
-	result, err := s.RoleStore.Save(role)
+	defer span.End()
+	err := s.ScheduledPostStore.Update(scheduledPost)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
-	return result, err
+	return err
 }
 
 func (s *OpenTracingLayerSchemeStore) CountByScope(scope string) (int64, error) {
@@ -8574,11 +9272,10 @@ func (s *OpenTracingLayerSchemeStore) CountByScope(scope string) (int64, error)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SchemeStore.CountByScope(scope)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8592,11 +9289,10 @@ func (s *OpenTracingLayerSchemeStore) CountWithoutPermission(scope string, permi
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SchemeStore.CountWithoutPermission(scope, permissionID, roleScope, roleType)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8610,11 +9306,10 @@ func (s *OpenTracingLayerSchemeStore) Delete(schemeID string) (*model.Scheme, er
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SchemeStore.Delete(schemeID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8628,11 +9323,10 @@ func (s *OpenTracingLayerSchemeStore) Get(schemeID string) (*model.Scheme, error
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SchemeStore.Get(schemeID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8646,11 +9340,10 @@ func (s *OpenTracingLayerSchemeStore) GetAllPage(scope string, offset int, limit
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SchemeStore.GetAllPage(scope, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8664,11 +9357,10 @@ func (s *OpenTracingLayerSchemeStore) GetByName(schemeName string) (*model.Schem
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SchemeStore.GetByName(schemeName)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8682,11 +9374,10 @@ func (s *OpenTracingLayerSchemeStore) PermanentDeleteAll() error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.SchemeStore.PermanentDeleteAll()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -8700,11 +9391,10 @@ func (s *OpenTracingLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SchemeStore.Save(scheme)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8718,11 +9408,10 @@ func (s *OpenTracingLayerSessionStore) AnalyticsSessionCount() (int64, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SessionStore.AnalyticsSessionCount()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8736,11 +9425,10 @@ func (s *OpenTracingLayerSessionStore) Cleanup(expiryTime int64, batchSize int64
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.SessionStore.Cleanup(expiryTime, batchSize)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -8754,11 +9442,10 @@ func (s *OpenTracingLayerSessionStore) Get(c request.CTX, sessionIDOrToken strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SessionStore.Get(c, sessionIDOrToken)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8772,11 +9459,10 @@ func (s *OpenTracingLayerSessionStore) GetLRUSessions(c request.CTX, userID stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SessionStore.GetLRUSessions(c, userID, limit, offset)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8790,11 +9476,10 @@ func (s *OpenTracingLayerSessionStore) GetMobileSessionMetadata() ([]*model.Mobi
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SessionStore.GetMobileSessionMetadata()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8808,11 +9493,10 @@ func (s *OpenTracingLayerSessionStore) GetSessions(c request.CTX, userID string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SessionStore.GetSessions(c, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8826,11 +9510,10 @@ func (s *OpenTracingLayerSessionStore) GetSessionsExpired(thresholdMillis int64,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SessionStore.GetSessionsExpired(thresholdMillis, mobileOnly, unnotifiedOnly)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8844,11 +9527,10 @@ func (s *OpenTracingLayerSessionStore) GetSessionsWithActiveDeviceIds(userID str
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SessionStore.GetSessionsWithActiveDeviceIds(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8862,11 +9544,10 @@ func (s *OpenTracingLayerSessionStore) PermanentDeleteSessionsByUser(teamID stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.SessionStore.PermanentDeleteSessionsByUser(teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -8880,11 +9561,10 @@ func (s *OpenTracingLayerSessionStore) Remove(sessionIDOrToken string) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.SessionStore.Remove(sessionIDOrToken)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -8898,11 +9578,10 @@ func (s *OpenTracingLayerSessionStore) RemoveAllSessions() error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.SessionStore.RemoveAllSessions()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -8916,11 +9595,10 @@ func (s *OpenTracingLayerSessionStore) Save(c request.CTX, session *model.Sessio
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SessionStore.Save(c, session)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8934,11 +9612,10 @@ func (s *OpenTracingLayerSessionStore) UpdateDeviceId(id string, deviceID string
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SessionStore.UpdateDeviceId(id, deviceID, expiresAt)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -8952,11 +9629,10 @@ func (s *OpenTracingLayerSessionStore) UpdateExpiredNotify(sessionid string, not
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.SessionStore.UpdateExpiredNotify(sessionid, notified)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -8970,11 +9646,10 @@ func (s *OpenTracingLayerSessionStore) UpdateExpiresAt(sessionID string, timesta
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.SessionStore.UpdateExpiresAt(sessionID, timestamp)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -8988,11 +9663,10 @@ func (s *OpenTracingLayerSessionStore) UpdateLastActivityAt(sessionID string, ti
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.SessionStore.UpdateLastActivityAt(sessionID, timestamp)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -9006,11 +9680,10 @@ func (s *OpenTracingLayerSessionStore) UpdateProps(session *model.Session) error
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.SessionStore.UpdateProps(session)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -9024,11 +9697,10 @@ func (s *OpenTracingLayerSessionStore) UpdateRoles(userID string, roles string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SessionStore.UpdateRoles(userID, roles)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9042,11 +9714,10 @@ func (s *OpenTracingLayerSharedChannelStore) Delete(channelID string) (bool, err
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.Delete(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9060,11 +9731,10 @@ func (s *OpenTracingLayerSharedChannelStore) DeleteRemote(remoteID string) (bool
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.DeleteRemote(remoteID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9078,11 +9748,10 @@ func (s *OpenTracingLayerSharedChannelStore) Get(channelID string) (*model.Share
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.Get(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9096,11 +9765,10 @@ func (s *OpenTracingLayerSharedChannelStore) GetAll(offset int, limit int, opts
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.GetAll(offset, limit, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9114,11 +9782,10 @@ func (s *OpenTracingLayerSharedChannelStore) GetAllCount(opts model.SharedChanne
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.GetAllCount(opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9132,11 +9799,10 @@ func (s *OpenTracingLayerSharedChannelStore) GetAttachment(fileID string, remote
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.GetAttachment(fileID, remoteID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9150,11 +9816,10 @@ func (s *OpenTracingLayerSharedChannelStore) GetRemote(id string) (*model.Shared
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.GetRemote(id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9168,11 +9833,10 @@ func (s *OpenTracingLayerSharedChannelStore) GetRemoteByIds(channelID string, re
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.GetRemoteByIds(channelID, remoteID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9186,11 +9850,10 @@ func (s *OpenTracingLayerSharedChannelStore) GetRemoteForUser(remoteID string, u
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.GetRemoteForUser(remoteID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9204,11 +9867,10 @@ func (s *OpenTracingLayerSharedChannelStore) GetRemotes(offset int, limit int, o
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.GetRemotes(offset, limit, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9222,11 +9884,10 @@ func (s *OpenTracingLayerSharedChannelStore) GetRemotesStatus(channelID string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.GetRemotesStatus(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9240,11 +9901,10 @@ func (s *OpenTracingLayerSharedChannelStore) GetSingleUser(userID string, channe
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.GetSingleUser(userID, channelID, remoteID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9258,11 +9918,10 @@ func (s *OpenTracingLayerSharedChannelStore) GetUsersForSync(filter model.GetUse
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.GetUsersForSync(filter)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9276,11 +9935,10 @@ func (s *OpenTracingLayerSharedChannelStore) GetUsersForUser(userID string) ([]*
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.GetUsersForUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9294,11 +9952,10 @@ func (s *OpenTracingLayerSharedChannelStore) HasChannel(channelID string) (bool,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.HasChannel(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9312,11 +9969,10 @@ func (s *OpenTracingLayerSharedChannelStore) HasRemote(channelID string, remoteI
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.HasRemote(channelID, remoteID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9330,11 +9986,10 @@ func (s *OpenTracingLayerSharedChannelStore) Save(sc *model.SharedChannel) (*mod
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.Save(sc)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9348,11 +10003,10 @@ func (s *OpenTracingLayerSharedChannelStore) SaveAttachment(remote *model.Shared
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.SaveAttachment(remote)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9366,11 +10020,10 @@ func (s *OpenTracingLayerSharedChannelStore) SaveRemote(remote *model.SharedChan
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.SaveRemote(remote)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9384,11 +10037,10 @@ func (s *OpenTracingLayerSharedChannelStore) SaveUser(remote *model.SharedChanne
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.SaveUser(remote)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9402,11 +10054,10 @@ func (s *OpenTracingLayerSharedChannelStore) Update(sc *model.SharedChannel) (*m
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.Update(sc)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9420,11 +10071,10 @@ func (s *OpenTracingLayerSharedChannelStore) UpdateAttachmentLastSyncAt(id strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.SharedChannelStore.UpdateAttachmentLastSyncAt(id, syncTime)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -9438,11 +10088,10 @@ func (s *OpenTracingLayerSharedChannelStore) UpdateRemote(remote *model.SharedCh
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.UpdateRemote(remote)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9456,11 +10105,10 @@ func (s *OpenTracingLayerSharedChannelStore) UpdateRemoteCursor(id string, curso
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.SharedChannelStore.UpdateRemoteCursor(id, cursor)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -9474,11 +10122,10 @@ func (s *OpenTracingLayerSharedChannelStore) UpdateUserLastSyncAt(userID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.SharedChannelStore.UpdateUserLastSyncAt(userID, channelID, remoteID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -9492,11 +10139,10 @@ func (s *OpenTracingLayerSharedChannelStore) UpsertAttachment(remote *model.Shar
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SharedChannelStore.UpsertAttachment(remote)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9510,11 +10156,10 @@ func (s *OpenTracingLayerStatusStore) Get(userID string) (*model.Status, error)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.StatusStore.Get(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9528,11 +10173,10 @@ func (s *OpenTracingLayerStatusStore) GetByIds(userIds []string) ([]*model.Statu
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.StatusStore.GetByIds(userIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9546,11 +10190,10 @@ func (s *OpenTracingLayerStatusStore) GetTotalActiveUsersCount() (int64, error)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.StatusStore.GetTotalActiveUsersCount()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9564,11 +10207,10 @@ func (s *OpenTracingLayerStatusStore) ResetAll() error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.StatusStore.ResetAll()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -9582,11 +10224,10 @@ func (s *OpenTracingLayerStatusStore) SaveOrUpdate(status *model.Status) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.StatusStore.SaveOrUpdate(status)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -9600,11 +10241,10 @@ func (s *OpenTracingLayerStatusStore) UpdateExpiredDNDStatuses() ([]*model.Statu
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.StatusStore.UpdateExpiredDNDStatuses()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9618,11 +10258,10 @@ func (s *OpenTracingLayerStatusStore) UpdateLastActivityAt(userID string, lastAc
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.StatusStore.UpdateLastActivityAt(userID, lastActivityAt)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -9636,11 +10275,10 @@ func (s *OpenTracingLayerSystemStore) Get() (model.StringMap, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SystemStore.Get()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9654,11 +10292,10 @@ func (s *OpenTracingLayerSystemStore) GetByName(name string) (*model.System, err
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SystemStore.GetByName(name)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9672,11 +10309,10 @@ func (s *OpenTracingLayerSystemStore) InsertIfExists(system *model.System) (*mod
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SystemStore.InsertIfExists(system)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9690,11 +10326,10 @@ func (s *OpenTracingLayerSystemStore) PermanentDeleteByName(name string) (*model
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.SystemStore.PermanentDeleteByName(name)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9708,11 +10343,10 @@ func (s *OpenTracingLayerSystemStore) Save(system *model.System) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.SystemStore.Save(system)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -9726,11 +10360,10 @@ func (s *OpenTracingLayerSystemStore) SaveOrUpdate(system *model.System) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.SystemStore.SaveOrUpdate(system)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -9744,11 +10377,10 @@ func (s *OpenTracingLayerSystemStore) Update(system *model.System) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.SystemStore.Update(system)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -9762,11 +10394,10 @@ func (s *OpenTracingLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeID stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.AnalyticsGetTeamCountForScheme(schemeID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9780,11 +10411,10 @@ func (s *OpenTracingLayerTeamStore) AnalyticsTeamCount(opts *model.TeamSearch) (
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.AnalyticsTeamCount(opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9798,11 +10428,10 @@ func (s *OpenTracingLayerTeamStore) ClearAllCustomRoleAssignments() error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.TeamStore.ClearAllCustomRoleAssignments()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -9816,7 +10445,7 @@ func (s *OpenTracingLayerTeamStore) ClearCaches() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.TeamStore.ClearCaches()
 
 }
@@ -9829,11 +10458,10 @@ func (s *OpenTracingLayerTeamStore) Get(id string) (*model.Team, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.Get(id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9847,11 +10475,10 @@ func (s *OpenTracingLayerTeamStore) GetActiveMemberCount(teamID string, restrict
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetActiveMemberCount(teamID, restrictions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9865,11 +10492,10 @@ func (s *OpenTracingLayerTeamStore) GetAll() ([]*model.Team, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetAll()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9883,11 +10509,10 @@ func (s *OpenTracingLayerTeamStore) GetAllForExportAfter(limit int, afterID stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetAllForExportAfter(limit, afterID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9901,11 +10526,10 @@ func (s *OpenTracingLayerTeamStore) GetAllPage(offset int, limit int, opts *mode
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetAllPage(offset, limit, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9919,11 +10543,10 @@ func (s *OpenTracingLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, e
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetAllPrivateTeamListing()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9937,11 +10560,10 @@ func (s *OpenTracingLayerTeamStore) GetAllTeamListing() ([]*model.Team, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetAllTeamListing()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9955,11 +10577,10 @@ func (s *OpenTracingLayerTeamStore) GetByEmptyInviteID() ([]*model.Team, error)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetByEmptyInviteID()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9973,11 +10594,10 @@ func (s *OpenTracingLayerTeamStore) GetByInviteId(inviteID string) (*model.Team,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetByInviteId(inviteID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -9991,11 +10611,10 @@ func (s *OpenTracingLayerTeamStore) GetByName(name string) (*model.Team, error)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetByName(name)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10009,11 +10628,10 @@ func (s *OpenTracingLayerTeamStore) GetByNames(name []string) ([]*model.Team, er
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetByNames(name)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10027,11 +10645,10 @@ func (s *OpenTracingLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamID s
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetChannelUnreadsForAllTeams(excludeTeamID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10045,11 +10662,10 @@ func (s *OpenTracingLayerTeamStore) GetChannelUnreadsForTeam(teamID string, user
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetChannelUnreadsForTeam(teamID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10063,11 +10679,10 @@ func (s *OpenTracingLayerTeamStore) GetCommonTeamIDsForMultipleUsers(userIDs []s
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetCommonTeamIDsForMultipleUsers(userIDs)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10081,11 +10696,10 @@ func (s *OpenTracingLayerTeamStore) GetCommonTeamIDsForTwoUsers(userID string, o
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetCommonTeamIDsForTwoUsers(userID, otherUserID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10099,11 +10713,10 @@ func (s *OpenTracingLayerTeamStore) GetMany(ids []string) ([]*model.Team, error)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetMany(ids)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10117,11 +10730,10 @@ func (s *OpenTracingLayerTeamStore) GetMember(c request.CTX, teamID string, user
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetMember(c, teamID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10135,11 +10747,10 @@ func (s *OpenTracingLayerTeamStore) GetMembers(teamID string, offset int, limit
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetMembers(teamID, offset, limit, teamMembersGetOptions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10153,11 +10764,10 @@ func (s *OpenTracingLayerTeamStore) GetMembersByIds(teamID string, userIds []str
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetMembersByIds(teamID, userIds, restrictions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10171,11 +10781,10 @@ func (s *OpenTracingLayerTeamStore) GetTeamMembersForExport(userID string) ([]*m
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetTeamMembersForExport(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10189,11 +10798,10 @@ func (s *OpenTracingLayerTeamStore) GetTeamsByScheme(schemeID string, offset int
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetTeamsByScheme(schemeID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10207,11 +10815,10 @@ func (s *OpenTracingLayerTeamStore) GetTeamsByUserId(userID string) ([]*model.Te
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetTeamsByUserId(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10225,11 +10832,10 @@ func (s *OpenTracingLayerTeamStore) GetTeamsForUser(c request.CTX, userID string
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetTeamsForUser(c, userID, excludeTeamID, includeDeleted)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10243,11 +10849,10 @@ func (s *OpenTracingLayerTeamStore) GetTeamsForUserWithPagination(userID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetTeamsForUserWithPagination(userID, page, perPage)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10261,11 +10866,10 @@ func (s *OpenTracingLayerTeamStore) GetTotalMemberCount(teamID string, restricti
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetTotalMemberCount(teamID, restrictions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10279,11 +10883,10 @@ func (s *OpenTracingLayerTeamStore) GetUserTeamIds(userID string, allowFromCache
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GetUserTeamIds(userID, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10297,11 +10900,10 @@ func (s *OpenTracingLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.GroupSyncedTeamCount()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10315,7 +10917,7 @@ func (s *OpenTracingLayerTeamStore) InvalidateAllTeamIdsForUser(userID string) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.TeamStore.InvalidateAllTeamIdsForUser(userID)
 
 }
@@ -10328,11 +10930,10 @@ func (s *OpenTracingLayerTeamStore) MigrateTeamMembers(fromTeamID string, fromUs
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.MigrateTeamMembers(fromTeamID, fromUserID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10346,11 +10947,10 @@ func (s *OpenTracingLayerTeamStore) PermanentDelete(teamID string) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.TeamStore.PermanentDelete(teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -10364,11 +10964,10 @@ func (s *OpenTracingLayerTeamStore) RemoveAllMembersByTeam(teamID string) error
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.TeamStore.RemoveAllMembersByTeam(teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -10382,11 +10981,10 @@ func (s *OpenTracingLayerTeamStore) RemoveAllMembersByUser(ctx request.CTX, user
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.TeamStore.RemoveAllMembersByUser(ctx, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -10400,11 +10998,10 @@ func (s *OpenTracingLayerTeamStore) RemoveMember(rctx request.CTX, teamID string
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.TeamStore.RemoveMember(rctx, teamID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -10418,11 +11015,10 @@ func (s *OpenTracingLayerTeamStore) RemoveMembers(rctx request.CTX, teamID strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.TeamStore.RemoveMembers(rctx, teamID, userIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -10436,11 +11032,10 @@ func (s *OpenTracingLayerTeamStore) ResetAllTeamSchemes() error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.TeamStore.ResetAllTeamSchemes()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -10454,11 +11049,10 @@ func (s *OpenTracingLayerTeamStore) Save(team *model.Team) (*model.Team, error)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.Save(team)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10472,11 +11066,10 @@ func (s *OpenTracingLayerTeamStore) SaveMember(rctx request.CTX, member *model.T
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.SaveMember(rctx, member, maxUsersPerTeam)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10490,11 +11083,10 @@ func (s *OpenTracingLayerTeamStore) SaveMultipleMembers(members []*model.TeamMem
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.SaveMultipleMembers(members, maxUsersPerTeam)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10508,11 +11100,10 @@ func (s *OpenTracingLayerTeamStore) SearchAll(opts *model.TeamSearch) ([]*model.
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.SearchAll(opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10526,11 +11117,10 @@ func (s *OpenTracingLayerTeamStore) SearchAllPaged(opts *model.TeamSearch) ([]*m
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, resultVar1, err := s.TeamStore.SearchAllPaged(opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, resultVar1, err
@@ -10544,11 +11134,10 @@ func (s *OpenTracingLayerTeamStore) SearchOpen(opts *model.TeamSearch) ([]*model
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.SearchOpen(opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10562,11 +11151,10 @@ func (s *OpenTracingLayerTeamStore) SearchPrivate(opts *model.TeamSearch) ([]*mo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.SearchPrivate(opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10580,11 +11168,10 @@ func (s *OpenTracingLayerTeamStore) Update(team *model.Team) (*model.Team, error
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.Update(team)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10598,11 +11185,10 @@ func (s *OpenTracingLayerTeamStore) UpdateLastTeamIconUpdate(teamID string, curT
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.TeamStore.UpdateLastTeamIconUpdate(teamID, curTime)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -10616,11 +11202,10 @@ func (s *OpenTracingLayerTeamStore) UpdateMember(rctx request.CTX, member *model
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.UpdateMember(rctx, member)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10634,11 +11219,10 @@ func (s *OpenTracingLayerTeamStore) UpdateMembersRole(teamID string, adminIDs []
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.UpdateMembersRole(teamID, adminIDs)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10652,11 +11236,10 @@ func (s *OpenTracingLayerTeamStore) UpdateMultipleMembers(members []*model.TeamM
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.UpdateMultipleMembers(members)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10670,11 +11253,10 @@ func (s *OpenTracingLayerTeamStore) UserBelongsToTeams(userID string, teamIds []
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TeamStore.UserBelongsToTeams(userID, teamIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10688,11 +11270,10 @@ func (s *OpenTracingLayerTermsOfServiceStore) Get(id string, allowFromCache bool
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TermsOfServiceStore.Get(id, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10706,11 +11287,10 @@ func (s *OpenTracingLayerTermsOfServiceStore) GetLatest(allowFromCache bool) (*m
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TermsOfServiceStore.GetLatest(allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10724,11 +11304,10 @@ func (s *OpenTracingLayerTermsOfServiceStore) Save(termsOfService *model.TermsOf
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TermsOfServiceStore.Save(termsOfService)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10742,11 +11321,10 @@ func (s *OpenTracingLayerThreadStore) DeleteMembershipForUser(userID string, pos
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ThreadStore.DeleteMembershipForUser(userID, postID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -10760,11 +11338,10 @@ func (s *OpenTracingLayerThreadStore) DeleteMembershipsForChannel(userID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ThreadStore.DeleteMembershipsForChannel(userID, channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -10778,11 +11355,10 @@ func (s *OpenTracingLayerThreadStore) DeleteOrphanedRows(limit int) (int64, erro
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.DeleteOrphanedRows(limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10796,11 +11372,10 @@ func (s *OpenTracingLayerThreadStore) Get(id string) (*model.Thread, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.Get(id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10814,11 +11389,10 @@ func (s *OpenTracingLayerThreadStore) GetMembershipForUser(userID string, postID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.GetMembershipForUser(userID, postID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10832,11 +11406,10 @@ func (s *OpenTracingLayerThreadStore) GetMembershipsForUser(userID string, teamI
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.GetMembershipsForUser(userID, teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10850,11 +11423,10 @@ func (s *OpenTracingLayerThreadStore) GetTeamsUnreadForUser(userID string, teamI
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.GetTeamsUnreadForUser(userID, teamIDs, includeUrgentMentionCount)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10868,11 +11440,10 @@ func (s *OpenTracingLayerThreadStore) GetThreadFollowers(threadID string, fetchO
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.GetThreadFollowers(threadID, fetchOnlyActive)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10886,11 +11457,10 @@ func (s *OpenTracingLayerThreadStore) GetThreadForUser(threadMembership *model.T
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.GetThreadForUser(threadMembership, extended, postPriorityIsEnabled)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10904,11 +11474,10 @@ func (s *OpenTracingLayerThreadStore) GetThreadMembershipsForExport(postID strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.GetThreadMembershipsForExport(postID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10922,11 +11491,10 @@ func (s *OpenTracingLayerThreadStore) GetThreadUnreadReplyCount(threadMembership
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.GetThreadUnreadReplyCount(threadMembership)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10940,11 +11508,10 @@ func (s *OpenTracingLayerThreadStore) GetThreadsForUser(userID string, teamID st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.GetThreadsForUser(userID, teamID, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10958,11 +11525,10 @@ func (s *OpenTracingLayerThreadStore) GetTotalThreads(userID string, teamID stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.GetTotalThreads(userID, teamID, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10976,11 +11542,10 @@ func (s *OpenTracingLayerThreadStore) GetTotalUnreadMentions(userID string, team
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.GetTotalUnreadMentions(userID, teamID, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -10994,11 +11559,10 @@ func (s *OpenTracingLayerThreadStore) GetTotalUnreadThreads(userID string, teamI
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.GetTotalUnreadThreads(userID, teamID, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11012,11 +11576,10 @@ func (s *OpenTracingLayerThreadStore) GetTotalUnreadUrgentMentions(userID string
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.GetTotalUnreadUrgentMentions(userID, teamID, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11030,11 +11593,10 @@ func (s *OpenTracingLayerThreadStore) MaintainMembership(userID string, postID s
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.MaintainMembership(userID, postID, opts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11048,11 +11610,10 @@ func (s *OpenTracingLayerThreadStore) MaintainMultipleFromImport(memberships []*
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.MaintainMultipleFromImport(memberships)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11066,11 +11627,10 @@ func (s *OpenTracingLayerThreadStore) MarkAllAsRead(userID string, threadIds []s
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ThreadStore.MarkAllAsRead(userID, threadIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -11084,11 +11644,10 @@ func (s *OpenTracingLayerThreadStore) MarkAllAsReadByChannels(userID string, cha
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ThreadStore.MarkAllAsReadByChannels(userID, channelIDs)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -11102,11 +11661,10 @@ func (s *OpenTracingLayerThreadStore) MarkAllAsReadByTeam(userID string, teamID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ThreadStore.MarkAllAsReadByTeam(userID, teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -11120,11 +11678,10 @@ func (s *OpenTracingLayerThreadStore) MarkAsRead(userID string, threadID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.ThreadStore.MarkAsRead(userID, threadID, timestamp)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -11138,11 +11695,10 @@ func (s *OpenTracingLayerThreadStore) PermanentDeleteBatchForRetentionPolicies(n
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, resultVar1, err := s.ThreadStore.PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, resultVar1, err
@@ -11156,11 +11712,10 @@ func (s *OpenTracingLayerThreadStore) PermanentDeleteBatchThreadMembershipsForRe
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, resultVar1, err := s.ThreadStore.PermanentDeleteBatchThreadMembershipsForRetentionPolicies(now, globalPolicyEndTime, limit, cursor)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, resultVar1, err
@@ -11174,11 +11729,10 @@ func (s *OpenTracingLayerThreadStore) SaveMultipleMemberships(memberships []*mod
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.SaveMultipleMemberships(memberships)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11192,11 +11746,10 @@ func (s *OpenTracingLayerThreadStore) UpdateMembership(membership *model.ThreadM
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.ThreadStore.UpdateMembership(membership)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11210,7 +11763,7 @@ func (s *OpenTracingLayerTokenStore) Cleanup(expiryTime int64) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.TokenStore.Cleanup(expiryTime)
 
 }
@@ -11223,11 +11776,10 @@ func (s *OpenTracingLayerTokenStore) Delete(token string) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.TokenStore.Delete(token)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -11241,11 +11793,10 @@ func (s *OpenTracingLayerTokenStore) GetAllTokensByType(tokenType string) ([]*mo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TokenStore.GetAllTokensByType(tokenType)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11259,11 +11810,10 @@ func (s *OpenTracingLayerTokenStore) GetByToken(token string) (*model.Token, err
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.TokenStore.GetByToken(token)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11277,11 +11827,10 @@ func (s *OpenTracingLayerTokenStore) RemoveAllTokensByType(tokenType string) err
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.TokenStore.RemoveAllTokensByType(tokenType)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -11295,11 +11844,10 @@ func (s *OpenTracingLayerTokenStore) Save(recovery *model.Token) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.TokenStore.Save(recovery)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -11313,11 +11861,10 @@ func (s *OpenTracingLayerUploadSessionStore) Delete(id string) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UploadSessionStore.Delete(id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -11331,11 +11878,10 @@ func (s *OpenTracingLayerUploadSessionStore) Get(c request.CTX, id string) (*mod
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UploadSessionStore.Get(c, id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11349,11 +11895,10 @@ func (s *OpenTracingLayerUploadSessionStore) GetForUser(userID string) ([]*model
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UploadSessionStore.GetForUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11367,11 +11912,10 @@ func (s *OpenTracingLayerUploadSessionStore) Save(session *model.UploadSession)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UploadSessionStore.Save(session)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11385,11 +11929,10 @@ func (s *OpenTracingLayerUploadSessionStore) Update(session *model.UploadSession
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UploadSessionStore.Update(session)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -11403,11 +11946,10 @@ func (s *OpenTracingLayerUserStore) AnalyticsActiveCount(timestamp int64, option
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.AnalyticsActiveCount(timestamp, options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11421,11 +11963,10 @@ func (s *OpenTracingLayerUserStore) AnalyticsActiveCountForPeriod(startTime int6
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.AnalyticsActiveCountForPeriod(startTime, endTime, options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11439,11 +11980,10 @@ func (s *OpenTracingLayerUserStore) AnalyticsGetExternalUsers(hostDomain string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.AnalyticsGetExternalUsers(hostDomain)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11457,11 +11997,10 @@ func (s *OpenTracingLayerUserStore) AnalyticsGetGuestCount() (int64, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.AnalyticsGetGuestCount()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11475,11 +12014,10 @@ func (s *OpenTracingLayerUserStore) AnalyticsGetInactiveUsersCount() (int64, err
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.AnalyticsGetInactiveUsersCount()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11493,11 +12031,10 @@ func (s *OpenTracingLayerUserStore) AnalyticsGetSystemAdminCount() (int64, error
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.AnalyticsGetSystemAdminCount()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11511,11 +12048,10 @@ func (s *OpenTracingLayerUserStore) AutocompleteUsersInChannel(rctx request.CTX,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.AutocompleteUsersInChannel(rctx, teamID, channelID, term, options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11529,11 +12065,10 @@ func (s *OpenTracingLayerUserStore) ClearAllCustomRoleAssignments() error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserStore.ClearAllCustomRoleAssignments()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -11547,7 +12082,7 @@ func (s *OpenTracingLayerUserStore) ClearCaches() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.UserStore.ClearCaches()
 
 }
@@ -11560,11 +12095,10 @@ func (s *OpenTracingLayerUserStore) Count(options model.UserCountOptions) (int64
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.Count(options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11578,11 +12112,10 @@ func (s *OpenTracingLayerUserStore) DeactivateGuests() ([]string, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.DeactivateGuests()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11596,11 +12129,10 @@ func (s *OpenTracingLayerUserStore) DemoteUserToGuest(userID string) (*model.Use
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.DemoteUserToGuest(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11614,11 +12146,10 @@ func (s *OpenTracingLayerUserStore) Get(ctx context.Context, id string) (*model.
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.Get(ctx, id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11632,11 +12163,10 @@ func (s *OpenTracingLayerUserStore) GetAll() ([]*model.User, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetAll()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11650,11 +12180,10 @@ func (s *OpenTracingLayerUserStore) GetAllAfter(limit int, afterID string) ([]*m
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetAllAfter(limit, afterID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11668,11 +12197,10 @@ func (s *OpenTracingLayerUserStore) GetAllNotInAuthService(authServices []string
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetAllNotInAuthService(authServices)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11686,11 +12214,10 @@ func (s *OpenTracingLayerUserStore) GetAllProfiles(options *model.UserGetOptions
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetAllProfiles(options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11704,11 +12231,10 @@ func (s *OpenTracingLayerUserStore) GetAllProfilesInChannel(ctx context.Context,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetAllProfilesInChannel(ctx, channelID, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11722,11 +12248,10 @@ func (s *OpenTracingLayerUserStore) GetAllUsingAuthService(authService string) (
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetAllUsingAuthService(authService)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11740,11 +12265,10 @@ func (s *OpenTracingLayerUserStore) GetAnyUnreadPostCountForChannel(userID strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetAnyUnreadPostCountForChannel(userID, channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11758,11 +12282,10 @@ func (s *OpenTracingLayerUserStore) GetByAuth(authData *string, authService stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetByAuth(authData, authService)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11776,11 +12299,10 @@ func (s *OpenTracingLayerUserStore) GetByEmail(email string) (*model.User, error
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetByEmail(email)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11794,11 +12316,10 @@ func (s *OpenTracingLayerUserStore) GetByRemoteID(remoteID string) (*model.User,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetByRemoteID(remoteID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11812,11 +12333,10 @@ func (s *OpenTracingLayerUserStore) GetByUsername(username string) (*model.User,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetByUsername(username)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11830,11 +12350,10 @@ func (s *OpenTracingLayerUserStore) GetChannelGroupUsers(channelID string) ([]*m
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetChannelGroupUsers(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11848,7 +12367,7 @@ func (s *OpenTracingLayerUserStore) GetEtagForAllProfiles() string {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result := s.UserStore.GetEtagForAllProfiles()
 	return result
 }
@@ -11861,7 +12380,7 @@ func (s *OpenTracingLayerUserStore) GetEtagForProfiles(teamID string) string {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result := s.UserStore.GetEtagForProfiles(teamID)
 	return result
 }
@@ -11874,7 +12393,7 @@ func (s *OpenTracingLayerUserStore) GetEtagForProfilesNotInTeam(teamID string) s
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result := s.UserStore.GetEtagForProfilesNotInTeam(teamID)
 	return result
 }
@@ -11887,11 +12406,10 @@ func (s *OpenTracingLayerUserStore) GetForLogin(loginID string, allowSignInWithU
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetForLogin(loginID, allowSignInWithUsername, allowSignInWithEmail)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11905,11 +12423,10 @@ func (s *OpenTracingLayerUserStore) GetKnownUsers(userID string) ([]string, erro
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetKnownUsers(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11923,11 +12440,10 @@ func (s *OpenTracingLayerUserStore) GetMany(ctx context.Context, ids []string) (
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetMany(ctx, ids)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11941,11 +12457,10 @@ func (s *OpenTracingLayerUserStore) GetMfaUsedTimestamps(userID string) ([]int,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetMfaUsedTimestamps(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11959,11 +12474,10 @@ func (s *OpenTracingLayerUserStore) GetNewUsersForTeam(teamID string, offset int
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetNewUsersForTeam(teamID, offset, limit, viewRestrictions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11977,11 +12491,10 @@ func (s *OpenTracingLayerUserStore) GetProfileByGroupChannelIdsForUser(userID st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetProfileByGroupChannelIdsForUser(userID, channelIds)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -11995,11 +12508,10 @@ func (s *OpenTracingLayerUserStore) GetProfileByIds(ctx context.Context, userIds
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetProfileByIds(ctx, userIds, options, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12013,11 +12525,10 @@ func (s *OpenTracingLayerUserStore) GetProfiles(options *model.UserGetOptions) (
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetProfiles(options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12031,11 +12542,10 @@ func (s *OpenTracingLayerUserStore) GetProfilesByUsernames(usernames []string, v
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetProfilesByUsernames(usernames, viewRestrictions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12049,11 +12559,10 @@ func (s *OpenTracingLayerUserStore) GetProfilesInChannel(options *model.UserGetO
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetProfilesInChannel(options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12067,11 +12576,10 @@ func (s *OpenTracingLayerUserStore) GetProfilesInChannelByAdmin(options *model.U
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetProfilesInChannelByAdmin(options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12085,11 +12593,10 @@ func (s *OpenTracingLayerUserStore) GetProfilesInChannelByStatus(options *model.
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetProfilesInChannelByStatus(options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12103,11 +12610,10 @@ func (s *OpenTracingLayerUserStore) GetProfilesNotInChannel(teamID string, chann
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetProfilesNotInChannel(teamID, channelID, groupConstrained, offset, limit, viewRestrictions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12121,11 +12627,10 @@ func (s *OpenTracingLayerUserStore) GetProfilesNotInTeam(teamID string, groupCon
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetProfilesNotInTeam(teamID, groupConstrained, offset, limit, viewRestrictions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12139,11 +12644,10 @@ func (s *OpenTracingLayerUserStore) GetProfilesWithoutTeam(options *model.UserGe
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetProfilesWithoutTeam(options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12157,11 +12661,27 @@ func (s *OpenTracingLayerUserStore) GetRecentlyActiveUsersForTeam(teamID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetRecentlyActiveUsersForTeam(teamID, offset, limit, viewRestrictions)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerUserStore) GetScimProfiles(offset int, limit int) ([]*model.User, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "UserStore.GetScimProfiles")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.UserStore.GetScimProfiles(offset, limit)
+	if err != nil {
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12175,11 +12695,10 @@ func (s *OpenTracingLayerUserStore) GetSystemAdminProfiles() (map[string]*model.
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetSystemAdminProfiles()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12193,11 +12712,10 @@ func (s *OpenTracingLayerUserStore) GetTeamGroupUsers(teamID string) ([]*model.U
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetTeamGroupUsers(teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12211,11 +12729,10 @@ func (s *OpenTracingLayerUserStore) GetUnreadCount(userID string, isCRTEnabled b
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetUnreadCount(userID, isCRTEnabled)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12229,11 +12746,10 @@ func (s *OpenTracingLayerUserStore) GetUnreadCountForChannel(userID string, chan
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetUnreadCountForChannel(userID, channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12247,11 +12763,10 @@ func (s *OpenTracingLayerUserStore) GetUserCountForReport(filter *model.UserRepo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetUserCountForReport(filter)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12265,11 +12780,10 @@ func (s *OpenTracingLayerUserStore) GetUserReport(filter *model.UserReportOption
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetUserReport(filter)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12283,11 +12797,10 @@ func (s *OpenTracingLayerUserStore) GetUsersBatchForIndexing(startTime int64, st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetUsersBatchForIndexing(startTime, startFileID, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12301,11 +12814,10 @@ func (s *OpenTracingLayerUserStore) GetUsersWithInvalidEmails(page int, perPage
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.GetUsersWithInvalidEmails(page, perPage, restrictedDomains)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12319,11 +12831,10 @@ func (s *OpenTracingLayerUserStore) InferSystemInstallDate() (int64, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.InferSystemInstallDate()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12337,11 +12848,10 @@ func (s *OpenTracingLayerUserStore) InsertUsers(users []*model.User) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserStore.InsertUsers(users)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -12355,7 +12865,7 @@ func (s *OpenTracingLayerUserStore) InvalidateProfileCacheForUser(userID string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.UserStore.InvalidateProfileCacheForUser(userID)
 
 }
@@ -12368,7 +12878,7 @@ func (s *OpenTracingLayerUserStore) InvalidateProfilesInChannelCache(channelID s
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.UserStore.InvalidateProfilesInChannelCache(channelID)
 
 }
@@ -12381,7 +12891,7 @@ func (s *OpenTracingLayerUserStore) InvalidateProfilesInChannelCacheByUser(userI
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.UserStore.InvalidateProfilesInChannelCacheByUser(userID)
 
 }
@@ -12394,11 +12904,10 @@ func (s *OpenTracingLayerUserStore) IsEmpty(excludeBots bool) (bool, error) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.IsEmpty(excludeBots)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12412,11 +12921,10 @@ func (s *OpenTracingLayerUserStore) PermanentDelete(rctx request.CTX, userID str
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserStore.PermanentDelete(rctx, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -12430,11 +12938,10 @@ func (s *OpenTracingLayerUserStore) PromoteGuestToUser(userID string) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserStore.PromoteGuestToUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -12448,11 +12955,10 @@ func (s *OpenTracingLayerUserStore) RefreshPostStatsForUsers() error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserStore.RefreshPostStatsForUsers()
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -12466,11 +12972,10 @@ func (s *OpenTracingLayerUserStore) ResetAuthDataToEmailForUsers(service string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.ResetAuthDataToEmailForUsers(service, userIDs, includeDeleted, dryRun)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12484,11 +12989,10 @@ func (s *OpenTracingLayerUserStore) ResetLastPictureUpdate(userID string) error
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserStore.ResetLastPictureUpdate(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -12502,11 +13006,10 @@ func (s *OpenTracingLayerUserStore) Save(rctx request.CTX, user *model.User) (*m
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.Save(rctx, user)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12520,11 +13023,10 @@ func (s *OpenTracingLayerUserStore) Search(rctx request.CTX, teamID string, term
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.Search(rctx, teamID, term, options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12538,11 +13040,10 @@ func (s *OpenTracingLayerUserStore) SearchInChannel(channelID string, term strin
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.SearchInChannel(channelID, term, options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12556,11 +13057,10 @@ func (s *OpenTracingLayerUserStore) SearchInGroup(groupID string, term string, o
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.SearchInGroup(groupID, term, options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12574,11 +13074,10 @@ func (s *OpenTracingLayerUserStore) SearchNotInChannel(teamID string, channelID
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.SearchNotInChannel(teamID, channelID, term, options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12592,11 +13091,10 @@ func (s *OpenTracingLayerUserStore) SearchNotInGroup(groupID string, term string
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.SearchNotInGroup(groupID, term, options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12610,11 +13108,10 @@ func (s *OpenTracingLayerUserStore) SearchNotInTeam(notInTeamID string, term str
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.SearchNotInTeam(notInTeamID, term, options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12628,11 +13125,10 @@ func (s *OpenTracingLayerUserStore) SearchWithoutTeam(term string, options *mode
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.SearchWithoutTeam(term, options)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12646,11 +13142,10 @@ func (s *OpenTracingLayerUserStore) StoreMfaUsedTimestamps(userID string, ts []i
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserStore.StoreMfaUsedTimestamps(userID, ts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -12664,11 +13159,10 @@ func (s *OpenTracingLayerUserStore) Update(rctx request.CTX, user *model.User, a
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.Update(rctx, user, allowRoleUpdate)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12682,11 +13176,10 @@ func (s *OpenTracingLayerUserStore) UpdateAuthData(userID string, service string
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.UpdateAuthData(userID, service, authData, email, resetMfa)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12700,11 +13193,10 @@ func (s *OpenTracingLayerUserStore) UpdateFailedPasswordAttempts(userID string,
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserStore.UpdateFailedPasswordAttempts(userID, attempts)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -12718,11 +13210,10 @@ func (s *OpenTracingLayerUserStore) UpdateLastLogin(userID string, lastLogin int
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserStore.UpdateLastLogin(userID, lastLogin)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -12736,11 +13227,10 @@ func (s *OpenTracingLayerUserStore) UpdateLastPictureUpdate(userID string) error
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserStore.UpdateLastPictureUpdate(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -12754,11 +13244,10 @@ func (s *OpenTracingLayerUserStore) UpdateMfaActive(userID string, active bool)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserStore.UpdateMfaActive(userID, active)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -12772,11 +13261,10 @@ func (s *OpenTracingLayerUserStore) UpdateMfaSecret(userID string, secret string
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserStore.UpdateMfaSecret(userID, secret)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -12790,11 +13278,10 @@ func (s *OpenTracingLayerUserStore) UpdateNotifyProps(userID string, props map[s
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserStore.UpdateNotifyProps(userID, props)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -12808,11 +13295,10 @@ func (s *OpenTracingLayerUserStore) UpdatePassword(userID string, newPassword st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserStore.UpdatePassword(userID, newPassword)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -12826,11 +13312,10 @@ func (s *OpenTracingLayerUserStore) UpdateUpdateAt(userID string) (int64, error)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.UpdateUpdateAt(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12844,11 +13329,10 @@ func (s *OpenTracingLayerUserStore) VerifyEmail(userID string, email string) (st
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserStore.VerifyEmail(userID, email)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12862,11 +13346,10 @@ func (s *OpenTracingLayerUserAccessTokenStore) Delete(tokenID string) error {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserAccessTokenStore.Delete(tokenID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -12880,11 +13363,10 @@ func (s *OpenTracingLayerUserAccessTokenStore) DeleteAllForUser(userID string) e
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserAccessTokenStore.DeleteAllForUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -12898,11 +13380,10 @@ func (s *OpenTracingLayerUserAccessTokenStore) Get(tokenID string) (*model.UserA
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserAccessTokenStore.Get(tokenID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12916,11 +13397,10 @@ func (s *OpenTracingLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserAccessTokenStore.GetAll(offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12934,11 +13414,10 @@ func (s *OpenTracingLayerUserAccessTokenStore) GetByToken(tokenString string) (*
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserAccessTokenStore.GetByToken(tokenString)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12952,11 +13431,10 @@ func (s *OpenTracingLayerUserAccessTokenStore) GetByUser(userID string, page int
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserAccessTokenStore.GetByUser(userID, page, perPage)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12970,11 +13448,10 @@ func (s *OpenTracingLayerUserAccessTokenStore) Save(token *model.UserAccessToken
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserAccessTokenStore.Save(token)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -12988,11 +13465,10 @@ func (s *OpenTracingLayerUserAccessTokenStore) Search(term string) ([]*model.Use
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserAccessTokenStore.Search(term)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13006,11 +13482,10 @@ func (s *OpenTracingLayerUserAccessTokenStore) UpdateTokenDisable(tokenID string
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserAccessTokenStore.UpdateTokenDisable(tokenID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -13024,11 +13499,10 @@ func (s *OpenTracingLayerUserAccessTokenStore) UpdateTokenEnable(tokenID string)
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserAccessTokenStore.UpdateTokenEnable(tokenID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -13042,11 +13516,10 @@ func (s *OpenTracingLayerUserTermsOfServiceStore) Delete(userID string, termsOfS
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.UserTermsOfServiceStore.Delete(userID, termsOfServiceID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -13060,11 +13533,10 @@ func (s *OpenTracingLayerUserTermsOfServiceStore) GetByUser(userID string) (*mod
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserTermsOfServiceStore.GetByUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13078,11 +13550,231 @@ func (s *OpenTracingLayerUserTermsOfServiceStore) Save(userTermsOfService *model
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.UserTermsOfServiceStore.Save(userTermsOfService)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerWebAuthnCredentialStore) Delete(id string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebAuthnCredentialStore.Delete")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.WebAuthnCredentialStore.Delete(id)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerWebAuthnCredentialStore) DeleteForUser(userID string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebAuthnCredentialStore.DeleteForUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.WebAuthnCredentialStore.DeleteForUser(userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerWebAuthnCredentialStore) Get(id string) (*model.WebAuthnCredential, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebAuthnCredentialStore.Get")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.WebAuthnCredentialStore.Get(id)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerWebAuthnCredentialStore) GetByCredentialId(credentialID string) (*model.WebAuthnCredential, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebAuthnCredentialStore.GetByCredentialId")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.WebAuthnCredentialStore.GetByCredentialId(credentialID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerWebAuthnCredentialStore) GetForUser(userID string) ([]*model.WebAuthnCredential, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebAuthnCredentialStore.GetForUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.WebAuthnCredentialStore.GetForUser(userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerWebAuthnCredentialStore) Save(credential *model.WebAuthnCredential) (*model.WebAuthnCredential, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebAuthnCredentialStore.Save")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.WebAuthnCredentialStore.Save(credential)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerWebAuthnCredentialStore) UpdateSignCount(id string, signCount int64, lastUsedAt int64) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebAuthnCredentialStore.UpdateSignCount")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.WebAuthnCredentialStore.UpdateSignCount(id, signCount, lastUsedAt)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerWebPushSubscriptionStore) Delete(id string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebPushSubscriptionStore.Delete")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.WebPushSubscriptionStore.Delete(id)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerWebPushSubscriptionStore) DeleteForSession(sessionID string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebPushSubscriptionStore.DeleteForSession")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.WebPushSubscriptionStore.DeleteForSession(sessionID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerWebPushSubscriptionStore) DeleteForUser(userID string) error {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebPushSubscriptionStore.DeleteForUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	err := s.WebPushSubscriptionStore.DeleteForUser(userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return err
+}
+
+func (s *OpenTracingLayerWebPushSubscriptionStore) DeleteOrphaned() (int64, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebPushSubscriptionStore.DeleteOrphaned")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.WebPushSubscriptionStore.DeleteOrphaned()
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerWebPushSubscriptionStore) GetForUser(userID string) ([]*model.WebPushSubscription, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebPushSubscriptionStore.GetForUser")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.WebPushSubscriptionStore.GetForUser(userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return result, err
+}
+
+func (s *OpenTracingLayerWebPushSubscriptionStore) Save(subscription *model.WebPushSubscription) (*model.WebPushSubscription, error) {
+	origCtx := s.Root.Store.Context()
+	span, newCtx := tracing.StartSpanWithParentByContext(s.Root.Store.Context(), "WebPushSubscriptionStore.Save")
+	s.Root.Store.SetContext(newCtx)
+	defer func() {
+		s.Root.Store.SetContext(origCtx)
+	}()
+
+	defer span.End()
+	result, err := s.WebPushSubscriptionStore.Save(subscription)
+	if err != nil {
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13096,11 +13788,10 @@ func (s *OpenTracingLayerWebhookStore) AnalyticsIncomingCount(teamID string, use
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.AnalyticsIncomingCount(teamID, userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13114,11 +13805,10 @@ func (s *OpenTracingLayerWebhookStore) AnalyticsOutgoingCount(teamID string) (in
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.AnalyticsOutgoingCount(teamID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13132,7 +13822,7 @@ func (s *OpenTracingLayerWebhookStore) ClearCaches() {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.WebhookStore.ClearCaches()
 
 }
@@ -13145,11 +13835,10 @@ func (s *OpenTracingLayerWebhookStore) DeleteIncoming(webhookID string, timestam
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.WebhookStore.DeleteIncoming(webhookID, timestamp)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -13163,11 +13852,10 @@ func (s *OpenTracingLayerWebhookStore) DeleteOutgoing(webhookID string, timestam
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.WebhookStore.DeleteOutgoing(webhookID, timestamp)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -13181,11 +13869,10 @@ func (s *OpenTracingLayerWebhookStore) GetIncoming(id string, allowFromCache boo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.GetIncoming(id, allowFromCache)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13199,11 +13886,10 @@ func (s *OpenTracingLayerWebhookStore) GetIncomingByChannel(channelID string) ([
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.GetIncomingByChannel(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13217,11 +13903,10 @@ func (s *OpenTracingLayerWebhookStore) GetIncomingByTeam(teamID string, offset i
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.GetIncomingByTeam(teamID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13235,11 +13920,10 @@ func (s *OpenTracingLayerWebhookStore) GetIncomingByTeamByUser(teamID string, us
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.GetIncomingByTeamByUser(teamID, userID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13253,11 +13937,10 @@ func (s *OpenTracingLayerWebhookStore) GetIncomingList(offset int, limit int) ([
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.GetIncomingList(offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13271,11 +13954,10 @@ func (s *OpenTracingLayerWebhookStore) GetIncomingListByUser(userID string, offs
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.GetIncomingListByUser(userID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13289,11 +13971,10 @@ func (s *OpenTracingLayerWebhookStore) GetOutgoing(id string) (*model.OutgoingWe
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.GetOutgoing(id)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13307,11 +13988,10 @@ func (s *OpenTracingLayerWebhookStore) GetOutgoingByChannel(channelID string, of
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.GetOutgoingByChannel(channelID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13325,11 +14005,10 @@ func (s *OpenTracingLayerWebhookStore) GetOutgoingByChannelByUser(channelID stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.GetOutgoingByChannelByUser(channelID, userID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13343,11 +14022,10 @@ func (s *OpenTracingLayerWebhookStore) GetOutgoingByTeam(teamID string, offset i
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.GetOutgoingByTeam(teamID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13361,11 +14039,10 @@ func (s *OpenTracingLayerWebhookStore) GetOutgoingByTeamByUser(teamID string, us
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.GetOutgoingByTeamByUser(teamID, userID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13379,11 +14056,10 @@ func (s *OpenTracingLayerWebhookStore) GetOutgoingList(offset int, limit int) ([
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.GetOutgoingList(offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13397,11 +14073,10 @@ func (s *OpenTracingLayerWebhookStore) GetOutgoingListByUser(userID string, offs
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.GetOutgoingListByUser(userID, offset, limit)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13415,7 +14090,7 @@ func (s *OpenTracingLayerWebhookStore) InvalidateWebhookCache(webhook string) {
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	s.WebhookStore.InvalidateWebhookCache(webhook)
 
 }
@@ -13428,11 +14103,10 @@ func (s *OpenTracingLayerWebhookStore) PermanentDeleteIncomingByChannel(channelI
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.WebhookStore.PermanentDeleteIncomingByChannel(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -13446,11 +14120,10 @@ func (s *OpenTracingLayerWebhookStore) PermanentDeleteIncomingByUser(userID stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.WebhookStore.PermanentDeleteIncomingByUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -13464,11 +14137,10 @@ func (s *OpenTracingLayerWebhookStore) PermanentDeleteOutgoingByChannel(channelI
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.WebhookStore.PermanentDeleteOutgoingByChannel(channelID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -13482,11 +14154,10 @@ func (s *OpenTracingLayerWebhookStore) PermanentDeleteOutgoingByUser(userID stri
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	err := s.WebhookStore.PermanentDeleteOutgoingByUser(userID)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return err
@@ -13500,11 +14171,10 @@ func (s *OpenTracingLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebho
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.SaveIncoming(webhook)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13518,11 +14188,10 @@ func (s *OpenTracingLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebho
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.SaveOutgoing(webhook)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13536,11 +14205,10 @@ func (s *OpenTracingLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWeb
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.UpdateIncoming(webhook)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13554,11 +14222,10 @@ func (s *OpenTracingLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhoo
 		s.Root.Store.SetContext(origCtx)
 	}()
 
-	defer span.Finish()
+	defer span.End()
 	result, err := s.WebhookStore.UpdateOutgoing(hook)
 	if err != nil {
-		span.LogFields(spanlog.Error(err))
-		ext.Error.Set(span, true)
+		tracing.RecordError(span, err)
 	}
 
 	return result, err
@@ -13619,13 +14286,18 @@ func New(childStore store.Store, ctx context.Context) *OpenTracingLayer {
 	newStore.EmojiStore = &OpenTracingLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
 	newStore.FileInfoStore = &OpenTracingLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
 	newStore.GroupStore = &OpenTracingLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
+	newStore.ImportIdMappingStore = &OpenTracingLayerImportIdMappingStore{ImportIdMappingStore: childStore.ImportIdMapping(), Root: &newStore}
 	newStore.JobStore = &OpenTracingLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
+	newStore.LegalHoldStore = &OpenTracingLayerLegalHoldStore{LegalHoldStore: childStore.LegalHold(), Root: &newStore}
 	newStore.LicenseStore = &OpenTracingLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &OpenTracingLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.MfaRecoveryCodeStore = &OpenTracingLayerMfaRecoveryCodeStore{MfaRecoveryCodeStore: childStore.MfaRecoveryCode(), Root: &newStore}
 	newStore.NotifyAdminStore = &OpenTracingLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &OpenTracingLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &OpenTracingLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
+	newStore.OutgoingWebhookDeliveryStore = &OpenTracingLayerOutgoingWebhookDeliveryStore{OutgoingWebhookDeliveryStore: childStore.OutgoingWebhookDelivery(), Root: &newStore}
 	newStore.PluginStore = &OpenTracingLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
+	newStore.PollStore = &OpenTracingLayerPollStore{PollStore: childStore.Poll(), Root: &newStore}
 	newStore.PostStore = &OpenTracingLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
 	newStore.PostAcknowledgementStore = &OpenTracingLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
 	newStore.PostPersistentNotificationStore = &OpenTracingLayerPostPersistentNotificationStore{PostPersistentNotificationStore: childStore.PostPersistentNotification(), Root: &newStore}
@@ -13633,9 +14305,12 @@ func New(childStore store.Store, ctx context.Context) *OpenTracingLayer {
 	newStore.PreferenceStore = &OpenTracingLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
 	newStore.ProductNoticesStore = &OpenTracingLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
 	newStore.ReactionStore = &OpenTracingLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
+	newStore.ReminderStore = &OpenTracingLayerReminderStore{ReminderStore: childStore.Reminder(), Root: &newStore}
 	newStore.RemoteClusterStore = &OpenTracingLayerRemoteClusterStore{RemoteClusterStore: childStore.RemoteCluster(), Root: &newStore}
 	newStore.RetentionPolicyStore = &OpenTracingLayerRetentionPolicyStore{RetentionPolicyStore: childStore.RetentionPolicy(), Root: &newStore}
 	newStore.RoleStore = &OpenTracingLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.SavedSearchStore = &OpenTracingLayerSavedSearchStore{SavedSearchStore: childStore.SavedSearch(), Root: &newStore}
+	newStore.ScheduledPostStore = &OpenTracingLayerScheduledPostStore{ScheduledPostStore: childStore.ScheduledPost(), Root: &newStore}
 	newStore.SchemeStore = &OpenTracingLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
 	newStore.SessionStore = &OpenTracingLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
 	newStore.SharedChannelStore = &OpenTracingLayerSharedChannelStore{SharedChannelStore: childStore.SharedChannel(), Root: &newStore}
@@ -13649,6 +14324,8 @@ func New(childStore store.Store, ctx context.Context) *OpenTracingLayer {
 	newStore.UserStore = &OpenTracingLayerUserStore{UserStore: childStore.User(), Root: &newStore}
 	newStore.UserAccessTokenStore = &OpenTracingLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
 	newStore.UserTermsOfServiceStore = &OpenTracingLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
+	newStore.WebAuthnCredentialStore = &OpenTracingLayerWebAuthnCredentialStore{WebAuthnCredentialStore: childStore.WebAuthnCredential(), Root: &newStore}
+	newStore.WebPushSubscriptionStore = &OpenTracingLayerWebPushSubscriptionStore{WebPushSubscriptionStore: childStore.WebPushSubscription(), Root: &newStore}
 	newStore.WebhookStore = &OpenTracingLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
 	return &newStore
 }
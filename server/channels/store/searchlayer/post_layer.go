@@ -185,8 +185,16 @@ func (s SearchPostStore) searchPostsForUserByEngine(engine searchengine.SearchEn
 }
 
 func (s SearchPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.PostSearchResults, error) {
+	var unsupportedErr *model.AppError
 	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
 		if engine.IsSearchEnabled() {
+			// Searches the engine can't run are left to the database
+			if appErr := searchengine.CheckSearchFilters(engine, paramsList); appErr != nil {
+				rctx.Logger().Debug("Search engine doesn't support the search.", mlog.String("search_engine", engine.GetName()), mlog.Err(appErr))
+				unsupportedErr = appErr
+				continue
+			}
+
 			results, err := s.searchPostsForUserByEngine(engine, paramsList, userId, teamId, page, perPage)
 			if err != nil {
 				rctx.Logger().Warn("Encountered error on SearchPostsInTeamForUser.", mlog.String("search_engine", engine.GetName()), mlog.Err(err))
@@ -197,6 +205,9 @@ func (s SearchPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*mode
 	}
 
 	if *s.rootStore.getConfig().SqlSettings.DisableDatabaseSearch {
+		if unsupportedErr != nil {
+			return nil, unsupportedErr
+		}
 		return &model.PostSearchResults{PostList: model.NewPostList(), Matches: model.PostSearchMatches{}}, nil
 	}
 
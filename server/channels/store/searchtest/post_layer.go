@@ -15,6 +15,16 @@ import (
 )
 
 var searchPostStoreTests = []searchTest{
+	{
+		Name: "Should be able to search with boolean groups and post filters",
+		Fn:   testSearchWithBooleanGroupsAndPostFilters,
+		Tags: []string{EngineAll},
+	},
+	{
+		Name: "Should be able to filter posts by reactions, priority and mentions",
+		Fn:   testSearchReactionsPriorityAndMentionsFilters,
+		Tags: []string{EnginePostgres, EngineMySQL},
+	},
 	{
 		Name: "Should be able to search posts including results from DMs",
 		Fn:   testSearchPostsIncludingDMs,
@@ -280,6 +290,11 @@ var searchPostStoreTests = []searchTest{
 		Fn:   testSearchPostDeleted,
 		Tags: []string{EngineAll},
 	},
+	{
+		Name: "Should return the words matched in each post",
+		Fn:   testSearchReturnsMatches,
+		Tags: []string{EngineMySQL, EnginePostgres},
+	},
 }
 
 func TestSearchPostStore(t *testing.T, s store.Store, testEngine *SearchTestEngine) {
@@ -1988,3 +2003,102 @@ func testSearchPostDeleted(t *testing.T, th *SearchTestHelper) {
 		require.Len(t, results.Posts, 0)
 	})
 }
+
+func testSearchReturnsMatches(t *testing.T, th *SearchTestHelper) {
+	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "the release notes are ready", "", model.PostTypeDefault, 0, false)
+	require.NoError(t, err)
+	p2, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "Release day, notes to follow", "", model.PostTypeDefault, 0, false)
+	require.NoError(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	params := &model.SearchParams{Terms: "release notes"}
+	results, err := th.Store.Post().SearchPostsForUser(th.Context, []*model.SearchParams{params}, th.User.Id, th.Team.Id, 0, 20)
+	require.NoError(t, err)
+
+	require.Len(t, results.Posts, 2)
+	require.Equal(t, []string{"release", "notes"}, results.Matches[p1.Id])
+	require.Equal(t, []string{"Release", "notes"}, results.Matches[p2.Id])
+}
+
+func searchQueryParams(t *testing.T, text string) []*model.SearchParams {
+	t.Helper()
+
+	query, appErr := model.ParseSearchQuery(text)
+	require.Nil(t, appErr)
+	paramsList, appErr := query.SearchParams(0)
+	require.Nil(t, appErr)
+	return paramsList
+}
+
+func testSearchWithBooleanGroupsAndPostFilters(t *testing.T, th *SearchTestHelper) {
+	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "apple pie", "", model.PostTypeDefault, 0, true)
+	require.NoError(t, err)
+	p2, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "banana pie", "", model.PostTypeDefault, 0, false)
+	require.NoError(t, err)
+	p3, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "cherry pie", "", model.PostTypeDefault, 0, false)
+	require.NoError(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	t.Run("alternatives", func(t *testing.T) {
+		results, err := th.Store.Post().SearchPostsForUser(th.Context, searchQueryParams(t, "pie (apple OR banana)"), th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+
+		require.Len(t, results.Posts, 2)
+		th.checkPostInSearchResults(t, p1.Id, results.Posts)
+		th.checkPostInSearchResults(t, p2.Id, results.Posts)
+	})
+
+	t.Run("negated groups", func(t *testing.T) {
+		results, err := th.Store.Post().SearchPostsForUser(th.Context, searchQueryParams(t, "pie -(apple OR banana)"), th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p3.Id, results.Posts)
+	})
+
+	t.Run("pinned posts", func(t *testing.T) {
+		results, err := th.Store.Post().SearchPostsForUser(th.Context, searchQueryParams(t, "pie is:pinned"), th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+
+		require.Len(t, results.Posts, 1)
+		th.checkPostInSearchResults(t, p1.Id, results.Posts)
+
+		results, err = th.Store.Post().SearchPostsForUser(th.Context, searchQueryParams(t, "pie -is:pinned"), th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+
+		require.Len(t, results.Posts, 2)
+		th.checkPostInSearchResults(t, p2.Id, results.Posts)
+		th.checkPostInSearchResults(t, p3.Id, results.Posts)
+	})
+}
+
+func testSearchReactionsPriorityAndMentionsFilters(t *testing.T, th *SearchTestHelper) {
+	urgent := th.createPostModel(th.User.Id, th.ChannelBasic.Id, "deploy now @"+th.User2.Username, "", model.PostTypeDefault, 1000000, false)
+	urgent.Metadata = &model.PostMetadata{Priority: &model.PostPriority{Priority: model.NewPointer(model.PostPriorityUrgent)}}
+	p1, err := th.Store.Post().Save(th.Context, urgent)
+	require.NoError(t, err)
+	p2, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "deploy later", "", model.PostTypeDefault, 0, false)
+	require.NoError(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	for _, emoji := range []string{"smile", "tada"} {
+		_, err = th.Store.Reaction().Save(&model.Reaction{UserId: th.User.Id, PostId: p2.Id, EmojiName: emoji, ChannelId: p2.ChannelId})
+		require.NoError(t, err)
+	}
+
+	for text, expected := range map[string]*model.Post{
+		"deploy reactions:>1":                            p2,
+		"deploy reactions:0":                             p1,
+		"deploy priority:urgent":                         p1,
+		"deploy -priority:urgent":                        p2,
+		"deploy mentions:@" + th.User2.Username:          p1,
+		"deploy -mentions:" + th.User2.Username:          p2,
+		"deploy (priority:urgent OR reactions:>=2) -now": p2,
+	} {
+		results, err := th.Store.Post().SearchPostsForUser(th.Context, searchQueryParams(t, text), th.User.Id, th.Team.Id, 0, 20)
+		require.NoError(t, err)
+
+		require.Len(t, results.Posts, 1, text)
+		th.checkPostInSearchResults(t, expected.Id, results.Posts)
+	}
+}
@@ -158,6 +158,15 @@ func (s SqlChannelMemberHistoryStore) getFromChannelMembersTable(startTime int64
 	return histories, nil
 }
 
+// channelMemberHistoryLegalHoldScope matches the channel memberships held by
+// legal holds, so that exports of the held channels keep their participants.
+var channelMemberHistoryLegalHoldScope = legalHoldScope{
+	StartColumn:   "ChannelMemberHistory.JoinTime",
+	EndColumn:     "ChannelMemberHistory.LeaveTime",
+	ChannelColumn: "ChannelMemberHistory.ChannelId",
+	UserColumn:    "ChannelMemberHistory.UserId",
+}
+
 // PermanentDeleteBatchForRetentionPolicies deletes a batch of records which are affected by
 // the global or a granular retention policy.
 // See `genericPermanentDeleteBatchForRetentionPolicies` for details.
@@ -175,6 +184,7 @@ func (s SqlChannelMemberHistoryStore) PermanentDeleteBatchForRetentionPolicies(n
 		GlobalPolicyEndTime: globalPolicyEndTime,
 		Limit:               limit,
 		StoreDeletedIds:     false,
+		LegalHold:           channelMemberHistoryLegalHoldScope,
 	}, s.SqlStore, cursor)
 }
 
@@ -213,6 +223,7 @@ func (s SqlChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit
 			Where(sq.And{
 				sq.NotEq{"LeaveTime": nil},
 				sq.LtOrEq{"LeaveTime": endTime},
+				channelMemberHistoryLegalHoldScope.notHeld(),
 			}).Limit(uint64(limit)).
 			ToSql()
 		if err != nil {
@@ -229,6 +240,7 @@ func (s SqlChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit
 			Where(sq.And{
 				sq.NotEq{"LeaveTime": nil},
 				sq.LtOrEq{"LeaveTime": endTime},
+				channelMemberHistoryLegalHoldScope.notHeld(),
 			}).
 			Limit(uint64(limit)).ToSql()
 	}
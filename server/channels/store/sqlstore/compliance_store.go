@@ -268,10 +268,72 @@ func (s SqlComplianceStore) ComplianceExport(job *model.Compliance, cursor model
 		}
 	}
 
-	return append(channelPosts, directMessagePosts...), cursor, nil
+	cposts := append(channelPosts, directMessagePosts...)
+
+	pollPostIDs := []string{}
+	for _, cpost := range cposts {
+		if cpost.PostType == model.PostTypePoll {
+			pollPostIDs = append(pollPostIDs, cpost.PostId)
+		}
+	}
+	polls, err := s.getPollsForPosts(pollPostIDs)
+	if err != nil {
+		return nil, cursor, err
+	}
+	for _, cpost := range cposts {
+		if poll, ok := polls[cpost.PostId]; ok {
+			cpost.PostProps = model.AddPollToPostProps(cpost.PostProps, poll)
+		}
+	}
+
+	return cposts, cursor, nil
+}
+
+// getPollsForPosts returns the polls held by the given posts along with their
+// results, keyed by post id.
+func (s SqlComplianceStore) getPollsForPosts(postIDs []string) (map[string]*model.Poll, error) {
+	pollsByPost := make(map[string]*model.Poll, len(postIDs))
+	if len(postIDs) == 0 {
+		return pollsByPost, nil
+	}
+
+	polls, err := s.Poll().GetForPosts(postIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get polls for export")
+	}
+
+	pollIDs := make([]string, 0, len(polls))
+	for _, poll := range polls {
+		pollIDs = append(pollIDs, poll.Id)
+	}
+	votes, err := s.Poll().GetVotesForPolls(pollIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get poll votes for export")
+	}
+	votesByPoll := make(map[string][]*model.PollVote, len(polls))
+	for _, vote := range votes {
+		votesByPoll[vote.PollId] = append(votesByPoll[vote.PollId], vote)
+	}
+
+	for _, poll := range polls {
+		poll.SetResults(votesByPoll[poll.Id])
+		pollsByPost[poll.PostId] = poll
+	}
+
+	return pollsByPost, nil
 }
 
 func (s SqlComplianceStore) MessageExport(c request.CTX, cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error) {
+	return s.messageExport(c, nil, cursor, limit)
+}
+
+func (s SqlComplianceStore) LegalHoldMessageExport(c request.CTX, legalHoldID string, cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error) {
+	return s.messageExport(c, postsLegalHoldScope.heldByHold(legalHoldID), cursor, limit)
+}
+
+// messageExport returns the posts to export after the cursor, restricted to
+// the ones matching filter if set.
+func (s SqlComplianceStore) messageExport(c request.CTX, filter sq.Sqlizer, cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error) {
 	caseStmt, caseArgs, caseErr := sq.Case().
 		When(
 			sq.Eq{"Channels.Type": model.ChannelTypeDirect},
@@ -286,7 +348,7 @@ func (s SqlComplianceStore) MessageExport(c request.CTX, cursor model.MessageExp
 		return nil, cursor, errors.Wrap(caseErr, "unable to construct case statement")
 	}
 
-	query, args, err := s.getQueryBuilder().Select(`Posts.Id AS PostId,
+	builder := s.getQueryBuilder().Select(`Posts.Id AS PostId,
 			Posts.CreateAt AS PostCreateAt,
 			Posts.UpdateAt AS PostUpdateAt,
 			Posts.DeleteAt AS PostDeleteAt,
@@ -323,8 +385,12 @@ func (s SqlComplianceStore) MessageExport(c request.CTX, cursor model.MessageExp
 			sq.NotLike{"Posts.Type": "system_%"},
 		}).
 		OrderBy("PostUpdateAt, PostId").
-		Limit(uint64(limit)).
-		ToSql()
+		Limit(uint64(limit))
+	if filter != nil {
+		builder = builder.Where(filter)
+	}
+
+	query, args, err := builder.ToSql()
 	if err != nil {
 		return nil, cursor, errors.Wrap(err, "unable to construct query to export messages")
 	}
@@ -337,5 +403,22 @@ func (s SqlComplianceStore) MessageExport(c request.CTX, cursor model.MessageExp
 		cursor.LastPostUpdateAt = *cposts[len(cposts)-1].PostUpdateAt
 		cursor.LastPostId = *cposts[len(cposts)-1].PostId
 	}
+
+	pollPostIDs := []string{}
+	for _, cpost := range cposts {
+		if cpost.PostType != nil && *cpost.PostType == model.PostTypePoll {
+			pollPostIDs = append(pollPostIDs, *cpost.PostId)
+		}
+	}
+	polls, err := s.getPollsForPosts(pollPostIDs)
+	if err != nil {
+		return nil, cursor, err
+	}
+	for _, cpost := range cposts {
+		if poll, ok := polls[*cpost.PostId]; ok {
+			cpost.PostProps = model.NewPointer(model.AddPollToPostProps(model.SafeDereference(cpost.PostProps), poll))
+		}
+	}
+
 	return cposts, cursor, nil
 }
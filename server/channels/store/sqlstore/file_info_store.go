@@ -42,6 +42,7 @@ type fileInfoWithChannelID struct {
 	Content         string
 	RemoteId        *string
 	Archived        bool
+	ScanStatus      string
 }
 
 func (fi fileInfoWithChannelID) ToModel() *model.FileInfo {
@@ -66,6 +67,7 @@ func (fi fileInfoWithChannelID) ToModel() *model.FileInfo {
 		MiniPreview:     fi.MiniPreview,
 		Content:         fi.Content,
 		RemoteId:        fi.RemoteId,
+		ScanStatus:      fi.ScanStatus,
 	}
 }
 
@@ -106,6 +108,7 @@ func newSqlFileInfoStore(sqlStore *SqlStore, metrics einterfaces.MetricsInterfac
 		"Coalesce(FileInfo.Content, '') AS Content",
 		"Coalesce(FileInfo.RemoteId, '') AS RemoteId",
 		"FileInfo.Archived",
+		"FileInfo.ScanStatus",
 	}
 
 	return s
@@ -120,10 +123,10 @@ func (fs SqlFileInfoStore) Save(rctx request.CTX, info *model.FileInfo) (*model.
 	query := `
 		INSERT INTO FileInfo
 		(Id, CreatorId, PostId, ChannelId, CreateAt, UpdateAt, DeleteAt, Path, ThumbnailPath, PreviewPath,
-			Name, Extension, Size, MimeType, Width, Height, HasPreviewImage, MiniPreview, Content, RemoteId)
+			Name, Extension, Size, MimeType, Width, Height, HasPreviewImage, MiniPreview, Content, RemoteId, ScanStatus)
 		VALUES
 		(:Id, :CreatorId, :PostId, :ChannelId, :CreateAt, :UpdateAt, :DeleteAt, :Path, :ThumbnailPath, :PreviewPath,
-			:Name, :Extension, :Size, :MimeType, :Width, :Height, :HasPreviewImage, :MiniPreview, :Content, :RemoteId)
+			:Name, :Extension, :Size, :MimeType, :Width, :Height, :HasPreviewImage, :MiniPreview, :Content, :RemoteId, :ScanStatus)
 	`
 
 	if _, err := fs.GetMasterX().NamedExec(query, info); err != nil {
@@ -186,6 +189,7 @@ func (fs SqlFileInfoStore) Upsert(rctx request.CTX, info *model.FileInfo) (*mode
 			"MiniPreview":     info.MiniPreview,
 			"Content":         info.Content,
 			"RemoteId":        info.RemoteId,
+			"ScanStatus":      info.ScanStatus,
 		}).
 		Where(sq.Eq{"Id": info.Id}).
 		ToSql()
@@ -442,6 +446,20 @@ func (fs SqlFileInfoStore) SetContent(rctx request.CTX, fileId, content string)
 	return nil
 }
 
+func (fs SqlFileInfoStore) UpdateScanStatus(fileID, scanStatus string) error {
+	query := fs.getQueryBuilder().
+		Update("FileInfo").
+		Set("ScanStatus", scanStatus).
+		Set("UpdateAt", model.GetMillis()).
+		Where(sq.Eq{"Id": fileID})
+
+	if _, err := fs.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to update FileInfo scan status with id=%s", fileID)
+	}
+
+	return nil
+}
+
 func (fs SqlFileInfoStore) DeleteForPost(rctx request.CTX, postId string) (string, error) {
 	if _, err := fs.GetMasterX().Exec(
 		`UPDATE
@@ -469,15 +487,29 @@ func (fs SqlFileInfoStore) PermanentDelete(rctx request.CTX, fileId string) erro
 	return nil
 }
 
+// fileInfoLegalHoldScope matches the files held by legal holds.
+var fileInfoLegalHoldScope = legalHoldScope{
+	StartColumn:   "FileInfo.CreateAt",
+	EndColumn:     "FileInfo.CreateAt",
+	ChannelColumn: "FileInfo.ChannelId",
+	UserColumn:    "FileInfo.CreatorId",
+}
+
 func (fs SqlFileInfoStore) PermanentDeleteBatch(rctx request.CTX, endTime int64, limit int64) (int64, error) {
+	notHeld, notHeldArgs, err := fileInfoLegalHoldScope.notHeld().ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "file_info_tosql")
+	}
+
 	var query string
 	if fs.DriverName() == "postgres" {
-		query = "DELETE from FileInfo WHERE Id = any (array (SELECT Id FROM FileInfo WHERE CreateAt < ? AND CreatorId != ? LIMIT ?))"
+		query = "DELETE from FileInfo WHERE Id = any (array (SELECT Id FROM FileInfo WHERE CreateAt < ? AND CreatorId != ? AND " + notHeld + " LIMIT ?))"
 	} else {
-		query = "DELETE from FileInfo WHERE CreateAt < ? AND CreatorId != ? LIMIT ?"
+		query = "DELETE from FileInfo WHERE CreateAt < ? AND CreatorId != ? AND " + notHeld + " LIMIT ?"
 	}
 
-	sqlResult, err := fs.GetMasterX().Exec(query, endTime, model.BookmarkFileOwner, limit)
+	args := append(append([]any{endTime, model.BookmarkFileOwner}, notHeldArgs...), limit)
+	sqlResult, err := fs.GetMasterX().Exec(query, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to delete FileInfos in batch")
 	}
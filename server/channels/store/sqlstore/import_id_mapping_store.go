@@ -0,0 +1,72 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlImportIdMappingStore struct {
+	*SqlStore
+}
+
+func newSqlImportIdMappingStore(sqlStore *SqlStore) store.ImportIdMappingStore {
+	return &SqlImportIdMappingStore{sqlStore}
+}
+
+func (s *SqlImportIdMappingStore) SaveMultiple(mappings []*model.ImportIdMapping) error {
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	query := s.getQueryBuilder().
+		Insert("ImportIdMappings").
+		Columns("ObjectType", "OldId", "NewId", "CreateAt")
+	createAt := model.GetMillis()
+	for _, mapping := range mappings {
+		if mapping.CreateAt == 0 {
+			mapping.CreateAt = createAt
+		}
+		query = query.Values(mapping.ObjectType, mapping.OldId, mapping.NewId, mapping.CreateAt)
+	}
+
+	if s.DriverName() == model.DatabaseDriverMysql {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE NewId = VALUES(NewId), CreateAt = VALUES(CreateAt)")
+	} else {
+		query = query.Suffix("ON CONFLICT (objecttype, oldid) DO UPDATE SET NewId = excluded.NewId, CreateAt = excluded.CreateAt")
+	}
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to save %d ImportIdMappings", len(mappings))
+	}
+
+	return nil
+}
+
+func (s *SqlImportIdMappingStore) GetNewIds(objectType string, oldIDs []string) (map[string]string, error) {
+	newIDs := make(map[string]string, len(oldIDs))
+	if len(oldIDs) == 0 {
+		return newIDs, nil
+	}
+
+	query := s.getQueryBuilder().
+		Select("OldId", "NewId").
+		From("ImportIdMappings").
+		Where(sq.Eq{"ObjectType": objectType, "OldId": oldIDs})
+
+	var mappings []*model.ImportIdMapping
+	if err := s.GetReplicaX().SelectBuilder(&mappings, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ImportIdMappings with objectType=%s", objectType)
+	}
+
+	for _, mapping := range mappings {
+		newIDs[mapping.OldId] = mapping.NewId
+	}
+
+	return newIDs, nil
+}
@@ -0,0 +1,412 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlLegalHoldStore struct {
+	*SqlStore
+}
+
+func newSqlLegalHoldStore(sqlStore *SqlStore) store.LegalHoldStore {
+	return &SqlLegalHoldStore{sqlStore}
+}
+
+// legalHoldScope describes how the records of a table relate to the content
+// preserved by legal holds.
+type legalHoldScope struct {
+	// StartColumn and EndColumn bound the lifetime of a record, which is held
+	// if it overlaps the date range of a hold. Both are the creation time of
+	// the records which don't span a period of time.
+	StartColumn string
+	EndColumn   string
+	// ChannelColumn and UserColumn hold the channel and the author of a
+	// record. Either may be empty if records of the table have none.
+	ChannelColumn string
+	UserColumn    string
+}
+
+// postsLegalHoldScope matches the posts held by legal holds.
+var postsLegalHoldScope = legalHoldScope{
+	StartColumn:   "Posts.CreateAt",
+	EndColumn:     "Posts.CreateAt",
+	ChannelColumn: "Posts.ChannelId",
+	UserColumn:    "Posts.UserId",
+}
+
+func (l legalHoldScope) isZero() bool {
+	return l.StartColumn == ""
+}
+
+func (l legalHoldScope) heldBy(filter sq.Sqlizer) sq.SelectBuilder {
+	members := sq.Or{}
+	if l.ChannelColumn != "" {
+		members = append(members, sq.Expr("EXISTS (SELECT 1 FROM LegalHoldChannels WHERE LegalHoldChannels.LegalHoldId = LegalHolds.Id AND LegalHoldChannels.ChannelId = "+l.ChannelColumn+")"))
+	}
+	if l.UserColumn != "" {
+		members = append(members, sq.Expr("EXISTS (SELECT 1 FROM LegalHoldUsers WHERE LegalHoldUsers.LegalHoldId = LegalHolds.Id AND LegalHoldUsers.UserId = "+l.UserColumn+")"))
+	}
+
+	// The subquery is built with the default placeholders, they are converted
+	// along with the ones of the query embedding it.
+	return sq.Select("1").
+		From("LegalHolds").
+		Where(filter).
+		Where(l.EndColumn + " >= LegalHolds.StartsAt").
+		Where(sq.Or{
+			sq.Eq{"LegalHolds.EndsAt": 0},
+			sq.Expr(l.StartColumn + " <= LegalHolds.EndsAt"),
+		}).
+		Where(members)
+}
+
+// held returns the condition matching the records preserved by an active
+// legal hold.
+func (l legalHoldScope) held() sq.Sqlizer {
+	return sq.Expr("EXISTS (?)", l.heldBy(sq.Eq{"LegalHolds.DeleteAt": 0}))
+}
+
+// notHeld returns the condition matching the records which no active legal
+// hold preserves, and which are therefore allowed to be deleted.
+func (l legalHoldScope) notHeld() sq.Sqlizer {
+	return sq.Expr("NOT EXISTS (?)", l.heldBy(sq.Eq{"LegalHolds.DeleteAt": 0}))
+}
+
+// heldByHold returns the condition matching the records preserved by the
+// given legal hold, whether it was released or not.
+func (l legalHoldScope) heldByHold(holdID string) sq.Sqlizer {
+	return sq.Expr("EXISTS (?)", l.heldBy(sq.Eq{"LegalHolds.Id": holdID}))
+}
+
+func legalHoldSliceColumns() []string {
+	return []string{
+		"Id",
+		"Name",
+		"Description",
+		"StartsAt",
+		"EndsAt",
+		"CreateAt",
+		"UpdateAt",
+		"DeleteAt",
+	}
+}
+
+func legalHoldToSlice(hold *model.LegalHold) []any {
+	return []any{
+		hold.Id,
+		hold.Name,
+		hold.Description,
+		hold.StartsAt,
+		hold.EndsAt,
+		hold.CreateAt,
+		hold.UpdateAt,
+		hold.DeleteAt,
+	}
+}
+
+func (s *SqlLegalHoldStore) Save(hold *model.LegalHold) (_ *model.LegalHold, err error) {
+	hold.PreSave()
+	if appErr := hold.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	query := s.getQueryBuilder().
+		Insert("LegalHolds").
+		Columns(legalHoldSliceColumns()...).
+		Values(legalHoldToSlice(hold)...)
+
+	if _, err = transaction.ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save LegalHold with id=%s", hold.Id)
+	}
+
+	if err = s.saveMembers(transaction, hold); err != nil {
+		return nil, err
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return hold, nil
+}
+
+func (s *SqlLegalHoldStore) saveMembers(transaction *sqlxTxWrapper, hold *model.LegalHold) error {
+	if len(hold.UserIds) > 0 {
+		query := s.getQueryBuilder().
+			Insert("LegalHoldUsers").
+			Columns("LegalHoldId", "UserId")
+		for _, userID := range hold.UserIds {
+			query = query.Values(hold.Id, userID)
+		}
+
+		if _, err := transaction.ExecBuilder(query); err != nil {
+			return errors.Wrapf(err, "failed to save LegalHoldUsers for legalHoldId=%s", hold.Id)
+		}
+	}
+
+	if len(hold.ChannelIds) > 0 {
+		query := s.getQueryBuilder().
+			Insert("LegalHoldChannels").
+			Columns("LegalHoldId", "ChannelId")
+		for _, channelID := range hold.ChannelIds {
+			query = query.Values(hold.Id, channelID)
+		}
+
+		if _, err := transaction.ExecBuilder(query); err != nil {
+			return errors.Wrapf(err, "failed to save LegalHoldChannels for legalHoldId=%s", hold.Id)
+		}
+	}
+
+	return nil
+}
+
+func (s *SqlLegalHoldStore) Get(holdID string) (*model.LegalHold, error) {
+	query := s.getQueryBuilder().
+		Select(legalHoldSliceColumns()...).
+		From("LegalHolds").
+		Where(sq.Eq{"Id": holdID})
+
+	var hold model.LegalHold
+	if err := s.GetReplicaX().GetBuilder(&hold, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("LegalHold", holdID)
+		}
+		return nil, errors.Wrapf(err, "failed to get LegalHold with id=%s", holdID)
+	}
+
+	if err := s.loadMembers([]*model.LegalHold{&hold}); err != nil {
+		return nil, err
+	}
+
+	return &hold, nil
+}
+
+// GetAll returns a page of the legal holds, the most recently created first.
+func (s *SqlLegalHoldStore) GetAll(offset, limit int, includeReleased bool) ([]*model.LegalHold, error) {
+	query := s.getQueryBuilder().
+		Select(legalHoldSliceColumns()...).
+		From("LegalHolds").
+		OrderBy("CreateAt DESC", "Id ASC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	if !includeReleased {
+		query = query.Where(sq.Eq{"DeleteAt": 0})
+	}
+
+	holds := []*model.LegalHold{}
+	if err := s.GetReplicaX().SelectBuilder(&holds, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get LegalHolds")
+	}
+
+	if err := s.loadMembers(holds); err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+func (s *SqlLegalHoldStore) loadMembers(holds []*model.LegalHold) error {
+	if len(holds) == 0 {
+		return nil
+	}
+
+	holdsByID := make(map[string]*model.LegalHold, len(holds))
+	holdIDs := make([]string, 0, len(holds))
+	for _, hold := range holds {
+		hold.UserIds = []string{}
+		hold.ChannelIds = []string{}
+		holdsByID[hold.Id] = hold
+		holdIDs = append(holdIDs, hold.Id)
+	}
+
+	var users []struct {
+		LegalHoldId string
+		UserId      string
+	}
+	usersQuery := s.getQueryBuilder().
+		Select("LegalHoldId", "UserId").
+		From("LegalHoldUsers").
+		Where(sq.Eq{"LegalHoldId": holdIDs}).
+		OrderBy("LegalHoldId", "UserId")
+	if err := s.GetReplicaX().SelectBuilder(&users, usersQuery); err != nil {
+		return errors.Wrap(err, "failed to get LegalHoldUsers")
+	}
+	for _, user := range users {
+		hold := holdsByID[user.LegalHoldId]
+		hold.UserIds = append(hold.UserIds, user.UserId)
+	}
+
+	var channels []struct {
+		LegalHoldId string
+		ChannelId   string
+	}
+	channelsQuery := s.getQueryBuilder().
+		Select("LegalHoldId", "ChannelId").
+		From("LegalHoldChannels").
+		Where(sq.Eq{"LegalHoldId": holdIDs}).
+		OrderBy("LegalHoldId", "ChannelId")
+	if err := s.GetReplicaX().SelectBuilder(&channels, channelsQuery); err != nil {
+		return errors.Wrap(err, "failed to get LegalHoldChannels")
+	}
+	for _, channel := range channels {
+		hold := holdsByID[channel.LegalHoldId]
+		hold.ChannelIds = append(hold.ChannelIds, channel.ChannelId)
+	}
+
+	return nil
+}
+
+func (s *SqlLegalHoldStore) Update(hold *model.LegalHold) (_ *model.LegalHold, err error) {
+	hold.PreUpdate()
+	if appErr := hold.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	query := s.getQueryBuilder().
+		Update("LegalHolds").
+		Set("Name", hold.Name).
+		Set("Description", hold.Description).
+		Set("StartsAt", hold.StartsAt).
+		Set("EndsAt", hold.EndsAt).
+		Set("UpdateAt", hold.UpdateAt).
+		Where(sq.Eq{
+			"Id":       hold.Id,
+			"DeleteAt": 0,
+		})
+
+	result, err := transaction.ExecBuilder(query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update LegalHold with id=%s", hold.Id)
+	}
+	if rows, rowsErr := result.RowsAffected(); rowsErr != nil {
+		return nil, errors.Wrap(rowsErr, "unable to get rows affected")
+	} else if rows == 0 {
+		return nil, store.NewErrNotFound("LegalHold", hold.Id)
+	}
+
+	for _, table := range []string{"LegalHoldUsers", "LegalHoldChannels"} {
+		if _, err = transaction.ExecBuilder(s.getQueryBuilder().Delete(table).Where(sq.Eq{"LegalHoldId": hold.Id})); err != nil {
+			return nil, errors.Wrapf(err, "failed to delete %s for legalHoldId=%s", table, hold.Id)
+		}
+	}
+
+	if err = s.saveMembers(transaction, hold); err != nil {
+		return nil, err
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return hold, nil
+}
+
+// Release stops the legal hold from preserving its content. Released holds
+// are kept so that their content can still be exported.
+func (s *SqlLegalHoldStore) Release(holdID string, releaseAt int64) error {
+	query := s.getQueryBuilder().
+		Update("LegalHolds").
+		Set("DeleteAt", releaseAt).
+		Set("UpdateAt", releaseAt).
+		Where(sq.Eq{
+			"Id":       holdID,
+			"DeleteAt": 0,
+		})
+
+	result, err := s.GetMasterX().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to release LegalHold with id=%s", holdID)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if rows == 0 {
+		return store.NewErrNotFound("LegalHold", holdID)
+	}
+
+	return nil
+}
+
+func (s *SqlLegalHoldStore) IsUserHeld(userID string) (bool, error) {
+	custodianQuery := s.getQueryBuilder().
+		Select("1").
+		From("LegalHoldUsers").
+		InnerJoin("LegalHolds ON LegalHolds.Id = LegalHoldUsers.LegalHoldId").
+		Where(sq.Eq{
+			"LegalHoldUsers.UserId": userID,
+			"LegalHolds.DeleteAt":   0,
+		}).
+		Limit(1)
+
+	channelScope := postsLegalHoldScope
+	channelScope.UserColumn = ""
+	postsQuery := s.getQueryBuilder().
+		Select("1").
+		From("Posts").
+		Where(sq.Eq{"Posts.UserId": userID}).
+		Where(channelScope.held()).
+		Limit(1)
+
+	return s.anyExists(custodianQuery, postsQuery)
+}
+
+func (s *SqlLegalHoldStore) IsChannelHeld(channelID string) (bool, error) {
+	channelQuery := s.getQueryBuilder().
+		Select("1").
+		From("LegalHoldChannels").
+		InnerJoin("LegalHolds ON LegalHolds.Id = LegalHoldChannels.LegalHoldId").
+		Where(sq.Eq{
+			"LegalHoldChannels.ChannelId": channelID,
+			"LegalHolds.DeleteAt":         0,
+		}).
+		Limit(1)
+
+	custodianScope := postsLegalHoldScope
+	custodianScope.ChannelColumn = ""
+	postsQuery := s.getQueryBuilder().
+		Select("1").
+		From("Posts").
+		Where(sq.Eq{"Posts.ChannelId": channelID}).
+		Where(custodianScope.held()).
+		Limit(1)
+
+	return s.anyExists(channelQuery, postsQuery)
+}
+
+func (s *SqlLegalHoldStore) anyExists(queries ...sq.SelectBuilder) (bool, error) {
+	for _, query := range queries {
+		var found []int
+		if err := s.GetMasterX().SelectBuilder(&found, query); err != nil {
+			return false, errors.Wrap(err, "failed to check held content")
+		}
+		if len(found) > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
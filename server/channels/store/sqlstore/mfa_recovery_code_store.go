@@ -0,0 +1,99 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlMfaRecoveryCodeStore struct {
+	*SqlStore
+}
+
+func newSqlMfaRecoveryCodeStore(sqlStore *SqlStore) store.MfaRecoveryCodeStore {
+	return &SqlMfaRecoveryCodeStore{sqlStore}
+}
+
+func (s *SqlMfaRecoveryCodeStore) SaveForUser(userID string, codeHashes []string) (err error) {
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	deleteQuery := s.getQueryBuilder().
+		Delete("MfaRecoveryCodes").
+		Where(sq.Eq{"UserId": userID})
+	if _, err = transaction.ExecBuilder(deleteQuery); err != nil {
+		return errors.Wrapf(err, "failed to delete MfaRecoveryCodes with userId=%s", userID)
+	}
+
+	if len(codeHashes) > 0 {
+		createAt := model.GetMillis()
+		insertQuery := s.getQueryBuilder().
+			Insert("MfaRecoveryCodes").
+			Columns("UserId", "CodeHash", "CreateAt", "UsedAt")
+		for _, codeHash := range codeHashes {
+			insertQuery = insertQuery.Values(userID, codeHash, createAt, 0)
+		}
+		if _, err = transaction.ExecBuilder(insertQuery); err != nil {
+			return errors.Wrapf(err, "failed to save MfaRecoveryCodes with userId=%s", userID)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (s *SqlMfaRecoveryCodeStore) Use(userID, codeHash string) (bool, error) {
+	query := s.getQueryBuilder().
+		Update("MfaRecoveryCodes").
+		Set("UsedAt", model.GetMillis()).
+		Where(sq.Eq{"UserId": userID, "CodeHash": codeHash, "UsedAt": 0})
+
+	result, err := s.GetMasterX().ExecBuilder(query)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to use MfaRecoveryCode with userId=%s", userID)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get rows affected")
+	}
+
+	return rows == 1, nil
+}
+
+func (s *SqlMfaRecoveryCodeStore) CountUnused(userID string) (int64, error) {
+	query := s.getQueryBuilder().
+		Select("COUNT(*)").
+		From("MfaRecoveryCodes").
+		Where(sq.Eq{"UserId": userID, "UsedAt": 0})
+
+	var count int64
+	if err := s.GetMasterX().GetBuilder(&count, query); err != nil {
+		return 0, errors.Wrapf(err, "failed to count MfaRecoveryCodes with userId=%s", userID)
+	}
+
+	return count, nil
+}
+
+func (s *SqlMfaRecoveryCodeStore) DeleteForUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete("MfaRecoveryCodes").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete MfaRecoveryCodes with userId=%s", userID)
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlOutgoingWebhookDeliveryStore struct {
+	*SqlStore
+}
+
+func newSqlOutgoingWebhookDeliveryStore(sqlStore *SqlStore) store.OutgoingWebhookDeliveryStore {
+	return &SqlOutgoingWebhookDeliveryStore{sqlStore}
+}
+
+func outgoingWebhookDeliverySliceColumns() []string {
+	return []string{
+		"Id",
+		"CreateAt",
+		"UpdateAt",
+		"HookId",
+		"ChannelId",
+		"PostId",
+		"CallbackURL",
+		"ContentType",
+		"Payload",
+		"Status",
+		"Attempts",
+		"NextAttemptAt",
+		"LastAttemptAt",
+		"StatusCode",
+		"Latency",
+		"Response",
+		"Error",
+	}
+}
+
+func outgoingWebhookDeliveryToSlice(delivery *model.OutgoingWebhookDelivery) []any {
+	return []any{
+		delivery.Id,
+		delivery.CreateAt,
+		delivery.UpdateAt,
+		delivery.HookId,
+		delivery.ChannelId,
+		delivery.PostId,
+		delivery.CallbackURL,
+		delivery.ContentType,
+		delivery.Payload,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastAttemptAt,
+		delivery.StatusCode,
+		delivery.Latency,
+		delivery.Response,
+		delivery.Error,
+	}
+}
+
+func (s *SqlOutgoingWebhookDeliveryStore) Save(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	delivery.PreSave()
+	if err := delivery.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("OutgoingWebhookDeliveries").
+		Columns(outgoingWebhookDeliverySliceColumns()...).
+		Values(outgoingWebhookDeliveryToSlice(delivery)...)
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save OutgoingWebhookDelivery with id=%s", delivery.Id)
+	}
+
+	return delivery, nil
+}
+
+func (s *SqlOutgoingWebhookDeliveryStore) Get(deliveryID string) (*model.OutgoingWebhookDelivery, error) {
+	query := s.getQueryBuilder().
+		Select(outgoingWebhookDeliverySliceColumns()...).
+		From("OutgoingWebhookDeliveries").
+		Where(sq.Eq{"Id": deliveryID})
+
+	var delivery model.OutgoingWebhookDelivery
+	if err := s.GetMasterX().GetBuilder(&delivery, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("OutgoingWebhookDelivery", deliveryID)
+		}
+		return nil, errors.Wrapf(err, "failed to get OutgoingWebhookDelivery with id=%s", deliveryID)
+	}
+
+	return &delivery, nil
+}
+
+// Update saves the state and the outcome of the last attempt of a delivery.
+func (s *SqlOutgoingWebhookDeliveryStore) Update(delivery *model.OutgoingWebhookDelivery) error {
+	delivery.PreUpdate()
+	if err := delivery.IsValid(); err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder().
+		Update("OutgoingWebhookDeliveries").
+		Set("UpdateAt", delivery.UpdateAt).
+		Set("Status", delivery.Status).
+		Set("Attempts", delivery.Attempts).
+		Set("NextAttemptAt", delivery.NextAttemptAt).
+		Set("LastAttemptAt", delivery.LastAttemptAt).
+		Set("StatusCode", delivery.StatusCode).
+		Set("Latency", delivery.Latency).
+		Set("Response", delivery.Response).
+		Set("Error", delivery.Error).
+		Where(sq.Eq{"Id": delivery.Id})
+
+	res, err := s.GetMasterX().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update OutgoingWebhookDelivery with id=%s", delivery.Id)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get affected rows after updating OutgoingWebhookDelivery with id=%s", delivery.Id)
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("OutgoingWebhookDelivery", delivery.Id)
+	}
+
+	return nil
+}
+
+// GetForHook returns the delivery history of a hook, most recent first,
+// optionally filtered by status.
+func (s *SqlOutgoingWebhookDeliveryStore) GetForHook(hookID string, opts model.OutgoingWebhookDeliveryGetOptions) ([]*model.OutgoingWebhookDelivery, error) {
+	query := s.getQueryBuilder().
+		Select(outgoingWebhookDeliverySliceColumns()...).
+		From("OutgoingWebhookDeliveries").
+		Where(sq.Eq{"HookId": hookID}).
+		OrderBy("CreateAt DESC", "Id DESC").
+		Limit(uint64(opts.PerPage)).
+		Offset(uint64(opts.Page * opts.PerPage))
+
+	if opts.Status != "" {
+		query = query.Where(sq.Eq{"Status": opts.Status})
+	}
+
+	deliveries := []*model.OutgoingWebhookDelivery{}
+	if err := s.GetReplicaX().SelectBuilder(&deliveries, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get OutgoingWebhookDeliveries for hookId=%s", hookID)
+	}
+
+	return deliveries, nil
+}
+
+// GetPending returns the pending deliveries whose next attempt is due,
+// oldest first.
+func (s *SqlOutgoingWebhookDeliveryStore) GetPending(nextAttemptBefore int64, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	query := s.getQueryBuilder().
+		Select(outgoingWebhookDeliverySliceColumns()...).
+		From("OutgoingWebhookDeliveries").
+		Where(sq.And{
+			sq.Eq{"Status": model.OutgoingWebhookDeliveryStatusPending},
+			sq.LtOrEq{"NextAttemptAt": nextAttemptBefore},
+		}).
+		OrderBy("NextAttemptAt ASC", "Id ASC").
+		Limit(uint64(limit))
+
+	deliveries := []*model.OutgoingWebhookDelivery{}
+	if err := s.GetMasterX().SelectBuilder(&deliveries, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get pending OutgoingWebhookDeliveries")
+	}
+
+	return deliveries, nil
+}
+
+func (s *SqlOutgoingWebhookDeliveryStore) PermanentDeleteByHook(hookID string) error {
+	query := s.getQueryBuilder().
+		Delete("OutgoingWebhookDeliveries").
+		Where(sq.Eq{"HookId": hookID})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete OutgoingWebhookDeliveries for hookId=%s", hookID)
+	}
+
+	return nil
+}
+
+// PermanentDeleteBatch deletes up to limit deliveries that are no longer
+// pending and were last updated before endTime.
+func (s *SqlOutgoingWebhookDeliveryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	var query string
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		query = "DELETE FROM OutgoingWebhookDeliveries WHERE Id = any (array (SELECT Id FROM OutgoingWebhookDeliveries WHERE UpdateAt < ? AND Status != ? LIMIT ?))"
+	} else {
+		query = "DELETE FROM OutgoingWebhookDeliveries WHERE UpdateAt < ? AND Status != ? LIMIT ?"
+	}
+
+	sqlResult, err := s.GetMasterX().Exec(query, endTime, model.OutgoingWebhookDeliveryStatusPending, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete OutgoingWebhookDeliveries in batch")
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to retrieve rows affected")
+	}
+
+	return rowsAffected, nil
+}
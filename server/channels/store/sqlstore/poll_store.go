@@ -0,0 +1,257 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlPollStore struct {
+	*SqlStore
+}
+
+func newSqlPollStore(sqlStore *SqlStore) store.PollStore {
+	return &SqlPollStore{sqlStore}
+}
+
+func pollSliceColumns() []string {
+	return []string{
+		"Id",
+		"CreateAt",
+		"UpdateAt",
+		"DeleteAt",
+		"PostId",
+		"ChannelId",
+		"UserId",
+		"Question",
+		"Options",
+		"MultipleChoice",
+		"Anonymous",
+		"CloseAt",
+	}
+}
+
+func pollToSlice(poll *model.Poll) []any {
+	return []any{
+		poll.Id,
+		poll.CreateAt,
+		poll.UpdateAt,
+		poll.DeleteAt,
+		poll.PostId,
+		poll.ChannelId,
+		poll.UserId,
+		poll.Question,
+		poll.Options,
+		poll.MultipleChoice,
+		poll.Anonymous,
+		poll.CloseAt,
+	}
+}
+
+func (s *SqlPollStore) Save(poll *model.Poll) (*model.Poll, error) {
+	poll.PreSave()
+	if err := poll.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("Polls").
+		Columns(pollSliceColumns()...).
+		Values(pollToSlice(poll)...)
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save Poll with id=%s", poll.Id)
+	}
+
+	return poll, nil
+}
+
+func (s *SqlPollStore) Get(pollID string) (*model.Poll, error) {
+	return s.getBy(sq.Eq{"Id": pollID}, pollID)
+}
+
+func (s *SqlPollStore) GetForPost(postID string) (*model.Poll, error) {
+	return s.getBy(sq.Eq{"PostId": postID}, postID)
+}
+
+func (s *SqlPollStore) getBy(where sq.Eq, id string) (*model.Poll, error) {
+	where["DeleteAt"] = 0
+	query := s.getQueryBuilder().
+		Select(pollSliceColumns()...).
+		From("Polls").
+		Where(where)
+
+	var poll model.Poll
+	if err := s.GetReplicaX().GetBuilder(&poll, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("Poll", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get Poll with id=%s", id)
+	}
+
+	return &poll, nil
+}
+
+// GetForPosts returns the polls held by the given posts, including deleted
+// ones.
+func (s *SqlPollStore) GetForPosts(postIDs []string) ([]*model.Poll, error) {
+	polls := []*model.Poll{}
+	if len(postIDs) == 0 {
+		return polls, nil
+	}
+
+	query := s.getQueryBuilder().
+		Select(pollSliceColumns()...).
+		From("Polls").
+		Where(sq.Eq{"PostId": postIDs})
+
+	if err := s.GetReplicaX().SelectBuilder(&polls, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get Polls for posts")
+	}
+
+	return polls, nil
+}
+
+// Update updates the closing time of a poll, the only field that can change
+// once the poll has been created.
+func (s *SqlPollStore) Update(poll *model.Poll) error {
+	poll.PreUpdate()
+	if err := poll.IsValid(); err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder().
+		Update("Polls").
+		Set("UpdateAt", poll.UpdateAt).
+		Set("CloseAt", poll.CloseAt).
+		Where(sq.Eq{
+			"Id":       poll.Id,
+			"DeleteAt": 0,
+		})
+
+	res, err := s.GetMasterX().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update Poll with id=%s", poll.Id)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get affected rows after updating Poll with id=%s", poll.Id)
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("Poll", poll.Id)
+	}
+
+	return nil
+}
+
+func (s *SqlPollStore) DeleteForPost(postID string, deleteAt int64) error {
+	query := s.getQueryBuilder().
+		Update("Polls").
+		Set("DeleteAt", deleteAt).
+		Set("UpdateAt", deleteAt).
+		Where(sq.Eq{
+			"PostId":   postID,
+			"DeleteAt": 0,
+		})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete Poll for postId=%s", postID)
+	}
+
+	return nil
+}
+
+// SaveVotes replaces the votes of a user on a poll with votes for the given
+// options.
+func (s *SqlPollStore) SaveVotes(pollID, userID string, optionIDs []string) (err error) {
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	deleteQuery := s.getQueryBuilder().
+		Delete("PollVotes").
+		Where(sq.Eq{
+			"PollId": pollID,
+			"UserId": userID,
+		})
+
+	if _, err = transaction.ExecBuilder(deleteQuery); err != nil {
+		return errors.Wrapf(err, "failed to delete PollVotes for pollId=%s and userId=%s", pollID, userID)
+	}
+
+	if len(optionIDs) > 0 {
+		createAt := model.GetMillis()
+		insertQuery := s.getQueryBuilder().
+			Insert("PollVotes").
+			Columns("PollId", "UserId", "OptionId", "CreateAt")
+		for _, optionID := range optionIDs {
+			insertQuery = insertQuery.Values(pollID, userID, optionID, createAt)
+		}
+
+		if _, err = transaction.ExecBuilder(insertQuery); err != nil {
+			return errors.Wrapf(err, "failed to save PollVotes for pollId=%s and userId=%s", pollID, userID)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (s *SqlPollStore) GetVotes(pollID string) ([]*model.PollVote, error) {
+	query := s.getQueryBuilder().
+		Select("PollId", "UserId", "OptionId", "CreateAt").
+		From("PollVotes").
+		Where(sq.Eq{"PollId": pollID}).
+		OrderBy("CreateAt ASC", "UserId ASC")
+
+	votes := []*model.PollVote{}
+	if err := s.GetReplicaX().SelectBuilder(&votes, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get PollVotes for pollId=%s", pollID)
+	}
+
+	return votes, nil
+}
+
+// GetVotesForPolls returns the votes cast on any of the given polls.
+func (s *SqlPollStore) GetVotesForPolls(pollIDs []string) ([]*model.PollVote, error) {
+	votes := []*model.PollVote{}
+	if len(pollIDs) == 0 {
+		return votes, nil
+	}
+
+	query := s.getQueryBuilder().
+		Select("PollId", "UserId", "OptionId", "CreateAt").
+		From("PollVotes").
+		Where(sq.Eq{"PollId": pollIDs}).
+		OrderBy("CreateAt ASC", "UserId ASC")
+
+	if err := s.GetReplicaX().SelectBuilder(&votes, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get PollVotes for polls")
+	}
+
+	return votes, nil
+}
+
+func (s *SqlPollStore) PermanentDeleteVotesByUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete("PollVotes").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete PollVotes for userId=%s", userID)
+	}
+
+	return nil
+}
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -29,6 +31,13 @@ import (
 // Regex to get quoted strings
 var quotedStringsRegex = regexp.MustCompile(`("[^"]*")`)
 
+// searchRelevanceRecencyMillis is how long it takes for the relevance of a post
+// to be halved when search results are ranked by relevance.
+const searchRelevanceRecencyMillis = 30 * 24 * 60 * 60 * 1000
+
+// searchPostsLimit is the maximum number of posts returned by a search.
+const searchPostsLimit = 100
+
 type SqlPostStore struct {
 	*SqlStore
 	metrics           einterfaces.MetricsInterface
@@ -147,6 +156,20 @@ func newSqlPostStore(sqlStore *SqlStore, metrics einterfaces.MetricsInterface) s
 }
 
 func (s *SqlPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
+	for idx, post := range posts {
+		if post.Id != "" && !post.IsRemote() {
+			return nil, idx, store.NewErrInvalidInput("Post", "id", post.Id)
+		}
+	}
+
+	return s.saveMultiple(posts)
+}
+
+func (s *SqlPostStore) SaveMultipleWithIds(posts []*model.Post) ([]*model.Post, int, error) {
+	return s.saveMultiple(posts)
+}
+
+func (s *SqlPostStore) saveMultiple(posts []*model.Post) ([]*model.Post, int, error) {
 	channelNewPosts := make(map[string]int)
 	channelNewRootPosts := make(map[string]int)
 	maxDateNewPosts := make(map[string]int64)
@@ -154,9 +177,6 @@ func (s *SqlPostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, er
 	rootIds := make(map[string]int)
 	maxDateRootIds := make(map[string]int64)
 	for idx, post := range posts {
-		if post.Id != "" && !post.IsRemote() {
-			return nil, idx, store.NewErrInvalidInput("Post", "id", post.Id)
-		}
 		post.PreSave()
 		maxPostSize := s.GetMaxPostSize()
 		if err := post.IsValid(maxPostSize); err != nil {
@@ -1963,6 +1983,67 @@ func (s *SqlPostStore) buildCreateDateFilterClause(params *model.SearchParams, b
 	return builder
 }
 
+// searchPostAttributeConditions are the conditions on the searched post for
+// the values of the has: and is: filters.
+var searchPostAttributeConditions = map[string]string{
+	model.SearchHasFile:      "EXISTS (SELECT 1 FROM FileInfo WHERE FileInfo.PostId = q2.Id AND FileInfo.DeleteAt = 0)",
+	model.SearchHasLink:      "(q2.Message LIKE '%http://%' OR q2.Message LIKE '%https://%')",
+	model.SearchIsPinned:     "q2.IsPinned = true",
+	model.SearchIsThreadRoot: "q2.RootId = '' AND EXISTS (SELECT 1 FROM Posts Replies WHERE Replies.RootId = q2.Id AND Replies.DeleteAt = 0)",
+}
+
+func (s *SqlPostStore) buildSearchPostAttributesFilterClause(params *model.SearchParams, builder sq.SelectBuilder) sq.SelectBuilder {
+	// handle has: is: reactions: priority: mentions: filters
+	for _, value := range append(slices.Clone(params.HasFilters), params.IsFilters...) {
+		if condition, ok := searchPostAttributeConditions[value]; ok {
+			builder = builder.Where(condition)
+		}
+	}
+
+	for _, value := range append(slices.Clone(params.ExcludedHasFilters), params.ExcludedIsFilters...) {
+		if condition, ok := searchPostAttributeConditions[value]; ok {
+			builder = builder.Where("NOT (" + condition + ")")
+		}
+	}
+
+	reactionCount := "(SELECT COUNT(*) FROM Reactions WHERE Reactions.PostId = q2.Id AND Reactions.DeleteAt = 0)"
+	if params.MinReactions > 0 {
+		builder = builder.Where(reactionCount+" >= ?", params.MinReactions)
+	}
+	if params.MaxReactions != nil {
+		builder = builder.Where(reactionCount+" <= ?", *params.MaxReactions)
+	}
+
+	priority := "EXISTS (SELECT 1 FROM PostsPriority WHERE PostsPriority.PostId = q2.Id AND PostsPriority.Priority = ?)"
+	for _, value := range params.Priorities {
+		builder = builder.Where(priority, value)
+	}
+	for _, value := range params.ExcludedPriorities {
+		builder = builder.Where("NOT "+priority, value)
+	}
+
+	mention := "LOWER(q2.Message) ~ ?"
+	if s.DriverName() == model.DatabaseDriverMysql {
+		mention = "LOWER(q2.Message) REGEXP ?"
+	}
+	for _, username := range params.MentionedUsers {
+		builder = builder.Where(mention, mentionRegexp(username))
+	}
+	for _, username := range params.ExcludedMentionedUsers {
+		builder = builder.Where("NOT ("+mention+")", mentionRegexp(username))
+	}
+
+	return builder
+}
+
+// mentionRegexp returns the regular expression matching the mentions of a
+// username in a lowercase message, but not the ones of longer usernames starting
+// with it nor email addresses. A trailing dot is punctuation, as when parsing the
+// mentions of a post.
+func mentionRegexp(username string) string {
+	return `(^|[^a-z0-9._-])@` + regexp.QuoteMeta(strings.ToLower(username)) + `([^a-z0-9._-]|[.]([^a-z0-9_-]|$)|$)`
+}
+
 func (s *SqlPostStore) buildSearchTeamFilterClause(teamId string, builder sq.SelectBuilder) sq.SelectBuilder {
 	if teamId == "" {
 		return builder
@@ -2036,16 +2117,106 @@ func (s *SqlPostStore) buildSearchPostFilterClause(teamID string, fromUsers []st
 }
 
 func (s *SqlPostStore) Search(teamId string, userId string, params *model.SearchParams) (*model.PostList, error) {
-	return s.search(teamId, userId, params, true, true)
+	list, _, err := s.search(teamId, userId, params, true, true)
+	return list, err
+}
+
+// rankByRelevance reports whether search results are ordered by how well they
+// match the terms, rather than by creation time only.
+func (s *SqlPostStore) rankByRelevance() bool {
+	return s.settings.DatabaseSearchRanking != nil && *s.settings.DatabaseSearchRanking == model.DatabaseSearchRankingRelevance
+}
+
+// textSearchConfigGroup is a set of channels whose posts are searched with
+// the same PostgreSQL text search configuration.
+type textSearchConfigGroup struct {
+	config string
+	// filter restricts the search to the channels of the group. It is nil when
+	// the group covers every channel.
+	filter sq.Sqlizer
+}
+
+// textSearchConfigGroups splits the channels by the PostgreSQL text search
+// configuration used for their posts. Configurations set for a channel take
+// precedence over the one of its team, and the database default applies to
+// the others.
+//
+// Each group is searched with its configuration as a literal so that an
+// expression index on the posts, e.g. to_tsvector('english', Message), can
+// be used. Only the default configuration is indexed out of the box: every
+// other configuration needs its own index, for instance
+//
+//	CREATE INDEX idx_posts_message_txt_french ON Posts USING gin(to_tsvector('french', Message));
+//
+// or its searches scan all the posts.
+func (s *SqlPostStore) textSearchConfigGroups() []textSearchConfigGroup {
+	if len(s.settings.TextSearchConfigs) == 0 {
+		return []textSearchConfigGroup{{config: s.pgDefaultTextSearchConfig}}
+	}
+
+	allIDs := make([]string, 0, len(s.settings.TextSearchConfigs))
+	idsByConfig := map[string][]string{}
+	for id, config := range s.settings.TextSearchConfigs {
+		allIDs = append(allIDs, id)
+		idsByConfig[config] = append(idsByConfig[config], id)
+	}
+	sort.Strings(allIDs)
+
+	configs := make([]string, 0, len(idsByConfig))
+	for config := range idsByConfig {
+		configs = append(configs, config)
+	}
+	sort.Strings(configs)
+
+	teamChannels := func(teamIDs []string) string {
+		return "q2.ChannelId IN (SELECT Channels.Id FROM Channels WHERE Channels.TeamId IN (" + sq.Placeholders(len(teamIDs)) + "))"
+	}
+	toArgs := func(ids []string) []any {
+		args := make([]any, len(ids))
+		for i, id := range ids {
+			args[i] = id
+		}
+		return args
+	}
+
+	groups := make([]textSearchConfigGroup, 0, len(configs)+1)
+	for _, config := range configs {
+		ids := idsByConfig[config]
+		sort.Strings(ids)
+		groups = append(groups, textSearchConfigGroup{
+			config: config,
+			filter: sq.Or{
+				sq.Eq{"q2.ChannelId": ids},
+				sq.And{
+					sq.NotEq{"q2.ChannelId": allIDs},
+					sq.Expr(teamChannels(ids), toArgs(ids)...),
+				},
+			},
+		})
+	}
+
+	groups = append(groups, textSearchConfigGroup{
+		config: s.pgDefaultTextSearchConfig,
+		filter: sq.And{
+			sq.NotEq{"q2.ChannelId": allIDs},
+			sq.Expr("NOT "+teamChannels(allIDs), toArgs(allIDs)...),
+		},
+	})
+
+	return groups
 }
 
-func (s *SqlPostStore) search(teamId string, userId string, params *model.SearchParams, channelsByName bool, userByUsername bool) (*model.PostList, error) {
+// search returns the posts matching the params, along with the words and
+// phrases of each post which matched the terms.
+func (s *SqlPostStore) search(teamId string, userId string, params *model.SearchParams, channelsByName bool, userByUsername bool) (*model.PostList, model.PostSearchMatches, error) {
 	list := model.NewPostList()
+	matches := model.PostSearchMatches{}
 	if params.Terms == "" && params.ExcludedTerms == "" &&
 		len(params.InChannels) == 0 && len(params.ExcludedChannels) == 0 &&
 		len(params.FromUsers) == 0 && len(params.ExcludedUsers) == 0 &&
-		params.OnDate == "" && params.AfterDate == "" && params.BeforeDate == "" {
-		return list, nil
+		params.OnDate == "" && params.AfterDate == "" && params.BeforeDate == "" &&
+		!params.HasPostFilters() {
+		return list, matches, nil
 	}
 
 	baseQuery := s.getQueryBuilder().Select(
@@ -2054,15 +2225,19 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 	).From("Posts q2").
 		Where("q2.DeleteAt = 0").
 		Where(fmt.Sprintf("q2.Type NOT LIKE '%s%%'", model.PostSystemMessagePrefix)).
-		OrderByClause("q2.CreateAt DESC").
-		Limit(100)
+		Limit(searchPostsLimit)
+
+	orderBy := "q2.CreateAt DESC"
+	var orderByArgs []any
+	recencyArgs := []any{model.GetMillis(), float64(searchRelevanceRecencyMillis)}
 
 	var err error
 	baseQuery, err = s.buildSearchPostFilterClause(teamId, params.FromUsers, params.ExcludedUsers, userByUsername, baseQuery)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to build search post filter clause")
+		return nil, nil, errors.Wrap(err, "failed to build search post filter clause")
 	}
 	baseQuery = s.buildCreateDateFilterClause(params, baseQuery)
+	baseQuery = s.buildSearchPostAttributesFilterClause(params, baseQuery)
 
 	termMap := map[string]bool{}
 	terms := params.Terms
@@ -2083,6 +2258,7 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 		excludedTerms = strings.Replace(excludedTerms, c, " ", -1)
 	}
 
+	var searchQueries []sq.SelectBuilder
 	if terms == "" && excludedTerms == "" {
 		// we've already confirmed that we have a channel or user to search for
 	} else if s.DriverName() == model.DatabaseDriverPostgres {
@@ -2123,17 +2299,32 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 			tsQueryClause += " &!(" + excludedClause + ")"
 		}
 
-		searchClause := fmt.Sprintf("to_tsvector('%[1]s', %[2]s) @@  to_tsquery('%[1]s', ?)", s.pgDefaultTextSearchConfig, searchType)
-		baseQuery = baseQuery.Where(searchClause, tsQueryClause)
+		// Each text search configuration is searched separately, with the
+		// configuration as a literal, so that its index can be used.
+		for _, group := range s.textSearchConfigGroups() {
+			searchClause := fmt.Sprintf("to_tsvector('%[1]s', %[2]s) @@  to_tsquery('%[1]s', ?)", group.config, searchType)
+			query := baseQuery.Where(searchClause, tsQueryClause)
+			if group.filter != nil {
+				query = query.Where(group.filter)
+			}
+
+			if s.rankByRelevance() && terms != "" {
+				rankBy := fmt.Sprintf("ts_rank(to_tsvector('%[1]s', %[2]s), to_tsquery('%[1]s', ?)) / (1 + (? - q2.CreateAt) / ?) DESC, q2.CreateAt DESC", group.config, searchType)
+				query = query.OrderByClause(rankBy, append([]any{tsQueryClause}, recencyArgs...)...)
+			} else {
+				query = query.OrderBy(orderBy)
+			}
+			searchQueries = append(searchQueries, query)
+		}
 	} else if s.DriverName() == model.DatabaseDriverMysql {
 		if searchType == "Message" {
 			terms, err = removeMysqlStopWordsFromTerms(terms)
 			if err != nil {
-				return nil, errors.Wrap(err, "failed to remove Mysql stop-words from terms")
+				return nil, nil, errors.Wrap(err, "failed to remove Mysql stop-words from terms")
 			}
 
 			if terms == "" {
-				return list, nil
+				return list, matches, nil
 			}
 		}
 
@@ -2160,6 +2351,14 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 		}
 
 		baseQuery = baseQuery.Where(searchClause, termsClause)
+
+		if s.rankByRelevance() && terms != "" {
+			orderBy = fmt.Sprintf("%s / (1 + (? - q2.CreateAt) / ?) DESC, q2.CreateAt DESC", searchClause)
+			orderByArgs = append([]any{termsClause}, recencyArgs...)
+		}
+	}
+	if len(searchQueries) == 0 {
+		searchQueries = []sq.SelectBuilder{baseQuery.OrderByClause(orderBy, orderByArgs...)}
 	}
 
 	inQuery := s.getSubQueryBuilder().Select("Id").
@@ -2180,41 +2379,78 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 
 	inQueryClause, inQueryClauseArgs, err := inQuery.ToSql()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	baseQuery = baseQuery.Where(fmt.Sprintf("ChannelId IN (%s)", inQueryClause), inQueryClauseArgs...)
+	var posts []*model.Post
+	lists := make([]*model.PostList, 0, len(searchQueries))
+	for _, searchQuery := range searchQueries {
+		searchQuery = searchQuery.Where(fmt.Sprintf("ChannelId IN (%s)", inQueryClause), inQueryClauseArgs...)
 
-	searchQuery, searchQueryArgs, err := baseQuery.ToSql()
-	if err != nil {
-		return nil, err
+		query, queryArgs, err := searchQuery.ToSql()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var queryPosts []*model.Post
+		if err := s.GetSearchReplicaX().Select(&queryPosts, query, queryArgs...); err != nil {
+			mlog.Warn("Query error searching posts.", mlog.String("error", trimInput(err.Error())))
+			// Don't return the error to the caller as it is of no use to the user. Instead return an empty set of search results.
+			return list, matches, nil
+		}
+
+		queryList := model.NewPostList()
+		for _, p := range queryPosts {
+			queryList.AddPost(p)
+			queryList.AddOrder(p.Id)
+		}
+		posts = append(posts, queryPosts...)
+		lists = append(lists, queryList)
 	}
 
-	var posts []*model.Post
+	// Results searched with several text search configurations are merged
+	// like the results of several searches.
+	if len(lists) > 1 {
+		merged := model.NewPostList()
+		for _, l := range lists {
+			merged.Extend(l)
+		}
+		if s.rankByRelevance() && terms != "" {
+			merged.Order = fuseSearchRankings(merged, lists)
+		} else {
+			merged.SortByCreateAt()
+		}
+		if len(merged.Order) > searchPostsLimit {
+			merged.Order = merged.Order[:searchPostsLimit]
+		}
 
-	if err := s.GetSearchReplicaX().Select(&posts, searchQuery, searchQueryArgs...); err != nil {
-		mlog.Warn("Query error searching posts.", mlog.String("error", trimInput(err.Error())))
-		// Don't return the error to the caller as it is of no use to the user. Instead return an empty set of search results.
-	} else {
-		for _, p := range posts {
-			if searchType == "Hashtags" {
-				exactMatch := false
-				for _, tag := range strings.Split(p.Hashtags, " ") {
-					if termMap[strings.ToUpper(tag)] {
-						exactMatch = true
-						break
-					}
-				}
-				if !exactMatch {
-					continue
+		posts = posts[:0]
+		for _, postID := range merged.Order {
+			posts = append(posts, merged.Posts[postID])
+		}
+	}
+
+	for _, p := range posts {
+		if searchType == "Hashtags" {
+			exactMatch := false
+			for _, tag := range strings.Split(p.Hashtags, " ") {
+				if termMap[strings.ToUpper(tag)] {
+					exactMatch = true
+					break
 				}
 			}
-			list.AddPost(p)
-			list.AddOrder(p.Id)
+			if !exactMatch {
+				continue
+			}
+		}
+		list.AddPost(p)
+		list.AddOrder(p.Id)
+		if postMatches := params.FindMatches(p.Message); len(postMatches) > 0 {
+			matches[p.Id] = postMatches
 		}
 	}
 	list.MakeNonNil()
-	return list, nil
+	return list, matches, nil
 }
 
 func removeMysqlStopWordsFromTerms(terms string) (string, error) {
@@ -2478,6 +2714,37 @@ func (s *SqlPostStore) GetEditHistoryForPost(postId string) ([]*model.Post, erro
 	return posts, nil
 }
 
+func (s *SqlPostStore) PermanentDeleteEditHistoryForPost(postID string, keep int) (int64, error) {
+	query := s.getQueryBuilder().
+		Select("Id").
+		From("Posts").
+		Where(sq.Eq{"OriginalId": postID}).
+		OrderBy("UpdateAt DESC", "Id DESC")
+
+	ids := []string{}
+	if err := s.GetMasterX().SelectBuilder(&ids, query); err != nil {
+		return 0, errors.Wrapf(err, "failed to get edit history with postId=%s", postID)
+	}
+	if len(ids) <= keep {
+		return 0, nil
+	}
+
+	deleteQuery := s.getQueryBuilder().
+		Delete("Posts").
+		Where(sq.Eq{"Id": ids[keep:]})
+	result, err := s.GetMasterX().ExecBuilder(deleteQuery)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to delete edit history with postId=%s", postID)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get rows affected")
+	}
+
+	return deleted, nil
+}
+
 func (s *SqlPostStore) GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
 	posts := []*model.PostForIndexing{}
 
@@ -2548,18 +2815,25 @@ func (s *SqlPostStore) PermanentDeleteBatchForRetentionPolicies(now, globalPolic
 		GlobalPolicyEndTime: globalPolicyEndTime,
 		Limit:               limit,
 		StoreDeletedIds:     true,
+		LegalHold:           postsLegalHoldScope,
 	}, s.SqlStore, cursor)
 }
 
 func (s *SqlPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	notHeld, notHeldArgs, err := postsLegalHoldScope.notHeld().ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "post_tosql")
+	}
+
 	var query string
 	if s.DriverName() == "postgres" {
-		query = "DELETE from Posts WHERE Id = any (array (SELECT Id FROM Posts WHERE CreateAt < ? LIMIT ?))"
+		query = "DELETE from Posts WHERE Id = any (array (SELECT Id FROM Posts WHERE CreateAt < ? AND " + notHeld + " LIMIT ?))"
 	} else {
-		query = "DELETE from Posts WHERE CreateAt < ? LIMIT ?"
+		query = "DELETE from Posts WHERE CreateAt < ? AND " + notHeld + " LIMIT ?"
 	}
 
-	sqlResult, err := s.GetMasterX().Exec(query, endTime, limit)
+	args := append(append([]any{endTime}, notHeldArgs...), limit)
+	sqlResult, err := s.GetMasterX().Exec(query, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to delete Posts")
 	}
@@ -2646,21 +2920,34 @@ func (s *SqlPostStore) GetMaxPostSize() int {
 	return s.maxPostSizeCached
 }
 
-func (s *SqlPostStore) GetParentsForExportAfter(limit int, afterId string, includeArchivedChannel bool) ([]*model.PostForExport, error) {
+// exportUpdatedSinceCond matches the root posts that were updated, or had any
+// of their replies updated, at or after since.
+func exportUpdatedSinceCond(alias string, since int64) sq.Sqlizer {
+	return sq.Or{
+		sq.GtOrEq{alias + ".UpdateAt": since},
+		sq.Expr("EXISTS (SELECT 1 FROM Posts r WHERE r.RootId = "+alias+".Id AND r.UpdateAt >= ?)", since),
+	}
+}
+
+func (s *SqlPostStore) GetParentsForExportAfter(limit int, afterId string, includeArchivedChannel bool, since int64) ([]*model.PostForExport, error) {
 	for {
+		rootIdsQuery := s.getQueryBuilder().
+			Select("Id").
+			From("Posts").
+			Where(sq.And{
+				sq.Gt{"Posts.Id": afterId},
+				sq.Eq{"Posts.RootId": ""},
+				sq.Eq{"Posts.DeleteAt": 0},
+			}).
+			OrderBy("Posts.Id").
+			Limit(uint64(limit))
+
+		if since > 0 {
+			rootIdsQuery = rootIdsQuery.Where(exportUpdatedSinceCond("Posts", since))
+		}
+
 		rootIds := []string{}
-		err := s.GetReplicaX().Select(&rootIds,
-			`SELECT
-				Id
-			FROM
-				Posts
-			WHERE
-				Posts.Id > ?
-				AND Posts.RootId = ''
-				AND Posts.DeleteAt = 0
-			ORDER BY Posts.Id
-			LIMIT ?`,
-			afterId, limit)
+		err := s.GetReplicaX().SelectBuilder(&rootIds, rootIdsQuery)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to find Posts")
 		}
@@ -2745,7 +3032,7 @@ func (s *SqlPostStore) GetRepliesForExport(rootId string) ([]*model.ReplyForExpo
 	return result, nil
 }
 
-func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string, includeArchivedChannels bool, since int64) ([]*model.DirectPostForExport, error) {
 	aggFn := "COALESCE(json_agg(u1.username) FILTER (WHERE u1.username IS NOT NULL), '[]')"
 	if s.DriverName() == model.DatabaseDriverMysql {
 		aggFn = "IF (COUNT(u1.Username) = 0, JSON_ARRAY(), JSON_ARRAYAGG(u1.Username))"
@@ -2775,6 +3062,10 @@ func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId str
 		)
 	}
 
+	if since > 0 {
+		query = query.Where(exportUpdatedSinceCond("p", since))
+	}
+
 	queryString, args, err := query.ToSql()
 	if err != nil {
 		return nil, errors.Wrap(err, "post_tosql")
@@ -2841,37 +3132,74 @@ func (s *SqlPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.
 
 	var wg sync.WaitGroup
 
-	pchan := make(chan store.StoreResult[*model.PostList], len(paramsList))
+	type searchResult struct {
+		list    *model.PostList
+		matches model.PostSearchMatches
+		err     error
+	}
+	results := make([]searchResult, len(paramsList))
 
-	for _, params := range paramsList {
+	for i, params := range paramsList {
 		// remove any unquoted term that contains only non-alphanumeric chars
 		// ex: abcd "**" && abc     >>     abcd "**" abc
 		params.Terms = removeNonAlphaNumericUnquotedTerms(params.Terms, " ")
 
 		wg.Add(1)
 
-		go func(params *model.SearchParams) {
+		go func(i int, params *model.SearchParams) {
 			defer wg.Done()
-			postList, err := s.search(teamId, userId, params, false, false)
-			pchan <- store.StoreResult[*model.PostList]{Data: postList, NErr: err}
-		}(params)
+			postList, matches, err := s.search(teamId, userId, params, false, false)
+			results[i] = searchResult{list: postList, matches: matches, err: err}
+		}(i, params)
 	}
 
 	wg.Wait()
-	close(pchan)
 
 	posts := model.NewPostList()
+	matches := model.PostSearchMatches{}
+	lists := make([]*model.PostList, 0, len(results))
 
-	for result := range pchan {
-		if result.NErr != nil {
-			return nil, result.NErr
+	for _, result := range results {
+		if result.err != nil {
+			return nil, result.err
+		}
+		posts.Extend(result.list)
+		lists = append(lists, result.list)
+		for postID, postMatches := range result.matches {
+			matches[postID] = append(matches[postID], postMatches...)
 		}
-		posts.Extend(result.Data)
 	}
 
-	posts.SortByCreateAt()
+	if s.rankByRelevance() {
+		posts.Order = fuseSearchRankings(posts, lists)
+	} else {
+		posts.SortByCreateAt()
+	}
+
+	return model.MakePostSearchResults(posts, matches), nil
+}
+
+// fuseSearchRankings merges the results of the searches run for each set of
+// params, using the sum of the reciprocal of each post's rank in them. Posts
+// found by several of the searches come first, then the best ranked ones.
+func fuseSearchRankings(posts *model.PostList, lists []*model.PostList) []string {
+	const k = 60
+
+	scores := map[string]float64{}
+	for _, list := range lists {
+		for rank, postID := range list.Order {
+			scores[postID] += 1 / float64(k+rank+1)
+		}
+	}
 
-	return model.MakePostSearchResults(posts, nil), nil
+	order := slices.Clone(posts.Order)
+	sort.SliceStable(order, func(i, j int) bool {
+		if scores[order[i]] != scores[order[j]] {
+			return scores[order[i]] > scores[order[j]]
+		}
+		return posts.Posts[order[i]].CreateAt > posts.Posts[order[j]].CreateAt
+	})
+	return order
 }
 
 func (s *SqlPostStore) GetOldestEntityCreationTime() (int64, error) {
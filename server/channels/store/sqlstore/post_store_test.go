@@ -4,10 +4,15 @@
 package sqlstore
 
 import (
+	"regexp"
 	"testing"
 
+	sq "github.com/mattermost/squirrel"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/mattermost/mattermost/server/public/model"
+
 	"github.com/mattermost/mattermost/server/v8/channels/store/searchtest"
 	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
 )
@@ -63,3 +68,73 @@ func TestMysqlStopWords(t *testing.T) {
 		})
 	}
 }
+
+func TestTextSearchConfigGroups(t *testing.T) {
+	newPostStore := func(textSearchConfigs map[string]string) *SqlPostStore {
+		return &SqlPostStore{SqlStore: &SqlStore{
+			settings:                  &model.SqlSettings{TextSearchConfigs: textSearchConfigs},
+			pgDefaultTextSearchConfig: "pg_catalog.english",
+		}}
+	}
+
+	t.Run("without configurations", func(t *testing.T) {
+		groups := newPostStore(nil).textSearchConfigGroups()
+		require.Len(t, groups, 1)
+		assert.Equal(t, "pg_catalog.english", groups[0].config)
+		assert.Nil(t, groups[0].filter)
+	})
+
+	t.Run("with configurations", func(t *testing.T) {
+		channelID := model.NewId()
+		teamID := model.NewId()
+		otherTeamID := model.NewId()
+		groups := newPostStore(map[string]string{
+			channelID:   "french",
+			teamID:      "french",
+			otherTeamID: "german",
+		}).textSearchConfigGroups()
+
+		require.Len(t, groups, 3)
+		assert.Equal(t, "french", groups[0].config)
+		assert.Equal(t, "german", groups[1].config)
+		assert.Equal(t, "pg_catalog.english", groups[2].config)
+
+		for _, group := range groups {
+			require.NotNil(t, group.filter)
+			query, args, err := sq.Select("*").From("Posts q2").Where(group.filter).ToSql()
+			require.NoError(t, err)
+			// The configuration is never part of the query so that its index
+			// can be used.
+			assert.NotContains(t, query, "regconfig")
+			assert.NotContains(t, query, "CASE")
+			assert.NotEmpty(t, args)
+		}
+
+		_, args, err := sq.Select("*").From("Posts q2").Where(groups[1].filter).ToSql()
+		require.NoError(t, err)
+		assert.Contains(t, args, otherTeamID)
+	})
+}
+
+func TestMentionRegexp(t *testing.T) {
+	mention := regexp.MustCompile(mentionRegexp("John.Doe"))
+
+	for _, message := range []string{
+		"@john.doe",
+		"hi @john.doe, lunch?",
+		"thanks @john.doe.",
+		"(@john.doe)",
+	} {
+		assert.True(t, mention.MatchString(message), message)
+	}
+
+	for _, message := range []string{
+		"@john.doe2",
+		"@john.doe.smith",
+		"@john.doe_",
+		"john.doe@john.doe.com",
+		"@johnxdoe",
+	} {
+		assert.False(t, mention.MatchString(message), message)
+	}
+}
@@ -345,15 +345,32 @@ func (s *SqlReactionStore) DeleteOrphanedRowsByIds(r *model.RetentionIdsForDelet
 	return rowsAffected, nil
 }
 
+// reactionsLegalHoldScope matches the reactions held by legal holds.
+var reactionsLegalHoldScope = legalHoldScope{
+	StartColumn:   "Reactions.CreateAt",
+	EndColumn:     "Reactions.CreateAt",
+	ChannelColumn: "Reactions.ChannelId",
+	UserColumn:    "Reactions.UserId",
+}
+
 func (s *SqlReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	notHeld, notHeldArgs, err := reactionsLegalHoldScope.notHeld().ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "reaction_tosql")
+	}
+
 	var query string
 	if s.DriverName() == "postgres" {
-		query = "DELETE from Reactions WHERE CreateAt = any (array (SELECT CreateAt FROM Reactions WHERE CreateAt < ? LIMIT ?))"
+		query = "DELETE from Reactions WHERE CreateAt = any (array (SELECT CreateAt FROM Reactions WHERE CreateAt < ? AND " + notHeld + " LIMIT ?)) AND " + notHeld
 	} else {
-		query = "DELETE from Reactions WHERE CreateAt < ? LIMIT ?"
+		query = "DELETE from Reactions WHERE CreateAt < ? AND " + notHeld + " LIMIT ?"
 	}
 
-	sqlResult, err := s.GetMasterX().Exec(query, endTime, limit)
+	args := append(append([]any{endTime}, notHeldArgs...), limit)
+	if s.DriverName() == "postgres" {
+		args = append(args, notHeldArgs...)
+	}
+	sqlResult, err := s.GetMasterX().Exec(query, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to delete Reactions")
 	}
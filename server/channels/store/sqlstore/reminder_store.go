@@ -0,0 +1,227 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlReminderStore struct {
+	*SqlStore
+}
+
+func newSqlReminderStore(sqlStore *SqlStore) store.ReminderStore {
+	return &SqlReminderStore{sqlStore}
+}
+
+func reminderSliceColumns() []string {
+	return []string{
+		"Id",
+		"CreateAt",
+		"UpdateAt",
+		"UserId",
+		"TargetType",
+		"TargetId",
+		"Message",
+		"TriggerAt",
+		"LastTriggeredAt",
+		"Recurrence",
+	}
+}
+
+func reminderToSlice(reminder *model.Reminder) []any {
+	return []any{
+		reminder.Id,
+		reminder.CreateAt,
+		reminder.UpdateAt,
+		reminder.UserId,
+		reminder.TargetType,
+		reminder.TargetId,
+		reminder.Message,
+		reminder.TriggerAt,
+		reminder.LastTriggeredAt,
+		reminder.Recurrence,
+	}
+}
+
+func (s *SqlReminderStore) Save(reminder *model.Reminder) (*model.Reminder, error) {
+	reminder.PreSave()
+	if err := reminder.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("Reminders").
+		Columns(reminderSliceColumns()...).
+		Values(reminderToSlice(reminder)...)
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save Reminder with id=%s", reminder.Id)
+	}
+
+	return reminder, nil
+}
+
+func (s *SqlReminderStore) Get(reminderID string) (*model.Reminder, error) {
+	query := s.getQueryBuilder().
+		Select(reminderSliceColumns()...).
+		From("Reminders").
+		Where(sq.Eq{"Id": reminderID})
+
+	var reminder model.Reminder
+	if err := s.GetReplicaX().GetBuilder(&reminder, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("Reminder", reminderID)
+		}
+		return nil, errors.Wrapf(err, "failed to get Reminder with id=%s", reminderID)
+	}
+
+	return &reminder, nil
+}
+
+// GetForUser returns the reminders created by the given user, the ones to be
+// delivered first coming first and the completed ones last.
+func (s *SqlReminderStore) GetForUser(userID string) ([]*model.Reminder, error) {
+	query := s.getQueryBuilder().
+		Select(reminderSliceColumns()...).
+		From("Reminders").
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("TriggerAt = 0", "TriggerAt ASC", "LastTriggeredAt DESC", "Id ASC")
+
+	reminders := []*model.Reminder{}
+	if err := s.GetReplicaX().SelectBuilder(&reminders, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get Reminders for userId=%s", userID)
+	}
+
+	return reminders, nil
+}
+
+// GetLastTriggeredForUser returns the reminder most recently delivered to the
+// given user, or created by the user for a channel.
+func (s *SqlReminderStore) GetLastTriggeredForUser(userID string) (*model.Reminder, error) {
+	query := s.getQueryBuilder().
+		Select(reminderSliceColumns()...).
+		From("Reminders").
+		Where(sq.Or{
+			sq.Eq{"TargetType": model.ReminderTargetUser, "TargetId": userID},
+			sq.Eq{"TargetType": model.ReminderTargetChannel, "UserId": userID},
+		}).
+		Where(sq.Gt{"LastTriggeredAt": 0}).
+		OrderBy("LastTriggeredAt DESC", "Id ASC").
+		Limit(1)
+
+	var reminder model.Reminder
+	if err := s.GetReplicaX().GetBuilder(&reminder, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("Reminder", "userId="+userID)
+		}
+		return nil, errors.Wrapf(err, "failed to get last triggered Reminder for userId=%s", userID)
+	}
+
+	return &reminder, nil
+}
+
+// GetDue returns the reminders to be delivered before the given time, the
+// oldest first.
+func (s *SqlReminderStore) GetDue(before int64, limit int) ([]*model.Reminder, error) {
+	query := s.getQueryBuilder().
+		Select(reminderSliceColumns()...).
+		From("Reminders").
+		Where(sq.And{
+			sq.Gt{"TriggerAt": 0},
+			sq.LtOrEq{"TriggerAt": before},
+		}).
+		OrderBy("TriggerAt ASC", "Id ASC").
+		Limit(uint64(limit))
+
+	reminders := []*model.Reminder{}
+	if err := s.GetMasterX().SelectBuilder(&reminders, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get due Reminders")
+	}
+
+	return reminders, nil
+}
+
+// Update updates the delivery times of a reminder, the only fields that can
+// change once the reminder has been created.
+func (s *SqlReminderStore) Update(reminder *model.Reminder) error {
+	reminder.PreUpdate()
+	if err := reminder.IsValid(); err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder().
+		Update("Reminders").
+		Set("UpdateAt", reminder.UpdateAt).
+		Set("TriggerAt", reminder.TriggerAt).
+		Set("LastTriggeredAt", reminder.LastTriggeredAt).
+		Where(sq.Eq{"Id": reminder.Id})
+
+	res, err := s.GetMasterX().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update Reminder with id=%s", reminder.Id)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if count == 0 {
+		return store.NewErrNotFound("Reminder", reminder.Id)
+	}
+
+	return nil
+}
+
+func (s *SqlReminderStore) Delete(reminderID string) error {
+	query := s.getQueryBuilder().
+		Delete("Reminders").
+		Where(sq.Eq{"Id": reminderID})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete Reminder with id=%s", reminderID)
+	}
+
+	return nil
+}
+
+// DeleteCompletedBefore deletes the reminders that were delivered for the
+// last time before the given time.
+func (s *SqlReminderStore) DeleteCompletedBefore(before int64) error {
+	query := s.getQueryBuilder().
+		Delete("Reminders").
+		Where(sq.And{
+			sq.Eq{"TriggerAt": 0},
+			sq.Lt{"LastTriggeredAt": before},
+		})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrap(err, "failed to delete completed Reminders")
+	}
+
+	return nil
+}
+
+// PermanentDeleteByUser deletes the reminders created by the given user and
+// the ones to be delivered to the user.
+func (s *SqlReminderStore) PermanentDeleteByUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete("Reminders").
+		Where(sq.Or{
+			sq.Eq{"UserId": userID},
+			sq.Eq{"TargetType": model.ReminderTargetUser, "TargetId": userID},
+		})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete Reminders for userId=%s", userID)
+	}
+
+	return nil
+}
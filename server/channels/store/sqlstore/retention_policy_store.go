@@ -933,6 +933,9 @@ type RetentionPolicyBatchDeletionInfo struct {
 	GlobalPolicyEndTime int64
 	Limit               int64
 	StoreDeletedIds     bool
+	// LegalHold matches the records preserved by legal holds, which are never
+	// deleted. It is left empty for the records which legal holds don't cover.
+	LegalHold legalHoldScope
 }
 
 // genericPermanentDeleteBatchForRetentionPolicies is a helper function for tables
@@ -943,6 +946,9 @@ func genericPermanentDeleteBatchForRetentionPolicies(
 	cursor model.RetentionPolicyCursor,
 ) (int64, model.RetentionPolicyCursor, error) {
 	baseBuilder := r.BaseBuilder.InnerJoin("Channels ON " + r.ChannelIDTable + ".ChannelId = Channels.Id")
+	if !r.LegalHold.isZero() {
+		baseBuilder = baseBuilder.Where(r.LegalHold.notHeld())
+	}
 
 	scopedTimeColumn := r.Table + "." + r.TimeColumn
 	nowStr := strconv.FormatInt(r.NowMillis, 10)
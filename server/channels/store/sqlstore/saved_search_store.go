@@ -0,0 +1,228 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlSavedSearchStore struct {
+	*SqlStore
+}
+
+func newSqlSavedSearchStore(sqlStore *SqlStore) store.SavedSearchStore {
+	return &SqlSavedSearchStore{sqlStore}
+}
+
+func savedSearchSliceColumns(prefix ...string) []string {
+	var p string
+	if len(prefix) == 1 {
+		p = prefix[0] + "."
+	} else if len(prefix) > 1 {
+		panic("cannot accept multiple prefixes")
+	}
+
+	return []string{
+		p + "Id",
+		p + "CreateAt",
+		p + "UpdateAt",
+		p + "UserId",
+		p + "TeamId",
+		p + "Name",
+		p + "Terms",
+		p + "IsOrSearch",
+		p + "TimeZoneOffset",
+		p + "Alert",
+		p + "LastCheckedAt",
+	}
+}
+
+func savedSearchToSlice(savedSearch *model.SavedSearch) []any {
+	return []any{
+		savedSearch.Id,
+		savedSearch.CreateAt,
+		savedSearch.UpdateAt,
+		savedSearch.UserId,
+		savedSearch.TeamId,
+		savedSearch.Name,
+		savedSearch.Terms,
+		savedSearch.IsOrSearch,
+		savedSearch.TimeZoneOffset,
+		savedSearch.Alert,
+		savedSearch.LastCheckedAt,
+	}
+}
+
+func (s *SqlSavedSearchStore) Save(savedSearch *model.SavedSearch) (*model.SavedSearch, error) {
+	savedSearch.PreSave()
+	if err := savedSearch.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("SavedSearches").
+		Columns(savedSearchSliceColumns()...).
+		Values(savedSearchToSlice(savedSearch)...)
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save SavedSearch with id=%s", savedSearch.Id)
+	}
+
+	return savedSearch, nil
+}
+
+func (s *SqlSavedSearchStore) Get(savedSearchID string) (*model.SavedSearch, error) {
+	query := s.getQueryBuilder().
+		Select(savedSearchSliceColumns()...).
+		From("SavedSearches").
+		Where(sq.Eq{"Id": savedSearchID})
+
+	var savedSearch model.SavedSearch
+	if err := s.GetReplicaX().GetBuilder(&savedSearch, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("SavedSearch", savedSearchID)
+		}
+		return nil, errors.Wrapf(err, "failed to get SavedSearch with id=%s", savedSearchID)
+	}
+
+	return &savedSearch, nil
+}
+
+func (s *SqlSavedSearchStore) GetForUser(userID string) ([]*model.SavedSearch, error) {
+	query := s.getQueryBuilder().
+		Select(savedSearchSliceColumns()...).
+		From("SavedSearches").
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("Name ASC", "Id ASC")
+
+	savedSearches := []*model.SavedSearch{}
+	if err := s.GetReplicaX().SelectBuilder(&savedSearches, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get SavedSearches for userId=%s", userID)
+	}
+
+	return savedSearches, nil
+}
+
+func (s *SqlSavedSearchStore) GetWithAlerts(afterID string, limit int) ([]*model.SavedSearch, error) {
+	query := s.getQueryBuilder().
+		Select(savedSearchSliceColumns()...).
+		From("SavedSearches").
+		Where(sq.And{
+			sq.NotEq{"Alert": model.SavedSearchAlertNone},
+			sq.Gt{"Id": afterID},
+		}).
+		OrderBy("Id ASC").
+		Limit(uint64(limit))
+
+	savedSearches := []*model.SavedSearch{}
+	if err := s.GetMasterX().SelectBuilder(&savedSearches, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get SavedSearches with alerts")
+	}
+
+	return savedSearches, nil
+}
+
+func (s *SqlSavedSearchStore) GetWithAlertsForChannel(channelID string) ([]*model.SavedSearch, error) {
+	query := s.getQueryBuilder().
+		Select(savedSearchSliceColumns("SavedSearches")...).
+		From("SavedSearches").
+		Join("ChannelMembers ON ChannelMembers.UserId = SavedSearches.UserId").
+		Where(sq.And{
+			sq.Eq{"ChannelMembers.ChannelId": channelID},
+			sq.NotEq{"SavedSearches.Alert": model.SavedSearchAlertNone},
+		}).
+		OrderBy("SavedSearches.Id ASC")
+
+	savedSearches := []*model.SavedSearch{}
+	if err := s.GetReplicaX().SelectBuilder(&savedSearches, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get SavedSearches with alerts for channelId=%s", channelID)
+	}
+
+	return savedSearches, nil
+}
+
+func (s *SqlSavedSearchStore) Update(savedSearch *model.SavedSearch) (*model.SavedSearch, error) {
+	savedSearch.PreUpdate()
+	if err := savedSearch.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Update("SavedSearches").
+		Set("UpdateAt", savedSearch.UpdateAt).
+		Set("Name", savedSearch.Name).
+		Set("Terms", savedSearch.Terms).
+		Set("IsOrSearch", savedSearch.IsOrSearch).
+		Set("TimeZoneOffset", savedSearch.TimeZoneOffset).
+		Set("Alert", savedSearch.Alert).
+		Set("LastCheckedAt", savedSearch.LastCheckedAt).
+		Where(sq.Eq{"Id": savedSearch.Id})
+
+	res, err := s.GetMasterX().ExecBuilder(query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update SavedSearch with id=%s", savedSearch.Id)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get rows affected")
+	}
+	if count == 0 {
+		return nil, store.NewErrNotFound("SavedSearch", savedSearch.Id)
+	}
+
+	return savedSearch, nil
+}
+
+func (s *SqlSavedSearchStore) UpdateLastCheckedAt(savedSearchID string, previous, lastCheckedAt int64) (bool, error) {
+	query := s.getQueryBuilder().
+		Update("SavedSearches").
+		Set("LastCheckedAt", lastCheckedAt).
+		Where(sq.Eq{
+			"Id":            savedSearchID,
+			"LastCheckedAt": previous,
+		})
+
+	res, err := s.GetMasterX().ExecBuilder(query)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to update LastCheckedAt of SavedSearch with id=%s", savedSearchID)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	return count > 0, nil
+}
+
+func (s *SqlSavedSearchStore) Delete(savedSearchID string) error {
+	query := s.getQueryBuilder().
+		Delete("SavedSearches").
+		Where(sq.Eq{"Id": savedSearchID})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete SavedSearch with id=%s", savedSearchID)
+	}
+
+	return nil
+}
+
+func (s *SqlSavedSearchStore) PermanentDeleteByUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete("SavedSearches").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete SavedSearches for userId=%s", userID)
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlScheduledPostStore struct {
+	*SqlStore
+}
+
+func newSqlScheduledPostStore(sqlStore *SqlStore) store.ScheduledPostStore {
+	return &SqlScheduledPostStore{sqlStore}
+}
+
+func scheduledPostSliceColumns() []string {
+	return []string{
+		"Id",
+		"CreateAt",
+		"UpdateAt",
+		"UserId",
+		"ChannelId",
+		"RootId",
+		"Message",
+		"Props",
+		"FileIds",
+		"Priority",
+		"ScheduledAt",
+		"ProcessedAt",
+		"PostId",
+		"ErrorCode",
+	}
+}
+
+func scheduledPostToSlice(scheduledPost *model.ScheduledPost) []any {
+	return []any{
+		scheduledPost.Id,
+		scheduledPost.CreateAt,
+		scheduledPost.UpdateAt,
+		scheduledPost.UserId,
+		scheduledPost.ChannelId,
+		scheduledPost.RootId,
+		scheduledPost.Message,
+		model.StringInterfaceToJSON(scheduledPost.GetProps()),
+		model.ArrayToJSON(scheduledPost.FileIds),
+		model.StringInterfaceToJSON(scheduledPost.Priority),
+		scheduledPost.ScheduledAt,
+		scheduledPost.ProcessedAt,
+		scheduledPost.PostId,
+		scheduledPost.ErrorCode,
+	}
+}
+
+func (s *SqlScheduledPostStore) Save(scheduledPost *model.ScheduledPost) (*model.ScheduledPost, error) {
+	scheduledPost.PreSave()
+	if err := scheduledPost.IsValid(model.PostMessageMaxRunesV2); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert("ScheduledPosts").
+		Columns(scheduledPostSliceColumns()...).
+		Values(scheduledPostToSlice(scheduledPost)...)
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save ScheduledPost with id=%s", scheduledPost.Id)
+	}
+
+	return scheduledPost, nil
+}
+
+func (s *SqlScheduledPostStore) Get(scheduledPostID string) (*model.ScheduledPost, error) {
+	query := s.getQueryBuilder().
+		Select(scheduledPostSliceColumns()...).
+		From("ScheduledPosts").
+		Where(sq.Eq{"Id": scheduledPostID})
+
+	var scheduledPost model.ScheduledPost
+	if err := s.GetReplicaX().GetBuilder(&scheduledPost, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ScheduledPost", scheduledPostID)
+		}
+		return nil, errors.Wrapf(err, "failed to get ScheduledPost with id=%s", scheduledPostID)
+	}
+
+	return &scheduledPost, nil
+}
+
+// Update updates the user editable fields of a scheduled post that is yet
+// to be processed.
+func (s *SqlScheduledPostStore) Update(scheduledPost *model.ScheduledPost) error {
+	scheduledPost.PreUpdate()
+	if err := scheduledPost.IsValid(model.PostMessageMaxRunesV2); err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder().
+		Update("ScheduledPosts").
+		Set("UpdateAt", scheduledPost.UpdateAt).
+		Set("Message", scheduledPost.Message).
+		Set("Props", model.StringInterfaceToJSON(scheduledPost.GetProps())).
+		Set("FileIds", model.ArrayToJSON(scheduledPost.FileIds)).
+		Set("Priority", model.StringInterfaceToJSON(scheduledPost.Priority)).
+		Set("ScheduledAt", scheduledPost.ScheduledAt).
+		Where(sq.Eq{
+			"Id":          scheduledPost.Id,
+			"ProcessedAt": 0,
+		})
+
+	res, err := s.GetMasterX().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update ScheduledPost with id=%s", scheduledPost.Id)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get affected rows after updating ScheduledPost with id=%s", scheduledPost.Id)
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("ScheduledPost", scheduledPost.Id)
+	}
+
+	return nil
+}
+
+func (s *SqlScheduledPostStore) Delete(scheduledPostID string) error {
+	query := s.getQueryBuilder().
+		Delete("ScheduledPosts").
+		Where(sq.Eq{"Id": scheduledPostID})
+
+	res, err := s.GetMasterX().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete ScheduledPost with id=%s", scheduledPostID)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get affected rows after deleting ScheduledPost with id=%s", scheduledPostID)
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("ScheduledPost", scheduledPostID)
+	}
+
+	return nil
+}
+
+// GetScheduledPostsForUser returns the scheduled posts of a user in the
+// channels of the given team, including direct and group messages.
+func (s *SqlScheduledPostStore) GetScheduledPostsForUser(userID, teamID string) ([]*model.ScheduledPost, error) {
+	columns := scheduledPostSliceColumns()
+	for i, column := range columns {
+		columns[i] = "sp." + column
+	}
+
+	query := s.getQueryBuilder().
+		Select(columns...).
+		From("ScheduledPosts sp").
+		InnerJoin("Channels c ON c.Id = sp.ChannelId").
+		Where(sq.And{
+			sq.Eq{"sp.UserId": userID},
+			sq.Or{
+				sq.Eq{"c.TeamId": teamID},
+				sq.Eq{"c.TeamId": ""},
+			},
+		}).
+		OrderBy("sp.ScheduledAt ASC", "sp.Id ASC")
+
+	scheduledPosts := []*model.ScheduledPost{}
+	if err := s.GetReplicaX().SelectBuilder(&scheduledPosts, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ScheduledPosts for userId=%s and teamId=%s", userID, teamID)
+	}
+
+	return scheduledPosts, nil
+}
+
+// GetPendingScheduledPosts returns a page of unprocessed scheduled posts due
+// before opts.ScheduledBefore, ordered by ScheduledAt and Id so callers can
+// paginate with AfterTime and AfterId.
+func (s *SqlScheduledPostStore) GetPendingScheduledPosts(opts model.GetScheduledPostsForProcessingOptions) ([]*model.ScheduledPost, error) {
+	query := s.getQueryBuilder().
+		Select(scheduledPostSliceColumns()...).
+		From("ScheduledPosts").
+		Where(sq.And{
+			sq.Eq{"ProcessedAt": 0},
+			sq.LtOrEq{"ScheduledAt": opts.ScheduledBefore},
+			sq.Or{
+				sq.Gt{"ScheduledAt": opts.AfterTime},
+				sq.And{
+					sq.Eq{"ScheduledAt": opts.AfterTime},
+					sq.Gt{"Id": opts.AfterId},
+				},
+			},
+		}).
+		OrderBy("ScheduledAt ASC", "Id ASC").
+		Limit(uint64(opts.PerPage))
+
+	scheduledPosts := []*model.ScheduledPost{}
+	if err := s.GetMasterX().SelectBuilder(&scheduledPosts, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get pending ScheduledPosts")
+	}
+
+	return scheduledPosts, nil
+}
+
+// MarkAsProcessed records the outcome of publishing a scheduled post: either
+// the ID of the created post, or the reason it could not be published.
+func (s *SqlScheduledPostStore) MarkAsProcessed(scheduledPostID, postID, errorCode string, processedAt int64) error {
+	query := s.getQueryBuilder().
+		Update("ScheduledPosts").
+		Set("ProcessedAt", processedAt).
+		Set("UpdateAt", processedAt).
+		Set("PostId", postID).
+		Set("ErrorCode", errorCode).
+		Where(sq.Eq{"Id": scheduledPostID})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to mark ScheduledPost with id=%s as processed", scheduledPostID)
+	}
+
+	return nil
+}
+
+func (s *SqlScheduledPostStore) PermanentDeleteByUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete("ScheduledPosts").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete ScheduledPosts for userId=%s", userID)
+	}
+
+	return nil
+}
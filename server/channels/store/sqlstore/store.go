@@ -111,6 +111,16 @@ type SqlStoreStores struct {
 	postPersistentNotification store.PostPersistentNotificationStore
 	desktopTokens              store.DesktopTokensStore
 	channelBookmarks           store.ChannelBookmarkStore
+	scheduledPost              store.ScheduledPostStore
+	outgoingWebhookDelivery    store.OutgoingWebhookDeliveryStore
+	poll                       store.PollStore
+	reminder                   store.ReminderStore
+	importIdMapping            store.ImportIdMappingStore
+	legalHold                  store.LegalHoldStore
+	webAuthnCredential         store.WebAuthnCredentialStore
+	mfaRecoveryCode            store.MfaRecoveryCodeStore
+	webPushSubscription        store.WebPushSubscriptionStore
+	savedSearch                store.SavedSearchStore
 }
 
 type SqlStore struct {
@@ -236,6 +246,16 @@ func New(settings model.SqlSettings, logger mlog.LoggerIFace, metrics einterface
 	store.stores.postPersistentNotification = newSqlPostPersistentNotificationStore(store)
 	store.stores.desktopTokens = newSqlDesktopTokensStore(store, metrics)
 	store.stores.channelBookmarks = newSqlChannelBookmarkStore(store)
+	store.stores.scheduledPost = newSqlScheduledPostStore(store)
+	store.stores.outgoingWebhookDelivery = newSqlOutgoingWebhookDeliveryStore(store)
+	store.stores.poll = newSqlPollStore(store)
+	store.stores.reminder = newSqlReminderStore(store)
+	store.stores.importIdMapping = newSqlImportIdMappingStore(store)
+	store.stores.legalHold = newSqlLegalHoldStore(store)
+	store.stores.webAuthnCredential = newSqlWebAuthnCredentialStore(store)
+	store.stores.mfaRecoveryCode = newSqlMfaRecoveryCodeStore(store)
+	store.stores.webPushSubscription = newSqlWebPushSubscriptionStore(store)
+	store.stores.savedSearch = newSqlSavedSearchStore(store)
 
 	store.stores.preference.(*SqlPreferenceStore).deleteUnusedFeatures()
 
@@ -1039,6 +1059,46 @@ func (ss *SqlStore) ChannelBookmark() store.ChannelBookmarkStore {
 	return ss.stores.channelBookmarks
 }
 
+func (ss *SqlStore) ScheduledPost() store.ScheduledPostStore {
+	return ss.stores.scheduledPost
+}
+
+func (ss *SqlStore) OutgoingWebhookDelivery() store.OutgoingWebhookDeliveryStore {
+	return ss.stores.outgoingWebhookDelivery
+}
+
+func (ss *SqlStore) Poll() store.PollStore {
+	return ss.stores.poll
+}
+
+func (ss *SqlStore) Reminder() store.ReminderStore {
+	return ss.stores.reminder
+}
+
+func (ss *SqlStore) ImportIdMapping() store.ImportIdMappingStore {
+	return ss.stores.importIdMapping
+}
+
+func (ss *SqlStore) LegalHold() store.LegalHoldStore {
+	return ss.stores.legalHold
+}
+
+func (ss *SqlStore) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return ss.stores.webAuthnCredential
+}
+
+func (ss *SqlStore) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	return ss.stores.mfaRecoveryCode
+}
+
+func (ss *SqlStore) WebPushSubscription() store.WebPushSubscriptionStore {
+	return ss.stores.webPushSubscription
+}
+
+func (ss *SqlStore) SavedSearch() store.SavedSearchStore {
+	return ss.stores.savedSearch
+}
+
 func (ss *SqlStore) DropAllTables() {
 	if ss.DriverName() == model.DatabaseDriverPostgres {
 		ss.masterX.Exec(`DO
@@ -981,6 +981,11 @@ func (s *SqlThreadStore) PermanentDeleteBatchForRetentionPolicies(now, globalPol
 		GlobalPolicyEndTime: globalPolicyEndTime,
 		Limit:               limit,
 		StoreDeletedIds:     false,
+		LegalHold: legalHoldScope{
+			StartColumn:   "Threads.LastReplyAt",
+			EndColumn:     "Threads.LastReplyAt",
+			ChannelColumn: "Threads.ChannelId",
+		},
 	}, s.SqlStore, cursor)
 }
 
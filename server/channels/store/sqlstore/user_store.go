@@ -1236,6 +1236,23 @@ func (us SqlUserStore) GetAllUsingAuthService(authService string) ([]*model.User
 	return users, nil
 }
 
+// GetScimProfiles returns a page of the users which can be provisioned through SCIM,
+// that is every user, deactivated ones included, but bots and remote users.
+func (us SqlUserStore) GetScimProfiles(offset, limit int) ([]*model.User, error) {
+	query := us.usersQuery.
+		Where("b.UserId IS NULL").
+		Where(sq.Or{sq.Eq{"u.RemoteId": ""}, sq.Eq{"u.RemoteId": nil}}).
+		OrderBy("u.CreateAt ASC", "u.Id ASC").
+		Offset(uint64(offset)).Limit(uint64(limit))
+
+	users := []*model.User{}
+	if err := us.GetReplicaX().SelectBuilder(&users, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get SCIM User profiles")
+	}
+
+	return users, nil
+}
+
 func (us SqlUserStore) GetAllNotInAuthService(authServices []string) ([]*model.User, error) {
 	query := us.usersQuery.
 		Where(sq.NotEq{"u.AuthService": authServices}).
@@ -0,0 +1,162 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlWebPushSubscriptionStore struct {
+	*SqlStore
+}
+
+func newSqlWebPushSubscriptionStore(sqlStore *SqlStore) store.WebPushSubscriptionStore {
+	return &SqlWebPushSubscriptionStore{sqlStore}
+}
+
+func webPushSubscriptionSliceColumns(prefix string) []string {
+	return []string{
+		prefix + "Id",
+		prefix + "UserId",
+		prefix + "SessionId",
+		prefix + "Endpoint",
+		prefix + "P256dh",
+		prefix + "Auth",
+		prefix + "CreateAt",
+	}
+}
+
+func webPushSubscriptionToSlice(subscription *model.WebPushSubscription) []any {
+	return []any{
+		subscription.Id,
+		subscription.UserId,
+		subscription.SessionId,
+		subscription.Endpoint,
+		subscription.P256dh,
+		subscription.Auth,
+		subscription.CreateAt,
+	}
+}
+
+// Save replaces the subscription of the session, and the subscriptions of
+// other sessions of the user with the same endpoint, which belong to the same
+// browser.
+func (s *SqlWebPushSubscriptionStore) Save(subscription *model.WebPushSubscription) (_ *model.WebPushSubscription, err error) {
+	subscription.PreSave()
+	if appErr := subscription.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	transaction, err := s.GetMasterX().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	deleteQuery := s.getQueryBuilder().
+		Delete("WebPushSubscriptions").
+		Where(sq.Or{
+			sq.Eq{"SessionId": subscription.SessionId},
+			sq.Eq{"UserId": subscription.UserId, "Endpoint": subscription.Endpoint},
+		})
+	if _, err = transaction.ExecBuilder(deleteQuery); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete WebPushSubscriptions with sessionId=%s", subscription.SessionId)
+	}
+
+	insertQuery := s.getQueryBuilder().
+		Insert("WebPushSubscriptions").
+		Columns(webPushSubscriptionSliceColumns("")...).
+		Values(webPushSubscriptionToSlice(subscription)...)
+	if _, err = transaction.ExecBuilder(insertQuery); err != nil {
+		return nil, errors.Wrapf(err, "failed to save WebPushSubscription with id=%s", subscription.Id)
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return subscription, nil
+}
+
+// GetForUser returns the subscriptions of the sessions of the user which
+// haven't expired.
+func (s *SqlWebPushSubscriptionStore) GetForUser(userID string) ([]*model.WebPushSubscription, error) {
+	query := s.getQueryBuilder().
+		Select(webPushSubscriptionSliceColumns("WebPushSubscriptions.")...).
+		From("WebPushSubscriptions").
+		Join("Sessions ON Sessions.Id = WebPushSubscriptions.SessionId").
+		Where(sq.Eq{"WebPushSubscriptions.UserId": userID}).
+		Where(sq.Or{
+			sq.Eq{"Sessions.ExpiresAt": 0},
+			sq.Gt{"Sessions.ExpiresAt": model.GetMillis()},
+		}).
+		OrderBy("WebPushSubscriptions.CreateAt ASC")
+
+	subscriptions := []*model.WebPushSubscription{}
+	if err := s.GetReplicaX().SelectBuilder(&subscriptions, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get WebPushSubscriptions with userId=%s", userID)
+	}
+
+	return subscriptions, nil
+}
+
+func (s *SqlWebPushSubscriptionStore) Delete(id string) error {
+	query := s.getQueryBuilder().
+		Delete("WebPushSubscriptions").
+		Where(sq.Eq{"Id": id})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete WebPushSubscription with id=%s", id)
+	}
+
+	return nil
+}
+
+func (s *SqlWebPushSubscriptionStore) DeleteForSession(sessionID string) error {
+	query := s.getQueryBuilder().
+		Delete("WebPushSubscriptions").
+		Where(sq.Eq{"SessionId": sessionID})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete WebPushSubscription with sessionId=%s", sessionID)
+	}
+
+	return nil
+}
+
+func (s *SqlWebPushSubscriptionStore) DeleteForUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete("WebPushSubscriptions").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMasterX().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete WebPushSubscriptions with userId=%s", userID)
+	}
+
+	return nil
+}
+
+// DeleteOrphaned deletes the subscriptions of the sessions which were
+// removed.
+func (s *SqlWebPushSubscriptionStore) DeleteOrphaned() (int64, error) {
+	query := s.getQueryBuilder().
+		Delete("WebPushSubscriptions").
+		Where(sq.Expr("SessionId NOT IN (SELECT Id FROM Sessions)"))
+
+	result, err := s.GetMasterX().ExecBuilder(query)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete orphaned WebPushSubscriptions")
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get rows affected")
+	}
+
+	return deleted, nil
+}
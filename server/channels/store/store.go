@@ -91,6 +91,16 @@ type Store interface {
 	PostPersistentNotification() PostPersistentNotificationStore
 	DesktopTokens() DesktopTokensStore
 	ChannelBookmark() ChannelBookmarkStore
+	ScheduledPost() ScheduledPostStore
+	OutgoingWebhookDelivery() OutgoingWebhookDeliveryStore
+	Poll() PollStore
+	Reminder() ReminderStore
+	ImportIdMapping() ImportIdMappingStore
+	LegalHold() LegalHoldStore
+	WebAuthnCredential() WebAuthnCredentialStore
+	MfaRecoveryCode() MfaRecoveryCodeStore
+	WebPushSubscription() WebPushSubscriptionStore
+	SavedSearch() SavedSearchStore
 }
 
 type RetentionPolicyStore interface {
@@ -358,6 +368,9 @@ type ThreadStore interface {
 
 type PostStore interface {
 	SaveMultiple(posts []*model.Post) ([]*model.Post, int, error)
+	// SaveMultipleWithIds saves posts like SaveMultiple, but keeps the IDs
+	// already set on them. It is used by the bulk import.
+	SaveMultipleWithIds(posts []*model.Post) ([]*model.Post, int, error)
 	Save(rctx request.CTX, post *model.Post) (*model.Post, error)
 	Update(rctx request.CTX, newPost *model.Post, oldPost *model.Post) (*model.Post, error)
 	Get(ctx context.Context, id string, opts model.GetPostsOptions, userID string, sanitizeOptions map[string]bool) (*model.PostList, error)
@@ -390,14 +403,17 @@ type PostStore interface {
 	OverwriteMultiple(posts []*model.Post) ([]*model.Post, int, error)
 	GetPostsByIds(postIds []string) ([]*model.Post, error)
 	GetEditHistoryForPost(postID string) ([]*model.Post, error)
+	// PermanentDeleteEditHistoryForPost deletes the oldest previous versions of
+	// the post, keeping the given number of most recent ones.
+	PermanentDeleteEditHistoryForPost(postID string, keep int) (int64, error)
 	GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error)
 	PermanentDeleteBatchForRetentionPolicies(now, globalPolicyEndTime, limit int64, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error)
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	GetOldest() (*model.Post, error)
 	GetMaxPostSize() int
-	GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error)
+	GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool, since int64) ([]*model.PostForExport, error)
 	GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error)
-	GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error)
+	GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool, since int64) ([]*model.DirectPostForExport, error)
 	SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userID, teamID string, page, perPage int) (*model.PostSearchResults, error)
 	GetOldestEntityCreationTime() (int64, error)
 	HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userID string) (bool, error)
@@ -446,6 +462,7 @@ type UserStore interface {
 	GetByRemoteID(remoteID string) (*model.User, error)
 	GetByAuth(authData *string, authService string) (*model.User, error)
 	GetAllUsingAuthService(authService string) ([]*model.User, error)
+	GetScimProfiles(offset, limit int) ([]*model.User, error)
 	GetAllNotInAuthService(authServices []string) ([]*model.User, error)
 	GetByUsername(username string) (*model.User, error)
 	GetForLogin(loginID string, allowSignInWithUsername, allowSignInWithEmail bool) (*model.User, error)
@@ -557,6 +574,9 @@ type ComplianceStore interface {
 	GetAll(offset, limit int) (model.Compliances, error)
 	ComplianceExport(compliance *model.Compliance, cursor model.ComplianceExportCursor, limit int) ([]*model.CompliancePost, model.ComplianceExportCursor, error)
 	MessageExport(c request.CTX, cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error)
+	// LegalHoldMessageExport returns the posts held by the given legal hold, in
+	// the same order and format as MessageExport.
+	LegalHoldMessageExport(c request.CTX, legalHoldID string, cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error)
 }
 
 type OAuthStore interface {
@@ -656,6 +676,7 @@ type PreferenceStore interface {
 	Save(preferences model.Preferences) error
 	GetCategory(userID string, category string) (model.Preferences, error)
 	GetCategoryAndName(category string, nane string) (model.Preferences, error)
+	GetCategoryAndNameForUsers(userIDs []string, category string, name string) (model.Preferences, error)
 	Get(userID string, category string, name string) (*model.Preference, error)
 	GetAll(userID string) (model.Preferences, error)
 	Delete(userID, category, name string) error
@@ -728,6 +749,7 @@ type FileInfoStore interface {
 	PermanentDeleteBatch(ctx request.CTX, endTime int64, limit int64) (int64, error)
 	PermanentDeleteByUser(ctx request.CTX, userID string) (int64, error)
 	SetContent(ctx request.CTX, fileID, content string) error
+	UpdateScanStatus(fileID, scanStatus string) error
 	Search(ctx request.CTX, paramsList []*model.SearchParams, userID, teamID string, page, perPage int) (*model.FileInfoList, error)
 	CountAll() (int64, error)
 	GetFilesBatchForIndexing(startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error)
@@ -1053,6 +1075,129 @@ type ChannelBookmarkStore interface {
 	GetBookmarksForChannelSince(channelID string, since int64) ([]*model.ChannelBookmarkWithFileInfo, error)
 }
 
+type ScheduledPostStore interface {
+	Save(scheduledPost *model.ScheduledPost) (*model.ScheduledPost, error)
+	Get(scheduledPostID string) (*model.ScheduledPost, error)
+	Update(scheduledPost *model.ScheduledPost) error
+	Delete(scheduledPostID string) error
+	GetScheduledPostsForUser(userID, teamID string) ([]*model.ScheduledPost, error)
+	GetPendingScheduledPosts(opts model.GetScheduledPostsForProcessingOptions) ([]*model.ScheduledPost, error)
+	MarkAsProcessed(scheduledPostID, postID, errorCode string, processedAt int64) error
+	PermanentDeleteByUser(userID string) error
+}
+
+type OutgoingWebhookDeliveryStore interface {
+	Save(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error)
+	Get(deliveryID string) (*model.OutgoingWebhookDelivery, error)
+	Update(delivery *model.OutgoingWebhookDelivery) error
+	GetForHook(hookID string, opts model.OutgoingWebhookDeliveryGetOptions) ([]*model.OutgoingWebhookDelivery, error)
+	GetPending(nextAttemptBefore int64, limit int) ([]*model.OutgoingWebhookDelivery, error)
+	PermanentDeleteByHook(hookID string) error
+	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
+}
+
+type PollStore interface {
+	Save(poll *model.Poll) (*model.Poll, error)
+	Get(pollID string) (*model.Poll, error)
+	GetForPost(postID string) (*model.Poll, error)
+	GetForPosts(postIDs []string) ([]*model.Poll, error)
+	Update(poll *model.Poll) error
+	DeleteForPost(postID string, deleteAt int64) error
+	SaveVotes(pollID, userID string, optionIDs []string) error
+	GetVotes(pollID string) ([]*model.PollVote, error)
+	GetVotesForPolls(pollIDs []string) ([]*model.PollVote, error)
+	PermanentDeleteVotesByUser(userID string) error
+}
+
+type ReminderStore interface {
+	Save(reminder *model.Reminder) (*model.Reminder, error)
+	Get(reminderID string) (*model.Reminder, error)
+	GetForUser(userID string) ([]*model.Reminder, error)
+	GetLastTriggeredForUser(userID string) (*model.Reminder, error)
+	GetDue(before int64, limit int) ([]*model.Reminder, error)
+	Update(reminder *model.Reminder) error
+	Delete(reminderID string) error
+	DeleteCompletedBefore(before int64) error
+	PermanentDeleteByUser(userID string) error
+}
+
+type ImportIdMappingStore interface {
+	// SaveMultiple saves the mappings, replacing the existing ones for the
+	// same objects.
+	SaveMultiple(mappings []*model.ImportIdMapping) error
+	// GetNewIds returns the IDs objects of the given type were imported
+	// with, keyed by the IDs they were exported with.
+	GetNewIds(objectType string, oldIDs []string) (map[string]string, error)
+}
+
+type LegalHoldStore interface {
+	Save(hold *model.LegalHold) (*model.LegalHold, error)
+	Get(holdID string) (*model.LegalHold, error)
+	GetAll(offset, limit int, includeReleased bool) ([]*model.LegalHold, error)
+	Update(hold *model.LegalHold) (*model.LegalHold, error)
+	Release(holdID string, releaseAt int64) error
+	// IsUserHeld reports whether an active legal hold preserves the user as a
+	// custodian, or any post of the user in a held channel.
+	IsUserHeld(userID string) (bool, error)
+	// IsChannelHeld reports whether an active legal hold preserves the
+	// channel, or any post of a custodian in the channel.
+	IsChannelHeld(channelID string) (bool, error)
+}
+
+type WebAuthnCredentialStore interface {
+	Save(credential *model.WebAuthnCredential) (*model.WebAuthnCredential, error)
+	Get(id string) (*model.WebAuthnCredential, error)
+	GetByCredentialId(credentialID string) (*model.WebAuthnCredential, error)
+	GetForUser(userID string) ([]*model.WebAuthnCredential, error)
+	// UpdateSignCount records a successful authentication with the
+	// credential.
+	UpdateSignCount(id string, signCount, lastUsedAt int64) error
+	Delete(id string) error
+	DeleteForUser(userID string) error
+}
+
+type MfaRecoveryCodeStore interface {
+	// SaveForUser replaces the recovery codes of the user.
+	SaveForUser(userID string, codeHashes []string) error
+	// Use marks the code as used, and returns false if the user has no such
+	// unused code.
+	Use(userID, codeHash string) (bool, error)
+	CountUnused(userID string) (int64, error)
+	DeleteForUser(userID string) error
+}
+
+type WebPushSubscriptionStore interface {
+	// Save replaces the subscription of the session.
+	Save(subscription *model.WebPushSubscription) (*model.WebPushSubscription, error)
+	// GetForUser returns the subscriptions of the active sessions of the
+	// user.
+	GetForUser(userID string) ([]*model.WebPushSubscription, error)
+	Delete(id string) error
+	DeleteForSession(sessionID string) error
+	DeleteForUser(userID string) error
+	// DeleteOrphaned deletes the subscriptions of removed sessions.
+	DeleteOrphaned() (int64, error)
+}
+
+type SavedSearchStore interface {
+	Save(savedSearch *model.SavedSearch) (*model.SavedSearch, error)
+	Get(savedSearchID string) (*model.SavedSearch, error)
+	GetForUser(userID string) ([]*model.SavedSearch, error)
+	// GetWithAlerts returns the saved searches with alerts enabled, ordered
+	// by id and starting after afterID.
+	GetWithAlerts(afterID string, limit int) ([]*model.SavedSearch, error)
+	// GetWithAlertsForChannel returns the saved searches with alerts enabled
+	// of the members of the channel.
+	GetWithAlertsForChannel(channelID string) ([]*model.SavedSearch, error)
+	Update(savedSearch *model.SavedSearch) (*model.SavedSearch, error)
+	// UpdateLastCheckedAt moves the alerts of a saved search forward, unless
+	// they were moved since previous was read. It reports whether the
+	// saved search was updated.
+	UpdateLastCheckedAt(savedSearchID string, previous, lastCheckedAt int64) (bool, error)
+	Delete(savedSearchID string) error
+	PermanentDeleteByUser(userID string) error
+}
+
 // ChannelSearchOpts contains options for searching channels.
 //
 // NotAssociatedToGroup will exclude channels that have associated, active GroupChannels records.
@@ -6,6 +6,7 @@ package storetest
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -206,6 +207,17 @@ func testCommandStoreUpdate(t *testing.T, rctx request.CTX, ss store.Store) {
 	_, nErr = ss.Command().Update(o1)
 	require.NoError(t, nErr)
 
+	o1.RotateSigningSecret(time.Hour)
+	o1.RotateSigningSecret(time.Hour)
+
+	_, nErr = ss.Command().Update(o1)
+	require.NoError(t, nErr)
+
+	o2, nErr := ss.Command().Get(o1.Id)
+	require.NoError(t, nErr)
+	require.Equal(t, o1.SigningSecrets(), o2.SigningSecrets())
+	require.Equal(t, o1.PreviousSigningSecretExpireAt, o2.PreviousSigningSecretExpireAt)
+
 	o1.URL = "junk"
 
 	_, err := ss.Command().Update(o1)
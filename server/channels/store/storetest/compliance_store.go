@@ -54,6 +54,7 @@ func TestComplianceStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("MessageExportPrivateChannel", func(t *testing.T) { testMessageExportPrivateChannel(t, rctx, ss) })
 	t.Run("MessageExportDirectMessageChannel", func(t *testing.T) { testMessageExportDirectMessageChannel(t, rctx, ss) })
 	t.Run("MessageExportGroupMessageChannel", func(t *testing.T) { testMessageExportGroupMessageChannel(t, rctx, ss) })
+	t.Run("MessageExportPoll", func(t *testing.T) { testMessageExportPoll(t, rctx, ss) })
 	t.Run("MessageEditExportMessage", func(t *testing.T) { testEditExportMessage(t, rctx, ss) })
 	t.Run("MessageEditAfterExportMessage", func(t *testing.T) { testEditAfterExportMessage(t, rctx, ss) })
 	t.Run("MessageDeleteExportMessage", func(t *testing.T) { testDeleteExportMessage(t, rctx, ss) })
@@ -499,6 +500,70 @@ func testMessageExportPublicChannel(t *testing.T, rctx request.CTX, ss store.Sto
 	assert.Equal(t, user1.Username, *messageExportMap[post2.Id].Username)
 }
 
+func testMessageExportPoll(t *testing.T, rctx request.CTX, ss store.Store) {
+	defer cleanupStoreState(t, rctx, ss)
+
+	startTime := model.GetMillis()
+
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName: "DisplayName",
+		Name:        NewTestID(),
+		Email:       MakeEmail(),
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+
+	user, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: model.NewUsername(),
+	})
+	require.NoError(t, err)
+
+	channel, err := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      team.Id,
+		Name:        model.NewId(),
+		DisplayName: "Public Channel",
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+
+	post, err := ss.Post().Save(rctx, &model.Post{
+		ChannelId: channel.Id,
+		UserId:    user.Id,
+		CreateAt:  startTime,
+		Message:   "Where should we eat?",
+		Type:      model.PostTypePoll,
+	})
+	require.NoError(t, err)
+
+	poll := &model.Poll{
+		PostId:    post.Id,
+		ChannelId: channel.Id,
+		UserId:    user.Id,
+		Question:  post.Message,
+		Options:   model.PollOptions{{Text: "Pizza"}, {Text: "Sushi"}},
+	}
+	poll.PreSave()
+	poll, err = ss.Poll().Save(poll)
+	require.NoError(t, err)
+	require.NoError(t, ss.Poll().SaveVotes(poll.Id, user.Id, []string{poll.Options[1].Id}))
+
+	messages, _, err := ss.Compliance().MessageExport(rctx, model.MessageExportCursor{LastPostUpdateAt: startTime - 10}, 10)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.NotNil(t, messages[0].PostProps)
+
+	var props struct {
+		Poll *model.Poll `json:"poll"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(*messages[0].PostProps), &props))
+	require.NotNil(t, props.Poll)
+	assert.Equal(t, poll.Id, props.Poll.Id)
+	assert.Equal(t, 1, props.Poll.TotalVoters)
+	assert.Equal(t, 1, props.Poll.Results[1].Votes)
+	assert.Equal(t, []string{user.Id}, props.Poll.Results[1].UserIds)
+}
+
 func testMessageExportPrivateChannel(t *testing.T, rctx request.CTX, ss store.Store) {
 	defer cleanupStoreState(t, rctx, ss)
 
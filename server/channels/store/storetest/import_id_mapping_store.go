@@ -0,0 +1,50 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestImportIdMappingStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveAndGetImportIdMappings", func(t *testing.T) { testSaveAndGetImportIdMappings(t, rctx, ss) })
+}
+
+func testSaveAndGetImportIdMappings(t *testing.T, rctx request.CTX, ss store.Store) {
+	postMapping := &model.ImportIdMapping{ObjectType: model.ImportIdMappingObjectTypePost, OldId: model.NewId(), NewId: model.NewId()}
+	fileMapping := &model.ImportIdMapping{ObjectType: model.ImportIdMappingObjectTypeFile, OldId: model.NewId(), NewId: model.NewId()}
+
+	require.NoError(t, ss.ImportIdMapping().SaveMultiple([]*model.ImportIdMapping{postMapping, fileMapping}))
+	assert.NotZero(t, postMapping.CreateAt)
+
+	t.Run("gets the mappings of the given type", func(t *testing.T) {
+		newIDs, err := ss.ImportIdMapping().GetNewIds(model.ImportIdMappingObjectTypePost, []string{postMapping.OldId, fileMapping.OldId, model.NewId()})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{postMapping.OldId: postMapping.NewId}, newIDs)
+	})
+
+	t.Run("replaces existing mappings", func(t *testing.T) {
+		replaced := &model.ImportIdMapping{ObjectType: model.ImportIdMappingObjectTypePost, OldId: postMapping.OldId, NewId: model.NewId()}
+		require.NoError(t, ss.ImportIdMapping().SaveMultiple([]*model.ImportIdMapping{replaced}))
+
+		newIDs, err := ss.ImportIdMapping().GetNewIds(model.ImportIdMappingObjectTypePost, []string{postMapping.OldId})
+		require.NoError(t, err)
+		assert.Equal(t, replaced.NewId, newIDs[postMapping.OldId])
+	})
+
+	t.Run("no ids", func(t *testing.T) {
+		require.NoError(t, ss.ImportIdMapping().SaveMultiple(nil))
+
+		newIDs, err := ss.ImportIdMapping().GetNewIds(model.ImportIdMappingObjectTypeFile, nil)
+		require.NoError(t, err)
+		assert.Empty(t, newIDs)
+	})
+}
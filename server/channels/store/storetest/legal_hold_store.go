@@ -0,0 +1,278 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestLegalHoldStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveLegalHold", func(t *testing.T) { testSaveLegalHold(t, rctx, ss) })
+	t.Run("GetAllLegalHolds", func(t *testing.T) { testGetAllLegalHolds(t, rctx, ss) })
+	t.Run("UpdateLegalHold", func(t *testing.T) { testUpdateLegalHold(t, rctx, ss) })
+	t.Run("ReleaseLegalHold", func(t *testing.T) { testReleaseLegalHold(t, rctx, ss) })
+	t.Run("IsHeld", func(t *testing.T) { testLegalHoldIsHeld(t, rctx, ss) })
+	t.Run("RetentionSkipsHeldContent", func(t *testing.T) { testRetentionSkipsHeldContent(t, rctx, ss) })
+	t.Run("LegalHoldMessageExport", func(t *testing.T) { testLegalHoldMessageExport(t, rctx, ss) })
+}
+
+func saveLegalHoldPost(t *testing.T, rctx request.CTX, ss store.Store, channelID, userID string, createAt int64) *model.Post {
+	t.Helper()
+
+	post, err := ss.Post().Save(rctx, &model.Post{
+		ChannelId: channelID,
+		UserId:    userID,
+		Message:   "message " + model.NewId(),
+		CreateAt:  createAt,
+	})
+	require.NoError(t, err)
+
+	return post
+}
+
+func testSaveLegalHold(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("should save and get a legal hold", func(t *testing.T) {
+		hold := &model.LegalHold{
+			Name:        "Litigation",
+			UserIds:     []string{model.NewId(), model.NewId()},
+			ChannelIds:  []string{model.NewId()},
+			StartsAt:    1000,
+			Description: "Smith v. Example",
+		}
+
+		saved, err := ss.LegalHold().Save(hold)
+		require.NoError(t, err)
+		require.NotEmpty(t, saved.Id)
+
+		fetched, err := ss.LegalHold().Get(saved.Id)
+		require.NoError(t, err)
+		assert.Equal(t, saved, fetched)
+	})
+
+	t.Run("should not save an invalid legal hold", func(t *testing.T) {
+		_, err := ss.LegalHold().Save(&model.LegalHold{
+			Name: "Litigation",
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("should return not found for a missing legal hold", func(t *testing.T) {
+		_, err := ss.LegalHold().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testGetAllLegalHolds(t *testing.T, rctx request.CTX, ss store.Store) {
+	first, err := ss.LegalHold().Save(&model.LegalHold{
+		Name:    "Litigation",
+		UserIds: []string{model.NewId()},
+	})
+	require.NoError(t, err)
+	second, err := ss.LegalHold().Save(&model.LegalHold{
+		Name:       "Litigation",
+		ChannelIds: []string{model.NewId()},
+	})
+	require.NoError(t, err)
+	require.NoError(t, ss.LegalHold().Release(first.Id, model.GetMillis()))
+
+	findHold := func(holds []*model.LegalHold, holdID string) *model.LegalHold {
+		for _, hold := range holds {
+			if hold.Id == holdID {
+				return hold
+			}
+		}
+		return nil
+	}
+
+	holds, err := ss.LegalHold().GetAll(0, 1000, false)
+	require.NoError(t, err)
+	assert.Nil(t, findHold(holds, first.Id))
+	fetched := findHold(holds, second.Id)
+	require.NotNil(t, fetched)
+	assert.Equal(t, second.ChannelIds, fetched.ChannelIds)
+	assert.Equal(t, []string{}, fetched.UserIds)
+
+	holds, err = ss.LegalHold().GetAll(0, 1000, true)
+	require.NoError(t, err)
+	fetched = findHold(holds, first.Id)
+	require.NotNil(t, fetched)
+	assert.Equal(t, first.UserIds, fetched.UserIds)
+	assert.NotZero(t, fetched.DeleteAt)
+}
+
+func testUpdateLegalHold(t *testing.T, rctx request.CTX, ss store.Store) {
+	hold, err := ss.LegalHold().Save(&model.LegalHold{
+		Name:       "Litigation",
+		UserIds:    []string{model.NewId()},
+		ChannelIds: []string{model.NewId()},
+	})
+	require.NoError(t, err)
+
+	hold.Name = "Investigation"
+	hold.UserIds = []string{model.NewId(), model.NewId()}
+	hold.ChannelIds = []string{}
+	hold.EndsAt = 5000
+	_, err = ss.LegalHold().Update(hold)
+	require.NoError(t, err)
+
+	fetched, err := ss.LegalHold().Get(hold.Id)
+	require.NoError(t, err)
+	assert.Equal(t, hold, fetched)
+
+	t.Run("should not update a released legal hold", func(t *testing.T) {
+		require.NoError(t, ss.LegalHold().Release(hold.Id, model.GetMillis()))
+
+		_, err := ss.LegalHold().Update(hold)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testReleaseLegalHold(t *testing.T, rctx request.CTX, ss store.Store) {
+	hold, err := ss.LegalHold().Save(&model.LegalHold{
+		Name:    "Litigation",
+		UserIds: []string{model.NewId()},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.LegalHold().Release(hold.Id, 1234))
+
+	fetched, err := ss.LegalHold().Get(hold.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234), fetched.DeleteAt)
+
+	err = ss.LegalHold().Release(hold.Id, 5678)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testLegalHoldIsHeld(t *testing.T, rctx request.CTX, ss store.Store) {
+	custodianID := model.NewId()
+	heldChannelID := model.NewId()
+	otherChannelID := model.NewId()
+	posterID := model.NewId()
+
+	// The poster only has content in the held channel, and the custodian in
+	// another channel.
+	saveLegalHoldPost(t, rctx, ss, heldChannelID, posterID, 1500)
+	saveLegalHoldPost(t, rctx, ss, otherChannelID, custodianID, 1500)
+
+	hold, err := ss.LegalHold().Save(&model.LegalHold{
+		Name:       "Litigation",
+		UserIds:    []string{custodianID},
+		ChannelIds: []string{heldChannelID},
+		StartsAt:   1000,
+		EndsAt:     2000,
+	})
+	require.NoError(t, err)
+
+	isHeld := func(userID string) bool {
+		held, err := ss.LegalHold().IsUserHeld(userID)
+		require.NoError(t, err)
+		return held
+	}
+	isChannelHeld := func(channelID string) bool {
+		held, err := ss.LegalHold().IsChannelHeld(channelID)
+		require.NoError(t, err)
+		return held
+	}
+
+	assert.True(t, isHeld(custodianID))
+	assert.True(t, isHeld(posterID))
+	assert.False(t, isHeld(model.NewId()))
+	assert.True(t, isChannelHeld(heldChannelID))
+	assert.True(t, isChannelHeld(otherChannelID))
+	assert.False(t, isChannelHeld(model.NewId()))
+
+	require.NoError(t, ss.LegalHold().Release(hold.Id, model.GetMillis()))
+
+	assert.False(t, isHeld(custodianID))
+	assert.False(t, isHeld(posterID))
+	assert.False(t, isChannelHeld(heldChannelID))
+	assert.False(t, isChannelHeld(otherChannelID))
+}
+
+func testRetentionSkipsHeldContent(t *testing.T, rctx request.CTX, ss store.Store) {
+	custodianID := model.NewId()
+	heldChannelID := model.NewId()
+	otherChannelID := model.NewId()
+
+	heldByChannel := saveLegalHoldPost(t, rctx, ss, heldChannelID, model.NewId(), 1500)
+	heldByCustodian := saveLegalHoldPost(t, rctx, ss, otherChannelID, custodianID, 1500)
+	beforeRange := saveLegalHoldPost(t, rctx, ss, heldChannelID, custodianID, 500)
+	notHeld := saveLegalHoldPost(t, rctx, ss, otherChannelID, model.NewId(), 1500)
+
+	hold, err := ss.LegalHold().Save(&model.LegalHold{
+		Name:       "Litigation",
+		UserIds:    []string{custodianID},
+		ChannelIds: []string{heldChannelID},
+		StartsAt:   1000,
+		EndsAt:     2000,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, ss.LegalHold().Release(hold.Id, model.GetMillis()))
+	}()
+
+	for {
+		deleted, err := ss.Post().PermanentDeleteBatch(3000, 1000)
+		require.NoError(t, err)
+		if deleted < 1000 {
+			break
+		}
+	}
+
+	for _, post := range []*model.Post{heldByChannel, heldByCustodian} {
+		_, err = ss.Post().GetSingle(rctx, post.Id, true)
+		require.NoError(t, err, "held post %s was deleted", post.Id)
+	}
+	for _, post := range []*model.Post{beforeRange, notHeld} {
+		_, err = ss.Post().GetSingle(rctx, post.Id, true)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr), "post %s was not deleted", post.Id)
+	}
+}
+
+func testLegalHoldMessageExport(t *testing.T, rctx request.CTX, ss store.Store) {
+	custodianID := model.NewId()
+	heldChannelID := model.NewId()
+	otherChannelID := model.NewId()
+
+	heldByChannel := saveLegalHoldPost(t, rctx, ss, heldChannelID, model.NewId(), 1500)
+	heldByCustodian := saveLegalHoldPost(t, rctx, ss, otherChannelID, custodianID, 1600)
+	saveLegalHoldPost(t, rctx, ss, heldChannelID, custodianID, 2500)
+	saveLegalHoldPost(t, rctx, ss, otherChannelID, model.NewId(), 1500)
+
+	hold, err := ss.LegalHold().Save(&model.LegalHold{
+		Name:       "Litigation",
+		UserIds:    []string{custodianID},
+		ChannelIds: []string{heldChannelID},
+		StartsAt:   1000,
+		EndsAt:     2000,
+	})
+	require.NoError(t, err)
+	require.NoError(t, ss.LegalHold().Release(hold.Id, model.GetMillis()))
+
+	// Released holds can still be exported.
+	posts, cursor, err := ss.Compliance().LegalHoldMessageExport(rctx, hold.Id, model.MessageExportCursor{}, 10)
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+
+	exportedIDs := []string{*posts[0].PostId, *posts[1].PostId}
+	assert.ElementsMatch(t, []string{heldByChannel.Id, heldByCustodian.Id}, exportedIDs)
+	assert.Equal(t, exportedIDs[1], cursor.LastPostId)
+
+	posts, _, err = ss.Compliance().LegalHoldMessageExport(rctx, hold.Id, cursor, 10)
+	require.NoError(t, err)
+	assert.Empty(t, posts)
+}
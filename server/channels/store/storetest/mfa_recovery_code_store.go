@@ -0,0 +1,79 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestMfaRecoveryCodeStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveAndUse", func(t *testing.T) { testMfaRecoveryCodeSaveAndUse(t, ss) })
+	t.Run("DeleteForUser", func(t *testing.T) { testMfaRecoveryCodeDeleteForUser(t, ss) })
+}
+
+func testMfaRecoveryCodeSaveAndUse(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(userID, []string{"hash1", "hash2", "hash3"}))
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(otherUserID, []string{"hash1"}))
+
+	count, err := ss.MfaRecoveryCode().CountUnused(userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	used, err := ss.MfaRecoveryCode().Use(userID, "hash2")
+	require.NoError(t, err)
+	assert.True(t, used)
+
+	used, err = ss.MfaRecoveryCode().Use(userID, "hash2")
+	require.NoError(t, err)
+	assert.False(t, used, "codes can only be used once")
+
+	used, err = ss.MfaRecoveryCode().Use(userID, "unknown")
+	require.NoError(t, err)
+	assert.False(t, used)
+
+	count, err = ss.MfaRecoveryCode().CountUnused(userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	count, err = ss.MfaRecoveryCode().CountUnused(otherUserID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	t.Run("saving replaces the codes", func(t *testing.T) {
+		require.NoError(t, ss.MfaRecoveryCode().SaveForUser(userID, []string{"hash2", "hash4"}))
+
+		count, err := ss.MfaRecoveryCode().CountUnused(userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		used, err := ss.MfaRecoveryCode().Use(userID, "hash1")
+		require.NoError(t, err)
+		assert.False(t, used)
+
+		used, err = ss.MfaRecoveryCode().Use(userID, "hash2")
+		require.NoError(t, err)
+		assert.True(t, used)
+	})
+}
+
+func testMfaRecoveryCodeDeleteForUser(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(userID, []string{"hash1", "hash2"}))
+
+	require.NoError(t, ss.MfaRecoveryCode().DeleteForUser(userID))
+
+	count, err := ss.MfaRecoveryCode().CountUnused(userID)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
@@ -112,6 +112,43 @@ func (_m *ComplianceStore) GetAll(offset int, limit int) (model.Compliances, err
 	return r0, r1
 }
 
+// LegalHoldMessageExport provides a mock function with given fields: c, legalHoldID, cursor, limit
+func (_m *ComplianceStore) LegalHoldMessageExport(c request.CTX, legalHoldID string, cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error) {
+	ret := _m.Called(c, legalHoldID, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LegalHoldMessageExport")
+	}
+
+	var r0 []*model.MessageExport
+	var r1 model.MessageExportCursor
+	var r2 error
+	if rf, ok := ret.Get(0).(func(request.CTX, string, model.MessageExportCursor, int) ([]*model.MessageExport, model.MessageExportCursor, error)); ok {
+		return rf(c, legalHoldID, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(request.CTX, string, model.MessageExportCursor, int) []*model.MessageExport); ok {
+		r0 = rf(c, legalHoldID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.MessageExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(request.CTX, string, model.MessageExportCursor, int) model.MessageExportCursor); ok {
+		r1 = rf(c, legalHoldID, cursor, limit)
+	} else {
+		r1 = ret.Get(1).(model.MessageExportCursor)
+	}
+
+	if rf, ok := ret.Get(2).(func(request.CTX, string, model.MessageExportCursor, int) error); ok {
+		r2 = rf(c, legalHoldID, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // MessageExport provides a mock function with given fields: c, cursor, limit
 func (_m *ComplianceStore) MessageExport(c request.CTX, cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error) {
 	ret := _m.Called(c, cursor, limit)
@@ -565,6 +565,24 @@ func (_m *FileInfoStore) SetContent(ctx request.CTX, fileID string, content stri
 	return r0
 }
 
+// UpdateScanStatus provides a mock function with given fields: fileID, scanStatus
+func (_m *FileInfoStore) UpdateScanStatus(fileID string, scanStatus string) error {
+	ret := _m.Called(fileID, scanStatus)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateScanStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(fileID, scanStatus)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Upsert provides a mock function with given fields: rctx, info
 func (_m *FileInfoStore) Upsert(rctx request.CTX, info *model.FileInfo) (*model.FileInfo, error) {
 	ret := _m.Called(rctx, info)
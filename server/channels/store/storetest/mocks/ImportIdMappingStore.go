@@ -0,0 +1,77 @@
+// Code generated by mockery v2.42.2. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/mattermost/mattermost/server/public/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ImportIdMappingStore is an autogenerated mock type for the ImportIdMappingStore type
+type ImportIdMappingStore struct {
+	mock.Mock
+}
+
+// GetNewIds provides a mock function with given fields: objectType, oldIDs
+func (_m *ImportIdMappingStore) GetNewIds(objectType string, oldIDs []string) (map[string]string, error) {
+	ret := _m.Called(objectType, oldIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNewIds")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []string) (map[string]string, error)); ok {
+		return rf(objectType, oldIDs)
+	}
+	if rf, ok := ret.Get(0).(func(string, []string) map[string]string); ok {
+		r0 = rf(objectType, oldIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(objectType, oldIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveMultiple provides a mock function with given fields: mappings
+func (_m *ImportIdMappingStore) SaveMultiple(mappings []*model.ImportIdMapping) error {
+	ret := _m.Called(mappings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveMultiple")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*model.ImportIdMapping) error); ok {
+		r0 = rf(mappings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewImportIdMappingStore creates a new instance of ImportIdMappingStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewImportIdMappingStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ImportIdMappingStore {
+	mock := &ImportIdMappingStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,223 @@
+// Code generated by mockery v2.42.2. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/mattermost/mattermost/server/public/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LegalHoldStore is an autogenerated mock type for the LegalHoldStore type
+type LegalHoldStore struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: holdID
+func (_m *LegalHoldStore) Get(holdID string) (*model.LegalHold, error) {
+	ret := _m.Called(holdID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.LegalHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.LegalHold, error)); ok {
+		return rf(holdID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.LegalHold); ok {
+		r0 = rf(holdID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LegalHold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(holdID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: offset, limit, includeReleased
+func (_m *LegalHoldStore) GetAll(offset int, limit int, includeReleased bool) ([]*model.LegalHold, error) {
+	ret := _m.Called(offset, limit, includeReleased)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*model.LegalHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, bool) ([]*model.LegalHold, error)); ok {
+		return rf(offset, limit, includeReleased)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, bool) []*model.LegalHold); ok {
+		r0 = rf(offset, limit, includeReleased)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.LegalHold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, bool) error); ok {
+		r1 = rf(offset, limit, includeReleased)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsChannelHeld provides a mock function with given fields: channelID
+func (_m *LegalHoldStore) IsChannelHeld(channelID string) (bool, error) {
+	ret := _m.Called(channelID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsChannelHeld")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(channelID)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(channelID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsUserHeld provides a mock function with given fields: userID
+func (_m *LegalHoldStore) IsUserHeld(userID string) (bool, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsUserHeld")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Release provides a mock function with given fields: holdID, releaseAt
+func (_m *LegalHoldStore) Release(holdID string, releaseAt int64) error {
+	ret := _m.Called(holdID, releaseAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64) error); ok {
+		r0 = rf(holdID, releaseAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: hold
+func (_m *LegalHoldStore) Save(hold *model.LegalHold) (*model.LegalHold, error) {
+	ret := _m.Called(hold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 *model.LegalHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.LegalHold) (*model.LegalHold, error)); ok {
+		return rf(hold)
+	}
+	if rf, ok := ret.Get(0).(func(*model.LegalHold) *model.LegalHold); ok {
+		r0 = rf(hold)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LegalHold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.LegalHold) error); ok {
+		r1 = rf(hold)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: hold
+func (_m *LegalHoldStore) Update(hold *model.LegalHold) (*model.LegalHold, error) {
+	ret := _m.Called(hold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *model.LegalHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.LegalHold) (*model.LegalHold, error)); ok {
+		return rf(hold)
+	}
+	if rf, ok := ret.Get(0).(func(*model.LegalHold) *model.LegalHold); ok {
+		r0 = rf(hold)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LegalHold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.LegalHold) error); ok {
+		r1 = rf(hold)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewLegalHoldStore creates a new instance of LegalHoldStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLegalHoldStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LegalHoldStore {
+	mock := &LegalHoldStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
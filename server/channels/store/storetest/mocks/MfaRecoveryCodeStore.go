@@ -0,0 +1,118 @@
+// Code generated by mockery v2.42.2. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MfaRecoveryCodeStore is an autogenerated mock type for the MfaRecoveryCodeStore type
+type MfaRecoveryCodeStore struct {
+	mock.Mock
+}
+
+// CountUnused provides a mock function with given fields: userID
+func (_m *MfaRecoveryCodeStore) CountUnused(userID string) (int64, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUnused")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteForUser provides a mock function with given fields: userID
+func (_m *MfaRecoveryCodeStore) DeleteForUser(userID string) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteForUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveForUser provides a mock function with given fields: userID, codeHashes
+func (_m *MfaRecoveryCodeStore) SaveForUser(userID string, codeHashes []string) error {
+	ret := _m.Called(userID, codeHashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveForUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(userID, codeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Use provides a mock function with given fields: userID, codeHash
+func (_m *MfaRecoveryCodeStore) Use(userID string, codeHash string) (bool, error) {
+	ret := _m.Called(userID, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Use")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
+		return rf(userID, codeHash)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(userID, codeHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMfaRecoveryCodeStore creates a new instance of MfaRecoveryCodeStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMfaRecoveryCodeStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MfaRecoveryCodeStore {
+	mock := &MfaRecoveryCodeStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,281 @@
+// Code generated by mockery v2.42.2. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/mattermost/mattermost/server/public/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PollStore is an autogenerated mock type for the PollStore type
+type PollStore struct {
+	mock.Mock
+}
+
+// DeleteForPost provides a mock function with given fields: postID, deleteAt
+func (_m *PollStore) DeleteForPost(postID string, deleteAt int64) error {
+	ret := _m.Called(postID, deleteAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteForPost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64) error); ok {
+		r0 = rf(postID, deleteAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: pollID
+func (_m *PollStore) Get(pollID string) (*model.Poll, error) {
+	ret := _m.Called(pollID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.Poll
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.Poll, error)); ok {
+		return rf(pollID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.Poll); ok {
+		r0 = rf(pollID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Poll)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pollID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForPost provides a mock function with given fields: postID
+func (_m *PollStore) GetForPost(postID string) (*model.Poll, error) {
+	ret := _m.Called(postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForPost")
+	}
+
+	var r0 *model.Poll
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.Poll, error)); ok {
+		return rf(postID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.Poll); ok {
+		r0 = rf(postID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Poll)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(postID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForPosts provides a mock function with given fields: postIDs
+func (_m *PollStore) GetForPosts(postIDs []string) ([]*model.Poll, error) {
+	ret := _m.Called(postIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForPosts")
+	}
+
+	var r0 []*model.Poll
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]*model.Poll, error)); ok {
+		return rf(postIDs)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []*model.Poll); ok {
+		r0 = rf(postIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Poll)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(postIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVotes provides a mock function with given fields: pollID
+func (_m *PollStore) GetVotes(pollID string) ([]*model.PollVote, error) {
+	ret := _m.Called(pollID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVotes")
+	}
+
+	var r0 []*model.PollVote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*model.PollVote, error)); ok {
+		return rf(pollID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*model.PollVote); ok {
+		r0 = rf(pollID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PollVote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pollID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVotesForPolls provides a mock function with given fields: pollIDs
+func (_m *PollStore) GetVotesForPolls(pollIDs []string) ([]*model.PollVote, error) {
+	ret := _m.Called(pollIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVotesForPolls")
+	}
+
+	var r0 []*model.PollVote
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]*model.PollVote, error)); ok {
+		return rf(pollIDs)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []*model.PollVote); ok {
+		r0 = rf(pollIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PollVote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(pollIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PermanentDeleteVotesByUser provides a mock function with given fields: userID
+func (_m *PollStore) PermanentDeleteVotesByUser(userID string) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PermanentDeleteVotesByUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: poll
+func (_m *PollStore) Save(poll *model.Poll) (*model.Poll, error) {
+	ret := _m.Called(poll)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 *model.Poll
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Poll) (*model.Poll, error)); ok {
+		return rf(poll)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Poll) *model.Poll); ok {
+		r0 = rf(poll)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Poll)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Poll) error); ok {
+		r1 = rf(poll)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveVotes provides a mock function with given fields: pollID, userID, optionIDs
+func (_m *PollStore) SaveVotes(pollID string, userID string, optionIDs []string) error {
+	ret := _m.Called(pollID, userID, optionIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveVotes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, []string) error); ok {
+		r0 = rf(pollID, userID, optionIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: poll
+func (_m *PollStore) Update(poll *model.Poll) error {
+	ret := _m.Called(poll)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Poll) error); ok {
+		r0 = rf(poll)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPollStore creates a new instance of PollStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPollStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PollStore {
+	mock := &PollStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
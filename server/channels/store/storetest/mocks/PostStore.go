@@ -161,9 +161,9 @@ func (_m *PostStore) Get(ctx context.Context, id string, opts model.GetPostsOpti
 	return r0, r1
 }
 
-// GetDirectPostParentsForExportAfter provides a mock function with given fields: limit, afterID, includeArchivedChannels
-func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
-	ret := _m.Called(limit, afterID, includeArchivedChannels)
+// GetDirectPostParentsForExportAfter provides a mock function with given fields: limit, afterID, includeArchivedChannels, since
+func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool, since int64) ([]*model.DirectPostForExport, error) {
+	ret := _m.Called(limit, afterID, includeArchivedChannels, since)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDirectPostParentsForExportAfter")
@@ -171,19 +171,19 @@ func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterID strin
 
 	var r0 []*model.DirectPostForExport
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, string, bool) ([]*model.DirectPostForExport, error)); ok {
-		return rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(0).(func(int, string, bool, int64) ([]*model.DirectPostForExport, error)); ok {
+		return rf(limit, afterID, includeArchivedChannels, since)
 	}
-	if rf, ok := ret.Get(0).(func(int, string, bool) []*model.DirectPostForExport); ok {
-		r0 = rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(0).(func(int, string, bool, int64) []*model.DirectPostForExport); ok {
+		r0 = rf(limit, afterID, includeArchivedChannels, since)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.DirectPostForExport)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, string, bool) error); ok {
-		r1 = rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(1).(func(int, string, bool, int64) error); ok {
+		r1 = rf(limit, afterID, includeArchivedChannels, since)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -433,9 +433,9 @@ func (_m *PostStore) GetOldestEntityCreationTime() (int64, error) {
 	return r0, r1
 }
 
-// GetParentsForExportAfter provides a mock function with given fields: limit, afterID, includeArchivedChannels
-func (_m *PostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error) {
-	ret := _m.Called(limit, afterID, includeArchivedChannels)
+// GetParentsForExportAfter provides a mock function with given fields: limit, afterID, includeArchivedChannels, since
+func (_m *PostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool, since int64) ([]*model.PostForExport, error) {
+	ret := _m.Called(limit, afterID, includeArchivedChannels, since)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetParentsForExportAfter")
@@ -443,19 +443,19 @@ func (_m *PostStore) GetParentsForExportAfter(limit int, afterID string, include
 
 	var r0 []*model.PostForExport
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, string, bool) ([]*model.PostForExport, error)); ok {
-		return rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(0).(func(int, string, bool, int64) ([]*model.PostForExport, error)); ok {
+		return rf(limit, afterID, includeArchivedChannels, since)
 	}
-	if rf, ok := ret.Get(0).(func(int, string, bool) []*model.PostForExport); ok {
-		r0 = rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(0).(func(int, string, bool, int64) []*model.PostForExport); ok {
+		r0 = rf(limit, afterID, includeArchivedChannels, since)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.PostForExport)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, string, bool) error); ok {
-		r1 = rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(1).(func(int, string, bool, int64) error); ok {
+		r1 = rf(limit, afterID, includeArchivedChannels, since)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1163,6 +1163,34 @@ func (_m *PostStore) PermanentDeleteByUser(rctx request.CTX, userID string) erro
 	return r0
 }
 
+// PermanentDeleteEditHistoryForPost provides a mock function with given fields: postID, keep
+func (_m *PostStore) PermanentDeleteEditHistoryForPost(postID string, keep int) (int64, error) {
+	ret := _m.Called(postID, keep)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PermanentDeleteEditHistoryForPost")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) (int64, error)); ok {
+		return rf(postID, keep)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) int64); ok {
+		r0 = rf(postID, keep)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(postID, keep)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: rctx, post
 func (_m *PostStore) Save(rctx request.CTX, post *model.Post) (*model.Post, error) {
 	ret := _m.Called(rctx, post)
@@ -1230,6 +1258,43 @@ func (_m *PostStore) SaveMultiple(posts []*model.Post) ([]*model.Post, int, erro
 	return r0, r1, r2
 }
 
+// SaveMultipleWithIds provides a mock function with given fields: posts
+func (_m *PostStore) SaveMultipleWithIds(posts []*model.Post) ([]*model.Post, int, error) {
+	ret := _m.Called(posts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveMultipleWithIds")
+	}
+
+	var r0 []*model.Post
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func([]*model.Post) ([]*model.Post, int, error)); ok {
+		return rf(posts)
+	}
+	if rf, ok := ret.Get(0).(func([]*model.Post) []*model.Post); ok {
+		r0 = rf(posts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]*model.Post) int); ok {
+		r1 = rf(posts)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func([]*model.Post) error); ok {
+		r2 = rf(posts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Search provides a mock function with given fields: teamID, userID, params
 func (_m *PostStore) Search(teamID string, userID string, params *model.SearchParams) (*model.PostList, error) {
 	ret := _m.Called(teamID, userID, params)
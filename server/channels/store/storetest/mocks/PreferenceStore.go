@@ -272,6 +272,36 @@ func (_m *PreferenceStore) GetCategoryAndName(category string, nane string) (mod
 	return r0, r1
 }
 
+// GetCategoryAndNameForUsers provides a mock function with given fields: userIDs, category, name
+func (_m *PreferenceStore) GetCategoryAndNameForUsers(userIDs []string, category string, name string) (model.Preferences, error) {
+	ret := _m.Called(userIDs, category, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCategoryAndNameForUsers")
+	}
+
+	var r0 model.Preferences
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string, string, string) (model.Preferences, error)); ok {
+		return rf(userIDs, category, name)
+	}
+	if rf, ok := ret.Get(0).(func([]string, string, string) model.Preferences); ok {
+		r0 = rf(userIDs, category, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.Preferences)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string, string, string) error); ok {
+		r1 = rf(userIDs, category, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PermanentDeleteByUser provides a mock function with given fields: userID
 func (_m *PreferenceStore) PermanentDeleteByUser(userID string) error {
 	ret := _m.Called(userID)
@@ -0,0 +1,251 @@
+// Code generated by mockery v2.42.2. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/mattermost/mattermost/server/public/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReminderStore is an autogenerated mock type for the ReminderStore type
+type ReminderStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: reminderID
+func (_m *ReminderStore) Delete(reminderID string) error {
+	ret := _m.Called(reminderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(reminderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCompletedBefore provides a mock function with given fields: before
+func (_m *ReminderStore) DeleteCompletedBefore(before int64) error {
+	ret := _m.Called(before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCompletedBefore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(before)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: reminderID
+func (_m *ReminderStore) Get(reminderID string) (*model.Reminder, error) {
+	ret := _m.Called(reminderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.Reminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.Reminder, error)); ok {
+		return rf(reminderID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.Reminder); ok {
+		r0 = rf(reminderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Reminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(reminderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDue provides a mock function with given fields: before, limit
+func (_m *ReminderStore) GetDue(before int64, limit int) ([]*model.Reminder, error) {
+	ret := _m.Called(before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDue")
+	}
+
+	var r0 []*model.Reminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int) ([]*model.Reminder, error)); ok {
+		return rf(before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int) []*model.Reminder); ok {
+		r0 = rf(before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Reminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForUser provides a mock function with given fields: userID
+func (_m *ReminderStore) GetForUser(userID string) ([]*model.Reminder, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForUser")
+	}
+
+	var r0 []*model.Reminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*model.Reminder, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*model.Reminder); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Reminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLastTriggeredForUser provides a mock function with given fields: userID
+func (_m *ReminderStore) GetLastTriggeredForUser(userID string) (*model.Reminder, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastTriggeredForUser")
+	}
+
+	var r0 *model.Reminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.Reminder, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.Reminder); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Reminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PermanentDeleteByUser provides a mock function with given fields: userID
+func (_m *ReminderStore) PermanentDeleteByUser(userID string) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PermanentDeleteByUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: reminder
+func (_m *ReminderStore) Save(reminder *model.Reminder) (*model.Reminder, error) {
+	ret := _m.Called(reminder)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 *model.Reminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Reminder) (*model.Reminder, error)); ok {
+		return rf(reminder)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Reminder) *model.Reminder); ok {
+		r0 = rf(reminder)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Reminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Reminder) error); ok {
+		r1 = rf(reminder)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: reminder
+func (_m *ReminderStore) Update(reminder *model.Reminder) error {
+	ret := _m.Called(reminder)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.Reminder) error); ok {
+		r0 = rf(reminder)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewReminderStore creates a new instance of ReminderStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReminderStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReminderStore {
+	mock := &ReminderStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,273 @@
+// Code generated by mockery v2.42.2. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/mattermost/mattermost/server/public/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SavedSearchStore is an autogenerated mock type for the SavedSearchStore type
+type SavedSearchStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: savedSearchID
+func (_m *SavedSearchStore) Delete(savedSearchID string) error {
+	ret := _m.Called(savedSearchID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(savedSearchID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: savedSearchID
+func (_m *SavedSearchStore) Get(savedSearchID string) (*model.SavedSearch, error) {
+	ret := _m.Called(savedSearchID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.SavedSearch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.SavedSearch, error)); ok {
+		return rf(savedSearchID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.SavedSearch); ok {
+		r0 = rf(savedSearchID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SavedSearch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(savedSearchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForUser provides a mock function with given fields: userID
+func (_m *SavedSearchStore) GetForUser(userID string) ([]*model.SavedSearch, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForUser")
+	}
+
+	var r0 []*model.SavedSearch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*model.SavedSearch, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*model.SavedSearch); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SavedSearch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWithAlerts provides a mock function with given fields: afterID, limit
+func (_m *SavedSearchStore) GetWithAlerts(afterID string, limit int) ([]*model.SavedSearch, error) {
+	ret := _m.Called(afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWithAlerts")
+	}
+
+	var r0 []*model.SavedSearch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) ([]*model.SavedSearch, error)); ok {
+		return rf(afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) []*model.SavedSearch); ok {
+		r0 = rf(afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SavedSearch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWithAlertsForChannel provides a mock function with given fields: channelID
+func (_m *SavedSearchStore) GetWithAlertsForChannel(channelID string) ([]*model.SavedSearch, error) {
+	ret := _m.Called(channelID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWithAlertsForChannel")
+	}
+
+	var r0 []*model.SavedSearch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*model.SavedSearch, error)); ok {
+		return rf(channelID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*model.SavedSearch); ok {
+		r0 = rf(channelID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SavedSearch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(channelID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PermanentDeleteByUser provides a mock function with given fields: userID
+func (_m *SavedSearchStore) PermanentDeleteByUser(userID string) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PermanentDeleteByUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: savedSearch
+func (_m *SavedSearchStore) Save(savedSearch *model.SavedSearch) (*model.SavedSearch, error) {
+	ret := _m.Called(savedSearch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 *model.SavedSearch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.SavedSearch) (*model.SavedSearch, error)); ok {
+		return rf(savedSearch)
+	}
+	if rf, ok := ret.Get(0).(func(*model.SavedSearch) *model.SavedSearch); ok {
+		r0 = rf(savedSearch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SavedSearch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.SavedSearch) error); ok {
+		r1 = rf(savedSearch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: savedSearch
+func (_m *SavedSearchStore) Update(savedSearch *model.SavedSearch) (*model.SavedSearch, error) {
+	ret := _m.Called(savedSearch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *model.SavedSearch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.SavedSearch) (*model.SavedSearch, error)); ok {
+		return rf(savedSearch)
+	}
+	if rf, ok := ret.Get(0).(func(*model.SavedSearch) *model.SavedSearch); ok {
+		r0 = rf(savedSearch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SavedSearch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.SavedSearch) error); ok {
+		r1 = rf(savedSearch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLastCheckedAt provides a mock function with given fields: savedSearchID, previous, lastCheckedAt
+func (_m *SavedSearchStore) UpdateLastCheckedAt(savedSearchID string, previous int64, lastCheckedAt int64) (bool, error) {
+	ret := _m.Called(savedSearchID, previous, lastCheckedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLastCheckedAt")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64, int64) (bool, error)); ok {
+		return rf(savedSearchID, previous, lastCheckedAt)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64, int64) bool); ok {
+		r0 = rf(savedSearchID, previous, lastCheckedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64, int64) error); ok {
+		r1 = rf(savedSearchID, previous, lastCheckedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSavedSearchStore creates a new instance of SavedSearchStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSavedSearchStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SavedSearchStore {
+	mock := &SavedSearchStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,221 @@
+// Code generated by mockery v2.42.2. DO NOT EDIT.
+
+// Regenerate this file using `make store-mocks`.
+
+package mocks
+
+import (
+	model "github.com/mattermost/mattermost/server/public/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ScheduledPostStore is an autogenerated mock type for the ScheduledPostStore type
+type ScheduledPostStore struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: scheduledPostID
+func (_m *ScheduledPostStore) Delete(scheduledPostID string) error {
+	ret := _m.Called(scheduledPostID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(scheduledPostID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: scheduledPostID
+func (_m *ScheduledPostStore) Get(scheduledPostID string) (*model.ScheduledPost, error) {
+	ret := _m.Called(scheduledPostID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.ScheduledPost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*model.ScheduledPost, error)); ok {
+		return rf(scheduledPostID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.ScheduledPost); ok {
+		r0 = rf(scheduledPostID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ScheduledPost)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(scheduledPostID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingScheduledPosts provides a mock function with given fields: opts
+func (_m *ScheduledPostStore) GetPendingScheduledPosts(opts model.GetScheduledPostsForProcessingOptions) ([]*model.ScheduledPost, error) {
+	ret := _m.Called(opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingScheduledPosts")
+	}
+
+	var r0 []*model.ScheduledPost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model.GetScheduledPostsForProcessingOptions) ([]*model.ScheduledPost, error)); ok {
+		return rf(opts)
+	}
+	if rf, ok := ret.Get(0).(func(model.GetScheduledPostsForProcessingOptions) []*model.ScheduledPost); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ScheduledPost)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model.GetScheduledPostsForProcessingOptions) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetScheduledPostsForUser provides a mock function with given fields: userID, teamID
+func (_m *ScheduledPostStore) GetScheduledPostsForUser(userID string, teamID string) ([]*model.ScheduledPost, error) {
+	ret := _m.Called(userID, teamID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetScheduledPostsForUser")
+	}
+
+	var r0 []*model.ScheduledPost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]*model.ScheduledPost, error)); ok {
+		return rf(userID, teamID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []*model.ScheduledPost); ok {
+		r0 = rf(userID, teamID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ScheduledPost)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userID, teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkAsProcessed provides a mock function with given fields: scheduledPostID, postID, errorCode, processedAt
+func (_m *ScheduledPostStore) MarkAsProcessed(scheduledPostID string, postID string, errorCode string, processedAt int64) error {
+	ret := _m.Called(scheduledPostID, postID, errorCode, processedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkAsProcessed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, int64) error); ok {
+		r0 = rf(scheduledPostID, postID, errorCode, processedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PermanentDeleteByUser provides a mock function with given fields: userID
+func (_m *ScheduledPostStore) PermanentDeleteByUser(userID string) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PermanentDeleteByUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: scheduledPost
+func (_m *ScheduledPostStore) Save(scheduledPost *model.ScheduledPost) (*model.ScheduledPost, error) {
+	ret := _m.Called(scheduledPost)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 *model.ScheduledPost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.ScheduledPost) (*model.ScheduledPost, error)); ok {
+		return rf(scheduledPost)
+	}
+	if rf, ok := ret.Get(0).(func(*model.ScheduledPost) *model.ScheduledPost); ok {
+		r0 = rf(scheduledPost)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ScheduledPost)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.ScheduledPost) error); ok {
+		r1 = rf(scheduledPost)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: scheduledPost
+func (_m *ScheduledPostStore) Update(scheduledPost *model.ScheduledPost) error {
+	ret := _m.Called(scheduledPost)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*model.ScheduledPost) error); ok {
+		r0 = rf(scheduledPost)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewScheduledPostStore creates a new instance of ScheduledPostStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewScheduledPostStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ScheduledPostStore {
+	mock := &ScheduledPostStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
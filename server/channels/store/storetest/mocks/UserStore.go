@@ -1251,6 +1251,36 @@ func (_m *UserStore) GetRecentlyActiveUsersForTeam(teamID string, offset int, li
 	return r0, r1
 }
 
+// GetScimProfiles provides a mock function with given fields: offset, limit
+func (_m *UserStore) GetScimProfiles(offset int, limit int) ([]*model.User, error) {
+	ret := _m.Called(offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetScimProfiles")
+	}
+
+	var r0 []*model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) ([]*model.User, error)); ok {
+		return rf(offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) []*model.User); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSystemAdminProfiles provides a mock function with given fields:
 func (_m *UserStore) GetSystemAdminProfiles() (map[string]*model.User, error) {
 	ret := _m.Called()
@@ -0,0 +1,293 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestOutgoingWebhookDeliveryStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveOutgoingWebhookDelivery", func(t *testing.T) { testSaveOutgoingWebhookDelivery(t, rctx, ss) })
+	t.Run("UpdateOutgoingWebhookDelivery", func(t *testing.T) { testUpdateOutgoingWebhookDelivery(t, rctx, ss) })
+	t.Run("GetOutgoingWebhookDeliveriesForHook", func(t *testing.T) { testGetOutgoingWebhookDeliveriesForHook(t, rctx, ss) })
+	t.Run("GetPendingOutgoingWebhookDeliveries", func(t *testing.T) { testGetPendingOutgoingWebhookDeliveries(t, rctx, ss) })
+	t.Run("PermanentDeleteOutgoingWebhookDeliveries", func(t *testing.T) { testPermanentDeleteOutgoingWebhookDeliveries(t, rctx, ss) })
+}
+
+func testSaveOutgoingWebhookDelivery(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("save valid delivery", func(t *testing.T) {
+		delivery, err := ss.OutgoingWebhookDelivery().Save(&model.OutgoingWebhookDelivery{
+			HookId:      model.NewId(),
+			ChannelId:   model.NewId(),
+			PostId:      model.NewId(),
+			CallbackURL: "http://example.com/hook",
+			ContentType: "application/json",
+			Payload:     `{"text":"hello"}`,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, delivery.Id)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusPending, delivery.Status)
+
+		fetched, err := ss.OutgoingWebhookDelivery().Get(delivery.Id)
+		require.NoError(t, err)
+		assert.Equal(t, delivery.HookId, fetched.HookId)
+		assert.Equal(t, delivery.CallbackURL, fetched.CallbackURL)
+		assert.Equal(t, delivery.Payload, fetched.Payload)
+	})
+
+	t.Run("save invalid delivery", func(t *testing.T) {
+		delivery := &model.OutgoingWebhookDelivery{
+			HookId:      model.NewId(),
+			ChannelId:   model.NewId(),
+			PostId:      model.NewId(),
+			CallbackURL: "not a url",
+			ContentType: "application/json",
+			Payload:     `{"text":"hello"}`,
+		}
+		_, err := ss.OutgoingWebhookDelivery().Save(delivery)
+		require.Error(t, err)
+	})
+
+	t.Run("get unknown delivery", func(t *testing.T) {
+		_, err := ss.OutgoingWebhookDelivery().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testUpdateOutgoingWebhookDelivery(t *testing.T, rctx request.CTX, ss store.Store) {
+	delivery, err := ss.OutgoingWebhookDelivery().Save(&model.OutgoingWebhookDelivery{
+		HookId:      model.NewId(),
+		ChannelId:   model.NewId(),
+		PostId:      model.NewId(),
+		CallbackURL: "http://example.com/hook",
+		ContentType: "application/json",
+		Payload:     `{"text":"hello"}`,
+	})
+	require.NoError(t, err)
+
+	delivery.Status = model.OutgoingWebhookDeliveryStatusFailed
+	delivery.Attempts = 3
+	delivery.LastAttemptAt = model.GetMillis()
+	delivery.StatusCode = 503
+	delivery.Latency = 120
+	delivery.Response = "service unavailable"
+	require.NoError(t, ss.OutgoingWebhookDelivery().Update(delivery))
+
+	fetched, err := ss.OutgoingWebhookDelivery().Get(delivery.Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.OutgoingWebhookDeliveryStatusFailed, fetched.Status)
+	assert.Equal(t, 3, fetched.Attempts)
+	assert.Equal(t, 503, fetched.StatusCode)
+	assert.Equal(t, int64(120), fetched.Latency)
+	assert.Equal(t, "service unavailable", fetched.Response)
+
+	unknown := &model.OutgoingWebhookDelivery{
+		HookId:      model.NewId(),
+		ChannelId:   model.NewId(),
+		PostId:      model.NewId(),
+		CallbackURL: "http://example.com/hook",
+		ContentType: "application/json",
+		Payload:     `{"text":"hello"}`,
+	}
+	unknown.PreSave()
+	err = ss.OutgoingWebhookDelivery().Update(unknown)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testGetOutgoingWebhookDeliveriesForHook(t *testing.T, rctx request.CTX, ss store.Store) {
+	hookID := model.NewId()
+	now := model.GetMillis()
+
+	first := &model.OutgoingWebhookDelivery{
+		HookId:      hookID,
+		ChannelId:   model.NewId(),
+		PostId:      model.NewId(),
+		CallbackURL: "http://example.com/hook",
+		ContentType: "application/json",
+		Payload:     `{"text":"hello"}`,
+		CreateAt:    now - 2000,
+	}
+	first, err := ss.OutgoingWebhookDelivery().Save(first)
+	require.NoError(t, err)
+
+	second := &model.OutgoingWebhookDelivery{
+		HookId:      hookID,
+		ChannelId:   model.NewId(),
+		PostId:      model.NewId(),
+		CallbackURL: "http://example.com/hook",
+		ContentType: "application/json",
+		Payload:     `{"text":"hello"}`,
+		CreateAt:    now - 1000,
+		Status:      model.OutgoingWebhookDeliveryStatusFailed,
+	}
+	second, err = ss.OutgoingWebhookDelivery().Save(second)
+	require.NoError(t, err)
+
+	_, err = ss.OutgoingWebhookDelivery().Save(&model.OutgoingWebhookDelivery{
+		HookId:      model.NewId(),
+		ChannelId:   model.NewId(),
+		PostId:      model.NewId(),
+		CallbackURL: "http://example.com/hook",
+		ContentType: "application/json",
+		Payload:     `{"text":"hello"}`,
+	})
+	require.NoError(t, err)
+
+	deliveries, err := ss.OutgoingWebhookDelivery().GetForHook(hookID, model.OutgoingWebhookDeliveryGetOptions{PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, second.Id, deliveries[0].Id)
+	assert.Equal(t, first.Id, deliveries[1].Id)
+
+	deliveries, err = ss.OutgoingWebhookDelivery().GetForHook(hookID, model.OutgoingWebhookDeliveryGetOptions{Page: 1, PerPage: 1})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, first.Id, deliveries[0].Id)
+
+	deliveries, err = ss.OutgoingWebhookDelivery().GetForHook(hookID, model.OutgoingWebhookDeliveryGetOptions{Status: model.OutgoingWebhookDeliveryStatusFailed, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, second.Id, deliveries[0].Id)
+}
+
+func testGetPendingOutgoingWebhookDeliveries(t *testing.T, rctx request.CTX, ss store.Store) {
+	// Make the test independent of deliveries left behind by other tests.
+	now := int64(1000)
+
+	due := &model.OutgoingWebhookDelivery{
+		HookId:        model.NewId(),
+		ChannelId:     model.NewId(),
+		PostId:        model.NewId(),
+		CallbackURL:   "http://example.com/hook",
+		ContentType:   "application/json",
+		Payload:       `{"text":"hello"}`,
+		NextAttemptAt: now - 10,
+	}
+	due, err := ss.OutgoingWebhookDelivery().Save(due)
+	require.NoError(t, err)
+
+	notDue := &model.OutgoingWebhookDelivery{
+		HookId:        model.NewId(),
+		ChannelId:     model.NewId(),
+		PostId:        model.NewId(),
+		CallbackURL:   "http://example.com/hook",
+		ContentType:   "application/json",
+		Payload:       `{"text":"hello"}`,
+		NextAttemptAt: now + 10,
+	}
+	_, err = ss.OutgoingWebhookDelivery().Save(notDue)
+	require.NoError(t, err)
+
+	failed := &model.OutgoingWebhookDelivery{
+		HookId:        model.NewId(),
+		ChannelId:     model.NewId(),
+		PostId:        model.NewId(),
+		CallbackURL:   "http://example.com/hook",
+		ContentType:   "application/json",
+		Payload:       `{"text":"hello"}`,
+		NextAttemptAt: now - 10,
+		Status:        model.OutgoingWebhookDeliveryStatusFailed,
+	}
+	_, err = ss.OutgoingWebhookDelivery().Save(failed)
+	require.NoError(t, err)
+
+	deliveries, err := ss.OutgoingWebhookDelivery().GetPending(now, 100)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, due.Id, deliveries[0].Id)
+}
+
+func testPermanentDeleteOutgoingWebhookDeliveries(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("by hook", func(t *testing.T) {
+		hookID := model.NewId()
+		delivery, err := ss.OutgoingWebhookDelivery().Save(&model.OutgoingWebhookDelivery{
+			HookId:      hookID,
+			ChannelId:   model.NewId(),
+			PostId:      model.NewId(),
+			CallbackURL: "http://example.com/hook",
+			ContentType: "application/json",
+			Payload:     `{"text":"hello"}`,
+		})
+		require.NoError(t, err)
+		other, err := ss.OutgoingWebhookDelivery().Save(&model.OutgoingWebhookDelivery{
+			HookId:      model.NewId(),
+			ChannelId:   model.NewId(),
+			PostId:      model.NewId(),
+			CallbackURL: "http://example.com/hook",
+			ContentType: "application/json",
+			Payload:     `{"text":"hello"}`,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, ss.OutgoingWebhookDelivery().PermanentDeleteByHook(hookID))
+
+		_, err = ss.OutgoingWebhookDelivery().Get(delivery.Id)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+
+		_, err = ss.OutgoingWebhookDelivery().Get(other.Id)
+		require.NoError(t, err)
+	})
+
+	t.Run("batch keeps pending deliveries", func(t *testing.T) {
+		old := &model.OutgoingWebhookDelivery{
+			HookId:      model.NewId(),
+			ChannelId:   model.NewId(),
+			PostId:      model.NewId(),
+			CallbackURL: "http://example.com/hook",
+			ContentType: "application/json",
+			Payload:     `{"text":"hello"}`,
+			CreateAt:    1000,
+			Status:      model.OutgoingWebhookDeliveryStatusSuccess,
+		}
+		old, err := ss.OutgoingWebhookDelivery().Save(old)
+		require.NoError(t, err)
+
+		oldPending := &model.OutgoingWebhookDelivery{
+			HookId:      model.NewId(),
+			ChannelId:   model.NewId(),
+			PostId:      model.NewId(),
+			CallbackURL: "http://example.com/hook",
+			ContentType: "application/json",
+			Payload:     `{"text":"hello"}`,
+			CreateAt:    1000,
+		}
+		oldPending, err = ss.OutgoingWebhookDelivery().Save(oldPending)
+		require.NoError(t, err)
+
+		recent, err := ss.OutgoingWebhookDelivery().Save(&model.OutgoingWebhookDelivery{
+			HookId:      model.NewId(),
+			ChannelId:   model.NewId(),
+			PostId:      model.NewId(),
+			CallbackURL: "http://example.com/hook",
+			ContentType: "application/json",
+			Payload:     `{"text":"hello"}`,
+		})
+		require.NoError(t, err)
+		recent.Status = model.OutgoingWebhookDeliveryStatusFailed
+		require.NoError(t, ss.OutgoingWebhookDelivery().Update(recent))
+
+		deleted, err := ss.OutgoingWebhookDelivery().PermanentDeleteBatch(2000, 1000)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(1))
+
+		_, err = ss.OutgoingWebhookDelivery().Get(old.Id)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+
+		_, err = ss.OutgoingWebhookDelivery().Get(oldPending.Id)
+		require.NoError(t, err)
+		_, err = ss.OutgoingWebhookDelivery().Get(recent.Id)
+		require.NoError(t, err)
+	})
+}
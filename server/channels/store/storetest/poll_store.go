@@ -0,0 +1,271 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestPollStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SavePoll", func(t *testing.T) { testSavePoll(t, rctx, ss) })
+	t.Run("GetPollForPosts", func(t *testing.T) { testGetPollForPosts(t, rctx, ss) })
+	t.Run("UpdatePoll", func(t *testing.T) { testUpdatePoll(t, rctx, ss) })
+	t.Run("DeletePollForPost", func(t *testing.T) { testDeletePollForPost(t, rctx, ss) })
+	t.Run("SavePollVotes", func(t *testing.T) { testSavePollVotes(t, rctx, ss) })
+	t.Run("GetPollVotesForPolls", func(t *testing.T) { testGetPollVotesForPolls(t, rctx, ss) })
+}
+
+func testSavePoll(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("should save and get a poll", func(t *testing.T) {
+		poll := &model.Poll{
+			PostId:         model.NewId(),
+			ChannelId:      model.NewId(),
+			UserId:         model.NewId(),
+			Question:       "Where should we eat?",
+			Options:        model.PollOptions{{Text: "Pizza"}, {Text: "Sushi"}, {Text: "Tacos"}},
+			MultipleChoice: true,
+			CloseAt:        model.GetMillis() + 60*1000,
+		}
+
+		saved, err := ss.Poll().Save(poll)
+		require.NoError(t, err)
+		require.NotEmpty(t, saved.Id)
+
+		fetched, err := ss.Poll().Get(saved.Id)
+		require.NoError(t, err)
+		assert.Equal(t, saved.Question, fetched.Question)
+		assert.Equal(t, saved.Options, fetched.Options)
+		assert.True(t, fetched.MultipleChoice)
+		assert.False(t, fetched.Anonymous)
+		assert.Equal(t, saved.CloseAt, fetched.CloseAt)
+
+		fetched, err = ss.Poll().GetForPost(saved.PostId)
+		require.NoError(t, err)
+		assert.Equal(t, saved.Id, fetched.Id)
+	})
+
+	t.Run("should fail for an invalid poll", func(t *testing.T) {
+		poll := &model.Poll{
+			PostId:    model.NewId(),
+			ChannelId: model.NewId(),
+			UserId:    model.NewId(),
+			Question:  "Where should we eat?",
+			Options:   model.PollOptions{{Text: "Pizza"}},
+		}
+
+		_, err := ss.Poll().Save(poll)
+		require.Error(t, err)
+	})
+
+	t.Run("should fail for a post that already has a poll", func(t *testing.T) {
+		poll, err := ss.Poll().Save(&model.Poll{
+			PostId:    model.NewId(),
+			ChannelId: model.NewId(),
+			UserId:    model.NewId(),
+			Question:  "Where should we eat?",
+			Options:   model.PollOptions{{Text: "Pizza"}, {Text: "Sushi"}, {Text: "Tacos"}},
+		})
+		require.NoError(t, err)
+
+		other := &model.Poll{
+			PostId:    poll.PostId,
+			ChannelId: model.NewId(),
+			UserId:    model.NewId(),
+			Question:  "Where should we eat?",
+			Options:   model.PollOptions{{Text: "Pizza"}, {Text: "Sushi"}, {Text: "Tacos"}},
+		}
+		_, err = ss.Poll().Save(other)
+		require.Error(t, err)
+	})
+
+	t.Run("should return not found for an unknown poll", func(t *testing.T) {
+		_, err := ss.Poll().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testGetPollForPosts(t *testing.T, rctx request.CTX, ss store.Store) {
+	poll1, err := ss.Poll().Save(&model.Poll{
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Question:  "Where should we eat?",
+		Options:   model.PollOptions{{Text: "Pizza"}, {Text: "Sushi"}, {Text: "Tacos"}},
+	})
+	require.NoError(t, err)
+	poll2, err := ss.Poll().Save(&model.Poll{
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Question:  "Where should we eat?",
+		Options:   model.PollOptions{{Text: "Pizza"}, {Text: "Sushi"}, {Text: "Tacos"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, ss.Poll().DeleteForPost(poll2.PostId, model.GetMillis()))
+
+	polls, err := ss.Poll().GetForPosts([]string{poll1.PostId, poll2.PostId, model.NewId()})
+	require.NoError(t, err)
+	require.Len(t, polls, 2)
+
+	polls, err = ss.Poll().GetForPosts(nil)
+	require.NoError(t, err)
+	require.Empty(t, polls)
+}
+
+func testUpdatePoll(t *testing.T, rctx request.CTX, ss store.Store) {
+	poll, err := ss.Poll().Save(&model.Poll{
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Question:  "Where should we eat?",
+		Options:   model.PollOptions{{Text: "Pizza"}, {Text: "Sushi"}, {Text: "Tacos"}},
+	})
+	require.NoError(t, err)
+
+	closeAt := model.GetMillis()
+	poll.CloseAt = closeAt
+	poll.Question = "ignored"
+	require.NoError(t, ss.Poll().Update(poll))
+
+	fetched, err := ss.Poll().Get(poll.Id)
+	require.NoError(t, err)
+	assert.Equal(t, closeAt, fetched.CloseAt)
+	assert.Equal(t, "Where should we eat?", fetched.Question)
+
+	t.Run("should not update a deleted poll", func(t *testing.T) {
+		require.NoError(t, ss.Poll().DeleteForPost(poll.PostId, model.GetMillis()))
+
+		err := ss.Poll().Update(poll)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testDeletePollForPost(t *testing.T, rctx request.CTX, ss store.Store) {
+	poll, err := ss.Poll().Save(&model.Poll{
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Question:  "Where should we eat?",
+		Options:   model.PollOptions{{Text: "Pizza"}, {Text: "Sushi"}, {Text: "Tacos"}},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.Poll().DeleteForPost(poll.PostId, model.GetMillis()))
+
+	_, err = ss.Poll().Get(poll.Id)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+
+	_, err = ss.Poll().GetForPost(poll.PostId)
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testSavePollVotes(t *testing.T, rctx request.CTX, ss store.Store) {
+	poll, err := ss.Poll().Save(&model.Poll{
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Question:  "Where should we eat?",
+		Options:   model.PollOptions{{Text: "Pizza"}, {Text: "Sushi"}, {Text: "Tacos"}},
+	})
+	require.NoError(t, err)
+
+	userID1 := model.NewId()
+	userID2 := model.NewId()
+
+	require.NoError(t, ss.Poll().SaveVotes(poll.Id, userID1, []string{poll.Options[0].Id, poll.Options[1].Id}))
+	require.NoError(t, ss.Poll().SaveVotes(poll.Id, userID2, []string{poll.Options[0].Id}))
+
+	votes, err := ss.Poll().GetVotes(poll.Id)
+	require.NoError(t, err)
+	require.Len(t, votes, 3)
+
+	t.Run("should replace the votes of a user", func(t *testing.T) {
+		require.NoError(t, ss.Poll().SaveVotes(poll.Id, userID1, []string{poll.Options[2].Id}))
+
+		votes, err := ss.Poll().GetVotes(poll.Id)
+		require.NoError(t, err)
+		require.Len(t, votes, 2)
+		for _, vote := range votes {
+			if vote.UserId == userID1 {
+				assert.Equal(t, poll.Options[2].Id, vote.OptionId)
+			}
+		}
+	})
+
+	t.Run("should retract the votes of a user", func(t *testing.T) {
+		require.NoError(t, ss.Poll().SaveVotes(poll.Id, userID1, nil))
+
+		votes, err := ss.Poll().GetVotes(poll.Id)
+		require.NoError(t, err)
+		require.Len(t, votes, 1)
+		assert.Equal(t, userID2, votes[0].UserId)
+	})
+
+	t.Run("should delete the votes of a user", func(t *testing.T) {
+		require.NoError(t, ss.Poll().PermanentDeleteVotesByUser(userID2))
+
+		votes, err := ss.Poll().GetVotes(poll.Id)
+		require.NoError(t, err)
+		require.Empty(t, votes)
+	})
+}
+
+func testGetPollVotesForPolls(t *testing.T, rctx request.CTX, ss store.Store) {
+	poll1, err := ss.Poll().Save(&model.Poll{
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Question:  "Where should we eat?",
+		Options:   model.PollOptions{{Text: "Pizza"}, {Text: "Sushi"}, {Text: "Tacos"}},
+	})
+	require.NoError(t, err)
+	poll2, err := ss.Poll().Save(&model.Poll{
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Question:  "Where should we eat?",
+		Options:   model.PollOptions{{Text: "Pizza"}, {Text: "Sushi"}, {Text: "Tacos"}},
+	})
+	require.NoError(t, err)
+	poll3, err := ss.Poll().Save(&model.Poll{
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Question:  "Where should we eat?",
+		Options:   model.PollOptions{{Text: "Pizza"}, {Text: "Sushi"}, {Text: "Tacos"}},
+	})
+	require.NoError(t, err)
+
+	userID := model.NewId()
+	require.NoError(t, ss.Poll().SaveVotes(poll1.Id, userID, []string{poll1.Options[0].Id}))
+	require.NoError(t, ss.Poll().SaveVotes(poll2.Id, userID, []string{poll2.Options[1].Id}))
+	require.NoError(t, ss.Poll().SaveVotes(poll3.Id, userID, []string{poll3.Options[2].Id}))
+
+	votes, err := ss.Poll().GetVotesForPolls([]string{poll1.Id, poll2.Id, model.NewId()})
+	require.NoError(t, err)
+	require.Len(t, votes, 2)
+	optionIDsByPoll := map[string]string{}
+	for _, vote := range votes {
+		optionIDsByPoll[vote.PollId] = vote.OptionId
+	}
+	assert.Equal(t, map[string]string{
+		poll1.Id: poll1.Options[0].Id,
+		poll2.Id: poll2.Options[1].Id,
+	}, optionIDsByPoll)
+
+	votes, err = ss.Poll().GetVotesForPolls(nil)
+	require.NoError(t, err)
+	require.Empty(t, votes)
+}
@@ -23,6 +23,7 @@ import (
 
 func TestPostStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("SaveMultiple", func(t *testing.T) { testPostStoreSaveMultiple(t, rctx, ss) })
+	t.Run("SaveMultipleWithIds", func(t *testing.T) { testPostStoreSaveMultipleWithIds(t, rctx, ss) })
 	t.Run("Save", func(t *testing.T) { testPostStoreSave(t, rctx, ss) })
 	t.Run("SaveAndUpdateChannelMsgCounts", func(t *testing.T) { testPostStoreSaveChannelMsgCounts(t, rctx, ss) })
 	t.Run("Get", func(t *testing.T) { testPostStoreGet(t, rctx, ss) })
@@ -65,6 +66,44 @@ func TestPostStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("GetPostReminderMetadata", func(t *testing.T) { testGetPostReminderMetadata(t, rctx, ss, s) })
 	t.Run("GetNthRecentPostTime", func(t *testing.T) { testGetNthRecentPostTime(t, rctx, ss) })
 	t.Run("GetEditHistoryForPost", func(t *testing.T) { testGetEditHistoryForPost(t, rctx, ss) })
+	t.Run("PermanentDeleteEditHistoryForPost", func(t *testing.T) { testPermanentDeleteEditHistoryForPost(t, rctx, ss) })
+}
+
+func testPostStoreSaveMultipleWithIds(t *testing.T, rctx request.CTX, ss store.Store) {
+	rootPost := &model.Post{
+		Id:        model.NewId(),
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Message:   NewTestID(),
+	}
+	replyPost := &model.Post{
+		ChannelId: rootPost.ChannelId,
+		UserId:    model.NewId(),
+		RootId:    rootPost.Id,
+		Message:   NewTestID(),
+	}
+
+	newPosts, errIdx, err := ss.Post().SaveMultipleWithIds([]*model.Post{rootPost, replyPost})
+	require.NoError(t, err)
+	require.Equal(t, -1, errIdx)
+	require.Len(t, newPosts, 2)
+	assert.NotEmpty(t, replyPost.Id)
+
+	storedPost, err := ss.Post().GetSingle(rctx, rootPost.Id, false)
+	require.NoError(t, err)
+	assert.Equal(t, rootPost.Message, storedPost.Message)
+	assert.Equal(t, int64(1), newPosts[0].ReplyCount)
+
+	t.Run("fails to save a post with an existing id", func(t *testing.T) {
+		duplicatePost := &model.Post{
+			Id:        rootPost.Id,
+			ChannelId: model.NewId(),
+			UserId:    model.NewId(),
+			Message:   NewTestID(),
+		}
+		_, _, err := ss.Post().SaveMultipleWithIds([]*model.Post{duplicatePost})
+		require.Error(t, err)
+	})
 }
 
 func testPostStoreSave(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -4370,7 +4409,7 @@ func testPostStoreGetParentsForExportAfter(t *testing.T, rctx request.CTX, ss st
 	require.NoError(t, nErr)
 
 	t.Run("without archived channels", func(t *testing.T) {
-		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), false, 0)
 		assert.NoError(t, err)
 
 		found := false
@@ -4393,7 +4432,7 @@ func testPostStoreGetParentsForExportAfter(t *testing.T, rctx request.CTX, ss st
 	})
 
 	t.Run("with archived channels", func(t *testing.T) {
-		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), true)
+		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), true, 0)
 		assert.NoError(t, err)
 
 		found := false
@@ -4421,7 +4460,7 @@ func testPostStoreGetParentsForExportAfter(t *testing.T, rctx request.CTX, ss st
 		}))
 		require.NoError(t, err)
 
-		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), false, 0)
 		assert.NoError(t, err)
 
 		for _, p := range posts {
@@ -4431,6 +4470,44 @@ func testPostStoreGetParentsForExportAfter(t *testing.T, rctx request.CTX, ss st
 			}
 		}
 	})
+
+	t.Run("updated since", func(t *testing.T) {
+		p3 := &model.Post{}
+		p3.ChannelId = c1.Id
+		p3.UserId = u1.Id
+		p3.Message = NewTestID()
+		p3.CreateAt = 5000
+		p3, nErr = ss.Post().Save(rctx, p3)
+		require.NoError(t, nErr)
+
+		postIds := func(posts []*model.PostForExport) []string {
+			ids := make([]string, 0, len(posts))
+			for _, p := range posts {
+				ids = append(ids, p.Id)
+			}
+			return ids
+		}
+
+		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), false, 4000)
+		require.NoError(t, err)
+		assert.Contains(t, postIds(posts), p3.Id)
+		assert.NotContains(t, postIds(posts), p1.Id)
+
+		// A new reply makes its thread part of the export again.
+		r1 := &model.Post{}
+		r1.ChannelId = c1.Id
+		r1.UserId = u1.Id
+		r1.RootId = p1.Id
+		r1.Message = NewTestID()
+		r1.CreateAt = 6000
+		_, nErr = ss.Post().Save(rctx, r1)
+		require.NoError(t, nErr)
+
+		posts, err = ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), false, 4000)
+		require.NoError(t, err)
+		assert.Contains(t, postIds(posts), p1.Id)
+		assert.Contains(t, postIds(posts), p3.Id)
+	})
 }
 
 func testPostStoreGetRepliesForExport(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -4544,7 +4621,7 @@ func testPostStoreGetDirectPostParentsForExportAfter(t *testing.T, rctx request.
 	p1, nErr = ss.Post().Save(rctx, p1)
 	require.NoError(t, nErr)
 
-	r1, nErr := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+	r1, nErr := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false, 0)
 	assert.NoError(t, nErr)
 
 	assert.Equal(t, p1.Message, r1[0].Message)
@@ -4604,11 +4681,11 @@ func testPostStoreGetDirectPostParentsForExportAfterDeleted(t *testing.T, rctx r
 	_, nErr = ss.Post().Save(rctx, p1)
 	require.NoError(t, nErr)
 
-	r1, nErr := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+	r1, nErr := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false, 0)
 	assert.NoError(t, nErr)
 	assert.Equal(t, 0, len(r1))
 
-	r1, nErr = ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), true)
+	r1, nErr = ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), true, 0)
 	assert.NoError(t, nErr)
 	assert.Equal(t, 1, len(r1))
 
@@ -4667,7 +4744,7 @@ func testPostStoreGetDirectPostParentsForExportAfterBatched(t *testing.T, rctx r
 	sort.Slice(postIds, func(i, j int) bool { return postIds[i] < postIds[j] })
 
 	// Get all posts
-	r1, err := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+	r1, err := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, len(postIds), len(r1))
 	var exportedPostIds []string
@@ -4678,7 +4755,7 @@ func testPostStoreGetDirectPostParentsForExportAfterBatched(t *testing.T, rctx r
 	assert.ElementsMatch(t, postIds, exportedPostIds)
 
 	// Get 100
-	r1, err = ss.Post().GetDirectPostParentsForExportAfter(100, strings.Repeat("0", 26), false)
+	r1, err = ss.Post().GetDirectPostParentsForExportAfter(100, strings.Repeat("0", 26), false, 0)
 	assert.NoError(t, err)
 	assert.Equal(t, 100, len(r1))
 	exportedPostIds = []string{}
@@ -5294,3 +5371,52 @@ func testGetEditHistoryForPost(t *testing.T, rctx request.CTX, ss store.Store) {
 		require.NoError(t, err)
 	})
 }
+
+func testPermanentDeleteEditHistoryForPost(t *testing.T, rctx request.CTX, ss store.Store) {
+	post, err := ss.Post().Save(rctx, &model.Post{
+		ChannelId: model.NewId(),
+		UserId:    model.NewId(),
+		Message:   "edit 0",
+	})
+	require.NoError(t, err)
+
+	for i := 1; i <= 4; i++ {
+		oldPost := post.Clone()
+		updatedPost := post.Clone()
+		updatedPost.Message = fmt.Sprintf("edit %d", i)
+		updatedPost.EditAt = model.GetMillis()
+		post, err = ss.Post().Update(rctx, updatedPost, oldPost)
+		require.NoError(t, err)
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	t.Run("keeps the most recent revisions", func(t *testing.T) {
+		deleted, err := ss.Post().PermanentDeleteEditHistoryForPost(post.Id, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+
+		edits, err := ss.Post().GetEditHistoryForPost(post.Id)
+		require.NoError(t, err)
+		require.Len(t, edits, 2)
+		assert.Equal(t, "edit 3", edits[0].Message)
+		assert.Equal(t, "edit 2", edits[1].Message)
+	})
+
+	t.Run("nothing to delete", func(t *testing.T) {
+		deleted, err := ss.Post().PermanentDeleteEditHistoryForPost(post.Id, 2)
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+	})
+
+	t.Run("current version is kept", func(t *testing.T) {
+		_, err := ss.Post().PermanentDeleteEditHistoryForPost(post.Id, 0)
+		require.NoError(t, err)
+
+		_, err = ss.Post().GetEditHistoryForPost(post.Id)
+		require.Error(t, err)
+
+		current, err := ss.Post().GetSingle(rctx, post.Id, false)
+		require.NoError(t, err)
+		assert.Equal(t, "edit 4", current.Message)
+	})
+}
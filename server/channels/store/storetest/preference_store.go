@@ -18,6 +18,7 @@ func TestPreferenceStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlSt
 	t.Run("PreferenceSave", func(t *testing.T) { testPreferenceSave(t, rctx, ss) })
 	t.Run("PreferenceGet", func(t *testing.T) { testPreferenceGet(t, rctx, ss) })
 	t.Run("PreferenceGetCategory", func(t *testing.T) { testPreferenceGetCategory(t, rctx, ss) })
+	t.Run("PreferenceGetCategoryAndNameForUsers", func(t *testing.T) { testPreferenceGetCategoryAndNameForUsers(t, rctx, ss) })
 	t.Run("PreferenceGetAll", func(t *testing.T) { testPreferenceGetAll(t, rctx, ss) })
 	t.Run("PreferenceDeleteByUser", func(t *testing.T) { testPreferenceDeleteByUser(t, rctx, ss) })
 	t.Run("PreferenceDelete", func(t *testing.T) { testPreferenceDelete(t, rctx, ss) })
@@ -103,6 +104,58 @@ func testPreferenceGet(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.Error(t, err, "no error on getting a missing preference")
 }
 
+func testPreferenceGetCategoryAndNameForUsers(t *testing.T, rctx request.CTX, ss store.Store) {
+	userId1 := model.NewId()
+	userId2 := model.NewId()
+	otherUserId := model.NewId()
+	category := model.PreferenceCategoryAdvancedSettings
+	name := model.NewId()
+
+	preferences := model.Preferences{
+		{
+			UserId:   userId1,
+			Category: category,
+			Name:     name,
+			Value:    "false",
+		},
+		{
+			UserId:   userId2,
+			Category: category,
+			Name:     name,
+			Value:    "true",
+		},
+		// same user/category, different name
+		{
+			UserId:   userId1,
+			Category: category,
+			Name:     model.NewId(),
+		},
+		// same user/name, different category
+		{
+			UserId:   userId2,
+			Category: model.NewId(),
+			Name:     name,
+		},
+		// user not requested
+		{
+			UserId:   otherUserId,
+			Category: category,
+			Name:     name,
+		},
+	}
+
+	err := ss.Preference().Save(preferences)
+	require.NoError(t, err)
+
+	result, err := ss.Preference().GetCategoryAndNameForUsers([]string{userId1, userId2, model.NewId()}, category, name)
+	require.NoError(t, err)
+	require.ElementsMatch(t, model.Preferences{preferences[0], preferences[1]}, result)
+
+	result, err = ss.Preference().GetCategoryAndNameForUsers([]string{}, category, name)
+	require.NoError(t, err)
+	require.Empty(t, result)
+}
+
 func testPreferenceGetCategory(t *testing.T, rctx request.CTX, ss store.Store) {
 	userId := model.NewId()
 	category := model.PreferenceCategoryDirectChannelShow
@@ -0,0 +1,375 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestReminderStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveReminder", func(t *testing.T) { testSaveReminder(t, rctx, ss) })
+	t.Run("GetRemindersForUser", func(t *testing.T) { testGetRemindersForUser(t, rctx, ss) })
+	t.Run("GetLastTriggeredReminderForUser", func(t *testing.T) { testGetLastTriggeredReminderForUser(t, rctx, ss) })
+	t.Run("GetDueReminders", func(t *testing.T) { testGetDueReminders(t, rctx, ss) })
+	t.Run("UpdateReminder", func(t *testing.T) { testUpdateReminder(t, rctx, ss) })
+	t.Run("DeleteReminders", func(t *testing.T) { testDeleteReminders(t, rctx, ss) })
+}
+
+func testSaveReminder(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("should save and get a reminder", func(t *testing.T) {
+		reminder := &model.Reminder{
+			UserId:     model.NewId(),
+			TargetType: model.ReminderTargetUser,
+			TargetId:   model.NewId(),
+			Message:    "Water the plants",
+			TriggerAt:  model.GetMillis() + 60*1000,
+		}
+		reminder.Recurrence = &model.ReminderRecurrence{
+			Frequency: model.ReminderFrequencyWeekly,
+			Weekdays:  []time.Weekday{time.Monday, time.Thursday},
+			Hour:      9,
+			TimeZone:  "Europe/Paris",
+		}
+
+		saved, err := ss.Reminder().Save(reminder)
+		require.NoError(t, err)
+		require.NotEmpty(t, saved.Id)
+
+		fetched, err := ss.Reminder().Get(saved.Id)
+		require.NoError(t, err)
+		assert.Equal(t, saved, fetched)
+	})
+
+	t.Run("should save a reminder without recurrence", func(t *testing.T) {
+		saved, err := ss.Reminder().Save(&model.Reminder{
+			UserId:     model.NewId(),
+			TargetType: model.ReminderTargetUser,
+			TargetId:   model.NewId(),
+			Message:    "Water the plants",
+			TriggerAt:  model.GetMillis() + 60*1000,
+		})
+		require.NoError(t, err)
+
+		fetched, err := ss.Reminder().Get(saved.Id)
+		require.NoError(t, err)
+		assert.Nil(t, fetched.Recurrence)
+	})
+
+	t.Run("should not save an invalid reminder", func(t *testing.T) {
+		reminder := &model.Reminder{
+			UserId:     model.NewId(),
+			TargetType: model.ReminderTargetUser,
+			TargetId:   model.NewId(),
+			Message:    "",
+			TriggerAt:  model.GetMillis() + 60*1000,
+		}
+		_, err := ss.Reminder().Save(reminder)
+		require.Error(t, err)
+	})
+
+	t.Run("should return not found for a missing reminder", func(t *testing.T) {
+		_, err := ss.Reminder().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testGetRemindersForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	now := model.GetMillis()
+
+	later, err := ss.Reminder().Save(&model.Reminder{
+		UserId:     userID,
+		TargetType: model.ReminderTargetUser,
+		TargetId:   userID,
+		Message:    "Water the plants",
+		TriggerAt:  now + 2*60*1000,
+	})
+	require.NoError(t, err)
+	sooner, err := ss.Reminder().Save(&model.Reminder{
+		UserId:     userID,
+		TargetType: model.ReminderTargetUser,
+		TargetId:   userID,
+		Message:    "Water the plants",
+		TriggerAt:  now + 60*1000,
+	})
+	require.NoError(t, err)
+
+	completed := &model.Reminder{
+		UserId:     userID,
+		TargetType: model.ReminderTargetUser,
+		TargetId:   userID,
+		Message:    "Water the plants",
+		TriggerAt:  now + 60*1000,
+	}
+	completed, err = ss.Reminder().Save(completed)
+	require.NoError(t, err)
+	completed.MarkTriggered(time.Now())
+	require.NoError(t, ss.Reminder().Update(completed))
+
+	_, err = ss.Reminder().Save(&model.Reminder{
+		UserId:     model.NewId(),
+		TargetType: model.ReminderTargetUser,
+		TargetId:   model.NewId(),
+		Message:    "Water the plants",
+		TriggerAt:  now + 60*1000,
+	})
+	require.NoError(t, err)
+
+	reminders, err := ss.Reminder().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, reminders, 3)
+	assert.Equal(t, sooner.Id, reminders[0].Id)
+	assert.Equal(t, later.Id, reminders[1].Id)
+	assert.Equal(t, completed.Id, reminders[2].Id)
+}
+
+func testGetLastTriggeredReminderForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	now := model.GetMillis()
+
+	_, err := ss.Reminder().GetLastTriggeredForUser(userID)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+
+	first, err := ss.Reminder().Save(&model.Reminder{
+		UserId:     userID,
+		TargetType: model.ReminderTargetUser,
+		TargetId:   userID,
+		Message:    "Water the plants",
+		TriggerAt:  now,
+	})
+	require.NoError(t, err)
+	first.MarkTriggered(time.UnixMilli(now))
+	require.NoError(t, ss.Reminder().Update(first))
+
+	// A reminder delivered to the user by someone else.
+	second := &model.Reminder{
+		UserId:     model.NewId(),
+		TargetType: model.ReminderTargetUser,
+		TargetId:   userID,
+		Message:    "Water the plants",
+		TriggerAt:  now,
+	}
+	second, err = ss.Reminder().Save(second)
+	require.NoError(t, err)
+	second.MarkTriggered(time.UnixMilli(now + 1000))
+	require.NoError(t, ss.Reminder().Update(second))
+
+	// A reminder created by the user for someone else.
+	other := &model.Reminder{
+		UserId:     userID,
+		TargetType: model.ReminderTargetUser,
+		TargetId:   model.NewId(),
+		Message:    "Water the plants",
+		TriggerAt:  now,
+	}
+	other, err = ss.Reminder().Save(other)
+	require.NoError(t, err)
+	other.MarkTriggered(time.UnixMilli(now + 2000))
+	require.NoError(t, ss.Reminder().Update(other))
+
+	last, err := ss.Reminder().GetLastTriggeredForUser(userID)
+	require.NoError(t, err)
+	assert.Equal(t, second.Id, last.Id)
+}
+
+func testGetDueReminders(t *testing.T, rctx request.CTX, ss store.Store) {
+	now := model.GetMillis()
+	userID := model.NewId()
+
+	due1, err := ss.Reminder().Save(&model.Reminder{
+		UserId:     userID,
+		TargetType: model.ReminderTargetUser,
+		TargetId:   userID,
+		Message:    "Water the plants",
+		TriggerAt:  now - 2000,
+	})
+	require.NoError(t, err)
+	due2, err := ss.Reminder().Save(&model.Reminder{
+		UserId:     userID,
+		TargetType: model.ReminderTargetUser,
+		TargetId:   userID,
+		Message:    "Water the plants",
+		TriggerAt:  now - 1000,
+	})
+	require.NoError(t, err)
+	_, err = ss.Reminder().Save(&model.Reminder{
+		UserId:     userID,
+		TargetType: model.ReminderTargetUser,
+		TargetId:   userID,
+		Message:    "Water the plants",
+		TriggerAt:  now + 60*1000,
+	})
+	require.NoError(t, err)
+	_, err = ss.Reminder().Save(&model.Reminder{
+		UserId:     userID,
+		TargetType: model.ReminderTargetUser,
+		TargetId:   userID,
+		Message:    "Water the plants",
+		TriggerAt:  0,
+	})
+	require.NoError(t, err)
+
+	reminders, err := ss.Reminder().GetDue(now, 100)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, reminder := range reminders {
+		if reminder.UserId == userID {
+			ids = append(ids, reminder.Id)
+		}
+	}
+	assert.Equal(t, []string{due1.Id, due2.Id}, ids)
+}
+
+func testUpdateReminder(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("should update the delivery times only", func(t *testing.T) {
+		reminder, err := ss.Reminder().Save(&model.Reminder{
+			UserId:     model.NewId(),
+			TargetType: model.ReminderTargetUser,
+			TargetId:   model.NewId(),
+			Message:    "Water the plants",
+			TriggerAt:  model.GetMillis() + 60*1000,
+		})
+		require.NoError(t, err)
+
+		reminder.Message = "Changed"
+		reminder.TriggerAt = model.GetMillis() + 120*1000
+		reminder.LastTriggeredAt = model.GetMillis()
+		require.NoError(t, ss.Reminder().Update(reminder))
+
+		fetched, err := ss.Reminder().Get(reminder.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "Water the plants", fetched.Message)
+		assert.Equal(t, reminder.TriggerAt, fetched.TriggerAt)
+		assert.Equal(t, reminder.LastTriggeredAt, fetched.LastTriggeredAt)
+	})
+
+	t.Run("should return not found for a missing reminder", func(t *testing.T) {
+		reminder := &model.Reminder{
+			UserId:     model.NewId(),
+			TargetType: model.ReminderTargetUser,
+			TargetId:   model.NewId(),
+			Message:    "Water the plants",
+			TriggerAt:  model.GetMillis() + 60*1000,
+		}
+		reminder.PreSave()
+		err := ss.Reminder().Update(reminder)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testDeleteReminders(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("delete", func(t *testing.T) {
+		reminder, err := ss.Reminder().Save(&model.Reminder{
+			UserId:     model.NewId(),
+			TargetType: model.ReminderTargetUser,
+			TargetId:   model.NewId(),
+			Message:    "Water the plants",
+			TriggerAt:  model.GetMillis() + 60*1000,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, ss.Reminder().Delete(reminder.Id))
+		_, err = ss.Reminder().Get(reminder.Id)
+		require.Error(t, err)
+	})
+
+	t.Run("delete completed before", func(t *testing.T) {
+		now := time.Now()
+		userID := model.NewId()
+
+		old, err := ss.Reminder().Save(&model.Reminder{
+			UserId:     userID,
+			TargetType: model.ReminderTargetUser,
+			TargetId:   userID,
+			Message:    "Water the plants",
+			TriggerAt:  now.UnixMilli(),
+		})
+		require.NoError(t, err)
+		old.MarkTriggered(now.Add(-48 * time.Hour))
+		require.NoError(t, ss.Reminder().Update(old))
+
+		recent, err := ss.Reminder().Save(&model.Reminder{
+			UserId:     userID,
+			TargetType: model.ReminderTargetUser,
+			TargetId:   userID,
+			Message:    "Water the plants",
+			TriggerAt:  now.UnixMilli(),
+		})
+		require.NoError(t, err)
+		recent.MarkTriggered(now)
+		require.NoError(t, ss.Reminder().Update(recent))
+
+		pending, err := ss.Reminder().Save(&model.Reminder{
+			UserId:     userID,
+			TargetType: model.ReminderTargetUser,
+			TargetId:   userID,
+			Message:    "Water the plants",
+			TriggerAt:  now.UnixMilli() + 60*1000,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, ss.Reminder().DeleteCompletedBefore(now.Add(-24*time.Hour).UnixMilli()))
+
+		reminders, err := ss.Reminder().GetForUser(userID)
+		require.NoError(t, err)
+		require.Len(t, reminders, 2)
+		assert.Equal(t, pending.Id, reminders[0].Id)
+		assert.Equal(t, recent.Id, reminders[1].Id)
+	})
+
+	t.Run("permanent delete by user", func(t *testing.T) {
+		userID := model.NewId()
+		otherUserID := model.NewId()
+
+		created, err := ss.Reminder().Save(&model.Reminder{
+			UserId:     userID,
+			TargetType: model.ReminderTargetUser,
+			TargetId:   userID,
+			Message:    "Water the plants",
+			TriggerAt:  model.GetMillis() + 60*1000,
+		})
+		require.NoError(t, err)
+
+		received := &model.Reminder{
+			UserId:     otherUserID,
+			TargetType: model.ReminderTargetUser,
+			TargetId:   userID,
+			Message:    "Water the plants",
+			TriggerAt:  model.GetMillis() + 60*1000,
+		}
+		received, err = ss.Reminder().Save(received)
+		require.NoError(t, err)
+
+		kept, err := ss.Reminder().Save(&model.Reminder{
+			UserId:     otherUserID,
+			TargetType: model.ReminderTargetUser,
+			TargetId:   otherUserID,
+			Message:    "Water the plants",
+			TriggerAt:  model.GetMillis() + 60*1000,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, ss.Reminder().PermanentDeleteByUser(userID))
+
+		_, err = ss.Reminder().Get(created.Id)
+		require.Error(t, err)
+		_, err = ss.Reminder().Get(received.Id)
+		require.Error(t, err)
+		_, err = ss.Reminder().Get(kept.Id)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestSavedSearchStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveSavedSearch", func(t *testing.T) { testSaveSavedSearch(t, rctx, ss) })
+	t.Run("GetSavedSearchesForUser", func(t *testing.T) { testGetSavedSearchesForUser(t, rctx, ss) })
+	t.Run("GetSavedSearchesWithAlerts", func(t *testing.T) { testGetSavedSearchesWithAlerts(t, rctx, ss) })
+	t.Run("UpdateSavedSearch", func(t *testing.T) { testUpdateSavedSearch(t, rctx, ss) })
+	t.Run("DeleteSavedSearches", func(t *testing.T) { testDeleteSavedSearches(t, rctx, ss) })
+}
+
+func testSaveSavedSearch(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("should save and get a saved search", func(t *testing.T) {
+		savedSearch := &model.SavedSearch{
+			UserId:         model.NewId(),
+			Name:           "Deploy errors",
+			Terms:          "error in:prod-alerts from:deploybot",
+			Alert:          model.SavedSearchAlertDirectMessage,
+			TeamId:         model.NewId(),
+			IsOrSearch:     true,
+			TimeZoneOffset: 3600,
+		}
+
+		saved, err := ss.SavedSearch().Save(savedSearch)
+		require.NoError(t, err)
+		require.NotEmpty(t, saved.Id)
+		assert.Equal(t, saved.CreateAt, saved.LastCheckedAt)
+
+		fetched, err := ss.SavedSearch().Get(saved.Id)
+		require.NoError(t, err)
+		assert.Equal(t, saved, fetched)
+	})
+
+	t.Run("should not save an invalid saved search", func(t *testing.T) {
+		savedSearch := &model.SavedSearch{
+			UserId: model.NewId(),
+			Name:   "Unbalanced",
+			Terms:  "(error OR warning",
+			Alert:  model.SavedSearchAlertNone,
+		}
+
+		_, err := ss.SavedSearch().Save(savedSearch)
+		var appErr *model.AppError
+		require.True(t, errors.As(err, &appErr))
+	})
+
+	t.Run("should return a not found error for an unknown saved search", func(t *testing.T) {
+		_, err := ss.SavedSearch().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testGetSavedSearchesForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	for _, name := range []string{"Beta", "Alpha"} {
+		_, err := ss.SavedSearch().Save(&model.SavedSearch{
+			UserId: userID,
+			Name:   name,
+			Terms:  "error in:prod-alerts from:deploybot",
+			Alert:  model.SavedSearchAlertNone,
+		})
+		require.NoError(t, err)
+	}
+	_, err := ss.SavedSearch().Save(&model.SavedSearch{
+		UserId: model.NewId(),
+		Name:   "Other",
+		Terms:  "error in:prod-alerts from:deploybot",
+		Alert:  model.SavedSearchAlertNone,
+	})
+	require.NoError(t, err)
+
+	savedSearches, err := ss.SavedSearch().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, savedSearches, 2)
+	assert.Equal(t, "Alpha", savedSearches[0].Name)
+	assert.Equal(t, "Beta", savedSearches[1].Name)
+
+	savedSearches, err = ss.SavedSearch().GetForUser(model.NewId())
+	require.NoError(t, err)
+	assert.Empty(t, savedSearches)
+}
+
+func testGetSavedSearchesWithAlerts(t *testing.T, rctx request.CTX, ss store.Store) {
+	member := model.NewId()
+	nonMember := model.NewId()
+
+	channel, err := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Prod alerts",
+		Name:        NewTestID(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+	_, err = ss.Channel().SaveMember(rctx, &model.ChannelMember{
+		ChannelId:   channel.Id,
+		UserId:      member,
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	})
+	require.NoError(t, err)
+
+	withDM, err := ss.SavedSearch().Save(&model.SavedSearch{
+		UserId: member,
+		Name:   "DM",
+		Terms:  "error in:prod-alerts from:deploybot",
+		Alert:  model.SavedSearchAlertDirectMessage,
+	})
+	require.NoError(t, err)
+	withWebsocket, err := ss.SavedSearch().Save(&model.SavedSearch{
+		UserId: member,
+		Name:   "Websocket",
+		Terms:  "error in:prod-alerts from:deploybot",
+		Alert:  model.SavedSearchAlertWebsocket,
+	})
+	require.NoError(t, err)
+	_, err = ss.SavedSearch().Save(&model.SavedSearch{
+		UserId: member,
+		Name:   "Without alert",
+		Terms:  "error in:prod-alerts from:deploybot",
+		Alert:  model.SavedSearchAlertNone,
+	})
+	require.NoError(t, err)
+	ofNonMember, err := ss.SavedSearch().Save(&model.SavedSearch{
+		UserId: nonMember,
+		Name:   "Non member",
+		Terms:  "error in:prod-alerts from:deploybot",
+		Alert:  model.SavedSearchAlertDirectMessage,
+	})
+	require.NoError(t, err)
+
+	t.Run("should page through the saved searches with alerts", func(t *testing.T) {
+		found := map[string]bool{}
+		afterID := ""
+		for {
+			savedSearches, err := ss.SavedSearch().GetWithAlerts(afterID, 1)
+			require.NoError(t, err)
+			if len(savedSearches) == 0 {
+				break
+			}
+			require.Len(t, savedSearches, 1)
+			assert.Greater(t, savedSearches[0].Id, afterID)
+			assert.True(t, savedSearches[0].HasAlert())
+			found[savedSearches[0].Id] = true
+			afterID = savedSearches[0].Id
+		}
+
+		assert.True(t, found[withDM.Id])
+		assert.True(t, found[withWebsocket.Id])
+		assert.True(t, found[ofNonMember.Id])
+	})
+
+	t.Run("should get the saved searches with alerts of the channel members", func(t *testing.T) {
+		savedSearches, err := ss.SavedSearch().GetWithAlertsForChannel(channel.Id)
+		require.NoError(t, err)
+		ids := []string{}
+		for _, savedSearch := range savedSearches {
+			ids = append(ids, savedSearch.Id)
+		}
+		assert.ElementsMatch(t, []string{withDM.Id, withWebsocket.Id}, ids)
+	})
+}
+
+func testUpdateSavedSearch(t *testing.T, rctx request.CTX, ss store.Store) {
+	saved, err := ss.SavedSearch().Save(&model.SavedSearch{
+		UserId: model.NewId(),
+		Name:   "Deploy errors",
+		Terms:  "error in:prod-alerts from:deploybot",
+		Alert:  model.SavedSearchAlertNone,
+	})
+	require.NoError(t, err)
+
+	t.Run("should update a saved search", func(t *testing.T) {
+		saved.Patch(&model.SavedSearchPatch{
+			Name:  model.NewPointer("Deploy warnings"),
+			Terms: model.NewPointer("warning in:prod-alerts"),
+			Alert: model.NewPointer(model.SavedSearchAlertWebsocket),
+		})
+		updated, err := ss.SavedSearch().Update(saved)
+		require.NoError(t, err)
+		assert.NotZero(t, updated.LastCheckedAt)
+
+		fetched, err := ss.SavedSearch().Get(saved.Id)
+		require.NoError(t, err)
+		assert.Equal(t, updated, fetched)
+	})
+
+	t.Run("should only move the alerts forward from the expected time", func(t *testing.T) {
+		fetched, err := ss.SavedSearch().Get(saved.Id)
+		require.NoError(t, err)
+		lastCheckedAt := fetched.LastCheckedAt
+
+		updated, err := ss.SavedSearch().UpdateLastCheckedAt(saved.Id, lastCheckedAt, lastCheckedAt+1000)
+		require.NoError(t, err)
+		assert.True(t, updated)
+
+		updated, err = ss.SavedSearch().UpdateLastCheckedAt(saved.Id, lastCheckedAt, lastCheckedAt+2000)
+		require.NoError(t, err)
+		assert.False(t, updated)
+
+		fetched, err = ss.SavedSearch().Get(saved.Id)
+		require.NoError(t, err)
+		assert.Equal(t, lastCheckedAt+1000, fetched.LastCheckedAt)
+	})
+
+	t.Run("should return a not found error for an unknown saved search", func(t *testing.T) {
+		savedSearch := &model.SavedSearch{
+			UserId: model.NewId(),
+			Name:   "Unknown",
+			Terms:  "error in:prod-alerts from:deploybot",
+			Alert:  model.SavedSearchAlertNone,
+		}
+		savedSearch.PreSave()
+
+		_, err := ss.SavedSearch().Update(savedSearch)
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testDeleteSavedSearches(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	first, err := ss.SavedSearch().Save(&model.SavedSearch{
+		UserId: userID,
+		Name:   "First",
+		Terms:  "error in:prod-alerts from:deploybot",
+		Alert:  model.SavedSearchAlertNone,
+	})
+	require.NoError(t, err)
+	_, err = ss.SavedSearch().Save(&model.SavedSearch{
+		UserId: userID,
+		Name:   "Second",
+		Terms:  "error in:prod-alerts from:deploybot",
+		Alert:  model.SavedSearchAlertNone,
+	})
+	require.NoError(t, err)
+	other, err := ss.SavedSearch().Save(&model.SavedSearch{
+		UserId: model.NewId(),
+		Name:   "Other",
+		Terms:  "error in:prod-alerts from:deploybot",
+		Alert:  model.SavedSearchAlertNone,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.SavedSearch().Delete(first.Id))
+	savedSearches, err := ss.SavedSearch().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, savedSearches, 1)
+
+	require.NoError(t, ss.SavedSearch().PermanentDeleteByUser(userID))
+	savedSearches, err = ss.SavedSearch().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, savedSearches)
+
+	_, err = ss.SavedSearch().Get(other.Id)
+	require.NoError(t, err)
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestScheduledPostStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveScheduledPost", func(t *testing.T) { testSaveScheduledPost(t, rctx, ss) })
+	t.Run("UpdateScheduledPost", func(t *testing.T) { testUpdateScheduledPost(t, rctx, ss) })
+	t.Run("DeleteScheduledPost", func(t *testing.T) { testDeleteScheduledPost(t, rctx, ss) })
+	t.Run("GetScheduledPostsForUser", func(t *testing.T) { testGetScheduledPostsForUser(t, rctx, ss) })
+	t.Run("GetPendingScheduledPosts", func(t *testing.T) { testGetPendingScheduledPosts(t, rctx, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testPermanentDeleteScheduledPostsByUser(t, rctx, ss) })
+}
+
+func testSaveScheduledPost(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	channelID := model.NewId()
+
+	t.Run("save valid scheduled post", func(t *testing.T) {
+		scheduledPost := &model.ScheduledPost{
+			UserId:      userID,
+			ChannelId:   channelID,
+			Message:     "scheduled message " + model.NewId(),
+			ScheduledAt: model.GetMillis() + 60000,
+		}
+		saved, err := ss.ScheduledPost().Save(scheduledPost)
+		require.NoError(t, err)
+		require.NotEmpty(t, saved.Id)
+
+		fetched, err := ss.ScheduledPost().Get(saved.Id)
+		require.NoError(t, err)
+		assert.Equal(t, scheduledPost.Message, fetched.Message)
+		assert.Equal(t, scheduledPost.ScheduledAt, fetched.ScheduledAt)
+		assert.Equal(t, int64(0), fetched.ProcessedAt)
+	})
+
+	t.Run("save invalid scheduled post", func(t *testing.T) {
+		scheduledPost := &model.ScheduledPost{
+			UserId:      userID,
+			ChannelId:   channelID,
+			Message:     "scheduled message " + model.NewId(),
+			ScheduledAt: 0,
+		}
+		_, err := ss.ScheduledPost().Save(scheduledPost)
+		require.Error(t, err)
+	})
+
+	t.Run("get unknown scheduled post", func(t *testing.T) {
+		_, err := ss.ScheduledPost().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testUpdateScheduledPost(t *testing.T, rctx request.CTX, ss store.Store) {
+	scheduledPost, err := ss.ScheduledPost().Save(&model.ScheduledPost{
+		UserId:      model.NewId(),
+		ChannelId:   model.NewId(),
+		Message:     "scheduled message " + model.NewId(),
+		ScheduledAt: model.GetMillis() + 60000,
+	})
+	require.NoError(t, err)
+
+	t.Run("update pending scheduled post", func(t *testing.T) {
+		scheduledPost.Message = "updated message"
+		scheduledPost.ScheduledAt += 1000
+		require.NoError(t, ss.ScheduledPost().Update(scheduledPost))
+
+		fetched, err := ss.ScheduledPost().Get(scheduledPost.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "updated message", fetched.Message)
+		assert.Equal(t, scheduledPost.ScheduledAt, fetched.ScheduledAt)
+	})
+
+	t.Run("processed scheduled posts cannot be updated", func(t *testing.T) {
+		postID := model.NewId()
+		require.NoError(t, ss.ScheduledPost().MarkAsProcessed(scheduledPost.Id, postID, "", model.GetMillis()))
+
+		fetched, err := ss.ScheduledPost().Get(scheduledPost.Id)
+		require.NoError(t, err)
+		assert.Equal(t, postID, fetched.PostId)
+		assert.True(t, fetched.IsProcessed())
+
+		fetched.Message = "too late"
+		err = ss.ScheduledPost().Update(fetched)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testDeleteScheduledPost(t *testing.T, rctx request.CTX, ss store.Store) {
+	scheduledPost, err := ss.ScheduledPost().Save(&model.ScheduledPost{
+		UserId:      model.NewId(),
+		ChannelId:   model.NewId(),
+		Message:     "scheduled message " + model.NewId(),
+		ScheduledAt: model.GetMillis() + 60000,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.ScheduledPost().Delete(scheduledPost.Id))
+
+	_, err = ss.ScheduledPost().Get(scheduledPost.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	err = ss.ScheduledPost().Delete(scheduledPost.Id)
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testGetScheduledPostsForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	teamID := model.NewId()
+
+	channel, err := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      teamID,
+		DisplayName: "DisplayName",
+		Name:        "channel" + model.NewId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+
+	otherTeamChannel, err := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "DisplayName",
+		Name:        "channel" + model.NewId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+
+	dmChannel, err := ss.Channel().CreateDirectChannel(rctx, &model.User{Id: userID}, &model.User{Id: model.NewId()})
+	require.NoError(t, err)
+
+	now := model.GetMillis()
+	later, err := ss.ScheduledPost().Save(&model.ScheduledPost{
+		UserId:      userID,
+		ChannelId:   channel.Id,
+		Message:     "scheduled message " + model.NewId(),
+		ScheduledAt: now + 120000,
+	})
+	require.NoError(t, err)
+	sooner, err := ss.ScheduledPost().Save(&model.ScheduledPost{
+		UserId:      userID,
+		ChannelId:   dmChannel.Id,
+		Message:     "scheduled message " + model.NewId(),
+		ScheduledAt: now + 60000,
+	})
+	require.NoError(t, err)
+	_, err = ss.ScheduledPost().Save(&model.ScheduledPost{
+		UserId:      userID,
+		ChannelId:   otherTeamChannel.Id,
+		Message:     "scheduled message " + model.NewId(),
+		ScheduledAt: now + 60000,
+	})
+	require.NoError(t, err)
+	_, err = ss.ScheduledPost().Save(&model.ScheduledPost{
+		UserId:      model.NewId(),
+		ChannelId:   channel.Id,
+		Message:     "scheduled message " + model.NewId(),
+		ScheduledAt: now + 60000,
+	})
+	require.NoError(t, err)
+
+	scheduledPosts, err := ss.ScheduledPost().GetScheduledPostsForUser(userID, teamID)
+	require.NoError(t, err)
+	require.Len(t, scheduledPosts, 2)
+	assert.Equal(t, sooner.Id, scheduledPosts[0].Id)
+	assert.Equal(t, later.Id, scheduledPosts[1].Id)
+}
+
+func testGetPendingScheduledPosts(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	channelID := model.NewId()
+	now := model.GetMillis()
+
+	due1, err := ss.ScheduledPost().Save(&model.ScheduledPost{
+		UserId:      userID,
+		ChannelId:   channelID,
+		Message:     "scheduled message " + model.NewId(),
+		ScheduledAt: now - 3000,
+	})
+	require.NoError(t, err)
+	due2, err := ss.ScheduledPost().Save(&model.ScheduledPost{
+		UserId:      userID,
+		ChannelId:   channelID,
+		Message:     "scheduled message " + model.NewId(),
+		ScheduledAt: now - 2000,
+	})
+	require.NoError(t, err)
+	processed, err := ss.ScheduledPost().Save(&model.ScheduledPost{
+		UserId:      userID,
+		ChannelId:   channelID,
+		Message:     "scheduled message " + model.NewId(),
+		ScheduledAt: now - 1000,
+	})
+	require.NoError(t, err)
+	require.NoError(t, ss.ScheduledPost().MarkAsProcessed(processed.Id, "", model.ScheduledPostErrorCodeChannelArchived, now))
+	_, err = ss.ScheduledPost().Save(&model.ScheduledPost{
+		UserId:      userID,
+		ChannelId:   channelID,
+		Message:     "scheduled message " + model.NewId(),
+		ScheduledAt: now + 60000,
+	})
+	require.NoError(t, err)
+
+	opts := model.GetScheduledPostsForProcessingOptions{
+		ScheduledBefore: now,
+		AfterTime:       now - 4000,
+		PerPage:         1,
+	}
+	page, err := ss.ScheduledPost().GetPendingScheduledPosts(opts)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, due1.Id, page[0].Id)
+
+	opts.AfterTime = page[0].ScheduledAt
+	opts.AfterId = page[0].Id
+	opts.PerPage = 10
+	page, err = ss.ScheduledPost().GetPendingScheduledPosts(opts)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, due2.Id, page[0].Id)
+}
+
+func testPermanentDeleteScheduledPostsByUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	scheduledPost, err := ss.ScheduledPost().Save(&model.ScheduledPost{
+		UserId:      userID,
+		ChannelId:   model.NewId(),
+		Message:     "scheduled message " + model.NewId(),
+		ScheduledAt: model.GetMillis() + 60000,
+	})
+	require.NoError(t, err)
+	otherScheduledPost, err := ss.ScheduledPost().Save(&model.ScheduledPost{
+		UserId:      model.NewId(),
+		ChannelId:   model.NewId(),
+		Message:     "scheduled message " + model.NewId(),
+		ScheduledAt: model.GetMillis() + 60000,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.ScheduledPost().PermanentDeleteByUser(userID))
+
+	_, err = ss.ScheduledPost().Get(scheduledPost.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	_, err = ss.ScheduledPost().Get(otherScheduledPost.Id)
+	require.NoError(t, err)
+}
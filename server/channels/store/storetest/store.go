@@ -65,6 +65,16 @@ type Store struct {
 	PostPersistentNotificationStore mocks.PostPersistentNotificationStore
 	DesktopTokensStore              mocks.DesktopTokensStore
 	ChannelBookmarkStore            mocks.ChannelBookmarkStore
+	ScheduledPostStore              mocks.ScheduledPostStore
+	OutgoingWebhookDeliveryStore    mocks.OutgoingWebhookDeliveryStore
+	PollStore                       mocks.PollStore
+	ReminderStore                   mocks.ReminderStore
+	ImportIdMappingStore            mocks.ImportIdMappingStore
+	LegalHoldStore                  mocks.LegalHoldStore
+	WebAuthnCredentialStore         mocks.WebAuthnCredentialStore
+	MfaRecoveryCodeStore            mocks.MfaRecoveryCodeStore
+	WebPushSubscriptionStore        mocks.WebPushSubscriptionStore
+	SavedSearchStore                mocks.SavedSearchStore
 }
 
 func (s *Store) SetContext(context context.Context)            { s.context = context }
@@ -112,6 +122,7 @@ func (s *Store) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 }
 func (s *Store) ChannelBookmark() store.ChannelBookmarkStore { return &s.ChannelBookmarkStore }
 func (s *Store) DesktopTokens() store.DesktopTokensStore     { return &s.DesktopTokensStore }
+func (s *Store) ScheduledPost() store.ScheduledPostStore     { return &s.ScheduledPostStore }
 func (s *Store) NotifyAdmin() store.NotifyAdminStore         { return &s.NotifyAdminStore }
 func (s *Store) Group() store.GroupStore                     { return &s.GroupStore }
 func (s *Store) LinkMetadata() store.LinkMetadataStore       { return &s.LinkMetadataStore }
@@ -120,6 +131,23 @@ func (s *Store) PostPriority() store.PostPriorityStore       { return &s.PostPri
 func (s *Store) PostAcknowledgement() store.PostAcknowledgementStore {
 	return &s.PostAcknowledgementStore
 }
+func (s *Store) OutgoingWebhookDelivery() store.OutgoingWebhookDeliveryStore {
+	return &s.OutgoingWebhookDeliveryStore
+}
+func (s *Store) Poll() store.PollStore         { return &s.PollStore }
+func (s *Store) Reminder() store.ReminderStore { return &s.ReminderStore }
+func (s *Store) ImportIdMapping() store.ImportIdMappingStore {
+	return &s.ImportIdMappingStore
+}
+func (s *Store) LegalHold() store.LegalHoldStore { return &s.LegalHoldStore }
+func (s *Store) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return &s.WebAuthnCredentialStore
+}
+func (s *Store) MfaRecoveryCode() store.MfaRecoveryCodeStore { return &s.MfaRecoveryCodeStore }
+func (s *Store) WebPushSubscription() store.WebPushSubscriptionStore {
+	return &s.WebPushSubscriptionStore
+}
+func (s *Store) SavedSearch() store.SavedSearchStore { return &s.SavedSearchStore }
 func (s *Store) PostPersistentNotification() store.PostPersistentNotificationStore {
 	return &s.PostPersistentNotificationStore
 }
@@ -188,5 +216,15 @@ func (s *Store) AssertExpectations(t mock.TestingT) bool {
 		&s.PostPersistentNotificationStore,
 		&s.DesktopTokensStore,
 		&s.ChannelBookmarkStore,
+		&s.ScheduledPostStore,
+		&s.OutgoingWebhookDeliveryStore,
+		&s.PollStore,
+		&s.ReminderStore,
+		&s.ImportIdMappingStore,
+		&s.LegalHoldStore,
+		&s.WebAuthnCredentialStore,
+		&s.MfaRecoveryCodeStore,
+		&s.WebPushSubscriptionStore,
+		&s.SavedSearchStore,
 	)
 }
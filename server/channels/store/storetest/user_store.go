@@ -56,6 +56,7 @@ func TestUserStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("UpdateFailedPasswordAttempts", func(t *testing.T) { testUserStoreUpdateFailedPasswordAttempts(t, rctx, ss) })
 	t.Run("Get", func(t *testing.T) { testUserStoreGet(t, rctx, ss) })
 	t.Run("GetAllUsingAuthService", func(t *testing.T) { testGetAllUsingAuthService(t, rctx, ss) })
+	t.Run("GetScimProfiles", func(t *testing.T) { testUserStoreGetScimProfiles(t, rctx, ss) })
 	t.Run("GetAllProfiles", func(t *testing.T) { testUserStoreGetAllProfiles(t, rctx, ss) })
 	t.Run("GetProfiles", func(t *testing.T) { testUserStoreGetProfiles(t, rctx, ss) })
 	t.Run("GetProfilesInChannel", func(t *testing.T) { testUserStoreGetProfilesInChannel(t, rctx, ss) })
@@ -420,6 +421,63 @@ func sanitized(user *model.User) *model.User {
 	return clonedUser
 }
 
+func testUserStoreGetScimProfiles(t *testing.T, rctx request.CTX, ss store.Store) {
+	u1, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: "u1" + model.NewId(),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u1.Id)) }()
+
+	u2, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: "u2" + model.NewId(),
+		DeleteAt: model.GetMillis(),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u2.Id)) }()
+
+	u3, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: "u3" + model.NewId(),
+	})
+	require.NoError(t, err)
+	_, nErr := ss.Bot().Save(&model.Bot{
+		UserId:   u3.Id,
+		Username: u3.Username,
+		OwnerId:  u1.Id,
+	})
+	require.NoError(t, nErr)
+	defer func() { require.NoError(t, ss.Bot().PermanentDelete(u3.Id)) }()
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u3.Id)) }()
+
+	u4, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: "u4" + model.NewId(),
+		RemoteId: model.NewPointer(NewTestID()),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u4.Id)) }()
+
+	var ids []string
+	for offset := 0; ; offset += 2 {
+		users, err := ss.User().GetScimProfiles(offset, 2)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(users), 2)
+		for _, user := range users {
+			ids = append(ids, user.Id)
+		}
+		if len(users) < 2 {
+			break
+		}
+	}
+
+	assert.Contains(t, ids, u1.Id)
+	assert.Contains(t, ids, u2.Id)
+	assert.NotContains(t, ids, u3.Id)
+	assert.NotContains(t, ids, u4.Id)
+}
+
 func testUserStoreGetAllProfiles(t *testing.T, rctx request.CTX, ss store.Store) {
 	u1, err := ss.User().Save(rctx, &model.User{
 		Email:    MakeEmail(),
@@ -0,0 +1,192 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestWebPushSubscriptionStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveAndGetForUser", func(t *testing.T) { testWebPushSubscriptionSaveAndGetForUser(t, rctx, ss) })
+	t.Run("Delete", func(t *testing.T) { testWebPushSubscriptionDelete(t, rctx, ss) })
+	t.Run("DeleteOrphaned", func(t *testing.T) { testWebPushSubscriptionDeleteOrphaned(t, rctx, ss) })
+}
+
+func makeWebPushSession(t *testing.T, rctx request.CTX, ss store.Store, userID string, expiresAt int64) *model.Session {
+	t.Helper()
+	session, err := ss.Session().Save(rctx, &model.Session{UserId: userID, ExpiresAt: expiresAt})
+	require.NoError(t, err)
+	return session
+}
+
+func testWebPushSubscriptionSaveAndGetForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	session1 := makeWebPushSession(t, rctx, ss, userID, 0)
+	session2 := makeWebPushSession(t, rctx, ss, userID, model.GetMillis()+60000)
+	expiredSession := makeWebPushSession(t, rctx, ss, userID, model.GetMillis()-60000)
+
+	subscription1, err := ss.WebPushSubscription().Save(&model.WebPushSubscription{
+		UserId:    session1.UserId,
+		SessionId: session1.Id,
+		Endpoint:  "https://push.example.com/" + model.NewId(),
+		P256dh:    "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:      "BTBZMqHH6r4Tts7J_aSIgg",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, subscription1.Id)
+
+	subscription2, err := ss.WebPushSubscription().Save(&model.WebPushSubscription{
+		UserId:    session2.UserId,
+		SessionId: session2.Id,
+		Endpoint:  "https://push.example.com/" + model.NewId(),
+		P256dh:    "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:      "BTBZMqHH6r4Tts7J_aSIgg",
+	})
+	require.NoError(t, err)
+
+	_, err = ss.WebPushSubscription().Save(&model.WebPushSubscription{
+		UserId:    expiredSession.UserId,
+		SessionId: expiredSession.Id,
+		Endpoint:  "https://push.example.com/" + model.NewId(),
+		P256dh:    "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:      "BTBZMqHH6r4Tts7J_aSIgg",
+	})
+	require.NoError(t, err)
+
+	t.Run("skips expired sessions", func(t *testing.T) {
+		subscriptions, err := ss.WebPushSubscription().GetForUser(userID)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.WebPushSubscription{subscription1, subscription2}, subscriptions)
+	})
+
+	t.Run("replaces the subscription of the session", func(t *testing.T) {
+		replacement, err := ss.WebPushSubscription().Save(&model.WebPushSubscription{
+			UserId:    session1.UserId,
+			SessionId: session1.Id,
+			Endpoint:  "https://push.example.com/" + model.NewId(),
+			P256dh:    "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+			Auth:      "BTBZMqHH6r4Tts7J_aSIgg",
+		})
+		require.NoError(t, err)
+
+		subscriptions, err := ss.WebPushSubscription().GetForUser(userID)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.WebPushSubscription{subscription2, replacement}, subscriptions)
+		subscription1 = replacement
+	})
+
+	t.Run("replaces the subscription of the endpoint", func(t *testing.T) {
+		session3 := makeWebPushSession(t, rctx, ss, userID, 0)
+		sameBrowser := &model.WebPushSubscription{
+			UserId:    session3.UserId,
+			SessionId: session3.Id,
+			Endpoint:  subscription2.Endpoint,
+			P256dh:    "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+			Auth:      "BTBZMqHH6r4Tts7J_aSIgg",
+		}
+		sameBrowser, err := ss.WebPushSubscription().Save(sameBrowser)
+		require.NoError(t, err)
+
+		subscriptions, err := ss.WebPushSubscription().GetForUser(userID)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.WebPushSubscription{subscription1, sameBrowser}, subscriptions)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		invalid := &model.WebPushSubscription{
+			UserId:    session1.UserId,
+			SessionId: session1.Id,
+			Endpoint:  "http://push.example.com",
+			P256dh:    "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+			Auth:      "BTBZMqHH6r4Tts7J_aSIgg",
+		}
+		_, err := ss.WebPushSubscription().Save(invalid)
+		require.Error(t, err)
+	})
+}
+
+func testWebPushSubscriptionDelete(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	session1 := makeWebPushSession(t, rctx, ss, userID, 0)
+	session2 := makeWebPushSession(t, rctx, ss, userID, 0)
+	session3 := makeWebPushSession(t, rctx, ss, userID, 0)
+
+	subscription1, err := ss.WebPushSubscription().Save(&model.WebPushSubscription{
+		UserId:    session1.UserId,
+		SessionId: session1.Id,
+		Endpoint:  "https://push.example.com/" + model.NewId(),
+		P256dh:    "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:      "BTBZMqHH6r4Tts7J_aSIgg",
+	})
+	require.NoError(t, err)
+	_, err = ss.WebPushSubscription().Save(&model.WebPushSubscription{
+		UserId:    session2.UserId,
+		SessionId: session2.Id,
+		Endpoint:  "https://push.example.com/" + model.NewId(),
+		P256dh:    "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:      "BTBZMqHH6r4Tts7J_aSIgg",
+	})
+	require.NoError(t, err)
+	subscription3, err := ss.WebPushSubscription().Save(&model.WebPushSubscription{
+		UserId:    session3.UserId,
+		SessionId: session3.Id,
+		Endpoint:  "https://push.example.com/" + model.NewId(),
+		P256dh:    "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:      "BTBZMqHH6r4Tts7J_aSIgg",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.WebPushSubscription().Delete(subscription1.Id))
+	require.NoError(t, ss.WebPushSubscription().DeleteForSession(session2.Id))
+
+	subscriptions, err := ss.WebPushSubscription().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.WebPushSubscription{subscription3}, subscriptions)
+
+	require.NoError(t, ss.WebPushSubscription().DeleteForUser(userID))
+
+	subscriptions, err = ss.WebPushSubscription().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, subscriptions)
+}
+
+func testWebPushSubscriptionDeleteOrphaned(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	session1 := makeWebPushSession(t, rctx, ss, userID, 0)
+	session2 := makeWebPushSession(t, rctx, ss, userID, 0)
+
+	_, err := ss.WebPushSubscription().Save(&model.WebPushSubscription{
+		UserId:    session1.UserId,
+		SessionId: session1.Id,
+		Endpoint:  "https://push.example.com/" + model.NewId(),
+		P256dh:    "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:      "BTBZMqHH6r4Tts7J_aSIgg",
+	})
+	require.NoError(t, err)
+	subscription2, err := ss.WebPushSubscription().Save(&model.WebPushSubscription{
+		UserId:    session2.UserId,
+		SessionId: session2.Id,
+		Endpoint:  "https://push.example.com/" + model.NewId(),
+		P256dh:    "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+		Auth:      "BTBZMqHH6r4Tts7J_aSIgg",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.Session().Remove(session1.Id))
+
+	deleted, err := ss.WebPushSubscription().DeleteOrphaned()
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(1))
+
+	subscriptions, err := ss.WebPushSubscription().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.WebPushSubscription{subscription2}, subscriptions)
+}
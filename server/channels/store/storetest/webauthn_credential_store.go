@@ -0,0 +1,164 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestWebAuthnCredentialStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveAndGet", func(t *testing.T) { testWebAuthnCredentialSaveAndGet(t, ss) })
+	t.Run("GetForUser", func(t *testing.T) { testWebAuthnCredentialGetForUser(t, ss) })
+	t.Run("UpdateSignCount", func(t *testing.T) { testWebAuthnCredentialUpdateSignCount(t, ss) })
+	t.Run("Delete", func(t *testing.T) { testWebAuthnCredentialDelete(t, ss) })
+}
+
+func testWebAuthnCredentialSaveAndGet(t *testing.T, ss store.Store) {
+	credential, err := ss.WebAuthnCredential().Save(&model.WebAuthnCredential{
+		UserId:       model.NewId(),
+		Name:         "Security key",
+		CredentialId: model.NewId(),
+		PublicKey:    []byte{0xa5, 0x01, 0x02},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, credential.Id)
+
+	t.Run("get", func(t *testing.T) {
+		got, err := ss.WebAuthnCredential().Get(credential.Id)
+		require.NoError(t, err)
+		assert.Equal(t, credential, got)
+
+		got, err = ss.WebAuthnCredential().GetByCredentialId(credential.CredentialId)
+		require.NoError(t, err)
+		assert.Equal(t, credential, got)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := ss.WebAuthnCredential().GetByCredentialId(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+
+	t.Run("duplicate credential id", func(t *testing.T) {
+		duplicate := &model.WebAuthnCredential{
+			UserId:       model.NewId(),
+			Name:         "Security key",
+			CredentialId: credential.CredentialId,
+			PublicKey:    []byte{0xa5, 0x01, 0x02},
+		}
+		_, err := ss.WebAuthnCredential().Save(duplicate)
+		var conflictErr *store.ErrConflict
+		require.True(t, errors.As(err, &conflictErr))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		invalid := &model.WebAuthnCredential{
+			UserId:       model.NewId(),
+			Name:         "Security key",
+			CredentialId: model.NewId(),
+			PublicKey:    nil,
+		}
+		_, err := ss.WebAuthnCredential().Save(invalid)
+		require.Error(t, err)
+	})
+}
+
+func testWebAuthnCredentialGetForUser(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+
+	first := &model.WebAuthnCredential{
+		UserId:       userID,
+		Name:         "Security key",
+		CredentialId: model.NewId(),
+		PublicKey:    []byte{0xa5, 0x01, 0x02},
+		CreateAt:     1000,
+	}
+	_, err := ss.WebAuthnCredential().Save(first)
+	require.NoError(t, err)
+
+	second := &model.WebAuthnCredential{
+		UserId:       userID,
+		Name:         "Security key",
+		CredentialId: model.NewId(),
+		PublicKey:    []byte{0xa5, 0x01, 0x02},
+		CreateAt:     2000,
+		Passwordless: true,
+	}
+	_, err = ss.WebAuthnCredential().Save(second)
+	require.NoError(t, err)
+
+	_, err = ss.WebAuthnCredential().Save(&model.WebAuthnCredential{
+		UserId:       model.NewId(),
+		Name:         "Security key",
+		CredentialId: model.NewId(),
+		PublicKey:    []byte{0xa5, 0x01, 0x02},
+	})
+	require.NoError(t, err)
+
+	credentials, err := ss.WebAuthnCredential().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.WebAuthnCredential{first, second}, credentials)
+
+	credentials, err = ss.WebAuthnCredential().GetForUser(model.NewId())
+	require.NoError(t, err)
+	assert.Empty(t, credentials)
+}
+
+func testWebAuthnCredentialUpdateSignCount(t *testing.T, ss store.Store) {
+	credential, err := ss.WebAuthnCredential().Save(&model.WebAuthnCredential{
+		UserId:       model.NewId(),
+		Name:         "Security key",
+		CredentialId: model.NewId(),
+		PublicKey:    []byte{0xa5, 0x01, 0x02},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.WebAuthnCredential().UpdateSignCount(credential.Id, 42, 1234))
+
+	got, err := ss.WebAuthnCredential().Get(credential.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), got.SignCount)
+	assert.Equal(t, int64(1234), got.LastUsedAt)
+}
+
+func testWebAuthnCredentialDelete(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	credential, err := ss.WebAuthnCredential().Save(&model.WebAuthnCredential{
+		UserId:       userID,
+		Name:         "Security key",
+		CredentialId: model.NewId(),
+		PublicKey:    []byte{0xa5, 0x01, 0x02},
+	})
+	require.NoError(t, err)
+	_, err = ss.WebAuthnCredential().Save(&model.WebAuthnCredential{
+		UserId:       userID,
+		Name:         "Security key",
+		CredentialId: model.NewId(),
+		PublicKey:    []byte{0xa5, 0x01, 0x02},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.WebAuthnCredential().Delete(credential.Id))
+
+	var nfErr *store.ErrNotFound
+	err = ss.WebAuthnCredential().Delete(credential.Id)
+	require.True(t, errors.As(err, &nfErr))
+
+	credentials, err := ss.WebAuthnCredential().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, credentials, 1)
+
+	require.NoError(t, ss.WebAuthnCredential().DeleteForUser(userID))
+	credentials, err = ss.WebAuthnCredential().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, credentials)
+}
@@ -567,6 +567,18 @@ func testWebhookStoreUpdateOutgoing(t *testing.T, rctx request.CTX, ss store.Sto
 
 	_, err := ss.Webhook().UpdateOutgoing(o1)
 	require.NoError(t, err)
+
+	o1.RotateSigningSecret(time.Hour)
+	o1.RotateSigningSecret(time.Hour)
+
+	_, err = ss.Webhook().UpdateOutgoing(o1)
+	require.NoError(t, err)
+
+	o2, err := ss.Webhook().GetOutgoing(o1.Id)
+	require.NoError(t, err)
+	require.Equal(t, o1.SigningSecret, o2.SigningSecret)
+	require.Equal(t, o1.PreviousSigningSecret, o2.PreviousSigningSecret)
+	require.Equal(t, o1.PreviousSigningSecretExpireAt, o2.PreviousSigningSecretExpireAt)
 }
 
 func testWebhookStoreCountIncoming(t *testing.T, rctx request.CTX, ss store.Store) {
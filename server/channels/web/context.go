@@ -4,6 +4,7 @@
 package web
 
 import (
+	"crypto/subtle"
 	"net/http"
 	"path"
 	"regexp"
@@ -157,6 +158,22 @@ func (c *Context) RemoteClusterTokenRequired() {
 	}
 }
 
+// ScimTokenRequired checks that the request carries the SCIM bearer token of
+// the configuration in its Authorization header.
+func (c *Context) ScimTokenRequired(token string, tokenLocation app.TokenLocation) {
+	settings := c.App.Config().ScimSettings
+	if !*settings.Enable {
+		c.Err = model.NewAppError("", "api.context.scim_disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	if tokenLocation != app.TokenLocationHeader || *settings.BearerToken == "" ||
+		subtle.ConstantTimeCompare([]byte(token), []byte(*settings.BearerToken)) != 1 {
+		c.Err = model.NewAppError("", "api.context.session_expired.app_error", nil, "ScimTokenRequired", http.StatusUnauthorized)
+		return
+	}
+}
+
 func (c *Context) MfaRequired() {
 	// Must be licensed for MFA and have it configured for enforcement
 	if license := c.App.Channels().License(); license == nil || !*license.Features.MFA || !*c.App.Config().ServiceSettings.EnableMultifactorAuthentication || !*c.App.Config().ServiceSettings.EnforceMultifactorAuthentication {
@@ -199,6 +216,19 @@ func (c *Context) MfaRequired() {
 		c.Err = model.NewAppError("MfaRequired", "api.context.mfa_required.app_error", nil, "", http.StatusForbidden)
 		return
 	}
+
+	// The policy may require a specific second factor
+	if method := *c.App.Config().ServiceSettings.EnforcedMultifactorMethod; method != "" {
+		hasMethod, appErr := c.App.UserHasMfaMethod(user, method)
+		if appErr != nil {
+			c.Err = appErr
+			return
+		}
+		if !hasMethod {
+			c.Err = model.NewAppError("MfaRequired", "api.context.mfa_method_required.app_error", map[string]any{"Method": method}, "", http.StatusForbidden)
+			return
+		}
+	}
 }
 
 // ExtendSessionExpiryIfNeeded will update Session.ExpiresAt based on session lengths in config.
@@ -497,6 +527,102 @@ func (c *Context) RequireUploadId() *Context {
 	return c
 }
 
+func (c *Context) RequireScheduledPostId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.ScheduledPostId) {
+		c.SetInvalidURLParam("scheduled_post_id")
+	}
+
+	return c
+}
+
+func (c *Context) RequirePollId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.PollId) {
+		c.SetInvalidURLParam("poll_id")
+	}
+
+	return c
+}
+
+func (c *Context) RequireReminderId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.ReminderId) {
+		c.SetInvalidURLParam("reminder_id")
+	}
+
+	return c
+}
+
+func (c *Context) RequireSavedSearchId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.SavedSearchId) {
+		c.SetInvalidURLParam("saved_search_id")
+	}
+
+	return c
+}
+
+func (c *Context) RequireConfigVersionId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.ConfigVersionId) {
+		c.SetInvalidURLParam("config_version_id")
+	}
+
+	return c
+}
+
+func (c *Context) RequireLegalHoldId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.LegalHoldId) {
+		c.SetInvalidURLParam("legal_hold_id")
+	}
+
+	return c
+}
+
+func (c *Context) RequireWebAuthnCredentialId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.WebAuthnCredentialId) {
+		c.SetInvalidURLParam("webauthn_credential_id")
+	}
+
+	return c
+}
+
+func (c *Context) RequireDeliveryId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.DeliveryId) {
+		c.SetInvalidURLParam("delivery_id")
+	}
+
+	return c
+}
+
 func (c *Context) RequireFilename() *Context {
 	if c.Err != nil {
 		return c
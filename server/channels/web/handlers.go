@@ -16,9 +16,8 @@ import (
 	"time"
 
 	"github.com/klauspost/compress/gzhttp"
-	"github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/ext"
-	spanlog "github.com/opentracing/opentracing-go/log"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
@@ -82,6 +81,7 @@ type Handler struct {
 	RequireSession            bool
 	RequireCloudKey           bool
 	RequireRemoteClusterToken bool
+	RequireScimToken          bool
 	TrustRequester            bool
 	RequireMfa                bool
 	IsStatic                  bool
@@ -211,22 +211,21 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if *c.App.Config().ServiceSettings.EnableOpenTracing {
-		span, ctx := tracing.StartRootSpanByContext(context.Background(), "web:ServeHTTP")
-		carrier := opentracing.HTTPHeadersCarrier(r.Header)
-		_ = opentracing.GlobalTracer().Inject(span.Context(), opentracing.HTTPHeaders, carrier)
-		ext.HTTPMethod.Set(span, r.Method)
-		ext.HTTPUrl.Set(span, c.AppContext.Path())
-		ext.PeerAddress.Set(span, c.AppContext.IPAddress())
-		span.SetTag("request_id", c.AppContext.RequestId())
-		span.SetTag("user_agent", c.AppContext.UserAgent())
+		span, ctx := tracing.StartHTTPServerSpan(context.Background(), r.Header, "web:ServeHTTP")
+		span.SetAttributes(
+			semconv.HTTPRequestMethodKey.String(r.Method),
+			semconv.URLPath(c.AppContext.Path()),
+			semconv.ClientAddress(c.AppContext.IPAddress()),
+			semconv.UserAgentOriginal(c.AppContext.UserAgent()),
+			attribute.String("request_id", c.AppContext.RequestId()),
+		)
 
 		defer func() {
 			if c.Err != nil {
-				span.LogFields(spanlog.Error(c.Err))
-				ext.HTTPStatusCode.Set(span, uint16(c.Err.StatusCode))
-				ext.Error.Set(span, true)
+				tracing.RecordError(span, c.Err)
+				span.SetAttributes(semconv.HTTPResponseStatusCode(c.Err.StatusCode))
 			}
-			span.Finish()
+			span.End()
 		}()
 		c.AppContext = c.AppContext.WithContext(ctx)
 
@@ -290,7 +289,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	token, tokenLocation := app.ParseAuthTokenFromRequest(r)
 
-	if token != "" && tokenLocation != app.TokenLocationCloudHeader && tokenLocation != app.TokenLocationRemoteClusterHeader {
+	if token != "" && tokenLocation != app.TokenLocationCloudHeader && tokenLocation != app.TokenLocationRemoteClusterHeader && !h.RequireScimToken {
 		session, err := c.App.GetSession(token)
 
 		if err != nil {
@@ -371,6 +370,10 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		c.RemoteClusterTokenRequired()
 	}
 
+	if c.Err == nil && h.RequireScimToken {
+		c.ScimTokenRequired(token, tokenLocation)
+	}
+
 	if c.Err == nil && h.IsLocal {
 		// if the connection is local, RemoteAddr shouldn't have the
 		// shape IP:PORT (it will be "@" in Linux, for example)
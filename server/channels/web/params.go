@@ -111,6 +111,30 @@ type Params struct {
 
 	// Cloud
 	InvoiceId string
+
+	// Scheduled posts
+	ScheduledPostId string
+
+	// Polls
+	PollId string
+
+	// Reminders
+	ReminderId string
+
+	// Saved searches
+	SavedSearchId string
+
+	// Configuration history
+	ConfigVersionId string
+
+	// Outgoing webhook deliveries
+	DeliveryId string
+
+	// Legal holds
+	LegalHoldId string
+
+	// WebAuthn credentials
+	WebAuthnCredentialId string
 }
 
 func ParamsFromRequest(r *http.Request) *Params {
@@ -178,6 +202,14 @@ func ParamsFromRequest(r *http.Request) *Params {
 	params.ExcludeHome, _ = strconv.ParseBool(query.Get("exclude_home"))
 	params.ExcludeRemote, _ = strconv.ParseBool(query.Get("exclude_remote"))
 	params.ChannelBookmarkId = props["bookmark_id"]
+	params.ScheduledPostId = props["scheduled_post_id"]
+	params.PollId = props["poll_id"]
+	params.ReminderId = props["reminder_id"]
+	params.SavedSearchId = props["saved_search_id"]
+	params.ConfigVersionId = props["config_version_id"]
+	params.DeliveryId = props["delivery_id"]
+	params.LegalHoldId = props["legal_hold_id"]
+	params.WebAuthnCredentialId = props["webauthn_credential_id"]
 	params.Scope = query.Get("scope")
 
 	if val, err := strconv.Atoi(query.Get("page")); err != nil || val < 0 {
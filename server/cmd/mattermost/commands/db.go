@@ -329,6 +329,9 @@ func ConfigToFileBackendSettings(s *model.FileSettings, enableComplianceFeature
 			Directory:  *s.Directory,
 		}
 	}
+	if *s.DriverName == model.ImageDriverAzureBlob || *s.DriverName == model.ImageDriverGCS {
+		return filestore.NewFileBackendSettingsFromConfig(s, enableComplianceFeature, skipVerify)
+	}
 	return filestore.FileBackendSettings{
 		DriverName:                         *s.DriverName,
 		AmazonS3AccessKeyId:                *s.AmazonS3AccessKeyId,
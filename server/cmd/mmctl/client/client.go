@@ -96,6 +96,9 @@ type Client interface {
 	PatchConfig(context.Context, *model.Config) (*model.Config, *model.Response, error)
 	ReloadConfig(ctx context.Context) (*model.Response, error)
 	MigrateConfig(ctx context.Context, from, to string) (*model.Response, error)
+	GetConfigHistory(ctx context.Context, page, perPage int) ([]*model.ConfigVersion, *model.Response, error)
+	GetConfigVersionDiff(ctx context.Context, versionID, compareTo string) (*model.ConfigVersionDiff, *model.Response, error)
+	RollbackConfig(ctx context.Context, versionID string) (*model.Config, *model.Response, error)
 	SyncLdap(ctx context.Context, includeRemovedMembers bool) (*model.Response, error)
 	MigrateIdLdap(ctx context.Context, toAttribute string) (*model.Response, error)
 	GetUsers(ctx context.Context, page, perPage int, etag string) ([]*model.User, *model.Response, error)
@@ -127,11 +130,18 @@ type Client interface {
 	GetUploadsForUser(ctx context.Context, userID string) ([]*model.UploadSession, *model.Response, error)
 	UploadData(ctx context.Context, uploadID string, data io.Reader) (*model.FileInfo, *model.Response, error)
 	ListImports(ctx context.Context) ([]string, *model.Response, error)
+	GetImportValidationReport(ctx context.Context, jobId string) (*model.ImportValidationReport, *model.Response, error)
 	GetJob(ctx context.Context, id string) (*model.Job, *model.Response, error)
 	GetJobs(ctx context.Context, jobType string, status string, page int, perPage int) ([]*model.Job, *model.Response, error)
 	GetJobsByType(ctx context.Context, jobType string, page int, perPage int) ([]*model.Job, *model.Response, error)
 	CreateJob(ctx context.Context, job *model.Job) (*model.Job, *model.Response, error)
 	CancelJob(ctx context.Context, jobID string) (*model.Response, error)
+	CreateLegalHold(ctx context.Context, hold *model.LegalHold) (*model.LegalHold, *model.Response, error)
+	GetLegalHolds(ctx context.Context, page, perPage int, includeReleased bool) ([]*model.LegalHold, *model.Response, error)
+	GetLegalHold(ctx context.Context, legalHoldId string) (*model.LegalHold, *model.Response, error)
+	PatchLegalHold(ctx context.Context, legalHoldId string, patch *model.LegalHoldPatch) (*model.LegalHold, *model.Response, error)
+	ReleaseLegalHold(ctx context.Context, legalHoldId string) (*model.LegalHold, *model.Response, error)
+	ExportLegalHold(ctx context.Context, legalHoldId string, exportType string) (*model.Job, *model.Response, error)
 	UpdateJobStatus(ctx context.Context, jobId string, status string, force bool) (*model.Response, error)
 	CreateIncomingWebhook(ctx context.Context, hook *model.IncomingWebhook) (*model.IncomingWebhook, *model.Response, error)
 	UpdateIncomingWebhook(ctx context.Context, hook *model.IncomingWebhook) (*model.IncomingWebhook, *model.Response, error)
@@ -147,6 +157,8 @@ type Client interface {
 	GetOutgoingWebhooksForTeam(ctx context.Context, teamID string, page int, perPage int, etag string) ([]*model.OutgoingWebhook, *model.Response, error)
 	RegenOutgoingHookToken(ctx context.Context, hookID string) (*model.OutgoingWebhook, *model.Response, error)
 	DeleteOutgoingWebhook(ctx context.Context, hookID string) (*model.Response, error)
+	GetOutgoingWebhookDeliveries(ctx context.Context, hookID string, status string, page int, perPage int) ([]*model.OutgoingWebhookDelivery, *model.Response, error)
+	ReplayOutgoingWebhookDelivery(ctx context.Context, hookID, deliveryID string) (*model.OutgoingWebhookDelivery, *model.Response, error)
 	ListExports(ctx context.Context) ([]string, *model.Response, error)
 	DeleteExport(ctx context.Context, name string) (*model.Response, error)
 	DownloadExport(ctx context.Context, name string, wr io.Writer, offset int64) (int64, *model.Response, error)
@@ -0,0 +1,110 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+)
+
+var AntivirusCmd = &cobra.Command{
+	Use:   "antivirus",
+	Short: "Management of the antivirus scanning of files",
+}
+
+var AntivirusRescanCmd = &cobra.Command{
+	Use:     "rescan",
+	Example: "  antivirus rescan --only-quarantined",
+	Short:   "Start a job rescanning the stored files",
+	Long: "Start a job that sends the stored files to the antivirus scanner again and updates their quarantine state, " +
+		"for instance after the scanner signatures were updated or to scan the files uploaded before scanning was enabled.",
+	Args: cobra.NoArgs,
+	RunE: withClient(antivirusRescanCmdF),
+}
+
+var AntivirusJobCmd = &cobra.Command{
+	Use:   "job",
+	Short: "List and show antivirus rescan jobs",
+}
+
+var AntivirusJobListCmd = &cobra.Command{
+	Use:     "list",
+	Example: "  antivirus job list",
+	Short:   "List antivirus rescan jobs",
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	RunE:    withClient(antivirusJobListCmdF),
+}
+
+var AntivirusJobShowCmd = &cobra.Command{
+	Use:     "show [rescanJobID]",
+	Example: "  antivirus job show f3d68qkkm7n8xgsfxwuo498rah",
+	Short:   "Show antivirus rescan job",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(antivirusJobShowCmdF),
+}
+
+func init() {
+	AntivirusRescanCmd.Flags().Bool("only-quarantined", false, "Only rescan the files which are not known to be clean")
+	AntivirusJobListCmd.Flags().Int("page", 0, "Page number to fetch for the list of rescan jobs")
+	AntivirusJobListCmd.Flags().Int("per-page", DefaultPageSize, "Number of rescan jobs to be fetched")
+	AntivirusJobListCmd.Flags().Bool("all", false, "Fetch all rescan jobs. --page flag will be ignore if provided")
+	AntivirusJobCmd.AddCommand(
+		AntivirusJobListCmd,
+		AntivirusJobShowCmd,
+	)
+	AntivirusCmd.AddCommand(
+		AntivirusRescanCmd,
+		AntivirusJobCmd,
+	)
+	RootCmd.AddCommand(AntivirusCmd)
+}
+
+func antivirusRescanCmdF(c client.Client, command *cobra.Command, args []string) error {
+	job := &model.Job{
+		Type: model.JobTypeAntivirusRescan,
+	}
+	if onlyQuarantined, _ := command.Flags().GetBool("only-quarantined"); onlyQuarantined {
+		job.Data = model.StringMap{"only_quarantined": "true"}
+	}
+
+	job, _, err := c.CreateJob(context.TODO(), job)
+	if err != nil {
+		return fmt.Errorf("failed to create antivirus rescan job: %w", err)
+	}
+
+	printer.PrintT("Antivirus rescan job successfully created, ID: {{.Id}}", job)
+
+	return nil
+}
+
+func antivirusJobShowCmdF(c client.Client, command *cobra.Command, args []string) error {
+	job, _, err := c.GetJob(context.TODO(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get antivirus rescan job: %w", err)
+	}
+	printAntivirusRescanJob(job)
+	return nil
+}
+
+func antivirusJobListCmdF(c client.Client, command *cobra.Command, args []string) error {
+	return jobListCmdF(c, command, model.JobTypeAntivirusRescan, "")
+}
+
+func printAntivirusRescanJob(job *model.Job) {
+	if job.StartAt > 0 {
+		printer.PrintT(fmt.Sprintf("  ID: {{.Id}}\n  Status: {{.Status}}\n  Created: %s\n  Started: %s\n  Processed: %s\n  Clean: %s\n  Infected: %s\n  Failed: %s\n  Errors: %s\n",
+			time.Unix(job.CreateAt/1000, 0), time.Unix(job.StartAt/1000, 0), job.Data["processed"], job.Data["clean"], job.Data["infected"], job.Data["failed"], job.Data["errors"]), job)
+	} else {
+		printer.PrintT(fmt.Sprintf("  ID: {{.Id}}\n  Status: {{.Status}}\n  Created: %s\n\n",
+			time.Unix(job.CreateAt/1000, 0)), job)
+	}
+}
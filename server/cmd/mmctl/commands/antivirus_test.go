@@ -0,0 +1,112 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+)
+
+func (s *MmctlUnitTestSuite) TestAntivirusRescanCmdF() {
+	s.Run("create rescan job", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Type: model.JobTypeAntivirusRescan,
+		}
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("only-quarantined", false, "")
+
+		err := antivirusRescanCmdF(s.client, cmd, nil)
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+	})
+
+	s.Run("create rescan job for quarantined files only", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Type: model.JobTypeAntivirusRescan,
+			Data: model.StringMap{"only_quarantined": "true"},
+		}
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("only-quarantined", false, "")
+		s.Require().NoError(cmd.Flags().Set("only-quarantined", "true"))
+
+		err := antivirusRescanCmdF(s.client, cmd, nil)
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+	})
+
+	s.Run("fail to create rescan job", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Type: model.JobTypeAntivirusRescan,
+		}
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("only-quarantined", false, "")
+
+		err := antivirusRescanCmdF(s.client, cmd, nil)
+		s.Require().EqualError(err, "failed to create antivirus rescan job: mock error")
+		s.Empty(printer.GetLines())
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestAntivirusJobShowCmdF() {
+	s.Run("show rescan job", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Id:       model.NewId(),
+			Type:     model.JobTypeAntivirusRescan,
+			CreateAt: model.GetMillis(),
+			StartAt:  model.GetMillis(),
+			Data: map[string]string{
+				"processed": "10",
+				"clean":     "8",
+				"infected":  "1",
+				"failed":    "1",
+				"errors":    "0",
+			},
+		}
+
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), mockJob.Id).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		err := antivirusJobShowCmdF(s.client, &cobra.Command{}, []string{mockJob.Id})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+	})
+}
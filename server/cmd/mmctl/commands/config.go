@@ -12,6 +12,8 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
@@ -104,6 +106,37 @@ var ConfigMigrateCmd = &cobra.Command{
 	RunE:    withClient(configMigrateCmdF),
 }
 
+var ConfigHistoryCmd = &cobra.Command{
+	Use:     "history",
+	Short:   "List the versions of the configuration",
+	Long:    "Lists the versions of the configuration kept in the database, newest first, along with who saved them. Only available for database backed configurations.",
+	Example: "config history --page 0 --per-page 20",
+	Args:    cobra.NoArgs,
+	RunE:    withClient(configHistoryCmdF),
+}
+
+var ConfigDiffCmd = &cobra.Command{
+	Use:   "diff <version-id> [compare-to-version-id]",
+	Short: "Show the changes of a version of the configuration",
+	Long:  "Shows the settings changed by a version of the configuration, compared to the version that preceded it or to the given version. Sensitive values are masked.",
+	Example: `  # show what a version changed
+  config diff w6jbogoetprqmmjgbsm3chfbba
+
+  # show the changes from a version to another one
+  config diff w6jbogoetprqmmjgbsm3chfbba 7r3bjmfj5jfqpq7ih1j6wx3ujy`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: withClient(configDiffCmdF),
+}
+
+var ConfigRollbackCmd = &cobra.Command{
+	Use:     "rollback <version-id>",
+	Short:   "Roll back the configuration",
+	Long:    "Makes a version of the configuration kept in the database the active one again. Settings overridden through environment variables keep their values.",
+	Example: "config rollback w6jbogoetprqmmjgbsm3chfbba",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(configRollbackCmdF),
+}
+
 var ConfigSubpathCmd = &cobra.Command{
 	Use:   "subpath",
 	Short: "Update client asset loading to use the configured subpath",
@@ -123,6 +156,11 @@ var ConfigSubpathCmd = &cobra.Command{
 func init() {
 	ConfigResetCmd.Flags().Bool("confirm", false, "confirm you really want to reset all configuration settings to its default value")
 
+	ConfigHistoryCmd.Flags().Int("page", 0, "Page number to fetch for the list of configuration versions")
+	ConfigHistoryCmd.Flags().Int("per-page", DefaultPageSize, "Number of configuration versions to be fetched")
+
+	ConfigRollbackCmd.Flags().Bool("confirm", false, "confirm you really want to roll back the configuration")
+
 	ConfigSubpathCmd.Flags().StringP("assets-dir", "a", "", "directory of the Mattermost assets in the local filesystem")
 	_ = ConfigSubpathCmd.MarkFlagRequired("assets-dir")
 	ConfigSubpathCmd.Flags().StringP("path", "p", "", "path to update the assets with")
@@ -137,6 +175,9 @@ func init() {
 		ConfigShowCmd,
 		ConfigReloadCmd,
 		ConfigMigrateCmd,
+		ConfigHistoryCmd,
+		ConfigDiffCmd,
+		ConfigRollbackCmd,
 		ConfigSubpathCmd,
 	)
 	RootCmd.AddCommand(ConfigCmd)
@@ -537,6 +578,78 @@ func configMigrateCmdF(c client.Client, cmd *cobra.Command, args []string) error
 	return nil
 }
 
+func configHistoryCmdF(c client.Client, cmd *cobra.Command, _ []string) error {
+	page, _ := cmd.Flags().GetInt("page")
+	perPage, _ := cmd.Flags().GetInt("per-page")
+
+	versions, _, err := c.GetConfigHistory(context.TODO(), page, perPage)
+	if err != nil {
+		return fmt.Errorf("failed to get the configuration history: %w", err)
+	}
+
+	if len(versions) == 0 {
+		printer.Print("No configuration versions found")
+		return nil
+	}
+
+	for _, version := range versions {
+		printer.PrintT(fmt.Sprintf("{{.Id}}{{if .Active}} (active){{end}}\n  Created: %s\n  Source: {{.Source}}\n  User: {{.UserId}}\n  Session: {{.SessionId}}\n",
+			time.Unix(version.CreateAt/1000, 0)), version)
+	}
+
+	return nil
+}
+
+var configDiffChangeTemplate = template.Must(template.New("").Funcs(template.FuncMap{
+	"json": func(v any) string {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(b)
+	},
+}).Parse("{{.Path}}: {{json .BaseVal}} -> {{json .ActualVal}}"))
+
+func configDiffCmdF(c client.Client, _ *cobra.Command, args []string) error {
+	var compareTo string
+	if len(args) > 1 {
+		compareTo = args[1]
+	}
+
+	diff, _, err := c.GetConfigVersionDiff(context.TODO(), args[0], compareTo)
+	if err != nil {
+		return fmt.Errorf("failed to get the configuration diff: %w", err)
+	}
+
+	if len(diff.Changes) == 0 {
+		printer.Print("No changes found")
+		return nil
+	}
+
+	for _, change := range diff.Changes {
+		printer.PrintPreparedT(configDiffChangeTemplate, change)
+	}
+
+	return nil
+}
+
+func configRollbackCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	confirmFlag, _ := cmd.Flags().GetBool("confirm")
+	if !confirmFlag {
+		if err := getConfirmation(fmt.Sprintf("Are you sure you want to roll back the configuration to version %s? (YES/NO): ", args[0]), false); err != nil {
+			return err
+		}
+	}
+
+	config, _, err := c.RollbackConfig(context.TODO(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to roll back the configuration: %w", err)
+	}
+
+	printer.PrintT("Configuration rolled back successfully", config)
+	return nil
+}
+
 func configSubpathCmdF(cmd *cobra.Command, _ []string) error {
 	assetsDir, _ := cmd.Flags().GetString("assets-dir")
 	path, _ := cmd.Flags().GetString("path")
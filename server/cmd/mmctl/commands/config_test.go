@@ -893,6 +893,150 @@ func (s *MmctlUnitTestSuite) TestConfigMigrateCmd() {
 	})
 }
 
+func (s *MmctlUnitTestSuite) TestConfigHistoryCmd() {
+	s.Run("Should list the configuration versions", func() {
+		printer.Clean()
+		versions := []*model.ConfigVersion{
+			{Id: model.NewId(), CreateAt: model.GetMillis(), Active: true, UserId: model.NewId(), SessionId: model.NewId(), Source: model.ConfigChangeSourceSystemConsole},
+			{Id: model.NewId(), CreateAt: model.GetMillis() - 1000, Source: model.ConfigChangeSourceMmctl},
+		}
+
+		s.client.
+			EXPECT().
+			GetConfigHistory(context.TODO(), 1, 2).
+			Return(versions, &model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 1, "")
+		cmd.Flags().Int("per-page", 2, "")
+
+		err := configHistoryCmdF(s.client, cmd, []string{})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(versions[0], printer.GetLines()[0])
+		s.Require().Equal(versions[1], printer.GetLines()[1])
+		s.Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Should fail on error when getting the configuration history", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetConfigHistory(context.TODO(), 0, DefaultPageSize).
+			Return(nil, &model.Response{StatusCode: http.StatusNotImplemented}, errors.New("some-error")).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", DefaultPageSize, "")
+
+		err := configHistoryCmdF(s.client, cmd, []string{})
+		s.Require().NotNil(err)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestConfigDiffCmd() {
+	s.Run("Should show the changes of a version", func() {
+		printer.Clean()
+		versionID := model.NewId()
+		diff := &model.ConfigVersionDiff{
+			Base:   &model.ConfigVersion{Id: model.NewId()},
+			Actual: &model.ConfigVersion{Id: versionID},
+			Changes: []*model.ConfigVersionChange{
+				{Path: "TeamSettings.SiteName", BaseVal: "Mattermost", ActualVal: "ADifferentName"},
+				{Path: "EmailSettings.SMTPPassword", BaseVal: model.FakeSetting, ActualVal: model.FakeSetting},
+			},
+		}
+
+		s.client.
+			EXPECT().
+			GetConfigVersionDiff(context.TODO(), versionID, "").
+			Return(diff, &model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := configDiffCmdF(s.client, &cobra.Command{}, []string{versionID})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(diff.Changes[0], printer.GetLines()[0])
+		s.Require().Equal(diff.Changes[1], printer.GetLines()[1])
+		s.Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Should compare with the given version", func() {
+		printer.Clean()
+		versionID := model.NewId()
+		compareTo := model.NewId()
+
+		s.client.
+			EXPECT().
+			GetConfigVersionDiff(context.TODO(), versionID, compareTo).
+			Return(&model.ConfigVersionDiff{}, &model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := configDiffCmdF(s.client, &cobra.Command{}, []string{versionID, compareTo})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("No changes found", printer.GetLines()[0])
+	})
+
+	s.Run("Should fail on error when getting the diff", func() {
+		printer.Clean()
+		versionID := model.NewId()
+
+		s.client.
+			EXPECT().
+			GetConfigVersionDiff(context.TODO(), versionID, "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("some-error")).
+			Times(1)
+
+		err := configDiffCmdF(s.client, &cobra.Command{}, []string{versionID})
+		s.Require().NotNil(err)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestConfigRollbackCmd() {
+	s.Run("Should roll back the configuration", func() {
+		printer.Clean()
+		versionID := model.NewId()
+		cfg := &model.Config{}
+		cfg.SetDefaults()
+
+		s.client.
+			EXPECT().
+			RollbackConfig(context.TODO(), versionID).
+			Return(cfg, &model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("confirm", true, "")
+
+		err := configRollbackCmdF(s.client, cmd, []string{versionID})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(cfg, printer.GetLines()[0])
+		s.Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Should fail on error when rolling back the configuration", func() {
+		printer.Clean()
+		versionID := model.NewId()
+
+		s.client.
+			EXPECT().
+			RollbackConfig(context.TODO(), versionID).
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("some-error")).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("confirm", true, "")
+
+		err := configRollbackCmdF(s.client, cmd, []string{versionID})
+		s.Require().NotNil(err)
+	})
+}
+
 func TestCloudRestricted(t *testing.T) {
 	cfg := &model.Config{
 		ServiceSettings: model.ServiceSettings{
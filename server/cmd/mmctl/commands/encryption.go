@@ -0,0 +1,104 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+)
+
+var EncryptionCmd = &cobra.Command{
+	Use:   "encryption",
+	Short: "Management of file encryption at rest",
+}
+
+var EncryptionReencryptCmd = &cobra.Command{
+	Use:     "reencrypt",
+	Example: "  encryption reencrypt",
+	Short:   "Start a job re-encrypting the stored files",
+	Long: "Start a job that encrypts the files stored before encryption at rest was enabled, " +
+		"and re-encrypts the files encrypted with a retired master key using the current one.",
+	Args: cobra.NoArgs,
+	RunE: withClient(encryptionReencryptCmdF),
+}
+
+var EncryptionJobCmd = &cobra.Command{
+	Use:   "job",
+	Short: "List and show file re-encryption jobs",
+}
+
+var EncryptionJobListCmd = &cobra.Command{
+	Use:     "list",
+	Example: "  encryption job list",
+	Short:   "List file re-encryption jobs",
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	RunE:    withClient(encryptionJobListCmdF),
+}
+
+var EncryptionJobShowCmd = &cobra.Command{
+	Use:     "show [reencryptionJobID]",
+	Example: "  encryption job show f3d68qkkm7n8xgsfxwuo498rah",
+	Short:   "Show file re-encryption job",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(encryptionJobShowCmdF),
+}
+
+func init() {
+	EncryptionJobListCmd.Flags().Int("page", 0, "Page number to fetch for the list of re-encryption jobs")
+	EncryptionJobListCmd.Flags().Int("per-page", DefaultPageSize, "Number of re-encryption jobs to be fetched")
+	EncryptionJobListCmd.Flags().Bool("all", false, "Fetch all re-encryption jobs. --page flag will be ignore if provided")
+	EncryptionJobCmd.AddCommand(
+		EncryptionJobListCmd,
+		EncryptionJobShowCmd,
+	)
+	EncryptionCmd.AddCommand(
+		EncryptionReencryptCmd,
+		EncryptionJobCmd,
+	)
+	RootCmd.AddCommand(EncryptionCmd)
+}
+
+func encryptionReencryptCmdF(c client.Client, command *cobra.Command, args []string) error {
+	job, _, err := c.CreateJob(context.TODO(), &model.Job{
+		Type: model.JobTypeFileReencryption,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create file re-encryption job: %w", err)
+	}
+
+	printer.PrintT("File re-encryption job successfully created, ID: {{.Id}}", job)
+
+	return nil
+}
+
+func encryptionJobShowCmdF(c client.Client, command *cobra.Command, args []string) error {
+	job, _, err := c.GetJob(context.TODO(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get file re-encryption job: %w", err)
+	}
+	printReencryptionJob(job)
+	return nil
+}
+
+func encryptionJobListCmdF(c client.Client, command *cobra.Command, args []string) error {
+	return jobListCmdF(c, command, model.JobTypeFileReencryption, "")
+}
+
+func printReencryptionJob(job *model.Job) {
+	if job.StartAt > 0 {
+		printer.PrintT(fmt.Sprintf("  ID: {{.Id}}\n  Status: {{.Status}}\n  Created: %s\n  Started: %s\n  Processed: %s\n  Re-encrypted: %s\n  Errors: %s\n",
+			time.Unix(job.CreateAt/1000, 0), time.Unix(job.StartAt/1000, 0), job.Data["processed"], job.Data["reencrypted"], job.Data["errors"]), job)
+	} else {
+		printer.PrintT(fmt.Sprintf("  ID: {{.Id}}\n  Status: {{.Status}}\n  Created: %s\n\n",
+			time.Unix(job.CreateAt/1000, 0)), job)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+)
+
+func (s *MmctlUnitTestSuite) TestEncryptionReencryptCmdF() {
+	s.Run("create re-encryption job", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Type: model.JobTypeFileReencryption,
+		}
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		err := encryptionReencryptCmdF(s.client, &cobra.Command{}, nil)
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+	})
+
+	s.Run("fail to create re-encryption job", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Type: model.JobTypeFileReencryption,
+		}
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := encryptionReencryptCmdF(s.client, &cobra.Command{}, nil)
+		s.Require().EqualError(err, "failed to create file re-encryption job: mock error")
+		s.Empty(printer.GetLines())
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestEncryptionJobShowCmdF() {
+	s.Run("show re-encryption job", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Id:       model.NewId(),
+			Type:     model.JobTypeFileReencryption,
+			CreateAt: model.GetMillis(),
+			StartAt:  model.GetMillis(),
+			Data: map[string]string{
+				"processed":   "10",
+				"reencrypted": "4",
+				"errors":      "0",
+			},
+		}
+
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), mockJob.Id).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		err := encryptionJobShowCmdF(s.client, &cobra.Command{}, []string{mockJob.Id})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+	})
+}
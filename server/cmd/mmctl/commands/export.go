@@ -4,10 +4,15 @@
 package commands
 
 import (
+	"archive/zip"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
@@ -24,8 +29,14 @@ var ExportCmd = &cobra.Command{
 var ExportCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create export file",
-	Args:  cobra.NoArgs,
-	RunE:  withClient(exportCreateCmdF),
+	Example: `  # create a full export
+  $ mmctl export create
+
+  # only export what changed since a previous export
+  $ mmctl export create --since-export o98rj3ur83dp5dppfyk5yk6osy
+  $ mmctl export create --since-manifest previous_export.zip`,
+	Args: cobra.NoArgs,
+	RunE: withClient(exportCreateCmdF),
 }
 
 var ExportDownloadCmd = &cobra.Command{
@@ -94,6 +105,15 @@ var ExportJobCancelCmd = &cobra.Command{
 	RunE:    withClient(exportJobCancelCmdF),
 }
 
+var ExportJobResumeCmd = &cobra.Command{
+	Use:     "resume [exportJobID]",
+	Example: "  export job resume o98rj3ur83dp5dppfyk5yk6osy",
+	Short:   "Resume export job",
+	Long:    "Resume an export job that failed or was canceled. The export continues from the last checkpoint the job recorded.",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(exportJobResumeCmdF),
+}
+
 func init() {
 	ExportCreateCmd.Flags().Bool("attachments", false, "Set to true to include file attachments in the export file.")
 	_ = ExportCreateCmd.Flags().MarkHidden("attachments")
@@ -103,6 +123,10 @@ func init() {
 	ExportCreateCmd.Flags().Bool("include-archived-channels", false, "Include archived channels in the export file.")
 	ExportCreateCmd.Flags().Bool("include-profile-pictures", false, "Include profile pictures in the export file.")
 	ExportCreateCmd.Flags().Bool("no-roles-and-schemes", false, "Exclude roles and custom permission schemes from the export file.")
+	ExportCreateCmd.Flags().String("since", "", "Only export the data created or updated since the given time, in RFC3339 format.")
+	ExportCreateCmd.Flags().String("since-export", "", "Only export the data created or updated since the given export job started.")
+	ExportCreateCmd.Flags().String("since-manifest", "", "Only export the data created or updated since the export described by the given manifest. Either the manifest file or the export archive containing it can be used.")
+	ExportCreateCmd.MarkFlagsMutuallyExclusive("since", "since-export", "since-manifest")
 
 	ExportDownloadCmd.Flags().Bool("resume", false, "Set to true to resume an export download.")
 	_ = ExportDownloadCmd.Flags().MarkHidden("resume")
@@ -115,10 +139,13 @@ func init() {
 	ExportJobListCmd.Flags().Int("per-page", DefaultPageSize, "Number of export jobs to be fetched")
 	ExportJobListCmd.Flags().Bool("all", false, "Fetch all export jobs. --page flag will be ignore if provided")
 
+	ExportJobResumeCmd.Flags().Bool("force", false, "Resume the job whatever its status is. This is needed for jobs left in progress by a server that stopped.")
+
 	ExportJobCmd.AddCommand(
 		ExportJobListCmd,
 		ExportJobShowCmd,
 		ExportJobCancelCmd,
+		ExportJobResumeCmd,
 	)
 	ExportCmd.AddCommand(
 		ExportCreateCmd,
@@ -154,6 +181,14 @@ func exportCreateCmdF(c client.Client, command *cobra.Command, args []string) er
 		data["include_profile_pictures"] = "true"
 	}
 
+	since, err := exportSince(c, command)
+	if err != nil {
+		return err
+	}
+	if since > 0 {
+		data["since"] = strconv.FormatInt(since, 10)
+	}
+
 	job, _, err := c.CreateJob(context.TODO(), &model.Job{
 		Type: model.JobTypeExportProcess,
 		Data: data,
@@ -167,6 +202,75 @@ func exportCreateCmdF(c client.Client, command *cobra.Command, args []string) er
 	return nil
 }
 
+// exportSince returns the time, in milliseconds, from which an incremental
+// export has to include the data, or zero for a full export.
+func exportSince(c client.Client, command *cobra.Command) (int64, error) {
+	if since, _ := command.Flags().GetString("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse since time: %w", err)
+		}
+		return model.GetMillisForTime(t), nil
+	}
+
+	if jobID, _ := command.Flags().GetString("since-export"); jobID != "" {
+		job, _, err := c.GetJob(context.TODO(), jobID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get export job: %w", err)
+		}
+		if job.Type != model.JobTypeExportProcess || job.Status != model.JobStatusSuccess {
+			return 0, fmt.Errorf("job %q is not a successful export job", jobID)
+		}
+		startedAt, err := strconv.ParseInt(job.Data["export_started_at"], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("export job %q does not record when it started", jobID)
+		}
+		return startedAt, nil
+	}
+
+	if manifestPath, _ := command.Flags().GetString("since-manifest"); manifestPath != "" {
+		manifest, err := readExportManifest(manifestPath)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read export manifest: %w", err)
+		}
+		return manifest.ExportedAt, nil
+	}
+
+	return 0, nil
+}
+
+// readExportManifest reads an export manifest either from its own file or
+// from the export archive containing it.
+func readExportManifest(manifestPath string) (*model.BulkExportManifest, error) {
+	var rd io.ReadCloser
+	if filepath.Ext(manifestPath) == ".zip" {
+		zipRd, err := zip.OpenReader(manifestPath)
+		if err != nil {
+			return nil, err
+		}
+		defer zipRd.Close()
+
+		rd, err = zipRd.Open(model.BulkExportManifestName)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		f, err := os.Open(manifestPath)
+		if err != nil {
+			return nil, err
+		}
+		rd = f
+	}
+	defer rd.Close()
+
+	var manifest model.BulkExportManifest
+	if err := json.NewDecoder(rd).Decode(&manifest); err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
+
 func exportListCmdF(c client.Client, command *cobra.Command, args []string) error {
 	exports, _, err := c.ListExports(context.TODO())
 	if err != nil {
@@ -296,3 +400,27 @@ func exportJobCancelCmdF(c client.Client, _ *cobra.Command, args []string) error
 
 	return nil
 }
+
+func exportJobResumeCmdF(c client.Client, command *cobra.Command, args []string) error {
+	job, _, err := c.GetJob(context.TODO(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get export job: %w", err)
+	}
+
+	if job.Type != model.JobTypeExportProcess {
+		return fmt.Errorf("job %q is not an export job", job.Id)
+	}
+
+	force, _ := command.Flags().GetBool("force")
+	if !force && job.Status != model.JobStatusError && job.Status != model.JobStatusCanceled {
+		return fmt.Errorf("export job %q can't be resumed while its status is %q", job.Id, job.Status)
+	}
+
+	if _, err := c.UpdateJobStatus(context.TODO(), job.Id, model.JobStatusPending, true); err != nil {
+		return fmt.Errorf("failed to resume export job: %w", err)
+	}
+
+	printer.PrintT("Export job {{.Id}} will resume from its last checkpoint", job)
+
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
 
@@ -87,6 +89,135 @@ func (s *MmctlUnitTestSuite) TestExportCreateCmdF() {
 		s.Empty(printer.GetErrorLines())
 		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
 	})
+
+	s.Run("create export since a time", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Type: model.JobTypeExportProcess,
+			Data: map[string]string{
+				"include_attachments":       "true",
+				"include_roles_and_schemes": "true",
+				"since":                     "1700000000000",
+			},
+		}
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("since", "2023-11-14T22:13:20Z", "")
+
+		err := exportCreateCmdF(s.client, cmd, nil)
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+	})
+
+	s.Run("create export since an invalid time", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("since", "yesterday", "")
+
+		err := exportCreateCmdF(s.client, cmd, nil)
+		s.Require().Error(err)
+		s.Empty(printer.GetLines())
+	})
+
+	s.Run("create export since a previous export", func() {
+		printer.Clean()
+		previousJob := &model.Job{
+			Id:     model.NewId(),
+			Type:   model.JobTypeExportProcess,
+			Status: model.JobStatusSuccess,
+			Data: map[string]string{
+				"export_started_at": "1700000000000",
+			},
+		}
+		mockJob := &model.Job{
+			Type: model.JobTypeExportProcess,
+			Data: map[string]string{
+				"include_attachments":       "true",
+				"include_roles_and_schemes": "true",
+				"since":                     "1700000000000",
+			},
+		}
+
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), previousJob.Id).
+			Return(previousJob, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("since-export", previousJob.Id, "")
+
+		err := exportCreateCmdF(s.client, cmd, nil)
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+	})
+
+	s.Run("create export since an unfinished export", func() {
+		printer.Clean()
+		previousJob := &model.Job{
+			Id:     model.NewId(),
+			Type:   model.JobTypeExportProcess,
+			Status: model.JobStatusInProgress,
+		}
+
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), previousJob.Id).
+			Return(previousJob, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("since-export", previousJob.Id, "")
+
+		err := exportCreateCmdF(s.client, cmd, nil)
+		s.Require().EqualError(err, fmt.Sprintf("job %q is not a successful export job", previousJob.Id))
+		s.Empty(printer.GetLines())
+	})
+
+	s.Run("create export since a manifest", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Type: model.JobTypeExportProcess,
+			Data: map[string]string{
+				"include_attachments":       "true",
+				"include_roles_and_schemes": "true",
+				"since":                     "1700000000000",
+			},
+		}
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		manifestPath := filepath.Join(s.T().TempDir(), model.BulkExportManifestName)
+		err := os.WriteFile(manifestPath, []byte(`{"job_id":"","since":0,"exported_at":1700000000000}`), 0600)
+		s.Require().NoError(err)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("since-manifest", manifestPath, "")
+
+		err = exportCreateCmdF(s.client, cmd, nil)
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+	})
 }
 
 func (s *MmctlUnitTestSuite) TestExportDeleteCmdF() {
@@ -148,3 +279,96 @@ func (s *MmctlUnitTestSuite) TestExportListCmdF() {
 		}
 	})
 }
+
+func (s *MmctlUnitTestSuite) TestExportJobResumeCmdF() {
+	s.Run("resume failed export job", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Id:     model.NewId(),
+			Type:   model.JobTypeExportProcess,
+			Status: model.JobStatusError,
+		}
+
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), mockJob.Id).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			UpdateJobStatus(context.TODO(), mockJob.Id, model.JobStatusPending, true).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := exportJobResumeCmdF(s.client, &cobra.Command{}, []string{mockJob.Id})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+	})
+
+	s.Run("resume export job in progress", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Id:     model.NewId(),
+			Type:   model.JobTypeExportProcess,
+			Status: model.JobStatusInProgress,
+		}
+
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), mockJob.Id).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		err := exportJobResumeCmdF(s.client, &cobra.Command{}, []string{mockJob.Id})
+		s.Require().EqualError(err, fmt.Sprintf("export job %q can't be resumed while its status is %q", mockJob.Id, model.JobStatusInProgress))
+		s.Empty(printer.GetLines())
+	})
+
+	s.Run("force resume export job in progress", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Id:     model.NewId(),
+			Type:   model.JobTypeExportProcess,
+			Status: model.JobStatusInProgress,
+		}
+
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), mockJob.Id).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			UpdateJobStatus(context.TODO(), mockJob.Id, model.JobStatusPending, true).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("force", true, "")
+
+		err := exportJobResumeCmdF(s.client, cmd, []string{mockJob.Id})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+	})
+
+	s.Run("resume a job that is not an export", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Id:     model.NewId(),
+			Type:   model.JobTypeImportProcess,
+			Status: model.JobStatusError,
+		}
+
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), mockJob.Id).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		err := exportJobResumeCmdF(s.client, &cobra.Command{}, []string{mockJob.Id})
+		s.Require().EqualError(err, fmt.Sprintf("job %q is not an export job", mockJob.Id))
+	})
+}
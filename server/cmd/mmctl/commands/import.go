@@ -82,6 +82,15 @@ var ImportJobShowCmd = &cobra.Command{
 	RunE:    withClient(importJobShowCmdF),
 }
 
+var ImportJobReportCmd = &cobra.Command{
+	Use:     "report [importJobID]",
+	Example: " import job report f3d68qkkm7n8xgsfxwuo498rah",
+	Short:   "Show the validation report of a dry-run import job",
+	Long:    "Show the validation report of an import job started with \"mmctl import process --dry-run\". The report lists the problems found in the import file, grouped by line type.",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(importJobReportCmdF),
+}
+
 var ImportProcessCmd = &cobra.Command{
 	Use:     "process [importname]",
 	Example: "  import process 35uy6cwrqfnhdx3genrhqqznxc_import.zip",
@@ -115,6 +124,8 @@ func init() {
 
 	ImportProcessCmd.Flags().Bool("bypass-upload", false, "If this is set, the file is not processed from the server, but rather directly read from the filesystem. Works only in --local mode.")
 	ImportProcessCmd.Flags().Bool("extract-content", true, "If this is set, document attachments will be extracted and indexed during the import process. It is advised to disable it to improve performance.")
+	ImportProcessCmd.Flags().Bool("dry-run", false, "Validate the import file without importing it. Every reference to teams, channels, users and attachments is resolved, and a report of the problems found can be fetched with \"mmctl import job report\" once the job is done.")
+	ImportProcessCmd.Flags().String("id-mode", "", "How to import the posts and files with the IDs they were exported with. \"preserve\" keeps the original IDs, \"remap\" derives new IDs from them. In both modes, permalinks to the exporting server are rewritten to point to the imported posts.")
 
 	ImportListCmd.AddCommand(
 		ImportListAvailableCmd,
@@ -123,6 +134,7 @@ func init() {
 	ImportJobCmd.AddCommand(
 		ImportJobListCmd,
 		ImportJobShowCmd,
+		ImportJobReportCmd,
 	)
 	ImportCmd.AddCommand(
 		ImportUploadCmd,
@@ -291,13 +303,26 @@ func importProcessCmdF(c client.Client, command *cobra.Command, args []string) e
 
 	extractContent, _ := command.Flags().GetBool("extract-content")
 
+	idMode, _ := command.Flags().GetString("id-mode")
+	if !model.IsValidImportIdMode(idMode) {
+		return fmt.Errorf("invalid --id-mode %q, must be one of %q or %q", idMode, model.ImportIdModePreserve, model.ImportIdModeRemap)
+	}
+
+	data := map[string]string{
+		"import_file":     importFile,
+		"local_mode":      strconv.FormatBool(isLocal && bypassUpload),
+		"extract_content": strconv.FormatBool(extractContent),
+	}
+	if idMode != "" {
+		data["id_mode"] = idMode
+	}
+	if dryRun, _ := command.Flags().GetBool("dry-run"); dryRun {
+		data["dry_run"] = "true"
+	}
+
 	job, _, err := c.CreateJob(context.TODO(), &model.Job{
 		Type: model.JobTypeImportProcess,
-		Data: map[string]string{
-			"import_file":     importFile,
-			"local_mode":      strconv.FormatBool(isLocal && bypassUpload),
-			"extract_content": strconv.FormatBool(extractContent),
-		},
+		Data: data,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create import process job: %w", err)
@@ -308,6 +333,23 @@ func importProcessCmdF(c client.Client, command *cobra.Command, args []string) e
 	return nil
 }
 
+func importJobReportCmdF(c client.Client, command *cobra.Command, args []string) error {
+	report, _, err := c.GetImportValidationReport(context.TODO(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get import validation report: %w", err)
+	}
+
+	printer.PrintT(fmt.Sprintf(`Validation report of {{.ImportFile}}
+  Lines: {{.TotalLines}}
+  Issues: {{.IssueCount}}{{if .Truncated}} (only the first %d are listed){{end}}
+{{range $type, $issues := .Issues}}
+{{$type}}:
+{{range $issues}}  line {{.Line}}{{if .Field}} [{{.Field}}]{{end}}: {{.Message}}
+{{end}}{{end}}`, model.ImportValidationReportMaxIssues), report)
+
+	return nil
+}
+
 func importJobShowCmdF(c client.Client, command *cobra.Command, args []string) error {
 	job, _, err := c.GetJob(context.TODO(), args[0])
 	if err != nil {
@@ -150,6 +150,51 @@ func (s *MmctlUnitTestSuite) TestImportJobShowCmdF() {
 	})
 }
 
+func (s *MmctlUnitTestSuite) TestImportJobReportCmdF() {
+	s.Run("not found", func() {
+		printer.Clean()
+
+		jobID := model.NewId()
+
+		s.client.
+			EXPECT().
+			GetImportValidationReport(context.TODO(), jobID).
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+
+		err := importJobReportCmdF(s.client, &cobra.Command{}, []string{jobID})
+		s.Require().NotNil(err)
+		s.Empty(printer.GetLines())
+	})
+
+	s.Run("found", func() {
+		printer.Clean()
+		report := &model.ImportValidationReport{
+			JobId:      model.NewId(),
+			ImportFile: "import.zip",
+			TotalLines: 3,
+			LineCounts: map[string]int{"version": 1, "post": 1, "user": 1},
+			IssueCount: 2,
+			Issues: map[string][]*model.ImportValidationIssue{
+				"user": {{Line: 2, Field: "user.teams[0].name", ErrorId: "app.import.validate.unknown_team.error", Message: "Reference to unknown team myteam."}},
+				"post": {{Line: 3, ErrorId: "app.import.validate.unknown_user.error", Message: "Reference to unknown user someone."}},
+			},
+		}
+
+		s.client.
+			EXPECT().
+			GetImportValidationReport(context.TODO(), report.JobId).
+			Return(report, &model.Response{}, nil).
+			Times(1)
+
+		err := importJobReportCmdF(s.client, &cobra.Command{}, []string{report.JobId})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+		s.Equal(report, printer.GetLines()[0].(*model.ImportValidationReport))
+	})
+}
+
 func (s *MmctlUnitTestSuite) TestImportJobListCmdF() {
 	s.Run("no import jobs", func() {
 		printer.Clean()
@@ -233,6 +278,74 @@ func (s *MmctlUnitTestSuite) TestImportProcessCmdF() {
 	s.Len(printer.GetLines(), 1)
 	s.Empty(printer.GetErrorLines())
 	s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+
+	s.Run("id mode", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Type: model.JobTypeImportProcess,
+			Data: map[string]string{
+				"import_file":     importFile,
+				"local_mode":      "false",
+				"extract_content": "true",
+				"id_mode":         model.ImportIdModeRemap,
+			},
+		}
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("extract-content", true, "")
+		cmd.Flags().String("id-mode", "", "")
+		s.Require().NoError(cmd.Flags().Set("id-mode", model.ImportIdModeRemap))
+
+		err := importProcessCmdF(s.client, cmd, []string{importFile})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+	})
+
+	s.Run("dry run", func() {
+		printer.Clean()
+		mockJob := &model.Job{
+			Type: model.JobTypeImportProcess,
+			Data: map[string]string{
+				"import_file":     importFile,
+				"local_mode":      "false",
+				"extract_content": "true",
+				"dry_run":         "true",
+			},
+		}
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("extract-content", true, "")
+		cmd.Flags().Bool("dry-run", false, "")
+		s.Require().NoError(cmd.Flags().Set("dry-run", "true"))
+
+		err := importProcessCmdF(s.client, cmd, []string{importFile})
+		s.Require().Nil(err)
+		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+	})
+
+	s.Run("invalid id mode", func() {
+		printer.Clean()
+		cmd := &cobra.Command{}
+		cmd.Flags().String("id-mode", "", "")
+		s.Require().NoError(cmd.Flags().Set("id-mode", "renumber"))
+
+		err := importProcessCmdF(s.client, cmd, []string{importFile})
+		s.Require().Error(err)
+		s.Empty(printer.GetLines())
+	})
 }
 
 func (s *MmctlUnitTestSuite) TestImportValidateCmdF() {
@@ -0,0 +1,359 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+)
+
+var LegalHoldCmd = &cobra.Command{
+	Use:   "legalhold",
+	Short: "Management of legal holds",
+	Long: "Management of legal holds. The content of the users and channels of an active legal hold " +
+		"is preserved from data retention and permanent deletion.",
+}
+
+var LegalHoldCreateCmd = &cobra.Command{
+	Use:     "create [name]",
+	Short:   "Create a legal hold",
+	Long:    "Create a legal hold preserving the posts and files of its users and channels.",
+	Example: `  legalhold create "Smith v. Example" --users john.doe,jane@example.com --channels myteam:town-square --starts-at 2024-01-01T00:00:00Z`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(legalHoldCreateCmdF),
+}
+
+var LegalHoldListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List legal holds",
+	Example: "  legalhold list --include-released",
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	RunE:    withClient(legalHoldListCmdF),
+}
+
+var LegalHoldShowCmd = &cobra.Command{
+	Use:     "show [legalHoldID]",
+	Short:   "Show a legal hold",
+	Example: "  legalhold show f3d68qkkm7n8xgsfxwuo498rah",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(legalHoldShowCmdF),
+}
+
+var LegalHoldPatchCmd = &cobra.Command{
+	Use:   "patch [legalHoldID]",
+	Short: "Update a legal hold",
+	Long: "Update an active legal hold. Only the given flags are changed, and the --users and --channels flags " +
+		"replace the users and channels of the legal hold.",
+	Example: "  legalhold patch f3d68qkkm7n8xgsfxwuo498rah --channels myteam:town-square,myteam:off-topic --ends-at 2025-01-01T00:00:00Z",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(legalHoldPatchCmdF),
+}
+
+var LegalHoldReleaseCmd = &cobra.Command{
+	Use:     "release [legalHoldID]",
+	Short:   "Release a legal hold",
+	Long:    "Release a legal hold. Its content becomes subject to data retention again, but can still be exported.",
+	Example: "  legalhold release f3d68qkkm7n8xgsfxwuo498rah",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(legalHoldReleaseCmdF),
+}
+
+var LegalHoldExportCmd = &cobra.Command{
+	Use:     "export [legalHoldID]",
+	Short:   "Start a job exporting the content of a legal hold",
+	Example: "  legalhold export f3d68qkkm7n8xgsfxwuo498rah --type actiance",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(legalHoldExportCmdF),
+}
+
+var LegalHoldJobCmd = &cobra.Command{
+	Use:   "job",
+	Short: "List and show legal hold export jobs",
+}
+
+var LegalHoldJobListCmd = &cobra.Command{
+	Use:     "list",
+	Example: "  legalhold job list",
+	Short:   "List legal hold export jobs",
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	RunE:    withClient(legalHoldJobListCmdF),
+}
+
+var LegalHoldJobShowCmd = &cobra.Command{
+	Use:     "show [exportJobID]",
+	Example: "  legalhold job show f3d68qkkm7n8xgsfxwuo498rah",
+	Short:   "Show legal hold export job",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(legalHoldJobShowCmdF),
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{LegalHoldCreateCmd, LegalHoldPatchCmd} {
+		cmd.Flags().String("description", "", "Description of the legal hold")
+		cmd.Flags().StringSlice("users", nil, "Comma-separated list of users whose content is held, by username, email or ID")
+		cmd.Flags().StringSlice("channels", nil, "Comma-separated list of channels whose content is held, as team:channel or channel ID")
+		cmd.Flags().String("starts-at", "", "Only hold content created at or after this time, in RFC3339 format")
+		cmd.Flags().String("ends-at", "", "Only hold content created before this time, in RFC3339 format")
+	}
+	LegalHoldPatchCmd.Flags().String("name", "", "Name of the legal hold")
+
+	LegalHoldListCmd.Flags().Int("page", 0, "Page number to fetch for the list of legal holds")
+	LegalHoldListCmd.Flags().Int("per-page", DefaultPageSize, "Number of legal holds to be fetched")
+	LegalHoldListCmd.Flags().Bool("all", false, "Fetch all legal holds. --page flag will be ignore if provided")
+	LegalHoldListCmd.Flags().Bool("include-released", false, "Include released legal holds")
+
+	LegalHoldExportCmd.Flags().String("type", model.ComplianceExportTypeActiance, "Export format, one of csv, actiance, globalrelay or globalrelay-zip")
+
+	LegalHoldJobListCmd.Flags().Int("page", 0, "Page number to fetch for the list of export jobs")
+	LegalHoldJobListCmd.Flags().Int("per-page", DefaultPageSize, "Number of export jobs to be fetched")
+	LegalHoldJobListCmd.Flags().Bool("all", false, "Fetch all export jobs. --page flag will be ignore if provided")
+
+	LegalHoldJobCmd.AddCommand(
+		LegalHoldJobListCmd,
+		LegalHoldJobShowCmd,
+	)
+	LegalHoldCmd.AddCommand(
+		LegalHoldCreateCmd,
+		LegalHoldListCmd,
+		LegalHoldShowCmd,
+		LegalHoldPatchCmd,
+		LegalHoldReleaseCmd,
+		LegalHoldExportCmd,
+		LegalHoldJobCmd,
+	)
+	RootCmd.AddCommand(LegalHoldCmd)
+}
+
+func legalHoldCreateCmdF(c client.Client, command *cobra.Command, args []string) error {
+	hold := &model.LegalHold{Name: args[0]}
+	hold.Description, _ = command.Flags().GetString("description")
+
+	var err error
+	if hold.UserIds, err = legalHoldUserIDs(c, command); err != nil {
+		return err
+	}
+	if hold.ChannelIds, err = legalHoldChannelIDs(c, command); err != nil {
+		return err
+	}
+	if hold.StartsAt, err = legalHoldTime(command, "starts-at"); err != nil {
+		return err
+	}
+	if hold.EndsAt, err = legalHoldTime(command, "ends-at"); err != nil {
+		return err
+	}
+
+	savedHold, _, err := c.CreateLegalHold(context.TODO(), hold)
+	if err != nil {
+		return fmt.Errorf("failed to create legal hold: %w", err)
+	}
+
+	printLegalHold(savedHold)
+
+	return nil
+}
+
+func legalHoldListCmdF(c client.Client, command *cobra.Command, args []string) error {
+	page, _ := command.Flags().GetInt("page")
+	perPage, _ := command.Flags().GetInt("per-page")
+	showAll, _ := command.Flags().GetBool("all")
+	includeReleased, _ := command.Flags().GetBool("include-released")
+
+	if showAll {
+		page = 0
+	}
+
+	for {
+		holds, _, err := c.GetLegalHolds(context.TODO(), page, perPage, includeReleased)
+		if err != nil {
+			return fmt.Errorf("failed to get legal holds: %w", err)
+		}
+
+		if len(holds) == 0 {
+			if !showAll || page == 0 {
+				printer.Print("No legal holds found")
+			}
+			return nil
+		}
+
+		for _, hold := range holds {
+			printLegalHold(hold)
+		}
+
+		if !showAll {
+			return nil
+		}
+		page++
+	}
+}
+
+func legalHoldShowCmdF(c client.Client, command *cobra.Command, args []string) error {
+	hold, _, err := c.GetLegalHold(context.TODO(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get legal hold: %w", err)
+	}
+
+	printLegalHold(hold)
+
+	return nil
+}
+
+func legalHoldPatchCmdF(c client.Client, command *cobra.Command, args []string) error {
+	patch := &model.LegalHoldPatch{}
+
+	if command.Flags().Changed("name") {
+		name, _ := command.Flags().GetString("name")
+		patch.Name = &name
+	}
+	if command.Flags().Changed("description") {
+		description, _ := command.Flags().GetString("description")
+		patch.Description = &description
+	}
+	if command.Flags().Changed("users") {
+		userIDs, err := legalHoldUserIDs(c, command)
+		if err != nil {
+			return err
+		}
+		patch.UserIds = &userIDs
+	}
+	if command.Flags().Changed("channels") {
+		channelIDs, err := legalHoldChannelIDs(c, command)
+		if err != nil {
+			return err
+		}
+		patch.ChannelIds = &channelIDs
+	}
+	if command.Flags().Changed("starts-at") {
+		startsAt, err := legalHoldTime(command, "starts-at")
+		if err != nil {
+			return err
+		}
+		patch.StartsAt = &startsAt
+	}
+	if command.Flags().Changed("ends-at") {
+		endsAt, err := legalHoldTime(command, "ends-at")
+		if err != nil {
+			return err
+		}
+		patch.EndsAt = &endsAt
+	}
+
+	hold, _, err := c.PatchLegalHold(context.TODO(), args[0], patch)
+	if err != nil {
+		return fmt.Errorf("failed to update legal hold: %w", err)
+	}
+
+	printLegalHold(hold)
+
+	return nil
+}
+
+func legalHoldReleaseCmdF(c client.Client, command *cobra.Command, args []string) error {
+	hold, _, err := c.ReleaseLegalHold(context.TODO(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to release legal hold: %w", err)
+	}
+
+	printer.PrintT("Legal hold {{.Name}} ({{.Id}}) successfully released", hold)
+
+	return nil
+}
+
+func legalHoldExportCmdF(c client.Client, command *cobra.Command, args []string) error {
+	exportType, _ := command.Flags().GetString("type")
+
+	job, _, err := c.ExportLegalHold(context.TODO(), args[0], exportType)
+	if err != nil {
+		return fmt.Errorf("failed to create legal hold export job: %w", err)
+	}
+
+	printer.PrintT("Legal hold export job successfully created, ID: {{.Id}}", job)
+
+	return nil
+}
+
+func legalHoldJobShowCmdF(c client.Client, command *cobra.Command, args []string) error {
+	job, _, err := c.GetJob(context.TODO(), args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get legal hold export job: %w", err)
+	}
+
+	printLegalHoldExportJob(job)
+
+	return nil
+}
+
+func legalHoldJobListCmdF(c client.Client, command *cobra.Command, args []string) error {
+	return jobListCmdF(c, command, model.JobTypeLegalHoldExport, "")
+}
+
+func legalHoldUserIDs(c client.Client, command *cobra.Command) ([]string, error) {
+	userArgs, _ := command.Flags().GetStringSlice("users")
+	users, err := getUsersFromArgs(c, userArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.Id)
+	}
+	return userIDs, nil
+}
+
+func legalHoldChannelIDs(c client.Client, command *cobra.Command) ([]string, error) {
+	channelArgs, _ := command.Flags().GetStringSlice("channels")
+	channels, err := getChannelsFromArgs(c, channelArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	channelIDs := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		channelIDs = append(channelIDs, channel.Id)
+	}
+	return channelIDs, nil
+}
+
+func legalHoldTime(command *cobra.Command, flag string) (int64, error) {
+	value, _ := command.Flags().GetString(flag)
+	if value == "" {
+		return 0, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, errors.New("invalid " + flag + " time '" + value + "', it must be in RFC3339 format")
+	}
+	return model.GetMillisForTime(t), nil
+}
+
+func printLegalHold(hold *model.LegalHold) {
+	status := "active"
+	if hold.IsReleased() {
+		status = "released on " + time.UnixMilli(hold.DeleteAt).UTC().Format(time.RFC3339)
+	}
+
+	printer.PrintT(fmt.Sprintf("{{.Id}}: {{.Name}} (%s, %d users, %d channels)", status, len(hold.UserIds), len(hold.ChannelIds)), hold)
+}
+
+func printLegalHoldExportJob(job *model.Job) {
+	if job.Status == model.JobStatusSuccess {
+		printer.PrintT(fmt.Sprintf("  ID: {{.Id}}\n  Status: {{.Status}}\n  Created: %s\n  Legal hold: %s\n  Format: %s\n  Posts: %s\n  Directory: %s\n",
+			time.Unix(job.CreateAt/1000, 0), job.Data[model.LegalHoldExportJobDataHoldId], job.Data[model.LegalHoldExportJobDataExportType],
+			job.Data[model.LegalHoldExportJobDataPostCount], job.Data[model.LegalHoldExportJobDataExportDir]), job)
+	} else {
+		printer.PrintT(fmt.Sprintf("  ID: {{.Id}}\n  Status: {{.Status}}\n  Created: %s\n  Legal hold: %s\n  Format: %s\n",
+			time.Unix(job.CreateAt/1000, 0), job.Data[model.LegalHoldExportJobDataHoldId], job.Data[model.LegalHoldExportJobDataExportType]), job)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+)
+
+func (s *MmctlUnitTestSuite) TestLegalHoldCreateCmdF() {
+	s.Run("create a legal hold", func() {
+		printer.Clean()
+		user := &model.User{Id: model.NewId(), Email: "john@example.com"}
+		channel := &model.Channel{Id: model.NewId()}
+		expectedHold := &model.LegalHold{
+			Name:        "Smith v. Example",
+			Description: "Litigation",
+			UserIds:     []string{user.Id},
+			ChannelIds:  []string{channel.Id},
+			StartsAt:    1704067200000,
+		}
+		savedHold := *expectedHold
+		savedHold.Id = model.NewId()
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), user.Email, "").
+			Return(user, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateLegalHold(context.TODO(), expectedHold).
+			Return(&savedHold, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("description", "Litigation", "")
+		cmd.Flags().StringSlice("users", []string{user.Email}, "")
+		cmd.Flags().StringSlice("channels", []string{channel.Id}, "")
+		cmd.Flags().String("starts-at", "2024-01-01T00:00:00Z", "")
+
+		err := legalHoldCreateCmdF(s.client, cmd, []string{"Smith v. Example"})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+		s.Equal(&savedHold, printer.GetLines()[0].(*model.LegalHold))
+	})
+
+	s.Run("fail on an invalid time", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("ends-at", "2024-01-01", "")
+
+		err := legalHoldCreateCmdF(s.client, cmd, []string{"Smith v. Example"})
+		s.Require().EqualError(err, "invalid ends-at time '2024-01-01', it must be in RFC3339 format")
+		s.Empty(printer.GetLines())
+	})
+
+	s.Run("fail to create a legal hold", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			CreateLegalHold(context.TODO(), &model.LegalHold{Name: "Smith v. Example", UserIds: []string{}, ChannelIds: []string{}}).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := legalHoldCreateCmdF(s.client, &cobra.Command{}, []string{"Smith v. Example"})
+		s.Require().EqualError(err, "failed to create legal hold: mock error")
+		s.Empty(printer.GetLines())
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestLegalHoldListCmdF() {
+	s.Run("list legal holds", func() {
+		printer.Clean()
+		holds := []*model.LegalHold{
+			{Id: model.NewId(), Name: "First"},
+			{Id: model.NewId(), Name: "Second", DeleteAt: model.GetMillis()},
+		}
+
+		s.client.
+			EXPECT().
+			GetLegalHolds(context.TODO(), 0, DefaultPageSize, true).
+			Return(holds, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("per-page", DefaultPageSize, "")
+		cmd.Flags().Bool("include-released", true, "")
+
+		err := legalHoldListCmdF(s.client, cmd, nil)
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Equal(holds[0], printer.GetLines()[0].(*model.LegalHold))
+		s.Equal(holds[1], printer.GetLines()[1].(*model.LegalHold))
+	})
+
+	s.Run("list all legal holds", func() {
+		printer.Clean()
+		hold := &model.LegalHold{Id: model.NewId(), Name: "First"}
+
+		s.client.
+			EXPECT().
+			GetLegalHolds(context.TODO(), 0, 1, false).
+			Return([]*model.LegalHold{hold}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetLegalHolds(context.TODO(), 1, 1, false).
+			Return([]*model.LegalHold{}, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("per-page", 1, "")
+		cmd.Flags().Bool("all", true, "")
+
+		err := legalHoldListCmdF(s.client, cmd, nil)
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Equal(hold, printer.GetLines()[0].(*model.LegalHold))
+	})
+
+	s.Run("no legal holds", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetLegalHolds(context.TODO(), 0, DefaultPageSize, false).
+			Return([]*model.LegalHold{}, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("per-page", DefaultPageSize, "")
+
+		err := legalHoldListCmdF(s.client, cmd, nil)
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Equal("No legal holds found", printer.GetLines()[0])
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestLegalHoldPatchCmdF() {
+	s.Run("patch only the changed fields", func() {
+		printer.Clean()
+		holdID := model.NewId()
+		name := "Renamed"
+		endsAt := int64(1735689600000)
+		hold := &model.LegalHold{Id: holdID, Name: name, EndsAt: endsAt}
+
+		s.client.
+			EXPECT().
+			PatchLegalHold(context.TODO(), holdID, &model.LegalHoldPatch{Name: &name, EndsAt: &endsAt}).
+			Return(hold, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", "", "")
+		cmd.Flags().String("description", "", "")
+		cmd.Flags().String("ends-at", "", "")
+		s.Require().NoError(cmd.Flags().Set("name", name))
+		s.Require().NoError(cmd.Flags().Set("ends-at", "2025-01-01T00:00:00Z"))
+
+		err := legalHoldPatchCmdF(s.client, cmd, []string{holdID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Equal(hold, printer.GetLines()[0].(*model.LegalHold))
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestLegalHoldReleaseCmdF() {
+	s.Run("release a legal hold", func() {
+		printer.Clean()
+		hold := &model.LegalHold{Id: model.NewId(), Name: "First", DeleteAt: model.GetMillis()}
+
+		s.client.
+			EXPECT().
+			ReleaseLegalHold(context.TODO(), hold.Id).
+			Return(hold, &model.Response{}, nil).
+			Times(1)
+
+		err := legalHoldReleaseCmdF(s.client, &cobra.Command{}, []string{hold.Id})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Equal(hold, printer.GetLines()[0].(*model.LegalHold))
+	})
+
+	s.Run("fail to release a legal hold", func() {
+		printer.Clean()
+		holdID := model.NewId()
+
+		s.client.
+			EXPECT().
+			ReleaseLegalHold(context.TODO(), holdID).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := legalHoldReleaseCmdF(s.client, &cobra.Command{}, []string{holdID})
+		s.Require().EqualError(err, "failed to release legal hold: mock error")
+		s.Empty(printer.GetLines())
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestLegalHoldExportCmdF() {
+	s.Run("create a legal hold export job", func() {
+		printer.Clean()
+		holdID := model.NewId()
+		job := &model.Job{Id: model.NewId(), Type: model.JobTypeLegalHoldExport}
+
+		s.client.
+			EXPECT().
+			ExportLegalHold(context.TODO(), holdID, model.ComplianceExportTypeCsv).
+			Return(job, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("type", model.ComplianceExportTypeCsv, "")
+
+		err := legalHoldExportCmdF(s.client, cmd, []string{holdID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Equal(job, printer.GetLines()[0].(*model.Job))
+	})
+}
@@ -5,12 +5,14 @@ package commands
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/mattermost/mattermost/server/public/model"
 
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -86,6 +88,26 @@ var DeleteWebhookCmd = &cobra.Command{
 	RunE:    withClient(deleteWebhookCmdF),
 }
 
+var ListWebhookDeliveriesCmd = &cobra.Command{
+	Use:   "deliveries [webhookId]",
+	Short: "List outgoing webhook deliveries",
+	Long:  "List the delivery history of the outgoing webhook specified by [webhookId], most recent first",
+	Args:  cobra.ExactArgs(1),
+	Example: `  webhook deliveries w16zb5tu3n1zkqo18goqry1je
+  webhook deliveries w16zb5tu3n1zkqo18goqry1je --status failed --page 1 --per-page 20`,
+	RunE: withClient(listWebhookDeliveriesCmdF),
+}
+
+var ReplayWebhookDeliveryCmd = &cobra.Command{
+	Use:   "replay [webhookId] [deliveryIds...]",
+	Short: "Replay failed outgoing webhook deliveries",
+	Long:  "Attempt the given failed deliveries of the outgoing webhook specified by [webhookId] again",
+	Args:  cobra.MinimumNArgs(1),
+	Example: `  webhook replay w16zb5tu3n1zkqo18goqry1je ktauj4u5fpbkpxzmqsjawgywdc
+  webhook replay w16zb5tu3n1zkqo18goqry1je --all-failed`,
+	RunE: withClient(replayWebhookDeliveryCmdF),
+}
+
 func listWebhookCmdF(c client.Client, command *cobra.Command, args []string) error {
 	var teams []*model.Team
 
@@ -426,6 +448,86 @@ func showWebhookCmdF(c client.Client, command *cobra.Command, args []string) err
 	return errors.New("Webhook with id '" + webhookID + "' not found")
 }
 
+func printWebhookDelivery(delivery *model.OutgoingWebhookDelivery) {
+	printer.PrintT("{{.Id}}\t{{.Status}}\tattempts: {{.Attempts}}\tstatus code: {{.StatusCode}}\tlatency: {{.Latency}}ms\t{{.CallbackURL}}", delivery)
+}
+
+func listWebhookDeliveriesCmdF(c client.Client, command *cobra.Command, args []string) error {
+	status, err := command.Flags().GetString("status")
+	if err != nil {
+		return err
+	}
+	page, err := command.Flags().GetInt("page")
+	if err != nil {
+		return err
+	}
+	perPage, err := command.Flags().GetInt("per-page")
+	if err != nil {
+		return err
+	}
+
+	switch status {
+	case "", model.OutgoingWebhookDeliveryStatusPending, model.OutgoingWebhookDeliveryStatusSuccess, model.OutgoingWebhookDeliveryStatusFailed:
+	default:
+		return fmt.Errorf("invalid delivery status: %s", status)
+	}
+
+	deliveries, _, err := c.GetOutgoingWebhookDeliveries(context.TODO(), args[0], status, page, perPage)
+	if err != nil {
+		return fmt.Errorf("unable to get deliveries for webhook %s: %w", args[0], err)
+	}
+
+	if len(deliveries) == 0 {
+		printer.Print("No deliveries found")
+		return nil
+	}
+
+	for _, delivery := range deliveries {
+		printWebhookDelivery(delivery)
+	}
+
+	return nil
+}
+
+func replayWebhookDeliveryCmdF(c client.Client, command *cobra.Command, args []string) error {
+	webhookID := args[0]
+	deliveryIDs := args[1:]
+
+	allFailed, err := command.Flags().GetBool("all-failed")
+	if err != nil {
+		return err
+	}
+
+	if allFailed == (len(deliveryIDs) > 0) {
+		return errors.New("either delivery IDs or the --all-failed flag must be specified")
+	}
+
+	if allFailed {
+		failed, err := getPages(func(page, numPerPage int, etag string) ([]*model.OutgoingWebhookDelivery, *model.Response, error) {
+			return c.GetOutgoingWebhookDeliveries(context.TODO(), webhookID, model.OutgoingWebhookDeliveryStatusFailed, page, numPerPage)
+		}, DefaultPageSize)
+		if err != nil {
+			return fmt.Errorf("unable to get failed deliveries for webhook %s: %w", webhookID, err)
+		}
+		for _, delivery := range failed {
+			deliveryIDs = append(deliveryIDs, delivery.Id)
+		}
+	}
+
+	var errs *multierror.Error
+	for _, deliveryID := range deliveryIDs {
+		delivery, _, err := c.ReplayOutgoingWebhookDelivery(context.TODO(), webhookID, deliveryID)
+		if err != nil {
+			printer.PrintError("Unable to replay delivery '" + deliveryID + "': " + err.Error())
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		printWebhookDelivery(delivery)
+	}
+
+	return errs.ErrorOrNil()
+}
+
 func init() {
 	CreateIncomingWebhookCmd.Flags().String("channel", "", "Channel ID (required)")
 	_ = CreateIncomingWebhookCmd.MarkFlagRequired("channel")
@@ -467,6 +569,12 @@ func init() {
 	ModifyOutgoingWebhookCmd.Flags().StringArray("url", []string{}, "Callback URL")
 	ModifyOutgoingWebhookCmd.Flags().String("content-type", "", "Content-type")
 
+	ListWebhookDeliveriesCmd.Flags().String("status", "", "Only list deliveries with the given status (pending, success or failed)")
+	ListWebhookDeliveriesCmd.Flags().Int("page", 0, "Page number to fetch for the list of deliveries")
+	ListWebhookDeliveriesCmd.Flags().Int("per-page", DefaultPageSize, "Number of deliveries to be fetched")
+
+	ReplayWebhookDeliveryCmd.Flags().Bool("all-failed", false, "Replay every failed delivery of the webhook")
+
 	WebhookCmd.AddCommand(
 		ListWebhookCmd,
 		CreateIncomingWebhookCmd,
@@ -475,6 +583,8 @@ func init() {
 		ModifyOutgoingWebhookCmd,
 		DeleteWebhookCmd,
 		ShowWebhookCmd,
+		ListWebhookDeliveriesCmd,
+		ReplayWebhookDeliveryCmd,
 	)
 
 	RootCmd.AddCommand(WebhookCmd)
@@ -788,3 +788,142 @@ func (s *MmctlUnitTestSuite) TestShowWebhookCmd() {
 		s.Require().Equal("Webhook with id '"+nonExistentID+"' not found", err.Error())
 	})
 }
+
+func (s *MmctlUnitTestSuite) TestListWebhookDeliveriesCmd() {
+	webhookID := model.NewId()
+
+	s.Run("List failed deliveries", func() {
+		printer.Clean()
+
+		mockDelivery := &model.OutgoingWebhookDelivery{Id: model.NewId(), HookId: webhookID, Status: model.OutgoingWebhookDeliveryStatusFailed}
+
+		s.client.
+			EXPECT().
+			GetOutgoingWebhookDeliveries(context.TODO(), webhookID, model.OutgoingWebhookDeliveryStatusFailed, 1, 20).
+			Return([]*model.OutgoingWebhookDelivery{mockDelivery}, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("status", model.OutgoingWebhookDeliveryStatusFailed, "")
+		cmd.Flags().Int("page", 1, "")
+		cmd.Flags().Int("per-page", 20, "")
+
+		err := listWebhookDeliveriesCmdF(s.client, cmd, []string{webhookID})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Len(printer.GetErrorLines(), 0)
+		s.Require().Equal(mockDelivery, printer.GetLines()[0])
+	})
+
+	s.Run("Invalid status", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("status", "unknown", "")
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", 20, "")
+
+		err := listWebhookDeliveriesCmdF(s.client, cmd, []string{webhookID})
+		s.Require().Error(err)
+		s.Len(printer.GetLines(), 0)
+	})
+
+	s.Run("Error when getting deliveries", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetOutgoingWebhookDeliveries(context.TODO(), webhookID, "", 0, 20).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("status", "", "")
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", 20, "")
+
+		err := listWebhookDeliveriesCmdF(s.client, cmd, []string{webhookID})
+		s.Require().Error(err)
+		s.Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestReplayWebhookDeliveryCmd() {
+	webhookID := model.NewId()
+
+	s.Run("Replay the given deliveries", func() {
+		printer.Clean()
+
+		deliveryID := model.NewId()
+		mockDelivery := &model.OutgoingWebhookDelivery{Id: deliveryID, HookId: webhookID, Status: model.OutgoingWebhookDeliveryStatusSuccess}
+
+		s.client.
+			EXPECT().
+			ReplayOutgoingWebhookDelivery(context.TODO(), webhookID, deliveryID).
+			Return(mockDelivery, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("all-failed", false, "")
+
+		err := replayWebhookDeliveryCmdF(s.client, cmd, []string{webhookID, deliveryID})
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Len(printer.GetErrorLines(), 0)
+		s.Require().Equal(mockDelivery, printer.GetLines()[0])
+	})
+
+	s.Run("Replay all failed deliveries", func() {
+		printer.Clean()
+
+		failed := []*model.OutgoingWebhookDelivery{
+			{Id: model.NewId(), HookId: webhookID, Status: model.OutgoingWebhookDeliveryStatusFailed},
+			{Id: model.NewId(), HookId: webhookID, Status: model.OutgoingWebhookDeliveryStatusFailed},
+		}
+
+		s.client.
+			EXPECT().
+			GetOutgoingWebhookDeliveries(context.TODO(), webhookID, model.OutgoingWebhookDeliveryStatusFailed, 0, DefaultPageSize).
+			Return(failed, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetOutgoingWebhookDeliveries(context.TODO(), webhookID, model.OutgoingWebhookDeliveryStatusFailed, 1, DefaultPageSize).
+			Return([]*model.OutgoingWebhookDelivery{}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			ReplayOutgoingWebhookDelivery(context.TODO(), webhookID, failed[0].Id).
+			Return(failed[0], &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			ReplayOutgoingWebhookDelivery(context.TODO(), webhookID, failed[1].Id).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("all-failed", true, "")
+
+		err := replayWebhookDeliveryCmdF(s.client, cmd, []string{webhookID})
+		s.Require().Error(err)
+		s.Len(printer.GetLines(), 1)
+		s.Len(printer.GetErrorLines(), 1)
+	})
+
+	s.Run("Either delivery IDs or --all-failed are required", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("all-failed", false, "")
+
+		err := replayWebhookDeliveryCmdF(s.client, cmd, []string{webhookID})
+		s.Require().Error(err)
+
+		cmd = &cobra.Command{}
+		cmd.Flags().Bool("all-failed", true, "")
+
+		err = replayWebhookDeliveryCmdF(s.client, cmd, []string{webhookID, model.NewId()})
+		s.Require().Error(err)
+	})
+}
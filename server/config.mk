@@ -4,8 +4,8 @@
 
 # Enable services to be run in docker.
 #
-# Possible options: mysql, postgres, minio, inbucket, openldap, dejavu,
-# keycloak, elasticsearch, opensearch, redis, prometheus,
+# Possible options: mysql, postgres, minio, azurite, fakegcs, inbucket,
+# openldap, dejavu, keycloak, elasticsearch, opensearch, redis, prometheus,
 # grafana, loki and promtail.
 #
 # Must be space separated names.
@@ -200,7 +200,7 @@ func parseDSN(dsn string) (string, string, error) {
 
 // Set replaces the current configuration in its entirety and updates the backing store.
 func (ds *DatabaseStore) Set(newCfg *model.Config) error {
-	return ds.persist(newCfg)
+	return ds.persist(newCfg, nil)
 }
 
 // maxLength identifies the maximum length of a configuration or configuration file
@@ -212,8 +212,9 @@ func (ds *DatabaseStore) checkLength(length int) error {
 	return nil
 }
 
-// persist writes the configuration to the configured database.
-func (ds *DatabaseStore) persist(cfg *model.Config) error {
+// persist writes the configuration to the configured database, recording the
+// author of the change when given.
+func (ds *DatabaseStore) persist(cfg *model.Config, author *model.ConfigChangeAuthor) error {
 	b, err := marshalConfig(cfg)
 	if err != nil {
 		return errors.Wrap(err, "failed to serialize")
@@ -286,14 +287,22 @@ func (ds *DatabaseStore) persist(cfg *model.Config) error {
 	}
 
 	params := map[string]any{
-		"id":        model.NewId(),
-		"value":     value,
-		"create_at": model.GetMillis(),
-		"key":       "ConfigurationId",
-		"sha":       hex.EncodeToString(sum[0:]),
-	}
-
-	if _, err := tx.NamedExec("INSERT INTO Configurations (Id, Value, CreateAt, Active, SHA) VALUES (:id, :value, :create_at, TRUE, :sha)", params); err != nil {
+		"id":         model.NewId(),
+		"value":      value,
+		"create_at":  model.GetMillis(),
+		"key":        "ConfigurationId",
+		"sha":        hex.EncodeToString(sum[0:]),
+		"user_id":    "",
+		"session_id": "",
+		"source":     "",
+	}
+	if author != nil {
+		params["user_id"] = author.UserId
+		params["session_id"] = author.SessionId
+		params["source"] = author.Source
+	}
+
+	if _, err := tx.NamedExec("INSERT INTO Configurations (Id, Value, CreateAt, Active, SHA, UserId, SessionId, Source) VALUES (:id, :value, :create_at, TRUE, :sha, :user_id, :session_id, :source)", params); err != nil {
 		return errors.Wrap(err, "failed to record new configuration")
 	}
 
@@ -426,3 +435,93 @@ func (ds *DatabaseStore) cleanUp(thresholdCreatAt int) error {
 
 	return nil
 }
+
+// configVersionColumns lists the columns describing a version of the configuration.
+const configVersionColumns = "Id, CreateAt, COALESCE(Active, FALSE), UserId, SessionId, Source"
+
+func scanConfigVersion(row interface{ Scan(...any) error }, dest ...any) (*model.ConfigVersion, error) {
+	var version model.ConfigVersion
+	if err := row.Scan(append([]any{&version.Id, &version.CreateAt, &version.Active, &version.UserId, &version.SessionId, &version.Source}, dest...)...); err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// getHistory returns the versions of the configuration kept in the database,
+// newest first.
+func (ds *DatabaseStore) getHistory(offset, limit int) ([]*model.ConfigVersion, error) {
+	query, args, err := sqlx.Named("SELECT "+configVersionColumns+" FROM Configurations ORDER BY CreateAt DESC, Id DESC LIMIT :limit OFFSET :offset", map[string]any{
+		"limit":  limit,
+		"offset": offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ds.db.Query(ds.db.Rebind(query), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query configuration history")
+	}
+	defer rows.Close()
+
+	versions := []*model.ConfigVersion{}
+	for rows.Next() {
+		version, err := scanConfigVersion(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan configuration version")
+		}
+		versions = append(versions, version)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate configuration history")
+	}
+
+	return versions, nil
+}
+
+// getVersion returns a version of the configuration along with its value.
+func (ds *DatabaseStore) getVersion(id string) (*model.ConfigVersion, *model.Config, error) {
+	query, args, err := sqlx.Named("SELECT "+configVersionColumns+", Value FROM Configurations WHERE Id = :id", map[string]any{
+		"id": id,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var value []byte
+	version, err := scanConfigVersion(ds.db.QueryRow(ds.db.Rebind(query), args...), &value)
+	if err == sql.ErrNoRows {
+		return nil, nil, ErrConfigVersionNotFound
+	} else if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to query configuration version %s", id)
+	}
+
+	var cfg *model.Config
+	if err = json.Unmarshal(value, &cfg); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to unmarshal configuration version %s", id)
+	}
+
+	return version, cfg, nil
+}
+
+// getPreviousVersionId returns the id of the version of the configuration
+// that preceded the given one.
+func (ds *DatabaseStore) getPreviousVersionId(id string) (string, error) {
+	query, args, err := sqlx.Named(`SELECT c.Id FROM Configurations c, Configurations v
+		WHERE v.Id = :id AND (c.CreateAt < v.CreateAt OR (c.CreateAt = v.CreateAt AND c.Id < v.Id))
+		ORDER BY c.CreateAt DESC, c.Id DESC LIMIT 1`, map[string]any{
+		"id": id,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var previousId string
+	if err = ds.db.QueryRow(ds.db.Rebind(query), args...).Scan(&previousId); err == sql.ErrNoRows {
+		return "", ErrConfigVersionNotFound
+	} else if err != nil {
+		return "", errors.Wrapf(err, "failed to query the configuration version preceding %s", id)
+	}
+
+	return previousId, nil
+}
@@ -876,7 +876,7 @@ func TestDatabaseStoreLoad(t *testing.T) {
 		newCfg := minimalConfig.Clone()
 		dbStore, ok := ds.backingStore.(*DatabaseStore)
 		require.True(t, ok)
-		err = dbStore.persist(newCfg)
+		err = dbStore.persist(newCfg, nil)
 		require.NoError(t, err)
 
 		err = ds.Load()
@@ -1159,3 +1159,69 @@ func TestCleanUp(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, count+3 == initialCount)
 }
+
+func TestDatabaseStoreHistory(t *testing.T) {
+	activeID, tearDown := setupConfigDatabase(t, minimalConfig, nil)
+	defer tearDown()
+
+	ds, err := newTestDatabaseStore(nil)
+	require.NoError(t, err)
+	defer ds.Close()
+
+	author := &model.ConfigChangeAuthor{
+		UserId:    model.NewId(),
+		SessionId: model.NewId(),
+		Source:    model.ConfigChangeSourceSystemConsole,
+	}
+	newCfg := ds.Get().Clone()
+	newCfg.ServiceSettings.SiteURL = model.NewPointer("http://history")
+	_, _, err = ds.SetWithAuthor(newCfg, author)
+	require.NoError(t, err)
+
+	t.Run("get history", func(t *testing.T) {
+		versions, err := ds.GetHistory(0, 10)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(versions), 2)
+
+		assert.True(t, versions[0].Active)
+		assert.Equal(t, author.UserId, versions[0].UserId)
+		assert.Equal(t, author.SessionId, versions[0].SessionId)
+		assert.Equal(t, author.Source, versions[0].Source)
+		for _, version := range versions[1:] {
+			assert.False(t, version.Active)
+		}
+
+		versions, err = ds.GetHistory(0, 1)
+		require.NoError(t, err)
+		require.Len(t, versions, 1)
+	})
+
+	t.Run("get version", func(t *testing.T) {
+		versions, err := ds.GetHistory(0, 1)
+		require.NoError(t, err)
+		require.Len(t, versions, 1)
+
+		version, cfg, err := ds.GetVersion(versions[0].Id)
+		require.NoError(t, err)
+		assert.Equal(t, versions[0], version)
+		assert.Equal(t, "http://history", *cfg.ServiceSettings.SiteURL)
+
+		_, _, err = ds.GetVersion(model.NewId())
+		assert.ErrorIs(t, err, ErrConfigVersionNotFound)
+	})
+
+	t.Run("get previous version", func(t *testing.T) {
+		versions, err := ds.GetHistory(0, 10)
+		require.NoError(t, err)
+
+		previousID, err := ds.GetPreviousVersionId(versions[0].Id)
+		require.NoError(t, err)
+		assert.Equal(t, versions[1].Id, previousID)
+
+		_, err = ds.GetPreviousVersionId(versions[len(versions)-1].Id)
+		assert.ErrorIs(t, err, ErrConfigVersionNotFound)
+
+		_, _, err = ds.GetVersion(activeID)
+		require.NoError(t, err)
+	})
+}
@@ -40,6 +40,10 @@ var configSensitivePaths = map[string]bool{
 	"LdapSettings.BindPassword":                              true,
 	"FileSettings.PublicLinkSalt":                            true,
 	"FileSettings.AmazonS3SecretAccessKey":                   true,
+	"FileSettings.AzureAccessKey":                            true,
+	"FileSettings.GCSCredentialsJSON":                        true,
+	"FileSettings.EncryptionAtRestMasterKey":                 true,
+	"FileSettings.EncryptionAtRestRetiredKeys":               true,
 	"SqlSettings.DataSource":                                 true,
 	"SqlSettings.AtRestEncryptKey":                           true,
 	"SqlSettings.DataSourceReplicas":                         true,
@@ -54,6 +58,7 @@ var configSensitivePaths = map[string]bool{
 	"MessageExportSettings.GlobalRelaySettings.SMTPPassword": true,
 	"MessageExportSettings.GlobalRelaySettings.EmailAddress": true,
 	"ServiceSettings.SplitKey":                               true,
+	"ScimSettings.BearerToken":                               true,
 	"PluginSettings.Plugins":                                 true,
 }
 
@@ -0,0 +1,3 @@
+ALTER TABLE Configurations DROP COLUMN IF EXISTS Source;
+ALTER TABLE Configurations DROP COLUMN IF EXISTS SessionId;
+ALTER TABLE Configurations DROP COLUMN IF EXISTS UserId;
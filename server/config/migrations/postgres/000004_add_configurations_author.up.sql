@@ -0,0 +1,3 @@
+ALTER TABLE Configurations ADD COLUMN IF NOT EXISTS UserId VARCHAR(26) DEFAULT '';
+ALTER TABLE Configurations ADD COLUMN IF NOT EXISTS SessionId VARCHAR(26) DEFAULT '';
+ALTER TABLE Configurations ADD COLUMN IF NOT EXISTS Source VARCHAR(64) DEFAULT '';
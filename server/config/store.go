@@ -20,6 +20,14 @@ var (
 	// ErrReadOnlyStore is returned when an attempt to modify a read-only
 	// configuration store is made.
 	ErrReadOnlyStore = errors.New("configuration store is read-only")
+
+	// ErrConfigHistoryNotSupported is returned when the history of the
+	// configuration is requested from a store that does not keep it.
+	ErrConfigHistoryNotSupported = errors.New("configuration history is only kept by database backed configuration stores")
+
+	// ErrConfigVersionNotFound is returned when a version of the configuration
+	// is not kept by the store.
+	ErrConfigVersionNotFound = errors.New("configuration version not found")
 )
 
 // Store is the higher level object that handles storing and retrieval of config data.
@@ -166,6 +174,12 @@ func (s *Store) SetReadOnlyFF(readOnly bool) {
 // Set replaces the current configuration in its entirety and updates the backing store.
 // It returns both old and new versions of the config.
 func (s *Store) Set(newCfg *model.Config) (*model.Config, *model.Config, error) {
+	return s.SetWithAuthor(newCfg, &model.ConfigChangeAuthor{Source: model.ConfigChangeSourceServer})
+}
+
+// SetWithAuthor behaves like Set, recording the author of the change in the
+// history of database backed stores.
+func (s *Store) SetWithAuthor(newCfg *model.Config, author *model.ConfigChangeAuthor) (*model.Config, *model.Config, error) {
 	s.configLock.Lock()
 	defer s.configLock.Unlock()
 
@@ -210,7 +224,7 @@ func (s *Store) Set(newCfg *model.Config) (*model.Config, *model.Config, error)
 		newCfgNoEnv.FeatureFlags = nil
 	}
 
-	if err := s.backingStore.Set(newCfgNoEnv); err != nil {
+	if err := s.persist(newCfgNoEnv, author); err != nil {
 		return nil, nil, errors.Wrap(err, "failed to persist")
 	}
 
@@ -318,7 +332,7 @@ func (s *Store) Load() error {
 	// We write back to the backing store only if the store is not read-only
 	// and the config has either changed or is missing.
 	if !s.readOnly && (hasChanged || len(configBytes) == 0) {
-		err := s.backingStore.Set(loadedCfgNoEnv)
+		err := s.persist(loadedCfgNoEnv, &model.ConfigChangeAuthor{Source: model.ConfigChangeSourceEnvironment})
 		if err != nil && !errors.Is(err, ErrReadOnlyConfiguration) {
 			return errors.Wrap(err, "failed to persist")
 		}
@@ -345,6 +359,15 @@ func (s *Store) Load() error {
 	return nil
 }
 
+// persist writes the configuration to the backing store. Database backed
+// stores also record the author of the change.
+func (s *Store) persist(cfg *model.Config, author *model.ConfigChangeAuthor) error {
+	if ds, ok := s.backingStore.(*DatabaseStore); ok {
+		return ds.persist(cfg, author)
+	}
+	return s.backingStore.Set(cfg)
+}
+
 // GetFile fetches the contents of a previously persisted configuration file.
 // If no such file exists, an empty byte array will be returned without error.
 func (s *Store) GetFile(name string) ([]byte, error) {
@@ -411,3 +434,43 @@ func (s *Store) CleanUp() error {
 		return nil
 	}
 }
+
+// GetHistory returns a page of the versions of the configuration kept by the
+// store, newest first.
+func (s *Store) GetHistory(page, perPage int) ([]*model.ConfigVersion, error) {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+
+	ds, ok := s.backingStore.(*DatabaseStore)
+	if !ok {
+		return nil, ErrConfigHistoryNotSupported
+	}
+	return ds.getHistory(page*perPage, perPage)
+}
+
+// GetVersion returns a version of the configuration kept by the store, along
+// with its value. The value does not include environment overrides.
+func (s *Store) GetVersion(id string) (*model.ConfigVersion, *model.Config, error) {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+
+	ds, ok := s.backingStore.(*DatabaseStore)
+	if !ok {
+		return nil, nil, ErrConfigHistoryNotSupported
+	}
+	return ds.getVersion(id)
+}
+
+// GetPreviousVersionId returns the id of the version of the configuration
+// that preceded the given one, or ErrConfigVersionNotFound when the store no
+// longer keeps it.
+func (s *Store) GetPreviousVersionId(id string) (string, error) {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+
+	ds, ok := s.backingStore.(*DatabaseStore)
+	if !ok {
+		return "", ErrConfigHistoryNotSupported
+	}
+	return ds.getPreviousVersionId(id)
+}
@@ -8,7 +8,10 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
 )
 
 func TestNewStoreFromDSN(t *testing.T) {
@@ -101,3 +104,17 @@ func TestNewStoreReadOnly(t *testing.T) {
 		fs.Close()
 	})
 }
+
+func TestStoreHistoryNotSupported(t *testing.T) {
+	ms := NewTestMemoryStore()
+	defer ms.Close()
+
+	_, err := ms.GetHistory(0, 10)
+	assert.ErrorIs(t, err, ErrConfigHistoryNotSupported)
+
+	_, _, err = ms.GetVersion(model.NewId())
+	assert.ErrorIs(t, err, ErrConfigHistoryNotSupported)
+
+	_, err = ms.GetPreviousVersionId(model.NewId())
+	assert.ErrorIs(t, err, ErrConfigHistoryNotSupported)
+}
@@ -33,6 +33,22 @@ func desanitize(actual, target *model.Config) {
 	if *target.FileSettings.AmazonS3SecretAccessKey == model.FakeSetting {
 		target.FileSettings.AmazonS3SecretAccessKey = actual.FileSettings.AmazonS3SecretAccessKey
 	}
+	if *target.FileSettings.AzureAccessKey == model.FakeSetting {
+		target.FileSettings.AzureAccessKey = actual.FileSettings.AzureAccessKey
+	}
+	if *target.FileSettings.GCSCredentialsJSON == model.FakeSetting {
+		target.FileSettings.GCSCredentialsJSON = actual.FileSettings.GCSCredentialsJSON
+	}
+	if *target.FileSettings.EncryptionAtRestMasterKey == model.FakeSetting {
+		target.FileSettings.EncryptionAtRestMasterKey = actual.FileSettings.EncryptionAtRestMasterKey
+	}
+	if len(target.FileSettings.EncryptionAtRestRetiredKeys) == len(actual.FileSettings.EncryptionAtRestRetiredKeys) {
+		for i, value := range target.FileSettings.EncryptionAtRestRetiredKeys {
+			if value == model.FakeSetting {
+				target.FileSettings.EncryptionAtRestRetiredKeys[i] = actual.FileSettings.EncryptionAtRestRetiredKeys[i]
+			}
+		}
+	}
 
 	if *target.EmailSettings.SMTPPassword == model.FakeSetting {
 		target.EmailSettings.SMTPPassword = actual.EmailSettings.SMTPPassword
@@ -88,6 +104,10 @@ func desanitize(actual, target *model.Config) {
 	if *target.ServiceSettings.SplitKey == model.FakeSetting {
 		*target.ServiceSettings.SplitKey = *actual.ServiceSettings.SplitKey
 	}
+
+	if *target.ScimSettings.BearerToken == model.FakeSetting {
+		target.ScimSettings.BearerToken = actual.ScimSettings.BearerToken
+	}
 }
 
 // fixConfig patches invalid or missing data in the configuration.
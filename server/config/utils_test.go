@@ -25,6 +25,10 @@ func TestDesanitize(t *testing.T) {
 	actual.LdapSettings.BindPassword = model.NewPointer("bind_password")
 	actual.FileSettings.PublicLinkSalt = model.NewPointer("public_link_salt")
 	actual.FileSettings.AmazonS3SecretAccessKey = model.NewPointer("amazon_s3_secret_access_key")
+	actual.FileSettings.AzureAccessKey = model.NewPointer("azure_access_key")
+	actual.FileSettings.GCSCredentialsJSON = model.NewPointer("gcs_credentials_json")
+	actual.FileSettings.EncryptionAtRestMasterKey = model.NewPointer("encryption_at_rest_master_key")
+	actual.FileSettings.EncryptionAtRestRetiredKeys = []string{"encryption_at_rest_retired_key"}
 	actual.EmailSettings.SMTPPassword = model.NewPointer("smtp_password")
 	actual.GitLabSettings.Secret = model.NewPointer("secret")
 	actual.OpenIdSettings.Secret = model.NewPointer("secret")
@@ -35,6 +39,7 @@ func TestDesanitize(t *testing.T) {
 	actual.SqlSettings.DataSourceReplicas = append(actual.SqlSettings.DataSourceReplicas, "replica1")
 	actual.SqlSettings.DataSourceSearchReplicas = append(actual.SqlSettings.DataSourceSearchReplicas, "search_replica0")
 	actual.SqlSettings.DataSourceSearchReplicas = append(actual.SqlSettings.DataSourceSearchReplicas, "search_replica1")
+	actual.ScimSettings.BearerToken = model.NewPointer("scim_bearer_token")
 
 	target := &model.Config{}
 	target.SetDefaults()
@@ -47,6 +52,10 @@ func TestDesanitize(t *testing.T) {
 	target.LdapSettings.BindPassword = model.NewPointer(model.FakeSetting)
 	target.FileSettings.PublicLinkSalt = model.NewPointer(model.FakeSetting)
 	target.FileSettings.AmazonS3SecretAccessKey = model.NewPointer(model.FakeSetting)
+	target.FileSettings.AzureAccessKey = model.NewPointer(model.FakeSetting)
+	target.FileSettings.GCSCredentialsJSON = model.NewPointer(model.FakeSetting)
+	target.FileSettings.EncryptionAtRestMasterKey = model.NewPointer(model.FakeSetting)
+	target.FileSettings.EncryptionAtRestRetiredKeys = []string{model.FakeSetting}
 	target.EmailSettings.SMTPPassword = model.NewPointer(model.FakeSetting)
 	target.GitLabSettings.Secret = model.NewPointer(model.FakeSetting)
 	target.OpenIdSettings.Secret = model.NewPointer(model.FakeSetting)
@@ -55,6 +64,7 @@ func TestDesanitize(t *testing.T) {
 	target.ElasticsearchSettings.Password = model.NewPointer(model.FakeSetting)
 	target.SqlSettings.DataSourceReplicas = []string{model.FakeSetting, model.FakeSetting}
 	target.SqlSettings.DataSourceSearchReplicas = []string{model.FakeSetting, model.FakeSetting}
+	target.ScimSettings.BearerToken = model.NewPointer(model.FakeSetting)
 
 	actualClone := actual.Clone()
 	desanitize(actual, target)
@@ -68,6 +78,10 @@ func TestDesanitize(t *testing.T) {
 	assert.Equal(t, *actual.LdapSettings.BindPassword, *target.LdapSettings.BindPassword)
 	assert.Equal(t, *actual.FileSettings.PublicLinkSalt, *target.FileSettings.PublicLinkSalt)
 	assert.Equal(t, *actual.FileSettings.AmazonS3SecretAccessKey, *target.FileSettings.AmazonS3SecretAccessKey)
+	assert.Equal(t, *actual.FileSettings.AzureAccessKey, *target.FileSettings.AzureAccessKey)
+	assert.Equal(t, *actual.FileSettings.GCSCredentialsJSON, *target.FileSettings.GCSCredentialsJSON)
+	assert.Equal(t, *actual.FileSettings.EncryptionAtRestMasterKey, *target.FileSettings.EncryptionAtRestMasterKey)
+	assert.Equal(t, actual.FileSettings.EncryptionAtRestRetiredKeys, target.FileSettings.EncryptionAtRestRetiredKeys)
 	assert.Equal(t, *actual.EmailSettings.SMTPPassword, *target.EmailSettings.SMTPPassword)
 	assert.Equal(t, *actual.GitLabSettings.Secret, *target.GitLabSettings.Secret)
 	assert.Equal(t, *actual.OpenIdSettings.Secret, *target.OpenIdSettings.Secret)
@@ -77,6 +91,7 @@ func TestDesanitize(t *testing.T) {
 	assert.Equal(t, actual.SqlSettings.DataSourceReplicas, target.SqlSettings.DataSourceReplicas)
 	assert.Equal(t, actual.SqlSettings.DataSourceSearchReplicas, target.SqlSettings.DataSourceSearchReplicas)
 	assert.Equal(t, actual.ServiceSettings.SplitKey, target.ServiceSettings.SplitKey)
+	assert.Equal(t, *actual.ScimSettings.BearerToken, *target.ScimSettings.BearerToken)
 }
 
 func TestFixInvalidLocales(t *testing.T) {
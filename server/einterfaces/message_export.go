@@ -11,4 +11,8 @@ import (
 type MessageExportInterface interface {
 	StartSynchronizeJob(c request.CTX, exportFromTimestamp int64) (*model.Job, *model.AppError)
 	RunExport(c request.CTX, format string, since int64, limit int) (int64, *model.AppError)
+	// RunLegalHoldExport exports the posts held by the legal hold in the given
+	// format, and returns the directory of the export and the number of posts
+	// exported.
+	RunLegalHoldExport(c request.CTX, legalHoldID string, format string) (string, int64, *model.AppError)
 }
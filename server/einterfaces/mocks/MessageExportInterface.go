@@ -45,6 +45,43 @@ func (_m *MessageExportInterface) RunExport(c request.CTX, format string, since
 	return r0, r1
 }
 
+// RunLegalHoldExport provides a mock function with given fields: c, legalHoldID, format
+func (_m *MessageExportInterface) RunLegalHoldExport(c request.CTX, legalHoldID string, format string) (string, int64, *model.AppError) {
+	ret := _m.Called(c, legalHoldID, format)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunLegalHoldExport")
+	}
+
+	var r0 string
+	var r1 int64
+	var r2 *model.AppError
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string) (string, int64, *model.AppError)); ok {
+		return rf(c, legalHoldID, format)
+	}
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string) string); ok {
+		r0 = rf(c, legalHoldID, format)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(request.CTX, string, string) int64); ok {
+		r1 = rf(c, legalHoldID, format)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(request.CTX, string, string) *model.AppError); ok {
+		r2 = rf(c, legalHoldID, format)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*model.AppError)
+		}
+	}
+
+	return r0, r1, r2
+}
+
 // StartSynchronizeJob provides a mock function with given fields: c, exportFromTimestamp
 func (_m *MessageExportInterface) StartSynchronizeJob(c request.CTX, exportFromTimestamp int64) (*model.Job, *model.AppError) {
 	ret := _m.Called(c, exportFromTimestamp)
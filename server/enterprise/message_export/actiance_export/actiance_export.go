@@ -75,11 +75,12 @@ type LeaveExport struct {
 // The Message element indicates the message sent by a user
 type PostExport struct {
 	XMLName      xml.Name `xml:"Message"`
-	UserEmail    string   `xml:"LoginName"`    // the email of the person that sent the post
-	UserType     string   `xml:"UserType"`     // the type of the person that sent the post
-	PostTime     int64    `xml:"DateTimeUTC"`  // utc timestamp (seconds), time at which the user sent the post. Example: 1366611728
-	Message      string   `xml:"Content"`      // the text body of the post
-	PreviewsPost string   `xml:"PreviewsPost"` // the post id of the post that is previewed by the permalink preview feature
+	UserEmail    string   `xml:"LoginName"`              // the email of the person that sent the post
+	UserType     string   `xml:"UserType"`               // the type of the person that sent the post
+	PostTime     int64    `xml:"DateTimeUTC"`            // utc timestamp (seconds), time at which the user sent the post. Example: 1366611728
+	Message      string   `xml:"Content"`                // the text body of the post
+	PreviewsPost string   `xml:"PreviewsPost"`           // the post id of the post that is previewed by the permalink preview feature
+	EditedByPost string   `xml:"EditedByPost,omitempty"` // the post id of the post whose edit replaced this message, set on edit records only
 }
 
 // The FileTransferStarted element indicates the beginning of a file transfer in a conversation
@@ -121,6 +122,12 @@ func ActianceExport(rctx request.CTX, posts []*model.MessageExport, db store.Sto
 		}
 		elementsByChannel[*post.ChannelId] = append(elementsByChannel[*post.ChannelId], postToExportEntry(post, post.PostCreateAt, *post.PostMessage))
 
+		if common_export.IsEditRevision(post) {
+			editEntry := postToExportEntry(post, post.PostUpdateAt, "edit "+*post.PostMessage)
+			editEntry.EditedByPost = *post.PostOriginalId
+			elementsByChannel[*post.ChannelId] = append(elementsByChannel[*post.ChannelId], editEntry)
+		}
+
 		if post.PostDeleteAt != nil && *post.PostDeleteAt > 0 && post.PostProps != nil {
 			props := map[string]any{}
 			if json.Unmarshal([]byte(*post.PostProps), &props) == nil {
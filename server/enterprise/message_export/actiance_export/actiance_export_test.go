@@ -227,6 +227,14 @@ func runTestActianceExport(t *testing.T, exportBackend filestore.FileBackend, at
 				"    <Message>\n",
 				"      <LoginName>test@test.com</LoginName>\n",
 				"      <UserType>user</UserType>\n",
+				"      <DateTimeUTC>2</DateTimeUTC>\n",
+				"      <Content>edit edit message</Content>\n",
+				"      <PreviewsPost></PreviewsPost>\n",
+				"      <EditedByPost>post-original-id</EditedByPost>\n",
+				"    </Message>\n",
+				"    <Message>\n",
+				"      <LoginName>test@test.com</LoginName>\n",
+				"      <UserType>user</UserType>\n",
 				"      <DateTimeUTC>1</DateTimeUTC>\n",
 				"      <Content>message</Content>\n",
 				"      <PreviewsPost></PreviewsPost>\n",
@@ -834,6 +842,14 @@ func runTestMultipleActianceExport(t *testing.T, exportBackend filestore.FileBac
 					"      <Content>edit message</Content>\n",
 					"      <PreviewsPost></PreviewsPost>\n",
 					"    </Message>\n",
+					"    <Message>\n",
+					"      <LoginName>test@test.com</LoginName>\n",
+					"      <UserType>user</UserType>\n",
+					"      <DateTimeUTC>2</DateTimeUTC>\n",
+					"      <Content>edit edit message</Content>\n",
+					"      <PreviewsPost></PreviewsPost>\n",
+					"      <EditedByPost>post-original-id</EditedByPost>\n",
+					"    </Message>\n",
 					"    <ParticipantLeft>\n",
 					"      <LoginName>test@test.com</LoginName>\n",
 					"      <UserType>user</UserType>\n",
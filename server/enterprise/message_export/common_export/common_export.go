@@ -4,6 +4,7 @@
 package common_export
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -59,6 +60,30 @@ type Metadata struct {
 	EndTime          int64
 }
 
+// IsEditRevision returns whether the post is a previous version of the post
+// with id PostOriginalId, which was replaced by an edit at PostUpdateAt. Edits
+// keep the previous version as a deleted copy of the post, which unlike posts
+// deleted by users has no deleteBy prop.
+func IsEditRevision(post *model.MessageExport) bool {
+	if post.PostOriginalId == nil || *post.PostOriginalId == "" || post.PostUpdateAt == nil {
+		return false
+	}
+	if post.PostDeleteAt == nil || *post.PostDeleteAt == 0 {
+		return false
+	}
+
+	if post.PostProps != nil {
+		props := map[string]any{}
+		if json.Unmarshal([]byte(*post.PostProps), &props) == nil {
+			if _, ok := props[model.PostPropsDeleteBy]; ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
 func (metadata *Metadata) Update(post *model.MessageExport, attachments int) {
 	channelMetadata, ok := metadata.Channels[*post.ChannelId]
 	if !ok {
@@ -235,3 +235,29 @@ func TestGetJoinsAndLeavesForChannel(t *testing.T) {
 		})
 	}
 }
+
+func TestIsEditRevision(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		originalID string
+		deleteAt   int64
+		props      string
+		expected   bool
+	}{
+		{name: "current version", deleteAt: 0, expected: false},
+		{name: "deleted post", deleteAt: 2, props: "{\"deleteBy\":\"user-id\"}", expected: false},
+		{name: "previous version", originalID: "post-id", deleteAt: 2, props: "{}", expected: true},
+		{name: "previous version of a deleted post", originalID: "post-id", deleteAt: 2, props: "{\"deleteBy\":\"user-id\"}", expected: false},
+		{name: "original id without delete", originalID: "post-id", deleteAt: 0, expected: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			post := &model.MessageExport{
+				PostOriginalId: model.NewPointer(tc.originalID),
+				PostUpdateAt:   model.NewPointer(int64(2)),
+				PostDeleteAt:   model.NewPointer(tc.deleteAt),
+				PostProps:      model.NewPointer(tc.props),
+			}
+			assert.Equal(t, tc.expected, IsEditRevision(post))
+		})
+	}
+}
@@ -113,6 +113,13 @@ func CsvExport(rctx request.CTX, posts []*model.MessageExport, db store.Store, e
 			return warningCount, model.NewAppError("CsvExportPost", "ent.compliance.csv.post.export.appError", nil, "", 0).Wrap(err)
 		}
 
+		// the edit record links the replaced message to the post that replaced it through the "Edited By Post Id" column
+		if common_export.IsEditRevision(post) {
+			if err = csvWriter.Write(postToRow(post, post.PostUpdateAt, "edit "+*post.PostMessage)); err != nil {
+				return warningCount, model.NewAppError("CsvExportPost", "ent.compliance.csv.post.export.appError", nil, "", 0).Wrap(err)
+			}
+		}
+
 		if post.PostDeleteAt != nil && *post.PostDeleteAt > 0 && post.PostProps != nil {
 			props := map[string]any{}
 			if json.Unmarshal([]byte(*post.PostProps), &props) == nil {
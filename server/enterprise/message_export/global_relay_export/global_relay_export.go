@@ -71,6 +71,7 @@ type Message struct {
 	SenderEmail    string
 	Message        string
 	PreviewsPost   string
+	EditedByPost   string
 }
 
 func GlobalRelayExport(rctx request.CTX, posts []*model.MessageExport, db store.Store, fileAttachmentBackend filestore.FileBackend, dest io.Writer, templates *templates.Container) ([]string, int64, *model.AppError) {
@@ -370,6 +371,15 @@ func addPostToChannelExport(rctx request.CTX, channelExport *ChannelExport, post
 		PreviewsPost:   post.PreviewID(),
 	}
 	channelExport.Messages = append(channelExport.Messages, element)
+
+	// add an explicit record of the edit which replaced this version of the message
+	if common_export.IsEditRevision(post) {
+		editElement := element
+		editElement.SentTime = *post.PostUpdateAt
+		editElement.Message = "edit " + *post.PostMessage
+		editElement.EditedByPost = *post.PostOriginalId
+		channelExport.Messages = append(channelExport.Messages, editElement)
+	}
 	channelExport.EndTime = *post.PostCreateAt
 	channelExport.numUserMessages[*post.UserId] += 1
 }
@@ -1329,3 +1329,36 @@ func TestGlobalRelayExport(t *testing.T) {
 		})
 	}
 }
+
+func TestAddPostToChannelExportEdit(t *testing.T) {
+	rctx := request.TestContext(t)
+
+	channelExport := &ChannelExport{
+		Messages:        []Message{},
+		numUserMessages: map[string]int{},
+	}
+	post := &model.MessageExport{
+		PostId:         model.NewPointer("revision-id"),
+		PostOriginalId: model.NewPointer("post-id"),
+		PostCreateAt:   model.NewPointer(int64(1)),
+		PostUpdateAt:   model.NewPointer(int64(5)),
+		PostDeleteAt:   model.NewPointer(int64(5)),
+		PostMessage:    model.NewPointer("message"),
+		PostType:       model.NewPointer(""),
+		PostProps:      model.NewPointer("{}"),
+		UserId:         model.NewPointer("user-id"),
+		UserEmail:      model.NewPointer("test@test.com"),
+		Username:       model.NewPointer("username"),
+	}
+
+	addPostToChannelExport(rctx, channelExport, post)
+
+	require.Len(t, channelExport.Messages, 2)
+	assert.Equal(t, int64(1), channelExport.Messages[0].SentTime)
+	assert.Equal(t, "message", channelExport.Messages[0].Message)
+	assert.Empty(t, channelExport.Messages[0].EditedByPost)
+	assert.Equal(t, int64(5), channelExport.Messages[1].SentTime)
+	assert.Equal(t, "edit message", channelExport.Messages[1].Message)
+	assert.Equal(t, "post-id", channelExport.Messages[1].EditedByPost)
+	assert.Equal(t, 1, channelExport.numUserMessages["user-id"])
+}
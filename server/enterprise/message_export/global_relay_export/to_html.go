@@ -98,6 +98,7 @@ func messageToHTML(message *Message, t *templates.Container) (string, error) {
 			"Email":        message.SenderEmail,
 			"Message":      message.Message,
 			"PreviewsPost": message.PreviewsPost,
+			"EditedByPost": message.EditedByPost,
 		},
 	}
 
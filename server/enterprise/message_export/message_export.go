@@ -33,6 +33,9 @@ import (
 
 const (
 	GlobalRelayExportFilename = "global-relay.zip"
+
+	legalHoldExportPath      = "legal_holds"
+	legalHoldExportBatchSize = 10000
 )
 
 type MessageExportInterfaceImpl struct {
@@ -110,6 +113,45 @@ func (m *MessageExportInterfaceImpl) RunExport(rctx request.CTX, exportType stri
 	return runExportByType(rctx, exportType, postsToExport, exportDirectory, m.Server.Store(), fileBackend, fileBackend, t, m.Server.Config())
 }
 
+func (m *MessageExportInterfaceImpl) RunLegalHoldExport(rctx request.CTX, legalHoldID string, exportType string) (string, int64, *model.AppError) {
+	var postsToExport []*model.MessageExport
+	cursor := model.MessageExportCursor{}
+	for {
+		posts, nextCursor, err := m.Server.Store().Compliance().LegalHoldMessageExport(rctx, legalHoldID, cursor, legalHoldExportBatchSize)
+		if err != nil {
+			return "", 0, model.NewAppError("RunLegalHoldExport", "ent.message_export.run_export.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		postsToExport = append(postsToExport, posts...)
+		if len(posts) < legalHoldExportBatchSize {
+			break
+		}
+		cursor = nextCursor
+	}
+	rctx.Logger().Debug("Found held posts to export", mlog.String("legal_hold_id", legalHoldID), mlog.Int("number_of_posts", len(postsToExport)))
+
+	fileBackend := m.Server.FileBackend()
+	templatesDir, ok := fileutils.FindDir("templates")
+	if !ok {
+		return "", 0, model.NewAppError("RunLegalHoldExport", "ent.compliance.run_export.template_watcher.appError", nil, "", http.StatusAccepted)
+	}
+
+	t, err := templates.New(templatesDir)
+	if err != nil {
+		return "", 0, model.NewAppError("RunLegalHoldExport", "ent.compliance.run_export.template_watcher.appError", nil, "", http.StatusAccepted).Wrap(err)
+	}
+
+	exportDirectory := path.Join(exportPath, legalHoldExportPath, legalHoldID, strconv.FormatInt(model.GetMillis(), 10))
+	warningCount, appErr := runExportByType(rctx, exportType, postsToExport, exportDirectory, m.Server.Store(), fileBackend, fileBackend, t, m.Server.Config())
+	if appErr != nil {
+		return "", 0, appErr
+	}
+	if warningCount > 0 {
+		rctx.Logger().Warn("Legal hold export completed with warnings", mlog.String("legal_hold_id", legalHoldID), mlog.Int("warning_count", warningCount))
+	}
+
+	return exportDirectory, int64(len(postsToExport)), nil
+}
+
 func runExportByType(rctx request.CTX, exportType string, postsToExport []*model.MessageExport, exportDirectory string, db store.Store, exportBackend filestore.FileBackend, fileAttachmentBackend filestore.FileBackend, htmlTemplates *templates.Container, config *model.Config) (warningCount int64, appErr *model.AppError) {
 	// go through all the posts and if the post's props contain 'from_bot' - override the IsBot field, since it's possible that the sender is not a user, but was a Bot and vise-versa
 	for _, post := range postsToExport {
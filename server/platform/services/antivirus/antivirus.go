@@ -0,0 +1,88 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package antivirus streams file contents to an external antivirus scanner.
+// Two protocols are supported: the clamd INSTREAM command, over TCP or a unix
+// socket, and ICAP RESPMOD as implemented by most commercial scanning
+// gateways.
+package antivirus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"time"
+)
+
+// chunkSize is the size of the chunks the file contents are streamed in.
+const chunkSize = 64 * 1024
+
+// Result is the verdict of the scanner for a single file.
+type Result struct {
+	// Clean is true if the scanner did not find anything in the file.
+	Clean bool
+	// Signature is the name of the threat found by the scanner, if any.
+	Signature string
+}
+
+// Scanner scans a stream of data.
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (*Result, error)
+}
+
+// ScanSettings defines how to reach the scanner.
+type ScanSettings struct {
+	// URL selects the protocol from its scheme: tcp://host:port and
+	// unix:///path/to/socket for clamd, icap://host:port/service for ICAP.
+	URL string
+	// Timeout bounds the whole scan of a file, including the connection.
+	Timeout time.Duration
+}
+
+// NewScanner returns a Scanner for the protocol selected by the URL.
+func NewScanner(settings ScanSettings) (Scanner, error) {
+	u, err := url.Parse(settings.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid antivirus scan URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case "tcp":
+		return &clamdScanner{network: "tcp", address: u.Host, timeout: settings.Timeout}, nil
+	case "unix":
+		return &clamdScanner{network: "unix", address: u.Path, timeout: settings.Timeout}, nil
+	case "icap":
+		host := u.Host
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "1344")
+		}
+		return &icapScanner{url: u, address: host, timeout: settings.Timeout}, nil
+	}
+	return nil, fmt.Errorf("unsupported antivirus scan URL scheme %q", u.Scheme)
+}
+
+// dial opens a connection whose deadline is the earliest of the context
+// deadline and the scan timeout.
+func dial(ctx context.Context, network, address string, timeout time.Duration) (net.Conn, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the antivirus scanner: %w", err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package antivirus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http/httputil"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// serve accepts connections on the listener and hands them to handler until
+// the test ends.
+func serve(t *testing.T, listener net.Listener, handler func(net.Conn) error) {
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				assert.NoError(t, handler(conn))
+			}()
+		}
+	}()
+}
+
+// fakeClamd reads an INSTREAM command and reports the stream as infected if
+// it contains the EICAR test string.
+func fakeClamd(conn net.Conn) error {
+	reader := bufio.NewReader(conn)
+	command, err := reader.ReadString(0)
+	if err != nil {
+		return err
+	}
+	if command != "zINSTREAM\x00" {
+		return fmt.Errorf("unexpected command %q", command)
+	}
+
+	var data bytes.Buffer
+	for {
+		var size uint32
+		if err = binary.Read(reader, binary.BigEndian, &size); err != nil {
+			return err
+		}
+		if size == 0 {
+			break
+		}
+		if _, err = io.CopyN(&data, reader, int64(size)); err != nil {
+			return err
+		}
+	}
+
+	reply := "stream: OK\x00"
+	if strings.Contains(data.String(), eicar) {
+		reply = "stream: Win.Test.EICAR_HDB-1 FOUND\x00"
+	}
+	_, err = io.WriteString(conn, reply)
+	return err
+}
+
+// fakeICAP reads a RESPMOD request and reports the encapsulated body as
+// infected if it contains the EICAR test string, using the headers selected
+// by the service path.
+func fakeICAP(conn net.Conn) error {
+	reader := textproto.NewReader(bufio.NewReader(conn))
+	requestLine, err := reader.ReadLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(requestLine, "RESPMOD icap://") {
+		return fmt.Errorf("unexpected request %q", requestLine)
+	}
+	header, err := reader.ReadMIMEHeader()
+	if err != nil {
+		return err
+	}
+	if header.Get("Allow") != "204" {
+		return fmt.Errorf("missing Allow header")
+	}
+
+	// The encapsulated response header is followed by the chunked body.
+	if _, err = reader.ReadLine(); err != nil {
+		return err
+	}
+	if _, err = reader.ReadMIMEHeader(); err != nil {
+		return err
+	}
+	body, err := io.ReadAll(httputil.NewChunkedReader(reader.R))
+	if err != nil {
+		return err
+	}
+
+	response := "ICAP/1.0 204 No Content\r\n\r\n"
+	if strings.Contains(string(body), eicar) {
+		switch {
+		case strings.HasSuffix(requestLine, "/virus-id ICAP/1.0"):
+			response = "ICAP/1.0 200 OK\r\nX-Virus-ID: Eicar-Signature\r\nEncapsulated: null-body=0\r\n\r\n"
+		case strings.HasSuffix(requestLine, "/block-page ICAP/1.0"):
+			response = "ICAP/1.0 200 OK\r\nEncapsulated: res-hdr=0, null-body=45\r\n\r\nHTTP/1.1 403 Forbidden\r\nContent-Length: 0\r\n\r\n"
+		default:
+			response = "ICAP/1.0 200 OK\r\nX-Infection-Found: Type=0; Resolution=2; Threat=Eicar-Test-Signature;\r\nEncapsulated: null-body=0\r\n\r\n"
+		}
+	}
+	_, err = io.WriteString(conn, response)
+	return err
+}
+
+func TestScanner(t *testing.T) {
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	serve(t, tcpListener, fakeClamd)
+
+	unixListener, err := net.Listen("unix", filepath.Join(t.TempDir(), "clamd.sock"))
+	require.NoError(t, err)
+	serve(t, unixListener, fakeClamd)
+
+	icapListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	serve(t, icapListener, fakeICAP)
+
+	for name, tc := range map[string]struct {
+		url       string
+		signature string
+	}{
+		"clamd over tcp":     {url: "tcp://" + tcpListener.Addr().String(), signature: "Win.Test.EICAR_HDB-1"},
+		"clamd over unix":    {url: "unix://" + unixListener.Addr().String(), signature: "Win.Test.EICAR_HDB-1"},
+		"icap infection":     {url: "icap://" + icapListener.Addr().String() + "/avscan", signature: "Eicar-Test-Signature"},
+		"icap virus id":      {url: "icap://" + icapListener.Addr().String() + "/virus-id", signature: "Eicar-Signature"},
+		"icap blocking page": {url: "icap://" + icapListener.Addr().String() + "/block-page", signature: icapUnknownSignature},
+	} {
+		t.Run(name, func(t *testing.T) {
+			scanner, err := NewScanner(ScanSettings{URL: tc.url, Timeout: 5 * time.Second})
+			require.NoError(t, err)
+
+			// Larger than a chunk to exercise the streaming.
+			clean := bytes.Repeat([]byte("clean data "), chunkSize/5)
+			result, err := scanner.Scan(context.Background(), bytes.NewReader(clean))
+			require.NoError(t, err)
+			assert.True(t, result.Clean)
+			assert.Empty(t, result.Signature)
+
+			infected := append(clean, []byte(eicar)...)
+			result, err = scanner.Scan(context.Background(), bytes.NewReader(infected))
+			require.NoError(t, err)
+			assert.False(t, result.Clean)
+			assert.Equal(t, tc.signature, result.Signature)
+		})
+	}
+
+	t.Run("unreachable scanner", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		listener.Close()
+
+		scanner, err := NewScanner(ScanSettings{URL: "tcp://" + address, Timeout: time.Second})
+		require.NoError(t, err)
+		_, err = scanner.Scan(context.Background(), strings.NewReader("data"))
+		require.Error(t, err)
+	})
+
+	t.Run("clamd error", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		serve(t, listener, func(conn net.Conn) error {
+			_, err := io.WriteString(conn, "INSTREAM size limit exceeded. ERROR\x00")
+			return err
+		})
+
+		scanner, err := NewScanner(ScanSettings{URL: "tcp://" + listener.Addr().String(), Timeout: time.Second})
+		require.NoError(t, err)
+		_, err = scanner.Scan(context.Background(), strings.NewReader("data"))
+		require.ErrorContains(t, err, "size limit exceeded")
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		_, err := NewScanner(ScanSettings{URL: "http://localhost"})
+		require.Error(t, err)
+	})
+}
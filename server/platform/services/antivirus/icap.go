@@ -0,0 +1,158 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package antivirus
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	icapEncapsulatedResponseHeader = "HTTP/1.1 200 OK\r\nContent-Type: application/octet-stream\r\n\r\n"
+	icapUnknownSignature           = "blocked by the ICAP service"
+)
+
+// icapScanner sends the data as the body of an encapsulated HTTP response in
+// an ICAP RESPMOD request (RFC 3507). The service answers 204 when it does
+// not need to modify the response, which means the file is clean.
+type icapScanner struct {
+	url     *url.URL
+	address string
+	timeout time.Duration
+}
+
+func (s *icapScanner) Scan(ctx context.Context, r io.Reader) (*Result, error) {
+	conn, err := dial(ctx, "tcp", s.address, s.timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	writeErr := s.writeRequest(conn, r)
+
+	// Like clamd, an ICAP service may reply and close the connection
+	// before the whole body was sent.
+	reader := textproto.NewReader(bufio.NewReader(conn))
+	result, err := readICAPResponse(reader)
+	if err != nil && writeErr != nil {
+		return nil, fmt.Errorf("failed to send the file to the ICAP service: %w", writeErr)
+	}
+	return result, err
+}
+
+func (s *icapScanner) writeRequest(conn io.Writer, r io.Reader) error {
+	w := bufio.NewWriterSize(conn, chunkSize+32)
+
+	fmt.Fprintf(w, "RESPMOD %s ICAP/1.0\r\n", s.url.String())
+	fmt.Fprintf(w, "Host: %s\r\n", s.url.Host)
+	fmt.Fprintf(w, "Allow: 204\r\n")
+	fmt.Fprintf(w, "Encapsulated: res-hdr=0, res-body=%d\r\n\r\n", len(icapEncapsulatedResponseHeader))
+	w.WriteString(icapEncapsulatedResponseHeader)
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			fmt.Fprintf(w, "%x\r\n", n)
+			w.Write(buf[:n])
+			if _, wErr := w.WriteString("\r\n"); wErr != nil {
+				return wErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	w.WriteString("0\r\n\r\n")
+	return w.Flush()
+}
+
+func readICAPResponse(reader *textproto.Reader) (*Result, error) {
+	statusLine, err := reader.ReadLine()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the ICAP response: %w", err)
+	}
+
+	code, err := parseStatusCode(statusLine, "ICAP/")
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := reader.ReadMIMEHeader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the ICAP response headers: %w", err)
+	}
+
+	switch code {
+	case 204:
+		return &Result{Clean: true}, nil
+	case 200:
+	default:
+		return nil, fmt.Errorf("the ICAP service returned an error: %s", statusLine)
+	}
+
+	if infection := header.Get("X-Infection-Found"); infection != "" {
+		return &Result{Signature: parseInfectionFound(infection)}, nil
+	}
+	if virusID := header.Get("X-Virus-ID"); virusID != "" {
+		return &Result{Signature: virusID}, nil
+	}
+	if header.Get("X-Violations-Found") != "" {
+		return &Result{Signature: icapUnknownSignature}, nil
+	}
+
+	// Without any of the well known headers, a service blocking the file
+	// replaces the encapsulated response with an error page.
+	if strings.Contains(header.Get("Encapsulated"), "res-hdr") {
+		httpStatusLine, err := reader.ReadLine()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the encapsulated response: %w", err)
+		}
+		httpCode, err := parseStatusCode(httpStatusLine, "HTTP/")
+		if err != nil {
+			return nil, err
+		}
+		if httpCode >= 400 {
+			return &Result{Signature: icapUnknownSignature}, nil
+		}
+	}
+
+	return &Result{Clean: true}, nil
+}
+
+func parseStatusCode(statusLine, protocol string) (int, error) {
+	parts := strings.SplitN(statusLine, " ", 3)
+	if len(parts) < 2 || !strings.HasPrefix(parts[0], protocol) {
+		return 0, fmt.Errorf("malformed status line %q", statusLine)
+	}
+
+	code, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("malformed status line %q", statusLine)
+	}
+	return code, nil
+}
+
+// parseInfectionFound extracts the threat name from headers such as
+// "Type=0; Resolution=2; Threat=Eicar-Test-Signature;".
+func parseInfectionFound(value string) string {
+	for _, field := range strings.Split(value, ";") {
+		if name, ok := strings.CutPrefix(strings.TrimSpace(field), "Threat="); ok && name != "" {
+			return name
+		}
+	}
+	return icapUnknownSignature
+}
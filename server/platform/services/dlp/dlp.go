@@ -0,0 +1,155 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package dlp evaluates the data loss prevention rules of the server against
+// messages and extracted file contents.
+package dlp
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+var presets = map[string]struct {
+	pattern   string
+	validator string
+}{
+	model.DLPPresetCreditCard: {
+		pattern:   `\b(?:\d[ -]?){12,18}\d\b`,
+		validator: model.DLPValidatorLuhn,
+	},
+	model.DLPPresetAPIKey: {
+		pattern: `\b(?:` +
+			`AKIA[0-9A-Z]{16}|` + // AWS access key ids
+			`gh[pousr]_[A-Za-z0-9]{36,255}|` + // GitHub tokens
+			`xox[abposr]-[A-Za-z0-9-]{10,}|` + // Slack tokens
+			`[sr]k_live_[A-Za-z0-9]{24,}|` + // Stripe keys
+			`AIza[0-9A-Za-z_-]{35}` + // Google API keys
+			`)\b`,
+	},
+}
+
+type matcher struct {
+	re        *regexp.Regexp
+	validator func(string) bool
+}
+
+type rule struct {
+	*model.DLPRule
+	matchers []matcher
+}
+
+// Engine holds the compiled rules. It is safe for concurrent use.
+type Engine struct {
+	rules []*rule
+}
+
+// Match is the location of a match in the scanned text.
+type Match struct {
+	Start int
+	End   int
+}
+
+// Violation lists the matches of a rule in the scanned text.
+type Violation struct {
+	Rule    *model.DLPRule
+	Matches []Match
+}
+
+// NewEngine compiles the rules.
+func NewEngine(rules []*model.DLPRule) (*Engine, error) {
+	engine := &Engine{}
+	for _, r := range rules {
+		compiled := &rule{DLPRule: r}
+
+		if preset, ok := presets[r.Preset]; ok {
+			compiled.matchers = append(compiled.matchers, matcher{
+				re:        regexp.MustCompile(preset.pattern),
+				validator: validators[preset.validator],
+			})
+		}
+
+		if r.Pattern != "" {
+			re, err := regexp.Compile(r.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern for data loss prevention rule %q: %w", r.Name, err)
+			}
+			compiled.matchers = append(compiled.matchers, matcher{re: re, validator: validators[r.Validator]})
+		}
+
+		if len(r.Keywords) > 0 {
+			quoted := make([]string, len(r.Keywords))
+			for i, keyword := range r.Keywords {
+				quoted[i] = regexp.QuoteMeta(keyword)
+			}
+			compiled.matchers = append(compiled.matchers, matcher{
+				re: regexp.MustCompile(`(?i)(?:^|\b)(?:` + strings.Join(quoted, "|") + `)(?:\b|$)`),
+			})
+		}
+
+		engine.rules = append(engine.rules, compiled)
+	}
+	return engine, nil
+}
+
+// Scan returns the violations of the rules in effect in the channel.
+func (e *Engine) Scan(text, teamID, channelID string) []*Violation {
+	if e == nil || text == "" {
+		return nil
+	}
+
+	var violations []*Violation
+	for _, r := range e.rules {
+		if !r.AppliesTo(teamID, channelID) {
+			continue
+		}
+
+		var matches []Match
+		for _, m := range r.matchers {
+			for _, loc := range m.re.FindAllStringIndex(text, -1) {
+				if m.validator != nil && !m.validator(text[loc[0]:loc[1]]) {
+					continue
+				}
+				matches = append(matches, Match{Start: loc[0], End: loc[1]})
+			}
+		}
+
+		if len(matches) > 0 {
+			violations = append(violations, &Violation{Rule: r.DLPRule, Matches: matches})
+		}
+	}
+	return violations
+}
+
+// Redact masks the matches of the violations in the text, keeping its length
+// in characters.
+func Redact(text string, violations []*Violation) string {
+	var matches []Match
+	for _, violation := range violations {
+		matches = append(matches, violation.Matches...)
+	}
+	if len(matches) == 0 {
+		return text
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Start < matches[j].Start })
+
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		if match.End <= last {
+			continue
+		}
+		start := max(match.Start, last)
+		b.WriteString(text[last:start])
+		b.WriteString(strings.Repeat("*", utf8.RuneCountInString(text[start:match.End])))
+		last = match.End
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package dlp
+
+import "github.com/mattermost/mattermost/server/public/model"
+
+var validators = map[string]func(string) bool{
+	model.DLPValidatorLuhn: isValidLuhn,
+}
+
+// isValidLuhn checks the digits of the match, ignoring any separator, against
+// the Luhn checksum.
+func isValidLuhn(match string) bool {
+	var sum, nDigits int
+	double := false
+	for i := len(match) - 1; i >= 0; i-- {
+		c := match[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		digit := int(c - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+		nDigits++
+	}
+	return nDigits >= 2 && sum%10 == 0
+}
@@ -0,0 +1,328 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package inboundmail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/jaytaylor/html2text"
+	"golang.org/x/net/html/charset"
+)
+
+// maxPartDepth bounds the nesting of multipart bodies.
+const maxPartDepth = 8
+
+// Attachment is a file attached to a message.
+type Attachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Message is a parsed email.
+type Message struct {
+	Header mail.Header
+	// From is the address of the From header.
+	From    string
+	Subject string
+	// Text is the plain text body, converted from HTML if the message has
+	// no plain text body.
+	Text        string
+	Attachments []*Attachment
+}
+
+var wordDecoder = &mime.WordDecoder{CharsetReader: charset.NewReaderLabel}
+
+// ParseMessage parses an RFC 5322 message, walking its MIME parts to find
+// the body and the attachments.
+func ParseMessage(data []byte) (*Message, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the message: %w", err)
+	}
+
+	from, err := msg.Header.AddressList("From")
+	if err != nil || len(from) != 1 {
+		return nil, errors.New("the message must have a single sender")
+	}
+
+	subject, err := wordDecoder.DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+
+	parsed := &Message{
+		Header:  msg.Header,
+		From:    from[0].Address,
+		Subject: subject,
+	}
+
+	var text, html string
+	if err := parsed.walkPart(textproto.MIMEHeader(msg.Header), msg.Body, 0, &text, &html); err != nil {
+		return nil, err
+	}
+
+	if text == "" && html != "" {
+		text, err = html2text.FromString(html, html2text.Options{OmitLinks: true})
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the HTML body: %w", err)
+		}
+	}
+	parsed.Text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	return parsed, nil
+}
+
+func (m *Message) walkPart(header textproto.MIMEHeader, body io.Reader, depth int, text, html *string) error {
+	if depth > maxPartDepth {
+		return errors.New("the message is nested too deeply")
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read a part of the message: %w", err)
+			}
+			if err := m.walkPart(part.Header, part, depth+1, text, html); err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := io.ReadAll(decodeTransferEncoding(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return fmt.Errorf("failed to decode a part of the message: %w", err)
+	}
+
+	disposition, dispositionParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	name := dispositionParams["filename"]
+	if name == "" {
+		name = params["name"]
+	}
+	if name, err = wordDecoder.DecodeHeader(name); err != nil {
+		name = ""
+	}
+
+	// The first text parts which aren't attachments make up the body.
+	isBody := disposition != "attachment" && name == ""
+	switch {
+	case isBody && mediaType == "text/plain" && *text == "":
+		*text, err = decodeCharset(params["charset"], content)
+		return err
+	case isBody && mediaType == "text/html" && *html == "":
+		*html, err = decodeCharset(params["charset"], content)
+		return err
+	case isBody:
+		// Other inline parts, such as calendar invitations or the
+		// alternative bodies, are ignored.
+		return nil
+	}
+
+	m.Attachments = append(m.Attachments, &Attachment{
+		Name:        filepath.Base(name),
+		ContentType: mediaType,
+		Data:        content,
+	})
+	return nil
+}
+
+func decodeTransferEncoding(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, newBase64Cleaner(r))
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	}
+	return r
+}
+
+// base64Cleaner drops the line breaks and spaces of base64 bodies.
+type base64Cleaner struct {
+	r io.Reader
+}
+
+func newBase64Cleaner(r io.Reader) io.Reader {
+	return &base64Cleaner{r}
+}
+
+func (c *base64Cleaner) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	j := 0
+	for _, b := range p[:n] {
+		if b != '\r' && b != '\n' && b != ' ' && b != '\t' {
+			p[j] = b
+			j++
+		}
+	}
+	return j, err
+}
+
+func decodeCharset(label string, content []byte) (string, error) {
+	if label == "" || strings.EqualFold(label, "utf-8") || strings.EqualFold(label, "us-ascii") {
+		return string(content), nil
+	}
+	reader, err := charset.NewReaderLabel(label, bytes.NewReader(content))
+	if err != nil {
+		return "", fmt.Errorf("unsupported charset %q: %w", label, err)
+	}
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode the %q charset: %w", label, err)
+	}
+	return string(decoded), nil
+}
+
+var authenticationFailureRegexp = regexp.MustCompile(`(?i)\b(spf|dkim|dmarc)\s*=\s*(fail|softfail|permerror)\b`)
+
+// AuthenticationFailed returns true if the Authentication-Results headers
+// added by the receiving MTA report that the sender could not be
+// authenticated.
+func (m *Message) AuthenticationFailed() bool {
+	for _, result := range m.Header["Authentication-Results"] {
+		if authenticationFailureRegexp.MatchString(result) {
+			return true
+		}
+	}
+	return false
+}
+
+var authenticationResultsCommentRegexp = regexp.MustCompile(`\([^()]*\)`)
+
+// Authenticated returns true if an Authentication-Results header added by one
+// of the trusted authentication servers reports that the DKIM signature or the
+// SPF check of the domain of the sender passed. Anyone can add such headers, so
+// the trusted MTAs must remove the ones claiming their authserv-id from the
+// messages they receive, as required by RFC 8601, and the server must only
+// accept connections from them.
+func (m *Message) Authenticated(trustedAuthServIDs []string) bool {
+	_, fromDomain, found := strings.Cut(strings.ToLower(m.From), "@")
+	if !found || fromDomain == "" {
+		return false
+	}
+
+	for _, result := range m.Header["Authentication-Results"] {
+		result = authenticationResultsCommentRegexp.ReplaceAllString(result, " ")
+		parts := strings.Split(result, ";")
+
+		// The authserv-id may be followed by a version.
+		fields := strings.Fields(parts[0])
+		if len(fields) == 0 || !slices.ContainsFunc(trustedAuthServIDs, func(id string) bool { return strings.EqualFold(id, fields[0]) }) {
+			continue
+		}
+
+		for _, resinfo := range parts[1:] {
+			if authenticationResultPasses(strings.Fields(strings.ToLower(resinfo)), fromDomain) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// authenticationResultPasses returns true if the fields of a result report a
+// passing DKIM signature or SPF check for the given domain.
+func authenticationResultPasses(fields []string, domain string) bool {
+	if len(fields) == 0 {
+		return false
+	}
+
+	var properties []string
+	switch fields[0] {
+	case "dkim=pass":
+		properties = []string{"header.d", "header.i"}
+	case "spf=pass":
+		properties = []string{"smtp.mailfrom"}
+	default:
+		return false
+	}
+
+	for _, field := range fields[1:] {
+		property, value, found := strings.Cut(field, "=")
+		if !found || !slices.Contains(properties, property) {
+			continue
+		}
+
+		value = strings.Trim(value, `"`)
+		if i := strings.LastIndex(value, "@"); i >= 0 {
+			value = value[i+1:]
+		}
+		if value == domain {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// The attribution lines of the quoted message, such as
+	// "On Mon, Jan 2, 2006 at 3:04 PM, Jane <jane@example.com> wrote:".
+	attributionRegexp = regexp.MustCompile(`(?i)^(on|le|am|el)\s.+\s(wrote|a écrit|schrieb|escribió)(\s.*)?:$`)
+	// The separators inserted by Outlook and other clients.
+	separatorRegexp = regexp.MustCompile(`(?i)^(-{3,}\s*original message\s*-{3,}|_{20,}|-{3,}\s*forwarded message\s*-{3,})$`)
+	// The headers of the quoted message inserted by Outlook.
+	quotedFromRegexp   = regexp.MustCompile(`(?i)^\*?(from|de|von):\*?\s`)
+	quotedHeaderRegexp = regexp.MustCompile(`(?i)^\*?(sent|date|to|envoyé|gesendet|enviado):\*?\s`)
+	// The signatures added by mobile clients.
+	mobileSignatureRegexp = regexp.MustCompile(`(?i)^(sent from my .+|get outlook for .+|sent from mail for windows.*)$`)
+)
+
+// StripReply returns the text written by the sender of a reply, without the
+// quoted message and the signature.
+func StripReply(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	var kept []string
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		// RFC 3676 signature delimiter, often trimmed by clients.
+		if line == "-- " || line == "--" {
+			break
+		}
+		if separatorRegexp.MatchString(trimmed) || mobileSignatureRegexp.MatchString(trimmed) {
+			break
+		}
+		if attributionRegexp.MatchString(trimmed) {
+			break
+		}
+		// Attribution lines are often wrapped.
+		if i+1 < len(lines) && attributionRegexp.MatchString(trimmed+" "+strings.TrimSpace(lines[i+1])) {
+			break
+		}
+		if quotedFromRegexp.MatchString(trimmed) && i+1 < len(lines) && quotedHeaderRegexp.MatchString(strings.TrimSpace(lines[i+1])) {
+			break
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+
+		kept = append(kept, strings.TrimRight(line, " \t"))
+	}
+
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package inboundmail
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func crlf(s string) []byte {
+	return []byte(strings.ReplaceAll(s, "\n", "\r\n"))
+}
+
+func TestParseMessage(t *testing.T) {
+	t.Run("plain text", func(t *testing.T) {
+		msg, err := ParseMessage(crlf(`From: Jane Doe <jane@example.com>
+To: reply+abc@chat.example.com
+Subject: =?UTF-8?Q?Re:_caf=C3=A9?=
+
+Sounds good!
+`))
+		require.NoError(t, err)
+		assert.Equal(t, "jane@example.com", msg.From)
+		assert.Equal(t, "Re: café", msg.Subject)
+		assert.Equal(t, "Sounds good!\n", msg.Text)
+		assert.Empty(t, msg.Attachments)
+	})
+
+	t.Run("alternative prefers plain text", func(t *testing.T) {
+		msg, err := ParseMessage(crlf(`From: jane@example.com
+Content-Type: multipart/alternative; boundary="b1"
+
+--b1
+Content-Type: text/plain; charset=utf-8
+Content-Transfer-Encoding: quoted-printable
+
+Caf=C3=A9 at noon?
+--b1
+Content-Type: text/html; charset=utf-8
+
+<p>Caf&eacute; at <b>noon</b>?</p>
+--b1--
+`))
+		require.NoError(t, err)
+		assert.Equal(t, "Café at noon?", msg.Text)
+	})
+
+	t.Run("html only", func(t *testing.T) {
+		msg, err := ParseMessage(crlf(`From: jane@example.com
+Content-Type: text/html; charset=iso-8859-1
+Content-Transfer-Encoding: quoted-printable
+
+<p>Caf=E9 at <b>noon</b></p>
+`))
+		require.NoError(t, err)
+		assert.Equal(t, "Café at *noon*", strings.TrimSpace(msg.Text))
+	})
+
+	t.Run("attachments", func(t *testing.T) {
+		msg, err := ParseMessage(crlf(`From: jane@example.com
+Content-Type: multipart/mixed; boundary="b1"
+
+--b1
+Content-Type: multipart/alternative; boundary="b2"
+
+--b2
+Content-Type: text/plain
+
+See attached.
+--b2--
+--b1
+Content-Type: text/plain; name="notes.txt"
+Content-Disposition: attachment; filename="../notes.txt"
+Content-Transfer-Encoding: base64
+
+aGVsbG8g
+d29ybGQ=
+--b1
+Content-Type: image/png
+Content-Disposition: inline; filename="screenshot.png"
+
+PNG
+--b1--
+`))
+		require.NoError(t, err)
+		assert.Equal(t, "See attached.", msg.Text)
+		require.Len(t, msg.Attachments, 2)
+		assert.Equal(t, "notes.txt", msg.Attachments[0].Name)
+		assert.Equal(t, "text/plain", msg.Attachments[0].ContentType)
+		assert.Equal(t, "hello world", string(msg.Attachments[0].Data))
+		assert.Equal(t, "screenshot.png", msg.Attachments[1].Name)
+	})
+
+	t.Run("no sender", func(t *testing.T) {
+		_, err := ParseMessage(crlf(`To: reply@chat.example.com
+
+Hello
+`))
+		require.Error(t, err)
+	})
+}
+
+func TestAuthenticationFailed(t *testing.T) {
+	msg, err := ParseMessage(crlf(`From: jane@example.com
+Authentication-Results: mx.example.com; spf=pass smtp.mailfrom=example.com; dkim=pass header.d=example.com
+
+Hello
+`))
+	require.NoError(t, err)
+	assert.False(t, msg.AuthenticationFailed())
+
+	msg, err = ParseMessage(crlf(`From: jane@example.com
+Authentication-Results: mx.example.com; spf=pass smtp.mailfrom=example.com; dmarc=fail header.from=example.com
+
+Hello
+`))
+	require.NoError(t, err)
+	assert.True(t, msg.AuthenticationFailed())
+}
+
+func TestAuthenticated(t *testing.T) {
+	trusted := []string{"mx.example.com"}
+
+	for name, test := range map[string]struct {
+		Results  string
+		Expected bool
+	}{
+		"dkim pass": {
+			Results:  "mx.example.com; dkim=pass (1024-bit key) header.d=example.com header.b=abc",
+			Expected: true,
+		},
+		"spf pass with a version": {
+			Results:  "mx.example.com 1; spf=pass (sender is authorized) smtp.mailfrom=jane@example.com",
+			Expected: true,
+		},
+		"untrusted server": {
+			Results:  "attacker.example.org; dkim=pass header.d=example.com",
+			Expected: false,
+		},
+		"other domain": {
+			Results:  "mx.example.com; dkim=pass header.d=example.org; spf=pass smtp.mailfrom=example.org",
+			Expected: false,
+		},
+		"no pass": {
+			Results:  "mx.example.com; dkim=none; spf=neutral smtp.mailfrom=example.com",
+			Expected: false,
+		},
+		"no results": {
+			Expected: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			header := ""
+			if test.Results != "" {
+				header = "Authentication-Results: " + test.Results + "\n"
+			}
+			msg, err := ParseMessage(crlf("From: Jane <jane@Example.com>\n" + header + "\nHello\n"))
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, msg.Authenticated(trusted))
+		})
+	}
+}
+
+func TestStripReply(t *testing.T) {
+	for name, test := range map[string]struct {
+		Text     string
+		Expected string
+	}{
+		"no quote": {
+			Text:     "Sounds good!\n",
+			Expected: "Sounds good!",
+		},
+		"quoted lines": {
+			Text:     "Sounds good!\n\nOn Mon, Jan 2, 2006 at 3:04 PM, Jane <jane@example.com> wrote:\n> Lunch?\n",
+			Expected: "Sounds good!",
+		},
+		"wrapped attribution": {
+			Text:     "Sounds good!\n\nOn Mon, Jan 2, 2006 at 3:04 PM, Jane Doe\n<jane@example.com> wrote:\n> Lunch?\n",
+			Expected: "Sounds good!",
+		},
+		"german attribution": {
+			Text:     "Gerne!\n\nAm 02.01.2006 um 15:04 schrieb Jane <jane@example.com>:\n> Lunch?\n",
+			Expected: "Gerne!",
+		},
+		"inline quotes": {
+			Text:     "> Lunch?\nYes\n> At noon?\nSure\n",
+			Expected: "Yes\nSure",
+		},
+		"signature": {
+			Text:     "Sounds good!\n\n-- \nJane Doe\nExample Inc.\n",
+			Expected: "Sounds good!",
+		},
+		"mobile signature": {
+			Text:     "Sounds good!\n\nSent from my iPhone\n",
+			Expected: "Sounds good!",
+		},
+		"outlook": {
+			Text:     "Sounds good!\r\n\r\n________________________________\r\nFrom: Mattermost <noreply@example.com>\r\nSent: Monday, January 2, 2006 3:04 PM\r\n",
+			Expected: "Sounds good!",
+		},
+		"outlook headers": {
+			Text:     "Sounds good!\n\nFrom: Mattermost <noreply@example.com>\nSent: Monday, January 2, 2006 3:04 PM\n",
+			Expected: "Sounds good!",
+		},
+		"from in the reply": {
+			Text:     "From: the team\nThanks!\n",
+			Expected: "From: the team\nThanks!",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, StripReply(test.Text))
+		})
+	}
+}
@@ -0,0 +1,475 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package inboundmail receives email over SMTP or LMTP and parses it. The
+// server only implements what a mail transfer agent relaying to it needs: it
+// doesn't support TLS or authentication, and is meant to sit behind the MTA
+// receiving mail for the domain. Only connections from the trusted sources are
+// accepted.
+package inboundmail
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	ProtocolSMTP = "smtp"
+	ProtocolLMTP = "lmtp"
+
+	// maxLineLength is the maximum length of a command line, including the
+	// line ending, as set by RFC 5321.
+	maxLineLength = 512
+	// maxRecipients is the minimum number of recipients an SMTP server
+	// must accept per message, as set by RFC 5321.
+	maxRecipients = 100
+
+	defaultTimeout = 5 * time.Minute
+)
+
+// ErrServerClosed is returned by Serve after Close is called.
+var ErrServerClosed = errors.New("inboundmail: server closed")
+
+// ErrNoTrustedSources is returned by Serve when no trusted source is set.
+var ErrNoTrustedSources = errors.New("inboundmail: no trusted sources")
+
+// Error is an error returned by the handlers with the reply code to send to
+// the client. 4xx codes tell the client to try again later while 5xx codes
+// make it bounce the message. Other errors are temporary failures.
+type Error struct {
+	Code    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return strconv.Itoa(e.Code) + " " + e.Message
+}
+
+// Server receives messages over SMTP or LMTP.
+type Server struct {
+	// Hostname is announced in the greeting.
+	Hostname string
+	// Protocol is either ProtocolSMTP or ProtocolLMTP.
+	Protocol string
+	// MaxMessageSize is the maximum size of a message, in bytes.
+	MaxMessageSize int64
+	// Timeout bounds the time to wait for a command or for the message data.
+	Timeout time.Duration
+	// TrustedSources are the networks connections are accepted from.
+	TrustedSources []*net.IPNet
+	// AcceptRecipient is called when a client adds a recipient to a message.
+	AcceptRecipient func(address string) error
+	// Deliver is called with the message for each of its recipients.
+	Deliver func(from, to string, data []byte) error
+	Logger  mlog.LoggerIFace
+
+	mutex    sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+// ParseTrustedSources parses a list of IP addresses and CIDR ranges.
+func ParseTrustedSources(sources []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(sources))
+	for _, source := range sources {
+		if _, network, err := net.ParseCIDR(source); err == nil {
+			networks = append(networks, network)
+			continue
+		}
+		ip := net.ParseIP(source)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid trusted source %q", source)
+		}
+		bits := 8 * len(ip)
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 32
+		}
+		networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return networks, nil
+}
+
+// Serve accepts connections on the listener until Close is called. It refuses
+// to serve when no trusted source is set.
+func (s *Server) Serve(l net.Listener) error {
+	if len(s.TrustedSources) == 0 {
+		return ErrNoTrustedSources
+	}
+
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		return ErrServerClosed
+	}
+	s.listener = l
+	s.conns = make(map[net.Conn]struct{})
+	s.mutex.Unlock()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			s.mutex.Lock()
+			closed := s.closed
+			s.mutex.Unlock()
+			if closed {
+				return ErrServerClosed
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			return err
+		}
+
+		s.mutex.Lock()
+		if s.closed {
+			s.mutex.Unlock()
+			conn.Close()
+			return ErrServerClosed
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mutex.Unlock()
+
+		go func() {
+			defer s.wg.Done()
+			s.handleConn(conn)
+
+			s.mutex.Lock()
+			delete(s.conns, conn)
+			s.mutex.Unlock()
+		}()
+	}
+}
+
+// ListenAndServe listens on the TCP address and serves connections.
+func (s *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// Close stops accepting connections and closes the open ones.
+func (s *Server) Close() error {
+	s.mutex.Lock()
+	s.closed = true
+	var err error
+	if s.listener != nil {
+		err = s.listener.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mutex.Unlock()
+
+	s.wg.Wait()
+	return err
+}
+
+// session is the state of a client connection.
+type session struct {
+	server *Server
+	conn   net.Conn
+	reader *bufio.Reader
+	writer *bufio.Writer
+
+	greeted    bool
+	from       string
+	hasFrom    bool
+	recipients []string
+}
+
+// isTrusted returns true if the connection comes from a trusted source.
+func (s *Server) isTrusted(conn net.Conn) bool {
+	addr, ok := conn.RemoteAddr().(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	for _, network := range s.TrustedSources {
+		if network.Contains(addr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	if !s.isTrusted(conn) {
+		if s.Logger != nil {
+			s.Logger.Warn("Refused an inbound email connection from an untrusted source", mlog.String("remote_addr", conn.RemoteAddr().String()))
+		}
+		conn.SetDeadline(time.Now().Add(10 * time.Second))
+		fmt.Fprintf(conn, "554 Access denied\r\n")
+		return
+	}
+
+	sess := &session{
+		server: s,
+		conn:   conn,
+		reader: bufio.NewReaderSize(conn, maxLineLength),
+		writer: bufio.NewWriter(conn),
+	}
+
+	service := "ESMTP"
+	if s.Protocol == ProtocolLMTP {
+		service = "LMTP"
+	}
+	sess.reply(220, s.Hostname+" "+service+" ready")
+
+	for {
+		sess.setDeadline()
+		line, err := sess.readLine()
+		if err != nil {
+			if errors.Is(err, bufio.ErrBufferFull) {
+				sess.reply(500, "Line too long")
+			}
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		if !sess.handleCommand(strings.ToUpper(verb), strings.TrimSpace(arg)) {
+			return
+		}
+	}
+}
+
+func (sess *session) setDeadline() {
+	timeout := sess.server.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	sess.conn.SetDeadline(time.Now().Add(timeout))
+}
+
+func (sess *session) readLine() (string, error) {
+	line, err := sess.reader.ReadSlice('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(line), "\r\n"), nil
+}
+
+func (sess *session) reply(code int, message string) {
+	fmt.Fprintf(sess.writer, "%d %s\r\n", code, message)
+	sess.writer.Flush()
+}
+
+func (sess *session) replyError(err error) {
+	var mailErr *Error
+	if errors.As(err, &mailErr) {
+		sess.reply(mailErr.Code, mailErr.Message)
+		return
+	}
+	sess.reply(451, "Requested action aborted: local error in processing")
+}
+
+func (sess *session) reset() {
+	sess.from = ""
+	sess.hasFrom = false
+	sess.recipients = nil
+}
+
+// handleCommand handles a command, and returns false when the connection
+// must be closed.
+func (sess *session) handleCommand(verb, arg string) bool {
+	lmtp := sess.server.Protocol == ProtocolLMTP
+
+	switch verb {
+	case "HELO", "EHLO", "LHLO":
+		if (verb == "LHLO") != lmtp {
+			sess.reply(500, "Command not recognized")
+			return true
+		}
+		sess.reset()
+		sess.greeted = true
+		if verb == "HELO" {
+			sess.reply(250, sess.server.Hostname)
+			return true
+		}
+		fmt.Fprintf(sess.writer, "250-%s\r\n250-PIPELINING\r\n250-8BITMIME\r\n250 SIZE %d\r\n", sess.server.Hostname, sess.server.MaxMessageSize)
+		sess.writer.Flush()
+
+	case "MAIL":
+		if !sess.greeted {
+			sess.reply(503, "Bad sequence of commands")
+			return true
+		}
+		if sess.hasFrom {
+			sess.reply(503, "Sender already specified")
+			return true
+		}
+		from, params, err := parsePath(arg, "FROM:")
+		if err != nil {
+			sess.reply(501, "Syntax error in MAIL command")
+			return true
+		}
+		if size, ok := params["SIZE"]; ok {
+			if n, err := strconv.ParseInt(size, 10, 64); err == nil && n > sess.server.MaxMessageSize {
+				sess.reply(552, "Message size exceeds fixed maximum message size")
+				return true
+			}
+		}
+		sess.from = from
+		sess.hasFrom = true
+		sess.reply(250, "OK")
+
+	case "RCPT":
+		if !sess.hasFrom {
+			sess.reply(503, "Bad sequence of commands")
+			return true
+		}
+		to, _, err := parsePath(arg, "TO:")
+		if err != nil || to == "" {
+			sess.reply(501, "Syntax error in RCPT command")
+			return true
+		}
+		if len(sess.recipients) >= maxRecipients {
+			sess.reply(452, "Too many recipients")
+			return true
+		}
+		if sess.server.AcceptRecipient != nil {
+			if err := sess.server.AcceptRecipient(to); err != nil {
+				sess.replyError(err)
+				return true
+			}
+		}
+		sess.recipients = append(sess.recipients, to)
+		sess.reply(250, "OK")
+
+	case "DATA":
+		if len(sess.recipients) == 0 {
+			sess.reply(503, "Bad sequence of commands")
+			return true
+		}
+		sess.reply(354, "Start mail input; end with <CRLF>.<CRLF>")
+		return sess.handleData(lmtp)
+
+	case "RSET":
+		sess.reset()
+		sess.reply(250, "OK")
+
+	case "NOOP":
+		sess.reply(250, "OK")
+
+	case "VRFY":
+		sess.reply(252, "Cannot VRFY user, but will accept message and attempt delivery")
+
+	case "QUIT":
+		sess.reply(221, "Bye")
+		return false
+
+	case "STARTTLS", "AUTH":
+		sess.reply(502, "Command not implemented")
+
+	default:
+		sess.reply(500, "Command not recognized")
+	}
+
+	return true
+}
+
+func (sess *session) handleData(lmtp bool) bool {
+	sess.setDeadline()
+
+	// Read one more byte than allowed to detect oversized messages, and
+	// consume the rest so that the connection can be reused.
+	dotReader := textproto.NewReader(sess.reader).DotReader()
+	data, err := io.ReadAll(io.LimitReader(dotReader, sess.server.MaxMessageSize+1))
+	if err == nil && int64(len(data)) > sess.server.MaxMessageSize {
+		_, err = io.Copy(io.Discard, dotReader)
+		if err == nil {
+			sess.reset()
+			sess.reply(552, "Message size exceeds fixed maximum message size")
+			return true
+		}
+	}
+	if err != nil {
+		return false
+	}
+
+	from, recipients := sess.from, sess.recipients
+	sess.reset()
+
+	var firstErr error
+	for _, to := range recipients {
+		err := sess.server.Deliver(from, to, data)
+		if err != nil && sess.server.Logger != nil {
+			sess.server.Logger.Warn("Failed to deliver an inbound email", mlog.String("recipient", to), mlog.Err(err))
+		}
+
+		// LMTP replies for each recipient, while SMTP replies once.
+		if lmtp {
+			if err != nil {
+				sess.replyError(err)
+			} else {
+				sess.reply(250, "OK")
+			}
+		} else if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if !lmtp {
+		if firstErr != nil {
+			sess.replyError(firstErr)
+		} else {
+			sess.reply(250, "OK")
+		}
+	}
+
+	return true
+}
+
+// parsePath parses the argument of the MAIL and RCPT commands, such as
+// "FROM:<user@example.com> SIZE=1024". The null path "<>" is allowed.
+func parsePath(arg, prefix string) (string, map[string]string, error) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", nil, errors.New("missing prefix")
+	}
+	arg = strings.TrimSpace(arg[len(prefix):])
+	if !strings.HasPrefix(arg, "<") {
+		return "", nil, errors.New("missing path")
+	}
+	end := strings.IndexByte(arg, '>')
+	if end < 0 {
+		return "", nil, errors.New("unterminated path")
+	}
+	path := arg[1:end]
+
+	params := make(map[string]string)
+	for _, param := range strings.Fields(arg[end+1:]) {
+		key, value, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(key)] = value
+	}
+
+	if path == "" {
+		return "", params, nil
+	}
+	// Source routes are obsolete and ignored.
+	if i := strings.LastIndexByte(path, ':'); i >= 0 && strings.HasPrefix(path, "@") {
+		path = path[i+1:]
+	}
+	address, err := mail.ParseAddress("<" + path + ">")
+	if err != nil {
+		return "", nil, err
+	}
+	return address.Address, params, nil
+}
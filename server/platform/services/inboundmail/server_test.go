@@ -0,0 +1,219 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package inboundmail
+
+import (
+	"bufio"
+	"net"
+	"net/smtp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type delivery struct {
+	From string
+	To   string
+	Data string
+}
+
+func startTestServer(t *testing.T, protocol string, trustedSources ...string) (string, *[]delivery) {
+	t.Helper()
+
+	var mutex sync.Mutex
+	var deliveries []delivery
+
+	if len(trustedSources) == 0 {
+		trustedSources = []string{"127.0.0.1"}
+	}
+	networks, err := ParseTrustedSources(trustedSources)
+	require.NoError(t, err)
+
+	server := &Server{
+		Hostname:       "chat.example.com",
+		Protocol:       protocol,
+		MaxMessageSize: 1024,
+		TrustedSources: networks,
+		AcceptRecipient: func(address string) error {
+			if !strings.HasPrefix(address, "reply+") {
+				return &Error{Code: 550, Message: "No such user"}
+			}
+			return nil
+		},
+		Deliver: func(from, to string, data []byte) error {
+			if strings.Contains(to, "fail") {
+				return &Error{Code: 554, Message: "Rejected"}
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			deliveries = append(deliveries, delivery{From: from, To: to, Data: string(data)})
+			return nil
+		},
+		Logger: mlog.CreateConsoleTestLogger(t),
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		done <- server.Serve(listener)
+	}()
+	t.Cleanup(func() {
+		require.NoError(t, server.Close())
+		assert.ErrorIs(t, <-done, ErrServerClosed)
+	})
+
+	return listener.Addr().String(), &deliveries
+}
+
+func TestServerSMTP(t *testing.T) {
+	addr, deliveries := startTestServer(t, ProtocolSMTP)
+
+	message := "From: jane@example.com\r\nSubject: Re: Lunch\r\n\r\nSounds good!\r\n.leading dot\r\n"
+	err := smtp.SendMail(addr, nil, "jane@example.com", []string{"reply+abc@chat.example.com"}, []byte(message))
+	require.NoError(t, err)
+
+	require.Len(t, *deliveries, 1)
+	assert.Equal(t, "jane@example.com", (*deliveries)[0].From)
+	assert.Equal(t, "reply+abc@chat.example.com", (*deliveries)[0].To)
+	// Line endings are normalized.
+	assert.Equal(t, strings.ReplaceAll(message, "\r\n", "\n"), (*deliveries)[0].Data)
+
+	t.Run("unknown recipient", func(t *testing.T) {
+		err := smtp.SendMail(addr, nil, "jane@example.com", []string{"jane@chat.example.com"}, []byte(message))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "550")
+	})
+
+	t.Run("rejected message", func(t *testing.T) {
+		err := smtp.SendMail(addr, nil, "jane@example.com", []string{"reply+fail@chat.example.com"}, []byte(message))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "554")
+	})
+
+	t.Run("message too large", func(t *testing.T) {
+		err := smtp.SendMail(addr, nil, "jane@example.com", []string{"reply+abc@chat.example.com"}, []byte(strings.Repeat("a", 2048)))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "552")
+	})
+}
+
+func TestServerLMTP(t *testing.T) {
+	addr, deliveries := startTestServer(t, ProtocolLMTP)
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	expect := func(code string) string {
+		t.Helper()
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(line, code), line)
+		return line
+	}
+	send := func(line string) {
+		t.Helper()
+		_, err := conn.Write([]byte(line + "\r\n"))
+		require.NoError(t, err)
+	}
+
+	expect("220 chat.example.com LMTP")
+
+	send("EHLO client")
+	expect("500")
+
+	send("LHLO client")
+	expect("250-chat.example.com")
+	expect("250-")
+	expect("250-")
+	expect("250 SIZE 1024")
+
+	send("MAIL FROM:<jane@example.com>")
+	expect("250")
+	send("RCPT TO:<reply+abc@chat.example.com>")
+	expect("250")
+	send("RCPT TO:<reply+fail@chat.example.com>")
+	expect("250")
+	send("DATA")
+	expect("354")
+	send("From: jane@example.com\r\n\r\nHello\r\n.")
+
+	// LMTP replies for each recipient.
+	expect("250")
+	expect("554")
+
+	send("QUIT")
+	expect("221")
+
+	require.Len(t, *deliveries, 1)
+	assert.Equal(t, "reply+abc@chat.example.com", (*deliveries)[0].To)
+	assert.Equal(t, "From: jane@example.com\n\nHello\n", (*deliveries)[0].Data)
+}
+
+func TestServerTrustedSources(t *testing.T) {
+	t.Run("untrusted source", func(t *testing.T) {
+		addr, deliveries := startTestServer(t, ProtocolSMTP, "192.0.2.0/24", "2001:db8::1")
+
+		err := smtp.SendMail(addr, nil, "jane@example.com", []string{"reply+abc@chat.example.com"}, []byte("Hello\r\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "554")
+		assert.Empty(t, *deliveries)
+	})
+
+	t.Run("trusted range", func(t *testing.T) {
+		addr, deliveries := startTestServer(t, ProtocolSMTP, "127.0.0.0/8")
+
+		err := smtp.SendMail(addr, nil, "jane@example.com", []string{"reply+abc@chat.example.com"}, []byte("Hello\r\n"))
+		require.NoError(t, err)
+		assert.Len(t, *deliveries, 1)
+	})
+
+	t.Run("no trusted sources", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		server := &Server{Protocol: ProtocolSMTP, MaxMessageSize: 1024}
+		assert.ErrorIs(t, server.Serve(listener), ErrNoTrustedSources)
+	})
+}
+
+func TestParseTrustedSources(t *testing.T) {
+	networks, err := ParseTrustedSources([]string{"10.0.0.1", "192.168.0.0/16", "::1"})
+	require.NoError(t, err)
+	require.Len(t, networks, 3)
+	assert.True(t, networks[0].Contains(net.ParseIP("10.0.0.1")))
+	assert.False(t, networks[0].Contains(net.ParseIP("10.0.0.2")))
+	assert.True(t, networks[1].Contains(net.ParseIP("192.168.1.1")))
+	assert.True(t, networks[2].Contains(net.ParseIP("::1")))
+
+	_, err = ParseTrustedSources([]string{"mx.example.com"})
+	require.Error(t, err)
+}
+
+func TestParsePath(t *testing.T) {
+	address, params, err := parsePath("FROM:<jane@example.com> SIZE=1024 BODY=8BITMIME", "FROM:")
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", address)
+	assert.Equal(t, "1024", params["SIZE"])
+
+	address, _, err = parsePath("from: <>", "FROM:")
+	require.NoError(t, err)
+	assert.Empty(t, address)
+
+	address, _, err = parsePath("TO:<@relay.example.com:reply@chat.example.com>", "TO:")
+	require.NoError(t, err)
+	assert.Equal(t, "reply@chat.example.com", address)
+
+	_, _, err = parsePath("TO:reply@chat.example.com", "TO:")
+	require.Error(t, err)
+}
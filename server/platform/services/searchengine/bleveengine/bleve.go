@@ -39,11 +39,13 @@ type BleveEngine struct {
 	ready        int32
 	cfg          *model.Config
 	indexSync    bool
+	cluster      *clusterClient
 }
 
 var keywordMapping *mapping.FieldMapping
 var standardMapping *mapping.FieldMapping
 var dateMapping *mapping.FieldMapping
+var booleanMapping *mapping.FieldMapping
 
 func init() {
 	keywordMapping = bleve.NewTextFieldMapping()
@@ -53,6 +55,8 @@ func init() {
 	standardMapping.Analyzer = standard.Name
 
 	dateMapping = bleve.NewNumericFieldMapping()
+
+	booleanMapping = bleve.NewBooleanFieldMapping()
 }
 
 func getChannelIndexMapping() *mapping.IndexMappingImpl {
@@ -81,6 +85,9 @@ func getPostIndexMapping() *mapping.IndexMappingImpl {
 	postMapping.AddFieldMappingsAt("Type", keywordMapping)
 	postMapping.AddFieldMappingsAt("Hashtags", standardMapping)
 	postMapping.AddFieldMappingsAt("Attachments", standardMapping)
+	postMapping.AddFieldMappingsAt("IsPinned", booleanMapping)
+	postMapping.AddFieldMappingsAt("HasFiles", booleanMapping)
+	postMapping.AddFieldMappingsAt("HasLink", booleanMapping)
 
 	indexMapping := bleve.NewIndexMapping()
 	indexMapping.AddDocumentMapping("_default", postMapping)
@@ -277,6 +284,10 @@ func (b *BleveEngine) PurgeIndexes(rctx request.CTX) *model.AppError {
 		return nil
 	}
 
+	if b.forwardsToLeader() {
+		return b.sendToLeader(clusterMethodPurgeIndexes, &clusterArgs{})
+	}
+
 	b.Mutex.Lock()
 	defer b.Mutex.Unlock()
 
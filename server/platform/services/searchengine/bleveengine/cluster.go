@@ -0,0 +1,320 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package bleveengine
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+)
+
+// In a cluster, the indexes of the leader node are the only ones used. The
+// other nodes forward every change to the leader and ask it to run their
+// searches, so that all the nodes see the same results. The leader answers
+// both, so that the changes it could not apply are reported by the node which
+// forwarded them.
+
+const clusterRequestTimeout = 10 * time.Second
+
+const (
+	clusterMethodIndexPost            = "IndexPost"
+	clusterMethodDeletePost           = "DeletePost"
+	clusterMethodDeleteChannelPosts   = "DeleteChannelPosts"
+	clusterMethodDeleteUserPosts      = "DeleteUserPosts"
+	clusterMethodIndexChannel         = "IndexChannel"
+	clusterMethodDeleteChannel        = "DeleteChannel"
+	clusterMethodIndexUser            = "IndexUser"
+	clusterMethodDeleteUser           = "DeleteUser"
+	clusterMethodIndexFile            = "IndexFile"
+	clusterMethodDeleteFile           = "DeleteFile"
+	clusterMethodDeleteUserFiles      = "DeleteUserFiles"
+	clusterMethodDeletePostFiles      = "DeletePostFiles"
+	clusterMethodDeleteFilesBatch     = "DeleteFilesBatch"
+	clusterMethodPurgeIndexes         = "PurgeIndexes"
+	clusterMethodSearchPosts          = "SearchPosts"
+	clusterMethodSearchFiles          = "SearchFiles"
+	clusterMethodSearchChannels       = "SearchChannels"
+	clusterMethodSearchUsersInChannel = "SearchUsersInChannel"
+	clusterMethodSearchUsersInTeam    = "SearchUsersInTeam"
+)
+
+// ClusterService is the part of the platform the engine needs to share its
+// indexes between the nodes of a cluster.
+type ClusterService interface {
+	IsLeader() bool
+	Cluster() einterfaces.ClusterInterface
+}
+
+// clusterArgs holds the arguments of any of the forwarded methods. Only the
+// fields used by the method are set.
+type clusterArgs struct {
+	Post                 *model.Post              `json:"post,omitempty"`
+	Channel              *model.Channel           `json:"channel,omitempty"`
+	User                 *model.User              `json:"user,omitempty"`
+	File                 *model.FileInfo          `json:"file,omitempty"`
+	TeamId               string                   `json:"team_id,omitempty"`
+	ChannelId            string                   `json:"channel_id,omitempty"`
+	UserId               string                   `json:"user_id,omitempty"`
+	PostId               string                   `json:"post_id,omitempty"`
+	FileId               string                   `json:"file_id,omitempty"`
+	UserIds              []string                 `json:"user_ids,omitempty"`
+	TeamMemberIds        []string                 `json:"team_member_ids,omitempty"`
+	TeamsIds             []string                 `json:"teams_ids,omitempty"`
+	ChannelsIds          []string                 `json:"channels_ids,omitempty"`
+	RestrictedToChannels []string                 `json:"restricted_to_channels"`
+	Channels             model.ChannelList        `json:"channels,omitempty"`
+	SearchParams         []*model.SearchParams    `json:"search_params,omitempty"`
+	Page                 int                      `json:"page,omitempty"`
+	PerPage              int                      `json:"per_page,omitempty"`
+	Term                 string                   `json:"term,omitempty"`
+	IsGuest              bool                     `json:"is_guest,omitempty"`
+	Options              *model.UserSearchOptions `json:"options,omitempty"`
+	EndTime              int64                    `json:"end_time,omitempty"`
+	Limit                int64                    `json:"limit,omitempty"`
+}
+
+type clusterResult struct {
+	Ids      []string                `json:"ids"`
+	OtherIds []string                `json:"other_ids,omitempty"`
+	Matches  model.PostSearchMatches `json:"matches,omitempty"`
+}
+
+type clusterRequest struct {
+	Id     string       `json:"id"`
+	NodeId string       `json:"node_id"`
+	Method string       `json:"method"`
+	Args   *clusterArgs `json:"args"`
+}
+
+type clusterResponse struct {
+	Id     string          `json:"id"`
+	Result *clusterResult  `json:"result,omitempty"`
+	Error  *model.AppError `json:"error,omitempty"`
+}
+
+type clusterClient struct {
+	service ClusterService
+	logger  mlog.LoggerIFace
+
+	pendingMut sync.Mutex
+	pending    map[string]chan *clusterResponse
+}
+
+// SetCluster makes the engine share the indexes of the cluster leader when
+// the server runs in a cluster.
+func (b *BleveEngine) SetCluster(service ClusterService, logger mlog.LoggerIFace) {
+	b.Mutex.Lock()
+	defer b.Mutex.Unlock()
+
+	b.cluster = &clusterClient{
+		service: service,
+		logger:  logger,
+		pending: map[string]chan *clusterResponse{},
+	}
+}
+
+// OwnsIndexes reports whether this node's indexes are the ones used by the
+// cluster. It is always the case outside of a cluster.
+func (b *BleveEngine) OwnsIndexes() bool {
+	return !b.forwardsToLeader()
+}
+
+func (b *BleveEngine) forwardsToLeader() bool {
+	b.Mutex.RLock()
+	defer b.Mutex.RUnlock()
+
+	if b.cluster == nil || b.cluster.service.Cluster() == nil {
+		return false
+	}
+	return *b.cfg.ClusterSettings.Enable && !b.cluster.service.IsLeader()
+}
+
+// sendToLeader forwards a change to the indexes of the leader and waits for
+// it to be applied.
+func (b *BleveEngine) sendToLeader(method string, args *clusterArgs) *model.AppError {
+	if _, appErr := b.requestFromLeader(method, args); appErr != nil {
+		b.cluster.logger.Warn("Failed to forward the Bleve change to the leader", mlog.String("method", method), mlog.Err(appErr))
+		return appErr
+	}
+	return nil
+}
+
+// requestFromLeader runs a change or a search on the leader and waits for its
+// results.
+func (b *BleveEngine) requestFromLeader(method string, args *clusterArgs) (*clusterResult, *model.AppError) {
+	req := &clusterRequest{
+		Id:     model.NewId(),
+		NodeId: b.cluster.service.Cluster().GetMyClusterInfo().Id,
+		Method: method,
+		Args:   args,
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, model.NewAppError("Bleveengine.requestFromLeader", "bleveengine.cluster.encode_request.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	responseCh := make(chan *clusterResponse, 1)
+	b.cluster.pendingMut.Lock()
+	b.cluster.pending[req.Id] = responseCh
+	b.cluster.pendingMut.Unlock()
+	defer func() {
+		b.cluster.pendingMut.Lock()
+		delete(b.cluster.pending, req.Id)
+		b.cluster.pendingMut.Unlock()
+	}()
+
+	b.cluster.service.Cluster().SendClusterMessage(&model.ClusterMessage{
+		Event:    model.ClusterEventBleveRequest,
+		SendType: model.ClusterSendReliable,
+		Data:     data,
+	})
+
+	select {
+	case response := <-responseCh:
+		if response.Error != nil {
+			return nil, response.Error
+		}
+		if response.Result == nil {
+			return &clusterResult{Ids: []string{}}, nil
+		}
+		return response.Result, nil
+	case <-time.After(clusterRequestTimeout):
+		return nil, model.NewAppError("Bleveengine.requestFromLeader", "bleveengine.cluster.request_timeout.error", nil, "method="+method, http.StatusGatewayTimeout)
+	}
+}
+
+// HandleClusterRequest applies a change or runs a search forwarded by another
+// node. Only the leader handles them.
+func (b *BleveEngine) HandleClusterRequest(msg *model.ClusterMessage) {
+	if b.cluster == nil || b.forwardsToLeader() {
+		return
+	}
+
+	var req clusterRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil || req.Args == nil {
+		b.cluster.logger.Warn("Failed to decode the forwarded Bleve request", mlog.Err(err))
+		return
+	}
+
+	// Searches may take a while, so they don't hold up the changes sent
+	// after them. Changes are applied in the order they were sent.
+	switch req.Method {
+	case clusterMethodSearchPosts, clusterMethodSearchFiles, clusterMethodSearchChannels, clusterMethodSearchUsersInChannel, clusterMethodSearchUsersInTeam:
+		go b.respondToSearch(&req)
+	default:
+		response := &clusterResponse{Id: req.Id}
+		if response.Error = b.applyClusterChange(&req); response.Error != nil {
+			b.cluster.logger.Warn("Failed to apply the forwarded Bleve change", mlog.String("method", req.Method), mlog.Err(response.Error))
+		}
+		b.respond(&req, response)
+	}
+}
+
+func (b *BleveEngine) applyClusterChange(req *clusterRequest) *model.AppError {
+	rctx := request.EmptyContext(b.cluster.logger)
+	args := req.Args
+
+	switch req.Method {
+	case clusterMethodIndexPost:
+		return b.IndexPost(args.Post, args.TeamId)
+	case clusterMethodDeletePost:
+		return b.DeletePost(args.Post)
+	case clusterMethodDeleteChannelPosts:
+		return b.DeleteChannelPosts(rctx, args.ChannelId)
+	case clusterMethodDeleteUserPosts:
+		return b.DeleteUserPosts(rctx, args.UserId)
+	case clusterMethodIndexChannel:
+		return b.IndexChannel(rctx, args.Channel, args.UserIds, args.TeamMemberIds)
+	case clusterMethodDeleteChannel:
+		return b.DeleteChannel(args.Channel)
+	case clusterMethodIndexUser:
+		return b.IndexUser(rctx, args.User, args.TeamsIds, args.ChannelsIds)
+	case clusterMethodDeleteUser:
+		return b.DeleteUser(args.User)
+	case clusterMethodIndexFile:
+		return b.IndexFile(args.File, args.ChannelId)
+	case clusterMethodDeleteFile:
+		return b.DeleteFile(args.FileId)
+	case clusterMethodDeleteUserFiles:
+		return b.DeleteUserFiles(rctx, args.UserId)
+	case clusterMethodDeletePostFiles:
+		return b.DeletePostFiles(rctx, args.PostId)
+	case clusterMethodDeleteFilesBatch:
+		return b.DeleteFilesBatch(rctx, args.EndTime, args.Limit)
+	case clusterMethodPurgeIndexes:
+		return b.PurgeIndexes(rctx)
+	default:
+		return model.NewAppError("Bleveengine.applyClusterChange", "bleveengine.cluster.unknown_method.error", nil, "method="+req.Method, http.StatusBadRequest)
+	}
+}
+
+func (b *BleveEngine) respondToSearch(req *clusterRequest) {
+	args := req.Args
+	response := &clusterResponse{Id: req.Id, Result: &clusterResult{}}
+
+	switch req.Method {
+	case clusterMethodSearchPosts:
+		response.Result.Ids, response.Result.Matches, response.Error = b.SearchPosts(args.Channels, args.SearchParams, args.Page, args.PerPage)
+	case clusterMethodSearchFiles:
+		response.Result.Ids, response.Error = b.SearchFiles(args.Channels, args.SearchParams, args.Page, args.PerPage)
+	case clusterMethodSearchChannels:
+		response.Result.Ids, response.Error = b.SearchChannels(args.TeamId, args.UserId, args.Term, args.IsGuest)
+	case clusterMethodSearchUsersInChannel:
+		response.Result.Ids, response.Result.OtherIds, response.Error = b.SearchUsersInChannel(args.TeamId, args.ChannelId, args.RestrictedToChannels, args.Term, args.Options)
+	case clusterMethodSearchUsersInTeam:
+		response.Result.Ids, response.Error = b.SearchUsersInTeam(args.TeamId, args.RestrictedToChannels, args.Term, args.Options)
+	}
+
+	b.respond(req, response)
+}
+
+// respond sends the response to a forwarded request to the node waiting for it.
+func (b *BleveEngine) respond(req *clusterRequest, response *clusterResponse) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		b.cluster.logger.Warn("Failed to encode the Bleve response", mlog.String("method", req.Method), mlog.Err(err))
+		return
+	}
+
+	if err := b.cluster.service.Cluster().SendClusterMessageToNode(req.NodeId, &model.ClusterMessage{
+		Event:    model.ClusterEventBleveResponse,
+		SendType: model.ClusterSendReliable,
+		Data:     data,
+	}); err != nil {
+		b.cluster.logger.Warn("Failed to send the Bleve response", mlog.String("method", req.Method), mlog.String("node_id", req.NodeId), mlog.Err(err))
+	}
+}
+
+// HandleClusterResponse passes the results of a change or a search run by the
+// leader to the request waiting for them.
+func (b *BleveEngine) HandleClusterResponse(msg *model.ClusterMessage) {
+	if b.cluster == nil {
+		return
+	}
+
+	var response clusterResponse
+	if err := json.Unmarshal(msg.Data, &response); err != nil {
+		b.cluster.logger.Warn("Failed to decode the Bleve response", mlog.Err(err))
+		return
+	}
+
+	b.cluster.pendingMut.Lock()
+	responseCh, ok := b.cluster.pending[response.Id]
+	b.cluster.pendingMut.Unlock()
+	if !ok {
+		return
+	}
+
+	// The channel holds a single response, any duplicate is dropped.
+	select {
+	case responseCh <- &response:
+	default:
+	}
+}
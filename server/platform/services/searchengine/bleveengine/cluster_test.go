@@ -0,0 +1,98 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package bleveengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
+)
+
+type testClusterService struct {
+	leader  bool
+	cluster *mocks.ClusterInterface
+}
+
+func (s *testClusterService) IsLeader() bool {
+	return s.leader
+}
+
+func (s *testClusterService) Cluster() einterfaces.ClusterInterface {
+	return s.cluster
+}
+
+func startClusterTestEngine(t *testing.T, service *testClusterService) *BleveEngine {
+	t.Helper()
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.BleveSettings.EnableIndexing = model.NewPointer(true)
+	cfg.BleveSettings.EnableSearching = model.NewPointer(true)
+	cfg.BleveSettings.IndexDir = model.NewPointer(t.TempDir())
+	cfg.ClusterSettings.Enable = model.NewPointer(true)
+
+	engine := NewBleveEngine(cfg)
+	require.Nil(t, engine.Start())
+	t.Cleanup(func() {
+		require.Nil(t, engine.Stop())
+	})
+
+	engine.SetCluster(service, mlog.CreateConsoleTestLogger(t))
+	return engine
+}
+
+func TestBleveEngineCluster(t *testing.T) {
+	leaderCluster := &mocks.ClusterInterface{}
+	followerCluster := &mocks.ClusterInterface{}
+
+	leader := startClusterTestEngine(t, &testClusterService{leader: true, cluster: leaderCluster})
+	follower := startClusterTestEngine(t, &testClusterService{leader: false, cluster: followerCluster})
+
+	followerCluster.On("GetMyClusterInfo").Return(&model.ClusterInfo{Id: "follower"})
+	followerCluster.On("SendClusterMessage", mock.Anything).Run(func(args mock.Arguments) {
+		leader.HandleClusterRequest(args.Get(0).(*model.ClusterMessage))
+	})
+	leaderCluster.On("SendClusterMessageToNode", "follower", mock.Anything).Run(func(args mock.Arguments) {
+		follower.HandleClusterResponse(args.Get(1).(*model.ClusterMessage))
+	}).Return(nil)
+
+	assert.True(t, leader.OwnsIndexes())
+	assert.False(t, follower.OwnsIndexes())
+
+	channelID := model.NewId()
+	post := createPost(model.NewId(), channelID)
+	post.Message = "indexed through the leader"
+	require.Nil(t, follower.IndexPost(post, model.NewId()))
+
+	t.Run("changes are applied to the leader's indexes", func(t *testing.T) {
+		count, err := leader.PostIndex.DocCount()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), count)
+
+		count, err = follower.PostIndex.DocCount()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), count)
+	})
+
+	t.Run("searches are run by the leader", func(t *testing.T) {
+		ids, _, appErr := follower.SearchPosts(model.ChannelList{{Id: channelID}}, []*model.SearchParams{{Terms: "leader"}}, 0, 20)
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{post.Id}, ids)
+	})
+
+	t.Run("deletions are applied to the leader's indexes", func(t *testing.T) {
+		require.Nil(t, follower.DeletePost(post))
+
+		count, err := leader.PostIndex.DocCount()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), count)
+	})
+}
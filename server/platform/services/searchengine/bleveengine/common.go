@@ -37,6 +37,9 @@ type BLVPost struct {
 	Type        string
 	Hashtags    []string
 	Attachments string
+	IsPinned    bool
+	HasFiles    bool
+	HasLink     bool
 }
 
 type BLVFile struct {
@@ -128,6 +131,9 @@ func BLVPostFromPostForIndexing(post *model.PostForIndexing) *BLVPost {
 		Message:   post.Message,
 		Type:      post.Type,
 		Hashtags:  strings.Fields(post.Hashtags),
+		IsPinned:  post.IsPinned,
+		HasFiles:  len(post.FileIds) > 0,
+		HasLink:   strings.Contains(post.Message, "http://") || strings.Contains(post.Message, "https://"),
 	}
 }
 
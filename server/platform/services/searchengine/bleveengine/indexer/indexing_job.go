@@ -150,6 +150,12 @@ func (worker *BleveIndexerWorker) DoJob(job *model.Job) {
 	logger := worker.logger.With(jobs.JobLoggerFields(job)...)
 	logger.Debug("Worker: Received a new candidate job.")
 
+	// In a cluster, only the node whose indexes are used runs the job.
+	if !worker.engine.OwnsIndexes() {
+		logger.Debug("Worker: Skipping job as the indexes are owned by the cluster leader")
+		return
+	}
+
 	claimed, err := worker.jobServer.ClaimJob(job)
 	if err != nil {
 		logger.Warn("Worker: Error occurred while trying to claim job", mlog.Err(err))
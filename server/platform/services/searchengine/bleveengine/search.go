@@ -5,6 +5,7 @@ package bleveengine
 
 import (
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/blevesearch/bleve/v2"
@@ -18,7 +19,19 @@ import (
 const DeletePostsBatchSize = 500
 const DeleteFilesBatchSize = 500
 
+// postFilterFields are the fields of the indexed posts for the values of the
+// has: and is: filters which can be searched.
+var postFilterFields = map[string]string{
+	model.SearchHasFile:  "HasFiles",
+	model.SearchHasLink:  "HasLink",
+	model.SearchIsPinned: "IsPinned",
+}
+
 func (b *BleveEngine) IndexPost(post *model.Post, teamId string) *model.AppError {
+	if b.forwardsToLeader() {
+		return b.sendToLeader(clusterMethodIndexPost, &clusterArgs{Post: post, TeamId: teamId})
+	}
+
 	b.Mutex.RLock()
 	defer b.Mutex.RUnlock()
 
@@ -29,7 +42,28 @@ func (b *BleveEngine) IndexPost(post *model.Post, teamId string) *model.AppError
 	return nil
 }
 
+func (b *BleveEngine) SupportsSearchFilter(filter string) bool {
+	if filter == string(model.SearchQueryOr) {
+		return true
+	}
+
+	name, value, _ := strings.Cut(filter, ":")
+	if name != model.SearchFilterHas && name != model.SearchFilterIs {
+		return false
+	}
+	_, ok := postFilterFields[value]
+	return ok
+}
+
 func (b *BleveEngine) SearchPosts(channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, *model.AppError) {
+	if b.forwardsToLeader() {
+		result, appErr := b.requestFromLeader(clusterMethodSearchPosts, &clusterArgs{Channels: channels, SearchParams: searchParams, Page: page, PerPage: perPage})
+		if appErr != nil {
+			return nil, nil, appErr
+		}
+		return result.Ids, result.Matches, nil
+	}
+
 	channelQueries := []query.Query{}
 	for _, channel := range channels {
 		channelIdQ := bleve.NewTermQuery(channel.Id)
@@ -38,6 +72,53 @@ func (b *BleveEngine) SearchPosts(channels model.ChannelList, searchParams []*mo
 	}
 	channelDisjunctionQ := bleve.NewDisjunctionQuery(channelQueries...)
 
+	// Each group of params is an alternative of an advanced search
+	var groupQueries []query.Query
+	for _, group := range groupSearchParams(searchParams) {
+		groupQueries = append(groupQueries, postSearchQuery(channelDisjunctionQ, group))
+	}
+
+	searchQuery := groupQueries[0]
+	if len(groupQueries) > 1 {
+		searchQuery = bleve.NewDisjunctionQuery(groupQueries...)
+	}
+
+	search := bleve.NewSearchRequestOptions(searchQuery, perPage, page*perPage, false)
+	search.SortBy([]string{"-CreateAt"})
+	results, err := b.PostIndex.Search(search)
+	if err != nil {
+		return nil, nil, model.NewAppError("Bleveengine.SearchPosts", "bleveengine.search_posts.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	postIds := []string{}
+	matches := model.PostSearchMatches{}
+
+	for _, r := range results.Hits {
+		postIds = append(postIds, r.ID)
+	}
+
+	return postIds, matches, nil
+}
+
+// groupSearchParams splits the params by group, keeping their order.
+func groupSearchParams(searchParams []*model.SearchParams) [][]*model.SearchParams {
+	var groups [][]*model.SearchParams
+	indexes := map[int]int{}
+	for _, params := range searchParams {
+		index, ok := indexes[params.Group]
+		if !ok {
+			index = len(groups)
+			indexes[params.Group] = index
+			groups = append(groups, nil)
+		}
+		groups[index] = append(groups[index], params)
+	}
+	return groups
+}
+
+// postSearchQuery returns the query for the posts of the channels matching a
+// group of params.
+func postSearchQuery(channelDisjunctionQ query.Query, searchParams []*model.SearchParams) query.Query {
 	var termQueries []query.Query
 	var notTermQueries []query.Query
 	var filters []query.Query
@@ -97,6 +178,18 @@ func (b *BleveEngine) SearchPosts(channels model.ChannelList, searchParams []*mo
 				notFilters = append(notFilters, bleve.NewDisjunctionQuery(excludedUsers...))
 			}
 
+			for _, value := range append(slices.Clone(params.HasFilters), params.IsFilters...) {
+				filterQ := bleve.NewBoolFieldQuery(true)
+				filterQ.SetField(postFilterFields[value])
+				filters = append(filters, filterQ)
+			}
+
+			for _, value := range append(slices.Clone(params.ExcludedHasFilters), params.ExcludedIsFilters...) {
+				filterQ := bleve.NewBoolFieldQuery(true)
+				filterQ.SetField(postFilterFields[value])
+				notFilters = append(notFilters, filterQ)
+			}
+
 			if params.OnDate != "" {
 				before, after := params.GetOnDateMillis()
 				beforeFloat64 := float64(before)
@@ -211,21 +304,7 @@ func (b *BleveEngine) SearchPosts(channels model.ChannelList, searchParams []*mo
 		query.AddMustNot(notFilters...)
 	}
 
-	search := bleve.NewSearchRequestOptions(query, perPage, page*perPage, false)
-	search.SortBy([]string{"-CreateAt"})
-	results, err := b.PostIndex.Search(search)
-	if err != nil {
-		return nil, nil, model.NewAppError("Bleveengine.SearchPosts", "bleveengine.search_posts.error", nil, "", http.StatusInternalServerError).Wrap(err)
-	}
-
-	postIds := []string{}
-	matches := model.PostSearchMatches{}
-
-	for _, r := range results.Hits {
-		postIds = append(postIds, r.ID)
-	}
-
-	return postIds, matches, nil
+	return query
 }
 
 func (b *BleveEngine) deletePosts(searchRequest *bleve.SearchRequest, batchSize int) (int64, error) {
@@ -257,6 +336,10 @@ func (b *BleveEngine) deletePosts(searchRequest *bleve.SearchRequest, batchSize
 }
 
 func (b *BleveEngine) DeleteChannelPosts(rctx request.CTX, channelID string) *model.AppError {
+	if b.forwardsToLeader() {
+		return b.sendToLeader(clusterMethodDeleteChannelPosts, &clusterArgs{ChannelId: channelID})
+	}
+
 	b.Mutex.RLock()
 	defer b.Mutex.RUnlock()
 
@@ -276,6 +359,10 @@ func (b *BleveEngine) DeleteChannelPosts(rctx request.CTX, channelID string) *mo
 }
 
 func (b *BleveEngine) DeleteUserPosts(rctx request.CTX, userID string) *model.AppError {
+	if b.forwardsToLeader() {
+		return b.sendToLeader(clusterMethodDeleteUserPosts, &clusterArgs{UserId: userID})
+	}
+
 	b.Mutex.RLock()
 	defer b.Mutex.RUnlock()
 
@@ -295,6 +382,10 @@ func (b *BleveEngine) DeleteUserPosts(rctx request.CTX, userID string) *model.Ap
 }
 
 func (b *BleveEngine) DeletePost(post *model.Post) *model.AppError {
+	if b.forwardsToLeader() {
+		return b.sendToLeader(clusterMethodDeletePost, &clusterArgs{Post: &model.Post{Id: post.Id}})
+	}
+
 	b.Mutex.RLock()
 	defer b.Mutex.RUnlock()
 
@@ -305,6 +396,10 @@ func (b *BleveEngine) DeletePost(post *model.Post) *model.AppError {
 }
 
 func (b *BleveEngine) IndexChannel(_ request.CTX, channel *model.Channel, userIDs, teamMemberIDs []string) *model.AppError {
+	if b.forwardsToLeader() {
+		return b.sendToLeader(clusterMethodIndexChannel, &clusterArgs{Channel: channel, UserIds: userIDs, TeamMemberIds: teamMemberIDs})
+	}
+
 	b.Mutex.RLock()
 	defer b.Mutex.RUnlock()
 
@@ -316,6 +411,14 @@ func (b *BleveEngine) IndexChannel(_ request.CTX, channel *model.Channel, userID
 }
 
 func (b *BleveEngine) SearchChannels(teamId, userID, term string, isGuest bool) ([]string, *model.AppError) {
+	if b.forwardsToLeader() {
+		result, appErr := b.requestFromLeader(clusterMethodSearchChannels, &clusterArgs{TeamId: teamId, UserId: userID, Term: term, IsGuest: isGuest})
+		if appErr != nil {
+			return nil, appErr
+		}
+		return result.Ids, nil
+	}
+
 	// This query essentially boils down to (if teamID is passed):
 	// match teamID == <>
 	// AND
@@ -394,6 +497,10 @@ func (b *BleveEngine) SearchChannels(teamId, userID, term string, isGuest bool)
 }
 
 func (b *BleveEngine) DeleteChannel(channel *model.Channel) *model.AppError {
+	if b.forwardsToLeader() {
+		return b.sendToLeader(clusterMethodDeleteChannel, &clusterArgs{Channel: &model.Channel{Id: channel.Id}})
+	}
+
 	b.Mutex.RLock()
 	defer b.Mutex.RUnlock()
 
@@ -404,6 +511,10 @@ func (b *BleveEngine) DeleteChannel(channel *model.Channel) *model.AppError {
 }
 
 func (b *BleveEngine) IndexUser(_ request.CTX, user *model.User, teamsIds, channelsIds []string) *model.AppError {
+	if b.forwardsToLeader() {
+		return b.sendToLeader(clusterMethodIndexUser, &clusterArgs{User: user, TeamsIds: teamsIds, ChannelsIds: channelsIds})
+	}
+
 	b.Mutex.RLock()
 	defer b.Mutex.RUnlock()
 
@@ -415,6 +526,14 @@ func (b *BleveEngine) IndexUser(_ request.CTX, user *model.User, teamsIds, chann
 }
 
 func (b *BleveEngine) SearchUsersInChannel(teamId, channelId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, []string, *model.AppError) {
+	if b.forwardsToLeader() {
+		result, appErr := b.requestFromLeader(clusterMethodSearchUsersInChannel, &clusterArgs{TeamId: teamId, ChannelId: channelId, RestrictedToChannels: restrictedToChannels, Term: term, Options: options})
+		if appErr != nil {
+			return nil, nil, appErr
+		}
+		return result.Ids, result.OtherIds, nil
+	}
+
 	if restrictedToChannels != nil && len(restrictedToChannels) == 0 {
 		return []string{}, []string{}, nil
 	}
@@ -495,6 +614,14 @@ func (b *BleveEngine) SearchUsersInChannel(teamId, channelId string, restrictedT
 }
 
 func (b *BleveEngine) SearchUsersInTeam(teamId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, *model.AppError) {
+	if b.forwardsToLeader() {
+		result, appErr := b.requestFromLeader(clusterMethodSearchUsersInTeam, &clusterArgs{TeamId: teamId, RestrictedToChannels: restrictedToChannels, Term: term, Options: options})
+		if appErr != nil {
+			return nil, appErr
+		}
+		return result.Ids, nil
+	}
+
 	if restrictedToChannels != nil && len(restrictedToChannels) == 0 {
 		return []string{}, nil
 	}
@@ -553,6 +680,10 @@ func (b *BleveEngine) SearchUsersInTeam(teamId string, restrictedToChannels []st
 }
 
 func (b *BleveEngine) DeleteUser(user *model.User) *model.AppError {
+	if b.forwardsToLeader() {
+		return b.sendToLeader(clusterMethodDeleteUser, &clusterArgs{User: &model.User{Id: user.Id}})
+	}
+
 	b.Mutex.RLock()
 	defer b.Mutex.RUnlock()
 
@@ -563,6 +694,10 @@ func (b *BleveEngine) DeleteUser(user *model.User) *model.AppError {
 }
 
 func (b *BleveEngine) IndexFile(file *model.FileInfo, channelId string) *model.AppError {
+	if b.forwardsToLeader() {
+		return b.sendToLeader(clusterMethodIndexFile, &clusterArgs{File: file, ChannelId: channelId})
+	}
+
 	b.Mutex.RLock()
 	defer b.Mutex.RUnlock()
 
@@ -574,6 +709,14 @@ func (b *BleveEngine) IndexFile(file *model.FileInfo, channelId string) *model.A
 }
 
 func (b *BleveEngine) SearchFiles(channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, *model.AppError) {
+	if b.forwardsToLeader() {
+		result, appErr := b.requestFromLeader(clusterMethodSearchFiles, &clusterArgs{Channels: channels, SearchParams: searchParams, Page: page, PerPage: perPage})
+		if appErr != nil {
+			return nil, appErr
+		}
+		return result.Ids, nil
+	}
+
 	channelQueries := []query.Query{}
 	for _, channel := range channels {
 		channelIdQ := bleve.NewTermQuery(channel.Id)
@@ -782,6 +925,10 @@ func (b *BleveEngine) SearchFiles(channels model.ChannelList, searchParams []*mo
 }
 
 func (b *BleveEngine) DeleteFile(fileID string) *model.AppError {
+	if b.forwardsToLeader() {
+		return b.sendToLeader(clusterMethodDeleteFile, &clusterArgs{FileId: fileID})
+	}
+
 	b.Mutex.RLock()
 	defer b.Mutex.RUnlock()
 
@@ -820,6 +967,10 @@ func (b *BleveEngine) deleteFiles(searchRequest *bleve.SearchRequest, batchSize
 }
 
 func (b *BleveEngine) DeleteUserFiles(rctx request.CTX, userID string) *model.AppError {
+	if b.forwardsToLeader() {
+		return b.sendToLeader(clusterMethodDeleteUserFiles, &clusterArgs{UserId: userID})
+	}
+
 	b.Mutex.RLock()
 	defer b.Mutex.RUnlock()
 
@@ -839,6 +990,10 @@ func (b *BleveEngine) DeleteUserFiles(rctx request.CTX, userID string) *model.Ap
 }
 
 func (b *BleveEngine) DeletePostFiles(rctx request.CTX, postID string) *model.AppError {
+	if b.forwardsToLeader() {
+		return b.sendToLeader(clusterMethodDeletePostFiles, &clusterArgs{PostId: postID})
+	}
+
 	b.Mutex.RLock()
 	defer b.Mutex.RUnlock()
 
@@ -858,6 +1013,10 @@ func (b *BleveEngine) DeletePostFiles(rctx request.CTX, postID string) *model.Ap
 }
 
 func (b *BleveEngine) DeleteFilesBatch(rctx request.CTX, endTime, limit int64) *model.AppError {
+	if b.forwardsToLeader() {
+		return b.sendToLeader(clusterMethodDeleteFilesBatch, &clusterArgs{EndTime: endTime, Limit: limit})
+	}
+
 	b.Mutex.RLock()
 	defer b.Mutex.RUnlock()
 
@@ -52,3 +52,11 @@ type SearchEngineInterface interface {
 	DataRetentionDeleteIndexes(rctx request.CTX, cutoff time.Time) *model.AppError
 	IsChannelsIndexVerified() bool
 }
+
+// SearchFiltersInterface is implemented by the engines able to run some of the
+// features of the advanced search syntax: alternatives, whose params have
+// different groups, and the filters returned by SearchParams.PostFilters.
+// Engines which don't implement it are considered to support none of them.
+type SearchFiltersInterface interface {
+	SupportsSearchFilter(filter string) bool
+}
@@ -4,6 +4,8 @@
 package searchengine
 
 import (
+	"net/http"
+
 	"github.com/mattermost/mattermost/server/public/model"
 )
 
@@ -61,3 +63,24 @@ func (seb *Broker) ActiveEngine() string {
 	}
 	return "database"
 }
+
+// CheckSearchFilters returns an error when the engine can't run some of the
+// features of the advanced search syntax used by the params.
+func CheckSearchFilters(engine SearchEngineInterface, paramsList []*model.SearchParams) *model.AppError {
+	filters := []string{}
+	for _, params := range paramsList {
+		if params.Group != 0 {
+			filters = append(filters, string(model.SearchQueryOr))
+		}
+		filters = append(filters, params.PostFilters()...)
+	}
+
+	supporter, _ := engine.(SearchFiltersInterface)
+	for _, filter := range filters {
+		if supporter == nil || !supporter.SupportsSearchFilter(filter) {
+			return model.NewAppError("CheckSearchFilters", "searchengine.unsupported_search_filter.app_error", map[string]any{"Engine": engine.GetName(), "Filter": filter}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
@@ -4,11 +4,13 @@
 package searchengine
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestActiveEngine(t *testing.T) {
@@ -40,3 +42,42 @@ func TestActiveEngine(t *testing.T) {
 
 	assert.Equal(t, "none", b.ActiveEngine())
 }
+
+type filterSearchEngine struct {
+	*mocks.SearchEngineInterface
+	supported []string
+}
+
+func (e *filterSearchEngine) SupportsSearchFilter(filter string) bool {
+	return slices.Contains(e.supported, filter)
+}
+
+func TestCheckSearchFilters(t *testing.T) {
+	engine := &mocks.SearchEngineInterface{}
+	engine.On("GetName").Return("elasticsearch")
+
+	plain := []*model.SearchParams{{Terms: "word"}, {Terms: "#hashtag", IsHashtag: true}}
+	alternatives := []*model.SearchParams{{Terms: "apple"}, {Terms: "banana", Group: 1}}
+	filtered := []*model.SearchParams{{Terms: "word", IsFilters: []string{model.SearchIsPinned}, MinReactions: 2}}
+
+	t.Run("engines without support for filters", func(t *testing.T) {
+		assert.Nil(t, CheckSearchFilters(engine, plain))
+
+		appErr := CheckSearchFilters(engine, alternatives)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "searchengine.unsupported_search_filter.app_error", appErr.Id)
+
+		assert.NotNil(t, CheckSearchFilters(engine, filtered))
+	})
+
+	t.Run("engines supporting some filters", func(t *testing.T) {
+		supporter := &filterSearchEngine{SearchEngineInterface: engine, supported: []string{string(model.SearchQueryOr), "is:pinned"}}
+
+		assert.Nil(t, CheckSearchFilters(supporter, plain))
+		assert.Nil(t, CheckSearchFilters(supporter, alternatives))
+		assert.NotNil(t, CheckSearchFilters(supporter, filtered))
+
+		supporter.supported = append(supporter.supported, model.SearchFilterReactions)
+		assert.Nil(t, CheckSearchFilters(supporter, filtered))
+	})
+}
@@ -18,7 +18,17 @@ import (
 )
 
 // postsToAttachments returns the file attachments for a slice of posts that need to be synchronized.
+// Quarantined files are skipped until the antivirus scan reports them clean.
 func (scs *Service) shouldSyncAttachment(fi *model.FileInfo, rc *model.RemoteCluster) bool {
+	if fi.IsQuarantined() {
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceDebug, "skipping quarantined shared channel attachment",
+			mlog.String("file_id", fi.Id),
+			mlog.String("remote_id", rc.RemoteId),
+			mlog.String("scan_status", fi.ScanStatus),
+		)
+		return false
+	}
+
 	sca, err := scs.server.GetStore().SharedChannel().GetAttachment(fi.Id, rc.RemoteId)
 	if err != nil {
 		if _, ok := err.(errNotFound); !ok {
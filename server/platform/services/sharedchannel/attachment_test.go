@@ -0,0 +1,65 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+func TestShouldSyncAttachment(t *testing.T) {
+	rc := &model.RemoteCluster{RemoteId: model.NewId()}
+
+	setup := func(t *testing.T) (*Service, *mocks.SharedChannelStore) {
+		mockSharedChannelStore := &mocks.SharedChannelStore{}
+		mockStore := &mocks.Store{}
+		mockStore.On("SharedChannel").Return(mockSharedChannelStore)
+
+		mockServer := &MockServerIface{}
+		mockServer.On("Log").Return(mlog.CreateConsoleTestLogger(t))
+		mockServer.On("GetStore").Return(mockStore)
+
+		return &Service{server: mockServer, app: &MockAppIface{}}, mockSharedChannelStore
+	}
+
+	for _, status := range []string{model.FileScanStatusPending, model.FileScanStatusInfected, model.FileScanStatusFailed} {
+		t.Run("skips "+status+" files", func(t *testing.T) {
+			scs, mockSharedChannelStore := setup(t)
+			fi := &model.FileInfo{Id: model.NewId(), ScanStatus: status, UpdateAt: model.GetMillis()}
+
+			assert.False(t, scs.shouldSyncAttachment(fi, rc))
+			mockSharedChannelStore.AssertNotCalled(t, "GetAttachment")
+		})
+	}
+
+	t.Run("syncs clean files never synced", func(t *testing.T) {
+		scs, mockSharedChannelStore := setup(t)
+		fi := &model.FileInfo{Id: model.NewId(), ScanStatus: model.FileScanStatusClean, UpdateAt: model.GetMillis()}
+		mockSharedChannelStore.On("GetAttachment", fi.Id, rc.RemoteId).Return(nil, store.NewErrNotFound("SharedChannelAttachment", fi.Id))
+
+		assert.True(t, scs.shouldSyncAttachment(fi, rc))
+	})
+
+	t.Run("syncs files updated since the last sync", func(t *testing.T) {
+		scs, mockSharedChannelStore := setup(t)
+		fi := &model.FileInfo{Id: model.NewId(), UpdateAt: model.GetMillis()}
+		mockSharedChannelStore.On("GetAttachment", fi.Id, rc.RemoteId).Return(&model.SharedChannelAttachment{LastSyncAt: fi.UpdateAt - 1}, nil)
+
+		assert.True(t, scs.shouldSyncAttachment(fi, rc))
+	})
+
+	t.Run("skips files already synced", func(t *testing.T) {
+		scs, mockSharedChannelStore := setup(t)
+		fi := &model.FileInfo{Id: model.NewId(), UpdateAt: model.GetMillis()}
+		mockSharedChannelStore.On("GetAttachment", fi.Id, rc.RemoteId).Return(&model.SharedChannelAttachment{LastSyncAt: fi.UpdateAt}, nil)
+
+		assert.False(t, scs.shouldSyncAttachment(fi, rc))
+	})
+}
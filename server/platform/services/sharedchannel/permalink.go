@@ -15,9 +15,10 @@ import (
 )
 
 var (
-	// Team name regex taken from model.IsValidTeamName
-	permaLinkRegex       = regexp.MustCompile(`https?://[0-9.\-A-Za-z]+/[a-z0-9]+([a-z\-0-9]+|(__)?)[a-z0-9]+/pl/([a-zA-Z0-9]+)`)
-	permaLinkSharedRegex = regexp.MustCompile(`https?://[0-9.\-A-Za-z]+/[a-z0-9]+([a-z\-0-9]+|(__)?)[a-z0-9]+/plshared/([a-zA-Z0-9]+)`)
+	// Team name regex taken from model.IsValidTeamName. The site URL might have
+	// a port and a subpath.
+	permaLinkRegex       = regexp.MustCompile(`https?://[0-9.\-A-Za-z]+(:[0-9]+)?(/[0-9.\-_~A-Za-z]+)*/[a-z0-9]+([a-z\-0-9]+|(__)?)[a-z0-9]+/pl/([a-zA-Z0-9]+)`)
+	permaLinkSharedRegex = regexp.MustCompile(`https?://[0-9.\-A-Za-z]+(:[0-9]+)?(/[0-9.\-_~A-Za-z]+)*/[a-z0-9]+([a-z\-0-9]+|(__)?)[a-z0-9]+/plshared/([a-zA-Z0-9]+)`)
 )
 
 const (
@@ -27,10 +28,7 @@ const (
 // processPermalinkToRemote processes all permalinks going towards a remote site.
 func (scs *Service) processPermalinkToRemote(p *model.Post) string {
 	var sent bool
-	return permaLinkRegex.ReplaceAllStringFunc(p.Message, func(msg string) string {
-		// Extract the postID (This is simple enough not to warrant full-blown URL parsing.)
-		lastSlash := strings.LastIndexByte(msg, '/')
-		postID := msg[lastSlash+1:]
+	return RewritePermalinks(p.Message, func(msg, postID string) string {
 		opts := model.GetPostsOptions{
 			SkipFetchThreads: true,
 		}
@@ -63,22 +61,49 @@ func (scs *Service) processPermalinkToRemote(p *model.Post) string {
 // processPermalinkFromRemote processes all permalinks coming from a remote site.
 func (scs *Service) processPermalinkFromRemote(p *model.Post, team *model.Team) string {
 	return permaLinkSharedRegex.ReplaceAllStringFunc(p.Message, func(remoteLink string) string {
-		// Extract host name
-		parsed, err := url.Parse(remoteLink)
+		postID := remoteLink[strings.LastIndexByte(remoteLink, '/')+1:]
+
+		// Replace with local SiteURL and team, and plshared with pl
+		link, err := RelocatePermalink(remoteLink, nil, scs.siteURL, team.Name, postID)
 		if err != nil {
 			scs.server.Log().Log(mlog.LvlSharedChannelServiceWarn, "Unable to parse the remote link during replacing permalinks", mlog.Err(err))
 			return remoteLink
 		}
+		return link
+	})
+}
 
-		// Replace with local SiteURL
-		parsed.Scheme = scs.siteURL.Scheme
-		parsed.Host = scs.siteURL.Host
+// RewritePermalinks replaces every permalink in message with the result of
+// rewrite, which is given the permalink and the ID of the post it points to.
+func RewritePermalinks(message string, rewrite func(permalink, postID string) string) string {
+	return permaLinkRegex.ReplaceAllStringFunc(message, func(permalink string) string {
+		// Extract the postID (This is simple enough not to warrant full-blown URL parsing.)
+		lastSlash := strings.LastIndexByte(permalink, '/')
+		return rewrite(permalink, permalink[lastSlash+1:])
+	})
+}
 
-		// Replace team name with local team
-		teamEnd := strings.Index(parsed.Path, "/"+permalinkMarker)
-		parsed.Path = "/" + team.Name + parsed.Path[teamEnd:]
+// RelocatePermalink returns permalink pointing to postID on siteURL. The team
+// of the permalink is replaced with teamName, unless it is empty, in which case
+// it is read from the permalink after the path of sourceSiteURL, if any.
+func RelocatePermalink(permalink string, sourceSiteURL, siteURL *url.URL, teamName, postID string) (string, error) {
+	parsed, err := url.Parse(permalink)
+	if err != nil {
+		return "", err
+	}
 
-		// Replace plshared with pl
-		return strings.Replace(parsed.String(), "/"+permalinkMarker+"/", "/pl/", 1)
-	})
+	if teamName == "" {
+		path := parsed.Path
+		if sourceSiteURL != nil {
+			path = strings.TrimPrefix(path, strings.TrimSuffix(sourceSiteURL.Path, "/"))
+		}
+		teamName, _, _ = strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	}
+
+	parsed.Scheme = siteURL.Scheme
+	parsed.Host = siteURL.Host
+	parsed.Path = strings.TrimSuffix(siteURL.Path, "/") + "/" + teamName + "/pl/" + postID
+	parsed.RawPath = ""
+
+	return parsed.String(), nil
 }
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/plugin/plugintest/mock"
@@ -111,3 +112,88 @@ func TestProcessPermalinkFromRemote(t *testing.T) {
 			out)
 	})
 }
+
+func TestRewritePermalinks(t *testing.T) {
+	siteURL, _ := url.Parse("https://chat.example.com")
+
+	out := RewritePermalinks("see https://old.example.com/team/pl/postid1 and http://old.example.com/other-team/pl/postid2?x=1 but not https://old.example.com/team/channels/town-square",
+		func(permalink, postID string) string {
+			link, err := RelocatePermalink(permalink, nil, siteURL, "", "new"+postID)
+			require.NoError(t, err)
+			return link
+		})
+	assert.Equal(t,
+		"see https://chat.example.com/team/pl/newpostid1 and https://chat.example.com/other-team/pl/newpostid2?x=1 but not https://old.example.com/team/channels/town-square",
+		out)
+}
+
+func TestRelocatePermalink(t *testing.T) {
+	for name, test := range map[string]struct {
+		Permalink     string
+		SourceSiteURL string
+		SiteURL       string
+		TeamName      string
+		Expected      string
+	}{
+		"no subpaths": {
+			Permalink: "https://old.example.com/team/pl/postid",
+			SiteURL:   "https://chat.example.com",
+			Expected:  "https://chat.example.com/team/pl/newid",
+		},
+		"team name": {
+			Permalink: "https://old.example.com/team/pl/postid",
+			SiteURL:   "https://chat.example.com",
+			TeamName:  "other-team",
+			Expected:  "https://chat.example.com/other-team/pl/newid",
+		},
+		"target subpath": {
+			Permalink: "https://old.example.com/team/pl/postid",
+			SiteURL:   "https://chat.example.com/mattermost/",
+			Expected:  "https://chat.example.com/mattermost/team/pl/newid",
+		},
+		"source subpath": {
+			Permalink:     "https://old.example.com/old/team/pl/postid",
+			SourceSiteURL: "https://old.example.com/old",
+			SiteURL:       "https://chat.example.com",
+			Expected:      "https://chat.example.com/team/pl/newid",
+		},
+		"subpaths on both sides": {
+			Permalink:     "http://old.example.com:8065/old/chat/team/pl/postid?x=1",
+			SourceSiteURL: "http://old.example.com:8065/old/chat/",
+			SiteURL:       "https://chat.example.com/mattermost",
+			Expected:      "https://chat.example.com/mattermost/team/pl/newid?x=1",
+		},
+		"subpaths on both sides with a team name": {
+			Permalink:     "https://old.example.com/old/team/plshared/postid",
+			SourceSiteURL: "https://old.example.com/old",
+			SiteURL:       "https://chat.example.com/mattermost",
+			TeamName:      "myteam",
+			Expected:      "https://chat.example.com/mattermost/myteam/pl/newid",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var sourceSiteURL *url.URL
+			if test.SourceSiteURL != "" {
+				var err error
+				sourceSiteURL, err = url.Parse(test.SourceSiteURL)
+				require.NoError(t, err)
+			}
+			siteURL, err := url.Parse(test.SiteURL)
+			require.NoError(t, err)
+
+			link, err := RelocatePermalink(test.Permalink, sourceSiteURL, siteURL, test.TeamName, "newid")
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, link)
+		})
+	}
+}
+
+func TestRewritePermalinksWithSubpath(t *testing.T) {
+	var postIDs []string
+	out := RewritePermalinks("see https://old.example.com:8065/old/chat/team/pl/postid1 and https://old.example.com/team/pl/postid2", func(permalink, postID string) string {
+		postIDs = append(postIDs, postID)
+		return "<" + permalink + ">"
+	})
+	assert.Equal(t, []string{"postid1", "postid2"}, postIDs)
+	assert.Equal(t, "see <https://old.example.com:8065/old/chat/team/pl/postid1> and <https://old.example.com/team/pl/postid2>", out)
+}
@@ -78,6 +78,8 @@ const (
 	TrackConfigExport              = "config_export"
 	TrackConfigWrangler            = "config_wrangler"
 	TrackConfigConnectedWorkspaces = "config_connected_workspaces"
+	TrackConfigScim                = "config_scim"
+	TrackConfigDataLossPrevention  = "config_data_loss_prevention"
 	TrackFeatureFlags              = "config_feature_flags"
 	TrackPermissionsGeneral        = "permissions_general"
 	TrackPermissionsSystemScheme   = "permissions_system_scheme"
@@ -476,6 +478,11 @@ func (ts *TelemetryService) trackConfig() {
 		"enable_outgoing_oauth_connections":                       cfg.ServiceSettings.EnableOutgoingOAuthConnections,
 		"enable_commands":                                         *cfg.ServiceSettings.EnableCommands,
 		"outgoing_integrations_requests_timeout":                  cfg.ServiceSettings.OutgoingIntegrationRequestsTimeout,
+		"outgoing_webhook_max_delivery_attempts":                  *cfg.ServiceSettings.OutgoingWebhookMaxDeliveryAttempts,
+		"outgoing_webhook_retry_backoff_seconds":                  *cfg.ServiceSettings.OutgoingWebhookRetryBackoffSeconds,
+		"outgoing_webhook_max_backoff_seconds":                    *cfg.ServiceSettings.OutgoingWebhookMaxBackoffSeconds,
+		"outgoing_webhook_history_days":                           *cfg.ServiceSettings.OutgoingWebhookHistoryDays,
+		"integration_signing_secret_grace_hours":                  *cfg.ServiceSettings.IntegrationSigningSecretGraceHours,
 		"enable_post_username_override":                           cfg.ServiceSettings.EnablePostUsernameOverride,
 		"enable_post_icon_override":                               cfg.ServiceSettings.EnablePostIconOverride,
 		"enable_user_access_tokens":                               *cfg.ServiceSettings.EnableUserAccessTokens,
@@ -489,6 +496,10 @@ func (ts *TelemetryService) trackConfig() {
 		"enable_client_performance_debugging":                     *cfg.ServiceSettings.EnableClientPerformanceDebugging,
 		"enable_multifactor_authentication":                       *cfg.ServiceSettings.EnableMultifactorAuthentication,
 		"enforce_multifactor_authentication":                      *cfg.ServiceSettings.EnforceMultifactorAuthentication,
+		"multifactor_authentication_methods":                      strings.Join(cfg.ServiceSettings.MultifactorAuthenticationMethods, ","),
+		"enforced_multifactor_method":                             *cfg.ServiceSettings.EnforcedMultifactorMethod,
+		"enable_mfa_recovery_codes":                               *cfg.ServiceSettings.EnableMfaRecoveryCodes,
+		"enable_passwordless_login":                               *cfg.ServiceSettings.EnablePasswordlessLogin,
 		"enable_oauth_service_provider":                           cfg.ServiceSettings.EnableOAuthServiceProvider,
 		"connection_security":                                     *cfg.ServiceSettings.ConnectionSecurity,
 		"tls_strict_transport":                                    *cfg.ServiceSettings.TLSStrictTransport,
@@ -515,8 +526,11 @@ func (ts *TelemetryService) trackConfig() {
 		"cors_debug":                                              *cfg.ServiceSettings.CorsDebug,
 		"isdefault_allowed_untrusted_internal_connections":        isDefault(*cfg.ServiceSettings.AllowedUntrustedInternalConnections, ""),
 		"post_edit_time_limit":                                    *cfg.ServiceSettings.PostEditTimeLimit,
+		"post_edit_history_limit":                                 *cfg.ServiceSettings.PostEditHistoryLimit,
 		"enable_user_typing_messages":                             *cfg.ServiceSettings.EnableUserTypingMessages,
 		"enable_channel_viewed_messages":                          *cfg.ServiceSettings.EnableChannelViewedMessages,
+		"enable_read_receipts":                                    *cfg.ServiceSettings.EnableReadReceipts,
+		"read_receipts_max_channel_members":                       *cfg.ServiceSettings.ReadReceiptsMaxChannelMembers,
 		"time_between_user_typing_updates_milliseconds":           *cfg.ServiceSettings.TimeBetweenUserTypingUpdatesMilliseconds,
 		"cluster_log_timeout_milliseconds":                        *cfg.ServiceSettings.ClusterLogTimeoutMilliseconds,
 		"enable_post_search":                                      *cfg.ServiceSettings.EnablePostSearch,
@@ -558,6 +572,7 @@ func (ts *TelemetryService) trackConfig() {
 		"persistent_notification_max_count":                       *cfg.ServiceSettings.PersistentNotificationMaxCount,
 		"persistent_notification_max_recipients":                  *cfg.ServiceSettings.PersistentNotificationMaxRecipients,
 		"allow_synced_drafts":                                     *cfg.ServiceSettings.AllowSyncedDrafts,
+		"scheduled_posts":                                         *cfg.ServiceSettings.ScheduledPosts,
 		"refresh_post_stats_run_time":                             *cfg.ServiceSettings.RefreshPostStatsRunTime,
 		"maximum_payload_size":                                    *cfg.ServiceSettings.MaximumPayloadSizeBytes,
 		"maximum_url_length":                                      *cfg.ServiceSettings.MaximumURLLength,
@@ -605,6 +620,8 @@ func (ts *TelemetryService) trackConfig() {
 		"data_source_search_replicas":          len(cfg.SqlSettings.DataSourceSearchReplicas),
 		"query_timeout":                        *cfg.SqlSettings.QueryTimeout,
 		"disable_database_search":              *cfg.SqlSettings.DisableDatabaseSearch,
+		"database_search_ranking":              *cfg.SqlSettings.DatabaseSearchRanking,
+		"text_search_configs_count":            len(cfg.SqlSettings.TextSearchConfigs),
 		"migrations_statement_timeout_seconds": *cfg.SqlSettings.MigrationsStatementTimeoutSeconds,
 		"replica_monitor_interval_seconds":     *cfg.SqlSettings.ReplicaMonitorIntervalSeconds,
 	})
@@ -651,22 +668,25 @@ func (ts *TelemetryService) trackConfig() {
 	})
 
 	ts.SendTelemetry(TrackConfigFile, map[string]any{
-		"enable_public_links":           cfg.FileSettings.EnablePublicLink,
-		"driver_name":                   *cfg.FileSettings.DriverName,
-		"isdefault_directory":           isDefault(*cfg.FileSettings.Directory, model.FileSettingsDefaultDirectory),
-		"isabsolute_directory":          filepath.IsAbs(*cfg.FileSettings.Directory),
-		"extract_content":               *cfg.FileSettings.ExtractContent,
-		"archive_recursion":             *cfg.FileSettings.ArchiveRecursion,
-		"amazon_s3_ssl":                 *cfg.FileSettings.AmazonS3SSL,
-		"amazon_s3_sse":                 *cfg.FileSettings.AmazonS3SSE,
-		"amazon_s3_signv2":              *cfg.FileSettings.AmazonS3SignV2,
-		"amazon_s3_trace":               *cfg.FileSettings.AmazonS3Trace,
-		"max_file_size":                 *cfg.FileSettings.MaxFileSize,
-		"max_image_resolution":          *cfg.FileSettings.MaxImageResolution,
-		"max_image_decoder_concurrency": *cfg.FileSettings.MaxImageDecoderConcurrency,
-		"enable_file_attachments":       *cfg.FileSettings.EnableFileAttachments,
-		"enable_mobile_upload":          *cfg.FileSettings.EnableMobileUpload,
-		"enable_mobile_download":        *cfg.FileSettings.EnableMobileDownload,
+		"enable_public_links":            cfg.FileSettings.EnablePublicLink,
+		"driver_name":                    *cfg.FileSettings.DriverName,
+		"isdefault_directory":            isDefault(*cfg.FileSettings.Directory, model.FileSettingsDefaultDirectory),
+		"isabsolute_directory":           filepath.IsAbs(*cfg.FileSettings.Directory),
+		"extract_content":                *cfg.FileSettings.ExtractContent,
+		"archive_recursion":              *cfg.FileSettings.ArchiveRecursion,
+		"amazon_s3_ssl":                  *cfg.FileSettings.AmazonS3SSL,
+		"amazon_s3_sse":                  *cfg.FileSettings.AmazonS3SSE,
+		"amazon_s3_signv2":               *cfg.FileSettings.AmazonS3SignV2,
+		"amazon_s3_trace":                *cfg.FileSettings.AmazonS3Trace,
+		"max_file_size":                  *cfg.FileSettings.MaxFileSize,
+		"max_image_resolution":           *cfg.FileSettings.MaxImageResolution,
+		"max_image_decoder_concurrency":  *cfg.FileSettings.MaxImageDecoderConcurrency,
+		"enable_file_attachments":        *cfg.FileSettings.EnableFileAttachments,
+		"enable_mobile_upload":           *cfg.FileSettings.EnableMobileUpload,
+		"enable_mobile_download":         *cfg.FileSettings.EnableMobileDownload,
+		"enable_encryption_at_rest":      *cfg.FileSettings.EnableEncryptionAtRest,
+		"enable_antivirus_scan":          *cfg.FileSettings.EnableAntivirusScan,
+		"antivirus_scan_timeout_seconds": *cfg.FileSettings.AntivirusScanTimeoutSeconds,
 	})
 
 	ts.SendTelemetry(TrackConfigEmail, map[string]any{
@@ -694,6 +714,10 @@ func (ts *TelemetryService) trackConfig() {
 		"isdefault_login_button_border_color":  isDefault(*cfg.EmailSettings.LoginButtonBorderColor, ""),
 		"isdefault_login_button_text_color":    isDefault(*cfg.EmailSettings.LoginButtonTextColor, ""),
 		"smtp_server_timeout":                  *cfg.EmailSettings.SMTPServerTimeout,
+		"enable_reply_by_email":                *cfg.EmailSettings.EnableReplyByEmail,
+		"inbound_email_protocol":               *cfg.EmailSettings.InboundEmailProtocol,
+		"inbound_email_max_message_size":       *cfg.EmailSettings.InboundEmailMaxMessageSize,
+		"enable_web_push_notifications":        *cfg.EmailSettings.EnableWebPushNotifications,
 	})
 
 	ts.SendTelemetry(TrackConfigRate, map[string]any{
@@ -963,6 +987,16 @@ func (ts *TelemetryService) trackConfig() {
 		"max_posts_per_sync":                  *cfg.ConnectedWorkspacesSettings.MaxPostsPerSync,
 	})
 
+	ts.SendTelemetry(TrackConfigScim, map[string]any{
+		"enable":            *cfg.ScimSettings.Enable,
+		"user_auth_service": *cfg.ScimSettings.UserAuthService,
+	})
+
+	ts.SendTelemetry(TrackConfigDataLossPrevention, map[string]any{
+		"enable":          *cfg.DataLossPreventionSettings.Enable,
+		"number_of_rules": len(cfg.DataLossPreventionSettings.Rules),
+	})
+
 	// Convert feature flags to map[string]any for sending
 	flags := cfg.FeatureFlags.ToMap()
 	interfaceFlags := make(map[string]any)
@@ -5,83 +5,215 @@ package tracing
 
 import (
 	"context"
-	"io"
+	"fmt"
+	"net/http"
+	"os"
 	"time"
 
-	opentracing "github.com/opentracing/opentracing-go"
-	"github.com/uber/jaeger-client-go"
-	jaegercfg "github.com/uber/jaeger-client-go/config"
-	"github.com/uber/jaeger-client-go/zipkin"
-	"github.com/uber/jaeger-lib/metrics"
-
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
-// Tracer is a wrapper around Jaeger OpenTracing client, used to properly de-initialize jaeger on exit
+const (
+	serviceName     = "mattermost"
+	instrumentation = "github.com/mattermost/mattermost/server/v8"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+// Tracer is a wrapper around the OpenTelemetry tracer provider, used to flush
+// the pending spans and properly de-initialize the exporter on exit.
 type Tracer struct {
-	closer io.Closer
+	provider *sdktrace.TracerProvider
 }
 
-type LogrusAdapter struct {
+// New instantiates an OpenTelemetry tracer provider exporting spans with OTLP,
+// and registers it globally along with the W3C trace context propagator.
+// The exporter and sampler are configured through the standard OTEL_* environment
+// variables, e.g. OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_EXPORTER_OTLP_PROTOCOL or
+// OTEL_TRACES_SAMPLER. See https://opentelemetry.io/docs/specs/otel/protocol/exporter/
+func New() (*Tracer, error) {
+	exporter, err := newExporter(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(model.CurrentVersion),
+		attribute.String("server.start_time", time.Now().UTC().Format(time.RFC3339)),
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the tracing resource")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		mlog.Error("OpenTelemetry error", mlog.Err(err))
+	}))
+
+	mlog.Info("OpenTelemetry tracing initialized")
+	return &Tracer{
+		provider: provider,
+	}, nil
+}
+
+func newExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	protocol := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+	if protocol == "" {
+		protocol = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+
+	switch protocol {
+	case "", "http/protobuf":
+		return otlptracehttp.New(ctx)
+	case "grpc":
+		return otlptracegrpc.New(ctx)
+	}
+	return nil, fmt.Errorf("unsupported OTLP protocol %q", protocol)
+}
+
+func (t *Tracer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return t.provider.Shutdown(ctx)
 }
 
-// Error - logrus adapter for span errors
-func (LogrusAdapter) Error(msg string) {
-	mlog.Error(msg)
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentation)
 }
 
-// Infof - logrus adapter for span info logging
-func (LogrusAdapter) Infof(msg string, args ...any) {
-	// we ignore Info messages from opentracing
+// StartRootSpanByContext starts a span continuing the trace of the context, which
+// might be a remote one extracted from a request, or a new trace otherwise.
+func StartRootSpanByContext(ctx context.Context, operationName string, opts ...trace.SpanStartOption) (trace.Span, context.Context) {
+	ctx, span := tracer().Start(ctx, operationName, opts...)
+	return span, ctx
 }
 
-// New instantiates Jaeger opentracing client with default options
-// To override the defaults use environment variables listed here: https://github.com/jaegertracing/jaeger-client-go/blob/master/config/config.go
-func New() (*Tracer, error) {
-	cfg := jaegercfg.Configuration{
-		Sampler: &jaegercfg.SamplerConfig{
-			Type:  jaeger.SamplerTypeConst,
-			Param: 1,
-		},
-		Reporter: &jaegercfg.ReporterConfig{
-			LogSpans: true,
-		},
+// StartSpanWithParentByContext starts a child span of the span of the context.
+func StartSpanWithParentByContext(ctx context.Context, operationName string) (trace.Span, context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
 	}
+	ctx, span := tracer().Start(ctx, operationName)
+	return span, ctx
+}
 
-	zipkinPropagator := zipkin.NewZipkinB3HTTPHeaderPropagator()
+// SetAttribute records a parameter of the traced operation on the span.
+func SetAttribute(span trace.Span, key string, value any) {
+	switch v := value.(type) {
+	case string:
+		span.SetAttributes(attribute.String(key, v))
+	case bool:
+		span.SetAttributes(attribute.Bool(key, v))
+	case int:
+		span.SetAttributes(attribute.Int(key, v))
+	case int64:
+		span.SetAttributes(attribute.Int64(key, v))
+	case float64:
+		span.SetAttributes(attribute.Float64(key, v))
+	case []string:
+		span.SetAttributes(attribute.StringSlice(key, v))
+	case fmt.Stringer:
+		span.SetAttributes(attribute.Stringer(key, v))
+	default:
+		span.SetAttributes(attribute.String(key, fmt.Sprintf("%v", v)))
+	}
+}
 
-	closer, err := cfg.InitGlobalTracer(
-		"mattermost",
-		jaegercfg.Logger(LogrusAdapter{}),
-		jaegercfg.Metrics(metrics.NullFactory),
-		jaegercfg.Tag("serverStartTime", time.Now().UTC().Format(time.RFC3339)),
-		jaegercfg.Injector(opentracing.HTTPHeaders, zipkinPropagator),
-		jaegercfg.Extractor(opentracing.HTTPHeaders, zipkinPropagator),
-		jaegercfg.ZipkinSharedRPCSpan(true),
-	)
-	if err != nil {
-		return nil, err
+// RecordError records the error on the span and flags the span as failed.
+func RecordError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// InjectContext returns the trace context to be propagated to another process,
+// or nil if there is no trace to propagate.
+func InjectContext(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
 	}
-	mlog.Info("Opentracing initialized")
-	return &Tracer{
-		closer: closer,
-	}, nil
+
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
 }
 
-func (t *Tracer) Close() error {
-	return t.closer.Close()
+// ExtractContext returns a context carrying the trace context propagated by another process.
+func ExtractContext(ctx context.Context, carrier map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// InjectHTTPHeaders adds the trace context to the headers of an HTTP request.
+func InjectHTTPHeaders(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
 }
 
-func StartRootSpanByContext(ctx context.Context, operationName string) (opentracing.Span, context.Context) {
-	return opentracing.StartSpanFromContext(ctx, operationName)
+// ExtractHTTPHeaders returns a context carrying the trace context of an HTTP request.
+func ExtractHTTPHeaders(ctx context.Context, header http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// StartHTTPServerSpan starts the span of a request received from a client. The
+// trace context sent by clients isn't trusted, so the span starts a new trace
+// which is only linked to the trace of the client, if any.
+func StartHTTPServerSpan(ctx context.Context, header http.Header, operationName string) (trace.Span, context.Context) {
+	opts := []trace.SpanStartOption{trace.WithNewRoot(), trace.WithSpanKind(trace.SpanKindServer)}
+	if remote := trace.SpanContextFromContext(ExtractHTTPHeaders(context.Background(), header)); remote.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: remote}))
+	}
+
+	ctx, span := tracer().Start(ctx, operationName, opts...)
+	return span, ctx
 }
 
-func StartSpanWithParentByContext(ctx context.Context, operationName string) (opentracing.Span, context.Context) {
-	parentSpan := opentracing.SpanFromContext(ctx)
+// InjectClusterMessage adds the trace context to the properties of a cluster
+// message, so that the handling of the message on other nodes joins the trace.
+func InjectClusterMessage(ctx context.Context, msg *model.ClusterMessage) {
+	traceContext := InjectContext(ctx)
+	if len(traceContext) == 0 {
+		return
+	}
 
-	if parentSpan == nil {
-		return StartRootSpanByContext(ctx, operationName)
+	if msg.Props == nil {
+		msg.Props = make(map[string]string, len(traceContext))
+	}
+	for key, value := range traceContext {
+		msg.Props[key] = value
 	}
+}
+
+// ClusterMessageContext returns a context carrying the trace context of a
+// cluster message, for the handlers to continue the trace of its handling.
+func ClusterMessageContext(ctx context.Context, msg *model.ClusterMessage) context.Context {
+	return ExtractContext(ctx, msg.Props)
+}
 
-	return opentracing.StartSpanFromContext(ctx, operationName, opentracing.ChildOf(parentSpan.Context()))
+// StartClusterMessageSpan starts the span of the handling of a cluster message,
+// continuing the trace of the node which sent it if any.
+func StartClusterMessageSpan(msg *model.ClusterMessage) (trace.Span, context.Context) {
+	ctx := ExtractContext(context.Background(), msg.Props)
+	ctx, span := tracer().Start(ctx, "cluster:"+string(msg.Event), trace.WithSpanKind(trace.SpanKindConsumer))
+	return span, ctx
 }
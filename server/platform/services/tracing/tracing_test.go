@@ -0,0 +1,155 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func setupTestTracer(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	prevProvider := otel.GetTracerProvider()
+	prevPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	return recorder
+}
+
+func TestInjectContext(t *testing.T) {
+	setupTestTracer(t)
+
+	t.Run("no span", func(t *testing.T) {
+		assert.Nil(t, InjectContext(context.Background()))
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		span, ctx := StartRootSpanByContext(context.Background(), "test")
+		defer span.End()
+
+		carrier := InjectContext(ctx)
+		require.NotEmpty(t, carrier)
+
+		extracted := trace.SpanContextFromContext(ExtractContext(context.Background(), carrier))
+		assert.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+		assert.Equal(t, span.SpanContext().SpanID(), extracted.SpanID())
+		assert.True(t, extracted.IsRemote())
+	})
+}
+
+func TestHTTPHeaders(t *testing.T) {
+	setupTestTracer(t)
+
+	span, ctx := StartRootSpanByContext(context.Background(), "test")
+	defer span.End()
+
+	header := http.Header{}
+	InjectHTTPHeaders(ctx, header)
+	require.NotEmpty(t, header.Get("traceparent"))
+
+	extracted := trace.SpanContextFromContext(ExtractHTTPHeaders(context.Background(), header))
+	assert.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+}
+
+func TestStartHTTPServerSpan(t *testing.T) {
+	recorder := setupTestTracer(t)
+
+	t.Run("without trace", func(t *testing.T) {
+		span, _ := StartHTTPServerSpan(context.Background(), http.Header{}, "server")
+		span.End()
+
+		ended := recorder.Ended()
+		require.NotEmpty(t, ended)
+		received := ended[len(ended)-1]
+		assert.Equal(t, trace.SpanKindServer, received.SpanKind())
+		assert.False(t, received.Parent().IsValid())
+		assert.Empty(t, received.Links())
+	})
+
+	t.Run("starts a new trace linked to the trace of the client", func(t *testing.T) {
+		clientSpan, clientCtx := StartRootSpanByContext(context.Background(), "client")
+		clientSpan.End()
+		header := http.Header{}
+		InjectHTTPHeaders(clientCtx, header)
+
+		span, ctx := StartHTTPServerSpan(context.Background(), header, "server")
+		span.End()
+
+		ended := recorder.Ended()
+		require.NotEmpty(t, ended)
+		received := ended[len(ended)-1]
+		assert.NotEqual(t, clientSpan.SpanContext().TraceID(), received.SpanContext().TraceID())
+		assert.False(t, received.Parent().IsValid())
+		require.Len(t, received.Links(), 1)
+		assert.Equal(t, clientSpan.SpanContext().SpanID(), received.Links()[0].SpanContext.SpanID())
+		assert.Equal(t, received.SpanContext().TraceID(), trace.SpanContextFromContext(ctx).TraceID())
+	})
+}
+
+func TestClusterMessage(t *testing.T) {
+	recorder := setupTestTracer(t)
+
+	t.Run("without trace", func(t *testing.T) {
+		msg := &model.ClusterMessage{Event: model.ClusterEventPublish}
+		InjectClusterMessage(context.Background(), msg)
+		assert.Nil(t, msg.Props)
+	})
+
+	t.Run("continues the trace of the sender", func(t *testing.T) {
+		span, ctx := StartRootSpanByContext(context.Background(), "sender")
+		span.End()
+
+		msg := &model.ClusterMessage{
+			Event: model.ClusterEventPublish,
+			Props: map[string]string{"EventID": "id"},
+		}
+		InjectClusterMessage(ctx, msg)
+		assert.Equal(t, "id", msg.Props["EventID"])
+		assert.NotEmpty(t, msg.Props["traceparent"])
+
+		receiverSpan, _ := StartClusterMessageSpan(msg)
+		receiverSpan.End()
+
+		ended := recorder.Ended()
+		require.NotEmpty(t, ended)
+		received := ended[len(ended)-1]
+		assert.Equal(t, "cluster:"+string(model.ClusterEventPublish), received.Name())
+		assert.Equal(t, trace.SpanKindConsumer, received.SpanKind())
+		assert.Equal(t, span.SpanContext().TraceID(), received.SpanContext().TraceID())
+		assert.Equal(t, span.SpanContext().SpanID(), received.Parent().SpanID())
+	})
+
+	t.Run("passes the trace context to the handler", func(t *testing.T) {
+		span, ctx := StartRootSpanByContext(context.Background(), "sender")
+		span.End()
+
+		msg := &model.ClusterMessage{Event: model.ClusterEventPublish}
+		InjectClusterMessage(ctx, msg)
+		receiverSpan, receiverCtx := StartClusterMessageSpan(msg)
+		receiverSpan.End()
+		InjectClusterMessage(receiverCtx, msg)
+
+		handled := trace.SpanContextFromContext(ClusterMessageContext(context.Background(), msg))
+		assert.Equal(t, receiverSpan.SpanContext().TraceID(), handled.TraceID())
+		assert.Equal(t, receiverSpan.SpanContext().SpanID(), handled.SpanID())
+	})
+}
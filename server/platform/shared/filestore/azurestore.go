@@ -0,0 +1,476 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// azureCopyPollInterval is how often the status of a pending blob copy is
+// checked.
+const azureCopyPollInterval = 100 * time.Millisecond
+
+// AzureFileBackend contains all necessary information to communicate with
+// an Azure Blob Storage container.
+type AzureFileBackend struct {
+	container      string
+	pathPrefix     string
+	endpoint       string
+	credential     *container.SharedKeyCredential
+	client         *container.Client
+	timeout        time.Duration
+	presignExpires time.Duration
+}
+
+type AzureFileBackendAuthError struct {
+	DetailedError string
+}
+
+// AzureFileBackendNoContainerError is returned when testing a connection and no container is found
+type AzureFileBackendNoContainerError struct{}
+
+var _ FileBackendWithLinkGenerator = (*AzureFileBackend)(nil)
+
+func (s *AzureFileBackendAuthError) Error() string {
+	return s.DetailedError
+}
+
+func (s *AzureFileBackendNoContainerError) Error() string {
+	return "no such container"
+}
+
+// NewAzureFileBackend returns an instance of an AzureFileBackend.
+func NewAzureFileBackend(settings FileBackendSettings) (*AzureFileBackend, error) {
+	if settings.AzureStorageAccount == "" || settings.AzureAccessKey == "" {
+		return nil, errors.New("missing azure storage account credentials")
+	}
+
+	endpoint := settings.AzureEndpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", settings.AzureStorageAccount)
+	}
+
+	backend := &AzureFileBackend{
+		container:      settings.AzureContainer,
+		pathPrefix:     settings.AzurePathPrefix,
+		endpoint:       strings.TrimRight(endpoint, "/"),
+		timeout:        time.Duration(settings.AzureRequestTimeoutMilliseconds) * time.Millisecond,
+		presignExpires: time.Duration(settings.AzurePresignExpiresSeconds) * time.Second,
+	}
+
+	cred, err := container.NewSharedKeyCredential(settings.AzureStorageAccount, settings.AzureAccessKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the azure credentials")
+	}
+	backend.credential = cred
+
+	opts := &container.ClientOptions{}
+	if settings.SkipVerify {
+		tr := http.DefaultTransport.(*http.Transport).Clone()
+		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		opts.ClientOptions = azcore.ClientOptions{Transport: &http.Client{Transport: tr}}
+	}
+
+	client, err := container.NewClientWithSharedKeyCredential(backend.endpoint+"/"+backend.container, cred, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the azure client")
+	}
+	backend.client = client
+
+	return backend, nil
+}
+
+func (b *AzureFileBackend) DriverName() string {
+	return driverAzureBlob
+}
+
+func (b *AzureFileBackend) TestConnection() error {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	if _, err := b.client.GetProperties(ctx, nil); err != nil {
+		if bloberror.HasCode(err, bloberror.ContainerNotFound) {
+			return &AzureFileBackendNoContainerError{}
+		}
+		return &AzureFileBackendAuthError{DetailedError: "unable to check if the Azure container exists"}
+	}
+	mlog.Debug("Connection to Azure Blob Storage is good. Container exists.")
+	return nil
+}
+
+// MakeBucket creates the container the backend stores its files in.
+func (b *AzureFileBackend) MakeBucket() error {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	if _, err := b.client.Create(ctx, nil); err != nil && !bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
+		return errors.Wrap(err, "unable to create the azure container")
+	}
+	return nil
+}
+
+func (b *AzureFileBackend) blobClient(path string) *blockblob.Client {
+	return b.client.NewBlockBlobClient(path)
+}
+
+// Caller must close the first return value
+func (b *AzureFileBackend) Reader(path string) (ReadCloseSeeker, error) {
+	path = b.prefixedPath(path)
+	client := b.blobClient(path)
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	props, err := client.GetProperties(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open file %s", path)
+	}
+
+	return b.blobReader(client, *props.ContentLength), nil
+}
+
+func (b *AzureFileBackend) blobReader(client *blockblob.Client, size int64) *rangeReader {
+	return newRangeReader(b.timeout, size, func(ctx context.Context, offset int64) (io.ReadCloser, error) {
+		resp, err := client.DownloadStream(ctx, &blob.DownloadStreamOptions{Range: blob.HTTPRange{Offset: offset}})
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read file %s", client.URL())
+		}
+		return resp.Body, nil
+	})
+}
+
+func (b *AzureFileBackend) ReadFile(path string) ([]byte, error) {
+	path = b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	resp, err := b.blobClient(path).DownloadStream(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open file %s", path)
+	}
+
+	defer resp.Body.Close()
+	f, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read file %s", path)
+	}
+	return f, nil
+}
+
+func (b *AzureFileBackend) FileExists(path string) (bool, error) {
+	path = b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	_, err := b.blobClient(path).GetProperties(ctx, nil)
+	if err == nil {
+		return true, nil
+	}
+
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return false, nil
+	}
+
+	return false, errors.Wrapf(err, "unable to know if file %s exists", path)
+}
+
+func (b *AzureFileBackend) FileSize(path string) (int64, error) {
+	path = b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	props, err := b.blobClient(path).GetProperties(ctx, nil)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to get file size for %s", path)
+	}
+
+	return *props.ContentLength, nil
+}
+
+func (b *AzureFileBackend) FileModTime(path string) (time.Time, error) {
+	path = b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	props, err := b.blobClient(path).GetProperties(ctx, nil)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "unable to get modification time for file %s", path)
+	}
+
+	return *props.LastModified, nil
+}
+
+func (b *AzureFileBackend) CopyFile(oldPath, newPath string) error {
+	oldPath = b.prefixedPath(oldPath)
+	newPath = b.prefixedPath(newPath)
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	if err := b.copyBlob(ctx, oldPath, newPath); err != nil {
+		return errors.Wrapf(err, "unable to copy file from %s to %s", oldPath, newPath)
+	}
+
+	return nil
+}
+
+// copyBlob copies a blob within the container and waits for the copy to
+// complete. Copies within a storage account are usually completed by the time
+// the request returns.
+func (b *AzureFileBackend) copyBlob(ctx context.Context, srcPath, dstPath string) error {
+	dst := b.blobClient(dstPath)
+	resp, err := dst.StartCopyFromURL(ctx, b.blobClient(srcPath).URL(), nil)
+	if err != nil {
+		return err
+	}
+
+	status := resp.CopyStatus
+	for status != nil && *status == blob.CopyStatusTypePending {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(azureCopyPollInterval):
+		}
+
+		props, err := dst.GetProperties(ctx, nil)
+		if err != nil {
+			return err
+		}
+		status = props.CopyStatus
+	}
+
+	if status != nil && *status != blob.CopyStatusTypeSuccess {
+		return errors.Errorf("copy finished with status %s", *status)
+	}
+
+	return nil
+}
+
+func (b *AzureFileBackend) MoveFile(oldPath, newPath string) error {
+	oldPath = b.prefixedPath(oldPath)
+	newPath = b.prefixedPath(newPath)
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	if err := b.copyBlob(ctx, oldPath, newPath); err != nil {
+		return errors.Wrapf(err, "unable to copy the file to %s to the new destination", newPath)
+	}
+
+	ctx2, cancel2 := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel2()
+	if _, err := b.blobClient(oldPath).Delete(ctx2, nil); err != nil {
+		return errors.Wrapf(err, "unable to remove the file old file %s", oldPath)
+	}
+
+	return nil
+}
+
+func (b *AzureFileBackend) WriteFile(fr io.Reader, path string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	return b.WriteFileContext(ctx, fr, path)
+}
+
+func (b *AzureFileBackend) WriteFileContext(ctx context.Context, fr io.Reader, path string) (int64, error) {
+	path = b.prefixedPath(path)
+
+	cr := &countingReader{r: fr}
+	_, err := b.blobClient(path).UploadStream(ctx, cr, &blockblob.UploadStreamOptions{
+		HTTPHeaders: &blob.HTTPHeaders{BlobContentType: model.NewPointer(contentTypeForPath(path))},
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable write the data in the file %s", path)
+	}
+
+	return cr.n, nil
+}
+
+func (b *AzureFileBackend) AppendFile(fr io.Reader, path string) (int64, error) {
+	fp := b.prefixedPath(path)
+	client := b.blobClient(fp)
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	props, err := client.GetProperties(ctx, nil)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to find the file %s to append the data", path)
+	}
+	list, err := client.GetBlockList(ctx, blockblob.BlockListTypeCommitted, nil)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to find the file %s to append the data", path)
+	}
+
+	blockIDs := make([]string, 0, len(list.CommittedBlocks)+2)
+	for _, block := range list.CommittedBlocks {
+		blockIDs = append(blockIDs, *block.Name)
+	}
+
+	ctx2, cancel2 := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel2()
+	if len(blockIDs) == 0 && *props.ContentLength > 0 {
+		// Small blobs are written in a single request and have no blocks,
+		// so their content is staged back as the first block of the blob.
+		existing := b.blobReader(client, *props.ContentLength)
+		existingID := newAzureBlockID()
+		_, err = client.StageBlock(ctx2, existingID, existing, nil)
+		existing.Close()
+		if err != nil {
+			return 0, errors.Wrapf(err, "unable append the data in the file %s", path)
+		}
+		blockIDs = append(blockIDs, existingID)
+	}
+
+	data, err := io.ReadAll(fr)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to read the data to append to the file %s", path)
+	}
+
+	blockID := newAzureBlockID()
+	if _, err := client.StageBlock(ctx2, blockID, streaming.NopCloser(bytes.NewReader(data)), nil); err != nil {
+		return 0, errors.Wrapf(err, "unable append the data in the file %s", path)
+	}
+
+	ctx3, cancel3 := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel3()
+	_, err = client.CommitBlockList(ctx3, append(blockIDs, blockID), &blockblob.CommitBlockListOptions{
+		HTTPHeaders: &blob.HTTPHeaders{BlobContentType: model.NewPointer(contentTypeForPath(fp))},
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable append the data in the file %s", path)
+	}
+
+	return int64(len(data)), nil
+}
+
+func (b *AzureFileBackend) RemoveFile(path string) error {
+	path = b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	if _, err := b.blobClient(path).Delete(ctx, nil); err != nil {
+		return errors.Wrapf(err, "unable to remove the file %s", path)
+	}
+
+	return nil
+}
+
+func (b *AzureFileBackend) listDirectory(path string, recursion bool) ([]string, error) {
+	path = b.prefixedPath(path)
+	if !strings.HasSuffix(path, "/") && path != "" {
+		// Listing a prefix would also return the blobs sharing the same
+		// name prefix, so we make sure to only list the directory.
+		path = path + "/"
+	}
+
+	var names []string
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	if recursion {
+		pager := b.client.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{Prefix: &path})
+		for pager.More() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to list the directory %s", path)
+			}
+			for _, item := range page.Segment.BlobItems {
+				names = append(names, *item.Name)
+			}
+		}
+	} else {
+		pager := b.client.NewListBlobsHierarchyPager("/", &container.ListBlobsHierarchyOptions{Prefix: &path})
+		for pager.More() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to list the directory %s", path)
+			}
+			for _, prefix := range page.Segment.BlobPrefixes {
+				names = append(names, *prefix.Name)
+			}
+			for _, item := range page.Segment.BlobItems {
+				names = append(names, *item.Name)
+			}
+		}
+	}
+
+	paths := trimListedPaths(names, b.pathPrefix)
+	// Check if only one item was returned and it matches the path prefix
+	if len(paths) == 1 && strings.TrimRight(path, "/") == paths[0] {
+		// Return a fs.PathError to maintain consistency
+		return nil, &fs.PathError{Op: "readdir", Path: path, Err: fs.ErrNotExist}
+	}
+
+	return paths, nil
+}
+
+func (b *AzureFileBackend) ListDirectory(path string) ([]string, error) {
+	return b.listDirectory(path, false)
+}
+
+func (b *AzureFileBackend) ListDirectoryRecursively(path string) ([]string, error) {
+	return b.listDirectory(path, true)
+}
+
+func (b *AzureFileBackend) RemoveDirectory(path string) error {
+	path = b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	pager := b.client.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{Prefix: &path})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "unable to remove the directory %s", path)
+		}
+		for _, item := range page.Segment.BlobItems {
+			if _, err := b.blobClient(*item.Name).Delete(ctx, nil); err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+				return errors.Wrapf(err, "unable to remove the directory %s", path)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (b *AzureFileBackend) GeneratePublicLink(path string) (string, time.Duration, error) {
+	path = b.prefixedPath(path)
+	qps, err := sas.BlobSignatureValues{
+		Protocol:           sas.ProtocolHTTPSandHTTP,
+		ExpiryTime:         time.Now().UTC().Add(b.presignExpires),
+		Permissions:        (&sas.BlobPermissions{Read: true}).String(),
+		ContainerName:      b.container,
+		BlobName:           path,
+		ContentDisposition: "attachment",
+	}.SignWithSharedKey(b.credential)
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "unable to generate public link for %s", path)
+	}
+
+	return b.blobClient(path).URL() + "?" + qps.Encode(), b.presignExpires, nil
+}
+
+func (b *AzureFileBackend) prefixedPath(s string) string {
+	return filepath.Join(b.pathPrefix, s)
+}
+
+// newAzureBlockID returns a new block id, padded to the length of the ids
+// used when uploading streams since all the blocks of a blob must have ids
+// of the same length.
+func newAzureBlockID() string {
+	var id [64]byte
+	copy(id[:], model.NewId())
+	return base64.StdEncoding.EncodeToString(id[:])
+}
@@ -0,0 +1,802 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	encryptionMagic           = "MMENC"
+	encryptionVersion         = 1
+	encryptionChunkSize       = 64 * 1024
+	encryptionNoncePrefixSize = 7
+	encryptionDataKeySize     = 32
+	aesGCMOverhead            = 16
+	segmentTrailerSize        = 8
+
+	reencryptTempSuffix = ".reencrypt"
+	appendTempSuffix    = ".append"
+)
+
+// KeyProvider wraps and unwraps the per-file data keys used by the
+// EncryptedFileBackend. The StaticKeyProvider keeps the master keys in the
+// configuration, other implementations can delegate to a key management service.
+type KeyProvider interface {
+	// CurrentKeyID returns the identifier of the master key used to wrap new data keys.
+	CurrentKeyID() string
+	// WrapKey encrypts a data key with the current master key.
+	WrapKey(dataKey []byte) (keyID string, wrappedKey []byte, err error)
+	// UnwrapKey decrypts a data key wrapped by the master key identified by keyID.
+	UnwrapKey(keyID string, wrappedKey []byte) ([]byte, error)
+}
+
+// StaticKeyProvider is a KeyProvider backed by base64 encoded 256-bit master keys.
+// Retired keys are only used to unwrap the data keys of existing files.
+type StaticKeyProvider struct {
+	currentKeyID string
+	masterKeys   map[string]cipher.AEAD
+}
+
+var _ KeyProvider = (*StaticKeyProvider)(nil)
+
+func NewStaticKeyProvider(masterKey string, retiredKeys []string) (*StaticKeyProvider, error) {
+	provider := &StaticKeyProvider{
+		masterKeys: make(map[string]cipher.AEAD),
+	}
+
+	for i, encoded := range append([]string{masterKey}, retiredKeys...) {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to decode the master key")
+		}
+		if len(key) != encryptionDataKeySize {
+			return nil, errors.New("master keys must be 256 bits long")
+		}
+
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+
+		sum := sha256.Sum256(key)
+		keyID := hex.EncodeToString(sum[:8])
+		if i == 0 {
+			provider.currentKeyID = keyID
+		}
+		provider.masterKeys[keyID] = aead
+	}
+
+	return provider, nil
+}
+
+func (p *StaticKeyProvider) CurrentKeyID() string {
+	return p.currentKeyID
+}
+
+func (p *StaticKeyProvider) WrapKey(dataKey []byte) (string, []byte, error) {
+	aead := p.masterKeys[p.currentKeyID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, errors.Wrap(err, "unable to generate a nonce")
+	}
+	return p.currentKeyID, aead.Seal(nonce, nonce, dataKey, []byte(p.currentKeyID)), nil
+}
+
+func (p *StaticKeyProvider) UnwrapKey(keyID string, wrappedKey []byte) ([]byte, error) {
+	aead, ok := p.masterKeys[keyID]
+	if !ok {
+		return nil, errors.Errorf("unknown master key %s", keyID)
+	}
+	if len(wrappedKey) < aead.NonceSize() {
+		return nil, errors.New("wrapped key is too short")
+	}
+	nonce, ciphertext := wrappedKey[:aead.NonceSize()], wrappedKey[aead.NonceSize():]
+	dataKey, err := aead.Open(nil, nonce, ciphertext, []byte(keyID))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to unwrap data key with master key %s", keyID)
+	}
+	return dataKey, nil
+}
+
+// EncryptedFileBackend wraps a FileBackend to encrypt files at rest using
+// envelope encryption: each file is encrypted with its own data key, which is
+// stored in the file header wrapped by a master key of the KeyProvider.
+//
+// File contents are sealed with AES-256-GCM in fixed size chunks, so files can
+// be streamed and seeked without being fully loaded in memory. The data written
+// by each call to WriteFile or AppendFile forms a segment of chunks, so that
+// appending never reads nor re-encrypts the existing contents. Files written
+// before encryption was enabled are returned as they are.
+type EncryptedFileBackend struct {
+	backend  FileBackend
+	provider KeyProvider
+}
+
+var _ FileBackend = (*EncryptedFileBackend)(nil)
+
+func NewEncryptedFileBackend(backend FileBackend, provider KeyProvider) *EncryptedFileBackend {
+	return &EncryptedFileBackend{
+		backend:  backend,
+		provider: provider,
+	}
+}
+
+// Unwrap returns the backend the encrypted files are stored in.
+func (b *EncryptedFileBackend) Unwrap() FileBackend {
+	return b.backend
+}
+
+func (b *EncryptedFileBackend) DriverName() string {
+	return b.backend.DriverName()
+}
+
+func (b *EncryptedFileBackend) TestConnection() error {
+	dataKey := make([]byte, encryptionDataKeySize)
+	keyID, wrappedKey, err := b.provider.WrapKey(dataKey)
+	if err != nil {
+		return errors.Wrap(err, "unable to wrap a data key")
+	}
+	if _, err := b.provider.UnwrapKey(keyID, wrappedKey); err != nil {
+		return errors.Wrap(err, "unable to unwrap a data key")
+	}
+
+	return b.backend.TestConnection()
+}
+
+func (b *EncryptedFileBackend) Reader(path string) (ReadCloseSeeker, error) {
+	raw, err := b.backend.Reader(path)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := readEncryptionHeader(raw)
+	if err != nil {
+		raw.Close()
+		return nil, errors.Wrapf(err, "unable to read the encryption header of file %s", path)
+	}
+	if header == nil {
+		if _, err := raw.Seek(0, io.SeekStart); err != nil {
+			raw.Close()
+			return nil, errors.Wrapf(err, "unable to rewind file %s", path)
+		}
+		return raw, nil
+	}
+
+	r, err := b.newDecryptingReader(raw, header)
+	if err != nil {
+		raw.Close()
+		return nil, errors.Wrapf(err, "unable to decrypt file %s", path)
+	}
+	return r, nil
+}
+
+func (b *EncryptedFileBackend) ReadFile(path string) ([]byte, error) {
+	r, err := b.Reader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read file %s", path)
+	}
+	return data, nil
+}
+
+func (b *EncryptedFileBackend) FileExists(path string) (bool, error) {
+	return b.backend.FileExists(path)
+}
+
+func (b *EncryptedFileBackend) FileSize(path string) (int64, error) {
+	raw, err := b.backend.Reader(path)
+	if err != nil {
+		return 0, err
+	}
+	defer raw.Close()
+
+	header, err := readEncryptionHeader(raw)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to read the encryption header of file %s", path)
+	}
+	if header == nil {
+		return b.backend.FileSize(path)
+	}
+
+	segments, err := readEncryptedSegments(raw, header)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to get file size for %s", path)
+	}
+	return segments[len(segments)-1].end(), nil
+}
+
+func (b *EncryptedFileBackend) CopyFile(oldPath, newPath string) error {
+	return b.backend.CopyFile(oldPath, newPath)
+}
+
+func (b *EncryptedFileBackend) MoveFile(oldPath, newPath string) error {
+	return b.backend.MoveFile(oldPath, newPath)
+}
+
+func (b *EncryptedFileBackend) WriteFile(fr io.Reader, path string) (int64, error) {
+	counter := &countingReader{r: fr}
+	er, err := b.newEncryptingReader(counter)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to encrypt file %s", path)
+	}
+
+	if _, err := b.backend.WriteFile(er, path); err != nil {
+		return counter.n, err
+	}
+	return counter.n, nil
+}
+
+func (b *EncryptedFileBackend) WriteFileContext(ctx context.Context, fr io.Reader, path string) (int64, error) {
+	counter := &countingReader{r: fr}
+	er, err := b.newEncryptingReader(counter)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to encrypt file %s", path)
+	}
+	// The context is also checked while encrypting, for backends not supporting it.
+	er.ctx = ctx
+
+	if _, err := TryWriteFileContext(ctx, b.backend, er, path); err != nil {
+		return counter.n, err
+	}
+	return counter.n, nil
+}
+
+// AppendFile seals the appended data in a new segment at the end of the file,
+// with the data key of the file. Files written before encryption was enabled
+// are encrypted as a whole the first time data is appended to them.
+func (b *EncryptedFileBackend) AppendFile(fr io.Reader, path string) (int64, error) {
+	raw, err := b.backend.Reader(path)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to find the file %s to append the data", path)
+	}
+
+	header, err := readEncryptionHeader(raw)
+	if err != nil {
+		raw.Close()
+		return 0, errors.Wrapf(err, "unable to read the encryption header of file %s", path)
+	}
+	if header == nil {
+		if _, err := raw.Seek(0, io.SeekStart); err != nil {
+			raw.Close()
+			return 0, errors.Wrapf(err, "unable to rewind file %s", path)
+		}
+		defer raw.Close()
+		return b.encryptAndAppendFile(raw, fr, path)
+	}
+
+	segments, err := readEncryptedSegments(raw, header)
+	raw.Close()
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to append the data in the file %s", path)
+	}
+	next := segments[len(segments)-1].firstChunk + segments[len(segments)-1].chunks()
+	if next > math.MaxUint32 {
+		return 0, errors.Errorf("file %s is too large to append data", path)
+	}
+
+	dataKey, err := b.provider.UnwrapKey(header.keyID, header.wrappedKey)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to decrypt file %s", path)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return 0, err
+	}
+
+	counter := &countingReader{r: fr}
+	if _, err := b.backend.AppendFile(newSegmentEncryptingReader(counter, aead, header.noncePrefix, uint32(next)), path); err != nil {
+		return counter.n, errors.Wrapf(err, "unable to append the data in the file %s", path)
+	}
+	return counter.n, nil
+}
+
+// encryptAndAppendFile encrypts a plaintext file along with the data appended
+// to it.
+func (b *EncryptedFileBackend) encryptAndAppendFile(r io.Reader, fr io.Reader, path string) (int64, error) {
+	counter := &countingReader{r: fr}
+	tmpPath := path + appendTempSuffix
+	if _, err := b.WriteFile(io.MultiReader(r, counter), tmpPath); err != nil {
+		b.backend.RemoveFile(tmpPath)
+		return counter.n, errors.Wrapf(err, "unable to append the data in the file %s", path)
+	}
+	if err := b.backend.MoveFile(tmpPath, path); err != nil {
+		return counter.n, errors.Wrapf(err, "unable to append the data in the file %s", path)
+	}
+	return counter.n, nil
+}
+
+func (b *EncryptedFileBackend) RemoveFile(path string) error {
+	return b.backend.RemoveFile(path)
+}
+
+func (b *EncryptedFileBackend) FileModTime(path string) (time.Time, error) {
+	return b.backend.FileModTime(path)
+}
+
+func (b *EncryptedFileBackend) ListDirectory(path string) ([]string, error) {
+	return b.backend.ListDirectory(path)
+}
+
+func (b *EncryptedFileBackend) ListDirectoryRecursively(path string) ([]string, error) {
+	return b.backend.ListDirectoryRecursively(path)
+}
+
+func (b *EncryptedFileBackend) RemoveDirectory(path string) error {
+	return b.backend.RemoveDirectory(path)
+}
+
+// Reencrypt makes sure the file is encrypted with the current master key.
+// Plaintext files are encrypted, and the data keys of files encrypted with a
+// retired master key are wrapped again. It returns whether the file was rewritten.
+func (b *EncryptedFileBackend) Reencrypt(path string) (bool, error) {
+	if strings.HasSuffix(path, reencryptTempSuffix) || strings.HasSuffix(path, appendTempSuffix) {
+		return false, nil
+	}
+
+	raw, err := b.backend.Reader(path)
+	if err != nil {
+		return false, err
+	}
+	defer raw.Close()
+
+	header, err := readEncryptionHeader(raw)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to read the encryption header of file %s", path)
+	}
+
+	var src io.Reader
+	if header == nil {
+		if _, err = raw.Seek(0, io.SeekStart); err != nil {
+			return false, errors.Wrapf(err, "unable to rewind file %s", path)
+		}
+		if src, err = b.newEncryptingReader(raw); err != nil {
+			return false, errors.Wrapf(err, "unable to encrypt file %s", path)
+		}
+	} else {
+		if header.keyID == b.provider.CurrentKeyID() {
+			return false, nil
+		}
+
+		dataKey, err := b.provider.UnwrapKey(header.keyID, header.wrappedKey)
+		if err != nil {
+			return false, errors.Wrapf(err, "unable to decrypt file %s", path)
+		}
+		if header.keyID, header.wrappedKey, err = b.provider.WrapKey(dataKey); err != nil {
+			return false, errors.Wrapf(err, "unable to encrypt file %s", path)
+		}
+
+		// The contents remain sealed with the same data key, only the header changes.
+		src = io.MultiReader(bytes.NewReader(header.marshal()), raw)
+	}
+
+	tmpPath := path + reencryptTempSuffix
+	if _, err := TryWriteFileContext(context.Background(), b.backend, src, tmpPath); err != nil {
+		b.backend.RemoveFile(tmpPath)
+		return false, errors.Wrapf(err, "unable to write re-encrypted file %s", path)
+	}
+	if err := b.backend.MoveFile(tmpPath, path); err != nil {
+		return false, errors.Wrapf(err, "unable to replace file %s", path)
+	}
+
+	return true, nil
+}
+
+// encryptionHeader is stored at the beginning of every encrypted file:
+//
+//	magic | version | key id length (1 byte) | key id | wrapped key length (2 bytes) | wrapped key | nonce prefix
+//
+// It is followed by the segments of the file, each made of its sealed chunks
+// and of its plaintext size (8 bytes).
+type encryptionHeader struct {
+	keyID       string
+	wrappedKey  []byte
+	noncePrefix []byte
+}
+
+func (h *encryptionHeader) size() int64 {
+	return int64(len(encryptionMagic) + 1 + 1 + len(h.keyID) + 2 + len(h.wrappedKey) + len(h.noncePrefix))
+}
+
+func (h *encryptionHeader) marshal() []byte {
+	buf := make([]byte, 0, h.size())
+	buf = append(buf, encryptionMagic...)
+	buf = append(buf, encryptionVersion, byte(len(h.keyID)))
+	buf = append(buf, h.keyID...)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(h.wrappedKey)))
+	buf = append(buf, h.wrappedKey...)
+	return append(buf, h.noncePrefix...)
+}
+
+// readEncryptionHeader reads the encryption header at the beginning of r. It
+// returns a nil header if the file is not encrypted.
+func readEncryptionHeader(r io.Reader) (*encryptionHeader, error) {
+	prefix := make([]byte, len(encryptionMagic)+2)
+	if _, err := io.ReadFull(r, prefix); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if string(prefix[:len(encryptionMagic)]) != encryptionMagic {
+		return nil, nil
+	}
+	if version := prefix[len(encryptionMagic)]; version != encryptionVersion {
+		return nil, errors.Errorf("unsupported encryption version %d", version)
+	}
+
+	header := &encryptionHeader{}
+	keyID := make([]byte, prefix[len(encryptionMagic)+1])
+	if _, err := io.ReadFull(r, keyID); err != nil {
+		return nil, errors.Wrap(err, "invalid encryption header")
+	}
+	header.keyID = string(keyID)
+
+	var wrappedKeyLen uint16
+	if err := binary.Read(r, binary.BigEndian, &wrappedKeyLen); err != nil {
+		return nil, errors.Wrap(err, "invalid encryption header")
+	}
+	header.wrappedKey = make([]byte, wrappedKeyLen)
+	if _, err := io.ReadFull(r, header.wrappedKey); err != nil {
+		return nil, errors.Wrap(err, "invalid encryption header")
+	}
+
+	header.noncePrefix = make([]byte, encryptionNoncePrefixSize)
+	if _, err := io.ReadFull(r, header.noncePrefix); err != nil {
+		return nil, errors.Wrap(err, "invalid encryption header")
+	}
+
+	return header, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the cipher")
+	}
+	return aead, nil
+}
+
+// chunkNonce derives the nonce of a chunk from its index, which keeps increasing
+// across the segments of the file. The last chunk of a segment is flagged and
+// authenticates the size of the segment, so that truncated segments fail to
+// decrypt. Whole segments removed from the end of a file are not detected.
+func chunkNonce(prefix []byte, index uint32, last bool) []byte {
+	nonce := make([]byte, 0, encryptionNoncePrefixSize+5)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, index)
+	if last {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
+
+// segmentSizeAAD is the additional data of the last chunk of a segment, which
+// is also stored in clear after it.
+func segmentSizeAAD(size int64) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(size))
+}
+
+// encryptedSegment is the data written by a call to WriteFile or AppendFile.
+// Segments always end with a chunk shorter than encryptionChunkSize, which
+// might be empty.
+type encryptedSegment struct {
+	rawOffset   int64
+	plainOffset int64
+	size        int64
+	firstChunk  int64
+}
+
+func (s *encryptedSegment) end() int64 {
+	return s.plainOffset + s.size
+}
+
+func (s *encryptedSegment) lastChunk() int64 {
+	return s.size / encryptionChunkSize
+}
+
+func (s *encryptedSegment) chunks() int64 {
+	return s.lastChunk() + 1
+}
+
+// encryptedSegmentSize returns the size of a sealed segment, trailer included.
+func encryptedSegmentSize(size int64) int64 {
+	return size + (size/encryptionChunkSize+1)*aesGCMOverhead + segmentTrailerSize
+}
+
+// readEncryptedSegments locates the segments of an encrypted file by reading
+// their sizes from the end of the file.
+func readEncryptedSegments(raw io.ReadSeeker, header *encryptionHeader) ([]encryptedSegment, error) {
+	end, err := raw.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get the encrypted file size")
+	}
+
+	var segments []encryptedSegment
+	trailer := make([]byte, segmentTrailerSize)
+	for end > header.size() {
+		if end-header.size() < segmentTrailerSize {
+			return nil, errors.New("invalid encrypted file size")
+		}
+		if _, err := raw.Seek(end-segmentTrailerSize, io.SeekStart); err != nil {
+			return nil, errors.Wrap(err, "unable to seek the encrypted file")
+		}
+		if _, err := io.ReadFull(raw, trailer); err != nil {
+			return nil, errors.Wrap(err, "unable to read the encrypted file")
+		}
+
+		size := binary.BigEndian.Uint64(trailer)
+		if size > uint64(end) || end-encryptedSegmentSize(int64(size)) < header.size() {
+			return nil, errors.New("invalid encrypted file size")
+		}
+		end -= encryptedSegmentSize(int64(size))
+		segments = append(segments, encryptedSegment{rawOffset: end, size: int64(size)})
+	}
+	if len(segments) == 0 {
+		return nil, errors.New("invalid encrypted file size")
+	}
+
+	slices.Reverse(segments)
+	var plainOffset, chunk int64
+	for i := range segments {
+		segments[i].plainOffset = plainOffset
+		segments[i].firstChunk = chunk
+		plainOffset += segments[i].size
+		chunk += segments[i].chunks()
+	}
+	if chunk-1 > math.MaxUint32 {
+		return nil, errors.New("invalid encrypted file size")
+	}
+
+	return segments, nil
+}
+
+type encryptingReader struct {
+	ctx     context.Context
+	src     io.Reader
+	aead    cipher.AEAD
+	prefix  []byte
+	index   uint32
+	size    int64
+	plain   []byte
+	sealed  []byte
+	pending []byte
+	done    bool
+}
+
+// newSegmentEncryptingReader returns a reader sealing src in a segment whose
+// first chunk has the given index.
+func newSegmentEncryptingReader(src io.Reader, aead cipher.AEAD, prefix []byte, index uint32) *encryptingReader {
+	return &encryptingReader{
+		ctx:    context.Background(),
+		src:    src,
+		aead:   aead,
+		prefix: prefix,
+		index:  index,
+		plain:  make([]byte, encryptionChunkSize),
+		sealed: make([]byte, 0, encryptionChunkSize+aesGCMOverhead+segmentTrailerSize),
+	}
+}
+
+func (b *EncryptedFileBackend) newEncryptingReader(src io.Reader) (*encryptingReader, error) {
+	dataKey := make([]byte, encryptionDataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, errors.Wrap(err, "unable to generate a data key")
+	}
+
+	header := &encryptionHeader{
+		noncePrefix: make([]byte, encryptionNoncePrefixSize),
+	}
+	if _, err := rand.Read(header.noncePrefix); err != nil {
+		return nil, errors.Wrap(err, "unable to generate a nonce")
+	}
+
+	var err error
+	if header.keyID, header.wrappedKey, err = b.provider.WrapKey(dataKey); err != nil {
+		return nil, errors.Wrap(err, "unable to wrap the data key")
+	}
+	if len(header.keyID) > math.MaxUint8 || len(header.wrappedKey) > math.MaxUint16 {
+		return nil, errors.New("wrapped data key is too long")
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	r := newSegmentEncryptingReader(src, aead, header.noncePrefix, 0)
+	r.pending = header.marshal()
+	return r, nil
+}
+
+func (r *encryptingReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.sealNext(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+func (r *encryptingReader) sealNext() error {
+	n, err := io.ReadFull(r.src, r.plain)
+	if ctxErr := r.ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	last := err == io.EOF || err == io.ErrUnexpectedEOF
+	if err != nil && !last {
+		return err
+	}
+	if !last && r.index == math.MaxUint32 {
+		return errors.New("file is too large to be encrypted")
+	}
+
+	r.size += int64(n)
+	if !last {
+		r.pending = r.aead.Seal(r.sealed[:0], chunkNonce(r.prefix, r.index, false), r.plain[:n], nil)
+		r.index++
+		return nil
+	}
+
+	sizeAAD := segmentSizeAAD(r.size)
+	r.pending = r.aead.Seal(r.sealed[:0], chunkNonce(r.prefix, r.index, true), r.plain[:n], sizeAAD)
+	r.pending = append(r.pending, sizeAAD...)
+	r.done = true
+	return nil
+}
+
+type decryptingReader struct {
+	raw      ReadCloseSeeker
+	aead     cipher.AEAD
+	prefix   []byte
+	segments []encryptedSegment
+	size     int64
+
+	offset     int64
+	chunkIndex int64
+	chunk      []byte
+	sealed     []byte
+}
+
+func (b *EncryptedFileBackend) newDecryptingReader(raw ReadCloseSeeker, header *encryptionHeader) (*decryptingReader, error) {
+	dataKey, err := b.provider.UnwrapKey(header.keyID, header.wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := readEncryptedSegments(raw, header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptingReader{
+		raw:        raw,
+		aead:       aead,
+		prefix:     header.noncePrefix,
+		segments:   segments,
+		size:       segments[len(segments)-1].end(),
+		chunkIndex: -1,
+		chunk:      make([]byte, 0, encryptionChunkSize),
+		sealed:     make([]byte, encryptionChunkSize+aesGCMOverhead),
+	}, nil
+}
+
+func (r *decryptingReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		// An empty last chunk holds no data, but it's still opened to
+		// detect files truncated at a chunk boundary.
+		last := &r.segments[len(r.segments)-1]
+		if last.firstChunk+last.lastChunk() != r.chunkIndex {
+			if err := r.openChunk(last, last.lastChunk()); err != nil {
+				return 0, err
+			}
+		}
+		return 0, io.EOF
+	}
+
+	segment := &r.segments[sort.Search(len(r.segments), func(i int) bool { return r.segments[i].end() > r.offset })]
+	index := (r.offset - segment.plainOffset) / encryptionChunkSize
+	if segment.firstChunk+index != r.chunkIndex {
+		if err := r.openChunk(segment, index); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.chunk[r.offset-segment.plainOffset-index*encryptionChunkSize:])
+	r.offset += int64(n)
+	return n, nil
+}
+
+func (r *decryptingReader) openChunk(segment *encryptedSegment, index int64) error {
+	sealedChunkSize := int64(encryptionChunkSize + aesGCMOverhead)
+	if _, err := r.raw.Seek(segment.rawOffset+index*sealedChunkSize, io.SeekStart); err != nil {
+		return errors.Wrap(err, "unable to seek the encrypted file")
+	}
+
+	sealed := r.sealed
+	last := index == segment.lastChunk()
+	var additionalData []byte
+	if last {
+		sealed = sealed[:segment.size-index*encryptionChunkSize+aesGCMOverhead]
+		additionalData = segmentSizeAAD(segment.size)
+	}
+	if _, err := io.ReadFull(r.raw, sealed); err != nil {
+		return errors.Wrap(err, "unable to read the encrypted file")
+	}
+
+	chunk, err := r.aead.Open(r.chunk[:0], chunkNonce(r.prefix, uint32(segment.firstChunk+index), last), sealed, additionalData)
+	if err != nil {
+		r.chunkIndex = -1
+		return errors.Wrap(err, "unable to decrypt the file")
+	}
+	r.chunk = chunk
+	r.chunkIndex = segment.firstChunk + index
+	return nil
+}
+
+func (r *decryptingReader) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = r.offset + offset
+	case io.SeekEnd:
+		abs = r.size + offset
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if abs < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	r.offset = abs
+	return abs, nil
+}
+
+func (r *decryptingReader) Close() error {
+	return r.raw.Close()
+}
+
+// CancelTimeout cancels the timeout of the underlying reader, if it has one.
+func (r *decryptingReader) CancelTimeout() bool {
+	if tr, ok := r.raw.(interface{ CancelTimeout() bool }); ok {
+		return tr.CancelTimeout()
+	}
+	return true
+}
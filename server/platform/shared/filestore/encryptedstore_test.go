@@ -0,0 +1,307 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMasterKey(t *testing.T) string {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func newTestEncryptedBackend(t *testing.T, dir, masterKey string, retiredKeys ...string) *EncryptedFileBackend {
+	provider, err := NewStaticKeyProvider(masterKey, retiredKeys)
+	require.NoError(t, err)
+	return NewEncryptedFileBackend(&LocalFileBackend{directory: dir}, provider)
+}
+
+func TestNewStaticKeyProvider(t *testing.T) {
+	_, err := NewStaticKeyProvider("not base64", nil)
+	require.Error(t, err)
+
+	_, err = NewStaticKeyProvider(base64.StdEncoding.EncodeToString(make([]byte, 16)), nil)
+	require.Error(t, err)
+
+	current := newTestMasterKey(t)
+	retired := newTestMasterKey(t)
+	provider, err := NewStaticKeyProvider(current, []string{retired})
+	require.NoError(t, err)
+
+	retiredProvider, err := NewStaticKeyProvider(retired, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, provider.CurrentKeyID(), retiredProvider.CurrentKeyID())
+
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+	keyID, wrapped, err := retiredProvider.WrapKey(dataKey)
+	require.NoError(t, err)
+
+	unwrapped, err := provider.UnwrapKey(keyID, wrapped)
+	require.NoError(t, err)
+	require.Equal(t, dataKey, unwrapped)
+
+	wrapped[len(wrapped)-1] ^= 1
+	_, err = provider.UnwrapKey(keyID, wrapped)
+	require.Error(t, err)
+
+	_, err = provider.UnwrapKey("unknown", wrapped)
+	require.Error(t, err)
+}
+
+func TestEncryptedFileBackend(t *testing.T) {
+	dir := t.TempDir()
+	backend := newTestEncryptedBackend(t, dir, newTestMasterKey(t))
+
+	sizes := []int{0, 1, encryptionChunkSize - 1, encryptionChunkSize, encryptionChunkSize + 1, 3*encryptionChunkSize + 17}
+	for _, size := range sizes {
+		data := make([]byte, size)
+		_, err := rand.Read(data)
+		require.NoError(t, err)
+
+		path := "tests/" + randomString()
+		written, err := backend.WriteFile(bytes.NewReader(data), path)
+		require.NoError(t, err)
+		require.EqualValues(t, size, written)
+
+		raw, err := os.ReadFile(filepath.Join(dir, path))
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(raw, []byte(encryptionMagic)))
+		if size > 16 {
+			require.False(t, bytes.Contains(raw, data[:16]), "plaintext must not be stored")
+		}
+
+		read, err := backend.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, data, read, "size %d", size)
+
+		fileSize, err := backend.FileSize(path)
+		require.NoError(t, err)
+		require.EqualValues(t, size, fileSize)
+
+		if size == 0 {
+			continue
+		}
+
+		r, err := backend.Reader(path)
+		require.NoError(t, err)
+		for _, offset := range []int64{int64(size) - 1, 0, int64(size) / 2} {
+			pos, err := r.Seek(offset, io.SeekStart)
+			require.NoError(t, err)
+			require.Equal(t, offset, pos)
+
+			rest, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, data[offset:], rest)
+		}
+		end, err := r.Seek(0, io.SeekEnd)
+		require.NoError(t, err)
+		require.EqualValues(t, size, end)
+		require.NoError(t, r.Close())
+	}
+}
+
+func TestEncryptedFileBackendPlaintextFiles(t *testing.T) {
+	dir := t.TempDir()
+	local := &LocalFileBackend{directory: dir}
+	backend := newTestEncryptedBackend(t, dir, newTestMasterKey(t))
+
+	for _, data := range [][]byte{[]byte("abc"), []byte("plaintext written before encryption was enabled")} {
+		path := "tests/" + randomString()
+		_, err := local.WriteFile(bytes.NewReader(data), path)
+		require.NoError(t, err)
+
+		read, err := backend.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, data, read)
+
+		size, err := backend.FileSize(path)
+		require.NoError(t, err)
+		require.EqualValues(t, len(data), size)
+	}
+}
+
+func TestEncryptedFileBackendTampering(t *testing.T) {
+	dir := t.TempDir()
+	backend := newTestEncryptedBackend(t, dir, newTestMasterKey(t))
+
+	data := make([]byte, 2*encryptionChunkSize+10)
+	path := "tests/" + randomString()
+	_, err := backend.WriteFile(bytes.NewReader(data), path)
+	require.NoError(t, err)
+
+	raw, err := os.ReadFile(filepath.Join(dir, path))
+	require.NoError(t, err)
+
+	t.Run("modified contents", func(t *testing.T) {
+		modified := bytes.Clone(raw)
+		modified[len(modified)-20] ^= 1
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), modified, 0600))
+
+		_, err := backend.ReadFile(path)
+		require.Error(t, err)
+	})
+
+	t.Run("truncated at a chunk boundary", func(t *testing.T) {
+		header, err := readEncryptionHeader(bytes.NewReader(raw))
+		require.NoError(t, err)
+		truncated := raw[:header.size()+2*(encryptionChunkSize+aesGCMOverhead)]
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), truncated, 0600))
+
+		_, err = backend.ReadFile(path)
+		require.Error(t, err)
+	})
+
+	t.Run("truncated with a forged segment size", func(t *testing.T) {
+		header, err := readEncryptionHeader(bytes.NewReader(raw))
+		require.NoError(t, err)
+		truncated := bytes.Clone(raw[:header.size()+2*(encryptionChunkSize+aesGCMOverhead)])
+		truncated = append(truncated, make([]byte, aesGCMOverhead)...)
+		truncated = append(truncated, segmentSizeAAD(2*encryptionChunkSize)...)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), truncated, 0600))
+
+		_, err = backend.ReadFile(path)
+		require.Error(t, err)
+	})
+
+	t.Run("unknown master key", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), raw, 0600))
+
+		other := newTestEncryptedBackend(t, dir, newTestMasterKey(t))
+		_, err := other.ReadFile(path)
+		require.Error(t, err)
+	})
+}
+
+func TestEncryptedFileBackendAppendFile(t *testing.T) {
+	dir := t.TempDir()
+	local := &LocalFileBackend{directory: dir}
+	backend := newTestEncryptedBackend(t, dir, newTestMasterKey(t))
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := backend.AppendFile(bytes.NewReader([]byte("missing")), "tests/"+randomString())
+		require.Error(t, err)
+	})
+
+	t.Run("encrypted file", func(t *testing.T) {
+		path := "tests/" + randomString()
+		expected := bytes.Repeat([]byte("a"), encryptionChunkSize+5)
+		_, err := backend.WriteFile(bytes.NewReader(expected), path)
+		require.NoError(t, err)
+
+		for _, appended := range [][]byte{
+			[]byte("appended"),
+			{},
+			bytes.Repeat([]byte("b"), encryptionChunkSize),
+			bytes.Repeat([]byte("c"), 2*encryptionChunkSize+17),
+		} {
+			before, err := local.ReadFile(path)
+			require.NoError(t, err)
+
+			written, err := backend.AppendFile(bytes.NewReader(appended), path)
+			require.NoError(t, err)
+			require.EqualValues(t, len(appended), written)
+			expected = append(expected, appended...)
+
+			after, err := local.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, before, after[:len(before)], "existing data should not be rewritten")
+
+			read, err := backend.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, expected, read)
+
+			size, err := backend.FileSize(path)
+			require.NoError(t, err)
+			require.EqualValues(t, len(expected), size)
+		}
+
+		r, err := backend.Reader(path)
+		require.NoError(t, err)
+		defer r.Close()
+		for _, offset := range []int64{encryptionChunkSize, encryptionChunkSize + 3, 2*encryptionChunkSize + 10, int64(len(expected)) - 20} {
+			_, err = r.Seek(offset, io.SeekStart)
+			require.NoError(t, err)
+			buf := make([]byte, 20)
+			_, err = io.ReadFull(r, buf)
+			require.NoError(t, err)
+			require.Equal(t, expected[offset:offset+20], buf)
+		}
+	})
+
+	t.Run("plaintext file", func(t *testing.T) {
+		path := "tests/" + randomString()
+		_, err := local.WriteFile(bytes.NewReader([]byte("plaintext")), path)
+		require.NoError(t, err)
+
+		_, err = backend.AppendFile(bytes.NewReader([]byte(" appended")), path)
+		require.NoError(t, err)
+
+		raw, err := local.ReadFile(path)
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(raw, []byte(encryptionMagic)))
+
+		read, err := backend.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, []byte("plaintext appended"), read)
+
+		exists, err := backend.FileExists(path + appendTempSuffix)
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+}
+
+func TestEncryptedFileBackendReencrypt(t *testing.T) {
+	dir := t.TempDir()
+	local := &LocalFileBackend{directory: dir}
+	oldKey := newTestMasterKey(t)
+	newKey := newTestMasterKey(t)
+
+	plaintextPath := "tests/" + randomString()
+	plaintext := []byte("plaintext file")
+	_, err := local.WriteFile(bytes.NewReader(plaintext), plaintextPath)
+	require.NoError(t, err)
+
+	oldBackend := newTestEncryptedBackend(t, dir, oldKey)
+	encryptedPath := "tests/" + randomString()
+	encrypted := bytes.Repeat([]byte("encrypted file"), encryptionChunkSize/4)
+	_, err = oldBackend.WriteFile(bytes.NewReader(encrypted), encryptedPath)
+	require.NoError(t, err)
+
+	backend := newTestEncryptedBackend(t, dir, newKey, oldKey)
+
+	rewritten, err := backend.Reencrypt(plaintextPath)
+	require.NoError(t, err)
+	assert.True(t, rewritten)
+
+	rewritten, err = backend.Reencrypt(encryptedPath)
+	require.NoError(t, err)
+	assert.True(t, rewritten)
+
+	for path, data := range map[string][]byte{plaintextPath: plaintext, encryptedPath: encrypted} {
+		rewritten, err = backend.Reencrypt(path)
+		require.NoError(t, err)
+		assert.False(t, rewritten)
+
+		// Files can now be read without the retired key.
+		read, err := newTestEncryptedBackend(t, dir, newKey).ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, data, read)
+
+		exists, err := local.FileExists(path + reencryptTempSuffix)
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+}
@@ -6,6 +6,8 @@ package filestore
 import (
 	"context"
 	"io"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -13,8 +15,10 @@ import (
 )
 
 const (
-	driverS3    = "amazons3"
-	driverLocal = "local"
+	driverS3        = "amazons3"
+	driverLocal     = "local"
+	driverAzureBlob = "azureblob"
+	driverGCS       = "gcs"
 )
 
 type ReadCloseSeeker interface {
@@ -63,14 +67,67 @@ type FileBackendSettings struct {
 	AmazonS3RequestTimeoutMilliseconds int64
 	AmazonS3PresignExpiresSeconds      int64
 	AmazonS3UploadPartSizeBytes        int64
+	AzureStorageAccount                string
+	AzureAccessKey                     string
+	AzureContainer                     string
+	AzurePathPrefix                    string
+	AzureEndpoint                      string
+	AzureRequestTimeoutMilliseconds    int64
+	AzurePresignExpiresSeconds         int64
+	GCSBucket                          string
+	GCSPathPrefix                      string
+	GCSCredentialsJSON                 string
+	GCSEndpoint                        string
+	GCSRequestTimeoutMilliseconds      int64
+	GCSPresignExpiresSeconds           int64
+	EncryptionAtRest                   bool
+	EncryptionAtRestMasterKey          string
+	EncryptionAtRestRetiredKeys        []string
+	// EncryptionKeyProvider takes precedence over the master keys above when
+	// the data keys are managed by an external key management service.
+	EncryptionKeyProvider KeyProvider
 }
 
 func NewFileBackendSettingsFromConfig(fileSettings *model.FileSettings, enableComplianceFeature bool, skipVerify bool) FileBackendSettings {
-	if *fileSettings.DriverName == model.ImageDriverLocal {
+	settings := newDriverSettingsFromConfig(fileSettings, enableComplianceFeature, skipVerify)
+	if fileSettings.EnableEncryptionAtRest != nil && *fileSettings.EnableEncryptionAtRest {
+		settings.EncryptionAtRest = true
+		settings.EncryptionAtRestMasterKey = *fileSettings.EncryptionAtRestMasterKey
+		settings.EncryptionAtRestRetiredKeys = fileSettings.EncryptionAtRestRetiredKeys
+	}
+	return settings
+}
+
+func newDriverSettingsFromConfig(fileSettings *model.FileSettings, enableComplianceFeature bool, skipVerify bool) FileBackendSettings {
+	switch *fileSettings.DriverName {
+	case model.ImageDriverLocal:
 		return FileBackendSettings{
 			DriverName: *fileSettings.DriverName,
 			Directory:  *fileSettings.Directory,
 		}
+	case model.ImageDriverAzureBlob:
+		return FileBackendSettings{
+			DriverName:                      *fileSettings.DriverName,
+			AzureStorageAccount:             *fileSettings.AzureStorageAccount,
+			AzureAccessKey:                  *fileSettings.AzureAccessKey,
+			AzureContainer:                  *fileSettings.AzureContainer,
+			AzurePathPrefix:                 *fileSettings.AzurePathPrefix,
+			AzureEndpoint:                   *fileSettings.AzureEndpoint,
+			AzureRequestTimeoutMilliseconds: *fileSettings.AzureRequestTimeoutMilliseconds,
+			AzurePresignExpiresSeconds:      *fileSettings.AzurePresignExpiresSeconds,
+			SkipVerify:                      skipVerify,
+		}
+	case model.ImageDriverGCS:
+		return FileBackendSettings{
+			DriverName:                    *fileSettings.DriverName,
+			GCSBucket:                     *fileSettings.GCSBucket,
+			GCSPathPrefix:                 *fileSettings.GCSPathPrefix,
+			GCSCredentialsJSON:            *fileSettings.GCSCredentialsJSON,
+			GCSEndpoint:                   *fileSettings.GCSEndpoint,
+			GCSRequestTimeoutMilliseconds: *fileSettings.GCSRequestTimeoutMilliseconds,
+			GCSPresignExpiresSeconds:      *fileSettings.GCSPresignExpiresSeconds,
+			SkipVerify:                    skipVerify,
+		}
 	}
 	return FileBackendSettings{
 		DriverName:                         *fileSettings.DriverName,
@@ -117,6 +174,19 @@ func NewExportFileBackendSettingsFromConfig(fileSettings *model.FileSettings, en
 }
 
 func (settings *FileBackendSettings) CheckMandatoryS3Fields() error {
+	switch settings.DriverName {
+	case driverAzureBlob:
+		if settings.AzureContainer == "" {
+			return errors.New("missing azure container settings")
+		}
+		return nil
+	case driverGCS:
+		if settings.GCSBucket == "" {
+			return errors.New("missing gcs bucket settings")
+		}
+		return nil
+	}
+
 	if settings.AmazonS3Bucket == "" {
 		return errors.New("missing s3 bucket settings")
 	}
@@ -140,6 +210,22 @@ func NewExportFileBackend(settings FileBackendSettings) (FileBackend, error) {
 }
 
 func newFileBackend(settings FileBackendSettings, canBeCloud bool) (FileBackend, error) {
+	backend, err := newDriverFileBackend(settings, canBeCloud)
+	if err != nil || !settings.EncryptionAtRest {
+		return backend, err
+	}
+
+	provider := settings.EncryptionKeyProvider
+	if provider == nil {
+		provider, err = NewStaticKeyProvider(settings.EncryptionAtRestMasterKey, settings.EncryptionAtRestRetiredKeys)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load the encryption at rest master keys")
+		}
+	}
+	return NewEncryptedFileBackend(backend, provider), nil
+}
+
+func newDriverFileBackend(settings FileBackendSettings, canBeCloud bool) (FileBackend, error) {
 	switch settings.DriverName {
 	case driverS3:
 		newBackendFn := NewS3FileBackend
@@ -151,6 +237,18 @@ func newFileBackend(settings FileBackendSettings, canBeCloud bool) (FileBackend,
 			return nil, errors.Wrap(err, "unable to connect to the s3 backend")
 		}
 		return backend, nil
+	case driverAzureBlob:
+		backend, err := NewAzureFileBackend(settings)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to connect to the azure blob storage backend")
+		}
+		return backend, nil
+	case driverGCS:
+		backend, err := NewGCSFileBackend(settings)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to connect to the google cloud storage backend")
+		}
+		return backend, nil
 	case driverLocal:
 		return &LocalFileBackend{
 			directory: settings.Directory,
@@ -173,3 +271,37 @@ func TryWriteFileContext(ctx context.Context, fb FileBackend, fr io.Reader, path
 
 	return fb.WriteFile(fr, path)
 }
+
+// contentTypeForPath returns the content type object stores are given for a
+// file, based on its extension.
+func contentTypeForPath(path string) string {
+	if ext := filepath.Ext(path); isFileExtImage(ext) {
+		return getImageMimeType(ext)
+	}
+	return "binary/octet-stream"
+}
+
+// trimListedPaths strips the path prefix from the names listed by an object
+// store, so that it remains transparent to the application.
+func trimListedPaths(names []string, pathPrefix string) []string {
+	var paths []string
+	for _, name := range names {
+		trimmed := strings.Trim(strings.TrimPrefix(name, pathPrefix), "/")
+		if trimmed != "" {
+			paths = append(paths, trimmed)
+		}
+	}
+	return paths
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
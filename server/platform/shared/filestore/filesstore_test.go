@@ -6,6 +6,7 @@ package filestore
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"math"
@@ -53,6 +54,21 @@ func TestLocalFileBackendTestSuite(t *testing.T) {
 	})
 }
 
+func TestEncryptedLocalFileBackendTestSuite(t *testing.T) {
+	dir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	suite.Run(t, &FileBackendTestSuite{
+		settings: FileBackendSettings{
+			DriverName:                driverLocal,
+			Directory:                 dir,
+			EncryptionAtRest:          true,
+			EncryptionAtRestMasterKey: base64.StdEncoding.EncodeToString(make([]byte, 32)),
+		},
+	})
+}
+
 func TestS3FileBackendTestSuite(t *testing.T) {
 	runBackendTest(t, false)
 }
@@ -90,6 +106,53 @@ func runBackendTest(t *testing.T, encrypt bool) {
 	})
 }
 
+func TestAzureFileBackendTestSuite(t *testing.T) {
+	azuriteHost := os.Getenv("CI_AZURITE_HOST")
+	if azuriteHost == "" {
+		azuriteHost = "localhost"
+	}
+
+	azuritePort := os.Getenv("CI_AZURITE_PORT")
+	if azuritePort == "" {
+		azuritePort = "10000"
+	}
+
+	// Azurite only knows of the well-known development storage account.
+	suite.Run(t, &FileBackendTestSuite{
+		settings: FileBackendSettings{
+			DriverName:                      driverAzureBlob,
+			AzureStorageAccount:             "devstoreaccount1",
+			AzureAccessKey:                  "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw==",
+			AzureContainer:                  "mattermost-test",
+			AzureEndpoint:                   fmt.Sprintf("http://%s:%s/devstoreaccount1", azuriteHost, azuritePort),
+			AzureRequestTimeoutMilliseconds: 5000,
+			AzurePresignExpiresSeconds:      3600,
+		},
+	})
+}
+
+func TestGCSFileBackendTestSuite(t *testing.T) {
+	gcsHost := os.Getenv("CI_FAKE_GCS_HOST")
+	if gcsHost == "" {
+		gcsHost = "localhost"
+	}
+
+	gcsPort := os.Getenv("CI_FAKE_GCS_PORT")
+	if gcsPort == "" {
+		gcsPort = "4443"
+	}
+
+	suite.Run(t, &FileBackendTestSuite{
+		settings: FileBackendSettings{
+			DriverName:                    driverGCS,
+			GCSBucket:                     "mattermost-test",
+			GCSEndpoint:                   fmt.Sprintf("http://%s:%s/storage/v1/", gcsHost, gcsPort),
+			GCSRequestTimeoutMilliseconds: 5000,
+			GCSPresignExpiresSeconds:      3600,
+		},
+	})
+}
+
 func (s *FileBackendTestSuite) SetupTest() {
 	backend, err := NewFileBackend(s.settings)
 	require.NoError(s.T(), err)
@@ -97,10 +160,10 @@ func (s *FileBackendTestSuite) SetupTest() {
 
 	// This is needed to create the bucket if it doesn't exist.
 	err = s.backend.TestConnection()
-	if _, ok := err.(*S3FileBackendNoBucketError); ok {
-		s3Backend := s.backend.(*S3FileBackend)
-		s.NoError(s3Backend.MakeBucket())
-	} else {
+	switch err.(type) {
+	case *S3FileBackendNoBucketError, *AzureFileBackendNoContainerError, *GCSFileBackendNoBucketError:
+		s.NoError(s.backend.(interface{ MakeBucket() error }).MakeBucket())
+	default:
 		s.NoError(err)
 	}
 }
@@ -210,6 +273,38 @@ func (s *FileBackendTestSuite) TestReadWriteFileContext() {
 	})
 }
 
+func (s *FileBackendTestSuite) TestReader() {
+	b := []byte("0123456789")
+	path := "tests/" + randomString()
+
+	_, err := s.backend.WriteFile(bytes.NewReader(b), path)
+	s.Require().NoError(err)
+	defer s.backend.RemoveFile(path)
+
+	r, err := s.backend.Reader(path)
+	s.Require().NoError(err)
+	defer r.Close()
+
+	head := make([]byte, 4)
+	_, err = io.ReadFull(r, head)
+	s.NoError(err)
+	s.Equal("0123", string(head))
+
+	pos, err := r.Seek(-3, io.SeekEnd)
+	s.NoError(err)
+	s.EqualValues(7, pos)
+
+	tail, err := io.ReadAll(r)
+	s.NoError(err)
+	s.Equal("789", string(tail))
+
+	_, err = r.Seek(2, io.SeekStart)
+	s.NoError(err)
+	all, err := io.ReadAll(r)
+	s.NoError(err)
+	s.Equal("23456789", string(all))
+}
+
 func (s *FileBackendTestSuite) TestReadWriteFileImage() {
 	b := []byte("testimage")
 	path := "tests/" + randomString() + ".png"
@@ -806,3 +901,76 @@ func TestNewExportFileBackendSettingsFromConfig(t *testing.T) {
 		require.Equal(t, expected, actual)
 	})
 }
+
+func TestNewFileBackendSettingsFromConfig(t *testing.T) {
+	t.Run("azure blob storage filestore", func(t *testing.T) {
+		expected := FileBackendSettings{
+			DriverName:                      driverAzureBlob,
+			AzureStorageAccount:             "account",
+			AzureAccessKey:                  "key",
+			AzureContainer:                  "mattermost-test",
+			AzurePathPrefix:                 "prefix",
+			AzureEndpoint:                   "https://azure.example.com",
+			AzureRequestTimeoutMilliseconds: 1000,
+			AzurePresignExpiresSeconds:      60000,
+			SkipVerify:                      true,
+		}
+
+		actual := NewFileBackendSettingsFromConfig(&model.FileSettings{
+			DriverName:                      model.NewPointer(driverAzureBlob),
+			AzureStorageAccount:             model.NewPointer("account"),
+			AzureAccessKey:                  model.NewPointer("key"),
+			AzureContainer:                  model.NewPointer("mattermost-test"),
+			AzurePathPrefix:                 model.NewPointer("prefix"),
+			AzureEndpoint:                   model.NewPointer("https://azure.example.com"),
+			AzureRequestTimeoutMilliseconds: model.NewPointer(int64(1000)),
+			AzurePresignExpiresSeconds:      model.NewPointer(int64(60000)),
+		}, false, true)
+
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("google cloud storage filestore", func(t *testing.T) {
+		expected := FileBackendSettings{
+			DriverName:                    driverGCS,
+			GCSBucket:                     "mattermost-test",
+			GCSPathPrefix:                 "prefix",
+			GCSCredentialsJSON:            "{}",
+			GCSEndpoint:                   "https://gcs.example.com",
+			GCSRequestTimeoutMilliseconds: 1000,
+			GCSPresignExpiresSeconds:      60000,
+		}
+
+		actual := NewFileBackendSettingsFromConfig(&model.FileSettings{
+			DriverName:                    model.NewPointer(driverGCS),
+			GCSBucket:                     model.NewPointer("mattermost-test"),
+			GCSPathPrefix:                 model.NewPointer("prefix"),
+			GCSCredentialsJSON:            model.NewPointer("{}"),
+			GCSEndpoint:                   model.NewPointer("https://gcs.example.com"),
+			GCSRequestTimeoutMilliseconds: model.NewPointer(int64(1000)),
+			GCSPresignExpiresSeconds:      model.NewPointer(int64(60000)),
+		}, false, false)
+
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("encryption at rest", func(t *testing.T) {
+		expected := FileBackendSettings{
+			DriverName:                  driverLocal,
+			Directory:                   "directory",
+			EncryptionAtRest:            true,
+			EncryptionAtRestMasterKey:   "master",
+			EncryptionAtRestRetiredKeys: []string{"retired"},
+		}
+
+		actual := NewFileBackendSettingsFromConfig(&model.FileSettings{
+			DriverName:                  model.NewPointer(driverLocal),
+			Directory:                   model.NewPointer("directory"),
+			EnableEncryptionAtRest:      model.NewPointer(true),
+			EncryptionAtRestMasterKey:   model.NewPointer("master"),
+			EncryptionAtRestRetiredKeys: []string{"retired"},
+		}, false, false)
+
+		require.Equal(t, expected, actual)
+	})
+}
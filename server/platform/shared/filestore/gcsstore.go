@@ -0,0 +1,412 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// GCSFileBackend contains all necessary information to communicate with
+// a Google Cloud Storage bucket.
+type GCSFileBackend struct {
+	bucket         string
+	pathPrefix     string
+	projectID      string
+	client         *storage.Client
+	timeout        time.Duration
+	presignExpires time.Duration
+}
+
+type GCSFileBackendAuthError struct {
+	DetailedError string
+}
+
+// GCSFileBackendNoBucketError is returned when testing a connection and no bucket is found
+type GCSFileBackendNoBucketError struct{}
+
+var _ FileBackendWithLinkGenerator = (*GCSFileBackend)(nil)
+
+func (s *GCSFileBackendAuthError) Error() string {
+	return s.DetailedError
+}
+
+func (s *GCSFileBackendNoBucketError) Error() string {
+	return "no such bucket"
+}
+
+// NewGCSFileBackend returns an instance of a GCSFileBackend. When no
+// credentials are configured, the application default credentials are used,
+// unless a custom endpoint is set, in which case requests are not
+// authenticated so that emulators can be used.
+func NewGCSFileBackend(settings FileBackendSettings) (*GCSFileBackend, error) {
+	backend := &GCSFileBackend{
+		bucket:         settings.GCSBucket,
+		pathPrefix:     settings.GCSPathPrefix,
+		timeout:        time.Duration(settings.GCSRequestTimeoutMilliseconds) * time.Millisecond,
+		presignExpires: time.Duration(settings.GCSPresignExpiresSeconds) * time.Second,
+	}
+
+	var opts []option.ClientOption
+	if settings.GCSEndpoint != "" {
+		opts = append(opts, option.WithEndpoint(settings.GCSEndpoint))
+	}
+
+	if settings.GCSCredentialsJSON != "" {
+		var creds struct {
+			ProjectID string `json:"project_id"`
+		}
+		if err := json.Unmarshal([]byte(settings.GCSCredentialsJSON), &creds); err != nil {
+			return nil, errors.Wrap(err, "unable to parse the gcs credentials")
+		}
+		backend.projectID = creds.ProjectID
+		opts = append(opts, option.WithCredentialsJSON([]byte(settings.GCSCredentialsJSON)))
+	} else if settings.GCSEndpoint != "" {
+		opts = append(opts, option.WithoutAuthentication())
+		if settings.SkipVerify {
+			tr := http.DefaultTransport.(*http.Transport).Clone()
+			tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+			opts = append(opts, option.WithHTTPClient(&http.Client{Transport: tr}))
+		}
+	}
+
+	client, err := storage.NewClient(context.Background(), opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the gcs client")
+	}
+	backend.client = client
+
+	return backend, nil
+}
+
+func (b *GCSFileBackend) DriverName() string {
+	return driverGCS
+}
+
+func (b *GCSFileBackend) TestConnection() error {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	if _, err := b.client.Bucket(b.bucket).Attrs(ctx); err != nil {
+		if errors.Is(err, storage.ErrBucketNotExist) {
+			return &GCSFileBackendNoBucketError{}
+		}
+		return &GCSFileBackendAuthError{DetailedError: "unable to check if the GCS bucket exists"}
+	}
+	mlog.Debug("Connection to Google Cloud Storage is good. Bucket exists.")
+	return nil
+}
+
+// MakeBucket creates the bucket the backend stores its files in, in the
+// project of the configured credentials.
+func (b *GCSFileBackend) MakeBucket() error {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	if err := b.client.Bucket(b.bucket).Create(ctx, b.projectID, nil); err != nil {
+		return errors.Wrap(err, "unable to create the gcs bucket")
+	}
+	return nil
+}
+
+func (b *GCSFileBackend) object(path string) *storage.ObjectHandle {
+	return b.client.Bucket(b.bucket).Object(path)
+}
+
+// Caller must close the first return value
+func (b *GCSFileBackend) Reader(path string) (ReadCloseSeeker, error) {
+	path = b.prefixedPath(path)
+	obj := b.object(path)
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	attrs, err := obj.Attrs(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open file %s", path)
+	}
+
+	return newRangeReader(b.timeout, attrs.Size, func(ctx context.Context, offset int64) (io.ReadCloser, error) {
+		r, err := obj.NewRangeReader(ctx, offset, -1)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read file %s", path)
+		}
+		return r, nil
+	}), nil
+}
+
+func (b *GCSFileBackend) ReadFile(path string) ([]byte, error) {
+	path = b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	r, err := b.object(path).NewReader(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open file %s", path)
+	}
+
+	defer r.Close()
+	f, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read file %s", path)
+	}
+	return f, nil
+}
+
+func (b *GCSFileBackend) FileExists(path string) (bool, error) {
+	path = b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	_, err := b.object(path).Attrs(ctx)
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return false, nil
+	}
+
+	return false, errors.Wrapf(err, "unable to know if file %s exists", path)
+}
+
+func (b *GCSFileBackend) FileSize(path string) (int64, error) {
+	path = b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	attrs, err := b.object(path).Attrs(ctx)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to get file size for %s", path)
+	}
+
+	return attrs.Size, nil
+}
+
+func (b *GCSFileBackend) FileModTime(path string) (time.Time, error) {
+	path = b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	attrs, err := b.object(path).Attrs(ctx)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "unable to get modification time for file %s", path)
+	}
+
+	return attrs.Updated, nil
+}
+
+func (b *GCSFileBackend) CopyFile(oldPath, newPath string) error {
+	oldPath = b.prefixedPath(oldPath)
+	newPath = b.prefixedPath(newPath)
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	if _, err := b.object(newPath).CopierFrom(b.object(oldPath)).Run(ctx); err != nil {
+		return errors.Wrapf(err, "unable to copy file from %s to %s", oldPath, newPath)
+	}
+
+	return nil
+}
+
+func (b *GCSFileBackend) MoveFile(oldPath, newPath string) error {
+	oldPath = b.prefixedPath(oldPath)
+	newPath = b.prefixedPath(newPath)
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	if _, err := b.object(newPath).CopierFrom(b.object(oldPath)).Run(ctx); err != nil {
+		return errors.Wrapf(err, "unable to copy the file to %s to the new destination", newPath)
+	}
+
+	ctx2, cancel2 := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel2()
+	if err := b.object(oldPath).Delete(ctx2); err != nil {
+		return errors.Wrapf(err, "unable to remove the file old file %s", oldPath)
+	}
+
+	return nil
+}
+
+func (b *GCSFileBackend) WriteFile(fr io.Reader, path string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	return b.WriteFileContext(ctx, fr, path)
+}
+
+func (b *GCSFileBackend) WriteFileContext(ctx context.Context, fr io.Reader, path string) (int64, error) {
+	path = b.prefixedPath(path)
+	return b.writeObject(ctx, fr, path)
+}
+
+func (b *GCSFileBackend) writeObject(ctx context.Context, fr io.Reader, path string) (int64, error) {
+	// The writer is cancelled along with the context, which discards the
+	// object if it hasn't been closed yet.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := b.object(path).NewWriter(ctx)
+	w.ContentType = contentTypeForPath(path)
+	written, err := io.Copy(w, fr)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable write the data in the file %s", path)
+	}
+	if err := w.Close(); err != nil {
+		return 0, errors.Wrapf(err, "unable write the data in the file %s", path)
+	}
+
+	return written, nil
+}
+
+func (b *GCSFileBackend) AppendFile(fr io.Reader, path string) (int64, error) {
+	fp := b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	if _, err := b.object(fp).Attrs(ctx); err != nil {
+		return 0, errors.Wrapf(err, "unable to find the file %s to append the data", path)
+	}
+
+	partName := fp + ".part"
+	ctx2, cancel2 := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel2()
+	written, err := b.writeObject(ctx2, fr, partName)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable append the data in the file %s", path)
+	}
+	defer func() {
+		ctx4, cancel4 := context.WithTimeout(context.Background(), b.timeout)
+		defer cancel4()
+		b.object(partName).Delete(ctx4)
+	}()
+
+	ctx3, cancel3 := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel3()
+	composer := b.object(fp).ComposerFrom(b.object(fp), b.object(partName))
+	composer.ContentType = contentTypeForPath(fp)
+	if _, err := composer.Run(ctx3); err != nil {
+		return 0, errors.Wrapf(err, "unable append the data in the file %s", path)
+	}
+
+	return written, nil
+}
+
+func (b *GCSFileBackend) RemoveFile(path string) error {
+	path = b.prefixedPath(path)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	if err := b.object(path).Delete(ctx); err != nil {
+		return errors.Wrapf(err, "unable to remove the file %s", path)
+	}
+
+	return nil
+}
+
+func (b *GCSFileBackend) listDirectory(path string, recursion bool) ([]string, error) {
+	path = b.prefixedPath(path)
+	if !strings.HasSuffix(path, "/") && path != "" {
+		// Listing a prefix would also return the objects sharing the same
+		// name prefix, so we make sure to only list the directory.
+		path = path + "/"
+	}
+
+	query := &storage.Query{Prefix: path}
+	if !recursion {
+		query.Delimiter = "/"
+	}
+	if err := query.SetAttrSelection([]string{"Name"}); err != nil {
+		return nil, errors.Wrapf(err, "unable to list the directory %s", path)
+	}
+
+	var names []string
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	it := b.client.Bucket(b.bucket).Objects(ctx, query)
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "unable to list the directory %s", path)
+		}
+		if attrs.Prefix != "" {
+			names = append(names, attrs.Prefix)
+		} else {
+			names = append(names, attrs.Name)
+		}
+	}
+
+	paths := trimListedPaths(names, b.pathPrefix)
+	// Check if only one item was returned and it matches the path prefix
+	if len(paths) == 1 && strings.TrimRight(path, "/") == paths[0] {
+		// Return a fs.PathError to maintain consistency
+		return nil, &fs.PathError{Op: "readdir", Path: path, Err: fs.ErrNotExist}
+	}
+
+	return paths, nil
+}
+
+func (b *GCSFileBackend) ListDirectory(path string) ([]string, error) {
+	return b.listDirectory(path, false)
+}
+
+func (b *GCSFileBackend) ListDirectoryRecursively(path string) ([]string, error) {
+	return b.listDirectory(path, true)
+}
+
+func (b *GCSFileBackend) RemoveDirectory(path string) error {
+	path = b.prefixedPath(path)
+	query := &storage.Query{Prefix: path}
+	if err := query.SetAttrSelection([]string{"Name"}); err != nil {
+		return errors.Wrapf(err, "unable to remove the directory %s", path)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	it := b.client.Bucket(b.bucket).Objects(ctx, query)
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		} else if err != nil {
+			return errors.Wrapf(err, "unable to remove the directory %s", path)
+		}
+		if err := b.object(attrs.Name).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			return errors.Wrapf(err, "unable to remove the directory %s", path)
+		}
+	}
+
+	return nil
+}
+
+func (b *GCSFileBackend) GeneratePublicLink(path string) (string, time.Duration, error) {
+	path = b.prefixedPath(path)
+
+	reqParams := make(url.Values)
+	reqParams.Set("response-content-disposition", "attachment")
+
+	link, err := b.client.Bucket(b.bucket).SignedURL(path, &storage.SignedURLOptions{
+		Method:          http.MethodGet,
+		Expires:         time.Now().Add(b.presignExpires),
+		Scheme:          storage.SigningSchemeV4,
+		QueryParameters: reqParams,
+	})
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "unable to generate public link for %s", path)
+	}
+
+	return link, b.presignExpires, nil
+}
+
+func (b *GCSFileBackend) prefixedPath(s string) string {
+	return filepath.Join(b.pathPrefix, s)
+}
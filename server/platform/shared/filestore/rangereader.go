@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// rangeReader implements ReadCloseSeeker on top of object stores that only
+// offer ranged downloads. The body of the object is opened lazily at the
+// current offset, and reopened whenever a seek moves the offset.
+type rangeReader struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	timer  *time.Timer
+
+	open   func(ctx context.Context, offset int64) (io.ReadCloser, error)
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+func newRangeReader(timeout time.Duration, size int64, open func(ctx context.Context, offset int64) (io.ReadCloser, error)) *rangeReader {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &rangeReader{
+		ctx:    ctx,
+		cancel: cancel,
+		timer:  time.AfterFunc(timeout, cancel),
+		open:   open,
+		size:   size,
+	}
+}
+
+func (r *rangeReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	if r.body == nil {
+		body, err := r.open(r.ctx, r.offset)
+		if err != nil {
+			return 0, err
+		}
+		r.body = body
+	}
+
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *rangeReader) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = r.offset + offset
+	case io.SeekEnd:
+		abs = r.size + offset
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if abs < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if abs != r.offset && r.body != nil {
+		r.body.Close()
+		r.body = nil
+	}
+	r.offset = abs
+
+	return abs, nil
+}
+
+func (r *rangeReader) Close() error {
+	r.timer.Stop()
+	defer r.cancel()
+	if r.body != nil {
+		return r.body.Close()
+	}
+	return nil
+}
+
+// CancelTimeout attempts to cancel the timeout for this reader. It allows calling
+// code to ignore the timeout in case of longer running operations. The methods returns
+// false if the timeout has already fired.
+func (r *rangeReader) CancelTimeout() bool {
+	return r.timer.Stop()
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mfa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// RecoveryCodeCount is the number of recovery codes generated at once.
+	RecoveryCodeCount = 10
+
+	// This will result in 80 bits of entropy per code.
+	recoveryCodeSize       = 10
+	recoveryCodeGroupRunes = 4
+)
+
+var recoveryCodeRegexp = regexp.MustCompile(`^[a-z2-7]{4}(-[a-z2-7]{4}){3}$`)
+
+type RecoveryCodeStore interface {
+	SaveForUser(userID string, codeHashes []string) error
+	Use(userID, codeHash string) (bool, error)
+}
+
+// RecoveryCodes are one-time codes that replace the second factor of a user
+// who lost access to it. Only their hashes are stored.
+type RecoveryCodes struct {
+	store RecoveryCodeStore
+}
+
+func NewRecoveryCodes(store RecoveryCodeStore) *RecoveryCodes {
+	return &RecoveryCodes{store}
+}
+
+// IsRecoveryCode returns true if the token has the format of a recovery code
+// rather than of a TOTP token.
+func IsRecoveryCode(token string) bool {
+	return recoveryCodeRegexp.MatchString(normalizeRecoveryCode(token))
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+// hashRecoveryCode hashes the code along with the user ID. The codes have
+// enough entropy for a fast hash to be safe.
+func hashRecoveryCode(userID, code string) string {
+	hash := sha256.Sum256([]byte(userID + ":" + normalizeRecoveryCode(code)))
+	return hex.EncodeToString(hash[:])
+}
+
+func newRecoveryCode() string {
+	data := make([]byte, recoveryCodeSize)
+	rand.Read(data)
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(data))
+
+	groups := make([]string, 0, len(code)/recoveryCodeGroupRunes)
+	for i := 0; i < len(code); i += recoveryCodeGroupRunes {
+		groups = append(groups, code[i:i+recoveryCodeGroupRunes])
+	}
+	return strings.Join(groups, "-")
+}
+
+// Generate replaces the recovery codes of the user with new ones, and
+// returns them. They can't be retrieved later on.
+func (r *RecoveryCodes) Generate(userID string) ([]string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		codes[i] = newRecoveryCode()
+		hashes[i] = hashRecoveryCode(userID, codes[i])
+	}
+
+	if err := r.store.SaveForUser(userID, hashes); err != nil {
+		return nil, errors.Wrap(err, "unable to store the recovery codes")
+	}
+
+	return codes, nil
+}
+
+// Use consumes the recovery code of the user. It returns false if the code
+// doesn't exist or was already used.
+func (r *RecoveryCodes) Use(userID, code string) (bool, error) {
+	if !IsRecoveryCode(code) {
+		return false, nil
+	}
+
+	used, err := r.store.Use(userID, hashRecoveryCode(userID, code))
+	if err != nil {
+		return false, errors.Wrap(err, "unable to use the recovery code")
+	}
+
+	return used, nil
+}
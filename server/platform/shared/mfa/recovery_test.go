@@ -0,0 +1,90 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mfa
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest/mock"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	userID := "user-id"
+
+	t.Run("fail on store action fail", func(t *testing.T) {
+		storeMock := mocks.MfaRecoveryCodeStore{}
+		storeMock.On("SaveForUser", userID, mock.Anything).Return(errors.New("failed to save"))
+
+		_, err := NewRecoveryCodes(&storeMock).Generate(userID)
+		require.ErrorContains(t, err, "unable to store the recovery codes")
+	})
+
+	t.Run("successful generate", func(t *testing.T) {
+		var savedHashes []string
+		storeMock := mocks.MfaRecoveryCodeStore{}
+		storeMock.On("SaveForUser", userID, mock.Anything).Run(func(args mock.Arguments) {
+			savedHashes = args.Get(1).([]string)
+		}).Return(nil)
+
+		codes, err := NewRecoveryCodes(&storeMock).Generate(userID)
+		require.NoError(t, err)
+		require.Len(t, codes, RecoveryCodeCount)
+		require.Len(t, savedHashes, RecoveryCodeCount)
+
+		seen := map[string]bool{}
+		for i, code := range codes {
+			assert.True(t, IsRecoveryCode(code), code)
+			assert.False(t, seen[code], "codes must be unique")
+			seen[code] = true
+			assert.Equal(t, hashRecoveryCode(userID, code), savedHashes[i])
+			assert.NotContains(t, savedHashes[i], code, "codes must not be stored in clear")
+		}
+	})
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	userID := "user-id"
+	code := "abcd-efgh-ijkl-mn23"
+
+	t.Run("normalizes the code", func(t *testing.T) {
+		storeMock := mocks.MfaRecoveryCodeStore{}
+		storeMock.On("Use", userID, hashRecoveryCode(userID, code)).Return(true, nil)
+
+		used, err := NewRecoveryCodes(&storeMock).Use(userID, " "+strings.ToUpper(code)+" ")
+		require.NoError(t, err)
+		assert.True(t, used)
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		storeMock := mocks.MfaRecoveryCodeStore{}
+		storeMock.On("Use", userID, mock.Anything).Return(false, nil)
+
+		used, err := NewRecoveryCodes(&storeMock).Use(userID, code)
+		require.NoError(t, err)
+		assert.False(t, used)
+	})
+
+	t.Run("TOTP token", func(t *testing.T) {
+		storeMock := mocks.MfaRecoveryCodeStore{}
+
+		used, err := NewRecoveryCodes(&storeMock).Use(userID, "123456")
+		require.NoError(t, err)
+		assert.False(t, used)
+		storeMock.AssertNotCalled(t, "Use", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fail on store action fail", func(t *testing.T) {
+		storeMock := mocks.MfaRecoveryCodeStore{}
+		storeMock.On("Use", userID, mock.Anything).Return(false, errors.New("failed"))
+
+		_, err := NewRecoveryCodes(&storeMock).Use(userID, code)
+		require.ErrorContains(t, err, "unable to use the recovery code")
+	})
+}
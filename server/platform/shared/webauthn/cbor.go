@@ -0,0 +1,186 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	cborUnsigned = iota
+	cborNegative
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+// cborMaxDepth limits the nesting of the items, authenticators never send
+// deeply nested structures.
+const cborMaxDepth = 16
+
+var errCBORTruncated = errors.New("truncated CBOR data")
+
+// decodeCBOR decodes the first CBOR item (RFC 8949) of data and returns it
+// along with the number of bytes read. It supports the subset of CBOR used by
+// WebAuthn: integers are returned as int64, byte strings as []byte, text
+// strings as string, arrays as []any and maps as map[any]any. Indefinite
+// length items and floating point numbers are not supported.
+func decodeCBOR(data []byte) (any, int, error) {
+	d := &cborDecoder{data: data}
+	item, err := d.decode(0)
+	if err != nil {
+		return nil, 0, err
+	}
+	return item, d.offset, nil
+}
+
+type cborDecoder struct {
+	data   []byte
+	offset int
+}
+
+func (d *cborDecoder) decode(depth int) (any, error) {
+	if depth > cborMaxDepth {
+		return nil, errors.New("CBOR data is nested too deeply")
+	}
+
+	major, argument, err := d.readHead()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborUnsigned:
+		if argument > math.MaxInt64 {
+			return nil, errors.New("CBOR integer overflows int64")
+		}
+		return int64(argument), nil
+	case cborNegative:
+		if argument > math.MaxInt64 {
+			return nil, errors.New("CBOR integer overflows int64")
+		}
+		return -1 - int64(argument), nil
+	case cborBytes:
+		return d.readBytes(argument)
+	case cborText:
+		b, err := d.readBytes(argument)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case cborArray:
+		if argument > uint64(len(d.data)-d.offset) {
+			return nil, errCBORTruncated
+		}
+		array := make([]any, 0, argument)
+		for i := uint64(0); i < argument; i++ {
+			item, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, item)
+		}
+		return array, nil
+	case cborMap:
+		if argument > uint64(len(d.data)-d.offset) {
+			return nil, errCBORTruncated
+		}
+		m := make(map[any]any, argument)
+		for i := uint64(0); i < argument; i++ {
+			key, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, fmt.Errorf("unsupported CBOR map key of type %T", key)
+			}
+			value, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = value
+		}
+		return m, nil
+	case cborTag:
+		// Tags only add semantics to the tagged item.
+		return d.decode(depth + 1)
+	default:
+		switch argument {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22, 23:
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unsupported CBOR simple value %d", argument)
+	}
+}
+
+// readHead reads the initial byte of an item and its argument.
+func (d *cborDecoder) readHead() (byte, uint64, error) {
+	if d.offset >= len(d.data) {
+		return 0, 0, errCBORTruncated
+	}
+	initial := d.data[d.offset]
+	d.offset++
+
+	major := initial >> 5
+	info := initial & 0x1f
+
+	var size int
+	switch {
+	case info < 24:
+		return major, uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, 0, fmt.Errorf("unsupported CBOR additional information %d", info)
+	}
+
+	if major == cborSimple && size > 1 {
+		return 0, 0, errors.New("CBOR floating point numbers are not supported")
+	}
+	if len(d.data)-d.offset < size {
+		return 0, 0, errCBORTruncated
+	}
+
+	var argument uint64
+	switch size {
+	case 1:
+		argument = uint64(d.data[d.offset])
+	case 2:
+		argument = uint64(binary.BigEndian.Uint16(d.data[d.offset:]))
+	case 4:
+		argument = uint64(binary.BigEndian.Uint32(d.data[d.offset:]))
+	case 8:
+		argument = binary.BigEndian.Uint64(d.data[d.offset:])
+	}
+	d.offset += size
+
+	return major, argument, nil
+}
+
+func (d *cborDecoder) readBytes(length uint64) ([]byte, error) {
+	if length > uint64(len(d.data)-d.offset) {
+		return nil, errCBORTruncated
+	}
+	b := d.data[d.offset : d.offset+int(length)]
+	d.offset += int(length)
+	return b, nil
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package webauthn verifies the registration and authentication ceremonies of
+// WebAuthn credentials (https://www.w3.org/TR/webauthn-2/), including
+// passkeys. Attestation statements are not verified: credentials are trusted
+// on first use, as requested by the "none" attestation conveyance.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	ceremonyCreate = "webauthn.create"
+	ceremonyGet    = "webauthn.get"
+
+	flagUserPresent         = 0x01
+	flagUserVerified        = 0x04
+	flagAttestedCredentials = 0x40
+
+	authenticatorDataMinLength = 37
+	aaguidLength               = 16
+)
+
+// ErrCredentialCloned is returned when the signature counter of a credential
+// did not increase, which suggests the authenticator was cloned.
+var ErrCredentialCloned = errors.New("the signature counter of the credential did not increase")
+
+// RelyingParty identifies the server the credentials are scoped to.
+type RelyingParty struct {
+	// ID is the effective domain of the server, such as "chat.example.com".
+	ID string
+	// Origin is the origin of the web application, such as
+	// "https://chat.example.com".
+	Origin string
+}
+
+// ClientData is the client data collected by the browser during a ceremony.
+type ClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// Credential is a credential created by an authenticator.
+type Credential struct {
+	ID []byte
+	// PublicKey is the COSE_Key encoding of the credential public key.
+	PublicKey    []byte
+	SignCount    uint32
+	UserVerified bool
+}
+
+// Assertion is the result of a successful authentication ceremony.
+type Assertion struct {
+	SignCount    uint32
+	UserVerified bool
+}
+
+// DecodeBase64URL decodes the unpadded base64url encoding used by WebAuthn,
+// tolerating padding.
+func DecodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// EncodeBase64URL encodes binary data for WebAuthn clients.
+func EncodeBase64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ParseClientData decodes the clientDataJSON of a ceremony. It is used to
+// look up the challenge before verifying the ceremony.
+func ParseClientData(clientDataJSON []byte) (*ClientData, error) {
+	var clientData ClientData
+	if err := json.Unmarshal(clientDataJSON, &clientData); err != nil {
+		return nil, fmt.Errorf("failed to decode the client data: %w", err)
+	}
+	return &clientData, nil
+}
+
+// VerifyRegistration verifies the response of the authenticator to a
+// credential creation request issued with the given challenge, and returns
+// the new credential.
+func (rp *RelyingParty) VerifyRegistration(challenge, clientDataJSON, attestationObject []byte, requireUserVerification bool) (*Credential, error) {
+	if err := rp.verifyClientData(clientDataJSON, ceremonyCreate, challenge); err != nil {
+		return nil, err
+	}
+
+	item, _, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the attestation object: %w", err)
+	}
+	attestation, ok := item.(map[any]any)
+	if !ok {
+		return nil, errors.New("the attestation object is not a map")
+	}
+	authData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, errors.New("the attestation object has no authenticator data")
+	}
+
+	flags, signCount, err := rp.verifyAuthenticatorData(authData, requireUserVerification)
+	if err != nil {
+		return nil, err
+	}
+	if flags&flagAttestedCredentials == 0 {
+		return nil, errors.New("the authenticator data has no attested credential")
+	}
+
+	// The attested credential data follows the fixed length header.
+	data := authData[authenticatorDataMinLength:]
+	if len(data) < aaguidLength+2 {
+		return nil, errors.New("the attested credential data is truncated")
+	}
+	data = data[aaguidLength:]
+	idLength := int(binary.BigEndian.Uint16(data))
+	data = data[2:]
+	if idLength == 0 || len(data) < idLength {
+		return nil, errors.New("invalid credential ID")
+	}
+	credentialID := data[:idLength]
+	data = data[idLength:]
+
+	// The public key may be followed by extensions.
+	_, keyLength, err := parsePublicKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		ID:           bytes.Clone(credentialID),
+		PublicKey:    bytes.Clone(data[:keyLength]),
+		SignCount:    signCount,
+		UserVerified: flags&flagUserVerified != 0,
+	}, nil
+}
+
+// VerifyAssertion verifies the response of the authenticator to an
+// authentication request issued with the given challenge, using the COSE
+// public key and the signature counter stored for the credential.
+func (rp *RelyingParty) VerifyAssertion(challenge, publicKeyCOSE []byte, storedSignCount uint32, clientDataJSON, authenticatorData, signature []byte, requireUserVerification bool) (*Assertion, error) {
+	if err := rp.verifyClientData(clientDataJSON, ceremonyGet, challenge); err != nil {
+		return nil, err
+	}
+
+	flags, signCount, err := rp.verifyAuthenticatorData(authenticatorData, requireUserVerification)
+	if err != nil {
+		return nil, err
+	}
+
+	key, _, err := parsePublicKey(publicKeyCOSE)
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(bytes.Clone(authenticatorData), clientDataHash[:]...)
+	if err := key.verify(signed, signature); err != nil {
+		return nil, err
+	}
+
+	// Authenticators which don't implement the counter always report zero.
+	if (signCount != 0 || storedSignCount != 0) && signCount <= storedSignCount {
+		return nil, ErrCredentialCloned
+	}
+
+	return &Assertion{
+		SignCount:    signCount,
+		UserVerified: flags&flagUserVerified != 0,
+	}, nil
+}
+
+func (rp *RelyingParty) verifyClientData(clientDataJSON []byte, ceremony string, challenge []byte) error {
+	clientData, err := ParseClientData(clientDataJSON)
+	if err != nil {
+		return err
+	}
+
+	if clientData.Type != ceremony {
+		return fmt.Errorf("unexpected ceremony type %q", clientData.Type)
+	}
+
+	receivedChallenge, err := DecodeBase64URL(clientData.Challenge)
+	if err != nil || len(challenge) == 0 || subtle.ConstantTimeCompare(receivedChallenge, challenge) != 1 {
+		return errors.New("the challenge does not match")
+	}
+
+	if clientData.Origin != rp.Origin {
+		return fmt.Errorf("unexpected origin %q", clientData.Origin)
+	}
+	if clientData.CrossOrigin {
+		return errors.New("cross origin ceremonies are not allowed")
+	}
+
+	return nil
+}
+
+// verifyAuthenticatorData checks the fixed length header of the
+// authenticator data, and returns its flags and signature counter.
+func (rp *RelyingParty) verifyAuthenticatorData(authData []byte, requireUserVerification bool) (byte, uint32, error) {
+	if len(authData) < authenticatorDataMinLength {
+		return 0, 0, errors.New("the authenticator data is truncated")
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if !slices.Equal(authData[:32], rpIDHash[:]) {
+		return 0, 0, errors.New("the credential is scoped to another relying party")
+	}
+
+	flags := authData[32]
+	if flags&flagUserPresent == 0 {
+		return 0, 0, errors.New("the user was not present")
+	}
+	if requireUserVerification && flags&flagUserVerified == 0 {
+		return 0, 0, errors.New("the user was not verified")
+	}
+
+	return flags, binary.BigEndian.Uint32(authData[33:37]), nil
+}
@@ -0,0 +1,337 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeCBOR encodes the subset of CBOR decoded by decodeCBOR.
+func encodeCBOR(v any) []byte {
+	head := func(major byte, argument uint64) []byte {
+		switch {
+		case argument < 24:
+			return []byte{major<<5 | byte(argument)}
+		case argument <= 0xff:
+			return []byte{major<<5 | 24, byte(argument)}
+		case argument <= 0xffff:
+			return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(argument))
+		default:
+			return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(argument))
+		}
+	}
+
+	switch v := v.(type) {
+	case int:
+		if v < 0 {
+			return head(cborNegative, uint64(-1-v))
+		}
+		return head(cborUnsigned, uint64(v))
+	case []byte:
+		return append(head(cborBytes, uint64(len(v))), v...)
+	case string:
+		return append(head(cborText, uint64(len(v))), v...)
+	case []any:
+		out := head(cborArray, uint64(len(v)))
+		for _, item := range v {
+			out = append(out, encodeCBOR(item)...)
+		}
+		return out
+	case map[any]any:
+		out := head(cborMap, uint64(len(v)))
+		for key, value := range v {
+			out = append(out, encodeCBOR(key)...)
+			out = append(out, encodeCBOR(value)...)
+		}
+		return out
+	}
+	panic("unsupported type")
+}
+
+// testAuthenticator emulates an authenticator holding a single credential.
+type testAuthenticator struct {
+	rpID         string
+	origin       string
+	credentialID []byte
+	signer       crypto.Signer
+	publicKey    []byte
+	signCount    uint32
+	noCounter    bool
+	flags        byte
+}
+
+func newTestAuthenticator(t *testing.T, algorithm int) *testAuthenticator {
+	a := &testAuthenticator{
+		rpID:         "chat.example.com",
+		origin:       "https://chat.example.com",
+		credentialID: make([]byte, 32),
+		flags:        flagUserPresent | flagUserVerified,
+	}
+	_, err := rand.Read(a.credentialID)
+	require.NoError(t, err)
+
+	switch algorithm {
+	case AlgorithmES256:
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		a.signer = key
+		a.publicKey = encodeCBOR(map[any]any{
+			coseKeyType:      coseKeyTypeEC2,
+			coseKeyAlgorithm: AlgorithmES256,
+			coseKeyCurve:     coseCurveP256,
+			coseKeyX:         key.X.FillBytes(make([]byte, 32)),
+			coseKeyY:         key.Y.FillBytes(make([]byte, 32)),
+		})
+	case AlgorithmEdDSA:
+		public, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		a.signer = key
+		a.publicKey = encodeCBOR(map[any]any{
+			coseKeyType:      coseKeyTypeOKP,
+			coseKeyAlgorithm: AlgorithmEdDSA,
+			coseKeyCurve:     coseCurveEd25519,
+			coseKeyX:         []byte(public),
+		})
+	case AlgorithmRS256:
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		a.signer = key
+		a.publicKey = encodeCBOR(map[any]any{
+			coseKeyType:      coseKeyTypeRSA,
+			coseKeyAlgorithm: AlgorithmRS256,
+			coseKeyRSAN:      key.N.Bytes(),
+			coseKeyRSAE:      big.NewInt(int64(key.E)).Bytes(),
+		})
+	}
+
+	return a
+}
+
+func (a *testAuthenticator) clientData(ceremony string, challenge []byte) []byte {
+	clientData, _ := json.Marshal(ClientData{
+		Type:      ceremony,
+		Challenge: EncodeBase64URL(challenge),
+		Origin:    a.origin,
+	})
+	return clientData
+}
+
+func (a *testAuthenticator) authenticatorData(flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.rpID))
+	data := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(data, a.signCount)
+}
+
+func (a *testAuthenticator) create(challenge []byte) (clientDataJSON, attestationObject []byte) {
+	authData := a.authenticatorData(a.flags | flagAttestedCredentials)
+	authData = append(authData, make([]byte, aaguidLength)...)
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.credentialID)))
+	authData = append(authData, a.credentialID...)
+	authData = append(authData, a.publicKey...)
+
+	return a.clientData(ceremonyCreate, challenge), encodeCBOR(map[any]any{
+		"fmt":      "none",
+		"attStmt":  map[any]any{},
+		"authData": authData,
+	})
+}
+
+func (a *testAuthenticator) get(t *testing.T, challenge []byte) (clientDataJSON, authData, signature []byte) {
+	if !a.noCounter {
+		a.signCount++
+	}
+	clientDataJSON = a.clientData(ceremonyGet, challenge)
+	authData = a.authenticatorData(a.flags)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(authData, clientDataHash[:]...)
+
+	var err error
+	if _, ok := a.signer.(ed25519.PrivateKey); ok {
+		signature, err = a.signer.Sign(rand.Reader, signed, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(signed)
+		signature, err = a.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	require.NoError(t, err)
+
+	return clientDataJSON, authData, signature
+}
+
+func TestCeremonies(t *testing.T) {
+	rp := &RelyingParty{ID: "chat.example.com", Origin: "https://chat.example.com"}
+	challenge := []byte("a random challenge of enough bytes")
+
+	for name, algorithm := range map[string]int{"ES256": AlgorithmES256, "EdDSA": AlgorithmEdDSA, "RS256": AlgorithmRS256} {
+		t.Run(name, func(t *testing.T) {
+			authenticator := newTestAuthenticator(t, algorithm)
+
+			clientDataJSON, attestationObject := authenticator.create(challenge)
+			credential, err := rp.VerifyRegistration(challenge, clientDataJSON, attestationObject, true)
+			require.NoError(t, err)
+			assert.Equal(t, authenticator.credentialID, credential.ID)
+			assert.Equal(t, authenticator.publicKey, credential.PublicKey)
+			assert.True(t, credential.UserVerified)
+
+			clientDataJSON, authData, signature := authenticator.get(t, challenge)
+			assertion, err := rp.VerifyAssertion(challenge, credential.PublicKey, credential.SignCount, clientDataJSON, authData, signature, true)
+			require.NoError(t, err)
+			assert.Equal(t, uint32(1), assertion.SignCount)
+			assert.True(t, assertion.UserVerified)
+		})
+	}
+}
+
+func TestVerifyRegistration(t *testing.T) {
+	rp := &RelyingParty{ID: "chat.example.com", Origin: "https://chat.example.com"}
+	challenge := []byte("a random challenge of enough bytes")
+
+	t.Run("wrong challenge", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, AlgorithmES256)
+		clientDataJSON, attestationObject := authenticator.create([]byte("another challenge"))
+		_, err := rp.VerifyRegistration(challenge, clientDataJSON, attestationObject, false)
+		require.ErrorContains(t, err, "challenge")
+	})
+
+	t.Run("wrong origin", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, AlgorithmES256)
+		authenticator.origin = "https://evil.example.com"
+		clientDataJSON, attestationObject := authenticator.create(challenge)
+		_, err := rp.VerifyRegistration(challenge, clientDataJSON, attestationObject, false)
+		require.ErrorContains(t, err, "origin")
+	})
+
+	t.Run("wrong relying party", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, AlgorithmES256)
+		authenticator.rpID = "example.com"
+		clientDataJSON, attestationObject := authenticator.create(challenge)
+		_, err := rp.VerifyRegistration(challenge, clientDataJSON, attestationObject, false)
+		require.ErrorContains(t, err, "relying party")
+	})
+
+	t.Run("user verification required", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, AlgorithmES256)
+		authenticator.flags = flagUserPresent
+		clientDataJSON, attestationObject := authenticator.create(challenge)
+		_, err := rp.VerifyRegistration(challenge, clientDataJSON, attestationObject, true)
+		require.ErrorContains(t, err, "not verified")
+
+		credential, err := rp.VerifyRegistration(challenge, clientDataJSON, attestationObject, false)
+		require.NoError(t, err)
+		assert.False(t, credential.UserVerified)
+	})
+
+	t.Run("assertion instead of attestation", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, AlgorithmES256)
+		clientDataJSON, _, _ := authenticator.get(t, challenge)
+		_, attestationObject := authenticator.create(challenge)
+		_, err := rp.VerifyRegistration(challenge, clientDataJSON, attestationObject, false)
+		require.ErrorContains(t, err, "ceremony")
+	})
+
+	t.Run("malformed attestation object", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, AlgorithmES256)
+		clientDataJSON, attestationObject := authenticator.create(challenge)
+		_, err := rp.VerifyRegistration(challenge, clientDataJSON, attestationObject[:len(attestationObject)-10], false)
+		require.Error(t, err)
+	})
+}
+
+func TestVerifyAssertion(t *testing.T) {
+	rp := &RelyingParty{ID: "chat.example.com", Origin: "https://chat.example.com"}
+	challenge := []byte("a random challenge of enough bytes")
+
+	t.Run("invalid signature", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, AlgorithmES256)
+		other := newTestAuthenticator(t, AlgorithmES256)
+		clientDataJSON, authData, signature := authenticator.get(t, challenge)
+		_, err := rp.VerifyAssertion(challenge, other.publicKey, 0, clientDataJSON, authData, signature, false)
+		require.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("tampered authenticator data", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, AlgorithmEdDSA)
+		authenticator.flags = flagUserPresent
+		clientDataJSON, authData, signature := authenticator.get(t, challenge)
+		authData[32] |= flagUserVerified
+		_, err := rp.VerifyAssertion(challenge, authenticator.publicKey, 0, clientDataJSON, authData, signature, true)
+		require.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("signature counter regression", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, AlgorithmES256)
+		clientDataJSON, authData, signature := authenticator.get(t, challenge)
+		_, err := rp.VerifyAssertion(challenge, authenticator.publicKey, 1, clientDataJSON, authData, signature, false)
+		require.ErrorIs(t, err, ErrCredentialCloned)
+	})
+
+	t.Run("authenticator without counter", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, AlgorithmES256)
+		authenticator.noCounter = true
+		clientDataJSON, authData, signature := authenticator.get(t, challenge)
+		assertion, err := rp.VerifyAssertion(challenge, authenticator.publicKey, 0, clientDataJSON, authData, signature, false)
+		require.NoError(t, err)
+		assert.Zero(t, assertion.SignCount)
+	})
+
+	t.Run("user not present", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, AlgorithmES256)
+		authenticator.flags = 0
+		clientDataJSON, authData, signature := authenticator.get(t, challenge)
+		_, err := rp.VerifyAssertion(challenge, authenticator.publicKey, 0, clientDataJSON, authData, signature, false)
+		require.ErrorContains(t, err, "not present")
+	})
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	t.Run("values", func(t *testing.T) {
+		data := encodeCBOR([]any{0, 23, 24, 1000, 70000, -1, -300, "text", []byte{1, 2}, map[any]any{"a": 1}})
+		item, n, err := decodeCBOR(append(data, 0xff))
+		require.NoError(t, err)
+		assert.Equal(t, len(data), n)
+		assert.Equal(t, []any{int64(0), int64(23), int64(24), int64(1000), int64(70000), int64(-1), int64(-300), "text", []byte{1, 2}, map[any]any{"a": int64(1)}}, item)
+	})
+
+	t.Run("simple values", func(t *testing.T) {
+		item, _, err := decodeCBOR([]byte{0x83, 0xf4, 0xf5, 0xf6})
+		require.NoError(t, err)
+		assert.Equal(t, []any{false, true, nil}, item)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		for _, data := range [][]byte{{}, {0x19, 0x01}, {0x44, 0x01}, {0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}} {
+			_, _, err := decodeCBOR(data)
+			require.Error(t, err)
+		}
+	})
+
+	t.Run("too deep", func(t *testing.T) {
+		data := make([]byte, cborMaxDepth+2)
+		for i := range data {
+			data[i] = 0x81
+		}
+		_, _, err := decodeCBOR(data)
+		require.ErrorContains(t, err, "nested")
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		for _, data := range [][]byte{{0x5f}, {0xfa, 0, 0, 0, 0}, {0xa1, 0x80, 0x00}} {
+			_, _, err := decodeCBOR(data)
+			require.Error(t, err)
+		}
+	})
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package webpush sends Web Push messages (RFC 8030) to the push services of
+// browsers. Messages are encrypted for the subscription (RFC 8291), and the
+// server identifies itself with a VAPID key (RFC 8292).
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// MaxPayloadSize is the largest payload that fits in the 4096 bytes
+	// push services are required to accept, once encrypted.
+	MaxPayloadSize = maxRecordSize - headerSize - tagSize - 1
+
+	UrgencyVeryLow = "very-low"
+	UrgencyLow     = "low"
+	UrgencyNormal  = "normal"
+	UrgencyHigh    = "high"
+
+	maxRecordSize = 4096
+	headerSize    = saltSize + 4 + 1 + publicKeySize
+	saltSize      = 16
+	tagSize       = 16
+	authSize      = 16
+	publicKeySize = 65
+
+	// vapidExpiration is how long the VAPID tokens are valid. Push services
+	// reject tokens valid for more than 24 hours.
+	vapidExpiration = 12 * time.Hour
+)
+
+var (
+	// ErrSubscriptionGone is returned when the push service reports that
+	// the subscription expired or was removed by the user.
+	ErrSubscriptionGone = errors.New("webpush: the subscription is no longer valid")
+
+	// ErrPayloadTooLarge is returned for payloads larger than MaxPayloadSize.
+	ErrPayloadTooLarge = errors.New("webpush: the payload is too large")
+)
+
+// Subscription is the push subscription of a browser.
+type Subscription struct {
+	// Endpoint is the URL of the push service to send messages to.
+	Endpoint string
+	// P256dh is the public key of the browser, in uncompressed form.
+	P256dh []byte
+	// Auth is the authentication secret of the browser.
+	Auth []byte
+}
+
+// Options are the optional headers of a push message.
+type Options struct {
+	// TTL is how long the push service keeps the message while the browser
+	// is offline.
+	TTL time.Duration
+	// Urgency lets the browser save battery for less urgent messages.
+	Urgency string
+	// Topic replaces the pending messages with the same topic.
+	Topic string
+}
+
+// Sender sends push messages on behalf of the application server.
+type Sender struct {
+	Client *http.Client
+	// Key is the VAPID key of the server. Browsers only accept messages
+	// signed with the key the subscription was created for.
+	Key *ecdsa.PrivateKey
+	// Subject is a mailto: or https: URL the operators of push services can
+	// use to contact the server administrator.
+	Subject string
+}
+
+// Send encrypts the payload for the subscription and delivers it to its
+// push service.
+func (s *Sender) Send(ctx context.Context, subscription *Subscription, payload []byte, options Options) error {
+	body, err := Encrypt(payload, subscription.P256dh, subscription.Auth)
+	if err != nil {
+		return err
+	}
+
+	authorization, err := VAPIDAuthorization(subscription.Endpoint, s.Subject, s.Key, time.Now().Add(vapidExpiration))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(options.TTL.Seconds())))
+	if options.Urgency != "" {
+		req.Header.Set("Urgency", options.Urgency)
+	}
+	if options.Topic != "" {
+		req.Header.Set("Topic", options.Topic)
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrSubscriptionGone
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webpush: the push service returned %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	_, err = io.Copy(io.Discard, resp.Body)
+	return err
+}
+
+// Encrypt encrypts the payload for a subscription with the aes128gcm content
+// encoding, as a single record.
+func Encrypt(payload, p256dh, auth []byte) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return encrypt(payload, p256dh, auth, salt, key)
+}
+
+func encrypt(payload, p256dh, auth, salt []byte, key *ecdh.PrivateKey) ([]byte, error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, ErrPayloadTooLarge
+	}
+	if len(auth) != authSize {
+		return nil, errors.New("webpush: invalid authentication secret")
+	}
+
+	clientKey, err := ecdh.P256().NewPublicKey(p256dh)
+	if err != nil {
+		return nil, fmt.Errorf("webpush: invalid public key: %w", err)
+	}
+	secret, err := key.ECDH(clientKey)
+	if err != nil {
+		return nil, err
+	}
+	serverKey := key.PublicKey().Bytes()
+
+	// Combine the shared secret with the authentication secret of the
+	// browser, then derive the content encryption key and the nonce.
+	keyInfo := append(append([]byte("WebPush: info\x00"), p256dh...), serverKey...)
+	ikm, err := hkdfExpand(hkdf.Extract(sha256.New, secret, auth), keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+	cek, err := hkdfExpand(prk, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdfExpand(prk, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, headerSize)
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, maxRecordSize)
+	header = append(header, byte(len(serverKey)))
+	header = append(header, serverKey...)
+
+	// The padding delimiter of the last record.
+	plaintext := append(append([]byte{}, payload...), 0x02)
+
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+func hkdfExpand(prk, info []byte, length int) ([]byte, error) {
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, info), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VAPIDAuthorization returns the Authorization header identifying the server
+// to the push service of the endpoint.
+func VAPIDAuthorization(endpoint, subject string, key *ecdsa.PrivateKey, expiration time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", errors.New("webpush: invalid endpoint")
+	}
+
+	claims := jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": expiration.Unix(),
+	}
+	if subject != "" {
+		claims["sub"] = subject
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(key)
+	if err != nil {
+		return "", fmt.Errorf("webpush: failed to sign the VAPID token: %w", err)
+	}
+
+	publicKey, err := EncodePublicKey(&key.PublicKey)
+	if err != nil {
+		return "", err
+	}
+
+	return "vapid t=" + token + ", k=" + publicKey, nil
+}
+
+// EncodePublicKey encodes a VAPID public key the way browsers expect it as
+// the applicationServerKey of subscriptions.
+func EncodePublicKey(key *ecdsa.PublicKey) (string, error) {
+	ecdhKey, err := key.ECDH()
+	if err != nil {
+		return "", fmt.Errorf("webpush: invalid VAPID key: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(ecdhKey.Bytes()), nil
+}
+
+// DecodeKey decodes the base64url encoded keys of subscriptions, with or
+// without padding.
+func DecodeKey(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webpush
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustDecode(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// TestEncrypt checks the example of RFC 8291, appendix A.
+func TestEncrypt(t *testing.T) {
+	serverKey, err := ecdh.P256().NewPrivateKey(mustDecode(t, "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"))
+	require.NoError(t, err)
+
+	body, err := encrypt(
+		[]byte("When I grow up, I want to be a watermelon"),
+		mustDecode(t, "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"),
+		mustDecode(t, "BTBZMqHH6r4Tts7J_aSIgg"),
+		mustDecode(t, "DGv6ra1nlYgDCS1FRnbzlw"),
+		serverKey,
+	)
+	require.NoError(t, err)
+
+	expected := "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+	assert.Equal(t, expected, base64.RawURLEncoding.EncodeToString(body))
+
+	t.Run("invalid keys", func(t *testing.T) {
+		_, err := Encrypt([]byte("hello"), []byte("not a key"), make([]byte, authSize))
+		assert.Error(t, err)
+
+		_, err = Encrypt([]byte("hello"), serverKey.PublicKey().Bytes(), make([]byte, 4))
+		assert.Error(t, err)
+	})
+
+	t.Run("payload too large", func(t *testing.T) {
+		_, err := Encrypt(make([]byte, MaxPayloadSize+1), serverKey.PublicKey().Bytes(), make([]byte, authSize))
+		assert.ErrorIs(t, err, ErrPayloadTooLarge)
+
+		body, err := Encrypt(make([]byte, MaxPayloadSize), serverKey.PublicKey().Bytes(), make([]byte, authSize))
+		require.NoError(t, err)
+		assert.Len(t, body, maxRecordSize)
+	})
+}
+
+func TestVAPIDAuthorization(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	expiration := time.Now().Add(time.Hour).Truncate(time.Second)
+	authorization, err := VAPIDAuthorization("https://push.example.com/send/abc?x=1", "mailto:admin@example.com", key, expiration)
+	require.NoError(t, err)
+
+	token, publicKey, found := strings.Cut(strings.TrimPrefix(authorization, "vapid t="), ", k=")
+	require.True(t, found, authorization)
+
+	encodedKey, err := EncodePublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	assert.Equal(t, encodedKey, publicKey)
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+		return &key.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"ES256"}))
+	require.NoError(t, err)
+	assert.Equal(t, "https://push.example.com", claims["aud"])
+	assert.Equal(t, "mailto:admin@example.com", claims["sub"])
+	assert.Equal(t, float64(expiration.Unix()), claims["exp"])
+
+	_, err = VAPIDAuthorization("not a url", "", key, expiration)
+	assert.Error(t, err)
+}
+
+func TestSend(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	browserKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	status := http.StatusCreated
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sender := &Sender{Client: server.Client(), Key: key, Subject: "mailto:admin@example.com"}
+	subscription := &Subscription{
+		Endpoint: server.URL + "/push/abc",
+		P256dh:   browserKey.PublicKey().Bytes(),
+		Auth:     make([]byte, authSize),
+	}
+
+	err = sender.Send(context.Background(), subscription, []byte(`{"message":"hello"}`), Options{TTL: time.Hour, Urgency: UrgencyHigh, Topic: "channel"})
+	require.NoError(t, err)
+	assert.Equal(t, "/push/abc", received.URL.Path)
+	assert.Equal(t, "aes128gcm", received.Header.Get("Content-Encoding"))
+	assert.Equal(t, "3600", received.Header.Get("TTL"))
+	assert.Equal(t, "high", received.Header.Get("Urgency"))
+	assert.Equal(t, "channel", received.Header.Get("Topic"))
+	assert.True(t, strings.HasPrefix(received.Header.Get("Authorization"), "vapid t="))
+	assert.Len(t, body, headerSize+len(`{"message":"hello"}`)+1+tagSize)
+
+	status = http.StatusGone
+	err = sender.Send(context.Background(), subscription, []byte("hello"), Options{})
+	assert.ErrorIs(t, err, ErrSubscriptionGone)
+
+	status = http.StatusBadRequest
+	err = sender.Send(context.Background(), subscription, []byte("hello"), Options{})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrSubscriptionGone)
+}
+
+func TestDecodeKey(t *testing.T) {
+	for _, encoded := range []string{"BTBZMqHH6r4Tts7J_aSIgg", "BTBZMqHH6r4Tts7J_aSIgg=="} {
+		key, err := DecodeKey(encoded)
+		require.NoError(t, err)
+		assert.Len(t, key, authSize)
+	}
+
+	_, err := DecodeKey("not/base64url")
+	assert.Error(t, err)
+}
@@ -7,10 +7,26 @@ package model
 // included with the export (e.g. file attachments).
 const ExportDataDir = "data"
 
+// BulkExportManifestName is the name of the file describing an export
+// within its archive.
+const BulkExportManifestName = "export_manifest.json"
+
 type BulkExportOpts struct {
 	IncludeAttachments      bool
 	IncludeProfilePictures  bool
 	IncludeArchivedChannels bool
 	IncludeRolesAndSchemes  bool
 	CreateArchive           bool
+	// Since, when set, limits the export to the entities created or
+	// updated at or after the given time, in milliseconds.
+	Since int64
+}
+
+// BulkExportManifest describes an export archive. The ExportedAt value of a
+// previous export can be used as the Since option of the next one to only
+// export what changed in between.
+type BulkExportManifest struct {
+	JobId      string `json:"job_id,omitempty"`
+	Since      int64  `json:"since"`
+	ExportedAt int64  `json:"exported_at"`
 }
@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/sha256"
+	"path/filepath"
+)
+
+const (
+	// ImportIdModePreserve imports posts and files with the IDs they had on
+	// the server they were exported from.
+	ImportIdModePreserve = "preserve"
+	// ImportIdModeRemap imports posts and files with IDs derived from the
+	// ones they had on the server they were exported from, so that importing
+	// the same archive again yields the same IDs.
+	ImportIdModeRemap = "remap"
+
+	ImportIdMappingObjectTypePost = "post"
+	ImportIdMappingObjectTypeFile = "file"
+
+	// ImportReportsDir is the directory of the file store the validation
+	// reports of dry-run imports are written to.
+	ImportReportsDir = "import_reports"
+	// ImportValidationReportMaxIssues is the maximum number of issues a
+	// validation report lists. Further issues are only counted.
+	ImportValidationReportMaxIssues = 10000
+)
+
+type BulkImportOpts struct {
+	DryRun         bool
+	ExtractContent bool
+	Workers        int
+	// ImportPath is the path the attachments of the import are relative to.
+	ImportPath string
+	// IdMode is one of the ImportIdMode values. When empty, imported posts
+	// and files are given new IDs.
+	IdMode string
+}
+
+// ImportIdMapping records the ID an object was imported with, given the ID
+// it had on the server it was exported from.
+type ImportIdMapping struct {
+	ObjectType string `json:"object_type"`
+	OldId      string `json:"old_id"`
+	NewId      string `json:"new_id"`
+	CreateAt   int64  `json:"create_at"`
+}
+
+func IsValidImportIdMode(mode string) bool {
+	return mode == "" || mode == ImportIdModePreserve || mode == ImportIdModeRemap
+}
+
+// RemapImportId returns the ID to import an object with, in the
+// ImportIdModeRemap mode, given the ID it was exported with.
+func RemapImportId(id string) string {
+	sum := sha256.Sum256([]byte("import:" + id))
+	return encoding.EncodeToString(sum[:16])
+}
+
+// ImportValidationReport lists the problems a dry-run of a bulk import found,
+// grouped by the type of the line they were found on.
+type ImportValidationReport struct {
+	JobId      string                              `json:"job_id,omitempty"`
+	ImportFile string                              `json:"import_file,omitempty"`
+	CreateAt   int64                               `json:"create_at"`
+	TotalLines int                                 `json:"total_lines"`
+	LineCounts map[string]int                      `json:"line_counts"`
+	IssueCount int                                 `json:"issue_count"`
+	Truncated  bool                                `json:"truncated"`
+	Issues     map[string][]*ImportValidationIssue `json:"issues"`
+}
+
+// ImportValidationIssue is a problem found on a line of a bulk import.
+type ImportValidationIssue struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	ErrorId string `json:"error_id"`
+	Message string `json:"message"`
+}
+
+func NewImportValidationReport() *ImportValidationReport {
+	return &ImportValidationReport{
+		CreateAt:   GetMillis(),
+		LineCounts: map[string]int{},
+		Issues:     map[string][]*ImportValidationIssue{},
+	}
+}
+
+// AddIssue records an issue found on a line of the given type. Once the
+// report lists ImportValidationReportMaxIssues issues, further ones are only
+// counted.
+func (r *ImportValidationReport) AddIssue(lineType string, issue *ImportValidationIssue) {
+	r.IssueCount++
+	if r.IssueCount > ImportValidationReportMaxIssues {
+		r.Truncated = true
+		return
+	}
+
+	r.Issues[lineType] = append(r.Issues[lineType], issue)
+}
+
+// ImportValidationReportPath returns the path of the file store the
+// validation report of the given import job is written to.
+func ImportValidationReportPath(jobID string) string {
+	return filepath.Join(ImportReportsDir, jobID+".json")
+}
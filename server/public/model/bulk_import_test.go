@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemapImportId(t *testing.T) {
+	id := NewId()
+
+	remapped := RemapImportId(id)
+	assert.True(t, IsValidId(remapped))
+	assert.NotEqual(t, id, remapped)
+	assert.Equal(t, remapped, RemapImportId(id))
+	assert.NotEqual(t, remapped, RemapImportId(NewId()))
+}
+
+func TestIsValidImportIdMode(t *testing.T) {
+	assert.True(t, IsValidImportIdMode(""))
+	assert.True(t, IsValidImportIdMode(ImportIdModePreserve))
+	assert.True(t, IsValidImportIdMode(ImportIdModeRemap))
+	assert.False(t, IsValidImportIdMode("keep"))
+}
+
+func TestImportValidationReportAddIssue(t *testing.T) {
+	report := NewImportValidationReport()
+
+	report.AddIssue("post", &ImportValidationIssue{Line: 2, ErrorId: "post_error"})
+	report.AddIssue("user", &ImportValidationIssue{Line: 3, ErrorId: "user_error"})
+	report.AddIssue("post", &ImportValidationIssue{Line: 4, ErrorId: "post_error"})
+
+	assert.Equal(t, 3, report.IssueCount)
+	assert.False(t, report.Truncated)
+	assert.Len(t, report.Issues["post"], 2)
+	assert.Len(t, report.Issues["user"], 1)
+
+	for i := report.IssueCount; i < ImportValidationReportMaxIssues+5; i++ {
+		report.AddIssue("post", &ImportValidationIssue{Line: 5 + i, ErrorId: "post_error"})
+	}
+
+	assert.Equal(t, ImportValidationReportMaxIssues+5, report.IssueCount)
+	assert.True(t, report.Truncated)
+	assert.Len(t, report.Issues["post"], ImportValidationReportMaxIssues-1)
+}
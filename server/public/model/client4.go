@@ -468,6 +468,38 @@ func (c *Client4) reactionsRoute() string {
 	return "/reactions"
 }
 
+func (c *Client4) pollsRoute() string {
+	return "/polls"
+}
+
+func (c *Client4) pollRoute(pollId string) string {
+	return fmt.Sprintf(c.pollsRoute()+"/%v", pollId)
+}
+
+func (c *Client4) remindersRoute() string {
+	return "/reminders"
+}
+
+func (c *Client4) reminderRoute(reminderId string) string {
+	return fmt.Sprintf(c.remindersRoute()+"/%v", reminderId)
+}
+
+func (c *Client4) savedSearchesRoute() string {
+	return "/saved_searches"
+}
+
+func (c *Client4) savedSearchRoute(savedSearchId string) string {
+	return fmt.Sprintf(c.savedSearchesRoute()+"/%v", savedSearchId)
+}
+
+func (c *Client4) legalHoldsRoute() string {
+	return "/legal_holds"
+}
+
+func (c *Client4) legalHoldRoute(legalHoldId string) string {
+	return fmt.Sprintf(c.legalHoldsRoute()+"/%v", legalHoldId)
+}
+
 func (c *Client4) oAuthAppsRoute() string {
 	return "/oauth/apps"
 }
@@ -856,6 +888,44 @@ func (c *Client4) login(ctx context.Context, m map[string]string) (*User, *Respo
 	return &user, BuildResponse(r), nil
 }
 
+// GetWebAuthnLoginOptions returns the options to pass to
+// navigator.credentials.get to log in with a WebAuthn credential. With a
+// login ID, the resulting assertion, JSON encoded, is the MFA token of
+// LoginWithMFA. Without one, it is passed to LoginWithWebAuthn.
+func (c *Client4) GetWebAuthnLoginOptions(ctx context.Context, loginId string) (*WebAuthnRequestOptions, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.usersRoute()+"/login/webauthn/options", MapToJSON(map[string]string{"login_id": loginId}))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var options WebAuthnRequestOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		return nil, nil, NewAppError("GetWebAuthnLoginOptions", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &options, BuildResponse(r), nil
+}
+
+// LoginWithWebAuthn logs an email user in with a passwordless credential.
+func (c *Client4) LoginWithWebAuthn(ctx context.Context, assertion *WebAuthnAssertion, deviceId string) (*User, *Response, error) {
+	buf, err := json.Marshal(WebAuthnLogin{Credential: assertion, DeviceId: deviceId})
+	if err != nil {
+		return nil, nil, NewAppError("LoginWithWebAuthn", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, c.usersRoute()+"/login/webauthn", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	c.AuthToken = r.Header.Get(HeaderToken)
+	c.AuthType = HeaderBearer
+
+	var user User
+	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+		return nil, nil, NewAppError("LoginWithWebAuthn", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &user, BuildResponse(r), nil
+}
+
 func (c *Client4) LoginWithDesktopToken(ctx context.Context, token, deviceId string) (*User, *Response, error) {
 	m := make(map[string]string)
 	m["token"] = token
@@ -1526,6 +1596,100 @@ func (c *Client4) GenerateMfaSecret(ctx context.Context, userId string) (*MfaSec
 	return &secret, BuildResponse(r), nil
 }
 
+// GenerateMfaRecoveryCodes replaces the recovery codes of a user with new
+// ones. The codes are only returned once. Must be logged in as the user.
+func (c *Client4) GenerateMfaRecoveryCodes(ctx context.Context, userId string) (*MfaRecoveryCodes, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.userRoute(userId)+"/mfa/recovery_codes", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var codes MfaRecoveryCodes
+	if err := json.NewDecoder(r.Body).Decode(&codes); err != nil {
+		return nil, nil, NewAppError("GenerateMfaRecoveryCodes", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &codes, BuildResponse(r), nil
+}
+
+// GetMfaRecoveryCodesStatus returns the number of unused recovery codes of a
+// user.
+func (c *Client4) GetMfaRecoveryCodesStatus(ctx context.Context, userId string) (*MfaRecoveryCodesStatus, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/mfa/recovery_codes", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var status MfaRecoveryCodesStatus
+	if err := json.NewDecoder(r.Body).Decode(&status); err != nil {
+		return nil, nil, NewAppError("GetMfaRecoveryCodesStatus", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &status, BuildResponse(r), nil
+}
+
+// BeginWebAuthnRegistration returns the options to pass to
+// navigator.credentials.create to register a WebAuthn credential. Must be
+// logged in as the user.
+func (c *Client4) BeginWebAuthnRegistration(ctx context.Context, userId string, passwordless bool) (*WebAuthnCreationOptions, *Response, error) {
+	buf, err := json.Marshal(WebAuthnRegistrationRequest{Passwordless: passwordless})
+	if err != nil {
+		return nil, nil, NewAppError("BeginWebAuthnRegistration", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, c.userRoute(userId)+"/webauthn/registration/options", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var options WebAuthnCreationOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		return nil, nil, NewAppError("BeginWebAuthnRegistration", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &options, BuildResponse(r), nil
+}
+
+// FinishWebAuthnRegistration saves the credential created by the
+// authenticator.
+func (c *Client4) FinishWebAuthnRegistration(ctx context.Context, userId string, registration *WebAuthnRegistration) (*WebAuthnCredential, *Response, error) {
+	buf, err := json.Marshal(registration)
+	if err != nil {
+		return nil, nil, NewAppError("FinishWebAuthnRegistration", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, c.userRoute(userId)+"/webauthn/credentials", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var credential WebAuthnCredential
+	if err := json.NewDecoder(r.Body).Decode(&credential); err != nil {
+		return nil, nil, NewAppError("FinishWebAuthnRegistration", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &credential, BuildResponse(r), nil
+}
+
+// GetWebAuthnCredentials returns the WebAuthn credentials of a user.
+func (c *Client4) GetWebAuthnCredentials(ctx context.Context, userId string) ([]*WebAuthnCredential, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/webauthn/credentials", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var credentials []*WebAuthnCredential
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+		return nil, nil, NewAppError("GetWebAuthnCredentials", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return credentials, BuildResponse(r), nil
+}
+
+// DeleteWebAuthnCredential deletes a WebAuthn credential of a user. Deleting
+// the last second factor of the user deactivates MFA.
+func (c *Client4) DeleteWebAuthnCredential(ctx context.Context, userId, credentialId string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.userRoute(userId)+"/webauthn/credentials/"+credentialId)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // UpdateUserPassword updates a user's password. Must be logged in as the user or be a system administrator.
 func (c *Client4) UpdateUserPassword(ctx context.Context, userId, currentPassword, newPassword string) (*Response, error) {
 	requestBody := map[string]string{"current_password": currentPassword, "new_password": newPassword}
@@ -1737,6 +1901,51 @@ func (c *Client4) AttachDeviceProps(ctx context.Context, newProps map[string]str
 	return BuildResponse(r), nil
 }
 
+// GetWebPushPublicKey returns the VAPID public key browsers must subscribe to push
+// notifications with.
+func (c *Client4) GetWebPushPublicKey(ctx context.Context) (*WebPushPublicKey, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.usersRoute()+"/sessions/web_push/public_key", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var publicKey WebPushPublicKey
+	if err := json.NewDecoder(r.Body).Decode(&publicKey); err != nil {
+		return nil, nil, NewAppError("GetWebPushPublicKey", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &publicKey, BuildResponse(r), nil
+}
+
+// AttachWebPushSubscription sets the Web Push subscription of the browser of the
+// current session.
+func (c *Client4) AttachWebPushSubscription(ctx context.Context, subscription *WebPushSubscriptionRequest) (*WebPushSubscription, *Response, error) {
+	buf, err := json.Marshal(subscription)
+	if err != nil {
+		return nil, nil, NewAppError("AttachWebPushSubscription", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPutBytes(ctx, c.usersRoute()+"/sessions/web_push", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var created WebPushSubscription
+	if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+		return nil, nil, NewAppError("AttachWebPushSubscription", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &created, BuildResponse(r), nil
+}
+
+// DetachWebPushSubscription stops sending push notifications to the browser of the
+// current session.
+func (c *Client4) DetachWebPushSubscription(ctx context.Context) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.usersRoute()+"/sessions/web_push")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // GetTeamsUnreadForUser will return an array with TeamUnread objects that contain the amount
 // of unread messages and mentions the current user has for the teams it belongs to.
 // An optional team ID can be set to exclude that team from the results.
@@ -4162,6 +4371,35 @@ func (c *Client4) GetEditHistoryForPost(ctx context.Context, postId string) ([]*
 	return list, BuildResponse(r), nil
 }
 
+// GetPostEditHistory returns the previous versions of a post along with the
+// changes each edit made.
+func (c *Client4) GetPostEditHistory(ctx context.Context, postId string) (*PostEditHistory, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.postRoute(postId)+"/edit_history/revisions", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var history PostEditHistory
+	if err := json.NewDecoder(r.Body).Decode(&history); err != nil {
+		return nil, nil, NewAppError("GetPostEditHistory", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &history, BuildResponse(r), nil
+}
+
+// GetPostReadReceipts returns the members of the channel who have read the post.
+func (c *Client4) GetPostReadReceipts(ctx context.Context, postId string) (*PostReadReceipts, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.postRoute(postId)+"/read_receipts", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var receipts PostReadReceipts
+	if err := json.NewDecoder(r.Body).Decode(&receipts); err != nil {
+		return nil, nil, NewAppError("GetPostReadReceipts", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &receipts, BuildResponse(r), nil
+}
+
 // GetFlaggedPostsForUser returns flagged posts of a user based on user id string.
 func (c *Client4) GetFlaggedPostsForUser(ctx context.Context, userId string, page int, perPage int) (*PostList, *Response, error) {
 	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
@@ -4889,6 +5127,58 @@ func (c *Client4) MigrateConfig(ctx context.Context, from, to string) (*Response
 	return BuildResponse(r), nil
 }
 
+// GetConfigHistory returns a page of the versions of the configuration kept in
+// the database, newest first.
+func (c *Client4) GetConfigHistory(ctx context.Context, page, perPage int) ([]*ConfigVersion, *Response, error) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoAPIGet(ctx, c.configRoute()+"/history"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var versions []*ConfigVersion
+	if err := json.NewDecoder(r.Body).Decode(&versions); err != nil {
+		return nil, nil, NewAppError("GetConfigHistory", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return versions, BuildResponse(r), nil
+}
+
+// GetConfigVersionDiff returns the settings changed from the compareTo version of
+// the configuration to the given one, or from the version that preceded it when
+// compareTo is empty.
+func (c *Client4) GetConfigVersionDiff(ctx context.Context, versionId, compareTo string) (*ConfigVersionDiff, *Response, error) {
+	values := url.Values{}
+	if compareTo != "" {
+		values.Set("compare_to", compareTo)
+	}
+	r, err := c.DoAPIGet(ctx, c.configRoute()+"/history/"+versionId+"/diff?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var diff ConfigVersionDiff
+	if err := json.NewDecoder(r.Body).Decode(&diff); err != nil {
+		return nil, nil, NewAppError("GetConfigVersionDiff", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &diff, BuildResponse(r), nil
+}
+
+// RollbackConfig makes a version of the configuration kept in the database the
+// active one again.
+func (c *Client4) RollbackConfig(ctx context.Context, versionId string) (*Config, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.configRoute()+"/history/"+versionId+"/rollback", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var cfg *Config
+	d := json.NewDecoder(r.Body)
+	return cfg, BuildResponse(r), d.Decode(&cfg)
+}
+
 // UploadLicenseFile will add a license file to the system.
 func (c *Client4) UploadLicenseFile(ctx context.Context, data []byte) (*Response, error) {
 	body := &bytes.Buffer{}
@@ -5200,6 +5490,35 @@ func (c *Client4) RegenOutgoingHookToken(ctx context.Context, hookId string) (*O
 	return &ow, BuildResponse(r), nil
 }
 
+// RotateOutgoingWebhookSigningSecret generates a new signing secret for the outgoing webhook.
+// The previous secret keeps being used to sign requests during the configured grace period.
+func (c *Client4) RotateOutgoingWebhookSigningSecret(ctx context.Context, hookId string) (*OutgoingWebhook, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.outgoingWebhookRoute(hookId)+"/signing_secret/rotate", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var ow OutgoingWebhook
+	if err := json.NewDecoder(r.Body).Decode(&ow); err != nil {
+		return nil, nil, NewAppError("RotateOutgoingWebhookSigningSecret", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &ow, BuildResponse(r), nil
+}
+
+// RemoveOutgoingWebhookSigningSecret stops the signing of the requests of the outgoing webhook.
+func (c *Client4) RemoveOutgoingWebhookSigningSecret(ctx context.Context, hookId string) (*OutgoingWebhook, *Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.outgoingWebhookRoute(hookId)+"/signing_secret")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var ow OutgoingWebhook
+	if err := json.NewDecoder(r.Body).Decode(&ow); err != nil {
+		return nil, nil, NewAppError("RemoveOutgoingWebhookSigningSecret", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &ow, BuildResponse(r), nil
+}
+
 // DeleteOutgoingWebhook delete the outgoing webhook on the system requested by Hook Id.
 func (c *Client4) DeleteOutgoingWebhook(ctx context.Context, hookId string) (*Response, error) {
 	r, err := c.DoAPIDelete(ctx, c.outgoingWebhookRoute(hookId))
@@ -5210,6 +5529,42 @@ func (c *Client4) DeleteOutgoingWebhook(ctx context.Context, hookId string) (*Re
 	return BuildResponse(r), nil
 }
 
+// GetOutgoingWebhookDeliveries returns a page of the delivery history of an
+// outgoing webhook, most recent first. An empty status returns every delivery.
+func (c *Client4) GetOutgoingWebhookDeliveries(ctx context.Context, hookId string, status string, page int, perPage int) ([]*OutgoingWebhookDelivery, *Response, error) {
+	values := url.Values{}
+	values.Set("page", strconv.Itoa(page))
+	values.Set("per_page", strconv.Itoa(perPage))
+	if status != "" {
+		values.Set("status", status)
+	}
+	r, err := c.DoAPIGet(ctx, c.outgoingWebhookRoute(hookId)+"/deliveries?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var deliveries []*OutgoingWebhookDelivery
+	if err := json.NewDecoder(r.Body).Decode(&deliveries); err != nil {
+		return nil, nil, NewAppError("GetOutgoingWebhookDeliveries", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return deliveries, BuildResponse(r), nil
+}
+
+// ReplayOutgoingWebhookDelivery attempts a failed delivery of an outgoing
+// webhook again and returns the updated delivery.
+func (c *Client4) ReplayOutgoingWebhookDelivery(ctx context.Context, hookId, deliveryId string) (*OutgoingWebhookDelivery, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.outgoingWebhookRoute(hookId)+"/deliveries/"+deliveryId+"/replay", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var delivery OutgoingWebhookDelivery
+	if err := json.NewDecoder(r.Body).Decode(&delivery); err != nil {
+		return nil, nil, NewAppError("ReplayOutgoingWebhookDelivery", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &delivery, BuildResponse(r), nil
+}
+
 // Preferences Section
 
 // GetPreferences returns the user's preferences.
@@ -6572,6 +6927,411 @@ func (c *Client4) DeleteDraft(ctx context.Context, userId, channelId, rootId str
 	return df, BuildResponse(r), nil
 }
 
+// CreateScheduledPost schedules a post to be published at ScheduledAt.
+func (c *Client4) CreateScheduledPost(ctx context.Context, scheduledPost *ScheduledPost) (*ScheduledPost, *Response, error) {
+	buf, err := json.Marshal(scheduledPost)
+	if err != nil {
+		return nil, nil, NewAppError("CreateScheduledPost", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPostBytes(ctx, c.postsRoute()+"/schedule", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var sp ScheduledPost
+	if err := json.NewDecoder(r.Body).Decode(&sp); err != nil {
+		return nil, nil, NewAppError("CreateScheduledPost", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &sp, BuildResponse(r), nil
+}
+
+// GetUserScheduledPosts returns the scheduled posts of the current user in the given team.
+func (c *Client4) GetUserScheduledPosts(ctx context.Context, teamId string) ([]*ScheduledPost, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.postsRoute()+"/scheduled/team/"+teamId, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var scheduledPosts []*ScheduledPost
+	if err := json.NewDecoder(r.Body).Decode(&scheduledPosts); err != nil {
+		return nil, nil, NewAppError("GetUserScheduledPosts", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return scheduledPosts, BuildResponse(r), nil
+}
+
+// UpdateScheduledPost updates the content and delivery time of a scheduled post.
+func (c *Client4) UpdateScheduledPost(ctx context.Context, scheduledPost *ScheduledPost) (*ScheduledPost, *Response, error) {
+	buf, err := json.Marshal(scheduledPost)
+	if err != nil {
+		return nil, nil, NewAppError("UpdateScheduledPost", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPutBytes(ctx, c.postsRoute()+"/schedule/"+scheduledPost.Id, buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var sp ScheduledPost
+	if err := json.NewDecoder(r.Body).Decode(&sp); err != nil {
+		return nil, nil, NewAppError("UpdateScheduledPost", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &sp, BuildResponse(r), nil
+}
+
+// DeleteScheduledPost deletes a scheduled post.
+func (c *Client4) DeleteScheduledPost(ctx context.Context, scheduledPostId string) (*ScheduledPost, *Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.postsRoute()+"/schedule/"+scheduledPostId)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var sp ScheduledPost
+	if err := json.NewDecoder(r.Body).Decode(&sp); err != nil {
+		return nil, nil, NewAppError("DeleteScheduledPost", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &sp, BuildResponse(r), nil
+}
+
+// Polls Section
+
+// CreatePoll creates a poll along with the post that holds it.
+func (c *Client4) CreatePoll(ctx context.Context, pollRequest *CreatePollRequest) (*Poll, *Response, error) {
+	buf, err := json.Marshal(pollRequest)
+	if err != nil {
+		return nil, nil, NewAppError("CreatePoll", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPostBytes(ctx, c.pollsRoute(), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var poll Poll
+	if err := json.NewDecoder(r.Body).Decode(&poll); err != nil {
+		return nil, nil, NewAppError("CreatePoll", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &poll, BuildResponse(r), nil
+}
+
+// GetPoll returns a poll with its results and the votes of the current user.
+func (c *Client4) GetPoll(ctx context.Context, pollId string) (*Poll, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.pollRoute(pollId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var poll Poll
+	if err := json.NewDecoder(r.Body).Decode(&poll); err != nil {
+		return nil, nil, NewAppError("GetPoll", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &poll, BuildResponse(r), nil
+}
+
+// VotePoll replaces the votes of the current user on a poll. Voting for no
+// option retracts the votes.
+func (c *Client4) VotePoll(ctx context.Context, pollId string, optionIds []string) (*Poll, *Response, error) {
+	buf, err := json.Marshal(&PollVoteRequest{OptionIds: optionIds})
+	if err != nil {
+		return nil, nil, NewAppError("VotePoll", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPostBytes(ctx, c.pollRoute(pollId)+"/votes", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var poll Poll
+	if err := json.NewDecoder(r.Body).Decode(&poll); err != nil {
+		return nil, nil, NewAppError("VotePoll", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &poll, BuildResponse(r), nil
+}
+
+// ClosePoll stops a poll from accepting votes.
+func (c *Client4) ClosePoll(ctx context.Context, pollId string) (*Poll, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.pollRoute(pollId)+"/close", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var poll Poll
+	if err := json.NewDecoder(r.Body).Decode(&poll); err != nil {
+		return nil, nil, NewAppError("ClosePoll", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &poll, BuildResponse(r), nil
+}
+
+// Reminders Section
+
+// GetUserReminders returns the reminders created by a user.
+func (c *Client4) GetUserReminders(ctx context.Context, userId string) ([]*Reminder, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/reminders", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var reminders []*Reminder
+	if err := json.NewDecoder(r.Body).Decode(&reminders); err != nil {
+		return nil, nil, NewAppError("GetUserReminders", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return reminders, BuildResponse(r), nil
+}
+
+// DeleteReminder deletes a reminder.
+func (c *Client4) DeleteReminder(ctx context.Context, reminderId string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.reminderRoute(reminderId))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// SnoozeReminder moves the next delivery of a reminder to snoozeUntil, in
+// milliseconds since the epoch.
+func (c *Client4) SnoozeReminder(ctx context.Context, reminderId string, snoozeUntil int64) (*Reminder, *Response, error) {
+	buf, err := json.Marshal(&SnoozeReminderRequest{SnoozeUntil: snoozeUntil})
+	if err != nil {
+		return nil, nil, NewAppError("SnoozeReminder", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPostBytes(ctx, c.reminderRoute(reminderId)+"/snooze", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var reminder Reminder
+	if err := json.NewDecoder(r.Body).Decode(&reminder); err != nil {
+		return nil, nil, NewAppError("SnoozeReminder", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &reminder, BuildResponse(r), nil
+}
+
+// Saved Searches Section
+
+// CreateSavedSearch saves a search of the current user.
+func (c *Client4) CreateSavedSearch(ctx context.Context, savedSearch *SavedSearch) (*SavedSearch, *Response, error) {
+	buf, err := json.Marshal(savedSearch)
+	if err != nil {
+		return nil, nil, NewAppError("CreateSavedSearch", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPostBytes(ctx, c.savedSearchesRoute(), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var saved SavedSearch
+	if err := json.NewDecoder(r.Body).Decode(&saved); err != nil {
+		return nil, nil, NewAppError("CreateSavedSearch", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &saved, BuildResponse(r), nil
+}
+
+// GetUserSavedSearches returns the saved searches of a user.
+func (c *Client4) GetUserSavedSearches(ctx context.Context, userId string) ([]*SavedSearch, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/saved_searches", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var savedSearches []*SavedSearch
+	if err := json.NewDecoder(r.Body).Decode(&savedSearches); err != nil {
+		return nil, nil, NewAppError("GetUserSavedSearches", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return savedSearches, BuildResponse(r), nil
+}
+
+// GetSavedSearch returns a saved search.
+func (c *Client4) GetSavedSearch(ctx context.Context, savedSearchId string) (*SavedSearch, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.savedSearchRoute(savedSearchId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var savedSearch SavedSearch
+	if err := json.NewDecoder(r.Body).Decode(&savedSearch); err != nil {
+		return nil, nil, NewAppError("GetSavedSearch", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &savedSearch, BuildResponse(r), nil
+}
+
+// PatchSavedSearch changes a saved search of the current user.
+func (c *Client4) PatchSavedSearch(ctx context.Context, savedSearchId string, patch *SavedSearchPatch) (*SavedSearch, *Response, error) {
+	buf, err := json.Marshal(patch)
+	if err != nil {
+		return nil, nil, NewAppError("PatchSavedSearch", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPutBytes(ctx, c.savedSearchRoute(savedSearchId)+"/patch", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var savedSearch SavedSearch
+	if err := json.NewDecoder(r.Body).Decode(&savedSearch); err != nil {
+		return nil, nil, NewAppError("PatchSavedSearch", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &savedSearch, BuildResponse(r), nil
+}
+
+// DeleteSavedSearch deletes a saved search of the current user.
+func (c *Client4) DeleteSavedSearch(ctx context.Context, savedSearchId string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.savedSearchRoute(savedSearchId))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// RunSavedSearch returns a page of the posts matching a saved search of the
+// current user.
+func (c *Client4) RunSavedSearch(ctx context.Context, savedSearchId string, page, perPage int) (*PostList, *Response, error) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoAPIPost(ctx, c.savedSearchRoute(savedSearchId)+"/search"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var list PostList
+	if r.StatusCode == http.StatusNotModified {
+		return &list, BuildResponse(r), nil
+	}
+	if err := json.NewDecoder(r.Body).Decode(&list); err != nil {
+		return nil, nil, NewAppError("RunSavedSearch", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &list, BuildResponse(r), nil
+}
+
+// Legal Holds Section
+
+// CreateLegalHold creates a legal hold preserving the content of its users
+// and channels from data retention and permanent deletion.
+func (c *Client4) CreateLegalHold(ctx context.Context, hold *LegalHold) (*LegalHold, *Response, error) {
+	buf, err := json.Marshal(hold)
+	if err != nil {
+		return nil, nil, NewAppError("CreateLegalHold", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPostBytes(ctx, c.legalHoldsRoute(), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var savedHold LegalHold
+	if err := json.NewDecoder(r.Body).Decode(&savedHold); err != nil {
+		return nil, nil, NewAppError("CreateLegalHold", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &savedHold, BuildResponse(r), nil
+}
+
+// GetLegalHolds returns a page of legal holds. Released holds are only
+// returned if includeReleased is set.
+func (c *Client4) GetLegalHolds(ctx context.Context, page, perPage int, includeReleased bool) ([]*LegalHold, *Response, error) {
+	query := fmt.Sprintf("?page=%d&per_page=%d&include_released=%t", page, perPage, includeReleased)
+	r, err := c.DoAPIGet(ctx, c.legalHoldsRoute()+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var holds []*LegalHold
+	if err := json.NewDecoder(r.Body).Decode(&holds); err != nil {
+		return nil, nil, NewAppError("GetLegalHolds", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return holds, BuildResponse(r), nil
+}
+
+// GetLegalHold returns a legal hold.
+func (c *Client4) GetLegalHold(ctx context.Context, legalHoldId string) (*LegalHold, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.legalHoldRoute(legalHoldId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var hold LegalHold
+	if err := json.NewDecoder(r.Body).Decode(&hold); err != nil {
+		return nil, nil, NewAppError("GetLegalHold", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &hold, BuildResponse(r), nil
+}
+
+// PatchLegalHold partially updates an active legal hold.
+func (c *Client4) PatchLegalHold(ctx context.Context, legalHoldId string, patch *LegalHoldPatch) (*LegalHold, *Response, error) {
+	buf, err := json.Marshal(patch)
+	if err != nil {
+		return nil, nil, NewAppError("PatchLegalHold", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPutBytes(ctx, c.legalHoldRoute(legalHoldId)+"/patch", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var hold LegalHold
+	if err := json.NewDecoder(r.Body).Decode(&hold); err != nil {
+		return nil, nil, NewAppError("PatchLegalHold", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &hold, BuildResponse(r), nil
+}
+
+// ReleaseLegalHold releases a legal hold, making its content subject to data
+// retention again.
+func (c *Client4) ReleaseLegalHold(ctx context.Context, legalHoldId string) (*LegalHold, *Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.legalHoldRoute(legalHoldId))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var hold LegalHold
+	if err := json.NewDecoder(r.Body).Decode(&hold); err != nil {
+		return nil, nil, NewAppError("ReleaseLegalHold", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &hold, BuildResponse(r), nil
+}
+
+// ExportLegalHold creates a job exporting the content held by a legal hold in
+// the given compliance export format.
+func (c *Client4) ExportLegalHold(ctx context.Context, legalHoldId string, exportType string) (*Job, *Response, error) {
+	buf, err := json.Marshal(&LegalHoldExportRequest{ExportType: exportType})
+	if err != nil {
+		return nil, nil, NewAppError("ExportLegalHold", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPostBytes(ctx, c.legalHoldRoute(legalHoldId)+"/export", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var job Job
+	if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+		return nil, nil, NewAppError("ExportLegalHold", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &job, BuildResponse(r), nil
+}
+
 // Commands Section
 
 // CreateCommand will create a new command if the user have the right permissions.
@@ -6754,6 +7514,35 @@ func (c *Client4) RegenCommandToken(ctx context.Context, commandId string) (stri
 	return MapFromJSON(r.Body)["token"], BuildResponse(r), nil
 }
 
+// RotateCommandSigningSecret generates a new signing secret for the command.
+// The previous secret keeps being used to sign requests during the configured grace period.
+func (c *Client4) RotateCommandSigningSecret(ctx context.Context, commandId string) (*Command, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.commandRoute(commandId)+"/signing_secret/rotate", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var cmd Command
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		return nil, nil, NewAppError("RotateCommandSigningSecret", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &cmd, BuildResponse(r), nil
+}
+
+// RemoveCommandSigningSecret stops the signing of the requests of the command.
+func (c *Client4) RemoveCommandSigningSecret(ctx context.Context, commandId string) (*Command, *Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.commandRoute(commandId)+"/signing_secret")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var cmd Command
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		return nil, nil, NewAppError("RemoveCommandSigningSecret", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &cmd, BuildResponse(r), nil
+}
+
 // Status Section
 
 // GetUserStatus returns a user based on the provided user id string.
@@ -8562,6 +9351,21 @@ func (c *Client4) ListImports(ctx context.Context) ([]string, *Response, error)
 	return c.ArrayFromJSON(r.Body), BuildResponse(r), nil
 }
 
+// GetImportValidationReport returns the report written by a dry-run of an
+// import job.
+func (c *Client4) GetImportValidationReport(ctx context.Context, jobId string) (*ImportValidationReport, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.importsRoute()+"/reports/"+jobId, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var report ImportValidationReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		return nil, nil, NewAppError("GetImportValidationReport", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &report, BuildResponse(r), nil
+}
+
 func (c *Client4) ListExports(ctx context.Context) ([]string, *Response, error) {
 	r, err := c.DoAPIGet(ctx, c.exportsRoute(), "")
 	if err != nil {
@@ -6,6 +6,7 @@ package model
 import (
 	"net/http"
 	"strings"
+	"time"
 )
 
 const (
@@ -39,6 +40,11 @@ type Command struct {
 	AutocompleteData *AutocompleteData `db:"-" json:"autocomplete_data,omitempty"`
 	// AutocompleteIconData is a base64 encoded svg
 	AutocompleteIconData string `db:"-" json:"autocomplete_icon_data,omitempty"`
+	// SigningSecret is used to sign the requests sent to the URL. Requests
+	// are not signed if it is empty.
+	SigningSecret                 string `json:"signing_secret"`
+	PreviousSigningSecret         string `json:"-"`
+	PreviousSigningSecretExpireAt int64  `json:"previous_signing_secret_expire_at"`
 }
 
 func (o *Command) Auditable() map[string]interface{} {
@@ -126,9 +132,39 @@ func (o *Command) IsValid() *AppError {
 		}
 	}
 
+	if !isValidIntegrationSigningSecret(o.SigningSecret) || !isValidIntegrationSigningSecret(o.PreviousSigningSecret) {
+		return NewAppError("Command.IsValid", "model.command.is_valid.signing_secret.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
+// SigningSecrets returns the secrets that requests have to be signed with.
+func (o *Command) SigningSecrets() []string {
+	return integrationSigningSecrets(o.SigningSecret, o.PreviousSigningSecret, o.PreviousSigningSecretExpireAt)
+}
+
+// RotateSigningSecret generates a new signing secret. The current secret, if
+// any, keeps being used to sign requests for the given grace period, so that
+// receivers can be updated without rejecting requests.
+func (o *Command) RotateSigningSecret(gracePeriod time.Duration) {
+	o.PreviousSigningSecret = ""
+	o.PreviousSigningSecretExpireAt = 0
+	if o.SigningSecret != "" && gracePeriod > 0 {
+		o.PreviousSigningSecret = o.SigningSecret
+		o.PreviousSigningSecretExpireAt = GetMillis() + gracePeriod.Milliseconds()
+	}
+
+	o.SigningSecret = NewIntegrationSigningSecret()
+}
+
+// RemoveSigningSecret stops the signing of requests.
+func (o *Command) RemoveSigningSecret() {
+	o.SigningSecret = ""
+	o.PreviousSigningSecret = ""
+	o.PreviousSigningSecretExpireAt = 0
+}
+
 func (o *Command) PreSave() {
 	if o.Id == "" {
 		o.Id = NewId()
@@ -148,6 +184,8 @@ func (o *Command) PreUpdate() {
 
 func (o *Command) Sanitize() {
 	o.Token = ""
+	o.SigningSecret = ""
+	o.PreviousSigningSecretExpireAt = 0
 	o.CreatorId = ""
 	o.Method = ""
 	o.URL = ""
@@ -6,6 +6,7 @@ package model
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -104,6 +105,31 @@ func TestCommandIsValid(t *testing.T) {
 
 	o.Description = strings.Repeat("1", 128)
 	require.Nil(t, o.IsValid())
+
+	o.SigningSecret = strings.Repeat("1", IntegrationSigningSecretMaxLength+1)
+	require.NotNil(t, o.IsValid(), "should be invalid")
+
+	o.SigningSecret = NewIntegrationSigningSecret()
+	require.Nil(t, o.IsValid())
+}
+
+func TestCommandRotateSigningSecret(t *testing.T) {
+	o := Command{}
+	o.RotateSigningSecret(time.Hour)
+	first := o.SigningSecret
+	require.NotEmpty(t, first)
+	require.Equal(t, []string{first}, o.SigningSecrets())
+
+	o.RotateSigningSecret(time.Hour)
+	require.Equal(t, []string{o.SigningSecret, first}, o.SigningSecrets())
+
+	o.Sanitize()
+	require.Empty(t, o.SigningSecret)
+
+	o.RotateSigningSecret(time.Hour)
+	o.RemoveSigningSecret()
+	require.Empty(t, o.SigningSecrets())
+	require.Empty(t, o.PreviousSigningSecret)
 }
 
 func TestCommandPreSave(t *testing.T) {
@@ -5,6 +5,7 @@ package model
 
 import (
 	"crypto/tls"
+	"encoding/base64"
 	"encoding/json"
 	"io"
 	"math"
@@ -15,6 +16,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -31,12 +33,17 @@ const (
 	ConnSecurityTLS      = "TLS"
 	ConnSecurityStarttls = "STARTTLS"
 
-	ImageDriverLocal = "local"
-	ImageDriverS3    = "amazons3"
+	ImageDriverLocal     = "local"
+	ImageDriverS3        = "amazons3"
+	ImageDriverAzureBlob = "azureblob"
+	ImageDriverGCS       = "gcs"
 
 	DatabaseDriverMysql    = "mysql"
 	DatabaseDriverPostgres = "postgres"
 
+	DatabaseSearchRankingRecency   = "recency"
+	DatabaseSearchRankingRelevance = "relevance"
+
 	SearchengineElasticsearch = "elasticsearch"
 
 	MinioAccessKey = "minioaccesskey"
@@ -138,6 +145,11 @@ const (
 
 	EmailSettingsDefaultFeedbackOrganization = ""
 
+	InboundEmailProtocolSMTP                       = "smtp"
+	InboundEmailProtocolLMTP                       = "lmtp"
+	EmailSettingsDefaultInboundEmailListenAddress  = "127.0.0.1:10025"
+	EmailSettingsDefaultInboundEmailMaxMessageSize = 25 * 1024 * 1024 // 25MB
+
 	SupportSettingsDefaultTermsOfServiceLink = "https://mattermost.com/pl/terms-of-use/"
 	SupportSettingsDefaultPrivacyPolicyLink  = "https://mattermost.com/pl/privacy-policy/"
 	SupportSettingsDefaultAboutLink          = "https://mattermost.com/pl/about-mattermost"
@@ -227,6 +239,13 @@ const (
 
 	OutgoingIntegrationRequestsDefaultTimeout = 30
 
+	OutgoingWebhookDefaultMaxDeliveryAttempts = 5
+	OutgoingWebhookDefaultRetryBackoffSeconds = 30
+	OutgoingWebhookDefaultMaxBackoffSeconds   = 3600
+	OutgoingWebhookDefaultHistoryDays         = 7
+
+	IntegrationSigningSecretDefaultGraceHours = 24
+
 	PluginSettingsDefaultDirectory         = "./plugins"
 	PluginSettingsDefaultClientDirectory   = "./client/plugins"
 	PluginSettingsDefaultEnableMarketplace = true
@@ -273,6 +292,10 @@ func GetDefaultAppCustomURLSchemes() []string {
 	return []string{"mmauth://", "mmauthbeta://"}
 }
 
+// textSearchConfigRegex matches the name of a PostgreSQL text search
+// configuration, optionally qualified by its schema.
+var textSearchConfigRegex = regexp.MustCompile(`^([a-z_][a-z0-9_]*\.)?[a-z_][a-z0-9_]*$`)
+
 var ServerTLSSupportedCiphers = map[string]uint16{
 	"TLS_RSA_WITH_RC4_128_SHA":                tls.TLS_RSA_WITH_RC4_128_SHA,
 	"TLS_RSA_WITH_3DES_EDE_CBC_SHA":           tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA,
@@ -326,6 +349,11 @@ type ServiceSettings struct {
 	EnableOutgoingOAuthConnections      *bool    `access:"integrations_integration_management"`
 	EnableCommands                      *bool    `access:"integrations_integration_management"`
 	OutgoingIntegrationRequestsTimeout  *int64   `access:"integrations_integration_management"` // In seconds.
+	OutgoingWebhookMaxDeliveryAttempts  *int     `access:"integrations_integration_management"`
+	OutgoingWebhookRetryBackoffSeconds  *int     `access:"integrations_integration_management"`
+	OutgoingWebhookMaxBackoffSeconds    *int     `access:"integrations_integration_management"`
+	OutgoingWebhookHistoryDays          *int     `access:"integrations_integration_management"`
+	IntegrationSigningSecretGraceHours  *int     `access:"integrations_integration_management"`
 	EnablePostUsernameOverride          *bool    `access:"integrations_integration_management"`
 	EnablePostIconOverride              *bool    `access:"integrations_integration_management"`
 	GoogleDeveloperKey                  *string  `access:"site_posts,write_restrictable,cloud_restrictable"`
@@ -342,6 +370,10 @@ type ServiceSettings struct {
 	AllowedUntrustedInternalConnections *string  `access:"environment_web_server,write_restrictable,cloud_restrictable"`
 	EnableMultifactorAuthentication     *bool    `access:"authentication_mfa"`
 	EnforceMultifactorAuthentication    *bool    `access:"authentication_mfa"`
+	MultifactorAuthenticationMethods    []string `access:"authentication_mfa"`
+	EnforcedMultifactorMethod           *string  `access:"authentication_mfa"`
+	EnableMfaRecoveryCodes              *bool    `access:"authentication_mfa"`
+	EnablePasswordlessLogin             *bool    `access:"authentication_mfa"`
 	EnableUserAccessTokens              *bool    `access:"integrations_integration_management"`
 	AllowCorsFrom                       *string  `access:"integrations_cors,write_restrictable,cloud_restrictable"`
 	CorsExposedHeaders                  *string  `access:"integrations_cors,write_restrictable,cloud_restrictable"`
@@ -371,12 +403,15 @@ type ServiceSettings struct {
 	EnableCustomEmoji                                 *bool   `access:"site_emoji"`
 	EnableEmojiPicker                                 *bool   `access:"site_emoji"`
 	PostEditTimeLimit                                 *int    `access:"user_management_permissions"`
+	PostEditHistoryLimit                              *int    `access:"user_management_permissions"`
 	TimeBetweenUserTypingUpdatesMilliseconds          *int64  `access:"experimental_features,write_restrictable,cloud_restrictable"`
 	EnablePostSearch                                  *bool   `access:"write_restrictable,cloud_restrictable"`
 	EnableFileSearch                                  *bool   `access:"write_restrictable"`
 	MinimumHashtagLength                              *int    `access:"environment_database,write_restrictable,cloud_restrictable"`
 	EnableUserTypingMessages                          *bool   `access:"experimental_features,write_restrictable,cloud_restrictable"`
 	EnableChannelViewedMessages                       *bool   `access:"experimental_features,write_restrictable,cloud_restrictable"`
+	EnableReadReceipts                                *bool   `access:"site_posts"`
+	ReadReceiptsMaxChannelMembers                     *int    `access:"site_posts"`
 	EnableUserStatuses                                *bool   `access:"write_restrictable,cloud_restrictable"`
 	ExperimentalEnableAuthenticationTransfer          *bool   `access:"experimental_features"`
 	ClusterLogTimeoutMilliseconds                     *int    `access:"write_restrictable,cloud_restrictable"`
@@ -415,6 +450,7 @@ type ServiceSettings struct {
 	ManagedResourcePaths                              *string `access:"environment_web_server,write_restrictable,cloud_restrictable"`
 	EnableCustomGroups                                *bool   `access:"site_users_and_teams"`
 	AllowSyncedDrafts                                 *bool   `access:"site_posts"`
+	ScheduledPosts                                    *bool   `access:"site_posts"`
 	UniqueEmojiReactionLimitPerPost                   *int    `access:"site_posts"`
 	RefreshPostStatsRunTime                           *string `access:"site_users_and_teams"`
 	MaximumPayloadSizeBytes                           *int64  `access:"environment_file_storage,write_restrictable,cloud_restrictable"`
@@ -505,6 +541,22 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.EnforceMultifactorAuthentication = NewPointer(false)
 	}
 
+	if s.MultifactorAuthenticationMethods == nil {
+		s.MultifactorAuthenticationMethods = []string{MfaMethodTotp}
+	}
+
+	if s.EnforcedMultifactorMethod == nil {
+		s.EnforcedMultifactorMethod = NewPointer("")
+	}
+
+	if s.EnableMfaRecoveryCodes == nil {
+		s.EnableMfaRecoveryCodes = NewPointer(true)
+	}
+
+	if s.EnablePasswordlessLogin == nil {
+		s.EnablePasswordlessLogin = NewPointer(false)
+	}
+
 	if s.EnableUserAccessTokens == nil {
 		s.EnableUserAccessTokens = NewPointer(false)
 	}
@@ -537,6 +589,26 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.OutgoingIntegrationRequestsTimeout = NewPointer(int64(OutgoingIntegrationRequestsDefaultTimeout))
 	}
 
+	if s.OutgoingWebhookMaxDeliveryAttempts == nil {
+		s.OutgoingWebhookMaxDeliveryAttempts = NewPointer(OutgoingWebhookDefaultMaxDeliveryAttempts)
+	}
+
+	if s.OutgoingWebhookRetryBackoffSeconds == nil {
+		s.OutgoingWebhookRetryBackoffSeconds = NewPointer(OutgoingWebhookDefaultRetryBackoffSeconds)
+	}
+
+	if s.OutgoingWebhookMaxBackoffSeconds == nil {
+		s.OutgoingWebhookMaxBackoffSeconds = NewPointer(OutgoingWebhookDefaultMaxBackoffSeconds)
+	}
+
+	if s.OutgoingWebhookHistoryDays == nil {
+		s.OutgoingWebhookHistoryDays = NewPointer(OutgoingWebhookDefaultHistoryDays)
+	}
+
+	if s.IntegrationSigningSecretGraceHours == nil {
+		s.IntegrationSigningSecretGraceHours = NewPointer(IntegrationSigningSecretDefaultGraceHours)
+	}
+
 	if s.ConnectionSecurity == nil {
 		s.ConnectionSecurity = NewPointer("")
 	}
@@ -621,6 +693,14 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.EnableChannelViewedMessages = NewPointer(true)
 	}
 
+	if s.EnableReadReceipts == nil {
+		s.EnableReadReceipts = NewPointer(false)
+	}
+
+	if s.ReadReceiptsMaxChannelMembers == nil {
+		s.ReadReceiptsMaxChannelMembers = NewPointer(0)
+	}
+
 	if s.EnableUserStatuses == nil {
 		s.EnableUserStatuses = NewPointer(true)
 	}
@@ -783,6 +863,10 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.PostEditTimeLimit = NewPointer(-1)
 	}
 
+	if s.PostEditHistoryLimit == nil {
+		s.PostEditHistoryLimit = NewPointer(0)
+	}
+
 	if s.ExperimentalEnableDefaultChannelLeaveJoinMessages == nil {
 		s.ExperimentalEnableDefaultChannelLeaveJoinMessages = NewPointer(true)
 	}
@@ -923,6 +1007,10 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.AllowSyncedDrafts = NewPointer(true)
 	}
 
+	if s.ScheduledPosts == nil {
+		s.ScheduledPosts = NewPointer(true)
+	}
+
 	if s.UniqueEmojiReactionLimitPerPost == nil {
 		s.UniqueEmojiReactionLimitPerPost = NewPointer(ServiceSettingsDefaultUniqueReactionsPerPost)
 	}
@@ -1280,18 +1368,24 @@ type ReplicaLagSettings struct {
 }
 
 type SqlSettings struct {
-	DriverName                        *string               `access:"environment_database,write_restrictable,cloud_restrictable"`
-	DataSource                        *string               `access:"environment_database,write_restrictable,cloud_restrictable"` // telemetry: none
-	DataSourceReplicas                []string              `access:"environment_database,write_restrictable,cloud_restrictable"`
-	DataSourceSearchReplicas          []string              `access:"environment_database,write_restrictable,cloud_restrictable"`
-	MaxIdleConns                      *int                  `access:"environment_database,write_restrictable,cloud_restrictable"`
-	ConnMaxLifetimeMilliseconds       *int                  `access:"environment_database,write_restrictable,cloud_restrictable"`
-	ConnMaxIdleTimeMilliseconds       *int                  `access:"environment_database,write_restrictable,cloud_restrictable"`
-	MaxOpenConns                      *int                  `access:"environment_database,write_restrictable,cloud_restrictable"`
-	Trace                             *bool                 `access:"environment_database,write_restrictable,cloud_restrictable"`
-	AtRestEncryptKey                  *string               `access:"environment_database,write_restrictable,cloud_restrictable"` // telemetry: none
-	QueryTimeout                      *int                  `access:"environment_database,write_restrictable,cloud_restrictable"`
-	DisableDatabaseSearch             *bool                 `access:"environment_database,write_restrictable,cloud_restrictable"`
+	DriverName                  *string  `access:"environment_database,write_restrictable,cloud_restrictable"`
+	DataSource                  *string  `access:"environment_database,write_restrictable,cloud_restrictable"` // telemetry: none
+	DataSourceReplicas          []string `access:"environment_database,write_restrictable,cloud_restrictable"`
+	DataSourceSearchReplicas    []string `access:"environment_database,write_restrictable,cloud_restrictable"`
+	MaxIdleConns                *int     `access:"environment_database,write_restrictable,cloud_restrictable"`
+	ConnMaxLifetimeMilliseconds *int     `access:"environment_database,write_restrictable,cloud_restrictable"`
+	ConnMaxIdleTimeMilliseconds *int     `access:"environment_database,write_restrictable,cloud_restrictable"`
+	MaxOpenConns                *int     `access:"environment_database,write_restrictable,cloud_restrictable"`
+	Trace                       *bool    `access:"environment_database,write_restrictable,cloud_restrictable"`
+	AtRestEncryptKey            *string  `access:"environment_database,write_restrictable,cloud_restrictable"` // telemetry: none
+	QueryTimeout                *int     `access:"environment_database,write_restrictable,cloud_restrictable"`
+	DisableDatabaseSearch       *bool    `access:"environment_database,write_restrictable,cloud_restrictable"`
+	DatabaseSearchRanking       *string  `access:"environment_database,write_restrictable,cloud_restrictable"`
+	// TextSearchConfigs maps team and channel ids to the PostgreSQL text search
+	// configuration used to search their posts. Each configuration other than the
+	// default one needs its own expression index on Posts, e.g.
+	// to_tsvector('french', Message), or its searches scan all the posts.
+	TextSearchConfigs                 map[string]string     `access:"environment_database,write_restrictable,cloud_restrictable"`
 	MigrationsStatementTimeoutSeconds *int                  `access:"environment_database,write_restrictable,cloud_restrictable"`
 	ReplicaLagSettings                []*ReplicaLagSettings `access:"environment_database,write_restrictable,cloud_restrictable"` // telemetry: none
 	ReplicaMonitorIntervalSeconds     *int                  `access:"environment_database,write_restrictable,cloud_restrictable"`
@@ -1352,6 +1446,14 @@ func (s *SqlSettings) SetDefaults(isUpdate bool) {
 		s.DisableDatabaseSearch = NewPointer(false)
 	}
 
+	if s.DatabaseSearchRanking == nil {
+		s.DatabaseSearchRanking = NewPointer(DatabaseSearchRankingRecency)
+	}
+
+	if s.TextSearchConfigs == nil {
+		s.TextSearchConfigs = map[string]string{}
+	}
+
 	if s.MigrationsStatementTimeoutSeconds == nil {
 		s.MigrationsStatementTimeoutSeconds = NewPointer(100000)
 	}
@@ -1644,6 +1746,27 @@ type FileSettings struct {
 	AmazonS3Trace                      *bool   `access:"environment_file_storage,write_restrictable,cloud_restrictable"`
 	AmazonS3RequestTimeoutMilliseconds *int64  `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
 	AmazonS3UploadPartSizeBytes        *int64  `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	AzureStorageAccount                *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	AzureAccessKey                     *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	AzureContainer                     *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	AzurePathPrefix                    *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	AzureEndpoint                      *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	AzureRequestTimeoutMilliseconds    *int64  `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	AzurePresignExpiresSeconds         *int64  `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	GCSBucket                          *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	GCSPathPrefix                      *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	GCSCredentialsJSON                 *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	GCSEndpoint                        *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	GCSRequestTimeoutMilliseconds      *int64  `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	GCSPresignExpiresSeconds           *int64  `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	// Encryption at rest settings
+	EnableEncryptionAtRest      *bool    `access:"environment_file_storage,write_restrictable,cloud_restrictable"`
+	EncryptionAtRestMasterKey   *string  `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	EncryptionAtRestRetiredKeys []string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	// Antivirus scanning settings
+	EnableAntivirusScan         *bool   `access:"environment_file_storage,write_restrictable,cloud_restrictable"`
+	AntivirusScanURL            *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
+	AntivirusScanTimeoutSeconds *int64  `access:"environment_file_storage,write_restrictable,cloud_restrictable"`
 	// Export store settings
 	DedicatedExportStore                     *bool   `access:"environment_file_storage,write_restrictable"`
 	ExportDriverName                         *string `access:"environment_file_storage,write_restrictable"`
@@ -1773,6 +1896,84 @@ func (s *FileSettings) SetDefaults(isUpdate bool) {
 		s.AmazonS3UploadPartSizeBytes = NewPointer(int64(FileSettingsDefaultS3UploadPartSizeBytes))
 	}
 
+	if s.AzureStorageAccount == nil {
+		s.AzureStorageAccount = NewPointer("")
+	}
+
+	if s.AzureAccessKey == nil {
+		s.AzureAccessKey = NewPointer("")
+	}
+
+	if s.AzureContainer == nil {
+		s.AzureContainer = NewPointer("")
+	}
+
+	if s.AzurePathPrefix == nil {
+		s.AzurePathPrefix = NewPointer("")
+	}
+
+	if s.AzureEndpoint == nil {
+		// Defaults to "https://<AzureStorageAccount>.blob.core.windows.net"
+		s.AzureEndpoint = NewPointer("")
+	}
+
+	if s.AzureRequestTimeoutMilliseconds == nil {
+		s.AzureRequestTimeoutMilliseconds = NewPointer(int64(30000))
+	}
+
+	if s.AzurePresignExpiresSeconds == nil {
+		s.AzurePresignExpiresSeconds = NewPointer(int64(21600)) // 6h
+	}
+
+	if s.GCSBucket == nil {
+		s.GCSBucket = NewPointer("")
+	}
+
+	if s.GCSPathPrefix == nil {
+		s.GCSPathPrefix = NewPointer("")
+	}
+
+	if s.GCSCredentialsJSON == nil {
+		// Application default credentials are used when empty.
+		s.GCSCredentialsJSON = NewPointer("")
+	}
+
+	if s.GCSEndpoint == nil {
+		s.GCSEndpoint = NewPointer("")
+	}
+
+	if s.GCSRequestTimeoutMilliseconds == nil {
+		s.GCSRequestTimeoutMilliseconds = NewPointer(int64(30000))
+	}
+
+	if s.GCSPresignExpiresSeconds == nil {
+		s.GCSPresignExpiresSeconds = NewPointer(int64(21600)) // 6h
+	}
+
+	if s.EnableEncryptionAtRest == nil {
+		s.EnableEncryptionAtRest = NewPointer(false)
+	}
+
+	if s.EncryptionAtRestMasterKey == nil {
+		s.EncryptionAtRestMasterKey = NewPointer("")
+	}
+
+	if s.EncryptionAtRestRetiredKeys == nil {
+		s.EncryptionAtRestRetiredKeys = []string{}
+	}
+
+	if s.EnableAntivirusScan == nil {
+		s.EnableAntivirusScan = NewPointer(false)
+	}
+
+	if s.AntivirusScanURL == nil {
+		s.AntivirusScanURL = NewPointer("tcp://localhost:3310")
+	}
+
+	if s.AntivirusScanTimeoutSeconds == nil {
+		s.AntivirusScanTimeoutSeconds = NewPointer(int64(60))
+	}
+
 	if s.DedicatedExportStore == nil {
 		s.DedicatedExportStore = NewPointer(false)
 	}
@@ -1871,6 +2072,21 @@ type EmailSettings struct {
 	LoginButtonColor                  *string `access:"experimental_features"`
 	LoginButtonBorderColor            *string `access:"experimental_features"`
 	LoginButtonTextColor              *string `access:"experimental_features"`
+	EnableReplyByEmail                *bool   `access:"site_notifications"`
+	ReplyByEmailAddress               *string `access:"site_notifications,cloud_restrictable"` // telemetry: none
+	InboundEmailProtocol              *string `access:"environment_smtp,write_restrictable,cloud_restrictable"`
+	InboundEmailListenAddress         *string `access:"environment_smtp,write_restrictable,cloud_restrictable"` // telemetry: none
+	InboundEmailMaxMessageSize        *int64  `access:"environment_smtp,write_restrictable,cloud_restrictable"`
+	// InboundEmailTrustedAuthServIds lists the authserv-ids of the MTAs whose
+	// Authentication-Results headers are trusted to authenticate the senders of
+	// replies. The inbound email server must only be reachable through those
+	// MTAs, which must remove the headers claiming their authserv-id from the
+	// messages they receive.
+	InboundEmailTrustedAuthServIds []string `access:"environment_smtp,write_restrictable,cloud_restrictable"` // telemetry: none
+	// InboundEmailTrustedSources lists the IP addresses and CIDR ranges of
+	// those MTAs. Connections from other addresses are refused.
+	InboundEmailTrustedSources []string `access:"environment_smtp,write_restrictable,cloud_restrictable"` // telemetry: none
+	EnableWebPushNotifications *bool    `access:"environment_push_notification_server"`
 }
 
 func (s *EmailSettings) SetDefaults(isUpdate bool) {
@@ -2013,6 +2229,38 @@ func (s *EmailSettings) SetDefaults(isUpdate bool) {
 	if s.LoginButtonTextColor == nil {
 		s.LoginButtonTextColor = NewPointer("#2389D7")
 	}
+
+	if s.EnableReplyByEmail == nil {
+		s.EnableReplyByEmail = NewPointer(false)
+	}
+
+	if s.ReplyByEmailAddress == nil {
+		s.ReplyByEmailAddress = NewPointer("")
+	}
+
+	if s.InboundEmailProtocol == nil {
+		s.InboundEmailProtocol = NewPointer(InboundEmailProtocolSMTP)
+	}
+
+	if s.InboundEmailListenAddress == nil {
+		s.InboundEmailListenAddress = NewPointer(EmailSettingsDefaultInboundEmailListenAddress)
+	}
+
+	if s.InboundEmailMaxMessageSize == nil {
+		s.InboundEmailMaxMessageSize = NewPointer(int64(EmailSettingsDefaultInboundEmailMaxMessageSize))
+	}
+
+	if s.InboundEmailTrustedAuthServIds == nil {
+		s.InboundEmailTrustedAuthServIds = []string{}
+	}
+
+	if s.InboundEmailTrustedSources == nil {
+		s.InboundEmailTrustedSources = []string{}
+	}
+
+	if s.EnableWebPushNotifications == nil {
+		s.EnableWebPushNotifications = NewPointer(false)
+	}
 }
 
 type RateLimitSettings struct {
@@ -3296,6 +3544,44 @@ func (w *WranglerSettings) IsValid() *AppError {
 	return nil
 }
 
+type ScimSettings struct {
+	Enable          *bool
+	BearerToken     *string // telemetry: none
+	UserAuthService *string
+}
+
+func (s *ScimSettings) SetDefaults() {
+	if s.Enable == nil {
+		s.Enable = NewPointer(false)
+	}
+
+	if s.BearerToken == nil {
+		s.BearerToken = NewPointer("")
+	}
+
+	if s.UserAuthService == nil {
+		s.UserAuthService = NewPointer(UserAuthServiceSaml)
+	}
+}
+
+func (s *ScimSettings) isValid() *AppError {
+	if !*s.Enable {
+		return nil
+	}
+
+	if len(*s.BearerToken) < 32 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.scim_bearer_token.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	switch *s.UserAuthService {
+	case UserAuthServiceSaml, ServiceOpenid, ServiceGitlab, ServiceGoogle, ServiceOffice365:
+	default:
+		return NewAppError("Config.IsValid", "model.config.is_valid.scim_user_auth_service.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 type ConnectedWorkspacesSettings struct {
 	EnableSharedChannels            *bool
 	EnableRemoteClusterService      *bool
@@ -3628,6 +3914,8 @@ type Config struct {
 	ExportSettings              ExportSettings
 	WranglerSettings            WranglerSettings
 	ConnectedWorkspacesSettings ConnectedWorkspacesSettings
+	ScimSettings                ScimSettings
+	DataLossPreventionSettings  DataLossPreventionSettings
 }
 
 func (o *Config) Auditable() map[string]interface{} {
@@ -3745,6 +4033,8 @@ func (o *Config) SetDefaults() {
 	o.ExportSettings.SetDefaults()
 	o.WranglerSettings.SetDefaults()
 	o.ConnectedWorkspacesSettings.SetDefaults(isUpdate, o.ExperimentalSettings)
+	o.ScimSettings.SetDefaults()
+	o.DataLossPreventionSettings.SetDefaults()
 }
 
 func (o *Config) IsValid() *AppError {
@@ -3844,6 +4134,14 @@ func (o *Config) IsValid() *AppError {
 		return appErr
 	}
 
+	if appErr := o.ScimSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
+	if appErr := o.DataLossPreventionSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
 	return nil
 }
 
@@ -3920,6 +4218,16 @@ func (s *SqlSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.sql_max_conn.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.DatabaseSearchRanking != DatabaseSearchRankingRecency && *s.DatabaseSearchRanking != DatabaseSearchRankingRelevance {
+		return NewAppError("Config.IsValid", "model.config.is_valid.sql_database_search_ranking.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for id, textSearchConfig := range s.TextSearchConfigs {
+		if !IsValidId(id) || !textSearchConfigRegex.MatchString(textSearchConfig) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.sql_text_search_configs.app_error", map[string]any{"Id": id}, "", http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -3928,7 +4236,7 @@ func (s *FileSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.max_file_size.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	if !(*s.DriverName == ImageDriverLocal || *s.DriverName == ImageDriverS3) {
+	if !(*s.DriverName == ImageDriverLocal || *s.DriverName == ImageDriverS3 || *s.DriverName == ImageDriverAzureBlob || *s.DriverName == ImageDriverGCS) {
 		return NewAppError("Config.IsValid", "model.config.is_valid.file_driver.app_error", nil, "", http.StatusBadRequest)
 	}
 
@@ -3948,9 +4256,72 @@ func (s *FileSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.amazons3_timeout.app_error", map[string]any{"Value": *s.MaxImageDecoderConcurrency}, "", http.StatusBadRequest)
 	}
 
+	if *s.DriverName == ImageDriverAzureBlob {
+		if *s.AzureContainer == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.azure_container.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		if *s.AzureRequestTimeoutMilliseconds <= 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.azure_timeout.app_error", map[string]any{"Value": *s.AzureRequestTimeoutMilliseconds}, "", http.StatusBadRequest)
+		}
+	}
+
+	if *s.DriverName == ImageDriverGCS {
+		if *s.GCSBucket == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.gcs_bucket.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		if *s.GCSRequestTimeoutMilliseconds <= 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.gcs_timeout.app_error", map[string]any{"Value": *s.GCSRequestTimeoutMilliseconds}, "", http.StatusBadRequest)
+		}
+	}
+
+	if *s.EnableEncryptionAtRest && !isValidEncryptionAtRestKey(*s.EncryptionAtRestMasterKey) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.encryption_at_rest_master_key.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, key := range s.EncryptionAtRestRetiredKeys {
+		if !isValidEncryptionAtRestKey(key) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.encryption_at_rest_retired_keys.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	if *s.EnableAntivirusScan {
+		if !isValidAntivirusScanURL(*s.AntivirusScanURL) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.antivirus_scan_url.app_error", map[string]any{"Value": *s.AntivirusScanURL}, "", http.StatusBadRequest)
+		}
+
+		if *s.AntivirusScanTimeoutSeconds <= 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.antivirus_scan_timeout.app_error", map[string]any{"Value": *s.AntivirusScanTimeoutSeconds}, "", http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
+// isValidAntivirusScanURL checks that the URL points to a clamd daemon over
+// TCP or a unix socket, or to an ICAP service.
+func isValidAntivirusScanURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	switch u.Scheme {
+	case "tcp", "icap":
+		return u.Host != ""
+	case "unix":
+		return u.Path != ""
+	}
+	return false
+}
+
+// isValidEncryptionAtRestKey checks that the key is a base64 encoded 256-bit key.
+func isValidEncryptionAtRestKey(key string) bool {
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	return err == nil && len(decoded) == 32
+}
+
 func (s *EmailSettings) isValid() *AppError {
 	if !(*s.ConnectionSecurity == ConnSecurityNone || *s.ConnectionSecurity == ConnSecurityTLS || *s.ConnectionSecurity == ConnSecurityStarttls || *s.ConnectionSecurity == ConnSecurityPlain) {
 		return NewAppError("Config.IsValid", "model.config.is_valid.email_security.app_error", nil, "", http.StatusBadRequest)
@@ -3968,6 +4339,40 @@ func (s *EmailSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.email_notification_contents_type.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if !(*s.InboundEmailProtocol == InboundEmailProtocolSMTP || *s.InboundEmailProtocol == InboundEmailProtocolLMTP) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.inbound_email_protocol.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.InboundEmailMaxMessageSize <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.inbound_email_max_message_size.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.EnableReplyByEmail {
+		// Replies are sent to sub-addresses of the reply address.
+		if !IsValidEmail(*s.ReplyByEmailAddress) || strings.Contains(*s.ReplyByEmailAddress, "+") {
+			return NewAppError("Config.IsValid", "model.config.is_valid.reply_by_email_address.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		if *s.InboundEmailListenAddress == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.inbound_email_listen_address.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		// Replies are only accepted once a trusted MTA authenticated their sender.
+		if len(s.InboundEmailTrustedAuthServIds) == 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.inbound_email_trusted_authserv_ids.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		// The inbound email server only accepts connections from those MTAs.
+		if len(s.InboundEmailTrustedSources) == 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.inbound_email_trusted_sources.app_error", nil, "", http.StatusBadRequest)
+		}
+		for _, source := range s.InboundEmailTrustedSources {
+			if _, _, err := net.ParseCIDR(source); err != nil && net.ParseIP(source) == nil {
+				return NewAppError("Config.IsValid", "model.config.is_valid.inbound_email_trusted_sources.app_error", nil, "source="+source, http.StatusBadRequest)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -4177,6 +4582,27 @@ func (s *ServiceSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.login_attempts.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.PostEditHistoryLimit < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.post_edit_history_limit.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.ReadReceiptsMaxChannelMembers < 0 || *s.ReadReceiptsMaxChannelMembers > ReadReceiptsMaxChannelMembersLimit {
+		return NewAppError("Config.IsValid", "model.config.is_valid.read_receipts_max_channel_members.app_error", map[string]any{"Max": ReadReceiptsMaxChannelMembersLimit}, "", http.StatusBadRequest)
+	}
+
+	if len(s.MultifactorAuthenticationMethods) == 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.mfa_methods.app_error", nil, "", http.StatusBadRequest)
+	}
+	for _, method := range s.MultifactorAuthenticationMethods {
+		if method != MfaMethodTotp && method != MfaMethodWebAuthn {
+			return NewAppError("Config.IsValid", "model.config.is_valid.mfa_method.app_error", map[string]any{"Method": method}, "", http.StatusBadRequest)
+		}
+	}
+
+	if *s.EnforcedMultifactorMethod != "" && !slices.Contains(s.MultifactorAuthenticationMethods, *s.EnforcedMultifactorMethod) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.enforced_mfa_method.app_error", map[string]any{"Method": *s.EnforcedMultifactorMethod}, "", http.StatusBadRequest)
+	}
+
 	if *s.SiteURL != "" {
 		if _, err := url.ParseRequestURI(*s.SiteURL); err != nil {
 			return NewAppError("Config.IsValid", "model.config.is_valid.site_url.app_error", nil, "", http.StatusBadRequest).Wrap(err)
@@ -4205,6 +4631,22 @@ func (s *ServiceSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.outgoing_integrations_request_timeout.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.OutgoingWebhookMaxDeliveryAttempts < 1 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.outgoing_webhook_max_delivery_attempts.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.OutgoingWebhookRetryBackoffSeconds <= 0 || *s.OutgoingWebhookMaxBackoffSeconds < *s.OutgoingWebhookRetryBackoffSeconds {
+		return NewAppError("Config.IsValid", "model.config.is_valid.outgoing_webhook_retry_backoff.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.OutgoingWebhookHistoryDays <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.outgoing_webhook_history_days.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.IntegrationSigningSecretGraceHours < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.integration_signing_secret_grace_hours.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if *s.ExperimentalGroupUnreadChannels != GroupUnreadChannelsDisabled &&
 		*s.ExperimentalGroupUnreadChannels != GroupUnreadChannelsDefaultOn &&
 		*s.ExperimentalGroupUnreadChannels != GroupUnreadChannelsDefaultOff {
@@ -4468,6 +4910,22 @@ func (o *Config) Sanitize(pluginManifests []*Manifest) {
 		*o.FileSettings.AmazonS3SecretAccessKey = FakeSetting
 	}
 
+	if o.FileSettings.AzureAccessKey != nil && *o.FileSettings.AzureAccessKey != "" {
+		*o.FileSettings.AzureAccessKey = FakeSetting
+	}
+
+	if o.FileSettings.GCSCredentialsJSON != nil && *o.FileSettings.GCSCredentialsJSON != "" {
+		*o.FileSettings.GCSCredentialsJSON = FakeSetting
+	}
+
+	if o.FileSettings.EncryptionAtRestMasterKey != nil && *o.FileSettings.EncryptionAtRestMasterKey != "" {
+		*o.FileSettings.EncryptionAtRestMasterKey = FakeSetting
+	}
+
+	for i := range o.FileSettings.EncryptionAtRestRetiredKeys {
+		o.FileSettings.EncryptionAtRestRetiredKeys[i] = FakeSetting
+	}
+
 	if o.EmailSettings.SMTPPassword != nil && *o.EmailSettings.SMTPPassword != "" {
 		*o.EmailSettings.SMTPPassword = FakeSetting
 	}
@@ -4526,6 +4984,10 @@ func (o *Config) Sanitize(pluginManifests []*Manifest) {
 		*o.CacheSettings.RedisPassword = FakeSetting
 	}
 
+	if o.ScimSettings.BearerToken != nil && *o.ScimSettings.BearerToken != "" {
+		*o.ScimSettings.BearerToken = FakeSetting
+	}
+
 	o.PluginSettings.Sanitize(pluginManifests)
 }
 
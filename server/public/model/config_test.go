@@ -4,6 +4,7 @@
 package model
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -101,6 +102,32 @@ func TestServiceSettingsIsValid(t *testing.T) {
 			},
 			ExpectError: false,
 		},
+		"no MFA method": {
+			ServiceSettings: ServiceSettings{
+				MultifactorAuthenticationMethods: []string{},
+			},
+			ExpectError: true,
+		},
+		"unknown MFA method": {
+			ServiceSettings: ServiceSettings{
+				MultifactorAuthenticationMethods: []string{MfaMethodTotp, "sms"},
+			},
+			ExpectError: true,
+		},
+		"enforced MFA method is allowed": {
+			ServiceSettings: ServiceSettings{
+				MultifactorAuthenticationMethods: []string{MfaMethodTotp, MfaMethodWebAuthn},
+				EnforcedMultifactorMethod:        NewPointer(MfaMethodWebAuthn),
+			},
+			ExpectError: false,
+		},
+		"enforced MFA method is not allowed": {
+			ServiceSettings: ServiceSettings{
+				MultifactorAuthenticationMethods: []string{MfaMethodTotp},
+				EnforcedMultifactorMethod:        NewPointer(MfaMethodWebAuthn),
+			},
+			ExpectError: true,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			test.ServiceSettings.SetDefaults(false)
@@ -154,6 +181,96 @@ func TestConfigDefaultEmailNotificationContentsType(t *testing.T) {
 	require.Equal(t, *c1.EmailSettings.EmailNotificationContentsType, EmailNotificationContentsFull)
 }
 
+func TestEmailSettingsIsValidReplyByEmail(t *testing.T) {
+	for name, test := range map[string]struct {
+		EmailSettings EmailSettings
+		ExpectError   bool
+	}{
+		"disabled": {
+			EmailSettings: EmailSettings{},
+			ExpectError:   false,
+		},
+		"enabled": {
+			EmailSettings: EmailSettings{
+				EnableReplyByEmail:             NewPointer(true),
+				ReplyByEmailAddress:            NewPointer("reply@chat.example.com"),
+				InboundEmailTrustedAuthServIds: []string{"mx.chat.example.com"},
+				InboundEmailTrustedSources:     []string{"127.0.0.1", "10.0.0.0/8", "2001:db8::1"},
+			},
+			ExpectError: false,
+		},
+		"enabled without trusted authserv-ids": {
+			EmailSettings: EmailSettings{
+				EnableReplyByEmail:         NewPointer(true),
+				ReplyByEmailAddress:        NewPointer("reply@chat.example.com"),
+				InboundEmailTrustedSources: []string{"127.0.0.1"},
+			},
+			ExpectError: true,
+		},
+		"enabled without trusted sources": {
+			EmailSettings: EmailSettings{
+				EnableReplyByEmail:             NewPointer(true),
+				ReplyByEmailAddress:            NewPointer("reply@chat.example.com"),
+				InboundEmailTrustedAuthServIds: []string{"mx.chat.example.com"},
+			},
+			ExpectError: true,
+		},
+		"invalid trusted source": {
+			EmailSettings: EmailSettings{
+				EnableReplyByEmail:             NewPointer(true),
+				ReplyByEmailAddress:            NewPointer("reply@chat.example.com"),
+				InboundEmailTrustedAuthServIds: []string{"mx.chat.example.com"},
+				InboundEmailTrustedSources:     []string{"mx.chat.example.com"},
+			},
+			ExpectError: true,
+		},
+		"enabled without an address": {
+			EmailSettings: EmailSettings{
+				EnableReplyByEmail: NewPointer(true),
+			},
+			ExpectError: true,
+		},
+		"address with a sub-address": {
+			EmailSettings: EmailSettings{
+				EnableReplyByEmail:  NewPointer(true),
+				ReplyByEmailAddress: NewPointer("reply+tag@chat.example.com"),
+			},
+			ExpectError: true,
+		},
+		"enabled without a listen address": {
+			EmailSettings: EmailSettings{
+				EnableReplyByEmail:        NewPointer(true),
+				ReplyByEmailAddress:       NewPointer("reply@chat.example.com"),
+				InboundEmailListenAddress: NewPointer(""),
+			},
+			ExpectError: true,
+		},
+		"unknown protocol": {
+			EmailSettings: EmailSettings{
+				InboundEmailProtocol: NewPointer("imap"),
+			},
+			ExpectError: true,
+		},
+		"invalid max message size": {
+			EmailSettings: EmailSettings{
+				InboundEmailMaxMessageSize: NewPointer(int64(0)),
+			},
+			ExpectError: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.EmailSettings.SetDefaults(false)
+
+			appErr := test.EmailSettings.isValid()
+			if test.ExpectError {
+				assert.NotNil(t, appErr)
+			} else {
+				assert.Nil(t, appErr)
+			}
+		})
+	}
+}
+
 func TestConfigDefaultFileSettingsS3SSE(t *testing.T) {
 	c1 := Config{}
 	c1.SetDefaults()
@@ -161,6 +278,117 @@ func TestConfigDefaultFileSettingsS3SSE(t *testing.T) {
 	require.False(t, *c1.FileSettings.AmazonS3SSE)
 }
 
+func TestConfigFileSettingsCloudDrivers(t *testing.T) {
+	t.Run("azure blob storage requires a container", func(t *testing.T) {
+		c1 := Config{}
+		c1.SetDefaults()
+		*c1.FileSettings.DriverName = ImageDriverAzureBlob
+
+		appErr := c1.FileSettings.isValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.azure_container.app_error", appErr.Id)
+
+		*c1.FileSettings.AzureContainer = "mattermost"
+		require.Nil(t, c1.FileSettings.isValid())
+	})
+
+	t.Run("google cloud storage requires a bucket", func(t *testing.T) {
+		c1 := Config{}
+		c1.SetDefaults()
+		*c1.FileSettings.DriverName = ImageDriverGCS
+
+		appErr := c1.FileSettings.isValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.gcs_bucket.app_error", appErr.Id)
+
+		*c1.FileSettings.GCSBucket = "mattermost"
+		require.Nil(t, c1.FileSettings.isValid())
+
+		*c1.FileSettings.GCSRequestTimeoutMilliseconds = 0
+		appErr = c1.FileSettings.isValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.gcs_timeout.app_error", appErr.Id)
+	})
+}
+
+func TestConfigFileSettingsEncryptionAtRest(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(make([]byte, 32))
+
+	c1 := Config{}
+	c1.SetDefaults()
+	*c1.FileSettings.EnableEncryptionAtRest = true
+
+	appErr := c1.FileSettings.isValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.config.is_valid.encryption_at_rest_master_key.app_error", appErr.Id)
+
+	*c1.FileSettings.EncryptionAtRestMasterKey = base64.StdEncoding.EncodeToString(make([]byte, 16))
+	appErr = c1.FileSettings.isValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.config.is_valid.encryption_at_rest_master_key.app_error", appErr.Id)
+
+	*c1.FileSettings.EncryptionAtRestMasterKey = key
+	require.Nil(t, c1.FileSettings.isValid())
+
+	c1.FileSettings.EncryptionAtRestRetiredKeys = []string{key, "not a key"}
+	appErr = c1.FileSettings.isValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.config.is_valid.encryption_at_rest_retired_keys.app_error", appErr.Id)
+}
+
+func TestConfigFileSettingsAntivirusScan(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	*c1.FileSettings.EnableAntivirusScan = true
+	require.Nil(t, c1.FileSettings.isValid())
+
+	for _, valid := range []string{"tcp://clamav:3310", "unix:///var/run/clamav/clamd.ctl", "icap://icap.example.com:1344/avscan"} {
+		*c1.FileSettings.AntivirusScanURL = valid
+		require.Nil(t, c1.FileSettings.isValid(), valid)
+	}
+
+	for _, invalid := range []string{"", "clamav:3310", "http://clamav", "tcp://", "unix://"} {
+		*c1.FileSettings.AntivirusScanURL = invalid
+		appErr := c1.FileSettings.isValid()
+		require.NotNil(t, appErr, invalid)
+		require.Equal(t, "model.config.is_valid.antivirus_scan_url.app_error", appErr.Id)
+	}
+
+	*c1.FileSettings.AntivirusScanURL = "tcp://clamav:3310"
+	*c1.FileSettings.AntivirusScanTimeoutSeconds = 0
+	appErr := c1.FileSettings.isValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.config.is_valid.antivirus_scan_timeout.app_error", appErr.Id)
+}
+
+func TestConfigSqlSettingsDatabaseSearch(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+	require.Equal(t, DatabaseSearchRankingRecency, *c1.SqlSettings.DatabaseSearchRanking)
+	require.Nil(t, c1.SqlSettings.isValid())
+
+	*c1.SqlSettings.DatabaseSearchRanking = DatabaseSearchRankingRelevance
+	require.Nil(t, c1.SqlSettings.isValid())
+
+	*c1.SqlSettings.DatabaseSearchRanking = "score"
+	appErr := c1.SqlSettings.isValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.config.is_valid.sql_database_search_ranking.app_error", appErr.Id)
+
+	*c1.SqlSettings.DatabaseSearchRanking = DatabaseSearchRankingRecency
+	for _, valid := range []string{"german", "pg_catalog.french", "simple_unaccent"} {
+		c1.SqlSettings.TextSearchConfigs = map[string]string{NewId(): valid}
+		require.Nil(t, c1.SqlSettings.isValid(), valid)
+	}
+
+	for id, invalid := range map[string]string{NewId(): "german'; DROP TABLE Posts; --", NewId(): "", "not-an-id": "german"} {
+		c1.SqlSettings.TextSearchConfigs = map[string]string{id: invalid}
+		appErr := c1.SqlSettings.isValid()
+		require.NotNil(t, appErr, invalid)
+		require.Equal(t, "model.config.is_valid.sql_text_search_configs.app_error", appErr.Id)
+	}
+}
+
 func TestConfigDefaultSignatureAlgorithm(t *testing.T) {
 	c1 := Config{}
 	c1.SetDefaults()
@@ -1376,6 +1604,10 @@ func TestConfigSanitize(t *testing.T) {
 
 	*c.LdapSettings.BindPassword = "foo"
 	*c.FileSettings.AmazonS3SecretAccessKey = "bar"
+	*c.FileSettings.AzureAccessKey = "bar"
+	*c.FileSettings.GCSCredentialsJSON = "{}"
+	*c.FileSettings.EncryptionAtRestMasterKey = "key"
+	c.FileSettings.EncryptionAtRestRetiredKeys = []string{"retired"}
 	*c.EmailSettings.SMTPPassword = "baz"
 	*c.GitLabSettings.Secret = "bingo"
 	*c.OpenIdSettings.Secret = "secret"
@@ -1392,6 +1624,10 @@ func TestConfigSanitize(t *testing.T) {
 	assert.Equal(t, FakeSetting, *c.LdapSettings.BindPassword)
 	assert.Equal(t, FakeSetting, *c.FileSettings.PublicLinkSalt)
 	assert.Equal(t, FakeSetting, *c.FileSettings.AmazonS3SecretAccessKey)
+	assert.Equal(t, FakeSetting, *c.FileSettings.AzureAccessKey)
+	assert.Equal(t, FakeSetting, *c.FileSettings.GCSCredentialsJSON)
+	assert.Equal(t, FakeSetting, *c.FileSettings.EncryptionAtRestMasterKey)
+	assert.Equal(t, FakeSetting, c.FileSettings.EncryptionAtRestRetiredKeys[0])
 	assert.Equal(t, FakeSetting, *c.EmailSettings.SMTPPassword)
 	assert.Equal(t, FakeSetting, *c.GitLabSettings.Secret)
 	assert.Equal(t, FakeSetting, *c.OpenIdSettings.Secret)
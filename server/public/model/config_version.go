@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+const (
+	// ConfigChangeSourceSystemConsole changes are saved from the System Console.
+	ConfigChangeSourceSystemConsole = "system_console"
+	// ConfigChangeSourceMmctl changes are saved by mmctl in local mode. Changes
+	// made by mmctl through the API can't be told apart from the ones of other
+	// clients.
+	ConfigChangeSourceMmctl = "mmctl"
+	// ConfigChangeSourceAPI changes are saved by any other API client.
+	ConfigChangeSourceAPI = "api"
+	// ConfigChangeSourceEnvironment changes are written back by the server
+	// while loading its configuration, e.g. to fill in new defaults or the
+	// custom defaults provided through the environment.
+	ConfigChangeSourceEnvironment = "environment"
+	// ConfigChangeSourceServer changes are made by the server itself, e.g. by
+	// migrations or plugins.
+	ConfigChangeSourceServer = "server"
+)
+
+// ConfigChangeAuthor identifies who saved a version of the configuration.
+type ConfigChangeAuthor struct {
+	UserId    string `json:"user_id"`
+	SessionId string `json:"session_id"`
+	Source    string `json:"source"`
+}
+
+// ConfigVersion describes a version of the configuration kept by a database
+// backed configuration store.
+type ConfigVersion struct {
+	Id        string `json:"id"`
+	CreateAt  int64  `json:"create_at"`
+	Active    bool   `json:"active"`
+	UserId    string `json:"user_id"`
+	SessionId string `json:"session_id"`
+	Source    string `json:"source"`
+}
+
+func (v *ConfigVersion) Auditable() map[string]any {
+	return map[string]any{
+		"id":         v.Id,
+		"create_at":  v.CreateAt,
+		"active":     v.Active,
+		"user_id":    v.UserId,
+		"session_id": v.SessionId,
+		"source":     v.Source,
+	}
+}
+
+// ConfigVersionChange is a setting that differs between two versions of the
+// configuration. Sensitive values are masked.
+type ConfigVersionChange struct {
+	Path      string `json:"path"`
+	BaseVal   any    `json:"base_val"`
+	ActualVal any    `json:"actual_val"`
+}
+
+// ConfigVersionDiff lists the settings changed from the Base version of the
+// configuration to the Actual one.
+type ConfigVersionDiff struct {
+	Base    *ConfigVersion         `json:"base"`
+	Actual  *ConfigVersion         `json:"actual"`
+	Changes []*ConfigVersionChange `json:"changes"`
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"regexp"
+)
+
+const (
+	// DLPActionBlock rejects the message or the file.
+	DLPActionBlock = "block"
+	// DLPActionRedact masks the matching text of the message. Files can't
+	// be redacted, so they are blocked instead.
+	DLPActionRedact = "redact"
+	// DLPActionWarn lets the message through and warns its author with an
+	// ephemeral message.
+	DLPActionWarn = "warn"
+	// DLPActionNotify lets the message through and posts a notice in the
+	// compliance channel of the rule.
+	DLPActionNotify = "notify"
+
+	// DLPValidatorLuhn only keeps the pattern matches whose digits pass the
+	// Luhn checksum, used by credit card numbers.
+	DLPValidatorLuhn = "luhn"
+
+	// DLPPresetCreditCard matches credit card numbers.
+	DLPPresetCreditCard = "credit_card"
+	// DLPPresetAPIKey matches the API keys and tokens of well known
+	// services.
+	DLPPresetAPIKey = "api_key"
+
+	DLPRuleNameMaxRunes = 64
+)
+
+// DLPRule is a data loss prevention rule evaluated against the messages
+// and the extracted content of the files posted on the server.
+type DLPRule struct {
+	Name string
+	// Preset selects one of the built-in patterns, with its validator.
+	Preset string
+	// Pattern is a regular expression in the RE2 syntax.
+	Pattern string
+	// Validator filters the matches of Pattern.
+	Validator string
+	// Keywords are matched as whole words, ignoring case.
+	Keywords []string
+	// TeamIds and ChannelIds restrict the rule to some teams or channels.
+	// The rule applies everywhere when both are empty.
+	TeamIds    []string
+	ChannelIds []string
+	Action     string
+	// ComplianceChannelId is the channel notified of the hits of a rule
+	// with the notify action.
+	ComplianceChannelId string
+}
+
+// AppliesTo returns true if the rule is in effect in the channel.
+func (r *DLPRule) AppliesTo(teamID, channelID string) bool {
+	if len(r.TeamIds) == 0 && len(r.ChannelIds) == 0 {
+		return true
+	}
+
+	for _, id := range r.TeamIds {
+		if id == teamID {
+			return true
+		}
+	}
+	for _, id := range r.ChannelIds {
+		if id == channelID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *DLPRule) isValid() *AppError {
+	if r.Name == "" || len([]rune(r.Name)) > DLPRuleNameMaxRunes {
+		return NewAppError("Config.IsValid", "model.config.is_valid.dlp_rule_name.app_error", map[string]any{"MaxLength": DLPRuleNameMaxRunes}, "", http.StatusBadRequest)
+	}
+
+	if r.Preset == "" && r.Pattern == "" && len(r.Keywords) == 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.dlp_rule_matcher.app_error", map[string]any{"Name": r.Name}, "", http.StatusBadRequest)
+	}
+
+	switch r.Preset {
+	case "", DLPPresetCreditCard, DLPPresetAPIKey:
+	default:
+		return NewAppError("Config.IsValid", "model.config.is_valid.dlp_rule_preset.app_error", map[string]any{"Name": r.Name, "Preset": r.Preset}, "", http.StatusBadRequest)
+	}
+
+	if r.Pattern != "" {
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return NewAppError("Config.IsValid", "model.config.is_valid.dlp_rule_pattern.app_error", map[string]any{"Name": r.Name}, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
+	switch r.Validator {
+	case "", DLPValidatorLuhn:
+	default:
+		return NewAppError("Config.IsValid", "model.config.is_valid.dlp_rule_validator.app_error", map[string]any{"Name": r.Name, "Validator": r.Validator}, "", http.StatusBadRequest)
+	}
+
+	for _, keyword := range r.Keywords {
+		if keyword == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.dlp_rule_matcher.app_error", map[string]any{"Name": r.Name}, "", http.StatusBadRequest)
+		}
+	}
+
+	for _, id := range append(append([]string{}, r.TeamIds...), r.ChannelIds...) {
+		if !IsValidId(id) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.dlp_rule_scope.app_error", map[string]any{"Name": r.Name}, "", http.StatusBadRequest)
+		}
+	}
+
+	switch r.Action {
+	case DLPActionBlock, DLPActionRedact, DLPActionWarn:
+	case DLPActionNotify:
+		if !IsValidId(r.ComplianceChannelId) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.dlp_rule_compliance_channel.app_error", map[string]any{"Name": r.Name}, "", http.StatusBadRequest)
+		}
+	default:
+		return NewAppError("Config.IsValid", "model.config.is_valid.dlp_rule_action.app_error", map[string]any{"Name": r.Name, "Action": r.Action}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+type DataLossPreventionSettings struct {
+	Enable *bool      `access:"compliance_compliance_monitoring"`
+	Rules  []*DLPRule `access:"compliance_compliance_monitoring"` // telemetry: none
+}
+
+func (s *DataLossPreventionSettings) SetDefaults() {
+	if s.Enable == nil {
+		s.Enable = NewPointer(false)
+	}
+
+	if s.Rules == nil {
+		s.Rules = []*DLPRule{}
+	}
+}
+
+func (s *DataLossPreventionSettings) isValid() *AppError {
+	names := make(map[string]bool, len(s.Rules))
+	for _, rule := range s.Rules {
+		if rule == nil {
+			return NewAppError("Config.IsValid", "model.config.is_valid.dlp_rule_name.app_error", map[string]any{"MaxLength": DLPRuleNameMaxRunes}, "", http.StatusBadRequest)
+		}
+		if appErr := rule.isValid(); appErr != nil {
+			return appErr
+		}
+		if names[rule.Name] {
+			return NewAppError("Config.IsValid", "model.config.is_valid.dlp_rule_duplicate_name.app_error", map[string]any{"Name": rule.Name}, "", http.StatusBadRequest)
+		}
+		names[rule.Name] = true
+	}
+
+	return nil
+}
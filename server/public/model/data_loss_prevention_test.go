@@ -0,0 +1,88 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDLPRuleAppliesTo(t *testing.T) {
+	teamID := NewId()
+	channelID := NewId()
+
+	rule := &DLPRule{}
+	assert.True(t, rule.AppliesTo(teamID, channelID))
+	assert.True(t, rule.AppliesTo("", channelID))
+
+	rule.TeamIds = []string{teamID}
+	assert.True(t, rule.AppliesTo(teamID, NewId()))
+	assert.False(t, rule.AppliesTo(NewId(), channelID))
+
+	rule.ChannelIds = []string{channelID}
+	assert.True(t, rule.AppliesTo(NewId(), channelID))
+	assert.True(t, rule.AppliesTo("", channelID))
+	assert.False(t, rule.AppliesTo("", NewId()))
+}
+
+func TestDataLossPreventionSettingsIsValid(t *testing.T) {
+	validRule := func() *DLPRule {
+		return &DLPRule{
+			Name:    "Credit cards",
+			Preset:  DLPPresetCreditCard,
+			TeamIds: []string{NewId()},
+			Action:  DLPActionBlock,
+		}
+	}
+
+	s := DataLossPreventionSettings{}
+	s.SetDefaults()
+	require.Nil(t, s.isValid())
+
+	s.Rules = []*DLPRule{validRule()}
+	require.Nil(t, s.isValid())
+
+	for name, tc := range map[string]struct {
+		update func(r *DLPRule)
+		errID  string
+	}{
+		"missing name":           {func(r *DLPRule) { r.Name = "" }, "model.config.is_valid.dlp_rule_name.app_error"},
+		"name too long":          {func(r *DLPRule) { r.Name = strings.Repeat("a", DLPRuleNameMaxRunes+1) }, "model.config.is_valid.dlp_rule_name.app_error"},
+		"no matcher":             {func(r *DLPRule) { r.Preset = "" }, "model.config.is_valid.dlp_rule_matcher.app_error"},
+		"empty keyword":          {func(r *DLPRule) { r.Keywords = []string{"secret", ""} }, "model.config.is_valid.dlp_rule_matcher.app_error"},
+		"unknown preset":         {func(r *DLPRule) { r.Preset = "ssn" }, "model.config.is_valid.dlp_rule_preset.app_error"},
+		"invalid pattern":        {func(r *DLPRule) { r.Pattern = "(unclosed" }, "model.config.is_valid.dlp_rule_pattern.app_error"},
+		"unknown validator":      {func(r *DLPRule) { r.Validator = "iban" }, "model.config.is_valid.dlp_rule_validator.app_error"},
+		"invalid scope":          {func(r *DLPRule) { r.ChannelIds = []string{"town-square"} }, "model.config.is_valid.dlp_rule_scope.app_error"},
+		"unknown action":         {func(r *DLPRule) { r.Action = "delete" }, "model.config.is_valid.dlp_rule_action.app_error"},
+		"notify without channel": {func(r *DLPRule) { r.Action = DLPActionNotify }, "model.config.is_valid.dlp_rule_compliance_channel.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rule := validRule()
+			tc.update(rule)
+			s.Rules = []*DLPRule{rule}
+			appErr := s.isValid()
+			require.NotNil(t, appErr)
+			assert.Equal(t, tc.errID, appErr.Id)
+		})
+	}
+
+	t.Run("duplicate names", func(t *testing.T) {
+		s.Rules = []*DLPRule{validRule(), validRule()}
+		appErr := s.isValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.config.is_valid.dlp_rule_duplicate_name.app_error", appErr.Id)
+	})
+
+	t.Run("notify with channel", func(t *testing.T) {
+		rule := validRule()
+		rule.Action = DLPActionNotify
+		rule.ComplianceChannelId = NewId()
+		s.Rules = []*DLPRule{rule}
+		require.Nil(t, s.isValid())
+	})
+}
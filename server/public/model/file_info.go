@@ -15,6 +15,17 @@ const (
 	FileinfoSortBySize    = "Size"
 )
 
+const (
+	// FileScanStatusPending is set on files uploaded while antivirus scanning
+	// is enabled, until the scanner reports a verdict.
+	FileScanStatusPending  = "pending"
+	FileScanStatusClean    = "clean"
+	FileScanStatusInfected = "infected"
+	// FileScanStatusFailed is set when the scanner could not be reached or
+	// returned an error. Such files stay quarantined until they are rescanned.
+	FileScanStatusFailed = "failed"
+)
+
 // GetFileInfosOptions contains options for getting FileInfos
 type GetFileInfosOptions struct {
 	// UserIds optionally limits the FileInfos to those created by the given users.
@@ -57,6 +68,9 @@ type FileInfo struct {
 	Content         string  `json:"-"`
 	RemoteId        *string `json:"remote_id"`
 	Archived        bool    `json:"archived"`
+	// ScanStatus holds the result of the antivirus scan of the file. It is
+	// empty for files uploaded while scanning was disabled.
+	ScanStatus string `json:"scan_status,omitempty"`
 }
 
 func (fi *FileInfo) Auditable() map[string]interface{} {
@@ -124,6 +138,16 @@ func (fi *FileInfo) IsImage() bool {
 	return strings.HasPrefix(fi.MimeType, "image")
 }
 
+// IsQuarantined returns true if the contents of the file must not be served
+// because the antivirus scan has not reported it clean.
+func (fi *FileInfo) IsQuarantined() bool {
+	switch fi.ScanStatus {
+	case FileScanStatusPending, FileScanStatusInfected, FileScanStatusFailed:
+		return true
+	}
+	return false
+}
+
 func (fi *FileInfo) IsSvg() bool {
 	return fi.MimeType == "image/svg+xml"
 }
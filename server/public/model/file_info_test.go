@@ -74,3 +74,16 @@ func TestFileInfoIsImage(t *testing.T) {
 		assert.False(t, info.IsImage(), "Text file should not be considered as an image")
 	})
 }
+
+func TestFileInfoIsQuarantined(t *testing.T) {
+	for status, quarantined := range map[string]bool{
+		"":                     false,
+		FileScanStatusClean:    false,
+		FileScanStatusPending:  true,
+		FileScanStatusInfected: true,
+		FileScanStatusFailed:   true,
+	} {
+		info := &FileInfo{ScanStatus: status}
+		assert.Equal(t, quarantined, info.IsQuarantined(), status)
+	}
+}
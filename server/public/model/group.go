@@ -11,6 +11,7 @@ import (
 const (
 	GroupSourceLdap   GroupSource = "ldap"
 	GroupSourceCustom GroupSource = "custom"
+	GroupSourceScim   GroupSource = "scim"
 
 	GroupNameMaxLength        = 64
 	GroupSourceMaxLength      = 64
@@ -24,6 +25,7 @@ type GroupSource string
 var allGroupSources = []GroupSource{
 	GroupSourceLdap,
 	GroupSourceCustom,
+	GroupSourceScim,
 }
 
 var groupSourcesRequiringRemoteID = []GroupSource{
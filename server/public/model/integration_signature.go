@@ -0,0 +1,153 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outgoing webhooks and custom slash commands with a signing secret sign
+// every request they send. The signature is an HMAC-SHA256, keyed with the
+// signing secret, of "v1:<timestamp>:<content>", where the timestamp is the
+// value of the HeaderIntegrationTimestamp header and the content is the raw
+// request body, or the raw query string for GET requests.
+//
+// While a signing secret is being rotated, requests carry one signature per
+// valid secret, separated by commas.
+const (
+	HeaderIntegrationSignature = "X-Mattermost-Signature"
+	HeaderIntegrationTimestamp = "X-Mattermost-Request-Timestamp"
+
+	IntegrationSignatureVersion = "v1"
+
+	// IntegrationSignatureDefaultTolerance is the recommended maximum age of
+	// a signed request, used to reject replayed requests.
+	IntegrationSignatureDefaultTolerance = 5 * time.Minute
+
+	IntegrationSigningSecretMinLength = 32
+	IntegrationSigningSecretMaxLength = 128
+)
+
+var (
+	ErrIntegrationSignatureMissing  = errors.New("the request is not signed")
+	ErrIntegrationSignatureExpired  = errors.New("the request timestamp is outside of the tolerance")
+	ErrIntegrationSignatureMismatch = errors.New("the request signature does not match")
+)
+
+// NewIntegrationSigningSecret generates a random signing secret.
+func NewIntegrationSigningSecret() string {
+	return NewRandomString(IntegrationSigningSecretMinLength + 8)
+}
+
+// ComputeIntegrationSignature returns the signature of the content sent at
+// the given unix timestamp, in the "v1=<hex>" format used by the signature
+// header.
+func ComputeIntegrationSignature(secret string, timestamp int64, content []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(IntegrationSignatureVersion + ":" + strconv.FormatInt(timestamp, 10) + ":"))
+	mac.Write(content)
+
+	return IntegrationSignatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignIntegrationRequest sets the timestamp and signature headers of a
+// request, with one signature per secret. Nothing is set if there are no
+// secrets.
+func SignIntegrationRequest(header http.Header, content []byte, secrets []string) {
+	if len(secrets) == 0 {
+		return
+	}
+
+	timestamp := time.Now().Unix()
+	signatures := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		signatures = append(signatures, ComputeIntegrationSignature(secret, timestamp, content))
+	}
+
+	header.Set(HeaderIntegrationTimestamp, strconv.FormatInt(timestamp, 10))
+	header.Set(HeaderIntegrationSignature, strings.Join(signatures, ","))
+}
+
+// VerifyIntegrationSignature checks that the headers of a request carry a
+// valid signature of the content for the given secret, and that the request
+// is not older than the tolerance. A tolerance of zero disables the check of
+// the timestamp.
+func VerifyIntegrationSignature(secret string, header http.Header, content []byte, tolerance time.Duration) error {
+	timestampHeader := header.Get(HeaderIntegrationTimestamp)
+	signatureHeader := header.Get(HeaderIntegrationSignature)
+	if timestampHeader == "" || signatureHeader == "" {
+		return ErrIntegrationSignatureMissing
+	}
+
+	timestamp, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return ErrIntegrationSignatureMismatch
+	}
+
+	if tolerance > 0 {
+		age := time.Since(time.Unix(timestamp, 0))
+		if age > tolerance || age < -tolerance {
+			return ErrIntegrationSignatureExpired
+		}
+	}
+
+	expected := []byte(ComputeIntegrationSignature(secret, timestamp, content))
+	for _, signature := range strings.Split(signatureHeader, ",") {
+		if hmac.Equal(expected, []byte(strings.TrimSpace(signature))) {
+			return nil
+		}
+	}
+
+	return ErrIntegrationSignatureMismatch
+}
+
+// VerifyIntegrationRequest checks the signature of a request received from an
+// outgoing webhook or a slash command. The body of the request is read and
+// replaced, so that it can still be read after a successful verification.
+func VerifyIntegrationRequest(r *http.Request, secret string, tolerance time.Duration) error {
+	if r.Method == http.MethodGet {
+		return VerifyIntegrationSignature(secret, r.Header, []byte(r.URL.RawQuery), tolerance)
+	}
+
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return err
+		}
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	return VerifyIntegrationSignature(secret, r.Header, body, tolerance)
+}
+
+// integrationSigningSecrets returns the secrets that requests have to be
+// signed with: the current one and, during its grace period, the previous one.
+func integrationSigningSecrets(secret, previousSecret string, previousExpireAt int64) []string {
+	if secret == "" {
+		return nil
+	}
+
+	secrets := []string{secret}
+	if previousSecret != "" && previousExpireAt > GetMillis() {
+		secrets = append(secrets, previousSecret)
+	}
+
+	return secrets
+}
+
+func isValidIntegrationSigningSecret(secret string) bool {
+	return secret == "" || (len(secret) >= IntegrationSigningSecretMinLength && len(secret) <= IntegrationSigningSecretMaxLength)
+}
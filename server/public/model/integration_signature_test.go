@@ -0,0 +1,99 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeIntegrationSignature(t *testing.T) {
+	// Expected value computed independently with:
+	// printf 'v1:1700000000:{"text":"hello"}' | openssl dgst -sha256 -hmac secret
+	signature := ComputeIntegrationSignature("secret", 1700000000, []byte(`{"text":"hello"}`))
+	assert.Equal(t, "v1=26dc59974268c60925e1b720e9f40edbd5e62eec1b41d0f69b56170848e3e827", signature)
+
+	assert.NotEqual(t, signature, ComputeIntegrationSignature("other", 1700000000, []byte(`{"text":"hello"}`)))
+	assert.NotEqual(t, signature, ComputeIntegrationSignature("secret", 1700000001, []byte(`{"text":"hello"}`)))
+	assert.NotEqual(t, signature, ComputeIntegrationSignature("secret", 1700000000, []byte(`{"text":"hello!"}`)))
+}
+
+func TestVerifyIntegrationSignature(t *testing.T) {
+	content := []byte("token=abc&text=hello")
+
+	t.Run("valid signature", func(t *testing.T) {
+		header := http.Header{}
+		SignIntegrationRequest(header, content, []string{"secret"})
+		require.NoError(t, VerifyIntegrationSignature("secret", header, content, IntegrationSignatureDefaultTolerance))
+	})
+
+	t.Run("any of the signatures is accepted", func(t *testing.T) {
+		header := http.Header{}
+		SignIntegrationRequest(header, content, []string{"new", "old"})
+		assert.Len(t, strings.Split(header.Get(HeaderIntegrationSignature), ","), 2)
+		require.NoError(t, VerifyIntegrationSignature("new", header, content, IntegrationSignatureDefaultTolerance))
+		require.NoError(t, VerifyIntegrationSignature("old", header, content, IntegrationSignatureDefaultTolerance))
+		require.ErrorIs(t, VerifyIntegrationSignature("other", header, content, IntegrationSignatureDefaultTolerance), ErrIntegrationSignatureMismatch)
+	})
+
+	t.Run("no secrets", func(t *testing.T) {
+		header := http.Header{}
+		SignIntegrationRequest(header, content, nil)
+		assert.Empty(t, header.Get(HeaderIntegrationSignature))
+		require.ErrorIs(t, VerifyIntegrationSignature("secret", header, content, IntegrationSignatureDefaultTolerance), ErrIntegrationSignatureMissing)
+	})
+
+	t.Run("tampered content", func(t *testing.T) {
+		header := http.Header{}
+		SignIntegrationRequest(header, content, []string{"secret"})
+		require.ErrorIs(t, VerifyIntegrationSignature("secret", header, []byte("token=abc&text=bye"), IntegrationSignatureDefaultTolerance), ErrIntegrationSignatureMismatch)
+	})
+
+	t.Run("old timestamp", func(t *testing.T) {
+		timestamp := time.Now().Add(-10 * time.Minute).Unix()
+		header := http.Header{}
+		header.Set(HeaderIntegrationTimestamp, strconv.FormatInt(timestamp, 10))
+		header.Set(HeaderIntegrationSignature, ComputeIntegrationSignature("secret", timestamp, content))
+
+		require.ErrorIs(t, VerifyIntegrationSignature("secret", header, content, IntegrationSignatureDefaultTolerance), ErrIntegrationSignatureExpired)
+		require.NoError(t, VerifyIntegrationSignature("secret", header, content, 0))
+	})
+
+	t.Run("invalid timestamp", func(t *testing.T) {
+		header := http.Header{}
+		SignIntegrationRequest(header, content, []string{"secret"})
+		header.Set(HeaderIntegrationTimestamp, "now")
+		require.ErrorIs(t, VerifyIntegrationSignature("secret", header, content, IntegrationSignatureDefaultTolerance), ErrIntegrationSignatureMismatch)
+	})
+}
+
+func TestVerifyIntegrationRequest(t *testing.T) {
+	t.Run("POST", func(t *testing.T) {
+		body := `{"text":"hello"}`
+		r := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(body))
+		SignIntegrationRequest(r.Header, []byte(body), []string{"secret"})
+
+		require.NoError(t, VerifyIntegrationRequest(r, "secret", IntegrationSignatureDefaultTolerance))
+
+		read, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(read), "the body should still be readable")
+	})
+
+	t.Run("GET", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/command?token=abc&text=hello", nil)
+		SignIntegrationRequest(r.Header, []byte(r.URL.RawQuery), []string{"secret"})
+
+		require.NoError(t, VerifyIntegrationRequest(r, "secret", IntegrationSignatureDefaultTolerance))
+		require.ErrorIs(t, VerifyIntegrationRequest(r, "other", IntegrationSignatureDefaultTolerance), ErrIntegrationSignatureMismatch)
+	})
+}
@@ -41,6 +41,13 @@ const (
 	JobTypeExportUsersToCSV              = "export_users_to_csv"
 	JobTypeDeleteDmsPreferencesMigration = "delete_dms_preferences_migration"
 	JobTypeMobileSessionMetadata         = "mobile_session_metadata"
+	JobTypeScheduledPosts                = "scheduled_posts"
+	JobTypeOutgoingWebhookDeliveries     = "outgoing_webhook_deliveries"
+	JobTypeReminders                     = "reminders"
+	JobTypeFileReencryption              = "file_reencryption"
+	JobTypeLegalHoldExport               = "legal_hold_export"
+	JobTypeAntivirusRescan               = "antivirus_rescan"
+	JobTypeSavedSearchAlerts             = "saved_search_alerts"
 
 	JobStatusPending         = "pending"
 	JobStatusInProgress      = "in_progress"
@@ -74,6 +81,13 @@ var AllJobTypes = [...]string{
 	JobTypeCleanupDesktopTokens,
 	JobTypeRefreshPostStats,
 	JobTypeMobileSessionMetadata,
+	JobTypeScheduledPosts,
+	JobTypeOutgoingWebhookDeliveries,
+	JobTypeReminders,
+	JobTypeFileReencryption,
+	JobTypeLegalHoldExport,
+	JobTypeAntivirusRescan,
+	JobTypeSavedSearchAlerts,
 }
 
 type Job struct {
@@ -0,0 +1,189 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	LegalHoldNameMaxRunes        = 64
+	LegalHoldDescriptionMaxRunes = 1024
+	LegalHoldMaxMembers          = 1000
+
+	LegalHoldExportJobDataHoldId     = "legal_hold_id"
+	LegalHoldExportJobDataExportType = "export_type"
+	LegalHoldExportJobDataExportDir  = "export_dir"
+	LegalHoldExportJobDataPostCount  = "post_count"
+)
+
+// LegalHold preserves the content of its custodians and channels, created
+// within its date range, from any deletion until the hold is released.
+type LegalHold struct {
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	UserIds     []string `json:"user_ids"`
+	ChannelIds  []string `json:"channel_ids"`
+	// StartsAt and EndsAt bound the creation time of the held content. An
+	// EndsAt of 0 holds all the content created after StartsAt.
+	StartsAt int64 `json:"starts_at"`
+	EndsAt   int64 `json:"ends_at"`
+	CreateAt int64 `json:"create_at"`
+	UpdateAt int64 `json:"update_at"`
+	// DeleteAt is the time at which the hold was released.
+	DeleteAt int64 `json:"delete_at"`
+}
+
+type LegalHoldPatch struct {
+	Name        *string   `json:"name"`
+	Description *string   `json:"description"`
+	UserIds     *[]string `json:"user_ids"`
+	ChannelIds  *[]string `json:"channel_ids"`
+	StartsAt    *int64    `json:"starts_at"`
+	EndsAt      *int64    `json:"ends_at"`
+}
+
+// LegalHoldExportRequest starts the export of the content held by a legal
+// hold in one of the compliance export formats.
+type LegalHoldExportRequest struct {
+	ExportType string `json:"export_type"`
+}
+
+func (h *LegalHold) Auditable() map[string]any {
+	return map[string]any{
+		"id":          h.Id,
+		"name":        h.Name,
+		"user_ids":    h.UserIds,
+		"channel_ids": h.ChannelIds,
+		"starts_at":   h.StartsAt,
+		"ends_at":     h.EndsAt,
+		"create_at":   h.CreateAt,
+		"update_at":   h.UpdateAt,
+		"delete_at":   h.DeleteAt,
+	}
+}
+
+func (h *LegalHold) IsValid() *AppError {
+	if !IsValidId(h.Id) {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if h.CreateAt == 0 {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.create_at.app_error", nil, "id="+h.Id, http.StatusBadRequest)
+	}
+
+	if h.UpdateAt == 0 {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.update_at.app_error", nil, "id="+h.Id, http.StatusBadRequest)
+	}
+
+	if h.Name == "" || utf8.RuneCountInString(h.Name) > LegalHoldNameMaxRunes {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.name.app_error", map[string]any{"MaxLength": LegalHoldNameMaxRunes}, "id="+h.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(h.Description) > LegalHoldDescriptionMaxRunes {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.description.app_error", map[string]any{"MaxLength": LegalHoldDescriptionMaxRunes}, "id="+h.Id, http.StatusBadRequest)
+	}
+
+	if len(h.UserIds) == 0 && len(h.ChannelIds) == 0 {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.no_members.app_error", nil, "id="+h.Id, http.StatusBadRequest)
+	}
+
+	if len(h.UserIds) > LegalHoldMaxMembers || len(h.ChannelIds) > LegalHoldMaxMembers {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.too_many_members.app_error", map[string]any{"Max": LegalHoldMaxMembers}, "id="+h.Id, http.StatusBadRequest)
+	}
+
+	for _, userID := range h.UserIds {
+		if !IsValidId(userID) {
+			return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.user_id.app_error", nil, "id="+h.Id, http.StatusBadRequest)
+		}
+	}
+
+	for _, channelID := range h.ChannelIds {
+		if !IsValidId(channelID) {
+			return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.channel_id.app_error", nil, "id="+h.Id, http.StatusBadRequest)
+		}
+	}
+
+	if h.StartsAt < 0 || h.EndsAt < 0 || (h.EndsAt != 0 && h.EndsAt < h.StartsAt) {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.date_range.app_error", nil, "id="+h.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (h *LegalHold) PreSave() {
+	if h.Id == "" {
+		h.Id = NewId()
+	}
+
+	if h.CreateAt == 0 {
+		h.CreateAt = GetMillis()
+	}
+	h.UpdateAt = h.CreateAt
+	h.DeleteAt = 0
+
+	h.normalize()
+}
+
+func (h *LegalHold) PreUpdate() {
+	h.UpdateAt = GetMillis()
+	h.normalize()
+}
+
+func (h *LegalHold) normalize() {
+	h.Name = strings.TrimSpace(h.Name)
+	h.Description = strings.TrimSpace(h.Description)
+
+	if h.UserIds == nil {
+		h.UserIds = []string{}
+	}
+	if h.ChannelIds == nil {
+		h.ChannelIds = []string{}
+	}
+	slices.Sort(h.UserIds)
+	h.UserIds = slices.Compact(h.UserIds)
+	slices.Sort(h.ChannelIds)
+	h.ChannelIds = slices.Compact(h.ChannelIds)
+}
+
+func (h *LegalHold) Patch(patch *LegalHoldPatch) {
+	if patch.Name != nil {
+		h.Name = *patch.Name
+	}
+
+	if patch.Description != nil {
+		h.Description = *patch.Description
+	}
+
+	if patch.UserIds != nil {
+		h.UserIds = *patch.UserIds
+	}
+
+	if patch.ChannelIds != nil {
+		h.ChannelIds = *patch.ChannelIds
+	}
+
+	if patch.StartsAt != nil {
+		h.StartsAt = *patch.StartsAt
+	}
+
+	if patch.EndsAt != nil {
+		h.EndsAt = *patch.EndsAt
+	}
+}
+
+// IsReleased reports whether the hold no longer preserves its content.
+func (h *LegalHold) IsReleased() bool {
+	return h.DeleteAt != 0
+}
+
+// Covers reports whether content created at the given time falls within the
+// date range of the hold.
+func (h *LegalHold) Covers(createAt int64) bool {
+	return createAt >= h.StartsAt && (h.EndsAt == 0 || createAt <= h.EndsAt)
+}
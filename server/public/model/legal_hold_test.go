@@ -0,0 +1,124 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newValidLegalHold() *LegalHold {
+	hold := &LegalHold{
+		Name:       "Litigation",
+		UserIds:    []string{NewId()},
+		ChannelIds: []string{NewId()},
+		StartsAt:   1000,
+		EndsAt:     2000,
+	}
+	hold.PreSave()
+
+	return hold
+}
+
+func TestLegalHoldIsValid(t *testing.T) {
+	t.Run("valid hold", func(t *testing.T) {
+		require.Nil(t, newValidLegalHold().IsValid())
+	})
+
+	t.Run("name", func(t *testing.T) {
+		hold := newValidLegalHold()
+		hold.Name = ""
+		appErr := hold.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.legal_hold.is_valid.name.app_error", appErr.Id)
+
+		hold.Name = strings.Repeat("a", LegalHoldNameMaxRunes+1)
+		require.NotNil(t, hold.IsValid())
+	})
+
+	t.Run("no members", func(t *testing.T) {
+		hold := newValidLegalHold()
+		hold.UserIds = []string{}
+		require.Nil(t, hold.IsValid())
+
+		hold.ChannelIds = []string{}
+		appErr := hold.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.legal_hold.is_valid.no_members.app_error", appErr.Id)
+	})
+
+	t.Run("invalid member ids", func(t *testing.T) {
+		hold := newValidLegalHold()
+		hold.UserIds = []string{"junk"}
+		require.NotNil(t, hold.IsValid())
+
+		hold = newValidLegalHold()
+		hold.ChannelIds = []string{"junk"}
+		require.NotNil(t, hold.IsValid())
+	})
+
+	t.Run("date range", func(t *testing.T) {
+		hold := newValidLegalHold()
+		hold.EndsAt = 0
+		require.Nil(t, hold.IsValid())
+
+		hold.EndsAt = hold.StartsAt - 1
+		appErr := hold.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.legal_hold.is_valid.date_range.app_error", appErr.Id)
+
+		hold.StartsAt = -1
+		hold.EndsAt = 0
+		require.NotNil(t, hold.IsValid())
+	})
+}
+
+func TestLegalHoldPreSave(t *testing.T) {
+	userID := NewId()
+	hold := &LegalHold{
+		Name:     " Litigation ",
+		UserIds:  []string{userID, userID},
+		DeleteAt: 1,
+	}
+	hold.PreSave()
+
+	assert.True(t, IsValidId(hold.Id))
+	assert.NotZero(t, hold.CreateAt)
+	assert.Equal(t, hold.CreateAt, hold.UpdateAt)
+	assert.Zero(t, hold.DeleteAt)
+	assert.Equal(t, "Litigation", hold.Name)
+	assert.Equal(t, []string{userID}, hold.UserIds)
+	assert.Equal(t, []string{}, hold.ChannelIds)
+}
+
+func TestLegalHoldPatch(t *testing.T) {
+	hold := newValidLegalHold()
+	channelIDs := hold.ChannelIds
+
+	hold.Patch(&LegalHoldPatch{
+		Name:    NewPointer("Investigation"),
+		UserIds: &[]string{},
+		EndsAt:  NewPointer(int64(0)),
+	})
+
+	assert.Equal(t, "Investigation", hold.Name)
+	assert.Empty(t, hold.UserIds)
+	assert.Equal(t, channelIDs, hold.ChannelIds)
+	assert.Equal(t, int64(1000), hold.StartsAt)
+	assert.Zero(t, hold.EndsAt)
+}
+
+func TestLegalHoldCovers(t *testing.T) {
+	hold := newValidLegalHold()
+	assert.False(t, hold.Covers(999))
+	assert.True(t, hold.Covers(1000))
+	assert.True(t, hold.Covers(2000))
+	assert.False(t, hold.Covers(2001))
+
+	hold.EndsAt = 0
+	assert.True(t, hold.Covers(1<<50))
+}
@@ -7,3 +7,13 @@ type MfaSecret struct {
 	Secret string `json:"secret"`
 	QRCode string `json:"qr_code"`
 }
+
+// MfaRecoveryCodes are one-time codes that replace the second factor of a
+// user. They are only returned when generated.
+type MfaRecoveryCodes struct {
+	Codes []string `json:"codes"`
+}
+
+type MfaRecoveryCodesStatus struct {
+	Remaining int64 `json:"remaining"`
+}
@@ -40,4 +40,6 @@ const (
 	NotificationReasonTooManyUsersInChannel              NotificationReason = "too_many_users_in_channel"
 	NotificationReasonResolvePersistentNotificationError NotificationReason = "resolve_persistent_notification_error"
 	NotificationReasonMissingThreadMembership            NotificationReason = "missing_thread_membership"
+	NotificationReasonWebPushSendError                   NotificationReason = "web_push_send_error"
+	NotificationReasonWebPushSubscriptionGone            NotificationReason = "web_push_subscription_gone"
 )
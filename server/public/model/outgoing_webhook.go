@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type OutgoingWebhook struct {
@@ -28,6 +29,11 @@ type OutgoingWebhook struct {
 	ContentType  string      `json:"content_type"`
 	Username     string      `json:"username"`
 	IconURL      string      `json:"icon_url"`
+	// SigningSecret is used to sign the requests sent to the callback URLs.
+	// Requests are not signed if it is empty.
+	SigningSecret                 string `json:"signing_secret"`
+	PreviousSigningSecret         string `json:"-"`
+	PreviousSigningSecretExpireAt int64  `json:"previous_signing_secret_expire_at"`
 }
 
 func (o *OutgoingWebhook) Auditable() map[string]interface{} {
@@ -171,6 +177,10 @@ func (o *OutgoingWebhook) IsValid() *AppError {
 		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.icon_url.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if !isValidIntegrationSigningSecret(o.SigningSecret) || !isValidIntegrationSigningSecret(o.PreviousSigningSecret) {
+		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.signing_secret.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -191,6 +201,32 @@ func (o *OutgoingWebhook) PreUpdate() {
 	o.UpdateAt = GetMillis()
 }
 
+// SigningSecrets returns the secrets that requests have to be signed with.
+func (o *OutgoingWebhook) SigningSecrets() []string {
+	return integrationSigningSecrets(o.SigningSecret, o.PreviousSigningSecret, o.PreviousSigningSecretExpireAt)
+}
+
+// RotateSigningSecret generates a new signing secret. The current secret, if
+// any, keeps being used to sign requests for the given grace period, so that
+// receivers can be updated without rejecting requests.
+func (o *OutgoingWebhook) RotateSigningSecret(gracePeriod time.Duration) {
+	o.PreviousSigningSecret = ""
+	o.PreviousSigningSecretExpireAt = 0
+	if o.SigningSecret != "" && gracePeriod > 0 {
+		o.PreviousSigningSecret = o.SigningSecret
+		o.PreviousSigningSecretExpireAt = GetMillis() + gracePeriod.Milliseconds()
+	}
+
+	o.SigningSecret = NewIntegrationSigningSecret()
+}
+
+// RemoveSigningSecret stops the signing of requests.
+func (o *OutgoingWebhook) RemoveSigningSecret() {
+	o.SigningSecret = ""
+	o.PreviousSigningSecret = ""
+	o.PreviousSigningSecretExpireAt = 0
+}
+
 func (o *OutgoingWebhook) TriggerWordExactMatch(word string) bool {
 	if word == "" {
 		return false
@@ -0,0 +1,166 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	OutgoingWebhookDeliveryStatusPending = "pending"
+	OutgoingWebhookDeliveryStatusSuccess = "success"
+	OutgoingWebhookDeliveryStatusFailed  = "failed"
+
+	OutgoingWebhookDeliveryResponseMaxRunes = 1024
+	OutgoingWebhookDeliveryErrorMaxRunes    = 1024
+	OutgoingWebhookDeliveryPayloadMaxRunes  = 65535
+)
+
+// OutgoingWebhookDelivery records the delivery of an outgoing webhook event
+// to one of the hook's callback URLs, together with the outcome of the last
+// attempt. Pending deliveries are retried with exponential backoff until
+// they succeed or run out of attempts, at which point they are marked as
+// failed and can be replayed manually.
+type OutgoingWebhookDelivery struct {
+	Id            string `json:"id"`
+	CreateAt      int64  `json:"create_at"`
+	UpdateAt      int64  `json:"update_at"`
+	HookId        string `json:"hook_id"`
+	ChannelId     string `json:"channel_id"`
+	PostId        string `json:"post_id"`
+	CallbackURL   string `json:"callback_url"`
+	ContentType   string `json:"content_type"`
+	Payload       string `json:"-"`
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
+	NextAttemptAt int64  `json:"next_attempt_at"`
+	LastAttemptAt int64  `json:"last_attempt_at"`
+	StatusCode    int    `json:"status_code"`
+	Latency       int64  `json:"latency"` // In milliseconds.
+	Response      string `json:"response"`
+	Error         string `json:"error"`
+}
+
+type OutgoingWebhookDeliveryGetOptions struct {
+	Status  string
+	Page    int
+	PerPage int
+}
+
+func (d *OutgoingWebhookDelivery) Auditable() map[string]any {
+	return map[string]any{
+		"id":              d.Id,
+		"create_at":       d.CreateAt,
+		"update_at":       d.UpdateAt,
+		"hook_id":         d.HookId,
+		"channel_id":      d.ChannelId,
+		"post_id":         d.PostId,
+		"status":          d.Status,
+		"attempts":        d.Attempts,
+		"next_attempt_at": d.NextAttemptAt,
+		"last_attempt_at": d.LastAttemptAt,
+		"status_code":     d.StatusCode,
+	}
+}
+
+func (d *OutgoingWebhookDelivery) IsValid() *AppError {
+	if !IsValidId(d.Id) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if d.CreateAt == 0 {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.create_at.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	if d.UpdateAt == 0 {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.update_at.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(d.HookId) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.hook_id.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(d.ChannelId) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.channel_id.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(d.PostId) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.post_id.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidHTTPURL(d.CallbackURL) || utf8.RuneCountInString(d.CallbackURL) > 1024 {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.callback_url.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(d.Payload) > OutgoingWebhookDeliveryPayloadMaxRunes {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.payload.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	switch d.Status {
+	case OutgoingWebhookDeliveryStatusPending, OutgoingWebhookDeliveryStatusSuccess, OutgoingWebhookDeliveryStatusFailed:
+	default:
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_webhook_delivery.is_valid.status.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (d *OutgoingWebhookDelivery) PreSave() {
+	if d.Id == "" {
+		d.Id = NewId()
+	}
+
+	if d.CreateAt == 0 {
+		d.CreateAt = GetMillis()
+	}
+	d.UpdateAt = d.CreateAt
+
+	if d.Status == "" {
+		d.Status = OutgoingWebhookDeliveryStatusPending
+	}
+	d.PreCommit()
+}
+
+func (d *OutgoingWebhookDelivery) PreUpdate() {
+	d.UpdateAt = GetMillis()
+	d.PreCommit()
+}
+
+// PreCommit truncates the response and error of the last attempt so they
+// fit in the delivery history.
+func (d *OutgoingWebhookDelivery) PreCommit() {
+	d.Response = truncateRunes(d.Response, OutgoingWebhookDeliveryResponseMaxRunes)
+	d.Error = truncateRunes(d.Error, OutgoingWebhookDeliveryErrorMaxRunes)
+}
+
+// IsSuccessStatusCode reports whether the given HTTP status code means the
+// receiver accepted the delivery.
+func (d *OutgoingWebhookDelivery) IsSuccessStatusCode() bool {
+	return d.StatusCode >= 200 && d.StatusCode < 300
+}
+
+// OutgoingWebhookRetryBackoff returns the delay in milliseconds before the
+// next attempt of a delivery that already failed the given number of times.
+// The delay doubles with every attempt, starting from initialSeconds and
+// capped to maxSeconds.
+func OutgoingWebhookRetryBackoff(attempts, initialSeconds, maxSeconds int) int64 {
+	backoff := int64(initialSeconds)
+	for i := 1; i < attempts && backoff < int64(maxSeconds); i++ {
+		backoff *= 2
+	}
+	if backoff > int64(maxSeconds) {
+		backoff = int64(maxSeconds)
+	}
+
+	return backoff * 1000
+}
+
+func truncateRunes(s string, maxRunes int) string {
+	if utf8.RuneCountInString(s) <= maxRunes {
+		return s
+	}
+
+	return string([]rune(s)[:maxRunes])
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutgoingWebhookDeliveryIsValid(t *testing.T) {
+	o := OutgoingWebhookDelivery{}
+
+	assert.NotNil(t, o.IsValid())
+
+	o.Id = NewId()
+	assert.NotNil(t, o.IsValid())
+
+	o.CreateAt = GetMillis()
+	assert.NotNil(t, o.IsValid())
+
+	o.UpdateAt = GetMillis()
+	assert.NotNil(t, o.IsValid())
+
+	o.HookId = NewId()
+	assert.NotNil(t, o.IsValid())
+
+	o.ChannelId = NewId()
+	assert.NotNil(t, o.IsValid())
+
+	o.PostId = NewId()
+	assert.NotNil(t, o.IsValid())
+
+	o.CallbackURL = "nowhere.com/"
+	assert.NotNil(t, o.IsValid())
+
+	o.CallbackURL = "http://nowhere.com/"
+	assert.NotNil(t, o.IsValid(), "status is required")
+
+	o.Status = "unknown"
+	assert.NotNil(t, o.IsValid())
+
+	o.Status = OutgoingWebhookDeliveryStatusPending
+	assert.Nil(t, o.IsValid())
+
+	o.Payload = strings.Repeat("0", OutgoingWebhookDeliveryPayloadMaxRunes+1)
+	assert.NotNil(t, o.IsValid())
+}
+
+func TestOutgoingWebhookDeliveryPreSave(t *testing.T) {
+	o := OutgoingWebhookDelivery{
+		Response: strings.Repeat("ü", OutgoingWebhookDeliveryResponseMaxRunes+10),
+		Error:    strings.Repeat("e", OutgoingWebhookDeliveryErrorMaxRunes+10),
+	}
+	o.PreSave()
+
+	assert.NotEmpty(t, o.Id)
+	assert.NotEqual(t, 0, o.CreateAt)
+	assert.Equal(t, o.CreateAt, o.UpdateAt)
+	assert.Equal(t, OutgoingWebhookDeliveryStatusPending, o.Status)
+	assert.Equal(t, strings.Repeat("ü", OutgoingWebhookDeliveryResponseMaxRunes), o.Response)
+	assert.Len(t, o.Error, OutgoingWebhookDeliveryErrorMaxRunes)
+}
+
+func TestOutgoingWebhookDeliveryIsSuccessStatusCode(t *testing.T) {
+	for statusCode, expected := range map[int]bool{
+		0:   false,
+		200: true,
+		204: true,
+		301: false,
+		404: false,
+		503: false,
+	} {
+		o := OutgoingWebhookDelivery{StatusCode: statusCode}
+		assert.Equal(t, expected, o.IsSuccessStatusCode(), statusCode)
+	}
+}
+
+func TestOutgoingWebhookRetryBackoff(t *testing.T) {
+	assert.Equal(t, int64(30*1000), OutgoingWebhookRetryBackoff(1, 30, 3600))
+	assert.Equal(t, int64(60*1000), OutgoingWebhookRetryBackoff(2, 30, 3600))
+	assert.Equal(t, int64(120*1000), OutgoingWebhookRetryBackoff(3, 30, 3600))
+	assert.Equal(t, int64(3600*1000), OutgoingWebhookRetryBackoff(10, 30, 3600))
+	assert.Equal(t, int64(3600*1000), OutgoingWebhookRetryBackoff(1000, 30, 3600))
+}
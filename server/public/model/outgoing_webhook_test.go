@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -83,6 +84,12 @@ func TestOutgoingWebhookIsValid(t *testing.T) {
 
 	o.IconURL = strings.Repeat("1", 1024)
 	assert.Nilf(t, o.IsValid(), "IconURL length %d should be valid", len(o.IconURL))
+
+	o.SigningSecret = "short"
+	assert.NotNil(t, o.IsValid(), "SigningSecret should be invalid")
+
+	o.SigningSecret = NewIntegrationSigningSecret()
+	assert.Nil(t, o.IsValid())
 }
 
 func TestOutgoingWebhookPayloadToFormValues(t *testing.T) {
@@ -128,6 +135,33 @@ func TestOutgoingWebhookPreUpdate(t *testing.T) {
 	o.PreUpdate()
 }
 
+func TestOutgoingWebhookRotateSigningSecret(t *testing.T) {
+	o := OutgoingWebhook{}
+	assert.Empty(t, o.SigningSecrets())
+
+	o.RotateSigningSecret(time.Hour)
+	first := o.SigningSecret
+	assert.Len(t, first, IntegrationSigningSecretMinLength+8)
+	assert.Empty(t, o.PreviousSigningSecret, "there is no previous secret to keep")
+	assert.Equal(t, []string{first}, o.SigningSecrets())
+
+	o.RotateSigningSecret(time.Hour)
+	assert.NotEqual(t, first, o.SigningSecret)
+	assert.Equal(t, first, o.PreviousSigningSecret)
+	assert.Greater(t, o.PreviousSigningSecretExpireAt, GetMillis())
+	assert.Equal(t, []string{o.SigningSecret, first}, o.SigningSecrets())
+
+	o.PreviousSigningSecretExpireAt = GetMillis() - 1
+	assert.Equal(t, []string{o.SigningSecret}, o.SigningSecrets(), "the previous secret should expire")
+
+	o.RotateSigningSecret(0)
+	assert.Empty(t, o.PreviousSigningSecret, "the previous secret should not be kept without a grace period")
+
+	o.RemoveSigningSecret()
+	assert.Empty(t, o.SigningSecret)
+	assert.Empty(t, o.SigningSecrets())
+}
+
 func TestOutgoingWebhookTriggerWordStartsWith(t *testing.T) {
 	o := OutgoingWebhook{Id: NewId()}
 	o.TriggerWords = append(o.TriggerWords, "foo")
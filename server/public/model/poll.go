@@ -0,0 +1,372 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	PollQuestionMaxRunes = 1024
+	PollOptionMaxRunes   = 256
+	PollMinOptions       = 2
+	PollMaxOptions       = 20
+
+	// PostPropsPoll is the key under which compliance exports include the
+	// poll held by a post in its props.
+	PostPropsPoll = "poll"
+)
+
+type PollOption struct {
+	Id   string `json:"id"`
+	Text string `json:"text"`
+}
+
+// PollOptions is stored in the database as a JSON array.
+type PollOptions []*PollOption
+
+func (o PollOptions) Value() (driver.Value, error) {
+	j, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(j), nil
+}
+
+func (o *PollOptions) Scan(value any) error {
+	if value == nil {
+		return nil
+	}
+
+	buf, ok := value.([]byte)
+	if ok {
+		return json.Unmarshal(buf, o)
+	}
+
+	str, ok := value.(string)
+	if ok {
+		return json.Unmarshal([]byte(str), o)
+	}
+
+	return errors.New("received value is neither a byte slice nor string")
+}
+
+// Poll is the content of a post of type PostTypePoll.
+type Poll struct {
+	Id             string      `json:"id"`
+	CreateAt       int64       `json:"create_at"`
+	UpdateAt       int64       `json:"update_at"`
+	DeleteAt       int64       `json:"delete_at"`
+	PostId         string      `json:"post_id"`
+	ChannelId      string      `json:"channel_id"`
+	UserId         string      `json:"user_id"`
+	Question       string      `json:"question"`
+	Options        PollOptions `json:"options"`
+	MultipleChoice bool        `json:"multiple_choice"`
+	Anonymous      bool        `json:"anonymous"`
+	// CloseAt is the time at which the poll stops accepting votes. Polls with
+	// a CloseAt of 0 stay open until they are closed.
+	CloseAt int64 `json:"close_at"`
+
+	// Results and TotalVoters are computed from the votes. The voters of each
+	// option are only listed for polls that are not anonymous.
+	Results     []*PollOptionResult `json:"results" db:"-"`
+	TotalVoters int                 `json:"total_voters" db:"-"`
+	// MyVotes lists the options voted for by the user the poll was fetched for.
+	MyVotes []string `json:"my_votes,omitempty" db:"-"`
+}
+
+type PollOptionResult struct {
+	OptionId string   `json:"option_id"`
+	Votes    int      `json:"votes"`
+	UserIds  []string `json:"user_ids,omitempty"`
+}
+
+type PollVote struct {
+	PollId   string `json:"poll_id"`
+	UserId   string `json:"user_id"`
+	OptionId string `json:"option_id"`
+	CreateAt int64  `json:"create_at"`
+}
+
+// CreatePollRequest is the payload used to create a poll along with the post
+// that holds it.
+type CreatePollRequest struct {
+	ChannelId      string   `json:"channel_id"`
+	RootId         string   `json:"root_id"`
+	Question       string   `json:"question"`
+	Options        []string `json:"options"`
+	MultipleChoice bool     `json:"multiple_choice"`
+	Anonymous      bool     `json:"anonymous"`
+	CloseAt        int64    `json:"close_at"`
+}
+
+// PollVoteRequest replaces the votes of a user. An empty list of options
+// retracts the votes.
+type PollVoteRequest struct {
+	OptionIds []string `json:"option_ids"`
+}
+
+func (r *CreatePollRequest) ToPoll() *Poll {
+	poll := &Poll{
+		ChannelId:      r.ChannelId,
+		Question:       r.Question,
+		MultipleChoice: r.MultipleChoice,
+		Anonymous:      r.Anonymous,
+		CloseAt:        r.CloseAt,
+	}
+
+	for _, text := range r.Options {
+		poll.Options = append(poll.Options, &PollOption{Text: text})
+	}
+
+	return poll
+}
+
+func (p *Poll) Auditable() map[string]any {
+	return map[string]any{
+		"id":              p.Id,
+		"create_at":       p.CreateAt,
+		"update_at":       p.UpdateAt,
+		"delete_at":       p.DeleteAt,
+		"post_id":         p.PostId,
+		"channel_id":      p.ChannelId,
+		"user_id":         p.UserId,
+		"multiple_choice": p.MultipleChoice,
+		"anonymous":       p.Anonymous,
+		"close_at":        p.CloseAt,
+	}
+}
+
+func (p *Poll) IsValid() *AppError {
+	if !IsValidId(p.Id) {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if p.CreateAt == 0 {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.create_at.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if p.UpdateAt == 0 {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.update_at.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(p.PostId) {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.post_id.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(p.ChannelId) {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.channel_id.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(p.UserId) {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.user_id.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if p.CloseAt < 0 {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.close_at.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	return p.IsValidContent()
+}
+
+// IsValidContent validates the fields of the poll provided by its creator.
+func (p *Poll) IsValidContent() *AppError {
+	question := strings.TrimSpace(p.Question)
+	if question == "" || utf8.RuneCountInString(question) > PollQuestionMaxRunes {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.question.app_error", map[string]any{"MaxLength": PollQuestionMaxRunes}, "", http.StatusBadRequest)
+	}
+
+	if len(p.Options) < PollMinOptions || len(p.Options) > PollMaxOptions {
+		return NewAppError("Poll.IsValid", "model.poll.is_valid.options.app_error", map[string]any{"Min": PollMinOptions, "Max": PollMaxOptions}, "", http.StatusBadRequest)
+	}
+
+	texts := make(map[string]bool, len(p.Options))
+	for _, option := range p.Options {
+		text := strings.TrimSpace(option.Text)
+		if text == "" || utf8.RuneCountInString(text) > PollOptionMaxRunes {
+			return NewAppError("Poll.IsValid", "model.poll.is_valid.option_text.app_error", map[string]any{"MaxLength": PollOptionMaxRunes}, "", http.StatusBadRequest)
+		}
+
+		if texts[strings.ToLower(text)] {
+			return NewAppError("Poll.IsValid", "model.poll.is_valid.option_duplicate.app_error", nil, "", http.StatusBadRequest)
+		}
+		texts[strings.ToLower(text)] = true
+	}
+
+	return nil
+}
+
+func (p *Poll) PreSave() {
+	if p.Id == "" {
+		p.Id = NewId()
+	}
+
+	if p.CreateAt == 0 {
+		p.CreateAt = GetMillis()
+	}
+	p.UpdateAt = p.CreateAt
+
+	p.Question = strings.TrimSpace(p.Question)
+	for _, option := range p.Options {
+		if option.Id == "" {
+			option.Id = NewId()
+		}
+		option.Text = strings.TrimSpace(option.Text)
+	}
+}
+
+func (p *Poll) PreUpdate() {
+	p.UpdateAt = GetMillis()
+}
+
+// IsClosed returns whether the poll no longer accepts votes.
+func (p *Poll) IsClosed() bool {
+	return p.CloseAt > 0 && p.CloseAt <= GetMillis()
+}
+
+func (p *Poll) GetOption(optionID string) *PollOption {
+	for _, option := range p.Options {
+		if option.Id == optionID {
+			return option
+		}
+	}
+
+	return nil
+}
+
+// GetOptionByText returns the option with the given text, ignoring case and
+// surrounding spaces like the check for duplicate options does.
+func (p *Poll) GetOptionByText(text string) *PollOption {
+	text = strings.TrimSpace(text)
+	for _, option := range p.Options {
+		if strings.EqualFold(strings.TrimSpace(option.Text), text) {
+			return option
+		}
+	}
+
+	return nil
+}
+
+// IsValidVote checks that the options exist and that their number is allowed
+// by the poll.
+func (p *Poll) IsValidVote(optionIDs []string) *AppError {
+	if len(optionIDs) > 1 && !p.MultipleChoice {
+		return NewAppError("Poll.IsValidVote", "model.poll.is_valid_vote.single_choice.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	seen := make(map[string]bool, len(optionIDs))
+	for _, optionID := range optionIDs {
+		if p.GetOption(optionID) == nil || seen[optionID] {
+			return NewAppError("Poll.IsValidVote", "model.poll.is_valid_vote.option.app_error", nil, "option_id="+optionID, http.StatusBadRequest)
+		}
+		seen[optionID] = true
+	}
+
+	return nil
+}
+
+// SetResults computes the results of the poll from its votes.
+func (p *Poll) SetResults(votes []*PollVote) {
+	p.Results = make([]*PollOptionResult, 0, len(p.Options))
+	resultsByOption := make(map[string]*PollOptionResult, len(p.Options))
+	for _, option := range p.Options {
+		result := &PollOptionResult{OptionId: option.Id}
+		p.Results = append(p.Results, result)
+		resultsByOption[option.Id] = result
+	}
+
+	voters := make(map[string]bool)
+	for _, vote := range votes {
+		result, ok := resultsByOption[vote.OptionId]
+		if !ok {
+			continue
+		}
+
+		result.Votes++
+		if !p.Anonymous {
+			result.UserIds = append(result.UserIds, vote.UserId)
+		}
+		voters[vote.UserId] = true
+	}
+	p.TotalVoters = len(voters)
+}
+
+// SetMyVotes records the options voted for by the given user.
+func (p *Poll) SetMyVotes(votes []*PollVote, userID string) {
+	p.MyVotes = []string{}
+	for _, vote := range votes {
+		if vote.UserId == userID {
+			p.MyVotes = append(p.MyVotes, vote.OptionId)
+		}
+	}
+}
+
+// ToPost returns the post that holds the poll.
+func (p *Poll) ToPost(rootID string) *Post {
+	return &Post{
+		ChannelId: p.ChannelId,
+		UserId:    p.UserId,
+		RootId:    rootID,
+		Message:   p.Question,
+		Type:      PostTypePoll,
+	}
+}
+
+func (p *Poll) Clone() *Poll {
+	pCopy := *p
+
+	pCopy.Options = make(PollOptions, 0, len(p.Options))
+	for _, option := range p.Options {
+		optionCopy := *option
+		pCopy.Options = append(pCopy.Options, &optionCopy)
+	}
+
+	if p.Results != nil {
+		pCopy.Results = make([]*PollOptionResult, 0, len(p.Results))
+		for _, result := range p.Results {
+			resultCopy := *result
+			resultCopy.UserIds = append([]string(nil), result.UserIds...)
+			pCopy.Results = append(pCopy.Results, &resultCopy)
+		}
+	}
+
+	if p.MyVotes != nil {
+		pCopy.MyVotes = append([]string{}, p.MyVotes...)
+	}
+
+	return &pCopy
+}
+
+// AddPollToPostProps returns the given JSON encoded post props with the poll
+// added under PostPropsPoll. The props are returned unchanged if they cannot
+// be decoded.
+func AddPollToPostProps(props string, poll *Poll) string {
+	propsMap := StringInterface{}
+	if props != "" {
+		if err := json.Unmarshal([]byte(props), &propsMap); err != nil {
+			return props
+		}
+	}
+	if propsMap == nil {
+		propsMap = StringInterface{}
+	}
+
+	exported := poll.Clone()
+	exported.MyVotes = nil
+	propsMap[PostPropsPoll] = exported
+
+	b, err := json.Marshal(propsMap)
+	if err != nil {
+		return props
+	}
+
+	return string(b)
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newValidPoll() *Poll {
+	poll := &Poll{
+		PostId:    NewId(),
+		ChannelId: NewId(),
+		UserId:    NewId(),
+		Question:  "Where should we eat?",
+		Options: PollOptions{
+			{Text: "Pizza"},
+			{Text: "Sushi"},
+		},
+	}
+	poll.PreSave()
+
+	return poll
+}
+
+func TestPollIsValid(t *testing.T) {
+	t.Run("valid poll", func(t *testing.T) {
+		require.Nil(t, newValidPoll().IsValid())
+	})
+
+	t.Run("invalid ids", func(t *testing.T) {
+		poll := newValidPoll()
+		poll.PostId = "junk"
+		require.NotNil(t, poll.IsValid())
+
+		poll = newValidPoll()
+		poll.ChannelId = ""
+		require.NotNil(t, poll.IsValid())
+	})
+
+	t.Run("empty question", func(t *testing.T) {
+		poll := newValidPoll()
+		poll.Question = "  "
+		appErr := poll.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.poll.is_valid.question.app_error", appErr.Id)
+	})
+
+	t.Run("question too long", func(t *testing.T) {
+		poll := newValidPoll()
+		poll.Question = strings.Repeat("a", PollQuestionMaxRunes+1)
+		require.NotNil(t, poll.IsValid())
+	})
+
+	t.Run("not enough options", func(t *testing.T) {
+		poll := newValidPoll()
+		poll.Options = poll.Options[:1]
+		appErr := poll.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.poll.is_valid.options.app_error", appErr.Id)
+	})
+
+	t.Run("too many options", func(t *testing.T) {
+		poll := newValidPoll()
+		for i := 0; i < PollMaxOptions; i++ {
+			poll.Options = append(poll.Options, &PollOption{Id: NewId(), Text: NewId()})
+		}
+		require.NotNil(t, poll.IsValid())
+	})
+
+	t.Run("empty option", func(t *testing.T) {
+		poll := newValidPoll()
+		poll.Options[1].Text = ""
+		appErr := poll.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.poll.is_valid.option_text.app_error", appErr.Id)
+	})
+
+	t.Run("duplicate options", func(t *testing.T) {
+		poll := newValidPoll()
+		poll.Options[1].Text = "PIZZA "
+		appErr := poll.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.poll.is_valid.option_duplicate.app_error", appErr.Id)
+	})
+
+	t.Run("negative close at", func(t *testing.T) {
+		poll := newValidPoll()
+		poll.CloseAt = -1
+		require.NotNil(t, poll.IsValid())
+	})
+}
+
+func TestPollPreSave(t *testing.T) {
+	poll := &Poll{
+		Question: " Lunch? ",
+		Options:  PollOptions{{Text: " Yes"}, {Text: "No "}},
+	}
+	poll.PreSave()
+
+	assert.True(t, IsValidId(poll.Id))
+	assert.NotZero(t, poll.CreateAt)
+	assert.Equal(t, poll.CreateAt, poll.UpdateAt)
+	assert.Equal(t, "Lunch?", poll.Question)
+	for _, option := range poll.Options {
+		assert.True(t, IsValidId(option.Id))
+	}
+	assert.Equal(t, "Yes", poll.Options[0].Text)
+	assert.Equal(t, "No", poll.Options[1].Text)
+}
+
+func TestPollIsClosed(t *testing.T) {
+	poll := newValidPoll()
+	assert.False(t, poll.IsClosed())
+
+	poll.CloseAt = GetMillis() + 60*1000
+	assert.False(t, poll.IsClosed())
+
+	poll.CloseAt = GetMillis() - 1
+	assert.True(t, poll.IsClosed())
+}
+
+func TestPollGetOption(t *testing.T) {
+	poll := newValidPoll()
+
+	assert.Equal(t, poll.Options[1], poll.GetOption(poll.Options[1].Id))
+	assert.Nil(t, poll.GetOption(NewId()))
+
+	assert.Equal(t, poll.Options[0], poll.GetOptionByText(" pizza"))
+	assert.Nil(t, poll.GetOptionByText("Burgers"))
+}
+
+func TestPollIsValidVote(t *testing.T) {
+	poll := newValidPoll()
+	first, second := poll.Options[0].Id, poll.Options[1].Id
+
+	assert.Nil(t, poll.IsValidVote(nil))
+	assert.Nil(t, poll.IsValidVote([]string{first}))
+	assert.NotNil(t, poll.IsValidVote([]string{first, second}))
+	assert.NotNil(t, poll.IsValidVote([]string{NewId()}))
+
+	poll.MultipleChoice = true
+	assert.Nil(t, poll.IsValidVote([]string{first, second}))
+	assert.NotNil(t, poll.IsValidVote([]string{first, first}))
+}
+
+func TestPollSetResults(t *testing.T) {
+	poll := newValidPoll()
+	first, second := poll.Options[0].Id, poll.Options[1].Id
+	userID1, userID2 := NewId(), NewId()
+	votes := []*PollVote{
+		{PollId: poll.Id, UserId: userID1, OptionId: first},
+		{PollId: poll.Id, UserId: userID1, OptionId: second},
+		{PollId: poll.Id, UserId: userID2, OptionId: first},
+		{PollId: poll.Id, UserId: userID2, OptionId: NewId()},
+	}
+
+	poll.SetResults(votes)
+	require.Len(t, poll.Results, 2)
+	assert.Equal(t, 2, poll.TotalVoters)
+	assert.Equal(t, first, poll.Results[0].OptionId)
+	assert.Equal(t, 2, poll.Results[0].Votes)
+	assert.ElementsMatch(t, []string{userID1, userID2}, poll.Results[0].UserIds)
+	assert.Equal(t, 1, poll.Results[1].Votes)
+
+	poll.SetMyVotes(votes, userID1)
+	assert.ElementsMatch(t, []string{first, second}, poll.MyVotes)
+
+	t.Run("anonymous polls do not list voters", func(t *testing.T) {
+		poll.Anonymous = true
+		poll.SetResults(votes)
+		assert.Equal(t, 2, poll.Results[0].Votes)
+		assert.Empty(t, poll.Results[0].UserIds)
+	})
+}
+
+func TestPollOptionsScan(t *testing.T) {
+	options := PollOptions{{Id: NewId(), Text: "Yes"}, {Id: NewId(), Text: "No"}}
+	value, err := options.Value()
+	require.NoError(t, err)
+
+	var scanned PollOptions
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, options, scanned)
+
+	scanned = nil
+	require.NoError(t, scanned.Scan([]byte(value.(string))))
+	assert.Equal(t, options, scanned)
+
+	require.Error(t, scanned.Scan(42))
+}
+
+func TestPollClone(t *testing.T) {
+	poll := newValidPoll()
+	poll.SetResults([]*PollVote{{UserId: NewId(), OptionId: poll.Options[0].Id}})
+
+	clone := poll.Clone()
+	require.Equal(t, poll, clone)
+
+	clone.Options[0].Text = "Burgers"
+	clone.Results[0].UserIds[0] = "changed"
+	assert.Equal(t, "Pizza", poll.Options[0].Text)
+	assert.NotEqual(t, "changed", poll.Results[0].UserIds[0])
+}
+
+func TestAddPollToPostProps(t *testing.T) {
+	poll := newValidPoll()
+	poll.SetResults([]*PollVote{{UserId: NewId(), OptionId: poll.Options[0].Id}})
+	poll.MyVotes = []string{poll.Options[0].Id}
+
+	for name, props := range map[string]string{
+		"empty props":    "",
+		"null props":     "null",
+		"existing props": `{"from_webhook":"true"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var decoded struct {
+				FromWebhook string `json:"from_webhook"`
+				Poll        *Poll  `json:"poll"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(AddPollToPostProps(props, poll)), &decoded))
+			require.NotNil(t, decoded.Poll)
+			assert.Equal(t, poll.Id, decoded.Poll.Id)
+			assert.Equal(t, 1, decoded.Poll.Results[0].Votes)
+			assert.Empty(t, decoded.Poll.MyVotes)
+			if props == `{"from_webhook":"true"}` {
+				assert.Equal(t, "true", decoded.FromWebhook)
+			}
+		})
+	}
+
+	t.Run("invalid props are left unchanged", func(t *testing.T) {
+		assert.Equal(t, "{invalid", AddPollToPostProps("{invalid", poll))
+	})
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	PostDiffEqual  = "equal"
+	PostDiffInsert = "insert"
+	PostDiffDelete = "delete"
+
+	// postDiffMaxCells bounds the memory used to diff the part of two messages
+	// which differs. Larger changes are reported as a single replacement.
+	postDiffMaxCells = 1 << 20
+)
+
+// PostDiffSegment is a run of text which was kept, inserted or deleted by an
+// edit.
+type PostDiffSegment struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// PostRevision is a previous version of a post, along with the changes the
+// edit which replaced it made.
+type PostRevision struct {
+	Post               *Post             `json:"post"`
+	EditedAt           int64             `json:"edited_at"`
+	MessageDiff        []PostDiffSegment `json:"message_diff"`
+	AddedFileIds       []string          `json:"added_file_ids"`
+	RemovedFileIds     []string          `json:"removed_file_ids"`
+	AttachmentsChanged bool              `json:"attachments_changed"`
+}
+
+// PostEditHistory is the revisions of a post, newest first.
+type PostEditHistory struct {
+	PostId    string          `json:"post_id"`
+	Revisions []*PostRevision `json:"revisions"`
+}
+
+// NewPostRevision describes the edit which replaced the previous version of a
+// post with the next one.
+func NewPostRevision(previous, next *Post) *PostRevision {
+	revision := &PostRevision{
+		Post:               previous,
+		EditedAt:           previous.UpdateAt,
+		MessageDiff:        DiffPostMessages(previous.Message, next.Message),
+		AddedFileIds:       []string{},
+		RemovedFileIds:     []string{},
+		AttachmentsChanged: !previous.AttachmentsEqual(next),
+	}
+
+	for _, fileID := range next.FileIds {
+		if !slices.Contains(previous.FileIds, fileID) {
+			revision.AddedFileIds = append(revision.AddedFileIds, fileID)
+		}
+	}
+	for _, fileID := range previous.FileIds {
+		if !slices.Contains(next.FileIds, fileID) {
+			revision.RemovedFileIds = append(revision.RemovedFileIds, fileID)
+		}
+	}
+
+	return revision
+}
+
+// DiffPostMessages returns the word level changes which turn the previous
+// message into the next one.
+func DiffPostMessages(previous, next string) []PostDiffSegment {
+	a := splitPostDiffTokens(previous)
+	b := splitPostDiffTokens(next)
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	diff := &postDiffBuilder{segments: []PostDiffSegment{}}
+	diff.add(PostDiffEqual, a[:prefix]...)
+
+	middleA := a[prefix : len(a)-suffix]
+	middleB := b[prefix : len(b)-suffix]
+	if len(middleA)*len(middleB) > postDiffMaxCells {
+		diff.add(PostDiffDelete, middleA...)
+		diff.add(PostDiffInsert, middleB...)
+	} else {
+		diffPostTokens(diff, middleA, middleB)
+	}
+
+	diff.add(PostDiffEqual, a[len(a)-suffix:]...)
+	return diff.segments
+}
+
+// diffPostTokens adds the changes between a and b, using their longest common
+// subsequence.
+func diffPostTokens(diff *postDiffBuilder, a, b []string) {
+	// lengths[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff.add(PostDiffEqual, a[i])
+			i++
+			j++
+		case lengths[i+1][j] > lengths[i][j+1]:
+			diff.add(PostDiffDelete, a[i])
+			i++
+		default:
+			diff.add(PostDiffInsert, b[j])
+			j++
+		}
+	}
+	diff.add(PostDiffDelete, a[i:]...)
+	diff.add(PostDiffInsert, b[j:]...)
+}
+
+// splitPostDiffTokens splits the message into words and the whitespace
+// between them.
+func splitPostDiffTokens(message string) []string {
+	tokens := []string{}
+	start := 0
+	for start < len(message) {
+		r, size := utf8.DecodeRuneInString(message[start:])
+		space := unicode.IsSpace(r)
+		end := start + size
+		for end < len(message) {
+			r, size = utf8.DecodeRuneInString(message[end:])
+			if unicode.IsSpace(r) != space {
+				break
+			}
+			end += size
+		}
+		tokens = append(tokens, message[start:end])
+		start = end
+	}
+	return tokens
+}
+
+type postDiffBuilder struct {
+	segments []PostDiffSegment
+}
+
+// add appends the tokens to the diff, merging them with the last segment if it
+// has the same operation. Deletions are kept before the insertions they are
+// adjacent to.
+func (b *postDiffBuilder) add(op string, tokens ...string) {
+	for _, token := range tokens {
+		n := len(b.segments)
+		switch {
+		case n > 0 && b.segments[n-1].Op == op:
+			b.segments[n-1].Text += token
+		case op == PostDiffDelete && n > 1 && b.segments[n-1].Op == PostDiffInsert && b.segments[n-2].Op == PostDiffDelete:
+			b.segments[n-2].Text += token
+		case op == PostDiffDelete && n > 0 && b.segments[n-1].Op == PostDiffInsert:
+			b.segments = slices.Insert(b.segments, n-1, PostDiffSegment{Op: op, Text: token})
+		default:
+			b.segments = append(b.segments, PostDiffSegment{Op: op, Text: token})
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffPostMessages(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		previous string
+		next     string
+		expected []PostDiffSegment
+	}{
+		{
+			name:     "unchanged",
+			previous: "hello world",
+			next:     "hello world",
+			expected: []PostDiffSegment{{Op: PostDiffEqual, Text: "hello world"}},
+		},
+		{
+			name:     "empty",
+			expected: []PostDiffSegment{},
+		},
+		{
+			name:     "word replaced",
+			previous: "the quick brown fox",
+			next:     "the slow brown fox",
+			expected: []PostDiffSegment{
+				{Op: PostDiffEqual, Text: "the "},
+				{Op: PostDiffDelete, Text: "quick"},
+				{Op: PostDiffInsert, Text: "slow"},
+				{Op: PostDiffEqual, Text: " brown fox"},
+			},
+		},
+		{
+			name:     "words inserted and deleted",
+			previous: "one two three four",
+			next:     "zero one three four five",
+			expected: []PostDiffSegment{
+				{Op: PostDiffInsert, Text: "zero "},
+				{Op: PostDiffEqual, Text: "one "},
+				{Op: PostDiffDelete, Text: "two "},
+				{Op: PostDiffEqual, Text: "three four"},
+				{Op: PostDiffInsert, Text: " five"},
+			},
+		},
+		{
+			name:     "multibyte",
+			previous: "café ouvert",
+			next:     "café fermé",
+			expected: []PostDiffSegment{
+				{Op: PostDiffEqual, Text: "café "},
+				{Op: PostDiffDelete, Text: "ouvert"},
+				{Op: PostDiffInsert, Text: "fermé"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, DiffPostMessages(tc.previous, tc.next))
+		})
+	}
+
+	t.Run("large change is a replacement", func(t *testing.T) {
+		previous := strings.Repeat("a ", 2000)
+		next := strings.Repeat("b ", 2000)
+		diff := DiffPostMessages("x "+previous, "x "+next)
+		assert.Equal(t, []PostDiffSegment{
+			{Op: PostDiffEqual, Text: "x "},
+			{Op: PostDiffDelete, Text: strings.TrimSuffix(previous, " ")},
+			{Op: PostDiffInsert, Text: strings.TrimSuffix(next, " ")},
+			{Op: PostDiffEqual, Text: " "},
+		}, diff)
+	})
+}
+
+func TestNewPostRevision(t *testing.T) {
+	previous := &Post{Id: NewId(), Message: "hello", FileIds: StringArray{"a", "b"}, UpdateAt: 1234}
+	next := &Post{Id: NewId(), Message: "hello world", FileIds: StringArray{"b", "c"}}
+	next.AddProp("attachments", []*SlackAttachment{{Text: "attachment"}})
+
+	revision := NewPostRevision(previous, next)
+	assert.Equal(t, previous, revision.Post)
+	assert.Equal(t, int64(1234), revision.EditedAt)
+	assert.Equal(t, []PostDiffSegment{
+		{Op: PostDiffEqual, Text: "hello"},
+		{Op: PostDiffInsert, Text: " world"},
+	}, revision.MessageDiff)
+	assert.Equal(t, []string{"c"}, revision.AddedFileIds)
+	assert.Equal(t, []string{"a"}, revision.RemovedFileIds)
+	assert.True(t, revision.AttachmentsChanged)
+}
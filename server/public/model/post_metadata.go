@@ -28,6 +28,9 @@ type PostMetadata struct {
 
 	// Acknowledgements holds acknowledgements made by users to the post
 	Acknowledgements []*PostAcknowledgement `json:"acknowledgements,omitempty"`
+
+	// Poll holds the poll and its results for posts of type PostTypePoll.
+	Poll *Poll `json:"poll,omitempty"`
 }
 
 func (p *PostMetadata) Auditable() map[string]any {
@@ -47,6 +50,7 @@ func (p *PostMetadata) Auditable() map[string]any {
 		"reactions":        p.Reactions,
 		"priority":         p.Priority,
 		"acknowledgements": p.Acknowledgements,
+		"poll":             p.Poll,
 	}
 }
 
@@ -94,6 +98,11 @@ func (p *PostMetadata) Copy() *PostMetadata {
 		}
 	}
 
+	var pollCopy *Poll
+	if p.Poll != nil {
+		pollCopy = p.Poll.Clone()
+	}
+
 	return &PostMetadata{
 		Embeds:           embedsCopy,
 		Emojis:           emojisCopy,
@@ -102,5 +111,6 @@ func (p *PostMetadata) Copy() *PostMetadata {
 		Reactions:        reactionsCopy,
 		Priority:         postPriorityCopy,
 		Acknowledgements: acknowledgementsCopy,
+		Poll:             pollCopy,
 	}
 }
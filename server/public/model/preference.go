@@ -30,6 +30,7 @@ const (
 	// - "join_leave"
 	// - "unread_scroll_position"
 	// - "sync_drafts"
+	// - "send_read_receipts"
 	// - "feature_enabled_markdown_preview" <- deprecated in favor of "formatting"
 	PreferenceCategoryAdvancedSettings = "advanced_settings"
 	// PreferenceCategoryFlaggedPost is used to store the user's saved posts.
@@ -116,6 +117,8 @@ const (
 	PreferenceCloudUserEphemeralInfo         = "cloud_user_ephemeral_info"
 
 	PreferenceNameRecommendedNextStepsHide = "hide"
+
+	PreferenceNameSendReadReceipts = "send_read_receipts"
 )
 
 type Preference struct {
@@ -0,0 +1,17 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// ReadReceiptsMaxChannelMembersLimit is the largest channel for which read
+// receipts can be enabled. Receipts are computed from the last viewed time of
+// every member, so they are only offered for channels small enough to list.
+const ReadReceiptsMaxChannelMembersLimit = 100
+
+// PostReadReceipts is the members of a channel who have viewed it since the
+// post was created, excluding the author and members who don't send receipts.
+type PostReadReceipts struct {
+	PostId    string   `json:"post_id"`
+	ChannelId string   `json:"channel_id"`
+	ReadBy    []string `json:"read_by"`
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ReminderTargetUser    = "user"
+	ReminderTargetChannel = "channel"
+
+	ReminderFrequencyDaily    = "daily"
+	ReminderFrequencyWeekdays = "weekdays"
+	ReminderFrequencyWeekly   = "weekly"
+	ReminderFrequencyMonthly  = "monthly"
+
+	ReminderMessageMaxRunes = 1024
+
+	// PostPropsReminderId is set on the posts delivering a reminder.
+	PostPropsReminderId = "reminder_id"
+)
+
+// Reminder is a message delivered by the system bot to a user or a channel at
+// a given time, and optionally again on a recurring schedule.
+type Reminder struct {
+	Id         string `json:"id"`
+	CreateAt   int64  `json:"create_at"`
+	UpdateAt   int64  `json:"update_at"`
+	UserId     string `json:"user_id"`
+	TargetType string `json:"target_type"`
+	TargetId   string `json:"target_id"`
+	Message    string `json:"message"`
+	// TriggerAt is the time of the next delivery of the reminder. It is 0
+	// once a reminder that does not recur has been delivered.
+	TriggerAt       int64               `json:"trigger_at"`
+	LastTriggeredAt int64               `json:"last_triggered_at"`
+	Recurrence      *ReminderRecurrence `json:"recurrence,omitempty"`
+}
+
+// ReminderRecurrence describes when a recurring reminder is delivered again.
+// The time of day is expressed in TimeZone so that reminders follow daylight
+// saving time changes.
+type ReminderRecurrence struct {
+	Frequency string `json:"frequency"`
+	// Weekdays lists the days of the week on which weekly reminders are
+	// delivered.
+	Weekdays []time.Weekday `json:"weekdays,omitempty"`
+	// DayOfMonth is the day on which monthly reminders are delivered. It is
+	// moved to the last day of the month for shorter months.
+	DayOfMonth int    `json:"day_of_month,omitempty"`
+	Hour       int    `json:"hour"`
+	Minute     int    `json:"minute"`
+	TimeZone   string `json:"time_zone"`
+}
+
+func (r ReminderRecurrence) Value() (driver.Value, error) {
+	j, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(j), nil
+}
+
+func (r *ReminderRecurrence) Scan(value any) error {
+	if value == nil {
+		return nil
+	}
+
+	buf, ok := value.([]byte)
+	if ok {
+		return json.Unmarshal(buf, r)
+	}
+
+	str, ok := value.(string)
+	if ok {
+		return json.Unmarshal([]byte(str), r)
+	}
+
+	return errors.New("received value is neither a byte slice nor string")
+}
+
+func (r *ReminderRecurrence) IsValid() bool {
+	if r.Hour < 0 || r.Hour > 23 || r.Minute < 0 || r.Minute > 59 {
+		return false
+	}
+
+	if _, err := time.LoadLocation(r.TimeZone); err != nil {
+		return false
+	}
+
+	switch r.Frequency {
+	case ReminderFrequencyDaily, ReminderFrequencyWeekdays:
+		return true
+	case ReminderFrequencyWeekly:
+		if len(r.Weekdays) == 0 {
+			return false
+		}
+		for _, weekday := range r.Weekdays {
+			if weekday < time.Sunday || weekday > time.Saturday {
+				return false
+			}
+		}
+		return true
+	case ReminderFrequencyMonthly:
+		return r.DayOfMonth >= 1 && r.DayOfMonth <= 31
+	}
+
+	return false
+}
+
+// Next returns the first occurrence of the recurrence strictly after the
+// given time.
+func (r *ReminderRecurrence) Next(after time.Time) time.Time {
+	loc, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	local := after.In(loc)
+	// A year covers every frequency, the loop only ends early for invalid
+	// recurrences.
+	for i := 0; i <= 366; i++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+i, 0, 0, 0, 0, loc)
+		if !r.matchesDay(day) {
+			continue
+		}
+
+		candidate := time.Date(day.Year(), day.Month(), day.Day(), r.Hour, r.Minute, 0, 0, loc)
+		if candidate.After(after) {
+			return candidate
+		}
+	}
+
+	return time.Time{}
+}
+
+func (r *ReminderRecurrence) matchesDay(day time.Time) bool {
+	switch r.Frequency {
+	case ReminderFrequencyDaily:
+		return true
+	case ReminderFrequencyWeekdays:
+		return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+	case ReminderFrequencyWeekly:
+		for _, weekday := range r.Weekdays {
+			if day.Weekday() == weekday {
+				return true
+			}
+		}
+	case ReminderFrequencyMonthly:
+		lastDay := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+		return day.Day() == min(r.DayOfMonth, lastDay)
+	}
+
+	return false
+}
+
+func (r *Reminder) Auditable() map[string]any {
+	return map[string]any{
+		"id":                r.Id,
+		"create_at":         r.CreateAt,
+		"update_at":         r.UpdateAt,
+		"user_id":           r.UserId,
+		"target_type":       r.TargetType,
+		"target_id":         r.TargetId,
+		"trigger_at":        r.TriggerAt,
+		"last_triggered_at": r.LastTriggeredAt,
+		"recurring":         r.IsRecurring(),
+	}
+}
+
+func (r *Reminder) IsValid() *AppError {
+	if !IsValidId(r.Id) {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if r.CreateAt == 0 {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.create_at.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.UpdateAt == 0 {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.update_at.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.UserId) {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.user_id.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.TargetType != ReminderTargetUser && r.TargetType != ReminderTargetChannel {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.target_type.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.TargetId) {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.target_id.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	message := strings.TrimSpace(r.Message)
+	if message == "" || utf8.RuneCountInString(message) > ReminderMessageMaxRunes {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.message.app_error", map[string]any{"MaxLength": ReminderMessageMaxRunes}, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.TriggerAt < 0 || (r.TriggerAt == 0 && r.IsRecurring()) {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.trigger_at.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.Recurrence != nil && !r.Recurrence.IsValid() {
+		return NewAppError("Reminder.IsValid", "model.reminder.is_valid.recurrence.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (r *Reminder) PreSave() {
+	if r.Id == "" {
+		r.Id = NewId()
+	}
+
+	if r.CreateAt == 0 {
+		r.CreateAt = GetMillis()
+	}
+	r.UpdateAt = r.CreateAt
+
+	r.Message = strings.TrimSpace(r.Message)
+	r.LastTriggeredAt = 0
+}
+
+func (r *Reminder) PreUpdate() {
+	r.UpdateAt = GetMillis()
+}
+
+func (r *Reminder) IsRecurring() bool {
+	return r.Recurrence != nil
+}
+
+// IsCompleted reports whether the reminder was delivered and will not be
+// delivered again unless snoozed.
+func (r *Reminder) IsCompleted() bool {
+	return r.TriggerAt == 0
+}
+
+// MarkTriggered records the delivery of the reminder at the given time and
+// schedules the next delivery of recurring reminders.
+func (r *Reminder) MarkTriggered(at time.Time) {
+	r.LastTriggeredAt = at.UnixMilli()
+	r.TriggerAt = 0
+	if r.Recurrence != nil {
+		r.TriggerAt = r.Recurrence.Next(at).UnixMilli()
+	}
+}
+
+// SnoozeReminderRequest moves the next delivery of a reminder. Snoozing a
+// recurring reminder past its next occurrence skips that occurrence.
+type SnoozeReminderRequest struct {
+	SnoozeUntil int64 `json:"snooze_until"`
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newValidReminder() *Reminder {
+	reminder := &Reminder{
+		UserId:     NewId(),
+		TargetType: ReminderTargetUser,
+		TargetId:   NewId(),
+		Message:    "Water the plants",
+		TriggerAt:  GetMillis() + 60*1000,
+	}
+	reminder.PreSave()
+
+	return reminder
+}
+
+func TestReminderIsValid(t *testing.T) {
+	t.Run("valid reminder", func(t *testing.T) {
+		require.Nil(t, newValidReminder().IsValid())
+	})
+
+	t.Run("invalid target type", func(t *testing.T) {
+		reminder := newValidReminder()
+		reminder.TargetType = "team"
+		appErr := reminder.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.reminder.is_valid.target_type.app_error", appErr.Id)
+	})
+
+	t.Run("invalid target id", func(t *testing.T) {
+		reminder := newValidReminder()
+		reminder.TargetId = "junk"
+		require.NotNil(t, reminder.IsValid())
+	})
+
+	t.Run("message", func(t *testing.T) {
+		reminder := newValidReminder()
+		reminder.Message = " "
+		require.NotNil(t, reminder.IsValid())
+
+		reminder.Message = strings.Repeat("a", ReminderMessageMaxRunes+1)
+		require.NotNil(t, reminder.IsValid())
+	})
+
+	t.Run("completed reminders must not recur", func(t *testing.T) {
+		reminder := newValidReminder()
+		reminder.TriggerAt = 0
+		require.Nil(t, reminder.IsValid())
+
+		reminder.Recurrence = &ReminderRecurrence{Frequency: ReminderFrequencyDaily, Hour: 9, TimeZone: "UTC"}
+		appErr := reminder.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.reminder.is_valid.trigger_at.app_error", appErr.Id)
+	})
+
+	t.Run("invalid recurrence", func(t *testing.T) {
+		reminder := newValidReminder()
+		reminder.Recurrence = &ReminderRecurrence{Frequency: ReminderFrequencyWeekly, Hour: 9, TimeZone: "UTC"}
+		appErr := reminder.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.reminder.is_valid.recurrence.app_error", appErr.Id)
+	})
+}
+
+func TestReminderRecurrenceIsValid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		recurrence ReminderRecurrence
+		valid      bool
+	}{
+		"daily":                {ReminderRecurrence{Frequency: ReminderFrequencyDaily, Hour: 9, TimeZone: "UTC"}, true},
+		"weekdays":             {ReminderRecurrence{Frequency: ReminderFrequencyWeekdays, Hour: 23, Minute: 59, TimeZone: "Europe/Paris"}, true},
+		"weekly":               {ReminderRecurrence{Frequency: ReminderFrequencyWeekly, Weekdays: []time.Weekday{time.Monday}, TimeZone: "UTC"}, true},
+		"weekly without days":  {ReminderRecurrence{Frequency: ReminderFrequencyWeekly, TimeZone: "UTC"}, false},
+		"weekly invalid day":   {ReminderRecurrence{Frequency: ReminderFrequencyWeekly, Weekdays: []time.Weekday{7}, TimeZone: "UTC"}, false},
+		"monthly":              {ReminderRecurrence{Frequency: ReminderFrequencyMonthly, DayOfMonth: 31, TimeZone: "UTC"}, true},
+		"monthly without day":  {ReminderRecurrence{Frequency: ReminderFrequencyMonthly, TimeZone: "UTC"}, false},
+		"unknown frequency":    {ReminderRecurrence{Frequency: "yearly", TimeZone: "UTC"}, false},
+		"invalid hour":         {ReminderRecurrence{Frequency: ReminderFrequencyDaily, Hour: 24, TimeZone: "UTC"}, false},
+		"invalid minute":       {ReminderRecurrence{Frequency: ReminderFrequencyDaily, Minute: 60, TimeZone: "UTC"}, false},
+		"unknown time zone":    {ReminderRecurrence{Frequency: ReminderFrequencyDaily, TimeZone: "Mars/Olympus"}, false},
+		"empty time zone, UTC": {ReminderRecurrence{Frequency: ReminderFrequencyDaily}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.valid, tc.recurrence.IsValid())
+		})
+	}
+}
+
+func TestReminderRecurrenceNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Friday 2024-03-08 10:00 in New York.
+	friday := time.Date(2024, time.March, 8, 10, 0, 0, 0, newYork)
+
+	for name, tc := range map[string]struct {
+		recurrence ReminderRecurrence
+		after      time.Time
+		expected   time.Time
+	}{
+		"daily, later today": {
+			ReminderRecurrence{Frequency: ReminderFrequencyDaily, Hour: 17, TimeZone: "America/New_York"},
+			friday,
+			time.Date(2024, time.March, 8, 17, 0, 0, 0, newYork),
+		},
+		"daily, already passed today": {
+			ReminderRecurrence{Frequency: ReminderFrequencyDaily, Hour: 9, TimeZone: "America/New_York"},
+			friday,
+			time.Date(2024, time.March, 9, 9, 0, 0, 0, newYork),
+		},
+		"daily, exactly now": {
+			ReminderRecurrence{Frequency: ReminderFrequencyDaily, Hour: 10, TimeZone: "America/New_York"},
+			friday,
+			time.Date(2024, time.March, 9, 10, 0, 0, 0, newYork),
+		},
+		"weekdays skip the weekend and follow daylight saving time": {
+			ReminderRecurrence{Frequency: ReminderFrequencyWeekdays, Hour: 9, TimeZone: "America/New_York"},
+			friday,
+			time.Date(2024, time.March, 11, 9, 0, 0, 0, newYork),
+		},
+		"weekly": {
+			ReminderRecurrence{Frequency: ReminderFrequencyWeekly, Weekdays: []time.Weekday{time.Wednesday, time.Tuesday}, Hour: 8, Minute: 30, TimeZone: "America/New_York"},
+			friday,
+			time.Date(2024, time.March, 12, 8, 30, 0, 0, newYork),
+		},
+		"monthly": {
+			ReminderRecurrence{Frequency: ReminderFrequencyMonthly, DayOfMonth: 1, Hour: 9, TimeZone: "America/New_York"},
+			friday,
+			time.Date(2024, time.April, 1, 9, 0, 0, 0, newYork),
+		},
+		"monthly on a day missing from the month": {
+			ReminderRecurrence{Frequency: ReminderFrequencyMonthly, DayOfMonth: 31, Hour: 9, TimeZone: "UTC"},
+			time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, time.April, 30, 9, 0, 0, 0, time.UTC),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			next := tc.recurrence.Next(tc.after)
+			assert.True(t, tc.expected.Equal(next), "expected %s, got %s", tc.expected, next)
+		})
+	}
+}
+
+func TestReminderMarkTriggered(t *testing.T) {
+	now := time.Date(2024, time.March, 8, 10, 0, 0, 0, time.UTC)
+
+	t.Run("one time reminder", func(t *testing.T) {
+		reminder := newValidReminder()
+		reminder.MarkTriggered(now)
+		assert.Equal(t, now.UnixMilli(), reminder.LastTriggeredAt)
+		assert.True(t, reminder.IsCompleted())
+	})
+
+	t.Run("recurring reminder", func(t *testing.T) {
+		reminder := newValidReminder()
+		reminder.Recurrence = &ReminderRecurrence{Frequency: ReminderFrequencyDaily, Hour: 9, TimeZone: "UTC"}
+		reminder.MarkTriggered(now)
+		assert.Equal(t, now.UnixMilli(), reminder.LastTriggeredAt)
+		assert.False(t, reminder.IsCompleted())
+		assert.Equal(t, time.Date(2024, time.March, 9, 9, 0, 0, 0, time.UTC).UnixMilli(), reminder.TriggerAt)
+	})
+}
+
+func TestReminderRecurrenceScan(t *testing.T) {
+	recurrence := ReminderRecurrence{Frequency: ReminderFrequencyWeekly, Weekdays: []time.Weekday{time.Monday}, Hour: 9, TimeZone: "UTC"}
+	value, err := recurrence.Value()
+	require.NoError(t, err)
+
+	var scanned ReminderRecurrence
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, recurrence, scanned)
+
+	scanned = ReminderRecurrence{}
+	require.NoError(t, scanned.Scan([]byte(value.(string))))
+	assert.Equal(t, recurrence, scanned)
+
+	require.Error(t, scanned.Scan(42))
+}
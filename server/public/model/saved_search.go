@@ -0,0 +1,212 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// SavedSearchAlertNone saved searches are only run on demand.
+	SavedSearchAlertNone = ""
+	// SavedSearchAlertDirectMessage saved searches deliver their new matches
+	// as a digest posted by the system bot in a direct message.
+	SavedSearchAlertDirectMessage = "direct_message"
+	// SavedSearchAlertWebsocket saved searches deliver their new matches as
+	// a websocket event.
+	SavedSearchAlertWebsocket = "websocket"
+
+	SavedSearchNameMaxRunes  = 64
+	SavedSearchTermsMaxRunes = 1024
+	SavedSearchMaxPerUser    = 50
+
+	// PostPropsSavedSearchId is set on the digests of new matches of a saved
+	// search.
+	PostPropsSavedSearchId = "saved_search_id"
+)
+
+// SavedSearch is a post search kept by a user so that it can be run again,
+// and optionally followed by alerts on the posts it matches.
+type SavedSearch struct {
+	Id       string `json:"id"`
+	CreateAt int64  `json:"create_at"`
+	UpdateAt int64  `json:"update_at"`
+	UserId   string `json:"user_id"`
+	// TeamId restricts the search to a team. Searches without a team run
+	// across all the teams of the user.
+	TeamId         string `json:"team_id"`
+	Name           string `json:"name"`
+	Terms          string `json:"terms"`
+	IsOrSearch     bool   `json:"is_or_search"`
+	TimeZoneOffset int    `json:"time_zone_offset"`
+	Alert          string `json:"alert"`
+	// LastCheckedAt is the creation time of the most recent post that was
+	// checked for alerts. Posts created before the alert was enabled are
+	// never reported.
+	LastCheckedAt int64 `json:"last_checked_at"`
+}
+
+// SavedSearchMatches is sent to the user of a saved search along with the
+// websocket event reporting its new matches.
+type SavedSearchMatches struct {
+	SavedSearchId string   `json:"saved_search_id"`
+	Name          string   `json:"name"`
+	PostIds       []string `json:"post_ids"`
+}
+
+func (s *SavedSearch) Auditable() map[string]any {
+	return map[string]any{
+		"id":              s.Id,
+		"create_at":       s.CreateAt,
+		"update_at":       s.UpdateAt,
+		"user_id":         s.UserId,
+		"team_id":         s.TeamId,
+		"name":            s.Name,
+		"is_or_search":    s.IsOrSearch,
+		"alert":           s.Alert,
+		"last_checked_at": s.LastCheckedAt,
+	}
+}
+
+func (s *SavedSearch) IsValid() *AppError {
+	if !IsValidId(s.Id) {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if s.CreateAt == 0 {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.create_at.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if s.UpdateAt == 0 {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.update_at.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(s.UserId) {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.user_id.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if s.TeamId != "" && !IsValidId(s.TeamId) {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.team_id.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if s.Name == "" || utf8.RuneCountInString(s.Name) > SavedSearchNameMaxRunes {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.name.app_error", map[string]any{"MaxLength": SavedSearchNameMaxRunes}, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if s.Terms == "" || utf8.RuneCountInString(s.Terms) > SavedSearchTermsMaxRunes {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.terms.app_error", map[string]any{"MaxLength": SavedSearchTermsMaxRunes}, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if _, appErr := s.SearchParams(); appErr != nil {
+		return appErr
+	}
+
+	if s.TimeZoneOffset < -14*60*60 || s.TimeZoneOffset > 14*60*60 {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.time_zone_offset.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	switch s.Alert {
+	case SavedSearchAlertNone, SavedSearchAlertDirectMessage, SavedSearchAlertWebsocket:
+	default:
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.alert.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if s.LastCheckedAt < 0 {
+		return NewAppError("SavedSearch.IsValid", "model.saved_search.is_valid.last_checked_at.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (s *SavedSearch) PreSave() {
+	if s.Id == "" {
+		s.Id = NewId()
+	}
+
+	if s.CreateAt == 0 {
+		s.CreateAt = GetMillis()
+	}
+	s.UpdateAt = s.CreateAt
+
+	s.Name = strings.TrimSpace(s.Name)
+	s.Terms = strings.TrimSpace(s.Terms)
+	s.LastCheckedAt = 0
+	if s.HasAlert() {
+		s.LastCheckedAt = s.CreateAt
+	}
+}
+
+func (s *SavedSearch) PreUpdate() {
+	s.UpdateAt = GetMillis()
+
+	s.Name = strings.TrimSpace(s.Name)
+	s.Terms = strings.TrimSpace(s.Terms)
+}
+
+// Patch applies the changes of a patch, restarting the alerts from now when
+// they get enabled or when the search changes.
+func (s *SavedSearch) Patch(patch *SavedSearchPatch) {
+	restartAlerts := false
+
+	if patch.Name != nil {
+		s.Name = *patch.Name
+	}
+
+	if patch.Terms != nil && *patch.Terms != s.Terms {
+		s.Terms = *patch.Terms
+		restartAlerts = true
+	}
+
+	if patch.IsOrSearch != nil && *patch.IsOrSearch != s.IsOrSearch {
+		s.IsOrSearch = *patch.IsOrSearch
+		restartAlerts = true
+	}
+
+	if patch.TimeZoneOffset != nil {
+		s.TimeZoneOffset = *patch.TimeZoneOffset
+	}
+
+	if patch.Alert != nil {
+		if *patch.Alert != SavedSearchAlertNone && !s.HasAlert() {
+			restartAlerts = true
+		}
+		s.Alert = *patch.Alert
+	}
+
+	if restartAlerts && s.HasAlert() {
+		s.LastCheckedAt = GetMillis()
+	}
+}
+
+// SearchParams parses the terms of the saved search.
+func (s *SavedSearch) SearchParams() ([]*SearchParams, *AppError) {
+	query, appErr := ParseSearchQuery(s.Terms)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return query.SearchParams(s.TimeZoneOffset)
+}
+
+func (s *SavedSearch) HasAlert() bool {
+	return s.Alert != SavedSearchAlertNone
+}
+
+type SavedSearchPatch struct {
+	Name           *string `json:"name"`
+	Terms          *string `json:"terms"`
+	IsOrSearch     *bool   `json:"is_or_search"`
+	TimeZoneOffset *int    `json:"time_zone_offset"`
+	Alert          *string `json:"alert"`
+}
+
+func (p *SavedSearchPatch) Auditable() map[string]any {
+	return map[string]any{
+		"name":         p.Name,
+		"is_or_search": p.IsOrSearch,
+		"alert":        p.Alert,
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedSearchIsValid(t *testing.T) {
+	newSavedSearch := func() *SavedSearch {
+		savedSearch := &SavedSearch{
+			UserId: NewId(),
+			Name:   " Deploy errors ",
+			Terms:  "error in:prod-alerts from:deploybot",
+		}
+		savedSearch.PreSave()
+		return savedSearch
+	}
+
+	savedSearch := newSavedSearch()
+	require.Nil(t, savedSearch.IsValid())
+	assert.Equal(t, "Deploy errors", savedSearch.Name)
+	assert.Zero(t, savedSearch.LastCheckedAt)
+
+	for name, tc := range map[string]struct {
+		update func(savedSearch *SavedSearch)
+		id     string
+	}{
+		"invalid user id": {
+			update: func(savedSearch *SavedSearch) { savedSearch.UserId = "junk" },
+			id:     "model.saved_search.is_valid.user_id.app_error",
+		},
+		"invalid team id": {
+			update: func(savedSearch *SavedSearch) { savedSearch.TeamId = "junk" },
+			id:     "model.saved_search.is_valid.team_id.app_error",
+		},
+		"empty name": {
+			update: func(savedSearch *SavedSearch) { savedSearch.Name = "" },
+			id:     "model.saved_search.is_valid.name.app_error",
+		},
+		"name too long": {
+			update: func(savedSearch *SavedSearch) { savedSearch.Name = strings.Repeat("a", SavedSearchNameMaxRunes+1) },
+			id:     "model.saved_search.is_valid.name.app_error",
+		},
+		"empty terms": {
+			update: func(savedSearch *SavedSearch) { savedSearch.Terms = "" },
+			id:     "model.saved_search.is_valid.terms.app_error",
+		},
+		"invalid search": {
+			update: func(savedSearch *SavedSearch) { savedSearch.Terms = "error OR" },
+			id:     "model.search_query.missing_operand.app_error",
+		},
+		"invalid time zone offset": {
+			update: func(savedSearch *SavedSearch) { savedSearch.TimeZoneOffset = 15 * 60 * 60 },
+			id:     "model.saved_search.is_valid.time_zone_offset.app_error",
+		},
+		"invalid alert": {
+			update: func(savedSearch *SavedSearch) { savedSearch.Alert = "email" },
+			id:     "model.saved_search.is_valid.alert.app_error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			savedSearch := newSavedSearch()
+			tc.update(savedSearch)
+			appErr := savedSearch.IsValid()
+			require.NotNil(t, appErr)
+			assert.Equal(t, tc.id, appErr.Id)
+		})
+	}
+}
+
+func TestSavedSearchAlerts(t *testing.T) {
+	t.Run("alerts start when the saved search is created", func(t *testing.T) {
+		savedSearch := &SavedSearch{
+			UserId: NewId(),
+			Name:   "Deploy errors",
+			Terms:  "error",
+			Alert:  SavedSearchAlertDirectMessage,
+		}
+		savedSearch.PreSave()
+		assert.True(t, savedSearch.HasAlert())
+		assert.Equal(t, savedSearch.CreateAt, savedSearch.LastCheckedAt)
+	})
+
+	t.Run("alerts restart when enabled or when the search changes", func(t *testing.T) {
+		savedSearch := &SavedSearch{Name: "Deploy errors", Terms: "error", LastCheckedAt: 1}
+
+		savedSearch.Patch(&SavedSearchPatch{Name: NewPointer("Errors")})
+		assert.Equal(t, "Errors", savedSearch.Name)
+		assert.Equal(t, int64(1), savedSearch.LastCheckedAt)
+
+		savedSearch.Patch(&SavedSearchPatch{Alert: NewPointer(SavedSearchAlertWebsocket)})
+		assert.Greater(t, savedSearch.LastCheckedAt, int64(1))
+
+		savedSearch.LastCheckedAt = 1
+		savedSearch.Patch(&SavedSearchPatch{Alert: NewPointer(SavedSearchAlertDirectMessage)})
+		assert.Equal(t, int64(1), savedSearch.LastCheckedAt)
+
+		savedSearch.Patch(&SavedSearchPatch{Terms: NewPointer("warning")})
+		assert.Greater(t, savedSearch.LastCheckedAt, int64(1))
+	})
+
+	t.Run("the terms are parsed with the time zone of the saved search", func(t *testing.T) {
+		savedSearch := &SavedSearch{Terms: "error OR warning", TimeZoneOffset: 3600}
+		paramsList, appErr := savedSearch.SearchParams()
+		require.Nil(t, appErr)
+		require.Len(t, paramsList, 2)
+		for _, params := range paramsList {
+			assert.Equal(t, 3600, params.TimeZoneOffset)
+		}
+	})
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	ScheduledPostErrorCodeUnknown             = "unknown"
+	ScheduledPostErrorCodeChannelArchived     = "channel_archived"
+	ScheduledPostErrorCodeChannelNotFound     = "channel_not_found"
+	ScheduledPostErrorCodeUserDoesNotExist    = "user_missing"
+	ScheduledPostErrorCodeUserDeleted         = "user_deleted"
+	ScheduledPostErrorCodeNoChannelPermission = "no_channel_permission"
+	ScheduledPostErrorCodeThreadDeleted       = "thread_deleted"
+
+	// ScheduledPostMaxErrorCodeRunes is the maximum length of the error code stored with a scheduled post.
+	ScheduledPostMaxErrorCodeRunes = 200
+)
+
+// ScheduledPost is a post composed by a user to be published at a later time.
+// Once processed, PostId holds the ID of the published post, or ErrorCode
+// holds the reason why the post could not be published.
+type ScheduledPost struct {
+	Id          string `json:"id"`
+	CreateAt    int64  `json:"create_at"`
+	UpdateAt    int64  `json:"update_at"`
+	UserId      string `json:"user_id"`
+	ChannelId   string `json:"channel_id"`
+	RootId      string `json:"root_id"`
+	Message     string `json:"message"`
+	ScheduledAt int64  `json:"scheduled_at"`
+	ProcessedAt int64  `json:"processed_at"`
+	PostId      string `json:"post_id,omitempty"`
+	ErrorCode   string `json:"error_code,omitempty"`
+
+	propsMu  sync.RWMutex    `db:"-"`       // Unexported mutex used to guard ScheduledPost.Props.
+	Props    StringInterface `json:"props"` // Deprecated: use GetProps()
+	FileIds  StringArray     `json:"file_ids,omitempty"`
+	Priority StringInterface `json:"priority,omitempty"`
+}
+
+func (s *ScheduledPost) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"id":           s.Id,
+		"create_at":    s.CreateAt,
+		"update_at":    s.UpdateAt,
+		"user_id":      s.UserId,
+		"channel_id":   s.ChannelId,
+		"root_id":      s.RootId,
+		"scheduled_at": s.ScheduledAt,
+		"processed_at": s.ProcessedAt,
+		"post_id":      s.PostId,
+		"error_code":   s.ErrorCode,
+		"file_ids":     s.FileIds,
+	}
+}
+
+func (s *ScheduledPost) IsValid(maxMessageSize int) *AppError {
+	if !IsValidId(s.Id) {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if s.CreateAt == 0 {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.create_at.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if s.UpdateAt == 0 {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.update_at.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(s.UserId) {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.user_id.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(s.ChannelId) {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.channel_id.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if !(IsValidId(s.RootId) || s.RootId == "") {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.root_id.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if s.ScheduledAt == 0 {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.scheduled_at.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if s.Message == "" && len(s.FileIds) == 0 {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.empty_post.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(s.Message) > maxMessageSize {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.msg.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(ArrayToJSON(s.FileIds)) > PostFileidsMaxRunes {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.file_ids.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(StringInterfaceToJSON(s.GetProps())) > PostPropsMaxRunes {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.props.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(StringInterfaceToJSON(s.Priority)) > PostPropsMaxRunes {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.priority.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if s.PostId != "" && !IsValidId(s.PostId) {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.post_id.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(s.ErrorCode) > ScheduledPostMaxErrorCodeRunes {
+		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.error_code.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (s *ScheduledPost) SetProps(props StringInterface) {
+	s.propsMu.Lock()
+	defer s.propsMu.Unlock()
+	s.Props = props
+}
+
+func (s *ScheduledPost) GetProps() StringInterface {
+	s.propsMu.RLock()
+	defer s.propsMu.RUnlock()
+	return s.Props
+}
+
+func (s *ScheduledPost) PreSave() {
+	if s.Id == "" {
+		s.Id = NewId()
+	}
+
+	if s.CreateAt == 0 {
+		s.CreateAt = GetMillis()
+	}
+	s.UpdateAt = s.CreateAt
+
+	s.ProcessedAt = 0
+	s.PostId = ""
+	s.ErrorCode = ""
+	s.PreCommit()
+}
+
+func (s *ScheduledPost) PreUpdate() {
+	s.UpdateAt = GetMillis()
+	s.PreCommit()
+}
+
+func (s *ScheduledPost) PreCommit() {
+	if s.GetProps() == nil {
+		s.SetProps(make(map[string]interface{}))
+	}
+
+	if s.FileIds == nil {
+		s.FileIds = []string{}
+	}
+
+	s.FileIds = RemoveDuplicateStrings(s.FileIds)
+}
+
+// IsProcessed reports whether the scheduled post was already published or
+// failed to be published.
+func (s *ScheduledPost) IsProcessed() bool {
+	return s.ProcessedAt != 0
+}
+
+// ToPost builds the post to be published from the scheduled post.
+func (s *ScheduledPost) ToPost() *Post {
+	post := &Post{
+		UserId:    s.UserId,
+		ChannelId: s.ChannelId,
+		RootId:    s.RootId,
+		Message:   s.Message,
+		FileIds:   s.FileIds,
+	}
+
+	for key, value := range s.GetProps() {
+		post.AddProp(key, value)
+	}
+
+	if len(s.Priority) > 0 {
+		priority := &PostPriority{}
+		if value, ok := s.Priority["priority"].(string); ok {
+			priority.Priority = NewPointer(value)
+		}
+		if value, ok := s.Priority["requested_ack"].(bool); ok {
+			priority.RequestedAck = NewPointer(value)
+		}
+		if value, ok := s.Priority["persistent_notifications"].(bool); ok {
+			priority.PersistentNotifications = NewPointer(value)
+		}
+		post.Metadata = &PostMetadata{Priority: priority}
+	}
+
+	return post
+}
+
+// GetScheduledPostsForProcessingOptions filters the pending scheduled posts
+// to be published by the scheduled posts job.
+type GetScheduledPostsForProcessingOptions struct {
+	ScheduledBefore int64
+	AfterId         string
+	AfterTime       int64
+	PerPage         int
+}
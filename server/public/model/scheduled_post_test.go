@@ -0,0 +1,130 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledPostIsValid(t *testing.T) {
+	o := ScheduledPost{}
+	maxMessageSize := 10000
+
+	err := o.IsValid(maxMessageSize)
+	assert.NotNil(t, err)
+
+	o.Id = NewId()
+	err = o.IsValid(maxMessageSize)
+	assert.NotNil(t, err)
+
+	o.CreateAt = GetMillis()
+	err = o.IsValid(maxMessageSize)
+	assert.NotNil(t, err)
+
+	o.UpdateAt = GetMillis()
+	err = o.IsValid(maxMessageSize)
+	assert.NotNil(t, err)
+
+	o.UserId = NewId()
+	err = o.IsValid(maxMessageSize)
+	assert.NotNil(t, err)
+
+	o.ChannelId = NewId()
+	o.RootId = "123"
+	err = o.IsValid(maxMessageSize)
+	assert.NotNil(t, err)
+
+	o.RootId = ""
+	err = o.IsValid(maxMessageSize)
+	assert.NotNil(t, err)
+
+	o.ScheduledAt = GetMillis()
+	err = o.IsValid(maxMessageSize)
+	assert.NotNil(t, err, "empty scheduled posts are not valid")
+
+	o.Message = strings.Repeat("0", maxMessageSize+1)
+	err = o.IsValid(maxMessageSize)
+	assert.NotNil(t, err)
+
+	o.Message = strings.Repeat("0", maxMessageSize)
+	err = o.IsValid(maxMessageSize)
+	assert.Nil(t, err)
+
+	o.Message = "test"
+	err = o.IsValid(maxMessageSize)
+	assert.Nil(t, err)
+
+	o.PostId = "123"
+	err = o.IsValid(maxMessageSize)
+	assert.NotNil(t, err)
+
+	o.PostId = NewId()
+	o.ErrorCode = strings.Repeat("0", ScheduledPostMaxErrorCodeRunes+1)
+	err = o.IsValid(maxMessageSize)
+	assert.NotNil(t, err)
+
+	o.ErrorCode = ScheduledPostErrorCodeChannelArchived
+	err = o.IsValid(maxMessageSize)
+	assert.Nil(t, err)
+
+	o.FileIds = StringArray{strings.Repeat("0", maxMessageSize+1)}
+	err = o.IsValid(maxMessageSize)
+	assert.NotNil(t, err)
+}
+
+func TestScheduledPostPreSave(t *testing.T) {
+	o := ScheduledPost{
+		Message:     "test",
+		ProcessedAt: GetMillis(),
+		PostId:      NewId(),
+		ErrorCode:   ScheduledPostErrorCodeUnknown,
+	}
+	o.PreSave()
+
+	assert.NotEmpty(t, o.Id)
+	assert.NotEqual(t, 0, o.CreateAt)
+	assert.Equal(t, o.CreateAt, o.UpdateAt)
+	assert.NotNil(t, o.GetProps())
+	assert.NotNil(t, o.FileIds)
+	assert.False(t, o.IsProcessed())
+	assert.Empty(t, o.PostId)
+	assert.Empty(t, o.ErrorCode)
+}
+
+func TestScheduledPostToPost(t *testing.T) {
+	o := ScheduledPost{
+		UserId:    NewId(),
+		ChannelId: NewId(),
+		RootId:    NewId(),
+		Message:   "test",
+		FileIds:   StringArray{NewId()},
+		Priority: StringInterface{
+			"priority":      PostPriorityUrgent,
+			"requested_ack": true,
+		},
+	}
+	o.SetProps(StringInterface{"key": "value"})
+
+	post := o.ToPost()
+	assert.Empty(t, post.Id)
+	assert.Equal(t, o.UserId, post.UserId)
+	assert.Equal(t, o.ChannelId, post.ChannelId)
+	assert.Equal(t, o.RootId, post.RootId)
+	assert.Equal(t, o.Message, post.Message)
+	assert.Equal(t, o.FileIds, post.FileIds)
+	assert.Equal(t, "value", post.GetProp("key"))
+
+	priority := post.GetPriority()
+	require.NotNil(t, priority)
+	assert.Equal(t, PostPriorityUrgent, *priority.Priority)
+	assert.True(t, *priority.RequestedAck)
+	assert.Nil(t, priority.PersistentNotifications)
+
+	o.Priority = nil
+	assert.Nil(t, o.ToPost().GetPriority())
+}
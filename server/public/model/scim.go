@@ -0,0 +1,249 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// SCIM 2.0 (RFC 7643 and RFC 7644) resources used by identity providers to
+// provision users and groups.
+const (
+	ScimSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ScimSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ScimSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	ScimSchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	ScimSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ScimSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ScimSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	ScimContentType = "application/scim+json"
+
+	ScimResourceTypeUser  = "User"
+	ScimResourceTypeGroup = "Group"
+
+	ScimDefaultCount = 100
+	ScimMaxCount     = 1000
+
+	// Error types of RFC 7644 section 3.12.
+	ScimErrorTypeInvalidFilter = "invalidFilter"
+	ScimErrorTypeInvalidPath   = "invalidPath"
+	ScimErrorTypeInvalidValue  = "invalidValue"
+	ScimErrorTypeInvalidSyntax = "invalidSyntax"
+	ScimErrorTypeNoTarget      = "noTarget"
+	ScimErrorTypeUniqueness    = "uniqueness"
+	ScimErrorTypeMutability    = "mutability"
+)
+
+type ScimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+type ScimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// ScimMultiValue is an element of a multi-valued attribute, such as the
+// emails of a user or the members of a group.
+type ScimMultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type ScimUser struct {
+	Schemas     []string         `json:"schemas"`
+	Id          string           `json:"id,omitempty"`
+	ExternalId  string           `json:"externalId,omitempty"`
+	UserName    string           `json:"userName"`
+	Name        *ScimName        `json:"name,omitempty"`
+	DisplayName string           `json:"displayName,omitempty"`
+	NickName    string           `json:"nickName,omitempty"`
+	Emails      []ScimMultiValue `json:"emails,omitempty"`
+	Active      *bool            `json:"active,omitempty"`
+	Meta        *ScimMeta        `json:"meta,omitempty"`
+}
+
+// PrimaryEmail returns the primary email of the user, or its first email if
+// none is marked as primary.
+func (u *ScimUser) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// IsActive returns whether the user is active, which is the case unless
+// active is explicitly set to false.
+func (u *ScimUser) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+type ScimGroup struct {
+	Schemas     []string         `json:"schemas"`
+	Id          string           `json:"id,omitempty"`
+	ExternalId  string           `json:"externalId,omitempty"`
+	DisplayName string           `json:"displayName"`
+	Members     []ScimMultiValue `json:"members,omitempty"`
+	Meta        *ScimMeta        `json:"meta,omitempty"`
+}
+
+type ScimListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+type ScimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type ScimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []ScimPatchOperation `json:"Operations"`
+}
+
+type ScimError struct {
+	Schemas  []string `json:"schemas"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+	Status   string   `json:"status"`
+}
+
+func NewScimError(status int, scimType string, detail string) *ScimError {
+	return &ScimError{
+		Schemas:  []string{ScimSchemaError},
+		ScimType: scimType,
+		Detail:   detail,
+		Status:   strconv.Itoa(status),
+	}
+}
+
+// NewScimAppError returns an AppError carrying a SCIM error type, which is
+// reported in the scimType field of the error response.
+func NewScimAppError(where string, scimType string, detail string) *AppError {
+	status := http.StatusBadRequest
+	if scimType == ScimErrorTypeUniqueness {
+		status = http.StatusConflict
+	}
+	return NewAppError(where, "model.scim.error.app_error", map[string]any{"ScimType": scimType, "Detail": detail}, detail, status)
+}
+
+// ScimErrorType returns the SCIM error type of an AppError created by
+// NewScimAppError, or an empty string.
+func ScimErrorType(appErr *AppError) string {
+	if appErr == nil || appErr.Id != "model.scim.error.app_error" {
+		return ""
+	}
+	scimType, _ := appErr.params["ScimType"].(string)
+	return scimType
+}
+
+type ScimSupported struct {
+	Supported bool `json:"supported"`
+}
+
+type ScimFilterSupported struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type ScimBulkSupported struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type ScimAuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+}
+
+type ScimServiceProviderConfig struct {
+	Schemas               []string                   `json:"schemas"`
+	Patch                 ScimSupported              `json:"patch"`
+	Bulk                  ScimBulkSupported          `json:"bulk"`
+	Filter                ScimFilterSupported        `json:"filter"`
+	ChangePassword        ScimSupported              `json:"changePassword"`
+	Sort                  ScimSupported              `json:"sort"`
+	Etag                  ScimSupported              `json:"etag"`
+	AuthenticationSchemes []ScimAuthenticationScheme `json:"authenticationSchemes"`
+	Meta                  *ScimMeta                  `json:"meta,omitempty"`
+}
+
+func NewScimServiceProviderConfig(location string) *ScimServiceProviderConfig {
+	return &ScimServiceProviderConfig{
+		Schemas: []string{ScimSchemaServiceProviderConfig},
+		Patch:   ScimSupported{Supported: true},
+		Filter:  ScimFilterSupported{Supported: true, MaxResults: ScimMaxCount},
+		AuthenticationSchemes: []ScimAuthenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "OAuth Bearer Token",
+			Description: "Authentication with the SCIM bearer token of the server configuration",
+			Primary:     true,
+		}},
+		Meta: &ScimMeta{ResourceType: "ServiceProviderConfig", Location: location},
+	}
+}
+
+type ScimResourceType struct {
+	Schemas     []string  `json:"schemas"`
+	Id          string    `json:"id"`
+	Name        string    `json:"name"`
+	Endpoint    string    `json:"endpoint"`
+	Description string    `json:"description"`
+	Schema      string    `json:"schema"`
+	Meta        *ScimMeta `json:"meta,omitempty"`
+}
+
+func NewScimResourceTypes(baseLocation string) []any {
+	return []any{
+		&ScimResourceType{
+			Schemas:     []string{ScimSchemaResourceType},
+			Id:          ScimResourceTypeUser,
+			Name:        ScimResourceTypeUser,
+			Endpoint:    "/Users",
+			Description: "User Account",
+			Schema:      ScimSchemaUser,
+			Meta:        &ScimMeta{ResourceType: "ResourceType", Location: baseLocation + "/ResourceTypes/" + ScimResourceTypeUser},
+		},
+		&ScimResourceType{
+			Schemas:     []string{ScimSchemaResourceType},
+			Id:          ScimResourceTypeGroup,
+			Name:        ScimResourceTypeGroup,
+			Endpoint:    "/Groups",
+			Description: "Group",
+			Schema:      ScimSchemaGroup,
+			Meta:        &ScimMeta{ResourceType: "ResourceType", Location: baseLocation + "/ResourceTypes/" + ScimResourceTypeGroup},
+		},
+	}
+}
+
+// ScimTime formats a timestamp in milliseconds as a SCIM dateTime.
+func ScimTime(millis int64) string {
+	if millis == 0 {
+		return ""
+	}
+	return GetTimeForMillis(millis).UTC().Format("2006-01-02T15:04:05Z")
+}
@@ -0,0 +1,506 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ScimFilter is a parsed SCIM filter expression, as described in RFC 7644
+// section 3.4.2.2. Attribute names and string values are compared case
+// insensitively.
+type ScimFilter interface {
+	matches(attrs map[string]any) bool
+}
+
+// ScimFilterComparison compares an attribute with a value, or checks that the
+// attribute is present if Operator is "pr".
+type ScimFilterComparison struct {
+	AttrPath string
+	Operator string
+	Value    any
+}
+
+type ScimFilterLogical struct {
+	Operator string
+	Left     ScimFilter
+	Right    ScimFilter
+}
+
+type ScimFilterNot struct {
+	Filter ScimFilter
+}
+
+// ScimFilterValuePath matches the elements of a multi-valued attribute, such
+// as emails[type eq "work"].
+type ScimFilterValuePath struct {
+	AttrPath string
+	Filter   ScimFilter
+}
+
+// ScimPath is the target of a PATCH operation: attr, attr.subAttr,
+// attr[filter] or attr[filter].subAttr.
+type ScimPath struct {
+	Attr    string
+	Filter  ScimFilter
+	SubAttr string
+}
+
+var scimComparisonOperators = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true,
+	"gt": true, "ge": true, "lt": true, "le": true,
+}
+
+// ParseScimFilter parses a SCIM filter expression.
+func ParseScimFilter(filter string) (ScimFilter, *AppError) {
+	tokens, err := tokenizeScimFilter(filter)
+	if err != nil {
+		return nil, NewScimAppError("ParseScimFilter", ScimErrorTypeInvalidFilter, err.Error())
+	}
+
+	p := &scimFilterParser{tokens: tokens}
+	f, err := p.parseOr()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	if err != nil {
+		return nil, NewScimAppError("ParseScimFilter", ScimErrorTypeInvalidFilter, err.Error())
+	}
+
+	return f, nil
+}
+
+// ParseScimPath parses the path of a SCIM PATCH operation.
+func ParseScimPath(path string) (*ScimPath, *AppError) {
+	attrPart := path
+	filterPart := ""
+	subAttr := ""
+	if open := strings.Index(path, "["); open >= 0 {
+		end := strings.LastIndex(path, "]")
+		if end < open {
+			return nil, NewScimAppError("ParseScimPath", ScimErrorTypeInvalidPath, "unbalanced brackets in "+path)
+		}
+		attrPart = path[:open]
+		filterPart = path[open+1 : end]
+		rest := path[end+1:]
+		if rest != "" {
+			if !strings.HasPrefix(rest, ".") || len(rest) == 1 {
+				return nil, NewScimAppError("ParseScimPath", ScimErrorTypeInvalidPath, "invalid path "+path)
+			}
+			subAttr = rest[1:]
+		}
+	}
+
+	attr := scimAttrName(attrPart)
+	if attr == "" {
+		return nil, NewScimAppError("ParseScimPath", ScimErrorTypeInvalidPath, "invalid path "+path)
+	}
+
+	result := &ScimPath{Attr: attr, SubAttr: subAttr}
+	if filterPart != "" {
+		f, appErr := ParseScimFilter(filterPart)
+		if appErr != nil {
+			return nil, appErr
+		}
+		result.Filter = f
+	} else if dot := strings.Index(attr, "."); dot >= 0 {
+		result.Attr = attr[:dot]
+		result.SubAttr = attr[dot+1:]
+	}
+
+	return result, nil
+}
+
+// ScimFilterEquality returns the attribute and value of a filter of the form
+// attr eq "value", with the attribute name in lower case.
+func ScimFilterEquality(filter ScimFilter) (string, string, bool) {
+	cmp, ok := filter.(*ScimFilterComparison)
+	if !ok || cmp.Operator != "eq" {
+		return "", "", false
+	}
+	value, ok := cmp.Value.(string)
+	if !ok {
+		return "", "", false
+	}
+	return strings.ToLower(cmp.AttrPath), value, true
+}
+
+// ScimFilterMatches returns whether a SCIM resource matches a filter.
+func ScimFilterMatches(filter ScimFilter, resource any) bool {
+	attrs, err := scimResourceToMap(resource)
+	if err != nil {
+		return false
+	}
+	return filter.matches(attrs)
+}
+
+func scimResourceToMap(resource any) (map[string]any, error) {
+	b, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	var attrs map[string]any
+	if err := json.Unmarshal(b, &attrs); err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+// scimAttrName strips the schema URN from a fully qualified attribute name,
+// such as urn:ietf:params:scim:schemas:core:2.0:User:userName.
+func scimAttrName(name string) string {
+	if strings.HasPrefix(strings.ToLower(name), "urn:") {
+		if idx := strings.LastIndex(name, ":"); idx >= 0 {
+			return name[idx+1:]
+		}
+	}
+	return name
+}
+
+// scimLookup returns the key of attrs matching name case insensitively.
+func scimLookup(attrs map[string]any, name string) (string, bool) {
+	if _, ok := attrs[name]; ok {
+		return name, true
+	}
+	for key := range attrs {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return name, false
+}
+
+// scimValues returns the values of an attribute path, flattening
+// multi-valued attributes.
+func scimValues(attrs map[string]any, attrPath string) []any {
+	current := []any{attrs}
+	for _, part := range strings.Split(attrPath, ".") {
+		var next []any
+		for _, value := range current {
+			m, ok := value.(map[string]any)
+			if !ok {
+				continue
+			}
+			key, found := scimLookup(m, part)
+			if !found {
+				continue
+			}
+			switch v := m[key].(type) {
+			case []any:
+				next = append(next, v...)
+			case nil:
+			default:
+				next = append(next, v)
+			}
+		}
+		current = next
+	}
+
+	// A multi-valued attribute compared directly is compared by its value.
+	for i, value := range current {
+		if m, ok := value.(map[string]any); ok {
+			if key, found := scimLookup(m, "value"); found {
+				current[i] = m[key]
+			}
+		}
+	}
+
+	return current
+}
+
+func (f *ScimFilterComparison) matches(attrs map[string]any) bool {
+	values := scimValues(attrs, f.AttrPath)
+	if f.Operator == "pr" {
+		for _, value := range values {
+			if s, ok := value.(string); !ok || s != "" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if f.Operator == "ne" {
+		for _, value := range values {
+			if scimCompare(value, "eq", f.Value) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, value := range values {
+		if scimCompare(value, f.Operator, f.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func scimCompare(actual any, operator string, expected any) bool {
+	switch e := expected.(type) {
+	case nil:
+		return operator == "eq" && actual == nil
+	case bool:
+		a, ok := actual.(bool)
+		return ok && operator == "eq" && a == e
+	case float64:
+		a, ok := actual.(float64)
+		if !ok {
+			return false
+		}
+		switch operator {
+		case "eq":
+			return a == e
+		case "gt":
+			return a > e
+		case "ge":
+			return a >= e
+		case "lt":
+			return a < e
+		case "le":
+			return a <= e
+		}
+		return false
+	case string:
+		a, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		a, e = strings.ToLower(a), strings.ToLower(e)
+		switch operator {
+		case "eq":
+			return a == e
+		case "co":
+			return strings.Contains(a, e)
+		case "sw":
+			return strings.HasPrefix(a, e)
+		case "ew":
+			return strings.HasSuffix(a, e)
+		case "gt":
+			return a > e
+		case "ge":
+			return a >= e
+		case "lt":
+			return a < e
+		case "le":
+			return a <= e
+		}
+	}
+	return false
+}
+
+func (f *ScimFilterLogical) matches(attrs map[string]any) bool {
+	if f.Operator == "and" {
+		return f.Left.matches(attrs) && f.Right.matches(attrs)
+	}
+	return f.Left.matches(attrs) || f.Right.matches(attrs)
+}
+
+func (f *ScimFilterNot) matches(attrs map[string]any) bool {
+	return !f.Filter.matches(attrs)
+}
+
+func (f *ScimFilterValuePath) matches(attrs map[string]any) bool {
+	key, found := scimLookup(attrs, f.AttrPath)
+	if !found {
+		return false
+	}
+	elements, ok := attrs[key].([]any)
+	if !ok {
+		elements = []any{attrs[key]}
+	}
+	for _, element := range elements {
+		if m, ok := element.(map[string]any); ok && f.Filter.matches(m) {
+			return true
+		}
+	}
+	return false
+}
+
+type scimToken struct {
+	text     string
+	isString bool
+}
+
+func tokenizeScimFilter(filter string) ([]scimToken, error) {
+	var tokens []scimToken
+	for i := 0; i < len(filter); {
+		c := filter[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == '[' || c == ']':
+			tokens = append(tokens, scimToken{text: string(c)})
+			i++
+		case c == '"':
+			end := i + 1
+			for end < len(filter) && filter[end] != '"' {
+				if filter[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(filter) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			var s string
+			if err := json.Unmarshal([]byte(filter[i:end+1]), &s); err != nil {
+				return nil, fmt.Errorf("invalid string %s", filter[i:end+1])
+			}
+			tokens = append(tokens, scimToken{text: s, isString: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(filter) && !strings.ContainsRune(" \t\n\r()[]\"", rune(filter[end])) {
+				end++
+			}
+			tokens = append(tokens, scimToken{text: filter[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type scimFilterParser struct {
+	tokens []scimToken
+	pos    int
+}
+
+func (p *scimFilterParser) peekKeyword(keyword string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].isString && strings.EqualFold(p.tokens[p.pos].text, keyword)
+}
+
+func (p *scimFilterParser) expect(text string) error {
+	if !p.peekKeyword(text) {
+		return fmt.Errorf("expected %q", text)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *scimFilterParser) parseOr() (ScimFilter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &ScimFilterLogical{Operator: "or", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *scimFilterParser) parseAnd() (ScimFilter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &ScimFilterLogical{Operator: "and", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *scimFilterParser) parseUnary() (ScimFilter, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of filter")
+	}
+
+	if p.peekKeyword("not") {
+		p.pos++
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return &ScimFilterNot{Filter: f}, nil
+	}
+
+	if p.peekKeyword("(") {
+		p.pos++
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+
+	attr := p.tokens[p.pos]
+	if attr.isString || strings.ContainsAny(attr.text, "()[]") {
+		return nil, fmt.Errorf("expected an attribute, got %q", attr.text)
+	}
+	attrPath := scimAttrName(attr.text)
+	p.pos++
+
+	if p.peekKeyword("[") {
+		p.pos++
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		return &ScimFilterValuePath{AttrPath: attrPath, Filter: f}, nil
+	}
+
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].isString {
+		return nil, fmt.Errorf("expected an operator after %q", attrPath)
+	}
+	operator := strings.ToLower(p.tokens[p.pos].text)
+	p.pos++
+
+	if operator == "pr" {
+		return &ScimFilterComparison{AttrPath: attrPath, Operator: operator}, nil
+	}
+	if !scimComparisonOperators[operator] {
+		return nil, fmt.Errorf("unknown operator %q", operator)
+	}
+
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("expected a value after %q", operator)
+	}
+	valueToken := p.tokens[p.pos]
+	p.pos++
+
+	var value any
+	switch {
+	case valueToken.isString:
+		value = valueToken.text
+	case strings.EqualFold(valueToken.text, "true"):
+		value = true
+	case strings.EqualFold(valueToken.text, "false"):
+		value = false
+	case strings.EqualFold(valueToken.text, "null"):
+		value = nil
+	default:
+		number, err := strconv.ParseFloat(valueToken.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q", valueToken.text)
+		}
+		value = number
+	}
+
+	return &ScimFilterComparison{AttrPath: attrPath, Operator: operator, Value: value}, nil
+}
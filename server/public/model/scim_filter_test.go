@@ -0,0 +1,120 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScimFilter(t *testing.T) {
+	user := &ScimUser{
+		Schemas:    []string{ScimSchemaUser},
+		Id:         "id1",
+		ExternalId: "00u1abcd",
+		UserName:   "john.doe",
+		Name:       &ScimName{GivenName: "John", FamilyName: "Doe"},
+		Emails: []ScimMultiValue{
+			{Value: "john@example.com", Type: "work", Primary: true},
+			{Value: "jd@home.example.org", Type: "home"},
+		},
+		Active: NewPointer(true),
+		Meta:   &ScimMeta{ResourceType: ScimResourceTypeUser, LastModified: "2024-03-01T10:00:00Z"},
+	}
+
+	for _, tc := range []struct {
+		filter  string
+		matches bool
+	}{
+		{`userName eq "john.doe"`, true},
+		{`USERNAME EQ "John.Doe"`, true},
+		{`userName eq "jane"`, false},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "john.doe"`, true},
+		{`externalId eq "00u1abcd"`, true},
+		{`name.givenName sw "jo"`, true},
+		{`name.familyName ew "oe"`, true},
+		{`emails co "example.com"`, true},
+		{`emails.value eq "jd@home.example.org"`, true},
+		{`emails[type eq "work" and value co "@example.com"]`, true},
+		{`emails[type eq "work" and value co "@home"]`, false},
+		{`active eq true`, true},
+		{`active eq false`, false},
+		{`nickName pr`, false},
+		{`name pr`, true},
+		{`userName ne "jane"`, true},
+		{`meta.lastModified gt "2024-01-01T00:00:00Z"`, true},
+		{`meta.lastModified lt "2024-01-01T00:00:00Z"`, false},
+		{`userName eq "jane" or externalId eq "00u1abcd"`, true},
+		{`userName eq "john.doe" and (active eq false or name.givenName eq "John")`, true},
+		{`not (userName eq "john.doe")`, false},
+		{`userName eq "john \"the\" doe"`, false},
+	} {
+		t.Run(tc.filter, func(t *testing.T) {
+			filter, appErr := ParseScimFilter(tc.filter)
+			require.Nil(t, appErr)
+			assert.Equal(t, tc.matches, ScimFilterMatches(filter, user))
+		})
+	}
+
+	for _, filter := range []string{
+		``,
+		`userName`,
+		`userName eq`,
+		`userName is "john"`,
+		`userName eq "john`,
+		`(userName eq "john"`,
+		`userName eq "john" and`,
+		`emails[type eq "work"`,
+		`userName eq john`,
+	} {
+		t.Run("invalid "+filter, func(t *testing.T) {
+			_, appErr := ParseScimFilter(filter)
+			require.NotNil(t, appErr)
+			assert.Equal(t, ScimErrorTypeInvalidFilter, ScimErrorType(appErr))
+		})
+	}
+}
+
+func TestScimFilterEquality(t *testing.T) {
+	filter, appErr := ParseScimFilter(`userName eq "John.Doe"`)
+	require.Nil(t, appErr)
+	attr, value, ok := ScimFilterEquality(filter)
+	require.True(t, ok)
+	assert.Equal(t, "username", attr)
+	assert.Equal(t, "John.Doe", value)
+
+	filter, appErr = ParseScimFilter(`userName sw "John"`)
+	require.Nil(t, appErr)
+	_, _, ok = ScimFilterEquality(filter)
+	assert.False(t, ok)
+}
+
+func TestParseScimPath(t *testing.T) {
+	path, appErr := ParseScimPath("name.givenName")
+	require.Nil(t, appErr)
+	assert.Equal(t, "name", path.Attr)
+	assert.Equal(t, "givenName", path.SubAttr)
+	assert.Nil(t, path.Filter)
+
+	path, appErr = ParseScimPath(`emails[type eq "work"].value`)
+	require.Nil(t, appErr)
+	assert.Equal(t, "emails", path.Attr)
+	assert.Equal(t, "value", path.SubAttr)
+	assert.NotNil(t, path.Filter)
+
+	path, appErr = ParseScimPath(`members[value eq "id1"]`)
+	require.Nil(t, appErr)
+	assert.Equal(t, "members", path.Attr)
+	assert.Empty(t, path.SubAttr)
+
+	path, appErr = ParseScimPath("urn:ietf:params:scim:schemas:core:2.0:User:active")
+	require.Nil(t, appErr)
+	assert.Equal(t, "active", path.Attr)
+
+	_, appErr = ParseScimPath(`emails[type eq "work"`)
+	require.NotNil(t, appErr)
+	assert.Equal(t, ScimErrorTypeInvalidPath, ScimErrorType(appErr))
+}
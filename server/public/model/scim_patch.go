@@ -0,0 +1,316 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ApplyPatch returns a copy of the user with the operations of a SCIM PATCH
+// request applied.
+func (u *ScimUser) ApplyPatch(operations []ScimPatchOperation) (*ScimUser, *AppError) {
+	var patched ScimUser
+	if appErr := applyScimPatch(u, operations, &patched); appErr != nil {
+		return nil, appErr
+	}
+	return &patched, nil
+}
+
+// ApplyPatch returns a copy of the group with the operations of a SCIM PATCH
+// request applied.
+func (g *ScimGroup) ApplyPatch(operations []ScimPatchOperation) (*ScimGroup, *AppError) {
+	var patched ScimGroup
+	if appErr := applyScimPatch(g, operations, &patched); appErr != nil {
+		return nil, appErr
+	}
+	return &patched, nil
+}
+
+func applyScimPatch(resource any, operations []ScimPatchOperation, patched any) *AppError {
+	attrs, err := scimResourceToMap(resource)
+	if err != nil {
+		return NewAppError("applyScimPatch", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, operation := range operations {
+		if appErr := applyScimPatchOperation(attrs, operation); appErr != nil {
+			return appErr
+		}
+	}
+
+	// Some identity providers send booleans as strings, such as "False".
+	if key, found := scimLookup(attrs, "active"); found {
+		if s, ok := attrs[key].(string); ok {
+			active, err := strconv.ParseBool(s)
+			if err != nil {
+				return NewScimAppError("applyScimPatch", ScimErrorTypeInvalidValue, "invalid value for active")
+			}
+			attrs[key] = active
+		}
+	}
+
+	b, err := json.Marshal(attrs)
+	if err != nil {
+		return NewAppError("applyScimPatch", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if err := json.Unmarshal(b, patched); err != nil {
+		return NewScimAppError("applyScimPatch", ScimErrorTypeInvalidValue, err.Error())
+	}
+
+	return nil
+}
+
+func applyScimPatchOperation(attrs map[string]any, operation ScimPatchOperation) *AppError {
+	op := strings.ToLower(operation.Op)
+	if op != "add" && op != "remove" && op != "replace" {
+		return NewScimAppError("applyScimPatchOperation", ScimErrorTypeInvalidSyntax, "unknown operation "+operation.Op)
+	}
+
+	var value any
+	if len(operation.Value) > 0 {
+		if err := json.Unmarshal(operation.Value, &value); err != nil {
+			return NewScimAppError("applyScimPatchOperation", ScimErrorTypeInvalidValue, err.Error())
+		}
+	}
+
+	if operation.Path == "" {
+		if op == "remove" {
+			return NewScimAppError("applyScimPatchOperation", ScimErrorTypeNoTarget, "remove requires a path")
+		}
+		values, ok := value.(map[string]any)
+		if !ok {
+			return NewScimAppError("applyScimPatchOperation", ScimErrorTypeInvalidValue, "value must be an object when no path is given")
+		}
+		for attr, attrValue := range values {
+			path, appErr := ParseScimPath(attr)
+			if appErr != nil {
+				return appErr
+			}
+			if appErr := applyScimPatchPath(attrs, op, path, attrValue); appErr != nil {
+				return appErr
+			}
+		}
+		return nil
+	}
+
+	path, appErr := ParseScimPath(operation.Path)
+	if appErr != nil {
+		return appErr
+	}
+	return applyScimPatchPath(attrs, op, path, value)
+}
+
+func applyScimPatchPath(attrs map[string]any, op string, path *ScimPath, value any) *AppError {
+	key, found := scimLookup(attrs, path.Attr)
+
+	if path.Filter != nil {
+		return applyScimPatchFilter(attrs, key, op, path, value)
+	}
+
+	if path.SubAttr != "" {
+		switch current := attrs[key].(type) {
+		case map[string]any:
+			setScimAttr(current, op, path.SubAttr, value)
+		case []any:
+			for _, element := range current {
+				if m, ok := element.(map[string]any); ok {
+					setScimAttr(m, op, path.SubAttr, value)
+				}
+			}
+		default:
+			if op != "remove" {
+				attrs[key] = map[string]any{path.SubAttr: value}
+			}
+		}
+		return nil
+	}
+
+	if !found && op == "remove" {
+		return nil
+	}
+
+	switch op {
+	case "remove":
+		current, isList := attrs[key].([]any)
+		removed, hasValues := value.([]any)
+		if !isList || !hasValues {
+			delete(attrs, key)
+			return nil
+		}
+		attrs[key] = removeScimValues(current, removed)
+	case "add":
+		switch current := attrs[key].(type) {
+		case []any:
+			added, ok := value.([]any)
+			if !ok {
+				added = []any{value}
+			}
+			attrs[key] = addScimValues(current, added)
+		case map[string]any:
+			mergeScimAttrs(current, value)
+		default:
+			attrs[key] = value
+		}
+	case "replace":
+		if current, ok := attrs[key].(map[string]any); ok {
+			mergeScimAttrs(current, value)
+			return nil
+		}
+		attrs[key] = value
+	}
+
+	return nil
+}
+
+func applyScimPatchFilter(attrs map[string]any, key string, op string, path *ScimPath, value any) *AppError {
+	elements, _ := attrs[key].([]any)
+
+	var result []any
+	matched := false
+	for _, element := range elements {
+		m, ok := element.(map[string]any)
+		if !ok || !path.Filter.matches(m) {
+			result = append(result, element)
+			continue
+		}
+
+		matched = true
+		switch {
+		case op == "remove" && path.SubAttr == "":
+			continue
+		case path.SubAttr != "":
+			setScimAttr(m, op, path.SubAttr, value)
+		default:
+			mergeScimAttrs(m, value)
+		}
+		result = append(result, m)
+	}
+
+	if !matched {
+		if op == "remove" {
+			return nil
+		}
+
+		// Adding a value to an element which does not exist yet, such as
+		// emails[type eq "work"].value, creates the element.
+		element, ok := scimFilterElement(path.Filter)
+		if !ok {
+			return NewScimAppError("applyScimPatchFilter", ScimErrorTypeNoTarget, "no value matches the path filter")
+		}
+		if path.SubAttr != "" {
+			element[path.SubAttr] = value
+		} else {
+			mergeScimAttrs(element, value)
+		}
+		result = append(result, element)
+	}
+
+	if result == nil {
+		result = []any{}
+	}
+	attrs[key] = result
+	return nil
+}
+
+// scimFilterElement returns the element described by a filter made of
+// equality comparisons, such as type eq "work" and primary eq true.
+func scimFilterElement(filter ScimFilter) (map[string]any, bool) {
+	switch f := filter.(type) {
+	case *ScimFilterComparison:
+		if f.Operator != "eq" || strings.Contains(f.AttrPath, ".") {
+			return nil, false
+		}
+		return map[string]any{f.AttrPath: f.Value}, true
+	case *ScimFilterLogical:
+		if f.Operator != "and" {
+			return nil, false
+		}
+		left, ok := scimFilterElement(f.Left)
+		if !ok {
+			return nil, false
+		}
+		right, ok := scimFilterElement(f.Right)
+		if !ok {
+			return nil, false
+		}
+		for k, v := range right {
+			left[k] = v
+		}
+		return left, true
+	}
+	return nil, false
+}
+
+func setScimAttr(attrs map[string]any, op string, name string, value any) {
+	key, _ := scimLookup(attrs, name)
+	if op == "remove" {
+		delete(attrs, key)
+		return
+	}
+	attrs[key] = value
+}
+
+func mergeScimAttrs(attrs map[string]any, value any) {
+	values, ok := value.(map[string]any)
+	if !ok {
+		return
+	}
+	for name, v := range values {
+		key, _ := scimLookup(attrs, name)
+		attrs[key] = v
+	}
+}
+
+// scimElementValue returns the value identifying an element of a
+// multi-valued attribute.
+func scimElementValue(element any) string {
+	if m, ok := element.(map[string]any); ok {
+		if key, found := scimLookup(m, "value"); found {
+			return fmt.Sprint(m[key])
+		}
+		b, _ := json.Marshal(m)
+		return string(b)
+	}
+	return fmt.Sprint(element)
+}
+
+// addScimValues appends values to a multi-valued attribute, replacing the
+// elements having the same value.
+func addScimValues(current []any, added []any) []any {
+	for _, value := range added {
+		replaced := false
+		for i, element := range current {
+			if scimElementValue(element) == scimElementValue(value) {
+				current[i] = value
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			current = append(current, value)
+		}
+	}
+	return current
+}
+
+func removeScimValues(current []any, removed []any) []any {
+	result := []any{}
+	for _, element := range current {
+		keep := true
+		for _, value := range removed {
+			if scimElementValue(element) == scimElementValue(value) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			result = append(result, element)
+		}
+	}
+	return result
+}
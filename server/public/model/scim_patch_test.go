@@ -0,0 +1,152 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseScimPatchOperations(t *testing.T, body string) []ScimPatchOperation {
+	t.Helper()
+
+	var request ScimPatchRequest
+	require.NoError(t, json.Unmarshal([]byte(body), &request))
+	return request.Operations
+}
+
+func TestScimUserApplyPatch(t *testing.T) {
+	user := &ScimUser{
+		Schemas:  []string{ScimSchemaUser},
+		Id:       "id1",
+		UserName: "john.doe",
+		Name:     &ScimName{GivenName: "John", FamilyName: "Doe"},
+		Emails:   []ScimMultiValue{{Value: "john@example.com", Type: "work", Primary: true}},
+		Active:   NewPointer(true),
+	}
+
+	t.Run("replace without a path", func(t *testing.T) {
+		patched, appErr := user.ApplyPatch(parseScimPatchOperations(t, `{"Operations": [
+			{"op": "replace", "value": {"active": false, "name.familyName": "Smith"}}
+		]}`))
+		require.Nil(t, appErr)
+		assert.False(t, patched.IsActive())
+		assert.Equal(t, "John", patched.Name.GivenName)
+		assert.Equal(t, "Smith", patched.Name.FamilyName)
+		assert.True(t, user.IsActive(), "the original user must not be modified")
+	})
+
+	t.Run("replace with string booleans and capitalized operations", func(t *testing.T) {
+		patched, appErr := user.ApplyPatch(parseScimPatchOperations(t, `{"Operations": [
+			{"op": "Replace", "path": "active", "value": "False"}
+		]}`))
+		require.Nil(t, appErr)
+		assert.False(t, patched.IsActive())
+	})
+
+	t.Run("replace a filtered sub-attribute", func(t *testing.T) {
+		patched, appErr := user.ApplyPatch(parseScimPatchOperations(t, `{"Operations": [
+			{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "john.doe@example.com"},
+			{"op": "add", "path": "userName", "value": "jdoe"}
+		]}`))
+		require.Nil(t, appErr)
+		assert.Equal(t, "john.doe@example.com", patched.PrimaryEmail())
+		assert.Equal(t, "jdoe", patched.UserName)
+	})
+
+	t.Run("add a filtered sub-attribute which does not exist", func(t *testing.T) {
+		noEmails := *user
+		noEmails.Emails = nil
+		patched, appErr := noEmails.ApplyPatch(parseScimPatchOperations(t, `{"Operations": [
+			{"op": "add", "path": "emails[type eq \"work\"].value", "value": "john@example.com"}
+		]}`))
+		require.Nil(t, appErr)
+		require.Len(t, patched.Emails, 1)
+		assert.Equal(t, ScimMultiValue{Value: "john@example.com", Type: "work"}, patched.Emails[0])
+	})
+
+	t.Run("remove an attribute", func(t *testing.T) {
+		patched, appErr := user.ApplyPatch(parseScimPatchOperations(t, `{"Operations": [
+			{"op": "remove", "path": "name.givenName"}
+		]}`))
+		require.Nil(t, appErr)
+		assert.Empty(t, patched.Name.GivenName)
+		assert.Equal(t, "Doe", patched.Name.FamilyName)
+	})
+
+	t.Run("invalid operations", func(t *testing.T) {
+		for _, body := range []string{
+			`{"Operations": [{"op": "move", "path": "active"}]}`,
+			`{"Operations": [{"op": "remove"}]}`,
+			`{"Operations": [{"op": "replace", "value": false}]}`,
+			`{"Operations": [{"op": "replace", "path": "active", "value": "maybe"}]}`,
+			`{"Operations": [{"op": "replace", "path": "emails[type sw \"h\"].value", "value": "x"}]}`,
+		} {
+			_, appErr := user.ApplyPatch(parseScimPatchOperations(t, body))
+			assert.NotNil(t, appErr, body)
+		}
+	})
+}
+
+func TestScimGroupApplyPatch(t *testing.T) {
+	group := &ScimGroup{
+		Schemas:     []string{ScimSchemaGroup},
+		Id:          "group1",
+		DisplayName: "Engineering",
+		Members:     []ScimMultiValue{{Value: "user1"}, {Value: "user2"}},
+	}
+
+	memberIDs := func(g *ScimGroup) []string {
+		ids := []string{}
+		for _, member := range g.Members {
+			ids = append(ids, member.Value)
+		}
+		return ids
+	}
+
+	t.Run("add members", func(t *testing.T) {
+		patched, appErr := group.ApplyPatch(parseScimPatchOperations(t, `{"Operations": [
+			{"op": "add", "path": "members", "value": [{"value": "user2"}, {"value": "user3"}]}
+		]}`))
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{"user1", "user2", "user3"}, memberIDs(patched))
+	})
+
+	t.Run("remove a member with a filter", func(t *testing.T) {
+		patched, appErr := group.ApplyPatch(parseScimPatchOperations(t, `{"Operations": [
+			{"op": "remove", "path": "members[value eq \"user1\"]"}
+		]}`))
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{"user2"}, memberIDs(patched))
+	})
+
+	t.Run("remove members with values", func(t *testing.T) {
+		patched, appErr := group.ApplyPatch(parseScimPatchOperations(t, `{"Operations": [
+			{"op": "Remove", "path": "members", "value": [{"value": "user2"}]}
+		]}`))
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{"user1"}, memberIDs(patched))
+	})
+
+	t.Run("remove all members", func(t *testing.T) {
+		patched, appErr := group.ApplyPatch(parseScimPatchOperations(t, `{"Operations": [
+			{"op": "remove", "path": "members"}
+		]}`))
+		require.Nil(t, appErr)
+		assert.Empty(t, patched.Members)
+	})
+
+	t.Run("replace members and display name", func(t *testing.T) {
+		patched, appErr := group.ApplyPatch(parseScimPatchOperations(t, `{"Operations": [
+			{"op": "replace", "path": "members", "value": [{"value": "user4"}]},
+			{"op": "replace", "value": {"displayName": "Platform"}}
+		]}`))
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{"user4"}, memberIDs(patched))
+		assert.Equal(t, "Platform", patched.DisplayName)
+	})
+}
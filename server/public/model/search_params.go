@@ -6,8 +6,12 @@ package model
 import (
 	"net/http"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 var searchTermPuncStart = regexp.MustCompile(`^[^\pL\d\s#"]+`)
@@ -35,6 +39,52 @@ type SearchParams struct {
 	// True if this search doesn't originate from a "current user".
 	SearchWithoutUserId bool   `json:"search_without_user_id,omitempty"`
 	Modifier            string `json:"modifier"`
+
+	// The fields below are only set by the advanced search syntax, see ParseSearchQuery.
+	InChannelPatterns       []string `json:"in_channel_patterns,omitempty"`
+	ExcludedChannelPatterns []string `json:"excluded_channel_patterns,omitempty"`
+	HasFilters              []string `json:"has_filters,omitempty"`
+	ExcludedHasFilters      []string `json:"excluded_has_filters,omitempty"`
+	IsFilters               []string `json:"is_filters,omitempty"`
+	ExcludedIsFilters       []string `json:"excluded_is_filters,omitempty"`
+	MinReactions            int      `json:"min_reactions,omitempty"`
+	MaxReactions            *int     `json:"max_reactions,omitempty"`
+	Priorities              []string `json:"priorities,omitempty"`
+	ExcludedPriorities      []string `json:"excluded_priorities,omitempty"`
+	MentionedUsers          []string `json:"mentioned_users,omitempty"`
+	ExcludedMentionedUsers  []string `json:"excluded_mentioned_users,omitempty"`
+	// Group identifies the clause of an advanced search the params come from.
+	// A post matches the search when it matches the params of any group.
+	Group int `json:"group,omitempty"`
+}
+
+// PostFilters returns the filters on the attributes of posts used by the
+// params, such as has:file or reactions, regardless of whether they are
+// negated. Search engines use them to check that they can run the search.
+func (p *SearchParams) PostFilters() []string {
+	filters := []string{}
+	for _, value := range append(slices.Clone(p.HasFilters), p.ExcludedHasFilters...) {
+		filters = append(filters, SearchFilterHas+":"+value)
+	}
+	for _, value := range append(slices.Clone(p.IsFilters), p.ExcludedIsFilters...) {
+		filters = append(filters, SearchFilterIs+":"+value)
+	}
+	if p.MinReactions != 0 || p.MaxReactions != nil {
+		filters = append(filters, SearchFilterReactions)
+	}
+	if len(p.Priorities) != 0 || len(p.ExcludedPriorities) != 0 {
+		filters = append(filters, SearchFilterPriority)
+	}
+	if len(p.MentionedUsers) != 0 || len(p.ExcludedMentionedUsers) != 0 {
+		filters = append(filters, SearchFilterMentions)
+	}
+	return filters
+}
+
+// HasPostFilters reports whether the params use any of the filters on the
+// attributes of posts.
+func (p *SearchParams) HasPostFilters() bool {
+	return len(p.PostFilters()) != 0
 }
 
 // Returns the epoch timestamp of the start of the day specified by SearchParams.AfterDate
@@ -231,7 +281,13 @@ func parseSearchFlags(input []string) ([]searchWord, []flag) {
 
 func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 	words, flags := parseSearchFlags(splitWords(text))
+	return makeSearchParams(words, flags, timeZoneOffset, false)
+}
 
+// makeSearchParams splits the words and flags of a search into params for its
+// plain terms and its hashtags. Params without terms are only returned when
+// there are flags, or when the search has other filters to apply.
+func makeSearchParams(words []searchWord, flags []flag, timeZoneOffset int, filtered bool) []*SearchParams {
 	hashtagTermList := []string{}
 	excludedHashtagTermList := []string{}
 	plainTermList := []string{}
@@ -358,7 +414,7 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 	// special case for when no terms are specified but we still have a filter
 	if plainTerms == "" && hashtagTerms == "" &&
 		excludedPlainTerms == "" && excludedHashtagTerms == "" &&
-		(len(inChannels) != 0 || len(fromUsers) != 0 ||
+		(filtered || len(inChannels) != 0 || len(fromUsers) != 0 ||
 			len(excludedChannels) != 0 || len(excludedUsers) != 0 ||
 			len(extensions) != 0 || len(excludedExtensions) != 0 ||
 			afterDate != "" || excludedAfterDate != "" ||
@@ -396,3 +452,92 @@ func IsSearchParamsListValid(paramsList []*SearchParams) *AppError {
 	}
 	return nil
 }
+
+// FindMatches returns the words and quoted phrases of the message matched by
+// the search terms, in the order they appear, so that they can be highlighted
+// when the search engine doesn't provide them.
+func (p *SearchParams) FindMatches(message string) []string {
+	var phrases []*regexp.Regexp
+	var terms []string
+	for _, word := range splitWords(p.Terms) {
+		if strings.HasPrefix(word, "\"") {
+			phraseWords := strings.Fields(strings.Trim(word, "\""))
+			if len(phraseWords) == 0 {
+				continue
+			}
+			for i := range phraseWords {
+				phraseWords[i] = regexp.QuoteMeta(phraseWords[i])
+			}
+			phrases = append(phrases, regexp.MustCompile(`(?i)`+strings.Join(phraseWords, `\s+`)))
+			continue
+		}
+		if p.IsHashtag {
+			terms = append(terms, strings.ToLower(word))
+			continue
+		}
+		for _, term := range strings.FieldsFunc(word, isNotSearchTermRune) {
+			terms = append(terms, strings.ToLower(term))
+		}
+	}
+
+	type match struct {
+		start int
+		text  string
+	}
+	var found []match
+
+	for _, phrase := range phrases {
+		for _, loc := range phrase.FindAllStringIndex(message, -1) {
+			found = append(found, match{start: loc[0], text: message[loc[0]:loc[1]]})
+		}
+	}
+
+	isWordRune := isSearchWordRune
+	if p.IsHashtag {
+		isWordRune = isHashtagRune
+	}
+	for start := 0; start < len(message); {
+		end := start + strings.IndexFunc(message[start:], func(r rune) bool { return !isWordRune(r) })
+		if end < start {
+			end = len(message)
+		}
+		if end == start {
+			_, size := utf8.DecodeRuneInString(message[start:])
+			start += size
+			continue
+		}
+
+		word := strings.ToLower(message[start:end])
+		for _, term := range terms {
+			if word == term || (strings.HasSuffix(term, "*") && strings.HasPrefix(word, strings.TrimSuffix(term, "*"))) {
+				found = append(found, match{start: start, text: message[start:end]})
+				break
+			}
+		}
+		start = end
+	}
+
+	sort.SliceStable(found, func(i, j int) bool { return found[i].start < found[j].start })
+
+	matches := []string{}
+	seen := map[string]bool{}
+	for _, m := range found {
+		if !seen[m.text] {
+			seen[m.text] = true
+			matches = append(matches, m.text)
+		}
+	}
+	return matches
+}
+
+func isSearchWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isHashtagRune(r rune) bool {
+	return isSearchWordRune(r) || r == '#' || r == '_' || r == '-'
+}
+
+func isNotSearchTermRune(r rune) bool {
+	return !isSearchWordRune(r) && r != '*'
+}
@@ -1797,3 +1797,46 @@ func TestIsSearchParamsListValid(t *testing.T) {
 	appErr = IsSearchParamsListValid([]*SearchParams{})
 	assert.Nil(t, appErr)
 }
+
+func TestSearchParamsFindMatches(t *testing.T) {
+	for name, tc := range map[string]struct {
+		params   SearchParams
+		message  string
+		expected []string
+	}{
+		"words": {
+			params:   SearchParams{Terms: "quick fox"},
+			message:  "The Quick brown fox jumps over the quick dog",
+			expected: []string{"Quick", "fox", "quick"},
+		},
+		"wildcard": {
+			params:   SearchParams{Terms: "jump*"},
+			message:  "The fox jumps and jumped",
+			expected: []string{"jumps", "jumped"},
+		},
+		"phrase": {
+			params:   SearchParams{Terms: `"brown  fox" dog`},
+			message:  "The brown fox and the Brown\nFox meet a dog",
+			expected: []string{"brown fox", "Brown\nFox", "dog"},
+		},
+		"punctuation": {
+			params:   SearchParams{Terms: "hello-world"},
+			message:  "hello, world!",
+			expected: []string{"hello", "world"},
+		},
+		"hashtags": {
+			params:   SearchParams{Terms: "#release", IsHashtag: true},
+			message:  "Shipping #Release-notes and #release today",
+			expected: []string{"#release"},
+		},
+		"no match": {
+			params:   SearchParams{Terms: "cat"},
+			message:  "catalog",
+			expected: []string{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.params.FindMatches(tc.message))
+		})
+	}
+}
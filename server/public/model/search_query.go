@@ -0,0 +1,582 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type SearchQueryOperator string
+
+const (
+	SearchQueryAnd    SearchQueryOperator = "and"
+	SearchQueryOr     SearchQueryOperator = "or"
+	SearchQueryNot    SearchQueryOperator = "not"
+	SearchQueryTerm   SearchQueryOperator = "term"
+	SearchQueryFilter SearchQueryOperator = "filter"
+)
+
+const (
+	SearchFilterIn        = "in"
+	SearchFilterHas       = "has"
+	SearchFilterIs        = "is"
+	SearchFilterReactions = "reactions"
+	SearchFilterPriority  = "priority"
+	SearchFilterMentions  = "mentions"
+
+	SearchHasFile      = "file"
+	SearchHasLink      = "link"
+	SearchIsPinned     = "pinned"
+	SearchIsThreadRoot = "thread-root"
+	SearchMentionsMe   = "me"
+
+	// SearchQueryMaxClauses is the maximum number of alternatives a search
+	// can be expanded to, as each of them is run as a separate search.
+	SearchQueryMaxClauses = 16
+)
+
+var searchReactionsFilterRegex = regexp.MustCompile(`^(>=|<=|>|<|=)?(\d+)$`)
+
+var searchFilterValues = map[string][]string{
+	SearchFilterHas:      {SearchHasFile, SearchHasLink},
+	SearchFilterIs:       {SearchIsPinned, SearchIsThreadRoot},
+	SearchFilterPriority: {PostPriorityUrgent, PostPriorityImportant},
+}
+
+// SearchQuery is a node of the expression parsed from the advanced search
+// syntax. Terms are words, quoted phrases and the flags supported by
+// ParseSearchParams, such as from: or before:, which are handled the same way.
+type SearchQuery struct {
+	Operator SearchQueryOperator `json:"operator"`
+	Children []*SearchQuery      `json:"children,omitempty"`
+	Term     string              `json:"term,omitempty"`
+	Filter   *SearchFilter       `json:"filter,omitempty"`
+}
+
+// SearchFilter is a filter on the attributes of posts, such as has:file or
+// reactions:>3. The comparison is only used by the reactions filter, and the
+// value of the in filter is a regular expression matching channel names.
+type SearchFilter struct {
+	Name       string `json:"name"`
+	Comparison string `json:"comparison,omitempty"`
+	Value      string `json:"value"`
+}
+
+type searchQueryTokenType int
+
+const (
+	searchQueryTokenWord searchQueryTokenType = iota
+	searchQueryTokenNot
+	searchQueryTokenOpen
+	searchQueryTokenClose
+)
+
+type searchQueryToken struct {
+	kind  searchQueryTokenType
+	value string
+}
+
+// ParseSearchQuery parses the advanced search syntax. On top of what
+// ParseSearchParams supports, terms can be combined with AND, OR, NOT and
+// parentheses, a leading dash negates a group as it does a term, and the
+// has:, is:, reactions:, priority: and mentions: filters are available.
+// Channels can also be matched by name with a regular expression, as in
+// in:/^team-.*/. An empty search returns a nil query.
+func ParseSearchQuery(text string) (*SearchQuery, *AppError) {
+	tokens, appErr := tokenizeSearchQuery(text)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	parser := &searchQueryParser{tokens: tokens}
+	query, appErr := parser.parseOr()
+	if appErr != nil {
+		return nil, appErr
+	}
+	if parser.pos < len(tokens) {
+		return nil, NewAppError("ParseSearchQuery", "model.search_query.unbalanced_parentheses.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return query, nil
+}
+
+func tokenizeSearchQuery(text string) ([]searchQueryToken, *AppError) {
+	var tokens []searchQueryToken
+	var word strings.Builder
+	depth := 0
+
+	endWord := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, searchQueryToken{kind: searchQueryTokenWord, value: word.String()})
+			word.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case unicode.IsSpace(r):
+			endWord()
+		case r == '"':
+			// As with splitWords, quotes start a new word unless they follow a dash,
+			// and unterminated ones are left for the terms to be cleaned up
+			if word.String() != "-" {
+				endWord()
+			}
+			end := strings.IndexByte(text[i+1:], '"')
+			if end == -1 {
+				word.WriteRune(r)
+				break
+			}
+			word.WriteString(text[i : i+end+2])
+			i += end + 2
+			endWord()
+			continue
+		case r == '(' && (word.Len() == 0 || word.String() == "-"):
+			if word.Len() > 0 {
+				tokens = append(tokens, searchQueryToken{kind: searchQueryTokenNot})
+				word.Reset()
+			}
+			tokens = append(tokens, searchQueryToken{kind: searchQueryTokenOpen})
+			depth++
+		case r == ')' && depth > 0:
+			endWord()
+			tokens = append(tokens, searchQueryToken{kind: searchQueryTokenClose})
+			depth--
+		case r == '/' && isSearchChannelFlag(word.String()):
+			end := indexSearchPatternEnd(text[i+1:])
+			if end == -1 {
+				return nil, NewAppError("ParseSearchQuery", "model.search_query.invalid_channel_pattern.app_error", map[string]any{"Pattern": text[i:]}, "", http.StatusBadRequest)
+			}
+			word.WriteString(text[i : i+end+2])
+			i += end + 2
+			continue
+		default:
+			word.WriteRune(r)
+		}
+		i += size
+	}
+	endWord()
+
+	if depth != 0 {
+		return nil, NewAppError("ParseSearchQuery", "model.search_query.unbalanced_parentheses.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return tokens, nil
+}
+
+func isSearchChannelFlag(word string) bool {
+	word = strings.ToLower(strings.TrimPrefix(word, "-"))
+	return word == "in:" || word == "channel:"
+}
+
+// indexSearchPatternEnd returns the index of the slash ending a regular
+// expression, skipping the escaped ones.
+func indexSearchPatternEnd(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '/':
+			return i
+		}
+	}
+	return -1
+}
+
+type searchQueryParser struct {
+	tokens []searchQueryToken
+	pos    int
+}
+
+func (p *searchQueryParser) peek() *searchQueryToken {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *searchQueryParser) isKeyword(keyword string) bool {
+	token := p.peek()
+	return token != nil && token.kind == searchQueryTokenWord && token.value == keyword
+}
+
+// atOperandEnd reports whether there is no operand left before the end of
+// the current group.
+func (p *searchQueryParser) atOperandEnd() bool {
+	token := p.peek()
+	return token == nil || token.kind == searchQueryTokenClose || p.isKeyword("OR") || p.isKeyword("AND")
+}
+
+func (p *searchQueryParser) missingOperandError() *AppError {
+	return NewAppError("ParseSearchQuery", "model.search_query.missing_operand.app_error", nil, "", http.StatusBadRequest)
+}
+
+func (p *searchQueryParser) parseOr() (*SearchQuery, *AppError) {
+	var children []*SearchQuery
+	for {
+		child, appErr := p.parseAnd()
+		if appErr != nil {
+			return nil, appErr
+		}
+		if child == nil {
+			if len(children) > 0 || p.isKeyword("OR") {
+				return nil, p.missingOperandError()
+			}
+		} else {
+			children = append(children, child)
+		}
+
+		if !p.isKeyword("OR") {
+			break
+		}
+		p.pos++
+	}
+
+	return combineSearchQueries(SearchQueryOr, children), nil
+}
+
+func (p *searchQueryParser) parseAnd() (*SearchQuery, *AppError) {
+	var children []*SearchQuery
+	for {
+		token := p.peek()
+		if token == nil || token.kind == searchQueryTokenClose || p.isKeyword("OR") {
+			break
+		}
+
+		if p.isKeyword("AND") {
+			p.pos++
+			if len(children) == 0 || p.atOperandEnd() {
+				return nil, p.missingOperandError()
+			}
+			continue
+		}
+
+		child, appErr := p.parseUnary()
+		if appErr != nil {
+			return nil, appErr
+		}
+		children = append(children, child)
+	}
+
+	return combineSearchQueries(SearchQueryAnd, children), nil
+}
+
+func (p *searchQueryParser) parseUnary() (*SearchQuery, *AppError) {
+	if token := p.peek(); token.kind == searchQueryTokenNot || p.isKeyword("NOT") {
+		p.pos++
+		if p.atOperandEnd() {
+			return nil, p.missingOperandError()
+		}
+
+		operand, appErr := p.parseUnary()
+		if appErr != nil {
+			return nil, appErr
+		}
+		return &SearchQuery{Operator: SearchQueryNot, Children: []*SearchQuery{operand}}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *searchQueryParser) parsePrimary() (*SearchQuery, *AppError) {
+	token := p.tokens[p.pos]
+	p.pos++
+
+	if token.kind == searchQueryTokenOpen {
+		query, appErr := p.parseOr()
+		if appErr != nil {
+			return nil, appErr
+		}
+		if query == nil {
+			return nil, p.missingOperandError()
+		}
+		if next := p.peek(); next == nil || next.kind != searchQueryTokenClose {
+			return nil, NewAppError("ParseSearchQuery", "model.search_query.unbalanced_parentheses.app_error", nil, "", http.StatusBadRequest)
+		}
+		p.pos++
+		return query, nil
+	}
+
+	word := token.value
+	negated := len(word) > 1 && word[0] == '-'
+	if negated {
+		word = word[1:]
+	}
+
+	query := &SearchQuery{Operator: SearchQueryTerm, Term: word}
+	if name, value, ok := strings.Cut(word, ":"); ok && isSearchQueryFlag(name) {
+		// As with ParseSearchParams, the value of a flag can follow it after a space
+		if next := p.peek(); value == "" && next != nil && next.kind == searchQueryTokenWord {
+			value = next.value
+			p.pos++
+			query.Term = word + value
+		}
+
+		filter, appErr := parseSearchFilter(name, value)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if filter != nil {
+			query = &SearchQuery{Operator: SearchQueryFilter, Filter: filter}
+		}
+	}
+
+	if negated {
+		query = &SearchQuery{Operator: SearchQueryNot, Children: []*SearchQuery{query}}
+	}
+	return query, nil
+}
+
+func isSearchQueryFlag(name string) bool {
+	name = strings.ToLower(name)
+	if slices.Contains(searchFlags[:], name) {
+		return true
+	}
+	switch name {
+	case SearchFilterHas, SearchFilterIs, SearchFilterReactions, SearchFilterPriority, SearchFilterMentions:
+		return true
+	}
+	return false
+}
+
+// parseSearchFilter returns the filter for the flag, or nil when the flag is
+// handled as a term.
+func parseSearchFilter(name, value string) (*SearchFilter, *AppError) {
+	name = strings.ToLower(name)
+	switch name {
+	case SearchFilterIn, "channel":
+		if len(value) < 2 || !strings.HasPrefix(value, "/") || !strings.HasSuffix(value, "/") {
+			return nil, nil
+		}
+		pattern := value[1 : len(value)-1]
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, NewAppError("ParseSearchQuery", "model.search_query.invalid_channel_pattern.app_error", map[string]any{"Pattern": value}, "", http.StatusBadRequest).Wrap(err)
+		}
+		return &SearchFilter{Name: SearchFilterIn, Value: pattern}, nil
+
+	case SearchFilterHas, SearchFilterIs, SearchFilterPriority:
+		value = strings.ToLower(value)
+		if !slices.Contains(searchFilterValues[name], value) {
+			return nil, NewAppError("ParseSearchQuery", "model.search_query.unsupported_filter.app_error", map[string]any{"Filter": name, "Value": value, "Supported": strings.Join(searchFilterValues[name], ", ")}, "", http.StatusBadRequest)
+		}
+		return &SearchFilter{Name: name, Value: value}, nil
+
+	case SearchFilterReactions:
+		match := searchReactionsFilterRegex.FindStringSubmatch(value)
+		if match == nil {
+			return nil, NewAppError("ParseSearchQuery", "model.search_query.invalid_reactions.app_error", map[string]any{"Value": value}, "", http.StatusBadRequest)
+		}
+		if _, err := strconv.Atoi(match[2]); err != nil {
+			return nil, NewAppError("ParseSearchQuery", "model.search_query.invalid_reactions.app_error", map[string]any{"Value": value}, "", http.StatusBadRequest).Wrap(err)
+		}
+		comparison := match[1]
+		if comparison == "" {
+			comparison = "="
+		}
+		return &SearchFilter{Name: name, Comparison: comparison, Value: match[2]}, nil
+
+	case SearchFilterMentions:
+		username := strings.ToLower(strings.TrimPrefix(value, "@"))
+		if username == "" {
+			return nil, NewAppError("ParseSearchQuery", "model.search_query.unsupported_filter.app_error", map[string]any{"Filter": name, "Value": value, "Supported": "@" + SearchMentionsMe + ", @username"}, "", http.StatusBadRequest)
+		}
+		return &SearchFilter{Name: name, Value: username}, nil
+	}
+
+	return nil, nil
+}
+
+func combineSearchQueries(operator SearchQueryOperator, children []*SearchQuery) *SearchQuery {
+	switch len(children) {
+	case 0:
+		return nil
+	case 1:
+		return children[0]
+	}
+	return &SearchQuery{Operator: operator, Children: children}
+}
+
+// searchQueryLiteral is a term or filter of a clause of a query, once
+// negations have been pushed down to them.
+type searchQueryLiteral struct {
+	term    string
+	filter  *SearchFilter
+	negated bool
+}
+
+// clauses rewrites the query as alternatives of terms and filters that must
+// all match.
+func (q *SearchQuery) clauses(negated bool) ([][]searchQueryLiteral, *AppError) {
+	switch q.Operator {
+	case SearchQueryTerm:
+		return [][]searchQueryLiteral{{{term: q.Term, negated: negated}}}, nil
+	case SearchQueryFilter:
+		if negated && q.Filter.Name == SearchFilterReactions {
+			return negateReactionsFilter(q.Filter).clauses(false)
+		}
+		return [][]searchQueryLiteral{{{filter: q.Filter, negated: negated}}}, nil
+	case SearchQueryNot:
+		return q.Children[0].clauses(!negated)
+	}
+
+	// A negated AND is the OR of its negated children, and the other way around
+	conjunction := (q.Operator == SearchQueryAnd) != negated
+
+	var result [][]searchQueryLiteral
+	if conjunction {
+		result = [][]searchQueryLiteral{{}}
+	}
+	for _, child := range q.Children {
+		childClauses, appErr := child.clauses(negated)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		count := len(result) + len(childClauses)
+		if conjunction {
+			count = len(result) * len(childClauses)
+		}
+		if count > SearchQueryMaxClauses {
+			return nil, NewAppError("SearchQuery.SearchParams", "model.search_query.too_complex.app_error", map[string]any{"Max": SearchQueryMaxClauses}, "", http.StatusBadRequest)
+		}
+
+		if !conjunction {
+			result = append(result, childClauses...)
+			continue
+		}
+
+		product := make([][]searchQueryLiteral, 0, count)
+		for _, clause := range result {
+			for _, childClause := range childClauses {
+				product = append(product, append(slices.Clone(clause), childClause...))
+			}
+		}
+		result = product
+	}
+
+	return result, nil
+}
+
+func negateReactionsFilter(filter *SearchFilter) *SearchQuery {
+	newFilter := func(comparison string) *SearchQuery {
+		return &SearchQuery{Operator: SearchQueryFilter, Filter: &SearchFilter{Name: filter.Name, Comparison: comparison, Value: filter.Value}}
+	}
+
+	switch filter.Comparison {
+	case ">":
+		return newFilter("<=")
+	case ">=":
+		return newFilter("<")
+	case "<":
+		return newFilter(">=")
+	case "<=":
+		return newFilter(">")
+	}
+	return &SearchQuery{Operator: SearchQueryOr, Children: []*SearchQuery{newFilter("<"), newFilter(">")}}
+}
+
+// SearchParams returns the params to run the query with. Each alternative of
+// the query gives a group of params, as ParseSearchParams would for its terms
+// and flags, with its filters applied to all of them.
+func (q *SearchQuery) SearchParams(timeZoneOffset int) ([]*SearchParams, *AppError) {
+	paramsList := []*SearchParams{}
+	if q == nil {
+		return paramsList, nil
+	}
+
+	clauses, appErr := q.clauses(false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	for group, clause := range clauses {
+		var input []string
+		var filters []searchQueryLiteral
+		for _, literal := range clause {
+			if literal.filter != nil {
+				filters = append(filters, literal)
+			} else if literal.negated {
+				input = append(input, "-"+literal.term)
+			} else {
+				input = append(input, literal.term)
+			}
+		}
+
+		words, flags := parseSearchFlags(input)
+		for _, params := range makeSearchParams(words, flags, timeZoneOffset, len(filters) > 0) {
+			params.Group = group
+			for _, literal := range filters {
+				literal.applyTo(params)
+			}
+			paramsList = append(paramsList, params)
+		}
+	}
+
+	return paramsList, nil
+}
+
+func (l searchQueryLiteral) applyTo(params *SearchParams) {
+	value := l.filter.Value
+	switch l.filter.Name {
+	case SearchFilterIn:
+		if l.negated {
+			params.ExcludedChannelPatterns = append(params.ExcludedChannelPatterns, value)
+		} else {
+			params.InChannelPatterns = append(params.InChannelPatterns, value)
+		}
+	case SearchFilterHas:
+		if l.negated {
+			params.ExcludedHasFilters = append(params.ExcludedHasFilters, value)
+		} else {
+			params.HasFilters = append(params.HasFilters, value)
+		}
+	case SearchFilterIs:
+		if l.negated {
+			params.ExcludedIsFilters = append(params.ExcludedIsFilters, value)
+		} else {
+			params.IsFilters = append(params.IsFilters, value)
+		}
+	case SearchFilterPriority:
+		if l.negated {
+			params.ExcludedPriorities = append(params.ExcludedPriorities, value)
+		} else {
+			params.Priorities = append(params.Priorities, value)
+		}
+	case SearchFilterMentions:
+		if l.negated {
+			params.ExcludedMentionedUsers = append(params.ExcludedMentionedUsers, value)
+		} else {
+			params.MentionedUsers = append(params.MentionedUsers, value)
+		}
+	case SearchFilterReactions:
+		// Negated reactions filters have been rewritten as comparisons
+		count, _ := strconv.Atoi(value)
+		switch l.filter.Comparison {
+		case ">":
+			params.MinReactions = max(params.MinReactions, count+1)
+		case ">=":
+			params.MinReactions = max(params.MinReactions, count)
+		case "<":
+			params.limitReactions(count - 1)
+		case "<=":
+			params.limitReactions(count)
+		default:
+			params.MinReactions = max(params.MinReactions, count)
+			params.limitReactions(count)
+		}
+	}
+}
+
+func (p *SearchParams) limitReactions(count int) {
+	if p.MaxReactions == nil || count < *p.MaxReactions {
+		p.MaxReactions = NewPointer(count)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseSearchQueryParams(t *testing.T, text string) []*SearchParams {
+	t.Helper()
+
+	query, appErr := ParseSearchQuery(text)
+	require.Nil(t, appErr)
+	paramsList, appErr := query.SearchParams(0)
+	require.Nil(t, appErr)
+	return paramsList
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	t.Run("searches without advanced syntax are parsed as by ParseSearchParams", func(t *testing.T) {
+		for _, text := range []string{
+			"",
+			"word",
+			"word1 word2 -word3",
+			"\"a quoted phrase\" -\"an excluded phrase\"",
+			"#hashtag word -#excluded",
+			"from:someone in:town-square word",
+			"from: someone -in: off-topic",
+			"after:2018-1-1 before:2018-1-10 -on:2018-1-5",
+			"ext:pdf -ext:doc word*",
+			"-from:someone",
+			"wo\"rd unterminated \"quote",
+			"smile :)",
+		} {
+			assert.Equal(t, ParseSearchParams(text, 0), parseSearchQueryParams(t, text), text)
+		}
+	})
+
+	t.Run("OR splits the search into groups", func(t *testing.T) {
+		paramsList := parseSearchQueryParams(t, "apple OR banana")
+		require.Len(t, paramsList, 2)
+		assert.Equal(t, "apple", paramsList[0].Terms)
+		assert.Equal(t, 0, paramsList[0].Group)
+		assert.Equal(t, "banana", paramsList[1].Terms)
+		assert.Equal(t, 1, paramsList[1].Group)
+	})
+
+	t.Run("AND binds tighter than OR", func(t *testing.T) {
+		paramsList := parseSearchQueryParams(t, "apple AND banana OR cherry")
+		require.Len(t, paramsList, 2)
+		assert.Equal(t, "apple banana", paramsList[0].Terms)
+		assert.Equal(t, "cherry", paramsList[1].Terms)
+	})
+
+	t.Run("groups are distributed over the other terms", func(t *testing.T) {
+		paramsList := parseSearchQueryParams(t, "(apple OR banana) from:someone cherry")
+		require.Len(t, paramsList, 2)
+		for i, terms := range []string{"apple cherry", "banana cherry"} {
+			assert.Equal(t, terms, paramsList[i].Terms)
+			assert.Equal(t, []string{"someone"}, paramsList[i].FromUsers)
+			assert.Equal(t, i, paramsList[i].Group)
+		}
+	})
+
+	t.Run("negated groups", func(t *testing.T) {
+		paramsList := parseSearchQueryParams(t, "apple -(banana cherry)")
+		require.Len(t, paramsList, 2)
+		assert.Equal(t, "apple", paramsList[0].Terms)
+		assert.Equal(t, "banana", paramsList[0].ExcludedTerms)
+		assert.Equal(t, "apple", paramsList[1].Terms)
+		assert.Equal(t, "cherry", paramsList[1].ExcludedTerms)
+
+		paramsList = parseSearchQueryParams(t, "NOT (banana OR -cherry)")
+		require.Len(t, paramsList, 1)
+		assert.Equal(t, "cherry", paramsList[0].Terms)
+		assert.Equal(t, "banana", paramsList[0].ExcludedTerms)
+	})
+
+	t.Run("hashtags and words of a group share its filters", func(t *testing.T) {
+		paramsList := parseSearchQueryParams(t, "#release notes has:file")
+		require.Len(t, paramsList, 2)
+		assert.False(t, paramsList[0].IsHashtag)
+		assert.True(t, paramsList[1].IsHashtag)
+		for _, params := range paramsList {
+			assert.Equal(t, []string{SearchHasFile}, params.HasFilters)
+			assert.Equal(t, 0, params.Group)
+		}
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		paramsList := parseSearchQueryParams(t, "has:file -has:LINK is:pinned -is:thread-root reactions:>3 priority:urgent -priority:important mentions:@me -mentions:Someone")
+		require.Len(t, paramsList, 1)
+
+		params := paramsList[0]
+		assert.Empty(t, params.Terms)
+		assert.True(t, params.HasPostFilters())
+		assert.Equal(t, []string{"has:file", "has:link", "is:pinned", "is:thread-root", SearchFilterReactions, SearchFilterPriority, SearchFilterMentions}, params.PostFilters())
+		assert.Equal(t, []string{SearchHasFile}, params.HasFilters)
+		assert.Equal(t, []string{SearchHasLink}, params.ExcludedHasFilters)
+		assert.Equal(t, []string{SearchIsPinned}, params.IsFilters)
+		assert.Equal(t, []string{SearchIsThreadRoot}, params.ExcludedIsFilters)
+		assert.Equal(t, 4, params.MinReactions)
+		assert.Nil(t, params.MaxReactions)
+		assert.Equal(t, []string{PostPriorityUrgent}, params.Priorities)
+		assert.Equal(t, []string{PostPriorityImportant}, params.ExcludedPriorities)
+		assert.Equal(t, []string{SearchMentionsMe}, params.MentionedUsers)
+		assert.Equal(t, []string{"someone"}, params.ExcludedMentionedUsers)
+	})
+
+	t.Run("reactions", func(t *testing.T) {
+		for text, expected := range map[string][][2]any{
+			"reactions:2":                {{2, NewPointer(2)}},
+			"reactions:>=2":              {{2, (*int)(nil)}},
+			"reactions:<2":               {{0, NewPointer(1)}},
+			"reactions:>1 reactions:<=5": {{2, NewPointer(5)}},
+			"-reactions:>3":              {{0, NewPointer(3)}},
+			"-reactions:2":               {{0, NewPointer(1)}, {3, (*int)(nil)}},
+		} {
+			paramsList := parseSearchQueryParams(t, text)
+			require.Len(t, paramsList, len(expected), text)
+			for i, params := range paramsList {
+				assert.Equal(t, expected[i][0], params.MinReactions, text)
+				assert.Equal(t, expected[i][1], params.MaxReactions, text)
+			}
+		}
+	})
+
+	t.Run("channel patterns", func(t *testing.T) {
+		paramsList := parseSearchQueryParams(t, "in:/^dev-(web|server)$/ -channel:/\\/archive/ word")
+		require.Len(t, paramsList, 1)
+		assert.Equal(t, "word", paramsList[0].Terms)
+		assert.Empty(t, paramsList[0].InChannels)
+		assert.Equal(t, []string{"^dev-(web|server)$"}, paramsList[0].InChannelPatterns)
+		assert.Equal(t, []string{"\\/archive"}, paramsList[0].ExcludedChannelPatterns)
+	})
+
+	t.Run("keywords are case sensitive", func(t *testing.T) {
+		paramsList := parseSearchQueryParams(t, "apple or banana")
+		require.Len(t, paramsList, 1)
+		assert.Equal(t, "apple or banana", paramsList[0].Terms)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for text, id := range map[string]string{
+			"(apple OR banana":    "model.search_query.unbalanced_parentheses.app_error",
+			"apple OR":            "model.search_query.missing_operand.app_error",
+			"OR apple":            "model.search_query.missing_operand.app_error",
+			"AND apple":           "model.search_query.missing_operand.app_error",
+			"apple AND OR banana": "model.search_query.missing_operand.app_error",
+			"apple NOT":           "model.search_query.missing_operand.app_error",
+			"apple ()":            "model.search_query.missing_operand.app_error",
+			"has:video":           "model.search_query.unsupported_filter.app_error",
+			"is:":                 "model.search_query.unsupported_filter.app_error",
+			"priority:low":        "model.search_query.unsupported_filter.app_error",
+			"mentions:@":          "model.search_query.unsupported_filter.app_error",
+			"reactions:many":      "model.search_query.invalid_reactions.app_error",
+			"reactions:!3":        "model.search_query.invalid_reactions.app_error",
+			"in:/[a-/":            "model.search_query.invalid_channel_pattern.app_error",
+			"in:/unterminated":    "model.search_query.invalid_channel_pattern.app_error",
+		} {
+			_, appErr := ParseSearchQuery(text)
+			require.NotNil(t, appErr, text)
+			assert.Equal(t, id, appErr.Id, text)
+		}
+	})
+
+	t.Run("searches expanding to too many groups", func(t *testing.T) {
+		query, appErr := ParseSearchQuery("(a OR b) (c OR d) (e OR f) (g OR h)")
+		require.Nil(t, appErr)
+		paramsList, appErr := query.SearchParams(0)
+		require.Nil(t, appErr)
+		assert.Len(t, paramsList, 16)
+
+		query, appErr = ParseSearchQuery("(a OR b) (c OR d) (e OR f) (g OR h) (i OR j)")
+		require.Nil(t, appErr)
+		_, appErr = query.SearchParams(0)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.search_query.too_complex.app_error", appErr.Id)
+	})
+}
@@ -15,6 +15,7 @@ const (
 	SystemLastComplianceTime               = "LastComplianceTime"
 	SystemAsymmetricSigningKeyKey          = "AsymmetricSigningKey"
 	SystemPostActionCookieSecretKey        = "PostActionCookieSecret"
+	SystemWebPushVAPIDKeyKey               = "WebPushVAPIDKey"
 	SystemInstallationDateKey              = "InstallationDate"
 	SystemOrganizationName                 = "OrganizationName"
 	SystemFirstAdminRole                   = "FirstAdminRole"
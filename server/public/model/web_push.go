@@ -0,0 +1,101 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	WebPushSubscriptionEndpointMaxLength = 1024
+	// WebPushSubscriptionP256dhLength is the length of the uncompressed
+	// P-256 public key of the browser.
+	WebPushSubscriptionP256dhLength = 65
+	WebPushSubscriptionAuthLength   = 16
+)
+
+// WebPushSubscription is the Web Push subscription of the browser of a
+// session. Push notifications are sent to it the same way they are sent to
+// the device of mobile sessions.
+type WebPushSubscription struct {
+	Id        string `json:"id"`
+	UserId    string `json:"user_id"`
+	SessionId string `json:"session_id"`
+	// Endpoint is the URL of the push service of the browser.
+	Endpoint string `json:"endpoint"`
+	// P256dh and Auth are the base64url encoded keys used to encrypt the
+	// notifications for the browser.
+	P256dh   string `json:"p256dh"`
+	Auth     string `json:"auth"`
+	CreateAt int64  `json:"create_at"`
+}
+
+// WebPushSubscriptionRequest is the subscription of a browser, as returned by
+// PushSubscription.toJSON().
+type WebPushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// WebPushPublicKey is the VAPID public key browsers subscribe with.
+type WebPushPublicKey struct {
+	PublicKey string `json:"public_key"`
+}
+
+func (s *WebPushSubscription) PreSave() {
+	if s.Id == "" {
+		s.Id = NewId()
+	}
+
+	if s.CreateAt == 0 {
+		s.CreateAt = GetMillis()
+	}
+}
+
+func (s *WebPushSubscription) IsValid() *AppError {
+	if !IsValidId(s.Id) {
+		return NewAppError("WebPushSubscription.IsValid", "model.web_push_subscription.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(s.UserId) {
+		return NewAppError("WebPushSubscription.IsValid", "model.web_push_subscription.is_valid.user_id.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(s.SessionId) {
+		return NewAppError("WebPushSubscription.IsValid", "model.web_push_subscription.is_valid.session_id.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if len(s.Endpoint) > WebPushSubscriptionEndpointMaxLength {
+		return NewAppError("WebPushSubscription.IsValid", "model.web_push_subscription.is_valid.endpoint.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+	if u, err := url.Parse(s.Endpoint); err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil {
+		return NewAppError("WebPushSubscription.IsValid", "model.web_push_subscription.is_valid.endpoint.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if key, err := decodeWebPushKey(s.P256dh); err != nil || len(key) != WebPushSubscriptionP256dhLength || key[0] != 0x04 {
+		return NewAppError("WebPushSubscription.IsValid", "model.web_push_subscription.is_valid.p256dh.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if auth, err := decodeWebPushKey(s.Auth); err != nil || len(auth) != WebPushSubscriptionAuthLength {
+		return NewAppError("WebPushSubscription.IsValid", "model.web_push_subscription.is_valid.auth.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	if s.CreateAt == 0 {
+		return NewAppError("WebPushSubscription.IsValid", "model.web_push_subscription.is_valid.create_at.app_error", nil, "id="+s.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// decodeWebPushKey decodes the base64url encoded keys of subscriptions. Some
+// browsers pad them.
+func decodeWebPushKey(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebPushSubscriptionIsValid(t *testing.T) {
+	newSubscription := func() *WebPushSubscription {
+		subscription := &WebPushSubscription{
+			UserId:    NewId(),
+			SessionId: NewId(),
+			Endpoint:  "https://fcm.googleapis.com/fcm/send/abc",
+			P256dh:    "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+			Auth:      "BTBZMqHH6r4Tts7J_aSIgg",
+		}
+		subscription.PreSave()
+		return subscription
+	}
+
+	require.Nil(t, newSubscription().IsValid())
+
+	padded := newSubscription()
+	padded.Auth += "=="
+	require.Nil(t, padded.IsValid())
+
+	for name, mutate := range map[string]func(s *WebPushSubscription){
+		"invalid id":         func(s *WebPushSubscription) { s.Id = "abc" },
+		"invalid user id":    func(s *WebPushSubscription) { s.UserId = "" },
+		"invalid session id": func(s *WebPushSubscription) { s.SessionId = "" },
+		"http endpoint":      func(s *WebPushSubscription) { s.Endpoint = "http://fcm.googleapis.com/fcm/send/abc" },
+		"endpoint with user": func(s *WebPushSubscription) { s.Endpoint = "https://user@fcm.googleapis.com/fcm/send/abc" },
+		"endpoint too long":  func(s *WebPushSubscription) { s.Endpoint += strings.Repeat("a", WebPushSubscriptionEndpointMaxLength) },
+		"invalid public key": func(s *WebPushSubscription) { s.P256dh = "BTBZMqHH6r4Tts7J_aSIgg" },
+		"invalid auth":       func(s *WebPushSubscription) { s.Auth = "not base64" },
+		"auth of wrong size": func(s *WebPushSubscription) { s.Auth = "BTBZMqHH6r4T" },
+		"no create at":       func(s *WebPushSubscription) { s.CreateAt = 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			subscription := newSubscription()
+			mutate(subscription)
+			require.NotNil(t, subscription.IsValid())
+		})
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	MfaMethodTotp     = "totp"
+	MfaMethodWebAuthn = "webauthn"
+
+	WebAuthnCredentialNameMaxRunes = 64
+	// WebAuthnCredentialIdMaxLength bounds the base64url encoded ID of the
+	// credentials. Authenticators commonly use IDs of 16 to 64 bytes.
+	WebAuthnCredentialIdMaxLength = 512
+	WebAuthnCeremonyTimeout       = 5 * 60 * 1000 // 5 minutes
+
+	WebAuthnUserVerificationRequired    = "required"
+	WebAuthnUserVerificationPreferred   = "preferred"
+	WebAuthnUserVerificationDiscouraged = "discouraged"
+)
+
+// WebAuthnCredential is a WebAuthn credential, such as a security key or a
+// passkey, registered by a user as a second factor. Passwordless credentials
+// also let email users log in without their password.
+type WebAuthnCredential struct {
+	Id     string `json:"id"`
+	UserId string `json:"user_id"`
+	Name   string `json:"name"`
+	// CredentialId is the base64url encoded ID chosen by the authenticator.
+	CredentialId string `json:"credential_id"`
+	// PublicKey is the COSE encoded public key of the credential.
+	PublicKey    []byte `json:"-"`
+	SignCount    int64  `json:"-"`
+	Passwordless bool   `json:"passwordless"`
+	CreateAt     int64  `json:"create_at"`
+	LastUsedAt   int64  `json:"last_used_at"`
+}
+
+func (c *WebAuthnCredential) PreSave() {
+	if c.Id == "" {
+		c.Id = NewId()
+	}
+
+	if c.CreateAt == 0 {
+		c.CreateAt = GetMillis()
+	}
+}
+
+func (c *WebAuthnCredential) IsValid() *AppError {
+	if !IsValidId(c.Id) {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(c.UserId) {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.user_id.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(c.Name) > WebAuthnCredentialNameMaxRunes {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.name.app_error", map[string]any{"MaxLength": WebAuthnCredentialNameMaxRunes}, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.CredentialId == "" || len(c.CredentialId) > WebAuthnCredentialIdMaxLength {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.credential_id.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if len(c.PublicKey) == 0 {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.public_key.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.CreateAt == 0 {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.create_at.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (c *WebAuthnCredential) Auditable() map[string]any {
+	return map[string]any{
+		"id":           c.Id,
+		"user_id":      c.UserId,
+		"name":         c.Name,
+		"passwordless": c.Passwordless,
+		"create_at":    c.CreateAt,
+	}
+}
+
+// The following types are passed to and from the navigator.credentials API
+// of the browsers, so they use the field names of the WebAuthn specification
+// rather than snake case. Binary values are base64url encoded.
+
+type WebAuthnRelyingPartyEntity struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type WebAuthnUserEntity struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type WebAuthnCredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+type WebAuthnCredentialDescriptor struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+}
+
+type WebAuthnAuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// WebAuthnCreationOptions are the options of navigator.credentials.create.
+type WebAuthnCreationOptions struct {
+	PublicKey WebAuthnPublicKeyCreationOptions `json:"publicKey"`
+}
+
+type WebAuthnPublicKeyCreationOptions struct {
+	Challenge              string                         `json:"challenge"`
+	Rp                     WebAuthnRelyingPartyEntity     `json:"rp"`
+	User                   WebAuthnUserEntity             `json:"user"`
+	PubKeyCredParams       []WebAuthnCredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                          `json:"timeout"`
+	ExcludeCredentials     []WebAuthnCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection WebAuthnAuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                         `json:"attestation"`
+}
+
+// WebAuthnRequestOptions are the options of navigator.credentials.get.
+type WebAuthnRequestOptions struct {
+	PublicKey WebAuthnPublicKeyRequestOptions `json:"publicKey"`
+}
+
+type WebAuthnPublicKeyRequestOptions struct {
+	Challenge        string                         `json:"challenge"`
+	Timeout          int64                          `json:"timeout"`
+	RpId             string                         `json:"rpId"`
+	AllowCredentials []WebAuthnCredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                         `json:"userVerification"`
+}
+
+// WebAuthnAttestation is the credential returned by
+// navigator.credentials.create.
+type WebAuthnAttestation struct {
+	Id       string                      `json:"id"`
+	Type     string                      `json:"type"`
+	Response WebAuthnAttestationResponse `json:"response"`
+}
+
+type WebAuthnAttestationResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AttestationObject string `json:"attestationObject"`
+}
+
+// WebAuthnAssertion is the credential returned by navigator.credentials.get.
+type WebAuthnAssertion struct {
+	Id       string                    `json:"id"`
+	Type     string                    `json:"type"`
+	Response WebAuthnAssertionResponse `json:"response"`
+}
+
+type WebAuthnAssertionResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AuthenticatorData string `json:"authenticatorData"`
+	Signature         string `json:"signature"`
+	UserHandle        string `json:"userHandle,omitempty"`
+}
+
+// WebAuthnRegistrationRequest starts the registration of a credential.
+type WebAuthnRegistrationRequest struct {
+	Passwordless bool `json:"passwordless"`
+}
+
+// WebAuthnRegistration completes the registration of a credential.
+type WebAuthnRegistration struct {
+	Name       string               `json:"name"`
+	Credential *WebAuthnAttestation `json:"credential"`
+}
+
+// WebAuthnLogin logs a user in with a passwordless credential.
+type WebAuthnLogin struct {
+	Credential *WebAuthnAssertion `json:"credential"`
+	DeviceId   string             `json:"device_id"`
+}
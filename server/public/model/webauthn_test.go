@@ -0,0 +1,42 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebAuthnCredentialIsValid(t *testing.T) {
+	newCredential := func() *WebAuthnCredential {
+		credential := &WebAuthnCredential{
+			UserId:       NewId(),
+			Name:         "Security key",
+			CredentialId: "q83vEjRWeJA",
+			PublicKey:    []byte{0xa5},
+		}
+		credential.PreSave()
+		return credential
+	}
+
+	require.Nil(t, newCredential().IsValid())
+
+	for name, mutate := range map[string]func(c *WebAuthnCredential){
+		"invalid id":             func(c *WebAuthnCredential) { c.Id = "abc" },
+		"invalid user id":        func(c *WebAuthnCredential) { c.UserId = "" },
+		"name too long":          func(c *WebAuthnCredential) { c.Name = strings.Repeat("a", WebAuthnCredentialNameMaxRunes+1) },
+		"empty credential id":    func(c *WebAuthnCredential) { c.CredentialId = "" },
+		"credential id too long": func(c *WebAuthnCredential) { c.CredentialId = strings.Repeat("a", WebAuthnCredentialIdMaxLength+1) },
+		"no public key":          func(c *WebAuthnCredential) { c.PublicKey = nil },
+		"no create at":           func(c *WebAuthnCredential) { c.CreateAt = 0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			credential := newCredential()
+			mutate(credential)
+			require.NotNil(t, credential.IsValid())
+		})
+	}
+}
@@ -81,14 +81,20 @@ const (
 	WebsocketEventDraftCreated                        WebsocketEventType = "draft_created"
 	WebsocketEventDraftUpdated                        WebsocketEventType = "draft_updated"
 	WebsocketEventDraftDeleted                        WebsocketEventType = "draft_deleted"
+	WebsocketEventScheduledPostCreated                WebsocketEventType = "scheduled_post_created"
+	WebsocketEventScheduledPostUpdated                WebsocketEventType = "scheduled_post_updated"
+	WebsocketEventScheduledPostDeleted                WebsocketEventType = "scheduled_post_deleted"
 	WebsocketEventAcknowledgementAdded                WebsocketEventType = "post_acknowledgement_added"
 	WebsocketEventAcknowledgementRemoved              WebsocketEventType = "post_acknowledgement_removed"
+	WebsocketEventPollUpdated                         WebsocketEventType = "poll_updated"
+	WebsocketEventSavedSearchMatched                  WebsocketEventType = "saved_search_matched"
 	WebsocketEventPersistentNotificationTriggered     WebsocketEventType = "persistent_notification_triggered"
 	WebsocketEventHostedCustomerSignupProgressUpdated WebsocketEventType = "hosted_customer_signup_progress_updated"
 	WebsocketEventChannelBookmarkCreated              WebsocketEventType = "channel_bookmark_created"
 	WebsocketEventChannelBookmarkUpdated              WebsocketEventType = "channel_bookmark_updated"
 	WebsocketEventChannelBookmarkDeleted              WebsocketEventType = "channel_bookmark_deleted"
 	WebsocketEventChannelBookmarkSorted               WebsocketEventType = "channel_bookmark_sorted"
+	WebsocketEventPostsRead                           WebsocketEventType = "posts_read"
 	WebsocketPresenceIndicator                        WebsocketEventType = "presence"
 	WebsocketPostedNotifyAck                          WebsocketEventType = "posted_notify_ack"
 )
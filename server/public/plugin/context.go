@@ -12,4 +12,8 @@ type Context struct {
 	IPAddress      string
 	AcceptLanguage string
 	UserAgent      string
+	// TraceContext carries the W3C trace context (traceparent, tracestate) of the
+	// request or hook event when tracing is enabled, so that plugins can continue
+	// the trace, e.g. with propagation.MapCarrier from OpenTelemetry.
+	TraceContext map[string]string
 }
@@ -91,6 +91,7 @@ export type ClientConfig = {
     EnablePostUsernameOverride: string;
     EnablePreviewModeBanner: string;
     EnablePublicLink: string;
+    EnableReadReceipts: string;
     EnableReliableWebSockets: string;
     EnableSaml: string;
     EnableSignInWithEmail: string;
@@ -109,7 +110,12 @@ export type ClientConfig = {
     EnableUserCreation: string;
     EnableUserDeactivation: string;
     EnableUserTypingMessages: string;
+    EnableWebPushNotifications: string;
     EnforceMultifactorAuthentication: string;
+    MultifactorAuthenticationMethods: string;
+    EnforcedMultifactorMethod: string;
+    EnableMfaRecoveryCodes: string;
+    EnablePasswordlessLogin: string;
     ExperimentalClientSideCertCheck: string;
     ExperimentalClientSideCertEnable: string;
     ExperimentalEnableAuthenticationTransfer: string;
@@ -164,6 +170,7 @@ export type ClientConfig = {
     PluginsEnabled: string;
     PostEditTimeLimit: string;
     PrivacyPolicyLink: string;
+    ReadReceiptsMaxChannelMembers: string;
     ReportAProblemLink: string;
     RequireEmailVerification: string;
     RestrictDirectMessage: string;
@@ -326,6 +333,10 @@ export type ServiceSettings = {
     AllowedUntrustedInternalConnections: string;
     EnableMultifactorAuthentication: boolean;
     EnforceMultifactorAuthentication: boolean;
+    MultifactorAuthenticationMethods: string[];
+    EnforcedMultifactorMethod: string;
+    EnableMfaRecoveryCodes: boolean;
+    EnablePasswordlessLogin: boolean;
     EnableUserAccessTokens: boolean;
     AllowCorsFrom: string;
     CorsExposedHeaders: string;
@@ -350,12 +361,15 @@ export type ServiceSettings = {
     EnableGifPicker: boolean;
     GiphySdkKey: string;
     PostEditTimeLimit: number;
+    PostEditHistoryLimit: number;
     TimeBetweenUserTypingUpdatesMilliseconds: number;
     EnablePostSearch: boolean;
     EnableFileSearch: boolean;
     MinimumHashtagLength: number;
     EnableUserTypingMessages: boolean;
     EnableChannelViewedMessages: boolean;
+    EnableReadReceipts: boolean;
+    ReadReceiptsMaxChannelMembers: number;
     EnableUserStatuses: boolean;
     ExperimentalEnableAuthenticationTransfer: boolean;
     ClusterLogTimeoutMilliseconds: number;
@@ -446,6 +460,8 @@ export type SqlSettings = {
     AtRestEncryptKey: string;
     QueryTimeout: number;
     DisableDatabaseSearch: boolean;
+    DatabaseSearchRanking: string;
+    TextSearchConfigs: Record<string, string>;
     MigrationsStatementTimeoutSeconds: number;
     ReplicaLagSettings: ReplicaLagSetting[];
     ReplicaMonitorIntervalSeconds: number;
@@ -517,6 +533,29 @@ export type ConnectedWorkspacesSettings = {
     MaxPostsPerSync: number;
 }
 
+export type ScimSettings = {
+    Enable: boolean;
+    BearerToken: string;
+    UserAuthService: string;
+};
+
+export type DLPRule = {
+    Name: string;
+    Preset: string;
+    Pattern: string;
+    Validator: string;
+    Keywords: string[];
+    TeamIds: string[];
+    ChannelIds: string[];
+    Action: 'block' | 'redact' | 'warn' | 'notify';
+    ComplianceChannelId: string;
+};
+
+export type DataLossPreventionSettings = {
+    Enable: boolean;
+    Rules: DLPRule[];
+};
+
 export type FileSettings = {
     EnableFileAttachments: boolean;
     EnableMobileUpload: boolean;
@@ -543,6 +582,25 @@ export type FileSettings = {
     AmazonS3Trace: boolean;
     AmazonS3RequestTimeoutMilliseconds: number;
     AmazonS3UploadPartSizeBytes: number;
+    AzureStorageAccount: string;
+    AzureAccessKey: string;
+    AzureContainer: string;
+    AzurePathPrefix: string;
+    AzureEndpoint: string;
+    AzureRequestTimeoutMilliseconds: number;
+    AzurePresignExpiresSeconds: number;
+    GCSBucket: string;
+    GCSPathPrefix: string;
+    GCSCredentialsJSON: string;
+    GCSEndpoint: string;
+    GCSRequestTimeoutMilliseconds: number;
+    GCSPresignExpiresSeconds: number;
+    EnableEncryptionAtRest: boolean;
+    EncryptionAtRestMasterKey: string;
+    EncryptionAtRestRetiredKeys: string[];
+    EnableAntivirusScan: boolean;
+    AntivirusScanURL: string;
+    AntivirusScanTimeoutSeconds: number;
     DedicatedExportStore: boolean;
     ExportDriverName: string;
     ExportDirectory: string;
@@ -594,6 +652,14 @@ export type EmailSettings = {
     LoginButtonColor: string;
     LoginButtonBorderColor: string;
     LoginButtonTextColor: string;
+    EnableReplyByEmail: boolean;
+    ReplyByEmailAddress: string;
+    InboundEmailProtocol: 'smtp' | 'lmtp';
+    InboundEmailListenAddress: string;
+    InboundEmailMaxMessageSize: number;
+    InboundEmailTrustedAuthServIds: string[];
+    InboundEmailTrustedSources: string[];
+    EnableWebPushNotifications: boolean;
 };
 
 export type RateLimitSettings = {
@@ -992,6 +1058,8 @@ export type AdminConfig = {
     ExportSettings: ExportSettings;
     WranglerSettings: WranglerSettings;
     ConnectedWorkspacesSettings: ConnectedWorkspacesSettings;
+    ScimSettings: ScimSettings;
+    DataLossPreventionSettings: DataLossPreventionSettings;
 };
 
 export type ReplicaLagSetting = {